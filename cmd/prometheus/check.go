@@ -0,0 +1,217 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/prometheus/common/log"
+
+	"github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/discovery/file"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/prometheus/prometheus/util/httputil"
+)
+
+// The exit codes of -check-config-only by class of error. The exit codes 1
+// and 2 remain those of other failures and invalid flags.
+const (
+	checkInvalidConfig = 3
+	checkInvalidRules  = 4
+	checkMissingFile   = 5
+	checkInvalidTLS    = 6
+)
+
+// checkError is an error found by -check-config-only, with the exit code of
+// its class.
+type checkError struct {
+	code int
+	err  error
+}
+
+func (e *checkError) Error() string {
+	return e.err.Error()
+}
+
+// checkConfigOnly checks the configuration file and returns the exit code of
+// -check-config-only.
+func checkConfigOnly(filename string) int {
+	if err := checkConfig(filename); err != nil {
+		log.Errorf("Invalid configuration: %s", err)
+		return err.code
+	}
+	log.Infof("Configuration file %s and the files it references are valid", filename)
+	return 0
+}
+
+// checkConfig loads the configuration file like the server would, then loads
+// all rule files, file_sd files and TLS material it references and checks the
+// existence of the other files it references.
+func checkConfig(filename string) *checkError {
+	if _, err := os.Stat(filename); err != nil {
+		return &checkError{checkMissingFile, err}
+	}
+	conf, err := config.LoadFile(filename)
+	if err != nil {
+		return &checkError{checkInvalidConfig, fmt.Errorf("couldn't load configuration (-config.file=%s): %v", filename, err)}
+	}
+	if cfg.expandEnv {
+		conf.ExpandEnv()
+	}
+	if cfg.labelTemplates {
+		if err := conf.ResolveLabelTemplates(); err != nil {
+			return &checkError{checkInvalidConfig, fmt.Errorf("couldn't load configuration (-config.file=%s): %v", filename, err)}
+		}
+	}
+
+	for _, pat := range conf.RuleFiles {
+		if err := checkRuleFiles(pat); err != nil {
+			return err
+		}
+	}
+	return walkConfig(reflect.ValueOf(conf), "", checkConfigValue)
+}
+
+// checkRuleFiles parses the rule files matching the pattern. Unlike the server,
+// it fails if a pattern without wildcards matches no file.
+func checkRuleFiles(pat string) *checkError {
+	files, err := filepath.Glob(pat)
+	if err != nil {
+		return &checkError{checkInvalidConfig, fmt.Errorf("invalid rule file pattern %q: %s", pat, err)}
+	}
+	if len(files) == 0 && !strings.ContainsAny(pat, "*?[") {
+		return &checkError{checkMissingFile, fmt.Errorf("rule file %q does not exist", pat)}
+	}
+	for _, fn := range files {
+		content, err := ioutil.ReadFile(fn)
+		if err != nil {
+			return &checkError{checkMissingFile, err}
+		}
+		if _, err := promql.ParseStmts(string(content)); err != nil {
+			return &checkError{checkInvalidRules, fmt.Errorf("error parsing %s: %s", fn, err)}
+		}
+	}
+	return nil
+}
+
+var (
+	tlsConfigType    = reflect.TypeOf(config.TLSConfig{})
+	fileSDConfigType = reflect.TypeOf(config.FileSDConfig{})
+)
+
+// walkConfig calls visit for all values reachable from v through exported
+// fields, together with the YAML name of the field they belong to, and
+// returns the first error.
+func walkConfig(v reflect.Value, name string, visit func(reflect.Value, string) *checkError) *checkError {
+	if err := visit(v, name); err != nil {
+		return err
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			return walkConfig(v.Elem(), name, visit)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			if f.PkgPath != "" {
+				continue
+			}
+			if err := walkConfig(v.Field(i), strings.Split(f.Tag.Get("yaml"), ",")[0], visit); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := walkConfig(v.Index(i), name, visit); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkConfigValue checks a value of the configuration: TLS configurations
+// and file_sd configurations are loaded, and the files of all fields named
+// like *_file must exist.
+func checkConfigValue(v reflect.Value, name string) *checkError {
+	switch {
+	case v.Type() == tlsConfigType:
+		return checkTLSConfig(v.Interface().(config.TLSConfig))
+	case v.Type() == fileSDConfigType:
+		return checkFileSDConfig(v.Interface().(config.FileSDConfig))
+	case v.Kind() == reflect.String && strings.HasSuffix(name, "_file"):
+		// References to secret providers are not checked.
+		fn := v.String()
+		if fn == "" || strings.Contains(fn, "://") {
+			return nil
+		}
+		if _, err := os.Stat(fn); err != nil {
+			return &checkError{checkMissingFile, fmt.Errorf("error checking %s %q: %s", name, fn, err)}
+		}
+	}
+	return nil
+}
+
+func checkTLSConfig(tlsConfig config.TLSConfig) *checkError {
+	for _, fn := range []string{tlsConfig.CAFile, tlsConfig.CertFile, tlsConfig.KeyFile} {
+		if fn == "" {
+			continue
+		}
+		if _, err := os.Stat(fn); err != nil {
+			return &checkError{checkMissingFile, fmt.Errorf("error checking TLS file %q: %s", fn, err)}
+		}
+	}
+	if (tlsConfig.CertFile == "") != (tlsConfig.KeyFile == "") {
+		return &checkError{checkInvalidTLS, fmt.Errorf("client cert file %q and client key file %q must be specified together", tlsConfig.CertFile, tlsConfig.KeyFile)}
+	}
+	if _, err := httputil.NewTLSConfig(tlsConfig); err != nil {
+		return &checkError{checkInvalidTLS, err}
+	}
+	// The server ignores CA files without certificates, leaving the server
+	// certificates of targets unverifiable.
+	if tlsConfig.CAFile != "" {
+		b, err := ioutil.ReadFile(tlsConfig.CAFile)
+		if err != nil {
+			return &checkError{checkMissingFile, err}
+		}
+		if !x509.NewCertPool().AppendCertsFromPEM(b) {
+			return &checkError{checkInvalidTLS, fmt.Errorf("no certificates found in CA file %q", tlsConfig.CAFile)}
+		}
+	}
+	return nil
+}
+
+// checkFileSDConfig loads the files currently matching the patterns of the
+// file_sd configuration.
+func checkFileSDConfig(sdConfig config.FileSDConfig) *checkError {
+	for _, pat := range sdConfig.Files {
+		files, err := filepath.Glob(pat)
+		if err != nil {
+			return &checkError{checkInvalidConfig, fmt.Errorf("invalid file_sd pattern %q: %s", pat, err)}
+		}
+		for _, fn := range files {
+			if _, err := file.ReadFile(fn); err != nil {
+				return &checkError{checkInvalidConfig, fmt.Errorf("error loading file_sd file %s: %s", fn, err)}
+			}
+		}
+	}
+	return nil
+}
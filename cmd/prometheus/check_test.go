@@ -0,0 +1,148 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/prometheus/util/testutil"
+)
+
+// selfSignedCert returns a self-signed certificate and its key in PEM format.
+func selfSignedCert(t *testing.T) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "prometheus"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+}
+
+func TestCheckConfig(t *testing.T) {
+	dir := testutil.NewTemporaryDirectory("check_config", t)
+	defer dir.Close()
+
+	write := func(name, content string) string {
+		fn := filepath.Join(dir.Path(), name)
+		if err := ioutil.WriteFile(fn, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return fn
+	}
+	cert, key := selfSignedCert(t)
+	_, otherKey := selfSignedCert(t)
+	write("cert.pem", string(cert))
+	write("key.pem", string(key))
+	write("other_key.pem", string(otherKey))
+	write("no_cert.pem", "no certificate")
+	write("good.rules", "job:up:sum = sum(up) by (job)\n")
+	write("bad.rules", "job:up:sum = sum(up by (job)\n")
+	write("good.json", `[{"targets": ["localhost:9090"]}]`)
+	write("bad.json", `[{"targets": ["localhost:9090"]`)
+
+	scenarios := []struct {
+		config string
+		code   int
+	}{
+		{
+			config: fmt.Sprintf(`
+rule_files: ["good.rules", "*.none"]
+scrape_configs:
+- job_name: good
+  file_sd_configs:
+  - files: ["%s/good.json"]
+  tls_config:
+    ca_file: cert.pem
+    cert_file: cert.pem
+    key_file: key.pem
+`, dir.Path()),
+		},
+		{
+			config: "scrape_configs:\n- job_name: a\n  scrape_interval: 1x\n",
+			code:   checkInvalidConfig,
+		},
+		{
+			config: "rule_files: [bad.rules]\n",
+			code:   checkInvalidRules,
+		},
+		{
+			config: "rule_files: [missing.rules]\n",
+			code:   checkMissingFile,
+		},
+		{
+			config: fmt.Sprintf("scrape_configs:\n- job_name: a\n  file_sd_configs:\n  - files: [\"%s/*.json\"]\n", dir.Path()),
+			code:   checkInvalidConfig,
+		},
+		{
+			config: "scrape_configs:\n- job_name: a\n  bearer_token_file: missing.token\n",
+			code:   checkMissingFile,
+		},
+		{
+			config: "remote_write:\n- url: http://remote/write\n  tls_config:\n    ca_file: missing.cer\n",
+			code:   checkMissingFile,
+		},
+		{
+			config: "scrape_configs:\n- job_name: a\n  tls_config:\n    cert_file: cert.pem\n    key_file: other_key.pem\n",
+			code:   checkInvalidTLS,
+		},
+		{
+			config: "scrape_configs:\n- job_name: a\n  tls_config:\n    ca_file: no_cert.pem\n",
+			code:   checkInvalidTLS,
+		},
+	}
+
+	for i, s := range scenarios {
+		fn := write("prometheus.yml", s.config)
+		err := checkConfig(fn)
+		switch {
+		case s.code == 0 && err != nil:
+			t.Errorf("%d. Unexpected error: %s", i, err)
+		case s.code != 0 && err == nil:
+			t.Errorf("%d. Expected error with exit code %d, got none", i, s.code)
+		case s.code != 0 && err.code != s.code:
+			t.Errorf("%d. Expected exit code %d, got %d: %s", i, s.code, err.code, err)
+		}
+	}
+
+	if err := checkConfig(filepath.Join(dir.Path(), "missing.yml")); err == nil || err.code != checkMissingFile {
+		t.Errorf("Expected missing file error for a missing configuration file, got %v", err)
+	}
+}
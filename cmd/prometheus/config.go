@@ -19,6 +19,7 @@ import (
 	"net"
 	"net/url"
 	"os"
+	"regexp"
 	"sort"
 	"strings"
 	"text/template"
@@ -29,11 +30,13 @@ import (
 	"github.com/prometheus/common/log"
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/discovery"
 	"github.com/prometheus/prometheus/notifier"
 	"github.com/prometheus/prometheus/promql"
 	"github.com/prometheus/prometheus/storage/local"
 	"github.com/prometheus/prometheus/storage/local/chunk"
 	"github.com/prometheus/prometheus/storage/local/index"
+	"github.com/prometheus/prometheus/util/logging"
 	"github.com/prometheus/prometheus/web"
 )
 
@@ -42,24 +45,33 @@ import (
 var cfg = struct {
 	fs *flag.FlagSet
 
-	printVersion bool
-	configFile   string
+	printVersion    bool
+	checkConfigOnly bool
+	configFile      string
 
 	storage            local.MemorySeriesStorageOptions
 	localStorageEngine string
 	notifier           notifier.Options
 	notifierTimeout    time.Duration
+	alertResendDelay   time.Duration
 	queryEngine        promql.EngineOptions
 	web                web.Options
 
 	alertmanagerURLs stringset
 	prometheusURL    string
+	corsOrigin       string
+	features         stringset
+	expandEnv        bool
+	labelTemplates   bool
+
+	autoGoMemLimitRatio float64
 
 	// Deprecated storage flags, kept for backwards compatibility.
 	deprecatedMemoryChunks       uint64
 	deprecatedMaxChunksToPersist uint64
 }{
 	alertmanagerURLs: stringset{},
+	features:         stringset{},
 	notifier: notifier.Options{
 		Registerer: prometheus.DefaultRegisterer,
 	},
@@ -104,6 +116,19 @@ func init() {
 		&cfg.configFile, "config.file", "prometheus.yml",
 		"Prometheus configuration file name.",
 	)
+	cfg.fs.BoolVar(
+		&cfg.checkConfigOnly, "check-config-only", false,
+		"Load the configuration file with the rule files, file_sd files and TLS material it references, then exit. The exit code is 3 for an invalid configuration or file_sd file, 4 for an invalid rule file, 5 for a missing file and 6 for invalid TLS material.",
+	)
+	cfg.fs.Var(
+		&cfg.features, "enable-feature",
+		"Comma-separated list of features to enable. Supported values are: 'forward-only' (only scrape targets and forward samples to remote write endpoints, without local storage, querying, or rule evaluation. Samples are only queued in memory, so the ones not sent yet are lost on restarts, and dropped when the queues are full during remote write outages); 'expand-env' (replace ${var} in external label values and secrets of the configuration file with environment variables, $$ escapes a $); 'label-templates' (resolve $(hostname) and $(env:NAME) in external label values, $$( escapes a $().",
+	)
+
+	cfg.fs.Float64Var(
+		&cfg.autoGoMemLimitRatio, "auto-gomemlimit.ratio", 0.9,
+		"The ratio of the cgroup memory limit to set GOMEMLIMIT, the soft memory limit of the Go runtime, to. A value of 0 disables setting GOMEMLIMIT. GOMEMLIMIT is never set if it is set as environment variable. Setting GOMEMLIMIT requires Prometheus to be built with Go 1.19 or later.",
+	)
 
 	// Web.
 	cfg.fs.StringVar(
@@ -135,8 +160,48 @@ func init() {
 		"Path to static asset directory, available at /user.",
 	)
 	cfg.fs.BoolVar(
-		&cfg.web.EnableQuit, "web.enable-remote-shutdown", false,
-		"Enable remote service shutdown.",
+		&cfg.web.EnableLifecycle, "web.enable-lifecycle", false,
+		"Enable shutdown and reload via HTTP request (POST to /-/quit and /-/reload).",
+	)
+	cfg.fs.BoolVar(
+		&cfg.web.EnableLifecycle, "web.enable-remote-shutdown", false,
+		"Deprecated. Use -web.enable-lifecycle instead.",
+	)
+	cfg.fs.BoolVar(
+		&cfg.web.EnableAdminAPI, "web.enable-admin-api", false,
+		"Enable API endpoints for admin control actions (POST to /api/v1/rules/<group>/evaluate and /api/v1/settings).",
+	)
+	cfg.fs.StringVar(
+		&cfg.corsOrigin, "web.cors.origin", ".*",
+		"Regex for CORS origins allowed to query the API. It is fully anchored. Example: 'https?://(domain1|domain2)\\.com'",
+	)
+	cfg.fs.BoolVar(
+		&cfg.web.LogRequests, "web.log-requests", false,
+		"Log every HTTP request with its method, path, status, duration and remote address.",
+	)
+	cfg.fs.StringVar(
+		&cfg.web.WebConfigFile, "web.config.file", "",
+		"Path to a configuration file that can enable TLS and basic authentication for the web interface, API, and telemetry. The basic auth users map user names to bcrypt hashes of their passwords. The file is only read on startup, not on configuration reloads.",
+	)
+	cfg.fs.IntVar(
+		&cfg.web.QueryLimits.MaxConcurrent, "web.api.max-concurrent-queries", 0,
+		"Maximum number of requests to the query API endpoints served concurrently. Requests above the limit are rejected with a 503 status. 0 means no limit.",
+	)
+	cfg.fs.Float64Var(
+		&cfg.web.QueryLimits.ClientRate, "web.api.client-rate-limit", 0,
+		"Maximum number of requests per second each client may send to the query API endpoints on average. Requests above the limit are rejected with a 503 status. 0 means no limit.",
+	)
+	cfg.fs.IntVar(
+		&cfg.web.QueryLimits.ClientBurst, "web.api.client-rate-burst", 10,
+		"Maximum number of requests each client may send to the query API endpoints at once when -web.api.client-rate-limit is set.",
+	)
+	cfg.fs.DurationVar(
+		&cfg.web.QueryCache.TTL, "web.api.query-cache-ttl", 0,
+		"How long the results of range queries whose start is aligned to their step are cached. Evaluations of the last 5m are never cached. 0 disables the cache.",
+	)
+	cfg.fs.IntVar(
+		&cfg.web.QueryCache.MaxEntries, "web.api.query-cache-max-entries", 100,
+		"Maximum number of range query results cached when -web.api.query-cache-ttl is set.",
 	)
 	cfg.fs.StringVar(
 		&cfg.web.ConsoleTemplatesPath, "web.console.templates", "consoles",
@@ -168,6 +233,14 @@ func init() {
 		&cfg.deprecatedMaxChunksToPersist, "storage.local.max-chunks-to-persist", 0,
 		"Deprecated. This flag has no effect anymore.",
 	)
+	cfg.fs.DurationVar(
+		&cfg.storage.HeadChunkTimeout, "storage.local.head-chunk-timeout", 0,
+		"Head chunks idle for at least that long are closed and may be persisted, even if they are not full yet. Larger values result in fuller chunks and fewer chunks to persist for slowly changing series, at the cost of more memory. Must not exceed the retention. If 0, the staleness delta is used.",
+	)
+	cfg.fs.DurationVar(
+		&cfg.storage.SeriesIdleTimeout, "storage.local.series-idle-timeout", 0,
+		"Series without new samples for at least that long are archived once all their chunks are persisted, freeing their memory even without memory pressure. Lower values reduce memory usage with high series churn, at the cost of unarchiving series that receive samples again. Must not be lower than the head chunk timeout. If 0, series are only archived under memory pressure.",
+	)
 	cfg.fs.DurationVar(
 		&cfg.storage.CheckpointInterval, "storage.local.checkpoint-interval", 5*time.Minute,
 		"The time to wait between checkpoints of in-memory metrics and chunks not yet persisted to series files. Note that a checkpoint is never triggered before at least as much time has passed as the last checkpoint took.",
@@ -184,6 +257,10 @@ func init() {
 		&cfg.storage.MinShrinkRatio, "storage.local.series-file-shrink-ratio", 0.1,
 		"A series file is only truncated (to delete samples that have exceeded the retention period) if it shrinks by at least the provided ratio. This saves I/O operations while causing only a limited storage space overhead. If 0 or smaller, truncation will be performed even for a single dropped chunk, while 1 or larger will effectively prevent any truncation.",
 	)
+	cfg.fs.Var(
+		&cfg.storage.PurgeWindow, "storage.local.purge-window",
+		"Daily time window in UTC during which series files are truncated to delete samples that have exceeded the retention period, in the format HH:MM-HH:MM, for example 22:00-06:00 to keep the I/O of truncations out of office hours. Outside of the window, series files are only appended to and disk usage may exceed the retention period. Empty to truncate at any time. Truncation can also be paused through the admin API.",
+	)
 	cfg.fs.BoolVar(
 		&cfg.storage.Dirty, "storage.local.dirty", false,
 		"If set, the local storage layer will perform crash recovery even if the last shutdown appears to be clean.",
@@ -192,9 +269,17 @@ func init() {
 		&cfg.storage.PedanticChecks, "storage.local.pedantic-checks", false,
 		"If set, a crash recovery will perform checks on each series file. This might take a very long time.",
 	)
+	cfg.fs.BoolVar(
+		&cfg.storage.AllowCorruptedStartup, "storage.local.allow-corrupted-startup", false,
+		"If set, corrupted indexes and a corrupted heads file found on startup are moved into the 'corrupted' sub-directory of the storage path and the storage starts without them, instead of failing. Series which cannot be recovered are lost.",
+	)
 	cfg.fs.Var(
 		&chunk.DefaultEncoding, "storage.local.chunk-encoding-version",
-		"Which chunk encoding version to use for newly created chunks. Currently supported is 0 (delta encoding), 1 (double-delta encoding), and 2 (double-delta encoding with variable bit-width).",
+		"Which chunk encoding version to use for newly created chunks. Currently supported is 0 (delta encoding), 1 (double-delta encoding), 2 (double-delta encoding with variable bit-width), and 3 (float32 encoding, storing values with reduced precision). The names delta, double-delta, varbit and float32 are accepted as well.",
+	)
+	cfg.fs.Var(
+		&chunk.MetricEncodings, "storage.local.chunk-encoding-rule",
+		"Chunk encoding of the newly created chunks of the metrics whose names match a regular expression, in the format <regex>=<encoding>, overriding -storage.local.chunk-encoding-version. Can be repeated, the first matching rule applies. For example, node_.*=float32 halves the size of the chunks of node_* metrics with non-integer values at the cost of precision.",
 	)
 	// Index cache sizes.
 	cfg.fs.IntVar(
@@ -236,6 +321,12 @@ func init() {
 		"storage.remote.timeout",
 	})
 
+	// Service discovery.
+	cfg.fs.DurationVar(
+		&discovery.UpdateInterval, "discovery.update-interval", discovery.UpdateInterval,
+		"Interval within which target group updates of service discovery are coalesced into one update of the scraped targets or the notified Alertmanagers, so that bursts of changes cause a single update. Initial target groups are applied right away.",
+	)
+
 	// Alertmanager.
 	cfg.fs.Var(
 		&cfg.alertmanagerURLs, "alertmanager.url",
@@ -249,6 +340,10 @@ func init() {
 		&cfg.notifierTimeout, "alertmanager.timeout", 10*time.Second,
 		"Alert manager HTTP API timeout.",
 	)
+	cfg.fs.DurationVar(
+		&cfg.alertResendDelay, "rules.alert.resend-delay", 0,
+		"Minimum amount of time to wait before resending an alert to the Alertmanager. Alerting rules can override it with RESEND_DELAY. Alerts are resent on every evaluation if zero. Firing alerts are sent without an end time, so the Alertmanager resolves them if they are not resent within its resolve_timeout (5m by default): the delay must be well below resolve_timeout, or alerts flap.",
+	)
 
 	// Query engine.
 	cfg.fs.DurationVar(
@@ -261,11 +356,31 @@ func init() {
 	)
 	cfg.fs.IntVar(
 		&cfg.queryEngine.MaxConcurrentQueries, "query.max-concurrency", 20,
-		"Maximum number of queries executed concurrently.",
+		"Maximum number of queries executed concurrently, including federation requests. Waiting rule evaluations are executed before waiting API queries, which are executed before waiting federation requests.",
+	)
+	cfg.fs.BoolVar(
+		&cfg.queryEngine.MatchDiagnostics, "query.match-diagnostics", false,
+		"Return the duplicate series of failed vector matchings in the statistics of API queries requested with the stats parameter.",
+	)
+	cfg.fs.IntVar(
+		&cfg.queryEngine.MaxCountValuesSeries, "query.max-count-values-series", 1000000,
+		"Maximum number of series a count_values aggregation may return in an evaluation step. 0 means unlimited.",
+	)
+	cfg.fs.StringVar(
+		(*string)(&cfg.queryEngine.ReplicaLabel), "query.replica-label", "",
+		"Label distinguishing the replicas of HA pairs. Series differing only by it are merged in all queries, including rule evaluations, using the replica with the lowest label value that has data at each evaluation. Empty disables deduplication.",
+	)
+	cfg.fs.IntVar(
+		&cfg.storage.MaxSeriesPerQuery, "query.max-touched-series", 0,
+		"Maximum number of series the selectors of a query may touch in the local storage. Queries touching more series fail. 0 means unlimited.",
+	)
+	cfg.fs.IntVar(
+		&cfg.storage.MaxChunksPerQuery, "query.max-touched-chunks", 0,
+		"Maximum number of chunks the selectors of a query may load from the local storage. Queries touching more chunks fail. 0 means unlimited.",
 	)
 
 	// Flags from the log package have to be added explicitly to our custom flag set.
-	log.AddFlags(cfg.fs)
+	logging.AddFlags(cfg.fs)
 }
 
 func parse(args []string) error {
@@ -280,12 +395,37 @@ func parse(args []string) error {
 		return err
 	}
 
+	if cfg.autoGoMemLimitRatio < 0 || cfg.autoGoMemLimitRatio > 1 {
+		return fmt.Errorf("auto-gomemlimit.ratio must be between 0 and 1: %g", cfg.autoGoMemLimitRatio)
+	}
+
+	if discovery.UpdateInterval < 0 {
+		return fmt.Errorf("negative discovery update interval: %s", discovery.UpdateInterval)
+	}
+
 	if promql.StalenessDelta < 0 {
 		return fmt.Errorf("negative staleness delta: %s", promql.StalenessDelta)
 	}
-	// The staleness delta is also a reasonable head chunk timeout. Thus, we
-	// don't expose it as a separate flag but set it here.
-	cfg.storage.HeadChunkTimeout = promql.StalenessDelta
+	// The staleness delta is also a reasonable default head chunk timeout.
+	if cfg.storage.HeadChunkTimeout == 0 {
+		cfg.storage.HeadChunkTimeout = promql.StalenessDelta
+	}
+	if cfg.storage.HeadChunkTimeout < 0 {
+		return fmt.Errorf("negative head chunk timeout: %s", cfg.storage.HeadChunkTimeout)
+	}
+	if cfg.storage.HeadChunkTimeout > cfg.storage.PersistenceRetentionPeriod {
+		return fmt.Errorf("head chunk timeout %s exceeds the retention %s", cfg.storage.HeadChunkTimeout, cfg.storage.PersistenceRetentionPeriod)
+	}
+	if cfg.storage.SeriesIdleTimeout < 0 {
+		return fmt.Errorf("negative series idle timeout: %s", cfg.storage.SeriesIdleTimeout)
+	}
+	if cfg.storage.SeriesIdleTimeout > 0 && cfg.storage.SeriesIdleTimeout < cfg.storage.HeadChunkTimeout {
+		return fmt.Errorf("series idle timeout %s is lower than the head chunk timeout %s", cfg.storage.SeriesIdleTimeout, cfg.storage.HeadChunkTimeout)
+	}
+
+	if cfg.queryEngine.ReplicaLabel != "" && !cfg.queryEngine.ReplicaLabel.IsValid() {
+		return fmt.Errorf("invalid -query.replica-label %q", cfg.queryEngine.ReplicaLabel)
+	}
 
 	if cfg.storage.TargetHeapSize < 1024*1024 {
 		return fmt.Errorf("target heap size smaller than %d: %d", 1024*1024, cfg.storage.TargetHeapSize)
@@ -294,6 +434,12 @@ func parse(args []string) error {
 	if err := parsePrometheusURL(); err != nil {
 		return err
 	}
+	corsOrigin, err := regexp.Compile("^(?:" + cfg.corsOrigin + ")$")
+	if err != nil {
+		return fmt.Errorf("invalid -web.cors.origin regex %q: %s", cfg.corsOrigin, err)
+	}
+	cfg.web.CORSOrigin = corsOrigin
+	cfg.web.LogLevel = cfg.fs.Lookup("log.level").Value
 	// Default -web.route-prefix to path of -web.external-url.
 	if cfg.web.RoutePrefix == "" {
 		cfg.web.RoutePrefix = cfg.web.ExternalURL.Path
@@ -307,6 +453,33 @@ func parse(args []string) error {
 		}
 	}
 
+	for f := range cfg.features {
+		switch f {
+		case "forward-only":
+			cfg.web.ForwardOnly = true
+		case "expand-env":
+			cfg.expandEnv = true
+		case "label-templates":
+			cfg.labelTemplates = true
+		default:
+			return fmt.Errorf("unknown feature flag: %q", f)
+		}
+	}
+	if cfg.web.ForwardOnly {
+		// Samples are only forwarded to remote storage, there is no
+		// point in keeping a local copy of them.
+		storageSet := false
+		cfg.fs.Visit(func(f *flag.Flag) {
+			if f.Name == "storage.local.engine" {
+				storageSet = true
+			}
+		})
+		if storageSet && cfg.localStorageEngine != "none" {
+			return fmt.Errorf("forward-only mode requires -storage.local.engine=none, got %q", cfg.localStorageEngine)
+		}
+		cfg.localStorageEngine = "none"
+	}
+
 	// Deal with deprecated storage flags.
 	if cfg.deprecatedMaxChunksToPersist > 0 {
 		log.Warn("Flag -storage.local.max-chunks-to-persist is deprecated. It has no effect.")
@@ -68,12 +68,79 @@ func TestParse(t *testing.T) {
 			input: []string{"-alertmanager.url", "ends/with/quote\""},
 			valid: false,
 		},
+		{
+			input: []string{"-enable-feature", "forward-only"},
+			valid: true,
+		},
+		{
+			input: []string{"-enable-feature", "forward-only,expand-env"},
+			valid: true,
+		},
+		{
+			input: []string{"-enable-feature", "label-templates"},
+			valid: true,
+		},
+		{
+			input: []string{"-enable-feature", "unknown"},
+			valid: false,
+		},
+		{
+			input: []string{"-enable-feature", "forward-only", "-storage.local.engine", "persisted"},
+			valid: false,
+		},
+		{
+			input: []string{"-web.cors.origin", `https?://(domain1|domain2)\.com`},
+			valid: true,
+		},
+		{
+			input: []string{"-web.cors.origin", "(unclosed"},
+			valid: false,
+		},
+		{
+			input: []string{"-auto-gomemlimit.ratio", "0"},
+			valid: true,
+		},
+		{
+			input: []string{"-auto-gomemlimit.ratio", "1.5"},
+			valid: false,
+		},
+		{
+			input: []string{"-storage.local.head-chunk-timeout", "2h"},
+			valid: true,
+		},
+		{
+			input: []string{"-storage.local.head-chunk-timeout", "-1m"},
+			valid: false,
+		},
+		{
+			input: []string{"-storage.local.head-chunk-timeout", "2h", "-storage.local.retention", "1h"},
+			valid: false,
+		},
+		{
+			input: []string{"-storage.local.series-idle-timeout", "1h"},
+			valid: true,
+		},
+		{
+			input: []string{"-storage.local.series-idle-timeout", "1m"},
+			valid: false,
+		},
+		{
+			input: []string{"-storage.local.series-idle-timeout", "-1h"},
+			valid: false,
+		},
 	}
 
 	for i, test := range tests {
 		// reset "immutable" config
 		cfg.prometheusURL = ""
 		cfg.alertmanagerURLs = stringset{}
+		cfg.features = stringset{}
+		cfg.web.ForwardOnly = false
+		cfg.expandEnv = false
+		cfg.corsOrigin = ".*"
+		cfg.storage.HeadChunkTimeout = 0
+		cfg.storage.SeriesIdleTimeout = 0
+		cfg.autoGoMemLimitRatio = 0.9
 
 		err := parse(test.input)
 		if test.valid && err != nil {
@@ -20,9 +20,11 @@ import (
 	"os"
 	"os/signal"
 	"runtime/debug"
+	"strconv"
 	"syscall"
 	"time"
 
+	opentracing "github.com/opentracing/opentracing-go"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/common/log"
 	"github.com/prometheus/common/version"
@@ -37,6 +39,8 @@ import (
 	"github.com/prometheus/prometheus/storage/fanin"
 	"github.com/prometheus/prometheus/storage/local"
 	"github.com/prometheus/prometheus/storage/remote"
+	"github.com/prometheus/prometheus/tracing"
+	"github.com/prometheus/prometheus/util/logging"
 	"github.com/prometheus/prometheus/web"
 )
 
@@ -79,25 +83,41 @@ func Main() int {
 		fmt.Fprintln(os.Stdout, version.Print("prometheus"))
 		return 0
 	}
+	if cfg.checkConfigOnly {
+		return checkConfigOnly(cfg.configFile)
+	}
 
-	if os.Getenv("GOGC") == "" {
+	cfg.web.GOGC = os.Getenv("GOGC")
+	if cfg.web.GOGC == "" {
 		debug.SetGCPercent(defaultGCPercent)
+		cfg.web.GOGC = strconv.Itoa(defaultGCPercent)
 	}
+	setMemoryLimit(cfg.autoGoMemLimitRatio, logging.New("runtime"))
 
 	log.Infoln("Starting prometheus", version.Info())
 	log.Infoln("Build context", version.BuildContext())
 	log.Infoln("Host details", Uname())
+	if cfg.web.ForwardOnly {
+		log.Warnln("Running in forward-only mode, local querying and rule evaluation are disabled, and samples queued for remote write are lost on restarts")
+	}
 
 	var (
 		sampleAppender = storage.Fanout{}
 		reloadables    []Reloadable
 	)
 
-	var localStorage local.Storage
+	var (
+		localStorage   local.Storage
+		localStartTime remote.StartTimeCallback
+	)
 	switch cfg.localStorageEngine {
 	case "persisted":
-		localStorage = local.NewMemorySeriesStorage(&cfg.storage)
+		ms := local.NewMemorySeriesStorage(&cfg.storage)
+		localStorage = ms
 		sampleAppender = storage.Fanout{localStorage}
+		// Samples older than the oldest local one, or than the retention
+		// period, have to be read from remote storage.
+		localStartTime = ms.OldestSampleTime
 	case "none":
 		localStorage = &local.NoopStorage{}
 	default:
@@ -107,7 +127,7 @@ func Main() int {
 
 	remoteAppender := &remote.Writer{}
 	sampleAppender = append(sampleAppender, remoteAppender)
-	remoteReader := &remote.Reader{}
+	remoteReader := remote.NewReader(localStartTime)
 	reloadables = append(reloadables, remoteAppender, remoteReader)
 
 	queryable := fanin.Queryable{
@@ -115,9 +135,15 @@ func Main() int {
 		Remote: remoteReader,
 	}
 
+	// The tracing manager is installed as the global tracer before any
+	// component starts spans. Spans are exported once tracing is configured.
+	tracingManager := tracing.NewManager(logging.New("tracing"))
+	opentracing.SetGlobalTracer(tracingManager)
+	reloadables = append(reloadables, tracingManager)
+
 	var (
-		notifier       = notifier.New(&cfg.notifier, log.Base())
-		targetManager  = retrieval.NewTargetManager(sampleAppender, log.Base())
+		notifier       = notifier.New(&cfg.notifier, logging.New("notifier"))
+		targetManager  = retrieval.NewTargetManager(sampleAppender, logging.New("scrape"))
 		queryEngine    = promql.NewEngine(queryable, &cfg.queryEngine)
 		ctx, cancelCtx = context.WithCancel(context.Background())
 	)
@@ -126,8 +152,9 @@ func Main() int {
 		SampleAppender: sampleAppender,
 		Notifier:       notifier,
 		QueryEngine:    queryEngine,
-		Context:        fanin.WithLocalOnly(ctx),
+		Context:        promql.WithPriority(fanin.WithLocalOnly(ctx), promql.PriorityRules),
 		ExternalURL:    cfg.web.ExternalURL,
+		ResendDelay:    cfg.alertResendDelay,
 	})
 
 	cfg.web.Context = ctx
@@ -136,6 +163,9 @@ func Main() int {
 	cfg.web.TargetManager = targetManager
 	cfg.web.RuleManager = ruleManager
 	cfg.web.Notifier = notifier
+	cfg.web.RemoteWriter = remoteAppender
+	cfg.web.StoragePath = cfg.storage.PersistenceStoragePath
+	cfg.web.StorageRetention = cfg.storage.PersistenceRetentionPeriod
 
 	cfg.web.Version = &web.PrometheusVersion{
 		Version:   version.Version,
@@ -154,9 +184,25 @@ func Main() int {
 	webHandler := web.New(&cfg.web)
 	go webHandler.Run()
 
-	reloadables = append(reloadables, targetManager, ruleManager, webHandler, notifier)
+	reloadables = append(reloadables, targetManager)
+	if !cfg.web.ForwardOnly {
+		reloadables = append(reloadables, ruleManager)
+	}
+	reloadables = append(reloadables, webHandler, notifier)
+
+	// reload applies the configuration file and records which components
+	// were affected, compared to the last successfully applied configuration.
+	var current *config.Config
+	reload := func() error {
+		conf, err := reloadConfig(cfg.configFile, reloadables...)
+		webHandler.RecordReload(newReloadResult(current, conf, err, cfg.web.ForwardOnly))
+		if err == nil {
+			current = conf
+		}
+		return err
+	}
 
-	if err := reloadConfig(cfg.configFile, reloadables...); err != nil {
+	if err := reload(); err != nil {
 		log.Errorf("Error loading config: %s", err)
 		return 1
 	}
@@ -172,11 +218,11 @@ func Main() int {
 		for {
 			select {
 			case <-hup:
-				if err := reloadConfig(cfg.configFile, reloadables...); err != nil {
+				if err := reload(); err != nil {
 					log.Errorf("Error reloading config: %s", err)
 				}
 			case rc := <-webHandler.Reload():
-				if err := reloadConfig(cfg.configFile, reloadables...); err != nil {
+				if err := reload(); err != nil {
 					log.Errorf("Error reloading config: %s", err)
 					rc <- err
 				} else {
@@ -188,6 +234,9 @@ func Main() int {
 
 	// Start all components. The order is NOT arbitrary.
 
+	// Pending spans are sent after all other components are stopped.
+	defer tracingManager.Stop()
+
 	if err := localStorage.Start(); err != nil {
 		log.Errorln("Error opening memory series storage:", err)
 		return 1
@@ -212,8 +261,12 @@ func Main() int {
 	go notifier.Run()
 	defer notifier.Stop()
 
-	go ruleManager.Run()
-	defer ruleManager.Stop()
+	// There are no rules to evaluate in forward-only mode, as there is no local
+	// storage to evaluate them against.
+	if !cfg.web.ForwardOnly {
+		go ruleManager.Run()
+		defer ruleManager.Stop()
+	}
 
 	go targetManager.Run()
 	defer targetManager.Stop()
@@ -225,9 +278,13 @@ func Main() int {
 	// Wait for reload or termination signals.
 	close(hupReady) // Unblock SIGHUP handler.
 
-	// Set web server to ready.
-	webHandler.Ready()
-	log.Info("Server is Ready to receive requests.")
+	// Set web server to ready once the initial service discovery is done, so
+	// that readiness probes don't succeed before targets are being scraped.
+	go func() {
+		<-targetManager.Ready()
+		webHandler.Ready()
+		log.Info("Server is Ready to receive requests.")
+	}()
 
 	term := make(chan os.Signal, 1)
 	signal.Notify(term, os.Interrupt, syscall.SIGTERM)
@@ -250,7 +307,9 @@ type Reloadable interface {
 	ApplyConfig(*config.Config) error
 }
 
-func reloadConfig(filename string, rls ...Reloadable) (err error) {
+// reloadConfig loads the configuration file and applies it to rls. The loaded
+// configuration is returned even if applying it failed.
+func reloadConfig(filename string, rls ...Reloadable) (conf *config.Config, err error) {
 	log.Infof("Loading configuration file %s", filename)
 	defer func() {
 		if err == nil {
@@ -261,16 +320,24 @@ func reloadConfig(filename string, rls ...Reloadable) (err error) {
 		}
 	}()
 
-	conf, err := config.LoadFile(filename)
+	conf, err = config.LoadFile(filename)
 	if err != nil {
-		return fmt.Errorf("couldn't load configuration (-config.file=%s): %v", filename, err)
+		return nil, fmt.Errorf("couldn't load configuration (-config.file=%s): %v", filename, err)
+	}
+	if cfg.expandEnv {
+		conf.ExpandEnv()
+	}
+	if cfg.labelTemplates {
+		if err := conf.ResolveLabelTemplates(); err != nil {
+			return nil, fmt.Errorf("couldn't load configuration (-config.file=%s): %v", filename, err)
+		}
 	}
 
 	// Add AlertmanagerConfigs for legacy Alertmanager URL flags.
 	for us := range cfg.alertmanagerURLs {
 		acfg, err := parseAlertmanagerURLToConfig(us)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		conf.AlertingConfig.AlertmanagerConfigs = append(conf.AlertingConfig.AlertmanagerConfigs, acfg)
 	}
@@ -283,7 +350,7 @@ func reloadConfig(filename string, rls ...Reloadable) (err error) {
 		}
 	}
 	if failed {
-		return fmt.Errorf("one or more errors occurred while applying the new configuration (-config.file=%s)", filename)
+		return conf, fmt.Errorf("one or more errors occurred while applying the new configuration (-config.file=%s)", filename)
 	}
-	return nil
+	return conf, nil
 }
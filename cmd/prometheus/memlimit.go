@@ -0,0 +1,134 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bufio"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/common/log"
+)
+
+const (
+	procSelfCgroup = "/proc/self/cgroup"
+	cgroupRoot     = "/sys/fs/cgroup"
+
+	// Memory limits of cgroup v1 above this value mean that the memory is
+	// not limited. The kernel reports the largest page aligned int64 then.
+	cgroupV1Unlimited = 1 << 62
+)
+
+// setMemoryLimit sets the soft memory limit of the Go runtime to the given
+// ratio of the memory limit of the cgroup Prometheus runs in, so that the
+// garbage collector works harder before the process is killed for running
+// out of memory. Nothing is changed if the ratio is 0, if the GOMEMLIMIT
+// environment variable is set, or if there is no cgroup memory limit.
+func setMemoryLimit(ratio float64, logger log.Logger) {
+	if ratio == 0 || os.Getenv("GOMEMLIMIT") != "" {
+		return
+	}
+	limit, ok, err := cgroupMemoryLimit(procSelfCgroup, cgroupRoot)
+	if err != nil {
+		logger.Warnf("Error reading cgroup memory limit, GOMEMLIMIT is not set: %s", err)
+		return
+	}
+	if !ok {
+		logger.Debugln("No cgroup memory limit found, GOMEMLIMIT is not set")
+		return
+	}
+	memLimit := int64(float64(limit) * ratio)
+	if !setRuntimeMemoryLimit(memLimit) {
+		logger.Warnln("GOMEMLIMIT is not supported by the Go version Prometheus was built with, it requires Go 1.19 or later")
+		return
+	}
+	logger.Infof("Set GOMEMLIMIT to %d bytes, %g of the cgroup memory limit of %d bytes", memLimit, ratio, limit)
+}
+
+// cgroupMemoryLimit returns the memory limit in bytes of the cgroup the
+// process belongs to according to the given cgroup file, read below the
+// given mount point of the cgroup file systems. Both cgroup v1 and v2 are
+// supported. It returns false if the memory of the cgroup is not limited.
+func cgroupMemoryLimit(cgroupFile, root string) (int64, bool, error) {
+	f, err := os.Open(cgroupFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+	defer f.Close()
+
+	var candidates []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// Lines have the form hierarchy-ID:controller-list:cgroup-path.
+		parts := strings.SplitN(scanner.Text(), ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		switch {
+		case parts[0] == "0" && parts[1] == "":
+			candidates = append(candidates,
+				filepath.Join(root, parts[2], "memory.max"),
+				filepath.Join(root, "memory.max"),
+			)
+		case hasController(parts[1], "memory"):
+			// Controllers of cgroup v1 take precedence as they are
+			// the ones enforced if both versions are mounted.
+			candidates = append([]string{
+				filepath.Join(root, "memory", parts[2], "memory.limit_in_bytes"),
+				filepath.Join(root, "memory", "memory.limit_in_bytes"),
+			}, candidates...)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, false, err
+	}
+
+	for _, c := range candidates {
+		b, err := ioutil.ReadFile(c)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return 0, false, err
+		}
+		s := strings.TrimSpace(string(b))
+		if s == "max" {
+			return 0, false, nil
+		}
+		limit, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return 0, false, err
+		}
+		if limit >= cgroupV1Unlimited {
+			return 0, false, nil
+		}
+		return limit, true, nil
+	}
+	return 0, false, nil
+}
+
+func hasController(controllers, name string) bool {
+	for _, c := range strings.Split(controllers, ",") {
+		if c == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCgroupMemoryLimit(t *testing.T) {
+	tests := []struct {
+		cgroup string
+		files  map[string]string
+		limit  int64
+		ok     bool
+	}{
+		{
+			// cgroup v2 in a container.
+			cgroup: "0::/\n",
+			files:  map[string]string{"memory.max": "1073741824\n"},
+			limit:  1073741824,
+			ok:     true,
+		},
+		{
+			// cgroup v2 with the cgroup of the process visible.
+			cgroup: "0::/system.slice/prometheus.service\n",
+			files: map[string]string{
+				"memory.max": "max\n",
+				"system.slice/prometheus.service/memory.max": "536870912\n",
+			},
+			limit: 536870912,
+			ok:    true,
+		},
+		{
+			cgroup: "0::/\n",
+			files:  map[string]string{"memory.max": "max\n"},
+		},
+		{
+			// cgroup v1.
+			cgroup: "12:cpu,cpuacct:/docker/abc\n11:memory:/docker/abc\n",
+			files:  map[string]string{"memory/memory.limit_in_bytes": "2147483648\n"},
+			limit:  2147483648,
+			ok:     true,
+		},
+		{
+			cgroup: "11:memory:/\n",
+			files:  map[string]string{"memory/memory.limit_in_bytes": "9223372036854771712\n"},
+		},
+		{
+			// No cgroup files available.
+			cgroup: "0::/\n",
+		},
+	}
+
+	for i, test := range tests {
+		dir, err := ioutil.TempDir("", "cgroup")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+
+		cgroupFile := filepath.Join(dir, "cgroup")
+		if err := ioutil.WriteFile(cgroupFile, []byte(test.cgroup), 0644); err != nil {
+			t.Fatal(err)
+		}
+		root := filepath.Join(dir, "fs")
+		for name, content := range test.files {
+			fn := filepath.Join(root, name)
+			if err := os.MkdirAll(filepath.Dir(fn), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := ioutil.WriteFile(fn, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		limit, ok, err := cgroupMemoryLimit(cgroupFile, root)
+		if err != nil {
+			t.Errorf("%d. unexpected error: %s", i, err)
+			continue
+		}
+		if ok != test.ok || limit != test.limit {
+			t.Errorf("%d. expected limit %d (%t), got %d (%t)", i, test.limit, test.ok, limit, ok)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/web"
+)
+
+// The single rule group all rules are loaded into.
+const defaultRuleGroup = "default"
+
+// newReloadResult describes what happened to the scrape pools, rule groups and
+// remote write queues when conf was applied in place of prev. prev is nil on
+// the initial load, conf is nil if the configuration file could not be loaded.
+func newReloadResult(prev, conf *config.Config, err error, forwardOnly bool) *web.ReloadResult {
+	res := &web.ReloadResult{
+		Time:              time.Now(),
+		Success:           err == nil,
+		ScrapePools:       []web.ReloadedComponent{},
+		RuleGroups:        []web.ReloadedComponent{},
+		RemoteWriteQueues: []web.ReloadedComponent{},
+	}
+	if err != nil {
+		res.Error = err.Error()
+	}
+	if conf == nil {
+		return res
+	}
+
+	if !forwardOnly {
+		res.RuleGroups = reloadedRuleGroups(prev, conf)
+	}
+	if prev == nil {
+		prev = &config.Config{}
+	}
+	res.ScrapePools = reloadedScrapePools(prev, conf)
+	res.RemoteWriteQueues = reloadedRemoteWriteQueues(prev, conf)
+	return res
+}
+
+// reloadedScrapePools matches scrape pools by their job name. Scrape pools of
+// jobs that are kept restart their scrape loops even if their configuration
+// is unchanged. Disabled scrape configs have no scrape pool.
+func reloadedScrapePools(prev, conf *config.Config) []web.ReloadedComponent {
+	old := make(map[string]*config.ScrapeConfig, len(prev.ScrapeConfigs))
+	for _, scfg := range prev.ScrapeConfigs {
+		if scfg.IsEnabled() {
+			old[scfg.JobName] = scfg
+		}
+	}
+
+	res := []web.ReloadedComponent{}
+	for _, scfg := range conf.ScrapeConfigs {
+		oscfg, ok := old[scfg.JobName]
+		delete(old, scfg.JobName)
+
+		switch {
+		case !scfg.IsEnabled():
+			if ok {
+				res = append(res, web.ReloadedComponent{Name: scfg.JobName, Action: web.ReloadStopped, Reason: "scrape config disabled"})
+			}
+		case !ok:
+			res = append(res, web.ReloadedComponent{Name: scfg.JobName, Action: web.ReloadCreated, Reason: "scrape config added"})
+		case !reflect.DeepEqual(oscfg, scfg):
+			res = append(res, web.ReloadedComponent{Name: scfg.JobName, Action: web.ReloadRestarted, Reason: "scrape config changed"})
+		default:
+			res = append(res, web.ReloadedComponent{Name: scfg.JobName, Action: web.ReloadRestarted, Reason: "scrape pools are restarted on every reload"})
+		}
+	}
+	for _, scfg := range prev.ScrapeConfigs {
+		if _, ok := old[scfg.JobName]; ok {
+			res = append(res, web.ReloadedComponent{Name: scfg.JobName, Action: web.ReloadStopped, Reason: "scrape config removed"})
+		}
+	}
+	return res
+}
+
+// reloadedRuleGroups reports the default rule group, which is recreated from
+// the rule files on every reload.
+func reloadedRuleGroups(prev, conf *config.Config) []web.ReloadedComponent {
+	g := web.ReloadedComponent{Name: defaultRuleGroup, Action: web.ReloadRestarted}
+	switch {
+	case prev == nil:
+		g.Action = web.ReloadCreated
+		g.Reason = "initial configuration"
+	case !reflect.DeepEqual(prev.RuleFiles, conf.RuleFiles):
+		g.Reason = "rule files changed"
+	case prev.GlobalConfig.EvaluationInterval != conf.GlobalConfig.EvaluationInterval:
+		g.Reason = "evaluation interval changed"
+	default:
+		g.Reason = "rule groups are restarted on every reload"
+	}
+	return []web.ReloadedComponent{g}
+}
+
+// reloadedRemoteWriteQueues matches remote write queues by their URL. All
+// queues are recreated on every reload.
+func reloadedRemoteWriteQueues(prev, conf *config.Config) []web.ReloadedComponent {
+	old := make(map[string]*config.RemoteWriteConfig, len(prev.RemoteWriteConfigs))
+	for _, rwcfg := range prev.RemoteWriteConfigs {
+		old[rwcfg.URL.Redacted()] = rwcfg
+	}
+
+	res := []web.ReloadedComponent{}
+	for _, rwcfg := range conf.RemoteWriteConfigs {
+		name := rwcfg.URL.Redacted()
+		orwcfg, ok := old[name]
+		delete(old, name)
+
+		switch {
+		case !ok:
+			res = append(res, web.ReloadedComponent{Name: name, Action: web.ReloadCreated, Reason: "remote write config added"})
+		case !reflect.DeepEqual(orwcfg, rwcfg):
+			res = append(res, web.ReloadedComponent{Name: name, Action: web.ReloadRestarted, Reason: "remote write config changed"})
+		default:
+			res = append(res, web.ReloadedComponent{Name: name, Action: web.ReloadRestarted, Reason: "remote write queues are restarted on every reload"})
+		}
+	}
+	for _, rwcfg := range prev.RemoteWriteConfigs {
+		name := rwcfg.URL.Redacted()
+		if _, ok := old[name]; ok {
+			res = append(res, web.ReloadedComponent{Name: name, Action: web.ReloadStopped, Reason: "remote write config removed"})
+			// Report queues sharing a URL only once.
+			delete(old, name)
+		}
+	}
+	return res
+}
@@ -0,0 +1,245 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/prometheus/prometheus/storage/local"
+	"github.com/prometheus/prometheus/util/cli"
+)
+
+// backfillStepsPerQuery is the number of steps evaluated by each range
+// query, well below the resolution limit of the query API.
+const backfillStepsPerQuery = 1000
+
+var backfillRulesUsage = strings.TrimSpace(`
+usage: promtool backfill-rules -start=<time> [-end=<time>] [-step=<duration>] [-output=<dir>] <server URL> <rule file>...
+
+Evaluate the recording rules of the given rule files against the query API of
+a Prometheus server over a historical range, and write the results into a new
+local storage directory. The directory can be served by a Prometheus server
+with -storage.local.path, e.g. one that is federated or read remotely.
+
+Rules are evaluated against the data of the server only, so rules depending on
+the results of other recording rules need those to have history on the server.
+
+examples:
+
+$ promtool backfill-rules -start=2017-06-01T00:00:00Z -step=1m http://localhost:9090 rules.conf
+`)
+
+// BackfillRulesCmd evaluates recording rules over a past range and writes
+// the results into a new local storage.
+func BackfillRulesCmd(t cli.Term, args ...string) int {
+	fs := flag.NewFlagSet("backfill-rules", flag.ContinueOnError)
+	fs.SetOutput(t.Err())
+	start := fs.String("start", "", "Start time of the range as RFC3339 or Unix timestamp.")
+	end := fs.String("end", "", "End time of the range as RFC3339 or Unix timestamp. Defaults to now.")
+	step := fs.Duration("step", time.Minute, "Evaluation interval of the rules.")
+	output := fs.String("output", "data", "Local storage directory to write the results to. Must not exist or be empty.")
+	if err := fs.Parse(args); err != nil || fs.NArg() < 2 || *start == "" {
+		t.Infof("%s", backfillRulesUsage)
+		return 2
+	}
+
+	startTime, err := parseTime(*start)
+	if err != nil {
+		t.Errorf("invalid start time: %s", err)
+		return 2
+	}
+	endTime := time.Now()
+	if *end != "" {
+		if endTime, err = parseTime(*end); err != nil {
+			t.Errorf("invalid end time: %s", err)
+			return 2
+		}
+	}
+	if !startTime.Before(endTime) {
+		t.Errorf("start time must be before end time")
+		return 2
+	}
+	if *step <= 0 {
+		t.Errorf("step must be positive")
+		return 2
+	}
+
+	var rules []*promql.RecordStmt
+	for _, f := range fs.Args()[1:] {
+		rs, err := recordingRules(f)
+		if err != nil {
+			t.Errorf("error loading rules from %s: %s", f, err)
+			return 1
+		}
+		rules = append(rules, rs...)
+	}
+	if len(rules) == 0 {
+		t.Errorf("no recording rules found")
+		return 1
+	}
+
+	if files, err := ioutil.ReadDir(*output); err == nil && len(files) > 0 {
+		t.Errorf("output directory %s is not empty", *output)
+		return 1
+	} else if err != nil && !os.IsNotExist(err) {
+		t.Errorf("error reading output directory: %s", err)
+		return 1
+	}
+
+	storage := local.NewMemorySeriesStorage(&local.MemorySeriesStorageOptions{
+		TargetHeapSize:             2 * 1024 * 1024 * 1024,
+		PersistenceStoragePath:     *output,
+		PersistenceRetentionPeriod: time.Since(startTime) + 24*time.Hour,
+		HeadChunkTimeout:           5 * time.Minute,
+		CheckpointInterval:         5 * time.Minute,
+		CheckpointDirtySeriesLimit: 5000,
+		SyncStrategy:               local.Adaptive,
+		MinShrinkRatio:             0.1,
+	})
+	if err := storage.Start(); err != nil {
+		t.Errorf("error opening storage: %s", err)
+		return 1
+	}
+
+	b := &rulesBackfiller{
+		server:  fs.Arg(0),
+		start:   startTime,
+		end:     endTime,
+		step:    *step,
+		storage: storage,
+	}
+	ret := 0
+	for _, r := range rules {
+		n, err := b.backfill(r)
+		if err != nil {
+			t.Errorf("error backfilling rule %s: %s", r.Name, err)
+			ret = 1
+			break
+		}
+		t.Infof("%s: %d samples written", r.Name, n)
+	}
+	if b.skipped > 0 {
+		t.Infof("%d out-of-order or duplicate samples skipped", b.skipped)
+	}
+
+	if err := storage.Stop(); err != nil {
+		t.Errorf("error closing storage: %s", err)
+		return 1
+	}
+	return ret
+}
+
+// recordingRules returns the recording rules of a rule file.
+func recordingRules(filename string) ([]*promql.RecordStmt, error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	stmts, err := promql.ParseStmts(string(content))
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []*promql.RecordStmt
+	for _, s := range stmts {
+		if r, ok := s.(*promql.RecordStmt); ok {
+			rules = append(rules, r)
+		}
+	}
+	return rules, nil
+}
+
+// rulesBackfiller evaluates recording rules through range queries and
+// appends the results to a storage.
+type rulesBackfiller struct {
+	server     string
+	start, end time.Time
+	step       time.Duration
+	storage    *local.MemorySeriesStorage
+
+	skipped int
+}
+
+// backfill evaluates the rule over the whole range and returns the number of
+// samples written.
+func (b *rulesBackfiller) backfill(r *promql.RecordStmt) (int, error) {
+	written := 0
+	window := b.step * backfillStepsPerQuery
+	for start := b.start; !start.After(b.end); start = start.Add(window + b.step) {
+		end := start.Add(window)
+		if end.After(b.end) {
+			end = b.end
+		}
+
+		params := url.Values{
+			"query": []string{r.Expr.String()},
+			"start": []string{formatTime(start)},
+			"end":   []string{formatTime(end)},
+			// The API does not accept Go duration strings like "1m0s".
+			"step": []string{strconv.FormatFloat(b.step.Seconds(), 'f', -1, 64)},
+		}
+		data, err := apiGet(b.server, "/query_range", params)
+		if err != nil {
+			return written, err
+		}
+		var qr queryResult
+		if err := json.Unmarshal(data, &qr); err != nil {
+			return written, fmt.Errorf("error decoding query result: %s", err)
+		}
+		if qr.ResultType != model.ValMatrix {
+			return written, fmt.Errorf("unexpected result type %q", qr.ResultType)
+		}
+		var matrix model.Matrix
+		if err := json.Unmarshal(qr.Result, &matrix); err != nil {
+			return written, fmt.Errorf("error decoding query result: %s", err)
+		}
+
+		for _, ss := range matrix {
+			m := make(model.Metric, len(ss.Metric)+len(r.Labels)+1)
+			for ln, lv := range ss.Metric {
+				m[ln] = lv
+			}
+			m[model.MetricNameLabel] = model.LabelValue(r.Name)
+			for ln, lv := range r.Labels {
+				m[ln] = lv
+			}
+
+			for _, sp := range ss.Values {
+				for b.storage.NeedsThrottling() {
+					time.Sleep(100 * time.Millisecond)
+				}
+				err := b.storage.Append(&model.Sample{Metric: m, Value: sp.Value, Timestamp: sp.Timestamp})
+				switch err {
+				case nil:
+					written++
+				case local.ErrOutOfOrderSample, local.ErrDuplicateSampleForTimestamp:
+					b.skipped++
+				default:
+					return written, err
+				}
+			}
+		}
+	}
+	return written, nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/common/route"
+	"golang.org/x/net/context"
+
+	"github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/prometheus/prometheus/storage/local"
+	"github.com/prometheus/prometheus/storage/metric"
+	"github.com/prometheus/prometheus/web/api/v1"
+)
+
+func TestRulesBackfiller(t *testing.T) {
+	suite, err := promql.NewTest(t, `
+		load 1m
+			test_metric{job="a"} 0+1x60
+			test_metric{job="b"} 0+2x60
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer suite.Close()
+	if err := suite.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	api := v1.NewAPI(
+		suite.QueryEngine(), suite.Storage(), nil, nil, nil, nil,
+		func() config.Config { return config.Config{} },
+		false, false, v1.QueryLimits{}, v1.QueryCacheOptions{},
+		nil, nil, nil, nil, regexp.MustCompile(".*"), "", "", nil,
+	)
+	router := route.New()
+	api.Register(router.WithPrefix("/api/v1"))
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	stmts, err := promql.ParseStmts(`job:test_metric:sum = sum(test_metric)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	storage, closer := local.NewTestStorage(t, 2)
+	defer closer.Close()
+
+	b := &rulesBackfiller{
+		server: server.URL,
+		start:  time.Unix(0, 0),
+		end:    time.Unix(3600, 0),
+		// The default step of the command.
+		step:    time.Minute,
+		storage: storage,
+	}
+	n, err := b.backfill(stmts[0].(*promql.RecordStmt))
+	if err != nil {
+		t.Fatalf("Error backfilling: %s", err)
+	}
+	if n != 61 {
+		t.Errorf("Expected 61 samples written, got %d", n)
+	}
+	storage.WaitForIndexing()
+
+	lm, err := metric.NewLabelMatcher(metric.Equal, model.MetricNameLabel, "job:test_metric:sum")
+	if err != nil {
+		t.Fatal(err)
+	}
+	its, err := storage.QueryRange(context.Background(), model.Earliest, model.Latest, lm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(its) != 1 {
+		t.Fatalf("Expected one backfilled series, got %d", len(its))
+	}
+	v := its[0].ValueAtOrBeforeTime(model.TimeFromUnix(1800))
+	if v.Value != 90 {
+		t.Errorf("Expected backfilled value 90 at 30m, got %v", v.Value)
+	}
+	its[0].Close()
+}
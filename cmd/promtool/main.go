@@ -14,51 +14,147 @@
 package main
 
 import (
+	"encoding/json"
+	"flag"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
+	"text/tabwriter"
+	"time"
 
+	"github.com/prometheus/common/model"
 	"github.com/prometheus/common/version"
 	"github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/discovery/file"
 	"github.com/prometheus/prometheus/promql"
+	"github.com/prometheus/prometheus/storage/local"
 	"github.com/prometheus/prometheus/util/cli"
 	"github.com/prometheus/prometheus/util/promlint"
 )
 
+// configCheckResult is the outcome of checking a configuration file and the
+// files it references.
+type configCheckResult struct {
+	File      string            `json:"file"`
+	Success   bool              `json:"success"`
+	Error     string            `json:"error,omitempty"`
+	RuleFiles []fileCheckResult `json:"ruleFiles"`
+	SDFiles   []fileCheckResult `json:"sdFiles"`
+}
+
+// fileCheckResult is the outcome of checking a rule or file_sd file.
+type fileCheckResult struct {
+	File    string `json:"file"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+	// The number of rules or target groups found.
+	Count int `json:"count"`
+}
+
+func newFileCheckResult(file string, n int, err error) fileCheckResult {
+	res := fileCheckResult{File: file, Success: err == nil, Count: n}
+	if err != nil {
+		res.Error = err.Error()
+	}
+	return res
+}
+
+var checkConfigUsage = strings.TrimSpace(`
+usage: promtool check-config [-output=text|json] <files>
+
+Validate configuration files along with the rule files, file_sd files, TLS
+certificates and bearer token files they reference.
+`)
+
 // CheckConfigCmd validates configuration files.
 func CheckConfigCmd(t cli.Term, args ...string) int {
-	if len(args) == 0 {
-		t.Infof("usage: promtool check-config <files>")
+	fs := flag.NewFlagSet("check-config", flag.ContinueOnError)
+	fs.SetOutput(t.Err())
+	output := fs.String("output", "text", "Output format, one of text or json.")
+	if err := fs.Parse(args); err != nil || fs.NArg() == 0 || (*output != "text" && *output != "json") {
+		t.Infof("%s", checkConfigUsage)
 		return 2
 	}
-	failed := false
 
-	for _, arg := range args {
-		ruleFiles, err := checkConfig(t, arg)
-		if err != nil {
-			t.Errorf("  FAILED: %s", err)
-			failed = true
+	var results []configCheckResult
+	for _, arg := range fs.Args() {
+		results = append(results, checkConfigFile(arg))
+	}
+
+	if *output == "json" {
+		enc := json.NewEncoder(t.Out())
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(results); err != nil {
+			t.Errorf("error encoding results: %s", err)
+			return 1
+		}
+	} else {
+		printConfigCheckResults(t, results)
+	}
+
+	for _, res := range results {
+		if !res.Success {
+			return 1
+		}
+	}
+	return 0
+}
+
+func printConfigCheckResults(t cli.Term, results []configCheckResult) {
+	printFile := func(kind string, res fileCheckResult) {
+		t.Infof("Checking %s", res.File)
+		if res.Success {
+			t.Infof("  SUCCESS: %d %s found", res.Count, kind)
 		} else {
-			t.Infof("  SUCCESS: %d rule files found", len(ruleFiles))
+			t.Errorf("  FAILED: %s", res.Error)
 		}
 		t.Infof("")
+	}
 
-		for _, rf := range ruleFiles {
-			if n, err := checkRules(t, rf); err != nil {
-				t.Errorf("  FAILED: %s", err)
-				failed = true
-			} else {
-				t.Infof("  SUCCESS: %d rules found", n)
-			}
-			t.Infof("")
+	for _, res := range results {
+		t.Infof("Checking %s", res.File)
+		if res.Error != "" {
+			t.Errorf("  FAILED: %s", res.Error)
+		} else {
+			t.Infof("  SUCCESS: %d rule files found", len(res.RuleFiles))
+		}
+		t.Infof("")
+
+		for _, rf := range res.RuleFiles {
+			printFile("rules", rf)
+		}
+		for _, sf := range res.SDFiles {
+			printFile("target groups", sf)
 		}
 	}
-	if failed {
-		return 1
+}
+
+// checkConfigFile checks a configuration file and recursively all rule and
+// file_sd files it references.
+func checkConfigFile(filename string) configCheckResult {
+	res := configCheckResult{File: filename}
+
+	ruleFiles, sdFiles, err := checkConfig(filename)
+	if err != nil {
+		res.Error = err.Error()
+		return res
 	}
-	return 0
+	res.Success = true
+
+	for _, rf := range ruleFiles {
+		n, err := checkRules(rf)
+		res.RuleFiles = append(res.RuleFiles, newFileCheckResult(rf, n, err))
+		res.Success = res.Success && err == nil
+	}
+	for _, sf := range sdFiles {
+		n, err := checkSDFile(sf)
+		res.SDFiles = append(res.SDFiles, newFileCheckResult(sf, n, err))
+		res.Success = res.Success && err == nil
+	}
+	return res
 }
 
 func checkFileExists(fn string) error {
@@ -70,58 +166,253 @@ func checkFileExists(fn string) error {
 	return err
 }
 
-func checkConfig(t cli.Term, filename string) ([]string, error) {
-	t.Infof("Checking %s", filename)
+// checkSecretFile checks the existence of a secret file. References to
+// secret providers, e.g. env://NAME, are not checked, as loading the
+// configuration already rejected unregistered providers.
+func checkSecretFile(fn, name string) error {
+	if strings.Contains(fn, "://") {
+		return nil
+	}
+	if err := checkFileExists(fn); err != nil {
+		return fmt.Errorf("error checking %s file %q: %s", name, fn, err)
+	}
+	return nil
+}
 
+// checkConfig loads the configuration file and checks the existence of the
+// files it references. It returns the rule files and the file_sd files found.
+// Loading the configuration already rejects duplicate job names.
+func checkConfig(filename string) ([]string, []string, error) {
 	if stat, err := os.Stat(filename); err != nil {
-		return nil, fmt.Errorf("cannot get file info")
+		return nil, nil, fmt.Errorf("cannot get file info")
 	} else if stat.IsDir() {
-		return nil, fmt.Errorf("is a directory")
+		return nil, nil, fmt.Errorf("is a directory")
 	}
 
 	cfg, err := config.LoadFile(filename)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var ruleFiles []string
 	for _, rf := range cfg.RuleFiles {
 		rfs, err := filepath.Glob(rf)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		// If an explicit file was given, error if it is not accessible.
 		if !strings.Contains(rf, "*") {
 			if len(rfs) == 0 {
-				return nil, fmt.Errorf("%q does not point to an existing file", rf)
+				return nil, nil, fmt.Errorf("%q does not point to an existing file", rf)
 			}
 			if err := checkFileExists(rfs[0]); err != nil {
-				return nil, fmt.Errorf("error checking rule file %q: %s", rfs[0], err)
+				return nil, nil, fmt.Errorf("error checking rule file %q: %s", rfs[0], err)
 			}
 		}
 		ruleFiles = append(ruleFiles, rfs...)
 	}
 
+	var sdFiles []string
 	for _, scfg := range cfg.ScrapeConfigs {
-		if err := checkFileExists(scfg.HTTPClientConfig.BearerTokenFile); err != nil {
-			return nil, fmt.Errorf("error checking bearer token file %q: %s", scfg.HTTPClientConfig.BearerTokenFile, err)
+		if err := checkHTTPClientConfig(scfg.HTTPClientConfig); err != nil {
+			return nil, nil, fmt.Errorf("scrape config %q: %s", scfg.JobName, err)
+		}
+		files, err := checkServiceDiscoveryConfig(scfg.ServiceDiscoveryConfig)
+		if err != nil {
+			return nil, nil, fmt.Errorf("scrape config %q: %s", scfg.JobName, err)
 		}
+		sdFiles = append(sdFiles, files...)
+	}
 
-		if err := checkTLSConfig(scfg.HTTPClientConfig.TLSConfig); err != nil {
-			return nil, err
+	for i, amcfg := range cfg.AlertingConfig.AlertmanagerConfigs {
+		if err := checkHTTPClientConfig(amcfg.HTTPClientConfig); err != nil {
+			return nil, nil, fmt.Errorf("alertmanager config %d: %s", i, err)
+		}
+		files, err := checkServiceDiscoveryConfig(amcfg.ServiceDiscoveryConfig)
+		if err != nil {
+			return nil, nil, fmt.Errorf("alertmanager config %d: %s", i, err)
+		}
+		sdFiles = append(sdFiles, files...)
+	}
+
+	for i, rwcfg := range cfg.RemoteWriteConfigs {
+		if err := checkHTTPClientConfig(rwcfg.HTTPClientConfig); err != nil {
+			return nil, nil, fmt.Errorf("remote write config %d: %s", i, err)
+		}
+		if rwcfg.SigV4Config != nil {
+			if err := checkSecretFile(rwcfg.SigV4Config.SecretKeyFile, "secret key"); err != nil {
+				return nil, nil, fmt.Errorf("remote write config %d: %s", i, err)
+			}
+		}
+		if rwcfg.AzureADConfig != nil && rwcfg.AzureADConfig.OAuth != nil {
+			if err := checkSecretFile(rwcfg.AzureADConfig.OAuth.ClientSecretFile, "client secret"); err != nil {
+				return nil, nil, fmt.Errorf("remote write config %d: %s", i, err)
+			}
+		}
+		if rwcfg.GoogleIAMConfig != nil {
+			if err := checkFileExists(rwcfg.GoogleIAMConfig.CredentialsFile); err != nil {
+				return nil, nil, fmt.Errorf("remote write config %d: error checking credentials file %q: %s", i, rwcfg.GoogleIAMConfig.CredentialsFile, err)
+			}
+		}
+	}
+	for i, rrcfg := range cfg.RemoteReadConfigs {
+		if err := checkHTTPClientConfig(rrcfg.HTTPClientConfig); err != nil {
+			return nil, nil, fmt.Errorf("remote read config %d: %s", i, err)
+		}
+	}
+
+	return ruleFiles, sdFiles, nil
+}
+
+func checkHTTPClientConfig(cfg config.HTTPClientConfig) error {
+	if err := checkSecretFile(cfg.BearerTokenFile, "bearer token"); err != nil {
+		return err
+	}
+	if cfg.BasicAuth != nil {
+		if err := checkSecretFile(cfg.BasicAuth.PasswordFile, "password"); err != nil {
+			return err
 		}
+	}
+	if cfg.OAuth2 != nil {
+		if err := checkSecretFile(cfg.OAuth2.ClientSecretFile, "client secret"); err != nil {
+			return err
+		}
+	}
+	return checkTLSConfig(cfg.TLSConfig)
+}
 
-		for _, kd := range scfg.ServiceDiscoveryConfig.KubernetesSDConfigs {
-			if err := checkTLSConfig(kd.TLSConfig); err != nil {
+// checkServiceDiscoveryConfig checks the files referenced by the service
+// discovery configuration and returns the file_sd files currently matching
+// its patterns.
+func checkServiceDiscoveryConfig(cfg config.ServiceDiscoveryConfig) ([]string, error) {
+	for _, kd := range cfg.KubernetesSDConfigs {
+		if err := checkSecretFile(kd.BearerTokenFile, "bearer token"); err != nil {
+			return nil, err
+		}
+		if kd.BasicAuth != nil {
+			if err := checkSecretFile(kd.BasicAuth.PasswordFile, "password"); err != nil {
 				return nil, err
 			}
 		}
+		if err := checkTLSConfig(kd.TLSConfig); err != nil {
+			return nil, err
+		}
+	}
+	for _, md := range cfg.MarathonSDConfigs {
+		if err := checkSecretFile(md.BearerTokenFile, "bearer token"); err != nil {
+			return nil, err
+		}
+		if err := checkTLSConfig(md.TLSConfig); err != nil {
+			return nil, err
+		}
+	}
+	for _, cd := range cfg.ConsulSDConfigs {
+		if err := checkSecretFile(cd.TokenFile, "token"); err != nil {
+			return nil, err
+		}
+		if err := checkSecretFile(cd.PasswordFile, "password"); err != nil {
+			return nil, err
+		}
+		if err := checkTLSConfig(cd.TLSConfig); err != nil {
+			return nil, err
+		}
+	}
+	for _, ed := range cfg.EC2SDConfigs {
+		if err := checkSecretFile(ed.SecretKeyFile, "secret key"); err != nil {
+			return nil, err
+		}
+	}
+	for _, ed := range cfg.ECSSDConfigs {
+		if err := checkSecretFile(ed.SecretKeyFile, "secret key"); err != nil {
+			return nil, err
+		}
+	}
+	for _, od := range cfg.OpenstackSDConfigs {
+		if err := checkSecretFile(od.PasswordFile, "password"); err != nil {
+			return nil, err
+		}
+	}
+	for _, ad := range cfg.AzureSDConfigs {
+		if err := checkSecretFile(ad.ClientSecretFile, "client secret"); err != nil {
+			return nil, err
+		}
+	}
+	for _, td := range cfg.TritonSDConfigs {
+		if err := checkTLSConfig(td.TLSConfig); err != nil {
+			return nil, err
+		}
+	}
+	for _, hd := range cfg.HetznerSDConfigs {
+		if err := checkHTTPClientConfig(hd.HTTPClientConfig); err != nil {
+			return nil, err
+		}
+	}
+	for _, ld := range cfg.LinodeSDConfigs {
+		if err := checkHTTPClientConfig(ld.HTTPClientConfig); err != nil {
+			return nil, err
+		}
+	}
+	for _, dd := range cfg.DigitalOceanSDConfigs {
+		if err := checkHTTPClientConfig(dd.HTTPClientConfig); err != nil {
+			return nil, err
+		}
+	}
+	for _, sd := range cfg.ScalewaySDConfigs {
+		if err := checkSecretFile(sd.SecretKeyFile, "secret key"); err != nil {
+			return nil, err
+		}
+	}
+	for _, pd := range cfg.PuppetDBSDConfigs {
+		if err := checkHTTPClientConfig(pd.HTTPClientConfig); err != nil {
+			return nil, err
+		}
+	}
+	for _, ed := range cfg.EurekaSDConfigs {
+		if err := checkHTTPClientConfig(ed.HTTPClientConfig); err != nil {
+			return nil, err
+		}
+	}
+	for _, nd := range cfg.NomadSDConfigs {
+		if err := checkHTTPClientConfig(nd.HTTPClientConfig); err != nil {
+			return nil, err
+		}
+	}
+	for _, ud := range cfg.UyuniSDConfigs {
+		if err := checkSecretFile(ud.PasswordFile, "password"); err != nil {
+			return nil, err
+		}
+		if err := checkHTTPClientConfig(ud.HTTPClientConfig); err != nil {
+			return nil, err
+		}
+	}
+	for _, vd := range cfg.VultrSDConfigs {
+		if err := checkHTTPClientConfig(vd.HTTPClientConfig); err != nil {
+			return nil, err
+		}
+	}
+	for _, kd := range cfg.KumaSDConfigs {
+		if err := checkHTTPClientConfig(kd.HTTPClientConfig); err != nil {
+			return nil, err
+		}
 	}
 
-	return ruleFiles, nil
+	var files []string
+	for _, fd := range cfg.FileSDConfigs {
+		for _, pattern := range fd.Files {
+			matches, err := filepath.Glob(pattern)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, matches...)
+		}
+	}
+	return files, nil
 }
 
 func checkTLSConfig(tlsConfig config.TLSConfig) error {
+	if err := checkFileExists(tlsConfig.CAFile); err != nil {
+		return fmt.Errorf("error checking CA file %q: %s", tlsConfig.CAFile, err)
+	}
 	if err := checkFileExists(tlsConfig.CertFile); err != nil {
 		return fmt.Errorf("error checking client cert file %q: %s", tlsConfig.CertFile, err)
 	}
@@ -139,6 +430,23 @@ func checkTLSConfig(tlsConfig config.TLSConfig) error {
 	return nil
 }
 
+// checkSDFile validates a file_sd file and returns the number of target groups
+// it contains.
+func checkSDFile(filename string) (int, error) {
+	tgs, err := file.ReadFile(filename)
+	if err != nil {
+		return 0, err
+	}
+	for i, tg := range tgs {
+		for _, t := range tg.Targets {
+			if t[model.AddressLabel] == "" {
+				return 0, fmt.Errorf("target group %d contains an empty target", i)
+			}
+		}
+	}
+	return len(tgs), nil
+}
+
 // CheckRulesCmd validates rule files.
 func CheckRulesCmd(t cli.Term, args ...string) int {
 	if len(args) == 0 {
@@ -148,7 +456,8 @@ func CheckRulesCmd(t cli.Term, args ...string) int {
 	failed := false
 
 	for _, arg := range args {
-		if n, err := checkRules(t, arg); err != nil {
+		t.Infof("Checking %s", arg)
+		if n, err := checkRules(arg); err != nil {
 			t.Errorf("  FAILED: %s", err)
 			failed = true
 		} else {
@@ -162,9 +471,7 @@ func CheckRulesCmd(t cli.Term, args ...string) int {
 	return 0
 }
 
-func checkRules(t cli.Term, filename string) (int, error) {
-	t.Infof("Checking %s", filename)
-
+func checkRules(filename string) (int, error) {
 	if stat, err := os.Stat(filename); err != nil {
 		return 0, fmt.Errorf("cannot get file info")
 	} else if stat.IsDir() {
@@ -187,6 +494,9 @@ var checkMetricsUsage = strings.TrimSpace(`
 usage: promtool check-metrics
 
 Pass Prometheus metrics over stdin to lint them for consistency and correctness.
+The metrics are checked against the naming best practices, e.g. counter and
+unit suffixes, for duplicated help texts and for labels with many values.
+The exit code is 3 if any problems are found.
 
 examples:
 
@@ -219,6 +529,120 @@ func CheckMetricsCmd(t cli.Term, args ...string) int {
 	return 0
 }
 
+// StorageListCmd lists the series directories of a local storage.
+func StorageListCmd(t cli.Term, args ...string) int {
+	if len(args) != 1 {
+		t.Infof("usage: promtool storage-list <storage path>")
+		return 2
+	}
+	dirs, err := local.ListSeriesDirs(args[0])
+	if err != nil {
+		t.Errorf("  FAILED: %s", err)
+		return 1
+	}
+
+	tw := tabwriter.NewWriter(t.Out(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "DIRECTORY\tSERIES FILES\tCHUNKS\tBYTES\tMIN TIME\tMAX TIME\tOVERLAPPING CHUNKS")
+	for _, d := range dirs {
+		printSeriesDirStats(tw, d)
+	}
+	tw.Flush()
+	return 0
+}
+
+func printSeriesDirStats(w io.Writer, d local.SeriesDirStats) {
+	minTime, maxTime := "-", "-"
+	if d.Chunks > 0 {
+		minTime = d.MinTime.Time().UTC().Format(time.RFC3339)
+		maxTime = d.MaxTime.Time().UTC().Format(time.RFC3339)
+	}
+	fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%s\t%s\t%d\n",
+		d.Dir, d.SeriesFiles, d.Chunks, d.Bytes, minTime, maxTime, d.OverlappingChunks)
+}
+
+var storageAnalyzeUsage = strings.TrimSpace(`
+usage: promtool storage-analyze [-limit=N] <storage path>
+
+Report statistics about the series files of a local storage, and the label
+cardinality of the series in its last checkpoint. The storage is not locked,
+so the statistics of a running server may be slightly inconsistent.
+`)
+
+// StorageAnalyzeCmd analyzes the series files and checkpoint of a local
+// storage.
+func StorageAnalyzeCmd(t cli.Term, args ...string) int {
+	fs := flag.NewFlagSet("storage-analyze", flag.ContinueOnError)
+	fs.SetOutput(t.Err())
+	limit := fs.Int("limit", 20, "How many items to show in each list.")
+	if err := fs.Parse(args); err != nil || fs.NArg() != 1 || *limit <= 0 {
+		t.Infof("%s", storageAnalyzeUsage)
+		return 2
+	}
+	a, err := local.AnalyzeStorage(fs.Arg(0), *limit)
+	if err != nil {
+		t.Errorf("  FAILED: %s", err)
+		return 1
+	}
+
+	tw := tabwriter.NewWriter(t.Out(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "DIRECTORY\tSERIES FILES\tCHUNKS\tBYTES\tMIN TIME\tMAX TIME\tOVERLAPPING CHUNKS")
+	printSeriesDirStats(tw, a.SeriesFiles)
+	tw.Flush()
+
+	fmt.Fprintf(t.Out(), "\nCheckpoint series: %d\n", a.CheckpointSeries.NumSeries)
+	printStatsEntries(t.Out(), "Highest cardinality metric names", a.CheckpointSeries.SeriesCountByMetricName)
+	printStatsEntries(t.Out(), "Highest cardinality labels", a.CheckpointSeries.LabelValueCountByLabelName)
+	printStatsEntries(t.Out(), "Most common label pairs", a.CheckpointSeries.SeriesCountByLabelValuePair)
+	return 0
+}
+
+func printStatsEntries(w io.Writer, title string, entries []local.StatsEntry) {
+	fmt.Fprintf(w, "\n%s:\n", title)
+	for _, e := range entries {
+		fmt.Fprintf(w, "%d %s\n", e.Value, e.Name)
+	}
+}
+
+var storageRepairUsage = strings.TrimSpace(`
+usage: promtool storage-repair <storage path>
+
+Repair a local storage offline. The crash recovery is run with pedantic checks
+of each series file, and the indexes are rebuilt from the series files.
+Corrupted indexes and a corrupted heads file are moved into the 'corrupted'
+sub-directory, series files which cannot be recovered into the 'orphaned'
+sub-directory. The Prometheus server using the storage must be stopped.
+`)
+
+// StorageRepairCmd repairs a local storage and reports what was quarantined.
+func StorageRepairCmd(t cli.Term, args ...string) int {
+	if len(args) != 1 {
+		t.Infof("%s", storageRepairUsage)
+		return 2
+	}
+	report, err := local.RepairStorage(args[0])
+	if err != nil {
+		t.Errorf("  FAILED: storage cannot be repaired: %s", err)
+		return 1
+	}
+
+	if len(report.Corrupted) == 0 && len(report.Orphaned) == 0 {
+		t.Infof("  SUCCESS: no corruption found")
+		return 0
+	}
+	for _, c := range report.Corrupted {
+		t.Infof("  QUARANTINED: %s", c)
+	}
+	for _, o := range report.Orphaned {
+		t.Errorf("  IRREPARABLE: %s %s: %s", o.Path, o.Metric, o.Reason)
+	}
+	if len(report.Orphaned) > 0 {
+		t.Errorf("  WARNING: storage repaired, the data of %d series files is lost", len(report.Orphaned))
+		return 3
+	}
+	t.Infof("  SUCCESS: storage repaired")
+	return 0
+}
+
 // VersionCmd prints the binaries version information.
 func VersionCmd(t cli.Term, _ ...string) int {
 	fmt.Fprintln(os.Stdout, version.Print("promtool"))
@@ -229,7 +653,7 @@ func main() {
 	app := cli.NewApp("promtool")
 
 	app.Register("check-config", &cli.Command{
-		Desc: "validate configuration files for correctness",
+		Desc: "validate configuration files and the files they reference for correctness",
 		Run:  CheckConfigCmd,
 	})
 
@@ -243,6 +667,56 @@ func main() {
 		Run:  CheckMetricsCmd,
 	})
 
+	app.Register("storage-list", &cli.Command{
+		Desc: "list the series directories of a local storage",
+		Run:  StorageListCmd,
+	})
+
+	app.Register("storage-analyze", &cli.Command{
+		Desc: "analyze the series files and label cardinality of a local storage",
+		Run:  StorageAnalyzeCmd,
+	})
+
+	app.Register("storage-repair", &cli.Command{
+		Desc: "repair a local storage offline, quarantining corrupted data",
+		Run:  StorageRepairCmd,
+	})
+
+	app.Register("query-instant", &cli.Command{
+		Desc: "run an instant query against a Prometheus server",
+		Run:  QueryInstantCmd,
+	})
+
+	app.Register("query-range", &cli.Command{
+		Desc: "run a range query against a Prometheus server",
+		Run:  QueryRangeCmd,
+	})
+
+	app.Register("query-series", &cli.Command{
+		Desc: "list the series matching selectors on a Prometheus server",
+		Run:  QuerySeriesCmd,
+	})
+
+	app.Register("query-labels", &cli.Command{
+		Desc: "list label names or label values on a Prometheus server",
+		Run:  QueryLabelsCmd,
+	})
+
+	app.Register("backfill-rules", &cli.Command{
+		Desc: "evaluate recording rules over a past range into a new local storage",
+		Run:  BackfillRulesCmd,
+	})
+
+	app.Register("promql-format", &cli.Command{
+		Desc: "print a PromQL expression in its canonical form",
+		Run:  PromQLFormatCmd,
+	})
+
+	app.Register("promql-parse", &cli.Command{
+		Desc: "print the syntax tree of a PromQL expression",
+		Run:  PromQLParseCmd,
+	})
+
 	app.Register("version", &cli.Command{
 		Desc: "print the version of this binary",
 		Run:  VersionCmd,
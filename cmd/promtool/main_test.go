@@ -0,0 +1,71 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckConfigSecretProviders(t *testing.T) {
+	dir, err := ioutil.TempDir("", "promtool_check_config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		passwordFile string
+		err          string
+	}{
+		{
+			passwordFile: "env://TEST_PROMTOOL_PASSWORD",
+		},
+		{
+			passwordFile: "vault://prometheus/password",
+			err:          `unknown secret provider "vault" in password_file`,
+		},
+		{
+			passwordFile: "missing_password",
+			err:          "error checking password file",
+		},
+	}
+	for i, test := range tests {
+		fn := filepath.Join(dir, "prometheus.yml")
+		cfg := `
+scrape_configs:
+- job_name: prometheus
+  basic_auth:
+    username: user
+    password_file: ` + test.passwordFile + `
+`
+		if err := ioutil.WriteFile(fn, []byte(cfg), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		_, _, err := checkConfig(fn)
+		if test.err == "" {
+			if err != nil {
+				t.Errorf("%d. Unexpected error checking config: %s", i, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%d. Expected error containing %q, got %v", i, test.err, err)
+		}
+	}
+}
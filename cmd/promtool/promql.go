@@ -0,0 +1,212 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/prometheus/prometheus/storage/metric"
+	"github.com/prometheus/prometheus/util/cli"
+)
+
+// readExpr returns the expression given as the only argument, or read from
+// stdin if there is none.
+func readExpr(fs *flag.FlagSet, stdin io.Reader) (string, error) {
+	if fs.NArg() == 1 {
+		return fs.Arg(0), nil
+	}
+	b, err := ioutil.ReadAll(stdin)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+var promqlFormatUsage = strings.TrimSpace(`
+usage: promtool promql-format [<expression>]
+
+Print the given expression, or the one read from stdin, in its canonical form.
+
+examples:
+
+$ promtool promql-format 'sum by(job)(rate(http_requests_total[5m]))'
+sum(rate(http_requests_total[5m])) BY (job)
+`)
+
+// PromQLFormatCmd prints an expression in its canonical form.
+func PromQLFormatCmd(t cli.Term, args ...string) int {
+	fs := flag.NewFlagSet("promql-format", flag.ContinueOnError)
+	fs.SetOutput(t.Err())
+	if err := fs.Parse(args); err != nil || fs.NArg() > 1 {
+		t.Infof("%s", promqlFormatUsage)
+		return 2
+	}
+
+	input, err := readExpr(fs, os.Stdin)
+	if err != nil {
+		t.Errorf("error reading expression: %s", err)
+		return 1
+	}
+	expr, err := promql.ParseExpr(input)
+	if err != nil {
+		t.Errorf("error parsing expression: %s", err)
+		return 1
+	}
+	fmt.Fprintln(t.Out(), expr)
+	return 0
+}
+
+var promqlParseUsage = strings.TrimSpace(`
+usage: promtool promql-parse [-output=json|tree] [<expression>]
+
+Parse the given expression, or the one read from stdin, and print its syntax
+tree.
+`)
+
+// PromQLParseCmd prints the syntax tree of an expression.
+func PromQLParseCmd(t cli.Term, args ...string) int {
+	fs := flag.NewFlagSet("promql-parse", flag.ContinueOnError)
+	fs.SetOutput(t.Err())
+	output := fs.String("output", "json", "Output format, one of json or tree.")
+	if err := fs.Parse(args); err != nil || fs.NArg() > 1 || (*output != "json" && *output != "tree") {
+		t.Infof("%s", promqlParseUsage)
+		return 2
+	}
+
+	input, err := readExpr(fs, os.Stdin)
+	if err != nil {
+		t.Errorf("error reading expression: %s", err)
+		return 1
+	}
+	expr, err := promql.ParseExpr(input)
+	if err != nil {
+		t.Errorf("error parsing expression: %s", err)
+		return 1
+	}
+
+	if *output == "tree" {
+		fmt.Fprint(t.Out(), promql.Tree(expr))
+		return 0
+	}
+	b, err := json.MarshalIndent(astNode(expr), "", "  ")
+	if err != nil {
+		t.Errorf("error encoding syntax tree: %s", err)
+		return 1
+	}
+	fmt.Fprintln(t.Out(), string(b))
+	return 0
+}
+
+// astNode returns a JSON encodable representation of an expression. Every
+// node holds its type and the type of the value it evaluates to.
+func astNode(expr promql.Expr) map[string]interface{} {
+	n := map[string]interface{}{
+		"valueType": expr.Type().String(),
+	}
+	switch e := expr.(type) {
+	case *promql.AggregateExpr:
+		n["type"] = "aggregateExpr"
+		n["op"] = fmt.Sprint(e.Op)
+		n["expr"] = astNode(e.Expr)
+		if e.Param != nil {
+			n["param"] = astNode(e.Param)
+		}
+		n["grouping"] = labelNames(e.Grouping)
+		n["without"] = e.Without
+		n["keepCommonLabels"] = e.KeepCommonLabels
+	case *promql.BinaryExpr:
+		n["type"] = "binaryExpr"
+		n["op"] = fmt.Sprint(e.Op)
+		n["lhs"] = astNode(e.LHS)
+		n["rhs"] = astNode(e.RHS)
+		n["returnBool"] = e.ReturnBool
+		if vm := e.VectorMatching; vm != nil {
+			n["vectorMatching"] = map[string]interface{}{
+				"card":           vm.Card.String(),
+				"matchingLabels": labelNames(vm.MatchingLabels),
+				"on":             vm.On,
+				"include":        labelNames(vm.Include),
+			}
+		}
+	case *promql.Call:
+		n["type"] = "call"
+		n["func"] = e.Func.Name
+		args := make([]interface{}, 0, len(e.Args))
+		for _, a := range e.Args {
+			args = append(args, astNode(a))
+		}
+		n["args"] = args
+	case *promql.MatrixSelector:
+		n["type"] = "matrixSelector"
+		n["name"] = e.Name
+		n["range"] = durationString(e.Range)
+		n["offset"] = durationString(e.Offset)
+		n["matchers"] = labelMatchers(e.LabelMatchers)
+	case *promql.NumberLiteral:
+		n["type"] = "numberLiteral"
+		n["val"] = e.Val.String()
+	case *promql.ParenExpr:
+		n["type"] = "parenExpr"
+		n["expr"] = astNode(e.Expr)
+	case *promql.StringLiteral:
+		n["type"] = "stringLiteral"
+		n["val"] = e.Val
+	case *promql.UnaryExpr:
+		n["type"] = "unaryExpr"
+		n["op"] = fmt.Sprint(e.Op)
+		n["expr"] = astNode(e.Expr)
+	case *promql.VectorSelector:
+		n["type"] = "vectorSelector"
+		n["name"] = e.Name
+		n["offset"] = durationString(e.Offset)
+		n["matchers"] = labelMatchers(e.LabelMatchers)
+	}
+	return n
+}
+
+func labelNames(lns model.LabelNames) []string {
+	res := make([]string, 0, len(lns))
+	for _, ln := range lns {
+		res = append(res, string(ln))
+	}
+	return res
+}
+
+func labelMatchers(ms []*metric.LabelMatcher) []map[string]string {
+	res := make([]map[string]string, 0, len(ms))
+	for _, m := range ms {
+		res = append(res, map[string]string{
+			"type":  m.Type.String(),
+			"name":  string(m.Name),
+			"value": string(m.Value),
+		})
+	}
+	return res
+}
+
+func durationString(d time.Duration) string {
+	if d == 0 {
+		return "0s"
+	}
+	return model.Duration(d).String()
+}
@@ -0,0 +1,104 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/prometheus/util/cli"
+)
+
+func TestReadExpr(t *testing.T) {
+	f, err := ioutil.TempFile("", "promtool_expr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+	if _, err := f.WriteString("rate(from_file[5m])\n"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Seek(0, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		args     []string
+		stdin    []byte
+		file     bool
+		expected string
+	}{
+		{
+			// The argument takes precedence over stdin.
+			args:     []string{"from_argument"},
+			stdin:    []byte("from_stdin"),
+			expected: "from_argument",
+		},
+		{
+			stdin:    []byte("sum(from_stdin)"),
+			expected: "sum(from_stdin)",
+		},
+		{
+			// Stdin redirected from a file, as in `promtool promql-format < expr`.
+			file:     true,
+			expected: "rate(from_file[5m])\n",
+		},
+	}
+	for i, test := range tests {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		if err := fs.Parse(test.args); err != nil {
+			t.Fatal(err)
+		}
+		var stdin io.Reader = bytes.NewReader(test.stdin)
+		if test.file {
+			stdin = f
+		}
+		expr, err := readExpr(fs, stdin)
+		if err != nil {
+			t.Fatalf("%d. Unexpected error: %s", i, err)
+		}
+		if expr != test.expected {
+			t.Errorf("%d. Expected expression %q, got %q", i, test.expected, expr)
+		}
+	}
+}
+
+func TestPromQLFormat(t *testing.T) {
+	var out, errOut bytes.Buffer
+	term := cli.BasicTerm(&out, &errOut)
+	if code := PromQLFormatCmd(term, `sum by(job)(rate(http_requests_total[5m]))`); code != 0 {
+		t.Fatalf("Unexpected exit code %d: %s", code, errOut.String())
+	}
+	if expected := "sum(rate(http_requests_total[5m])) BY (job)"; strings.TrimSpace(out.String()) != expected {
+		t.Errorf("Expected %q, got %q", expected, out.String())
+	}
+
+	errOut.Reset()
+	if code := PromQLFormatCmd(term, `sum(`); code != 1 {
+		t.Errorf("Expected exit code 1 for invalid expression, got %d", code)
+	}
+	if !strings.Contains(errOut.String(), "error parsing expression") {
+		t.Errorf("Unexpected error output %q", errOut.String())
+	}
+
+	if code := PromQLFormatCmd(term, "a", "b"); code != 2 {
+		t.Errorf("Expected exit code 2 for too many arguments, got %d", code)
+	}
+}
@@ -0,0 +1,404 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/prometheus/prometheus/util/cli"
+)
+
+// queryTimeout is the timeout of requests to the query API.
+const queryTimeout = 2 * time.Minute
+
+// apiResponse is the envelope of all query API responses.
+type apiResponse struct {
+	Status    string          `json:"status"`
+	Data      json.RawMessage `json:"data"`
+	ErrorType string          `json:"errorType"`
+	Error     string          `json:"error"`
+}
+
+// queryResult is the data of query and query_range responses.
+type queryResult struct {
+	ResultType model.ValueType `json:"resultType"`
+	Result     json.RawMessage `json:"result"`
+}
+
+// apiGet sends a GET request for the API endpoint with the given parameters
+// to the server and returns the data of the response.
+func apiGet(server, endpoint string, params url.Values) (json.RawMessage, error) {
+	u, err := url.Parse(server)
+	if err != nil {
+		return nil, fmt.Errorf("invalid server URL %q: %s", server, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid server URL %q: scheme must be http or https", server)
+	}
+	u.Path = strings.TrimRight(u.Path, "/") + "/api/v1" + endpoint
+	u.RawQuery = params.Encode()
+
+	client := &http.Client{Timeout: queryTimeout}
+	resp, err := client.Get(u.String())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var ar apiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&ar); err != nil {
+		return nil, fmt.Errorf("error decoding response with status %s: %s", resp.Status, err)
+	}
+	if ar.Status != "success" {
+		return nil, fmt.Errorf("%s: %s", ar.ErrorType, ar.Error)
+	}
+	return ar.Data, nil
+}
+
+// queryFlagSet returns a flag set with the flags common to all query
+// commands.
+func queryFlagSet(t cli.Term, name string) (*flag.FlagSet, *string) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(t.Err())
+	format := fs.String("format", "table", "Output format, one of table, json or csv.")
+	return fs, format
+}
+
+func validFormat(format string) bool {
+	return format == "table" || format == "json" || format == "csv"
+}
+
+var queryInstantUsage = strings.TrimSpace(`
+usage: promtool query-instant [-time=<time>] [-format=table|json|csv] <server URL> <expression>
+
+Evaluate an instant query against a Prometheus server, e.g.
+
+$ promtool query-instant http://localhost:9090 'up == 0'
+`)
+
+// QueryInstantCmd runs an instant query against a server.
+func QueryInstantCmd(t cli.Term, args ...string) int {
+	fs, format := queryFlagSet(t, "query-instant")
+	ts := fs.String("time", "", "Evaluation time as RFC3339 or Unix timestamp. Defaults to the current server time.")
+	if err := fs.Parse(args); err != nil || fs.NArg() != 2 || !validFormat(*format) {
+		t.Infof("%s", queryInstantUsage)
+		return 2
+	}
+
+	params := url.Values{"query": []string{fs.Arg(1)}}
+	if *ts != "" {
+		params.Set("time", *ts)
+	}
+	return runQuery(t, fs.Arg(0), "/query", params, *format)
+}
+
+var queryRangeUsage = strings.TrimSpace(`
+usage: promtool query-range [-start=<time>] [-end=<time>] [-step=<duration>] [-format=table|json|csv] <server URL> <expression>
+
+Evaluate a range query against a Prometheus server. The range defaults to the
+last hour, and the step to a resolution of about 250 points per series.
+`)
+
+// QueryRangeCmd runs a range query against a server.
+func QueryRangeCmd(t cli.Term, args ...string) int {
+	fs, format := queryFlagSet(t, "query-range")
+	start := fs.String("start", "", "Start time as RFC3339 or Unix timestamp. Defaults to one hour before the end.")
+	end := fs.String("end", "", "End time as RFC3339 or Unix timestamp. Defaults to now.")
+	step := fs.String("step", "", "Query resolution step width as duration or number of seconds.")
+	if err := fs.Parse(args); err != nil || fs.NArg() != 2 || !validFormat(*format) {
+		t.Infof("%s", queryRangeUsage)
+		return 2
+	}
+
+	endTime := time.Now()
+	if *end != "" {
+		var err error
+		if endTime, err = parseTime(*end); err != nil {
+			t.Errorf("invalid end time: %s", err)
+			return 2
+		}
+	}
+	startTime := endTime.Add(-time.Hour)
+	if *start != "" {
+		var err error
+		if startTime, err = parseTime(*start); err != nil {
+			t.Errorf("invalid start time: %s", err)
+			return 2
+		}
+	}
+	if !startTime.Before(endTime) {
+		t.Errorf("start time must be before end time")
+		return 2
+	}
+	if *step == "" {
+		resolution := math.Max(math.Floor(endTime.Sub(startTime).Seconds()/250), 1)
+		*step = strconv.FormatFloat(resolution, 'f', -1, 64)
+	}
+
+	params := url.Values{
+		"query": []string{fs.Arg(1)},
+		"start": []string{formatTime(startTime)},
+		"end":   []string{formatTime(endTime)},
+		"step":  []string{*step},
+	}
+	return runQuery(t, fs.Arg(0), "/query_range", params, *format)
+}
+
+var querySeriesUsage = strings.TrimSpace(`
+usage: promtool query-series [-start=<time>] [-end=<time>] [-format=table|json|csv] <server URL> <series selector>...
+
+List the series matching any of the given selectors, e.g.
+
+$ promtool query-series http://localhost:9090 'up{job="prometheus"}'
+`)
+
+// QuerySeriesCmd lists the series matching a set of selectors.
+func QuerySeriesCmd(t cli.Term, args ...string) int {
+	fs, format := queryFlagSet(t, "query-series")
+	start := fs.String("start", "", "Start time as RFC3339 or Unix timestamp.")
+	end := fs.String("end", "", "End time as RFC3339 or Unix timestamp.")
+	if err := fs.Parse(args); err != nil || fs.NArg() < 2 || !validFormat(*format) {
+		t.Infof("%s", querySeriesUsage)
+		return 2
+	}
+
+	params := url.Values{"match[]": fs.Args()[1:]}
+	if *start != "" {
+		params.Set("start", *start)
+	}
+	if *end != "" {
+		params.Set("end", *end)
+	}
+	data, err := apiGet(fs.Arg(0), "/series", params)
+	if err != nil {
+		t.Errorf("query failed: %s", err)
+		return 1
+	}
+	if *format == "json" {
+		return printJSON(t, data)
+	}
+
+	var series []model.Metric
+	if err := json.Unmarshal(data, &series); err != nil {
+		t.Errorf("error decoding series: %s", err)
+		return 1
+	}
+	if *format == "csv" {
+		return printSeriesCSV(t, series)
+	}
+	for _, m := range series {
+		fmt.Fprintln(t.Out(), m)
+	}
+	return 0
+}
+
+var queryLabelsUsage = strings.TrimSpace(`
+usage: promtool query-labels [-format=table|json|csv] <server URL> [<label name>]
+
+List the values of the given label name, or all label names if none is given.
+`)
+
+// QueryLabelsCmd lists label names or the values of a label.
+func QueryLabelsCmd(t cli.Term, args ...string) int {
+	fs, format := queryFlagSet(t, "query-labels")
+	if err := fs.Parse(args); err != nil || fs.NArg() < 1 || fs.NArg() > 2 || !validFormat(*format) {
+		t.Infof("%s", queryLabelsUsage)
+		return 2
+	}
+
+	endpoint := "/labels"
+	if fs.NArg() == 2 {
+		endpoint = "/label/" + url.PathEscape(fs.Arg(1)) + "/values"
+	}
+	data, err := apiGet(fs.Arg(0), endpoint, nil)
+	if err != nil {
+		t.Errorf("query failed: %s", err)
+		return 1
+	}
+	if *format == "json" {
+		return printJSON(t, data)
+	}
+
+	var values []string
+	if err := json.Unmarshal(data, &values); err != nil {
+		t.Errorf("error decoding label values: %s", err)
+		return 1
+	}
+	if *format == "csv" {
+		w := csv.NewWriter(t.Out())
+		w.Write([]string{"value"})
+		for _, v := range values {
+			w.Write([]string{v})
+		}
+		w.Flush()
+		return 0
+	}
+	for _, v := range values {
+		fmt.Fprintln(t.Out(), v)
+	}
+	return 0
+}
+
+func runQuery(t cli.Term, server, endpoint string, params url.Values, format string) int {
+	data, err := apiGet(server, endpoint, params)
+	if err != nil {
+		t.Errorf("query failed: %s", err)
+		return 1
+	}
+	if format == "json" {
+		return printJSON(t, data)
+	}
+
+	var qr queryResult
+	if err := json.Unmarshal(data, &qr); err != nil {
+		t.Errorf("error decoding query result: %s", err)
+		return 1
+	}
+	rows, err := queryResultRows(qr)
+	if err != nil {
+		t.Errorf("error decoding query result: %s", err)
+		return 1
+	}
+
+	header := []string{"metric", "timestamp", "value"}
+	if format == "csv" {
+		w := csv.NewWriter(t.Out())
+		w.Write(header)
+		w.WriteAll(rows)
+		return 0
+	}
+
+	tw := tabwriter.NewWriter(t.Out(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.ToUpper(strings.Join(header, "\t")))
+	for _, r := range rows {
+		fmt.Fprintln(tw, strings.Join(r, "\t"))
+	}
+	tw.Flush()
+	return 0
+}
+
+// queryResultRows flattens a query result into rows of metric, timestamp
+// and value.
+func queryResultRows(qr queryResult) ([][]string, error) {
+	var rows [][]string
+	switch qr.ResultType {
+	case model.ValVector:
+		var v model.Vector
+		if err := json.Unmarshal(qr.Result, &v); err != nil {
+			return nil, err
+		}
+		for _, s := range v {
+			rows = append(rows, []string{s.Metric.String(), s.Timestamp.String(), s.Value.String()})
+		}
+	case model.ValMatrix:
+		var m model.Matrix
+		if err := json.Unmarshal(qr.Result, &m); err != nil {
+			return nil, err
+		}
+		for _, ss := range m {
+			for _, sp := range ss.Values {
+				rows = append(rows, []string{ss.Metric.String(), sp.Timestamp.String(), sp.Value.String()})
+			}
+		}
+	case model.ValScalar:
+		var s model.Scalar
+		if err := json.Unmarshal(qr.Result, &s); err != nil {
+			return nil, err
+		}
+		rows = append(rows, []string{"", s.Timestamp.String(), s.Value.String()})
+	case model.ValString:
+		var s model.String
+		if err := json.Unmarshal(qr.Result, &s); err != nil {
+			return nil, err
+		}
+		rows = append(rows, []string{"", s.Timestamp.String(), s.Value})
+	default:
+		return nil, fmt.Errorf("unknown result type %q", qr.ResultType)
+	}
+	return rows, nil
+}
+
+func printJSON(t cli.Term, data json.RawMessage) int {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		t.Errorf("error decoding response: %s", err)
+		return 1
+	}
+	enc := json.NewEncoder(t.Out())
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		t.Errorf("error encoding response: %s", err)
+		return 1
+	}
+	return 0
+}
+
+// printSeriesCSV writes the series with one column per label name.
+func printSeriesCSV(t cli.Term, series []model.Metric) int {
+	names := map[model.LabelName]struct{}{}
+	for _, m := range series {
+		for ln := range m {
+			names[ln] = struct{}{}
+		}
+	}
+	header := make(model.LabelNames, 0, len(names))
+	for ln := range names {
+		header = append(header, ln)
+	}
+	sort.Sort(header)
+
+	w := csv.NewWriter(t.Out())
+	record := make([]string, len(header))
+	for i, ln := range header {
+		record[i] = string(ln)
+	}
+	w.Write(record)
+	for _, m := range series {
+		for i, ln := range header {
+			record[i] = string(m[ln])
+		}
+		w.Write(record)
+	}
+	w.Flush()
+	return 0
+}
+
+// parseTime parses a RFC3339 or Unix timestamp, as accepted by the query API.
+func parseTime(s string) (time.Time, error) {
+	if t, err := strconv.ParseFloat(s, 64); err == nil {
+		s, ns := math.Modf(t)
+		return time.Unix(int64(s), int64(ns*float64(time.Second))), nil
+	}
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("cannot parse %q to a valid timestamp", s)
+}
+
+func formatTime(t time.Time) string {
+	return strconv.FormatFloat(float64(t.UnixNano())/float64(time.Second), 'f', -1, 64)
+}
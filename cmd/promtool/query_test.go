@@ -0,0 +1,166 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/common/route"
+
+	"github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/promql"
+	"github.com/prometheus/prometheus/util/cli"
+	"github.com/prometheus/prometheus/web/api/v1"
+)
+
+// newTestAPIServer returns a server of the query API for the test storage.
+func newTestAPIServer(t *testing.T, input string) (*httptest.Server, func()) {
+	suite, err := promql.NewTest(t, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := suite.Run(); err != nil {
+		suite.Close()
+		t.Fatal(err)
+	}
+
+	api := v1.NewAPI(
+		suite.QueryEngine(), suite.Storage(), nil, nil, nil, nil,
+		func() config.Config { return config.Config{} },
+		false, false, v1.QueryLimits{}, v1.QueryCacheOptions{},
+		nil, nil, nil, nil, regexp.MustCompile(".*"), "", "", nil,
+	)
+	router := route.New()
+	api.Register(router.WithPrefix("/api/v1"))
+	server := httptest.NewServer(router)
+	return server, func() {
+		server.Close()
+		suite.Close()
+	}
+}
+
+func TestQueryCommands(t *testing.T) {
+	server, closeServer := newTestAPIServer(t, `
+		load 1m
+			test_metric{job="a"} 0+1x10
+			test_metric{job="b"} 0+2x10
+	`)
+	defer closeServer()
+
+	tests := []struct {
+		cmd    func(cli.Term, ...string) int
+		args   []string
+		code   int
+		out    []string
+		errOut string
+	}{
+		{
+			cmd:  QueryInstantCmd,
+			args: []string{"-time=300", server.URL, `test_metric{job="b"}`},
+			out: []string{
+				"METRIC                TIMESTAMP  VALUE",
+				`test_metric{job="b"}  300        10`,
+			},
+		},
+		{
+			cmd:  QueryInstantCmd,
+			args: []string{"-time=300", "-format=csv", server.URL, `sum(test_metric)`},
+			out:  []string{"metric,timestamp,value", "{},300,15"},
+		},
+		{
+			cmd:  QueryRangeCmd,
+			args: []string{"-start=0", "-end=120", "-step=60", "-format=csv", server.URL, `test_metric{job="a"}`},
+			out: []string{
+				"metric,timestamp,value",
+				`"test_metric{job=""a""}",0,0`,
+				`"test_metric{job=""a""}",60,1`,
+				`"test_metric{job=""a""}",120,2`,
+			},
+		},
+		{
+			cmd:  QuerySeriesCmd,
+			args: []string{"-format=csv", server.URL, `test_metric{job="a"}`},
+			out:  []string{"__name__,job", "test_metric,a"},
+		},
+		{
+			cmd:  QueryLabelsCmd,
+			args: []string{server.URL, "job"},
+			out:  []string{"a", "b"},
+		},
+		{
+			cmd:    QueryInstantCmd,
+			args:   []string{server.URL, `sum(`},
+			code:   1,
+			errOut: "query failed: bad_data: ",
+		},
+		{
+			cmd:    QueryRangeCmd,
+			args:   []string{"-start=120", "-end=0", server.URL, `test_metric`},
+			code:   2,
+			errOut: "start time must be before end time",
+		},
+		{
+			cmd:    QueryInstantCmd,
+			args:   []string{"ftp://localhost", `test_metric`},
+			code:   1,
+			errOut: "scheme must be http or https",
+		},
+	}
+	for i, test := range tests {
+		var out, errOut bytes.Buffer
+		if code := test.cmd(cli.BasicTerm(&out, &errOut), test.args...); code != test.code {
+			t.Errorf("%d. %v: expected exit code %d, got %d: %s", i, test.args, test.code, code, errOut.String())
+			continue
+		}
+		if test.errOut != "" && !strings.Contains(errOut.String(), test.errOut) {
+			t.Errorf("%d. %v: expected error output containing %q, got %q", i, test.args, test.errOut, errOut.String())
+		}
+		if test.out == nil {
+			continue
+		}
+		if lines := strings.Split(strings.TrimSpace(out.String()), "\n"); strings.Join(lines, "\n") != strings.Join(test.out, "\n") {
+			t.Errorf("%d. %v: expected output\n%s\ngot\n%s", i, test.args, strings.Join(test.out, "\n"), out.String())
+		}
+	}
+}
+
+func TestQueryInstantJSON(t *testing.T) {
+	server, closeServer := newTestAPIServer(t, `
+		load 1m
+			test_metric{job="a"} 0+1x10
+	`)
+	defer closeServer()
+
+	var out, errOut bytes.Buffer
+	if code := QueryInstantCmd(cli.BasicTerm(&out, &errOut), "-time=60", "-format=json", server.URL, "test_metric"); code != 0 {
+		t.Fatalf("Unexpected exit code %d: %s", code, errOut.String())
+	}
+	var res struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Value []interface{} `json:"value"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &res); err != nil {
+		t.Fatalf("Error decoding JSON output: %s", err)
+	}
+	if res.ResultType != "vector" || len(res.Result) != 1 || res.Result[0].Value[1] != "1" {
+		t.Errorf("Unexpected JSON output %s", out.String())
+	}
+}
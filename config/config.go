@@ -14,25 +14,38 @@
 package config
 
 import (
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/url"
+	"os"
 	"path/filepath"
+	"reflect"
 	"regexp"
 	"strings"
+	"text/template"
 	"time"
 
-	"github.com/aws/aws-sdk-go/aws/ec2metadata"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/prometheus/common/model"
+	"golang.org/x/crypto/bcrypt"
 	"gopkg.in/yaml.v2"
+
+	"github.com/prometheus/prometheus/util/awsutil"
 )
 
 var (
 	patFileSDName = regexp.MustCompile(`^[^*]*(\*[^/]*)?\.(json|yml|yaml|JSON|YML|YAML)$`)
 	patRulePath   = regexp.MustCompile(`^[^*]*(\*[^/]*)?$`)
 	relabelTarget = regexp.MustCompile(`^(?:(?:[a-zA-Z_]|\$(?:\{\w+\}|\w+))+\w*)+$`)
+	// Matches $(function) templates in external label values, and $$( escaping
+	// a literal $(.
+	patLabelTemplate = regexp.MustCompile(`\$\$\(|\$\(([^)]*)\)`)
+
+	// hostname returns the host name templated external label values are
+	// resolved with. It is a variable for testing.
+	hostname = os.Hostname
 )
 
 // Load parses the YAML input s into a Config.
@@ -62,9 +75,65 @@ func LoadFile(filename string) (*Config, error) {
 		return nil, err
 	}
 	resolveFilepaths(filepath.Dir(filename), cfg)
+	if err := cfg.loadScrapeConfigFiles(); err != nil {
+		return nil, err
+	}
 	return cfg, nil
 }
 
+// scrapeConfigFile is the content of a file included through
+// scrape_config_files.
+type scrapeConfigFile struct {
+	ScrapeConfigs []*ScrapeConfig `yaml:"scrape_configs,omitempty"`
+
+	// Catches all undefined fields and must be empty after parsing.
+	XXX map[string]interface{} `yaml:",inline"`
+}
+
+// loadScrapeConfigFiles appends the scrape configs of all files matching the
+// scrape config file patterns to the scrape configs. Relative paths within the
+// files are resolved against the directory of the respective file.
+func (c *Config) loadScrapeConfigFiles() error {
+	jobNames := map[string]struct{}{}
+	for _, scfg := range c.ScrapeConfigs {
+		jobNames[scfg.JobName] = struct{}{}
+	}
+
+	for _, pat := range c.ScrapeConfigFiles {
+		files, err := filepath.Glob(pat)
+		if err != nil {
+			// The only error can be a bad pattern.
+			return fmt.Errorf("error retrieving scrape config files for %s: %s", pat, err)
+		}
+		for _, fn := range files {
+			content, err := ioutil.ReadFile(fn)
+			if err != nil {
+				return err
+			}
+			sf := &scrapeConfigFile{}
+			if err := yaml.Unmarshal(content, sf); err != nil {
+				return fmt.Errorf("error parsing scrape config file %s: %s", fn, err)
+			}
+			if err := checkOverflow(sf.XXX, "scrape config file "+fn); err != nil {
+				return err
+			}
+			resolveFilepaths(filepath.Dir(fn), &Config{ScrapeConfigs: sf.ScrapeConfigs})
+
+			for _, scfg := range sf.ScrapeConfigs {
+				if err := c.GlobalConfig.setScrapeDefaults(scfg); err != nil {
+					return fmt.Errorf("error in scrape config file %s: %s", fn, err)
+				}
+				if _, ok := jobNames[scfg.JobName]; ok {
+					return fmt.Errorf("found multiple scrape configs with job name %q, last in scrape config file %s", scfg.JobName, fn)
+				}
+				jobNames[scfg.JobName] = struct{}{}
+				c.ScrapeConfigs = append(c.ScrapeConfigs, scfg)
+			}
+		}
+	}
+	return nil
+}
+
 // The defaults applied before parsing the respective config sections.
 var (
 	// DefaultConfig is the default top-level configuration.
@@ -151,6 +220,12 @@ var (
 		RefreshInterval: model.Duration(60 * time.Second),
 	}
 
+	// DefaultECSSDConfig is the default ECS SD configuration.
+	DefaultECSSDConfig = ECSSDConfig{
+		Port:            80,
+		RefreshInterval: model.Duration(60 * time.Second),
+	}
+
 	// DefaultOpenstackSDConfig is the default OpenStack SD configuration.
 	DefaultOpenstackSDConfig = OpenstackSDConfig{
 		Port:            80,
@@ -170,10 +245,83 @@ var (
 		Version:         1,
 	}
 
+	// DefaultHetznerSDConfig is the default Hetzner SD configuration.
+	DefaultHetznerSDConfig = HetznerSDConfig{
+		Port:            80,
+		RefreshInterval: model.Duration(60 * time.Second),
+	}
+
+	// DefaultLinodeSDConfig is the default Linode SD configuration.
+	DefaultLinodeSDConfig = LinodeSDConfig{
+		Port:            80,
+		TagSeparator:    ",",
+		RefreshInterval: model.Duration(60 * time.Second),
+	}
+
+	// DefaultDigitalOceanSDConfig is the default DigitalOcean SD configuration.
+	DefaultDigitalOceanSDConfig = DigitalOceanSDConfig{
+		Port:            80,
+		RefreshInterval: model.Duration(60 * time.Second),
+	}
+
+	// DefaultScalewaySDConfig is the default Scaleway SD configuration.
+	DefaultScalewaySDConfig = ScalewaySDConfig{
+		APIURL:          "https://api.scaleway.com",
+		Port:            80,
+		RefreshInterval: model.Duration(60 * time.Second),
+	}
+
+	// DefaultPuppetDBSDConfig is the default PuppetDB SD configuration.
+	DefaultPuppetDBSDConfig = PuppetDBSDConfig{
+		Port:            80,
+		RefreshInterval: model.Duration(60 * time.Second),
+	}
+
+	// DefaultEurekaSDConfig is the default Eureka SD configuration.
+	DefaultEurekaSDConfig = EurekaSDConfig{
+		RefreshInterval: model.Duration(30 * time.Second),
+	}
+
+	// DefaultNomadSDConfig is the default Nomad SD configuration.
+	DefaultNomadSDConfig = NomadSDConfig{
+		Server:          "http://localhost:4646",
+		Namespace:       "default",
+		AllowStale:      true,
+		TagSeparator:    ",",
+		RefreshInterval: model.Duration(60 * time.Second),
+	}
+
+	// DefaultUyuniSDConfig is the default Uyuni SD configuration.
+	DefaultUyuniSDConfig = UyuniSDConfig{
+		Entitlement:     "monitoring_entitled",
+		Separator:       ",",
+		RefreshInterval: model.Duration(60 * time.Second),
+	}
+
+	// DefaultVultrSDConfig is the default Vultr SD configuration.
+	DefaultVultrSDConfig = VultrSDConfig{
+		Port:            80,
+		RefreshInterval: model.Duration(60 * time.Second),
+	}
+
+	// DefaultOVHcloudSDConfig is the default OVHcloud SD configuration.
+	DefaultOVHcloudSDConfig = OVHcloudSDConfig{
+		Endpoint:        "ovh-eu",
+		Port:            80,
+		RefreshInterval: model.Duration(60 * time.Second),
+	}
+
+	// DefaultKumaSDConfig is the default Kuma SD configuration.
+	DefaultKumaSDConfig = KumaSDConfig{
+		RefreshInterval: model.Duration(30 * time.Second),
+		FetchTimeout:    model.Duration(2 * time.Minute),
+	}
+
 	// DefaultRemoteWriteConfig is the default remote write configuration.
 	DefaultRemoteWriteConfig = RemoteWriteConfig{
-		RemoteTimeout: model.Duration(30 * time.Second),
-		QueueConfig:   DefaultQueueConfig,
+		RemoteTimeout:   model.Duration(30 * time.Second),
+		QueueConfig:     DefaultQueueConfig,
+		ProtobufMessage: RemoteWriteProtoMsgV1,
 	}
 
 	// DefaultQueueConfig is the default remote queue configuration.
@@ -198,6 +346,12 @@ var (
 	DefaultRemoteReadConfig = RemoteReadConfig{
 		RemoteTimeout: model.Duration(1 * time.Minute),
 	}
+
+	// DefaultTracingConfig is the default tracing configuration.
+	DefaultTracingConfig = TracingConfig{
+		SamplingFraction: 0.1,
+		Timeout:          model.Duration(10 * time.Second),
+	}
 )
 
 // URL is a custom URL type that allows validation at configuration load time.
@@ -228,16 +382,32 @@ func (u URL) MarshalYAML() (interface{}, error) {
 	return nil, nil
 }
 
+// Redacted returns the string representation of the URL without user
+// credentials.
+func (u *URL) Redacted() string {
+	if u == nil || u.URL == nil {
+		return ""
+	}
+	ru := *u.URL
+	ru.User = nil
+	return ru.String()
+}
+
 // Config is the top-level configuration for Prometheus's config files.
 type Config struct {
 	GlobalConfig   GlobalConfig    `yaml:"global"`
 	AlertingConfig AlertingConfig  `yaml:"alerting,omitempty"`
 	RuleFiles      []string        `yaml:"rule_files,omitempty"`
 	ScrapeConfigs  []*ScrapeConfig `yaml:"scrape_configs,omitempty"`
+	// Files holding further scrape configs, which are appended to the
+	// scrape configs by LoadFile.
+	ScrapeConfigFiles []string `yaml:"scrape_config_files,omitempty"`
 
 	RemoteWriteConfigs []*RemoteWriteConfig `yaml:"remote_write,omitempty"`
 	RemoteReadConfigs  []*RemoteReadConfig  `yaml:"remote_read,omitempty"`
 
+	TracingConfig *TracingConfig `yaml:"tracing,omitempty"`
+
 	// Catches all undefined fields and must be empty after parsing.
 	XXX map[string]interface{} `yaml:",inline"`
 
@@ -262,6 +432,104 @@ func (s Secret) MarshalYAML() (interface{}, error) {
 	return nil, nil
 }
 
+// ExpandEnv replaces ${var} or $var in the external label values and the
+// secrets of the configuration with the values of the respective environment
+// variables. Undefined variables are replaced by the empty string, and $$ by
+// a literal $.
+func (c *Config) ExpandEnv() {
+	for ln, lv := range c.GlobalConfig.ExternalLabels {
+		c.GlobalConfig.ExternalLabels[ln] = model.LabelValue(expandEnv(string(lv)))
+	}
+	expandSecrets(reflect.ValueOf(c))
+}
+
+// ResolveLabelTemplates resolves the $(hostname) and $(env:NAME) templates in
+// the external label values of the configuration. $$( escapes a literal $(.
+func (c *Config) ResolveLabelTemplates() error {
+	for ln, lv := range c.GlobalConfig.ExternalLabels {
+		v, err := resolveLabelTemplate(string(lv))
+		if err != nil {
+			return fmt.Errorf("invalid value of external label %q: %s", ln, err)
+		}
+		c.GlobalConfig.ExternalLabels[ln] = model.LabelValue(v)
+	}
+	return nil
+}
+
+func expandEnv(s string) string {
+	return os.Expand(s, func(name string) string {
+		if name == "$" {
+			return "$"
+		}
+		return os.Getenv(name)
+	})
+}
+
+var secretType = reflect.TypeOf(Secret(""))
+
+// expandSecrets expands the environment variables in all secrets reachable
+// from v through exported fields.
+func expandSecrets(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			expandSecrets(v.Elem())
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath == "" {
+				expandSecrets(v.Field(i))
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			expandSecrets(v.Index(i))
+		}
+	case reflect.Map:
+		// Map values are not settable, so expand a copy of each and store it
+		// back.
+		for _, k := range v.MapKeys() {
+			e := reflect.New(v.Type().Elem()).Elem()
+			e.Set(v.MapIndex(k))
+			expandSecrets(e)
+			v.SetMapIndex(k, e)
+		}
+	case reflect.String:
+		if v.Type() == secretType && v.CanSet() {
+			v.SetString(expandEnv(v.String()))
+		}
+	}
+}
+
+// resolveLabelTemplate resolves the $(hostname) and $(env:NAME) templates in
+// an external label value to the host name and the value of the environment
+// variable NAME respectively.
+func resolveLabelTemplate(s string) (string, error) {
+	var err error
+	res := patLabelTemplate.ReplaceAllStringFunc(s, func(m string) string {
+		if m == "$$(" {
+			return "$("
+		}
+		fn := patLabelTemplate.FindStringSubmatch(m)[1]
+		switch {
+		case fn == "hostname":
+			h, herr := hostname()
+			if herr != nil && err == nil {
+				err = fmt.Errorf("unable to get hostname: %s", herr)
+			}
+			return h
+		case strings.HasPrefix(fn, "env:"):
+			return os.Getenv(strings.TrimPrefix(fn, "env:"))
+		default:
+			if err == nil {
+				err = fmt.Errorf("unknown template function %q", fn)
+			}
+			return m
+		}
+	})
+	return res, err
+}
+
 // resolveFilepaths joins all relative paths in a configuration
 // with a given base directory.
 func resolveFilepaths(baseDir string, cfg *Config) {
@@ -275,31 +543,97 @@ func resolveFilepaths(baseDir string, cfg *Config) {
 	for i, rf := range cfg.RuleFiles {
 		cfg.RuleFiles[i] = join(rf)
 	}
+	for i, sf := range cfg.ScrapeConfigFiles {
+		cfg.ScrapeConfigFiles[i] = join(sf)
+	}
+
+	// Secret files may refer to a secret provider instead of a file.
+	joinSecret := func(fp string) string {
+		if strings.Contains(fp, "://") {
+			return fp
+		}
+		return join(fp)
+	}
 
 	clientPaths := func(scfg *HTTPClientConfig) {
-		scfg.BearerTokenFile = join(scfg.BearerTokenFile)
+		scfg.BearerTokenFile = joinSecret(scfg.BearerTokenFile)
+		if scfg.BasicAuth != nil {
+			scfg.BasicAuth.PasswordFile = joinSecret(scfg.BasicAuth.PasswordFile)
+		}
+		if scfg.OAuth2 != nil {
+			scfg.OAuth2.ClientSecretFile = joinSecret(scfg.OAuth2.ClientSecretFile)
+		}
 		scfg.TLSConfig.CAFile = join(scfg.TLSConfig.CAFile)
 		scfg.TLSConfig.CertFile = join(scfg.TLSConfig.CertFile)
 		scfg.TLSConfig.KeyFile = join(scfg.TLSConfig.KeyFile)
 	}
 	sdPaths := func(cfg *ServiceDiscoveryConfig) {
 		for _, kcfg := range cfg.KubernetesSDConfigs {
-			kcfg.BearerTokenFile = join(kcfg.BearerTokenFile)
+			kcfg.BearerTokenFile = joinSecret(kcfg.BearerTokenFile)
+			if kcfg.BasicAuth != nil {
+				kcfg.BasicAuth.PasswordFile = joinSecret(kcfg.BasicAuth.PasswordFile)
+			}
 			kcfg.TLSConfig.CAFile = join(kcfg.TLSConfig.CAFile)
 			kcfg.TLSConfig.CertFile = join(kcfg.TLSConfig.CertFile)
 			kcfg.TLSConfig.KeyFile = join(kcfg.TLSConfig.KeyFile)
+			kcfg.KubeconfigFile = join(kcfg.KubeconfigFile)
 		}
 		for _, mcfg := range cfg.MarathonSDConfigs {
-			mcfg.BearerTokenFile = join(mcfg.BearerTokenFile)
+			mcfg.BearerTokenFile = joinSecret(mcfg.BearerTokenFile)
 			mcfg.TLSConfig.CAFile = join(mcfg.TLSConfig.CAFile)
 			mcfg.TLSConfig.CertFile = join(mcfg.TLSConfig.CertFile)
 			mcfg.TLSConfig.KeyFile = join(mcfg.TLSConfig.KeyFile)
 		}
 		for _, consulcfg := range cfg.ConsulSDConfigs {
+			consulcfg.TokenFile = joinSecret(consulcfg.TokenFile)
+			consulcfg.PasswordFile = joinSecret(consulcfg.PasswordFile)
 			consulcfg.TLSConfig.CAFile = join(consulcfg.TLSConfig.CAFile)
 			consulcfg.TLSConfig.CertFile = join(consulcfg.TLSConfig.CertFile)
 			consulcfg.TLSConfig.KeyFile = join(consulcfg.TLSConfig.KeyFile)
 		}
+		for _, ec2cfg := range cfg.EC2SDConfigs {
+			ec2cfg.SecretKeyFile = joinSecret(ec2cfg.SecretKeyFile)
+		}
+		for _, ecscfg := range cfg.ECSSDConfigs {
+			ecscfg.SecretKeyFile = joinSecret(ecscfg.SecretKeyFile)
+		}
+		for _, oscfg := range cfg.OpenstackSDConfigs {
+			oscfg.PasswordFile = joinSecret(oscfg.PasswordFile)
+		}
+		for _, azurecfg := range cfg.AzureSDConfigs {
+			azurecfg.ClientSecretFile = joinSecret(azurecfg.ClientSecretFile)
+		}
+		for _, hcfg := range cfg.HetznerSDConfigs {
+			clientPaths(&hcfg.HTTPClientConfig)
+		}
+		for _, lcfg := range cfg.LinodeSDConfigs {
+			clientPaths(&lcfg.HTTPClientConfig)
+		}
+		for _, docfg := range cfg.DigitalOceanSDConfigs {
+			clientPaths(&docfg.HTTPClientConfig)
+		}
+		for _, swcfg := range cfg.ScalewaySDConfigs {
+			swcfg.SecretKeyFile = joinSecret(swcfg.SecretKeyFile)
+		}
+		for _, pdbcfg := range cfg.PuppetDBSDConfigs {
+			clientPaths(&pdbcfg.HTTPClientConfig)
+		}
+		for _, ecfg := range cfg.EurekaSDConfigs {
+			clientPaths(&ecfg.HTTPClientConfig)
+		}
+		for _, ncfg := range cfg.NomadSDConfigs {
+			clientPaths(&ncfg.HTTPClientConfig)
+		}
+		for _, ucfg := range cfg.UyuniSDConfigs {
+			ucfg.PasswordFile = joinSecret(ucfg.PasswordFile)
+			clientPaths(&ucfg.HTTPClientConfig)
+		}
+		for _, vcfg := range cfg.VultrSDConfigs {
+			clientPaths(&vcfg.HTTPClientConfig)
+		}
+		for _, kcfg := range cfg.KumaSDConfigs {
+			clientPaths(&kcfg.HTTPClientConfig)
+		}
 	}
 
 	for _, cfg := range cfg.ScrapeConfigs {
@@ -310,6 +644,26 @@ func resolveFilepaths(baseDir string, cfg *Config) {
 		clientPaths(&cfg.HTTPClientConfig)
 		sdPaths(&cfg.ServiceDiscoveryConfig)
 	}
+	for _, cfg := range cfg.RemoteWriteConfigs {
+		clientPaths(&cfg.HTTPClientConfig)
+		if cfg.SigV4Config != nil {
+			cfg.SigV4Config.SecretKeyFile = joinSecret(cfg.SigV4Config.SecretKeyFile)
+		}
+		if cfg.AzureADConfig != nil && cfg.AzureADConfig.OAuth != nil {
+			cfg.AzureADConfig.OAuth.ClientSecretFile = joinSecret(cfg.AzureADConfig.OAuth.ClientSecretFile)
+		}
+		if cfg.GoogleIAMConfig != nil {
+			cfg.GoogleIAMConfig.CredentialsFile = join(cfg.GoogleIAMConfig.CredentialsFile)
+		}
+	}
+	for _, cfg := range cfg.RemoteReadConfigs {
+		clientPaths(&cfg.HTTPClientConfig)
+	}
+	if tcfg := cfg.TracingConfig; tcfg != nil {
+		tcfg.TLSConfig.CAFile = join(tcfg.TLSConfig.CAFile)
+		tcfg.TLSConfig.CertFile = join(tcfg.TLSConfig.CertFile)
+		tcfg.TLSConfig.KeyFile = join(tcfg.TLSConfig.KeyFile)
+	}
 }
 
 func checkOverflow(m map[string]interface{}, ctx string) error {
@@ -355,25 +709,17 @@ func (c *Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
 			return fmt.Errorf("invalid rule file path %q", rf)
 		}
 	}
+	for _, pat := range c.ScrapeConfigFiles {
+		if !patRulePath.MatchString(pat) {
+			return fmt.Errorf("invalid scrape config file path %q", pat)
+		}
+	}
 	// Do global overrides and validate unique names.
 	jobNames := map[string]struct{}{}
 	for _, scfg := range c.ScrapeConfigs {
-		// First set the correct scrape interval, then check that the timeout
-		// (inferred or explicit) is not greater than that.
-		if scfg.ScrapeInterval == 0 {
-			scfg.ScrapeInterval = c.GlobalConfig.ScrapeInterval
-		}
-		if scfg.ScrapeTimeout > scfg.ScrapeInterval {
-			return fmt.Errorf("scrape timeout greater than scrape interval for scrape config with job name %q", scfg.JobName)
-		}
-		if scfg.ScrapeTimeout == 0 {
-			if c.GlobalConfig.ScrapeTimeout > scfg.ScrapeInterval {
-				scfg.ScrapeTimeout = scfg.ScrapeInterval
-			} else {
-				scfg.ScrapeTimeout = c.GlobalConfig.ScrapeTimeout
-			}
+		if err := c.GlobalConfig.setScrapeDefaults(scfg); err != nil {
+			return err
 		}
-
 		if _, ok := jobNames[scfg.JobName]; ok {
 			return fmt.Errorf("found multiple scrape configs with job name %q", scfg.JobName)
 		}
@@ -392,7 +738,13 @@ type GlobalConfig struct {
 	// How frequently to evaluate rules by default.
 	EvaluationInterval model.Duration `yaml:"evaluation_interval,omitempty"`
 	// The labels to add to any timeseries that this Prometheus instance scrapes.
+	// Their values may contain $(hostname) and $(env:NAME) templates, which
+	// are resolved when the configuration is loaded.
 	ExternalLabels model.LabelSet `yaml:"external_labels,omitempty"`
+	// The default label limits of scrape configs. Zero means no limit.
+	LabelLimit            uint `yaml:"label_limit,omitempty"`
+	LabelNameLengthLimit  uint `yaml:"label_name_length_limit,omitempty"`
+	LabelValueLengthLimit uint `yaml:"label_value_length_limit,omitempty"`
 
 	// Catches all undefined fields and must be empty after parsing.
 	XXX map[string]interface{} `yaml:",inline"`
@@ -437,7 +789,39 @@ func (c *GlobalConfig) isZero() bool {
 	return c.ExternalLabels == nil &&
 		c.ScrapeInterval == 0 &&
 		c.ScrapeTimeout == 0 &&
-		c.EvaluationInterval == 0
+		c.EvaluationInterval == 0 &&
+		c.LabelLimit == 0 &&
+		c.LabelNameLengthLimit == 0 &&
+		c.LabelValueLengthLimit == 0
+}
+
+// setScrapeDefaults sets the values of scfg that default to the global ones.
+func (c *GlobalConfig) setScrapeDefaults(scfg *ScrapeConfig) error {
+	// First set the correct scrape interval, then check that the timeout
+	// (inferred or explicit) is not greater than that.
+	if scfg.ScrapeInterval == 0 {
+		scfg.ScrapeInterval = c.ScrapeInterval
+	}
+	if scfg.ScrapeTimeout > scfg.ScrapeInterval {
+		return fmt.Errorf("scrape timeout greater than scrape interval for scrape config with job name %q", scfg.JobName)
+	}
+	if scfg.ScrapeTimeout == 0 {
+		if c.ScrapeTimeout > scfg.ScrapeInterval {
+			scfg.ScrapeTimeout = scfg.ScrapeInterval
+		} else {
+			scfg.ScrapeTimeout = c.ScrapeTimeout
+		}
+	}
+	if scfg.LabelLimit == 0 {
+		scfg.LabelLimit = c.LabelLimit
+	}
+	if scfg.LabelNameLengthLimit == 0 {
+		scfg.LabelNameLengthLimit = c.LabelNameLengthLimit
+	}
+	if scfg.LabelValueLengthLimit == 0 {
+		scfg.LabelValueLengthLimit = c.LabelValueLengthLimit
+	}
+	return nil
 }
 
 // TLSConfig configures the options for TLS connections.
@@ -452,6 +836,13 @@ type TLSConfig struct {
 	ServerName string `yaml:"server_name,omitempty"`
 	// Disable target certificate validation.
 	InsecureSkipVerify bool `yaml:"insecure_skip_verify"`
+	// The minimum and maximum TLS versions to negotiate, the defaults of Go
+	// if unset.
+	MinVersion TLSVersion `yaml:"min_version,omitempty"`
+	MaxVersion TLSVersion `yaml:"max_version,omitempty"`
+	// The cipher suites to offer for TLS versions up to 1.2, the defaults of
+	// Go if empty. The cipher suites of TLS 1.3 are not configurable.
+	CipherSuites []TLSCipher `yaml:"cipher_suites,omitempty"`
 
 	// Catches all undefined fields and must be empty after parsing.
 	XXX map[string]interface{} `yaml:",inline"`
@@ -463,7 +854,88 @@ func (c *TLSConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	if err := unmarshal((*plain)(c)); err != nil {
 		return err
 	}
-	return checkOverflow(c.XXX, "TLS config")
+	if err := checkOverflow(c.XXX, "TLS config"); err != nil {
+		return err
+	}
+	// A client certificate is only used along with its key.
+	if len(c.CertFile) > 0 && len(c.KeyFile) == 0 {
+		return fmt.Errorf("client cert file %q specified without client key file", c.CertFile)
+	}
+	if len(c.KeyFile) > 0 && len(c.CertFile) == 0 {
+		return fmt.Errorf("client key file %q specified without client cert file", c.KeyFile)
+	}
+	if c.MinVersion != 0 && c.MaxVersion != 0 && c.MinVersion > c.MaxVersion {
+		return fmt.Errorf("TLS min_version %s is greater than max_version %s", c.MinVersion, c.MaxVersion)
+	}
+	return nil
+}
+
+// TLSVersion is a TLS protocol version, written like TLS12 in the
+// configuration.
+type TLSVersion uint16
+
+// TLSVersions are the TLS versions by their name in the configuration. TLS13
+// is only available in builds with Go 1.14 or later.
+var TLSVersions = map[string]TLSVersion{
+	"TLS12": tls.VersionTLS12,
+	"TLS11": tls.VersionTLS11,
+	"TLS10": tls.VersionTLS10,
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (v *TLSVersion) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	tv, ok := TLSVersions[s]
+	if !ok {
+		return fmt.Errorf("unknown TLS version %q", s)
+	}
+	*v = tv
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (v TLSVersion) MarshalYAML() (interface{}, error) {
+	if v == 0 {
+		return nil, nil
+	}
+	return v.String(), nil
+}
+
+func (v TLSVersion) String() string {
+	for s, tv := range TLSVersions {
+		if tv == v {
+			return s
+		}
+	}
+	return fmt.Sprintf("0x%04x", uint16(v))
+}
+
+// TLSCipher is a TLS cipher suite, written with its IANA name like
+// TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256 in the configuration.
+type TLSCipher uint16
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *TLSCipher) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	// Insecure cipher suites are accepted for the sake of legacy targets,
+	// but must be asked for explicitly.
+	id, ok := tlsCipherSuites()[s]
+	if !ok {
+		return fmt.Errorf("unknown TLS cipher suite %q", s)
+	}
+	*c = TLSCipher(id)
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (c TLSCipher) MarshalYAML() (interface{}, error) {
+	return tlsCipherSuiteName(uint16(c)), nil
 }
 
 // ServiceDiscoveryConfig configures lists of different service discovery mechanisms.
@@ -488,12 +960,36 @@ type ServiceDiscoveryConfig struct {
 	GCESDConfigs []*GCESDConfig `yaml:"gce_sd_configs,omitempty"`
 	// List of EC2 service discovery configurations.
 	EC2SDConfigs []*EC2SDConfig `yaml:"ec2_sd_configs,omitempty"`
+	// List of ECS service discovery configurations.
+	ECSSDConfigs []*ECSSDConfig `yaml:"ecs_sd_configs,omitempty"`
 	// List of OpenStack service discovery configurations.
 	OpenstackSDConfigs []*OpenstackSDConfig `yaml:"openstack_sd_configs,omitempty"`
 	// List of Azure service discovery configurations.
 	AzureSDConfigs []*AzureSDConfig `yaml:"azure_sd_configs,omitempty"`
 	// List of Triton service discovery configurations.
 	TritonSDConfigs []*TritonSDConfig `yaml:"triton_sd_configs,omitempty"`
+	// List of Hetzner service discovery configurations.
+	HetznerSDConfigs []*HetznerSDConfig `yaml:"hetzner_sd_configs,omitempty"`
+	// List of Linode service discovery configurations.
+	LinodeSDConfigs []*LinodeSDConfig `yaml:"linode_sd_configs,omitempty"`
+	// List of DigitalOcean service discovery configurations.
+	DigitalOceanSDConfigs []*DigitalOceanSDConfig `yaml:"digitalocean_sd_configs,omitempty"`
+	// List of Scaleway service discovery configurations.
+	ScalewaySDConfigs []*ScalewaySDConfig `yaml:"scaleway_sd_configs,omitempty"`
+	// List of PuppetDB service discovery configurations.
+	PuppetDBSDConfigs []*PuppetDBSDConfig `yaml:"puppetdb_sd_configs,omitempty"`
+	// List of Eureka service discovery configurations.
+	EurekaSDConfigs []*EurekaSDConfig `yaml:"eureka_sd_configs,omitempty"`
+	// List of Nomad service discovery configurations.
+	NomadSDConfigs []*NomadSDConfig `yaml:"nomad_sd_configs,omitempty"`
+	// List of Uyuni service discovery configurations.
+	UyuniSDConfigs []*UyuniSDConfig `yaml:"uyuni_sd_configs,omitempty"`
+	// List of Vultr service discovery configurations.
+	VultrSDConfigs []*VultrSDConfig `yaml:"vultr_sd_configs,omitempty"`
+	// List of OVHcloud service discovery configurations.
+	OVHcloudSDConfigs []*OVHcloudSDConfig `yaml:"ovhcloud_sd_configs,omitempty"`
+	// List of Kuma service discovery configurations.
+	KumaSDConfigs []*KumaSDConfig `yaml:"kuma_sd_configs,omitempty"`
 
 	// Catches all undefined fields and must be empty after parsing.
 	XXX map[string]interface{} `yaml:",inline"`
@@ -518,10 +1014,18 @@ type HTTPClientConfig struct {
 	BearerTokenFile string `yaml:"bearer_token_file,omitempty"`
 	// HTTP proxy server to use to connect to the targets.
 	ProxyURL URL `yaml:"proxy_url,omitempty"`
+	// Comma-separated hosts, domains, IP addresses and CIDR ranges which are
+	// connected to directly rather than through the proxy.
+	NoProxy string `yaml:"no_proxy,omitempty"`
+	// The headers sent to the proxy when establishing a tunnel for HTTPS
+	// requests with CONNECT.
+	ProxyConnectHeader map[string][]Secret `yaml:"proxy_connect_header,omitempty"`
 	// TLSConfig to use to connect to the targets.
 	TLSConfig TLSConfig `yaml:"tls_config,omitempty"`
 	// If set, override whether to use HTTP KeepAlive - scraping defaults OFF, remote read/write defaults ON
 	KeepAlive *bool `yaml:"keep_alive,omitempty"`
+	// The OAuth2 client credentials used to fetch a token for the targets.
+	OAuth2 *OAuth2 `yaml:"oauth2,omitempty"`
 
 	// Catches all undefined fields and must be empty after parsing.
 	XXX map[string]interface{} `yaml:",inline"`
@@ -531,9 +1035,23 @@ func (c *HTTPClientConfig) validate() error {
 	if len(c.BearerToken) > 0 && len(c.BearerTokenFile) > 0 {
 		return fmt.Errorf("at most one of bearer_token & bearer_token_file must be configured")
 	}
+	if err := checkSecretFile(c.BearerTokenFile, "bearer_token_file"); err != nil {
+		return err
+	}
 	if c.BasicAuth != nil && (len(c.BearerToken) > 0 || len(c.BearerTokenFile) > 0) {
 		return fmt.Errorf("at most one of basic_auth, bearer_token & bearer_token_file must be configured")
 	}
+	if c.OAuth2 != nil && (c.BasicAuth != nil || len(c.BearerToken) > 0 || len(c.BearerTokenFile) > 0) {
+		return fmt.Errorf("at most one of basic_auth, oauth2, bearer_token & bearer_token_file must be configured")
+	}
+	if c.ProxyURL.URL == nil {
+		if c.NoProxy != "" {
+			return fmt.Errorf("if no_proxy is configured, proxy_url must also be configured")
+		}
+		if len(c.ProxyConnectHeader) > 0 {
+			return fmt.Errorf("if proxy_connect_header is configured, proxy_url must also be configured")
+		}
+	}
 	return nil
 }
 
@@ -541,10 +1059,22 @@ func (c *HTTPClientConfig) validate() error {
 type ScrapeConfig struct {
 	// The job name to which the job label is set by default.
 	JobName string `yaml:"job_name"`
+	// Whether the targets of this scrape config are scraped. Disabled scrape
+	// configs are still validated. Defaults to true.
+	Enabled *bool `yaml:"enabled,omitempty"`
 	// Indicator whether the scraped metrics should remain unmodified.
 	HonorLabels bool `yaml:"honor_labels,omitempty"`
 	// A set of query parameters with which the target is scraped.
 	Params url.Values `yaml:"params,omitempty"`
+	// Query parameters set to the value of a label of the target after
+	// relabelling, by parameter name. They take precedence over Params.
+	ParamsFromLabels map[string]model.LabelName `yaml:"params_from_labels,omitempty"`
+	// HTTP headers with which the target is scraped. They are set as
+	// HeaderLabelPrefix labels before relabelling.
+	Headers map[string]string `yaml:"headers,omitempty"`
+	// The address of a multi-target exporter through which all targets are
+	// scraped. The address of a target is kept as its instance label.
+	ExporterAddress string `yaml:"exporter_address,omitempty"`
 	// How frequently to scrape the targets of this scrape config.
 	ScrapeInterval model.Duration `yaml:"scrape_interval,omitempty"`
 	// The timeout for scraping targets of this config.
@@ -555,6 +1085,17 @@ type ScrapeConfig struct {
 	Scheme string `yaml:"scheme,omitempty"`
 	// More than this many samples post metric-relabelling will cause the scrape to fail.
 	SampleLimit uint `yaml:"sample_limit,omitempty"`
+	// More than this many labels post metric-relabelling will cause the scrape to fail.
+	LabelLimit uint `yaml:"label_limit,omitempty"`
+	// More than this label name length post metric-relabelling will cause the scrape to fail.
+	LabelNameLengthLimit uint `yaml:"label_name_length_limit,omitempty"`
+	// More than this label value length post metric-relabelling will cause the scrape to fail.
+	LabelValueLengthLimit uint `yaml:"label_value_length_limit,omitempty"`
+	// The exposition formats to negotiate with targets in order of preference.
+	// Defaults to DefaultScrapeProtocols if empty.
+	ScrapeProtocols []ScrapeProtocol `yaml:"scrape_protocols,omitempty"`
+	// How long the targets removed by service discovery are still scraped.
+	TargetRemovalDelay model.Duration `yaml:"target_removal_delay,omitempty"`
 
 	// We cannot do proper Go type embedding below as the parser will then parse
 	// values arbitrarily into the overflow maps of further-down types.
@@ -571,6 +1112,38 @@ type ScrapeConfig struct {
 	XXX map[string]interface{} `yaml:",inline"`
 }
 
+// HeaderLabelPrefix is the prefix of the labels of a target that are sent as
+// HTTP headers when scraping it.
+const HeaderLabelPrefix = "__header_"
+
+// reservedScrapeHeaders are the lowercased headers that are set on scrapes by
+// Prometheus itself or by the HTTP client configuration.
+var reservedScrapeHeaders = map[string]struct{}{
+	"accept":                              {},
+	"authorization":                       {},
+	"user-agent":                          {},
+	"x-prometheus-scrape-timeout-seconds": {},
+}
+
+// IsReservedScrapeHeader returns whether the HTTP header is set on scrapes by
+// Prometheus itself and cannot be set by targets.
+func IsReservedScrapeHeader(header string) bool {
+	_, ok := reservedScrapeHeaders[strings.ToLower(header)]
+	return ok
+}
+
+// HeaderLabelName returns the name of the label holding the HTTP header of a
+// target. Dashes, which are invalid in label names, are replaced by
+// underscores.
+func HeaderLabelName(header string) model.LabelName {
+	return model.LabelName(HeaderLabelPrefix + strings.Replace(header, "-", "_", -1))
+}
+
+// IsEnabled returns whether the targets of the scrape config are scraped.
+func (c *ScrapeConfig) IsEnabled() bool {
+	return c.Enabled == nil || *c.Enabled
+}
+
 // UnmarshalYAML implements the yaml.Unmarshaler interface.
 func (c *ScrapeConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	*c = DefaultScrapeConfig
@@ -585,6 +1158,31 @@ func (c *ScrapeConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	if len(c.JobName) == 0 {
 		return fmt.Errorf("job_name is empty")
 	}
+	seen := map[ScrapeProtocol]struct{}{}
+	for _, p := range c.ScrapeProtocols {
+		if _, ok := seen[p]; ok {
+			return fmt.Errorf("duplicated scrape protocol %q in scrape config %q", p, c.JobName)
+		}
+		seen[p] = struct{}{}
+	}
+	for name := range c.ParamsFromLabels {
+		if !model.LabelName(model.ParamLabelPrefix + name).IsValid() {
+			return fmt.Errorf("invalid parameter name %q in params_from_labels of scrape config %q", name, c.JobName)
+		}
+	}
+	for name := range c.Headers {
+		if !HeaderLabelName(name).IsValid() {
+			return fmt.Errorf("invalid header name %q in headers of scrape config %q", name, c.JobName)
+		}
+		if IsReservedScrapeHeader(name) {
+			return fmt.Errorf("header %q in headers of scrape config %q is set by Prometheus", name, c.JobName)
+		}
+	}
+	if c.ExporterAddress != "" {
+		if err = CheckTargetAddress(model.LabelValue(c.ExporterAddress)); err != nil {
+			return fmt.Errorf("invalid exporter_address in scrape config %q: %s", c.JobName, err)
+		}
+	}
 
 	// The UnmarshalYAML method of HTTPClientConfig is not being called because it's not a pointer.
 	// We cannot make it a pointer as the parser panics for inlined pointer structs.
@@ -593,8 +1191,9 @@ func (c *ScrapeConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		return err
 	}
 
-	// Check for users putting URLs in target groups.
-	if len(c.RelabelConfigs) == 0 {
+	// Check for users putting URLs in target groups. They are valid targets
+	// of multi-target exporters.
+	if len(c.RelabelConfigs) == 0 && c.ExporterAddress == "" {
 		for _, tg := range c.ServiceDiscoveryConfig.StaticConfigs {
 			for _, t := range tg.Targets {
 				if err = CheckTargetAddress(t[model.AddressLabel]); err != nil {
@@ -606,10 +1205,55 @@ func (c *ScrapeConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	return nil
 }
 
+// ScrapeProtocol is an exposition format negotiated with scrape targets.
+type ScrapeProtocol string
+
+// The valid options for ScrapeProtocol.
+const (
+	PrometheusProto      ScrapeProtocol = "PrometheusProto"
+	PrometheusText0_0_4  ScrapeProtocol = "PrometheusText0.0.4"
+	OpenMetricsText0_0_1 ScrapeProtocol = "OpenMetricsText0.0.1"
+	OpenMetricsText1_0_0 ScrapeProtocol = "OpenMetricsText1.0.0"
+)
+
+var (
+	// ScrapeProtocolsHeaders are the media types of the scrape protocols
+	// sent in the Accept header of scrapes.
+	ScrapeProtocolsHeaders = map[ScrapeProtocol]string{
+		PrometheusProto:      "application/vnd.google.protobuf;proto=io.prometheus.client.MetricFamily;encoding=delimited",
+		PrometheusText0_0_4:  "text/plain;version=0.0.4",
+		OpenMetricsText0_0_1: "application/openmetrics-text;version=0.0.1",
+		OpenMetricsText1_0_0: "application/openmetrics-text;version=1.0.0",
+	}
+
+	// DefaultScrapeProtocols are the scrape protocols negotiated if none
+	// are configured.
+	DefaultScrapeProtocols = []ScrapeProtocol{
+		PrometheusProto,
+		PrometheusText0_0_4,
+	}
+)
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (p *ScrapeProtocol) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	if err := unmarshal((*string)(p)); err != nil {
+		return err
+	}
+	if _, ok := ScrapeProtocolsHeaders[*p]; !ok {
+		return fmt.Errorf("unknown scrape protocol %q, supported: [%s, %s, %s, %s]", *p,
+			PrometheusProto, PrometheusText0_0_4, OpenMetricsText0_0_1, OpenMetricsText1_0_0)
+	}
+	return nil
+}
+
 // AlertingConfig configures alerting and alertmanager related configs.
 type AlertingConfig struct {
 	AlertRelabelConfigs []*RelabelConfig      `yaml:"alert_relabel_configs,omitempty"`
 	AlertmanagerConfigs []*AlertmanagerConfig `yaml:"alertmanagers,omitempty"`
+	// Template of the source links of the alerts sent to Alertmanagers,
+	// replacing the link to the expression in the Prometheus UI. Alerting
+	// rules can override it with SOURCE_TMPL.
+	SourceTemplate string `yaml:"source_tmpl,omitempty"`
 
 	// Catches all undefined fields and must be empty after parsing.
 	XXX map[string]interface{} `yaml:",inline"`
@@ -624,6 +1268,9 @@ func (c *AlertingConfig) UnmarshalYAML(unmarshal func(interface{}) error) error
 	if err := unmarshal((*plain)(c)); err != nil {
 		return err
 	}
+	if _, err := template.New("source_tmpl").Parse(c.SourceTemplate); err != nil {
+		return fmt.Errorf("invalid source_tmpl: %s", err)
+	}
 	return checkOverflow(c.XXX, "alerting config")
 }
 
@@ -691,13 +1338,45 @@ func CheckTargetAddress(address model.LabelValue) error {
 
 // BasicAuth contains basic HTTP authentication credentials.
 type BasicAuth struct {
-	Username string `yaml:"username"`
-	Password Secret `yaml:"password"`
+	Username     string `yaml:"username"`
+	Password     Secret `yaml:"password"`
+	PasswordFile string `yaml:"password_file,omitempty"`
+
+	// Catches all undefined fields and must be empty after parsing.
+	XXX map[string]interface{} `yaml:",inline"`
+}
+
+// OAuth2 is the OAuth2 client credentials grant configuration.
+type OAuth2 struct {
+	ClientID         string            `yaml:"client_id"`
+	ClientSecret     Secret            `yaml:"client_secret"`
+	ClientSecretFile string            `yaml:"client_secret_file,omitempty"`
+	Scopes           []string          `yaml:"scopes,omitempty"`
+	TokenURL         string            `yaml:"token_url"`
+	EndpointParams   map[string]string `yaml:"endpoint_params,omitempty"`
 
 	// Catches all undefined fields and must be empty after parsing.
 	XXX map[string]interface{} `yaml:",inline"`
 }
 
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *OAuth2) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain OAuth2
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if err := checkOverflow(c.XXX, "oauth2"); err != nil {
+		return err
+	}
+	if c.ClientID == "" {
+		return fmt.Errorf("oauth2 configuration requires a client_id")
+	}
+	if c.TokenURL == "" {
+		return fmt.Errorf("oauth2 configuration requires a token_url")
+	}
+	return checkSecret(c.ClientSecret, c.ClientSecretFile, "client_secret")
+}
+
 // ClientCert contains client cert credentials.
 type ClientCert struct {
 	Cert string `yaml:"cert"`
@@ -714,7 +1393,10 @@ func (a *BasicAuth) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	if err != nil {
 		return err
 	}
-	return checkOverflow(a.XXX, "basic_auth")
+	if err := checkOverflow(a.XXX, "basic_auth"); err != nil {
+		return err
+	}
+	return checkSecret(a.Password, a.PasswordFile, "password")
 }
 
 // TargetGroup is a set of targets with a common label set.
@@ -858,11 +1540,15 @@ func (c *FileSDConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 type ConsulSDConfig struct {
 	Server       string `yaml:"server"`
 	Token        Secret `yaml:"token,omitempty"`
+	TokenFile    string `yaml:"token_file,omitempty"`
 	Datacenter   string `yaml:"datacenter,omitempty"`
+	Namespace    string `yaml:"namespace,omitempty"`
+	Partition    string `yaml:"partition,omitempty"`
 	TagSeparator string `yaml:"tag_separator,omitempty"`
 	Scheme       string `yaml:"scheme,omitempty"`
 	Username     string `yaml:"username,omitempty"`
 	Password     Secret `yaml:"password,omitempty"`
+	PasswordFile string `yaml:"password_file,omitempty"`
 	// The list of services for which targets are discovered.
 	// Defaults to all services if empty.
 	Services []string `yaml:"services"`
@@ -886,7 +1572,10 @@ func (c *ConsulSDConfig) UnmarshalYAML(unmarshal func(interface{}) error) error
 	if strings.TrimSpace(c.Server) == "" {
 		return fmt.Errorf("Consul SD configuration requires a server address")
 	}
-	return nil
+	if err := checkSecret(c.Token, c.TokenFile, "token"); err != nil {
+		return err
+	}
+	return checkSecret(c.Password, c.PasswordFile, "password")
 }
 
 // ServersetSDConfig is the configuration for Twitter serversets in Zookeeper based discovery.
@@ -989,6 +1678,9 @@ func (c *MarathonSDConfig) UnmarshalYAML(unmarshal func(interface{}) error) erro
 	if len(c.BearerToken) > 0 && len(c.BearerTokenFile) > 0 {
 		return fmt.Errorf("at most one of bearer_token & bearer_token_file must be configured")
 	}
+	if err := checkSecretFile(c.BearerTokenFile, "bearer_token_file"); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -1018,6 +1710,33 @@ func (c *KubernetesRole) UnmarshalYAML(unmarshal func(interface{}) error) error
 	}
 }
 
+// KubernetesNodeAddressType is a type of address of a Kubernetes node.
+type KubernetesNodeAddressType string
+
+// The valid options for KubernetesNodeAddressType.
+const (
+	KubernetesNodeInternalIP   KubernetesNodeAddressType = "InternalIP"
+	KubernetesNodeExternalIP   KubernetesNodeAddressType = "ExternalIP"
+	KubernetesNodeInternalDNS  KubernetesNodeAddressType = "InternalDNS"
+	KubernetesNodeExternalDNS  KubernetesNodeAddressType = "ExternalDNS"
+	KubernetesNodeLegacyHostIP KubernetesNodeAddressType = "LegacyHostIP"
+	KubernetesNodeHostname     KubernetesNodeAddressType = "Hostname"
+)
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *KubernetesNodeAddressType) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	if err := unmarshal((*string)(c)); err != nil {
+		return err
+	}
+	switch *c {
+	case KubernetesNodeInternalIP, KubernetesNodeExternalIP, KubernetesNodeInternalDNS,
+		KubernetesNodeExternalDNS, KubernetesNodeLegacyHostIP, KubernetesNodeHostname:
+		return nil
+	default:
+		return fmt.Errorf("Unknown Kubernetes node address type %q", *c)
+	}
+}
+
 // KubernetesSDConfig is the configuration for Kubernetes service discovery.
 type KubernetesSDConfig struct {
 	APIServer          URL                          `yaml:"api_server"`
@@ -1027,6 +1746,13 @@ type KubernetesSDConfig struct {
 	BearerTokenFile    string                       `yaml:"bearer_token_file,omitempty"`
 	TLSConfig          TLSConfig                    `yaml:"tls_config,omitempty"`
 	NamespaceDiscovery KubernetesNamespaceDiscovery `yaml:"namespaces"`
+	// The address types of nodes in order of preference to pick the address
+	// of node targets from.
+	NodeAddressTypes []KubernetesNodeAddressType `yaml:"node_address_types,omitempty"`
+	// The kubeconfig file to connect to the API server with, and the context
+	// to use instead of its current context.
+	KubeconfigFile    string `yaml:"kubeconfig_file,omitempty"`
+	KubeconfigContext string `yaml:"kubeconfig_context,omitempty"`
 
 	// Catches all undefined fields and must be empty after parsing.
 	XXX map[string]interface{} `yaml:",inline"`
@@ -1049,14 +1775,32 @@ func (c *KubernetesSDConfig) UnmarshalYAML(unmarshal func(interface{}) error) er
 	if len(c.BearerToken) > 0 && len(c.BearerTokenFile) > 0 {
 		return fmt.Errorf("at most one of bearer_token & bearer_token_file must be configured")
 	}
+	if err := checkSecretFile(c.BearerTokenFile, "bearer_token_file"); err != nil {
+		return err
+	}
 	if c.BasicAuth != nil && (len(c.BearerToken) > 0 || len(c.BearerTokenFile) > 0) {
 		return fmt.Errorf("at most one of basic_auth, bearer_token & bearer_token_file must be configured")
 	}
-	if c.APIServer.URL == nil &&
+	if c.KubeconfigContext != "" && c.KubeconfigFile == "" {
+		return fmt.Errorf("kubeconfig_context requires kubeconfig_file")
+	}
+	if c.KubeconfigFile != "" {
+		if c.APIServer.URL != nil {
+			return fmt.Errorf("at most one of api_server & kubeconfig_file must be configured")
+		}
+		if c.BasicAuth != nil || c.BearerToken != "" || c.BearerTokenFile != "" ||
+			c.TLSConfig.CAFile != "" || c.TLSConfig.CertFile != "" || c.TLSConfig.KeyFile != "" {
+			return fmt.Errorf("cannot use custom authentication with kubeconfig_file, the kubeconfig file provides it")
+		}
+	}
+	if c.APIServer.URL == nil && c.KubeconfigFile == "" &&
 		(c.BasicAuth != nil || c.BearerToken != "" || c.BearerTokenFile != "" ||
 			c.TLSConfig.CAFile != "" || c.TLSConfig.CertFile != "" || c.TLSConfig.KeyFile != "") {
 		return fmt.Errorf("to use custom authentication please provide the 'api_server' URL explicitly")
 	}
+	if len(c.NodeAddressTypes) > 0 && c.Role != KubernetesRoleNode {
+		return fmt.Errorf("node_address_types can only be set for role %q", KubernetesRoleNode)
+	}
 	return nil
 }
 
@@ -1064,6 +1808,12 @@ func (c *KubernetesSDConfig) UnmarshalYAML(unmarshal func(interface{}) error) er
 // Kubernetes namespaces.
 type KubernetesNamespaceDiscovery struct {
 	Names []string `yaml:"names"`
+	// Whether to only discover the namespace Prometheus runs in, as given by
+	// its pod service account.
+	OwnNamespace bool `yaml:"own_namespace,omitempty"`
+	// A label selector of the namespaces to discover, like "team=infra".
+	// The matching namespaces are listed when the discovery starts.
+	LabelSelector string `yaml:"label_selector,omitempty"`
 	// Catches all undefined fields and must be empty after parsing.
 	XXX map[string]interface{} `yaml:",inline"`
 }
@@ -1076,7 +1826,23 @@ func (c *KubernetesNamespaceDiscovery) UnmarshalYAML(unmarshal func(interface{})
 	if err != nil {
 		return err
 	}
-	return checkOverflow(c.XXX, "namespaces")
+	if err := checkOverflow(c.XXX, "namespaces"); err != nil {
+		return err
+	}
+	n := 0
+	if len(c.Names) > 0 {
+		n++
+	}
+	if c.OwnNamespace {
+		n++
+	}
+	if c.LabelSelector != "" {
+		n++
+	}
+	if n > 1 {
+		return fmt.Errorf("at most one of names, own_namespace & label_selector must be configured in namespaces")
+	}
+	return nil
 }
 
 // GCESDConfig is the configuration for GCE based service discovery.
@@ -1126,8 +1892,10 @@ type EC2SDConfig struct {
 	Region          string         `yaml:"region"`
 	AccessKey       string         `yaml:"access_key,omitempty"`
 	SecretKey       Secret         `yaml:"secret_key,omitempty"`
+	SecretKeyFile   string         `yaml:"secret_key_file,omitempty"`
 	Profile         string         `yaml:"profile,omitempty"`
 	RoleARN         string         `yaml:"role_arn,omitempty"`
+	ExternalID      string         `yaml:"external_id,omitempty"`
 	RefreshInterval model.Duration `yaml:"refresh_interval,omitempty"`
 	Port            int            `yaml:"port"`
 
@@ -1146,12 +1914,18 @@ func (c *EC2SDConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	if err := checkOverflow(c.XXX, "ec2_sd_config"); err != nil {
 		return err
 	}
+	if err := checkSecret(c.SecretKey, c.SecretKeyFile, "secret_key"); err != nil {
+		return err
+	}
+	if c.ExternalID != "" && c.RoleARN == "" {
+		return fmt.Errorf("external_id requires role_arn in EC2 SD configuration")
+	}
 	if c.Region == "" {
 		sess, err := session.NewSession()
 		if err != nil {
 			return err
 		}
-		metadata := ec2metadata.New(sess)
+		metadata := awsutil.NewEC2Metadata(sess)
 		region, err := metadata.Region()
 		if err != nil {
 			return fmt.Errorf("EC2 SD configuration requires a region")
@@ -1161,12 +1935,63 @@ func (c *EC2SDConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	return nil
 }
 
+// ECSSDConfig is the configuration for ECS based service discovery.
+type ECSSDConfig struct {
+	Region        string `yaml:"region"`
+	AccessKey     string `yaml:"access_key,omitempty"`
+	SecretKey     Secret `yaml:"secret_key,omitempty"`
+	SecretKeyFile string `yaml:"secret_key_file,omitempty"`
+	Profile       string `yaml:"profile,omitempty"`
+	RoleARN       string `yaml:"role_arn,omitempty"`
+	ExternalID    string `yaml:"external_id,omitempty"`
+	// The names or ARNs of the clusters to discover the tasks of. All
+	// clusters are discovered if empty.
+	Clusters        []string       `yaml:"clusters,omitempty"`
+	RefreshInterval model.Duration `yaml:"refresh_interval,omitempty"`
+	Port            int            `yaml:"port"`
+
+	// Catches all undefined fields and must be empty after parsing.
+	XXX map[string]interface{} `yaml:",inline"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *ECSSDConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultECSSDConfig
+	type plain ECSSDConfig
+	err := unmarshal((*plain)(c))
+	if err != nil {
+		return err
+	}
+	if err := checkOverflow(c.XXX, "ecs_sd_config"); err != nil {
+		return err
+	}
+	if err := checkSecret(c.SecretKey, c.SecretKeyFile, "secret_key"); err != nil {
+		return err
+	}
+	if c.ExternalID != "" && c.RoleARN == "" {
+		return fmt.Errorf("external_id requires role_arn in ECS SD configuration")
+	}
+	if c.Region == "" {
+		sess, err := session.NewSession()
+		if err != nil {
+			return err
+		}
+		region, err := awsutil.NewEC2Metadata(sess).Region()
+		if err != nil {
+			return fmt.Errorf("ECS SD configuration requires a region")
+		}
+		c.Region = region
+	}
+	return nil
+}
+
 // OpenstackSDConfig is the configuration for OpenStack based service discovery.
 type OpenstackSDConfig struct {
 	IdentityEndpoint string         `yaml:"identity_endpoint"`
 	Username         string         `yaml:"username"`
 	UserID           string         `yaml:"userid"`
 	Password         Secret         `yaml:"password"`
+	PasswordFile     string         `yaml:"password_file,omitempty"`
 	ProjectName      string         `yaml:"project_name"`
 	ProjectID        string         `yaml:"project_id"`
 	DomainName       string         `yaml:"domain_name"`
@@ -1217,17 +2042,21 @@ func (c *OpenstackSDConfig) UnmarshalYAML(unmarshal func(interface{}) error) err
 	if c.Role == "" {
 		return fmt.Errorf("role missing (one of: instance, hypervisor)")
 	}
+	if err := checkSecret(c.Password, c.PasswordFile, "password"); err != nil {
+		return err
+	}
 	return checkOverflow(c.XXX, "openstack_sd_config")
 }
 
 // AzureSDConfig is the configuration for Azure based service discovery.
 type AzureSDConfig struct {
-	Port            int            `yaml:"port"`
-	SubscriptionID  string         `yaml:"subscription_id"`
-	TenantID        string         `yaml:"tenant_id,omitempty"`
-	ClientID        string         `yaml:"client_id,omitempty"`
-	ClientSecret    Secret         `yaml:"client_secret,omitempty"`
-	RefreshInterval model.Duration `yaml:"refresh_interval,omitempty"`
+	Port             int            `yaml:"port"`
+	SubscriptionID   string         `yaml:"subscription_id"`
+	TenantID         string         `yaml:"tenant_id,omitempty"`
+	ClientID         string         `yaml:"client_id,omitempty"`
+	ClientSecret     Secret         `yaml:"client_secret,omitempty"`
+	ClientSecretFile string         `yaml:"client_secret_file,omitempty"`
+	RefreshInterval  model.Duration `yaml:"refresh_interval,omitempty"`
 
 	// Catches all undefined fields and must be empty after parsing.
 	XXX map[string]interface{} `yaml:",inline"`
@@ -1241,6 +2070,9 @@ func (c *AzureSDConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	if err != nil {
 		return err
 	}
+	if err := checkSecret(c.ClientSecret, c.ClientSecretFile, "client_secret"); err != nil {
+		return err
+	}
 
 	return checkOverflow(c.XXX, "azure_sd_config")
 }
@@ -1281,213 +2113,992 @@ func (c *TritonSDConfig) UnmarshalYAML(unmarshal func(interface{}) error) error
 	return checkOverflow(c.XXX, "triton_sd_config")
 }
 
-// RelabelAction is the action to be performed on relabeling.
-type RelabelAction string
+// HetznerRole is the API Hetzner SD discovers the servers of.
+type HetznerRole string
 
 const (
-	// RelabelReplace performs a regex replacement.
-	RelabelReplace RelabelAction = "replace"
-	// RelabelKeep drops targets for which the input does not match the regex.
-	RelabelKeep RelabelAction = "keep"
-	// RelabelDrop drops targets for which the input does match the regex.
-	RelabelDrop RelabelAction = "drop"
-	// RelabelHashMod sets a label to the modulus of a hash of labels.
-	RelabelHashMod RelabelAction = "hashmod"
-	// RelabelLabelMap copies labels to other labelnames based on a regex.
-	RelabelLabelMap RelabelAction = "labelmap"
-	// RelabelLabelDrop drops any label matching the regex.
-	RelabelLabelDrop RelabelAction = "labeldrop"
-	// RelabelLabelKeep drops any label not matching the regex.
-	RelabelLabelKeep RelabelAction = "labelkeep"
+	// HetznerRoleHcloud discovers the servers of a Hetzner Cloud project.
+	HetznerRoleHcloud HetznerRole = "hcloud"
+	// HetznerRoleRobot discovers the dedicated servers of a Hetzner Robot
+	// account.
+	HetznerRoleRobot HetznerRole = "robot"
 )
 
 // UnmarshalYAML implements the yaml.Unmarshaler interface.
-func (a *RelabelAction) UnmarshalYAML(unmarshal func(interface{}) error) error {
-	var s string
-	if err := unmarshal(&s); err != nil {
+func (c *HetznerRole) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	if err := unmarshal((*string)(c)); err != nil {
 		return err
 	}
-	switch act := RelabelAction(strings.ToLower(s)); act {
-	case RelabelReplace, RelabelKeep, RelabelDrop, RelabelHashMod, RelabelLabelMap, RelabelLabelDrop, RelabelLabelKeep:
-		*a = act
+	switch *c {
+	case HetznerRoleHcloud, HetznerRoleRobot:
 		return nil
+	default:
+		return fmt.Errorf("unknown Hetzner SD role %q", *c)
 	}
-	return fmt.Errorf("unknown relabel action %q", s)
 }
 
-// RelabelConfig is the configuration for relabeling of target label sets.
-type RelabelConfig struct {
-	// A list of labels from which values are taken and concatenated
-	// with the configured separator in order.
-	SourceLabels model.LabelNames `yaml:"source_labels,flow,omitempty"`
-	// Separator is the string between concatenated values from the source labels.
-	Separator string `yaml:"separator,omitempty"`
-	// Regex against which the concatenation is matched.
-	Regex Regexp `yaml:"regex,omitempty"`
-	// Modulus to take of the hash of concatenated values from the source labels.
-	Modulus uint64 `yaml:"modulus,omitempty"`
-	// TargetLabel is the label to which the resulting string is written in a replacement.
-	// Regexp interpolation is allowed for the replace action.
-	TargetLabel string `yaml:"target_label,omitempty"`
-	// Replacement is the regex replacement pattern to be used.
-	Replacement string `yaml:"replacement,omitempty"`
-	// Action is the action to be performed for the relabeling.
-	Action RelabelAction `yaml:"action,omitempty"`
+// HetznerSDConfig is the configuration for Hetzner based service discovery.
+type HetznerSDConfig struct {
+	Role            HetznerRole    `yaml:"role"`
+	Port            int            `yaml:"port"`
+	RefreshInterval model.Duration `yaml:"refresh_interval,omitempty"`
+
+	// The credentials of the API, a bearer token for the Cloud API and basic
+	// authentication for the Robot API.
+	HTTPClientConfig HTTPClientConfig `yaml:",inline"`
 
 	// Catches all undefined fields and must be empty after parsing.
 	XXX map[string]interface{} `yaml:",inline"`
 }
 
 // UnmarshalYAML implements the yaml.Unmarshaler interface.
-func (c *RelabelConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
-	*c = DefaultRelabelConfig
-	type plain RelabelConfig
+func (c *HetznerSDConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultHetznerSDConfig
+	type plain HetznerSDConfig
 	if err := unmarshal((*plain)(c)); err != nil {
 		return err
 	}
-	if err := checkOverflow(c.XXX, "relabel_config"); err != nil {
+	if err := checkOverflow(c.XXX, "hetzner_sd_config"); err != nil {
 		return err
 	}
-	if c.Regex.Regexp == nil {
-		c.Regex = MustNewRegexp("")
-	}
-	if c.Modulus == 0 && c.Action == RelabelHashMod {
-		return fmt.Errorf("relabel configuration for hashmod requires non-zero modulus")
-	}
-	if (c.Action == RelabelReplace || c.Action == RelabelHashMod) && c.TargetLabel == "" {
-		return fmt.Errorf("relabel configuration for %s action requires 'target_label' value", c.Action)
-	}
-	if c.Action == RelabelReplace && !relabelTarget.MatchString(c.TargetLabel) {
-		return fmt.Errorf("%q is invalid 'target_label' for %s action", c.TargetLabel, c.Action)
+	if c.Role == "" {
+		return fmt.Errorf("Hetzner SD configuration requires a role")
 	}
-	if c.Action == RelabelHashMod && !model.LabelName(c.TargetLabel).IsValid() {
-		return fmt.Errorf("%q is invalid 'target_label' for %s action", c.TargetLabel, c.Action)
+	// The UnmarshalYAML method of HTTPClientConfig is not being called because it's not a pointer.
+	// We cannot make it a pointer as the parser panics for inlined pointer structs.
+	// Thus we just do its validation here.
+	if err := c.HTTPClientConfig.validate(); err != nil {
+		return err
 	}
-
-	if c.Action == RelabelLabelDrop || c.Action == RelabelLabelKeep {
-		if c.SourceLabels != nil ||
-			c.TargetLabel != DefaultRelabelConfig.TargetLabel ||
-			c.Modulus != DefaultRelabelConfig.Modulus ||
-			c.Separator != DefaultRelabelConfig.Separator ||
-			c.Replacement != DefaultRelabelConfig.Replacement {
-			return fmt.Errorf("%s action requires only 'regex', and no other fields", c.Action)
-		}
+	if c.Role == HetznerRoleRobot && c.HTTPClientConfig.BasicAuth == nil {
+		return fmt.Errorf("Hetzner SD configuration with role robot requires basic_auth")
 	}
-
 	return nil
 }
 
-// Regexp encapsulates a regexp.Regexp and makes it YAML marshallable.
-type Regexp struct {
-	*regexp.Regexp
-	original string
-}
+// LinodeSDConfig is the configuration for Linode based service discovery.
+type LinodeSDConfig struct {
+	Port            int            `yaml:"port"`
+	TagSeparator    string         `yaml:"tag_separator,omitempty"`
+	RefreshInterval model.Duration `yaml:"refresh_interval,omitempty"`
 
-// NewRegexp creates a new anchored Regexp and returns an error if the
-// passed-in regular expression does not compile.
-func NewRegexp(s string) (Regexp, error) {
-	regex, err := regexp.Compile("^(?:" + s + ")$")
-	return Regexp{
-		Regexp:   regex,
-		original: s,
-	}, err
-}
+	// The credentials of the API, a personal access token set as bearer
+	// token.
+	HTTPClientConfig HTTPClientConfig `yaml:",inline"`
 
-// MustNewRegexp works like NewRegexp, but panics if the regular expression does not compile.
-func MustNewRegexp(s string) Regexp {
-	re, err := NewRegexp(s)
-	if err != nil {
-		panic(err)
-	}
-	return re
+	// Catches all undefined fields and must be empty after parsing.
+	XXX map[string]interface{} `yaml:",inline"`
 }
 
 // UnmarshalYAML implements the yaml.Unmarshaler interface.
-func (re *Regexp) UnmarshalYAML(unmarshal func(interface{}) error) error {
-	var s string
-	if err := unmarshal(&s); err != nil {
+func (c *LinodeSDConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultLinodeSDConfig
+	type plain LinodeSDConfig
+	if err := unmarshal((*plain)(c)); err != nil {
 		return err
 	}
-	r, err := NewRegexp(s)
-	if err != nil {
+	if err := checkOverflow(c.XXX, "linode_sd_config"); err != nil {
 		return err
 	}
-	*re = r
-	return nil
-}
-
-// MarshalYAML implements the yaml.Marshaler interface.
-func (re Regexp) MarshalYAML() (interface{}, error) {
-	if re.original != "" {
-		return re.original, nil
-	}
-	return nil, nil
+	// The UnmarshalYAML method of HTTPClientConfig is not being called because it's not a pointer.
+	// We cannot make it a pointer as the parser panics for inlined pointer structs.
+	// Thus we just do its validation here.
+	return c.HTTPClientConfig.validate()
 }
 
-// RemoteWriteConfig is the configuration for writing to remote storage.
-type RemoteWriteConfig struct {
-	URL                 *URL             `yaml:"url"`
-	RemoteTimeout       model.Duration   `yaml:"remote_timeout,omitempty"`
-	WriteRelabelConfigs []*RelabelConfig `yaml:"write_relabel_configs,omitempty"`
+// DigitalOceanSDConfig is the configuration for DigitalOcean based service
+// discovery.
+type DigitalOceanSDConfig struct {
+	Port            int            `yaml:"port"`
+	RefreshInterval model.Duration `yaml:"refresh_interval,omitempty"`
 
-	// We cannot do proper Go type embedding below as the parser will then parse
-	// values arbitrarily into the overflow maps of further-down types.
+	// The credentials of the API, an OAuth token set as bearer token or
+	// obtained with oauth2.
 	HTTPClientConfig HTTPClientConfig `yaml:",inline"`
-	QueueConfig      QueueConfig      `yaml:"queue_config,omitempty"`
 
 	// Catches all undefined fields and must be empty after parsing.
 	XXX map[string]interface{} `yaml:",inline"`
 }
 
 // UnmarshalYAML implements the yaml.Unmarshaler interface.
-func (c *RemoteWriteConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
-	*c = DefaultRemoteWriteConfig
-	type plain RemoteWriteConfig
+func (c *DigitalOceanSDConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultDigitalOceanSDConfig
+	type plain DigitalOceanSDConfig
 	if err := unmarshal((*plain)(c)); err != nil {
 		return err
 	}
-	if c.URL == nil {
-		return fmt.Errorf("url for remote_write is empty")
+	if err := checkOverflow(c.XXX, "digitalocean_sd_config"); err != nil {
+		return err
 	}
-
 	// The UnmarshalYAML method of HTTPClientConfig is not being called because it's not a pointer.
 	// We cannot make it a pointer as the parser panics for inlined pointer structs.
 	// Thus we just do its validation here.
-	if err := c.HTTPClientConfig.validate(); err != nil {
-		return err
-	}
-
-	return checkOverflow(c.XXX, "remote_write")
+	return c.HTTPClientConfig.validate()
 }
 
-// QueueConfig is the configuration for the queue used to write to remote
-// storage.
-type QueueConfig struct {
-	// Number of samples to buffer per shard before we start dropping them.
-	Capacity int `yaml:"capacity,omitempty"`
-
-	// Max number of shards, i.e. amount of concurrency.
-	MaxShards int `yaml:"max_shards,omitempty"`
+// ScalewayRole is the product Scaleway SD discovers the servers of.
+type ScalewayRole string
 
-	// Maximum number of samples per send.
-	MaxSamplesPerSend int `yaml:"max_samples_per_send,omitempty"`
+const (
+	// ScalewayRoleInstance discovers instances.
+	ScalewayRoleInstance ScalewayRole = "instance"
+	// ScalewayRoleBaremetal discovers Elastic Metal servers.
+	ScalewayRoleBaremetal ScalewayRole = "baremetal"
+)
 
-	// Maximum time sample will wait in buffer.
-	BatchSendDeadline time.Duration `yaml:"batch_send_deadline,omitempty"`
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *ScalewayRole) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	if err := unmarshal((*string)(c)); err != nil {
+		return err
+	}
+	switch *c {
+	case ScalewayRoleInstance, ScalewayRoleBaremetal:
+		return nil
+	default:
+		return fmt.Errorf("unknown Scaleway SD role %q", *c)
+	}
+}
 
-	// Max number of times to retry a batch on recoverable errors.
-	MaxRetries int `yaml:"max_retries,omitempty"`
+// ScalewaySDConfig is the configuration for Scaleway based service discovery.
+type ScalewaySDConfig struct {
+	Role   ScalewayRole `yaml:"role"`
+	APIURL string       `yaml:"api_url,omitempty"`
+	// The zone of the servers, like fr-par-1.
+	Zone string `yaml:"zone"`
+	// The project the servers belong to. Servers of all projects the secret
+	// key has access to are discovered if empty.
+	ProjectID       string         `yaml:"project_id,omitempty"`
+	SecretKey       Secret         `yaml:"secret_key,omitempty"`
+	SecretKeyFile   string         `yaml:"secret_key_file,omitempty"`
+	Port            int            `yaml:"port"`
+	RefreshInterval model.Duration `yaml:"refresh_interval,omitempty"`
 
-	// On recoverable errors, backoff exponentially.
-	MinBackoff time.Duration `yaml:"min_backoff,omitempty"`
-	MaxBackoff time.Duration `yaml:"max_backoff,omitempty"`
+	// Catches all undefined fields and must be empty after parsing.
+	XXX map[string]interface{} `yaml:",inline"`
 }
 
-// RemoteReadConfig is the configuration for reading from remote storage.
-type RemoteReadConfig struct {
-	URL           *URL           `yaml:"url"`
-	RemoteTimeout model.Duration `yaml:"remote_timeout,omitempty"`
-
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *ScalewaySDConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultScalewaySDConfig
+	type plain ScalewaySDConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if err := checkOverflow(c.XXX, "scaleway_sd_config"); err != nil {
+		return err
+	}
+	if c.Role == "" {
+		return fmt.Errorf("Scaleway SD configuration requires a role")
+	}
+	if c.Zone == "" {
+		return fmt.Errorf("Scaleway SD configuration requires a zone")
+	}
+	if c.SecretKey == "" && c.SecretKeyFile == "" {
+		return fmt.Errorf("Scaleway SD configuration requires a secret_key or secret_key_file")
+	}
+	if err := checkSecret(c.SecretKey, c.SecretKeyFile, "secret_key"); err != nil {
+		return err
+	}
+	if _, err := url.Parse(c.APIURL); err != nil {
+		return fmt.Errorf("invalid Scaleway SD api_url: %s", err)
+	}
+	return nil
+}
+
+// PuppetDBSDConfig is the configuration for PuppetDB based service discovery.
+type PuppetDBSDConfig struct {
+	// The URL of the PuppetDB root query endpoint, without the /pdb/query
+	// path.
+	URL string `yaml:"url"`
+	// The PQL query returning the resources to turn into targets.
+	Query string `yaml:"query"`
+	// Whether to add the parameters of the resources as labels.
+	IncludeParameters bool `yaml:"include_parameters,omitempty"`
+	// Whether to add the facts of the nodes of the resources as labels.
+	IncludeFacts    bool           `yaml:"include_facts,omitempty"`
+	Port            int            `yaml:"port"`
+	RefreshInterval model.Duration `yaml:"refresh_interval,omitempty"`
+
+	HTTPClientConfig HTTPClientConfig `yaml:",inline"`
+
+	// Catches all undefined fields and must be empty after parsing.
+	XXX map[string]interface{} `yaml:",inline"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *PuppetDBSDConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultPuppetDBSDConfig
+	type plain PuppetDBSDConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if err := checkOverflow(c.XXX, "puppetdb_sd_config"); err != nil {
+		return err
+	}
+	if c.URL == "" {
+		return fmt.Errorf("PuppetDB SD configuration requires a URL")
+	}
+	u, err := url.Parse(c.URL)
+	if err != nil {
+		return fmt.Errorf("invalid PuppetDB SD url: %s", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("PuppetDB SD url must use the http or https scheme, got %q", c.URL)
+	}
+	if c.Query == "" {
+		return fmt.Errorf("PuppetDB SD configuration requires a query")
+	}
+	// The UnmarshalYAML method of HTTPClientConfig is not being called because it's not a pointer.
+	// We cannot make it a pointer as the parser panics for inlined pointer structs.
+	// Thus we just do its validation here.
+	return c.HTTPClientConfig.validate()
+}
+
+// EurekaSDConfig is the configuration for Eureka based service discovery.
+type EurekaSDConfig struct {
+	// The URL of the Eureka server, like http://eureka:8761/eureka.
+	Server          string         `yaml:"server"`
+	RefreshInterval model.Duration `yaml:"refresh_interval,omitempty"`
+
+	HTTPClientConfig HTTPClientConfig `yaml:",inline"`
+
+	// Catches all undefined fields and must be empty after parsing.
+	XXX map[string]interface{} `yaml:",inline"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *EurekaSDConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultEurekaSDConfig
+	type plain EurekaSDConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if err := checkOverflow(c.XXX, "eureka_sd_config"); err != nil {
+		return err
+	}
+	if c.Server == "" {
+		return fmt.Errorf("Eureka SD configuration requires a server URL")
+	}
+	u, err := url.Parse(c.Server)
+	if err != nil {
+		return fmt.Errorf("invalid Eureka SD server URL: %s", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("Eureka SD server URL must use the http or https scheme, got %q", c.Server)
+	}
+	// The UnmarshalYAML method of HTTPClientConfig is not being called because it's not a pointer.
+	// We cannot make it a pointer as the parser panics for inlined pointer structs.
+	// Thus we just do its validation here.
+	return c.HTTPClientConfig.validate()
+}
+
+// NomadSDConfig is the configuration for Nomad based service discovery.
+type NomadSDConfig struct {
+	Server    string `yaml:"server,omitempty"`
+	Namespace string `yaml:"namespace,omitempty"`
+	// The region to query, the one of the server if empty.
+	Region string `yaml:"region,omitempty"`
+	// Whether any server can answer the queries, instead of only the leader.
+	AllowStale   bool   `yaml:"allow_stale"`
+	TagSeparator string `yaml:"tag_separator,omitempty"`
+	// Whether to look up the allocations of the services to add the task group
+	// and task they are registered by as labels.
+	IncludeAllocations bool           `yaml:"include_allocations,omitempty"`
+	RefreshInterval    model.Duration `yaml:"refresh_interval,omitempty"`
+
+	// The ACL token can be set as bearer token.
+	HTTPClientConfig HTTPClientConfig `yaml:",inline"`
+
+	// Catches all undefined fields and must be empty after parsing.
+	XXX map[string]interface{} `yaml:",inline"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *NomadSDConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultNomadSDConfig
+	type plain NomadSDConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if err := checkOverflow(c.XXX, "nomad_sd_config"); err != nil {
+		return err
+	}
+	u, err := url.Parse(c.Server)
+	if err != nil {
+		return fmt.Errorf("invalid Nomad SD server URL: %s", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("Nomad SD server URL must use the http or https scheme, got %q", c.Server)
+	}
+	// The UnmarshalYAML method of HTTPClientConfig is not being called because it's not a pointer.
+	// We cannot make it a pointer as the parser panics for inlined pointer structs.
+	// Thus we just do its validation here.
+	return c.HTTPClientConfig.validate()
+}
+
+// UyuniSDConfig is the configuration for Uyuni based service discovery.
+type UyuniSDConfig struct {
+	// The URL of the Uyuni or SUSE Manager server.
+	Server       string `yaml:"server"`
+	Username     string `yaml:"username"`
+	Password     Secret `yaml:"password,omitempty"`
+	PasswordFile string `yaml:"password_file,omitempty"`
+	// The entitlement of the systems to discover.
+	Entitlement string `yaml:"entitlement,omitempty"`
+	// The separator of the system groups list.
+	Separator       string         `yaml:"separator,omitempty"`
+	RefreshInterval model.Duration `yaml:"refresh_interval,omitempty"`
+
+	HTTPClientConfig HTTPClientConfig `yaml:",inline"`
+
+	// Catches all undefined fields and must be empty after parsing.
+	XXX map[string]interface{} `yaml:",inline"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *UyuniSDConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultUyuniSDConfig
+	type plain UyuniSDConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if err := checkOverflow(c.XXX, "uyuni_sd_config"); err != nil {
+		return err
+	}
+	if c.Server == "" {
+		return fmt.Errorf("Uyuni SD configuration requires a server URL")
+	}
+	u, err := url.Parse(c.Server)
+	if err != nil {
+		return fmt.Errorf("invalid Uyuni SD server URL: %s", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("Uyuni SD server URL must use the http or https scheme, got %q", c.Server)
+	}
+	if c.Username == "" {
+		return fmt.Errorf("Uyuni SD configuration requires a username")
+	}
+	if c.Password == "" && c.PasswordFile == "" {
+		return fmt.Errorf("Uyuni SD configuration requires a password or password_file")
+	}
+	if err := checkSecret(c.Password, c.PasswordFile, "password"); err != nil {
+		return err
+	}
+	// The UnmarshalYAML method of HTTPClientConfig is not being called because it's not a pointer.
+	// We cannot make it a pointer as the parser panics for inlined pointer structs.
+	// Thus we just do its validation here.
+	return c.HTTPClientConfig.validate()
+}
+
+// VultrSDConfig is the configuration for Vultr based service discovery.
+type VultrSDConfig struct {
+	Port            int            `yaml:"port"`
+	RefreshInterval model.Duration `yaml:"refresh_interval,omitempty"`
+
+	// The API key is set as bearer token.
+	HTTPClientConfig HTTPClientConfig `yaml:",inline"`
+
+	// Catches all undefined fields and must be empty after parsing.
+	XXX map[string]interface{} `yaml:",inline"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *VultrSDConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultVultrSDConfig
+	type plain VultrSDConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if err := checkOverflow(c.XXX, "vultr_sd_config"); err != nil {
+		return err
+	}
+	// The UnmarshalYAML method of HTTPClientConfig is not being called because it's not a pointer.
+	// We cannot make it a pointer as the parser panics for inlined pointer structs.
+	// Thus we just do its validation here.
+	return c.HTTPClientConfig.validate()
+}
+
+// OVHcloudService is the kind of servers OVHcloud SD discovers.
+type OVHcloudService string
+
+const (
+	// OVHcloudServiceVPS discovers virtual private servers.
+	OVHcloudServiceVPS OVHcloudService = "vps"
+	// OVHcloudServiceDedicatedServer discovers dedicated servers.
+	OVHcloudServiceDedicatedServer OVHcloudService = "dedicated_server"
+)
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *OVHcloudService) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	if err := unmarshal((*string)(c)); err != nil {
+		return err
+	}
+	switch *c {
+	case OVHcloudServiceVPS, OVHcloudServiceDedicatedServer:
+		return nil
+	default:
+		return fmt.Errorf("unknown OVHcloud SD service %q", *c)
+	}
+}
+
+// OVHcloudSDConfig is the configuration for OVHcloud based service discovery.
+type OVHcloudSDConfig struct {
+	// The API endpoint, either the name of an OVHcloud region like ovh-eu,
+	// ovh-ca or ovh-us, or the URL of the API.
+	Endpoint          string          `yaml:"endpoint,omitempty"`
+	ApplicationKey    string          `yaml:"application_key"`
+	ApplicationSecret Secret          `yaml:"application_secret"`
+	ConsumerKey       Secret          `yaml:"consumer_key"`
+	Service           OVHcloudService `yaml:"service"`
+	Port              int             `yaml:"port"`
+	RefreshInterval   model.Duration  `yaml:"refresh_interval,omitempty"`
+
+	// Catches all undefined fields and must be empty after parsing.
+	XXX map[string]interface{} `yaml:",inline"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *OVHcloudSDConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultOVHcloudSDConfig
+	type plain OVHcloudSDConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if err := checkOverflow(c.XXX, "ovhcloud_sd_config"); err != nil {
+		return err
+	}
+	if c.Service == "" {
+		return fmt.Errorf("OVHcloud SD configuration requires a service")
+	}
+	if c.ApplicationKey == "" || c.ApplicationSecret == "" || c.ConsumerKey == "" {
+		return fmt.Errorf("OVHcloud SD configuration requires an application_key, application_secret and consumer_key")
+	}
+	if c.Endpoint == "" {
+		return fmt.Errorf("OVHcloud SD configuration requires an endpoint")
+	}
+	return nil
+}
+
+// KumaSDConfig is the configuration for Kuma based service discovery, fetching
+// the targets assigned by the control plane with the Monitoring Assignment
+// Discovery Service.
+type KumaSDConfig struct {
+	// The URL of the control plane, like http://kuma-control-plane:5676.
+	Server string `yaml:"server"`
+	// The ID identifying Prometheus to the control plane, the hostname if
+	// empty.
+	ClientID        string         `yaml:"client_id,omitempty"`
+	RefreshInterval model.Duration `yaml:"refresh_interval,omitempty"`
+	// How long the control plane may hold a request until the assignments
+	// change.
+	FetchTimeout model.Duration `yaml:"fetch_timeout,omitempty"`
+
+	HTTPClientConfig HTTPClientConfig `yaml:",inline"`
+
+	// Catches all undefined fields and must be empty after parsing.
+	XXX map[string]interface{} `yaml:",inline"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *KumaSDConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultKumaSDConfig
+	type plain KumaSDConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if err := checkOverflow(c.XXX, "kuma_sd_config"); err != nil {
+		return err
+	}
+	if c.Server == "" {
+		return fmt.Errorf("Kuma SD configuration requires a server URL")
+	}
+	u, err := url.Parse(c.Server)
+	if err != nil {
+		return fmt.Errorf("invalid Kuma SD server URL: %s", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("Kuma SD server URL must use the http or https scheme, got %q", c.Server)
+	}
+	if c.FetchTimeout <= 0 {
+		return fmt.Errorf("Kuma SD fetch_timeout must be positive")
+	}
+	// The UnmarshalYAML method of HTTPClientConfig is not being called because it's not a pointer.
+	// We cannot make it a pointer as the parser panics for inlined pointer structs.
+	// Thus we just do its validation here.
+	return c.HTTPClientConfig.validate()
+}
+
+// RelabelAction is the action to be performed on relabeling.
+type RelabelAction string
+
+const (
+	// RelabelReplace performs a regex replacement.
+	RelabelReplace RelabelAction = "replace"
+	// RelabelKeep drops targets for which the input does not match the regex.
+	RelabelKeep RelabelAction = "keep"
+	// RelabelDrop drops targets for which the input does match the regex.
+	RelabelDrop RelabelAction = "drop"
+	// RelabelHashMod sets a label to the modulus of a hash of the concatenated
+	// source labels prefixed with the hash seed. See HashFunction for the hashes.
+	RelabelHashMod RelabelAction = "hashmod"
+	// RelabelLabelMap copies labels to other labelnames based on a regex.
+	RelabelLabelMap RelabelAction = "labelmap"
+	// RelabelLabelDrop drops any label matching the regex.
+	RelabelLabelDrop RelabelAction = "labeldrop"
+	// RelabelLabelKeep drops any label not matching the regex.
+	RelabelLabelKeep RelabelAction = "labelkeep"
+	// RelabelLowercase sets a label to the lowercased concatenation of the source labels.
+	RelabelLowercase RelabelAction = "lowercase"
+	// RelabelUppercase sets a label to the uppercased concatenation of the source labels.
+	RelabelUppercase RelabelAction = "uppercase"
+	// RelabelKeepEqual drops targets for which the concatenation of the source labels
+	// does not equal the target label.
+	RelabelKeepEqual RelabelAction = "keepequal"
+	// RelabelDropEqual drops targets for which the concatenation of the source labels
+	// equals the target label.
+	RelabelDropEqual RelabelAction = "dropequal"
+)
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (a *RelabelAction) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	switch act := RelabelAction(strings.ToLower(s)); act {
+	case RelabelReplace, RelabelKeep, RelabelDrop, RelabelHashMod, RelabelLabelMap, RelabelLabelDrop, RelabelLabelKeep,
+		RelabelLowercase, RelabelUppercase, RelabelKeepEqual, RelabelDropEqual:
+		*a = act
+		return nil
+	}
+	return fmt.Errorf("unknown relabel action %q", s)
+}
+
+// HashFunction is the hash function used by the hashmod relabel action.
+type HashFunction string
+
+const (
+	// HashMD5 uses the last 8 bytes of the MD5 sum as a big-endian integer.
+	// It is the default and stays stable across releases.
+	HashMD5 HashFunction = "md5"
+	// HashFNV64a uses the 64-bit FNV-1a hash, which is cheaper to compute.
+	HashFNV64a HashFunction = "fnv64a"
+)
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (h *HashFunction) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	switch f := HashFunction(strings.ToLower(s)); f {
+	case HashMD5, HashFNV64a:
+		*h = f
+		return nil
+	}
+	return fmt.Errorf("unknown hash function %q", s)
+}
+
+// RelabelConfig is the configuration for relabeling of target label sets.
+type RelabelConfig struct {
+	// A list of labels from which values are taken and concatenated
+	// with the configured separator in order.
+	SourceLabels model.LabelNames `yaml:"source_labels,flow,omitempty"`
+	// Separator is the string between concatenated values from the source labels.
+	Separator string `yaml:"separator,omitempty"`
+	// Regex against which the concatenation is matched.
+	Regex Regexp `yaml:"regex,omitempty"`
+	// Modulus to take of the hash of concatenated values from the source labels.
+	Modulus uint64 `yaml:"modulus,omitempty"`
+	// HashFunction is the hash function used by the hashmod action.
+	HashFunction HashFunction `yaml:"hash_function,omitempty"`
+	// HashSeed is prepended to the concatenated values before hashing, so that
+	// different fleets can shard the same targets differently.
+	HashSeed string `yaml:"hash_seed,omitempty"`
+	// TargetLabel is the label to which the resulting string is written in a replacement.
+	// Regexp interpolation is allowed for the replace action.
+	TargetLabel string `yaml:"target_label,omitempty"`
+	// Replacement is the regex replacement pattern to be used.
+	Replacement string `yaml:"replacement,omitempty"`
+	// Action is the action to be performed for the relabeling.
+	Action RelabelAction `yaml:"action,omitempty"`
+
+	// Catches all undefined fields and must be empty after parsing.
+	XXX map[string]interface{} `yaml:",inline"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *RelabelConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultRelabelConfig
+	type plain RelabelConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if err := checkOverflow(c.XXX, "relabel_config"); err != nil {
+		return err
+	}
+	if c.Regex.Regexp == nil {
+		c.Regex = MustNewRegexp("")
+	}
+	if c.Modulus == 0 && c.Action == RelabelHashMod {
+		return fmt.Errorf("relabel configuration for hashmod requires non-zero modulus")
+	}
+	if (c.HashFunction != "" || c.HashSeed != "") && c.Action != RelabelHashMod {
+		return fmt.Errorf("'hash_function' and 'hash_seed' are only allowed for the hashmod action")
+	}
+	switch c.Action {
+	case RelabelReplace, RelabelHashMod, RelabelLowercase, RelabelUppercase, RelabelKeepEqual, RelabelDropEqual:
+		if c.TargetLabel == "" {
+			return fmt.Errorf("relabel configuration for %s action requires 'target_label' value", c.Action)
+		}
+	}
+	if c.Action == RelabelReplace && !relabelTarget.MatchString(c.TargetLabel) {
+		return fmt.Errorf("%q is invalid 'target_label' for %s action", c.TargetLabel, c.Action)
+	}
+	switch c.Action {
+	case RelabelHashMod, RelabelLowercase, RelabelUppercase, RelabelKeepEqual, RelabelDropEqual:
+		if !model.LabelName(c.TargetLabel).IsValid() {
+			return fmt.Errorf("%q is invalid 'target_label' for %s action", c.TargetLabel, c.Action)
+		}
+	}
+
+	if c.Action == RelabelLabelDrop || c.Action == RelabelLabelKeep {
+		if c.SourceLabels != nil ||
+			c.TargetLabel != DefaultRelabelConfig.TargetLabel ||
+			c.Modulus != DefaultRelabelConfig.Modulus ||
+			c.Separator != DefaultRelabelConfig.Separator ||
+			c.Replacement != DefaultRelabelConfig.Replacement {
+			return fmt.Errorf("%s action requires only 'regex', and no other fields", c.Action)
+		}
+	}
+	if c.Action == RelabelKeepEqual || c.Action == RelabelDropEqual {
+		if c.Regex.original != DefaultRelabelConfig.Regex.original ||
+			c.Modulus != DefaultRelabelConfig.Modulus ||
+			c.Separator != DefaultRelabelConfig.Separator ||
+			c.Replacement != DefaultRelabelConfig.Replacement {
+			return fmt.Errorf("%s action requires only 'source_labels' and 'target_label', and no other fields", c.Action)
+		}
+	}
+
+	return nil
+}
+
+// Regexp encapsulates a regexp.Regexp and makes it YAML marshallable.
+type Regexp struct {
+	*regexp.Regexp
+	original string
+}
+
+// NewRegexp creates a new anchored Regexp and returns an error if the
+// passed-in regular expression does not compile.
+func NewRegexp(s string) (Regexp, error) {
+	regex, err := regexp.Compile("^(?:" + s + ")$")
+	return Regexp{
+		Regexp:   regex,
+		original: s,
+	}, err
+}
+
+// MustNewRegexp works like NewRegexp, but panics if the regular expression does not compile.
+func MustNewRegexp(s string) Regexp {
+	re, err := NewRegexp(s)
+	if err != nil {
+		panic(err)
+	}
+	return re
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (re *Regexp) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	r, err := NewRegexp(s)
+	if err != nil {
+		return err
+	}
+	*re = r
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (re Regexp) MarshalYAML() (interface{}, error) {
+	if re.original != "" {
+		return re.original, nil
+	}
+	return nil, nil
+}
+
+// RemoteWriteConfig is the configuration for writing to remote storage.
+type RemoteWriteConfig struct {
+	URL                 *URL             `yaml:"url"`
+	RemoteTimeout       model.Duration   `yaml:"remote_timeout,omitempty"`
+	WriteRelabelConfigs []*RelabelConfig `yaml:"write_relabel_configs,omitempty"`
+
+	// We cannot do proper Go type embedding below as the parser will then parse
+	// values arbitrarily into the overflow maps of further-down types.
+	HTTPClientConfig HTTPClientConfig `yaml:",inline"`
+	QueueConfig      QueueConfig      `yaml:"queue_config,omitempty"`
+	SigV4Config      *SigV4Config     `yaml:"sigv4,omitempty"`
+	AzureADConfig    *AzureADConfig   `yaml:"azuread,omitempty"`
+	GoogleIAMConfig  *GoogleIAMConfig `yaml:"google_iam,omitempty"`
+
+	// ProtobufMessage is the protobuf message sent to the remote endpoint.
+	ProtobufMessage RemoteWriteProtoMsg `yaml:"protobuf_message,omitempty"`
+
+	// Catches all undefined fields and must be empty after parsing.
+	XXX map[string]interface{} `yaml:",inline"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *RemoteWriteConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultRemoteWriteConfig
+	type plain RemoteWriteConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if c.URL == nil {
+		return fmt.Errorf("url for remote_write is empty")
+	}
+
+	// The UnmarshalYAML method of HTTPClientConfig is not being called because it's not a pointer.
+	// We cannot make it a pointer as the parser panics for inlined pointer structs.
+	// Thus we just do its validation here.
+	if err := c.HTTPClientConfig.validate(); err != nil {
+		return err
+	}
+
+	httpClientConfigAuthEnabled := c.HTTPClientConfig.BasicAuth != nil ||
+		c.HTTPClientConfig.OAuth2 != nil ||
+		len(c.HTTPClientConfig.BearerToken) > 0 ||
+		len(c.HTTPClientConfig.BearerTokenFile) > 0
+	authModules := 0
+	for _, enabled := range []bool{httpClientConfigAuthEnabled, c.SigV4Config != nil, c.AzureADConfig != nil, c.GoogleIAMConfig != nil} {
+		if enabled {
+			authModules++
+		}
+	}
+	if authModules > 1 {
+		return fmt.Errorf("at most one of basic_auth, oauth2, bearer_token, bearer_token_file, sigv4, azuread & google_iam must be configured")
+	}
+
+	if c.QueueConfig.SampleAgeLimit < 0 {
+		return fmt.Errorf("sample_age_limit for remote_write must not be negative")
+	}
+
+	switch c.ProtobufMessage {
+	case RemoteWriteProtoMsgV1, RemoteWriteProtoMsgV2:
+	default:
+		return fmt.Errorf("unknown remote write protobuf_message %q, must be one of %q or %q", c.ProtobufMessage, RemoteWriteProtoMsgV1, RemoteWriteProtoMsgV2)
+	}
+
+	return checkOverflow(c.XXX, "remote_write")
+}
+
+// RemoteWriteProtoMsg is the name of a protobuf message used for remote
+// write requests.
+type RemoteWriteProtoMsg string
+
+const (
+	// RemoteWriteProtoMsgV1 is the original remote write message, which
+	// sends all labels as plain strings.
+	RemoteWriteProtoMsgV1 RemoteWriteProtoMsg = "prometheus.WriteRequest"
+	// RemoteWriteProtoMsgV2 is the version 2 remote write message, which
+	// interns label names and values in a per-request symbol table.
+	RemoteWriteProtoMsgV2 RemoteWriteProtoMsg = "io.prometheus.write.v2.Request"
+)
+
+// SigV4Config is the configuration for signing remote write requests with
+// AWS's Signature Version 4 signing process.
+type SigV4Config struct {
+	Region        string `yaml:"region,omitempty"`
+	AccessKey     string `yaml:"access_key,omitempty"`
+	SecretKey     Secret `yaml:"secret_key,omitempty"`
+	SecretKeyFile string `yaml:"secret_key_file,omitempty"`
+	Profile       string `yaml:"profile,omitempty"`
+	RoleARN       string `yaml:"role_arn,omitempty"`
+
+	// Catches all undefined fields and must be empty after parsing.
+	XXX map[string]interface{} `yaml:",inline"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *SigV4Config) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain SigV4Config
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if err := checkOverflow(c.XXX, "sigv4"); err != nil {
+		return err
+	}
+	if err := checkSecret(c.SecretKey, c.SecretKeyFile, "secret_key"); err != nil {
+		return err
+	}
+	if (c.AccessKey == "") != (c.SecretKey == "" && c.SecretKeyFile == "") {
+		return fmt.Errorf("must provide an AWS SigV4 access_key and secret_key if either is provided")
+	}
+	return nil
+}
+
+// Azure clouds remote write requests can be authenticated against with Azure AD.
+const (
+	AzurePublic     = "AzurePublic"
+	AzureChina      = "AzureChina"
+	AzureGovernment = "AzureGovernment"
+)
+
+// AzureADConfig is the configuration for authenticating remote write requests
+// to Azure Monitor with Azure AD. Exactly one of a managed identity and an
+// OAuth client must be configured.
+type AzureADConfig struct {
+	// Cloud is the Azure cloud to authenticate against, AzurePublic by
+	// default.
+	Cloud           string                      `yaml:"cloud,omitempty"`
+	ManagedIdentity *AzureManagedIdentityConfig `yaml:"managed_identity,omitempty"`
+	OAuth           *AzureOAuthConfig           `yaml:"oauth,omitempty"`
+
+	// Catches all undefined fields and must be empty after parsing.
+	XXX map[string]interface{} `yaml:",inline"`
+}
+
+// AzureManagedIdentityConfig configures the managed identity tokens are
+// requested for from the instance metadata service.
+type AzureManagedIdentityConfig struct {
+	ClientID string `yaml:"client_id"`
+
+	// Catches all undefined fields and must be empty after parsing.
+	XXX map[string]interface{} `yaml:",inline"`
+}
+
+// AzureOAuthConfig configures the Azure AD application tokens are requested
+// for with the OAuth2 client credentials grant.
+type AzureOAuthConfig struct {
+	ClientID         string `yaml:"client_id"`
+	ClientSecret     Secret `yaml:"client_secret,omitempty"`
+	ClientSecretFile string `yaml:"client_secret_file,omitempty"`
+	TenantID         string `yaml:"tenant_id"`
+
+	// Catches all undefined fields and must be empty after parsing.
+	XXX map[string]interface{} `yaml:",inline"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *AzureADConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain AzureADConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if err := checkOverflow(c.XXX, "azuread"); err != nil {
+		return err
+	}
+	switch c.Cloud {
+	case "":
+		c.Cloud = AzurePublic
+	case AzurePublic, AzureChina, AzureGovernment:
+	default:
+		return fmt.Errorf("unknown azuread cloud %q, must be one of %s, %s or %s", c.Cloud, AzurePublic, AzureChina, AzureGovernment)
+	}
+	if (c.ManagedIdentity == nil) == (c.OAuth == nil) {
+		return fmt.Errorf("exactly one of managed_identity & oauth must be configured in azuread")
+	}
+	return nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *AzureManagedIdentityConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain AzureManagedIdentityConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if err := checkOverflow(c.XXX, "managed_identity"); err != nil {
+		return err
+	}
+	if c.ClientID == "" {
+		return fmt.Errorf("azuread managed_identity requires a client_id")
+	}
+	return nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *AzureOAuthConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain AzureOAuthConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if err := checkOverflow(c.XXX, "oauth"); err != nil {
+		return err
+	}
+	if c.ClientID == "" || c.TenantID == "" {
+		return fmt.Errorf("azuread oauth requires a client_id and a tenant_id")
+	}
+	if c.ClientSecret == "" && c.ClientSecretFile == "" {
+		return fmt.Errorf("azuread oauth requires a client_secret or client_secret_file")
+	}
+	return checkSecret(c.ClientSecret, c.ClientSecretFile, "client_secret")
+}
+
+// GoogleIAMConfig is the configuration for authenticating remote write
+// requests to Google Cloud Monitoring with a Google service account.
+type GoogleIAMConfig struct {
+	// CredentialsFile is the JSON key file of the service account. If it is
+	// empty, the Application Default Credentials are used.
+	CredentialsFile string `yaml:"credentials_file,omitempty"`
+
+	// Catches all undefined fields and must be empty after parsing.
+	XXX map[string]interface{} `yaml:",inline"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *GoogleIAMConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain GoogleIAMConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	return checkOverflow(c.XXX, "google_iam")
+}
+
+// QueueConfig is the configuration for the queue used to write to remote
+// storage.
+type QueueConfig struct {
+	// Number of samples to buffer per shard before we start dropping them.
+	Capacity int `yaml:"capacity,omitempty"`
+
+	// Max number of shards, i.e. amount of concurrency.
+	MaxShards int `yaml:"max_shards,omitempty"`
+
+	// Maximum number of samples per send.
+	MaxSamplesPerSend int `yaml:"max_samples_per_send,omitempty"`
+
+	// Maximum time sample will wait in buffer.
+	BatchSendDeadline time.Duration `yaml:"batch_send_deadline,omitempty"`
+
+	// Max number of times to retry a batch on recoverable errors.
+	MaxRetries int `yaml:"max_retries,omitempty"`
+
+	// On recoverable errors, backoff exponentially.
+	MinBackoff time.Duration `yaml:"min_backoff,omitempty"`
+	MaxBackoff time.Duration `yaml:"max_backoff,omitempty"`
+
+	// Samples older than this are dropped instead of being sent, so that
+	// recent samples keep flowing after a long outage. 0 means no limit.
+	SampleAgeLimit time.Duration `yaml:"sample_age_limit,omitempty"`
+}
+
+// RemoteReadConfig is the configuration for reading from remote storage.
+type RemoteReadConfig struct {
+	URL           *URL           `yaml:"url"`
+	RemoteTimeout model.Duration `yaml:"remote_timeout,omitempty"`
+	// RequiredMatchers is an optional list of equality matchers which have to
+	// be present in a selector to query the remote read endpoint.
+	RequiredMatchers model.LabelSet `yaml:"required_matchers,omitempty"`
+	// ReadRecent controls whether the remote endpoint is also queried for
+	// time ranges for which the local storage should have complete data.
+	ReadRecent bool `yaml:"read_recent,omitempty"`
+	// BestEffort controls whether errors of the remote endpoint fail queries
+	// or leave their results incomplete with a warning.
+	BestEffort bool `yaml:"best_effort,omitempty"`
+
 	// We cannot do proper Go type embedding below as the parser will then parse
 	// values arbitrarily into the overflow maps of further-down types.
 	HTTPClientConfig HTTPClientConfig `yaml:",inline"`
@@ -1516,3 +3127,138 @@ func (c *RemoteReadConfig) UnmarshalYAML(unmarshal func(interface{}) error) erro
 
 	return checkOverflow(c.XXX, "remote_read")
 }
+
+// TracingConfig configures the export of trace spans to an OpenTelemetry
+// collector through OTLP over HTTP.
+type TracingConfig struct {
+	// The host and port of the collector, spans are sent to its /v1/traces
+	// path.
+	Endpoint string `yaml:"endpoint"`
+	// The share of traces to record, from 0 to 1.
+	SamplingFraction float64 `yaml:"sampling_fraction,omitempty"`
+	// Send spans over plain HTTP instead of HTTPS.
+	Insecure  bool              `yaml:"insecure,omitempty"`
+	TLSConfig TLSConfig         `yaml:"tls_config,omitempty"`
+	Headers   map[string]Secret `yaml:"headers,omitempty"`
+	// The timeout of a single export request.
+	Timeout model.Duration `yaml:"timeout,omitempty"`
+
+	// Catches all undefined fields and must be empty after parsing.
+	XXX map[string]interface{} `yaml:",inline"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *TracingConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	*c = DefaultTracingConfig
+	type plain TracingConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if err := checkOverflow(c.XXX, "tracing"); err != nil {
+		return err
+	}
+	if c.Endpoint == "" {
+		return fmt.Errorf("endpoint for tracing is empty")
+	}
+	if strings.Contains(c.Endpoint, "://") {
+		return fmt.Errorf("tracing endpoint %q must be a host and port, not a URL", c.Endpoint)
+	}
+	if c.SamplingFraction < 0 || c.SamplingFraction > 1 {
+		return fmt.Errorf("tracing sampling_fraction must be between 0 and 1, got %g", c.SamplingFraction)
+	}
+	return nil
+}
+
+// WebConfig configures the Prometheus HTTP server. It is loaded from its own
+// file, given by the -web.config.file flag.
+type WebConfig struct {
+	TLSServerConfig *TLSServerConfig `yaml:"tls_server_config,omitempty"`
+	// Maps user names to the bcrypt hashes of their passwords.
+	BasicAuthUsers map[string]Secret `yaml:"basic_auth_users,omitempty"`
+
+	// Catches all undefined fields and must be empty after parsing.
+	XXX map[string]interface{} `yaml:",inline"`
+}
+
+// LoadWebConfigFile parses the given YAML file into a WebConfig.
+func LoadWebConfigFile(filename string) (*WebConfig, error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &WebConfig{}
+	if err := yaml.Unmarshal(content, cfg); err != nil {
+		return nil, err
+	}
+
+	if tcfg := cfg.TLSServerConfig; tcfg != nil {
+		baseDir := filepath.Dir(filename)
+		for _, fp := range []*string{&tcfg.CertFile, &tcfg.KeyFile, &tcfg.ClientCAFile} {
+			if len(*fp) > 0 && !filepath.IsAbs(*fp) {
+				*fp = filepath.Join(baseDir, *fp)
+			}
+		}
+	}
+	return cfg, nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *WebConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain WebConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	for user, hash := range c.BasicAuthUsers {
+		if _, err := bcrypt.Cost([]byte(hash)); err != nil {
+			return fmt.Errorf("password of basic auth user %q must be a bcrypt hash: %s", user, err)
+		}
+	}
+	return checkOverflow(c.XXX, "web config")
+}
+
+// Client authentication policies of the TLS server.
+const (
+	NoClientCert               = "NoClientCert"
+	RequestClientCert          = "RequestClientCert"
+	RequireAnyClientCert       = "RequireAnyClientCert"
+	VerifyClientCertIfGiven    = "VerifyClientCertIfGiven"
+	RequireAndVerifyClientCert = "RequireAndVerifyClientCert"
+)
+
+// TLSServerConfig configures TLS for the Prometheus HTTP server.
+type TLSServerConfig struct {
+	// The server certificate and its key.
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
+	// The policy for client certificates, defaults to NoClientCert.
+	ClientAuthType string `yaml:"client_auth_type,omitempty"`
+	// The CA certs used to verify client certificates.
+	ClientCAFile string `yaml:"client_ca_file,omitempty"`
+
+	// Catches all undefined fields and must be empty after parsing.
+	XXX map[string]interface{} `yaml:",inline"`
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *TLSServerConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain TLSServerConfig
+	if err := unmarshal((*plain)(c)); err != nil {
+		return err
+	}
+	if c.CertFile == "" || c.KeyFile == "" {
+		return fmt.Errorf("tls_server_config requires a cert_file and a key_file")
+	}
+
+	switch c.ClientAuthType {
+	case "":
+		c.ClientAuthType = NoClientCert
+	case NoClientCert, RequestClientCert, RequireAnyClientCert:
+	case VerifyClientCertIfGiven, RequireAndVerifyClientCert:
+		if c.ClientCAFile == "" {
+			return fmt.Errorf("client_auth_type %s requires a client_ca_file", c.ClientAuthType)
+		}
+	default:
+		return fmt.Errorf("invalid client_auth_type %q", c.ClientAuthType)
+	}
+	return checkOverflow(c.XXX, "tls_server_config")
+}
@@ -0,0 +1,137 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+)
+
+// A SecretProvider fetches secrets from an external source, e.g. a secret
+// store reachable through a local agent.
+type SecretProvider interface {
+	// Secret returns the current value of the secret identified by ref.
+	Secret(ref string) (string, error)
+}
+
+var (
+	secretProvidersMtx sync.RWMutex
+	secretProviders    = map[string]SecretProvider{}
+)
+
+// RegisterSecretProvider makes a SecretProvider available under the given
+// name. Secret file fields of the form <name>://<ref> are then fetched from
+// the provider instead of being read from a file.
+func RegisterSecretProvider(name string, p SecretProvider) {
+	secretProvidersMtx.Lock()
+	defer secretProvidersMtx.Unlock()
+
+	secretProviders[name] = p
+}
+
+// envSecretProvider fetches secrets from environment variables, so that
+// env://NAME refers to the value of the variable NAME.
+type envSecretProvider struct{}
+
+func (envSecretProvider) Secret(name string) (string, error) {
+	v, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("environment variable %q is not set", name)
+	}
+	return v, nil
+}
+
+func init() {
+	RegisterSecretProvider("env", envSecretProvider{})
+}
+
+// secretRef splits a secret file field of the form <name>://<ref> into the
+// provider name and the reference.
+func secretRef(s string) (string, string, bool) {
+	i := strings.Index(s, "://")
+	if i < 0 {
+		return "", "", false
+	}
+	return s[:i], s[i+len("://"):], true
+}
+
+// secretProvider returns the provider registered under the given name.
+func secretProvider(name string) (SecretProvider, bool) {
+	secretProvidersMtx.RLock()
+	defer secretProvidersMtx.RUnlock()
+
+	p, ok := secretProviders[name]
+	return p, ok
+}
+
+// ReadSecretFile returns the current value of a secret file field, either by
+// reading the file or by fetching it from its provider. Leading and trailing
+// whitespace is removed. Secrets should be read on each use, so that they can
+// be rotated without reloading the configuration.
+func ReadSecretFile(s string) (string, error) {
+	if name, ref, ok := secretRef(s); ok {
+		p, ok := secretProvider(name)
+		if !ok {
+			return "", fmt.Errorf("unknown secret provider %q", name)
+		}
+		v, err := p.Secret(ref)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(v), nil
+	}
+
+	b, err := ioutil.ReadFile(s)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// ReadSecret returns the inline secret if set and the current value of the
+// secret file field otherwise.
+func ReadSecret(inline Secret, file string) (string, error) {
+	if inline != "" || file == "" {
+		return string(inline), nil
+	}
+	v, err := ReadSecretFile(file)
+	if err != nil {
+		return "", fmt.Errorf("unable to read secret file %s: %s", file, err)
+	}
+	return v, nil
+}
+
+// checkSecret validates that at most one of an inline secret and a secret
+// file is set.
+func checkSecret(inline Secret, file, name string) error {
+	if inline != "" && file != "" {
+		return fmt.Errorf("at most one of %s & %s_file must be configured", name, name)
+	}
+	return checkSecretFile(file, name+"_file")
+}
+
+// checkSecretFile validates that a secret file field referring to a secret
+// provider names a registered one. Otherwise the reference would only fail
+// once the secret is used.
+func checkSecretFile(file, name string) error {
+	if provider, _, ok := secretRef(file); ok {
+		if _, ok := secretProvider(provider); !ok {
+			return fmt.Errorf("unknown secret provider %q in %s", provider, name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+// Copyright 2015 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build go1.14
+
+package config
+
+import "crypto/tls"
+
+// TLS 1.3 is supported by crypto/tls since Go 1.12, the cipher suite lookup
+// functions were added in Go 1.14.
+func init() {
+	TLSVersions["TLS13"] = tls.VersionTLS13
+}
+
+// tlsCipherSuites returns the IDs of the cipher suites implemented by
+// crypto/tls by their IANA name, including the insecure ones.
+func tlsCipherSuites() map[string]uint16 {
+	suites := map[string]uint16{}
+	for _, cs := range append(tls.CipherSuites(), tls.InsecureCipherSuites()...) {
+		suites[cs.Name] = cs.ID
+	}
+	return suites
+}
+
+func tlsCipherSuiteName(id uint16) string {
+	return tls.CipherSuiteName(id)
+}
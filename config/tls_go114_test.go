@@ -0,0 +1,50 @@
+// Copyright 2015 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build go1.14
+
+package config
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestTLSConfigGo114(t *testing.T) {
+	var c TLSConfig
+	err := yaml.Unmarshal([]byte(`
+min_version: TLS12
+max_version: TLS13
+cipher_suites:
+- TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256
+`), &c)
+	if err != nil {
+		t.Fatalf("Unexpected error parsing TLS config: %s", err)
+	}
+	if c.MaxVersion != tls.VersionTLS13 {
+		t.Errorf("want max version %s, got %s", TLSVersion(tls.VersionTLS13), c.MaxVersion)
+	}
+	if len(c.CipherSuites) != 1 || uint16(c.CipherSuites[0]) != tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305 {
+		t.Fatalf("Unexpected cipher suites %v", c.CipherSuites)
+	}
+
+	out, err := yaml.Marshal(c.CipherSuites[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := string(out), "TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256\n"; got != exp {
+		t.Errorf("want marshalled cipher suite %q, got %q", exp, got)
+	}
+}
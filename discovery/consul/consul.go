@@ -53,6 +53,10 @@ const (
 	datacenterLabel = model.MetaLabelPrefix + "consul_dc"
 	// serviceIDLabel is the name of the label containing the service ID.
 	serviceIDLabel = model.MetaLabelPrefix + "consul_service_id"
+	// namespaceLabel is the name of the label containing the namespace (Consul Enterprise).
+	namespaceLabel = model.MetaLabelPrefix + "consul_namespace"
+	// partitionLabel is the name of the label containing the admin partition (Consul Enterprise).
+	partitionLabel = model.MetaLabelPrefix + "consul_partition"
 
 	// Constants for instrumentation.
 	namespace = "prometheus"
@@ -90,6 +94,8 @@ type Discovery struct {
 	client           *consul.Client
 	clientConf       *consul.Config
 	clientDatacenter string
+	namespace        string
+	partition        string
 	tagSeparator     string
 	watchedServices  []string // Set of services which will be discovered.
 	logger           log.Logger
@@ -102,18 +108,32 @@ func NewDiscovery(conf *config.ConsulSDConfig, logger log.Logger) (*Discovery, e
 		return nil, err
 	}
 	transport := &http.Transport{TLSClientConfig: tls}
-	wrapper := &http.Client{Transport: transport}
+	var rt http.RoundTripper = &scopedTransport{
+		namespace: conf.Namespace,
+		partition: conf.Partition,
+		rt:        transport,
+	}
+
+	// Secret files are read on each request, so that they can be rotated.
+	httpAuth := &consul.HttpBasicAuth{
+		Username: conf.Username,
+		Password: string(conf.Password),
+	}
+	if conf.PasswordFile != "" {
+		rt = httputil.NewBasicAuthRoundTripper(conf.Username, "", conf.PasswordFile, rt)
+		httpAuth = nil
+	}
+	if conf.TokenFile != "" {
+		rt = &tokenFileTransport{tokenFile: conf.TokenFile, rt: rt}
+	}
 
 	clientConf := &consul.Config{
 		Address:    conf.Server,
 		Scheme:     conf.Scheme,
 		Datacenter: conf.Datacenter,
 		Token:      string(conf.Token),
-		HttpAuth: &consul.HttpBasicAuth{
-			Username: conf.Username,
-			Password: string(conf.Password),
-		},
-		HttpClient: wrapper,
+		HttpAuth:   httpAuth,
+		HttpClient: &http.Client{Transport: rt},
 	}
 	client, err := consul.NewClient(clientConf)
 	if err != nil {
@@ -125,11 +145,67 @@ func NewDiscovery(conf *config.ConsulSDConfig, logger log.Logger) (*Discovery, e
 		tagSeparator:     conf.TagSeparator,
 		watchedServices:  conf.Services,
 		clientDatacenter: clientConf.Datacenter,
+		namespace:        conf.Namespace,
+		partition:        conf.Partition,
 		logger:           logger,
 	}
 	return cd, nil
 }
 
+// scopedTransport adds the namespace and admin partition to the queries sent
+// to Consul, as the Consul client does not support them.
+type scopedTransport struct {
+	namespace string
+	partition string
+	rt        http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *scopedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.namespace == "" && t.partition == "" {
+		return t.rt.RoundTrip(req)
+	}
+	// RoundTrippers must not modify the request.
+	r := new(http.Request)
+	*r = *req
+	u := *req.URL
+	r.URL = &u
+
+	q := u.Query()
+	if t.namespace != "" {
+		q.Set("ns", t.namespace)
+	}
+	if t.partition != "" {
+		q.Set("partition", t.partition)
+	}
+	u.RawQuery = q.Encode()
+	return t.rt.RoundTrip(r)
+}
+
+// tokenFileTransport adds the ACL token read from a secret file to the
+// queries sent to Consul.
+type tokenFileTransport struct {
+	tokenFile string
+	rt        http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *tokenFileTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := config.ReadSecretFile(t.tokenFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read token file %s: %s", t.tokenFile, err)
+	}
+	// RoundTrippers must not modify the request.
+	r := new(http.Request)
+	*r = *req
+	r.Header = make(http.Header, len(req.Header))
+	for k, v := range req.Header {
+		r.Header[k] = v
+	}
+	r.Header.Set("X-Consul-Token", token)
+	return t.rt.RoundTrip(r)
+}
+
 // shouldWatch returns whether the service of the given name should be watched.
 func (d *Discovery) shouldWatch(name string) bool {
 	// If there's no fixed set of watched services, we watch everything.
@@ -210,6 +286,12 @@ func (d *Discovery) Run(ctx context.Context, ch chan<- []*config.TargetGroup) {
 				tagSeparator: d.tagSeparator,
 				logger:       d.logger,
 			}
+			if d.namespace != "" {
+				srv.labels[namespaceLabel] = model.LabelValue(d.namespace)
+			}
+			if d.partition != "" {
+				srv.labels[partitionLabel] = model.LabelValue(d.partition)
+			}
 
 			wctx, cancel := context.WithCancel(ctx)
 			go srv.watch(wctx, ch)
@@ -14,10 +14,18 @@
 package consul
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/prometheus/common/log"
+	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/config"
+	"golang.org/x/net/context"
 )
 
 func TestConfiguredService(t *testing.T) {
@@ -47,3 +55,71 @@ func TestNonConfiguredService(t *testing.T) {
 		t.Errorf("Expected service %s to be watched", "nonConfiguredServiceName")
 	}
 }
+
+func TestNamespaceAndPartition(t *testing.T) {
+	var (
+		mtx     sync.Mutex
+		queries []url.Values
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mtx.Lock()
+		queries = append(queries, r.URL.Query())
+		mtx.Unlock()
+
+		w.Header().Set("X-Consul-Index", "1")
+		switch r.URL.Path {
+		case "/v1/catalog/services":
+			w.Write([]byte(`{"web": []}`))
+		case "/v1/catalog/service/web":
+			w.Write([]byte(`[{"Node": "node1", "Address": "1.2.3.4", "ServicePort": 80}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	conf := &config.ConsulSDConfig{
+		Server:     strings.TrimPrefix(server.URL, "http://"),
+		Scheme:     "http",
+		Datacenter: "dc1",
+		Namespace:  "team-a",
+		Partition:  "part-1",
+	}
+	d, err := NewDiscovery(conf, log.Base())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := make(chan []*config.TargetGroup)
+	go d.Run(ctx, ch)
+
+	var tgs []*config.TargetGroup
+	select {
+	case tgs = <-ch:
+	case <-time.After(10 * time.Second):
+		t.Fatal("no target groups received")
+	}
+	if len(tgs) != 1 || len(tgs[0].Targets) != 1 {
+		t.Fatalf("unexpected target groups %v", tgs)
+	}
+	for name, expected := range map[model.LabelName]model.LabelValue{
+		namespaceLabel:  "team-a",
+		partitionLabel:  "part-1",
+		serviceLabel:    "web",
+		datacenterLabel: "dc1",
+	} {
+		if got := tgs[0].Labels[name]; got != expected {
+			t.Errorf("expected label %s=%q, got %q", name, expected, got)
+		}
+	}
+
+	mtx.Lock()
+	defer mtx.Unlock()
+	for _, q := range queries {
+		if q.Get("ns") != "team-a" || q.Get("partition") != "part-1" {
+			t.Errorf("query %v does not select the namespace and partition", q)
+		}
+	}
+}
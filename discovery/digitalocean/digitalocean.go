@@ -0,0 +1,287 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digitalocean
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
+	"golang.org/x/net/context/ctxhttp"
+
+	"github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/util/httputil"
+)
+
+const (
+	doLabel            = model.MetaLabelPrefix + "digitalocean_"
+	doLabelID          = doLabel + "droplet_id"
+	doLabelName        = doLabel + "droplet_name"
+	doLabelImage       = doLabel + "image"
+	doLabelImageName   = doLabel + "image_name"
+	doLabelPrivateIPv4 = doLabel + "private_ipv4"
+	doLabelPublicIPv4  = doLabel + "public_ipv4"
+	doLabelPublicIPv6  = doLabel + "public_ipv6"
+	doLabelRegion      = doLabel + "region"
+	doLabelSize        = doLabel + "size"
+	doLabelStatus      = doLabel + "status"
+	doLabelFeatures    = doLabel + "features"
+	doLabelTags        = doLabel + "tags"
+	doLabelVPC         = doLabel + "vpc"
+
+	// The separator of the features and tags lists.
+	separator = ","
+
+	doEndpoint = "https://api.digitalocean.com/v2"
+	// The maximum page size of the API.
+	doPerPage = 200
+)
+
+var (
+	refreshFailuresCount = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "prometheus_sd_digitalocean_refresh_failures_total",
+			Help: "The number of DigitalOcean-SD refresh failures.",
+		})
+	refreshDuration = prometheus.NewSummary(
+		prometheus.SummaryOpts{
+			Name: "prometheus_sd_digitalocean_refresh_duration_seconds",
+			Help: "The duration of a DigitalOcean-SD refresh in seconds.",
+		})
+	rateLimitedCount = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "prometheus_sd_digitalocean_rate_limited_refreshes_total",
+			Help: "The number of DigitalOcean-SD refreshes skipped because the API rate limit was exceeded.",
+		})
+)
+
+func init() {
+	prometheus.MustRegister(refreshFailuresCount)
+	prometheus.MustRegister(refreshDuration)
+	prometheus.MustRegister(rateLimitedCount)
+}
+
+type doNetwork struct {
+	IPAddress string `json:"ip_address"`
+	Type      string `json:"type"`
+}
+
+type doDroplet struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Image  struct {
+		Slug string `json:"slug"`
+		Name string `json:"name"`
+	} `json:"image"`
+	SizeSlug string `json:"size_slug"`
+	Region   struct {
+		Slug string `json:"slug"`
+	} `json:"region"`
+	Networks struct {
+		V4 []doNetwork `json:"v4"`
+		V6 []doNetwork `json:"v6"`
+	} `json:"networks"`
+	Features []string `json:"features"`
+	Tags     []string `json:"tags"`
+	VPCUUID  string   `json:"vpc_uuid"`
+}
+
+// Discovery periodically discovers the droplets of a DigitalOcean account. It
+// implements the TargetProvider interface.
+type Discovery struct {
+	client   *http.Client
+	port     int
+	interval time.Duration
+	logger   log.Logger
+
+	// The endpoint of the API.
+	endpoint  string
+	rateLimit *httputil.RateLimit
+
+	now func() time.Time
+}
+
+// NewDiscovery returns a new DigitalOcean discovery which periodically
+// refreshes its targets.
+func NewDiscovery(conf *config.DigitalOceanSDConfig, logger log.Logger) (*Discovery, error) {
+	client, err := httputil.NewClientFromConfig(conf.HTTPClientConfig)
+	if err != nil {
+		return nil, err
+	}
+	client.Timeout = time.Duration(conf.RefreshInterval)
+
+	return &Discovery{
+		client:    client,
+		port:      conf.Port,
+		interval:  time.Duration(conf.RefreshInterval),
+		logger:    logger,
+		endpoint:  doEndpoint,
+		rateLimit: httputil.NewRateLimit("RateLimit-Remaining", "RateLimit-Reset"),
+		now:       time.Now,
+	}, nil
+}
+
+// Run implements the TargetProvider interface.
+func (d *Discovery) Run(ctx context.Context, ch chan<- []*config.TargetGroup) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		if until, limited := d.rateLimit.Limited(d.now()); limited {
+			d.logger.Debugf("Skipping DigitalOcean refresh, API rate limit exceeded until %s", until)
+			rateLimitedCount.Inc()
+		} else if tg, err := d.refresh(ctx); err != nil {
+			d.logger.Errorf("Error refreshing DigitalOcean targets: %s", err)
+		} else {
+			select {
+			case ch <- []*config.TargetGroup{tg}:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (d *Discovery) refresh(ctx context.Context) (tg *config.TargetGroup, err error) {
+	t0 := time.Now()
+	defer func() {
+		refreshDuration.Observe(time.Since(t0).Seconds())
+		if err != nil {
+			refreshFailuresCount.Inc()
+		}
+	}()
+
+	var droplets []doDroplet
+	for page := 1; ; page++ {
+		var resp struct {
+			Droplets []doDroplet `json:"droplets"`
+			Links    struct {
+				Pages struct {
+					Next string `json:"next"`
+				} `json:"pages"`
+			} `json:"links"`
+		}
+		if err := d.get(ctx, fmt.Sprintf("/droplets?page=%d&per_page=%d", page, doPerPage), &resp); err != nil {
+			return nil, fmt.Errorf("could not list droplets: %s", err)
+		}
+		droplets = append(droplets, resp.Droplets...)
+		if resp.Links.Pages.Next == "" {
+			break
+		}
+	}
+
+	tg = &config.TargetGroup{
+		Source: d.endpoint,
+	}
+	for _, droplet := range droplets {
+		var public, private, publicV6 string
+		for _, n := range droplet.Networks.V4 {
+			switch {
+			case n.Type == "public" && public == "":
+				public = n.IPAddress
+			case n.Type == "private" && private == "":
+				private = n.IPAddress
+			}
+		}
+		for _, n := range droplet.Networks.V6 {
+			if n.Type == "public" {
+				publicV6 = n.IPAddress
+				break
+			}
+		}
+		if public == "" {
+			continue
+		}
+
+		labels := model.LabelSet{
+			model.AddressLabel: model.LabelValue(net.JoinHostPort(public, strconv.Itoa(d.port))),
+			doLabelID:          model.LabelValue(strconv.Itoa(droplet.ID)),
+			doLabelName:        model.LabelValue(droplet.Name),
+			doLabelImage:       model.LabelValue(droplet.Image.Slug),
+			doLabelImageName:   model.LabelValue(droplet.Image.Name),
+			doLabelPublicIPv4:  model.LabelValue(public),
+			doLabelRegion:      model.LabelValue(droplet.Region.Slug),
+			doLabelSize:        model.LabelValue(droplet.SizeSlug),
+			doLabelStatus:      model.LabelValue(droplet.Status),
+			doLabelVPC:         model.LabelValue(droplet.VPCUUID),
+		}
+		if private != "" {
+			labels[doLabelPrivateIPv4] = model.LabelValue(private)
+		}
+		if publicV6 != "" {
+			labels[doLabelPublicIPv6] = model.LabelValue(publicV6)
+		}
+		if len(droplet.Features) > 0 {
+			labels[doLabelFeatures] = model.LabelValue(separator + strings.Join(droplet.Features, separator) + separator)
+		}
+		if len(droplet.Tags) > 0 {
+			// We surround the separated list with the separator as well. This way regular expressions
+			// in relabeling rules don't have to consider tag positions.
+			labels[doLabelTags] = model.LabelValue(separator + strings.Join(droplet.Tags, separator) + separator)
+		}
+		tg.Targets = append(tg.Targets, labels)
+	}
+	return tg, nil
+}
+
+// get decodes the JSON response to a GET request of the path of the API,
+// unless the rate limit of the API is exhausted.
+func (d *Discovery) get(ctx context.Context, path string, v interface{}) error {
+	if until, limited := d.rateLimit.Limited(d.now()); limited {
+		return fmt.Errorf("API rate limit exceeded until %s", until)
+	}
+	req, err := http.NewRequest("GET", d.endpoint+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := ctxhttp.Do(ctx, d.client, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	d.rateLimit.Update(resp, d.now())
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return fmt.Errorf("API rate limit exceeded")
+	}
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("request to %s failed with status %s", path, resp.Status)
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("could not decode response to %s: %s", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package digitalocean
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/log"
+	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
+
+	"github.com/prometheus/prometheus/config"
+)
+
+func newTestDiscovery(t *testing.T, url string) *Discovery {
+	d, err := NewDiscovery(&config.DigitalOceanSDConfig{
+		Port: 80,
+		HTTPClientConfig: config.HTTPClientConfig{
+			BearerToken: "token",
+		},
+	}, log.Base())
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.endpoint = url
+	return d
+}
+
+func TestRefresh(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer token" {
+			t.Errorf("Expected bearer token authorization, got %q", auth)
+		}
+		switch r.URL.RequestURI() {
+		case "/droplets?page=1&per_page=200":
+			fmt.Fprintf(w, `{
+				"droplets": [{
+					"id": 3164444,
+					"name": "example.com",
+					"status": "active",
+					"image": {"slug": "ubuntu-16-04-x64", "name": "14.04 x64"},
+					"size_slug": "s-1vcpu-1gb",
+					"region": {"slug": "nyc3"},
+					"networks": {
+						"v4": [
+							{"ip_address": "10.128.192.124", "type": "private"},
+							{"ip_address": "192.241.165.154", "type": "public"}
+						],
+						"v6": [{"ip_address": "2604:a880:0:1010::18a:a001", "type": "public"}]
+					},
+					"features": ["backups", "ipv6", "virtio"],
+					"tags": ["monitor"],
+					"vpc_uuid": "f9b0769c-e118-42fb-a0c4-fed15ef69662"
+				}],
+				"links": {"pages": {"next": "%s/droplets?page=2&per_page=200"}}
+			}`, srv.URL)
+		case "/droplets?page=2&per_page=200":
+			fmt.Fprint(w, `{
+				"droplets": [{
+					"id": 3164445,
+					"name": "private.example.com",
+					"status": "active",
+					"networks": {"v4": [{"ip_address": "10.128.192.125", "type": "private"}]}
+				}, {
+					"id": 3164446,
+					"name": "off.example.com",
+					"status": "off",
+					"image": {"slug": "ubuntu-16-04-x64", "name": "14.04 x64"},
+					"size_slug": "s-1vcpu-2gb",
+					"region": {"slug": "sfo2"},
+					"networks": {"v4": [{"ip_address": "192.241.165.155", "type": "public"}]},
+					"vpc_uuid": "a0ef8a9d-e118-42fb-a0c4-fed15ef69662"
+				}],
+				"links": {}
+			}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	d := newTestDiscovery(t, srv.URL)
+	tg, err := d.refresh(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &config.TargetGroup{
+		Source: srv.URL,
+		Targets: []model.LabelSet{
+			{
+				"__address__":                      "192.241.165.154:80",
+				"__meta_digitalocean_droplet_id":   "3164444",
+				"__meta_digitalocean_droplet_name": "example.com",
+				"__meta_digitalocean_image":        "ubuntu-16-04-x64",
+				"__meta_digitalocean_image_name":   "14.04 x64",
+				"__meta_digitalocean_private_ipv4": "10.128.192.124",
+				"__meta_digitalocean_public_ipv4":  "192.241.165.154",
+				"__meta_digitalocean_public_ipv6":  "2604:a880:0:1010::18a:a001",
+				"__meta_digitalocean_region":       "nyc3",
+				"__meta_digitalocean_size":         "s-1vcpu-1gb",
+				"__meta_digitalocean_status":       "active",
+				"__meta_digitalocean_features":     ",backups,ipv6,virtio,",
+				"__meta_digitalocean_tags":         ",monitor,",
+				"__meta_digitalocean_vpc":          "f9b0769c-e118-42fb-a0c4-fed15ef69662",
+			},
+			{
+				"__address__":                      "192.241.165.155:80",
+				"__meta_digitalocean_droplet_id":   "3164446",
+				"__meta_digitalocean_droplet_name": "off.example.com",
+				"__meta_digitalocean_image":        "ubuntu-16-04-x64",
+				"__meta_digitalocean_image_name":   "14.04 x64",
+				"__meta_digitalocean_public_ipv4":  "192.241.165.155",
+				"__meta_digitalocean_region":       "sfo2",
+				"__meta_digitalocean_size":         "s-1vcpu-2gb",
+				"__meta_digitalocean_status":       "off",
+				"__meta_digitalocean_vpc":          "a0ef8a9d-e118-42fb-a0c4-fed15ef69662",
+			},
+		},
+	}
+	if !reflect.DeepEqual(tg, expected) {
+		t.Errorf("Expected target group\n%v\ngot\n%v", expected, tg)
+	}
+}
+
+func TestRefreshRateLimited(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		// The API only reports the reset time when rejecting requests.
+		w.Header().Set("RateLimit-Remaining", "0")
+		w.Header().Set("RateLimit-Reset", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{"id": "too_many_requests", "message": "API Rate limit exceeded."}`)
+	}))
+	defer srv.Close()
+
+	d := newTestDiscovery(t, srv.URL)
+	now := time.Unix(60, 0)
+	d.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if _, err := d.refresh(context.Background()); err == nil {
+			t.Fatal("Expected an error when exceeding the rate limit")
+		}
+	}
+	if requests != 1 {
+		t.Fatalf("Expected no request until the reset, got %d requests", requests)
+	}
+	if until, limited := d.rateLimit.Limited(now); !limited || !until.Equal(time.Unix(120, 0)) {
+		t.Fatalf("Expected requests to be held off until the reset, got %s", until)
+	}
+
+	now = time.Unix(120, 0)
+	if _, err := d.refresh(context.Background()); err == nil {
+		t.Fatal("Expected an error when exceeding the rate limit")
+	}
+	if requests != 2 {
+		t.Fatalf("Expected a request after the reset, got %d requests", requests)
+	}
+}
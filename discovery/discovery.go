@@ -15,25 +15,133 @@ package discovery
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/common/log"
 	"github.com/prometheus/prometheus/config"
 	"github.com/prometheus/prometheus/discovery/azure"
 	"github.com/prometheus/prometheus/discovery/consul"
+	"github.com/prometheus/prometheus/discovery/digitalocean"
 	"github.com/prometheus/prometheus/discovery/dns"
 	"github.com/prometheus/prometheus/discovery/ec2"
+	"github.com/prometheus/prometheus/discovery/ecs"
+	"github.com/prometheus/prometheus/discovery/eureka"
 	"github.com/prometheus/prometheus/discovery/file"
 	"github.com/prometheus/prometheus/discovery/gce"
+	"github.com/prometheus/prometheus/discovery/hetzner"
 	"github.com/prometheus/prometheus/discovery/kubernetes"
+	"github.com/prometheus/prometheus/discovery/kuma"
+	"github.com/prometheus/prometheus/discovery/linode"
 	"github.com/prometheus/prometheus/discovery/marathon"
+	"github.com/prometheus/prometheus/discovery/nomad"
 	"github.com/prometheus/prometheus/discovery/openstack"
+	"github.com/prometheus/prometheus/discovery/ovhcloud"
+	"github.com/prometheus/prometheus/discovery/puppetdb"
+	"github.com/prometheus/prometheus/discovery/scaleway"
 	"github.com/prometheus/prometheus/discovery/triton"
+	"github.com/prometheus/prometheus/discovery/uyuni"
+	"github.com/prometheus/prometheus/discovery/vultr"
 	"github.com/prometheus/prometheus/discovery/zookeeper"
 	"golang.org/x/net/context"
 )
 
+var (
+	receivedUpdates = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "prometheus",
+			Subsystem: "sd",
+			Name:      "received_updates_total",
+			Help:      "Total number of target group updates received from the service discovery providers.",
+		},
+		[]string{"name", "config", "provider"},
+	)
+	sentUpdates = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "prometheus",
+			Subsystem: "sd",
+			Name:      "updates_total",
+			Help:      "Total number of updates of the discovered target groups sent to their consumer.",
+		},
+		[]string{"name", "config"},
+	)
+	coalescedUpdates = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "prometheus",
+			Subsystem: "sd",
+			Name:      "coalesced_updates_total",
+			Help:      "Total number of target group updates received from the service discovery providers while an update of their consumer was pending already.",
+		},
+		[]string{"name", "config"},
+	)
+	updateDelay = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "prometheus",
+			Subsystem: "sd",
+			Name:      "update_delay_seconds",
+			Help:      "Delay between the first target group update received and the update of the discovered target groups sent to their consumer.",
+			Buckets:   []float64{.1, .5, 1, 2.5, 5, 10, 30, 60},
+		},
+		[]string{"name", "config"},
+	)
+	discoveredTargetsDesc = prometheus.NewDesc(
+		"prometheus_sd_discovered_targets",
+		"Current number of targets discovered, before relabeling.",
+		[]string{"name", "config"}, nil,
+	)
+)
+
+func init() {
+	prometheus.MustRegister(receivedUpdates)
+	prometheus.MustRegister(sentUpdates)
+	prometheus.MustRegister(coalescedUpdates)
+	prometheus.MustRegister(updateDelay)
+	prometheus.MustRegister(runningTargetSets)
+}
+
+// runningTargetSets collects the number of targets discovered by the running
+// target sets. Target sets with the same name and config, like the sets
+// replacing each other during a reload, are summed up.
+var runningTargetSets = &targetSetCollector{sets: map[*TargetSet]struct{}{}}
+
+type targetSetCollector struct {
+	mtx  sync.Mutex
+	sets map[*TargetSet]struct{}
+}
+
+func (c *targetSetCollector) add(ts *TargetSet) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.sets[ts] = struct{}{}
+}
+
+func (c *targetSetCollector) remove(ts *TargetSet) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	delete(c.sets, ts)
+}
+
+// Describe implements prometheus.Collector.
+func (c *targetSetCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- discoveredTargetsDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *targetSetCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	counts := map[[2]string]int{}
+	for ts := range c.sets {
+		counts[[2]string{ts.name, ts.config}] += ts.numTargets()
+	}
+	for k, n := range counts {
+		ch <- prometheus.MustNewConstMetric(discoveredTargetsDesc, prometheus.GaugeValue, float64(n), k[0], k[1])
+	}
+}
+
 // A TargetProvider provides information about target groups. It maintains a set
 // of sources from which TargetGroups can originate. Whenever a target provider
 // detects a potential change, it sends the TargetGroup through its provided channel.
@@ -97,6 +205,9 @@ func ProvidersFromConfig(cfg config.ServiceDiscoveryConfig, logger log.Logger) m
 	for i, c := range cfg.EC2SDConfigs {
 		app("ec2", i, ec2.NewDiscovery(c, logger))
 	}
+	for i, c := range cfg.ECSSDConfigs {
+		app("ecs", i, ecs.NewDiscovery(c, logger))
+	}
 	for i, c := range cfg.OpenstackSDConfigs {
 		openstackd, err := openstack.NewDiscovery(c, logger)
 		if err != nil {
@@ -125,6 +236,89 @@ func ProvidersFromConfig(cfg config.ServiceDiscoveryConfig, logger log.Logger) m
 		}
 		app("triton", i, t)
 	}
+	for i, c := range cfg.HetznerSDConfigs {
+		h, err := hetzner.NewDiscovery(c, logger)
+		if err != nil {
+			logger.Errorf("Cannot create Hetzner discovery: %s", err)
+			continue
+		}
+		app("hetzner", i, h)
+	}
+	for i, c := range cfg.LinodeSDConfigs {
+		l, err := linode.NewDiscovery(c, logger)
+		if err != nil {
+			logger.Errorf("Cannot create Linode discovery: %s", err)
+			continue
+		}
+		app("linode", i, l)
+	}
+	for i, c := range cfg.DigitalOceanSDConfigs {
+		do, err := digitalocean.NewDiscovery(c, logger)
+		if err != nil {
+			logger.Errorf("Cannot create DigitalOcean discovery: %s", err)
+			continue
+		}
+		app("digitalocean", i, do)
+	}
+	for i, c := range cfg.ScalewaySDConfigs {
+		app("scaleway", i, scaleway.NewDiscovery(c, logger))
+	}
+	for i, c := range cfg.PuppetDBSDConfigs {
+		pdb, err := puppetdb.NewDiscovery(c, logger)
+		if err != nil {
+			logger.Errorf("Cannot create PuppetDB discovery: %s", err)
+			continue
+		}
+		app("puppetdb", i, pdb)
+	}
+	for i, c := range cfg.EurekaSDConfigs {
+		e, err := eureka.NewDiscovery(c, logger)
+		if err != nil {
+			logger.Errorf("Cannot create Eureka discovery: %s", err)
+			continue
+		}
+		app("eureka", i, e)
+	}
+	for i, c := range cfg.NomadSDConfigs {
+		n, err := nomad.NewDiscovery(c, logger)
+		if err != nil {
+			logger.Errorf("Cannot create Nomad discovery: %s", err)
+			continue
+		}
+		app("nomad", i, n)
+	}
+	for i, c := range cfg.UyuniSDConfigs {
+		u, err := uyuni.NewDiscovery(c, logger)
+		if err != nil {
+			logger.Errorf("Cannot create Uyuni discovery: %s", err)
+			continue
+		}
+		app("uyuni", i, u)
+	}
+	for i, c := range cfg.VultrSDConfigs {
+		v, err := vultr.NewDiscovery(c, logger)
+		if err != nil {
+			logger.Errorf("Cannot create Vultr discovery: %s", err)
+			continue
+		}
+		app("vultr", i, v)
+	}
+	for i, c := range cfg.OVHcloudSDConfigs {
+		o, err := ovhcloud.NewDiscovery(c, logger)
+		if err != nil {
+			logger.Errorf("Cannot create OVHcloud discovery: %s", err)
+			continue
+		}
+		app("ovhcloud", i, o)
+	}
+	for i, c := range cfg.KumaSDConfigs {
+		k, err := kuma.NewDiscovery(c, logger)
+		if err != nil {
+			logger.Errorf("Cannot create Kuma discovery: %s", err)
+			continue
+		}
+		app("kuma", i, k)
+	}
 	if len(cfg.StaticConfigs) > 0 {
 		app("static", 0, NewStaticProvider(cfg.StaticConfigs))
 	}
@@ -157,6 +351,11 @@ func (sd *StaticProvider) Run(ctx context.Context, ch chan<- []*config.TargetGro
 	close(ch)
 }
 
+// UpdateInterval is the interval within which the target group updates of the
+// providers of a target set are coalesced before the target set sends them to
+// its Syncer. The initial target groups of new providers are sent right away.
+var UpdateInterval = 5 * time.Second
+
 // TargetSet handles multiple TargetProviders and sends a full overview of their
 // discovered TargetGroups to a Syncer.
 type TargetSet struct {
@@ -165,6 +364,15 @@ type TargetSet struct {
 	tgroups map[string]*config.TargetGroup
 
 	syncer Syncer
+	// The name of the consumer of the target set and the name of its
+	// configuration, which label the metrics of the target set.
+	name, config string
+	// The time of the first update since the last sync.
+	pendingSince time.Time
+	// The types of the providers updates were received from.
+	providerTypes map[string]struct{}
+	// The interval within which updates are coalesced.
+	interval time.Duration
 
 	syncCh          chan struct{}
 	providerCh      chan map[string]TargetProvider
@@ -176,49 +384,115 @@ type Syncer interface {
 	Sync([]*config.TargetGroup)
 }
 
-// NewTargetSet returns a new target sending TargetGroups to the Syncer.
-func NewTargetSet(s Syncer) *TargetSet {
+// NewTargetSet returns a new target sending TargetGroups to the Syncer. The
+// name of the consumer, like "scrape", and the name of the configuration of
+// the target set label its metrics.
+func NewTargetSet(s Syncer, name, config string) *TargetSet {
 	return &TargetSet{
-		syncCh:     make(chan struct{}, 1),
-		providerCh: make(chan map[string]TargetProvider),
-		syncer:     s,
+		syncCh:        make(chan struct{}, 1),
+		providerCh:    make(chan map[string]TargetProvider),
+		syncer:        s,
+		name:          name,
+		config:        config,
+		interval:      UpdateInterval,
+		providerTypes: map[string]struct{}{},
 	}
 }
 
 // Run starts the processing of target providers and their updates.
 // It blocks until the context gets canceled.
 func (ts *TargetSet) Run(ctx context.Context) {
-Loop:
+	runningTargetSets.add(ts)
+	defer runningTargetSets.remove(ts)
+
 	for {
-		// Throttle syncing to once per five seconds.
 		select {
 		case <-ctx.Done():
-			break Loop
+			return
 		case p := <-ts.providerCh:
 			ts.updateProviders(ctx, p)
-		case <-time.After(5 * time.Second):
-		}
-
-		select {
-		case <-ctx.Done():
-			break Loop
+			ts.sync()
 		case <-ts.syncCh:
+			// Wait for further updates, so that bursts of updates
+			// only cause one sync.
+			timer := time.NewTimer(ts.interval)
+		Wait:
+			for {
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return
+				case p := <-ts.providerCh:
+					ts.updateProviders(ctx, p)
+				case <-timer.C:
+					break Wait
+				}
+			}
 			ts.sync()
-		case p := <-ts.providerCh:
-			ts.updateProviders(ctx, p)
 		}
 	}
 }
 
+// sync sends the current target groups to the Syncer. A pending update is
+// sent along.
 func (ts *TargetSet) sync() {
-	ts.mtx.RLock()
+	select {
+	case <-ts.syncCh:
+	default:
+	}
+
+	ts.mtx.Lock()
 	var all []*config.TargetGroup
 	for _, tg := range ts.tgroups {
 		all = append(all, tg)
 	}
-	ts.mtx.RUnlock()
+	pendingSince := ts.pendingSince
+	ts.pendingSince = time.Time{}
+	ts.mtx.Unlock()
 
 	ts.syncer.Sync(all)
+
+	sentUpdates.WithLabelValues(ts.name, ts.config).Inc()
+	if !pendingSince.IsZero() {
+		updateDelay.WithLabelValues(ts.name, ts.config).Observe(time.Since(pendingSince).Seconds())
+	}
+}
+
+// receivedUpdate counts an update received from the named provider.
+func (ts *TargetSet) receivedUpdate(provider string) {
+	typ := providerType(provider)
+
+	ts.mtx.Lock()
+	ts.providerTypes[typ] = struct{}{}
+	ts.mtx.Unlock()
+
+	receivedUpdates.WithLabelValues(ts.name, ts.config, typ).Inc()
+}
+
+// DeleteMetrics deletes the series of the update metrics of the target set.
+// It is to be called once the configuration of a stopped target set was
+// removed, so that its series do not outlive it.
+func (ts *TargetSet) DeleteMetrics() {
+	ts.mtx.RLock()
+	defer ts.mtx.RUnlock()
+
+	for typ := range ts.providerTypes {
+		receivedUpdates.DeleteLabelValues(ts.name, ts.config, typ)
+	}
+	sentUpdates.DeleteLabelValues(ts.name, ts.config)
+	updateDelay.DeleteLabelValues(ts.name, ts.config)
+}
+
+// numTargets returns the number of targets in the target groups.
+func (ts *TargetSet) numTargets() int {
+	ts.mtx.RLock()
+	defer ts.mtx.RUnlock()
+
+	n := 0
+	for _, tg := range ts.tgroups {
+		n += len(tg.Targets)
+	}
+	return n
 }
 
 // UpdateProviders sets new target providers for the target set.
@@ -257,10 +531,9 @@ func (ts *TargetSet) updateProviders(ctx context.Context, providers map[string]T
 				if !ok {
 					break
 				}
+				ts.receivedUpdate(name)
 				// First set of all targets the provider knows.
-				for _, tgroup := range initial {
-					ts.setTargetGroup(name, tgroup)
-				}
+				ts.setTargetGroups(name, initial)
 			case <-time.After(5 * time.Second):
 				// Initial set didn't arrive. Act as if it was empty
 				// and wait for updates later on.
@@ -278,28 +551,25 @@ func (ts *TargetSet) updateProviders(ctx context.Context, providers map[string]T
 					if !ok {
 						return
 					}
-					for _, tg := range tgs {
-						ts.update(name, tg)
-					}
+					ts.receivedUpdate(name)
+					ts.update(name, tgs)
 				}
 			}
 		}(name, prov)
 	}
 
-	// We wait for a full initial set of target groups before releasing the mutex
-	// to ensure the initial sync is complete and there are no races with subsequent updates.
+	// We wait for a full initial set of target groups to ensure the
+	// initial sync is complete. The caller syncs them right away.
 	wg.Wait()
-	// Just signal that there are initial sets to sync now. Actual syncing must only
-	// happen in the runScraping loop.
-	select {
-	case ts.syncCh <- struct{}{}:
-	default:
-	}
 }
 
-// update handles a target group update from a target provider identified by the name.
-func (ts *TargetSet) update(name string, tgroup *config.TargetGroup) {
-	ts.setTargetGroup(name, tgroup)
+// update handles a target group update from a target provider identified by
+// the name. Updates received while a sync is pending already are coalesced
+// into it.
+func (ts *TargetSet) update(name string, tgroups []*config.TargetGroup) {
+	if ts.setTargetGroups(name, tgroups) {
+		coalescedUpdates.WithLabelValues(ts.name, ts.config).Inc()
+	}
 
 	select {
 	case ts.syncCh <- struct{}{}:
@@ -307,12 +577,30 @@ func (ts *TargetSet) update(name string, tgroup *config.TargetGroup) {
 	}
 }
 
-func (ts *TargetSet) setTargetGroup(name string, tg *config.TargetGroup) {
+// setTargetGroups sets the target groups of the target provider identified by
+// the name. It returns whether an update was pending already.
+func (ts *TargetSet) setTargetGroups(name string, tgroups []*config.TargetGroup) bool {
 	ts.mtx.Lock()
 	defer ts.mtx.Unlock()
 
-	if tg == nil {
-		return
+	pending := !ts.pendingSince.IsZero()
+	if !pending {
+		ts.pendingSince = time.Now()
+	}
+	for _, tg := range tgroups {
+		if tg == nil {
+			continue
+		}
+		ts.tgroups[name+"/"+tg.Source] = tg
+	}
+	return pending
+}
+
+// providerType returns the type of the target provider with the given name as
+// set by ProvidersFromConfig, like "dns" for "dns/0".
+func providerType(name string) string {
+	if i := strings.Index(name, "/"); i >= 0 {
+		return name[:i]
 	}
-	ts.tgroups[name+"/"+tg.Source] = tg
+	return name
 }
@@ -15,7 +15,10 @@ package discovery
 
 import (
 	"testing"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/prometheus/common/log"
 	"github.com/prometheus/prometheus/config"
 	"golang.org/x/net/context"
@@ -48,7 +51,7 @@ static_configs:
 
 	ts := NewTargetSet(&mockSyncer{
 		sync: func([]*config.TargetGroup) { called <- struct{}{} },
-	})
+	}, "test", "test")
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -75,6 +78,138 @@ static_configs:
 	verifyPresence(ts.tgroups, "static/0/1", false)
 }
 
+func TestTargetSetDiscoveredTargets(t *testing.T) {
+	cfg := &config.ServiceDiscoveryConfig{}
+	if err := yaml.Unmarshal([]byte(`
+static_configs:
+- targets: ["foo:9090", "bar:9090"]
+- targets: ["baz:9090"]
+`), cfg); err != nil {
+		t.Fatal(err)
+	}
+	called := make(chan struct{})
+	ts := NewTargetSet(&mockSyncer{
+		sync: func([]*config.TargetGroup) { called <- struct{}{} },
+	}, "test", "discovered")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go ts.Run(ctx)
+	ts.UpdateProviders(ProvidersFromConfig(*cfg, log.Base()))
+	<-called
+
+	ch := make(chan prometheus.Metric, 100)
+	runningTargetSets.Collect(ch)
+	close(ch)
+	found := false
+	for m := range ch {
+		var pb dto.Metric
+		if err := m.Write(&pb); err != nil {
+			t.Fatal(err)
+		}
+		labels := map[string]string{}
+		for _, l := range pb.Label {
+			labels[l.GetName()] = l.GetValue()
+		}
+		if labels["name"] != "test" || labels["config"] != "discovered" {
+			continue
+		}
+		found = true
+		if v := pb.GetGauge().GetValue(); v != 3 {
+			t.Errorf("Expected 3 discovered targets, got %v", v)
+		}
+	}
+	if !found {
+		t.Errorf("Expected the discovered targets of the target set to be collected")
+	}
+}
+
+func TestTargetSetCoalescesUpdates(t *testing.T) {
+	updates := make(chan []*config.TargetGroup)
+	called := make(chan []*config.TargetGroup, 10)
+
+	ts := NewTargetSet(&mockSyncer{
+		sync: func(tgs []*config.TargetGroup) { called <- tgs },
+	}, "test", "coalesced")
+	ts.interval = 100 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go ts.Run(ctx)
+	ts.UpdateProviders(map[string]TargetProvider{
+		"mock/0": &mockProvider{updates: updates},
+	})
+
+	// The initial target groups are synced right away.
+	select {
+	case tgs := <-called:
+		if len(tgs) != 0 {
+			t.Fatalf("Expected no initial target groups, got %v", tgs)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Initial target groups were not synced")
+	}
+
+	before := counterValue(t, coalescedUpdates.WithLabelValues("test", "coalesced"))
+	for _, src := range []string{"a", "b", "c"} {
+		updates <- []*config.TargetGroup{{Source: src}}
+	}
+
+	select {
+	case tgs := <-called:
+		if len(tgs) != 3 {
+			t.Fatalf("Expected 3 target groups in the coalesced update, got %v", tgs)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Updates were not synced")
+	}
+	select {
+	case tgs := <-called:
+		t.Fatalf("Expected a single sync of the updates, got another one with %v", tgs)
+	case <-time.After(2 * ts.interval):
+	}
+
+	if v := counterValue(t, coalescedUpdates.WithLabelValues("test", "coalesced")) - before; v != 2 {
+		t.Errorf("Expected 2 coalesced updates, got %v", v)
+	}
+}
+
+func counterValue(t *testing.T, c prometheus.Counter) float64 {
+	var pb dto.Metric
+	if err := c.Write(&pb); err != nil {
+		t.Fatal(err)
+	}
+	return pb.GetCounter().GetValue()
+}
+
+type mockProvider struct {
+	updates chan []*config.TargetGroup
+}
+
+func (p *mockProvider) Run(ctx context.Context, ch chan<- []*config.TargetGroup) {
+	defer close(ch)
+
+	// Send an empty initial set.
+	select {
+	case ch <- nil:
+	case <-ctx.Done():
+		return
+	}
+	for {
+		select {
+		case tgs := <-p.updates:
+			select {
+			case ch <- tgs:
+			case <-ctx.Done():
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 type mockSyncer struct {
 	sync func(tgs []*config.TargetGroup)
 }
@@ -84,3 +219,51 @@ func (s *mockSyncer) Sync(tgs []*config.TargetGroup) {
 		s.sync(tgs)
 	}
 }
+
+func TestTargetSetDeleteMetrics(t *testing.T) {
+	cfg := &config.ServiceDiscoveryConfig{}
+	if err := yaml.Unmarshal([]byte(`
+static_configs:
+- targets: ["foo:9090"]
+`), cfg); err != nil {
+		t.Fatal(err)
+	}
+	called := make(chan struct{})
+	ts := NewTargetSet(&mockSyncer{
+		sync: func([]*config.TargetGroup) { called <- struct{}{} },
+	}, "test", "deleted")
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go ts.Run(ctx)
+	ts.UpdateProviders(ProvidersFromConfig(*cfg, log.Base()))
+	<-called
+	cancel()
+
+	collected := func() int {
+		ch := make(chan prometheus.Metric, 100)
+		receivedUpdates.Collect(ch)
+		sentUpdates.Collect(ch)
+		updateDelay.Collect(ch)
+		close(ch)
+		n := 0
+		for m := range ch {
+			var pb dto.Metric
+			if err := m.Write(&pb); err != nil {
+				t.Fatal(err)
+			}
+			for _, l := range pb.Label {
+				if l.GetName() == "config" && l.GetValue() == "deleted" {
+					n++
+				}
+			}
+		}
+		return n
+	}
+	if n := collected(); n != 3 {
+		t.Fatalf("Expected 3 series of the target set, got %d", n)
+	}
+	ts.DeleteMetrics()
+	if n := collected(); n != 0 {
+		t.Errorf("Expected the series of the target set to be deleted, got %d", n)
+	}
+}
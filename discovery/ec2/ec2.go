@@ -30,6 +30,8 @@ import (
 
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/util/awsutil"
+	"github.com/prometheus/prometheus/util/httputil"
 	"github.com/prometheus/prometheus/util/strutil"
 )
 
@@ -69,18 +71,21 @@ func init() {
 // Discovery periodically performs EC2-SD requests. It implements
 // the TargetProvider interface.
 type Discovery struct {
-	aws      *aws.Config
-	interval time.Duration
-	profile  string
-	roleARN  string
-	port     int
-	logger   log.Logger
+	aws        *aws.Config
+	interval   time.Duration
+	profile    string
+	roleARN    string
+	externalID string
+	port       int
+	logger     log.Logger
 }
 
 // NewDiscovery returns a new EC2Discovery which periodically refreshes its targets.
 func NewDiscovery(conf *config.EC2SDConfig, logger log.Logger) *Discovery {
 	creds := credentials.NewStaticCredentials(conf.AccessKey, string(conf.SecretKey), "")
-	if conf.AccessKey == "" && conf.SecretKey == "" {
+	if conf.SecretKeyFile != "" {
+		creds = httputil.NewSecretFileCredentials(conf.AccessKey, conf.SecretKeyFile)
+	} else if conf.AccessKey == "" && conf.SecretKey == "" {
 		creds = nil
 	}
 	return &Discovery{
@@ -88,11 +93,12 @@ func NewDiscovery(conf *config.EC2SDConfig, logger log.Logger) *Discovery {
 			Region:      &conf.Region,
 			Credentials: creds,
 		},
-		profile:  conf.Profile,
-		roleARN:  conf.RoleARN,
-		interval: time.Duration(conf.RefreshInterval),
-		port:     conf.Port,
-		logger:   logger,
+		profile:    conf.Profile,
+		roleARN:    conf.RoleARN,
+		externalID: conf.ExternalID,
+		interval:   time.Duration(conf.RefreshInterval),
+		port:       conf.Port,
+		logger:     logger,
 	}
 }
 
@@ -142,6 +148,15 @@ func (d *Discovery) refresh() (tg *config.TargetGroup, err error) {
 		}
 	}()
 
+	if d.aws.Credentials == nil {
+		// Use the default credential chain, retrieving the instance profile
+		// credentials with IMDSv2.
+		sess, err := session.NewSession()
+		if err != nil {
+			return nil, fmt.Errorf("could not create aws session: %s", err)
+		}
+		d.aws.Credentials = awsutil.NewCredentials(sess, d.profile)
+	}
 	sess, err := session.NewSessionWithOptions(session.Options{
 		Config:  *d.aws,
 		Profile: d.profile,
@@ -152,7 +167,11 @@ func (d *Discovery) refresh() (tg *config.TargetGroup, err error) {
 
 	var ec2s *ec2.EC2
 	if d.roleARN != "" {
-		creds := stscreds.NewCredentials(sess, d.roleARN)
+		creds := stscreds.NewCredentials(sess, d.roleARN, func(p *stscreds.AssumeRoleProvider) {
+			if d.externalID != "" {
+				p.ExternalID = aws.String(d.externalID)
+			}
+		})
 		ec2s = ec2.New(sess, &aws.Config{Credentials: creds})
 	} else {
 		ec2s = ec2.New(sess)
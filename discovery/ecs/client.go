@@ -0,0 +1,235 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ecs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/signer/v4"
+	"golang.org/x/net/context"
+	"golang.org/x/net/context/ctxhttp"
+)
+
+const (
+	ecsService      = "ecs"
+	ecsTargetPrefix = "AmazonEC2ContainerServiceV20141113."
+	ecsContentType  = "application/x-amz-json-1.1"
+	// The maximum number of items the ECS API accepts or returns per call.
+	ecsMaxResults = 100
+)
+
+// client is a client of the subset of the ECS API needed for discovery. The
+// API uses the AWS JSON 1.1 protocol, which the vendored AWS SDK does not
+// provide a client for.
+type client struct {
+	endpoint string
+	region   string
+	signer   *v4.Signer
+	client   *http.Client
+}
+
+func newClient(endpoint, region string, creds *credentials.Credentials) *client {
+	return &client{
+		endpoint: endpoint,
+		region:   region,
+		signer:   v4.NewSigner(creds),
+		client:   http.DefaultClient,
+	}
+}
+
+type ecsTag struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type ecsAttachment struct {
+	Type    string `json:"type"`
+	Details []struct {
+		Name  string `json:"name"`
+		Value string `json:"value"`
+	} `json:"details"`
+}
+
+type ecsTask struct {
+	TaskARN              string          `json:"taskArn"`
+	ClusterARN           string          `json:"clusterArn"`
+	TaskDefinitionARN    string          `json:"taskDefinitionArn"`
+	ContainerInstanceARN string          `json:"containerInstanceArn"`
+	Group                string          `json:"group"`
+	LaunchType           string          `json:"launchType"`
+	AvailabilityZone     string          `json:"availabilityZone"`
+	DesiredStatus        string          `json:"desiredStatus"`
+	LastStatus           string          `json:"lastStatus"`
+	HealthStatus         string          `json:"healthStatus"`
+	Attachments          []ecsAttachment `json:"attachments"`
+	Tags                 []ecsTag        `json:"tags"`
+}
+
+type ecsContainerInstance struct {
+	ContainerInstanceARN string `json:"containerInstanceArn"`
+	EC2InstanceID        string `json:"ec2InstanceId"`
+}
+
+// listClusters returns the ARNs of all clusters.
+func (c *client) listClusters(ctx context.Context) ([]string, error) {
+	var arns []string
+	req := struct {
+		NextToken  string `json:"nextToken,omitempty"`
+		MaxResults int    `json:"maxResults"`
+	}{MaxResults: ecsMaxResults}
+	for {
+		var resp struct {
+			ClusterARNs []string `json:"clusterArns"`
+			NextToken   string   `json:"nextToken"`
+		}
+		if err := c.do(ctx, "ListClusters", req, &resp); err != nil {
+			return nil, err
+		}
+		arns = append(arns, resp.ClusterARNs...)
+		if resp.NextToken == "" {
+			return arns, nil
+		}
+		req.NextToken = resp.NextToken
+	}
+}
+
+// listTasks returns the ARNs of the running tasks of the cluster.
+func (c *client) listTasks(ctx context.Context, cluster string) ([]string, error) {
+	var arns []string
+	req := struct {
+		Cluster    string `json:"cluster"`
+		NextToken  string `json:"nextToken,omitempty"`
+		MaxResults int    `json:"maxResults"`
+	}{Cluster: cluster, MaxResults: ecsMaxResults}
+	for {
+		var resp struct {
+			TaskARNs  []string `json:"taskArns"`
+			NextToken string   `json:"nextToken"`
+		}
+		if err := c.do(ctx, "ListTasks", req, &resp); err != nil {
+			return nil, err
+		}
+		arns = append(arns, resp.TaskARNs...)
+		if resp.NextToken == "" {
+			return arns, nil
+		}
+		req.NextToken = resp.NextToken
+	}
+}
+
+// describeTasks returns the tasks of the cluster with the given ARNs,
+// including their tags.
+func (c *client) describeTasks(ctx context.Context, cluster string, arns []string) ([]ecsTask, error) {
+	var tasks []ecsTask
+	for _, batch := range batches(arns) {
+		req := struct {
+			Cluster string   `json:"cluster"`
+			Tasks   []string `json:"tasks"`
+			Include []string `json:"include"`
+		}{Cluster: cluster, Tasks: batch, Include: []string{"TAGS"}}
+		var resp struct {
+			Tasks []ecsTask `json:"tasks"`
+		}
+		if err := c.do(ctx, "DescribeTasks", req, &resp); err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, resp.Tasks...)
+	}
+	return tasks, nil
+}
+
+// describeContainerInstances returns the container instances of the cluster
+// with the given ARNs.
+func (c *client) describeContainerInstances(ctx context.Context, cluster string, arns []string) ([]ecsContainerInstance, error) {
+	var cis []ecsContainerInstance
+	for _, batch := range batches(arns) {
+		req := struct {
+			Cluster            string   `json:"cluster"`
+			ContainerInstances []string `json:"containerInstances"`
+		}{Cluster: cluster, ContainerInstances: batch}
+		var resp struct {
+			ContainerInstances []ecsContainerInstance `json:"containerInstances"`
+		}
+		if err := c.do(ctx, "DescribeContainerInstances", req, &resp); err != nil {
+			return nil, err
+		}
+		cis = append(cis, resp.ContainerInstances...)
+	}
+	return cis, nil
+}
+
+// do sends a signed request for the operation and decodes the response.
+func (c *client) do(ctx context.Context, op string, in, out interface{}) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", c.endpoint+"/", nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", ecsContentType)
+	req.Header.Set("X-Amz-Target", ecsTargetPrefix+op)
+	// Sign attaches the body to the signed request.
+	if _, err := c.signer.Sign(req, bytes.NewReader(body), ecsService, c.region, time.Now()); err != nil {
+		return fmt.Errorf("failed to sign %s request: %s", op, err)
+	}
+
+	resp, err := ctxhttp.Do(ctx, c.client, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode/100 != 2 {
+		var apiErr struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(b, &apiErr) != nil || apiErr.Type == "" {
+			return fmt.Errorf("%s failed with status %s", op, resp.Status)
+		}
+		// The type may be prefixed with the namespace of the service.
+		typ := apiErr.Type[strings.LastIndex(apiErr.Type, "#")+1:]
+		return fmt.Errorf("%s failed: %s: %s", op, typ, apiErr.Message)
+	}
+	if err := json.Unmarshal(b, out); err != nil {
+		return fmt.Errorf("could not decode %s response: %s", op, err)
+	}
+	return nil
+}
+
+// batches splits the ARNs into batches the ECS API accepts in one call.
+func batches(arns []string) [][]string {
+	var res [][]string
+	for len(arns) > ecsMaxResults {
+		res = append(res, arns[:ecsMaxResults])
+		arns = arns[ecsMaxResults:]
+	}
+	if len(arns) > 0 {
+		res = append(res, arns)
+	}
+	return res
+}
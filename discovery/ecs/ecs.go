@@ -0,0 +1,326 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ecs
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
+
+	"github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/util/awsutil"
+	"github.com/prometheus/prometheus/util/httputil"
+	"github.com/prometheus/prometheus/util/strutil"
+)
+
+const (
+	ecsLabel                     = model.MetaLabelPrefix + "ecs_"
+	ecsLabelCluster              = ecsLabel + "cluster"
+	ecsLabelClusterARN           = ecsLabel + "cluster_arn"
+	ecsLabelTaskARN              = ecsLabel + "task_arn"
+	ecsLabelTaskDefinitionARN    = ecsLabel + "task_definition_arn"
+	ecsLabelTaskGroup            = ecsLabel + "task_group"
+	ecsLabelService              = ecsLabel + "service"
+	ecsLabelLaunchType           = ecsLabel + "launch_type"
+	ecsLabelAZ                   = ecsLabel + "availability_zone"
+	ecsLabelDesiredStatus        = ecsLabel + "desired_status"
+	ecsLabelLastStatus           = ecsLabel + "last_status"
+	ecsLabelHealthStatus         = ecsLabel + "health_status"
+	ecsLabelIPAddress            = ecsLabel + "ip_address"
+	ecsLabelContainerInstanceARN = ecsLabel + "container_instance_arn"
+	ecsLabelEC2InstanceID        = ecsLabel + "ec2_instance_id"
+	ecsLabelTag                  = ecsLabel + "tag_"
+
+	// The prefix of the group of the tasks started by a service.
+	serviceGroupPrefix = "service:"
+)
+
+var (
+	ecsSDRefreshFailuresCount = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "prometheus_sd_ecs_refresh_failures_total",
+			Help: "The number of ECS-SD refresh failures.",
+		})
+	ecsSDRefreshDuration = prometheus.NewSummary(
+		prometheus.SummaryOpts{
+			Name: "prometheus_sd_ecs_refresh_duration_seconds",
+			Help: "The duration of a ECS-SD refresh in seconds.",
+		})
+)
+
+func init() {
+	prometheus.MustRegister(ecsSDRefreshFailuresCount)
+	prometheus.MustRegister(ecsSDRefreshDuration)
+}
+
+// Discovery periodically discovers the running tasks of ECS clusters, of both
+// the Fargate and EC2 launch types. It implements the TargetProvider
+// interface.
+type Discovery struct {
+	aws        *aws.Config
+	interval   time.Duration
+	profile    string
+	roleARN    string
+	externalID string
+	clusters   []string
+	port       int
+	logger     log.Logger
+
+	// The endpoint of the ECS API. Defaults to the endpoint of the region.
+	endpoint string
+}
+
+// NewDiscovery returns a new ECS discovery which periodically refreshes its
+// targets.
+func NewDiscovery(conf *config.ECSSDConfig, logger log.Logger) *Discovery {
+	creds := credentials.NewStaticCredentials(conf.AccessKey, string(conf.SecretKey), "")
+	if conf.SecretKeyFile != "" {
+		creds = httputil.NewSecretFileCredentials(conf.AccessKey, conf.SecretKeyFile)
+	} else if conf.AccessKey == "" && conf.SecretKey == "" {
+		creds = nil
+	}
+	return &Discovery{
+		aws: &aws.Config{
+			Region:      &conf.Region,
+			Credentials: creds,
+		},
+		profile:    conf.Profile,
+		roleARN:    conf.RoleARN,
+		externalID: conf.ExternalID,
+		clusters:   conf.Clusters,
+		interval:   time.Duration(conf.RefreshInterval),
+		port:       conf.Port,
+		logger:     logger,
+		endpoint:   fmt.Sprintf("https://ecs.%s.amazonaws.com", conf.Region),
+	}
+}
+
+// Run implements the TargetProvider interface.
+func (d *Discovery) Run(ctx context.Context, ch chan<- []*config.TargetGroup) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	// Get an initial set right away.
+	tg, err := d.refresh(ctx)
+	if err != nil {
+		d.logger.Error(err)
+	} else {
+		select {
+		case ch <- []*config.TargetGroup{tg}:
+		case <-ctx.Done():
+			return
+		}
+	}
+
+	for {
+		select {
+		case <-ticker.C:
+			tg, err := d.refresh(ctx)
+			if err != nil {
+				d.logger.Error(err)
+				continue
+			}
+
+			select {
+			case ch <- []*config.TargetGroup{tg}:
+			case <-ctx.Done():
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (d *Discovery) refresh(ctx context.Context) (tg *config.TargetGroup, err error) {
+	t0 := time.Now()
+	defer func() {
+		ecsSDRefreshDuration.Observe(time.Since(t0).Seconds())
+		if err != nil {
+			ecsSDRefreshFailuresCount.Inc()
+		}
+	}()
+
+	if d.aws.Credentials == nil {
+		// Use the default credential chain, retrieving the instance profile
+		// credentials with IMDSv2.
+		sess, err := session.NewSession()
+		if err != nil {
+			return nil, fmt.Errorf("could not create aws session: %s", err)
+		}
+		d.aws.Credentials = awsutil.NewCredentials(sess, d.profile)
+	}
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:  *d.aws,
+		Profile: d.profile,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not create aws session: %s", err)
+	}
+
+	creds := d.aws.Credentials
+	if d.roleARN != "" {
+		creds = stscreds.NewCredentials(sess, d.roleARN, func(p *stscreds.AssumeRoleProvider) {
+			if d.externalID != "" {
+				p.ExternalID = aws.String(d.externalID)
+			}
+		})
+	}
+	ecsc := newClient(d.endpoint, *d.aws.Region, creds)
+	ec2s := ec2.New(sess, &aws.Config{Credentials: creds})
+
+	clusters := d.clusters
+	if len(clusters) == 0 {
+		if clusters, err = ecsc.listClusters(ctx); err != nil {
+			return nil, fmt.Errorf("could not list clusters: %s", err)
+		}
+	}
+
+	tg = &config.TargetGroup{
+		Source: *d.aws.Region,
+	}
+	for _, cluster := range clusters {
+		targets, err := d.clusterTargets(ctx, ecsc, ec2s, cluster)
+		if err != nil {
+			return nil, fmt.Errorf("could not discover tasks of cluster %s: %s", cluster, err)
+		}
+		tg.Targets = append(tg.Targets, targets...)
+	}
+	return tg, nil
+}
+
+// clusterTargets returns a target for each running task of the cluster with
+// an IP address. The address of tasks using the awsvpc network mode, like all
+// Fargate tasks, is the one of their network interface. The address of other
+// tasks is the private IP of the EC2 instance of their container instance.
+func (d *Discovery) clusterTargets(ctx context.Context, ecsc *client, ec2s *ec2.EC2, cluster string) ([]model.LabelSet, error) {
+	arns, err := ecsc.listTasks(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	if len(arns) == 0 {
+		return nil, nil
+	}
+	tasks, err := ecsc.describeTasks(ctx, cluster, arns)
+	if err != nil {
+		return nil, err
+	}
+
+	var ciARNs []string
+	for _, t := range tasks {
+		if taskENIAddress(t) == "" && t.ContainerInstanceARN != "" {
+			ciARNs = append(ciARNs, t.ContainerInstanceARN)
+		}
+	}
+	instanceIDs := map[string]string{}
+	instanceIPs := map[string]string{}
+	if len(ciARNs) > 0 {
+		cis, err := ecsc.describeContainerInstances(ctx, cluster, ciARNs)
+		if err != nil {
+			return nil, err
+		}
+		var ids []*string
+		for _, ci := range cis {
+			if ci.EC2InstanceID == "" {
+				continue
+			}
+			if _, ok := instanceIPs[ci.EC2InstanceID]; !ok {
+				ids = append(ids, aws.String(ci.EC2InstanceID))
+				instanceIPs[ci.EC2InstanceID] = ""
+			}
+			instanceIDs[ci.ContainerInstanceARN] = ci.EC2InstanceID
+		}
+		if err := ec2s.DescribeInstancesPages(&ec2.DescribeInstancesInput{InstanceIds: ids}, func(p *ec2.DescribeInstancesOutput, lastPage bool) bool {
+			for _, r := range p.Reservations {
+				for _, inst := range r.Instances {
+					if inst.InstanceId != nil && inst.PrivateIpAddress != nil {
+						instanceIPs[*inst.InstanceId] = *inst.PrivateIpAddress
+					}
+				}
+			}
+			return true
+		}); err != nil {
+			return nil, fmt.Errorf("could not describe container instances: %s", err)
+		}
+	}
+
+	var targets []model.LabelSet
+	for _, t := range tasks {
+		ip := taskENIAddress(t)
+		instanceID := instanceIDs[t.ContainerInstanceARN]
+		if ip == "" {
+			ip = instanceIPs[instanceID]
+		}
+		if ip == "" {
+			continue
+		}
+
+		labels := model.LabelSet{
+			model.AddressLabel:        model.LabelValue(net.JoinHostPort(ip, fmt.Sprintf("%d", d.port))),
+			ecsLabelCluster:           model.LabelValue(t.ClusterARN[strings.LastIndex(t.ClusterARN, "/")+1:]),
+			ecsLabelClusterARN:        model.LabelValue(t.ClusterARN),
+			ecsLabelTaskARN:           model.LabelValue(t.TaskARN),
+			ecsLabelTaskDefinitionARN: model.LabelValue(t.TaskDefinitionARN),
+			ecsLabelTaskGroup:         model.LabelValue(t.Group),
+			ecsLabelLaunchType:        model.LabelValue(t.LaunchType),
+			ecsLabelAZ:                model.LabelValue(t.AvailabilityZone),
+			ecsLabelDesiredStatus:     model.LabelValue(t.DesiredStatus),
+			ecsLabelLastStatus:        model.LabelValue(t.LastStatus),
+			ecsLabelHealthStatus:      model.LabelValue(t.HealthStatus),
+			ecsLabelIPAddress:         model.LabelValue(ip),
+		}
+		if strings.HasPrefix(t.Group, serviceGroupPrefix) {
+			labels[ecsLabelService] = model.LabelValue(strings.TrimPrefix(t.Group, serviceGroupPrefix))
+		}
+		if t.ContainerInstanceARN != "" {
+			labels[ecsLabelContainerInstanceARN] = model.LabelValue(t.ContainerInstanceARN)
+		}
+		if instanceID != "" {
+			labels[ecsLabelEC2InstanceID] = model.LabelValue(instanceID)
+		}
+		for _, tag := range t.Tags {
+			name := strutil.SanitizeLabelName(tag.Key)
+			labels[ecsLabelTag+model.LabelName(name)] = model.LabelValue(tag.Value)
+		}
+		targets = append(targets, labels)
+	}
+	return targets, nil
+}
+
+// taskENIAddress returns the private IP of the network interface attached to
+// the task, if any.
+func taskENIAddress(t ecsTask) string {
+	for _, a := range t.Attachments {
+		if a.Type != "ElasticNetworkInterface" {
+			continue
+		}
+		for _, d := range a.Details {
+			if d.Name == "privateIPv4Address" {
+				return d.Value
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,207 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ecs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/common/log"
+	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
+
+	"github.com/prometheus/prometheus/config"
+)
+
+const describeInstancesResponse = `<DescribeInstancesResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <reservationSet>
+    <item>
+      <instancesSet>
+        <item>
+          <instanceId>i-0123456789</instanceId>
+          <privateIpAddress>10.0.1.5</privateIpAddress>
+        </item>
+      </instancesSet>
+    </item>
+  </reservationSet>
+</DescribeInstancesResponse>`
+
+// fakeAPI serves the ECS API for a single cluster with a Fargate task and a
+// task of the EC2 launch type, and the EC2 API for the instance of the latter.
+func fakeAPI(t *testing.T) http.HandlerFunc {
+	const cluster = "arn:aws:ecs:us-east-1:123456789012:cluster/production"
+	responses := map[string]string{
+		"ListClusters": `{"clusterArns": ["` + cluster + `"]}`,
+		"ListTasks":    `{"taskArns": ["arn:aws:ecs:us-east-1:123456789012:task/fargate"]}`,
+		"DescribeTasks": `{"tasks": [{
+			"taskArn": "arn:aws:ecs:us-east-1:123456789012:task/fargate",
+			"clusterArn": "` + cluster + `",
+			"taskDefinitionArn": "arn:aws:ecs:us-east-1:123456789012:task-definition/api:3",
+			"group": "service:api",
+			"launchType": "FARGATE",
+			"availabilityZone": "us-east-1a",
+			"desiredStatus": "RUNNING",
+			"lastStatus": "RUNNING",
+			"healthStatus": "HEALTHY",
+			"attachments": [{
+				"type": "ElasticNetworkInterface",
+				"details": [{"name": "subnetId", "value": "subnet-1"}, {"name": "privateIPv4Address", "value": "10.0.0.7"}]
+			}],
+			"tags": [{"key": "team-name", "value": "backend"}]
+		}, {
+			"taskArn": "arn:aws:ecs:us-east-1:123456789012:task/ec2",
+			"clusterArn": "` + cluster + `",
+			"taskDefinitionArn": "arn:aws:ecs:us-east-1:123456789012:task-definition/batch:1",
+			"containerInstanceArn": "arn:aws:ecs:us-east-1:123456789012:container-instance/ci",
+			"group": "family:batch",
+			"launchType": "EC2",
+			"availabilityZone": "us-east-1b",
+			"desiredStatus": "RUNNING",
+			"lastStatus": "PENDING",
+			"healthStatus": "UNKNOWN"
+		}]}`,
+		"DescribeContainerInstances": `{"containerInstances": [{
+			"containerInstanceArn": "arn:aws:ecs:us-east-1:123456789012:container-instance/ci",
+			"ec2InstanceId": "i-0123456789"
+		}]}`,
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=access/") {
+			t.Errorf("Expected signed request, got authorization %q", r.Header.Get("Authorization"))
+		}
+		target := r.Header.Get("X-Amz-Target")
+		if target == "" {
+			// The EC2 API uses the query protocol.
+			if err := r.ParseForm(); err != nil {
+				t.Fatal(err)
+			}
+			if a := r.Form.Get("Action"); a != "DescribeInstances" {
+				t.Errorf("Unexpected EC2 action %q", a)
+			}
+			if id := r.Form.Get("InstanceId.1"); id != "i-0123456789" {
+				t.Errorf("Expected instance i-0123456789 to be described, got %q", id)
+			}
+			fmt.Fprint(w, describeInstancesResponse)
+			return
+		}
+
+		op := strings.TrimPrefix(target, ecsTargetPrefix)
+		var req map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("%s: %s", op, err)
+		}
+		w.Header().Set("Content-Type", ecsContentType)
+		if op != "ListClusters" && req["cluster"] != cluster {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"__type": "com.amazonaws.ecs#ClusterNotFoundException", "message": "Cluster not found."}`)
+			return
+		}
+		resp, ok := responses[op]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, `{"__type": "com.amazonaws.ecs#InvalidParameterException", "message": "unknown operation %s"}`, op)
+			return
+		}
+		fmt.Fprint(w, resp)
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	srv := httptest.NewServer(fakeAPI(t))
+	defer srv.Close()
+
+	d := NewDiscovery(&config.ECSSDConfig{
+		Region:    "us-east-1",
+		AccessKey: "access",
+		SecretKey: "secret",
+		Port:      9100,
+	}, log.Base())
+	d.endpoint = srv.URL
+	d.aws.Endpoint = &srv.URL
+
+	tg, err := d.refresh(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &config.TargetGroup{
+		Source: "us-east-1",
+		Targets: []model.LabelSet{
+			{
+				"__address__":                    "10.0.0.7:9100",
+				"__meta_ecs_cluster":             "production",
+				"__meta_ecs_cluster_arn":         "arn:aws:ecs:us-east-1:123456789012:cluster/production",
+				"__meta_ecs_task_arn":            "arn:aws:ecs:us-east-1:123456789012:task/fargate",
+				"__meta_ecs_task_definition_arn": "arn:aws:ecs:us-east-1:123456789012:task-definition/api:3",
+				"__meta_ecs_task_group":          "service:api",
+				"__meta_ecs_service":             "api",
+				"__meta_ecs_launch_type":         "FARGATE",
+				"__meta_ecs_availability_zone":   "us-east-1a",
+				"__meta_ecs_desired_status":      "RUNNING",
+				"__meta_ecs_last_status":         "RUNNING",
+				"__meta_ecs_health_status":       "HEALTHY",
+				"__meta_ecs_ip_address":          "10.0.0.7",
+				"__meta_ecs_tag_team_name":       "backend",
+			},
+			{
+				"__address__":                       "10.0.1.5:9100",
+				"__meta_ecs_cluster":                "production",
+				"__meta_ecs_cluster_arn":            "arn:aws:ecs:us-east-1:123456789012:cluster/production",
+				"__meta_ecs_task_arn":               "arn:aws:ecs:us-east-1:123456789012:task/ec2",
+				"__meta_ecs_task_definition_arn":    "arn:aws:ecs:us-east-1:123456789012:task-definition/batch:1",
+				"__meta_ecs_task_group":             "family:batch",
+				"__meta_ecs_launch_type":            "EC2",
+				"__meta_ecs_availability_zone":      "us-east-1b",
+				"__meta_ecs_desired_status":         "RUNNING",
+				"__meta_ecs_last_status":            "PENDING",
+				"__meta_ecs_health_status":          "UNKNOWN",
+				"__meta_ecs_ip_address":             "10.0.1.5",
+				"__meta_ecs_container_instance_arn": "arn:aws:ecs:us-east-1:123456789012:container-instance/ci",
+				"__meta_ecs_ec2_instance_id":        "i-0123456789",
+			},
+		},
+	}
+	if !reflect.DeepEqual(tg, expected) {
+		t.Errorf("Expected target group\n%v\ngot\n%v", expected, tg)
+	}
+}
+
+func TestRefreshError(t *testing.T) {
+	srv := httptest.NewServer(fakeAPI(t))
+	defer srv.Close()
+
+	d := NewDiscovery(&config.ECSSDConfig{
+		Region:    "us-east-1",
+		AccessKey: "access",
+		SecretKey: "secret",
+		Clusters:  []string{"staging"},
+	}, log.Base())
+	d.endpoint = srv.URL
+	d.aws.Endpoint = &srv.URL
+
+	_, err := d.refresh(context.Background())
+	if err == nil {
+		t.Fatal("Expected an error for an unknown cluster")
+	}
+	expected := "could not discover tasks of cluster staging: ListTasks failed: ClusterNotFoundException: Cluster not found."
+	if err.Error() != expected {
+		t.Errorf("Expected error %q, got %q", expected, err)
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eureka
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
+	"golang.org/x/net/context/ctxhttp"
+
+	"github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/util/httputil"
+	"github.com/prometheus/prometheus/util/strutil"
+)
+
+const (
+	eurekaLabel                       = model.MetaLabelPrefix + "eureka_"
+	appNameLabel                      = eurekaLabel + "app_name"
+	appInstanceLabel                  = eurekaLabel + "app_instance_"
+	appInstanceIDLabel                = appInstanceLabel + "id"
+	appInstanceHostNameLabel          = appInstanceLabel + "hostname"
+	appInstanceHomePageURLLabel       = appInstanceLabel + "homepage_url"
+	appInstanceStatusPageURLLabel     = appInstanceLabel + "statuspage_url"
+	appInstanceHealthCheckURLLabel    = appInstanceLabel + "healthcheck_url"
+	appInstanceIPAddrLabel            = appInstanceLabel + "ip_addr"
+	appInstanceVipAddressLabel        = appInstanceLabel + "vip_address"
+	appInstanceSecureVipAddressLabel  = appInstanceLabel + "secure_vip_address"
+	appInstanceStatusLabel            = appInstanceLabel + "status"
+	appInstancePortLabel              = appInstanceLabel + "port"
+	appInstancePortEnabledLabel       = appInstanceLabel + "port_enabled"
+	appInstanceSecurePortLabel        = appInstanceLabel + "secure_port"
+	appInstanceSecurePortEnabledLabel = appInstanceLabel + "secure_port_enabled"
+	appInstanceCountryIDLabel         = appInstanceLabel + "country_id"
+	appInstanceDataCenterNameLabel    = appInstanceLabel + "datacenterinfo_name"
+	appInstanceMetadataPrefix         = appInstanceLabel + "metadata_"
+)
+
+var (
+	refreshFailuresCount = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "prometheus_sd_eureka_refresh_failures_total",
+			Help: "The number of Eureka-SD refresh failures.",
+		})
+	refreshDuration = prometheus.NewSummary(
+		prometheus.SummaryOpts{
+			Name: "prometheus_sd_eureka_refresh_duration_seconds",
+			Help: "The duration of a Eureka-SD refresh in seconds.",
+		})
+)
+
+func init() {
+	prometheus.MustRegister(refreshFailuresCount)
+	prometheus.MustRegister(refreshDuration)
+}
+
+type applications struct {
+	Applications []application `xml:"application"`
+}
+
+type application struct {
+	Name      string     `xml:"name"`
+	Instances []instance `xml:"instance"`
+}
+
+type port struct {
+	Port    int  `xml:",chardata"`
+	Enabled bool `xml:"enabled,attr"`
+}
+
+type instance struct {
+	InstanceID       string `xml:"instanceId"`
+	HostName         string `xml:"hostName"`
+	HomePageURL      string `xml:"homePageUrl"`
+	StatusPageURL    string `xml:"statusPageUrl"`
+	HealthCheckURL   string `xml:"healthCheckUrl"`
+	IPAddr           string `xml:"ipAddr"`
+	VipAddress       string `xml:"vipAddress"`
+	SecureVipAddress string `xml:"secureVipAddress"`
+	Status           string `xml:"status"`
+	Port             *port  `xml:"port"`
+	SecurePort       *port  `xml:"securePort"`
+	CountryID        int    `xml:"countryId"`
+	DataCenterInfo   *struct {
+		Name string `xml:"name"`
+	} `xml:"dataCenterInfo"`
+	Metadata *struct {
+		// The metadata is a map whose keys are the element names.
+		Items []struct {
+			XMLName xml.Name
+			Content string `xml:",innerxml"`
+		} `xml:",any"`
+	} `xml:"metadata"`
+}
+
+// Discovery periodically discovers the application instances registered in a
+// Eureka server. It implements the TargetProvider interface.
+type Discovery struct {
+	client   *http.Client
+	server   string
+	interval time.Duration
+	logger   log.Logger
+}
+
+// NewDiscovery returns a new Eureka discovery which periodically refreshes its
+// targets.
+func NewDiscovery(conf *config.EurekaSDConfig, logger log.Logger) (*Discovery, error) {
+	client, err := httputil.NewClientFromConfig(conf.HTTPClientConfig)
+	if err != nil {
+		return nil, err
+	}
+	client.Timeout = time.Duration(conf.RefreshInterval)
+
+	return &Discovery{
+		client:   client,
+		server:   strings.TrimSuffix(conf.Server, "/"),
+		interval: time.Duration(conf.RefreshInterval),
+		logger:   logger,
+	}, nil
+}
+
+// Run implements the TargetProvider interface.
+func (d *Discovery) Run(ctx context.Context, ch chan<- []*config.TargetGroup) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		tg, err := d.refresh(ctx)
+		if err != nil {
+			d.logger.Errorf("Error refreshing Eureka targets: %s", err)
+		} else {
+			select {
+			case ch <- []*config.TargetGroup{tg}:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (d *Discovery) refresh(ctx context.Context) (tg *config.TargetGroup, err error) {
+	t0 := time.Now()
+	defer func() {
+		refreshDuration.Observe(time.Since(t0).Seconds())
+		if err != nil {
+			refreshFailuresCount.Inc()
+		}
+	}()
+
+	apps, err := d.fetchApps(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tg = &config.TargetGroup{
+		Source: d.server,
+	}
+	for _, app := range apps.Applications {
+		for _, inst := range app.Instances {
+			tg.Targets = append(tg.Targets, targetLabels(app.Name, inst))
+		}
+	}
+	return tg, nil
+}
+
+func (d *Discovery) fetchApps(ctx context.Context) (*applications, error) {
+	req, err := http.NewRequest("GET", d.server+"/apps", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/xml")
+	resp, err := ctxhttp.Do(ctx, d.client, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("request to %s failed with status %s", req.URL, resp.Status)
+	}
+	var apps applications
+	if err := xml.Unmarshal(b, &apps); err != nil {
+		return nil, fmt.Errorf("could not decode applications: %s", err)
+	}
+	return &apps, nil
+}
+
+// targetLabels returns the labels of the instance, addressed by its hostname
+// and its port if it has one.
+func targetLabels(appName string, inst instance) model.LabelSet {
+	addr := inst.HostName
+	labels := model.LabelSet{
+		appNameLabel:                     model.LabelValue(appName),
+		appInstanceIDLabel:               model.LabelValue(inst.InstanceID),
+		appInstanceHostNameLabel:         model.LabelValue(inst.HostName),
+		appInstanceHomePageURLLabel:      model.LabelValue(inst.HomePageURL),
+		appInstanceStatusPageURLLabel:    model.LabelValue(inst.StatusPageURL),
+		appInstanceHealthCheckURLLabel:   model.LabelValue(inst.HealthCheckURL),
+		appInstanceIPAddrLabel:           model.LabelValue(inst.IPAddr),
+		appInstanceVipAddressLabel:       model.LabelValue(inst.VipAddress),
+		appInstanceSecureVipAddressLabel: model.LabelValue(inst.SecureVipAddress),
+		appInstanceStatusLabel:           model.LabelValue(inst.Status),
+		appInstanceCountryIDLabel:        model.LabelValue(strconv.Itoa(inst.CountryID)),
+	}
+	if inst.Port != nil {
+		labels[appInstancePortLabel] = model.LabelValue(strconv.Itoa(inst.Port.Port))
+		labels[appInstancePortEnabledLabel] = model.LabelValue(strconv.FormatBool(inst.Port.Enabled))
+		addr = net.JoinHostPort(inst.HostName, strconv.Itoa(inst.Port.Port))
+	}
+	if inst.SecurePort != nil {
+		labels[appInstanceSecurePortLabel] = model.LabelValue(strconv.Itoa(inst.SecurePort.Port))
+		labels[appInstanceSecurePortEnabledLabel] = model.LabelValue(strconv.FormatBool(inst.SecurePort.Enabled))
+	}
+	if inst.DataCenterInfo != nil {
+		labels[appInstanceDataCenterNameLabel] = model.LabelValue(inst.DataCenterInfo.Name)
+	}
+	if inst.Metadata != nil {
+		for _, m := range inst.Metadata.Items {
+			name := strutil.SanitizeLabelName(m.XMLName.Local)
+			labels[model.LabelName(appInstanceMetadataPrefix+name)] = model.LabelValue(m.Content)
+		}
+	}
+	labels[model.AddressLabel] = model.LabelValue(addr)
+	return labels
+}
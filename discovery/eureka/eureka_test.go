@@ -0,0 +1,141 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eureka
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/prometheus/common/log"
+	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
+
+	"github.com/prometheus/prometheus/config"
+)
+
+const testApps = `<applications>
+  <versions__delta>1</versions__delta>
+  <apps__hashcode>UP_2_</apps__hashcode>
+  <application>
+    <name>CONFIG-SERVICE</name>
+    <instance>
+      <instanceId>config-service001.test.com:config-service:8080</instanceId>
+      <hostName>config-service001.test.com</hostName>
+      <app>CONFIG-SERVICE</app>
+      <ipAddr>192.133.87.69</ipAddr>
+      <status>UP</status>
+      <overriddenstatus>UNKNOWN</overriddenstatus>
+      <port enabled="true">8080</port>
+      <securePort enabled="false">8080</securePort>
+      <countryId>1</countryId>
+      <dataCenterInfo class="com.netflix.appinfo.InstanceInfo$DefaultDataCenterInfo">
+        <name>MyOwn</name>
+      </dataCenterInfo>
+      <metadata>
+        <management.port>8080</management.port>
+        <project>prometheus</project>
+      </metadata>
+      <homePageUrl>http://config-service001.test.com:8080/</homePageUrl>
+      <statusPageUrl>http://config-service001.test.com:8080/info</statusPageUrl>
+      <healthCheckUrl>http://config-service001.test.com:8080/health</healthCheckUrl>
+      <vipAddress>config-service</vipAddress>
+      <secureVipAddress>config-service</secureVipAddress>
+    </instance>
+  </application>
+  <application>
+    <name>META-SERVICE</name>
+    <instance>
+      <instanceId>meta-service002.test.com:meta-service:8080</instanceId>
+      <hostName>meta-service002.test.com</hostName>
+      <app>META-SERVICE</app>
+      <ipAddr>192.133.87.70</ipAddr>
+      <status>DOWN</status>
+      <countryId>1</countryId>
+      <vipAddress>meta-service</vipAddress>
+    </instance>
+  </application>
+</applications>`
+
+func TestRefresh(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/eureka/apps" {
+			http.NotFound(w, r)
+			return
+		}
+		if accept := r.Header.Get("Accept"); accept != "application/xml" {
+			t.Errorf("Expected XML to be requested, got %q", accept)
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		fmt.Fprint(w, testApps)
+	}))
+	defer srv.Close()
+
+	d, err := NewDiscovery(&config.EurekaSDConfig{
+		Server: srv.URL + "/eureka/",
+	}, log.Base())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tg, err := d.refresh(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &config.TargetGroup{
+		Source: srv.URL + "/eureka",
+		Targets: []model.LabelSet{
+			{
+				"__address__":                                         "config-service001.test.com:8080",
+				"__meta_eureka_app_name":                              "CONFIG-SERVICE",
+				"__meta_eureka_app_instance_id":                       "config-service001.test.com:config-service:8080",
+				"__meta_eureka_app_instance_hostname":                 "config-service001.test.com",
+				"__meta_eureka_app_instance_homepage_url":             "http://config-service001.test.com:8080/",
+				"__meta_eureka_app_instance_statuspage_url":           "http://config-service001.test.com:8080/info",
+				"__meta_eureka_app_instance_healthcheck_url":          "http://config-service001.test.com:8080/health",
+				"__meta_eureka_app_instance_ip_addr":                  "192.133.87.69",
+				"__meta_eureka_app_instance_vip_address":              "config-service",
+				"__meta_eureka_app_instance_secure_vip_address":       "config-service",
+				"__meta_eureka_app_instance_status":                   "UP",
+				"__meta_eureka_app_instance_port":                     "8080",
+				"__meta_eureka_app_instance_port_enabled":             "true",
+				"__meta_eureka_app_instance_secure_port":              "8080",
+				"__meta_eureka_app_instance_secure_port_enabled":      "false",
+				"__meta_eureka_app_instance_country_id":               "1",
+				"__meta_eureka_app_instance_datacenterinfo_name":      "MyOwn",
+				"__meta_eureka_app_instance_metadata_management_port": "8080",
+				"__meta_eureka_app_instance_metadata_project":         "prometheus",
+			},
+			{
+				"__address__":                                   "meta-service002.test.com",
+				"__meta_eureka_app_name":                        "META-SERVICE",
+				"__meta_eureka_app_instance_id":                 "meta-service002.test.com:meta-service:8080",
+				"__meta_eureka_app_instance_hostname":           "meta-service002.test.com",
+				"__meta_eureka_app_instance_homepage_url":       "",
+				"__meta_eureka_app_instance_statuspage_url":     "",
+				"__meta_eureka_app_instance_healthcheck_url":    "",
+				"__meta_eureka_app_instance_ip_addr":            "192.133.87.70",
+				"__meta_eureka_app_instance_vip_address":        "meta-service",
+				"__meta_eureka_app_instance_secure_vip_address": "",
+				"__meta_eureka_app_instance_status":             "DOWN",
+				"__meta_eureka_app_instance_country_id":         "1",
+			},
+		},
+	}
+	if !reflect.DeepEqual(tg, expected) {
+		t.Errorf("Expected target group\n%v\ngot\n%v", expected, tg)
+	}
+}
@@ -194,7 +194,7 @@ func (d *Discovery) refresh(ctx context.Context, ch chan<- []*config.TargetGroup
 
 	ref := map[string]int{}
 	for _, p := range d.listFiles() {
-		tgroups, err := readFile(p)
+		tgroups, err := ReadFile(p)
 		if err != nil {
 			fileSDReadErrorsCount.Inc()
 			d.logger.Errorf("Error reading file %q: %s", p, err)
@@ -233,9 +233,9 @@ func fileSource(filename string, i int) string {
 	return fmt.Sprintf("%s:%d", filename, i)
 }
 
-// readFile reads a JSON or YAML list of targets groups from the file, depending on its
+// ReadFile reads a JSON or YAML list of targets groups from the file, depending on its
 // file extension. It returns full configuration target groups.
-func readFile(filename string) ([]*config.TargetGroup, error) {
+func ReadFile(filename string) ([]*config.TargetGroup, error) {
 	content, err := ioutil.ReadFile(filename)
 	if err != nil {
 		return nil, err
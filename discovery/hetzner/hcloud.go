@@ -0,0 +1,177 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hetzner
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
+
+	"github.com/prometheus/prometheus/util/strutil"
+)
+
+const (
+	hcloudLabel                      = hetznerLabel + "hcloud_"
+	hcloudLabelImageName             = hcloudLabel + "image_name"
+	hcloudLabelImageDescription      = hcloudLabel + "image_description"
+	hcloudLabelImageOSFlavor         = hcloudLabel + "image_os_flavor"
+	hcloudLabelImageOSVersion        = hcloudLabel + "image_os_version"
+	hcloudLabelDatacenterLocation    = hcloudLabel + "datacenter_location"
+	hcloudLabelDatacenterNetworkZone = hcloudLabel + "datacenter_location_network_zone"
+	hcloudLabelServerType            = hcloudLabel + "server_type"
+	hcloudLabelCPUCores              = hcloudLabel + "cpu_cores"
+	hcloudLabelCPUType               = hcloudLabel + "cpu_type"
+	hcloudLabelMemorySizeGB          = hcloudLabel + "memory_size_gb"
+	hcloudLabelDiskSizeGB            = hcloudLabel + "disk_size_gb"
+	hcloudLabelPrivateIPv4           = hcloudLabel + "private_ipv4_"
+	hcloudLabelLabel                 = hcloudLabel + "label_"
+
+	// The maximum number of items per page of the Cloud API.
+	hcloudPerPage = 50
+)
+
+type hcloudPagination struct {
+	Meta struct {
+		Pagination struct {
+			NextPage *int `json:"next_page"`
+		} `json:"pagination"`
+	} `json:"meta"`
+}
+
+type hcloudServer struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	PublicNet struct {
+		IPv4 struct {
+			IP string `json:"ip"`
+		} `json:"ipv4"`
+		IPv6 struct {
+			IP string `json:"ip"`
+		} `json:"ipv6"`
+	} `json:"public_net"`
+	PrivateNet []struct {
+		Network int    `json:"network"`
+		IP      string `json:"ip"`
+	} `json:"private_net"`
+	ServerType struct {
+		Name    string  `json:"name"`
+		Cores   int     `json:"cores"`
+		CPUType string  `json:"cpu_type"`
+		Memory  float64 `json:"memory"`
+		Disk    int     `json:"disk"`
+	} `json:"server_type"`
+	Datacenter struct {
+		Name     string `json:"name"`
+		Location struct {
+			Name        string `json:"name"`
+			NetworkZone string `json:"network_zone"`
+		} `json:"location"`
+	} `json:"datacenter"`
+	// The image is null if it was deleted after the server was created.
+	Image *struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		OSFlavor    string `json:"os_flavor"`
+		OSVersion   string `json:"os_version"`
+	} `json:"image"`
+	Labels map[string]string `json:"labels"`
+}
+
+type hcloudNetwork struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// hcloudTargets returns a target for each server of the Hetzner Cloud project
+// with a public IPv4 address.
+func (d *Discovery) hcloudTargets(ctx context.Context) ([]model.LabelSet, error) {
+	var servers []hcloudServer
+	for page := 1; ; {
+		var resp struct {
+			hcloudPagination
+			Servers []hcloudServer `json:"servers"`
+		}
+		if err := d.get(ctx, fmt.Sprintf("/servers?page=%d&per_page=%d", page, hcloudPerPage), &resp); err != nil {
+			return nil, fmt.Errorf("could not list servers: %s", err)
+		}
+		servers = append(servers, resp.Servers...)
+		if resp.Meta.Pagination.NextPage == nil {
+			break
+		}
+		page = *resp.Meta.Pagination.NextPage
+	}
+
+	networks := map[int]string{}
+	for page := 1; ; {
+		var resp struct {
+			hcloudPagination
+			Networks []hcloudNetwork `json:"networks"`
+		}
+		if err := d.get(ctx, fmt.Sprintf("/networks?page=%d&per_page=%d", page, hcloudPerPage), &resp); err != nil {
+			return nil, fmt.Errorf("could not list networks: %s", err)
+		}
+		for _, n := range resp.Networks {
+			networks[n.ID] = n.Name
+		}
+		if resp.Meta.Pagination.NextPage == nil {
+			break
+		}
+		page = *resp.Meta.Pagination.NextPage
+	}
+
+	targets := make([]model.LabelSet, 0, len(servers))
+	for _, s := range servers {
+		if s.PublicNet.IPv4.IP == "" {
+			continue
+		}
+		labels := model.LabelSet{
+			model.AddressLabel:               model.LabelValue(net.JoinHostPort(s.PublicNet.IPv4.IP, strconv.Itoa(d.port))),
+			hetznerLabelServerID:             model.LabelValue(strconv.Itoa(s.ID)),
+			hetznerLabelServerName:           model.LabelValue(s.Name),
+			hetznerLabelServerStatus:         model.LabelValue(s.Status),
+			hetznerLabelPublicIPv4:           model.LabelValue(s.PublicNet.IPv4.IP),
+			hetznerLabelPublicIPv6Network:    model.LabelValue(s.PublicNet.IPv6.IP),
+			hetznerLabelDatacenter:           model.LabelValue(s.Datacenter.Name),
+			hcloudLabelDatacenterLocation:    model.LabelValue(s.Datacenter.Location.Name),
+			hcloudLabelDatacenterNetworkZone: model.LabelValue(s.Datacenter.Location.NetworkZone),
+			hcloudLabelServerType:            model.LabelValue(s.ServerType.Name),
+			hcloudLabelCPUCores:              model.LabelValue(strconv.Itoa(s.ServerType.Cores)),
+			hcloudLabelCPUType:               model.LabelValue(s.ServerType.CPUType),
+			hcloudLabelMemorySizeGB:          model.LabelValue(strconv.FormatFloat(s.ServerType.Memory, 'f', -1, 64)),
+			hcloudLabelDiskSizeGB:            model.LabelValue(strconv.Itoa(s.ServerType.Disk)),
+		}
+		if s.Image != nil {
+			labels[hcloudLabelImageName] = model.LabelValue(s.Image.Name)
+			labels[hcloudLabelImageDescription] = model.LabelValue(s.Image.Description)
+			labels[hcloudLabelImageOSFlavor] = model.LabelValue(s.Image.OSFlavor)
+			labels[hcloudLabelImageOSVersion] = model.LabelValue(s.Image.OSVersion)
+		}
+		for _, pn := range s.PrivateNet {
+			name, ok := networks[pn.Network]
+			if !ok {
+				continue
+			}
+			labels[hcloudLabelPrivateIPv4+model.LabelName(strutil.SanitizeLabelName(name))] = model.LabelValue(pn.IP)
+		}
+		for k, v := range s.Labels {
+			labels[hcloudLabelLabel+model.LabelName(strutil.SanitizeLabelName(k))] = model.LabelValue(v)
+		}
+		targets = append(targets, labels)
+	}
+	return targets, nil
+}
@@ -0,0 +1,182 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hetzner
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
+	"golang.org/x/net/context/ctxhttp"
+
+	"github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/util/httputil"
+)
+
+const (
+	hetznerLabel                  = model.MetaLabelPrefix + "hetzner_"
+	hetznerLabelServerID          = hetznerLabel + "server_id"
+	hetznerLabelServerName        = hetznerLabel + "server_name"
+	hetznerLabelServerStatus      = hetznerLabel + "server_status"
+	hetznerLabelPublicIPv4        = hetznerLabel + "public_ipv4"
+	hetznerLabelPublicIPv6Network = hetznerLabel + "public_ipv6_network"
+	hetznerLabelDatacenter        = hetznerLabel + "datacenter"
+
+	hcloudEndpoint = "https://api.hetzner.cloud/v1"
+	robotEndpoint  = "https://robot-ws.your-server.de"
+)
+
+var (
+	refreshFailuresCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "prometheus_sd_hetzner_refresh_failures_total",
+			Help: "The number of Hetzner-SD refresh failures.",
+		},
+		[]string{"role"},
+	)
+	refreshDuration = prometheus.NewSummaryVec(
+		prometheus.SummaryOpts{
+			Name: "prometheus_sd_hetzner_refresh_duration_seconds",
+			Help: "The duration of a Hetzner-SD refresh in seconds.",
+		},
+		[]string{"role"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(refreshFailuresCount)
+	prometheus.MustRegister(refreshDuration)
+}
+
+// Discovery periodically discovers the servers of a Hetzner Cloud project or
+// Hetzner Robot account. It implements the TargetProvider interface.
+type Discovery struct {
+	client   *http.Client
+	role     config.HetznerRole
+	port     int
+	interval time.Duration
+	logger   log.Logger
+
+	// The endpoint of the API of the role.
+	endpoint string
+}
+
+// NewDiscovery returns a new Hetzner discovery which periodically refreshes
+// its targets.
+func NewDiscovery(conf *config.HetznerSDConfig, logger log.Logger) (*Discovery, error) {
+	client, err := httputil.NewClientFromConfig(conf.HTTPClientConfig)
+	if err != nil {
+		return nil, err
+	}
+	client.Timeout = time.Duration(conf.RefreshInterval)
+
+	d := &Discovery{
+		client:   client,
+		role:     conf.Role,
+		port:     conf.Port,
+		interval: time.Duration(conf.RefreshInterval),
+		logger:   logger,
+	}
+	switch conf.Role {
+	case config.HetznerRoleHcloud:
+		d.endpoint = hcloudEndpoint
+	case config.HetznerRoleRobot:
+		d.endpoint = robotEndpoint
+	default:
+		return nil, fmt.Errorf("unknown Hetzner SD role %q", conf.Role)
+	}
+	return d, nil
+}
+
+// Run implements the TargetProvider interface.
+func (d *Discovery) Run(ctx context.Context, ch chan<- []*config.TargetGroup) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		tg, err := d.refresh(ctx)
+		if err != nil {
+			d.logger.Errorf("Error refreshing Hetzner %s targets: %s", d.role, err)
+		} else {
+			select {
+			case ch <- []*config.TargetGroup{tg}:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (d *Discovery) refresh(ctx context.Context) (tg *config.TargetGroup, err error) {
+	t0 := time.Now()
+	defer func() {
+		refreshDuration.WithLabelValues(string(d.role)).Observe(time.Since(t0).Seconds())
+		if err != nil {
+			refreshFailuresCount.WithLabelValues(string(d.role)).Inc()
+		}
+	}()
+
+	var targets []model.LabelSet
+	switch d.role {
+	case config.HetznerRoleHcloud:
+		targets, err = d.hcloudTargets(ctx)
+	case config.HetznerRoleRobot:
+		targets, err = d.robotTargets(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &config.TargetGroup{
+		Source:  string(d.role),
+		Targets: targets,
+	}, nil
+}
+
+// get decodes the JSON response to a GET request of the path of the API.
+func (d *Discovery) get(ctx context.Context, path string, v interface{}) error {
+	req, err := http.NewRequest("GET", d.endpoint+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := ctxhttp.Do(ctx, d.client, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("request to %s failed with status %s", path, resp.Status)
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("could not decode response to %s: %s", path, err)
+	}
+	return nil
+}
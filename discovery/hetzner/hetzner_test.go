@@ -0,0 +1,231 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hetzner
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/prometheus/common/log"
+	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
+
+	"github.com/prometheus/prometheus/config"
+)
+
+func TestHcloudRefresh(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer token" {
+			t.Errorf("Expected bearer token authorization, got %q", auth)
+		}
+		paths = append(paths, r.URL.RequestURI())
+		switch r.URL.RequestURI() {
+		case "/servers?page=1&per_page=50":
+			fmt.Fprint(w, `{
+				"servers": [{
+					"id": 42,
+					"name": "web-1",
+					"status": "running",
+					"public_net": {"ipv4": {"ip": "1.2.3.4"}, "ipv6": {"ip": "2001:db8::/64"}},
+					"private_net": [{"network": 4711, "ip": "10.0.0.2"}],
+					"server_type": {"name": "cx11", "cores": 1, "cpu_type": "shared", "memory": 2.0, "disk": 20},
+					"datacenter": {"name": "fsn1-dc14", "location": {"name": "fsn1", "network_zone": "eu-central"}},
+					"image": {"name": "ubuntu-16.04", "description": "Ubuntu 16.04", "os_flavor": "ubuntu", "os_version": "16.04"},
+					"labels": {"my-key": "my-value"}
+				}],
+				"meta": {"pagination": {"next_page": 2}}
+			}`)
+		case "/servers?page=2&per_page=50":
+			fmt.Fprint(w, `{
+				"servers": [{
+					"id": 43,
+					"name": "db-1",
+					"status": "off",
+					"public_net": {"ipv4": {"ip": "1.2.3.5"}, "ipv6": {"ip": "2001:db8:1::/64"}},
+					"server_type": {"name": "cx21", "cores": 2, "cpu_type": "dedicated", "memory": 0.5, "disk": 40},
+					"datacenter": {"name": "nbg1-dc3", "location": {"name": "nbg1", "network_zone": "eu-central"}},
+					"image": null,
+					"labels": {}
+				}],
+				"meta": {"pagination": {"next_page": null}}
+			}`)
+		case "/networks?page=1&per_page=50":
+			fmt.Fprint(w, `{"networks": [{"id": 4711, "name": "main-net"}], "meta": {"pagination": {"next_page": null}}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	d, err := NewDiscovery(&config.HetznerSDConfig{
+		Role: config.HetznerRoleHcloud,
+		Port: 9100,
+		HTTPClientConfig: config.HTTPClientConfig{
+			BearerToken: "token",
+		},
+	}, log.Base())
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.endpoint = srv.URL
+
+	tg, err := d.refresh(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &config.TargetGroup{
+		Source: "hcloud",
+		Targets: []model.LabelSet{
+			{
+				"__address__":                                            "1.2.3.4:9100",
+				"__meta_hetzner_server_id":                               "42",
+				"__meta_hetzner_server_name":                             "web-1",
+				"__meta_hetzner_server_status":                           "running",
+				"__meta_hetzner_public_ipv4":                             "1.2.3.4",
+				"__meta_hetzner_public_ipv6_network":                     "2001:db8::/64",
+				"__meta_hetzner_datacenter":                              "fsn1-dc14",
+				"__meta_hetzner_hcloud_datacenter_location":              "fsn1",
+				"__meta_hetzner_hcloud_datacenter_location_network_zone": "eu-central",
+				"__meta_hetzner_hcloud_server_type":                      "cx11",
+				"__meta_hetzner_hcloud_cpu_cores":                        "1",
+				"__meta_hetzner_hcloud_cpu_type":                         "shared",
+				"__meta_hetzner_hcloud_memory_size_gb":                   "2",
+				"__meta_hetzner_hcloud_disk_size_gb":                     "20",
+				"__meta_hetzner_hcloud_image_name":                       "ubuntu-16.04",
+				"__meta_hetzner_hcloud_image_description":                "Ubuntu 16.04",
+				"__meta_hetzner_hcloud_image_os_flavor":                  "ubuntu",
+				"__meta_hetzner_hcloud_image_os_version":                 "16.04",
+				"__meta_hetzner_hcloud_private_ipv4_main_net":            "10.0.0.2",
+				"__meta_hetzner_hcloud_label_my_key":                     "my-value",
+			},
+			{
+				"__address__":                                            "1.2.3.5:9100",
+				"__meta_hetzner_server_id":                               "43",
+				"__meta_hetzner_server_name":                             "db-1",
+				"__meta_hetzner_server_status":                           "off",
+				"__meta_hetzner_public_ipv4":                             "1.2.3.5",
+				"__meta_hetzner_public_ipv6_network":                     "2001:db8:1::/64",
+				"__meta_hetzner_datacenter":                              "nbg1-dc3",
+				"__meta_hetzner_hcloud_datacenter_location":              "nbg1",
+				"__meta_hetzner_hcloud_datacenter_location_network_zone": "eu-central",
+				"__meta_hetzner_hcloud_server_type":                      "cx21",
+				"__meta_hetzner_hcloud_cpu_cores":                        "2",
+				"__meta_hetzner_hcloud_cpu_type":                         "dedicated",
+				"__meta_hetzner_hcloud_memory_size_gb":                   "0.5",
+				"__meta_hetzner_hcloud_disk_size_gb":                     "40",
+			},
+		},
+	}
+	if !reflect.DeepEqual(tg, expected) {
+		t.Errorf("Expected target group\n%v\ngot\n%v", expected, tg)
+	}
+	if len(paths) != 3 {
+		t.Errorf("Expected 3 requests, got %v", paths)
+	}
+}
+
+func TestRobotRefresh(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "user" || pass != "pass" {
+			t.Errorf("Expected basic authentication, got %q", r.Header.Get("Authorization"))
+		}
+		if r.URL.Path != "/server" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `[{
+			"server": {
+				"server_ip": "123.123.123.123",
+				"server_ipv6_net": "2a01:4f8:111:4221::",
+				"server_number": 321,
+				"server_name": "server1",
+				"product": "DS 3000",
+				"dc": "NBG1-DC1",
+				"status": "ready",
+				"cancelled": false,
+				"subnet": [{"ip": "123.123.123.120", "mask": "29"}, {"ip": "2a01:4f8:111:4221::", "mask": "64"}]
+			}
+		}, {
+			"server": {
+				"server_ip": null,
+				"server_number": 322,
+				"server_name": "ipv6-only",
+				"product": "DS 3000",
+				"dc": "FSN1-DC5",
+				"status": "ready",
+				"cancelled": true
+			}
+		}]`)
+	}))
+	defer srv.Close()
+
+	d, err := NewDiscovery(&config.HetznerSDConfig{
+		Role: config.HetznerRoleRobot,
+		Port: 80,
+		HTTPClientConfig: config.HTTPClientConfig{
+			BasicAuth: &config.BasicAuth{Username: "user", Password: "pass"},
+		},
+	}, log.Base())
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.endpoint = srv.URL
+
+	tg, err := d.refresh(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &config.TargetGroup{
+		Source: "robot",
+		Targets: []model.LabelSet{
+			{
+				"__address__":                        "123.123.123.123:80",
+				"__meta_hetzner_server_id":           "321",
+				"__meta_hetzner_server_name":         "server1",
+				"__meta_hetzner_server_status":       "ready",
+				"__meta_hetzner_public_ipv4":         "123.123.123.123",
+				"__meta_hetzner_public_ipv6_network": "2a01:4f8:111:4221::/64",
+				"__meta_hetzner_datacenter":          "nbg1-dc1",
+				"__meta_hetzner_robot_product":       "DS 3000",
+				"__meta_hetzner_robot_cancelled":     "false",
+			},
+		},
+	}
+	if !reflect.DeepEqual(tg, expected) {
+		t.Errorf("Expected target group\n%v\ngot\n%v", expected, tg)
+	}
+}
+
+func TestRefreshError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error": {"code": "unauthorized"}}`, http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	d, err := NewDiscovery(&config.HetznerSDConfig{Role: config.HetznerRoleHcloud}, log.Base())
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.endpoint = srv.URL
+
+	_, err = d.refresh(context.Background())
+	expected := "could not list servers: request to /servers?page=1&per_page=50 failed with status 401 Unauthorized"
+	if err == nil || err.Error() != expected {
+		t.Errorf("Expected error %q, got %v", expected, err)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hetzner
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
+)
+
+const (
+	robotLabel          = hetznerLabel + "robot_"
+	robotLabelProduct   = robotLabel + "product"
+	robotLabelCancelled = robotLabel + "cancelled"
+)
+
+type robotServer struct {
+	ServerIP     string `json:"server_ip"`
+	ServerNumber int    `json:"server_number"`
+	ServerName   string `json:"server_name"`
+	Product      string `json:"product"`
+	DC           string `json:"dc"`
+	Status       string `json:"status"`
+	Cancelled    bool   `json:"cancelled"`
+	Subnet       []struct {
+		IP   string `json:"ip"`
+		Mask string `json:"mask"`
+	} `json:"subnet"`
+}
+
+// robotTargets returns a target for each dedicated server of the Hetzner
+// Robot account with a public IPv4 address.
+func (d *Discovery) robotTargets(ctx context.Context) ([]model.LabelSet, error) {
+	var resp []struct {
+		Server robotServer `json:"server"`
+	}
+	if err := d.get(ctx, "/server", &resp); err != nil {
+		return nil, fmt.Errorf("could not list servers: %s", err)
+	}
+
+	targets := make([]model.LabelSet, 0, len(resp))
+	for _, r := range resp {
+		s := r.Server
+		if s.ServerIP == "" {
+			continue
+		}
+		labels := model.LabelSet{
+			model.AddressLabel:       model.LabelValue(net.JoinHostPort(s.ServerIP, strconv.Itoa(d.port))),
+			hetznerLabelServerID:     model.LabelValue(strconv.Itoa(s.ServerNumber)),
+			hetznerLabelServerName:   model.LabelValue(s.ServerName),
+			hetznerLabelServerStatus: model.LabelValue(s.Status),
+			hetznerLabelPublicIPv4:   model.LabelValue(s.ServerIP),
+			hetznerLabelDatacenter:   model.LabelValue(strings.ToLower(s.DC)),
+			robotLabelProduct:        model.LabelValue(s.Product),
+			robotLabelCancelled:      model.LabelValue(strconv.FormatBool(s.Cancelled)),
+		}
+		for _, subnet := range s.Subnet {
+			if strings.Contains(subnet.IP, ":") {
+				labels[hetznerLabelPublicIPv6Network] = model.LabelValue(subnet.IP + "/" + subnet.Mask)
+				break
+			}
+		}
+		targets = append(targets, labels)
+	}
+	return targets, nil
+}
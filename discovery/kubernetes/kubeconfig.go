@@ -0,0 +1,170 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+	"k8s.io/client-go/rest"
+)
+
+// kubeconfig is the subset of the kubeconfig file format needed to connect to
+// an API server.
+type kubeconfig struct {
+	CurrentContext string `yaml:"current-context"`
+	Clusters       []struct {
+		Name    string `yaml:"name"`
+		Cluster struct {
+			Server                   string `yaml:"server"`
+			TLSServerName            string `yaml:"tls-server-name"`
+			InsecureSkipTLSVerify    bool   `yaml:"insecure-skip-tls-verify"`
+			CertificateAuthority     string `yaml:"certificate-authority"`
+			CertificateAuthorityData string `yaml:"certificate-authority-data"`
+		} `yaml:"cluster"`
+	} `yaml:"clusters"`
+	Users []struct {
+		Name string `yaml:"name"`
+		User struct {
+			ClientCertificate     string                 `yaml:"client-certificate"`
+			ClientCertificateData string                 `yaml:"client-certificate-data"`
+			ClientKey             string                 `yaml:"client-key"`
+			ClientKeyData         string                 `yaml:"client-key-data"`
+			Token                 string                 `yaml:"token"`
+			TokenFile             string                 `yaml:"tokenFile"`
+			Username              string                 `yaml:"username"`
+			Password              string                 `yaml:"password"`
+			AuthProvider          map[string]interface{} `yaml:"auth-provider"`
+			Exec                  map[string]interface{} `yaml:"exec"`
+		} `yaml:"user"`
+	} `yaml:"users"`
+	Contexts []struct {
+		Name    string `yaml:"name"`
+		Context struct {
+			Cluster string `yaml:"cluster"`
+			User    string `yaml:"user"`
+		} `yaml:"context"`
+	} `yaml:"contexts"`
+}
+
+// loadKubeconfig returns the configuration of the connection to the API server
+// of the given context of the kubeconfig file, or of its current context if
+// none is given. Relative paths are relative to the directory of the file, as
+// for kubectl.
+func loadKubeconfig(filename, context string) (*rest.Config, error) {
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var kc kubeconfig
+	if err := yaml.Unmarshal(b, &kc); err != nil {
+		return nil, fmt.Errorf("error parsing kubeconfig file %s: %s", filename, err)
+	}
+
+	if context == "" {
+		context = kc.CurrentContext
+	}
+	if context == "" {
+		return nil, fmt.Errorf("no context given and kubeconfig file %s has no current context", filename)
+	}
+	clusterName, userName, found := "", "", false
+	for _, c := range kc.Contexts {
+		if c.Name == context {
+			clusterName, userName, found = c.Context.Cluster, c.Context.User, true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("context %q not found in kubeconfig file %s", context, filename)
+	}
+
+	dir := filepath.Dir(filename)
+	join := func(fp string) string {
+		if fp != "" && !filepath.IsAbs(fp) {
+			fp = filepath.Join(dir, fp)
+		}
+		return fp
+	}
+
+	kcfg := &rest.Config{}
+	found = false
+	for _, c := range kc.Clusters {
+		if c.Name != clusterName {
+			continue
+		}
+		found = true
+		kcfg.Host = c.Cluster.Server
+		kcfg.TLSClientConfig.ServerName = c.Cluster.TLSServerName
+		kcfg.TLSClientConfig.Insecure = c.Cluster.InsecureSkipTLSVerify
+		kcfg.TLSClientConfig.CAFile = join(c.Cluster.CertificateAuthority)
+		if kcfg.TLSClientConfig.CAData, err = decodeKubeconfigData(c.Cluster.CertificateAuthorityData); err != nil {
+			return nil, fmt.Errorf("invalid certificate authority data of cluster %q: %s", clusterName, err)
+		}
+		break
+	}
+	if !found {
+		return nil, fmt.Errorf("cluster %q of context %q not found in kubeconfig file %s", clusterName, context, filename)
+	}
+	if kcfg.Host == "" {
+		return nil, fmt.Errorf("cluster %q has no server in kubeconfig file %s", clusterName, filename)
+	}
+
+	if userName == "" {
+		return kcfg, nil
+	}
+	found = false
+	for _, u := range kc.Users {
+		if u.Name != userName {
+			continue
+		}
+		found = true
+		if u.User.AuthProvider != nil || u.User.Exec != nil {
+			return nil, fmt.Errorf("user %q uses an authentication plugin, which is not supported", userName)
+		}
+		kcfg.TLSClientConfig.CertFile = join(u.User.ClientCertificate)
+		kcfg.TLSClientConfig.KeyFile = join(u.User.ClientKey)
+		if kcfg.TLSClientConfig.CertData, err = decodeKubeconfigData(u.User.ClientCertificateData); err != nil {
+			return nil, fmt.Errorf("invalid client certificate data of user %q: %s", userName, err)
+		}
+		if kcfg.TLSClientConfig.KeyData, err = decodeKubeconfigData(u.User.ClientKeyData); err != nil {
+			return nil, fmt.Errorf("invalid client key data of user %q: %s", userName, err)
+		}
+		kcfg.BearerToken = u.User.Token
+		if kcfg.BearerToken == "" && u.User.TokenFile != "" {
+			b, err := ioutil.ReadFile(join(u.User.TokenFile))
+			if err != nil {
+				return nil, err
+			}
+			kcfg.BearerToken = strings.TrimSpace(string(b))
+		}
+		kcfg.Username = u.User.Username
+		kcfg.Password = u.User.Password
+		break
+	}
+	if !found {
+		return nil, fmt.Errorf("user %q of context %q not found in kubeconfig file %s", userName, context, filename)
+	}
+	return kcfg, nil
+}
+
+func decodeKubeconfigData(s string) ([]byte, error) {
+	if s == "" {
+		return nil, nil
+	}
+	return base64.StdEncoding.DecodeString(s)
+}
@@ -0,0 +1,110 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+)
+
+const testKubeconfig = `
+apiVersion: v1
+kind: Config
+current-context: production
+clusters:
+- name: production
+  cluster:
+    server: https://production.example.com:6443
+    certificate-authority: certs/ca.crt
+- name: staging
+  cluster:
+    server: https://staging.example.com:6443
+    tls-server-name: kubernetes
+    certificate-authority-data: Y2EtZGF0YQ==
+users:
+- name: admin
+  user:
+    client-certificate: /etc/certs/admin.crt
+    client-key: /etc/certs/admin.key
+- name: prometheus
+  user:
+    tokenFile: token
+- name: oidc
+  user:
+    auth-provider:
+      name: oidc
+contexts:
+- name: production
+  context:
+    cluster: production
+    user: admin
+- name: staging
+  context:
+    cluster: staging
+    user: prometheus
+- name: oidc
+  context:
+    cluster: staging
+    user: oidc
+- name: missing-cluster
+  context:
+    cluster: development
+`
+
+func TestLoadKubeconfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kubeconfig")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "config")
+	require.NoError(t, ioutil.WriteFile(filename, []byte(testKubeconfig), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "token"), []byte("secret\n"), 0600))
+
+	kcfg, err := loadKubeconfig(filename, "")
+	require.NoError(t, err)
+	require.Equal(t, &rest.Config{
+		Host: "https://production.example.com:6443",
+		TLSClientConfig: rest.TLSClientConfig{
+			CAFile:   filepath.Join(dir, "certs/ca.crt"),
+			CertFile: "/etc/certs/admin.crt",
+			KeyFile:  "/etc/certs/admin.key",
+		},
+	}, kcfg)
+
+	kcfg, err = loadKubeconfig(filename, "staging")
+	require.NoError(t, err)
+	require.Equal(t, &rest.Config{
+		Host:        "https://staging.example.com:6443",
+		BearerToken: "secret",
+		TLSClientConfig: rest.TLSClientConfig{
+			ServerName: "kubernetes",
+			CAData:     []byte("ca-data"),
+		},
+	}, kcfg)
+
+	for context, errMsg := range map[string]string{
+		"development":     `context "development" not found`,
+		"oidc":            `user "oidc" uses an authentication plugin`,
+		"missing-cluster": `cluster "development" of context "missing-cluster" not found`,
+	} {
+		_, err := loadKubeconfig(filename, context)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), errMsg)
+	}
+}
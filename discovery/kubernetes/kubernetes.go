@@ -14,16 +14,22 @@
 package kubernetes
 
 import (
+	"fmt"
 	"io/ioutil"
+	"net/http"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/util/httputil"
 
 	"github.com/prometheus/common/log"
 	"github.com/prometheus/common/model"
 	"golang.org/x/net/context"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/pkg/api"
@@ -48,10 +54,17 @@ var (
 		},
 		[]string{"role", "event"},
 	)
+	namespaceFailuresCount = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "prometheus_sd_kubernetes_namespace_failures_total",
+			Help: "The number of failed discoveries of the Kubernetes namespaces to discover targets in.",
+		},
+	)
 )
 
 func init() {
 	prometheus.MustRegister(eventCount)
+	prometheus.MustRegister(namespaceFailuresCount)
 
 	// Initialize metric vectors.
 	for _, role := range []string{"endpoints", "node", "pod", "service"} {
@@ -68,6 +81,7 @@ type Discovery struct {
 	role               config.KubernetesRole
 	logger             log.Logger
 	namespaceDiscovery *config.KubernetesNamespaceDiscovery
+	nodeAddressTypes   []apiv1.NodeAddressType
 }
 
 func init() {
@@ -78,12 +92,69 @@ func init() {
 	}
 }
 
-func (d *Discovery) getNamespaces() []string {
-	namespaces := d.namespaceDiscovery.Names
-	if len(namespaces) == 0 {
-		namespaces = []string{api.NamespaceAll}
+// serviceAccountNamespaceFile is the file the namespace of the pod service
+// account is mounted at.
+var serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+// The delays before retrying the discovery of namespaces, doubled for each
+// further retry.
+var (
+	namespaceRetryMin = time.Second
+	namespaceRetryMax = time.Minute
+)
+
+// waitForNamespaces returns the namespaces to discover targets in, retrying
+// failed discoveries with a backoff. It returns false if ctx is done first.
+func (d *Discovery) waitForNamespaces(ctx context.Context) ([]string, bool) {
+	delay := namespaceRetryMin
+	for {
+		namespaces, err := d.getNamespaces()
+		if err == nil {
+			return namespaces, true
+		}
+		namespaceFailuresCount.Inc()
+		d.logger.Errorf("Cannot discover Kubernetes namespaces, retrying in %s: %s", delay, err)
+
+		select {
+		case <-ctx.Done():
+			return nil, false
+		case <-time.After(delay):
+		}
+		if delay *= 2; delay > namespaceRetryMax {
+			delay = namespaceRetryMax
+		}
 	}
-	return namespaces
+}
+
+// getNamespaces returns the namespaces to discover targets in. Without
+// namespace restrictions, they are discovered in all namespaces.
+func (d *Discovery) getNamespaces() ([]string, error) {
+	nd := d.namespaceDiscovery
+	switch {
+	case nd.OwnNamespace:
+		b, err := ioutil.ReadFile(serviceAccountNamespaceFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read own namespace: %s", err)
+		}
+		ns := strings.TrimSpace(string(b))
+		if ns == "" {
+			return nil, fmt.Errorf("own namespace in %s is empty", serviceAccountNamespaceFile)
+		}
+		return []string{ns}, nil
+	case nd.LabelSelector != "":
+		l, err := d.client.Core().Namespaces().List(metav1.ListOptions{LabelSelector: nd.LabelSelector})
+		if err != nil {
+			return nil, fmt.Errorf("cannot list namespaces matching %q: %s", nd.LabelSelector, err)
+		}
+		namespaces := make([]string, 0, len(l.Items))
+		for _, ns := range l.Items {
+			namespaces = append(namespaces, ns.Name)
+		}
+		return namespaces, nil
+	case len(nd.Names) > 0:
+		return nd.Names, nil
+	}
+	return []string{api.NamespaceAll}, nil
 }
 
 // New creates a new Kubernetes discovery for the given role.
@@ -92,7 +163,18 @@ func New(l log.Logger, conf *config.KubernetesSDConfig) (*Discovery, error) {
 		kcfg *rest.Config
 		err  error
 	)
-	if conf.APIServer.URL == nil {
+	if sel := conf.NamespaceDiscovery.LabelSelector; sel != "" {
+		if _, err := labels.Parse(sel); err != nil {
+			return nil, fmt.Errorf("invalid namespace label selector %q: %s", sel, err)
+		}
+	}
+	if conf.KubeconfigFile != "" {
+		kcfg, err = loadKubeconfig(conf.KubeconfigFile, conf.KubeconfigContext)
+		if err != nil {
+			return nil, err
+		}
+		l.Infof("Using API server %s of kubeconfig file %s", kcfg.Host, conf.KubeconfigFile)
+	} else if conf.APIServer.URL == nil {
 		// Use the Kubernetes provided pod service account
 		// as described in https://kubernetes.io/docs/admin/service-accounts-admin/
 		kcfg, err = rest.InClusterConfig()
@@ -125,19 +207,23 @@ func New(l log.Logger, conf *config.KubernetesSDConfig) (*Discovery, error) {
 				Insecure: conf.TLSConfig.InsecureSkipVerify,
 			},
 		}
-		token := string(conf.BearerToken)
+		// Secret files are read on each request, so that they can be rotated.
+		kcfg.BearerToken = string(conf.BearerToken)
 		if conf.BearerTokenFile != "" {
-			bf, err := ioutil.ReadFile(conf.BearerTokenFile)
-			if err != nil {
-				return nil, err
+			kcfg.WrapTransport = func(rt http.RoundTripper) http.RoundTripper {
+				return httputil.NewBearerAuthFileRoundTripper(conf.BearerTokenFile, rt)
 			}
-			token = string(bf)
 		}
-		kcfg.BearerToken = token
 
-		if conf.BasicAuth != nil {
-			kcfg.Username = conf.BasicAuth.Username
-			kcfg.Password = string(conf.BasicAuth.Password)
+		if ba := conf.BasicAuth; ba != nil {
+			if ba.PasswordFile != "" {
+				kcfg.WrapTransport = func(rt http.RoundTripper) http.RoundTripper {
+					return httputil.NewBasicAuthRoundTripper(ba.Username, "", ba.PasswordFile, rt)
+				}
+			} else {
+				kcfg.Username = ba.Username
+				kcfg.Password = string(ba.Password)
+			}
 		}
 	}
 
@@ -147,11 +233,16 @@ func New(l log.Logger, conf *config.KubernetesSDConfig) (*Discovery, error) {
 	if err != nil {
 		return nil, err
 	}
+	var nodeAddressTypes []apiv1.NodeAddressType
+	for _, t := range conf.NodeAddressTypes {
+		nodeAddressTypes = append(nodeAddressTypes, apiv1.NodeAddressType(t))
+	}
 	return &Discovery{
 		client:             c,
 		logger:             l,
 		role:               conf.Role,
 		namespaceDiscovery: &conf.NamespaceDiscovery,
+		nodeAddressTypes:   nodeAddressTypes,
 	}, nil
 }
 
@@ -162,7 +253,10 @@ func (d *Discovery) Run(ctx context.Context, ch chan<- []*config.TargetGroup) {
 	rclient := d.client.Core().RESTClient()
 	reclient := d.client.Extensions().RESTClient()
 
-	namespaces := d.getNamespaces()
+	namespaces, ok := d.waitForNamespaces(ctx)
+	if !ok {
+		return
+	}
 
 	switch d.role {
 	case "endpoints":
@@ -263,6 +357,7 @@ func (d *Discovery) Run(ctx context.Context, ch chan<- []*config.TargetGroup) {
 		node := NewNode(
 			d.logger.With("kubernetes_sd", "node"),
 			cache.NewSharedInformer(nlw, &apiv1.Node{}, resyncPeriod),
+			d.nodeAddressTypes,
 		)
 		go node.informer.Run(ctx.Done())
 
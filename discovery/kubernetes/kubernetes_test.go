@@ -0,0 +1,147 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/log"
+	"golang.org/x/net/context"
+
+	"github.com/prometheus/prometheus/config"
+	"k8s.io/client-go/pkg/api"
+)
+
+func TestGetNamespaces(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kubernetes_sd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	defer func(f string) { serviceAccountNamespaceFile = f }(serviceAccountNamespaceFile)
+	serviceAccountNamespaceFile = filepath.Join(dir, "namespace")
+
+	d := &Discovery{namespaceDiscovery: &config.KubernetesNamespaceDiscovery{OwnNamespace: true}}
+	if _, err := d.getNamespaces(); err == nil {
+		t.Fatal("Expected an error without a service account namespace")
+	}
+
+	if err := ioutil.WriteFile(serviceAccountNamespaceFile, []byte("monitoring\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		nd       config.KubernetesNamespaceDiscovery
+		expected []string
+	}{
+		{
+			nd:       config.KubernetesNamespaceDiscovery{},
+			expected: []string{api.NamespaceAll},
+		},
+		{
+			nd:       config.KubernetesNamespaceDiscovery{Names: []string{"default", "kube-system"}},
+			expected: []string{"default", "kube-system"},
+		},
+		{
+			nd:       config.KubernetesNamespaceDiscovery{OwnNamespace: true},
+			expected: []string{"monitoring"},
+		},
+	} {
+		d := &Discovery{namespaceDiscovery: &c.nd}
+		namespaces, err := d.getNamespaces()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(namespaces, c.expected) {
+			t.Errorf("Expected namespaces %v for %+v, got %v", c.expected, c.nd, namespaces)
+		}
+	}
+}
+
+func TestWaitForNamespaces(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kubernetes_sd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	defer func(f string) { serviceAccountNamespaceFile = f }(serviceAccountNamespaceFile)
+	serviceAccountNamespaceFile = filepath.Join(dir, "namespace")
+	defer func(min, max time.Duration) { namespaceRetryMin, namespaceRetryMax = min, max }(namespaceRetryMin, namespaceRetryMax)
+	namespaceRetryMin, namespaceRetryMax = time.Millisecond, time.Millisecond
+
+	failures := func() float64 {
+		var pb dto.Metric
+		if err := namespaceFailuresCount.Write(&pb); err != nil {
+			t.Fatal(err)
+		}
+		return pb.GetCounter().GetValue()
+	}
+	d := &Discovery{
+		logger:             log.Base(),
+		namespaceDiscovery: &config.KubernetesNamespaceDiscovery{OwnNamespace: true},
+	}
+
+	// Failed discoveries are retried until the namespace can be read.
+	before := failures()
+	done := make(chan []string)
+	go func() {
+		namespaces, _ := d.waitForNamespaces(context.Background())
+		done <- namespaces
+	}()
+	for failures() < before+2 {
+		time.Sleep(time.Millisecond)
+	}
+	if err := ioutil.WriteFile(serviceAccountNamespaceFile, []byte("monitoring\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case namespaces := <-done:
+		if expected := []string{"monitoring"}; !reflect.DeepEqual(namespaces, expected) {
+			t.Errorf("Expected namespaces %v, got %v", expected, namespaces)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Namespaces were not discovered after retrying")
+	}
+
+	// Retrying stops once the context is done.
+	if err := os.Remove(serviceAccountNamespaceFile); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, ok := d.waitForNamespaces(ctx); ok {
+		t.Error("Expected no namespaces once the context is done")
+	}
+}
+
+func TestNewInvalidNamespaceLabelSelector(t *testing.T) {
+	_, err := New(nil, &config.KubernetesSDConfig{
+		APIServer: config.URL{},
+		Role:      config.KubernetesRolePod,
+		NamespaceDiscovery: config.KubernetesNamespaceDiscovery{
+			LabelSelector: "team in (",
+		},
+	})
+	if err == nil {
+		t.Fatal("Expected an error for an invalid namespace label selector")
+	}
+}
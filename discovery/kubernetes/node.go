@@ -28,16 +28,32 @@ import (
 	"k8s.io/client-go/tools/cache"
 )
 
+// defaultNodeAddressTypes is the order of preference of node address types
+// if none is configured.
+//
+// Derived from k8s.io/kubernetes/pkg/util/node/node.go
+var defaultNodeAddressTypes = []apiv1.NodeAddressType{
+	apiv1.NodeInternalIP,
+	apiv1.NodeExternalIP,
+	apiv1.NodeAddressType(api.NodeLegacyHostIP),
+	apiv1.NodeHostName,
+}
+
 // Node discovers Kubernetes nodes.
 type Node struct {
-	logger   log.Logger
-	informer cache.SharedInformer
-	store    cache.Store
+	logger       log.Logger
+	informer     cache.SharedInformer
+	store        cache.Store
+	addressTypes []apiv1.NodeAddressType
 }
 
-// NewNode returns a new node discovery.
-func NewNode(l log.Logger, inf cache.SharedInformer) *Node {
-	return &Node{logger: l, informer: inf, store: inf.GetStore()}
+// NewNode returns a new node discovery. The address of a node is picked from
+// the first of the given address types the node has an address of.
+func NewNode(l log.Logger, inf cache.SharedInformer, addressTypes []apiv1.NodeAddressType) *Node {
+	if len(addressTypes) == 0 {
+		addressTypes = defaultNodeAddressTypes
+	}
+	return &Node{logger: l, informer: inf, store: inf.GetStore(), addressTypes: addressTypes}
 }
 
 // Run implements the TargetProvider interface.
@@ -124,6 +140,8 @@ const (
 	nodeLabelPrefix      = metaLabelPrefix + "node_label_"
 	nodeAnnotationPrefix = metaLabelPrefix + "node_annotation_"
 	nodeAddressPrefix    = metaLabelPrefix + "node_address_"
+	nodeTaintPrefix      = metaLabelPrefix + "node_taint_"
+	nodeConditionPrefix  = metaLabelPrefix + "node_condition_"
 )
 
 func nodeLabels(n *apiv1.Node) model.LabelSet {
@@ -140,6 +158,21 @@ func nodeLabels(n *apiv1.Node) model.LabelSet {
 		ln := strutil.SanitizeLabelName(nodeAnnotationPrefix + k)
 		ls[model.LabelName(ln)] = lv(v)
 	}
+
+	// Taints are exposed in the value:effect notation of kubectl. Multiple
+	// taints with the same key are comma separated.
+	for _, t := range n.Spec.Taints {
+		ln := model.LabelName(strutil.SanitizeLabelName(nodeTaintPrefix + t.Key))
+		v := t.Value + ":" + string(t.Effect)
+		if prev, ok := ls[ln]; ok {
+			v = string(prev) + "," + v
+		}
+		ls[ln] = lv(v)
+	}
+	for _, c := range n.Status.Conditions {
+		ln := strutil.SanitizeLabelName(nodeConditionPrefix + string(c.Type))
+		ls[model.LabelName(ln)] = lv(string(c.Status))
+	}
 	return ls
 }
 
@@ -149,7 +182,7 @@ func (n *Node) buildNode(node *apiv1.Node) *config.TargetGroup {
 	}
 	tg.Labels = nodeLabels(node)
 
-	addr, addrMap, err := nodeAddress(node)
+	addr, addrMap, err := nodeAddress(node, n.addressTypes)
 	if err != nil {
 		n.logger.With("err", err).Debugf("No node address found")
 		return nil
@@ -170,30 +203,18 @@ func (n *Node) buildNode(node *apiv1.Node) *config.TargetGroup {
 	return tg
 }
 
-// nodeAddresses returns the provided node's address, based on the priority:
-// 1. NodeInternalIP
-// 2. NodeExternalIP
-// 3. NodeLegacyHostIP
-// 3. NodeHostName
-//
-// Derived from k8s.io/kubernetes/pkg/util/node/node.go
-func nodeAddress(node *apiv1.Node) (string, map[apiv1.NodeAddressType][]string, error) {
+// nodeAddress returns the provided node's address of the first of the given
+// address types it has an address of, along with all its addresses by type.
+func nodeAddress(node *apiv1.Node, addressTypes []apiv1.NodeAddressType) (string, map[apiv1.NodeAddressType][]string, error) {
 	m := map[apiv1.NodeAddressType][]string{}
 	for _, a := range node.Status.Addresses {
 		m[a.Type] = append(m[a.Type], a.Address)
 	}
 
-	if addresses, ok := m[apiv1.NodeInternalIP]; ok {
-		return addresses[0], m, nil
-	}
-	if addresses, ok := m[apiv1.NodeExternalIP]; ok {
-		return addresses[0], m, nil
-	}
-	if addresses, ok := m[apiv1.NodeAddressType(api.NodeLegacyHostIP)]; ok {
-		return addresses[0], m, nil
-	}
-	if addresses, ok := m[apiv1.NodeHostName]; ok {
-		return addresses[0], m, nil
+	for _, t := range addressTypes {
+		if addresses, ok := m[t]; ok {
+			return addresses[0], m, nil
+		}
 	}
 	return "", m, fmt.Errorf("host address unknown")
 }
@@ -159,7 +159,7 @@ func newFakeNodeInformer() *fakeInformer {
 
 func makeTestNodeDiscovery() (*Node, *fakeInformer) {
 	i := newFakeNodeInformer()
-	return NewNode(log.Base(), i), i
+	return NewNode(log.Base(), i, nil), i
 }
 
 func makeNode(name, address string, labels map[string]string, annotations map[string]string) *v1.Node {
@@ -355,3 +355,73 @@ func TestNodeDiscoveryUpdate(t *testing.T) {
 		},
 	}.Run(t)
 }
+
+func TestNodeDiscoveryTaintsAndConditions(t *testing.T) {
+	n, i := makeTestNodeDiscovery()
+	node := makeEnumeratedNode(0)
+	node.Spec.Taints = []v1.Taint{
+		{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule},
+		{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoExecute},
+		{Key: "node.alpha.kubernetes.io/unreachable", Effect: v1.TaintEffectNoExecute},
+	}
+	node.Status.Conditions = []v1.NodeCondition{
+		{Type: v1.NodeReady, Status: v1.ConditionTrue},
+		{Type: v1.NodeDiskPressure, Status: v1.ConditionFalse},
+	}
+	i.GetStore().Add(node)
+
+	k8sDiscoveryTest{
+		discovery: n,
+		expectedInitial: []*config.TargetGroup{
+			{
+				Targets: []model.LabelSet{
+					{
+						"__address__": "1.2.3.4:10250",
+						"instance":    "test0",
+						"__meta_kubernetes_node_address_InternalIP": "1.2.3.4",
+					},
+				},
+				Labels: model.LabelSet{
+					"__meta_kubernetes_node_name":                                       "test0",
+					"__meta_kubernetes_node_taint_dedicated":                            "gpu:NoSchedule,gpu:NoExecute",
+					"__meta_kubernetes_node_taint_node_alpha_kubernetes_io_unreachable": ":NoExecute",
+					"__meta_kubernetes_node_condition_Ready":                            "True",
+					"__meta_kubernetes_node_condition_DiskPressure":                     "False",
+				},
+				Source: "node/test0",
+			},
+		},
+	}.Run(t)
+}
+
+func TestNodeAddress(t *testing.T) {
+	node := &v1.Node{
+		Status: v1.NodeStatus{
+			Addresses: []v1.NodeAddress{
+				{Type: v1.NodeHostName, Address: "node0"},
+				{Type: v1.NodeExternalIP, Address: "5.6.7.8"},
+				{Type: v1.NodeInternalIP, Address: "1.2.3.4"},
+			},
+		},
+	}
+
+	for _, s := range []struct {
+		types    []v1.NodeAddressType
+		expected string
+		err      bool
+	}{
+		{types: defaultNodeAddressTypes, expected: "1.2.3.4"},
+		{types: []v1.NodeAddressType{v1.NodeHostName, v1.NodeInternalIP}, expected: "node0"},
+		{types: []v1.NodeAddressType{v1.NodeInternalDNS, v1.NodeExternalIP}, expected: "5.6.7.8"},
+		{types: []v1.NodeAddressType{v1.NodeExternalDNS}, err: true},
+	} {
+		addr, addrMap, err := nodeAddress(node, s.types)
+		if s.err {
+			require.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, s.expected, addr)
+		require.Equal(t, []string{"5.6.7.8"}, addrMap[v1.NodeExternalIP])
+	}
+}
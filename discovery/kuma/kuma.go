@@ -0,0 +1,291 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kuma
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
+	"golang.org/x/net/context/ctxhttp"
+
+	"github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/util/httputil"
+	"github.com/prometheus/prometheus/util/strutil"
+)
+
+const (
+	kumaLabel          = model.MetaLabelPrefix + "kuma_"
+	kumaLabelMesh      = kumaLabel + "mesh"
+	kumaLabelService   = kumaLabel + "service"
+	kumaLabelDataplane = kumaLabel + "dataplane"
+	kumaLabelPrefix    = kumaLabel + "label_"
+
+	// The path of the REST endpoint of the Monitoring Assignment Discovery
+	// Service and the type of its resources.
+	discoveryPath  = "/v3/discovery:monitoringassignments"
+	assignmentType = "type.googleapis.com/kuma.observability.v1.MonitoringAssignment"
+)
+
+var (
+	refreshFailuresCount = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "prometheus_sd_kuma_refresh_failures_total",
+			Help: "The number of Kuma-SD refresh failures.",
+		})
+	refreshDuration = prometheus.NewSummary(
+		prometheus.SummaryOpts{
+			Name: "prometheus_sd_kuma_refresh_duration_seconds",
+			Help: "The duration of a Kuma-SD refresh in seconds.",
+		})
+)
+
+func init() {
+	prometheus.MustRegister(refreshFailuresCount)
+	prometheus.MustRegister(refreshDuration)
+}
+
+type node struct {
+	ID string `json:"id"`
+}
+
+// discoveryRequest is the xDS request acknowledging the last version of the
+// resources received, if any.
+type discoveryRequest struct {
+	VersionInfo   string `json:"version_info,omitempty"`
+	Node          node   `json:"node"`
+	TypeURL       string `json:"type_url"`
+	ResponseNonce string `json:"response_nonce,omitempty"`
+}
+
+type discoveryResponse struct {
+	VersionInfo string       `json:"version_info"`
+	Resources   []assignment `json:"resources"`
+	TypeURL     string       `json:"type_url"`
+	Nonce       string       `json:"nonce"`
+}
+
+// assignment is the set of targets of a service of a mesh.
+type assignment struct {
+	Type    string            `json:"@type"`
+	Mesh    string            `json:"mesh"`
+	Service string            `json:"service"`
+	Targets []target          `json:"targets"`
+	Labels  map[string]string `json:"labels"`
+}
+
+// target is a dataplane of the service of an assignment.
+type target struct {
+	Name        string            `json:"name"`
+	Scheme      string            `json:"scheme"`
+	Address     string            `json:"address"`
+	MetricsPath string            `json:"metrics_path"`
+	Labels      map[string]string `json:"labels"`
+}
+
+// Discovery fetches the monitoring assignments of a Kuma control plane. It
+// implements the TargetProvider interface.
+type Discovery struct {
+	client   *http.Client
+	server   string
+	clientID string
+	interval time.Duration
+	logger   log.Logger
+
+	// The version and nonce of the last resources received, sent back to the
+	// control plane which holds the next request until they change.
+	versionInfo string
+	nonce       string
+	// The sources of the target groups sent last.
+	lastSources map[string]struct{}
+}
+
+// NewDiscovery returns a new Kuma discovery which fetches the assignments of
+// the control plane.
+func NewDiscovery(conf *config.KumaSDConfig, logger log.Logger) (*Discovery, error) {
+	client, err := httputil.NewClientFromConfig(conf.HTTPClientConfig)
+	if err != nil {
+		return nil, err
+	}
+	client.Timeout = time.Duration(conf.FetchTimeout)
+
+	clientID := conf.ClientID
+	if clientID == "" {
+		if clientID, err = os.Hostname(); err != nil {
+			return nil, fmt.Errorf("could not get the hostname as client ID: %s", err)
+		}
+	}
+
+	return &Discovery{
+		client:   client,
+		server:   strings.TrimSuffix(conf.Server, "/"),
+		clientID: clientID,
+		interval: time.Duration(conf.RefreshInterval),
+		logger:   logger,
+	}, nil
+}
+
+// Run implements the TargetProvider interface.
+func (d *Discovery) Run(ctx context.Context, ch chan<- []*config.TargetGroup) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		tgs, err := d.refresh(ctx)
+		if err != nil {
+			d.logger.Errorf("Error refreshing Kuma targets: %s", err)
+		} else if tgs != nil {
+			select {
+			case ch <- tgs:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// refresh returns the target group of each assignment, and an empty one for
+// each assignment removed since the last refresh. It returns no target groups
+// if the assignments did not change.
+func (d *Discovery) refresh(ctx context.Context) (tgs []*config.TargetGroup, err error) {
+	t0 := time.Now()
+	defer func() {
+		refreshDuration.Observe(time.Since(t0).Seconds())
+		if err != nil {
+			refreshFailuresCount.Inc()
+		}
+	}()
+
+	assignments, err := d.fetch(ctx)
+	if err != nil || assignments == nil {
+		return nil, err
+	}
+
+	sources := make(map[string]struct{}, len(assignments))
+	for _, a := range assignments {
+		tg := assignmentTargetGroup(a)
+		sources[tg.Source] = struct{}{}
+		tgs = append(tgs, tg)
+	}
+	for source := range d.lastSources {
+		if _, ok := sources[source]; !ok {
+			tgs = append(tgs, &config.TargetGroup{Source: source})
+		}
+	}
+	d.lastSources = sources
+	return tgs, nil
+}
+
+// fetch returns the assignments of the control plane, or nil if they did not
+// change since the last fetch.
+func (d *Discovery) fetch(ctx context.Context) ([]assignment, error) {
+	b, err := json.Marshal(discoveryRequest{
+		VersionInfo:   d.versionInfo,
+		Node:          node{ID: d.clientID},
+		TypeURL:       assignmentType,
+		ResponseNonce: d.nonce,
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("POST", d.server+discoveryPath, bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := ctxhttp.Do(ctx, d.client, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, nil
+	}
+	if resp.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("discovery request failed with status %s", resp.Status)
+	}
+	b, err = ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var dr discoveryResponse
+	if err := json.Unmarshal(b, &dr); err != nil {
+		return nil, fmt.Errorf("could not decode discovery response: %s", err)
+	}
+	if dr.TypeURL != assignmentType {
+		return nil, fmt.Errorf("unexpected resource type %q", dr.TypeURL)
+	}
+
+	assignments := make([]assignment, 0, len(dr.Resources))
+	for _, a := range dr.Resources {
+		if a.Type != assignmentType {
+			return nil, fmt.Errorf("unexpected resource type %q", a.Type)
+		}
+		assignments = append(assignments, a)
+	}
+	d.versionInfo = dr.VersionInfo
+	d.nonce = dr.Nonce
+	return assignments, nil
+}
+
+// assignmentTargetGroup returns a target group for the dataplanes of the
+// assignment. The labels of a target override the ones of the assignment.
+func assignmentTargetGroup(a assignment) *config.TargetGroup {
+	tg := &config.TargetGroup{
+		Source: a.Mesh + "/" + a.Service,
+		Labels: model.LabelSet{
+			kumaLabelMesh:    model.LabelValue(a.Mesh),
+			kumaLabelService: model.LabelValue(a.Service),
+		},
+	}
+	for name, value := range a.Labels {
+		tg.Labels[model.LabelName(kumaLabelPrefix+strutil.SanitizeLabelName(name))] = model.LabelValue(value)
+	}
+
+	for _, t := range a.Targets {
+		labels := model.LabelSet{
+			model.AddressLabel: model.LabelValue(t.Address),
+			kumaLabelDataplane: model.LabelValue(t.Name),
+		}
+		if t.Scheme != "" {
+			labels[model.SchemeLabel] = model.LabelValue(t.Scheme)
+		}
+		if t.MetricsPath != "" {
+			labels[model.MetricsPathLabel] = model.LabelValue(t.MetricsPath)
+		}
+		for name, value := range t.Labels {
+			labels[model.LabelName(kumaLabelPrefix+strutil.SanitizeLabelName(name))] = model.LabelValue(value)
+		}
+		tg.Targets = append(tg.Targets, labels)
+	}
+	return tg
+}
@@ -0,0 +1,186 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kuma
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/log"
+	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
+
+	"github.com/prometheus/prometheus/config"
+)
+
+func TestRefresh(t *testing.T) {
+	responses := []string{
+		`{
+			"version_info": "1",
+			"nonce": "a",
+			"type_url": "type.googleapis.com/kuma.observability.v1.MonitoringAssignment",
+			"resources": [{
+				"@type": "type.googleapis.com/kuma.observability.v1.MonitoringAssignment",
+				"mesh": "default",
+				"service": "backend",
+				"labels": {"team": "payments", "k8s.io/zone": "eu-1"},
+				"targets": [{
+					"name": "backend-01",
+					"scheme": "http",
+					"address": "10.1.0.5:5670",
+					"metrics_path": "/metrics",
+					"labels": {"version": "v1"}
+				}]
+			}, {
+				"@type": "type.googleapis.com/kuma.observability.v1.MonitoringAssignment",
+				"mesh": "default",
+				"service": "frontend",
+				"targets": [{"name": "frontend-01", "address": "10.1.0.6:5670"}]
+			}]
+		}`,
+		"",
+		`{
+			"version_info": "2",
+			"nonce": "b",
+			"type_url": "type.googleapis.com/kuma.observability.v1.MonitoringAssignment",
+			"resources": [{
+				"@type": "type.googleapis.com/kuma.observability.v1.MonitoringAssignment",
+				"mesh": "default",
+				"service": "frontend",
+				"targets": [{"name": "frontend-01", "address": "10.1.0.6:5670"}]
+			}]
+		}`,
+	}
+	expectedRequests := []discoveryRequest{
+		{Node: node{ID: "prometheus-0"}, TypeURL: assignmentType},
+		{VersionInfo: "1", Node: node{ID: "prometheus-0"}, TypeURL: assignmentType, ResponseNonce: "a"},
+		{VersionInfo: "1", Node: node{ID: "prometheus-0"}, TypeURL: assignmentType, ResponseNonce: "a"},
+	}
+
+	n := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != discoveryPath {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		if n >= len(responses) {
+			t.Errorf("Unexpected request %d", n)
+			http.NotFound(w, r)
+			return
+		}
+		var req discoveryRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("Could not decode request: %s", err)
+		}
+		if !reflect.DeepEqual(req, expectedRequests[n]) {
+			t.Errorf("Expected request %d\n%v\ngot\n%v", n, expectedRequests[n], req)
+		}
+		resp := responses[n]
+		n++
+		if resp == "" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fmt.Fprint(w, resp)
+	}))
+	defer srv.Close()
+
+	d, err := NewDiscovery(&config.KumaSDConfig{
+		Server:          srv.URL + "/",
+		ClientID:        "prometheus-0",
+		RefreshInterval: model.Duration(time.Second),
+		FetchTimeout:    model.Duration(time.Second),
+	}, log.Base())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	frontend := &config.TargetGroup{
+		Source: "default/frontend",
+		Labels: model.LabelSet{
+			"__meta_kuma_mesh":    "default",
+			"__meta_kuma_service": "frontend",
+		},
+		Targets: []model.LabelSet{
+			{
+				"__address__":           "10.1.0.6:5670",
+				"__meta_kuma_dataplane": "frontend-01",
+			},
+		},
+	}
+	expected := [][]*config.TargetGroup{
+		{
+			{
+				Source: "default/backend",
+				Labels: model.LabelSet{
+					"__meta_kuma_mesh":              "default",
+					"__meta_kuma_service":           "backend",
+					"__meta_kuma_label_team":        "payments",
+					"__meta_kuma_label_k8s_io_zone": "eu-1",
+				},
+				Targets: []model.LabelSet{
+					{
+						"__address__":               "10.1.0.5:5670",
+						"__scheme__":                "http",
+						"__metrics_path__":          "/metrics",
+						"__meta_kuma_dataplane":     "backend-01",
+						"__meta_kuma_label_version": "v1",
+					},
+				},
+			},
+			frontend,
+		},
+		// The assignments did not change.
+		nil,
+		// The backend assignment was removed.
+		{frontend, {Source: "default/backend"}},
+	}
+	for i, e := range expected {
+		tgs, err := d.refresh(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(tgs, e) {
+			t.Errorf("%d: expected target groups\n%v\ngot\n%v", i, e, tgs)
+		}
+	}
+}
+
+func TestRefreshUnexpectedType(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"version_info": "1", "type_url": "type.googleapis.com/envoy.config.cluster.v3.Cluster"}`)
+	}))
+	defer srv.Close()
+
+	d, err := NewDiscovery(&config.KumaSDConfig{
+		Server:       srv.URL,
+		ClientID:     "prometheus-0",
+		FetchTimeout: model.Duration(time.Second),
+	}, log.Base())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.refresh(context.Background()); err == nil {
+		t.Fatal("Expected an error for resources of an unexpected type")
+	}
+	if d.versionInfo != "" {
+		t.Errorf("Expected the version of rejected resources to not be acknowledged, got %q", d.versionInfo)
+	}
+}
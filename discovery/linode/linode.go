@@ -0,0 +1,299 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linode
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
+	"golang.org/x/net/context/ctxhttp"
+
+	"github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/util/httputil"
+)
+
+const (
+	linodeLabel                   = model.MetaLabelPrefix + "linode_"
+	linodeLabelID                 = linodeLabel + "instance_id"
+	linodeLabelName               = linodeLabel + "instance_label"
+	linodeLabelImage              = linodeLabel + "image"
+	linodeLabelPrivateIPv4        = linodeLabel + "private_ipv4"
+	linodeLabelPublicIPv4         = linodeLabel + "public_ipv4"
+	linodeLabelPublicIPv6         = linodeLabel + "public_ipv6"
+	linodeLabelExtraIPs           = linodeLabel + "extra_ips"
+	linodeLabelRegion             = linodeLabel + "region"
+	linodeLabelType               = linodeLabel + "type"
+	linodeLabelStatus             = linodeLabel + "status"
+	linodeLabelTags               = linodeLabel + "tags"
+	linodeLabelGroup              = linodeLabel + "group"
+	linodeLabelHypervisor         = linodeLabel + "hypervisor"
+	linodeLabelBackups            = linodeLabel + "backups"
+	linodeLabelSpecsDiskBytes     = linodeLabel + "specs_disk_bytes"
+	linodeLabelSpecsMemoryBytes   = linodeLabel + "specs_memory_bytes"
+	linodeLabelSpecsVCPUs         = linodeLabel + "specs_vcpus"
+	linodeLabelSpecsTransferBytes = linodeLabel + "specs_transfer_bytes"
+
+	linodeEndpoint = "https://api.linode.com/v4"
+	// The maximum page size of the API.
+	linodePageSize = 500
+)
+
+// privateIPv4Net is the network the private IPv4 addresses of Linode
+// instances are allocated from.
+var privateIPv4Net = &net.IPNet{IP: net.IPv4(192, 168, 128, 0), Mask: net.CIDRMask(17, 32)}
+
+var (
+	refreshFailuresCount = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "prometheus_sd_linode_refresh_failures_total",
+			Help: "The number of Linode-SD refresh failures.",
+		})
+	refreshDuration = prometheus.NewSummary(
+		prometheus.SummaryOpts{
+			Name: "prometheus_sd_linode_refresh_duration_seconds",
+			Help: "The duration of a Linode-SD refresh in seconds.",
+		})
+	rateLimitedCount = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "prometheus_sd_linode_rate_limited_refreshes_total",
+			Help: "The number of Linode-SD refreshes skipped because the API rate limit was exceeded.",
+		})
+)
+
+func init() {
+	prometheus.MustRegister(refreshFailuresCount)
+	prometheus.MustRegister(refreshDuration)
+	prometheus.MustRegister(rateLimitedCount)
+}
+
+type linodeInstance struct {
+	ID         int      `json:"id"`
+	Label      string   `json:"label"`
+	Group      string   `json:"group"`
+	Status     string   `json:"status"`
+	Type       string   `json:"type"`
+	Region     string   `json:"region"`
+	Image      string   `json:"image"`
+	Hypervisor string   `json:"hypervisor"`
+	IPv4       []string `json:"ipv4"`
+	IPv6       string   `json:"ipv6"`
+	Tags       []string `json:"tags"`
+	Specs      struct {
+		Disk     int `json:"disk"`
+		Memory   int `json:"memory"`
+		VCPUs    int `json:"vcpus"`
+		Transfer int `json:"transfer"`
+	} `json:"specs"`
+	Backups struct {
+		Enabled bool `json:"enabled"`
+	} `json:"backups"`
+}
+
+// Discovery periodically discovers the instances of a Linode account with
+// the API v4. It implements the TargetProvider interface.
+type Discovery struct {
+	client       *http.Client
+	port         int
+	tagSeparator string
+	interval     time.Duration
+	logger       log.Logger
+
+	// The endpoint of the API.
+	endpoint  string
+	rateLimit *httputil.RateLimit
+
+	now func() time.Time
+}
+
+// NewDiscovery returns a new Linode discovery which periodically refreshes
+// its targets.
+func NewDiscovery(conf *config.LinodeSDConfig, logger log.Logger) (*Discovery, error) {
+	client, err := httputil.NewClientFromConfig(conf.HTTPClientConfig)
+	if err != nil {
+		return nil, err
+	}
+	client.Timeout = time.Duration(conf.RefreshInterval)
+
+	return &Discovery{
+		client:       client,
+		port:         conf.Port,
+		tagSeparator: conf.TagSeparator,
+		interval:     time.Duration(conf.RefreshInterval),
+		logger:       logger,
+		endpoint:     linodeEndpoint,
+		rateLimit:    httputil.NewRateLimit("X-RateLimit-Remaining", "X-RateLimit-Reset"),
+		now:          time.Now,
+	}, nil
+}
+
+// Run implements the TargetProvider interface.
+func (d *Discovery) Run(ctx context.Context, ch chan<- []*config.TargetGroup) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		if until, limited := d.rateLimit.Limited(d.now()); limited {
+			d.logger.Debugf("Skipping Linode refresh, API rate limit exceeded until %s", until)
+			rateLimitedCount.Inc()
+		} else if tg, err := d.refresh(ctx); err != nil {
+			d.logger.Errorf("Error refreshing Linode targets: %s", err)
+		} else {
+			select {
+			case ch <- []*config.TargetGroup{tg}:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (d *Discovery) refresh(ctx context.Context) (tg *config.TargetGroup, err error) {
+	t0 := time.Now()
+	defer func() {
+		refreshDuration.Observe(time.Since(t0).Seconds())
+		if err != nil {
+			refreshFailuresCount.Inc()
+		}
+	}()
+
+	var instances []linodeInstance
+	for page, pages := 1, 1; page <= pages; page++ {
+		var resp struct {
+			Data  []linodeInstance `json:"data"`
+			Page  int              `json:"page"`
+			Pages int              `json:"pages"`
+		}
+		if err := d.get(ctx, fmt.Sprintf("/linode/instances?page=%d&page_size=%d", page, linodePageSize), &resp); err != nil {
+			return nil, fmt.Errorf("could not list instances: %s", err)
+		}
+		instances = append(instances, resp.Data...)
+		pages = resp.Pages
+	}
+
+	tg = &config.TargetGroup{
+		Source: d.endpoint,
+	}
+	for _, inst := range instances {
+		var public, private string
+		var extra []string
+		for _, ip := range inst.IPv4 {
+			switch {
+			case privateIPv4Net.Contains(net.ParseIP(ip)) && private == "":
+				private = ip
+			case !privateIPv4Net.Contains(net.ParseIP(ip)) && public == "":
+				public = ip
+			default:
+				extra = append(extra, ip)
+			}
+		}
+		if public == "" {
+			continue
+		}
+
+		labels := model.LabelSet{
+			model.AddressLabel:            model.LabelValue(net.JoinHostPort(public, strconv.Itoa(d.port))),
+			linodeLabelID:                 model.LabelValue(strconv.Itoa(inst.ID)),
+			linodeLabelName:               model.LabelValue(inst.Label),
+			linodeLabelImage:              model.LabelValue(inst.Image),
+			linodeLabelPublicIPv4:         model.LabelValue(public),
+			linodeLabelRegion:             model.LabelValue(inst.Region),
+			linodeLabelType:               model.LabelValue(inst.Type),
+			linodeLabelStatus:             model.LabelValue(inst.Status),
+			linodeLabelGroup:              model.LabelValue(inst.Group),
+			linodeLabelHypervisor:         model.LabelValue(inst.Hypervisor),
+			linodeLabelBackups:            model.LabelValue(backupsStatus(inst.Backups.Enabled)),
+			linodeLabelSpecsDiskBytes:     model.LabelValue(strconv.FormatInt(int64(inst.Specs.Disk)<<20, 10)),
+			linodeLabelSpecsMemoryBytes:   model.LabelValue(strconv.FormatInt(int64(inst.Specs.Memory)<<20, 10)),
+			linodeLabelSpecsVCPUs:         model.LabelValue(strconv.Itoa(inst.Specs.VCPUs)),
+			linodeLabelSpecsTransferBytes: model.LabelValue(strconv.FormatInt(int64(inst.Specs.Transfer)<<20, 10)),
+		}
+		if private != "" {
+			labels[linodeLabelPrivateIPv4] = model.LabelValue(private)
+		}
+		if inst.IPv6 != "" {
+			// The address is returned with its prefix length.
+			labels[linodeLabelPublicIPv6] = model.LabelValue(strings.SplitN(inst.IPv6, "/", 2)[0])
+		}
+		if len(extra) > 0 {
+			labels[linodeLabelExtraIPs] = model.LabelValue(d.tagSeparator + strings.Join(extra, d.tagSeparator) + d.tagSeparator)
+		}
+		if len(inst.Tags) > 0 {
+			// We surround the separated list with the separator as well. This way regular expressions
+			// in relabeling rules don't have to consider tag positions.
+			labels[linodeLabelTags] = model.LabelValue(d.tagSeparator + strings.Join(inst.Tags, d.tagSeparator) + d.tagSeparator)
+		}
+		tg.Targets = append(tg.Targets, labels)
+	}
+	return tg, nil
+}
+
+// get decodes the JSON response to a GET request of the path of the API. If
+// the rate limit of the API is exhausted, further requests are held off until
+// it is reset.
+func (d *Discovery) get(ctx context.Context, path string, v interface{}) error {
+	if until, limited := d.rateLimit.Limited(d.now()); limited {
+		return fmt.Errorf("API rate limit exceeded until %s", until)
+	}
+	req, err := http.NewRequest("GET", d.endpoint+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := ctxhttp.Do(ctx, d.client, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	d.rateLimit.Update(resp, d.now())
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		until, _ := d.rateLimit.Limited(d.now())
+		return fmt.Errorf("API rate limit exceeded until %s", until)
+	}
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("request to %s failed with status %s", path, resp.Status)
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("could not decode response to %s: %s", path, err)
+	}
+	return nil
+}
+
+func backupsStatus(enabled bool) string {
+	if enabled {
+		return "enabled"
+	}
+	return "disabled"
+}
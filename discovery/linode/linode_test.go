@@ -0,0 +1,205 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linode
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/log"
+	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
+
+	"github.com/prometheus/prometheus/config"
+)
+
+func newTestDiscovery(t *testing.T, url string) *Discovery {
+	d, err := NewDiscovery(&config.LinodeSDConfig{
+		Port:         9100,
+		TagSeparator: ",",
+		HTTPClientConfig: config.HTTPClientConfig{
+			BearerToken: "token",
+		},
+	}, log.Base())
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.endpoint = url
+	return d
+}
+
+func TestRefresh(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer token" {
+			t.Errorf("Expected bearer token authorization, got %q", auth)
+		}
+		switch r.URL.RequestURI() {
+		case "/linode/instances?page=1&page_size=500":
+			fmt.Fprint(w, `{
+				"data": [{
+					"id": 26838044,
+					"label": "prometheus-linode-sd-exporter-1",
+					"group": "",
+					"status": "running",
+					"type": "g6-standard-2",
+					"region": "us-east",
+					"image": "linode/arch",
+					"hypervisor": "kvm",
+					"ipv4": ["45.33.82.151", "192.168.170.51", "96.126.108.200"],
+					"ipv6": "2600:3c03::f03c:92ff:fe1a:1382/128",
+					"tags": ["monitoring", "web"],
+					"specs": {"disk": 81920, "memory": 4096, "vcpus": 2, "transfer": 4000},
+					"backups": {"enabled": false}
+				}],
+				"page": 1,
+				"pages": 2,
+				"results": 2
+			}`)
+		case "/linode/instances?page=2&page_size=500":
+			fmt.Fprint(w, `{
+				"data": [{
+					"id": 26837992,
+					"label": "private-only",
+					"status": "running",
+					"ipv4": ["192.168.200.3"]
+				}, {
+					"id": 26837938,
+					"label": "prometheus-linode-sd-exporter-2",
+					"group": "web",
+					"status": "offline",
+					"type": "g6-nanode-1",
+					"region": "ca-central",
+					"image": "linode/ubuntu20.04",
+					"hypervisor": "kvm",
+					"ipv4": ["172.104.18.104"],
+					"ipv6": "2600:3c04::f03c:92ff:fe1a:fb68/128",
+					"specs": {"disk": 25600, "memory": 1024, "vcpus": 1, "transfer": 1000},
+					"backups": {"enabled": true}
+				}],
+				"page": 2,
+				"pages": 2,
+				"results": 3
+			}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	d := newTestDiscovery(t, srv.URL)
+	tg, err := d.refresh(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &config.TargetGroup{
+		Source: srv.URL,
+		Targets: []model.LabelSet{
+			{
+				"__address__":                        "45.33.82.151:9100",
+				"__meta_linode_instance_id":          "26838044",
+				"__meta_linode_instance_label":       "prometheus-linode-sd-exporter-1",
+				"__meta_linode_image":                "linode/arch",
+				"__meta_linode_private_ipv4":         "192.168.170.51",
+				"__meta_linode_public_ipv4":          "45.33.82.151",
+				"__meta_linode_public_ipv6":          "2600:3c03::f03c:92ff:fe1a:1382",
+				"__meta_linode_extra_ips":            ",96.126.108.200,",
+				"__meta_linode_region":               "us-east",
+				"__meta_linode_type":                 "g6-standard-2",
+				"__meta_linode_status":               "running",
+				"__meta_linode_tags":                 ",monitoring,web,",
+				"__meta_linode_group":                "",
+				"__meta_linode_hypervisor":           "kvm",
+				"__meta_linode_backups":              "disabled",
+				"__meta_linode_specs_disk_bytes":     "85899345920",
+				"__meta_linode_specs_memory_bytes":   "4294967296",
+				"__meta_linode_specs_vcpus":          "2",
+				"__meta_linode_specs_transfer_bytes": "4194304000",
+			},
+			{
+				"__address__":                        "172.104.18.104:9100",
+				"__meta_linode_instance_id":          "26837938",
+				"__meta_linode_instance_label":       "prometheus-linode-sd-exporter-2",
+				"__meta_linode_image":                "linode/ubuntu20.04",
+				"__meta_linode_public_ipv4":          "172.104.18.104",
+				"__meta_linode_public_ipv6":          "2600:3c04::f03c:92ff:fe1a:fb68",
+				"__meta_linode_region":               "ca-central",
+				"__meta_linode_type":                 "g6-nanode-1",
+				"__meta_linode_status":               "offline",
+				"__meta_linode_group":                "web",
+				"__meta_linode_hypervisor":           "kvm",
+				"__meta_linode_backups":              "enabled",
+				"__meta_linode_specs_disk_bytes":     "26843545600",
+				"__meta_linode_specs_memory_bytes":   "1073741824",
+				"__meta_linode_specs_vcpus":          "1",
+				"__meta_linode_specs_transfer_bytes": "1048576000",
+			},
+		},
+	}
+	if !reflect.DeepEqual(tg, expected) {
+		t.Errorf("Expected target group\n%v\ngot\n%v", expected, tg)
+	}
+}
+
+func TestRefreshRateLimited(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch requests {
+		case 1:
+			// The last request of the current window.
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", "30")
+			fmt.Fprint(w, `{"data": [], "page": 1, "pages": 1}`)
+		default:
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(w, `{"errors": [{"reason": "Too many requests"}]}`)
+		}
+	}))
+	defer srv.Close()
+
+	d := newTestDiscovery(t, srv.URL)
+	now := time.Unix(0, 0)
+	d.now = func() time.Time { return now }
+
+	if _, err := d.refresh(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if until, _ := d.rateLimit.Limited(now); !until.Equal(time.Unix(30, 0)) {
+		t.Fatalf("Expected requests to be held off until the reset, got %s", until)
+	}
+
+	// No request is sent until the reset.
+	if _, err := d.refresh(context.Background()); err == nil {
+		t.Fatal("Expected an error while rate limited")
+	}
+	if requests != 1 {
+		t.Fatalf("Expected no request while rate limited, got %d requests", requests)
+	}
+
+	// A request rejected because of the rate limit holds off requests for
+	// the time given by the API.
+	now = time.Unix(30, 0)
+	if _, err := d.refresh(context.Background()); err == nil {
+		t.Fatal("Expected an error when exceeding the rate limit")
+	}
+	if until, _ := d.rateLimit.Limited(now); !until.Equal(time.Unix(90, 0)) {
+		t.Fatalf("Expected requests to be held off for the Retry-After duration, got %s", until)
+	}
+}
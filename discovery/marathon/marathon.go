@@ -21,7 +21,6 @@ import (
 	"net"
 	"net/http"
 	"strconv"
-	"strings"
 	"time"
 
 	"golang.org/x/net/context"
@@ -89,6 +88,7 @@ type Discovery struct {
 	lastRefresh     map[string]*config.TargetGroup
 	appsClient      AppListClient
 	token           string
+	tokenFile       string
 	logger          log.Logger
 }
 
@@ -99,15 +99,6 @@ func NewDiscovery(conf *config.MarathonSDConfig, logger log.Logger) (*Discovery,
 		return nil, err
 	}
 
-	token := string(conf.BearerToken)
-	if conf.BearerTokenFile != "" {
-		bf, err := ioutil.ReadFile(conf.BearerTokenFile)
-		if err != nil {
-			return nil, err
-		}
-		token = strings.TrimSpace(string(bf))
-	}
-
 	client := &http.Client{
 		Timeout: time.Duration(conf.Timeout),
 		Transport: &http.Transport{
@@ -120,7 +111,8 @@ func NewDiscovery(conf *config.MarathonSDConfig, logger log.Logger) (*Discovery,
 		servers:         conf.Servers,
 		refreshInterval: time.Duration(conf.RefreshInterval),
 		appsClient:      fetchApps,
-		token:           token,
+		token:           string(conf.BearerToken),
+		tokenFile:       conf.BearerTokenFile,
 		logger:          logger,
 	}, nil
 }
@@ -183,8 +175,17 @@ func (d *Discovery) updateServices(ctx context.Context, ch chan<- []*config.Targ
 }
 
 func (d *Discovery) fetchTargetGroups() (map[string]*config.TargetGroup, error) {
+	// The token file is read on each refresh, so that it can be rotated.
+	token := d.token
+	if d.tokenFile != "" {
+		var err error
+		if token, err = config.ReadSecretFile(d.tokenFile); err != nil {
+			return nil, fmt.Errorf("unable to read bearer token file %s: %s", d.tokenFile, err)
+		}
+	}
+
 	url := RandomAppsURL(d.servers)
-	apps, err := d.appsClient(d.client, url, d.token)
+	apps, err := d.appsClient(d.client, url, token)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,302 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nomad
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
+	"golang.org/x/net/context/ctxhttp"
+
+	"github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/util/httputil"
+)
+
+const (
+	nomadLabel               = model.MetaLabelPrefix + "nomad_"
+	nomadLabelService        = nomadLabel + "service"
+	nomadLabelServiceID      = nomadLabel + "service_id"
+	nomadLabelServiceAddress = nomadLabel + "service_address"
+	nomadLabelServicePort    = nomadLabel + "service_port"
+	nomadLabelNamespace      = nomadLabel + "namespace"
+	nomadLabelDatacenter     = nomadLabel + "dc"
+	nomadLabelNodeID         = nomadLabel + "node_id"
+	nomadLabelJob            = nomadLabel + "job"
+	nomadLabelAllocID        = nomadLabel + "alloc_id"
+	nomadLabelTags           = nomadLabel + "tags"
+	nomadLabelGroup          = nomadLabel + "group"
+	nomadLabelTask           = nomadLabel + "task"
+	nomadLabelNodeName       = nomadLabel + "node_name"
+	nomadLabelAllocStatus    = nomadLabel + "alloc_status"
+)
+
+var (
+	refreshFailuresCount = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "prometheus_sd_nomad_refresh_failures_total",
+			Help: "The number of Nomad-SD refresh failures.",
+		})
+	refreshDuration = prometheus.NewSummary(
+		prometheus.SummaryOpts{
+			Name: "prometheus_sd_nomad_refresh_duration_seconds",
+			Help: "The duration of a Nomad-SD refresh in seconds.",
+		})
+)
+
+func init() {
+	prometheus.MustRegister(refreshFailuresCount)
+	prometheus.MustRegister(refreshDuration)
+}
+
+type serviceStubs struct {
+	Namespace string `json:"Namespace"`
+	Services  []struct {
+		ServiceName string `json:"ServiceName"`
+	} `json:"Services"`
+}
+
+type serviceRegistration struct {
+	ID          string   `json:"ID"`
+	ServiceName string   `json:"ServiceName"`
+	Namespace   string   `json:"Namespace"`
+	NodeID      string   `json:"NodeID"`
+	Datacenter  string   `json:"Datacenter"`
+	JobID       string   `json:"JobID"`
+	AllocID     string   `json:"AllocID"`
+	Tags        []string `json:"Tags"`
+	Address     string   `json:"Address"`
+	Port        int      `json:"Port"`
+}
+
+type service struct {
+	Name string `json:"Name"`
+}
+
+type allocation struct {
+	ID           string `json:"ID"`
+	TaskGroup    string `json:"TaskGroup"`
+	NodeName     string `json:"NodeName"`
+	ClientStatus string `json:"ClientStatus"`
+	Job          struct {
+		TaskGroups []struct {
+			Name  string `json:"Name"`
+			Tasks []struct {
+				Name     string    `json:"Name"`
+				Services []service `json:"Services"`
+			} `json:"Tasks"`
+		} `json:"TaskGroups"`
+	} `json:"Job"`
+}
+
+// task returns the name of the task of the allocation registering the
+// service, or an empty string if the service is registered by its group.
+func (a *allocation) task(serviceName string) string {
+	for _, tg := range a.Job.TaskGroups {
+		if tg.Name != a.TaskGroup {
+			continue
+		}
+		for _, t := range tg.Tasks {
+			for _, s := range t.Services {
+				if s.Name == serviceName {
+					return t.Name
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// Discovery periodically discovers the services registered in the Nomad
+// service catalog. It implements the TargetProvider interface.
+type Discovery struct {
+	client             *http.Client
+	server             string
+	namespace          string
+	region             string
+	allowStale         bool
+	tagSeparator       string
+	includeAllocations bool
+	interval           time.Duration
+	logger             log.Logger
+}
+
+// NewDiscovery returns a new Nomad discovery which periodically refreshes its
+// targets.
+func NewDiscovery(conf *config.NomadSDConfig, logger log.Logger) (*Discovery, error) {
+	client, err := httputil.NewClientFromConfig(conf.HTTPClientConfig)
+	if err != nil {
+		return nil, err
+	}
+	client.Timeout = time.Duration(conf.RefreshInterval)
+
+	return &Discovery{
+		client:             client,
+		server:             strings.TrimSuffix(conf.Server, "/"),
+		namespace:          conf.Namespace,
+		region:             conf.Region,
+		allowStale:         conf.AllowStale,
+		tagSeparator:       conf.TagSeparator,
+		includeAllocations: conf.IncludeAllocations,
+		interval:           time.Duration(conf.RefreshInterval),
+		logger:             logger,
+	}, nil
+}
+
+// Run implements the TargetProvider interface.
+func (d *Discovery) Run(ctx context.Context, ch chan<- []*config.TargetGroup) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		tg, err := d.refresh(ctx)
+		if err != nil {
+			d.logger.Errorf("Error refreshing Nomad targets: %s", err)
+		} else {
+			select {
+			case ch <- []*config.TargetGroup{tg}:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (d *Discovery) refresh(ctx context.Context) (tg *config.TargetGroup, err error) {
+	t0 := time.Now()
+	defer func() {
+		refreshDuration.Observe(time.Since(t0).Seconds())
+		if err != nil {
+			refreshFailuresCount.Inc()
+		}
+	}()
+
+	var stubs []serviceStubs
+	if err := d.get(ctx, "/v1/services", d.namespace, &stubs); err != nil {
+		return nil, fmt.Errorf("could not list services: %s", err)
+	}
+
+	tg = &config.TargetGroup{
+		Source: d.server + "/" + d.namespace,
+	}
+	// The allocations are looked up once even if they register several
+	// services.
+	allocs := map[string]*allocation{}
+	for _, stub := range stubs {
+		for _, s := range stub.Services {
+			var regs []serviceRegistration
+			if err := d.get(ctx, "/v1/service/"+url.PathEscape(s.ServiceName), stub.Namespace, &regs); err != nil {
+				return nil, fmt.Errorf("could not get service %q: %s", s.ServiceName, err)
+			}
+			for _, reg := range regs {
+				labels := d.serviceLabels(reg)
+				if d.includeAllocations {
+					alloc, ok := allocs[reg.AllocID]
+					if !ok {
+						alloc = &allocation{}
+						if err := d.get(ctx, "/v1/allocation/"+url.PathEscape(reg.AllocID), reg.Namespace, alloc); err != nil {
+							return nil, fmt.Errorf("could not get allocation %q: %s", reg.AllocID, err)
+						}
+						allocs[reg.AllocID] = alloc
+					}
+					labels[nomadLabelGroup] = model.LabelValue(alloc.TaskGroup)
+					labels[nomadLabelNodeName] = model.LabelValue(alloc.NodeName)
+					labels[nomadLabelAllocStatus] = model.LabelValue(alloc.ClientStatus)
+					if task := alloc.task(reg.ServiceName); task != "" {
+						labels[nomadLabelTask] = model.LabelValue(task)
+					}
+				}
+				tg.Targets = append(tg.Targets, labels)
+			}
+		}
+	}
+	return tg, nil
+}
+
+func (d *Discovery) serviceLabels(reg serviceRegistration) model.LabelSet {
+	labels := model.LabelSet{
+		model.AddressLabel:       model.LabelValue(net.JoinHostPort(reg.Address, strconv.Itoa(reg.Port))),
+		nomadLabelService:        model.LabelValue(reg.ServiceName),
+		nomadLabelServiceID:      model.LabelValue(reg.ID),
+		nomadLabelServiceAddress: model.LabelValue(reg.Address),
+		nomadLabelServicePort:    model.LabelValue(strconv.Itoa(reg.Port)),
+		nomadLabelNamespace:      model.LabelValue(reg.Namespace),
+		nomadLabelDatacenter:     model.LabelValue(reg.Datacenter),
+		nomadLabelNodeID:         model.LabelValue(reg.NodeID),
+		nomadLabelJob:            model.LabelValue(reg.JobID),
+		nomadLabelAllocID:        model.LabelValue(reg.AllocID),
+	}
+	if len(reg.Tags) > 0 {
+		// We surround the separated list with the separator as well. This way regular expressions
+		// in relabeling rules don't have to consider tag positions.
+		labels[nomadLabelTags] = model.LabelValue(d.tagSeparator + strings.Join(reg.Tags, d.tagSeparator) + d.tagSeparator)
+	}
+	return labels
+}
+
+// get decodes the JSON response to a GET request of the path of the API in
+// the namespace.
+func (d *Discovery) get(ctx context.Context, path, namespace string, v interface{}) error {
+	params := url.Values{}
+	if namespace != "" {
+		params.Set("namespace", namespace)
+	}
+	if d.region != "" {
+		params.Set("region", d.region)
+	}
+	if d.allowStale {
+		params.Set("stale", "")
+	}
+	u := d.server + path
+	if len(params) > 0 {
+		u += "?" + params.Encode()
+	}
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := ctxhttp.Do(ctx, d.client, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("request to %s failed with status %s", path, resp.Status)
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("could not decode response to %s: %s", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nomad
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/prometheus/common/log"
+	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
+
+	"github.com/prometheus/prometheus/config"
+)
+
+func newTestServer(t *testing.T) (*httptest.Server, *int) {
+	allocRequests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer token" {
+			t.Errorf("Expected the ACL token as bearer token, got %q", auth)
+		}
+		q := r.URL.Query()
+		if ns := q.Get("namespace"); ns != "monitoring" {
+			t.Errorf("Expected the monitoring namespace to be queried, got %q", ns)
+		}
+		if region := q.Get("region"); region != "eu-west" {
+			t.Errorf("Expected the eu-west region to be queried, got %q", region)
+		}
+		if _, ok := q["stale"]; !ok {
+			t.Errorf("Expected stale queries to be allowed")
+		}
+		switch r.URL.Path {
+		case "/v1/services":
+			fmt.Fprint(w, `[{"Namespace": "monitoring", "Services": [{"ServiceName": "node-exporter", "Tags": ["metrics"]}]}]`)
+		case "/v1/service/node-exporter":
+			fmt.Fprint(w, `[{
+				"ID": "_nomad-task-2a4ba375-node-exporter-exporter-metrics",
+				"ServiceName": "node-exporter",
+				"Namespace": "monitoring",
+				"NodeID": "7d4f2b1a-7d67-4d5d-9a26-1e7a8c0c7b2e",
+				"Datacenter": "dc1",
+				"JobID": "node-exporter",
+				"AllocID": "2a4ba375-2e17-4a9c-b0e7-2ec39d8ef7a5",
+				"Tags": ["metrics", "system"],
+				"Address": "10.0.0.12",
+				"Port": 9100
+			}, {
+				"ID": "_nomad-task-2a4ba375-node-exporter-exporter-tls",
+				"ServiceName": "node-exporter",
+				"Namespace": "monitoring",
+				"NodeID": "7d4f2b1a-7d67-4d5d-9a26-1e7a8c0c7b2e",
+				"Datacenter": "dc1",
+				"JobID": "node-exporter",
+				"AllocID": "2a4ba375-2e17-4a9c-b0e7-2ec39d8ef7a5",
+				"Tags": [],
+				"Address": "10.0.0.12",
+				"Port": 9443
+			}]`)
+		case "/v1/allocation/2a4ba375-2e17-4a9c-b0e7-2ec39d8ef7a5":
+			allocRequests++
+			fmt.Fprint(w, `{
+				"ID": "2a4ba375-2e17-4a9c-b0e7-2ec39d8ef7a5",
+				"TaskGroup": "exporter",
+				"NodeName": "worker-1",
+				"ClientStatus": "running",
+				"Job": {"TaskGroups": [
+					{"Name": "other", "Tasks": [{"Name": "other", "Services": [{"Name": "node-exporter"}]}]},
+					{"Name": "exporter", "Tasks": [{"Name": "node-exporter", "Services": [{"Name": "node-exporter"}]}]}
+				]}
+			}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	return srv, &allocRequests
+}
+
+func TestRefresh(t *testing.T) {
+	srv, allocRequests := newTestServer(t)
+	defer srv.Close()
+
+	for _, includeAllocations := range []bool{false, true} {
+		d, err := NewDiscovery(&config.NomadSDConfig{
+			Server:             srv.URL,
+			Namespace:          "monitoring",
+			Region:             "eu-west",
+			AllowStale:         true,
+			TagSeparator:       ",",
+			IncludeAllocations: includeAllocations,
+			HTTPClientConfig: config.HTTPClientConfig{
+				BearerToken: "token",
+			},
+		}, log.Base())
+		if err != nil {
+			t.Fatal(err)
+		}
+		tg, err := d.refresh(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		targets := []model.LabelSet{
+			{
+				"__address__":                  "10.0.0.12:9100",
+				"__meta_nomad_service":         "node-exporter",
+				"__meta_nomad_service_id":      "_nomad-task-2a4ba375-node-exporter-exporter-metrics",
+				"__meta_nomad_service_address": "10.0.0.12",
+				"__meta_nomad_service_port":    "9100",
+				"__meta_nomad_namespace":       "monitoring",
+				"__meta_nomad_dc":              "dc1",
+				"__meta_nomad_node_id":         "7d4f2b1a-7d67-4d5d-9a26-1e7a8c0c7b2e",
+				"__meta_nomad_job":             "node-exporter",
+				"__meta_nomad_alloc_id":        "2a4ba375-2e17-4a9c-b0e7-2ec39d8ef7a5",
+				"__meta_nomad_tags":            ",metrics,system,",
+			},
+			{
+				"__address__":                  "10.0.0.12:9443",
+				"__meta_nomad_service":         "node-exporter",
+				"__meta_nomad_service_id":      "_nomad-task-2a4ba375-node-exporter-exporter-tls",
+				"__meta_nomad_service_address": "10.0.0.12",
+				"__meta_nomad_service_port":    "9443",
+				"__meta_nomad_namespace":       "monitoring",
+				"__meta_nomad_dc":              "dc1",
+				"__meta_nomad_node_id":         "7d4f2b1a-7d67-4d5d-9a26-1e7a8c0c7b2e",
+				"__meta_nomad_job":             "node-exporter",
+				"__meta_nomad_alloc_id":        "2a4ba375-2e17-4a9c-b0e7-2ec39d8ef7a5",
+			},
+		}
+		if includeAllocations {
+			for i := range targets {
+				targets[i] = targets[i].Merge(model.LabelSet{
+					"__meta_nomad_group":        "exporter",
+					"__meta_nomad_task":         "node-exporter",
+					"__meta_nomad_node_name":    "worker-1",
+					"__meta_nomad_alloc_status": "running",
+				})
+			}
+		}
+		expected := &config.TargetGroup{
+			Source:  srv.URL + "/monitoring",
+			Targets: targets,
+		}
+		if !reflect.DeepEqual(tg, expected) {
+			t.Errorf("Expected target group\n%v\ngot\n%v", expected, tg)
+		}
+	}
+	if *allocRequests != 1 {
+		t.Errorf("Expected the allocation to be looked up once, got %d requests", *allocRequests)
+	}
+}
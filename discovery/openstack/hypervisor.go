@@ -39,11 +39,12 @@ const (
 
 // HypervisorDiscovery discovers OpenStack hypervisors.
 type HypervisorDiscovery struct {
-	authOpts *gophercloud.AuthOptions
-	region   string
-	interval time.Duration
-	logger   log.Logger
-	port     int
+	authOpts     *gophercloud.AuthOptions
+	passwordFile string
+	region       string
+	interval     time.Duration
+	logger       log.Logger
+	port         int
 }
 
 // NewHypervisorDiscovery returns a new hypervisor discovery.
@@ -100,7 +101,7 @@ func (h *HypervisorDiscovery) refresh() (*config.TargetGroup, error) {
 		}
 	}()
 
-	provider, err := openstack.AuthenticatedClient(*h.authOpts)
+	provider, err := authenticate(*h.authOpts, h.passwordFile)
 	if err != nil {
 		return nil, fmt.Errorf("could not create OpenStack session: %s", err)
 	}
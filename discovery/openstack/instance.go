@@ -44,11 +44,12 @@ const (
 
 // InstanceDiscovery discovers OpenStack instances.
 type InstanceDiscovery struct {
-	authOpts *gophercloud.AuthOptions
-	region   string
-	interval time.Duration
-	logger   log.Logger
-	port     int
+	authOpts     *gophercloud.AuthOptions
+	passwordFile string
+	region       string
+	interval     time.Duration
+	logger       log.Logger
+	port         int
 }
 
 // NewInstanceDiscovery returns a new instance discovery.
@@ -105,7 +106,7 @@ func (i *InstanceDiscovery) refresh() (*config.TargetGroup, error) {
 		}
 	}()
 
-	provider, err := openstack.AuthenticatedClient(*i.authOpts)
+	provider, err := authenticate(*i.authOpts, i.passwordFile)
 	if err != nil {
 		return nil, fmt.Errorf("could not create OpenStack session: %s", err)
 	}
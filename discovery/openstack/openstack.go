@@ -15,9 +15,11 @@ package openstack
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/common/log"
 	"golang.org/x/net/context"
@@ -66,12 +68,27 @@ func NewDiscovery(conf *config.OpenstackSDConfig, l log.Logger) (Discovery, erro
 	case config.OpenStackRoleHypervisor:
 		hypervisor := NewHypervisorDiscovery(&opts,
 			time.Duration(conf.RefreshInterval), conf.Port, conf.Region, l)
+		hypervisor.passwordFile = conf.PasswordFile
 		return hypervisor, nil
 	case config.OpenStackRoleInstance:
 		instance := NewInstanceDiscovery(&opts,
 			time.Duration(conf.RefreshInterval), conf.Port, conf.Region, l)
+		instance.passwordFile = conf.PasswordFile
 		return instance, nil
 	default:
 		return nil, errors.New("unknown OpenStack discovery role")
 	}
 }
+
+// authenticate creates an authenticated OpenStack session. The password file,
+// if any, is read on each call, so that the password can be rotated.
+func authenticate(opts gophercloud.AuthOptions, passwordFile string) (*gophercloud.ProviderClient, error) {
+	if passwordFile != "" {
+		password, err := config.ReadSecretFile(passwordFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read password file %s: %s", passwordFile, err)
+		}
+		opts.Password = password
+	}
+	return openstack.AuthenticatedClient(opts)
+}
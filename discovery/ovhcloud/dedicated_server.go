@@ -0,0 +1,109 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovhcloud
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
+)
+
+const (
+	dedicatedServerLabel                = ovhcloudLabel + "dedicated_server_"
+	dedicatedServerLabelServerID        = dedicatedServerLabel + "server_id"
+	dedicatedServerLabelName            = dedicatedServerLabel + "name"
+	dedicatedServerLabelReverse         = dedicatedServerLabel + "reverse"
+	dedicatedServerLabelCommercialRange = dedicatedServerLabel + "commercial_range"
+	dedicatedServerLabelDatacenter      = dedicatedServerLabel + "datacenter"
+	dedicatedServerLabelRack            = dedicatedServerLabel + "rack"
+	dedicatedServerLabelOS              = dedicatedServerLabel + "os"
+	dedicatedServerLabelState           = dedicatedServerLabel + "state"
+	dedicatedServerLabelSupportLevel    = dedicatedServerLabel + "support_level"
+	dedicatedServerLabelLinkSpeed       = dedicatedServerLabel + "link_speed"
+	dedicatedServerLabelNoIntervention  = dedicatedServerLabel + "no_intervention"
+	dedicatedServerLabelIPv4            = dedicatedServerLabel + "ipv4"
+	dedicatedServerLabelIPv6            = dedicatedServerLabel + "ipv6"
+)
+
+type dedicatedServer struct {
+	ServerID        int    `json:"serverId"`
+	Name            string `json:"name"`
+	Reverse         string `json:"reverse"`
+	CommercialRange string `json:"commercialRange"`
+	Datacenter      string `json:"datacenter"`
+	Rack            string `json:"rack"`
+	OS              string `json:"os"`
+	State           string `json:"state"`
+	SupportLevel    string `json:"supportLevel"`
+	LinkSpeed       int    `json:"linkSpeed"`
+	NoIntervention  bool   `json:"noIntervention"`
+	// The main IPv4 address of the server.
+	IP string `json:"ip"`
+}
+
+// dedicatedServerTargets returns a target for each dedicated server of the
+// account with an IP address.
+func (d *Discovery) dedicatedServerTargets(ctx context.Context) ([]model.LabelSet, error) {
+	var names []string
+	if err := d.get(ctx, "/dedicated/server", &names); err != nil {
+		return nil, fmt.Errorf("could not list dedicated servers: %s", err)
+	}
+
+	var targets []model.LabelSet
+	for _, name := range names {
+		path := "/dedicated/server/" + url.PathEscape(name)
+		var s dedicatedServer
+		if err := d.get(ctx, path, &s); err != nil {
+			return nil, fmt.Errorf("could not get dedicated server %q: %s", name, err)
+		}
+		var ips []string
+		if err := d.get(ctx, path+"/ips", &ips); err != nil {
+			return nil, fmt.Errorf("could not get the IPs of dedicated server %q: %s", name, err)
+		}
+		ipv4, ipv6 := splitIPs(ips)
+		if s.IP != "" {
+			ipv4 = s.IP
+		}
+		addr := d.address(ipv4, ipv6)
+		if addr == "" {
+			continue
+		}
+
+		labels := model.LabelSet{
+			model.AddressLabel:                  model.LabelValue(addr),
+			dedicatedServerLabelServerID:        model.LabelValue(strconv.Itoa(s.ServerID)),
+			dedicatedServerLabelName:            model.LabelValue(s.Name),
+			dedicatedServerLabelReverse:         model.LabelValue(s.Reverse),
+			dedicatedServerLabelCommercialRange: model.LabelValue(s.CommercialRange),
+			dedicatedServerLabelDatacenter:      model.LabelValue(s.Datacenter),
+			dedicatedServerLabelRack:            model.LabelValue(s.Rack),
+			dedicatedServerLabelOS:              model.LabelValue(s.OS),
+			dedicatedServerLabelState:           model.LabelValue(s.State),
+			dedicatedServerLabelSupportLevel:    model.LabelValue(s.SupportLevel),
+			dedicatedServerLabelLinkSpeed:       model.LabelValue(strconv.Itoa(s.LinkSpeed)),
+			dedicatedServerLabelNoIntervention:  model.LabelValue(strconv.FormatBool(s.NoIntervention)),
+		}
+		if ipv4 != "" {
+			labels[dedicatedServerLabelIPv4] = model.LabelValue(ipv4)
+		}
+		if ipv6 != "" {
+			labels[dedicatedServerLabelIPv6] = model.LabelValue(ipv6)
+		}
+		targets = append(targets, labels)
+	}
+	return targets, nil
+}
@@ -0,0 +1,284 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovhcloud
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
+	"golang.org/x/net/context/ctxhttp"
+
+	"github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/util/httputil"
+)
+
+const ovhcloudLabel = model.MetaLabelPrefix + "ovhcloud_"
+
+// endpoints are the URLs of the APIs of the OVHcloud regions.
+var endpoints = map[string]string{
+	"ovh-eu": "https://eu.api.ovh.com/1.0",
+	"ovh-ca": "https://ca.api.ovh.com/1.0",
+	"ovh-us": "https://api.us.ovhcloud.com/1.0",
+}
+
+var (
+	refreshFailuresCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "prometheus_sd_ovhcloud_refresh_failures_total",
+			Help: "The number of OVHcloud-SD refresh failures.",
+		},
+		[]string{"service"},
+	)
+	refreshDuration = prometheus.NewSummaryVec(
+		prometheus.SummaryOpts{
+			Name: "prometheus_sd_ovhcloud_refresh_duration_seconds",
+			Help: "The duration of an OVHcloud-SD refresh in seconds.",
+		},
+		[]string{"service"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(refreshFailuresCount)
+	prometheus.MustRegister(refreshDuration)
+}
+
+// Discovery periodically discovers the VPS or dedicated servers of an
+// OVHcloud account. It implements the TargetProvider interface.
+type Discovery struct {
+	client            *http.Client
+	endpoint          string
+	applicationKey    string
+	applicationSecret string
+	consumerKey       string
+	service           config.OVHcloudService
+	port              int
+	interval          time.Duration
+	logger            log.Logger
+
+	// The API rejects requests beyond its rate limit, which are retried after
+	// a backoff like failed ones.
+	backoff httputil.Backoff
+	// The difference between the clock of the API and the local one, which
+	// requests are signed with.
+	timeDelta time.Duration
+}
+
+// NewDiscovery returns a new OVHcloud discovery which periodically refreshes
+// its targets.
+func NewDiscovery(conf *config.OVHcloudSDConfig, logger log.Logger) (*Discovery, error) {
+	endpoint, ok := endpoints[conf.Endpoint]
+	if !ok {
+		if !strings.HasPrefix(conf.Endpoint, "https://") && !strings.HasPrefix(conf.Endpoint, "http://") {
+			return nil, fmt.Errorf("unknown OVHcloud endpoint %q", conf.Endpoint)
+		}
+		endpoint = conf.Endpoint
+	}
+
+	return &Discovery{
+		client:            &http.Client{Timeout: time.Duration(conf.RefreshInterval)},
+		endpoint:          strings.TrimSuffix(endpoint, "/"),
+		applicationKey:    conf.ApplicationKey,
+		applicationSecret: string(conf.ApplicationSecret),
+		consumerKey:       string(conf.ConsumerKey),
+		service:           conf.Service,
+		port:              conf.Port,
+		interval:          time.Duration(conf.RefreshInterval),
+		logger:            logger,
+		backoff: httputil.Backoff{
+			Min:     time.Second,
+			Max:     30 * time.Second,
+			Retries: 3,
+		},
+	}, nil
+}
+
+// Run implements the TargetProvider interface.
+func (d *Discovery) Run(ctx context.Context, ch chan<- []*config.TargetGroup) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		tg, err := d.refresh(ctx)
+		if err != nil {
+			d.logger.Errorf("Error refreshing OVHcloud %s targets: %s", d.service, err)
+		} else {
+			select {
+			case ch <- []*config.TargetGroup{tg}:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (d *Discovery) refresh(ctx context.Context) (tg *config.TargetGroup, err error) {
+	t0 := time.Now()
+	defer func() {
+		refreshDuration.WithLabelValues(string(d.service)).Observe(time.Since(t0).Seconds())
+		if err != nil {
+			refreshFailuresCount.WithLabelValues(string(d.service)).Inc()
+		}
+	}()
+
+	if err := d.syncTime(ctx); err != nil {
+		return nil, fmt.Errorf("could not get the time of the API: %s", err)
+	}
+
+	var targets []model.LabelSet
+	switch d.service {
+	case config.OVHcloudServiceVPS:
+		targets, err = d.vpsTargets(ctx)
+	case config.OVHcloudServiceDedicatedServer:
+		targets, err = d.dedicatedServerTargets(ctx)
+	default:
+		err = fmt.Errorf("unknown service %q", d.service)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &config.TargetGroup{
+		Source:  fmt.Sprintf("%s/%s", d.endpoint, d.service),
+		Targets: targets,
+	}, nil
+}
+
+// syncTime records the difference between the clocks of the API and the local
+// one, as signatures with a timestamp too far off are rejected.
+func (d *Discovery) syncTime(ctx context.Context) error {
+	var ts int64
+	if err := d.do(ctx, "/auth/time", false, &ts); err != nil {
+		return err
+	}
+	d.timeDelta = time.Unix(ts, 0).Sub(time.Now())
+	return nil
+}
+
+// get decodes the JSON response to a signed GET request of the path of the
+// API.
+func (d *Discovery) get(ctx context.Context, path string, v interface{}) error {
+	return d.do(ctx, path, true, v)
+}
+
+func (d *Discovery) do(ctx context.Context, path string, sign bool, v interface{}) error {
+	for retry := 0; ; retry++ {
+		req, err := http.NewRequest("GET", d.endpoint+path, nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("X-Ovh-Application", d.applicationKey)
+		if sign {
+			d.sign(req)
+		}
+		resp, err := ctxhttp.Do(ctx, d.client, req)
+		if err != nil {
+			return err
+		}
+		b, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode/100 == 2 {
+			if err := json.Unmarshal(b, v); err != nil {
+				return fmt.Errorf("could not decode response to %s: %s", path, err)
+			}
+			return nil
+		}
+		delay, ok := d.backoff.Delay(resp, retry)
+		if !ok {
+			var apiErr struct {
+				Message string `json:"message"`
+			}
+			if json.Unmarshal(b, &apiErr) == nil && apiErr.Message != "" {
+				return fmt.Errorf("request to %s failed with status %s: %s", path, resp.Status, apiErr.Message)
+			}
+			return fmt.Errorf("request to %s failed with status %s", path, resp.Status)
+		}
+		d.logger.Debugf("Retrying request to %s failed with status %s in %s", path, resp.Status, delay)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// sign signs the request, which has no body, with the application secret and
+// the consumer key.
+func (d *Discovery) sign(req *http.Request) {
+	ts := strconv.FormatInt(time.Now().Add(d.timeDelta).Unix(), 10)
+	h := sha1.New()
+	fmt.Fprintf(h, "%s+%s+%s+%s+%s+%s", d.applicationSecret, d.consumerKey, req.Method, req.URL, "", ts)
+
+	req.Header.Set("X-Ovh-Consumer", d.consumerKey)
+	req.Header.Set("X-Ovh-Timestamp", ts)
+	req.Header.Set("X-Ovh-Signature", "$1$"+hex.EncodeToString(h.Sum(nil)))
+}
+
+// splitIPs returns the first IPv4 and IPv6 addresses of the IP blocks,
+// given in CIDR notation or as single addresses.
+func splitIPs(blocks []string) (ipv4, ipv6 string) {
+	for _, b := range blocks {
+		if i := strings.Index(b, "/"); i >= 0 {
+			b = b[:i]
+		}
+		ip := net.ParseIP(b)
+		switch {
+		case ip == nil:
+		case ip.To4() != nil:
+			if ipv4 == "" {
+				ipv4 = b
+			}
+		default:
+			if ipv6 == "" {
+				ipv6 = b
+			}
+		}
+	}
+	return ipv4, ipv6
+}
+
+// address returns the address of a target listening on the port of the
+// discovery, from its IPv4 address or its IPv6 one otherwise.
+func (d *Discovery) address(ipv4, ipv6 string) string {
+	ip := ipv4
+	if ip == "" {
+		ip = ipv6
+	}
+	if ip == "" {
+		return ""
+	}
+	return net.JoinHostPort(ip, strconv.Itoa(d.port))
+}
@@ -0,0 +1,208 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovhcloud
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/log"
+	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
+
+	"github.com/prometheus/prometheus/config"
+)
+
+// The clock of the fake API is ahead of the local one.
+const apiTimeOffset = time.Hour
+
+func newTestServer(t *testing.T) *httptest.Server {
+	var srv *httptest.Server
+	rejected := false
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if app := r.Header.Get("X-Ovh-Application"); app != "appkey" {
+			t.Errorf("Expected the application key to be sent, got %q", app)
+		}
+		if r.URL.Path == "/1.0/auth/time" {
+			fmt.Fprint(w, time.Now().Add(apiTimeOffset).Unix())
+			return
+		}
+
+		ts := r.Header.Get("X-Ovh-Timestamp")
+		h := sha1.New()
+		fmt.Fprintf(h, "appsecret+consumerkey+GET+%s%s++%s", srv.URL, r.URL.RequestURI(), ts)
+		if sig := r.Header.Get("X-Ovh-Signature"); sig != "$1$"+hex.EncodeToString(h.Sum(nil)) {
+			http.Error(w, `{"message": "Invalid signature"}`, http.StatusBadRequest)
+			return
+		}
+		var tsSecs int64
+		fmt.Sscan(ts, &tsSecs)
+		if d := time.Unix(tsSecs, 0).Sub(time.Now().Add(apiTimeOffset)); d > time.Minute || d < -time.Minute {
+			http.Error(w, `{"message": "Invalid timestamp"}`, http.StatusBadRequest)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/1.0/vps":
+			fmt.Fprint(w, `["vps-1a2b3c4d.vps.ovh.net"]`)
+		case "/1.0/vps/vps-1a2b3c4d.vps.ovh.net":
+			fmt.Fprint(w, `{
+				"name": "vps-1a2b3c4d.vps.ovh.net",
+				"displayName": "prometheus",
+				"state": "running",
+				"zone": "Region OpenStack: os-gra5",
+				"cluster": "",
+				"offerType": "ssd",
+				"netbootMode": "local",
+				"model": {"name": "vps-value-1-2-40", "offer": "VPS vps2020-value-1-2-40", "vcore": 1, "memory": 2048, "disk": 40}
+			}`)
+		case "/1.0/vps/vps-1a2b3c4d.vps.ovh.net/ips":
+			// The API is overloaded once.
+			if !rejected {
+				rejected = true
+				http.Error(w, `{"message": "Service unavailable"}`, http.StatusServiceUnavailable)
+				return
+			}
+			fmt.Fprint(w, `["2001:41d0:404:200::5f3e", "51.75.123.45"]`)
+		case "/1.0/dedicated/server":
+			fmt.Fprint(w, `["ns3012345.ip-51-68-12.eu", "ns3054321.ip-51-68-13.eu"]`)
+		case "/1.0/dedicated/server/ns3012345.ip-51-68-12.eu":
+			fmt.Fprint(w, `{
+				"serverId": 1234567,
+				"name": "ns3012345.ip-51-68-12.eu",
+				"reverse": "db1.example.com.",
+				"commercialRange": "advance-1",
+				"datacenter": "gra3",
+				"rack": "G315A05",
+				"os": "debian11_64",
+				"state": "ok",
+				"supportLevel": "pro",
+				"linkSpeed": 1000,
+				"noIntervention": false,
+				"ip": "51.68.12.34"
+			}`)
+		case "/1.0/dedicated/server/ns3012345.ip-51-68-12.eu/ips":
+			fmt.Fprint(w, `["51.68.12.34/32", "2001:41d0:303:2a22::/64"]`)
+		case "/1.0/dedicated/server/ns3054321.ip-51-68-13.eu":
+			fmt.Fprint(w, `{"serverId": 7654321, "name": "ns3054321.ip-51-68-13.eu", "state": "hacked"}`)
+		case "/1.0/dedicated/server/ns3054321.ip-51-68-13.eu/ips":
+			fmt.Fprint(w, `[]`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	return srv
+}
+
+func newTestDiscovery(t *testing.T, url string, service config.OVHcloudService) *Discovery {
+	d, err := NewDiscovery(&config.OVHcloudSDConfig{
+		Endpoint:          url + "/1.0",
+		ApplicationKey:    "appkey",
+		ApplicationSecret: "appsecret",
+		ConsumerKey:       "consumerkey",
+		Service:           service,
+		Port:              9100,
+	}, log.Base())
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.backoff.Min = time.Millisecond
+	return d
+}
+
+func TestVPSRefresh(t *testing.T) {
+	srv := newTestServer(t)
+	defer srv.Close()
+
+	d := newTestDiscovery(t, srv.URL, config.OVHcloudServiceVPS)
+	tg, err := d.refresh(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &config.TargetGroup{
+		Source: srv.URL + "/1.0/vps",
+		Targets: []model.LabelSet{
+			{
+				"__address__":                      "51.75.123.45:9100",
+				"__meta_ovhcloud_vps_name":         "vps-1a2b3c4d.vps.ovh.net",
+				"__meta_ovhcloud_vps_display_name": "prometheus",
+				"__meta_ovhcloud_vps_state":        "running",
+				"__meta_ovhcloud_vps_zone":         "Region OpenStack: os-gra5",
+				"__meta_ovhcloud_vps_cluster":      "",
+				"__meta_ovhcloud_vps_offer_type":   "ssd",
+				"__meta_ovhcloud_vps_model_name":   "vps-value-1-2-40",
+				"__meta_ovhcloud_vps_model_offer":  "VPS vps2020-value-1-2-40",
+				"__meta_ovhcloud_vps_vcore":        "1",
+				"__meta_ovhcloud_vps_memory_mb":    "2048",
+				"__meta_ovhcloud_vps_disk_gb":      "40",
+				"__meta_ovhcloud_vps_ipv4":         "51.75.123.45",
+				"__meta_ovhcloud_vps_ipv6":         "2001:41d0:404:200::5f3e",
+			},
+		},
+	}
+	if !reflect.DeepEqual(tg, expected) {
+		t.Errorf("Expected target group\n%v\ngot\n%v", expected, tg)
+	}
+}
+
+func TestDedicatedServerRefresh(t *testing.T) {
+	srv := newTestServer(t)
+	defer srv.Close()
+
+	d := newTestDiscovery(t, srv.URL, config.OVHcloudServiceDedicatedServer)
+	tg, err := d.refresh(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &config.TargetGroup{
+		Source: srv.URL + "/1.0/dedicated_server",
+		Targets: []model.LabelSet{
+			{
+				"__address__": "51.68.12.34:9100",
+				"__meta_ovhcloud_dedicated_server_server_id":        "1234567",
+				"__meta_ovhcloud_dedicated_server_name":             "ns3012345.ip-51-68-12.eu",
+				"__meta_ovhcloud_dedicated_server_reverse":          "db1.example.com.",
+				"__meta_ovhcloud_dedicated_server_commercial_range": "advance-1",
+				"__meta_ovhcloud_dedicated_server_datacenter":       "gra3",
+				"__meta_ovhcloud_dedicated_server_rack":             "G315A05",
+				"__meta_ovhcloud_dedicated_server_os":               "debian11_64",
+				"__meta_ovhcloud_dedicated_server_state":            "ok",
+				"__meta_ovhcloud_dedicated_server_support_level":    "pro",
+				"__meta_ovhcloud_dedicated_server_link_speed":       "1000",
+				"__meta_ovhcloud_dedicated_server_no_intervention":  "false",
+				"__meta_ovhcloud_dedicated_server_ipv4":             "51.68.12.34",
+				"__meta_ovhcloud_dedicated_server_ipv6":             "2001:41d0:303:2a22::",
+			},
+		},
+	}
+	if !reflect.DeepEqual(tg, expected) {
+		t.Errorf("Expected target group\n%v\ngot\n%v", expected, tg)
+	}
+}
+
+func TestUnknownEndpoint(t *testing.T) {
+	_, err := NewDiscovery(&config.OVHcloudSDConfig{
+		Endpoint: "ovh-xx",
+		Service:  config.OVHcloudServiceVPS,
+	}, log.Base())
+	if err == nil {
+		t.Fatal("Expected an error for an unknown endpoint")
+	}
+}
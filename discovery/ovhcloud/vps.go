@@ -0,0 +1,105 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ovhcloud
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
+)
+
+const (
+	vpsLabel            = ovhcloudLabel + "vps_"
+	vpsLabelName        = vpsLabel + "name"
+	vpsLabelDisplayName = vpsLabel + "display_name"
+	vpsLabelState       = vpsLabel + "state"
+	vpsLabelZone        = vpsLabel + "zone"
+	vpsLabelCluster     = vpsLabel + "cluster"
+	vpsLabelOfferType   = vpsLabel + "offer_type"
+	vpsLabelModelName   = vpsLabel + "model_name"
+	vpsLabelModelOffer  = vpsLabel + "model_offer"
+	vpsLabelVCore       = vpsLabel + "vcore"
+	vpsLabelMemory      = vpsLabel + "memory_mb"
+	vpsLabelDisk        = vpsLabel + "disk_gb"
+	vpsLabelIPv4        = vpsLabel + "ipv4"
+	vpsLabelIPv6        = vpsLabel + "ipv6"
+)
+
+type vps struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+	State       string `json:"state"`
+	Zone        string `json:"zone"`
+	Cluster     string `json:"cluster"`
+	OfferType   string `json:"offerType"`
+	Model       struct {
+		Name   string `json:"name"`
+		Offer  string `json:"offer"`
+		VCore  int    `json:"vcore"`
+		Memory int    `json:"memory"`
+		Disk   int    `json:"disk"`
+	} `json:"model"`
+}
+
+// vpsTargets returns a target for each VPS of the account with an IP address.
+func (d *Discovery) vpsTargets(ctx context.Context) ([]model.LabelSet, error) {
+	var names []string
+	if err := d.get(ctx, "/vps", &names); err != nil {
+		return nil, fmt.Errorf("could not list VPS: %s", err)
+	}
+
+	var targets []model.LabelSet
+	for _, name := range names {
+		path := "/vps/" + url.PathEscape(name)
+		var v vps
+		if err := d.get(ctx, path, &v); err != nil {
+			return nil, fmt.Errorf("could not get VPS %q: %s", name, err)
+		}
+		var ips []string
+		if err := d.get(ctx, path+"/ips", &ips); err != nil {
+			return nil, fmt.Errorf("could not get the IPs of VPS %q: %s", name, err)
+		}
+		ipv4, ipv6 := splitIPs(ips)
+		addr := d.address(ipv4, ipv6)
+		if addr == "" {
+			continue
+		}
+
+		labels := model.LabelSet{
+			model.AddressLabel:  model.LabelValue(addr),
+			vpsLabelName:        model.LabelValue(v.Name),
+			vpsLabelDisplayName: model.LabelValue(v.DisplayName),
+			vpsLabelState:       model.LabelValue(v.State),
+			vpsLabelZone:        model.LabelValue(v.Zone),
+			vpsLabelCluster:     model.LabelValue(v.Cluster),
+			vpsLabelOfferType:   model.LabelValue(v.OfferType),
+			vpsLabelModelName:   model.LabelValue(v.Model.Name),
+			vpsLabelModelOffer:  model.LabelValue(v.Model.Offer),
+			vpsLabelVCore:       model.LabelValue(strconv.Itoa(v.Model.VCore)),
+			vpsLabelMemory:      model.LabelValue(strconv.Itoa(v.Model.Memory)),
+			vpsLabelDisk:        model.LabelValue(strconv.Itoa(v.Model.Disk)),
+		}
+		if ipv4 != "" {
+			labels[vpsLabelIPv4] = model.LabelValue(ipv4)
+		}
+		if ipv6 != "" {
+			labels[vpsLabelIPv6] = model.LabelValue(ipv6)
+		}
+		targets = append(targets, labels)
+	}
+	return targets, nil
+}
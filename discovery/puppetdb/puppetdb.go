@@ -0,0 +1,316 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package puppetdb
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
+	"golang.org/x/net/context/ctxhttp"
+
+	"github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/util/httputil"
+	"github.com/prometheus/prometheus/util/strutil"
+)
+
+const (
+	pdbLabel            = model.MetaLabelPrefix + "puppetdb_"
+	pdbLabelQuery       = pdbLabel + "query"
+	pdbLabelCertname    = pdbLabel + "certname"
+	pdbLabelResource    = pdbLabel + "resource"
+	pdbLabelType        = pdbLabel + "type"
+	pdbLabelTitle       = pdbLabel + "title"
+	pdbLabelExported    = pdbLabel + "exported"
+	pdbLabelFile        = pdbLabel + "file"
+	pdbLabelEnvironment = pdbLabel + "environment"
+	pdbLabelTags        = pdbLabel + "tags"
+	pdbLabelParameter   = pdbLabel + "parameter_"
+	pdbLabelFact        = pdbLabel + "fact_"
+
+	// The separator of the tags and array values lists.
+	separator = ","
+
+	queryPath = "/pdb/query/v4"
+)
+
+var (
+	refreshFailuresCount = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "prometheus_sd_puppetdb_refresh_failures_total",
+			Help: "The number of PuppetDB-SD refresh failures.",
+		})
+	refreshDuration = prometheus.NewSummary(
+		prometheus.SummaryOpts{
+			Name: "prometheus_sd_puppetdb_refresh_duration_seconds",
+			Help: "The duration of a PuppetDB-SD refresh in seconds.",
+		})
+)
+
+func init() {
+	prometheus.MustRegister(refreshFailuresCount)
+	prometheus.MustRegister(refreshDuration)
+}
+
+type resource struct {
+	Certname    string                 `json:"certname"`
+	Resource    string                 `json:"resource"`
+	Type        string                 `json:"type"`
+	Title       string                 `json:"title"`
+	Exported    bool                   `json:"exported"`
+	File        string                 `json:"file"`
+	Environment string                 `json:"environment"`
+	Tags        []string               `json:"tags"`
+	Parameters  map[string]interface{} `json:"parameters"`
+}
+
+type fact struct {
+	Certname string      `json:"certname"`
+	Name     string      `json:"name"`
+	Value    interface{} `json:"value"`
+}
+
+// Discovery periodically discovers the resources returned by a PuppetDB query.
+// It implements the TargetProvider interface.
+type Discovery struct {
+	client            *http.Client
+	url               string
+	query             string
+	includeParameters bool
+	includeFacts      bool
+	port              int
+	interval          time.Duration
+	logger            log.Logger
+}
+
+// NewDiscovery returns a new PuppetDB discovery which periodically refreshes
+// its targets.
+func NewDiscovery(conf *config.PuppetDBSDConfig, logger log.Logger) (*Discovery, error) {
+	client, err := httputil.NewClientFromConfig(conf.HTTPClientConfig)
+	if err != nil {
+		return nil, err
+	}
+	client.Timeout = time.Duration(conf.RefreshInterval)
+
+	return &Discovery{
+		client:            client,
+		url:               strings.TrimSuffix(conf.URL, "/"),
+		query:             conf.Query,
+		includeParameters: conf.IncludeParameters,
+		includeFacts:      conf.IncludeFacts,
+		port:              conf.Port,
+		interval:          time.Duration(conf.RefreshInterval),
+		logger:            logger,
+	}, nil
+}
+
+// Run implements the TargetProvider interface.
+func (d *Discovery) Run(ctx context.Context, ch chan<- []*config.TargetGroup) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		tg, err := d.refresh(ctx)
+		if err != nil {
+			d.logger.Errorf("Error refreshing PuppetDB targets: %s", err)
+		} else {
+			select {
+			case ch <- []*config.TargetGroup{tg}:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (d *Discovery) refresh(ctx context.Context) (tg *config.TargetGroup, err error) {
+	t0 := time.Now()
+	defer func() {
+		refreshDuration.Observe(time.Since(t0).Seconds())
+		if err != nil {
+			refreshFailuresCount.Inc()
+		}
+	}()
+
+	var resources []resource
+	if err := d.do(ctx, d.query, &resources); err != nil {
+		return nil, fmt.Errorf("could not query resources: %s", err)
+	}
+
+	var facts map[string]model.LabelSet
+	if d.includeFacts && len(resources) > 0 {
+		facts, err = d.facts(ctx, resources)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tg = &config.TargetGroup{
+		Source: d.url,
+		Labels: model.LabelSet{
+			pdbLabelQuery: model.LabelValue(d.query),
+		},
+	}
+	for _, r := range resources {
+		labels := model.LabelSet{
+			model.AddressLabel:  model.LabelValue(net.JoinHostPort(r.Certname, strconv.Itoa(d.port))),
+			pdbLabelCertname:    model.LabelValue(r.Certname),
+			pdbLabelResource:    model.LabelValue(r.Resource),
+			pdbLabelType:        model.LabelValue(r.Type),
+			pdbLabelTitle:       model.LabelValue(r.Title),
+			pdbLabelExported:    model.LabelValue(strconv.FormatBool(r.Exported)),
+			pdbLabelFile:        model.LabelValue(r.File),
+			pdbLabelEnvironment: model.LabelValue(r.Environment),
+		}
+		if len(r.Tags) > 0 {
+			// We surround the separated list with the separator as well. This way regular expressions
+			// in relabeling rules don't have to consider tag positions.
+			labels[pdbLabelTags] = model.LabelValue(separator + strings.Join(r.Tags, separator) + separator)
+		}
+		if d.includeParameters {
+			addValues(labels, pdbLabelParameter, r.Parameters)
+		}
+		for name, value := range facts[r.Certname] {
+			labels[name] = value
+		}
+		tg.Targets = append(tg.Targets, labels)
+	}
+	return tg, nil
+}
+
+// facts returns the labels of the facts of the nodes of the resources, by
+// certname.
+func (d *Discovery) facts(ctx context.Context, resources []resource) (map[string]model.LabelSet, error) {
+	seen := map[string]struct{}{}
+	var certnames []string
+	for _, r := range resources {
+		if _, ok := seen[r.Certname]; ok {
+			continue
+		}
+		seen[r.Certname] = struct{}{}
+		certnames = append(certnames, strconv.Quote(r.Certname))
+	}
+	sort.Strings(certnames)
+
+	var facts []fact
+	query := fmt.Sprintf("facts[certname, name, value] { certname in [%s] }", strings.Join(certnames, ", "))
+	if err := d.do(ctx, query, &facts); err != nil {
+		return nil, fmt.Errorf("could not query facts: %s", err)
+	}
+
+	values := map[string]map[string]interface{}{}
+	for _, f := range facts {
+		if values[f.Certname] == nil {
+			values[f.Certname] = map[string]interface{}{}
+		}
+		values[f.Certname][f.Name] = f.Value
+	}
+	labels := make(map[string]model.LabelSet, len(values))
+	for certname, v := range values {
+		labels[certname] = model.LabelSet{}
+		addValues(labels[certname], pdbLabelFact, v)
+	}
+	return labels, nil
+}
+
+// do runs the PQL query and decodes its JSON result.
+func (d *Discovery) do(ctx context.Context, query string, v interface{}) error {
+	body, err := json.Marshal(struct {
+		Query string `json:"query"`
+	}{query})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", d.url+queryPath, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := ctxhttp.Do(ctx, d.client, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("query failed with status %s: %s", resp.Status, strings.TrimSpace(string(b)))
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("could not decode query result: %s", err)
+	}
+	return nil
+}
+
+// addValues adds a label with the given prefix for each value which is a
+// scalar or a list of scalars. Structured values like hashes are skipped.
+func addValues(labels model.LabelSet, prefix string, values map[string]interface{}) {
+	for name, value := range values {
+		s, ok := labelValue(value)
+		if !ok {
+			continue
+		}
+		labels[model.LabelName(prefix+strutil.SanitizeLabelName(name))] = model.LabelValue(s)
+	}
+}
+
+func labelValue(v interface{}) (string, bool) {
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case bool:
+		return strconv.FormatBool(v), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case []interface{}:
+		if len(v) == 0 {
+			return "", false
+		}
+		values := make([]string, 0, len(v))
+		for _, e := range v {
+			if _, ok := e.([]interface{}); ok {
+				return "", false
+			}
+			s, ok := labelValue(e)
+			if !ok {
+				return "", false
+			}
+			values = append(values, s)
+		}
+		return separator + strings.Join(values, separator) + separator, true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package puppetdb
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/prometheus/common/log"
+	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
+
+	"github.com/prometheus/prometheus/config"
+)
+
+const testQuery = `resources { type = "Class" and title = "Prometheus::Node_exporter" }`
+
+func newTestServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/pdb/query/v4" {
+			http.NotFound(w, r)
+			return
+		}
+		var req struct {
+			Query string `json:"query"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("Could not decode query: %s", err)
+			return
+		}
+		switch req.Query {
+		case testQuery:
+			fmt.Fprint(w, `[{
+				"certname": "edinburgh.example.com",
+				"resource": "49af83866dc5a1518968b68e58a25319107afe11",
+				"type": "Class",
+				"title": "Prometheus::Node_exporter",
+				"exported": false,
+				"file": "/etc/puppetlabs/code/environments/prod/modules/upstream/prometheus/manifests/init.pp",
+				"environment": "prod",
+				"tags": ["node_exporter", "class"],
+				"parameters": {
+					"alias": ["node_exporter", "exporter"],
+					"port": 9100,
+					"manage_user": true,
+					"options": {"collectors": "cpu"}
+				}
+			}]`)
+		case `facts[certname, name, value] { certname in ["edinburgh.example.com"] }`:
+			fmt.Fprint(w, `[
+				{"certname": "edinburgh.example.com", "name": "osfamily", "value": "Debian"},
+				{"certname": "edinburgh.example.com", "name": "processorcount", "value": 4},
+				{"certname": "edinburgh.example.com", "name": "os", "value": {"family": "Debian"}}
+			]`)
+		default:
+			http.Error(w, "unexpected query "+req.Query, http.StatusBadRequest)
+		}
+	}))
+}
+
+func TestRefresh(t *testing.T) {
+	srv := newTestServer(t)
+	defer srv.Close()
+
+	for _, tc := range []struct {
+		includeParameters, includeFacts bool
+		labels                          model.LabelSet
+	}{
+		{},
+		{
+			includeParameters: true,
+			labels: model.LabelSet{
+				"__meta_puppetdb_parameter_alias":       ",node_exporter,exporter,",
+				"__meta_puppetdb_parameter_port":        "9100",
+				"__meta_puppetdb_parameter_manage_user": "true",
+			},
+		},
+		{
+			includeFacts: true,
+			labels: model.LabelSet{
+				"__meta_puppetdb_fact_osfamily":       "Debian",
+				"__meta_puppetdb_fact_processorcount": "4",
+			},
+		},
+	} {
+		d, err := NewDiscovery(&config.PuppetDBSDConfig{
+			URL:               srv.URL + "/",
+			Query:             testQuery,
+			IncludeParameters: tc.includeParameters,
+			IncludeFacts:      tc.includeFacts,
+			Port:              9100,
+		}, log.Base())
+		if err != nil {
+			t.Fatal(err)
+		}
+		tg, err := d.refresh(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		target := model.LabelSet{
+			"__address__":                 "edinburgh.example.com:9100",
+			"__meta_puppetdb_certname":    "edinburgh.example.com",
+			"__meta_puppetdb_resource":    "49af83866dc5a1518968b68e58a25319107afe11",
+			"__meta_puppetdb_type":        "Class",
+			"__meta_puppetdb_title":       "Prometheus::Node_exporter",
+			"__meta_puppetdb_exported":    "false",
+			"__meta_puppetdb_file":        "/etc/puppetlabs/code/environments/prod/modules/upstream/prometheus/manifests/init.pp",
+			"__meta_puppetdb_environment": "prod",
+			"__meta_puppetdb_tags":        ",node_exporter,class,",
+		}
+		expected := &config.TargetGroup{
+			Source:  srv.URL,
+			Labels:  model.LabelSet{"__meta_puppetdb_query": testQuery},
+			Targets: []model.LabelSet{target.Merge(tc.labels)},
+		}
+		if !reflect.DeepEqual(tg, expected) {
+			t.Errorf("Expected target group\n%v\ngot\n%v", expected, tg)
+		}
+	}
+}
+
+func TestRefreshQueryError(t *testing.T) {
+	srv := newTestServer(t)
+	defer srv.Close()
+
+	d, err := NewDiscovery(&config.PuppetDBSDConfig{
+		URL:   srv.URL,
+		Query: "nodes {",
+		Port:  80,
+	}, log.Base())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.refresh(context.Background()); err == nil {
+		t.Fatal("Expected an error for a rejected query")
+	}
+}
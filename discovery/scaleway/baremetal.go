@@ -0,0 +1,106 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scaleway
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
+)
+
+const (
+	baremetalLabel               = scalewayLabel + "baremetal_"
+	baremetalLabelID             = baremetalLabel + "id"
+	baremetalLabelName           = baremetalLabel + "name"
+	baremetalLabelProjectID      = baremetalLabel + "project_id"
+	baremetalLabelOrganizationID = baremetalLabel + "organization_id"
+	baremetalLabelType           = baremetalLabel + "type"
+	baremetalLabelStatus         = baremetalLabel + "status"
+	baremetalLabelPublicIPv4     = baremetalLabel + "public_ipv4"
+	baremetalLabelPublicIPv6     = baremetalLabel + "public_ipv6"
+	baremetalLabelZone           = baremetalLabel + "zone"
+	baremetalLabelTags           = baremetalLabel + "tags"
+)
+
+type baremetalServer struct {
+	ID             string   `json:"id"`
+	Name           string   `json:"name"`
+	ProjectID      string   `json:"project_id"`
+	OrganizationID string   `json:"organization_id"`
+	OfferName      string   `json:"offer_name"`
+	Status         string   `json:"status"`
+	Zone           string   `json:"zone"`
+	Tags           []string `json:"tags"`
+	IPs            []struct {
+		Address string `json:"address"`
+		Version string `json:"version"`
+	} `json:"ips"`
+}
+
+// baremetalTargets returns a target for each Elastic Metal server of the zone
+// with a public IPv4 address.
+func (d *Discovery) baremetalTargets(ctx context.Context) ([]model.LabelSet, error) {
+	var servers []baremetalServer
+	for page := 1; ; page++ {
+		var resp struct {
+			TotalCount int               `json:"total_count"`
+			Servers    []baremetalServer `json:"servers"`
+		}
+		if _, err := d.get(ctx, d.serversPath("baremetal", "project_id", page), &resp); err != nil {
+			return nil, fmt.Errorf("could not list baremetal servers: %s", err)
+		}
+		servers = append(servers, resp.Servers...)
+		if len(resp.Servers) == 0 || len(servers) >= resp.TotalCount {
+			break
+		}
+	}
+
+	targets := make([]model.LabelSet, 0, len(servers))
+	for _, s := range servers {
+		labels := model.LabelSet{
+			baremetalLabelID:             model.LabelValue(s.ID),
+			baremetalLabelName:           model.LabelValue(s.Name),
+			baremetalLabelProjectID:      model.LabelValue(s.ProjectID),
+			baremetalLabelOrganizationID: model.LabelValue(s.OrganizationID),
+			baremetalLabelType:           model.LabelValue(s.OfferName),
+			baremetalLabelStatus:         model.LabelValue(s.Status),
+			baremetalLabelZone:           model.LabelValue(s.Zone),
+		}
+		if len(s.Tags) > 0 {
+			labels[baremetalLabelTags] = joinTags(s.Tags)
+		}
+		for _, ip := range s.IPs {
+			switch ip.Version {
+			case "IPv4":
+				if _, ok := labels[baremetalLabelPublicIPv4]; !ok {
+					labels[baremetalLabelPublicIPv4] = model.LabelValue(ip.Address)
+				}
+			case "IPv6":
+				if _, ok := labels[baremetalLabelPublicIPv6]; !ok {
+					labels[baremetalLabelPublicIPv6] = model.LabelValue(ip.Address)
+				}
+			}
+		}
+		addr, ok := labels[baremetalLabelPublicIPv4]
+		if !ok {
+			continue
+		}
+		labels[model.AddressLabel] = model.LabelValue(net.JoinHostPort(string(addr), strconv.Itoa(d.port)))
+		targets = append(targets, labels)
+	}
+	return targets, nil
+}
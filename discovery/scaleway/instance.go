@@ -0,0 +1,139 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scaleway
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
+)
+
+const (
+	instanceLabel                  = scalewayLabel + "instance_"
+	instanceLabelID                = instanceLabel + "id"
+	instanceLabelName              = instanceLabel + "name"
+	instanceLabelHostname          = instanceLabel + "hostname"
+	instanceLabelProjectID         = instanceLabel + "project_id"
+	instanceLabelOrganizationID    = instanceLabel + "organization_id"
+	instanceLabelType              = instanceLabel + "type"
+	instanceLabelStatus            = instanceLabel + "status"
+	instanceLabelImageID           = instanceLabel + "image_id"
+	instanceLabelImageName         = instanceLabel + "image_name"
+	instanceLabelImageArch         = instanceLabel + "image_arch"
+	instanceLabelPrivateIPv4       = instanceLabel + "private_ipv4"
+	instanceLabelPublicIPv4        = instanceLabel + "public_ipv4"
+	instanceLabelPublicIPv6        = instanceLabel + "public_ipv6"
+	instanceLabelSecurityGroupID   = instanceLabel + "security_group_id"
+	instanceLabelSecurityGroupName = instanceLabel + "security_group_name"
+	instanceLabelZone              = instanceLabel + "zone"
+	instanceLabelTags              = instanceLabel + "tags"
+)
+
+type instanceServer struct {
+	ID             string   `json:"id"`
+	Name           string   `json:"name"`
+	Hostname       string   `json:"hostname"`
+	Project        string   `json:"project"`
+	Organization   string   `json:"organization"`
+	CommercialType string   `json:"commercial_type"`
+	State          string   `json:"state"`
+	Zone           string   `json:"zone"`
+	Tags           []string `json:"tags"`
+	Image          *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+		Arch string `json:"arch"`
+	} `json:"image"`
+	PrivateIP *string `json:"private_ip"`
+	PublicIP  *struct {
+		Address string `json:"address"`
+	} `json:"public_ip"`
+	IPv6 *struct {
+		Address string `json:"address"`
+	} `json:"ipv6"`
+	SecurityGroup *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"security_group"`
+}
+
+// instanceTargets returns a target for each instance of the zone, addressed by
+// its private IPv4 address if it has one, and by its public IPv4 address
+// otherwise.
+func (d *Discovery) instanceTargets(ctx context.Context) ([]model.LabelSet, error) {
+	var servers []instanceServer
+	for page := 1; ; page++ {
+		var resp struct {
+			Servers []instanceServer `json:"servers"`
+		}
+		httpResp, err := d.get(ctx, d.serversPath("instance", "project", page), &resp)
+		if err != nil {
+			return nil, fmt.Errorf("could not list instances: %s", err)
+		}
+		servers = append(servers, resp.Servers...)
+		// The total number of servers is returned in a header.
+		total, err := strconv.Atoi(httpResp.Header.Get("X-Total-Count"))
+		if err != nil || len(resp.Servers) == 0 || len(servers) >= total {
+			break
+		}
+	}
+
+	targets := make([]model.LabelSet, 0, len(servers))
+	for _, s := range servers {
+		labels := model.LabelSet{
+			instanceLabelID:             model.LabelValue(s.ID),
+			instanceLabelName:           model.LabelValue(s.Name),
+			instanceLabelHostname:       model.LabelValue(s.Hostname),
+			instanceLabelProjectID:      model.LabelValue(s.Project),
+			instanceLabelOrganizationID: model.LabelValue(s.Organization),
+			instanceLabelType:           model.LabelValue(s.CommercialType),
+			instanceLabelStatus:         model.LabelValue(s.State),
+			instanceLabelZone:           model.LabelValue(s.Zone),
+		}
+		if s.Image != nil {
+			labels[instanceLabelImageID] = model.LabelValue(s.Image.ID)
+			labels[instanceLabelImageName] = model.LabelValue(s.Image.Name)
+			labels[instanceLabelImageArch] = model.LabelValue(s.Image.Arch)
+		}
+		if s.SecurityGroup != nil {
+			labels[instanceLabelSecurityGroupID] = model.LabelValue(s.SecurityGroup.ID)
+			labels[instanceLabelSecurityGroupName] = model.LabelValue(s.SecurityGroup.Name)
+		}
+		if len(s.Tags) > 0 {
+			labels[instanceLabelTags] = joinTags(s.Tags)
+		}
+
+		var addr string
+		if s.IPv6 != nil && s.IPv6.Address != "" {
+			labels[instanceLabelPublicIPv6] = model.LabelValue(s.IPv6.Address)
+		}
+		if s.PublicIP != nil && s.PublicIP.Address != "" {
+			labels[instanceLabelPublicIPv4] = model.LabelValue(s.PublicIP.Address)
+			addr = s.PublicIP.Address
+		}
+		if s.PrivateIP != nil && *s.PrivateIP != "" {
+			labels[instanceLabelPrivateIPv4] = model.LabelValue(*s.PrivateIP)
+			addr = *s.PrivateIP
+		}
+		if addr == "" {
+			continue
+		}
+		labels[model.AddressLabel] = model.LabelValue(net.JoinHostPort(addr, strconv.Itoa(d.port)))
+		targets = append(targets, labels)
+	}
+	return targets, nil
+}
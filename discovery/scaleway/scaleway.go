@@ -0,0 +1,196 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scaleway
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
+	"golang.org/x/net/context/ctxhttp"
+
+	"github.com/prometheus/prometheus/config"
+)
+
+const (
+	scalewayLabel = model.MetaLabelPrefix + "scaleway_"
+	// The separator of the tags list.
+	separator = ","
+	// The maximum page size of the API.
+	perPage = 100
+)
+
+var (
+	refreshFailuresCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "prometheus_sd_scaleway_refresh_failures_total",
+			Help: "The number of Scaleway-SD refresh failures.",
+		},
+		[]string{"role"},
+	)
+	refreshDuration = prometheus.NewSummaryVec(
+		prometheus.SummaryOpts{
+			Name: "prometheus_sd_scaleway_refresh_duration_seconds",
+			Help: "The duration of a Scaleway-SD refresh in seconds.",
+		},
+		[]string{"role"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(refreshFailuresCount)
+	prometheus.MustRegister(refreshDuration)
+}
+
+// Discovery periodically discovers the instances or Elastic Metal servers of
+// a Scaleway zone. It implements the TargetProvider interface.
+type Discovery struct {
+	client        *http.Client
+	role          config.ScalewayRole
+	apiURL        string
+	zone          string
+	projectID     string
+	secretKey     config.Secret
+	secretKeyFile string
+	port          int
+	interval      time.Duration
+	logger        log.Logger
+}
+
+// NewDiscovery returns a new Scaleway discovery which periodically refreshes
+// its targets.
+func NewDiscovery(conf *config.ScalewaySDConfig, logger log.Logger) *Discovery {
+	return &Discovery{
+		client:        &http.Client{Timeout: time.Duration(conf.RefreshInterval)},
+		role:          conf.Role,
+		apiURL:        strings.TrimSuffix(conf.APIURL, "/"),
+		zone:          conf.Zone,
+		projectID:     conf.ProjectID,
+		secretKey:     conf.SecretKey,
+		secretKeyFile: conf.SecretKeyFile,
+		port:          conf.Port,
+		interval:      time.Duration(conf.RefreshInterval),
+		logger:        logger,
+	}
+}
+
+// Run implements the TargetProvider interface.
+func (d *Discovery) Run(ctx context.Context, ch chan<- []*config.TargetGroup) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		tg, err := d.refresh(ctx)
+		if err != nil {
+			d.logger.Errorf("Error refreshing Scaleway %s targets: %s", d.role, err)
+		} else {
+			select {
+			case ch <- []*config.TargetGroup{tg}:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (d *Discovery) refresh(ctx context.Context) (tg *config.TargetGroup, err error) {
+	t0 := time.Now()
+	defer func() {
+		refreshDuration.WithLabelValues(string(d.role)).Observe(time.Since(t0).Seconds())
+		if err != nil {
+			refreshFailuresCount.WithLabelValues(string(d.role)).Inc()
+		}
+	}()
+
+	var targets []model.LabelSet
+	switch d.role {
+	case config.ScalewayRoleInstance:
+		targets, err = d.instanceTargets(ctx)
+	case config.ScalewayRoleBaremetal:
+		targets, err = d.baremetalTargets(ctx)
+	default:
+		err = fmt.Errorf("unknown role %q", d.role)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &config.TargetGroup{
+		Source:  fmt.Sprintf("%s/%s", d.role, d.zone),
+		Targets: targets,
+	}, nil
+}
+
+// serversPath returns the path of the page of the servers of the zone in the
+// API of the product, filtered by project with the given parameter.
+func (d *Discovery) serversPath(product, projectParam string, page int) string {
+	params := url.Values{
+		"page":     []string{fmt.Sprint(page)},
+		"per_page": []string{fmt.Sprint(perPage)},
+	}
+	if d.projectID != "" {
+		params.Set(projectParam, d.projectID)
+	}
+	return fmt.Sprintf("/%s/v1/zones/%s/servers?%s", product, url.PathEscape(d.zone), params.Encode())
+}
+
+// get decodes the JSON response to a GET request of the path of the API.
+func (d *Discovery) get(ctx context.Context, path string, v interface{}) (*http.Response, error) {
+	secret, err := config.ReadSecret(d.secretKey, d.secretKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("GET", d.apiURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Auth-Token", secret)
+	resp, err := ctxhttp.Do(ctx, d.client, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("request to %s failed with status %s", path, resp.Status)
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return nil, fmt.Errorf("could not decode response to %s: %s", path, err)
+	}
+	return resp, nil
+}
+
+func joinTags(tags []string) model.LabelValue {
+	// We surround the separated list with the separator as well. This way regular expressions
+	// in relabeling rules don't have to consider tag positions.
+	return model.LabelValue(separator + strings.Join(tags, separator) + separator)
+}
@@ -0,0 +1,223 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scaleway
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/prometheus/common/log"
+	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
+
+	"github.com/prometheus/prometheus/config"
+)
+
+func TestInstanceRefresh(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token := r.Header.Get("X-Auth-Token"); token != "secret" {
+			t.Errorf("Expected secret key as auth token, got %q", token)
+		}
+		if r.URL.Path != "/instance/v1/zones/fr-par-1/servers" {
+			http.NotFound(w, r)
+			return
+		}
+		if p := r.URL.Query().Get("project"); p != "project-1" {
+			t.Errorf("Expected servers to be filtered by project, got %q", p)
+		}
+		w.Header().Set("X-Total-Count", "2")
+		switch r.URL.Query().Get("page") {
+		case "1":
+			fmt.Fprint(w, `{"servers": [{
+				"id": "93c18a61-b681-49d0-a1cc-62b43883ae89",
+				"name": "scw-nervous-shirley",
+				"hostname": "scw-nervous-shirley",
+				"project": "project-1",
+				"organization": "org-1",
+				"commercial_type": "DEV1-S",
+				"state": "running",
+				"zone": "fr-par-1",
+				"tags": ["prometheus", "node"],
+				"image": {"id": "45a86b35-eca6-4055-9b34-ca69845da146", "name": "Ubuntu 20.04 Focal Fossa", "arch": "x86_64"},
+				"private_ip": "10.70.60.57",
+				"public_ip": {"address": "51.158.183.115"},
+				"ipv6": {"address": "2001:bc8:630:1e1c::1"},
+				"security_group": {"id": "984414da-9fc2-49c0-a925-fed6266fe092", "name": "Default security group"}
+			}]}`)
+		case "2":
+			fmt.Fprint(w, `{"servers": [{
+				"id": "5b6198b4-c677-41b5-9c05-04557264ae1f",
+				"name": "scw-public",
+				"hostname": "scw-public",
+				"project": "project-1",
+				"organization": "org-1",
+				"commercial_type": "DEV1-M",
+				"state": "stopped",
+				"zone": "fr-par-1",
+				"tags": [],
+				"image": null,
+				"private_ip": null,
+				"public_ip": {"address": "51.158.183.116"},
+				"ipv6": null,
+				"security_group": null
+			}]}`)
+		default:
+			t.Errorf("Unexpected page %q", r.URL.Query().Get("page"))
+		}
+	}))
+	defer srv.Close()
+
+	d := NewDiscovery(&config.ScalewaySDConfig{
+		Role:      config.ScalewayRoleInstance,
+		APIURL:    srv.URL + "/",
+		Zone:      "fr-par-1",
+		ProjectID: "project-1",
+		SecretKey: "secret",
+		Port:      9100,
+	}, log.Base())
+
+	tg, err := d.refresh(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &config.TargetGroup{
+		Source: "instance/fr-par-1",
+		Targets: []model.LabelSet{
+			{
+				"__address__":                                  "10.70.60.57:9100",
+				"__meta_scaleway_instance_id":                  "93c18a61-b681-49d0-a1cc-62b43883ae89",
+				"__meta_scaleway_instance_name":                "scw-nervous-shirley",
+				"__meta_scaleway_instance_hostname":            "scw-nervous-shirley",
+				"__meta_scaleway_instance_project_id":          "project-1",
+				"__meta_scaleway_instance_organization_id":     "org-1",
+				"__meta_scaleway_instance_type":                "DEV1-S",
+				"__meta_scaleway_instance_status":              "running",
+				"__meta_scaleway_instance_zone":                "fr-par-1",
+				"__meta_scaleway_instance_tags":                ",prometheus,node,",
+				"__meta_scaleway_instance_image_id":            "45a86b35-eca6-4055-9b34-ca69845da146",
+				"__meta_scaleway_instance_image_name":          "Ubuntu 20.04 Focal Fossa",
+				"__meta_scaleway_instance_image_arch":          "x86_64",
+				"__meta_scaleway_instance_private_ipv4":        "10.70.60.57",
+				"__meta_scaleway_instance_public_ipv4":         "51.158.183.115",
+				"__meta_scaleway_instance_public_ipv6":         "2001:bc8:630:1e1c::1",
+				"__meta_scaleway_instance_security_group_id":   "984414da-9fc2-49c0-a925-fed6266fe092",
+				"__meta_scaleway_instance_security_group_name": "Default security group",
+			},
+			{
+				"__address__":                              "51.158.183.116:9100",
+				"__meta_scaleway_instance_id":              "5b6198b4-c677-41b5-9c05-04557264ae1f",
+				"__meta_scaleway_instance_name":            "scw-public",
+				"__meta_scaleway_instance_hostname":        "scw-public",
+				"__meta_scaleway_instance_project_id":      "project-1",
+				"__meta_scaleway_instance_organization_id": "org-1",
+				"__meta_scaleway_instance_type":            "DEV1-M",
+				"__meta_scaleway_instance_status":          "stopped",
+				"__meta_scaleway_instance_zone":            "fr-par-1",
+				"__meta_scaleway_instance_public_ipv4":     "51.158.183.116",
+			},
+		},
+	}
+	if !reflect.DeepEqual(tg, expected) {
+		t.Errorf("Expected target group\n%v\ngot\n%v", expected, tg)
+	}
+}
+
+func TestBaremetalRefresh(t *testing.T) {
+	secretFile, err := ioutil.TempFile("", "scaleway_secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(secretFile.Name())
+	if _, err := secretFile.WriteString("secret\n"); err != nil {
+		t.Fatal(err)
+	}
+	secretFile.Close()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token := r.Header.Get("X-Auth-Token"); token != "secret" {
+			t.Errorf("Expected secret key as auth token, got %q", token)
+		}
+		if r.URL.Path != "/baremetal/v1/zones/fr-par-2/servers" {
+			http.NotFound(w, r)
+			return
+		}
+		if _, ok := r.URL.Query()["project_id"]; ok {
+			t.Errorf("Expected servers of all projects to be listed")
+		}
+		fmt.Fprint(w, `{"total_count": 2, "servers": [{
+			"id": "1c6f3b7d-9c2a-4e4b-8f7a-2b7e3c5d6a10",
+			"name": "scw-baremetal",
+			"project_id": "project-2",
+			"organization_id": "org-1",
+			"offer_name": "EM-A210R-HDD",
+			"status": "ready",
+			"zone": "fr-par-2",
+			"tags": ["storage"],
+			"ips": [
+				{"address": "2001:bc8:1201:14:46a8:42ff:fe2a:8fa4", "version": "IPv6"},
+				{"address": "51.159.71.28", "version": "IPv4"}
+			]
+		}, {
+			"id": "2c6f3b7d-9c2a-4e4b-8f7a-2b7e3c5d6a11",
+			"name": "scw-installing",
+			"project_id": "project-2",
+			"organization_id": "org-1",
+			"offer_name": "EM-A210R-HDD",
+			"status": "delivering",
+			"zone": "fr-par-2",
+			"tags": [],
+			"ips": []
+		}]}`)
+	}))
+	defer srv.Close()
+
+	d := NewDiscovery(&config.ScalewaySDConfig{
+		Role:          config.ScalewayRoleBaremetal,
+		APIURL:        srv.URL,
+		Zone:          "fr-par-2",
+		SecretKeyFile: secretFile.Name(),
+		Port:          80,
+	}, log.Base())
+
+	tg, err := d.refresh(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &config.TargetGroup{
+		Source: "baremetal/fr-par-2",
+		Targets: []model.LabelSet{
+			{
+				"__address__":                               "51.159.71.28:80",
+				"__meta_scaleway_baremetal_id":              "1c6f3b7d-9c2a-4e4b-8f7a-2b7e3c5d6a10",
+				"__meta_scaleway_baremetal_name":            "scw-baremetal",
+				"__meta_scaleway_baremetal_project_id":      "project-2",
+				"__meta_scaleway_baremetal_organization_id": "org-1",
+				"__meta_scaleway_baremetal_type":            "EM-A210R-HDD",
+				"__meta_scaleway_baremetal_status":          "ready",
+				"__meta_scaleway_baremetal_zone":            "fr-par-2",
+				"__meta_scaleway_baremetal_tags":            ",storage,",
+				"__meta_scaleway_baremetal_public_ipv4":     "51.159.71.28",
+				"__meta_scaleway_baremetal_public_ipv6":     "2001:bc8:1201:14:46a8:42ff:fe2a:8fa4",
+			},
+		},
+	}
+	if !reflect.DeepEqual(tg, expected) {
+		t.Errorf("Expected target group\n%v\ngot\n%v", expected, tg)
+	}
+}
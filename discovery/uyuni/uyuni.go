@@ -0,0 +1,353 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uyuni
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
+
+	"github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/util/httputil"
+)
+
+const (
+	uyuniLabel               = model.MetaLabelPrefix + "uyuni_"
+	uyuniLabelSystemID       = uyuniLabel + "system_id"
+	uyuniLabelMinionHostname = uyuniLabel + "minion_hostname"
+	uyuniLabelPrimaryFQDN    = uyuniLabel + "primary_fqdn"
+	uyuniLabelGroups         = uyuniLabel + "groups"
+	uyuniLabelExporter       = uyuniLabel + "exporter"
+	uyuniLabelMetricsPath    = uyuniLabel + "metrics_path"
+	uyuniLabelProxyModule    = uyuniLabel + "proxy_module"
+
+	apiPath = "/rpc/api"
+	// The formula configuring the exporters of the systems.
+	exportersFormula = "prometheus-exporters"
+)
+
+var (
+	refreshFailuresCount = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "prometheus_sd_uyuni_refresh_failures_total",
+			Help: "The number of Uyuni-SD refresh failures.",
+		})
+	refreshDuration = prometheus.NewSummary(
+		prometheus.SummaryOpts{
+			Name: "prometheus_sd_uyuni_refresh_duration_seconds",
+			Help: "The duration of a Uyuni-SD refresh in seconds.",
+		})
+)
+
+func init() {
+	prometheus.MustRegister(refreshFailuresCount)
+	prometheus.MustRegister(refreshDuration)
+}
+
+// system is a system managed by Uyuni with the information needed to build
+// targets of its exporters.
+type system struct {
+	id          int
+	hostname    string
+	primaryFQDN string
+	groups      []string
+	// The exporters formula values of the system.
+	formula map[string]interface{}
+}
+
+// Discovery periodically discovers the exporters configured with the formula
+// on the systems managed by a Uyuni server. It implements the TargetProvider
+// interface.
+type Discovery struct {
+	client       *http.Client
+	server       string
+	username     string
+	password     config.Secret
+	passwordFile string
+	entitlement  string
+	separator    string
+	interval     time.Duration
+	logger       log.Logger
+}
+
+// NewDiscovery returns a new Uyuni discovery which periodically refreshes its
+// targets.
+func NewDiscovery(conf *config.UyuniSDConfig, logger log.Logger) (*Discovery, error) {
+	client, err := httputil.NewClientFromConfig(conf.HTTPClientConfig)
+	if err != nil {
+		return nil, err
+	}
+	client.Timeout = time.Duration(conf.RefreshInterval)
+
+	return &Discovery{
+		client:       client,
+		server:       strings.TrimSuffix(conf.Server, "/"),
+		username:     conf.Username,
+		password:     conf.Password,
+		passwordFile: conf.PasswordFile,
+		entitlement:  conf.Entitlement,
+		separator:    conf.Separator,
+		interval:     time.Duration(conf.RefreshInterval),
+		logger:       logger,
+	}, nil
+}
+
+// Run implements the TargetProvider interface.
+func (d *Discovery) Run(ctx context.Context, ch chan<- []*config.TargetGroup) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		tg, err := d.refresh(ctx)
+		if err != nil {
+			d.logger.Errorf("Error refreshing Uyuni targets: %s", err)
+		} else {
+			select {
+			case ch <- []*config.TargetGroup{tg}:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (d *Discovery) refresh(ctx context.Context) (tg *config.TargetGroup, err error) {
+	t0 := time.Now()
+	defer func() {
+		refreshDuration.Observe(time.Since(t0).Seconds())
+		if err != nil {
+			refreshFailuresCount.Inc()
+		}
+	}()
+
+	password, err := config.ReadSecret(d.password, d.passwordFile)
+	if err != nil {
+		return nil, err
+	}
+	res, err := d.call(ctx, "auth.login", d.username, password)
+	if err != nil {
+		return nil, err
+	}
+	token, ok := res.(string)
+	if !ok {
+		return nil, fmt.Errorf("unexpected session key of type %T", res)
+	}
+	defer func() {
+		if _, err := d.call(ctx, "auth.logout", token); err != nil {
+			d.logger.Debugf("Error logging out of Uyuni: %s", err)
+		}
+	}()
+
+	systems, err := d.systems(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	tg = &config.TargetGroup{
+		Source: d.server,
+	}
+	for _, s := range systems {
+		tg.Targets = append(tg.Targets, d.exporterTargets(s)...)
+	}
+	return tg, nil
+}
+
+// systems returns the systems with the entitlement, sorted by ID.
+func (d *Discovery) systems(ctx context.Context, token string) ([]*system, error) {
+	res, err := d.call(ctx, "system.listSystemsWithEntitlement", token, d.entitlement)
+	if err != nil {
+		return nil, err
+	}
+	byID := map[int]*system{}
+	var ids []int
+	for _, v := range toSlice(res) {
+		id := toInt(toMap(v)["id"])
+		byID[id] = &system{id: id}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	sort.Ints(ids)
+
+	res, err = d.call(ctx, "system.listSystemGroupsForSystemsWithEntitlement", token, d.entitlement)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range toSlice(res) {
+		m := toMap(v)
+		s, ok := byID[toInt(m["id"])]
+		if !ok {
+			continue
+		}
+		for _, g := range toSlice(m["system_groups"]) {
+			s.groups = append(s.groups, toString(toMap(g)["name"]))
+		}
+	}
+
+	res, err = d.call(ctx, "system.getNetworkForSystems", token, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range toSlice(res) {
+		m := toMap(v)
+		s, ok := byID[toInt(m["system_id"])]
+		if !ok {
+			continue
+		}
+		s.hostname = toString(m["hostname"])
+		s.primaryFQDN = toString(m["primary_fqdn"])
+	}
+
+	res, err = d.call(ctx, "formula.getCombinedFormulaDataByServerIds", token, exportersFormula, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range toSlice(res) {
+		m := toMap(v)
+		s, ok := byID[toInt(m["system_id"])]
+		if !ok {
+			continue
+		}
+		s.formula = toMap(m["formula_values"])
+	}
+
+	systems := make([]*system, 0, len(ids))
+	for _, id := range ids {
+		systems = append(systems, byID[id])
+	}
+	return systems, nil
+}
+
+// exporterTargets returns a target for each enabled exporter of the system,
+// either scraped directly or through the exporter proxy of the formula.
+func (d *Discovery) exporterTargets(s *system) []model.LabelSet {
+	host := s.primaryFQDN
+	if host == "" {
+		host = s.hostname
+	}
+	if host == "" {
+		return nil
+	}
+
+	exporters := toMap(s.formula["exporters"])
+	names := make([]string, 0, len(exporters))
+	for name := range exporters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	proxyEnabled := toBool(s.formula["proxy_enabled"])
+	proxyPort := toInt(s.formula["proxy_port"])
+
+	var targets []model.LabelSet
+	for _, name := range names {
+		exporter := toMap(exporters[name])
+		if !toBool(exporter["enabled"]) {
+			continue
+		}
+		labels := model.LabelSet{
+			uyuniLabelSystemID:       model.LabelValue(strconv.Itoa(s.id)),
+			uyuniLabelMinionHostname: model.LabelValue(s.hostname),
+			uyuniLabelPrimaryFQDN:    model.LabelValue(s.primaryFQDN),
+			uyuniLabelExporter:       model.LabelValue(name),
+		}
+		if len(s.groups) > 0 {
+			// We surround the separated list with the separator as well. This way regular expressions
+			// in relabeling rules don't have to consider group positions.
+			labels[uyuniLabelGroups] = model.LabelValue(d.separator + strings.Join(s.groups, d.separator) + d.separator)
+		}
+		if proxyEnabled {
+			labels[model.AddressLabel] = model.LabelValue(net.JoinHostPort(host, strconv.Itoa(proxyPort)))
+			labels[uyuniLabelMetricsPath] = "/proxy"
+			labels[uyuniLabelProxyModule] = model.LabelValue(strings.TrimSuffix(name, "_exporter"))
+		} else {
+			// The address of exporters usually only holds the port they listen
+			// on, like :9100.
+			address := toString(exporter["address"])
+			i := strings.LastIndex(address, ":")
+			if i < 0 {
+				d.logger.Debugf("Ignoring exporter %s of system %d without port: %q", name, s.id, address)
+				continue
+			}
+			labels[model.AddressLabel] = model.LabelValue(net.JoinHostPort(host, address[i+1:]))
+			labels[uyuniLabelMetricsPath] = model.LabelValue(metricsPath(toString(exporter["args"])))
+		}
+		targets = append(targets, labels)
+	}
+	return targets
+}
+
+// metricsPath returns the metrics path set in the command line arguments of an
+// exporter, or the default one.
+func metricsPath(args string) string {
+	fields := strings.Fields(args)
+	for i, f := range fields {
+		if strings.HasPrefix(f, "--web.telemetry-path=") {
+			return strings.TrimPrefix(f, "--web.telemetry-path=")
+		}
+		if f == "--web.telemetry-path" && i+1 < len(fields) {
+			return fields[i+1]
+		}
+	}
+	return "/metrics"
+}
+
+func toSlice(v interface{}) []interface{} {
+	s, _ := v.([]interface{})
+	return s
+}
+
+func toMap(v interface{}) map[string]interface{} {
+	m, _ := v.(map[string]interface{})
+	return m
+}
+
+func toString(v interface{}) string {
+	s, _ := v.(string)
+	return s
+}
+
+func toBool(v interface{}) bool {
+	b, _ := v.(bool)
+	return b
+}
+
+func toInt(v interface{}) int {
+	switch v := v.(type) {
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	case string:
+		i, _ := strconv.Atoi(v)
+		return i
+	}
+	return 0
+}
@@ -0,0 +1,215 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uyuni
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/common/log"
+	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
+
+	"github.com/prometheus/prometheus/config"
+)
+
+// responses are the values returned by the fake API by method.
+var responses = map[string]string{
+	"auth.login":  `<string>session</string>`,
+	"auth.logout": `<int>1</int>`,
+	"system.listSystemsWithEntitlement": `<array><data>
+		<value><struct>
+			<member><name>id</name><value><int>1000010001</int></value></member>
+			<member><name>name</name><value><string>proxied.example.com</string></value></member>
+		</struct></value>
+		<value><struct>
+			<member><name>id</name><value><int>1000010000</int></value></member>
+			<member><name>name</name><value><string>minion.example.com</string></value></member>
+		</struct></value>
+	</data></array>`,
+	"system.listSystemGroupsForSystemsWithEntitlement": `<array><data>
+		<value><struct>
+			<member><name>id</name><value><int>1000010000</int></value></member>
+			<member><name>system_groups</name><value><array><data>
+				<value><struct><member><name>name</name><value>databases</value></member></struct></value>
+				<value><struct><member><name>name</name><value>production</value></member></struct></value>
+			</data></array></value></member>
+		</struct></value>
+	</data></array>`,
+	"system.getNetworkForSystems": `<array><data>
+		<value><struct>
+			<member><name>system_id</name><value><int>1000010000</int></value></member>
+			<member><name>hostname</name><value><string>minion</string></value></member>
+			<member><name>primary_fqdn</name><value><string>minion.example.com</string></value></member>
+			<member><name>ip</name><value><string>10.0.0.10</string></value></member>
+		</struct></value>
+		<value><struct>
+			<member><name>system_id</name><value><int>1000010001</int></value></member>
+			<member><name>hostname</name><value><string>proxied.example.com</string></value></member>
+			<member><name>primary_fqdn</name><value><string></string></value></member>
+		</struct></value>
+	</data></array>`,
+	"formula.getCombinedFormulaDataByServerIds": `<array><data>
+		<value><struct>
+			<member><name>system_id</name><value><int>1000010000</int></value></member>
+			<member><name>formula_values</name><value><struct>
+				<member><name>proxy_enabled</name><value><boolean>0</boolean></value></member>
+				<member><name>exporters</name><value><struct>
+					<member><name>node_exporter</name><value><struct>
+						<member><name>enabled</name><value><boolean>1</boolean></value></member>
+						<member><name>address</name><value><string>:9100</string></value></member>
+						<member><name>args</name><value><string></string></value></member>
+					</struct></value></member>
+					<member><name>postgres_exporter</name><value><struct>
+						<member><name>enabled</name><value><boolean>1</boolean></value></member>
+						<member><name>address</name><value><string>:9187</string></value></member>
+						<member><name>args</name><value><string>--web.telemetry-path /pg-metrics</string></value></member>
+					</struct></value></member>
+					<member><name>apache_exporter</name><value><struct>
+						<member><name>enabled</name><value><boolean>0</boolean></value></member>
+						<member><name>address</name><value><string>:9117</string></value></member>
+					</struct></value></member>
+				</struct></value></member>
+			</struct></value></member>
+		</struct></value>
+		<value><struct>
+			<member><name>system_id</name><value><int>1000010001</int></value></member>
+			<member><name>formula_values</name><value><struct>
+				<member><name>proxy_enabled</name><value><boolean>1</boolean></value></member>
+				<member><name>proxy_port</name><value><int>9999</int></value></member>
+				<member><name>exporters</name><value><struct>
+					<member><name>node_exporter</name><value><struct>
+						<member><name>enabled</name><value><boolean>1</boolean></value></member>
+						<member><name>address</name><value><string>:9100</string></value></member>
+					</struct></value></member>
+				</struct></value></member>
+			</struct></value></member>
+		</struct></value>
+	</data></array>`,
+}
+
+func newTestServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rpc/api" {
+			http.NotFound(w, r)
+			return
+		}
+		var call struct {
+			MethodName string `xml:"methodName"`
+			Params     []struct {
+				Value xmlrpcValue `xml:"value"`
+			} `xml:"params>param"`
+		}
+		if err := xml.NewDecoder(r.Body).Decode(&call); err != nil {
+			t.Errorf("Could not decode call: %s", err)
+			return
+		}
+		if call.MethodName == "auth.login" {
+			if len(call.Params) != 2 || *call.Params[0].Value.String != "gopher" || *call.Params[1].Value.String != "secret" {
+				fmt.Fprint(w, `<methodResponse><fault><value><struct>
+					<member><name>faultCode</name><value><int>2950</int></value></member>
+					<member><name>faultString</name><value><string>Either the password or username is incorrect.</string></value></member>
+				</struct></value></fault></methodResponse>`)
+				return
+			}
+		} else if len(call.Params) == 0 || *call.Params[0].Value.String != "session" {
+			t.Errorf("Expected %s to be called with the session key", call.MethodName)
+		}
+		resp, ok := responses[call.MethodName]
+		if !ok {
+			t.Errorf("Unexpected call to %s", call.MethodName)
+			return
+		}
+		fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?><methodResponse><params><param><value>%s</value></param></params></methodResponse>`, resp)
+	}))
+}
+
+func TestRefresh(t *testing.T) {
+	srv := newTestServer(t)
+	defer srv.Close()
+
+	d, err := NewDiscovery(&config.UyuniSDConfig{
+		Server:      srv.URL,
+		Username:    "gopher",
+		Password:    "secret",
+		Entitlement: "monitoring_entitled",
+		Separator:   ",",
+	}, log.Base())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tg, err := d.refresh(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &config.TargetGroup{
+		Source: srv.URL,
+		Targets: []model.LabelSet{
+			{
+				"__address__":                  "minion.example.com:9100",
+				"__meta_uyuni_system_id":       "1000010000",
+				"__meta_uyuni_minion_hostname": "minion",
+				"__meta_uyuni_primary_fqdn":    "minion.example.com",
+				"__meta_uyuni_groups":          ",databases,production,",
+				"__meta_uyuni_exporter":        "node_exporter",
+				"__meta_uyuni_metrics_path":    "/metrics",
+			},
+			{
+				"__address__":                  "minion.example.com:9187",
+				"__meta_uyuni_system_id":       "1000010000",
+				"__meta_uyuni_minion_hostname": "minion",
+				"__meta_uyuni_primary_fqdn":    "minion.example.com",
+				"__meta_uyuni_groups":          ",databases,production,",
+				"__meta_uyuni_exporter":        "postgres_exporter",
+				"__meta_uyuni_metrics_path":    "/pg-metrics",
+			},
+			{
+				"__address__":                  "proxied.example.com:9999",
+				"__meta_uyuni_system_id":       "1000010001",
+				"__meta_uyuni_minion_hostname": "proxied.example.com",
+				"__meta_uyuni_primary_fqdn":    "",
+				"__meta_uyuni_exporter":        "node_exporter",
+				"__meta_uyuni_metrics_path":    "/proxy",
+				"__meta_uyuni_proxy_module":    "node",
+			},
+		},
+	}
+	if !reflect.DeepEqual(tg, expected) {
+		t.Errorf("Expected target group\n%v\ngot\n%v", expected, tg)
+	}
+}
+
+func TestRefreshLoginFailure(t *testing.T) {
+	srv := newTestServer(t)
+	defer srv.Close()
+
+	d, err := NewDiscovery(&config.UyuniSDConfig{
+		Server:   srv.URL,
+		Username: "gopher",
+		Password: "wrong",
+	}, log.Base())
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = d.refresh(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "Either the password or username is incorrect.") {
+		t.Fatalf("Expected the login fault, got %v", err)
+	}
+}
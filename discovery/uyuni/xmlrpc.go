@@ -0,0 +1,190 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package uyuni
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/context"
+	"golang.org/x/net/context/ctxhttp"
+)
+
+// The Uyuni API only speaks XML-RPC. This file implements the subset of the
+// protocol needed for discovery: calls with string, integer and integer array
+// parameters, and responses decoded to generic values.
+
+// xmlrpcValue is an XML-RPC value as found in responses.
+type xmlrpcValue struct {
+	Text     string  `xml:",chardata"`
+	String   *string `xml:"string"`
+	Int      *string `xml:"int"`
+	I4       *string `xml:"i4"`
+	I8       *string `xml:"i8"`
+	Boolean  *string `xml:"boolean"`
+	Double   *string `xml:"double"`
+	DateTime *string `xml:"dateTime.iso8601"`
+	Struct   *struct {
+		Members []struct {
+			Name  string      `xml:"name"`
+			Value xmlrpcValue `xml:"value"`
+		} `xml:"member"`
+	} `xml:"struct"`
+	Array *struct {
+		Values []xmlrpcValue `xml:"data>value"`
+	} `xml:"array"`
+}
+
+// decode returns the value as a string, int64, bool, float64,
+// map[string]interface{} or []interface{}.
+func (v *xmlrpcValue) decode() (interface{}, error) {
+	switch {
+	case v.String != nil:
+		return *v.String, nil
+	case v.DateTime != nil:
+		return *v.DateTime, nil
+	case v.Int != nil, v.I4 != nil, v.I8 != nil:
+		s := v.Int
+		if s == nil {
+			s = v.I4
+		}
+		if s == nil {
+			s = v.I8
+		}
+		return strconv.ParseInt(strings.TrimSpace(*s), 10, 64)
+	case v.Boolean != nil:
+		return strings.TrimSpace(*v.Boolean) == "1", nil
+	case v.Double != nil:
+		return strconv.ParseFloat(strings.TrimSpace(*v.Double), 64)
+	case v.Struct != nil:
+		m := make(map[string]interface{}, len(v.Struct.Members))
+		for _, member := range v.Struct.Members {
+			mv, err := member.Value.decode()
+			if err != nil {
+				return nil, err
+			}
+			m[member.Name] = mv
+		}
+		return m, nil
+	case v.Array != nil:
+		a := make([]interface{}, 0, len(v.Array.Values))
+		for _, e := range v.Array.Values {
+			ev, err := e.decode()
+			if err != nil {
+				return nil, err
+			}
+			a = append(a, ev)
+		}
+		return a, nil
+	default:
+		// A value without a type is a string.
+		return v.Text, nil
+	}
+}
+
+type xmlrpcResponse struct {
+	Params []struct {
+		Value xmlrpcValue `xml:"value"`
+	} `xml:"params>param"`
+	Fault *struct {
+		Value xmlrpcValue `xml:"value"`
+	} `xml:"fault"`
+}
+
+// encodeValue writes the parameter of a call as an XML-RPC value.
+func encodeValue(buf *bytes.Buffer, v interface{}) error {
+	buf.WriteString("<value>")
+	switch v := v.(type) {
+	case string:
+		buf.WriteString("<string>")
+		if err := xml.EscapeText(buf, []byte(v)); err != nil {
+			return err
+		}
+		buf.WriteString("</string>")
+	case int:
+		fmt.Fprintf(buf, "<int>%d</int>", v)
+	case []int:
+		buf.WriteString("<array><data>")
+		for _, e := range v {
+			if err := encodeValue(buf, e); err != nil {
+				return err
+			}
+		}
+		buf.WriteString("</data></array>")
+	default:
+		return fmt.Errorf("unsupported XML-RPC parameter type %T", v)
+	}
+	buf.WriteString("</value>")
+	return nil
+}
+
+// call calls the XML-RPC method of the API with the parameters and returns its
+// decoded result.
+func (d *Discovery) call(ctx context.Context, method string, params ...interface{}) (interface{}, error) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString("<methodCall><methodName>")
+	if err := xml.EscapeText(&buf, []byte(method)); err != nil {
+		return nil, err
+	}
+	buf.WriteString("</methodName><params>")
+	for _, p := range params {
+		buf.WriteString("<param>")
+		if err := encodeValue(&buf, p); err != nil {
+			return nil, err
+		}
+		buf.WriteString("</param>")
+	}
+	buf.WriteString("</params></methodCall>")
+
+	req, err := http.NewRequest("POST", d.server+apiPath, &buf)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "text/xml")
+	resp, err := ctxhttp.Do(ctx, d.client, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("call to %s failed with status %s", method, resp.Status)
+	}
+	var r xmlrpcResponse
+	if err := xml.Unmarshal(b, &r); err != nil {
+		return nil, fmt.Errorf("could not decode response to %s: %s", method, err)
+	}
+	if r.Fault != nil {
+		fault, err := r.Fault.Value.decode()
+		if err != nil {
+			return nil, err
+		}
+		m, _ := fault.(map[string]interface{})
+		return nil, fmt.Errorf("call to %s failed: %v", method, m["faultString"])
+	}
+	if len(r.Params) != 1 {
+		return nil, fmt.Errorf("unexpected number of values in response to %s: %d", method, len(r.Params))
+	}
+	return r.Params[0].Value.decode()
+}
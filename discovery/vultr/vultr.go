@@ -0,0 +1,288 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vultr
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
+	"golang.org/x/net/context/ctxhttp"
+
+	"github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/util/httputil"
+)
+
+const (
+	vultrLabel                   = model.MetaLabelPrefix + "vultr_instance_"
+	vultrLabelID                 = vultrLabel + "id"
+	vultrLabelLabel              = vultrLabel + "label"
+	vultrLabelHostname           = vultrLabel + "hostname"
+	vultrLabelOS                 = vultrLabel + "os"
+	vultrLabelOSID               = vultrLabel + "os_id"
+	vultrLabelRegion             = vultrLabel + "region"
+	vultrLabelPlan               = vultrLabel + "plan"
+	vultrLabelStatus             = vultrLabel + "status"
+	vultrLabelServerStatus       = vultrLabel + "server_status"
+	vultrLabelMainIP             = vultrLabel + "main_ip"
+	vultrLabelInternalIP         = vultrLabel + "internal_ip"
+	vultrLabelMainIPv6           = vultrLabel + "main_ipv6"
+	vultrLabelVCPUCount          = vultrLabel + "vcpu_count"
+	vultrLabelRAMMB              = vultrLabel + "ram_mb"
+	vultrLabelDiskGB             = vultrLabel + "disk_gb"
+	vultrLabelAllowedBandwidthGB = vultrLabel + "allowed_bandwidth_gb"
+	vultrLabelFeatures           = vultrLabel + "features"
+	vultrLabelTags               = vultrLabel + "tags"
+
+	// The separator of the features and tags lists.
+	separator = ","
+
+	vultrEndpoint = "https://api.vultr.com/v2"
+	// The maximum page size of the API.
+	vultrPerPage = 100
+)
+
+var (
+	refreshFailuresCount = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "prometheus_sd_vultr_refresh_failures_total",
+			Help: "The number of Vultr-SD refresh failures.",
+		})
+	refreshDuration = prometheus.NewSummary(
+		prometheus.SummaryOpts{
+			Name: "prometheus_sd_vultr_refresh_duration_seconds",
+			Help: "The duration of a Vultr-SD refresh in seconds.",
+		})
+)
+
+func init() {
+	prometheus.MustRegister(refreshFailuresCount)
+	prometheus.MustRegister(refreshDuration)
+}
+
+type vultrInstance struct {
+	ID               string   `json:"id"`
+	Label            string   `json:"label"`
+	Hostname         string   `json:"hostname"`
+	OS               string   `json:"os"`
+	OSID             int      `json:"os_id"`
+	Region           string   `json:"region"`
+	Plan             string   `json:"plan"`
+	Status           string   `json:"status"`
+	ServerStatus     string   `json:"server_status"`
+	MainIP           string   `json:"main_ip"`
+	InternalIP       string   `json:"internal_ip"`
+	V6MainIP         string   `json:"v6_main_ip"`
+	VCPUCount        int      `json:"vcpu_count"`
+	RAM              int      `json:"ram"`
+	Disk             int      `json:"disk"`
+	AllowedBandwidth int      `json:"allowed_bandwidth"`
+	Features         []string `json:"features"`
+	Tags             []string `json:"tags"`
+}
+
+// Discovery periodically discovers the instances of a Vultr account. It
+// implements the TargetProvider interface.
+type Discovery struct {
+	client   *http.Client
+	port     int
+	interval time.Duration
+	logger   log.Logger
+
+	// The endpoint of the API.
+	endpoint string
+	// The API allows 30 requests per second and rejects further ones, which
+	// are retried after a backoff.
+	backoff httputil.Backoff
+}
+
+// NewDiscovery returns a new Vultr discovery which periodically refreshes its
+// targets.
+func NewDiscovery(conf *config.VultrSDConfig, logger log.Logger) (*Discovery, error) {
+	client, err := httputil.NewClientFromConfig(conf.HTTPClientConfig)
+	if err != nil {
+		return nil, err
+	}
+	client.Timeout = time.Duration(conf.RefreshInterval)
+
+	return &Discovery{
+		client:   client,
+		port:     conf.Port,
+		interval: time.Duration(conf.RefreshInterval),
+		logger:   logger,
+		endpoint: vultrEndpoint,
+		backoff: httputil.Backoff{
+			Min:     time.Second,
+			Max:     30 * time.Second,
+			Retries: 3,
+		},
+	}, nil
+}
+
+// Run implements the TargetProvider interface.
+func (d *Discovery) Run(ctx context.Context, ch chan<- []*config.TargetGroup) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		tg, err := d.refresh(ctx)
+		if err != nil {
+			d.logger.Errorf("Error refreshing Vultr targets: %s", err)
+		} else {
+			select {
+			case ch <- []*config.TargetGroup{tg}:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (d *Discovery) refresh(ctx context.Context) (tg *config.TargetGroup, err error) {
+	t0 := time.Now()
+	defer func() {
+		refreshDuration.Observe(time.Since(t0).Seconds())
+		if err != nil {
+			refreshFailuresCount.Inc()
+		}
+	}()
+
+	tg = &config.TargetGroup{
+		Source: d.endpoint,
+	}
+	// The instances are paginated with an opaque cursor.
+	cursor := ""
+	for {
+		params := url.Values{"per_page": []string{strconv.Itoa(vultrPerPage)}}
+		if cursor != "" {
+			params.Set("cursor", cursor)
+		}
+		var resp struct {
+			Instances []vultrInstance `json:"instances"`
+			Meta      struct {
+				Links struct {
+					Next string `json:"next"`
+				} `json:"links"`
+			} `json:"meta"`
+		}
+		if err := d.get(ctx, "/instances?"+params.Encode(), &resp); err != nil {
+			return nil, fmt.Errorf("could not list instances: %s", err)
+		}
+		for _, inst := range resp.Instances {
+			if labels := d.instanceLabels(inst); labels != nil {
+				tg.Targets = append(tg.Targets, labels)
+			}
+		}
+		cursor = resp.Meta.Links.Next
+		if cursor == "" {
+			break
+		}
+	}
+	return tg, nil
+}
+
+// instanceLabels returns the labels of the instance, addressed by its main
+// IPv4 address, or nil if it has none yet.
+func (d *Discovery) instanceLabels(inst vultrInstance) model.LabelSet {
+	// Instances being installed have no address yet.
+	if inst.MainIP == "" || inst.MainIP == "0.0.0.0" {
+		return nil
+	}
+	labels := model.LabelSet{
+		model.AddressLabel:           model.LabelValue(net.JoinHostPort(inst.MainIP, strconv.Itoa(d.port))),
+		vultrLabelID:                 model.LabelValue(inst.ID),
+		vultrLabelLabel:              model.LabelValue(inst.Label),
+		vultrLabelHostname:           model.LabelValue(inst.Hostname),
+		vultrLabelOS:                 model.LabelValue(inst.OS),
+		vultrLabelOSID:               model.LabelValue(strconv.Itoa(inst.OSID)),
+		vultrLabelRegion:             model.LabelValue(inst.Region),
+		vultrLabelPlan:               model.LabelValue(inst.Plan),
+		vultrLabelStatus:             model.LabelValue(inst.Status),
+		vultrLabelServerStatus:       model.LabelValue(inst.ServerStatus),
+		vultrLabelMainIP:             model.LabelValue(inst.MainIP),
+		vultrLabelVCPUCount:          model.LabelValue(strconv.Itoa(inst.VCPUCount)),
+		vultrLabelRAMMB:              model.LabelValue(strconv.Itoa(inst.RAM)),
+		vultrLabelDiskGB:             model.LabelValue(strconv.Itoa(inst.Disk)),
+		vultrLabelAllowedBandwidthGB: model.LabelValue(strconv.Itoa(inst.AllowedBandwidth)),
+	}
+	if inst.InternalIP != "" {
+		labels[vultrLabelInternalIP] = model.LabelValue(inst.InternalIP)
+	}
+	if inst.V6MainIP != "" {
+		labels[vultrLabelMainIPv6] = model.LabelValue(inst.V6MainIP)
+	}
+	// We surround the separated lists with the separator as well. This way regular expressions
+	// in relabeling rules don't have to consider feature and tag positions.
+	if len(inst.Features) > 0 {
+		labels[vultrLabelFeatures] = model.LabelValue(separator + strings.Join(inst.Features, separator) + separator)
+	}
+	if len(inst.Tags) > 0 {
+		labels[vultrLabelTags] = model.LabelValue(separator + strings.Join(inst.Tags, separator) + separator)
+	}
+	return labels
+}
+
+// get decodes the JSON response to a GET request of the path of the API,
+// retrying rejected and failed requests after a backoff.
+func (d *Discovery) get(ctx context.Context, path string, v interface{}) error {
+	for retry := 0; ; retry++ {
+		req, err := http.NewRequest("GET", d.endpoint+path, nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Accept", "application/json")
+		resp, err := ctxhttp.Do(ctx, d.client, req)
+		if err != nil {
+			return err
+		}
+		b, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode/100 == 2 {
+			if err := json.Unmarshal(b, v); err != nil {
+				return fmt.Errorf("could not decode response to %s: %s", path, err)
+			}
+			return nil
+		}
+		delay, ok := d.backoff.Delay(resp, retry)
+		if !ok {
+			return fmt.Errorf("request to %s failed with status %s", path, resp.Status)
+		}
+		d.logger.Debugf("Retrying request to %s failed with status %s in %s", path, resp.Status, delay)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vultr
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/log"
+	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
+
+	"github.com/prometheus/prometheus/config"
+)
+
+func newTestDiscovery(t *testing.T, url string) *Discovery {
+	d, err := NewDiscovery(&config.VultrSDConfig{
+		Port: 9100,
+		HTTPClientConfig: config.HTTPClientConfig{
+			BearerToken: "key",
+		},
+	}, log.Base())
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.endpoint = url
+	d.backoff.Min = time.Millisecond
+	d.backoff.Max = time.Millisecond
+	return d
+}
+
+func TestRefresh(t *testing.T) {
+	rejected := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer key" {
+			t.Errorf("Expected the API key as bearer token, got %q", auth)
+		}
+		switch r.URL.RequestURI() {
+		case "/instances?per_page=100":
+			fmt.Fprint(w, `{
+				"instances": [{
+					"id": "dbdbd38c-9884-4c92-95fe-899e50dee717",
+					"os": "Ubuntu 22.04 LTS x64",
+					"ram": 4096,
+					"disk": 128,
+					"main_ip": "149.28.234.27",
+					"vcpu_count": 2,
+					"region": "ams",
+					"plan": "vhf-2c-4gb",
+					"status": "active",
+					"allowed_bandwidth": 3000,
+					"power_status": "running",
+					"server_status": "ok",
+					"v6_main_ip": "2001:19f0:5001:2b4b:5400:4ff:fe2d:dd0b",
+					"label": "prometheus",
+					"internal_ip": "10.6.96.3",
+					"hostname": "prometheus.example.com",
+					"os_id": 1743,
+					"features": ["ipv6"],
+					"tags": ["monitoring"]
+				}],
+				"meta": {"total": 2, "links": {"next": "bmV4dF9fMg==", "prev": ""}}
+			}`)
+		case "/instances?cursor=bmV4dF9fMg%3D%3D&per_page=100":
+			// The API rate limit is exceeded once.
+			if !rejected {
+				rejected = true
+				http.Error(w, `{"error": "Rate limit exceeded", "status": 429}`, http.StatusTooManyRequests)
+				return
+			}
+			fmt.Fprint(w, `{
+				"instances": [{
+					"id": "fccb117c-62f7-4b17-995d-a8e56dd30b33",
+					"os": "Debian 11 x64 (bullseye)",
+					"ram": 1024,
+					"disk": 25,
+					"main_ip": "45.63.42.112",
+					"vcpu_count": 1,
+					"region": "fra",
+					"plan": "vc2-1c-1gb",
+					"status": "active",
+					"allowed_bandwidth": 1000,
+					"server_status": "ok",
+					"v6_main_ip": "",
+					"label": "",
+					"internal_ip": "",
+					"hostname": "vultr.guest",
+					"os_id": 477,
+					"features": [],
+					"tags": []
+				}, {
+					"id": "f8e6e6b0-9271-4d3f-9c8e-a9c0bb9a1c15",
+					"main_ip": "0.0.0.0",
+					"status": "pending",
+					"server_status": "none"
+				}],
+				"meta": {"total": 2, "links": {"next": "", "prev": "cHJldl9fMQ=="}}
+			}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	d := newTestDiscovery(t, srv.URL)
+	tg, err := d.refresh(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &config.TargetGroup{
+		Source: srv.URL,
+		Targets: []model.LabelSet{
+			{
+				"__address__":                                "149.28.234.27:9100",
+				"__meta_vultr_instance_id":                   "dbdbd38c-9884-4c92-95fe-899e50dee717",
+				"__meta_vultr_instance_label":                "prometheus",
+				"__meta_vultr_instance_hostname":             "prometheus.example.com",
+				"__meta_vultr_instance_os":                   "Ubuntu 22.04 LTS x64",
+				"__meta_vultr_instance_os_id":                "1743",
+				"__meta_vultr_instance_region":               "ams",
+				"__meta_vultr_instance_plan":                 "vhf-2c-4gb",
+				"__meta_vultr_instance_status":               "active",
+				"__meta_vultr_instance_server_status":        "ok",
+				"__meta_vultr_instance_main_ip":              "149.28.234.27",
+				"__meta_vultr_instance_internal_ip":          "10.6.96.3",
+				"__meta_vultr_instance_main_ipv6":            "2001:19f0:5001:2b4b:5400:4ff:fe2d:dd0b",
+				"__meta_vultr_instance_vcpu_count":           "2",
+				"__meta_vultr_instance_ram_mb":               "4096",
+				"__meta_vultr_instance_disk_gb":              "128",
+				"__meta_vultr_instance_allowed_bandwidth_gb": "3000",
+				"__meta_vultr_instance_features":             ",ipv6,",
+				"__meta_vultr_instance_tags":                 ",monitoring,",
+			},
+			{
+				"__address__":                                "45.63.42.112:9100",
+				"__meta_vultr_instance_id":                   "fccb117c-62f7-4b17-995d-a8e56dd30b33",
+				"__meta_vultr_instance_label":                "",
+				"__meta_vultr_instance_hostname":             "vultr.guest",
+				"__meta_vultr_instance_os":                   "Debian 11 x64 (bullseye)",
+				"__meta_vultr_instance_os_id":                "477",
+				"__meta_vultr_instance_region":               "fra",
+				"__meta_vultr_instance_plan":                 "vc2-1c-1gb",
+				"__meta_vultr_instance_status":               "active",
+				"__meta_vultr_instance_server_status":        "ok",
+				"__meta_vultr_instance_main_ip":              "45.63.42.112",
+				"__meta_vultr_instance_vcpu_count":           "1",
+				"__meta_vultr_instance_ram_mb":               "1024",
+				"__meta_vultr_instance_disk_gb":              "25",
+				"__meta_vultr_instance_allowed_bandwidth_gb": "1000",
+			},
+		},
+	}
+	if !reflect.DeepEqual(tg, expected) {
+		t.Errorf("Expected target group\n%v\ngot\n%v", expected, tg)
+	}
+}
+
+func TestRefreshRetries(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		http.Error(w, `{"error": "Rate limit exceeded", "status": 429}`, http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	d := newTestDiscovery(t, srv.URL)
+	if _, err := d.refresh(context.Background()); err == nil {
+		t.Fatal("Expected an error when the rate limit stays exceeded")
+	}
+	if requests != d.backoff.Retries+1 {
+		t.Fatalf("Expected %d requests, got %d", d.backoff.Retries+1, requests)
+	}
+}
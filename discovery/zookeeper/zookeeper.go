@@ -64,7 +64,7 @@ func NewDiscovery(
 	pf func(data []byte, path string) (model.LabelSet, error),
 ) *Discovery {
 	conn, _, err := zk.Connect(srvs, timeout)
-	conn.SetLogger(treecache.ZookeeperLogger{})
+	conn.SetLogger(treecache.NewZookeeperLogger(logger))
 	if err != nil {
 		return nil
 	}
@@ -77,7 +77,7 @@ func NewDiscovery(
 		logger:  logger,
 	}
 	for _, path := range paths {
-		sd.treeCaches = append(sd.treeCaches, treecache.NewZookeeperTreeCache(conn, path, updates))
+		sd.treeCaches = append(sd.treeCaches, treecache.NewZookeeperTreeCache(conn, path, updates, logger))
 	}
 	return sd
 }
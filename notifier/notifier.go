@@ -190,8 +190,8 @@ func (n *Notifier) ApplyConfig(conf *config.Config) error {
 	amSets := []*alertmanagerSet{}
 	ctx, cancel := context.WithCancel(n.ctx)
 
-	for _, cfg := range conf.AlertingConfig.AlertmanagerConfigs {
-		ams, err := newAlertmanagerSet(cfg, n.logger)
+	for i, cfg := range conf.AlertingConfig.AlertmanagerConfigs {
+		ams, err := newAlertmanagerSet(fmt.Sprintf("config-%d", i), cfg, n.logger)
 		if err != nil {
 			return err
 		}
@@ -210,6 +210,11 @@ func (n *Notifier) ApplyConfig(conf *config.Config) error {
 	if n.cancelDiscovery != nil {
 		n.cancelDiscovery()
 	}
+	// The sets of the remaining configurations are replaced by the ones of
+	// the same name, those of removed configurations are gone for good.
+	for i := len(amSets); i < len(n.alertmanagers); i++ {
+		n.alertmanagers[i].ts.DeleteMetrics()
+	}
 
 	n.cancelDiscovery = cancel
 	n.alertmanagers = amSets
@@ -450,7 +455,7 @@ type alertmanagerSet struct {
 	logger log.Logger
 }
 
-func newAlertmanagerSet(cfg *config.AlertmanagerConfig, logger log.Logger) (*alertmanagerSet, error) {
+func newAlertmanagerSet(name string, cfg *config.AlertmanagerConfig, logger log.Logger) (*alertmanagerSet, error) {
 	client, err := httputil.NewClientFromConfig(cfg.HTTPClientConfig)
 	if err != nil {
 		return nil, err
@@ -460,7 +465,7 @@ func newAlertmanagerSet(cfg *config.AlertmanagerConfig, logger log.Logger) (*ale
 		cfg:    cfg,
 		logger: logger,
 	}
-	s.ts = discovery.NewTargetSet(s)
+	s.ts = discovery.NewTargetSet(s, "notify", name)
 
 	return s, nil
 }
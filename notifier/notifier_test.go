@@ -196,6 +196,45 @@ func TestHandlerSendAll(t *testing.T) {
 	}
 }
 
+func TestHandlerSendAllClientConfigs(t *testing.T) {
+	var users [2]string
+	newServer := func(i int) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			users[i], _, _ = r.BasicAuth()
+		}))
+	}
+	server1, server2 := newServer(0), newServer(1)
+	defer server1.Close()
+	defer server2.Close()
+
+	h := New(&Options{}, log.Base())
+	for i, server := range []*httptest.Server{server1, server2} {
+		cfg := &config.AlertmanagerConfig{
+			Timeout: time.Second,
+			HTTPClientConfig: config.HTTPClientConfig{
+				BasicAuth: &config.BasicAuth{
+					Username: fmt.Sprintf("user%d", i),
+					Password: "secret",
+				},
+			},
+		}
+		ams, err := newAlertmanagerSet(fmt.Sprintf("config-%d", i), cfg, log.Base())
+		if err != nil {
+			t.Fatal(err)
+		}
+		u := server.URL
+		ams.ams = []alertmanager{alertmanagerMock{urlf: func() string { return u }}}
+		h.alertmanagers = append(h.alertmanagers, ams)
+	}
+
+	if !h.sendAll(&model.Alert{Labels: model.LabelSet{"alertname": "test"}}) {
+		t.Fatalf("all sends failed unexpectedly")
+	}
+	if users != [2]string{"user0", "user1"} {
+		t.Errorf("Expected each Alertmanager to receive the credentials of its config, got users %q", users)
+	}
+}
+
 func TestCustomDo(t *testing.T) {
 	const testURL = "http://testurl.com/"
 	const testBody = "testbody"
@@ -55,11 +55,17 @@ type Statements []Statement
 
 // AlertStmt represents an added alert rule.
 type AlertStmt struct {
-	Name        string
-	Expr        Expr
-	Duration    time.Duration
-	Labels      model.LabelSet
-	Annotations model.LabelSet
+	Name     string
+	Expr     Expr
+	Duration time.Duration
+	// ResendDelay overrides the minimum delay between sends of the
+	// alerts if it is not zero.
+	ResendDelay time.Duration
+	// SourceTemplate overrides the template of the source links of the
+	// alerts if it is not empty.
+	SourceTemplate string
+	Labels         model.LabelSet
+	Annotations    model.LabelSet
 }
 
 // EvalStmt holds an expression and information on the range it should
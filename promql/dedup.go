@@ -0,0 +1,86 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package promql
+
+import (
+	"github.com/prometheus/common/model"
+
+	"github.com/prometheus/prometheus/storage/metric"
+)
+
+// dedupReplicas returns the indexes of the metrics to keep when merging the
+// metrics that differ only by the replica label ln. Of each group of
+// replicas, the one with the lowest label value is kept. The indexes are in
+// the order of the first metric of each group.
+func dedupReplicas(ln model.LabelName, metrics []metric.Metric) []int {
+	var (
+		keep   []int
+		groups = make(map[model.Fingerprint]int, len(metrics))
+	)
+	for i, m := range metrics {
+		lv, ok := m.Metric[ln]
+		if !ok {
+			// There is nothing to merge series without replicas with.
+			keep = append(keep, i)
+			continue
+		}
+		tmp := m.Metric.Clone()
+		delete(tmp, ln)
+		fp := tmp.Fingerprint()
+
+		j, ok := groups[fp]
+		if !ok {
+			groups[fp] = len(keep)
+			keep = append(keep, i)
+			continue
+		}
+		if lv < metrics[keep[j]].Metric[ln] {
+			keep[j] = i
+		}
+	}
+	return keep
+}
+
+// dedupVector merges the elements of vec differing only by the replica label
+// and removes the label.
+func (ev *evaluator) dedupVector(vec vector) vector {
+	metrics := make([]metric.Metric, len(vec))
+	for i, s := range vec {
+		metrics[i] = s.Metric
+	}
+	res := make(vector, 0, len(vec))
+	for _, i := range dedupReplicas(ev.replicaLabel, metrics) {
+		s := vec[i]
+		s.Metric.Del(ev.replicaLabel)
+		res = append(res, s)
+	}
+	return res
+}
+
+// dedupMatrix merges the series of mat differing only by the replica label
+// and removes the label. The samples of a replica are never mixed with those
+// of another, so that counter resets are not introduced.
+func (ev *evaluator) dedupMatrix(mat matrix) matrix {
+	metrics := make([]metric.Metric, len(mat))
+	for i, ss := range mat {
+		metrics[i] = ss.Metric
+	}
+	res := make(matrix, 0, len(mat))
+	for _, i := range dedupReplicas(ev.replicaLabel, metrics) {
+		ss := mat[i]
+		ss.Metric.Del(ev.replicaLabel)
+		res = append(res, ss)
+	}
+	return res
+}
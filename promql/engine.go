@@ -19,19 +19,25 @@ import (
 	"math"
 	"runtime"
 	"sort"
+	"sync"
 	"time"
 
 	opentracing "github.com/opentracing/opentracing-go"
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/common/log"
 	"github.com/prometheus/common/model"
 	"golang.org/x/net/context"
 
+	"github.com/prometheus/prometheus/storage"
 	"github.com/prometheus/prometheus/storage/local"
 	"github.com/prometheus/prometheus/storage/metric"
+	"github.com/prometheus/prometheus/util/hashutil"
+	"github.com/prometheus/prometheus/util/logging"
 	"github.com/prometheus/prometheus/util/stats"
 )
 
+// logger logs the messages of the query engine.
+var logger = logging.New("query")
+
 const (
 	namespace = "prometheus"
 	subsystem = "engine"
@@ -56,6 +62,12 @@ var (
 		Name:      "queries_concurrent_max",
 		Help:      "The max number of concurrent queries.",
 	})
+	queuedQueries = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: subsystem,
+		Name:      "queries_queued",
+		Help:      "The current number of queries waiting to be executed, by priority.",
+	}, []string{"priority"})
 	queryPrepareTime = prometheus.NewSummary(
 		prometheus.SummaryOpts{
 			Namespace:   namespace,
@@ -97,6 +109,7 @@ var (
 func init() {
 	prometheus.MustRegister(currentQueries)
 	prometheus.MustRegister(maxConcurrentQueries)
+	prometheus.MustRegister(queuedQueries)
 	prometheus.MustRegister(queryPrepareTime)
 	prometheus.MustRegister(queryInnerEval)
 	prometheus.MustRegister(queryResultAppend)
@@ -163,6 +176,12 @@ func (mat matrix) value() model.Matrix {
 type Result struct {
 	Err   error
 	Value model.Value
+	// Non-fatal issues of the evaluation, like storages leaving the value
+	// incomplete.
+	Warnings []string
+	// Whether storages left the value incomplete, as reported in the
+	// Warnings.
+	Incomplete bool
 }
 
 // Vector returns a vector if the result value is one. An error is returned if
@@ -227,6 +246,15 @@ type (
 func (e ErrQueryTimeout) Error() string  { return fmt.Sprintf("query timed out in %s", string(e)) }
 func (e ErrQueryCanceled) Error() string { return fmt.Sprintf("query was canceled in %s", string(e)) }
 
+// ErrVectorMatch is returned if the vector matching of a binary operation
+// found duplicate series.
+type ErrVectorMatch struct {
+	Diagnostic stats.MatchDiagnostic
+	msg        string
+}
+
+func (e ErrVectorMatch) Error() string { return e.msg }
+
 // A Query is derived from an a raw query string and can be run against an engine
 // it is associated with.
 type Query interface {
@@ -236,6 +264,11 @@ type Query interface {
 	Statement() Statement
 	// Stats returns statistics about the lifetime of the query.
 	Stats() *stats.TimerGroup
+	// SampleStats returns the number of samples processed by the query.
+	SampleStats() *stats.QuerySamples
+	// Diagnostics returns the diagnostics of the failed evaluation of the
+	// query, if the engine collects them.
+	Diagnostics() []stats.MatchDiagnostic
 	// Cancel signals that a running query execution should be aborted.
 	Cancel()
 }
@@ -248,6 +281,10 @@ type query struct {
 	stmt Statement
 	// Timer stats for the query execution.
 	stats *stats.TimerGroup
+	// Sample counts of the query execution.
+	samples *stats.QuerySamples
+	// Diagnostics of the failed query execution.
+	diagnostics []stats.MatchDiagnostic
 	// Cancellation function for the query.
 	cancel func()
 
@@ -265,6 +302,16 @@ func (q *query) Stats() *stats.TimerGroup {
 	return q.stats
 }
 
+// SampleStats implements the Query interface.
+func (q *query) SampleStats() *stats.QuerySamples {
+	return q.samples
+}
+
+// Diagnostics implements the Query interface.
+func (q *query) Diagnostics() []stats.MatchDiagnostic {
+	return q.diagnostics
+}
+
 // Cancel implements the Query interface.
 func (q *query) Cancel() {
 	if q.cancel != nil {
@@ -278,8 +325,59 @@ func (q *query) Exec(ctx context.Context) *Result {
 		span.SetTag(queryTag, q.stmt.String())
 	}
 
+	ctx, warnings := storage.WithWarnings(ctx)
 	res, err := q.ng.exec(ctx, q)
-	return &Result{Err: err, Value: res}
+	result := &Result{Err: err, Value: res}
+
+	if s, ok := q.stmt.(*EvalStmt); ok {
+		if w := lookbackWarning(s); w != "" {
+			result.Warnings = append(result.Warnings, w)
+		}
+	}
+	if ws := warnings.Strings(); len(ws) > 0 {
+		result.Warnings = append(result.Warnings, ws...)
+		result.Incomplete = true
+	}
+	return result
+}
+
+// lookbackWarning returns a warning if the instant vector selectors of a range
+// query skip samples because the step exceeds the lookback delta. It only
+// depends on the expression and the step, so that it is the same for any part
+// of the range.
+func lookbackWarning(s *EvalStmt) string {
+	if s.Interval <= StalenessDelta {
+		return ""
+	}
+	var found bool
+	Inspect(s.Expr, func(node Node) bool {
+		if _, ok := node.(*VectorSelector); ok {
+			found = true
+		}
+		return !found
+	})
+	if !found {
+		return ""
+	}
+	return fmt.Sprintf(
+		"query resolution step %s exceeds the lookback delta %s, series may have gaps",
+		model.Duration(s.Interval), model.Duration(StalenessDelta),
+	)
+}
+
+// recordingSpans returns whether ctx holds a span of a sampled trace, to avoid
+// the cost of describing spans otherwise. Span contexts not reporting their
+// sampling state, like the ones of the opentracing.NoopTracer, are assumed
+// not to be recorded.
+func recordingSpans(ctx context.Context) bool {
+	span := opentracing.SpanFromContext(ctx)
+	if span == nil {
+		return false
+	}
+	sc, ok := span.Context().(interface {
+		IsSampled() bool
+	})
+	return ok && sc.IsSampled()
 }
 
 // contextDone returns an error if the context was canceled or timed out.
@@ -332,12 +430,24 @@ func NewEngine(queryable Queryable, o *EngineOptions) *Engine {
 type EngineOptions struct {
 	MaxConcurrentQueries int
 	Timeout              time.Duration
+	// Whether the diagnostics of failed vector matchings are kept in the
+	// query statistics.
+	MatchDiagnostics bool
+	// Maximum number of series a count_values aggregation may return in an
+	// evaluation step, zero if unlimited.
+	MaxCountValuesSeries int
+	// The label distinguishing the replicas of HA pairs. Selected series
+	// differing only by it are merged into the series of the replica with
+	// the lowest label value having data at the evaluation time, and the
+	// label is removed. Deduplication is disabled if empty.
+	ReplicaLabel model.LabelName
 }
 
 // DefaultEngineOptions are the default engine options.
 var DefaultEngineOptions = &EngineOptions{
 	MaxConcurrentQueries: 20,
 	Timeout:              2 * time.Minute,
+	MaxCountValuesSeries: 1000000,
 }
 
 // NewInstantQuery returns an evaluation query for the given expression at the given time.
@@ -376,9 +486,10 @@ func (ng *Engine) newQuery(expr Expr, start, end model.Time, interval time.Durat
 		Interval: interval,
 	}
 	qry := &query{
-		stmt:  es,
-		ng:    ng,
-		stats: stats.NewTimerGroup(),
+		stmt:    es,
+		ng:      ng,
+		stats:   stats.NewTimerGroup(),
+		samples: &stats.QuerySamples{},
 	}
 	return qry
 }
@@ -392,10 +503,11 @@ func (testStmt) stmt()          {}
 
 func (ng *Engine) newTestQuery(f func(context.Context) error) Query {
 	qry := &query{
-		q:     "test statement",
-		stmt:  testStmt(f),
-		ng:    ng,
-		stats: stats.NewTimerGroup(),
+		q:       "test statement",
+		stmt:    testStmt(f),
+		ng:      ng,
+		stats:   stats.NewTimerGroup(),
+		samples: &stats.QuerySamples{},
 	}
 	return qry
 }
@@ -411,8 +523,11 @@ func (ng *Engine) exec(ctx context.Context, q *query) (model.Value, error) {
 	q.cancel = cancel
 
 	queueTimer := q.stats.GetTimer(stats.ExecQueueTime).Start()
+	queueSpan, _ := opentracing.StartSpanFromContext(ctx, "promql.queue")
 
-	if err := ng.gate.Start(ctx); err != nil {
+	err := ng.gate.Start(ctx)
+	queueSpan.Finish()
+	if err != nil {
 		return nil, err
 	}
 	defer ng.gate.Done()
@@ -434,7 +549,11 @@ func (ng *Engine) exec(ctx context.Context, q *query) (model.Value, error) {
 
 	switch s := q.Statement().(type) {
 	case *EvalStmt:
-		return ng.execEvalStmt(ctx, q, s)
+		val, err := ng.execEvalStmt(ctx, q, s)
+		if e, ok := err.(ErrVectorMatch); ok && ng.options.MatchDiagnostics {
+			q.diagnostics = append(q.diagnostics, e.Diagnostic)
+		}
+		return val, err
 	case testStmt:
 		return nil, s(ctx)
 	}
@@ -451,26 +570,43 @@ func (ng *Engine) execEvalStmt(ctx context.Context, query *query, s *EvalStmt) (
 	defer querier.Close()
 
 	prepareTimer := query.stats.GetTimer(stats.QueryPreparationTime).Start()
-	err = ng.populateIterators(ctx, querier, s)
+	prepareSpan, prepareCtx := opentracing.StartSpanFromContext(ctx, "promql.prepare")
+	err = ng.populateIterators(prepareCtx, querier, s)
+	prepareSpan.Finish()
 	prepareTimer.Stop()
 	queryPrepareTime.Observe(prepareTimer.ElapsedTime().Seconds())
 
+	// The iterators of the selectors populated before an error must be
+	// closed as well.
+	defer ng.closeIterators(s)
 	if err != nil {
+		// The storage returns the plain context error of abandoned queries.
+		if cerr := contextDone(ctx, "query preparation"); cerr != nil {
+			return nil, cerr
+		}
 		return nil, err
 	}
-	defer ng.closeIterators(s)
 
 	evalTimer := query.stats.GetTimer(stats.InnerEvalTime).Start()
+	evalSpan, evalCtx := opentracing.StartSpanFromContext(ctx, "promql.eval")
+	defer evalSpan.Finish()
 	// Instant evaluation.
 	if s.Start == s.End && s.Interval == 0 {
 		evaluator := &evaluator{
 			Timestamp: s.Start,
-			ctx:       ctx,
+			ctx:       evalCtx,
+			// Spans for each sub-expression are only recorded for instant
+			// evaluations, as range evaluations evaluate them at every step.
+			traceExprs:           recordingSpans(evalCtx),
+			maxCountValuesSeries: ng.options.MaxCountValuesSeries,
+			replicaLabel:         ng.options.ReplicaLabel,
 		}
 		val, err := evaluator.Eval(s.Expr)
 		if err != nil {
 			return nil, err
 		}
+		query.samples.TotalSamples += int64(evaluator.samplesLoaded)
+		query.samples.PeakSamples = evaluator.samplesLoaded
 
 		// Turn matrix and vector types with protected metrics into
 		// model.* types.
@@ -487,9 +623,12 @@ func (ng *Engine) execEvalStmt(ctx context.Context, query *query, s *EvalStmt) (
 		return val, nil
 	}
 	numSteps := int(s.End.Sub(s.Start) / s.Interval)
+	evalSpan.SetTag("steps", numSteps+1)
 
 	// Range evaluation.
 	sampleStreams := map[model.Fingerprint]*sampleStream{}
+	// Number of samples accumulated in sampleStreams.
+	resultSamples := 0
 	for ts := s.Start; !ts.After(s.End); ts = ts.Add(s.Interval) {
 
 		if err := contextDone(ctx, "range evaluation"); err != nil {
@@ -497,16 +636,23 @@ func (ng *Engine) execEvalStmt(ctx context.Context, query *query, s *EvalStmt) (
 		}
 
 		evaluator := &evaluator{
-			Timestamp: ts,
-			ctx:       ctx,
+			Timestamp:            ts,
+			ctx:                  ctx,
+			maxCountValuesSeries: ng.options.MaxCountValuesSeries,
+			replicaLabel:         ng.options.ReplicaLabel,
 		}
 		val, err := evaluator.Eval(s.Expr)
 		if err != nil {
 			return nil, err
 		}
+		query.samples.TotalSamples += int64(evaluator.samplesLoaded)
+		if cur := resultSamples + evaluator.samplesLoaded; cur > query.samples.PeakSamples {
+			query.samples.PeakSamples = cur
+		}
 
 		switch v := val.(type) {
 		case *model.Scalar:
+			resultSamples++
 			// As the expression type does not change we can safely default to 0
 			// as the fingerprint for scalar expressions.
 			ss := sampleStreams[0]
@@ -519,6 +665,7 @@ func (ng *Engine) execEvalStmt(ctx context.Context, query *query, s *EvalStmt) (
 				Timestamp: v.Timestamp,
 			})
 		case vector:
+			resultSamples += len(v)
 			for _, sample := range v {
 				fp := sample.Metric.Metric.Fingerprint()
 				ss := sampleStreams[fp]
@@ -539,6 +686,7 @@ func (ng *Engine) execEvalStmt(ctx context.Context, query *query, s *EvalStmt) (
 		}
 	}
 	evalTimer.Stop()
+	evalSpan.Finish()
 	queryInnerEval.Observe(evalTimer.ElapsedTime().Seconds())
 
 	if err := contextDone(ctx, "expression evaluation"); err != nil {
@@ -561,7 +709,9 @@ func (ng *Engine) execEvalStmt(ctx context.Context, query *query, s *EvalStmt) (
 	resMatrix := mat.value()
 
 	sortTimer := query.stats.GetTimer(stats.ResultSortTime).Start()
+	sortSpan, _ := opentracing.StartSpanFromContext(ctx, "promql.sort")
 	sort.Sort(resMatrix)
+	sortSpan.Finish()
 	sortTimer.Stop()
 	queryResultSort.Observe(sortTimer.ElapsedTime().Seconds())
 	return resMatrix, nil
@@ -570,6 +720,15 @@ func (ng *Engine) execEvalStmt(ctx context.Context, query *query, s *EvalStmt) (
 func (ng *Engine) populateIterators(ctx context.Context, querier local.Querier, s *EvalStmt) error {
 	var queryErr error
 	Inspect(s.Expr, func(node Node) bool {
+		switch node.(type) {
+		case *VectorSelector, *MatrixSelector:
+		default:
+			return true
+		}
+		span, ctx := opentracing.StartSpanFromContext(ctx, "promql.select")
+		span.SetTag("selector", node.String())
+		defer span.Finish()
+
 		switch n := node.(type) {
 		case *VectorSelector:
 			if s.Start.Equal(s.End) {
@@ -629,6 +788,18 @@ type evaluator struct {
 	ctx context.Context
 
 	Timestamp model.Time
+
+	// Whether to record a span for the evaluation of each sub-expression.
+	traceExprs bool
+
+	// Number of samples loaded from storage by selectors.
+	samplesLoaded int
+
+	// Maximum number of series returned by count_values, zero if unlimited.
+	maxCountValuesSeries int
+
+	// The label by which selected series are deduplicated, if any.
+	replicaLabel model.LabelName
 }
 
 // fatalf causes a panic with the input formatted into an error.
@@ -650,7 +821,7 @@ func (ev *evaluator) recover(errp *error) {
 			buf := make([]byte, 64<<10)
 			buf = buf[:runtime.Stack(buf, false)]
 
-			log.Errorf("parser panic: %v\n%s", e, buf)
+			logger.Errorf("parser panic: %v\n%s", e, buf)
 			*errp = fmt.Errorf("unexpected error")
 		} else {
 			*errp = e.(error)
@@ -737,6 +908,17 @@ func (ev *evaluator) eval(expr Expr) model.Value {
 		ev.error(err)
 	}
 
+	if ev.traceExprs {
+		span, ctx := opentracing.StartSpanFromContext(ev.ctx, "promql.expr")
+		span.SetTag("expr", expr.String())
+		parent := ev.ctx
+		ev.ctx = ctx
+		defer func() {
+			ev.ctx = parent
+			span.Finish()
+		}()
+	}
+
 	switch e := expr.(type) {
 	case *AggregateExpr:
 		vector := ev.evalVector(e.Expr)
@@ -765,6 +947,9 @@ func (ev *evaluator) eval(expr Expr) model.Value {
 				return ev.vectorBinop(e.Op, lhs.(vector), rhs.(vector), e.VectorMatching, e.ReturnBool)
 			}
 		case lt == model.ValVector && rt == model.ValScalar:
+			if e.Op == itemLDefault {
+				return ev.vectorDefault(lhs.(vector), rhs.(*model.Scalar))
+			}
 			return ev.vectorScalarBinop(e.Op, lhs.(vector), rhs.(*model.Scalar), false, e.ReturnBool)
 
 		case lt == model.ValScalar && rt == model.ValVector:
@@ -822,6 +1007,10 @@ func (ev *evaluator) vectorSelector(node *VectorSelector) vector {
 			Timestamp: ev.Timestamp,
 		})
 	}
+	ev.samplesLoaded += len(vec)
+	if ev.replicaLabel != "" {
+		vec = ev.dedupVector(vec)
+	}
 	return vec
 }
 
@@ -838,6 +1027,7 @@ func (ev *evaluator) matrixSelector(node *MatrixSelector) matrix {
 		if len(samplePairs) == 0 {
 			continue
 		}
+		ev.samplesLoaded += len(samplePairs)
 
 		if node.Offset != 0 {
 			for _, sp := range samplePairs {
@@ -851,6 +1041,14 @@ func (ev *evaluator) matrixSelector(node *MatrixSelector) matrix {
 		}
 		sampleStreams = append(sampleStreams, sampleStream)
 	}
+	// Iterators stop returning range values once the query is abandoned,
+	// so the results must not be used then.
+	if err := contextDone(ev.ctx, "series selection"); err != nil {
+		ev.error(err)
+	}
+	if ev.replicaLabel != "" {
+		return ev.dedupMatrix(sampleStreams)
+	}
 	return matrix(sampleStreams)
 }
 
@@ -919,6 +1117,21 @@ func (ev *evaluator) vectorUnless(lhs, rhs vector, matching *VectorMatching) vec
 	return result
 }
 
+// vectorDefault returns lhs, or a single element without labels with the
+// value of rhs if lhs is empty.
+func (ev *evaluator) vectorDefault(lhs vector, rhs *model.Scalar) vector {
+	if len(lhs) > 0 {
+		return lhs
+	}
+	return vector{
+		&sample{
+			Metric:    metric.Metric{},
+			Value:     rhs.Value,
+			Timestamp: ev.Timestamp,
+		},
+	}
+}
+
 // vectorBinop evaluates a binary operation between two vectors, excluding set operators.
 func (ev *evaluator) vectorBinop(op itemType, lhs, rhs vector, matching *VectorMatching, returnBool bool) vector {
 	if matching.Card == CardManyToMany {
@@ -936,6 +1149,12 @@ func (ev *evaluator) vectorBinop(op itemType, lhs, rhs vector, matching *VectorM
 		lhs, rhs = rhs, lhs
 	}
 
+	// The sides of the operation for the error messages.
+	oneSide, manySide := "right", "left"
+	if matching.Card == CardOneToMany {
+		oneSide, manySide = manySide, oneSide
+	}
+
 	// All samples from the rhs hashed by the matching label/values.
 	rightSigs := map[uint64]*sample{}
 
@@ -944,9 +1163,10 @@ func (ev *evaluator) vectorBinop(op itemType, lhs, rhs vector, matching *VectorM
 		sig := sigf(rs.Metric)
 		// The rhs is guaranteed to be the 'one' side. Having multiple samples
 		// with the same signature means that the matching is many-to-many.
-		if _, found := rightSigs[sig]; found {
+		if dup, found := rightSigs[sig]; found {
 			// Many-to-many matching not allowed.
-			ev.errorf("many-to-many matching not allowed: matching labels must be unique on one side")
+			ev.matchError(matching, oneSide, dup.Metric.Metric, rs.Metric.Metric, true,
+				"many-to-many matching not allowed: matching labels must be unique on one side")
 		}
 		rightSigs[sig] = rs
 	}
@@ -957,14 +1177,9 @@ func (ev *evaluator) vectorBinop(op itemType, lhs, rhs vector, matching *VectorM
 
 	// For all lhs samples find a respective rhs sample and perform
 	// the binary operation.
-	for _, ls := range lhs {
-		sig := sigf(ls.Metric)
-
-		rs, found := rightSigs[sig] // Look for a match in the rhs vector.
-		if !found {
-			continue
-		}
-
+	// binop returns the value of the operation for a lhs sample and its
+	// match, and whether it is part of the result.
+	binop := func(ls, rs *sample) (model.SampleValue, bool) {
 		// Account for potentially swapped sidedness.
 		vl, vr := ls.Value, rs.Value
 		if matching.Card == CardOneToMany {
@@ -977,7 +1192,39 @@ func (ev *evaluator) vectorBinop(op itemType, lhs, rhs vector, matching *VectorM
 			} else {
 				value = 0.0
 			}
-		} else if !keep {
+			keep = true
+		}
+		return value, keep
+	}
+	// firstMatch returns the first of the lhs samples before the i-th one
+	// which is part of the result with the given metric, to name it in
+	// error messages.
+	firstMatch := func(i int, sig uint64, m model.Metric) model.Metric {
+		for _, ls := range lhs[:i] {
+			if sigf(ls.Metric) != sig {
+				continue
+			}
+			rs := rightSigs[sig]
+			if _, keep := binop(ls, rs); !keep {
+				continue
+			}
+			if m == nil || resultMetric(ls.Metric, rs.Metric, op, matching).Metric.Equal(m) {
+				return ls.Metric.Metric
+			}
+		}
+		return nil
+	}
+
+	for i, ls := range lhs {
+		sig := sigf(ls.Metric)
+
+		rs, found := rightSigs[sig] // Look for a match in the rhs vector.
+		if !found {
+			continue
+		}
+
+		value, keep := binop(ls, rs)
+		if !keep {
 			continue
 		}
 		metric := resultMetric(ls.Metric, rs.Metric, op, matching)
@@ -985,7 +1232,8 @@ func (ev *evaluator) vectorBinop(op itemType, lhs, rhs vector, matching *VectorM
 		insertedSigs, exists := matchedSigs[sig]
 		if matching.Card == CardOneToOne {
 			if exists {
-				ev.errorf("multiple matches for labels: many-to-one matching must be explicit (group_left/group_right)")
+				ev.matchError(matching, manySide, firstMatch(i, sig, nil), ls.Metric.Metric, true,
+					"multiple matches for labels: many-to-one matching must be explicit (group_left/group_right)")
 			}
 			matchedSigs[sig] = nil // Set existence to true.
 		} else {
@@ -997,7 +1245,8 @@ func (ev *evaluator) vectorBinop(op itemType, lhs, rhs vector, matching *VectorM
 				insertedSigs = map[uint64]struct{}{}
 				matchedSigs[sig] = insertedSigs
 			} else if _, duplicate := insertedSigs[insertSig]; duplicate {
-				ev.errorf("multiple matches for labels: grouping labels must ensure unique matches")
+				ev.matchError(matching, manySide, firstMatch(i, sig, metric.Metric), ls.Metric.Metric, false,
+					"multiple matches for labels: grouping labels must ensure unique matches")
 			}
 			insertedSigs[insertSig] = struct{}{}
 		}
@@ -1011,6 +1260,60 @@ func (ev *evaluator) vectorBinop(op itemType, lhs, rhs vector, matching *VectorM
 	return result
 }
 
+// matchError aborts the evaluation with an error naming the two series of
+// the given side of the operation which caused its vector matching to fail,
+// and if suggest is set, the labels that would tell them apart.
+func (ev *evaluator) matchError(matching *VectorMatching, side string, a, b model.Metric, suggest bool, problem string) {
+	group := model.Metric{}
+	if matching.On {
+		for _, ln := range matching.MatchingLabels {
+			if lv, ok := a[ln]; ok {
+				group[ln] = lv
+			}
+		}
+	} else {
+		for ln, lv := range a {
+			group[ln] = lv
+		}
+		for _, ln := range matching.MatchingLabels {
+			delete(group, ln)
+		}
+		delete(group, model.MetricNameLabel)
+	}
+
+	if b.Before(a) {
+		a, b = b, a
+	}
+	d := stats.MatchDiagnostic{
+		Side:       side,
+		MatchGroup: group,
+		Series:     []model.Metric{a, b},
+	}
+	msg := fmt.Sprintf("found duplicate series for the match group %s on the %s hand-side of the operation: [%s, %s]; %s", group, side, a, b, problem)
+	if suggest {
+		// The metric name is never part of the matching labels.
+		for ln, lv := range a {
+			if ln != model.MetricNameLabel && b[ln] != lv {
+				d.SuggestedLabels = append(d.SuggestedLabels, ln)
+			}
+		}
+		for ln := range b {
+			if _, ok := a[ln]; !ok && ln != model.MetricNameLabel {
+				d.SuggestedLabels = append(d.SuggestedLabels, ln)
+			}
+		}
+		sort.Sort(d.SuggestedLabels)
+	}
+	if len(d.SuggestedLabels) > 0 {
+		if matching.On {
+			msg += fmt.Sprintf("; consider adding one of the labels %s to on()", d.SuggestedLabels)
+		} else {
+			msg += fmt.Sprintf("; consider removing one of the labels %s from ignoring()", d.SuggestedLabels)
+		}
+	}
+	ev.error(ErrVectorMatch{Diagnostic: d, msg: msg})
+}
+
 // signatureFunc returns a function that calculates the signature for a metric
 // ignoring the provided labels. If on, then the given labels are only used instead.
 func signatureFunc(on bool, labels ...model.LabelName) func(m metric.Metric) uint64 {
@@ -1179,6 +1482,106 @@ func labelIntersection(metric1, metric2 metric.Metric) metric.Metric {
 	return metric1
 }
 
+// groupingKeyer computes the grouping keys and labels of the samples of an
+// aggregation without copying their metrics.
+type groupingKeyer struct {
+	without bool
+	// The sorted labels to group by.
+	labels model.LabelNames
+	// The labels to exclude from the grouping if without is set.
+	excluded map[model.LabelName]struct{}
+	// The label count_values sets to the value of the samples.
+	countValues bool
+	valueLabel  model.LabelName
+	// Buffer for the labels of a sample in without mode.
+	names model.LabelNames
+}
+
+func newGroupingKeyer(grouping model.LabelNames, without, countValues bool, valueLabel model.LabelName) *groupingKeyer {
+	gk := &groupingKeyer{
+		without:     without,
+		countValues: countValues,
+		valueLabel:  valueLabel,
+	}
+	if without {
+		gk.excluded = make(map[model.LabelName]struct{}, len(grouping)+2)
+		for _, ln := range grouping {
+			gk.excluded[ln] = struct{}{}
+		}
+		gk.excluded[model.MetricNameLabel] = struct{}{}
+		if countValues {
+			// The value label is added back with the sample value.
+			gk.excluded[valueLabel] = struct{}{}
+		}
+		return gk
+	}
+	gk.labels = make(model.LabelNames, 0, len(grouping)+1)
+	for _, ln := range grouping {
+		if !countValues || ln != valueLabel {
+			gk.labels = append(gk.labels, ln)
+		}
+	}
+	if countValues {
+		gk.labels = append(gk.labels, valueLabel)
+	}
+	sort.Sort(gk.labels)
+	return gk
+}
+
+// key returns the grouping key of a sample with the given metric and, for
+// count_values, formatted value.
+func (gk *groupingKeyer) key(m model.Metric, value model.LabelValue) uint64 {
+	names := gk.labels
+	if gk.without {
+		gk.names = gk.names[:0]
+		for ln := range m {
+			if _, ok := gk.excluded[ln]; !ok {
+				gk.names = append(gk.names, ln)
+			}
+		}
+		if gk.countValues {
+			gk.names = append(gk.names, gk.valueLabel)
+		}
+		sort.Sort(&gk.names)
+		names = gk.names
+	}
+
+	h := hashutil.New()
+	for _, ln := range names {
+		lv := m[ln]
+		if gk.countValues && ln == gk.valueLabel {
+			lv = value
+		}
+		h = hashutil.Add(h, string(ln))
+		h = hashutil.AddByte(h, model.SeparatorByte)
+		h = hashutil.Add(h, string(lv))
+		h = hashutil.AddByte(h, model.SeparatorByte)
+	}
+	return h
+}
+
+// metric returns the labels of the group of a sample.
+func (gk *groupingKeyer) metric(m model.Metric, value model.LabelValue) model.Metric {
+	res := model.Metric{}
+	if gk.without {
+		for ln, lv := range m {
+			if _, ok := gk.excluded[ln]; !ok {
+				res[ln] = lv
+			}
+		}
+	} else {
+		for _, ln := range gk.labels {
+			if lv, ok := m[ln]; ok {
+				res[ln] = lv
+			}
+		}
+	}
+	if gk.countValues {
+		res[gk.valueLabel] = value
+	}
+	return res
+}
+
 type groupedAggregation struct {
 	labels           metric.Metric
 	value            model.SampleValue
@@ -1206,53 +1609,39 @@ func (ev *evaluator) aggregation(op itemType, grouping model.LabelNames, without
 	var valueLabel model.LabelName
 	if op == itemCountValues {
 		valueLabel = model.LabelName(ev.evalString(param).Value)
-		if !without {
-			grouping = append(grouping, valueLabel)
-		}
 	}
+	// The heaps of topk and bottomk never hold more than all samples.
+	heapSize := int(k)
+	if int64(len(vec)) < k {
+		heapSize = len(vec)
+	}
+	gk := newGroupingKeyer(grouping, without, op == itemCountValues, valueLabel)
 
 	for _, s := range vec {
-		withoutMetric := s.Metric
-		if without {
-			for _, l := range grouping {
-				withoutMetric.Del(l)
-			}
-			withoutMetric.Del(model.MetricNameLabel)
-			if op == itemCountValues {
-				withoutMetric.Set(valueLabel, model.LabelValue(s.Value.String()))
-			}
-		} else {
-			if op == itemCountValues {
-				s.Metric.Set(valueLabel, model.LabelValue(s.Value.String()))
-			}
-		}
-
-		var groupingKey uint64
-		if without {
-			groupingKey = uint64(withoutMetric.Metric.Fingerprint())
-		} else {
-			groupingKey = model.SignatureForLabels(s.Metric.Metric, grouping...)
+		var value model.LabelValue
+		if op == itemCountValues {
+			value = model.LabelValue(s.Value.String())
 		}
+		groupingKey := gk.key(s.Metric.Metric, value)
 
 		groupedResult, ok := result[groupingKey]
 		// Add a new group if it doesn't exist.
 		if !ok {
+			if op == itemCountValues && ev.maxCountValuesSeries > 0 && len(result) >= ev.maxCountValuesSeries {
+				ev.errorf("count_values would return more than %d series, consider grouping by fewer labels", ev.maxCountValuesSeries)
+			}
 			var m metric.Metric
 			if keepCommon {
 				m = s.Metric
 				m.Del(model.MetricNameLabel)
-			} else if without {
-				m = withoutMetric
+				if op == itemCountValues {
+					m.Set(valueLabel, value)
+				}
 			} else {
 				m = metric.Metric{
-					Metric: model.Metric{},
+					Metric: gk.metric(s.Metric.Metric, value),
 					Copied: true,
 				}
-				for _, l := range grouping {
-					if v, ok := s.Metric.Metric[l]; ok {
-						m.Set(l, v)
-					}
-				}
 			}
 			result[groupingKey] = &groupedAggregation{
 				labels:           m,
@@ -1261,17 +1650,21 @@ func (ev *evaluator) aggregation(op itemType, grouping model.LabelNames, without
 				groupCount:       1,
 			}
 			if op == itemTopK || op == itemQuantile {
-				result[groupingKey].heap = make(vectorByValueHeap, 0, k)
-				heap.Push(&result[groupingKey].heap, &sample{Value: s.Value, Metric: s.Metric})
+				result[groupingKey].heap = make(vectorByValueHeap, 0, heapSize)
+				heap.Push(&result[groupingKey].heap, s)
 			} else if op == itemBottomK {
-				result[groupingKey].reverseHeap = make(vectorByReverseValueHeap, 0, k)
-				heap.Push(&result[groupingKey].reverseHeap, &sample{Value: s.Value, Metric: s.Metric})
+				result[groupingKey].reverseHeap = make(vectorByReverseValueHeap, 0, heapSize)
+				heap.Push(&result[groupingKey].reverseHeap, s)
 			}
 			continue
 		}
 		// Add the sample to the existing group.
 		if keepCommon {
-			groupedResult.labels = labelIntersection(groupedResult.labels, s.Metric)
+			sm := s.Metric
+			if op == itemCountValues {
+				sm.Set(valueLabel, model.LabelValue(s.Value.String()))
+			}
+			groupedResult.labels = labelIntersection(groupedResult.labels, sm)
 		}
 
 		switch op {
@@ -1299,14 +1692,14 @@ func (ev *evaluator) aggregation(op itemType, grouping model.LabelNames, without
 				if int64(len(groupedResult.heap)) == k {
 					heap.Pop(&groupedResult.heap)
 				}
-				heap.Push(&groupedResult.heap, &sample{Value: s.Value, Metric: s.Metric})
+				heap.Push(&groupedResult.heap, s)
 			}
 		case itemBottomK:
 			if int64(len(groupedResult.reverseHeap)) < k || groupedResult.reverseHeap[0].Value > s.Value || math.IsNaN(float64(groupedResult.reverseHeap[0].Value)) {
 				if int64(len(groupedResult.reverseHeap)) == k {
 					heap.Pop(&groupedResult.reverseHeap)
 				}
-				heap.Push(&groupedResult.reverseHeap, &sample{Value: s.Value, Metric: s.Metric})
+				heap.Push(&groupedResult.reverseHeap, s)
 			}
 		case itemQuantile:
 			groupedResult.heap = append(groupedResult.heap, s)
@@ -1390,36 +1783,139 @@ func shouldDropMetricName(op itemType) bool {
 // series is considered stale.
 var StalenessDelta = 5 * time.Minute
 
-// A queryGate controls the maximum number of concurrently running and waiting queries.
+// QueryPriority is the class of a query waiting for the engine. Waiting
+// queries of a higher priority are always executed first.
+type QueryPriority int
+
+// The priorities of queries, from the lowest to the highest.
+const (
+	PriorityFederation QueryPriority = iota
+	PriorityAPI
+	PriorityRules
+
+	numPriorities = int(PriorityRules) + 1
+)
+
+func (p QueryPriority) String() string {
+	switch p {
+	case PriorityFederation:
+		return "federation"
+	case PriorityAPI:
+		return "api"
+	case PriorityRules:
+		return "rules"
+	}
+	return fmt.Sprintf("QueryPriority(%d)", int(p))
+}
+
+type priorityKey struct{}
+
+// WithPriority returns a context whose queries wait for the engine with the
+// given priority. Queries of a context without a priority have PriorityAPI.
+func WithPriority(ctx context.Context, p QueryPriority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, p)
+}
+
+func priorityFromContext(ctx context.Context) QueryPriority {
+	if p, ok := ctx.Value(priorityKey{}).(QueryPriority); ok && p >= 0 && int(p) < numPriorities {
+		return p
+	}
+	return PriorityAPI
+}
+
+// Admit blocks until the engine could execute a query with the priority of the
+// context, or until the context is done. The returned function must be called
+// once the work is done. It lets work that reads from the storage outside of
+// the engine, like federation, share the limit of concurrent queries.
+func (ng *Engine) Admit(ctx context.Context) (func(), error) {
+	if err := ng.gate.Start(ctx); err != nil {
+		return nil, err
+	}
+	return ng.gate.Done, nil
+}
+
+// A queryGate controls the maximum number of concurrently running queries.
+// Waiting queries are started by priority, and in the order they arrived
+// within a priority.
 type queryGate struct {
-	ch chan struct{}
+	mtx     sync.Mutex
+	max     int
+	running int
+	waiting [numPriorities][]chan struct{}
 }
 
 // newQueryGate returns a query gate that limits the number of queries
 // being concurrently executed.
 func newQueryGate(length int) *queryGate {
-	return &queryGate{
-		ch: make(chan struct{}, length),
-	}
+	return &queryGate{max: length}
 }
 
 // Start blocks until the gate has a free spot or the context is done.
 func (g *queryGate) Start(ctx context.Context) error {
+	p := priorityFromContext(ctx)
+
+	g.mtx.Lock()
+	if g.running < g.max && g.numWaiting() == 0 {
+		g.running++
+		g.mtx.Unlock()
+		return nil
+	}
+	ch := make(chan struct{})
+	g.waiting[p] = append(g.waiting[p], ch)
+	queuedQueries.WithLabelValues(p.String()).Inc()
+	g.mtx.Unlock()
+
 	select {
-	case <-ctx.Done():
-		return contextDone(ctx, "query queue")
-	case g.ch <- struct{}{}:
+	case <-ch:
 		return nil
+	case <-ctx.Done():
 	}
+
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	for i, w := range g.waiting[p] {
+		if w == ch {
+			g.waiting[p] = append(g.waiting[p][:i], g.waiting[p][i+1:]...)
+			queuedQueries.WithLabelValues(p.String()).Dec()
+			return contextDone(ctx, "query queue")
+		}
+	}
+	// The spot was granted while the context was done.
+	g.release()
+	return contextDone(ctx, "query queue")
 }
 
 // Done releases a single spot in the gate.
 func (g *queryGate) Done() {
-	select {
-	case <-g.ch:
-	default:
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	if g.running == 0 {
 		panic("engine.queryGate.Done: more operations done than started")
 	}
+	g.release()
+}
+
+// release passes the spot of a finished query to the waiting query of the
+// highest priority.
+func (g *queryGate) release() {
+	for p := numPriorities - 1; p >= 0; p-- {
+		if len(g.waiting[p]) > 0 {
+			close(g.waiting[p][0])
+			g.waiting[p] = g.waiting[p][1:]
+			queuedQueries.WithLabelValues(QueryPriority(p).String()).Dec()
+			return
+		}
+	}
+	g.running--
+}
+
+func (g *queryGate) numWaiting() int {
+	n := 0
+	for _, w := range g.waiting {
+		n += len(w)
+	}
+	return n
 }
 
 // documentedType returns the internal type to the equivalent
@@ -14,11 +14,24 @@
 package promql
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
+	"sort"
 	"testing"
 	"time"
 
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/prometheus/common/log"
+	"github.com/prometheus/common/model"
 	"golang.org/x/net/context"
+
+	"github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/prometheus/prometheus/storage/local"
+	"github.com/prometheus/prometheus/storage/metric"
+	"github.com/prometheus/prometheus/tracing"
+	"github.com/prometheus/prometheus/util/stats"
 )
 
 func TestQueryConcurrency(t *testing.T) {
@@ -72,6 +85,85 @@ func TestQueryConcurrency(t *testing.T) {
 	}
 }
 
+func TestQueryPriority(t *testing.T) {
+	engine := NewEngine(nil, &EngineOptions{MaxConcurrentQueries: 1, Timeout: time.Minute})
+
+	// Wait until the given number of queries is running and waiting.
+	waitGate := func(running, waiting int) {
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			engine.gate.mtx.Lock()
+			r, w := engine.gate.running, engine.gate.numWaiting()
+			engine.gate.mtx.Unlock()
+			if r == running && w == waiting {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("Expected %d running and %d waiting queries, got %d and %d", running, waiting, r, w)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	block := make(chan struct{})
+	started := make(chan QueryPriority, 4)
+	go engine.newTestQuery(func(context.Context) error {
+		<-block
+		return nil
+	}).Exec(context.Background())
+	waitGate(1, 0)
+
+	// Queue a query of the given priority and wait until it is queued
+	// before the next one is.
+	queued := 0
+	queue := func(ctx context.Context, p QueryPriority) {
+		ctx = WithPriority(ctx, p)
+		go func() {
+			if p == PriorityFederation {
+				done, err := engine.Admit(ctx)
+				if err != nil {
+					return
+				}
+				started <- p
+				done()
+				return
+			}
+			engine.newTestQuery(func(context.Context) error {
+				started <- p
+				return nil
+			}).Exec(ctx)
+		}()
+		queued++
+		waitGate(1, queued)
+	}
+	queue(context.Background(), PriorityFederation)
+	queue(context.Background(), PriorityAPI)
+	ctx, cancel := context.WithCancel(context.Background())
+	queue(ctx, PriorityRules)
+	cancel()
+	queued--
+	waitGate(1, queued)
+	queue(context.Background(), PriorityRules)
+
+	close(block)
+	for _, expected := range []QueryPriority{PriorityRules, PriorityAPI, PriorityFederation} {
+		select {
+		case p := <-started:
+			if p != expected {
+				t.Errorf("Expected %s query to be executed, got %s", expected, p)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Expected %s query to be executed", expected)
+		}
+	}
+	// The canceled query left the queue before the others were executed.
+	select {
+	case p := <-started:
+		t.Errorf("Unexpected execution of canceled %s query", p)
+	default:
+	}
+}
+
 func TestQueryTimeout(t *testing.T) {
 	engine := NewEngine(nil, &EngineOptions{
 		Timeout:              5 * time.Millisecond,
@@ -223,3 +315,411 @@ func TestRecoverEvaluatorError(t *testing.T) {
 
 	panic(e)
 }
+
+func TestQuerySampleStats(t *testing.T) {
+	test, err := NewTest(t, `
+load 1m
+	metric{a="1"} 0+1x10
+	metric{a="2"} 0+1x10
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer test.Close()
+
+	if err := test.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		query      string
+		start, end model.Time
+		interval   time.Duration
+		total      int64
+		peak       int
+	}{
+		{
+			query: "metric",
+			start: model.TimeFromUnix(300),
+			total: 2,
+			peak:  2,
+		},
+		{
+			query: "metric[2m]",
+			start: model.TimeFromUnix(300),
+			total: 6,
+			peak:  6,
+		},
+		{
+			// Peak samples include the result accumulated in previous steps.
+			query:    "metric",
+			start:    model.TimeFromUnix(0),
+			end:      model.TimeFromUnix(240),
+			interval: time.Minute,
+			total:    10,
+			peak:     10,
+		},
+	}
+
+	for i, c := range tests {
+		var q Query
+		if c.interval == 0 {
+			q, err = test.QueryEngine().NewInstantQuery(c.query, c.start)
+		} else {
+			q, err = test.QueryEngine().NewRangeQuery(c.query, c.start, c.end, c.interval)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res := q.Exec(test.Context()); res.Err != nil {
+			t.Fatal(res.Err)
+		}
+
+		if s := q.SampleStats(); s.TotalSamples != c.total || s.PeakSamples != c.peak {
+			t.Errorf("%d. %s: expected %d total and %d peak samples, got %d and %d", i, c.query, c.total, c.peak, s.TotalSamples, s.PeakSamples)
+		}
+	}
+}
+
+func TestVectorMatchErrors(t *testing.T) {
+	test, err := NewTest(t, `
+load 1m
+	http_requests{job="api", instance="0", method="get"} 1
+	http_requests{job="api", instance="1", method="get"} 2
+	instance_info{job="api", instance="0", version="1"} 1
+	instance_info{job="api", instance="0", version="2"} 1
+	build_info{job="api", instance="0", version="1"} 1
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer test.Close()
+
+	if err := test.Run(); err != nil {
+		t.Fatal(err)
+	}
+	engine := NewEngine(test.Storage(), &EngineOptions{
+		MaxConcurrentQueries: 20,
+		Timeout:              time.Minute,
+		MatchDiagnostics:     true,
+	})
+
+	tests := []struct {
+		query      string
+		err        string
+		diagnostic stats.MatchDiagnostic
+	}{
+		{
+			query: `http_requests * on(job) instance_info`,
+			err:   `found duplicate series for the match group {job="api"} on the right hand-side of the operation: [instance_info{instance="0", job="api", version="1"}, instance_info{instance="0", job="api", version="2"}]; many-to-many matching not allowed: matching labels must be unique on one side; consider adding one of the labels version to on()`,
+			diagnostic: stats.MatchDiagnostic{
+				Side:       "right",
+				MatchGroup: model.Metric{"job": "api"},
+				Series: []model.Metric{
+					{"__name__": "instance_info", "job": "api", "instance": "0", "version": "1"},
+					{"__name__": "instance_info", "job": "api", "instance": "0", "version": "2"},
+				},
+				SuggestedLabels: model.LabelNames{"version"},
+			},
+		},
+		{
+			query: `instance_info * ignoring(instance, version) http_requests`,
+			err:   `found duplicate series for the match group {job="api", method="get"} on the right hand-side of the operation: [http_requests{instance="0", job="api", method="get"}, http_requests{instance="1", job="api", method="get"}]; many-to-many matching not allowed: matching labels must be unique on one side; consider removing one of the labels instance from ignoring()`,
+			diagnostic: stats.MatchDiagnostic{
+				Side:       "right",
+				MatchGroup: model.Metric{"job": "api", "method": "get"},
+				Series: []model.Metric{
+					{"__name__": "http_requests", "job": "api", "instance": "0", "method": "get"},
+					{"__name__": "http_requests", "job": "api", "instance": "1", "method": "get"},
+				},
+				SuggestedLabels: model.LabelNames{"instance"},
+			},
+		},
+		{
+			query: `{__name__=~"build_info|instance_info", version="1"} + on(instance) group_left http_requests{instance="0"}`,
+			err:   `found duplicate series for the match group {instance="0"} on the left hand-side of the operation: [build_info{instance="0", job="api", version="1"}, instance_info{instance="0", job="api", version="1"}]; multiple matches for labels: grouping labels must ensure unique matches`,
+			diagnostic: stats.MatchDiagnostic{
+				Side:       "left",
+				MatchGroup: model.Metric{"instance": "0"},
+				Series: []model.Metric{
+					{"__name__": "build_info", "job": "api", "instance": "0", "version": "1"},
+					{"__name__": "instance_info", "job": "api", "instance": "0", "version": "1"},
+				},
+			},
+		},
+		{
+			query: `instance_info * on(instance) http_requests{instance="0"}`,
+			err:   `found duplicate series for the match group {instance="0"} on the left hand-side of the operation: [instance_info{instance="0", job="api", version="1"}, instance_info{instance="0", job="api", version="2"}]; multiple matches for labels: many-to-one matching must be explicit (group_left/group_right); consider adding one of the labels version to on()`,
+			diagnostic: stats.MatchDiagnostic{
+				Side:       "left",
+				MatchGroup: model.Metric{"instance": "0"},
+				Series: []model.Metric{
+					{"__name__": "instance_info", "job": "api", "instance": "0", "version": "1"},
+					{"__name__": "instance_info", "job": "api", "instance": "0", "version": "2"},
+				},
+				SuggestedLabels: model.LabelNames{"version"},
+			},
+		},
+	}
+
+	for _, c := range tests {
+		q, err := engine.NewInstantQuery(c.query, model.TimeFromUnix(0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		res := q.Exec(test.Context())
+		d := q.Diagnostics()
+		if len(d) != 1 {
+			t.Errorf("%s: expected one diagnostic, got %v (error %v)", c.query, d, res.Err)
+			continue
+		}
+		if res.Err == nil || res.Err.Error() != c.err {
+			t.Errorf("%s: expected error\n%s\ngot\n%v", c.query, c.err, res.Err)
+		}
+		if !reflect.DeepEqual(d[0], c.diagnostic) {
+			t.Errorf("%s: expected diagnostic\n%v\ngot\n%v", c.query, c.diagnostic, d[0])
+		}
+	}
+
+	// Diagnostics are only kept if the engine is configured to.
+	q, err := test.QueryEngine().NewInstantQuery(tests[0].query, model.TimeFromUnix(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res := q.Exec(test.Context()); res.Err == nil {
+		t.Fatal("Expected an error")
+	}
+	if d := q.Diagnostics(); d != nil {
+		t.Errorf("Expected no diagnostics, got %v", d)
+	}
+}
+
+func TestCountValuesSeriesLimit(t *testing.T) {
+	test, err := NewTest(t, `
+load 1m
+	metric{a="1"} 1
+	metric{a="2"} 2
+	metric{a="3"} 3
+	metric{a="4"} 3
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer test.Close()
+
+	if err := test.Run(); err != nil {
+		t.Fatal(err)
+	}
+	engine := NewEngine(test.Storage(), &EngineOptions{
+		MaxConcurrentQueries: 20,
+		Timeout:              time.Minute,
+		MaxCountValuesSeries: 3,
+	})
+
+	tests := []struct {
+		query string
+		fail  bool
+	}{
+		{query: `count_values("value", metric)`},
+		{query: `count_values without (a) ("value", metric)`},
+		{query: `count_values by (a) ("value", metric)`, fail: true},
+		// The heaps are not sized by k.
+		{query: `topk(1e12, metric)`},
+		{query: `bottomk(1e12, metric)`},
+	}
+	for _, c := range tests {
+		q, err := engine.NewInstantQuery(c.query, model.TimeFromUnix(0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		res := q.Exec(test.Context())
+		if c.fail {
+			if res.Err == nil {
+				t.Errorf("%s: expected an error", c.query)
+			}
+			continue
+		}
+		if res.Err != nil {
+			t.Errorf("%s: unexpected error: %s", c.query, res.Err)
+		}
+	}
+}
+
+func TestReplicaDeduplication(t *testing.T) {
+	test, err := NewTest(t, `
+load 1m
+	up{job="api", replica="a"} 1+0x2
+	up{job="api", replica="b"} 2+0x10
+	up{job="db"} 3+0x10
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer test.Close()
+
+	if err := test.Run(); err != nil {
+		t.Fatal(err)
+	}
+	engine := NewEngine(test.Storage(), &EngineOptions{
+		MaxConcurrentQueries: 20,
+		Timeout:              time.Minute,
+		ReplicaLabel:         "replica",
+	})
+
+	tests := []struct {
+		query    string
+		ts       model.Time
+		expected string
+	}{
+		// The replica with the lowest label value is used while it has data.
+		{query: `up`, ts: 0, expected: "up{job=\"api\"} => 1 @[0]\nup{job=\"db\"} => 3 @[0]"},
+		{query: `up`, ts: model.TimeFromUnix(600), expected: "up{job=\"api\"} => 2 @[600]\nup{job=\"db\"} => 3 @[600]"},
+		// The samples of replicas are not mixed.
+		{query: `count_over_time(up{job="api"}[5m])`, ts: model.TimeFromUnix(180), expected: "{job=\"api\"} => 3 @[180]"},
+		{query: `count_over_time(up{job="api"}[5m])`, ts: model.TimeFromUnix(600), expected: "{job=\"api\"} => 6 @[600]"},
+	}
+	for _, c := range tests {
+		q, err := engine.NewInstantQuery(c.query, c.ts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res := q.Exec(test.Context())
+		if res.Err != nil {
+			t.Fatalf("%s: unexpected error: %s", c.query, res.Err)
+		}
+		vec := res.Value.(model.Vector)
+		sort.Sort(vec)
+		if vec.String() != c.expected {
+			t.Errorf("%s at %v: expected\n%s\ngot\n%s", c.query, c.ts, c.expected, vec)
+		}
+	}
+
+	// In range queries, each step uses the replica having data at its time.
+	q, err := engine.NewRangeQuery(`up{job="api"}`, 0, model.TimeFromUnix(600), 5*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := q.Exec(test.Context())
+	if res.Err != nil {
+		t.Fatal(res.Err)
+	}
+	expected := "up{job=\"api\"} =>\n1 @[0]\n1 @[300]\n2 @[600]"
+	if s := res.Value.String(); s != expected {
+		t.Errorf("expected range result\n%s\ngot\n%s", expected, s)
+	}
+}
+
+func TestQueryWarnings(t *testing.T) {
+	test, err := NewTest(t, `
+load 1m
+	up{job="api"} 1+0x30
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer test.Close()
+
+	if err := test.Run(); err != nil {
+		t.Fatal(err)
+	}
+	engine := test.QueryEngine()
+
+	tests := []struct {
+		query    string
+		step     time.Duration
+		warnings []string
+	}{
+		{query: `up`, step: 5 * time.Minute},
+		{query: `up`, step: 10 * time.Minute, warnings: []string{"query resolution step 10m exceeds the lookback delta 5m, series may have gaps"}},
+		{query: `sum(up) by (job)`, step: 10 * time.Minute, warnings: []string{"query resolution step 10m exceeds the lookback delta 5m, series may have gaps"}},
+		// Range selectors cover the whole step without gaps.
+		{query: `count_over_time(up[10m])`, step: 10 * time.Minute},
+	}
+	for _, c := range tests {
+		q, err := engine.NewRangeQuery(c.query, 0, model.TimeFromUnix(1800), c.step)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res := q.Exec(test.Context())
+		if res.Err != nil {
+			t.Fatalf("%s: unexpected error: %s", c.query, res.Err)
+		}
+		if !reflect.DeepEqual(res.Warnings, c.warnings) {
+			t.Errorf("%s with step %s: expected warnings %q, got %q", c.query, c.step, c.warnings, res.Warnings)
+		}
+		if res.Incomplete {
+			t.Errorf("%s with step %s: unexpected incomplete result", c.query, c.step)
+		}
+	}
+
+	// The lookback warning does not depend on the length of the range.
+	q, err := engine.NewRangeQuery(`up`, 0, 0, 10*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res := q.Exec(test.Context()); len(res.Warnings) != 1 {
+		t.Errorf("expected the lookback warning for a single step, got %q", res.Warnings)
+	}
+
+	// Warnings added by the storage are returned.
+	engine = NewEngine(warningQueryable{test.Storage()}, nil)
+	q, err = engine.NewInstantQuery(`up`, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := q.Exec(test.Context())
+	if expected := []string{"partial data"}; !reflect.DeepEqual(res.Warnings, expected) {
+		t.Errorf("expected warnings %q, got %q", expected, res.Warnings)
+	}
+	if !res.Incomplete {
+		t.Errorf("expected incomplete result")
+	}
+}
+
+// warningQueryable adds a warning to the instant queries of its queriers.
+type warningQueryable struct {
+	Queryable
+}
+
+func (q warningQueryable) Querier() (local.Querier, error) {
+	querier, err := q.Queryable.Querier()
+	return warningQuerier{querier}, err
+}
+
+type warningQuerier struct {
+	local.Querier
+}
+
+func (q warningQuerier) QueryInstant(ctx context.Context, ts model.Time, stalenessDelta time.Duration, matchers ...*metric.LabelMatcher) ([]local.SeriesIterator, error) {
+	storage.AddWarning(ctx, errors.New("partial data"))
+	return q.Querier.QueryInstant(ctx, ts, stalenessDelta, matchers...)
+}
+
+func TestRecordingSpans(t *testing.T) {
+	if recordingSpans(context.Background()) {
+		t.Errorf("want no recording without a span")
+	}
+	noop := opentracing.NoopTracer{}.StartSpan("query")
+	if recordingSpans(opentracing.ContextWithSpan(context.Background(), noop)) {
+		t.Errorf("want no recording of noop spans")
+	}
+
+	for _, fraction := range []float64{0, 1} {
+		m := tracing.NewManager(log.Base())
+		err := m.ApplyConfig(&config.Config{
+			TracingConfig: &config.TracingConfig{
+				Endpoint:         "localhost:4318",
+				Insecure:         true,
+				SamplingFraction: fraction,
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		span := m.StartSpan("query")
+		if got, exp := recordingSpans(opentracing.ContextWithSpan(context.Background(), span)), fraction == 1; got != exp {
+			t.Errorf("sampling fraction %g: want recording %t, got %t", fraction, exp, got)
+		}
+		m.Stop()
+	}
+}
@@ -517,34 +517,49 @@ func funcQuantileOverTime(ev *evaluator, args Expressions) model.Value {
 	return resultVector
 }
 
-// === stddev_over_time(matrix model.ValMatrix) Vector ===
-func funcStddevOverTime(ev *evaluator, args Expressions) model.Value {
+// === mad_over_time(matrix model.ValMatrix) Vector ===
+func funcMadOverTime(ev *evaluator, args Expressions) model.Value {
 	return aggrOverTime(ev, args, func(values []model.SamplePair) model.SampleValue {
-		var sum, squaredSum, count model.SampleValue
+		vals := make(vectorByValueHeap, 0, len(values))
 		for _, v := range values {
-			sum += v.Value
-			squaredSum += v.Value * v.Value
-			count++
+			vals = append(vals, &sample{Value: v.Value})
 		}
-		avg := sum / count
-		return model.SampleValue(math.Sqrt(float64(squaredSum/count - avg*avg)))
+		median := model.SampleValue(quantile(0.5, vals))
+		for _, s := range vals {
+			s.Value = model.SampleValue(math.Abs(float64(s.Value - median)))
+		}
+		return model.SampleValue(quantile(0.5, vals))
+	})
+}
+
+// === stddev_over_time(matrix model.ValMatrix) Vector ===
+func funcStddevOverTime(ev *evaluator, args Expressions) model.Value {
+	return aggrOverTime(ev, args, func(values []model.SamplePair) model.SampleValue {
+		return model.SampleValue(math.Sqrt(float64(variance(values))))
 	})
 }
 
 // === stdvar_over_time(matrix model.ValMatrix) Vector ===
 func funcStdvarOverTime(ev *evaluator, args Expressions) model.Value {
 	return aggrOverTime(ev, args, func(values []model.SamplePair) model.SampleValue {
-		var sum, squaredSum, count model.SampleValue
-		for _, v := range values {
-			sum += v.Value
-			squaredSum += v.Value * v.Value
-			count++
-		}
-		avg := sum / count
-		return squaredSum/count - avg*avg
+		return variance(values)
 	})
 }
 
+// variance returns the population variance of the given values. It uses
+// Welford's online algorithm, as subtracting the squared mean from the mean of
+// the squares loses all precision for large values with a small variance.
+func variance(values []model.SamplePair) model.SampleValue {
+	var mean, m2, count model.SampleValue
+	for _, v := range values {
+		count++
+		delta := v.Value - mean
+		mean += delta / count
+		m2 += delta * (v.Value - mean)
+	}
+	return m2 / count
+}
+
 // === abs(vector model.ValVector) Vector ===
 func funcAbs(ev *evaluator, args Expressions) model.Value {
 	vector := ev.evalVector(args[0])
@@ -723,8 +738,41 @@ func funcHistogramQuantile(ev *evaluator, args Expressions) model.Value {
 	inVec := ev.evalVector(args[1])
 
 	outVec := vector{}
+	for _, mb := range histogramBuckets(inVec) {
+		outVec = append(outVec, &sample{
+			Metric:    mb.metric,
+			Value:     model.SampleValue(bucketQuantile(q, mb.buckets)),
+			Timestamp: ev.Timestamp,
+		})
+	}
+
+	return outVec
+}
+
+// === histogram_fraction(lower, upper model.ValScalar, vector model.ValVector) Vector ===
+func funcHistogramFraction(ev *evaluator, args Expressions) model.Value {
+	lower := ev.evalFloat(args[0])
+	upper := ev.evalFloat(args[1])
+	inVec := ev.evalVector(args[2])
+
+	outVec := vector{}
+	for _, mb := range histogramBuckets(inVec) {
+		outVec = append(outVec, &sample{
+			Metric:    mb.metric,
+			Value:     model.SampleValue(bucketFraction(lower, upper, mb.buckets)),
+			Timestamp: ev.Timestamp,
+		})
+	}
+
+	return outVec
+}
+
+// histogramBuckets groups the bucket samples of the given vector by the
+// histogram they belong to. The metric of each histogram is the metric of its
+// buckets without the metric name and the bucket label.
+func histogramBuckets(vec vector) map[uint64]*metricWithBuckets {
 	signatureToMetricWithBuckets := map[uint64]*metricWithBuckets{}
-	for _, el := range inVec {
+	for _, el := range vec {
 		upperBound, err := strconv.ParseFloat(
 			string(el.Metric.Metric[model.BucketLabel]), 64,
 		)
@@ -743,16 +791,7 @@ func funcHistogramQuantile(ev *evaluator, args Expressions) model.Value {
 		}
 		mb.buckets = append(mb.buckets, bucket{upperBound, el.Value})
 	}
-
-	for _, mb := range signatureToMetricWithBuckets {
-		outVec = append(outVec, &sample{
-			Metric:    mb.metric,
-			Value:     model.SampleValue(bucketQuantile(q, mb.buckets)),
-			Timestamp: ev.Timestamp,
-		})
-	}
-
-	return outVec
+	return signatureToMetricWithBuckets
 }
 
 // === resets(matrix model.ValMatrix) Vector ===
@@ -897,6 +936,60 @@ func funcLabelJoin(ev *evaluator, args Expressions) model.Value {
 	return vector
 }
 
+// === label_lowercase(vector model.ValVector, labelname model.ValString...) Vector ===
+func funcLabelLowercase(ev *evaluator, args Expressions) model.Value {
+	return transformLabels(ev, args, "label_lowercase", strings.ToLower)
+}
+
+// === label_uppercase(vector model.ValVector, labelname model.ValString...) Vector ===
+func funcLabelUppercase(ev *evaluator, args Expressions) model.Value {
+	return transformLabels(ev, args, "label_uppercase", strings.ToUpper)
+}
+
+// === label_trim(vector model.ValVector, labelname model.ValString...) Vector ===
+func funcLabelTrim(ev *evaluator, args Expressions) model.Value {
+	return transformLabels(ev, args, "label_trim", strings.TrimSpace)
+}
+
+// transformLabels replaces the values of the given labels by the result of f,
+// deleting the labels whose values become empty.
+func transformLabels(ev *evaluator, args Expressions, name string, f func(string) string) model.Value {
+	var (
+		vector = ev.evalVector(args[0])
+		labels = make([]model.LabelName, len(args)-1)
+	)
+	for i := 1; i < len(args); i++ {
+		ln := model.LabelName(ev.evalString(args[i]).Value)
+		if !model.LabelNameRE.MatchString(string(ln)) {
+			ev.errorf("invalid label name in %s(): %s", name, ln)
+		}
+		labels[i-1] = ln
+	}
+
+	outSet := make(map[model.Fingerprint]struct{}, len(vector))
+	for _, el := range vector {
+		for _, ln := range labels {
+			lv, ok := el.Metric.Metric[ln]
+			if !ok {
+				continue
+			}
+			if v := f(string(lv)); v == "" {
+				el.Metric.Del(ln)
+			} else if v != string(lv) {
+				el.Metric.Set(ln, model.LabelValue(v))
+			}
+		}
+
+		fp := el.Metric.Metric.Fingerprint()
+		if _, exists := outSet[fp]; exists {
+			ev.errorf("duplicated label set in output of %s(): %s", name, el.Metric.Metric)
+		} else {
+			outSet[fp] = struct{}{}
+		}
+	}
+	return vector
+}
+
 // === vector(s scalar) Vector ===
 func funcVector(ev *evaluator, args Expressions) model.Value {
 	return vector{
@@ -1085,6 +1178,12 @@ var functions = map[string]*Function{
 		ReturnType: model.ValVector,
 		Call:       funcFloor,
 	},
+	"histogram_fraction": {
+		Name:       "histogram_fraction",
+		ArgTypes:   []model.ValueType{model.ValScalar, model.ValScalar, model.ValVector},
+		ReturnType: model.ValVector,
+		Call:       funcHistogramFraction,
+	},
 	"histogram_quantile": {
 		Name:       "histogram_quantile",
 		ArgTypes:   []model.ValueType{model.ValScalar, model.ValVector},
@@ -1135,6 +1234,27 @@ var functions = map[string]*Function{
 		ReturnType: model.ValVector,
 		Call:       funcLabelJoin,
 	},
+	"label_lowercase": {
+		Name:       "label_lowercase",
+		ArgTypes:   []model.ValueType{model.ValVector, model.ValString, model.ValString},
+		Variadic:   -1,
+		ReturnType: model.ValVector,
+		Call:       funcLabelLowercase,
+	},
+	"label_uppercase": {
+		Name:       "label_uppercase",
+		ArgTypes:   []model.ValueType{model.ValVector, model.ValString, model.ValString},
+		Variadic:   -1,
+		ReturnType: model.ValVector,
+		Call:       funcLabelUppercase,
+	},
+	"label_trim": {
+		Name:       "label_trim",
+		ArgTypes:   []model.ValueType{model.ValVector, model.ValString, model.ValString},
+		Variadic:   -1,
+		ReturnType: model.ValVector,
+		Call:       funcLabelTrim,
+	},
 	"ln": {
 		Name:       "ln",
 		ArgTypes:   []model.ValueType{model.ValVector},
@@ -1153,6 +1273,12 @@ var functions = map[string]*Function{
 		ReturnType: model.ValVector,
 		Call:       funcLog2,
 	},
+	"mad_over_time": {
+		Name:       "mad_over_time",
+		ArgTypes:   []model.ValueType{model.ValMatrix},
+		ReturnType: model.ValVector,
+		Call:       funcMadOverTime,
+	},
 	"max_over_time": {
 		Name:       "max_over_time",
 		ArgTypes:   []model.ValueType{model.ValMatrix},
@@ -94,7 +94,7 @@ const LowestPrec = 0 // Non-operators.
 // is LowestPrec.
 func (i itemType) precedence() int {
 	switch i {
-	case itemLOR:
+	case itemLOR, itemLDefault:
 		return 1
 	case itemLAND, itemLUnless:
 		return 2
@@ -154,6 +154,7 @@ const (
 	itemLAND
 	itemLOR
 	itemLUnless
+	itemLDefault
 	itemEQL
 	itemNEQ
 	itemLTE
@@ -264,6 +265,8 @@ var itemTypeStr = map[itemType]string{
 	itemEQLRegex: "=~",
 	itemNEQRegex: "!~",
 	itemPOW:      "^",
+	// The default operator is lexed as an identifier, see parser.expr.
+	itemLDefault: "default",
 }
 
 func init() {
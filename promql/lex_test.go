@@ -223,6 +223,9 @@ var tests = []struct {
 	}, {
 		input:    `unless`,
 		expected: []item{{itemLUnless, 0, `unless`}},
+	}, {
+		input:    `default`,
+		expected: []item{{itemIdentifier, 0, `default`}},
 	},
 	// Test aggregators.
 	{
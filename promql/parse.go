@@ -18,9 +18,9 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"text/template"
 	"time"
 
-	"github.com/prometheus/common/log"
 	"github.com/prometheus/common/model"
 
 	"github.com/prometheus/prometheus/storage/metric"
@@ -31,6 +31,11 @@ type parser struct {
 	lex       *lexer
 	token     [3]item
 	peekCount int
+
+	// allowEmptyMatchers makes vector selectors consisting only of
+	// matchers that match the empty string select all named series
+	// instead of failing.
+	allowEmptyMatchers bool
 }
 
 // ParseErr wraps a parsing error with line and position context.
@@ -87,7 +92,20 @@ func ParseMetric(input string) (m model.Metric, err error) {
 // ParseMetricSelector parses the provided textual metric selector into a list of
 // label matchers.
 func ParseMetricSelector(input string) (m metric.LabelMatchers, err error) {
+	return parseMetricSelector(newParser(input))
+}
+
+// ParseUnrestrictedMetricSelector parses the provided textual metric selector
+// like ParseMetricSelector, but also accepts selectors whose matchers all match
+// the empty string, e.g. {job!="foo"}. Those select all series with a metric
+// name that are matched by the given matchers.
+func ParseUnrestrictedMetricSelector(input string) (m metric.LabelMatchers, err error) {
 	p := newParser(input)
+	p.allowEmptyMatchers = true
+	return parseMetricSelector(p)
+}
+
+func parseMetricSelector(p *parser) (m metric.LabelMatchers, err error) {
 	defer p.recover(&err)
 
 	name := ""
@@ -331,7 +349,7 @@ func (p *parser) recover(errp *error) {
 			buf := make([]byte, 64<<10)
 			buf = buf[:runtime.Stack(buf, false)]
 
-			log.Errorf("parser panic: %v\n%s", e, buf)
+			logger.Errorf("parser panic: %v\n%s", e, buf)
 			*errp = errUnexpected
 		} else {
 			*errp = e.(error)
@@ -358,6 +376,8 @@ func (p *parser) stmt() Statement {
 // alertStmt parses an alert rule.
 //
 //		ALERT name IF expr [FOR duration]
+//			[RESEND_DELAY duration]
+//			[SOURCE_TMPL string]
 //			[LABELS label_set]
 //			[ANNOTATIONS label_set]
 //
@@ -384,6 +404,28 @@ func (p *parser) alertStmt() *AlertStmt {
 		}
 	}
 
+	// Optional resend delay clause. RESEND_DELAY is not a keyword so that
+	// it remains usable as a metric name.
+	var resendDelay time.Duration
+	if t := p.peek(); t.typ == itemIdentifier && strings.ToLower(t.val) == "resend_delay" {
+		p.next()
+		dur := p.expect(itemDuration, ctx)
+		resendDelay, err = parseDuration(dur.val)
+		if err != nil {
+			p.error(err)
+		}
+	}
+
+	// Optional source template clause, not a keyword either.
+	var sourceTmpl string
+	if t := p.peek(); t.typ == itemIdentifier && strings.ToLower(t.val) == "source_tmpl" {
+		p.next()
+		sourceTmpl = p.unquoteString(p.expect(itemString, ctx).val)
+		if _, err := template.New("source_tmpl").Parse(sourceTmpl); err != nil {
+			p.errorf("invalid source template: %s", err)
+		}
+	}
+
 	var (
 		labels      = model.LabelSet{}
 		annotations = model.LabelSet{}
@@ -398,11 +440,13 @@ func (p *parser) alertStmt() *AlertStmt {
 	}
 
 	return &AlertStmt{
-		Name:        name.val,
-		Expr:        expr,
-		Duration:    duration,
-		Labels:      labels,
-		Annotations: annotations,
+		Name:           name.val,
+		Expr:           expr,
+		Duration:       duration,
+		ResendDelay:    resendDelay,
+		SourceTemplate: sourceTmpl,
+		Labels:         labels,
+		Annotations:    annotations,
 	}
 }
 
@@ -437,6 +481,11 @@ func (p *parser) expr() Expr {
 	for {
 		// If the next token is not an operator the expression is done.
 		op := p.peek().typ
+		// "default" is a valid metric name and thus only the default
+		// operator in binary operator position.
+		if op == itemIdentifier && strings.ToLower(p.peek().val) == "default" {
+			op = itemLDefault
+		}
 		if !op.isOperator() {
 			return expr
 		}
@@ -976,7 +1025,14 @@ func (p *parser) vectorSelector(name string) *VectorSelector {
 		}
 	}
 	if !notEmpty {
-		p.errorf("vector selector must contain at least one non-empty matcher")
+		if !p.allowEmptyMatchers {
+			p.errorf("vector selector must contain at least one non-empty matcher")
+		}
+		m, err := metric.NewLabelMatcher(metric.RegexMatch, model.MetricNameLabel, ".+")
+		if err != nil {
+			panic(err) // Must not happen with a valid regular expression.
+		}
+		matchers = append(matchers, m)
 	}
 
 	return &VectorSelector{
@@ -1083,6 +1139,9 @@ func (p *parser) checkType(node Node) (typ model.ValueType) {
 		if (lt == model.ValScalar || rt == model.ValScalar) && n.Op.isSetOperator() {
 			p.errorf("set operator %q not allowed in binary scalar expression", n.Op)
 		}
+		if n.Op == itemLDefault && (lt != model.ValVector || rt != model.ValScalar) {
+			p.errorf("operator %q requires an instant vector on the left and a scalar on the right hand side", n.Op)
+		}
 
 	case *Call:
 		nargs := len(n.Func.ArgTypes)
@@ -226,6 +226,18 @@ var testExpr = []struct {
 		input:  "1 unless 1",
 		fail:   true,
 		errMsg: "set operator \"unless\" not allowed in binary scalar expression",
+	}, {
+		input:  "1 default 1",
+		fail:   true,
+		errMsg: "operator \"default\" requires an instant vector on the left and a scalar on the right hand side",
+	}, {
+		input:  "foo default bar",
+		fail:   true,
+		errMsg: "operator \"default\" requires an instant vector on the left and a scalar on the right hand side",
+	}, {
+		input:  "foo default on(job) 0",
+		fail:   true,
+		errMsg: "vector matching only allowed between instant vectors",
 	}, {
 		input:  "1 !~ 1",
 		fail:   true,
@@ -404,6 +416,69 @@ var testExpr = []struct {
 			},
 			VectorMatching: &VectorMatching{Card: CardManyToMany},
 		},
+	}, {
+		// Test default precedence.
+		input: "foo + bar default 0",
+		expected: &BinaryExpr{
+			Op: itemLDefault,
+			LHS: &BinaryExpr{
+				Op: itemADD,
+				LHS: &VectorSelector{
+					Name: "foo",
+					LabelMatchers: metric.LabelMatchers{
+						mustLabelMatcher(metric.Equal, model.MetricNameLabel, "foo"),
+					},
+				},
+				RHS: &VectorSelector{
+					Name: "bar",
+					LabelMatchers: metric.LabelMatchers{
+						mustLabelMatcher(metric.Equal, model.MetricNameLabel, "bar"),
+					},
+				},
+				VectorMatching: &VectorMatching{Card: CardOneToOne},
+			},
+			RHS: &NumberLiteral{0},
+		},
+	}, {
+		// "default" is only the default operator in binary operator position.
+		input: "default default 0",
+		expected: &BinaryExpr{
+			Op: itemLDefault,
+			LHS: &VectorSelector{
+				Name: "default",
+				LabelMatchers: metric.LabelMatchers{
+					mustLabelMatcher(metric.Equal, model.MetricNameLabel, "default"),
+				},
+			},
+			RHS: &NumberLiteral{0},
+		},
+	}, {
+		input: `default{job="a"}`,
+		expected: &VectorSelector{
+			Name: "default",
+			LabelMatchers: metric.LabelMatchers{
+				mustLabelMatcher(metric.Equal, "job", "a"),
+				mustLabelMatcher(metric.Equal, model.MetricNameLabel, "default"),
+			},
+		},
+	}, {
+		input: "rate(default[5m])",
+		expected: &Call{
+			Func: mustGetFunction("rate"),
+			Args: Expressions{
+				&MatrixSelector{
+					Name: "default",
+					LabelMatchers: metric.LabelMatchers{
+						mustLabelMatcher(metric.Equal, model.MetricNameLabel, "default"),
+					},
+					Range: 5 * time.Minute,
+				},
+			},
+		},
+	}, {
+		input:  "foo default",
+		fail:   true,
+		errMsg: "no valid expression found",
 	}, {
 		// Test and/or/unless precedence.
 		input: "foo and bar unless baz or qux",
@@ -1666,6 +1741,57 @@ var testStatement = []struct {
 				},
 			},
 		},
+	}, {
+		input: `ALERT SomeName IF resend_delay > 1 FOR 5m RESEND_DELAY 30s`,
+		expected: Statements{
+			&AlertStmt{
+				Name: "SomeName",
+				Expr: &BinaryExpr{
+					Op: itemGTR,
+					LHS: &VectorSelector{
+						Name: "resend_delay",
+						LabelMatchers: metric.LabelMatchers{
+							mustLabelMatcher(metric.Equal, model.MetricNameLabel, "resend_delay"),
+						},
+					},
+					RHS: &NumberLiteral{1},
+				},
+				Duration:    5 * time.Minute,
+				ResendDelay: 30 * time.Second,
+				Labels:      model.LabelSet{},
+				Annotations: model.LabelSet{},
+			},
+		},
+	}, {
+		input: `ALERT SomeName IF some_metric > 1 RESEND_DELAY 30`,
+		fail:  true,
+	}, {
+		input: `ALERT SomeName IF source_tmpl > 1 RESEND_DELAY 30s SOURCE_TMPL "https://grafana.example.com/explore?expr={{ urlquery .Expr }}" LABELS {a="b"}`,
+		expected: Statements{
+			&AlertStmt{
+				Name: "SomeName",
+				Expr: &BinaryExpr{
+					Op: itemGTR,
+					LHS: &VectorSelector{
+						Name: "source_tmpl",
+						LabelMatchers: metric.LabelMatchers{
+							mustLabelMatcher(metric.Equal, model.MetricNameLabel, "source_tmpl"),
+						},
+					},
+					RHS: &NumberLiteral{1},
+				},
+				ResendDelay:    30 * time.Second,
+				SourceTemplate: "https://grafana.example.com/explore?expr={{ urlquery .Expr }}",
+				Labels:         model.LabelSet{"a": "b"},
+				Annotations:    model.LabelSet{},
+			},
+		},
+	}, {
+		input: `ALERT SomeName IF some_metric > 1 SOURCE_TMPL "{{ .Expr"`,
+		fail:  true,
+	}, {
+		input: `ALERT SomeName IF some_metric > 1 SOURCE_TMPL 5m`,
+		fail:  true,
 	}, {
 		input: `
 			# A simple test alerting rule.
@@ -1900,6 +2026,61 @@ func TestParseSeries(t *testing.T) {
 	}
 }
 
+func TestParseUnrestrictedMetricSelector(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected metric.LabelMatchers
+		// Whether ParseMetricSelector rejects the input.
+		restricted bool
+		fail       bool
+	}{
+		{
+			input: `foo{bar!="baz"}`,
+			expected: metric.LabelMatchers{
+				mustLabelMatcher(metric.NotEqual, "bar", "baz"),
+				mustLabelMatcher(metric.Equal, model.MetricNameLabel, "foo"),
+			},
+		}, {
+			input: `{bar!="baz"}`,
+			expected: metric.LabelMatchers{
+				mustLabelMatcher(metric.NotEqual, "bar", "baz"),
+				mustLabelMatcher(metric.RegexMatch, model.MetricNameLabel, ".+"),
+			},
+			restricted: true,
+		}, {
+			input: `{bar=""}`,
+			expected: metric.LabelMatchers{
+				mustLabelMatcher(metric.Equal, "bar", ""),
+				mustLabelMatcher(metric.RegexMatch, model.MetricNameLabel, ".+"),
+			},
+			restricted: true,
+		}, {
+			input: `{}`,
+			fail:  true,
+		},
+	}
+
+	for _, test := range tests {
+		if _, err := ParseMetricSelector(test.input); test.restricted && err == nil {
+			t.Errorf("expected ParseMetricSelector to reject %q", test.input)
+		}
+
+		matchers, err := ParseUnrestrictedMetricSelector(test.input)
+		if test.fail {
+			if err == nil {
+				t.Errorf("expected error parsing %q", test.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("could not parse %q: %s", test.input, err)
+		}
+		if !reflect.DeepEqual(matchers, test.expected) {
+			t.Errorf("input %q: expected %v, got %v", test.input, test.expected, matchers)
+		}
+	}
+}
+
 func TestRecoverParserRuntime(t *testing.T) {
 	var p *parser
 	var err error
@@ -105,6 +105,12 @@ func (node *AlertStmt) String() string {
 	if node.Duration > 0 {
 		s += fmt.Sprintf("\n\tFOR %s", model.Duration(node.Duration))
 	}
+	if node.ResendDelay > 0 {
+		s += fmt.Sprintf("\n\tRESEND_DELAY %s", model.Duration(node.ResendDelay))
+	}
+	if node.SourceTemplate != "" {
+		s += fmt.Sprintf("\n\tSOURCE_TMPL %q", node.SourceTemplate)
+	}
 	if len(node.Labels) > 0 {
 		s += fmt.Sprintf("\n\tLABELS %s", node.Labels)
 	}
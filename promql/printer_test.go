@@ -34,8 +34,10 @@ func TestStatementString(t *testing.T) {
 			},
 			RHS: &NumberLiteral{10},
 		},
-		Duration: 5 * time.Minute,
-		Labels:   model.LabelSet{"foo": "bar"},
+		Duration:       5 * time.Minute,
+		ResendDelay:    30 * time.Second,
+		SourceTemplate: "https://grafana.example.com/explore?expr={{ urlquery .Expr }}",
+		Labels:         model.LabelSet{"foo": "bar"},
 		Annotations: model.LabelSet{
 			"notify": "team-a",
 		},
@@ -44,6 +46,8 @@ func TestStatementString(t *testing.T) {
 	expected := `ALERT FooAlert
 	IF foo > 10
 	FOR 5m
+	RESEND_DELAY 30s
+	SOURCE_TMPL "https://grafana.example.com/explore?expr={{ urlquery .Expr }}"
 	LABELS {foo="bar"}
 	ANNOTATIONS {notify="team-a"}`
 
@@ -109,6 +109,69 @@ func bucketQuantile(q model.SampleValue, buckets buckets) float64 {
 	return bucketStart + (bucketEnd-bucketStart)*float64(rank/count)
 }
 
+// bucketFraction estimates the fraction of observations between 'lower' and
+// 'upper' based on the given buckets. The buckets will be sorted by upperBound
+// by this function. Like in bucketQuantile, observations are assumed to be
+// distributed linearly within a bucket, a natural lower bound of 0 is assumed
+// for the lowest bucket if its upper bound is greater 0, and the observations
+// of a lowest bucket with an upper bound less or equal 0 are assumed to be at
+// its upper bound. The observations of the +Inf bucket are assumed to be at
+// the upper bound of the 2nd highest bucket.
+//
+// If 'buckets' has fewer than 2 elements, the highest bucket is not +Inf, no
+// observations were counted or a bound is NaN, NaN is returned.
+//
+// If lower>=upper, 0 is returned.
+func bucketFraction(lower, upper float64, buckets buckets) float64 {
+	if len(buckets) < 2 || math.IsNaN(lower) || math.IsNaN(upper) {
+		return math.NaN()
+	}
+	sort.Sort(buckets)
+	if !math.IsInf(buckets[len(buckets)-1].upperBound, +1) {
+		return math.NaN()
+	}
+
+	ensureMonotonic(buckets)
+
+	count := float64(buckets[len(buckets)-1].count)
+	if count == 0 {
+		return math.NaN()
+	}
+	if lower >= upper {
+		return 0
+	}
+	return (bucketRank(upper, buckets) - bucketRank(lower, buckets)) / count
+}
+
+// bucketRank estimates the number of observations less or equal v, assuming
+// the distribution of bucketFraction.
+func bucketRank(v float64, buckets buckets) float64 {
+	var rank float64
+	for i, b := range buckets {
+		if v >= b.upperBound {
+			rank = float64(b.count)
+			continue
+		}
+		var bucketStart float64
+		switch {
+		case i > 0:
+			bucketStart = buckets[i-1].upperBound
+		case b.upperBound <= 0:
+			// All observations of the lowest bucket are at its upper bound.
+			return 0
+		}
+		if v <= bucketStart {
+			return rank
+		}
+		if math.IsInf(b.upperBound, +1) {
+			// All observations of the +Inf bucket are at its lower bound.
+			return float64(b.count)
+		}
+		return rank + (float64(b.count)-rank)*(v-bucketStart)/(b.upperBound-bucketStart)
+	}
+	return rank
+}
+
 // The assumption that bucket counts increase monotonically with increasing
 // upperBound may be violated during:
 //
@@ -0,0 +1,355 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package promql
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
+
+	"github.com/prometheus/prometheus/storage/metric"
+)
+
+// ShardingHint describes by which labels the evaluation of an expression can
+// be split: evaluating it on the series of disjoint sets of values of such a
+// label and concatenating the results yields its result on all series.
+type ShardingHint struct {
+	// If AllLabels is set, the expression can be split by all labels except
+	// the ones in Labels, otherwise only by the ones in Labels.
+	AllLabels bool
+	Labels    model.LabelNames
+}
+
+// Shardable returns whether the expression can be split by any label.
+func (h ShardingHint) Shardable() bool {
+	return h.AllLabels || len(h.Labels) > 0
+}
+
+// ShardableBy returns whether the expression can be split by the label.
+func (h ShardingHint) ShardableBy(ln model.LabelName) bool {
+	for _, l := range h.Labels {
+		if l == ln {
+			return !h.AllLabels
+		}
+	}
+	return h.AllLabels
+}
+
+// AnalyzeShardability returns by which labels the evaluation of the
+// expression can be split, for example by job for sum by (job) aggregations.
+func AnalyzeShardability(expr Expr) ShardingHint {
+	s := shardLabels(expr)
+	h := ShardingHint{AllLabels: s.all}
+	for ln := range s.names {
+		h.Labels = append(h.Labels, ln)
+	}
+	sort.Sort(h.Labels)
+	return h
+}
+
+// labelSet is a finite set of labels, or if all is set, the set of all
+// labels but the given ones.
+type labelSet struct {
+	all   bool
+	names map[model.LabelName]struct{}
+}
+
+func newLabelSet(all bool, lns ...model.LabelName) labelSet {
+	s := labelSet{all: all, names: make(map[model.LabelName]struct{}, len(lns))}
+	for _, ln := range lns {
+		s.names[ln] = struct{}{}
+	}
+	return s
+}
+
+func (s labelSet) has(ln model.LabelName) bool {
+	_, ok := s.names[ln]
+	return ok != s.all
+}
+
+func (s labelSet) intersect(o labelSet) labelSet {
+	var res labelSet
+	switch {
+	case s.all && o.all:
+		res = newLabelSet(true)
+		for ln := range s.names {
+			res.names[ln] = struct{}{}
+		}
+		for ln := range o.names {
+			res.names[ln] = struct{}{}
+		}
+		return res
+	case s.all:
+		s, o = o, s
+	}
+	res = newLabelSet(false)
+	for ln := range s.names {
+		if o.has(ln) {
+			res.names[ln] = struct{}{}
+		}
+	}
+	return res
+}
+
+func (s labelSet) without(lns ...model.LabelName) labelSet {
+	return s.intersect(newLabelSet(true, lns...))
+}
+
+// shardLabels returns the labels by which the evaluation of the expression
+// can be split.
+func shardLabels(expr Expr) labelSet {
+	none := newLabelSet(false)
+
+	switch e := expr.(type) {
+	case *VectorSelector, *MatrixSelector:
+		return newLabelSet(true)
+
+	case *NumberLiteral, *StringLiteral:
+		return newLabelSet(true)
+
+	case *ParenExpr:
+		return shardLabels(e.Expr)
+
+	case *UnaryExpr:
+		return shardLabels(e.Expr)
+
+	case *AggregateExpr:
+		if e.Param != nil && !constant(e.Param) {
+			return none
+		}
+		s := shardLabels(e.Expr)
+		if e.Op == itemCountValues {
+			ln, ok := stringLiteral(e.Param)
+			if !ok {
+				return none
+			}
+			s = s.without(model.LabelName(ln))
+		}
+		if e.Without {
+			return s.without(e.Grouping...).without(model.MetricNameLabel)
+		}
+		return s.intersect(newLabelSet(false, e.Grouping...))
+
+	case *BinaryExpr:
+		if e.Op == itemLDefault {
+			// Whether the default applies depends on all series.
+			return none
+		}
+		lt, rt := e.LHS.Type(), e.RHS.Type()
+		if lt == model.ValScalar && rt == model.ValScalar {
+			if constant(e) {
+				return newLabelSet(true)
+			}
+			return none
+		}
+		if lt == model.ValScalar {
+			if !constant(e.LHS) {
+				return none
+			}
+			return shardLabels(e.RHS)
+		}
+		if rt == model.ValScalar {
+			if !constant(e.RHS) {
+				return none
+			}
+			return shardLabels(e.LHS)
+		}
+		// Matching elements of both sides have to be in the same shard.
+		s := shardLabels(e.LHS).intersect(shardLabels(e.RHS))
+		if m := e.VectorMatching; m != nil {
+			if m.On {
+				s = s.intersect(newLabelSet(false, m.MatchingLabels...))
+			} else {
+				s = s.without(m.MatchingLabels...).without(model.MetricNameLabel)
+			}
+		}
+		return s
+
+	case *Call:
+		if e.Type() != model.ValVector {
+			if constant(e) {
+				return newLabelSet(true)
+			}
+			return none
+		}
+		switch e.Func.Name {
+		case "absent", "drop_common_labels", "sort", "sort_desc":
+			// Their results depend on all series, or their order.
+			return none
+		}
+		s := newLabelSet(true)
+		series := false
+		for _, arg := range e.Args {
+			switch arg.Type() {
+			case model.ValVector, model.ValMatrix:
+				series = true
+				s = s.intersect(shardLabels(arg))
+			default:
+				if !constant(arg) {
+					return none
+				}
+			}
+		}
+		if !series {
+			// Functions like vector() create a series without labels.
+			return none
+		}
+		switch e.Func.Name {
+		case "label_replace", "label_join":
+			ln, ok := stringLiteral(e.Args[1])
+			if !ok {
+				return none
+			}
+			s = s.without(model.LabelName(ln))
+		case "label_lowercase", "label_uppercase", "label_trim":
+			// Series differing in the transformed labels can end up in
+			// the same output series.
+			for _, arg := range e.Args[1:] {
+				ln, ok := stringLiteral(arg)
+				if !ok {
+					return none
+				}
+				s = s.without(model.LabelName(ln))
+			}
+		case "histogram_quantile", "histogram_fraction":
+			s = s.without(model.BucketLabel)
+		}
+		return s
+	}
+	return none
+}
+
+// stringLiteral returns the value of a string literal expression.
+func stringLiteral(expr Expr) (string, bool) {
+	for {
+		switch e := expr.(type) {
+		case *ParenExpr:
+			expr = e.Expr
+		case *StringLiteral:
+			return e.Val, true
+		default:
+			return "", false
+		}
+	}
+}
+
+// constant returns whether the expression does not select any series, so
+// that it evaluates to the same value in all shards.
+func constant(expr Expr) bool {
+	c := true
+	Inspect(expr, func(node Node) bool {
+		switch node.(type) {
+		case *VectorSelector, *MatrixSelector:
+			c = false
+		}
+		return c
+	})
+	return c
+}
+
+// ExecShardedRangeQuery evaluates a range query by splitting it into up to
+// the given number of queries running concurrently, each on the series of a
+// subset of the values of the label. The query must be shardable by the
+// label according to AnalyzeShardability.
+//
+// This is experimental.
+func (ng *Engine) ExecShardedRangeQuery(ctx context.Context, qs string, start, end model.Time, interval time.Duration, ln model.LabelName, shards int) (model.Matrix, error) {
+	expr, err := ParseExpr(qs)
+	if err != nil {
+		return nil, err
+	}
+	if expr.Type() != model.ValVector || !AnalyzeShardability(expr).ShardableBy(ln) {
+		return nil, fmt.Errorf("query %q cannot be sharded by label %q", qs, ln)
+	}
+
+	querier, err := ng.queryable.Querier()
+	if err != nil {
+		return nil, err
+	}
+	values, err := querier.LabelValuesForLabelName(ctx, ln)
+	querier.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	// The first shard also holds the series without the label.
+	if len(values)+1 < shards {
+		shards = len(values) + 1
+	}
+	if shards < 1 {
+		shards = 1
+	}
+	alternatives := make([][]string, shards)
+	for i, v := range append(model.LabelValues{""}, values...) {
+		alternatives[i%shards] = append(alternatives[i%shards], regexp.QuoteMeta(string(v)))
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mtx      sync.Mutex
+		result   model.Matrix
+		firstErr error
+	)
+	for _, alts := range alternatives {
+		// Parsing the printed expression copies it.
+		shardExpr, err := ParseExpr(expr.String())
+		if err != nil {
+			return nil, err
+		}
+		m, err := metric.NewLabelMatcher(metric.RegexMatch, ln, model.LabelValue(strings.Join(alts, "|")))
+		if err != nil {
+			return nil, err
+		}
+		Inspect(shardExpr, func(node Node) bool {
+			switch n := node.(type) {
+			case *VectorSelector:
+				n.LabelMatchers = append(n.LabelMatchers, m)
+			case *MatrixSelector:
+				n.LabelMatchers = append(n.LabelMatchers, m)
+			}
+			return true
+		})
+
+		qry := ng.newQuery(shardExpr, start, end, interval)
+		qry.q = qs
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			res := qry.Exec(ctx)
+			mtx.Lock()
+			defer mtx.Unlock()
+			if res.Err != nil {
+				if firstErr == nil {
+					firstErr = res.Err
+				}
+				return
+			}
+			result = append(result, res.Value.(model.Matrix)...)
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	sort.Sort(result)
+	return result, nil
+}
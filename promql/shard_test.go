@@ -0,0 +1,166 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package promql
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+)
+
+func TestAnalyzeShardability(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected ShardingHint
+	}{
+		{
+			expr:     `rate(http_requests[5m]) * 2`,
+			expected: ShardingHint{AllLabels: true},
+		},
+		{
+			expr:     `sum by (job, instance) (rate(http_requests[5m]))`,
+			expected: ShardingHint{Labels: model.LabelNames{"instance", "job"}},
+		},
+		{
+			expr:     `sum without (instance) (http_requests)`,
+			expected: ShardingHint{AllLabels: true, Labels: model.LabelNames{"__name__", "instance"}},
+		},
+		{
+			expr:     `max by (job) (sum by (job, instance) (http_requests))`,
+			expected: ShardingHint{Labels: model.LabelNames{"job"}},
+		},
+		{
+			expr:     `sum(http_requests)`,
+			expected: ShardingHint{},
+		},
+		{
+			expr:     `topk by (job) (3, http_requests)`,
+			expected: ShardingHint{Labels: model.LabelNames{"job"}},
+		},
+		{
+			expr:     `topk by (job) (scalar(count(up)), http_requests)`,
+			expected: ShardingHint{},
+		},
+		{
+			expr:     `count_values by (job, version) ("version", build_info)`,
+			expected: ShardingHint{Labels: model.LabelNames{"job"}},
+		},
+		{
+			expr:     `http_requests / on(job, instance) group_left(version) build_info`,
+			expected: ShardingHint{Labels: model.LabelNames{"instance", "job"}},
+		},
+		{
+			expr:     `http_requests / ignoring(method) sum without (method) (http_requests)`,
+			expected: ShardingHint{AllLabels: true, Labels: model.LabelNames{"__name__", "method"}},
+		},
+		{
+			expr:     `http_requests / scalar(sum(http_requests))`,
+			expected: ShardingHint{},
+		},
+		{
+			expr:     `label_replace(up, "job", "$1", "instance", "(.*)")`,
+			expected: ShardingHint{AllLabels: true, Labels: model.LabelNames{"job"}},
+		},
+		{
+			expr:     `sum by (env, job) (label_lowercase(up, "env", "team"))`,
+			expected: ShardingHint{Labels: model.LabelNames{"job"}},
+		},
+		{
+			expr:     `label_trim(up, "job")`,
+			expected: ShardingHint{AllLabels: true, Labels: model.LabelNames{"job"}},
+		},
+		{
+			expr:     `histogram_quantile(0.9, sum by (job, le) (rate(http_request_duration_seconds_bucket[5m])))`,
+			expected: ShardingHint{Labels: model.LabelNames{"job"}},
+		},
+		{
+			expr:     `absent(up{job="api"})`,
+			expected: ShardingHint{},
+		},
+		{
+			expr:     `vector(1)`,
+			expected: ShardingHint{},
+		},
+	}
+
+	for _, c := range tests {
+		expr, err := ParseExpr(c.expr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if h := AnalyzeShardability(expr); !reflect.DeepEqual(h, c.expected) {
+			t.Errorf("%s: expected %+v, got %+v", c.expr, c.expected, h)
+		}
+	}
+
+	h := ShardingHint{AllLabels: true, Labels: model.LabelNames{"instance"}}
+	if !h.ShardableBy("job") || h.ShardableBy("instance") {
+		t.Errorf("Unexpected shardability of %+v", h)
+	}
+}
+
+func TestExecShardedRangeQuery(t *testing.T) {
+	test, err := NewTest(t, `
+load 1m
+	http_requests{job="api", instance="0"} 0+10x10
+	http_requests{job="api", instance="1"} 0+20x10
+	http_requests{job="db", instance="0"} 0+30x10
+	http_requests{job="web.1", instance="0"} 0+40x10
+	http_requests{instance="2"} 0+50x10
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer test.Close()
+
+	if err := test.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		ng    = test.QueryEngine()
+		start = model.TimeFromUnix(0)
+		end   = model.TimeFromUnix(600)
+	)
+	for _, qs := range []string{
+		`sum by (job) (rate(http_requests[5m]))`,
+		`http_requests{job=~"api|web.1"}`,
+	} {
+		q, err := ng.NewRangeQuery(qs, start, end, time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res := q.Exec(test.Context())
+		if res.Err != nil {
+			t.Fatal(res.Err)
+		}
+		expected := res.Value.(model.Matrix)
+
+		for _, shards := range []int{1, 2, 3, 10} {
+			result, err := ng.ExecShardedRangeQuery(test.Context(), qs, start, end, time.Minute, "job", shards)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(result, expected) {
+				t.Errorf("%s in %d shards: expected\n%v\ngot\n%v", qs, shards, expected, result)
+			}
+		}
+	}
+
+	if _, err := ng.ExecShardedRangeQuery(test.Context(), `sum(http_requests)`, start, end, time.Minute, "job", 2); err == nil {
+		t.Error("Expected an error for a query which cannot be sharded")
+	}
+}
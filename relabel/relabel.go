@@ -16,6 +16,7 @@ package relabel
 import (
 	"crypto/md5"
 	"fmt"
+	"hash/fnv"
 	"strings"
 
 	"github.com/prometheus/common/model"
@@ -71,7 +72,7 @@ func relabel(labels model.LabelSet, cfg *config.RelabelConfig) model.LabelSet {
 		}
 		labels[target] = model.LabelValue(res)
 	case config.RelabelHashMod:
-		mod := sum64(md5.Sum([]byte(val))) % cfg.Modulus
+		mod := hash(cfg.HashFunction, cfg.HashSeed+val) % cfg.Modulus
 		labels[model.LabelName(cfg.TargetLabel)] = model.LabelValue(fmt.Sprintf("%d", mod))
 	case config.RelabelLabelMap:
 		out := make(model.LabelSet, len(labels))
@@ -98,12 +99,35 @@ func relabel(labels model.LabelSet, cfg *config.RelabelConfig) model.LabelSet {
 				delete(labels, ln)
 			}
 		}
+	case config.RelabelLowercase:
+		labels[model.LabelName(cfg.TargetLabel)] = model.LabelValue(strings.ToLower(val))
+	case config.RelabelUppercase:
+		labels[model.LabelName(cfg.TargetLabel)] = model.LabelValue(strings.ToUpper(val))
+	case config.RelabelKeepEqual:
+		if labels[model.LabelName(cfg.TargetLabel)] != model.LabelValue(val) {
+			return nil
+		}
+	case config.RelabelDropEqual:
+		if labels[model.LabelName(cfg.TargetLabel)] == model.LabelValue(val) {
+			return nil
+		}
 	default:
 		panic(fmt.Errorf("retrieval.relabel: unknown relabel action type %q", cfg.Action))
 	}
 	return labels
 }
 
+// hash returns the hash of s computed with the given hash function, MD5 if
+// none is set.
+func hash(f config.HashFunction, s string) uint64 {
+	if f == config.HashFNV64a {
+		h := fnv.New64a()
+		h.Write([]byte(s))
+		return h.Sum64()
+	}
+	return sum64(md5.Sum([]byte(s)))
+}
+
 // sum64 sums the md5 hash to an uint64.
 func sum64(hash [md5.Size]byte) uint64 {
 	var s uint64
@@ -410,6 +410,122 @@ func TestRelabel(t *testing.T) {
 				"a": "foo",
 			},
 		},
+		{
+			input: model.LabelSet{
+				"c": "baz",
+			},
+			relabel: []*config.RelabelConfig{
+				{
+					SourceLabels: model.LabelNames{"c"},
+					TargetLabel:  "d",
+					Separator:    ";",
+					Action:       config.RelabelHashMod,
+					Modulus:      1000,
+					HashFunction: config.HashFNV64a,
+				},
+				{
+					SourceLabels: model.LabelNames{"c"},
+					TargetLabel:  "e",
+					Separator:    ";",
+					Action:       config.RelabelHashMod,
+					Modulus:      1000,
+					HashFunction: config.HashMD5,
+					HashSeed:     "shard-a",
+				},
+			},
+			output: model.LabelSet{
+				"c": "baz",
+				"d": "58",
+				"e": "231",
+			},
+		},
+		{
+			input: model.LabelSet{
+				"a": "AbCd",
+				"b": "Ef",
+			},
+			relabel: []*config.RelabelConfig{
+				{
+					SourceLabels: model.LabelNames{"a", "b"},
+					Separator:    "-",
+					TargetLabel:  "lower",
+					Action:       config.RelabelLowercase,
+				},
+				{
+					SourceLabels: model.LabelNames{"a"},
+					TargetLabel:  "upper",
+					Action:       config.RelabelUppercase,
+				},
+			},
+			output: model.LabelSet{
+				"a":     "AbCd",
+				"b":     "Ef",
+				"lower": "abcd-ef",
+				"upper": "ABCD",
+			},
+		},
+		{
+			input: model.LabelSet{
+				"a": "foo",
+				"b": "foo",
+			},
+			relabel: []*config.RelabelConfig{
+				{
+					SourceLabels: model.LabelNames{"a"},
+					TargetLabel:  "b",
+					Action:       config.RelabelKeepEqual,
+				},
+			},
+			output: model.LabelSet{
+				"a": "foo",
+				"b": "foo",
+			},
+		},
+		{
+			input: model.LabelSet{
+				"a": "foo",
+				"b": "bar",
+			},
+			relabel: []*config.RelabelConfig{
+				{
+					SourceLabels: model.LabelNames{"a"},
+					TargetLabel:  "b",
+					Action:       config.RelabelKeepEqual,
+				},
+			},
+			output: nil,
+		},
+		{
+			input: model.LabelSet{
+				"a": "foo",
+				"b": "foo",
+			},
+			relabel: []*config.RelabelConfig{
+				{
+					SourceLabels: model.LabelNames{"a"},
+					TargetLabel:  "b",
+					Action:       config.RelabelDropEqual,
+				},
+			},
+			output: nil,
+		},
+		{
+			input: model.LabelSet{
+				"a": "foo",
+				"b": "bar",
+			},
+			relabel: []*config.RelabelConfig{
+				{
+					SourceLabels: model.LabelNames{"a"},
+					TargetLabel:  "b",
+					Action:       config.RelabelDropEqual,
+				},
+			},
+			output: model.LabelSet{
+				"a": "foo",
+				"b": "bar",
+			},
+		},
 	}
 
 	for i, test := range tests {
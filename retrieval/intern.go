@@ -0,0 +1,90 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retrieval
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/common/model"
+)
+
+// internGenerations is the number of scrape intervals after which strings
+// no longer seen in any scrape of a pool start being dropped from its
+// interner.
+const internGenerations = 10
+
+// interner deduplicates the label names and values of the samples scraped
+// from all targets of a scrape pool, so that identical strings decoded from
+// different scrapes share their memory in the series they are stored in.
+//
+// Strings are kept in two generations. Strings seen during the current
+// generation are kept, strings only seen in the previous one are dropped
+// when the generation rotates, so that label values of churning targets
+// do not accumulate.
+type interner struct {
+	mtx        sync.Mutex
+	cur, prev  map[string]string
+	generation time.Duration
+	rotated    time.Time
+}
+
+func newInterner(interval time.Duration) *interner {
+	return &interner{
+		cur:        map[string]string{},
+		prev:       map[string]string{},
+		generation: internGenerations * interval,
+		rotated:    time.Now(),
+	}
+}
+
+// setInterval adapts the length of the generations to a new scrape interval.
+func (i *interner) setInterval(interval time.Duration) {
+	i.mtx.Lock()
+	defer i.mtx.Unlock()
+
+	i.generation = internGenerations * interval
+}
+
+// intern returns the interned copy of s. The lock must be held.
+func (i *interner) intern(s string) string {
+	if is, ok := i.cur[s]; ok {
+		return is
+	}
+	is, ok := i.prev[s]
+	if !ok {
+		is = s
+	}
+	i.cur[is] = is
+	return is
+}
+
+// internSamples replaces the label names and values of the samples with
+// their interned copies.
+func (i *interner) internSamples(samples model.Samples) {
+	i.mtx.Lock()
+	defer i.mtx.Unlock()
+
+	if now := time.Now(); now.Sub(i.rotated) > i.generation {
+		i.prev, i.cur = i.cur, make(map[string]string, len(i.cur))
+		i.rotated = now
+	}
+	for _, s := range samples {
+		// Assigning to an existing string key of a map replaces the key
+		// as well, so the metric can be updated in place.
+		for ln, lv := range s.Metric {
+			s.Metric[model.LabelName(i.intern(string(ln)))] = model.LabelValue(i.intern(string(lv)))
+		}
+	}
+}
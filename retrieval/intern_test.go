@@ -0,0 +1,85 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retrieval
+
+import (
+	"reflect"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/prometheus/common/model"
+)
+
+// newString returns a copy of s not sharing its memory.
+func newString(s string) string {
+	return string(append([]byte(nil), s...))
+}
+
+func sameString(a, b string) bool {
+	return unsafe.StringData(a) == unsafe.StringData(b)
+}
+
+func TestInternSamples(t *testing.T) {
+	i := newInterner(time.Minute)
+
+	newSamples := func() model.Samples {
+		return model.Samples{
+			{Metric: model.Metric{
+				model.LabelName(newString("__name__")): model.LabelValue(newString("up")),
+				model.LabelName(newString("pod")):      model.LabelValue(newString("web-0")),
+			}},
+		}
+	}
+	a, b := newSamples(), newSamples()
+	i.internSamples(a)
+	i.internSamples(b)
+
+	if !reflect.DeepEqual(a, b) {
+		t.Fatalf("Interning changed the samples: %v != %v", a, b)
+	}
+	for _, s := range append(a, b...) {
+		for ln, lv := range s.Metric {
+			if !sameString(string(ln), i.cur[string(ln)]) {
+				t.Errorf("Label name %q is not shared", ln)
+			}
+			if !sameString(string(lv), i.cur[string(lv)]) {
+				t.Errorf("Value %q of label %q is not shared", lv, ln)
+			}
+		}
+	}
+}
+
+func TestInternRotation(t *testing.T) {
+	i := newInterner(time.Minute)
+
+	i.internSamples(model.Samples{{Metric: model.Metric{"pod": "web-0"}}})
+	i.rotated = time.Now().Add(-time.Hour)
+	i.internSamples(model.Samples{{Metric: model.Metric{"pod": "web-1"}}})
+
+	// Strings of the previous generation are still shared once seen again.
+	if _, ok := i.prev["web-0"]; !ok {
+		t.Fatalf("Expected web-0 in the previous generation")
+	}
+	i.internSamples(model.Samples{{Metric: model.Metric{"pod": "web-1"}}})
+
+	i.rotated = time.Now().Add(-time.Hour)
+	i.internSamples(nil)
+	if _, ok := i.prev["web-0"]; ok {
+		t.Errorf("Expected web-0 to be dropped after two generations")
+	}
+	if _, ok := i.prev["web-1"]; !ok {
+		t.Errorf("Expected web-1 to be kept")
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retrieval
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const openMetricsMediaType = "application/openmetrics-text"
+
+// isOpenMetrics returns whether the response header announces the OpenMetrics
+// text format.
+func isOpenMetrics(h http.Header) bool {
+	mediatype, _, err := mime.ParseMediaType(h.Get("Content-Type"))
+	return err == nil && mediatype == openMetricsMediaType
+}
+
+// openMetricsReader converts the OpenMetrics text format into the Prometheus
+// text format, so that its samples can be decoded by the text parser.
+//
+// Only the samples are passed on: the metadata of the HELP, TYPE and UNIT
+// lines is collected by the reader, other comment lines are dropped,
+// exemplars are stripped and timestamps are converted from seconds to
+// milliseconds. Reading stops at the # EOF line.
+type openMetricsReader struct {
+	r   *bufio.Reader
+	buf []byte
+	eof bool
+
+	families map[string]*MetricMetadata
+	order    []string
+}
+
+func newOpenMetricsReader(r io.Reader) *openMetricsReader {
+	return &openMetricsReader{r: bufio.NewReader(r), families: map[string]*MetricMetadata{}}
+}
+
+var openMetricsHelpUnescaper = strings.NewReplacer(`\\`, `\`, `\n`, "\n", `\"`, `"`)
+
+// parseComment collects the metadata of a HELP, TYPE or UNIT line.
+func (r *openMetricsReader) parseComment(line string) {
+	fields := strings.SplitN(line, " ", 4)
+	if len(fields) < 3 || fields[0] != "#" {
+		return
+	}
+	name, text := fields[2], ""
+	if len(fields) == 4 {
+		text = fields[3]
+	}
+	switch fields[1] {
+	case "HELP", "TYPE", "UNIT":
+	default:
+		return
+	}
+	m, ok := r.families[name]
+	if !ok {
+		m = &MetricMetadata{Metric: name, Type: "unknown"}
+		r.families[name] = m
+		r.order = append(r.order, name)
+	}
+	switch fields[1] {
+	case "HELP":
+		m.Help = openMetricsHelpUnescaper.Replace(text)
+	case "TYPE":
+		m.Type = text
+	case "UNIT":
+		m.Unit = text
+	}
+}
+
+// metadata returns the metadata of the metric families read so far.
+func (r *openMetricsReader) metadata() []MetricMetadata {
+	md := make([]MetricMetadata, 0, len(r.order))
+	for _, name := range r.order {
+		md = append(md, *r.families[name])
+	}
+	return md
+}
+
+// Read implements io.Reader.
+func (r *openMetricsReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.eof {
+			return 0, io.EOF
+		}
+		line, err := r.r.ReadString('\n')
+		if err == io.EOF {
+			r.eof = true
+		} else if err != nil {
+			return 0, err
+		}
+		line = strings.TrimSuffix(line, "\n")
+
+		if line == "# EOF" {
+			r.eof = true
+			continue
+		}
+		if line == "" {
+			continue
+		}
+		if line[0] == '#' {
+			r.parseComment(line)
+			continue
+		}
+		sample, err := convertOpenMetricsSample(line)
+		if err != nil {
+			return 0, err
+		}
+		r.buf = append(r.buf[:0], sample...)
+		r.buf = append(r.buf, '\n')
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// convertOpenMetricsSample converts a sample line of the OpenMetrics text
+// format into a sample line of the Prometheus text format.
+func convertOpenMetricsSample(line string) (string, error) {
+	// The metric name with the labels ends at the first space outside of the
+	// quoted label values.
+	end := strings.IndexAny(line, " {")
+	if end < 0 {
+		return "", fmt.Errorf("invalid OpenMetrics sample %q", line)
+	}
+	if line[end] == '{' {
+		quoted := false
+		for end++; end < len(line); end++ {
+			c := line[end]
+			if quoted && c == '\\' {
+				end++
+			} else if c == '"' {
+				quoted = !quoted
+			} else if !quoted && c == '}' {
+				end++
+				break
+			}
+		}
+	}
+	series, rest := line[:end], line[end:]
+
+	// Exemplars are separated by a hash sign.
+	if i := strings.Index(rest, " # "); i >= 0 {
+		rest = rest[:i]
+	}
+	fields := strings.Fields(rest)
+	switch len(fields) {
+	case 1:
+		return series + " " + fields[0], nil
+	case 2:
+		ts, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid timestamp in OpenMetrics sample %q: %s", line, err)
+		}
+		return fmt.Sprintf("%s %s %d", series, fields[0], int64(math.Floor(ts*1000+0.5))), nil
+	default:
+		return "", fmt.Errorf("invalid OpenMetrics sample %q", line)
+	}
+}
@@ -14,13 +14,19 @@
 package retrieval
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	otlog "github.com/opentracing/opentracing-go/log"
 	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/prometheus/common/expfmt"
 	"github.com/prometheus/common/log"
 	"github.com/prometheus/common/model"
@@ -39,6 +45,7 @@ const (
 	scrapeDurationMetricName     = "scrape_duration_seconds"
 	scrapeSamplesMetricName      = "scrape_samples_scraped"
 	samplesPostRelabelMetricName = "scrape_samples_post_metric_relabeling"
+	scrapeSeriesAddedMetricName  = "scrape_series_added"
 )
 
 var (
@@ -85,6 +92,12 @@ var (
 			Help: "Total number of scrapes that hit the sample limit and were rejected.",
 		},
 	)
+	targetScrapeLabelLimit = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "prometheus_target_scrapes_exceeded_label_limits_total",
+			Help: "Total number of scrapes that hit one of the label limits and were rejected.",
+		},
+	)
 )
 
 func init() {
@@ -94,11 +107,13 @@ func init() {
 	prometheus.MustRegister(targetSyncIntervalLength)
 	prometheus.MustRegister(targetScrapePoolSyncsCounter)
 	prometheus.MustRegister(targetScrapeSampleLimit)
+	prometheus.MustRegister(targetScrapeLabelLimit)
 }
 
 // scrapePool manages scrapes for sets of targets.
 type scrapePool struct {
 	appender storage.SampleAppender
+	logger   log.Logger
 
 	ctx context.Context
 
@@ -109,24 +124,34 @@ type scrapePool struct {
 	// set of hashes.
 	targets map[uint64]*Target
 	loops   map[uint64]loop
+	// Timers stopping the scrape loops of targets removed by service
+	// discovery after the target removal delay.
+	removals map[uint64]*time.Timer
+	// Shared by the scrapers of all targets.
+	interner *interner
 
 	// Constructor for new scrape loops. This is settable for testing convenience.
-	newLoop func(context.Context, scraper, storage.SampleAppender, model.LabelSet, *config.ScrapeConfig) loop
+	newLoop func(context.Context, scraper, storage.SampleAppender, model.LabelSet, *config.ScrapeConfig, log.Logger) loop
 }
 
-func newScrapePool(ctx context.Context, cfg *config.ScrapeConfig, app storage.SampleAppender) *scrapePool {
+func newScrapePool(ctx context.Context, cfg *config.ScrapeConfig, app storage.SampleAppender, logger log.Logger) *scrapePool {
+	logger = logger.With("scrape_pool", cfg.JobName)
+
 	client, err := httputil.NewClientFromConfig(cfg.HTTPClientConfig)
 	if err != nil {
 		// Any errors that could occur here should be caught during config validation.
-		log.Errorf("Error creating HTTP client for job %q: %s", cfg.JobName, err)
+		logger.Errorf("Error creating HTTP client for job %q: %s", cfg.JobName, err)
 	}
 	return &scrapePool{
 		appender: app,
+		logger:   logger,
 		config:   cfg,
 		ctx:      ctx,
 		client:   client,
 		targets:  map[uint64]*Target{},
 		loops:    map[uint64]loop{},
+		removals: map[uint64]*time.Timer{},
+		interner: newInterner(time.Duration(cfg.ScrapeInterval)),
 		newLoop:  newScrapeLoop,
 	}
 }
@@ -149,6 +174,10 @@ func (sp *scrapePool) stop() {
 		delete(sp.loops, fp)
 		delete(sp.targets, fp)
 	}
+	for fp, t := range sp.removals {
+		t.Stop()
+		delete(sp.removals, fp)
+	}
 
 	wg.Wait()
 }
@@ -165,7 +194,7 @@ func (sp *scrapePool) reload(cfg *config.ScrapeConfig) {
 	client, err := httputil.NewClientFromConfig(cfg.HTTPClientConfig)
 	if err != nil {
 		// Any errors that could occur here should be caught during config validation.
-		log.Errorf("Error creating HTTP client for job %q: %s", cfg.JobName, err)
+		sp.logger.Errorf("Error creating HTTP client for job %q: %s", cfg.JobName, err)
 	}
 	sp.config = cfg
 	sp.client = client
@@ -174,17 +203,23 @@ func (sp *scrapePool) reload(cfg *config.ScrapeConfig) {
 		wg       sync.WaitGroup
 		interval = time.Duration(sp.config.ScrapeInterval)
 		timeout  = time.Duration(sp.config.ScrapeTimeout)
+		accept   = acceptHeader(sp.config.ScrapeProtocols)
 	)
+	if sp.interner != nil {
+		sp.interner.setInterval(interval)
+	}
 
 	for fp, oldLoop := range sp.loops {
 		var (
 			t = sp.targets[fp]
 			s = &targetScraper{
-				Target:  t,
-				client:  sp.client,
-				timeout: timeout,
+				Target:       t,
+				client:       sp.client,
+				timeout:      timeout,
+				acceptHeader: accept,
+				interner:     sp.interner,
 			}
-			newLoop = sp.newLoop(sp.ctx, s, sp.appender, t.Labels(), sp.config)
+			newLoop = sp.newLoop(sp.ctx, s, sp.appender, t.Labels(), sp.config, sp.targetLogger(t))
 		)
 		wg.Add(1)
 
@@ -213,7 +248,7 @@ func (sp *scrapePool) Sync(tgs []*config.TargetGroup) {
 	for _, tg := range tgs {
 		targets, err := targetsFromGroup(tg, sp.config)
 		if err != nil {
-			log.With("err", err).Error("creating targets failed")
+			sp.logger.With("err", err).Error("creating targets failed")
 			continue
 		}
 		all = append(all, targets...)
@@ -237,20 +272,28 @@ func (sp *scrapePool) sync(targets []*Target) {
 		uniqueTargets = map[uint64]struct{}{}
 		interval      = time.Duration(sp.config.ScrapeInterval)
 		timeout       = time.Duration(sp.config.ScrapeTimeout)
+		accept        = acceptHeader(sp.config.ScrapeProtocols)
 	)
 
 	for _, t := range targets {
 		hash := t.hash()
 		uniqueTargets[hash] = struct{}{}
 
+		// A target coming back within the removal delay is kept.
+		if r, ok := sp.removals[hash]; ok {
+			r.Stop()
+			delete(sp.removals, hash)
+		}
 		if _, ok := sp.targets[hash]; !ok {
 			s := &targetScraper{
-				Target:  t,
-				client:  sp.client,
-				timeout: timeout,
+				Target:       t,
+				client:       sp.client,
+				timeout:      timeout,
+				acceptHeader: accept,
+				interner:     sp.interner,
 			}
 
-			l := sp.newLoop(sp.ctx, s, sp.appender, t.Labels(), sp.config)
+			l := sp.newLoop(sp.ctx, s, sp.appender, t.Labels(), sp.config, sp.targetLogger(t))
 
 			sp.targets[hash] = t
 			sp.loops[hash] = l
@@ -259,20 +302,32 @@ func (sp *scrapePool) sync(targets []*Target) {
 		}
 	}
 
-	var wg sync.WaitGroup
+	var (
+		wg    sync.WaitGroup
+		delay = time.Duration(sp.config.TargetRemovalDelay)
+	)
 
-	// Stop and remove old targets and scraper loops.
+	// Stop and remove old targets and scraper loops. With a removal delay,
+	// old targets are still scraped until it has passed, so that their last
+	// samples are not lost when they are removed while still running.
 	for hash := range sp.targets {
-		if _, ok := uniqueTargets[hash]; !ok {
-			wg.Add(1)
-			go func(l loop) {
-				l.stop()
-				wg.Done()
-			}(sp.loops[hash])
-
-			delete(sp.loops, hash)
-			delete(sp.targets, hash)
+		if _, ok := uniqueTargets[hash]; ok {
+			continue
 		}
+		if delay > 0 {
+			if _, ok := sp.removals[hash]; !ok {
+				sp.removals[hash] = sp.scheduleRemoval(hash, delay)
+			}
+			continue
+		}
+		wg.Add(1)
+		go func(l loop) {
+			l.stop()
+			wg.Done()
+		}(sp.loops[hash])
+
+		delete(sp.loops, hash)
+		delete(sp.targets, hash)
 	}
 
 	// Wait for all potentially stopped scrapers to terminate.
@@ -282,21 +337,68 @@ func (sp *scrapePool) sync(targets []*Target) {
 	wg.Wait()
 }
 
+// scheduleRemoval returns a timer stopping the scrape loop of the target with
+// the given hash after the delay, unless the removal has been canceled.
+func (sp *scrapePool) scheduleRemoval(hash uint64, delay time.Duration) *time.Timer {
+	var t *time.Timer
+	t = time.AfterFunc(delay, func() {
+		sp.mtx.Lock()
+		defer sp.mtx.Unlock()
+
+		if sp.removals[hash] != t {
+			return
+		}
+		sp.loops[hash].stop()
+
+		delete(sp.removals, hash)
+		delete(sp.loops, hash)
+		delete(sp.targets, hash)
+	})
+	return t
+}
+
+// targetLogger returns the logger of the scrape loop of the given target.
+func (sp *scrapePool) targetLogger(t *Target) log.Logger {
+	return sp.logger.With("target", t.URL().String())
+}
+
 // A scraper retrieves samples and accepts a status report at the end.
 type scraper interface {
 	scrape(ctx context.Context, ts time.Time) (model.Samples, error)
-	report(start time.Time, dur time.Duration, err error)
+	report(start time.Time, dur time.Duration, seriesAdded int, err error)
 	offset(interval time.Duration) time.Duration
 }
 
 // targetScraper implements the scraper interface for a target.
 type targetScraper struct {
 	*Target
-	client  *http.Client
-	timeout time.Duration
+	client       *http.Client
+	timeout      time.Duration
+	acceptHeader string
+	interner     *interner
+	parser       textParser
 }
 
-const acceptHeader = `application/vnd.google.protobuf;proto=io.prometheus.client.MetricFamily;encoding=delimited;q=0.7,text/plain;version=0.0.4;q=0.3,*/*;q=0.1`
+// bodyBuffers holds the buffers the text format responses are read into.
+var bodyBuffers = sync.Pool{New: func() interface{} { return &bytes.Buffer{} }}
+
+// acceptHeader returns the Accept header preferring the given scrape
+// protocols in order, or the default ones if none are given.
+func acceptHeader(protocols []config.ScrapeProtocol) string {
+	if len(protocols) == 0 {
+		protocols = config.DefaultScrapeProtocols
+	}
+	var (
+		vals   = make([]string, 0, len(protocols)+1)
+		weight = len(config.ScrapeProtocolsHeaders) + 1
+	)
+	for _, p := range protocols {
+		vals = append(vals, fmt.Sprintf("%s;q=0.%d", config.ScrapeProtocolsHeaders[p], weight))
+		weight--
+	}
+	vals = append(vals, fmt.Sprintf("*/*;q=0.%d", weight))
+	return strings.Join(vals, ",")
+}
 
 var userAgentHeader = fmt.Sprintf("Prometheus/%s", version.Version)
 
@@ -305,9 +407,20 @@ func (s *targetScraper) scrape(ctx context.Context, ts time.Time) (model.Samples
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Add("Accept", acceptHeader)
+	for k, v := range s.Headers() {
+		req.Header[k] = v
+	}
+	accept := s.acceptHeader
+	if accept == "" {
+		accept = acceptHeader(nil)
+	}
+	req.Header.Add("Accept", accept)
 	req.Header.Set("User-Agent", userAgentHeader)
 	req.Header.Set("X-Prometheus-Scrape-Timeout-Seconds", fmt.Sprintf("%f", s.timeout.Seconds()))
+	if span := opentracing.SpanFromContext(ctx); span != nil {
+		// Let instrumented targets continue the trace of the scrape.
+		span.Tracer().Inject(span.Context(), opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(req.Header))
+	}
 
 	resp, err := ctxhttp.Do(ctx, s.client, req)
 	if err != nil {
@@ -320,28 +433,78 @@ func (s *targetScraper) scrape(ctx context.Context, ts time.Time) (model.Samples
 	}
 
 	var (
-		allSamples = make(model.Samples, 0, 200)
-		decSamples = make(model.Vector, 0, 50)
+		body   io.Reader = resp.Body
+		format           = expfmt.ResponseFormat(resp.Header)
+		// The OpenMetrics reader collects the metadata itself, as it
+		// drops the comment lines.
+		omReader *openMetricsReader
 	)
-	sdec := expfmt.SampleDecoder{
-		Dec: expfmt.NewDecoder(resp.Body, expfmt.ResponseFormat(resp.Header)),
-		Opts: &expfmt.DecodeOptions{
-			Timestamp: model.TimeFromUnixNano(ts.UnixNano()),
-		},
+	if isOpenMetrics(resp.Header) {
+		omReader = newOpenMetricsReader(resp.Body)
+		body, format = omReader, expfmt.FmtText
+	}
+	if format == expfmt.FmtText {
+		buf := bodyBuffers.Get().(*bytes.Buffer)
+		defer bodyBuffers.Put(buf)
+		buf.Reset()
+		if _, err := buf.ReadFrom(body); err != nil {
+			return nil, err
+		}
+		samples, err := s.parser.parse(buf.Bytes(), model.TimeFromUnixNano(ts.UnixNano()))
+		if err == nil {
+			if s.interner != nil {
+				s.interner.internSamples(samples)
+			}
+			if omReader != nil {
+				s.setMetadata(omReader.metadata())
+			} else {
+				s.setMetadata(s.parser.metadata())
+			}
+			return samples, nil
+		}
+		// Input the fast path does not handle is decoded by expfmt.
+		body = bytes.NewReader(buf.Bytes())
 	}
 
+	var (
+		allSamples = make(model.Samples, 0, 200)
+		metadata   []MetricMetadata
+		dec        = expfmt.NewDecoder(body, format)
+		opts       = &expfmt.DecodeOptions{
+			Timestamp: model.TimeFromUnixNano(ts.UnixNano()),
+		}
+		mf dto.MetricFamily
+	)
+
 	for {
-		if err = sdec.Decode(&decSamples); err != nil {
+		if err = dec.Decode(&mf); err != nil {
+			break
+		}
+		var decSamples model.Vector
+		if decSamples, err = expfmt.ExtractSamples(opts, &mf); err != nil {
 			break
 		}
 		allSamples = append(allSamples, decSamples...)
-		decSamples = decSamples[:0]
+		metadata = append(metadata, MetricMetadata{
+			Metric: mf.GetName(),
+			Type:   strings.ToLower(mf.GetType().String()),
+			Help:   mf.GetHelp(),
+		})
 	}
 
 	if err == io.EOF {
 		// Set err to nil since it is used in the scrape health recording.
 		err = nil
 	}
+	if err == nil {
+		if s.interner != nil {
+			s.interner.internSamples(allSamples)
+		}
+		if omReader != nil {
+			metadata = omReader.metadata()
+		}
+		s.setMetadata(metadata)
+	}
 	return allSamples, err
 }
 
@@ -353,6 +516,7 @@ type loop interface {
 
 type scrapeLoop struct {
 	scraper scraper
+	logger  log.Logger
 
 	// Where samples are ultimately sent.
 	appender storage.SampleAppender
@@ -361,6 +525,11 @@ type scrapeLoop struct {
 	metricRelabelConfigs []*config.RelabelConfig
 	honorLabels          bool
 	sampleLimit          uint
+	labelLimits          labelLimits
+
+	// The series of the last successful scrape, to count the series added
+	// by the next one.
+	lastSeries map[model.Fingerprint]struct{}
 
 	done   chan struct{}
 	ctx    context.Context
@@ -373,15 +542,22 @@ func newScrapeLoop(
 	appender storage.SampleAppender,
 	targetLabels model.LabelSet,
 	config *config.ScrapeConfig,
+	logger log.Logger,
 ) loop {
 	sl := &scrapeLoop{
 		scraper:              sc,
+		logger:               logger,
 		appender:             appender,
 		targetLabels:         targetLabels,
 		metricRelabelConfigs: config.MetricRelabelConfigs,
 		honorLabels:          config.HonorLabels,
 		sampleLimit:          config.SampleLimit,
-		done:                 make(chan struct{}),
+		labelLimits: labelLimits{
+			labelLimit:            config.LabelLimit,
+			labelNameLengthLimit:  config.LabelNameLengthLimit,
+			labelValueLengthLimit: config.LabelValueLengthLimit,
+		},
+		done: make(chan struct{}),
 	}
 	sl.ctx, sl.cancel = context.WithCancel(ctx)
 
@@ -415,7 +591,11 @@ func (sl *scrapeLoop) run(interval, timeout time.Duration, errc chan<- error) {
 				start                 = time.Now()
 				scrapeCtx, cancel     = context.WithTimeout(sl.ctx, timeout)
 				numPostRelabelSamples = 0
+				numSeriesAdded        = 0
 			)
+			span, spanCtx := opentracing.StartSpanFromContext(scrapeCtx, "scrape")
+			span.SetTag("job", string(sl.targetLabels[model.JobLabel]))
+			span.SetTag("instance", string(sl.targetLabels[model.InstanceLabel]))
 
 			// Only record after the first scrape.
 			if !last.IsZero() {
@@ -424,15 +604,26 @@ func (sl *scrapeLoop) run(interval, timeout time.Duration, errc chan<- error) {
 				)
 			}
 
-			samples, err := sl.scraper.scrape(scrapeCtx, start)
+			samples, err := sl.scraper.scrape(spanCtx, start)
 			cancel()
 			if err == nil {
-				numPostRelabelSamples, err = sl.append(samples)
+				numPostRelabelSamples, numSeriesAdded, err = sl.append(samples)
+			}
+			if err != nil {
+				sl.logger.With("err", err).Debug("Scrape failed")
+				if errc != nil {
+					errc <- err
+				}
 			}
-			if err != nil && errc != nil {
-				errc <- err
+			sl.report(start, time.Since(start), len(samples), numPostRelabelSamples, numSeriesAdded, err)
+
+			span.SetTag("samples", len(samples))
+			span.SetTag("post_relabel_samples", numPostRelabelSamples)
+			if err != nil {
+				ext.Error.Set(span, true)
+				span.LogFields(otlog.Error(err))
 			}
-			sl.report(start, time.Since(start), len(samples), numPostRelabelSamples, err)
+			span.Finish()
 			last = start
 		} else {
 			targetSkippedScrapes.Inc()
@@ -452,13 +643,14 @@ func (sl *scrapeLoop) stop() {
 }
 
 // wrapAppender wraps a SampleAppender for relabeling. It returns the wrappend
-// appender and an innermost countingAppender that counts the samples actually
-// appended in the end.
+// appender and an innermost countingAppender that counts the samples and
+// records the series actually appended in the end.
 func (sl *scrapeLoop) wrapAppender(app storage.SampleAppender) (storage.SampleAppender, *countingAppender) {
 	// Innermost appender is a countingAppender to count how many samples
 	// are left in the end.
 	countingAppender := &countingAppender{
 		SampleAppender: app,
+		series:         make(map[model.Fingerprint]struct{}, len(sl.lastSeries)),
 	}
 	app = countingAppender
 
@@ -485,7 +677,10 @@ func (sl *scrapeLoop) wrapAppender(app storage.SampleAppender) (storage.SampleAp
 	return app, countingAppender
 }
 
-func (sl *scrapeLoop) append(samples model.Samples) (int, error) {
+// append appends the scraped samples. It returns the number of samples left
+// after relabeling and the number of series that were not in the last
+// successful scrape.
+func (sl *scrapeLoop) append(samples model.Samples) (int, int, error) {
 	var (
 		numOutOfOrder = 0
 		numDuplicates = 0
@@ -493,10 +688,10 @@ func (sl *scrapeLoop) append(samples model.Samples) (int, error) {
 		countingApp   *countingAppender
 	)
 
-	if sl.sampleLimit > 0 {
-		// We need to check for the sample limit, so append everything
-		// to a wrapped bufferAppender first. Then point samples to the
-		// result.
+	if sl.sampleLimit > 0 || sl.labelLimits.enabled() {
+		// We need to check for the sample and label limits, so append
+		// everything to a wrapped bufferAppender first. Then point samples
+		// to the result.
 		bufApp := &bufferAppender{buffer: make(model.Samples, 0, len(samples))}
 		var wrappedBufApp storage.SampleAppender
 		wrappedBufApp, countingApp = sl.wrapAppender(bufApp)
@@ -505,14 +700,18 @@ func (sl *scrapeLoop) append(samples model.Samples) (int, error) {
 			wrappedBufApp.Append(s)
 		}
 		samples = bufApp.buffer
-		if uint(countingApp.count) > sl.sampleLimit {
+		if sl.sampleLimit > 0 && uint(countingApp.count) > sl.sampleLimit {
 			targetScrapeSampleLimit.Inc()
-			return countingApp.count, fmt.Errorf(
+			return countingApp.count, 0, fmt.Errorf(
 				"%d samples exceeded limit of %d", countingApp.count, sl.sampleLimit,
 			)
 		}
+		if err := sl.labelLimits.check(samples); err != nil {
+			targetScrapeLabelLimit.Inc()
+			return countingApp.count, 0, err
+		}
 	} else {
-		// No need to check for sample limit. Wrap sl.appender directly.
+		// No need to check for limits. Wrap sl.appender directly.
 		app, countingApp = sl.wrapAppender(sl.appender)
 	}
 
@@ -521,26 +720,68 @@ func (sl *scrapeLoop) append(samples model.Samples) (int, error) {
 			switch err {
 			case local.ErrOutOfOrderSample:
 				numOutOfOrder++
-				log.With("sample", s).With("error", err).Debug("Sample discarded")
+				sl.logger.With("sample", s).With("error", err).Debug("Sample discarded")
 			case local.ErrDuplicateSampleForTimestamp:
 				numDuplicates++
-				log.With("sample", s).With("error", err).Debug("Sample discarded")
+				sl.logger.With("sample", s).With("error", err).Debug("Sample discarded")
 			default:
-				log.With("sample", s).With("error", err).Warn("Sample discarded")
+				sl.logger.With("sample", s).With("error", err).Warn("Sample discarded")
 			}
 		}
 	}
 	if numOutOfOrder > 0 {
-		log.With("numDropped", numOutOfOrder).Warn("Error on ingesting out-of-order samples")
+		sl.logger.With("numDropped", numOutOfOrder).Warn("Error on ingesting out-of-order samples")
 	}
 	if numDuplicates > 0 {
-		log.With("numDropped", numDuplicates).Warn("Error on ingesting samples with different value but same timestamp")
+		sl.logger.With("numDropped", numDuplicates).Warn("Error on ingesting samples with different value but same timestamp")
+	}
+
+	numAdded := 0
+	for fp := range countingApp.series {
+		if _, ok := sl.lastSeries[fp]; !ok {
+			numAdded++
+		}
+	}
+	sl.lastSeries = countingApp.series
+	return countingApp.count, numAdded, nil
+}
+
+// labelLimits are the limits on the labels of scraped samples after metric
+// relabeling. A zero limit is not enforced.
+type labelLimits struct {
+	labelLimit            uint
+	labelNameLengthLimit  uint
+	labelValueLengthLimit uint
+}
+
+func (l labelLimits) enabled() bool {
+	return l.labelLimit > 0 || l.labelNameLengthLimit > 0 || l.labelValueLengthLimit > 0
+}
+
+// check returns an error for the first sample exceeding one of the limits.
+func (l labelLimits) check(samples model.Samples) error {
+	if !l.enabled() {
+		return nil
+	}
+	for _, s := range samples {
+		met := s.Metric[model.MetricNameLabel]
+		if l.labelLimit > 0 && uint(len(s.Metric)) > l.labelLimit {
+			return fmt.Errorf("label_limit exceeded (metric: %.50s, number of labels: %d, limit: %d)", met, len(s.Metric), l.labelLimit)
+		}
+		for ln, lv := range s.Metric {
+			if l.labelNameLengthLimit > 0 && uint(len(ln)) > l.labelNameLengthLimit {
+				return fmt.Errorf("label_name_length_limit exceeded (metric: %.50s, label name: %.50s, length: %d, limit: %d)", met, ln, len(ln), l.labelNameLengthLimit)
+			}
+			if l.labelValueLengthLimit > 0 && uint(len(lv)) > l.labelValueLengthLimit {
+				return fmt.Errorf("label_value_length_limit exceeded (metric: %.50s, label name: %.50s, value: %.50q, length: %d, limit: %d)", met, ln, lv, len(lv), l.labelValueLengthLimit)
+			}
+		}
 	}
-	return countingApp.count, nil
+	return nil
 }
 
-func (sl *scrapeLoop) report(start time.Time, duration time.Duration, scrapedSamples, postRelabelSamples int, err error) {
-	sl.scraper.report(start, duration, err)
+func (sl *scrapeLoop) report(start time.Time, duration time.Duration, scrapedSamples, postRelabelSamples, seriesAdded int, err error) {
+	sl.scraper.report(start, duration, seriesAdded, err)
 
 	ts := model.TimeFromUnixNano(start.UnixNano())
 
@@ -577,6 +818,13 @@ func (sl *scrapeLoop) report(start time.Time, duration time.Duration, scrapedSam
 		Timestamp: ts,
 		Value:     model.SampleValue(postRelabelSamples),
 	}
+	seriesAddedSample := &model.Sample{
+		Metric: model.Metric{
+			model.MetricNameLabel: scrapeSeriesAddedMetricName,
+		},
+		Timestamp: ts,
+		Value:     model.SampleValue(seriesAdded),
+	}
 
 	reportAppender := ruleLabelsAppender{
 		SampleAppender: sl.appender,
@@ -584,15 +832,18 @@ func (sl *scrapeLoop) report(start time.Time, duration time.Duration, scrapedSam
 	}
 
 	if err := reportAppender.Append(healthSample); err != nil {
-		log.With("sample", healthSample).With("error", err).Warn("Scrape health sample discarded")
+		sl.logger.With("sample", healthSample).With("error", err).Warn("Scrape health sample discarded")
 	}
 	if err := reportAppender.Append(durationSample); err != nil {
-		log.With("sample", durationSample).With("error", err).Warn("Scrape duration sample discarded")
+		sl.logger.With("sample", durationSample).With("error", err).Warn("Scrape duration sample discarded")
 	}
 	if err := reportAppender.Append(countSample); err != nil {
-		log.With("sample", durationSample).With("error", err).Warn("Scrape sample count sample discarded")
+		sl.logger.With("sample", durationSample).With("error", err).Warn("Scrape sample count sample discarded")
 	}
 	if err := reportAppender.Append(postRelabelSample); err != nil {
-		log.With("sample", durationSample).With("error", err).Warn("Scrape sample count post-relabeling sample discarded")
+		sl.logger.With("sample", durationSample).With("error", err).Warn("Scrape sample count post-relabeling sample discarded")
+	}
+	if err := reportAppender.Append(seriesAddedSample); err != nil {
+		sl.logger.With("sample", seriesAddedSample).With("error", err).Warn("Scrape series added sample discarded")
 	}
 }
@@ -25,6 +25,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/common/log"
 	"github.com/prometheus/common/model"
 	"golang.org/x/net/context"
 
@@ -36,7 +37,7 @@ func TestNewScrapePool(t *testing.T) {
 	var (
 		app = &nopAppender{}
 		cfg = &config.ScrapeConfig{}
-		sp  = newScrapePool(context.Background(), cfg, app)
+		sp  = newScrapePool(context.Background(), cfg, app, log.Base())
 	)
 
 	if a, ok := sp.appender.(*nopAppender); !ok || a != app {
@@ -139,7 +140,7 @@ func TestScrapePoolReload(t *testing.T) {
 	}
 	// On starting to run, new loops created on reload check whether their preceding
 	// equivalents have been stopped.
-	newLoop := func(ctx context.Context, s scraper, app storage.SampleAppender, tl model.LabelSet, cfg *config.ScrapeConfig) loop {
+	newLoop := func(ctx context.Context, s scraper, app storage.SampleAppender, tl model.LabelSet, cfg *config.ScrapeConfig, logger log.Logger) loop {
 		l := &testLoop{}
 		l.startFunc = func(interval, timeout time.Duration, errc chan<- error) {
 			if interval != 3*time.Second {
@@ -157,6 +158,7 @@ func TestScrapePoolReload(t *testing.T) {
 		return l
 	}
 	sp := &scrapePool{
+		logger:  log.Base(),
 		targets: map[uint64]*Target{},
 		loops:   map[uint64]loop{},
 		newLoop: newLoop,
@@ -222,6 +224,63 @@ func TestScrapePoolReload(t *testing.T) {
 	}
 }
 
+func TestScrapePoolSyncRemovalDelay(t *testing.T) {
+	stopped := make(chan uint64, 2)
+	newLoop := func(ctx context.Context, s scraper, app storage.SampleAppender, tl model.LabelSet, cfg *config.ScrapeConfig, logger log.Logger) loop {
+		hash := s.(*targetScraper).hash()
+		return &testLoop{
+			startFunc: func(interval, timeout time.Duration, errc chan<- error) {},
+			stopFunc: func() {
+				stopped <- hash
+			},
+		}
+	}
+	sp := &scrapePool{
+		logger:   log.Base(),
+		config:   &config.ScrapeConfig{TargetRemovalDelay: model.Duration(50 * time.Millisecond)},
+		targets:  map[uint64]*Target{},
+		loops:    map[uint64]loop{},
+		removals: map[uint64]*time.Timer{},
+		newLoop:  newLoop,
+	}
+	t1 := &Target{labels: model.LabelSet{model.AddressLabel: "example.com:1"}}
+	t2 := &Target{labels: model.LabelSet{model.AddressLabel: "example.com:2"}}
+
+	sp.sync([]*Target{t1, t2})
+	// Both targets are removed, but t2 comes back within the delay.
+	sp.sync(nil)
+	sp.mtx.RLock()
+	if len(sp.loops) != 2 {
+		t.Errorf("Expected removed targets to be scraped during the removal delay, got %d loops", len(sp.loops))
+	}
+	sp.mtx.RUnlock()
+	sp.sync([]*Target{t2})
+
+	select {
+	case hash := <-stopped:
+		if hash != t1.hash() {
+			t.Fatalf("Expected the loop of %v to be stopped, got the one of %v", t1, t2)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Loop of %v was not stopped after the removal delay", t1)
+	}
+
+	// The loop is stopped while the pool is locked for the removal.
+	sp.mtx.RLock()
+	defer sp.mtx.RUnlock()
+	if _, ok := sp.loops[t1.hash()]; ok || len(sp.loops) != 1 {
+		t.Errorf("Expected only the loop of %v after the removal delay, got %v", t2, sp.loops)
+	}
+	if len(sp.removals) != 0 {
+		t.Errorf("Expected no pending removals, got %v", sp.removals)
+	}
+	select {
+	case <-stopped:
+		t.Errorf("Expected only the loop of %v to be stopped", t1)
+	default:
+	}
+}
+
 func TestScrapeLoopWrapSampleAppender(t *testing.T) {
 	cfg := &config.ScrapeConfig{
 		MetricRelabelConfigs: []*config.RelabelConfig{
@@ -241,7 +300,7 @@ func TestScrapeLoopWrapSampleAppender(t *testing.T) {
 	target := newTestTarget("example.com:80", 10*time.Millisecond, nil)
 	app := &nopAppender{}
 
-	sp := newScrapePool(context.Background(), cfg, app)
+	sp := newScrapePool(context.Background(), cfg, app, log.Base())
 
 	cfg.HonorLabels = false
 
@@ -251,6 +310,7 @@ func TestScrapeLoopWrapSampleAppender(t *testing.T) {
 		sp.appender,
 		target.Labels(),
 		sp.config,
+		sp.logger,
 	).(*scrapeLoop)
 	wrapped, _ := sl.wrapAppender(sl.appender)
 
@@ -277,6 +337,7 @@ func TestScrapeLoopWrapSampleAppender(t *testing.T) {
 		sp.appender,
 		target.Labels(),
 		sp.config,
+		sp.logger,
 	).(*scrapeLoop)
 	wrapped, _ = sl.wrapAppender(sl.appender)
 
@@ -333,6 +394,10 @@ func TestScrapeLoopSampleProcessing(t *testing.T) {
 					Metric: model.Metric{"__name__": "scrape_samples_post_metric_relabeling"},
 					Value:  2,
 				},
+				{
+					Metric: model.Metric{"__name__": "scrape_series_added"},
+					Value:  2,
+				},
 			},
 			expectedPostRelabelSamplesCount: 2,
 		},
@@ -364,6 +429,10 @@ func TestScrapeLoopSampleProcessing(t *testing.T) {
 					Metric: model.Metric{"__name__": "scrape_samples_post_metric_relabeling"},
 					Value:  1,
 				},
+				{
+					Metric: model.Metric{"__name__": "scrape_series_added"},
+					Value:  1,
+				},
 			},
 			expectedPostRelabelSamplesCount: 1,
 		},
@@ -396,6 +465,10 @@ func TestScrapeLoopSampleProcessing(t *testing.T) {
 					Metric: model.Metric{"__name__": "scrape_samples_post_metric_relabeling"},
 					Value:  1,
 				},
+				{
+					Metric: model.Metric{"__name__": "scrape_series_added"},
+					Value:  1,
+				},
 			},
 			expectedPostRelabelSamplesCount: 1,
 		},
@@ -421,6 +494,10 @@ func TestScrapeLoopSampleProcessing(t *testing.T) {
 					Metric: model.Metric{"__name__": "scrape_samples_post_metric_relabeling"},
 					Value:  2,
 				},
+				{
+					Metric: model.Metric{"__name__": "scrape_series_added"},
+					Value:  0,
+				},
 			},
 			expectedPostRelabelSamplesCount: 2,
 		},
@@ -432,9 +509,9 @@ func TestScrapeLoopSampleProcessing(t *testing.T) {
 		target := newTestTarget("example.com:80", 10*time.Millisecond, nil)
 
 		scraper := &testScraper{}
-		sl := newScrapeLoop(context.Background(), scraper, ingestedSamples, target.Labels(), test.scrapeConfig).(*scrapeLoop)
-		num, err := sl.append(test.scrapedSamples)
-		sl.report(time.Unix(0, 0), 42*time.Second, len(test.scrapedSamples), num, err)
+		sl := newScrapeLoop(context.Background(), scraper, ingestedSamples, target.Labels(), test.scrapeConfig, log.Base()).(*scrapeLoop)
+		num, added, err := sl.append(test.scrapedSamples)
+		sl.report(time.Unix(0, 0), 42*time.Second, len(test.scrapedSamples), num, added, err)
 		reportedSamples := ingestedSamples.buffer
 		if err == nil {
 			reportedSamples = reportedSamples[num:]
@@ -452,9 +529,135 @@ func TestScrapeLoopSampleProcessing(t *testing.T) {
 
 }
 
+func TestScrapeLoopSeriesAdded(t *testing.T) {
+	sl := newScrapeLoop(context.Background(), &testScraper{}, &bufferAppender{}, nil, &config.ScrapeConfig{SampleLimit: 2}, log.Base()).(*scrapeLoop)
+
+	scrapes := []struct {
+		samples model.Samples
+		added   int
+		err     bool
+	}{
+		{
+			samples: model.Samples{
+				{Metric: model.Metric{"__name__": "a_metric"}},
+				{Metric: model.Metric{"__name__": "b_metric"}},
+			},
+			added: 2,
+		},
+		{
+			samples: model.Samples{
+				{Metric: model.Metric{"__name__": "a_metric"}},
+				{Metric: model.Metric{"__name__": "c_metric"}},
+			},
+			added: 1,
+		},
+		// Failed scrapes add no series and are not compared to.
+		{
+			samples: model.Samples{
+				{Metric: model.Metric{"__name__": "d_metric"}},
+				{Metric: model.Metric{"__name__": "e_metric"}},
+				{Metric: model.Metric{"__name__": "f_metric"}},
+			},
+			err: true,
+		},
+		{
+			samples: model.Samples{
+				{Metric: model.Metric{"__name__": "a_metric"}},
+				{Metric: model.Metric{"__name__": "c_metric"}},
+			},
+			added: 0,
+		},
+	}
+	for i, scrape := range scrapes {
+		_, added, err := sl.append(scrape.samples)
+		if (err != nil) != scrape.err {
+			t.Fatalf("%d. unexpected error %v", i, err)
+		}
+		if added != scrape.added {
+			t.Errorf("%d. expected %d series added, got %d", i, scrape.added, added)
+		}
+	}
+}
+
+func TestScrapeLoopLabelLimits(t *testing.T) {
+	samples := model.Samples{
+		{
+			Metric: model.Metric{"__name__": "a_metric", "foo": "bar"},
+		},
+		{
+			Metric: model.Metric{"__name__": "b_metric", "long_label_name": "long label value"},
+		},
+	}
+
+	testCases := []struct {
+		scrapeConfig *config.ScrapeConfig
+		expectedErr  string
+	}{
+		{
+			scrapeConfig: &config.ScrapeConfig{
+				LabelLimit:            2,
+				LabelNameLengthLimit:  15,
+				LabelValueLengthLimit: 16,
+			},
+		},
+		{
+			scrapeConfig: &config.ScrapeConfig{
+				LabelLimit: 1,
+			},
+			expectedErr: "label_limit exceeded (metric: a_metric, number of labels: 2, limit: 1)",
+		},
+		{
+			scrapeConfig: &config.ScrapeConfig{
+				LabelNameLengthLimit: 10,
+			},
+			expectedErr: "label_name_length_limit exceeded (metric: b_metric, label name: long_label_name, length: 15, limit: 10)",
+		},
+		{
+			scrapeConfig: &config.ScrapeConfig{
+				LabelValueLengthLimit: 10,
+			},
+			expectedErr: `label_value_length_limit exceeded (metric: b_metric, label name: long_label_name, value: "long label value", length: 16, limit: 10)`,
+		},
+		{
+			// Labels dropped by metric relabeling are not limited.
+			scrapeConfig: &config.ScrapeConfig{
+				LabelLimit: 1,
+				MetricRelabelConfigs: []*config.RelabelConfig{
+					{
+						Action: config.RelabelLabelDrop,
+						Regex:  config.MustNewRegexp("foo|long_label_name"),
+					},
+				},
+			},
+		},
+	}
+
+	for i, test := range testCases {
+		ingestedSamples := &bufferAppender{buffer: model.Samples{}}
+
+		sl := newScrapeLoop(context.Background(), &testScraper{}, ingestedSamples, nil, test.scrapeConfig, log.Base()).(*scrapeLoop)
+		_, _, err := sl.append(samples)
+		if test.expectedErr == "" {
+			if err != nil {
+				t.Fatalf("Case %d: unexpected error: %s", i, err)
+			}
+			if len(ingestedSamples.buffer) != len(samples) {
+				t.Fatalf("Case %d: expected %d ingested samples, got %d", i, len(samples), len(ingestedSamples.buffer))
+			}
+			continue
+		}
+		if err == nil || err.Error() != test.expectedErr {
+			t.Fatalf("Case %d: expected error %q, got %v", i, test.expectedErr, err)
+		}
+		if len(ingestedSamples.buffer) != 0 {
+			t.Fatalf("Case %d: expected no ingested samples, got %v", i, ingestedSamples.buffer)
+		}
+	}
+}
+
 func TestScrapeLoopStop(t *testing.T) {
 	scraper := &testScraper{}
-	sl := newScrapeLoop(context.Background(), scraper, nil, nil, &config.ScrapeConfig{})
+	sl := newScrapeLoop(context.Background(), scraper, nil, nil, &config.ScrapeConfig{}, log.Base())
 
 	// The scrape pool synchronizes on stopping scrape loops. However, new scrape
 	// loops are started asynchronously. Thus it's possible, that a loop is stopped
@@ -510,7 +713,7 @@ func TestScrapeLoopRun(t *testing.T) {
 	defer close(signal)
 
 	ctx, cancel := context.WithCancel(context.Background())
-	sl := newScrapeLoop(ctx, scraper, app, nil, &config.ScrapeConfig{})
+	sl := newScrapeLoop(ctx, scraper, app, nil, &config.ScrapeConfig{}, log.Base())
 
 	// The loop must terminate during the initial offset if the context
 	// is canceled.
@@ -548,7 +751,7 @@ func TestScrapeLoopRun(t *testing.T) {
 	}
 
 	ctx, cancel = context.WithCancel(context.Background())
-	sl = newScrapeLoop(ctx, scraper, app, nil, &config.ScrapeConfig{})
+	sl = newScrapeLoop(ctx, scraper, app, nil, &config.ScrapeConfig{}, log.Base())
 
 	go func() {
 		sl.run(time.Second, 100*time.Millisecond, errc)
@@ -594,6 +797,9 @@ func TestTargetScraperScrapeOK(t *testing.T) {
 				t.Errorf("Expected: %v", expectedTimeout)
 				t.Fatalf("Got: %v", timeout)
 			}
+			if orgID := r.Header.Get("X-Scope-OrgID"); orgID != "tenant-1" {
+				t.Fatalf("Expected X-Scope-OrgID header %q, got %q", "tenant-1", orgID)
+			}
 
 			w.Header().Set("Content-Type", `text/plain; version=0.0.4`)
 			w.Write([]byte("metric_a 1\nmetric_b 2\n"))
@@ -609,8 +815,9 @@ func TestTargetScraperScrapeOK(t *testing.T) {
 	ts := &targetScraper{
 		Target: &Target{
 			labels: model.LabelSet{
-				model.SchemeLabel:  model.LabelValue(serverURL.Scheme),
-				model.AddressLabel: model.LabelValue(serverURL.Host),
+				model.SchemeLabel:        model.LabelValue(serverURL.Scheme),
+				model.AddressLabel:       model.LabelValue(serverURL.Host),
+				"__header_X_Scope_OrgID": "tenant-1",
 			},
 		},
 		client:  http.DefaultClient,
@@ -645,6 +852,175 @@ func TestTargetScraperScrapeOK(t *testing.T) {
 	}
 }
 
+func TestTargetScraperScrapeOpenMetrics(t *testing.T) {
+	const accept = "application/openmetrics-text;version=1.0.0;q=0.5,text/plain;version=0.0.4;q=0.4,*/*;q=0.3"
+
+	server := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if got := r.Header.Get("Accept"); got != accept {
+				t.Errorf("Expected Accept header %q, got %q", accept, got)
+			}
+			w.Header().Set("Content-Type", `application/openmetrics-text; version=1.0.0; charset=utf-8`)
+			w.Write([]byte(`# HELP requests Requests served.
+# TYPE requests counter
+# UNIT requests requests
+requests_total{path="/a b}",code="200"} 3 # {trace_id="abc"} 1 1500000000.123
+requests_created{path="/a b}",code="200"} 1500000000
+# TYPE temperature gauge
+temperature 21.5 1500000000.5
+# EOF
+ignored 1
+`))
+		}),
+	)
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		panic(err)
+	}
+
+	ts := &targetScraper{
+		Target: &Target{
+			labels: model.LabelSet{
+				model.SchemeLabel:  model.LabelValue(serverURL.Scheme),
+				model.AddressLabel: model.LabelValue(serverURL.Host),
+			},
+		},
+		client:       http.DefaultClient,
+		acceptHeader: acceptHeader([]config.ScrapeProtocol{config.OpenMetricsText1_0_0, config.PrometheusText0_0_4}),
+	}
+	now := time.Now()
+
+	samples, err := ts.scrape(context.Background(), now)
+	if err != nil {
+		t.Fatalf("Unexpected scrape error: %s", err)
+	}
+
+	expectedSamples := model.Samples{
+		{
+			Metric:    model.Metric{"__name__": "requests_total", "path": "/a b}", "code": "200"},
+			Timestamp: model.TimeFromUnixNano(now.UnixNano()),
+			Value:     3,
+		},
+		{
+			Metric:    model.Metric{"__name__": "requests_created", "path": "/a b}", "code": "200"},
+			Timestamp: model.TimeFromUnixNano(now.UnixNano()),
+			Value:     1500000000,
+		},
+		{
+			Metric:    model.Metric{"__name__": "temperature"},
+			Timestamp: model.Time(1500000000500),
+			Value:     21.5,
+		},
+	}
+	sort.Sort(expectedSamples)
+	sort.Sort(samples)
+
+	if !reflect.DeepEqual(samples, expectedSamples) {
+		t.Errorf("Scraped samples did not match served metrics")
+		t.Errorf("Expected: %v", expectedSamples)
+		t.Fatalf("Got: %v", samples)
+	}
+
+	expectedMetadata := []MetricMetadata{
+		{Metric: "requests", Type: "counter", Help: "Requests served.", Unit: "requests"},
+		{Metric: "temperature", Type: "gauge"},
+	}
+	if md := ts.Metadata(); !reflect.DeepEqual(md, expectedMetadata) {
+		t.Errorf("Expected metadata %v, got %v", expectedMetadata, md)
+	}
+}
+
+func TestTargetScraperScrapeMetadata(t *testing.T) {
+	scenarios := []struct {
+		body     string
+		expected []MetricMetadata
+	}{
+		{
+			body: "# HELP a A \\\\ b\\n.\n# TYPE a counter\na 1\n# HELP b Untyped.\nb 1\n# TYPE c gauge\nc 1\nd 1\n",
+			expected: []MetricMetadata{
+				{Metric: "a", Type: "counter", Help: "A \\ b\n."},
+				{Metric: "b", Type: "untyped", Help: "Untyped."},
+				{Metric: "c", Type: "gauge"},
+				{Metric: "d", Type: "untyped"},
+			},
+		},
+		{
+			// Decoded by the expfmt fallback, as the +Inf bucket is
+			// missing.
+			body: "# HELP h A histogram.\n# TYPE h histogram\nh_bucket{le=\"1\"} 1\nh_sum 1\nh_count 1\nd 1\n",
+			expected: []MetricMetadata{
+				{Metric: "d", Type: "untyped"},
+				{Metric: "h", Type: "histogram", Help: "A histogram."},
+			},
+		},
+	}
+
+	for i, scenario := range scenarios {
+		server := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", `text/plain; version=0.0.4`)
+				w.Write([]byte(scenario.body))
+			}),
+		)
+
+		serverURL, err := url.Parse(server.URL)
+		if err != nil {
+			panic(err)
+		}
+		ts := &targetScraper{
+			Target: &Target{
+				labels: model.LabelSet{
+					model.SchemeLabel:  model.LabelValue(serverURL.Scheme),
+					model.AddressLabel: model.LabelValue(serverURL.Host),
+				},
+			},
+			client: http.DefaultClient,
+		}
+		if _, err := ts.scrape(context.Background(), time.Now()); err != nil {
+			t.Fatalf("%d. Unexpected scrape error: %s", i, err)
+		}
+		server.Close()
+
+		md := ts.Metadata()
+		sort.Slice(md, func(i, j int) bool { return md[i].Metric < md[j].Metric })
+		if !reflect.DeepEqual(md, scenario.expected) {
+			t.Errorf("%d. Expected metadata %v, got %v", i, scenario.expected, md)
+		}
+	}
+}
+
+func TestAcceptHeader(t *testing.T) {
+	var scenarios = []struct {
+		protocols []config.ScrapeProtocol
+		expected  string
+	}{
+		{
+			protocols: nil,
+			expected:  "application/vnd.google.protobuf;proto=io.prometheus.client.MetricFamily;encoding=delimited;q=0.5,text/plain;version=0.0.4;q=0.4,*/*;q=0.3",
+		}, {
+			protocols: []config.ScrapeProtocol{config.PrometheusText0_0_4},
+			expected:  "text/plain;version=0.0.4;q=0.5,*/*;q=0.4",
+		}, {
+			protocols: []config.ScrapeProtocol{
+				config.OpenMetricsText1_0_0,
+				config.OpenMetricsText0_0_1,
+				config.PrometheusText0_0_4,
+				config.PrometheusProto,
+			},
+			expected: "application/openmetrics-text;version=1.0.0;q=0.5,application/openmetrics-text;version=0.0.1;q=0.4," +
+				"text/plain;version=0.0.4;q=0.3,application/vnd.google.protobuf;proto=io.prometheus.client.MetricFamily;encoding=delimited;q=0.2,*/*;q=0.1",
+		},
+	}
+
+	for _, s := range scenarios {
+		if got := acceptHeader(s.protocols); got != s.expected {
+			t.Errorf("%v: expected Accept header %q, got %q", s.protocols, s.expected, got)
+		}
+	}
+}
+
 func TestTargetScrapeScrapeCancel(t *testing.T) {
 	block := make(chan struct{})
 
@@ -744,7 +1120,7 @@ func (ts *testScraper) offset(interval time.Duration) time.Duration {
 	return ts.offsetDur
 }
 
-func (ts *testScraper) report(start time.Time, duration time.Duration, err error) {
+func (ts *testScraper) report(start time.Time, duration time.Duration, seriesAdded int, err error) {
 	ts.lastStart = start
 	ts.lastDuration = duration
 	ts.lastError = err
@@ -17,6 +17,7 @@ import (
 	"fmt"
 	"hash/fnv"
 	"net"
+	"net/http"
 	"net/url"
 	"strings"
 	"sync"
@@ -52,6 +53,19 @@ type Target struct {
 	lastError  error
 	lastScrape time.Time
 	health     TargetHealth
+	// The number of series of the last scrape not in the previous one.
+	seriesAdded int
+	// The metadata of the metric families of the last successful scrape.
+	metadata []MetricMetadata
+}
+
+// MetricMetadata is the metadata a target exposes for a metric family.
+type MetricMetadata struct {
+	Metric string
+	Type   string
+	Help   string
+	// Only set by the OpenMetrics format.
+	Unit string
 }
 
 // NewTarget creates a reasonably configured target for querying.
@@ -138,7 +152,25 @@ func (t *Target) URL() *url.URL {
 	}
 }
 
-func (t *Target) report(start time.Time, dur time.Duration, err error) {
+// Headers returns the HTTP headers with which the target is scraped, set from
+// its header labels. Empty headers and headers set by Prometheus itself, which
+// relabelling may have created, are not sent.
+func (t *Target) Headers() http.Header {
+	h := http.Header{}
+	for k, v := range t.labels {
+		if !strings.HasPrefix(string(k), config.HeaderLabelPrefix) || v == "" {
+			continue
+		}
+		name := strings.Replace(string(k[len(config.HeaderLabelPrefix):]), "_", "-", -1)
+		if config.IsReservedScrapeHeader(name) {
+			continue
+		}
+		h.Set(name, string(v))
+	}
+	return h
+}
+
+func (t *Target) report(start time.Time, dur time.Duration, seriesAdded int, err error) {
 	t.mtx.Lock()
 	defer t.mtx.Unlock()
 
@@ -150,6 +182,23 @@ func (t *Target) report(start time.Time, dur time.Duration, err error) {
 
 	t.lastError = err
 	t.lastScrape = start
+	t.seriesAdded = seriesAdded
+}
+
+func (t *Target) setMetadata(md []MetricMetadata) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	t.metadata = md
+}
+
+// Metadata returns the metadata of the metric families exposed by the target
+// in its last successful scrape. The returned slice must not be modified.
+func (t *Target) Metadata() []MetricMetadata {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+
+	return t.metadata
 }
 
 // LastError returns the error encountered during the last scrape.
@@ -160,6 +209,15 @@ func (t *Target) LastError() error {
 	return t.lastError
 }
 
+// SeriesAdded returns the number of series of the last scrape that were not
+// in the previous scrape.
+func (t *Target) SeriesAdded() int {
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+
+	return t.seriesAdded
+}
+
 // LastScrape returns the time of the last scrape.
 func (t *Target) LastScrape() time.Time {
 	t.mtx.RLock()
@@ -250,14 +308,19 @@ func (app *bufferAppender) Append(s *model.Sample) error {
 
 func (app *bufferAppender) NeedsThrottling() bool { return false }
 
-// countingAppender counts the samples appended to the underlying appender.
+// countingAppender counts the appended samples and, if series is not nil,
+// records the fingerprints of their metrics.
 type countingAppender struct {
 	storage.SampleAppender
-	count int
+	count  int
+	series map[model.Fingerprint]struct{}
 }
 
 func (app *countingAppender) Append(s *model.Sample) error {
 	app.count++
+	if app.series != nil {
+		app.series[s.Metric.Fingerprint()] = struct{}{}
+	}
 	return app.SampleAppender.Append(s)
 }
 
@@ -284,6 +347,10 @@ func populateLabels(lset model.LabelSet, cfg *config.ScrapeConfig) (res, orig mo
 			lset[model.LabelName(model.ParamLabelPrefix+k)] = model.LabelValue(v[0])
 		}
 	}
+	// Encode scrape headers as labels.
+	for k, v := range cfg.Headers {
+		lset[config.HeaderLabelName(k)] = model.LabelValue(v)
+	}
 
 	preRelabelLabels := lset.Clone()
 	lset = relabel.Process(lset, cfg.RelabelConfigs...)
@@ -296,6 +363,20 @@ func populateLabels(lset model.LabelSet, cfg *config.ScrapeConfig) (res, orig mo
 		return nil, nil, fmt.Errorf("no address")
 	}
 
+	for name, ln := range cfg.ParamsFromLabels {
+		if lv, ok := lset[ln]; ok {
+			lset[model.LabelName(model.ParamLabelPrefix+name)] = lv
+		}
+	}
+	// Targets of a multi-target exporter are scraped through it, keeping
+	// their own address as instance label.
+	if cfg.ExporterAddress != "" {
+		if _, ok := lset[model.InstanceLabel]; !ok {
+			lset[model.InstanceLabel] = lset[model.AddressLabel]
+		}
+		lset[model.AddressLabel] = model.LabelValue(cfg.ExporterAddress)
+	}
+
 	// addPort checks whether we should add a default port to the address.
 	// If the address is not valid, we don't append a port either.
 	addPort := func(s string) bool {
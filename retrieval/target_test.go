@@ -127,6 +127,24 @@ func TestTargetURL(t *testing.T) {
 	}
 }
 
+func TestTargetHeaders(t *testing.T) {
+	labels := model.LabelSet{
+		model.AddressLabel:        "example.com:1234",
+		"__header_X_Scope_OrgID":  "tenant-1",
+		"__header_X_Cluster_Name": "",
+		"__header_authorization":  "Bearer relabelled",
+		"__header_User_Agent":     "relabelled",
+		"__param_header":          "ignored",
+	}
+	target := NewTarget(labels, labels, nil)
+
+	// Empty and reserved header labels are not sent.
+	expected := http.Header{"X-Scope-Orgid": []string{"tenant-1"}}
+	if h := target.Headers(); !reflect.DeepEqual(h, expected) {
+		t.Fatalf("Expected headers %v, but got %v", expected, h)
+	}
+}
+
 func newTestTarget(targetURL string, deadline time.Duration, labels model.LabelSet) *Target {
 	labels = labels.Clone()
 	labels[model.SchemeLabel] = "http"
@@ -39,6 +39,9 @@ type TargetManager struct {
 	// Set of unqiue targets by scrape configuration.
 	targetSets map[string]*targetSet
 	logger     log.Logger
+
+	// Closed once the initial targets of all target sets have been synced.
+	readyCh chan struct{}
 }
 
 type targetSet struct {
@@ -47,6 +50,15 @@ type targetSet struct {
 
 	ts *discovery.TargetSet
 	sp *scrapePool
+
+	syncOnce sync.Once
+	synced   chan struct{}
+}
+
+// Sync implements discovery.Syncer.
+func (ts *targetSet) Sync(tgs []*config.TargetGroup) {
+	ts.sp.Sync(tgs)
+	ts.syncOnce.Do(func() { close(ts.synced) })
 }
 
 // NewTargetManager creates a new TargetManager.
@@ -55,9 +67,16 @@ func NewTargetManager(app storage.SampleAppender, logger log.Logger) *TargetMana
 		appender:   app,
 		targetSets: map[string]*targetSet{},
 		logger:     logger,
+		readyCh:    make(chan struct{}),
 	}
 }
 
+// Ready returns a channel that is closed once the initial service discovery
+// of all scrape configurations the target manager was started with is done.
+func (tm *TargetManager) Ready() <-chan struct{} {
+	return tm.readyCh
+}
+
 // Run starts background processing to handle target updates.
 func (tm *TargetManager) Run() {
 	tm.logger.Info("Starting target manager...")
@@ -67,8 +86,25 @@ func (tm *TargetManager) Run() {
 	tm.ctx, tm.cancel = context.WithCancel(context.Background())
 	tm.reload()
 
+	initial := make([]*targetSet, 0, len(tm.targetSets))
+	for _, ts := range tm.targetSets {
+		initial = append(initial, ts)
+	}
+
 	tm.mtx.Unlock()
 
+	go func() {
+		// Target sets removed by a reload in the meantime are canceled and
+		// must not block readiness.
+		for _, ts := range initial {
+			select {
+			case <-ts.synced:
+			case <-ts.ctx.Done():
+			}
+		}
+		close(tm.readyCh)
+	}()
+
 	tm.wg.Wait()
 }
 
@@ -102,9 +138,10 @@ func (tm *TargetManager) reload() {
 			ts = &targetSet{
 				ctx:    ctx,
 				cancel: cancel,
-				sp:     newScrapePool(ctx, scfg, tm.appender),
+				sp:     newScrapePool(ctx, scfg, tm.appender, tm.logger),
+				synced: make(chan struct{}),
 			}
-			ts.ts = discovery.NewTargetSet(ts.sp)
+			ts.ts = discovery.NewTargetSet(ts, "scrape", scfg.JobName)
 
 			tm.targetSets[scfg.JobName] = ts
 
@@ -120,7 +157,7 @@ func (tm *TargetManager) reload() {
 		} else {
 			ts.sp.reload(scfg)
 		}
-		ts.ts.UpdateProviders(discovery.ProvidersFromConfig(scfg.ServiceDiscoveryConfig, tm.logger))
+		ts.ts.UpdateProviders(discovery.ProvidersFromConfig(scfg.ServiceDiscoveryConfig, tm.logger.With("component", "discovery")))
 	}
 
 	// Remove old target sets. Waiting for scrape pools to complete pending
@@ -128,6 +165,7 @@ func (tm *TargetManager) reload() {
 	for name, ts := range tm.targetSets {
 		if _, ok := jobs[name]; !ok {
 			ts.cancel()
+			ts.ts.DeleteMetrics()
 			delete(tm.targetSets, name)
 		}
 	}
@@ -152,13 +190,42 @@ func (tm *TargetManager) Targets() []*Target {
 	return targets
 }
 
+// TargetsActive returns the targets currently being scraped, grouped by the
+// name of the scrape pool they belong to.
+func (tm *TargetManager) TargetsActive() map[string][]*Target {
+	tm.mtx.RLock()
+	defer tm.mtx.RUnlock()
+
+	targets := make(map[string][]*Target, len(tm.targetSets))
+	for name, ps := range tm.targetSets {
+		ps.sp.mtx.RLock()
+
+		pool := make([]*Target, 0, len(ps.sp.targets))
+		for _, t := range ps.sp.targets {
+			pool = append(pool, t)
+		}
+		targets[name] = pool
+
+		ps.sp.mtx.RUnlock()
+	}
+
+	return targets
+}
+
 // ApplyConfig resets the manager's target providers and job configurations as defined
 // by the new cfg. The state of targets that are valid in the new configuration remains unchanged.
 func (tm *TargetManager) ApplyConfig(cfg *config.Config) error {
 	tm.mtx.Lock()
 	defer tm.mtx.Unlock()
 
-	tm.scrapeConfigs = cfg.ScrapeConfigs
+	// Disabled scrape configs are dropped, stopping the scrape pools of
+	// jobs that got disabled.
+	tm.scrapeConfigs = nil
+	for _, scfg := range cfg.ScrapeConfigs {
+		if scfg.IsEnabled() {
+			tm.scrapeConfigs = append(tm.scrapeConfigs, scfg)
+		}
+	}
 
 	if tm.ctx != nil {
 		tm.reload()
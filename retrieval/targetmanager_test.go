@@ -15,9 +15,12 @@ package retrieval
 
 import (
 	"fmt"
+	"net/url"
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/prometheus/common/log"
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/config"
 )
@@ -216,6 +219,85 @@ func TestPopulateLabels(t *testing.T) {
 			resOrig: nil,
 			err:     fmt.Errorf("invalid label value for \"custom\": \"\\xbd\""),
 		},
+		// Targets of a multi-target exporter with parameters from their labels.
+		{
+			in: model.LabelSet{
+				model.AddressLabel:       "https://example.com",
+				"__meta_blackbox_module": "http_2xx",
+			},
+			cfg: &config.ScrapeConfig{
+				Scheme:      "http",
+				MetricsPath: "/probe",
+				JobName:     "blackbox",
+				Params:      url.Values{"module": {"icmp"}},
+				ParamsFromLabels: map[string]model.LabelName{
+					"target": model.AddressLabel,
+					"module": "__meta_blackbox_module",
+					"region": "__meta_blackbox_region",
+				},
+				ExporterAddress: "blackbox-exporter:9115",
+			},
+			res: model.LabelSet{
+				model.AddressLabel:     "blackbox-exporter:9115",
+				model.InstanceLabel:    "https://example.com",
+				model.SchemeLabel:      "http",
+				model.MetricsPathLabel: "/probe",
+				model.JobLabel:         "blackbox",
+				"__param_target":       "https://example.com",
+				"__param_module":       "http_2xx",
+			},
+			resOrig: model.LabelSet{
+				model.AddressLabel:       "https://example.com",
+				model.SchemeLabel:        "http",
+				model.MetricsPathLabel:   "/probe",
+				model.JobLabel:           "blackbox",
+				"__param_module":         "icmp",
+				"__meta_blackbox_module": "http_2xx",
+			},
+		},
+		// Scrape headers are encoded as labels and can be set by relabelling.
+		{
+			in: model.LabelSet{
+				model.AddressLabel: "1.2.3.4:1000",
+				"__meta_tenant":    "tenant-1",
+			},
+			cfg: &config.ScrapeConfig{
+				Scheme:      "http",
+				MetricsPath: "/metrics",
+				JobName:     "job",
+				Headers: map[string]string{
+					"X-Scope-OrgID":  "default",
+					"X-Cluster-Name": "eu-1",
+				},
+				RelabelConfigs: []*config.RelabelConfig{
+					{
+						Action:       config.RelabelReplace,
+						Regex:        mustNewRegexp("(.+)"),
+						SourceLabels: model.LabelNames{"__meta_tenant"},
+						Replacement:  "${1}",
+						TargetLabel:  "__header_X_Scope_OrgID",
+					},
+				},
+			},
+			res: model.LabelSet{
+				model.AddressLabel:        "1.2.3.4:1000",
+				model.InstanceLabel:       "1.2.3.4:1000",
+				model.SchemeLabel:         "http",
+				model.MetricsPathLabel:    "/metrics",
+				model.JobLabel:            "job",
+				"__header_X_Scope_OrgID":  "tenant-1",
+				"__header_X_Cluster_Name": "eu-1",
+			},
+			resOrig: model.LabelSet{
+				model.AddressLabel:        "1.2.3.4:1000",
+				model.SchemeLabel:         "http",
+				model.MetricsPathLabel:    "/metrics",
+				model.JobLabel:            "job",
+				"__meta_tenant":           "tenant-1",
+				"__header_X_Scope_OrgID":  "default",
+				"__header_X_Cluster_Name": "eu-1",
+			},
+		},
 	}
 	for i, c := range cases {
 		in := c.in.Clone()
@@ -234,3 +316,41 @@ func TestPopulateLabels(t *testing.T) {
 		}
 	}
 }
+
+func TestTargetManagerReady(t *testing.T) {
+	tm := NewTargetManager(nopAppender{}, log.Base())
+	err := tm.ApplyConfig(&config.Config{
+		ScrapeConfigs: []*config.ScrapeConfig{{
+			JobName:        "test",
+			ScrapeInterval: model.Duration(time.Hour),
+			ScrapeTimeout:  model.Duration(time.Second),
+			ServiceDiscoveryConfig: config.ServiceDiscoveryConfig{
+				StaticConfigs: []*config.TargetGroup{{
+					Targets: []model.LabelSet{{model.AddressLabel: "localhost:9090"}},
+				}},
+			},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-tm.Ready():
+		t.Fatal("Target manager ready before running")
+	default:
+	}
+
+	go tm.Run()
+	defer tm.Stop()
+
+	select {
+	case <-tm.Ready():
+	case <-time.After(10 * time.Second):
+		t.Fatal("Target manager not ready after initial sync")
+	}
+
+	if n := len(tm.Targets()); n != 1 {
+		t.Fatalf("Expected 1 target after initial sync, got %d", n)
+	}
+}
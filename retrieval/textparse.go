@@ -0,0 +1,550 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retrieval
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/prometheus/prometheus/util/hashutil"
+)
+
+// errTextFallback is returned by the text parser for input it does not
+// handle, which is then decoded by the expfmt decoder instead.
+var errTextFallback = errors.New("input not supported by the text parser fast path")
+
+// The types of metric families in the text format.
+const (
+	familyUntyped = iota + 1
+	familyCounter
+	familyGauge
+	familySummary
+	familyHistogram
+)
+
+var familyTypes = []struct {
+	name string
+	typ  uint8
+}{
+	{"counter", familyCounter},
+	{"gauge", familyGauge},
+	{"summary", familySummary},
+	{"untyped", familyUntyped},
+	{"histogram", familyHistogram},
+}
+
+type textFamily struct {
+	// Zero if the family was only declared by a HELP line so far.
+	typ  uint8
+	help bool
+	// The escaped help text.
+	helpText string
+}
+
+// Flags of the series of summaries and histograms.
+const (
+	seriesSum = 1 << iota
+	seriesCount
+	seriesInf
+	seriesHistogram
+)
+
+// textLabel is a label pair of the sample line being parsed.
+type textLabel struct {
+	name, value string
+}
+
+// sampleSlabSize is the number of samples allocated at once.
+const sampleSlabSize = 128
+
+// textParser parses the text exposition format into samples without
+// building the intermediate metric families of the expfmt decoder, and
+// reuses the label strings of the previous parse.
+//
+// It returns the same samples as the expfmt decoder for the input it
+// accepts, but only accepts the well-formed output of the client libraries:
+// anything else, including all invalid input, results in errTextFallback so
+// that the expfmt decoder remains the reference for both the semantics of
+// the format and its error messages.
+//
+// A textParser must not be used concurrently.
+type textParser struct {
+	// The strings of the current and the previous parse.
+	strings, prevStrings map[string]string
+
+	families map[string]textFamily
+	// The flags of the summary and histogram series, by the hash of their
+	// family and labels. The expfmt decoder merges their lines, so they are
+	// only emitted as they are if each has exactly one sum and count line.
+	series map[uint64]uint8
+
+	labels  []textLabel
+	escaped []byte
+	float   []byte
+	slab    []model.Sample
+	// The number of samples of the last parse, to size the next one.
+	lastLen int
+}
+
+func (p *textParser) reset() {
+	if p.strings == nil {
+		p.strings = map[string]string{}
+		p.prevStrings = map[string]string{}
+		p.families = map[string]textFamily{}
+		p.series = map[uint64]uint8{}
+	}
+	for k := range p.prevStrings {
+		delete(p.prevStrings, k)
+	}
+	p.strings, p.prevStrings = p.prevStrings, p.strings
+	for k := range p.families {
+		delete(p.families, k)
+	}
+	for k := range p.series {
+		delete(p.series, k)
+	}
+}
+
+// str returns b as a string, reusing the string of the current or the
+// previous parse if there is one.
+func (p *textParser) str(b []byte) string {
+	if s, ok := p.strings[string(b)]; ok {
+		return s
+	}
+	s, ok := p.prevStrings[string(b)]
+	if !ok {
+		s = string(b)
+	}
+	p.strings[s] = s
+	return s
+}
+
+func (p *textParser) newSample() *model.Sample {
+	if len(p.slab) == 0 {
+		p.slab = make([]model.Sample, sampleSlabSize)
+	}
+	s := &p.slab[0]
+	p.slab = p.slab[1:]
+	return s
+}
+
+// parse returns the samples of the text format input, with the given
+// timestamp unless they have their own.
+func (p *textParser) parse(b []byte, ts model.Time) (model.Samples, error) {
+	p.reset()
+	samples := make(model.Samples, 0, p.lastLen)
+
+	for len(b) > 0 {
+		i := bytes.IndexByte(b, '\n')
+		if i < 0 {
+			// The expfmt decoder only accepts a final line without a
+			// line feed if it is blank.
+			if skipBlank(b, 0) != len(b) {
+				return nil, errTextFallback
+			}
+			break
+		}
+		line := b[:i]
+		b = b[i+1:]
+
+		pos := skipBlank(line, 0)
+		switch {
+		case pos == len(line):
+			continue
+		case line[pos] == '#':
+			if err := p.parseComment(line, pos+1); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		s, err := p.parseSample(line, pos, ts)
+		if err != nil {
+			return nil, err
+		}
+		samples = append(samples, s)
+	}
+
+	for _, flags := range p.series {
+		if flags&(seriesSum|seriesCount) != seriesSum|seriesCount {
+			return nil, errTextFallback
+		}
+		// The expfmt decoder adds missing +Inf buckets.
+		if flags&seriesHistogram != 0 && flags&seriesInf == 0 {
+			return nil, errTextFallback
+		}
+	}
+	p.lastLen = len(samples)
+	return samples, nil
+}
+
+// parseComment parses a comment line from the position after the '#'.
+func (p *textParser) parseComment(line []byte, pos int) error {
+	pos = skipBlank(line, pos)
+	start := pos
+	pos = skipToken(line, pos)
+	keyword := line[start:pos]
+	if pos == len(line) || (string(keyword) != "HELP" && string(keyword) != "TYPE") {
+		// Generic comment.
+		return nil
+	}
+	pos = skipBlank(line, pos)
+	start = pos
+	pos = skipMetricName(line, pos)
+	if pos == len(line) {
+		return nil
+	}
+	if start == pos || !isBlank(line[pos]) {
+		return errTextFallback
+	}
+	name := line[start:pos]
+	if pos = skipBlank(line, pos); pos == len(line) {
+		return nil
+	}
+	fname, f, _ := p.family(name)
+
+	if string(keyword) == "HELP" {
+		if f.help {
+			return errTextFallback
+		}
+		// Help texts must only contain valid escape sequences.
+		for i := pos; i < len(line); i++ {
+			if line[i] == '\\' {
+				if i++; i == len(line) || (line[i] != '\\' && line[i] != 'n') {
+					return errTextFallback
+				}
+			}
+		}
+		f.help = true
+		f.helpText = p.str(line[pos:])
+		p.families[fname] = f
+		return nil
+	}
+
+	if f.typ != 0 {
+		return errTextFallback
+	}
+	typ := line[pos:]
+	for _, ft := range familyTypes {
+		if bytes.EqualFold(typ, []byte(ft.name)) {
+			f.typ = ft.typ
+			p.families[fname] = f
+			return nil
+		}
+	}
+	return errTextFallback
+}
+
+var helpUnescaper = strings.NewReplacer(`\\`, `\`, `\n`, "\n")
+
+// metadata returns the metadata of the metric families of the last parse.
+// Like in the expfmt decoder, families without a TYPE line are untyped.
+func (p *textParser) metadata() []MetricMetadata {
+	md := make([]MetricMetadata, 0, len(p.families))
+	for name, f := range p.families {
+		m := MetricMetadata{Metric: name, Type: "untyped", Help: f.helpText}
+		for _, ft := range familyTypes {
+			if ft.typ == f.typ {
+				m.Type = ft.name
+			}
+		}
+		if strings.IndexByte(m.Help, '\\') >= 0 {
+			m.Help = helpUnescaper.Replace(m.Help)
+		}
+		md = append(md, m)
+	}
+	return md
+}
+
+// family returns the metric family of the metric name the way the expfmt
+// decoder resolves it, creating it if it does not exist yet, and the suffix
+// of the name if it belongs to a summary or histogram.
+func (p *textParser) family(name []byte) (string, textFamily, string) {
+	if f, ok := p.families[string(name)]; ok {
+		return p.str(name), f, ""
+	}
+	for _, suffix := range []string{"_count", "_sum", "_bucket"} {
+		if len(name) <= len(suffix) || string(name[len(name)-len(suffix):]) != suffix {
+			continue
+		}
+		base := name[:len(name)-len(suffix)]
+		if f, ok := p.families[string(base)]; ok {
+			if (f.typ == familySummary && suffix != "_bucket") || f.typ == familyHistogram {
+				return p.str(base), f, suffix
+			}
+		}
+		// The suffixes are mutually exclusive.
+		break
+	}
+	s := p.str(name)
+	p.families[s] = textFamily{}
+	return s, textFamily{}, ""
+}
+
+// parseSample parses a sample line from the position of the metric name.
+func (p *textParser) parseSample(line []byte, pos int, ts model.Time) (*model.Sample, error) {
+	start := pos
+	pos = skipMetricName(line, pos)
+	if start == pos {
+		return nil, errTextFallback
+	}
+	name := line[start:pos]
+	pos = skipBlank(line, pos)
+
+	p.labels = p.labels[:0]
+	if pos < len(line) && line[pos] == '{' {
+		var err error
+		if pos, err = p.parseLabels(line, pos+1); err != nil {
+			return nil, err
+		}
+		pos = skipBlank(line, pos)
+	}
+
+	start = pos
+	pos = skipToken(line, start)
+	v, err := strconv.ParseFloat(string(line[start:pos]), 64)
+	if err != nil {
+		return nil, errTextFallback
+	}
+	hasTimestamp := false
+	if pos < len(line) {
+		start = skipBlank(line, pos)
+		pos = skipToken(line, start)
+		t, err := strconv.ParseInt(string(line[start:pos]), 10, 64)
+		if err != nil || pos != len(line) {
+			return nil, errTextFallback
+		}
+		ts = model.TimeFromUnixNano(t * 1000000)
+		hasTimestamp = true
+	}
+
+	fname, f, suffix := p.family(name)
+	if f.typ == 0 {
+		f.typ = familyUntyped
+		p.families[fname] = f
+	}
+	if f.typ == familySummary || f.typ == familyHistogram {
+		// The samples of a summary or histogram series share the
+		// timestamp of its last line.
+		if hasTimestamp {
+			return nil, errTextFallback
+		}
+		if v, err = p.checkSeries(fname, f.typ, suffix, v); err != nil {
+			return nil, err
+		}
+	}
+
+	s := p.newSample()
+	s.Metric = make(model.Metric, len(p.labels)+1)
+	s.Metric[model.MetricNameLabel] = model.LabelValue(p.str(name))
+	for _, l := range p.labels {
+		s.Metric[model.LabelName(l.name)] = model.LabelValue(l.value)
+	}
+	s.Value = model.SampleValue(v)
+	s.Timestamp = ts
+	return s, nil
+}
+
+// parseLabels parses the labels of a sample line from the position after
+// the '{', and returns the position after the '}'.
+func (p *textParser) parseLabels(line []byte, pos int) (int, error) {
+	for {
+		if pos = skipBlank(line, pos); pos == len(line) {
+			return 0, errTextFallback
+		}
+		if line[pos] == '}' {
+			return pos + 1, nil
+		}
+		start := pos
+		pos = skipLabelName(line, pos)
+		if start == pos {
+			return 0, errTextFallback
+		}
+		name := line[start:pos]
+		if string(name) == model.MetricNameLabel {
+			return 0, errTextFallback
+		}
+		for _, l := range p.labels {
+			if l.name == string(name) {
+				return 0, errTextFallback
+			}
+		}
+
+		if pos = skipBlank(line, pos); pos == len(line) || line[pos] != '=' {
+			return 0, errTextFallback
+		}
+		if pos = skipBlank(line, pos+1); pos == len(line) || line[pos] != '"' {
+			return 0, errTextFallback
+		}
+		value, end, err := p.labelValue(line, pos+1)
+		if err != nil {
+			return 0, err
+		}
+		p.labels = append(p.labels, textLabel{name: p.str(name), value: value})
+
+		if pos = skipBlank(line, end); pos == len(line) {
+			return 0, errTextFallback
+		}
+		switch line[pos] {
+		case ',':
+			pos++
+		case '}':
+			return pos + 1, nil
+		default:
+			return 0, errTextFallback
+		}
+	}
+}
+
+// labelValue returns the label value starting at the position after the
+// opening quote, and the position after the closing quote.
+func (p *textParser) labelValue(line []byte, pos int) (string, int, error) {
+	end := bytes.IndexByte(line[pos:], '"')
+	if end < 0 {
+		return "", 0, errTextFallback
+	}
+	if bytes.IndexByte(line[pos:pos+end], '\\') < 0 {
+		return p.str(line[pos : pos+end]), pos + end + 1, nil
+	}
+
+	p.escaped = p.escaped[:0]
+	for i := pos; i < len(line); i++ {
+		switch c := line[i]; c {
+		case '"':
+			return p.str(p.escaped), i + 1, nil
+		case '\\':
+			if i++; i == len(line) {
+				return "", 0, errTextFallback
+			}
+			switch line[i] {
+			case '"', '\\':
+				p.escaped = append(p.escaped, line[i])
+			case 'n':
+				p.escaped = append(p.escaped, '\n')
+			default:
+				return "", 0, errTextFallback
+			}
+		default:
+			p.escaped = append(p.escaped, c)
+		}
+	}
+	return "", 0, errTextFallback
+}
+
+// checkSeries records a line of a summary or histogram series and returns
+// its value the way the expfmt decoder stores it. It normalizes the quantile
+// and le labels to their formatting by the expfmt decoder.
+func (p *textParser) checkSeries(fname string, typ uint8, suffix string, v float64) (float64, error) {
+	special := model.QuantileLabel
+	if typ == familyHistogram {
+		special = model.BucketLabel
+	}
+	// Summaries have quantile lines without suffix, histograms bucket lines
+	// with one.
+	isValue := (typ == familySummary && suffix == "") || suffix == "_bucket"
+
+	sig := hashutil.Add(hashutil.New(), fname)
+	found := false
+	for i, l := range p.labels {
+		if l.name != special {
+			// Combine the label hashes independently of their order.
+			h := hashutil.Add(hashutil.New(), l.name)
+			h = hashutil.AddByte(h, model.SeparatorByte)
+			sig += hashutil.Add(h, l.value)
+			continue
+		}
+		if !isValue {
+			return 0, errTextFallback
+		}
+		found = true
+		bound, err := strconv.ParseFloat(l.value, 64)
+		if err != nil {
+			return 0, errTextFallback
+		}
+		p.float = strconv.AppendFloat(p.float[:0], bound, 'g', -1, 64)
+		p.labels[i].value = p.str(p.float)
+		if typ == familyHistogram && math.IsInf(bound, +1) {
+			p.series[sig] |= seriesInf
+		}
+	}
+	if isValue != found {
+		return 0, errTextFallback
+	}
+
+	flags := p.series[sig]
+	if typ == familyHistogram {
+		flags |= seriesHistogram
+	}
+	switch suffix {
+	case "_sum":
+		if flags&seriesSum != 0 {
+			return 0, errTextFallback
+		}
+		flags |= seriesSum
+	case "_count":
+		if flags&seriesCount != 0 {
+			return 0, errTextFallback
+		}
+		flags |= seriesCount
+		v = float64(uint64(v))
+	case "_bucket":
+		v = float64(uint64(v))
+	}
+	p.series[sig] = flags
+	return v, nil
+}
+
+func isBlank(b byte) bool {
+	return b == ' ' || b == '\t'
+}
+
+func skipBlank(b []byte, pos int) int {
+	for pos < len(b) && isBlank(b[pos]) {
+		pos++
+	}
+	return pos
+}
+
+func skipToken(b []byte, pos int) int {
+	for pos < len(b) && !isBlank(b[pos]) {
+		pos++
+	}
+	return pos
+}
+
+func isLabelNameByte(b byte, first bool) bool {
+	return (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || b == '_' || (!first && b >= '0' && b <= '9')
+}
+
+func skipLabelName(b []byte, pos int) int {
+	for i := pos; i < len(b); i++ {
+		if !isLabelNameByte(b[i], i == pos) {
+			return i
+		}
+	}
+	return len(b)
+}
+
+func skipMetricName(b []byte, pos int) int {
+	for i := pos; i < len(b); i++ {
+		if b[i] != ':' && !isLabelNameByte(b[i], i == pos) {
+			return i
+		}
+	}
+	return len(b)
+}
@@ -0,0 +1,195 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retrieval
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/common/model"
+)
+
+const textParseInput = `# HELP http_requests_total The total number of HTTP requests.
+# TYPE http_requests_total counter
+http_requests_total{method="post",code="200"} 1027
+http_requests_total{method="post",code="400"}    3 1395066363000
+  http_requests_total { method = "get" , code = "200" , } 12
+
+# A normal comment.
+#
+msdos_file_access_time_seconds{path="C:\\DIR\\FILE.TXT",error="Cannot find file:\n\"FILE.TXT\""} 1.458255915e9
+metric_without_timestamp_and_labels 12.47
+something_weird{problem="division by zero"} +Inf -3982045
+# TYPE go_goroutines GAUGE
+go_goroutines	42
+
+# HELP http_request_duration_seconds A histogram of the request duration.
+# TYPE http_request_duration_seconds histogram
+http_request_duration_seconds_bucket{le="0.05"} 24054
+http_request_duration_seconds_bucket{le="0.1"} 33444
+http_request_duration_seconds_bucket{le="1.0"} 100392
+http_request_duration_seconds_bucket{le="1e3"} 129389
+http_request_duration_seconds_bucket{le="+Inf"} 144320
+http_request_duration_seconds_sum 53423
+http_request_duration_seconds_count 144320
+
+# HELP rpc_duration_seconds A summary of the RPC duration in seconds.
+# TYPE rpc_duration_seconds summary
+rpc_duration_seconds{quantile="0.01",service="a"} 3102
+rpc_duration_seconds{quantile="0.5",service="a"} 4773
+rpc_duration_seconds{quantile="0.99",service="a"} 76656
+rpc_duration_seconds_sum{service="a"} 1.7560473e+07
+rpc_duration_seconds_count{service="a"} 2693
+rpc_duration_seconds{service="b",quantile="0.50"} 1
+rpc_duration_seconds_count{service="b"} 7
+rpc_duration_seconds_sum{service="b"} 8.5
+`
+
+// decodeText decodes the text format with the expfmt decoder.
+func decodeText(b []byte, ts model.Time) (model.Samples, error) {
+	dec := expfmt.SampleDecoder{
+		Dec:  expfmt.NewDecoder(bytes.NewReader(b), expfmt.FmtText),
+		Opts: &expfmt.DecodeOptions{Timestamp: ts},
+	}
+	var all model.Samples
+	for {
+		var v model.Vector
+		if err := dec.Decode(&v); err != nil {
+			if err == io.EOF {
+				return all, nil
+			}
+			return nil, err
+		}
+		all = append(all, v...)
+	}
+}
+
+func sortSamples(s model.Samples) {
+	sort.Slice(s, func(i, j int) bool {
+		return fmt.Sprint(s[i]) < fmt.Sprint(s[j])
+	})
+}
+
+func TestTextParse(t *testing.T) {
+	inputs := []string{
+		textParseInput,
+		"",
+		"  \n\t\n",
+		"metric 1\n  ",
+		"# TYPE name summary\nname_sum 2\nname_count 3.7\n",
+		"# HELP name some help\nname_count 1\n",
+		"# HELP a_count c\n# TYPE a histogram\na_count 1\n",
+	}
+	ts := model.Time(1234)
+
+	var p textParser
+	for i, in := range inputs {
+		expected, err := decodeText([]byte(in), ts)
+		if err != nil {
+			t.Fatalf("%d: unexpected error decoding with expfmt: %s", i, err)
+		}
+		// Parse twice to also use the strings of the previous parse.
+		for j := 0; j < 2; j++ {
+			got, err := p.parse([]byte(in), ts)
+			if err != nil {
+				t.Fatalf("%d: unexpected error: %s", i, err)
+			}
+			sortSamples(expected)
+			sortSamples(got)
+			if len(got) != 0 || len(expected) != 0 {
+				if !reflect.DeepEqual(got, expected) {
+					t.Errorf("%d: expected samples\n%v\ngot\n%v", i, expected, got)
+				}
+			}
+		}
+	}
+}
+
+func TestTextParseFallback(t *testing.T) {
+	inputs := []string{
+		// Invalid input.
+		"metric 1",
+		"metric\n",
+		"metric 1 \n",
+		"metric 1 2 3\n",
+		"metric{a=\"b} 1\n",
+		"metric{a=\"\\t\"} 1\n",
+		"metric{__name__=\"b\"} 1\n",
+		"metric{a=\"b\" c=\"d\"} 1\n",
+		"1metric 1\n",
+		"# TYPE metric counter \n",
+		"# TYPE metric foo\n",
+		"# TYPE metric counter\n# TYPE metric gauge\n",
+		"metric 1\n# TYPE metric counter\n",
+		"# HELP metric a\n# HELP metric b\n",
+		"# HELP metric \\t\n",
+		// Valid input which is not handled.
+		"metric{a=\"b\",a=\"c\"} 1\n",
+		"# TYPE s summary\ns{quantile=\"0.5\"} 1\n",
+		"# TYPE s summary\ns_sum 1\ns_count 1\ns_sum 1\n",
+		"# TYPE s summary\ns 1\ns_sum 1\ns_count 1\n",
+		"# TYPE s summary\ns{quantile=\"0.5\"} 1 123\ns_sum 1\ns_count 1\n",
+		"# TYPE h histogram\nh_bucket{le=\"1\"} 1\nh_sum 1\nh_count 1\n",
+		"# TYPE h histogram\nh{le=\"+Inf\"} 1\nh_sum 1\nh_count 1\n",
+	}
+	var p textParser
+	for i, in := range inputs {
+		if _, err := p.parse([]byte(in), 0); err != errTextFallback {
+			t.Errorf("%d: expected fallback for %q, got %v", i, in, err)
+		}
+	}
+}
+
+func TestTextParseReusesStrings(t *testing.T) {
+	var p textParser
+	a, err := p.parse([]byte("metric{pod=\"web-0\"} 1\n"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := p.parse([]byte("metric{pod=\"web-0\"} 2\n"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for ln, lv := range a[0].Metric {
+		if !sameString(string(lv), string(b[0].Metric[ln])) {
+			t.Errorf("Value %q of label %q is not reused", lv, ln)
+		}
+	}
+}
+
+func BenchmarkTextParse(b *testing.B) {
+	in := []byte(textParseInput)
+	b.Run("fast", func(b *testing.B) {
+		var p textParser
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := p.parse(in, 0); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("expfmt", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := decodeText(in, 0); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
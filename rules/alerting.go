@@ -22,8 +22,8 @@ import (
 	"golang.org/x/net/context"
 
 	html_template "html/template"
+	text_template "text/template"
 
-	"github.com/prometheus/common/log"
 	"github.com/prometheus/common/model"
 
 	"github.com/prometheus/prometheus/promql"
@@ -77,6 +77,21 @@ type Alert struct {
 	// The interval during which the condition of this alert held true.
 	// ResolvedAt will be 0 to indicate a still active alert.
 	ActiveAt, ResolvedAt model.Time
+	// The time the alert was last sent to the Alertmanager, 0 if never.
+	LastSentAt model.Time
+}
+
+// needsSending returns whether the alert has to be sent at the given time if
+// it is to be resent at most every resendDelay.
+func (a *Alert) needsSending(ts model.Time, resendDelay time.Duration) bool {
+	if a.State == StatePending {
+		return false
+	}
+	// New and resolved alerts are sent right away.
+	if a.LastSentAt == 0 || a.ResolvedAt > a.LastSentAt {
+		return true
+	}
+	return a.LastSentAt.Add(resendDelay) <= ts
 }
 
 // An AlertingRule generates alerts from its vector expression.
@@ -92,6 +107,13 @@ type AlertingRule struct {
 	labels model.LabelSet
 	// Non-identifying key/value pairs.
 	annotations model.LabelSet
+	// The minimum delay between sends of the alerts, overriding the one of
+	// the group if not zero.
+	resendDelay time.Duration
+	// The template of the source links of the alerts, overriding the one of
+	// the group if not nil, and its text.
+	sourceTmpl     *text_template.Template
+	sourceTmplText string
 
 	// Protects the below.
 	mtx sync.Mutex
@@ -197,7 +219,7 @@ func (r *AlertingRule) Eval(ctx context.Context, ts model.Time, engine *promql.E
 			result, err := tmpl.Expand()
 			if err != nil {
 				result = fmt.Sprintf("<error expanding template: %s>", err)
-				log.Warnf("Error expanding alert template %v with data '%v': %s", r.Name(), tmplData, err)
+				logger.Warnf("Error expanding alert template %v with data '%v': %s", r.Name(), tmplData, err)
 			}
 			return model.LabelValue(result)
 		}
@@ -309,12 +331,43 @@ func (r *AlertingRule) currentAlerts() []*Alert {
 	return alerts
 }
 
+// alertsToSend returns copies of the alerts which have to be sent at the given
+// time and records them as sent.
+func (r *AlertingRule) alertsToSend(ts model.Time, resendDelay time.Duration) []*Alert {
+	if r.resendDelay > 0 {
+		resendDelay = r.resendDelay
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	var alerts []*Alert
+	for _, a := range r.active {
+		if !a.needsSending(ts, resendDelay) {
+			continue
+		}
+		a.LastSentAt = ts
+
+		anew := *a
+		anew.Labels = anew.Labels.Clone()
+		anew.Annotations = anew.Annotations.Clone()
+		alerts = append(alerts, &anew)
+	}
+	return alerts
+}
+
 func (r *AlertingRule) String() string {
 	s := fmt.Sprintf("ALERT %s", r.name)
 	s += fmt.Sprintf("\n\tIF %s", r.vector)
 	if r.holdDuration > 0 {
 		s += fmt.Sprintf("\n\tFOR %s", model.Duration(r.holdDuration))
 	}
+	if r.resendDelay > 0 {
+		s += fmt.Sprintf("\n\tRESEND_DELAY %s", model.Duration(r.resendDelay))
+	}
+	if r.sourceTmpl != nil {
+		s += fmt.Sprintf("\n\tSOURCE_TMPL %q", r.sourceTmplText)
+	}
 	if len(r.labels) > 0 {
 		s += fmt.Sprintf("\n\tLABELS %s", r.labels)
 	}
@@ -337,6 +390,12 @@ func (r *AlertingRule) HTMLSnippet(pathPrefix string) html_template.HTML {
 	if r.holdDuration > 0 {
 		s += fmt.Sprintf("\n  FOR %s", model.Duration(r.holdDuration))
 	}
+	if r.resendDelay > 0 {
+		s += fmt.Sprintf("\n  RESEND_DELAY %s", model.Duration(r.resendDelay))
+	}
+	if r.sourceTmpl != nil {
+		s += fmt.Sprintf("\n  SOURCE_TMPL %s", html_template.HTMLEscapeString(fmt.Sprintf("%q", r.sourceTmplText)))
+	}
 	if len(r.labels) > 0 {
 		s += fmt.Sprintf("\n  LABELS %s", html_template.HTMLEscapeString(r.labels.String()))
 	}
@@ -15,6 +15,7 @@ package rules
 
 import (
 	"testing"
+	"time"
 
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/promql"
@@ -60,3 +61,39 @@ func TestCurrentAlertsClonesLabelsAndAnnotations(t *testing.T) {
 		t.Fatalf("unexpected annotation value; want %q, got %q", want, got)
 	}
 }
+
+func TestAlertsToSendResendDelay(t *testing.T) {
+	r := AlertingRule{
+		active: map[model.Fingerprint]*Alert{
+			0: {State: StateFiring},
+			1: {State: StatePending},
+		},
+	}
+
+	for _, c := range []struct {
+		ts       model.Time
+		resolve  bool
+		expected int
+	}{
+		{ts: 1000, expected: 1},
+		{ts: 31000, expected: 0},
+		{ts: 61000, expected: 1},
+		// Resolved alerts are sent right away.
+		{ts: 75000, resolve: true, expected: 1},
+		{ts: 90000, expected: 0},
+	} {
+		if c.resolve {
+			r.active[0].State = StateInactive
+			r.active[0].ResolvedAt = c.ts
+		}
+		if got := len(r.alertsToSend(c.ts, time.Minute)); got != c.expected {
+			t.Errorf("At %v: expected %d alerts to send, got %d", c.ts, c.expected, got)
+		}
+	}
+
+	// The delay of the rule overrides the given one.
+	r.resendDelay = 10 * time.Second
+	if got := len(r.alertsToSend(100000, time.Minute)); got != 1 {
+		t.Errorf("Expected the alert to be resent after the delay of the rule, got %d alerts", got)
+	}
+}
@@ -14,6 +14,8 @@
 package rules
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/url"
@@ -22,9 +24,12 @@ import (
 	"time"
 
 	html_template "html/template"
+	text_template "text/template"
 
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	otlog "github.com/opentracing/opentracing-go/log"
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/common/log"
 	"github.com/prometheus/common/model"
 	"golang.org/x/net/context"
 
@@ -33,9 +38,13 @@ import (
 	"github.com/prometheus/prometheus/promql"
 	"github.com/prometheus/prometheus/storage"
 	"github.com/prometheus/prometheus/storage/local"
+	"github.com/prometheus/prometheus/util/logging"
 	"github.com/prometheus/prometheus/util/strutil"
 )
 
+// logger logs the messages of the rule evaluation.
+var logger = logging.New("rules")
+
 // Constants for instrumentation.
 const namespace = "prometheus"
 
@@ -127,6 +136,14 @@ type Group struct {
 	interval time.Duration
 	rules    []Rule
 	opts     *ManagerOptions
+	// The template of the source links of the alerts, nil for links to the
+	// expression in the Prometheus UI.
+	sourceTmpl *text_template.Template
+	// The minimum delay between sends of an alert, zero to send alerts on
+	// every evaluation. Rules can override it.
+	resendDelay time.Duration
+	// evalMtx serializes scheduled and on-demand evaluations.
+	evalMtx sync.Mutex
 
 	done       chan struct{}
 	terminated chan struct{}
@@ -251,35 +268,59 @@ func typeForRule(r Rule) ruleType {
 	panic(fmt.Errorf("unknown rule type: %T", r))
 }
 
+// RuleResult is the outcome of the evaluation of a rule.
+type RuleResult struct {
+	Rule   Rule
+	Vector model.Vector
+	Err    error
+}
+
 // Eval runs a single evaluation cycle in which all rules are evaluated in parallel.
 // In the future a single group will be evaluated sequentially to properly handle
-// rule dependency.
-func (g *Group) Eval() {
+// rule dependency. The results are returned in the order of the group's rules.
+func (g *Group) Eval() []RuleResult {
+	g.evalMtx.Lock()
+	defer g.evalMtx.Unlock()
+
 	var (
-		now = model.Now()
-		wg  sync.WaitGroup
+		now     = model.Now()
+		wg      sync.WaitGroup
+		results = make([]RuleResult, len(g.rules))
 	)
 
-	for _, rule := range g.rules {
+	groupSpan, groupCtx := opentracing.StartSpanFromContext(g.opts.Context, "rule_group")
+	groupSpan.SetTag("group", g.name)
+	defer groupSpan.Finish()
+
+	for i, rule := range g.rules {
 		rtyp := string(typeForRule(rule))
 
 		wg.Add(1)
 		// BUG(julius): Look at fixing thundering herd.
-		go func(rule Rule) {
+		go func(i int, rule Rule) {
 			defer wg.Done()
 
+			span, ctx := opentracing.StartSpanFromContext(groupCtx, "rule")
+			span.SetTag("rule", rule.Name())
+			span.SetTag("type", rtyp)
+			defer span.Finish()
+
 			defer func(t time.Time) {
 				evalDuration.WithLabelValues(rtyp).Observe(time.Since(t).Seconds())
 			}(time.Now())
 
 			evalTotal.WithLabelValues(rtyp).Inc()
 
-			vector, err := rule.Eval(g.opts.Context, now, g.opts.QueryEngine, g.opts.ExternalURL)
+			vector, err := rule.Eval(ctx, now, g.opts.QueryEngine, g.opts.ExternalURL)
+			results[i] = RuleResult{Rule: rule, Vector: vector, Err: err}
 			if err != nil {
+				ext.Error.Set(span, true)
+				span.LogFields(otlog.Error(err))
+
 				// Canceled queries are intentional termination of queries. This normally
 				// happens on shutdown and thus we skip logging of any errors here.
 				if _, ok := err.(promql.ErrQueryCanceled); !ok {
-					log.Warnf("Error while evaluating rule %q: %s", rule, err)
+					logger.Warnf("Error while evaluating rule %q: %s", rule, err)
 				}
 				evalFailures.WithLabelValues(rtyp).Inc()
 				return
@@ -297,41 +338,38 @@ func (g *Group) Eval() {
 					switch err {
 					case local.ErrOutOfOrderSample:
 						numOutOfOrder++
-						log.With("sample", s).With("error", err).Debug("Rule evaluation result discarded")
+						logger.With("sample", s).With("error", err).Debug("Rule evaluation result discarded")
 					case local.ErrDuplicateSampleForTimestamp:
 						numDuplicates++
-						log.With("sample", s).With("error", err).Debug("Rule evaluation result discarded")
+						logger.With("sample", s).With("error", err).Debug("Rule evaluation result discarded")
 					default:
-						log.With("sample", s).With("error", err).Warn("Rule evaluation result discarded")
+						logger.With("sample", s).With("error", err).Warn("Rule evaluation result discarded")
 					}
 				}
 			}
 			if numOutOfOrder > 0 {
-				log.With("numDropped", numOutOfOrder).Warn("Error on ingesting out-of-order result from rule evaluation")
+				logger.With("numDropped", numOutOfOrder).Warn("Error on ingesting out-of-order result from rule evaluation")
 			}
 			if numDuplicates > 0 {
-				log.With("numDropped", numDuplicates).Warn("Error on ingesting results from rule evaluation with different value but same timestamp")
+				logger.With("numDropped", numDuplicates).Warn("Error on ingesting results from rule evaluation with different value but same timestamp")
 			}
-		}(rule)
+		}(i, rule)
 	}
 	wg.Wait()
+
+	return results
 }
 
 // sendAlerts sends alert notifications for the given rule.
 func (g *Group) sendAlerts(rule *AlertingRule, timestamp model.Time) error {
 	var alerts model.Alerts
 
-	for _, alert := range rule.currentAlerts() {
-		// Only send actually firing alerts.
-		if alert.State == StatePending {
-			continue
-		}
-
+	for _, alert := range rule.alertsToSend(timestamp, g.resendDelay) {
 		a := &model.Alert{
 			StartsAt:     alert.ActiveAt.Add(rule.holdDuration).Time(),
 			Labels:       alert.Labels,
 			Annotations:  alert.Annotations,
-			GeneratorURL: g.opts.ExternalURL.String() + strutil.TableLinkForExpression(rule.vector.String()),
+			GeneratorURL: g.generatorURL(rule, alert),
 		}
 		if alert.ResolvedAt != 0 {
 			a.EndsAt = alert.ResolvedAt.Time()
@@ -347,6 +385,48 @@ func (g *Group) sendAlerts(rule *AlertingRule, timestamp model.Time) error {
 	return nil
 }
 
+// sourceData is the data the source templates of groups are executed with.
+type sourceData struct {
+	ExternalURL string
+	Expr        string
+	AlertName   string
+	Labels      map[string]string
+}
+
+// generatorURL returns the source link of an alert of the rule.
+func (g *Group) generatorURL(rule *AlertingRule, alert *Alert) string {
+	expr := rule.vector.String()
+	tmpl := g.sourceTmpl
+	if rule.sourceTmpl != nil {
+		tmpl = rule.sourceTmpl
+	}
+	if tmpl == nil {
+		return g.opts.ExternalURL.String() + strutil.TableLinkForExpression(expr)
+	}
+
+	data := sourceData{
+		ExternalURL: g.opts.ExternalURL.String(),
+		Expr:        expr,
+		AlertName:   rule.Name(),
+		Labels:      make(map[string]string, len(alert.Labels)),
+	}
+	for ln, lv := range alert.Labels {
+		data.Labels[string(ln)] = string(lv)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		logger.With("alert", rule.Name()).Warnf("Error executing source template: %s", err)
+		return g.opts.ExternalURL.String() + strutil.TableLinkForExpression(expr)
+	}
+	return buf.String()
+}
+
+// Errors returned by the on-demand evaluation of rule groups.
+var (
+	ErrGroupNotFound = errors.New("rule group not found")
+	ErrNotRunning    = errors.New("rule manager is not running yet")
+)
+
 // The Manager manages recording and alerting rules.
 type Manager struct {
 	opts   *ManagerOptions
@@ -362,6 +442,9 @@ type ManagerOptions struct {
 	Context        context.Context
 	Notifier       *notifier.Notifier
 	SampleAppender storage.SampleAppender
+	// The minimum delay between sends of an alert unless overridden by
+	// the rule, zero to send alerts on every evaluation.
+	ResendDelay time.Duration
 }
 
 // NewManager returns an implementation of Manager, ready to be started
@@ -385,13 +468,13 @@ func (m *Manager) Stop() {
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
 
-	log.Info("Stopping rule manager...")
+	logger.Info("Stopping rule manager...")
 
 	for _, eg := range m.groups {
 		eg.stop()
 	}
 
-	log.Info("Rule manager stopped.")
+	logger.Info("Rule manager stopped.")
 }
 
 // ApplyConfig updates the rule manager's state as the config requires. If
@@ -411,8 +494,17 @@ func (m *Manager) ApplyConfig(conf *config.Config) error {
 		files = append(files, fs...)
 	}
 
-	// To be replaced with a configurable per-group interval.
-	groups, err := m.loadGroups(time.Duration(conf.GlobalConfig.EvaluationInterval), files...)
+	var sourceTmpl *text_template.Template
+	if t := conf.AlertingConfig.SourceTemplate; t != "" {
+		var err error
+		if sourceTmpl, err = text_template.New("source_tmpl").Parse(t); err != nil {
+			return fmt.Errorf("error parsing source_tmpl: %s", err)
+		}
+	}
+
+	// To be replaced with a configurable per-group interval and source
+	// template.
+	groups, err := m.loadGroups(time.Duration(conf.GlobalConfig.EvaluationInterval), sourceTmpl, files...)
 	if err != nil {
 		return fmt.Errorf("error loading rules, previous rule set restored: %s", err)
 	}
@@ -457,7 +549,7 @@ func (m *Manager) ApplyConfig(conf *config.Config) error {
 // loadGroups reads groups from a list of files.
 // As there's currently no group syntax a single group named "default" containing
 // all rules will be returned.
-func (m *Manager) loadGroups(interval time.Duration, filenames ...string) (map[string]*Group, error) {
+func (m *Manager) loadGroups(interval time.Duration, sourceTmpl *text_template.Template, filenames ...string) (map[string]*Group, error) {
 	rules := []Rule{}
 	for _, fn := range filenames {
 		content, err := ioutil.ReadFile(fn)
@@ -474,7 +566,15 @@ func (m *Manager) loadGroups(interval time.Duration, filenames ...string) (map[s
 
 			switch r := stmt.(type) {
 			case *promql.AlertStmt:
-				rule = NewAlertingRule(r.Name, r.Expr, r.Duration, r.Labels, r.Annotations)
+				ar := NewAlertingRule(r.Name, r.Expr, r.Duration, r.Labels, r.Annotations)
+				ar.resendDelay = r.ResendDelay
+				if r.SourceTemplate != "" {
+					if ar.sourceTmpl, err = text_template.New("source_tmpl").Parse(r.SourceTemplate); err != nil {
+						return nil, fmt.Errorf("error parsing SOURCE_TMPL of alert %s in %s: %s", r.Name, fn, err)
+					}
+					ar.sourceTmplText = r.SourceTemplate
+				}
+				rule = ar
 
 			case *promql.RecordStmt:
 				rule = NewRecordingRule(r.Name, r.Expr, r.Labels)
@@ -489,10 +589,31 @@ func (m *Manager) loadGroups(interval time.Duration, filenames ...string) (map[s
 	// Currently there is no group syntax implemented. Thus all rules
 	// are read into a single default group.
 	g := NewGroup("default", interval, rules, m.opts)
+	g.sourceTmpl = sourceTmpl
+	g.resendDelay = m.opts.ResendDelay
 	groups := map[string]*Group{g.name: g}
 	return groups, nil
 }
 
+// EvalGroup evaluates the group of the given name immediately, independently
+// of its schedule, and returns the results of its rules. Their samples are
+// stored and alerts sent as for scheduled evaluations.
+func (m *Manager) EvalGroup(name string) ([]RuleResult, error) {
+	select {
+	case <-m.block:
+	default:
+		return nil, ErrNotRunning
+	}
+
+	m.mtx.RLock()
+	g, ok := m.groups[name]
+	m.mtx.RUnlock()
+	if !ok {
+		return nil, ErrGroupNotFound
+	}
+	return g.Eval(), nil
+}
+
 // Rules returns the list of the manager's rules.
 func (m *Manager) Rules() []Rule {
 	m.mtx.RLock()
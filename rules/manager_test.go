@@ -15,8 +15,12 @@ package rules
 
 import (
 	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
 	"strings"
 	"testing"
+	"text/template"
 	"time"
 
 	"github.com/prometheus/common/model"
@@ -152,3 +156,112 @@ func annotateWithTime(lines []string, timestamp model.Time) []string {
 	}
 	return annotatedLines
 }
+
+func TestGroupGeneratorURL(t *testing.T) {
+	expr, err := promql.ParseExpr(`up == 0`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rule := NewAlertingRule("InstanceDown", expr, time.Minute, nil, nil)
+	alert := &Alert{Labels: model.LabelSet{"instance": "host:9100"}}
+
+	externalURL, err := url.Parse("http://prometheus.example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := NewGroup("default", time.Minute, []Rule{rule}, &ManagerOptions{ExternalURL: externalURL})
+
+	if u := g.generatorURL(rule, alert); u != "http://prometheus.example.com/graph?g0.expr=up+%3D%3D+0&g0.tab=1" {
+		t.Errorf("Unexpected default source link %q", u)
+	}
+
+	g.sourceTmpl = template.Must(template.New("").Parse(
+		`https://grafana.example.com/explore?expr={{ urlquery .Expr }}&instance={{ .Labels.instance }}&alert={{ .AlertName }}&from={{ .ExternalURL }}`,
+	))
+	expected := "https://grafana.example.com/explore?expr=up+%3D%3D+0&instance=host:9100&alert=InstanceDown&from=http://prometheus.example.com"
+	if u := g.generatorURL(rule, alert); u != expected {
+		t.Errorf("Expected source link %q, got %q", expected, u)
+	}
+
+	// The template of the rule takes precedence.
+	rule.sourceTmpl = template.Must(template.New("").Parse(`https://dashboards.example.com/{{ .AlertName }}`))
+	if u := g.generatorURL(rule, alert); u != "https://dashboards.example.com/InstanceDown" {
+		t.Errorf("Unexpected source link of the rule %q", u)
+	}
+}
+
+func TestLoadGroupsSourceTemplate(t *testing.T) {
+	f, err := ioutil.TempFile("", "rules")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	_, err = f.WriteString(`ALERT InstanceDown IF up == 0 SOURCE_TMPL "https://dashboards.example.com/{{ .AlertName }}"`)
+	f.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewManager(&ManagerOptions{})
+	groups, err := m.loadGroups(time.Minute, nil, f.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	rule := groups["default"].rules[0].(*AlertingRule)
+	if rule.sourceTmpl == nil {
+		t.Fatalf("Expected the source template of the rule to be loaded")
+	}
+	if exp := "ALERT InstanceDown\n\tIF up == 0\n\tSOURCE_TMPL \"https://dashboards.example.com/{{ .AlertName }}\""; rule.String() != exp {
+		t.Errorf("Expected rule\n%s\ngot\n%s", exp, rule.String())
+	}
+}
+
+func TestManagerEvalGroup(t *testing.T) {
+	suite, err := promql.NewTest(t, ``)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer suite.Close()
+
+	record, err := promql.ParseExpr(`vector(1)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	failing, err := promql.ParseExpr(`label_replace(vector(1), "a", "b", "c", "(")`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts := &ManagerOptions{
+		QueryEngine:    suite.QueryEngine(),
+		Context:        suite.Context(),
+		SampleAppender: suite.Storage(),
+	}
+	m := NewManager(opts)
+	m.groups["default"] = NewGroup("default", time.Minute, []Rule{
+		NewRecordingRule("one", record, nil),
+		NewRecordingRule("failing", failing, nil),
+	}, opts)
+
+	if _, err := m.EvalGroup("default"); err != ErrNotRunning {
+		t.Fatalf("Expected error %q before running, got %v", ErrNotRunning, err)
+	}
+	m.Run()
+	if _, err := m.EvalGroup("unknown"); err != ErrGroupNotFound {
+		t.Fatalf("Expected error %q, got %v", ErrGroupNotFound, err)
+	}
+
+	results, err := m.EvalGroup("default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	if r := results[0]; r.Rule.Name() != "one" || r.Err != nil || len(r.Vector) != 1 || r.Vector[0].Value != 1 {
+		t.Errorf("Unexpected result of the recording rule: %+v", r)
+	}
+	if r := results[1]; r.Rule.Name() != "failing" || r.Err == nil {
+		t.Errorf("Expected an error for the failing rule, got %+v", r)
+	}
+}
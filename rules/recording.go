@@ -58,7 +58,7 @@ func (rule RecordingRule) Eval(ctx context.Context, timestamp model.Time, engine
 		vector model.Vector
 	)
 	if result.Err != nil {
-		return nil, err
+		return nil, result.Err
 	}
 
 	switch result.Value.(type) {
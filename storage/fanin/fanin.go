@@ -113,6 +113,10 @@ func (q querier) MetricsForLabelMatchers(ctx context.Context, from, through mode
 	return q.local.MetricsForLabelMatchers(ctx, from, through, matcherSets...)
 }
 
+func (q querier) ForEachMetricForLabelMatchers(ctx context.Context, from, through model.Time, f func(metric.Metric) bool, matcherSets ...metric.LabelMatchers) error {
+	return q.local.ForEachMetricForLabelMatchers(ctx, from, through, f, matcherSets...)
+}
+
 func (q querier) LastSampleForLabelMatchers(ctx context.Context, cutoff model.Time, matcherSets ...metric.LabelMatchers) (model.Vector, error) {
 	return q.local.LastSampleForLabelMatchers(ctx, cutoff, matcherSets...)
 }
@@ -121,6 +125,18 @@ func (q querier) LabelValuesForLabelName(ctx context.Context, ln model.LabelName
 	return q.local.LabelValuesForLabelName(ctx, ln)
 }
 
+func (q querier) LabelNames(ctx context.Context) (model.LabelNames, error) {
+	return q.local.LabelNames(ctx)
+}
+
+func (q querier) LabelValuesForLabelMatchers(ctx context.Context, from, through model.Time, ln model.LabelName, matcherSets ...metric.LabelMatchers) (model.LabelValues, error) {
+	return q.local.LabelValuesForLabelMatchers(ctx, from, through, ln, matcherSets...)
+}
+
+func (q querier) LabelNamesForLabelMatchers(ctx context.Context, from, through model.Time, matcherSets ...metric.LabelMatchers) (model.LabelNames, error) {
+	return q.local.LabelNamesForLabelMatchers(ctx, from, through, matcherSets...)
+}
+
 func (q querier) Close() error {
 	if q.local != nil {
 		if err := q.local.Close(); err != nil {
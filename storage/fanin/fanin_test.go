@@ -97,6 +97,10 @@ func (q testQuerier) MetricsForLabelMatchers(ctx context.Context, from, through
 	return metrics, nil
 }
 
+func (q testQuerier) ForEachMetricForLabelMatchers(ctx context.Context, from, through model.Time, f func(metric.Metric) bool, matcherSets ...metric.LabelMatchers) error {
+	panic("not implemented")
+}
+
 func (q testQuerier) LastSampleForLabelMatchers(ctx context.Context, cutoff model.Time, matcherSets ...metric.LabelMatchers) (model.Vector, error) {
 	panic("not implemented")
 }
@@ -105,6 +109,18 @@ func (q testQuerier) LabelValuesForLabelName(ctx context.Context, ln model.Label
 	panic("not implemented")
 }
 
+func (q testQuerier) LabelNames(ctx context.Context) (model.LabelNames, error) {
+	panic("not implemented")
+}
+
+func (q testQuerier) LabelValuesForLabelMatchers(ctx context.Context, from, through model.Time, ln model.LabelName, matcherSets ...metric.LabelMatchers) (model.LabelValues, error) {
+	panic("not implemented")
+}
+
+func (q testQuerier) LabelNamesForLabelMatchers(ctx context.Context, from, through model.Time, matcherSets ...metric.LabelMatchers) (model.LabelNames, error) {
+	panic("not implemented")
+}
+
 func (q testQuerier) Close() error {
 	return nil
 }
@@ -0,0 +1,200 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/prometheus/common/model"
+)
+
+// SeriesDirStats holds statistics about the series files in a directory of a
+// storage.
+type SeriesDirStats struct {
+	Dir         string
+	SeriesFiles int
+	Chunks      int
+	Bytes       int64
+	// The time range covered by the chunks. Both are 0 if there are no
+	// chunks.
+	MinTime, MaxTime model.Time
+	// The number of chunks that start before the preceding chunk of the
+	// same series ends, i.e. chunks holding duplicate or out-of-order data.
+	OverlappingChunks int
+}
+
+func (s *SeriesDirStats) merge(o SeriesDirStats) {
+	if o.Chunks > 0 {
+		if s.Chunks == 0 || o.MinTime < s.MinTime {
+			s.MinTime = o.MinTime
+		}
+		if s.Chunks == 0 || o.MaxTime > s.MaxTime {
+			s.MaxTime = o.MaxTime
+		}
+	}
+	s.SeriesFiles += o.SeriesFiles
+	s.Chunks += o.Chunks
+	s.Bytes += o.Bytes
+	s.OverlappingChunks += o.OverlappingChunks
+}
+
+// ListSeriesDirs returns statistics about the series files in each series
+// directory of the storage in basePath, ordered by directory name. Only chunk
+// headers are read and the storage is not locked, so it can be used on the
+// directory of a running Prometheus server. The result may be inconsistent in
+// that case, though.
+func ListSeriesDirs(basePath string) ([]SeriesDirStats, error) {
+	dirs, err := ioutil.ReadDir(basePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []SeriesDirStats
+	for _, d := range dirs {
+		if !d.IsDir() || !isSeriesDirName(d.Name()) {
+			continue
+		}
+		stats, err := seriesDirStats(filepath.Join(basePath, d.Name()))
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, stats)
+	}
+	return res, nil
+}
+
+func isSeriesDirName(name string) bool {
+	if len(name) != seriesDirNameLen {
+		return false
+	}
+	_, err := hex.DecodeString(name)
+	return err == nil
+}
+
+func seriesDirStats(dir string) (SeriesDirStats, error) {
+	stats := SeriesDirStats{Dir: dir}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return stats, err
+	}
+	for _, fi := range files {
+		if fi.IsDir() || !strings.HasSuffix(fi.Name(), seriesFileSuffix) {
+			continue
+		}
+		fs, err := seriesFileStats(filepath.Join(dir, fi.Name()))
+		if err != nil {
+			return stats, err
+		}
+		stats.merge(fs)
+	}
+	return stats, nil
+}
+
+func seriesFileStats(filename string) (SeriesDirStats, error) {
+	stats := SeriesDirStats{SeriesFiles: 1}
+
+	f, err := os.Open(filename)
+	if os.IsNotExist(err) {
+		// The series has been removed in the meantime.
+		return SeriesDirStats{}, nil
+	}
+	if err != nil {
+		return stats, err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return stats, err
+	}
+	stats.Bytes = fi.Size()
+
+	var (
+		header   = make([]byte, chunkHeaderLen)
+		lastTime model.Time
+	)
+	for i := 0; i < int(fi.Size()/chunkLenWithHeader); i++ {
+		if _, err := f.ReadAt(header, offsetForChunkIndex(i)); err != nil {
+			if err == io.EOF {
+				// The file has been truncated in the meantime.
+				break
+			}
+			return stats, err
+		}
+		first := model.Time(binary.LittleEndian.Uint64(header[chunkHeaderFirstTimeOffset:]))
+		last := model.Time(binary.LittleEndian.Uint64(header[chunkHeaderLastTimeOffset:]))
+
+		if stats.Chunks == 0 || first < stats.MinTime {
+			stats.MinTime = first
+		}
+		if stats.Chunks == 0 || last > stats.MaxTime {
+			stats.MaxTime = last
+		}
+		if stats.Chunks > 0 && first <= lastTime {
+			stats.OverlappingChunks++
+		}
+		lastTime = last
+		stats.Chunks++
+	}
+	return stats, nil
+}
+
+// StorageAnalysis holds the result of AnalyzeStorage.
+type StorageAnalysis struct {
+	// Statistics about all series files.
+	SeriesFiles SeriesDirStats
+	// Statistics about the series in the checkpoint of the series held in
+	// memory. Archived series are not included.
+	CheckpointSeries *SeriesStats
+}
+
+// AnalyzeStorage returns statistics about the series files and the checkpoint
+// of the storage in basePath, with the lists of the checkpoint statistics
+// limited to the top limit entries. Like ListSeriesDirs, it does not lock the
+// storage.
+func AnalyzeStorage(basePath string, limit int) (*StorageAnalysis, error) {
+	dirs, err := ListSeriesDirs(basePath)
+	if err != nil {
+		return nil, err
+	}
+	res := &StorageAnalysis{
+		SeriesFiles: SeriesDirStats{Dir: basePath},
+	}
+	for _, d := range dirs {
+		res.SeriesFiles.merge(d)
+	}
+
+	c := newSeriesCounter()
+	hs := newHeadsScanner(filepath.Join(basePath, headsFileName))
+	defer hs.close()
+	if hs.err != nil && !os.IsNotExist(hs.err) {
+		return nil, hs.err
+	}
+	for hs.err == nil && hs.scan() {
+		c.add(hs.series.metric)
+	}
+	if hs.err != nil && !os.IsNotExist(hs.err) {
+		return nil, hs.err
+	}
+	res.CheckpointSeries = c.stats(limit)
+
+	return res, nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/prometheus/prometheus/storage/local/chunk"
+	"github.com/prometheus/prometheus/util/testutil"
+)
+
+func TestListSeriesDirs(t *testing.T) {
+	p, closer := newTestPersistence(t, 1)
+	defer closer.Close()
+
+	fpToChunks := buildTestChunks(t, 1)
+	// Add a chunk to m1 overlapping with its existing chunks.
+	ch, err := chunk.NewForEncoding(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chs, err := ch.Add(model.SamplePair{Timestamp: 5, Value: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fp1 := m1.FastFingerprint()
+	fpToChunks[fp1] = append(fpToChunks[fp1], chs[0])
+
+	for fp, chunks := range fpToChunks {
+		if _, err := p.persistChunks(fp, chunks); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dirs, err := ListSeriesDirs(p.basePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	total := SeriesDirStats{}
+	for _, d := range dirs {
+		total.merge(d)
+	}
+	expected := SeriesDirStats{
+		SeriesFiles:       3,
+		Chunks:            31,
+		Bytes:             31 * chunkLenWithHeader,
+		MinTime:           0,
+		MaxTime:           9,
+		OverlappingChunks: 1,
+	}
+	if !reflect.DeepEqual(total, expected) {
+		t.Errorf("expected stats %+v, got %+v", expected, total)
+	}
+}
+
+func TestAnalyzeStorage(t *testing.T) {
+	dir := testutil.NewTemporaryDirectory("test_analyze_storage", t)
+	defer dir.Close()
+
+	storage := NewMemorySeriesStorage(&MemorySeriesStorageOptions{
+		TargetHeapSize:             1000000000,
+		PersistenceRetentionPeriod: 24 * time.Hour * 365 * 100,
+		PersistenceStoragePath:     dir.Path(),
+		HeadChunkTimeout:           5 * time.Minute,
+		CheckpointInterval:         time.Hour,
+		SyncStrategy:               Adaptive,
+	})
+	if err := storage.Start(); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 4; i++ {
+		storage.Append(&model.Sample{
+			Metric: model.Metric{
+				model.MetricNameLabel: model.LabelValue(fmt.Sprintf("metric_%d", i%2)),
+				"instance":            model.LabelValue(fmt.Sprintf("host_%d", i)),
+			},
+			Timestamp: model.Time(i),
+			Value:     model.SampleValue(i),
+		})
+	}
+	// Stopping writes the checkpoint.
+	if err := storage.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	analysis, err := AnalyzeStorage(dir.Path(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stats := analysis.CheckpointSeries
+	if stats.NumSeries != 4 {
+		t.Errorf("expected 4 series in checkpoint, got %d", stats.NumSeries)
+	}
+	expectedByLabelName := []StatsEntry{{"instance", 4}}
+	if !reflect.DeepEqual(stats.LabelValueCountByLabelName, expectedByLabelName) {
+		t.Errorf("expected label value count by label name %v, got %v", expectedByLabelName, stats.LabelValueCountByLabelName)
+	}
+}
@@ -0,0 +1,349 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"archive/tar"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
+
+	"github.com/prometheus/prometheus/storage/local/chunk"
+	"github.com/prometheus/prometheus/storage/metric"
+)
+
+// A block is a directory holding one file per series, in the format of the
+// series files of the storage, and a meta file listing the metrics of the
+// series. Blocks are written by ExportBlock and read by ImportBlock.
+const (
+	blockMetaFileName = "meta.json"
+	blockVersion      = 1
+)
+
+type blockMeta struct {
+	Version int           `json:"version"`
+	Series  []blockSeries `json:"series"`
+}
+
+type blockSeries struct {
+	Metric model.Metric `json:"metric"`
+	// The name of the series file in the block directory.
+	File string `json:"file"`
+}
+
+// ExportBlock writes the samples between from and through of the series
+// matching any of the matcher sets to w, as a tar archive of a block
+// directory. Once extracted, the block can be imported into another storage
+// with ImportBlock.
+func (s *MemorySeriesStorage) ExportBlock(
+	ctx context.Context, w io.Writer,
+	from, through model.Time,
+	matcherSets ...metric.LabelMatchers,
+) error {
+	return s.exportBlock(ctx, w, from, through, nil, matcherSets...)
+}
+
+// ExportBlockWithLabels is like ExportBlock, but adds the labels to the
+// metrics of the exported series which do not have them yet, like federation
+// does with external labels. Labels with empty values are ignored.
+func (s *MemorySeriesStorage) ExportBlockWithLabels(
+	ctx context.Context, w io.Writer,
+	from, through model.Time,
+	labels model.LabelSet,
+	matcherSets ...metric.LabelMatchers,
+) error {
+	return s.exportBlock(ctx, w, from, through, labels, matcherSets...)
+}
+
+func (s *MemorySeriesStorage) exportBlock(
+	ctx context.Context, w io.Writer,
+	from, through model.Time,
+	labels model.LabelSet,
+	matcherSets ...metric.LabelMatchers,
+) error {
+	pairs := map[model.Fingerprint]fingerprintSeriesPair{}
+	for _, matchers := range matcherSets {
+		ps, err := s.seriesForLabelMatchers(from, through, nil, matchers...)
+		if err != nil {
+			return err
+		}
+		for _, pair := range ps {
+			pairs[pair.fp] = pair
+		}
+	}
+	fps := make(model.Fingerprints, 0, len(pairs))
+	for fp := range pairs {
+		fps = append(fps, fp)
+	}
+	sort.Sort(fps)
+
+	tw := tar.NewWriter(w)
+	meta := blockMeta{Version: blockVersion, Series: []blockSeries{}}
+	for _, fp := range fps {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		pair := pairs[fp]
+		it := s.preloadChunksForRange(ctx, pair, from, through)
+		samples := it.RangeValues(metric.Interval{OldestInclusive: from, NewestInclusive: through})
+		it.Close()
+		if len(samples) == 0 {
+			continue
+		}
+
+		// The metric of a series never changes, so it can be read
+		// without locking the fingerprint.
+		m := pair.series.metric
+		if len(labels) > 0 {
+			m = m.Clone()
+			for ln, lv := range labels {
+				if _, ok := m[ln]; !ok && lv != "" {
+					m[ln] = lv
+				}
+			}
+		}
+		content, err := encodeSeriesFile(m, samples)
+		if err != nil {
+			return err
+		}
+		name := fp.String() + seriesFileSuffix
+		if err := writeTarFile(tw, name, content); err != nil {
+			return err
+		}
+		meta.Series = append(meta.Series, blockSeries{Metric: m, File: name})
+	}
+
+	b, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	if err := writeTarFile(tw, blockMetaFileName, b); err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// ImportBlock imports the series of the block in dir as archived series and
+// returns their number. The whole block is validated before any series is
+// imported. Series which are in the storage already cannot be imported.
+func (s *MemorySeriesStorage) ImportBlock(ctx context.Context, dir string) (int, error) {
+	meta, err := readBlockMeta(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	seen := make(map[string]struct{}, len(meta.Series))
+	for _, bs := range meta.Series {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		if _, _, _, err := loadBlockSeriesFile(dir, bs); err != nil {
+			return 0, err
+		}
+		key := bs.Metric.String()
+		if _, ok := seen[key]; ok {
+			return 0, fmt.Errorf("duplicate series %v in block", bs.Metric)
+		}
+		seen[key] = struct{}{}
+
+		fp := s.lockMappedFP(bs.Metric)
+		exists := s.seriesExists(fp)
+		s.fpLocker.Unlock(fp)
+		if exists {
+			return 0, fmt.Errorf("series %v exists already", bs.Metric)
+		}
+	}
+
+	for i, bs := range meta.Series {
+		// Series files are read again so that they cannot change
+		// between validation and import.
+		content, first, last, err := loadBlockSeriesFile(dir, bs)
+		if err != nil {
+			return i, err
+		}
+		if err := s.importSeries(bs.Metric, content, first, last); err != nil {
+			return i, err
+		}
+	}
+	s.persistence.waitForIndexing()
+	return len(meta.Series), nil
+}
+
+// importSeries installs the series file content of the metric and archives
+// the metric.
+func (s *MemorySeriesStorage) importSeries(m model.Metric, content []byte, first, last model.Time) error {
+	fp := s.lockMappedFP(m)
+	defer s.fpLocker.Unlock(fp)
+
+	if s.seriesExists(fp) {
+		return fmt.Errorf("series %v exists already", m)
+	}
+	if err := s.persistence.importSeriesFile(fp, content); err != nil {
+		return fmt.Errorf("error importing series %v: %s", m, err)
+	}
+	s.persistence.indexMetric(fp, m)
+	s.persistence.archiveMetric(fp, m, first, last)
+	s.raiseArchiveHighWatermark(last)
+	s.seriesOps.WithLabelValues(blockImport).Inc()
+	return nil
+}
+
+// lockMappedFP locks and returns the unique fingerprint of the metric. The
+// caller has to unlock it.
+func (s *MemorySeriesStorage) lockMappedFP(m model.Metric) model.Fingerprint {
+	rawFP := m.FastFingerprint()
+	s.fpLocker.Lock(rawFP)
+	fp := s.mapper.mapFP(rawFP, m)
+	if fp != rawFP {
+		s.fpLocker.Unlock(rawFP)
+		s.fpLocker.Lock(fp)
+	}
+	return fp
+}
+
+// seriesExists returns whether the series of the fingerprint is in memory or
+// archived. The caller must have locked the fp.
+func (s *MemorySeriesStorage) seriesExists(fp model.Fingerprint) bool {
+	if _, ok := s.fpToSeries.get(fp); ok {
+		return true
+	}
+	has, _, _ := s.persistence.hasArchivedMetric(fp)
+	return has
+}
+
+// encodeSeriesFile returns the content of a series file holding the samples of
+// the series with the metric.
+func encodeSeriesFile(m model.Metric, samples []model.SamplePair) ([]byte, error) {
+	chunks := []chunk.Chunk{chunk.NewForMetric(m)}
+	for _, sp := range samples {
+		cs, err := chunks[len(chunks)-1].Add(sp)
+		if err != nil {
+			return nil, err
+		}
+		chunks = append(chunks[:len(chunks)-1], cs...)
+	}
+
+	b := make([]byte, len(chunks)*chunkLenWithHeader)
+	for i, c := range chunks {
+		if err := writeChunkHeader(b[i*chunkLenWithHeader:], c); err != nil {
+			return nil, err
+		}
+		if err := c.MarshalToBuf(b[i*chunkLenWithHeader+chunkHeaderLen:]); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+func writeTarFile(tw *tar.Writer, name string, content []byte) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0640,
+		Size:    int64(len(content)),
+		ModTime: time.Now(),
+	}); err != nil {
+		return err
+	}
+	_, err := tw.Write(content)
+	return err
+}
+
+func readBlockMeta(dir string) (*blockMeta, error) {
+	b, err := ioutil.ReadFile(filepath.Join(dir, blockMetaFileName))
+	if err != nil {
+		return nil, err
+	}
+	var meta blockMeta
+	if err := json.Unmarshal(b, &meta); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %s", blockMetaFileName, err)
+	}
+	if meta.Version != blockVersion {
+		return nil, fmt.Errorf("unsupported block version %d", meta.Version)
+	}
+	return &meta, nil
+}
+
+// loadBlockSeriesFile reads and validates the series file of the block
+// series. It returns the content of the file along with the timestamps of
+// the first and last sample in it.
+func loadBlockSeriesFile(dir string, bs blockSeries) (content []byte, first, last model.Time, err error) {
+	if len(bs.Metric) == 0 {
+		return nil, 0, 0, fmt.Errorf("series without labels in block")
+	}
+	for ln, lv := range bs.Metric {
+		if !ln.IsValid() || len(lv) == 0 || !lv.IsValid() {
+			return nil, 0, 0, fmt.Errorf("invalid label pair %s=%q of series %v", ln, lv, bs.Metric)
+		}
+	}
+	if bs.File != filepath.Base(bs.File) || filepath.Ext(bs.File) != seriesFileSuffix {
+		return nil, 0, 0, fmt.Errorf("invalid file name %q of series %v", bs.File, bs.Metric)
+	}
+
+	content, err = ioutil.ReadFile(filepath.Join(dir, bs.File))
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	if len(content) == 0 || len(content)%chunkLenWithHeader != 0 {
+		return nil, 0, 0, fmt.Errorf(
+			"size %d of file %s is not a positive multiple of the on-disk chunk length %d",
+			len(content), bs.File, chunkLenWithHeader,
+		)
+	}
+
+	for i := 0; i < len(content)/chunkLenWithHeader; i++ {
+		buf := content[i*chunkLenWithHeader : (i+1)*chunkLenWithHeader]
+		c, err := chunk.NewForEncoding(chunk.Encoding(buf[chunkHeaderTypeOffset]))
+		if err != nil {
+			return nil, 0, 0, fmt.Errorf("chunk %d of file %s: %s", i, bs.File, err)
+		}
+		if err := c.UnmarshalFromBuf(buf[chunkHeaderLen:]); err != nil {
+			return nil, 0, 0, fmt.Errorf("chunk %d of file %s: %s", i, bs.File, err)
+		}
+
+		n := 0
+		it := c.NewIterator()
+		for it.Scan() {
+			t := it.Value().Timestamp
+			if (i > 0 || n > 0) && !t.After(last) {
+				return nil, 0, 0, fmt.Errorf("chunk %d of file %s: sample at %v is out of order", i, bs.File, t)
+			}
+			if n == 0 && t != model.Time(binary.LittleEndian.Uint64(buf[chunkHeaderFirstTimeOffset:])) {
+				return nil, 0, 0, fmt.Errorf("chunk %d of file %s: first sample time does not match the chunk header", i, bs.File)
+			}
+			if i == 0 && n == 0 {
+				first = t
+			}
+			last = t
+			n++
+		}
+		if err := it.Err(); err != nil {
+			return nil, 0, 0, fmt.Errorf("chunk %d of file %s: %s", i, bs.File, err)
+		}
+		if n == 0 {
+			return nil, 0, 0, fmt.Errorf("chunk %d of file %s is empty", i, bs.File)
+		}
+		if last != model.Time(binary.LittleEndian.Uint64(buf[chunkHeaderLastTimeOffset:])) {
+			return nil, 0, 0, fmt.Errorf("chunk %d of file %s: last sample time does not match the chunk header", i, bs.File)
+		}
+	}
+	return content, first, last, nil
+}
@@ -0,0 +1,190 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
+
+	"github.com/prometheus/prometheus/storage/metric"
+	"github.com/prometheus/prometheus/util/testutil"
+)
+
+// extractTar extracts the tar archive into dir.
+func extractTar(t *testing.T, r io.Reader, dir string) {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := ioutil.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, hdr.Name), b, 0640); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestExportImportBlock(t *testing.T) {
+	src, closer := NewTestStorage(t, 1)
+	defer closer.Close()
+
+	metrics := []model.Metric{
+		{model.MetricNameLabel: "test", "instance": "a"},
+		{model.MetricNameLabel: "test", "instance": "b"},
+		{model.MetricNameLabel: "other"},
+	}
+	for i := 0; i < 5000; i++ {
+		for j, m := range metrics {
+			src.Append(&model.Sample{
+				Metric:    m,
+				Timestamp: model.Time(i * 1000),
+				Value:     model.SampleValue(i * (j + 1)),
+			})
+		}
+	}
+	src.WaitForIndexing()
+
+	matchers, err := metric.NewLabelMatcher(metric.Equal, model.MetricNameLabel, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := src.ExportBlock(context.Background(), &buf, 1000000, model.Latest, metric.LabelMatchers{matchers}); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := testutil.NewTemporaryDirectory("test_block", t)
+	defer dir.Close()
+	extractTar(t, &buf, dir.Path())
+
+	dst, dstCloser := NewTestStorage(t, 1)
+	defer dstCloser.Close()
+
+	n, err := dst.ImportBlock(context.Background(), dir.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Fatalf("expected 2 imported series, got %d", n)
+	}
+
+	for _, m := range metrics[:2] {
+		fp := m.FastFingerprint()
+		if archived, _, _ := dst.persistence.hasArchivedMetric(fp); !archived {
+			t.Errorf("series %v not archived", m)
+		}
+		expected := src.preloadChunksForRange(context.Background(), makeFingerprintSeriesPair(src, fp), 1000000, model.Latest).RangeValues(
+			metric.Interval{OldestInclusive: 1000000, NewestInclusive: model.Latest},
+		)
+		got := dst.preloadChunksForRange(context.Background(), makeFingerprintSeriesPair(dst, fp), model.Earliest, model.Latest).RangeValues(
+			metric.Interval{OldestInclusive: model.Earliest, NewestInclusive: model.Latest},
+		)
+		if len(got) != 4000 || !reflect.DeepEqual(got, expected) {
+			t.Errorf("unexpected samples of imported series %v: got %d samples, expected %d", m, len(got), len(expected))
+		}
+	}
+	// Imported series are indexed.
+	fps := dst.fingerprintsForLabelPair(model.LabelPair{Name: "instance", Value: "b"}, nil, nil)
+	if len(fps) != 1 {
+		t.Errorf("expected 1 fingerprint for instance=b, got %d", len(fps))
+	}
+
+	if _, err := dst.ImportBlock(context.Background(), dir.Path()); err == nil {
+		t.Error("expected error importing existing series")
+	}
+}
+
+func TestImportInvalidBlock(t *testing.T) {
+	s, closer := NewTestStorage(t, 1)
+	defer closer.Close()
+
+	content, err := encodeSeriesFile(model.Metric{model.MetricNameLabel: "test"}, []model.SamplePair{{Timestamp: 1, Value: 1}, {Timestamp: 2, Value: 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	unordered, err := encodeSeriesFile(model.Metric{model.MetricNameLabel: "test"}, []model.SamplePair{{Timestamp: 1, Value: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	unordered = append(unordered, unordered...)
+
+	tests := []struct {
+		meta  string
+		files map[string][]byte
+	}{
+		{
+			meta: `{"version":2,"series":[]}`,
+		},
+		{
+			meta: `{"version":1,"series":[{"metric":{"__name__":"a"},"file":"missing.db"}]}`,
+		},
+		{
+			meta:  `{"version":1,"series":[{"metric":{"__name__":"a"},"file":"../a.db"}]}`,
+			files: map[string][]byte{"a.db": content},
+		},
+		{
+			meta:  `{"version":1,"series":[{"metric":{"__name__":"a","b":""},"file":"a.db"}]}`,
+			files: map[string][]byte{"a.db": content},
+		},
+		{
+			meta:  `{"version":1,"series":[{"metric":{"__name__":"a"},"file":"a.db"}]}`,
+			files: map[string][]byte{"a.db": content[:len(content)-1]},
+		},
+		{
+			meta:  `{"version":1,"series":[{"metric":{"__name__":"a"},"file":"a.db"}]}`,
+			files: map[string][]byte{"a.db": unordered},
+		},
+		{
+			meta:  `{"version":1,"series":[{"metric":{"__name__":"a"},"file":"a.db"},{"metric":{"__name__":"b"},"file":"a.db"},{"metric":{"__name__":"a"},"file":"a.db"}]}`,
+			files: map[string][]byte{"a.db": content},
+		},
+	}
+
+	for i, test := range tests {
+		dir := testutil.NewTemporaryDirectory("test_block", t)
+		if err := ioutil.WriteFile(filepath.Join(dir.Path(), blockMetaFileName), []byte(test.meta), 0640); err != nil {
+			t.Fatal(err)
+		}
+		for name, b := range test.files {
+			if err := ioutil.WriteFile(filepath.Join(dir.Path(), name), b, 0640); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if _, err := s.ImportBlock(context.Background(), dir.Path()); err == nil {
+			t.Errorf("%d. expected error importing invalid block", i)
+		}
+		dir.Close()
+	}
+
+	// Nothing has been imported.
+	if _, err := os.Stat(s.persistence.fileNameForFingerprint(model.Metric{model.MetricNameLabel: "b"}.FastFingerprint())); !os.IsNotExist(err) {
+		t.Errorf("expected no series file for partially valid block, got %v", err)
+	}
+}
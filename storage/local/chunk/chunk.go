@@ -18,11 +18,14 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"regexp"
 	"sort"
+	"strings"
 	"sync"
 	"sync/atomic"
 
 	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
 
 	"github.com/prometheus/prometheus/storage/metric"
 )
@@ -33,6 +36,10 @@ const ChunkLen = 1024
 // DefaultEncoding can be changed via a flag.
 var DefaultEncoding = DoubleDelta
 
+// MetricEncodings select the encodings of the chunks of metrics by metric
+// name, taking precedence over DefaultEncoding. They can be set via a flag.
+var MetricEncodings EncodingRules
+
 var (
 	errChunkBoundsExceeded = errors.New("attempted access outside of chunk boundaries")
 	errAddedToEvictedChunk = errors.New("attempted to add sample to evicted chunk")
@@ -44,7 +51,9 @@ type EvictRequest struct {
 	Evict bool
 }
 
-// Encoding defines which encoding we are using, delta, doubledelta, or varbit
+// Encoding defines which encoding we are using, delta, doubledelta, varbit,
+// or float32. Encodings are persisted with the chunks, so the value of an
+// encoding must never change.
 type Encoding byte
 
 // String implements flag.Value.
@@ -52,19 +61,24 @@ func (e Encoding) String() string {
 	return fmt.Sprintf("%d", e)
 }
 
-// Set implements flag.Value.
+// Set implements flag.Value. It accepts the number or the name of a
+// registered encoding.
 func (e *Encoding) Set(s string) error {
-	switch s {
-	case "0":
-		*e = Delta
-	case "1":
-		*e = DoubleDelta
-	case "2":
-		*e = Varbit
-	default:
-		return fmt.Errorf("invalid chunk encoding: %s", s)
+	for enc, desc := range encodings {
+		if s == enc.String() || s == desc.name {
+			*e = enc
+			return nil
+		}
 	}
-	return nil
+	return fmt.Errorf("invalid chunk encoding: %s", s)
+}
+
+// Name returns the name the encoding was registered with.
+func (e Encoding) Name() string {
+	if desc, ok := encodings[e]; ok {
+		return desc.name
+	}
+	return e.String()
 }
 
 const (
@@ -74,8 +88,82 @@ const (
 	DoubleDelta
 	// Varbit encoding
 	Varbit
+	// Float32 encoding, storing values with reduced precision.
+	Float32
 )
 
+type encodingDesc struct {
+	name     string
+	newChunk func() Chunk
+}
+
+// encodings holds the registered encodings.
+var encodings = map[Encoding]encodingDesc{}
+
+// RegisterEncoding registers a chunk encoding under the given name, with the
+// constructor of empty chunks of the encoding. It panics if the encoding or
+// the name is already registered. It must only be called from init
+// functions.
+func RegisterEncoding(e Encoding, name string, newChunk func() Chunk) {
+	for enc, desc := range encodings {
+		if enc == e || desc.name == name {
+			panic(fmt.Errorf("chunk encoding %d (%s) registered twice", e, name))
+		}
+	}
+	encodings[e] = encodingDesc{name: name, newChunk: newChunk}
+}
+
+// EncodingRule selects the encoding of the chunks of the metrics whose names
+// match a regular expression.
+type EncodingRule struct {
+	Metrics  *regexp.Regexp
+	Encoding Encoding
+}
+
+// EncodingRules are the rules selecting the encodings of chunks, of which
+// the first matching one applies.
+type EncodingRules []EncodingRule
+
+// String implements flag.Value.
+func (r EncodingRules) String() string {
+	rules := make([]string, 0, len(r))
+	for _, rule := range r {
+		rules = append(rules, fmt.Sprintf("%s=%s", strings.TrimSuffix(strings.TrimPrefix(rule.Metrics.String(), "^(?:"), ")$"), rule.Encoding.Name()))
+	}
+	return strings.Join(rules, ",")
+}
+
+// Set implements flag.Value. It appends a rule in the format
+// <metric name regex>=<encoding>.
+func (r *EncodingRules) Set(s string) error {
+	i := strings.LastIndex(s, "=")
+	if i < 0 {
+		return fmt.Errorf("invalid chunk encoding rule %q, expected <metric name regex>=<encoding>", s)
+	}
+	re, err := regexp.Compile("^(?:" + s[:i] + ")$")
+	if err != nil {
+		return fmt.Errorf("invalid metric name regex in chunk encoding rule %q: %s", s, err)
+	}
+	var e Encoding
+	if err := e.Set(s[i+1:]); err != nil {
+		return err
+	}
+	*r = append(*r, EncodingRule{Metrics: re, Encoding: e})
+	return nil
+}
+
+// encodingFor returns the encoding of the first rule matching the name of
+// the metric, or DefaultEncoding.
+func (r EncodingRules) encodingFor(m model.Metric) Encoding {
+	name := string(m[model.MetricNameLabel])
+	for _, rule := range r {
+		if rule.Metrics.MatchString(name) {
+			return rule.Encoding
+		}
+	}
+	return DefaultEncoding
+}
+
 // Desc contains meta-data for a chunk. Pay special attention to the
 // documented requirements for calling its methods concurrently (WRT pinning and
 // locking). The doc comments spell out the requirements for each method, but
@@ -132,14 +220,14 @@ func NewDesc(c Chunk, firstTime model.Time) *Desc {
 	}
 }
 
-// Add adds a sample pair to the underlying chunk. For safe concurrent access,
-// The chunk must be pinned, and the caller must have locked the fingerprint of
-// the series.
-func (d *Desc) Add(s model.SamplePair) ([]Chunk, error) {
+// Add adds a sample pair of the series with the metric to the underlying
+// chunk, see AddForMetric. For safe concurrent access, The chunk must be
+// pinned, and the caller must have locked the fingerprint of the series.
+func (d *Desc) Add(m model.Metric, s model.SamplePair) ([]Chunk, error) {
 	if d.C == nil {
 		return nil, errAddedToEvictedChunk
 	}
-	return d.C.Add(s)
+	return AddForMetric(d.C, m, s)
 }
 
 // Pin increments the refCount by one. Upon increment from 0 to 1, this
@@ -321,8 +409,12 @@ type Iterator interface {
 }
 
 // RangeValues is a utility function that retrieves all values within the given
-// range from an Iterator.
-func RangeValues(it Iterator, in metric.Interval) ([]model.SamplePair, error) {
+// range from an Iterator. It returns the error of the context without decoding
+// the chunk once the context is done.
+func RangeValues(ctx context.Context, it Iterator, in metric.Interval) ([]model.SamplePair, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	result := []model.SamplePair{}
 	if !it.FindAtOrAfter(in.OldestInclusive) {
 		return result, it.Err()
@@ -336,17 +428,58 @@ func RangeValues(it Iterator, in metric.Interval) ([]model.SamplePair, error) {
 	return result, it.Err()
 }
 
-// addToOverflowChunk is a utility function that creates a new chunk as overflow
-// chunk, adds the provided sample to it, and returns a chunk slice containing
-// the provided old chunk followed by the new overflow chunk.
+// addToOverflowChunk is a utility function that creates a new chunk of the same
+// encoding as overflow chunk, adds the provided sample to it, and returns a
+// chunk slice containing the provided old chunk followed by the new overflow
+// chunk.
 func addToOverflowChunk(c Chunk, s model.SamplePair) ([]Chunk, error) {
-	overflowChunks, err := New().Add(s)
+	overflow, err := NewForEncoding(c.Encoding())
+	if err != nil {
+		return nil, err
+	}
+	overflowChunks, err := overflow.Add(s)
 	if err != nil {
 		return nil, err
 	}
 	return []Chunk{c, overflowChunks[0]}, nil
 }
 
+// AddForMetric adds a sample pair of the series with the metric to the chunk
+// like Chunk.Add. If the chunk overflows, the new chunks are re-encoded with
+// the encoding selected for the metric, so that changes of the encoding flag
+// or rules apply to the following chunks of a series.
+func AddForMetric(c Chunk, m model.Metric, s model.SamplePair) ([]Chunk, error) {
+	chunks, err := c.Add(s)
+	if err != nil || len(chunks) == 1 {
+		return chunks, err
+	}
+	e := MetricEncodings.encodingFor(m)
+	if c.Encoding() == e {
+		return chunks, nil
+	}
+
+	head, err := NewForEncoding(e)
+	if err != nil {
+		return nil, err
+	}
+	body := chunks[:1]
+	for _, overflow := range chunks[1:] {
+		it := overflow.NewIterator()
+		for it.Scan() {
+			cs, err := head.Add(it.Value())
+			if err != nil {
+				return nil, err
+			}
+			body = append(body, cs[:len(cs)-1]...)
+			head = cs[len(cs)-1]
+		}
+		if it.Err() != nil {
+			return nil, it.Err()
+		}
+	}
+	return append(body, head), nil
+}
+
 // transcodeAndAdd is a utility function that transcodes the dst chunk into the
 // provided src chunk (plus the necessary overflow chunks) and then adds the
 // provided sample. It returns the new chunks (transcoded plus overflow) with
@@ -388,18 +521,23 @@ func New() Chunk {
 	return chunk
 }
 
+// NewForMetric creates a new chunk according to the encoding selected for
+// the metric by the MetricEncodings, or the DefaultEncoding flag.
+func NewForMetric(m model.Metric) Chunk {
+	chunk, err := NewForEncoding(MetricEncodings.encodingFor(m))
+	if err != nil {
+		panic(err)
+	}
+	return chunk
+}
+
 // NewForEncoding allows configuring what chunk type you want
 func NewForEncoding(encoding Encoding) (Chunk, error) {
-	switch encoding {
-	case Delta:
-		return newDeltaEncodedChunk(d1, d0, true, ChunkLen), nil
-	case DoubleDelta:
-		return newDoubleDeltaEncodedChunk(d1, d0, true, ChunkLen), nil
-	case Varbit:
-		return newVarbitChunk(varbitZeroEncoding), nil
-	default:
+	desc, ok := encodings[encoding]
+	if !ok {
 		return nil, fmt.Errorf("unknown chunk encoding: %v", encoding)
 	}
+	return desc.newChunk(), nil
 }
 
 // indexAccessor allows accesses to samples by index.
@@ -21,11 +21,14 @@ import (
 	"testing"
 
 	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
+
+	"github.com/prometheus/prometheus/storage/metric"
 )
 
 func TestLen(t *testing.T) {
 	chunks := []Chunk{}
-	for _, encoding := range []Encoding{Delta, DoubleDelta, Varbit} {
+	for _, encoding := range []Encoding{Delta, DoubleDelta, Varbit, Float32} {
 		c, err := NewForEncoding(encoding)
 		if err != nil {
 			t.Fatal(err)
@@ -47,3 +50,126 @@ func TestLen(t *testing.T) {
 		}
 	}
 }
+
+func TestEncodingSet(t *testing.T) {
+	for _, c := range []struct {
+		in       string
+		expected Encoding
+	}{
+		{in: "0", expected: Delta},
+		{in: "double-delta", expected: DoubleDelta},
+		{in: "2", expected: Varbit},
+		{in: "float32", expected: Float32},
+	} {
+		var e Encoding
+		if err := e.Set(c.in); err != nil {
+			t.Fatalf("%s: unexpected error: %s", c.in, err)
+		}
+		if e != c.expected {
+			t.Errorf("%s: expected encoding %d, got %d", c.in, c.expected, e)
+		}
+	}
+
+	var e Encoding
+	if err := e.Set("9"); err == nil {
+		t.Error("expected an error for an unknown encoding")
+	}
+}
+
+func TestEncodingRules(t *testing.T) {
+	var rules EncodingRules
+	for _, s := range []string{"node_.*=float32", "node_load1=0", "up=varbit"} {
+		if err := rules.Set(s); err != nil {
+			t.Fatalf("%s: unexpected error: %s", s, err)
+		}
+	}
+	for _, s := range []string{"node_.*", "(=float32", "up=unknown"} {
+		if err := rules.Set(s); err == nil {
+			t.Errorf("%s: expected an error", s)
+		}
+	}
+	if s := rules.String(); s != "node_.*=float32,node_load1=delta,up=varbit" {
+		t.Errorf("unexpected string representation %q", s)
+	}
+
+	for name, expected := range map[model.LabelValue]Encoding{
+		// The first matching rule applies.
+		"node_load1":          Float32,
+		"up":                  Varbit,
+		"http_requests_total": DefaultEncoding,
+		"prefixed_node_load1": DefaultEncoding,
+	} {
+		if e := rules.encodingFor(model.Metric{model.MetricNameLabel: name}); e != expected {
+			t.Errorf("%s: expected encoding %d, got %d", name, expected, e)
+		}
+	}
+}
+
+func TestAddForMetricOverflow(t *testing.T) {
+	defer func(rules EncodingRules) { MetricEncodings = rules }(MetricEncodings)
+	MetricEncodings = nil
+	if err := MetricEncodings.Set("up=varbit"); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, expected := range map[model.LabelValue]Encoding{
+		"up":                  Varbit,
+		"http_requests_total": DefaultEncoding,
+	} {
+		m := model.Metric{model.MetricNameLabel: name}
+		c, err := NewForEncoding(Delta)
+		if err != nil {
+			t.Fatal(err)
+		}
+		chunks := []Chunk{c}
+		var n int
+		for ts := model.Time(0); len(chunks) < 3; ts++ {
+			cs, err := AddForMetric(chunks[len(chunks)-1], m, model.SamplePair{Timestamp: ts, Value: model.SampleValue(ts)})
+			if err != nil {
+				t.Fatal(err)
+			}
+			chunks = append(chunks[:len(chunks)-1], cs...)
+			n++
+		}
+
+		if e := chunks[0].Encoding(); e != Delta {
+			t.Errorf("%s: expected the full chunk to keep encoding %d, got %d", name, Delta, e)
+		}
+		var values int
+		for _, c := range chunks {
+			if c != chunks[0] && c.Encoding() != expected {
+				t.Errorf("%s: expected overflow chunk encoding %d, got %d", name, expected, c.Encoding())
+			}
+			values += c.Len()
+		}
+		if values != n {
+			t.Errorf("%s: expected %d samples, got %d", name, n, values)
+		}
+	}
+}
+
+func TestRangeValuesCanceled(t *testing.T) {
+	c := New()
+	for ts := model.Time(0); ts < 10; ts++ {
+		cs, err := c.Add(model.SamplePair{Timestamp: ts, Value: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		c = cs[0]
+	}
+	in := metric.Interval{OldestInclusive: 0, NewestInclusive: 9}
+
+	values, err := RangeValues(context.Background(), c.NewIterator(), in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != 10 {
+		t.Fatalf("want 10 values, got %d", len(values))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := RangeValues(ctx, c.NewIterator(), in); err != context.Canceled {
+		t.Fatalf("want error %q, got %v", context.Canceled, err)
+	}
+}
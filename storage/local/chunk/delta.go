@@ -41,6 +41,12 @@ const (
 	deltaHeaderBufLenOffset     = 19
 )
 
+func init() {
+	RegisterEncoding(Delta, "delta", func() Chunk {
+		return newDeltaEncodedChunk(d1, d0, true, ChunkLen)
+	})
+}
+
 // A deltaEncodedChunk adaptively stores sample timestamps and values with a
 // delta encoding of various types (int, float) and bit widths. However, once 8
 // bytes would be needed to encode a delta value, a fall-back to the absolute
@@ -46,6 +46,12 @@ const (
 	doubleDeltaHeaderBaseValueDeltaOffset = 29
 )
 
+func init() {
+	RegisterEncoding(DoubleDelta, "double-delta", func() Chunk {
+		return newDoubleDeltaEncodedChunk(d1, d0, true, ChunkLen)
+	})
+}
+
 // A doubleDeltaEncodedChunk adaptively stores sample timestamps and values with
 // a double-delta encoding of various types (int, float) and bit widths. A base
 // value and timestamp and a base delta for each is saved in the header. The
@@ -0,0 +1,270 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chunk
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/prometheus/common/model"
+)
+
+// The 11-byte header of a float32 chunk looks like:
+//
+// - time delta bytes: 1 byte
+// - base time:        8 bytes
+// - used buf bytes:   2 bytes
+const (
+	float32HeaderBytes = 11
+
+	float32HeaderTimeBytesOffset = 0
+	float32HeaderBaseTimeOffset  = 1
+	float32HeaderBufLenOffset    = 9
+)
+
+func init() {
+	RegisterEncoding(Float32, "float32", func() Chunk {
+		return newFloat32Chunk(d1, ChunkLen)
+	})
+}
+
+// A float32Chunk stores sample timestamps as deltas to the first timestamp,
+// with the same adaptive bit widths as the deltaEncodedChunk, and sample
+// values as float32. Values are rounded to the nearest float32, so that the
+// chunk is lossy for values needing more precision. In exchange, a float
+// sample takes at most 8 bytes even if its values are not float32 exactly.
+// It implements the chunk interface.
+type float32Chunk []byte
+
+// newFloat32Chunk returns a newly allocated float32Chunk.
+func newFloat32Chunk(tb deltaBytes, length int) *float32Chunk {
+	if tb < 1 {
+		panic("need at least 1 time delta byte")
+	}
+	if length < float32HeaderBytes+16 {
+		panic(fmt.Errorf(
+			"chunk length %d bytes is insufficient, need at least %d",
+			length, float32HeaderBytes+16,
+		))
+	}
+	c := make(float32Chunk, float32HeaderTimeBytesOffset+1, length)
+	c[float32HeaderTimeBytesOffset] = byte(tb)
+	return &c
+}
+
+// Add implements chunk.
+func (c float32Chunk) Add(s model.SamplePair) ([]Chunk, error) {
+	if c.Len() == 0 {
+		c = c[:float32HeaderBytes]
+		binary.LittleEndian.PutUint64(c[float32HeaderBaseTimeOffset:], uint64(s.Timestamp))
+	}
+
+	remainingBytes := cap(c) - len(c)
+	sampleSize := c.sampleSize()
+
+	// Do we generally have space for another sample in this chunk? If not,
+	// overflow into a new one.
+	if remainingBytes < sampleSize {
+		return addToOverflowChunk(&c, s)
+	}
+
+	dt := s.Timestamp - c.baseTime()
+	if dt < 0 {
+		return nil, fmt.Errorf("time delta is less than zero: %v", dt)
+	}
+
+	// If the new timestamp needs more bytes than the current ones, reencode
+	// the existing chunk data into new chunk(s).
+	tb := c.timeBytes()
+	if ntb := max(tb, bytesNeededForUnsignedTimestampDelta(dt)); tb < d8 && ntb != tb {
+		if len(c)*2 < cap(c) {
+			return transcodeAndAdd(newFloat32Chunk(ntb, cap(c)), &c, s)
+		}
+		// Chunk is already half full. Better create a new one and save the transcoding efforts.
+		return addToOverflowChunk(&c, s)
+	}
+
+	offset := len(c)
+	c = c[:offset+sampleSize]
+
+	switch tb {
+	case d1:
+		c[offset] = byte(dt)
+	case d2:
+		binary.LittleEndian.PutUint16(c[offset:], uint16(dt))
+	case d4:
+		binary.LittleEndian.PutUint32(c[offset:], uint32(dt))
+	case d8:
+		// Store the absolute value (no delta) in case of d8.
+		binary.LittleEndian.PutUint64(c[offset:], uint64(s.Timestamp))
+	default:
+		return nil, fmt.Errorf("invalid number of bytes for time delta: %d", tb)
+	}
+	binary.LittleEndian.PutUint32(c[offset+int(tb):], math.Float32bits(float32(s.Value)))
+
+	return []Chunk{&c}, nil
+}
+
+// Clone implements chunk.
+func (c float32Chunk) Clone() Chunk {
+	clone := make(float32Chunk, len(c), cap(c))
+	copy(clone, c)
+	return &clone
+}
+
+// FirstTime implements chunk.
+func (c float32Chunk) FirstTime() model.Time {
+	return c.baseTime()
+}
+
+// NewIterator implements chunk.
+func (c *float32Chunk) NewIterator() Iterator {
+	return newIndexAccessingChunkIterator(c.Len(), &float32IndexAccessor{
+		c:      *c,
+		baseT:  c.baseTime(),
+		tBytes: c.timeBytes(),
+	})
+}
+
+// Marshal implements chunk.
+func (c float32Chunk) Marshal(w io.Writer) error {
+	if len(c) > math.MaxUint16 {
+		panic("chunk buffer length would overflow a 16 bit uint.")
+	}
+	binary.LittleEndian.PutUint16(c[float32HeaderBufLenOffset:], uint16(len(c)))
+
+	n, err := w.Write(c[:cap(c)])
+	if err != nil {
+		return err
+	}
+	if n != cap(c) {
+		return fmt.Errorf("wanted to write %d bytes, wrote %d", cap(c), n)
+	}
+	return nil
+}
+
+// MarshalToBuf implements chunk.
+func (c float32Chunk) MarshalToBuf(buf []byte) error {
+	if len(c) > math.MaxUint16 {
+		panic("chunk buffer length would overflow a 16 bit uint")
+	}
+	binary.LittleEndian.PutUint16(c[float32HeaderBufLenOffset:], uint16(len(c)))
+
+	n := copy(buf, c)
+	if n != len(c) {
+		return fmt.Errorf("wanted to copy %d bytes to buffer, copied %d", len(c), n)
+	}
+	return nil
+}
+
+// Unmarshal implements chunk.
+func (c *float32Chunk) Unmarshal(r io.Reader) error {
+	*c = (*c)[:cap(*c)]
+	if _, err := io.ReadFull(r, *c); err != nil {
+		return err
+	}
+	return c.setLen()
+}
+
+// UnmarshalFromBuf implements chunk.
+func (c *float32Chunk) UnmarshalFromBuf(buf []byte) error {
+	*c = (*c)[:cap(*c)]
+	copy(*c, buf)
+	return c.setLen()
+}
+
+// setLen sets the length of the underlying slice and performs some sanity checks.
+func (c *float32Chunk) setLen() error {
+	l := binary.LittleEndian.Uint16((*c)[float32HeaderBufLenOffset:])
+	if int(l) > cap(*c) {
+		return fmt.Errorf("float32 chunk length exceeded during unmarshaling: %d", l)
+	}
+	if int(l) < float32HeaderBytes {
+		return fmt.Errorf("float32 chunk length less than header size: %d < %d", l, float32HeaderBytes)
+	}
+	switch c.timeBytes() {
+	case d1, d2, d4, d8:
+		// Pass.
+	default:
+		return fmt.Errorf("invalid number of time bytes in float32 chunk: %d", c.timeBytes())
+	}
+	*c = (*c)[:l]
+	return nil
+}
+
+// Encoding implements chunk.
+func (c float32Chunk) Encoding() Encoding { return Float32 }
+
+// Utilization implements chunk.
+func (c float32Chunk) Utilization() float64 {
+	return float64(len(c)) / float64(cap(c))
+}
+
+func (c float32Chunk) timeBytes() deltaBytes {
+	return deltaBytes(c[float32HeaderTimeBytesOffset])
+}
+
+func (c float32Chunk) baseTime() model.Time {
+	return model.Time(binary.LittleEndian.Uint64(c[float32HeaderBaseTimeOffset:]))
+}
+
+func (c float32Chunk) sampleSize() int {
+	return int(c.timeBytes()) + 4
+}
+
+// Len implements Chunk. Runs in constant time.
+func (c float32Chunk) Len() int {
+	if len(c) < float32HeaderBytes {
+		return 0
+	}
+	return (len(c) - float32HeaderBytes) / c.sampleSize()
+}
+
+// float32IndexAccessor implements indexAccessor.
+type float32IndexAccessor struct {
+	c       float32Chunk
+	baseT   model.Time
+	tBytes  deltaBytes
+	lastErr error
+}
+
+func (acc *float32IndexAccessor) err() error {
+	return acc.lastErr
+}
+
+func (acc *float32IndexAccessor) timestampAtIndex(idx int) model.Time {
+	offset := float32HeaderBytes + idx*(int(acc.tBytes)+4)
+
+	switch acc.tBytes {
+	case d1:
+		return acc.baseT + model.Time(uint8(acc.c[offset]))
+	case d2:
+		return acc.baseT + model.Time(binary.LittleEndian.Uint16(acc.c[offset:]))
+	case d4:
+		return acc.baseT + model.Time(binary.LittleEndian.Uint32(acc.c[offset:]))
+	case d8:
+		// Take absolute value for d8.
+		return model.Time(binary.LittleEndian.Uint64(acc.c[offset:]))
+	default:
+		acc.lastErr = fmt.Errorf("invalid number of bytes for time delta: %d", acc.tBytes)
+		return model.Earliest
+	}
+}
+
+func (acc *float32IndexAccessor) sampleValueAtIndex(idx int) model.SampleValue {
+	offset := float32HeaderBytes + idx*(int(acc.tBytes)+4) + int(acc.tBytes)
+	return model.SampleValue(math.Float32frombits(binary.LittleEndian.Uint32(acc.c[offset:])))
+}
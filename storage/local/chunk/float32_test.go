@@ -0,0 +1,101 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chunk
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/prometheus/common/model"
+)
+
+func TestFloat32Chunk(t *testing.T) {
+	var (
+		chunks  = []Chunk{newFloat32Chunk(d1, ChunkLen)}
+		samples []model.SamplePair
+		ts      model.Time
+	)
+	for i := 0; i < 1000; i++ {
+		// The time deltas grow beyond the width of the first chunk.
+		ts += model.Time(i)
+		s := model.SamplePair{Timestamp: ts, Value: model.SampleValue(float64(i) * 0.1)}
+		samples = append(samples, s)
+
+		cs, err := chunks[len(chunks)-1].Add(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		chunks = append(chunks[:len(chunks)-1], cs...)
+	}
+	if len(chunks) < 2 {
+		t.Fatalf("expected overflow chunks, got %d chunk", len(chunks))
+	}
+
+	var values []model.SamplePair
+	for _, c := range chunks {
+		if c.Encoding() != Float32 {
+			t.Fatalf("expected overflow chunks to keep the float32 encoding, got %d", c.Encoding())
+		}
+
+		// Chunks survive marshaling.
+		var buf bytes.Buffer
+		if err := c.Marshal(&buf); err != nil {
+			t.Fatal(err)
+		}
+		u, err := NewForEncoding(Float32)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := u.Unmarshal(&buf); err != nil {
+			t.Fatal(err)
+		}
+
+		it := u.NewIterator()
+		for it.Scan() {
+			values = append(values, it.Value())
+		}
+		if it.Err() != nil {
+			t.Fatal(it.Err())
+		}
+	}
+
+	if len(values) != len(samples) {
+		t.Fatalf("expected %d samples, got %d", len(samples), len(values))
+	}
+	for i, v := range values {
+		if v.Timestamp != samples[i].Timestamp {
+			t.Errorf("%d. expected timestamp %v, got %v", i, samples[i].Timestamp, v.Timestamp)
+		}
+		// Values are rounded to float32.
+		if expected := model.SampleValue(float32(samples[i].Value)); v.Value != expected {
+			t.Errorf("%d. expected value %v, got %v", i, expected, v.Value)
+		}
+	}
+}
+
+func TestUnmarshalingCorruptedFloat32ReturnsAnError(t *testing.T) {
+	c := newFloat32Chunk(d1, ChunkLen)
+	cs, err := c.Add(model.SamplePair{Timestamp: 1, Value: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, ChunkLen)
+	if err := cs[0].MarshalToBuf(buf); err != nil {
+		t.Fatal(err)
+	}
+	buf[float32HeaderTimeBytesOffset] = 3
+	if err := cs[0].UnmarshalFromBuf(buf); err == nil {
+		t.Error("expected an error for invalid time bytes")
+	}
+}
@@ -236,6 +236,12 @@ var varbitWorstCaseBitsPerSample = map[varbitValueEncoding]int{
 	varbitDirectEncoding:         27 + 64,
 }
 
+func init() {
+	RegisterEncoding(Varbit, "varbit", func() Chunk {
+		return newVarbitChunk(varbitZeroEncoding)
+	})
+}
+
 // varbitChunk implements the chunk interface.
 type varbitChunk []byte
 
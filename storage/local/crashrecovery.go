@@ -22,7 +22,6 @@ import (
 	"strings"
 	"sync/atomic"
 
-	"github.com/prometheus/common/log"
 	"github.com/prometheus/common/model"
 
 	"github.com/prometheus/prometheus/storage/local/chunk"
@@ -37,8 +36,8 @@ import (
 // queue as started by newPersistence).
 func (p *persistence) recoverFromCrash(fingerprintToSeries map[model.Fingerprint]*memorySeries) error {
 	// TODO(beorn): We need proper tests for the crash recovery.
-	log.Warn("Starting crash recovery. Prometheus is inoperational until complete.")
-	log.Warn("To avoid crash recovery in the future, shut down Prometheus with SIGTERM or a HTTP POST to /-/quit.")
+	logger.Warn("Starting crash recovery. Prometheus is inoperational until complete.")
+	logger.Warn("To avoid crash recovery in the future, shut down Prometheus with SIGTERM or a HTTP POST to /-/quit (requires -web.enable-lifecycle).")
 
 	fpsSeen := map[model.Fingerprint]struct{}{}
 	count := 0
@@ -52,7 +51,7 @@ func (p *persistence) recoverFromCrash(fingerprintToSeries map[model.Fingerprint
 	// The mappings to rebuild.
 	fpm := fpMappings{}
 
-	log.Info("Scanning files.")
+	logger.Info("Scanning files.")
 	for i := 0; i < 1<<(seriesDirNameLen*4); i++ {
 		dirname := filepath.Join(p.basePath, fmt.Sprintf(seriesDirNameFmt, i))
 		dir, err := os.Open(dirname)
@@ -74,15 +73,15 @@ func (p *persistence) recoverFromCrash(fingerprintToSeries map[model.Fingerprint
 				}
 				count++
 				if count%10000 == 0 {
-					log.Infof("%d files scanned.", count)
+					logger.Infof("%d files scanned.", count)
 				}
 			}
 		}
 		dir.Close()
 	}
-	log.Infof("File scan complete. %d series found.", len(fpsSeen))
+	logger.Infof("File scan complete. %d series found.", len(fpsSeen))
 
-	log.Info("Checking for series without series file.")
+	logger.Info("Checking for series without series file.")
 	for fp, s := range fingerprintToSeries {
 		if _, seen := fpsSeen[fp]; !seen {
 			// fp exists in fingerprintToSeries, but has no representation on disk.
@@ -98,7 +97,7 @@ func (p *persistence) recoverFromCrash(fingerprintToSeries map[model.Fingerprint
 					// to unindex it, just in case it's in the indexes.
 					p.unindexMetric(fp, s.metric)
 				}
-				log.Warnf("Lost series detected: fingerprint %v, metric %v.", fp, s.metric)
+				logger.Warnf("Lost series detected: fingerprint %v, metric %v.", fp, s.metric)
 				continue
 			}
 			// If we are here, the only chunks we have are the chunks in the checkpoint.
@@ -106,12 +105,12 @@ func (p *persistence) recoverFromCrash(fingerprintToSeries map[model.Fingerprint
 			if s.persistWatermark > 0 || s.chunkDescsOffset != 0 {
 				minLostChunks := s.persistWatermark + s.chunkDescsOffset
 				if minLostChunks <= 0 {
-					log.Warnf(
+					logger.Warnf(
 						"Possible loss of chunks for fingerprint %v, metric %v.",
 						fp, s.metric,
 					)
 				} else {
-					log.Warnf(
+					logger.Warnf(
 						"Lost at least %d chunks for fingerprint %v, metric %v.",
 						minLostChunks, fp, s.metric,
 					)
@@ -128,7 +127,7 @@ func (p *persistence) recoverFromCrash(fingerprintToSeries map[model.Fingerprint
 			fpsSeen[fp] = struct{}{} // Add so that fpsSeen is complete.
 		}
 	}
-	log.Info("Check for series without series file complete.")
+	logger.Info("Check for series without series file complete.")
 
 	if err := p.cleanUpArchiveIndexes(fingerprintToSeries, fpsSeen, fpm); err != nil {
 		return err
@@ -150,7 +149,7 @@ func (p *persistence) recoverFromCrash(fingerprintToSeries map[model.Fingerprint
 	}
 	p.dirtyMtx.Unlock()
 
-	log.Warn("Crash recovery complete.")
+	logger.Warn("Crash recovery complete.")
 	return nil
 }
 
@@ -203,7 +202,7 @@ func (p *persistence) sanitizeSeries(
 			); err == nil {
 				return
 			}
-			log.
+			logger.
 				With("file", filename).
 				With("error", err).
 				Error("Failed to move lost series file to orphaned directory.")
@@ -211,7 +210,7 @@ func (p *persistence) sanitizeSeries(
 		// If we are here, we are either purging an incorrectly named
 		// file, or quarantining has failed. So simply delete the file.
 		if err = os.Remove(filename); err != nil {
-			log.
+			logger.
 				With("file", filename).
 				With("error", err).
 				Error("Failed to delete lost series file.")
@@ -220,12 +219,12 @@ func (p *persistence) sanitizeSeries(
 
 	if len(fi.Name()) != fpLen-seriesDirNameLen+len(seriesFileSuffix) ||
 		!strings.HasSuffix(fi.Name(), seriesFileSuffix) {
-		log.Warnf("Unexpected series file name %s.", filename)
+		logger.Warnf("Unexpected series file name %s.", filename)
 		purge()
 		return fp, false
 	}
 	if fp, err = model.FingerprintFromString(filepath.Base(dirname) + fi.Name()[:fpLen-seriesDirNameLen]); err != nil {
-		log.Warnf("Error parsing file name %s: %s", filename, err)
+		logger.Warnf("Error parsing file name %s: %s", filename, err)
 		purge()
 		return fp, false
 	}
@@ -234,24 +233,24 @@ func (p *persistence) sanitizeSeries(
 	chunksInFile := int(fi.Size()) / chunkLenWithHeader
 	modTime := fi.ModTime()
 	if bytesToTrim != 0 {
-		log.Warnf(
+		logger.Warnf(
 			"Truncating file %s to exactly %d chunks, trimming %d extraneous bytes.",
 			filename, chunksInFile, bytesToTrim,
 		)
 		f, err := os.OpenFile(filename, os.O_WRONLY, 0640)
 		if err != nil {
-			log.Errorf("Could not open file %s: %s", filename, err)
+			logger.Errorf("Could not open file %s: %s", filename, err)
 			purge()
 			return fp, false
 		}
 		if err := f.Truncate(fi.Size() - bytesToTrim); err != nil {
-			log.Errorf("Failed to truncate file %s: %s", filename, err)
+			logger.Errorf("Failed to truncate file %s: %s", filename, err)
 			purge()
 			return fp, false
 		}
 	}
 	if chunksInFile == 0 {
-		log.Warnf("No chunks left in file %s.", filename)
+		logger.Warnf("No chunks left in file %s.", filename)
 		purge()
 		return fp, false
 	}
@@ -280,14 +279,14 @@ func (p *persistence) sanitizeSeries(
 			// based on the loaded chunkDescs.
 			cds, err := p.loadChunkDescs(fp, 0)
 			if err != nil {
-				log.Errorf(
+				logger.Errorf(
 					"Failed to load chunk descriptors for metric %v, fingerprint %v: %s",
 					s.metric, fp, err,
 				)
 				purge()
 				return fp, false
 			}
-			log.Warnf(
+			logger.Warnf(
 				"Treating recovered metric %v, fingerprint %v, as freshly unarchived, with %d chunks in series file.",
 				s.metric, fp, len(cds),
 			)
@@ -296,7 +295,7 @@ func (p *persistence) sanitizeSeries(
 			s.savedFirstTime = cds[0].FirstTime()
 			s.lastTime, err = cds[len(cds)-1].LastTime()
 			if err != nil {
-				log.Errorf(
+				logger.Errorf(
 					"Failed to determine time of the last sample for metric %v, fingerprint %v: %s",
 					s.metric, fp, err,
 				)
@@ -322,7 +321,7 @@ func (p *persistence) sanitizeSeries(
 		chunk.NumMemDescs.Sub(float64(s.persistWatermark))
 		cds, err := p.loadChunkDescs(fp, 0)
 		if err != nil {
-			log.Errorf(
+			logger.Errorf(
 				"Failed to load chunk descriptors for metric %v, fingerprint %v: %s",
 				s.metric, fp, err,
 			)
@@ -336,7 +335,7 @@ func (p *persistence) sanitizeSeries(
 
 		lastTime, err := cds[len(cds)-1].LastTime()
 		if err != nil {
-			log.Errorf(
+			logger.Errorf(
 				"Failed to determine time of the last sample for metric %v, fingerprint %v: %s",
 				s.metric, fp, err,
 			)
@@ -351,7 +350,7 @@ func (p *persistence) sanitizeSeries(
 			}
 		}
 		if keepIdx == -1 {
-			log.Warnf(
+			logger.Warnf(
 				"Recovered metric %v, fingerprint %v: all %d chunks recovered from series file.",
 				s.metric, fp, chunksInFile,
 			)
@@ -363,7 +362,7 @@ func (p *persistence) sanitizeSeries(
 			s.evictChunkDescs(len(cds) - 1)
 			return fp, true
 		}
-		log.Warnf(
+		logger.Warnf(
 			"Recovered metric %v, fingerprint %v: recovered %d chunks from series file, recovered %d chunks from checkpoint.",
 			s.metric, fp, chunksInFile, len(s.chunkDescs)-keepIdx,
 		)
@@ -383,7 +382,7 @@ func (p *persistence) sanitizeSeries(
 	// This series is supposed to be archived.
 	metric, err := p.archivedMetric(fp)
 	if err != nil {
-		log.Errorf(
+		logger.Errorf(
 			"Fingerprint %v assumed archived but couldn't be looked up in archived index: %s",
 			fp, err,
 		)
@@ -391,7 +390,7 @@ func (p *persistence) sanitizeSeries(
 		return fp, false
 	}
 	if metric == nil {
-		log.Warnf(
+		logger.Warnf(
 			"Fingerprint %v assumed archived but couldn't be found in archived index.",
 			fp,
 		)
@@ -408,14 +407,14 @@ func (p *persistence) cleanUpArchiveIndexes(
 	fpsSeen map[model.Fingerprint]struct{},
 	fpm fpMappings,
 ) error {
-	log.Info("Cleaning up archive indexes.")
+	logger.Info("Cleaning up archive indexes.")
 	var fp codable.Fingerprint
 	var m codable.Metric
 	count := 0
 	if err := p.archivedFingerprintToMetrics.ForEach(func(kv index.KeyValueAccessor) error {
 		count++
 		if count%10000 == 0 {
-			log.Infof("%d archived metrics checked.", count)
+			logger.Infof("%d archived metrics checked.", count)
 		}
 		if err := kv.Key(&fp); err != nil {
 			return err
@@ -427,10 +426,10 @@ func (p *persistence) cleanUpArchiveIndexes(
 		}
 		if !fpSeen || inMemory {
 			if inMemory {
-				log.Warnf("Archive clean-up: Fingerprint %v is not archived. Purging from archive indexes.", model.Fingerprint(fp))
+				logger.Warnf("Archive clean-up: Fingerprint %v is not archived. Purging from archive indexes.", model.Fingerprint(fp))
 			}
 			if !fpSeen {
-				log.Warnf("Archive clean-up: Fingerprint %v is unknown. Purging from archive indexes.", model.Fingerprint(fp))
+				logger.Warnf("Archive clean-up: Fingerprint %v is unknown. Purging from archive indexes.", model.Fingerprint(fp))
 			}
 			// It's fine if the fp is not in the archive indexes.
 			if _, err := p.archivedFingerprintToMetrics.Delete(fp); err != nil {
@@ -453,7 +452,7 @@ func (p *persistence) cleanUpArchiveIndexes(
 		if has {
 			return nil // All good.
 		}
-		log.Warnf("Archive clean-up: Fingerprint %v is not in time-range index. Unarchiving it for recovery.")
+		logger.Warnf("Archive clean-up: Fingerprint %v is not in time-range index. Unarchiving it for recovery.")
 		// Again, it's fine if fp is not in the archive index.
 		if _, err := p.archivedFingerprintToMetrics.Delete(fp); err != nil {
 			return err
@@ -477,7 +476,7 @@ func (p *persistence) cleanUpArchiveIndexes(
 	if err := p.archivedFingerprintToTimeRange.ForEach(func(kv index.KeyValueAccessor) error {
 		count++
 		if count%10000 == 0 {
-			log.Infof("%d archived time ranges checked.", count)
+			logger.Infof("%d archived time ranges checked.", count)
 		}
 		if err := kv.Key(&fp); err != nil {
 			return err
@@ -489,19 +488,19 @@ func (p *persistence) cleanUpArchiveIndexes(
 		if has {
 			return nil // All good.
 		}
-		log.Warnf("Archive clean-up: Purging unknown fingerprint %v in time-range index.", fp)
+		logger.Warnf("Archive clean-up: Purging unknown fingerprint %v in time-range index.", fp)
 		deleted, err := p.archivedFingerprintToTimeRange.Delete(fp)
 		if err != nil {
 			return err
 		}
 		if !deleted {
-			log.Errorf("Fingerprint %v to be deleted from archivedFingerprintToTimeRange not found. This should never happen.", fp)
+			logger.Errorf("Fingerprint %v to be deleted from archivedFingerprintToTimeRange not found. This should never happen.", fp)
 		}
 		return nil
 	}); err != nil {
 		return err
 	}
-	log.Info("Clean-up of archive indexes complete.")
+	logger.Info("Clean-up of archive indexes complete.")
 	return nil
 }
 
@@ -509,16 +508,16 @@ func (p *persistence) rebuildLabelIndexes(
 	fpToSeries map[model.Fingerprint]*memorySeries,
 ) error {
 	count := 0
-	log.Info("Rebuilding label indexes.")
-	log.Info("Indexing metrics in memory.")
+	logger.Info("Rebuilding label indexes.")
+	logger.Info("Indexing metrics in memory.")
 	for fp, s := range fpToSeries {
 		p.indexMetric(fp, s.metric)
 		count++
 		if count%10000 == 0 {
-			log.Infof("%d metrics queued for indexing.", count)
+			logger.Infof("%d metrics queued for indexing.", count)
 		}
 	}
-	log.Info("Indexing archived metrics.")
+	logger.Info("Indexing archived metrics.")
 	var fp codable.Fingerprint
 	var m codable.Metric
 	if err := p.archivedFingerprintToMetrics.ForEach(func(kv index.KeyValueAccessor) error {
@@ -531,20 +530,20 @@ func (p *persistence) rebuildLabelIndexes(
 		p.indexMetric(model.Fingerprint(fp), model.Metric(m))
 		count++
 		if count%10000 == 0 {
-			log.Infof("%d metrics queued for indexing.", count)
+			logger.Infof("%d metrics queued for indexing.", count)
 		}
 		return nil
 	}); err != nil {
 		return err
 	}
-	log.Info("All requests for rebuilding the label indexes queued. (Actual processing may lag behind.)")
+	logger.Info("All requests for rebuilding the label indexes queued. (Actual processing may lag behind.)")
 	return nil
 }
 
 // maybeAddMapping adds a fingerprint mapping to fpm if the FastFingerprint of m is different from fp.
 func maybeAddMapping(fp model.Fingerprint, m model.Metric, fpm fpMappings) {
 	if rawFP := m.FastFingerprint(); rawFP != fp {
-		log.Warnf(
+		logger.Warnf(
 			"Metric %v with fingerprint %v is mapped from raw fingerprint %v.",
 			m, fp, rawFP,
 		)
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/prometheus/common/model"
@@ -35,6 +36,20 @@ const (
 	headsMagicString         = "PrometheusHeads"
 )
 
+// CheckpointSize returns the size in bytes of the checkpoint of the series held
+// in memory by a storage with the given persistence path. It is 0 if no
+// checkpoint has been written yet.
+func CheckpointSize(basePath string) (int64, error) {
+	fi, err := os.Stat(filepath.Join(basePath, headsFileName))
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return fi.Size(), nil
+}
+
 // headsScanner is a scanner to read time series with their heads from a
 // heads.db file. It follows a similar semantics as the bufio.Scanner.
 // It is not safe to use a headsScanner concurrently.
@@ -165,6 +165,22 @@ func (i *LabelNameLabelValuesIndex) LookupSet(l model.LabelName) (values map[mod
 	return
 }
 
+// LabelNames returns all label names stored in the index.
+//
+// This method is goroutine-safe.
+func (i *LabelNameLabelValuesIndex) LabelNames() (model.LabelNames, error) {
+	var names model.LabelNames
+	err := i.ForEach(func(kv KeyValueAccessor) error {
+		var ln codable.LabelName
+		if err := kv.Key(&ln); err != nil {
+			return err
+		}
+		names = append(names, model.LabelName(ln))
+		return nil
+	})
+	return names, err
+}
+
 // NewLabelNameLabelValuesIndex returns a LevelDB-backed
 // LabelNameLabelValuesIndex ready to use.
 func NewLabelNameLabelValuesIndex(basePath string) (*LabelNameLabelValuesIndex, error) {
@@ -22,6 +22,8 @@ const (
 	// Op-types for seriesOps.
 	create             = "create"
 	archive            = "archive"
+	idleArchive        = "archive_idle"
+	blockImport        = "import_from_block"
 	unarchive          = "unarchive"
 	memoryPurge        = "purge_from_memory"
 	archivePurge       = "purge_from_archive"
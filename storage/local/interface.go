@@ -51,8 +51,8 @@ type Storage interface {
 	// operations, stops all maintenance loops,and frees all resources.
 	Stop() error
 	// WaitForIndexing returns once all samples in the storage are
-	// indexed. Indexing is needed for FingerprintsForLabelMatchers and
-	// LabelValuesForLabelName and may lag behind.
+	// indexed. Indexing is needed for FingerprintsForLabelMatchers,
+	// LabelValuesForLabelName, and LabelNames and may lag behind.
 	WaitForIndexing()
 }
 
@@ -78,6 +78,11 @@ type Querier interface {
 	// have no samples in the specified interval from the returned map. In
 	// doubt, specify model.Earliest for from and model.Latest for through.
 	MetricsForLabelMatchers(ctx context.Context, from, through model.Time, matcherSets ...metric.LabelMatchers) ([]metric.Metric, error)
+	// ForEachMetricForLabelMatchers calls f for each metric that
+	// MetricsForLabelMatchers would return, until f returns false. The
+	// metrics are not collected, so that callers can process them one by
+	// one and stop early.
+	ForEachMetricForLabelMatchers(ctx context.Context, from, through model.Time, f func(metric.Metric) bool, matcherSets ...metric.LabelMatchers) error
 	// LastSampleForLabelMatchers returns the last samples that have been
 	// ingested for the time series matching the given set of label matchers.
 	// The label matching behavior is the same as in MetricsForLabelMatchers.
@@ -85,6 +90,17 @@ type Querier interface {
 	LastSampleForLabelMatchers(ctx context.Context, cutoff model.Time, matcherSets ...metric.LabelMatchers) (model.Vector, error)
 	// Get all of the label values that are associated with a given label name.
 	LabelValuesForLabelName(context.Context, model.LabelName) (model.LabelValues, error)
+	// Get all of the label names of all stored series.
+	LabelNames(context.Context) (model.LabelNames, error)
+	// LabelValuesForLabelMatchers returns the values of the given label name
+	// of the series matching the given sets of label matchers. The label
+	// matching behavior is the same as in MetricsForLabelMatchers, except
+	// that the times from and through are not hints but strict bounds.
+	LabelValuesForLabelMatchers(ctx context.Context, from, through model.Time, labelName model.LabelName, matcherSets ...metric.LabelMatchers) (model.LabelValues, error)
+	// LabelNamesForLabelMatchers returns the label names of the series
+	// matching the given sets of label matchers, with the same label
+	// matching behavior as LabelValuesForLabelMatchers.
+	LabelNamesForLabelMatchers(ctx context.Context, from, through model.Time, matcherSets ...metric.LabelMatchers) (model.LabelNames, error)
 }
 
 // SeriesIterator enables efficient access of sample values in a series. Its
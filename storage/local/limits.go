@@ -0,0 +1,89 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"fmt"
+	"sync"
+)
+
+// QueryLimitError is returned by queriers when the queries run on them touch
+// more series or chunks than allowed per query.
+type QueryLimitError struct {
+	// What exceeded the limit, "series" or "chunks".
+	Resource string
+	Limit    int
+}
+
+func (e QueryLimitError) Error() string {
+	return fmt.Sprintf("query touched more than the maximum of %d %s", e.Limit, e.Resource)
+}
+
+// queryTracker counts the series and chunks touched by the queries of a
+// querier. A nil queryTracker does not enforce any limits.
+type queryTracker struct {
+	maxSeries, maxChunks int
+
+	mtx            sync.Mutex
+	series, chunks int
+}
+
+// newQueryTracker returns a queryTracker enforcing the given limits, or nil if
+// there are none.
+func newQueryTracker(maxSeries, maxChunks int) *queryTracker {
+	if maxSeries <= 0 && maxChunks <= 0 {
+		return nil
+	}
+	return &queryTracker{maxSeries: maxSeries, maxChunks: maxChunks}
+}
+
+func (t *queryTracker) addSeries(n int) error {
+	if t == nil {
+		return nil
+	}
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	t.series += n
+	if t.maxSeries > 0 && t.series > t.maxSeries {
+		return QueryLimitError{Resource: "series", Limit: t.maxSeries}
+	}
+	return nil
+}
+
+func (t *queryTracker) addChunks(n int) error {
+	if t == nil {
+		return nil
+	}
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	t.chunks += n
+	if t.maxChunks > 0 && t.chunks > t.maxChunks {
+		return QueryLimitError{Resource: "chunks", Limit: t.maxChunks}
+	}
+	return nil
+}
+
+// pinnedChunks returns the number of chunks pinned by the iterator. Iterators
+// over the last sample of a series do not pin any.
+func pinnedChunks(it SeriesIterator) int {
+	if bit, ok := it.(*boundedIterator); ok {
+		it = bit.it
+	}
+	if mit, ok := it.(*memorySeriesIterator); ok {
+		return len(mit.pinnedChunkDescs)
+	}
+	return 0
+}
@@ -0,0 +1,101 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
+
+	"github.com/prometheus/prometheus/storage/metric"
+)
+
+func TestQueryLimits(t *testing.T) {
+	s, closer := NewTestStorage(t, 1)
+	defer closer.Close()
+
+	for i := 0; i < 10000; i++ {
+		for _, instance := range []model.LabelValue{"a", "b", "c"} {
+			s.Append(&model.Sample{
+				Metric:    model.Metric{model.MetricNameLabel: "test", "instance": instance},
+				Timestamp: model.Time(i * 1000),
+				Value:     model.SampleValue(i),
+			})
+		}
+	}
+	s.WaitForIndexing()
+
+	all := mustNewLabelMatcher(metric.Equal, model.MetricNameLabel, "test")
+	one := mustNewLabelMatcher(metric.Equal, "instance", "a")
+
+	// The storage itself is not limited.
+	s.maxSeriesPerQuery = 2
+	s.maxChunksPerQuery = 1
+	its, err := s.QueryRange(context.Background(), model.Earliest, model.Latest, all)
+	if err != nil {
+		t.Fatal(err)
+	}
+	closeIterators(its)
+
+	s.maxChunksPerQuery = 0
+	q, err := s.Querier()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := q.QueryRange(context.Background(), model.Earliest, model.Latest, all); err != (QueryLimitError{Resource: "series", Limit: 2}) {
+		t.Errorf("expected series limit error, got %v", err)
+	}
+
+	// All queries of a querier count towards the limit.
+	q, err = s.Querier()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		its, err := q.QueryInstant(context.Background(), model.Time(9999000), 5*time.Minute, one)
+		closeIterators(its)
+		if i < 2 && err != nil {
+			t.Fatalf("unexpected error in query %d: %s", i, err)
+		}
+		if i == 2 && err == nil {
+			t.Error("expected series limit error in the third query")
+		}
+	}
+
+	s.maxSeriesPerQuery = 0
+	s.maxChunksPerQuery = 5
+	q, err = s.Querier()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Instant queries for the last sample do not touch any chunks.
+	its, err = q.QueryInstant(context.Background(), model.Time(9999000), 5*time.Minute, all)
+	if err != nil {
+		t.Fatal(err)
+	}
+	closeIterators(its)
+	if _, err := q.QueryRange(context.Background(), model.Earliest, model.Latest, one); err != (QueryLimitError{Resource: "chunks", Limit: 5}) {
+		t.Errorf("expected chunks limit error, got %v", err)
+	}
+}
+
+func mustNewLabelMatcher(mt metric.MatchType, name model.LabelName, val model.LabelValue) *metric.LabelMatcher {
+	m, err := metric.NewLabelMatcher(mt, name, val)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
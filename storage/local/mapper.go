@@ -21,7 +21,6 @@ import (
 	"sync/atomic"
 
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/common/log"
 
 	"github.com/prometheus/common/model"
 )
@@ -163,7 +162,7 @@ func (m *fpMapper) maybeAddMapping(
 		// A new mapping has to be created.
 		mappedFP = m.nextMappedFP()
 		mappedFPs[ms] = mappedFP
-		log.Infof(
+		logger.Infof(
 			"Collision detected for fingerprint %v, metric %v, mapping to new fingerprint %v.",
 			fp, collidingMetric, mappedFP,
 		)
@@ -176,7 +175,7 @@ func (m *fpMapper) maybeAddMapping(
 	m.mappings[fp] = mappedFPs
 	m.mappingsCounter.Inc()
 	m.mtx.Unlock()
-	log.Infof(
+	logger.Infof(
 		"Collision detected for fingerprint %v, metric %v, mapping to new fingerprint %v.",
 		fp, collidingMetric, mappedFP,
 	)
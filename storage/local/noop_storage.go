@@ -79,11 +79,36 @@ func (s *NoopQuerier) MetricsForLabelMatchers(
 	return nil, nil
 }
 
+// ForEachMetricForLabelMatchers implements Querier.
+func (s *NoopQuerier) ForEachMetricForLabelMatchers(
+	ctx context.Context,
+	from, through model.Time,
+	f func(metric.Metric) bool,
+	matcherSets ...metric.LabelMatchers,
+) error {
+	return nil
+}
+
 // LabelValuesForLabelName implements Querier.
 func (s *NoopQuerier) LabelValuesForLabelName(ctx context.Context, labelName model.LabelName) (model.LabelValues, error) {
 	return nil, nil
 }
 
+// LabelNames implements Querier.
+func (s *NoopQuerier) LabelNames(ctx context.Context) (model.LabelNames, error) {
+	return nil, nil
+}
+
+// LabelValuesForLabelMatchers implements Querier.
+func (s *NoopQuerier) LabelValuesForLabelMatchers(ctx context.Context, from, through model.Time, labelName model.LabelName, matcherSets ...metric.LabelMatchers) (model.LabelValues, error) {
+	return nil, nil
+}
+
+// LabelNamesForLabelMatchers implements Querier.
+func (s *NoopQuerier) LabelNamesForLabelMatchers(ctx context.Context, from, through model.Time, matcherSets ...metric.LabelMatchers) (model.LabelNames, error) {
+	return nil, nil
+}
+
 // DropMetricsForLabelMatchers implements Storage.
 func (s *NoopStorage) DropMetricsForLabelMatchers(ctx context.Context, matchers ...*metric.LabelMatcher) (int, error) {
 	return 0, nil
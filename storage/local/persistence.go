@@ -30,7 +30,6 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/common/log"
 	"github.com/prometheus/common/model"
 
 	"github.com/prometheus/prometheus/storage/local/chunk"
@@ -57,6 +56,12 @@ const (
 
 	dirtyFileName = "DIRTY"
 
+	// Corrupted files and directories are moved into this directory if
+	// the storage is allowed to start with corrupted data.
+	corruptedDirName = "corrupted"
+	// Series files which cannot be recovered are moved into this directory.
+	orphanedDirName = "orphaned"
+
 	fileBufSize = 1 << 16 // 64kiB.
 
 	chunkHeaderLen             = 17
@@ -129,6 +134,7 @@ type persistence struct {
 	dirty          bool           // true if persistence was started in dirty state.
 	becameDirty    bool           // true if an inconsistency came up during runtime.
 	pedanticChecks bool           // true if crash recovery should check each series.
+	allowCorrupted bool           // true if corrupted data is quarantined on startup.
 	dirtyFileName  string         // The file used for locking and to mark dirty state.
 	fLock          flock.Releaser // The file lock to protect against concurrent usage.
 
@@ -142,7 +148,7 @@ type persistence struct {
 // newPersistence returns a newly allocated persistence backed by local disk storage, ready to use.
 func newPersistence(
 	basePath string,
-	dirty, pedanticChecks bool,
+	dirty, pedanticChecks, allowCorrupted bool,
 	shouldSync syncStrategy,
 	minShrinkRatio float64,
 ) (*persistence, error) {
@@ -197,7 +203,7 @@ func newPersistence(
 
 	fLock, dirtyfileExisted, err := flock.New(dirtyPath)
 	if err != nil {
-		log.Errorf("Could not lock %s, Prometheus already running?", dirtyPath)
+		logger.Errorf("Could not lock %s, Prometheus already running?", dirtyPath)
 		return nil, err
 	}
 	if dirtyfileExisted {
@@ -205,12 +211,22 @@ func newPersistence(
 	}
 
 	archivedFingerprintToMetrics, err := index.NewFingerprintMetricIndex(basePath)
+	if err != nil && allowCorrupted {
+		logger.
+			With("dir", filepath.Join(basePath, index.FingerprintToMetricDir)).
+			With("error", err).
+			Error("Could not open the fingerprint-to-metric index for archived series. Quarantining it and starting with an empty index, all archived time series are lost.")
+		if err = quarantineCorrupted(basePath, index.FingerprintToMetricDir); err == nil {
+			dirty = true
+			archivedFingerprintToMetrics, err = index.NewFingerprintMetricIndex(basePath)
+		}
+	}
 	if err != nil {
 		// At this point, we could simply blow away the archived
 		// fingerprint-to-metric index. However, then we would lose
 		// _all_ archived metrics. So better give the user an
 		// opportunity to repair the LevelDB with a 3rd party tool.
-		log.Errorf("Could not open the fingerprint-to-metric index for archived series. Please try a 3rd party tool to repair LevelDB in directory %q. If unsuccessful or undesired, delete the whole directory and restart Prometheus for crash recovery. You will lose all archived time series.", filepath.Join(basePath, index.FingerprintToMetricDir))
+		logger.Errorf("Could not open the fingerprint-to-metric index for archived series. Please try a 3rd party tool to repair LevelDB in directory %q. If unsuccessful or undesired, delete the whole directory and restart Prometheus for crash recovery. You will lose all archived time series.", filepath.Join(basePath, index.FingerprintToMetricDir))
 		return nil, err
 	}
 	archivedFingerprintToTimeRange, err := index.NewFingerprintTimeRangeIndex(basePath)
@@ -323,6 +339,7 @@ func newPersistence(
 		}),
 		dirty:          dirty,
 		pedanticChecks: pedanticChecks,
+		allowCorrupted: allowCorrupted,
 		dirtyFileName:  dirtyPath,
 		fLock:          fLock,
 		shouldSync:     shouldSync,
@@ -343,10 +360,31 @@ func newPersistence(
 		}
 	}
 	labelPairToFingerprints, err := index.NewLabelPairFingerprintIndex(basePath)
+	if err != nil && allowCorrupted {
+		// The label indexes are rebuilt by the crash recovery.
+		logger.
+			With("dir", filepath.Join(basePath, index.LabelPairToFingerprintsDir)).
+			With("error", err).
+			Error("Could not open the label-pair-to-fingerprints index. Quarantining it and rebuilding the label indexes.")
+		if err = quarantineCorrupted(basePath, index.LabelPairToFingerprintsDir); err == nil {
+			p.dirty = true
+			labelPairToFingerprints, err = index.NewLabelPairFingerprintIndex(basePath)
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
 	labelNameToLabelValues, err := index.NewLabelNameLabelValuesIndex(basePath)
+	if err != nil && allowCorrupted {
+		logger.
+			With("dir", filepath.Join(basePath, index.LabelNameToLabelValuesDir)).
+			With("error", err).
+			Error("Could not open the label-name-to-label-values index. Quarantining it and rebuilding the label indexes.")
+		if err = quarantineCorrupted(basePath, index.LabelNameToLabelValuesDir); err == nil {
+			p.dirty = true
+			labelNameToLabelValues, err = index.NewLabelNameLabelValuesIndex(basePath)
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -412,7 +450,7 @@ func (p *persistence) setDirty(err error) {
 	}
 	p.dirty = true
 	p.becameDirty = true
-	log.With("error", err).Error("The storage is now inconsistent. Restart Prometheus ASAP to initiate recovery.")
+	logger.With("error", err).Error("The storage is now inconsistent. Restart Prometheus ASAP to initiate recovery.")
 }
 
 // fingerprintsForLabelPair returns the fingerprints for the given label
@@ -441,6 +479,18 @@ func (p *persistence) labelValuesForLabelName(ln model.LabelName) (model.LabelVa
 	return lvs, nil
 }
 
+// labelNames returns all label names. This method is goroutine-safe but take
+// into account that metrics queued for indexing with IndexMetric might not have
+// made it into the index yet. (Same applies correspondingly to UnindexMetric.)
+func (p *persistence) labelNames() (model.LabelNames, error) {
+	lns, err := p.labelNameToLabelValues.LabelNames()
+	if err != nil {
+		p.setDirty(fmt.Errorf("error in method labelNames: %s", err))
+		return nil, err
+	}
+	return lns, nil
+}
+
 // persistChunks persists a number of consecutive chunks of a series. It is the
 // caller's responsibility to not modify the chunks concurrently and to not
 // persist or drop anything for the same fingerprint concurrently. It returns
@@ -630,7 +680,7 @@ func (p *persistence) loadChunkDescs(fp model.Fingerprint, offsetFromEnd int) ([
 func (p *persistence) checkpointSeriesMapAndHeads(
 	ctx context.Context, fingerprintToSeries *seriesMap, fpLocker *fingerprintLocker,
 ) (err error) {
-	log.Info("Checkpointing in-memory metrics and chunks...")
+	logger.Info("Checkpointing in-memory metrics and chunks...")
 	p.checkpointing.Set(1)
 	defer p.checkpointing.Set(0)
 	begin := time.Now()
@@ -662,7 +712,7 @@ func (p *persistence) checkpointSeriesMapAndHeads(
 		duration := time.Since(begin)
 		p.checkpointDuration.Observe(duration.Seconds())
 		p.checkpointLastDuration.Set(duration.Seconds())
-		log.Infof("Done checkpointing in-memory metrics and chunks in %v.", duration)
+		logger.Infof("Done checkpointing in-memory metrics and chunks in %v.", duration)
 	}()
 
 	w := bufio.NewWriterSize(f, fileBufSize)
@@ -843,7 +893,7 @@ func (p *persistence) loadSeriesMapAndHeads() (sm *seriesMap, chunksToPersist in
 
 	defer func() {
 		if p.dirty {
-			log.Warn("Persistence layer appears dirty.")
+			logger.Warn("Persistence layer appears dirty.")
 			p.startedDirty.Set(1)
 			err = p.recoverFromCrash(fingerprintToSeries)
 			if err != nil {
@@ -864,10 +914,21 @@ func (p *persistence) loadSeriesMapAndHeads() (sm *seriesMap, chunksToPersist in
 	}
 	if hs.err != nil {
 		p.dirty = true
-		log.
+		logger.
 			With("file", p.headsFileName()).
 			With("error", hs.err).
 			Error("Error reading heads file.")
+		if p.allowCorrupted {
+			// Keep the heads file for inspection. The crash recovery
+			// restores the series from the series files.
+			hs.close()
+			if err := quarantineCorrupted(p.basePath, filepath.Base(p.headsFileName())); err != nil {
+				logger.
+					With("file", p.headsFileName()).
+					With("error", err).
+					Error("Could not quarantine heads file.")
+			}
+		}
 		return sm, 0, hs.err
 	}
 	return sm, hs.chunksToPersistTotal, nil
@@ -1087,13 +1148,80 @@ func (p *persistence) deleteSeriesFile(fp model.Fingerprint) (int, error) {
 	return numChunks, nil
 }
 
+// importSeriesFile installs the given content as the series file of fp. The
+// content is written to a temporary file and synced before it is atomically
+// renamed, so that a crash never leaves a partial series file behind. It fails
+// if the series file exists already. The caller must have locked the
+// fingerprint.
+func (p *persistence) importSeriesFile(fp model.Fingerprint, content []byte) (err error) {
+	fname := p.fileNameForFingerprint(fp)
+	if _, err := os.Stat(fname); err == nil {
+		return fmt.Errorf("series file %s exists already", fname)
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	dir := p.dirNameForFingerprint(fp)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	tmp := p.tempFileNameForFingerprint(fp)
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0640)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			os.Remove(tmp)
+		}
+	}()
+	if _, err := f.Write(content); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, fname); err != nil {
+		return err
+	}
+
+	// Sync the directory so that the rename is durable, too.
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
+
+// quarantineCorrupted moves the named file or directory of the storage in the
+// given base path into a new sub-directory of the corrupted directory, so that
+// the storage can start without it.
+func quarantineCorrupted(basePath, name string) error {
+	dir := filepath.Join(basePath, corruptedDirName, strconv.FormatInt(time.Now().Unix(), 10))
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	oldName, newName := filepath.Join(basePath, name), filepath.Join(dir, name)
+	if err := os.Rename(oldName, newName); err != nil {
+		return err
+	}
+	logger.With("from", oldName).With("to", newName).Warn("Quarantined corrupted storage data.")
+	return nil
+}
+
 // quarantineSeriesFile moves a series file to the orphaned directory. It also
 // writes a hint file with the provided quarantine reason and, if series is
 // non-nil, the string representation of the metric.
 func (p *persistence) quarantineSeriesFile(fp model.Fingerprint, quarantineReason error, metric model.Metric) error {
 	var (
 		oldName     = p.fileNameForFingerprint(fp)
-		orphanedDir = filepath.Join(p.basePath, "orphaned", filepath.Base(filepath.Dir(oldName)))
+		orphanedDir = filepath.Join(p.basePath, orphanedDirName, filepath.Base(filepath.Dir(oldName)))
 		newName     = filepath.Join(orphanedDir, filepath.Base(oldName))
 		hintName    = newName[:len(newName)-len(seriesFileSuffix)] + hintFileSuffix
 	)
@@ -1229,6 +1357,24 @@ func (p *persistence) fingerprintsModifiedBefore(beforeTime model.Time) ([]model
 	return fps, err
 }
 
+// oldestArchivedSampleTime returns the time of the oldest sample of all
+// archived timeseries, or model.Latest if there are none. This method is
+// goroutine-safe.
+func (p *persistence) oldestArchivedSampleTime() (model.Time, error) {
+	var tr codable.TimeRange
+	oldest := model.Latest
+	err := p.archivedFingerprintToTimeRange.ForEach(func(kv index.KeyValueAccessor) error {
+		if err := kv.Value(&tr); err != nil {
+			return err
+		}
+		if tr.First.Before(oldest) {
+			oldest = tr.First
+		}
+		return nil
+	})
+	return oldest, err
+}
+
 // archivedMetric retrieves the archived metric with the given fingerprint. This
 // method is goroutine-safe.
 func (p *persistence) archivedMetric(fp model.Fingerprint) (model.Metric, error) {
@@ -1260,14 +1406,14 @@ func (p *persistence) purgeArchivedMetric(fp model.Fingerprint) (err error) {
 		return err
 	}
 	if !deleted {
-		log.Errorf("Tried to delete non-archived fingerprint %s from archivedFingerprintToMetrics index. This should never happen.", fp)
+		logger.Errorf("Tried to delete non-archived fingerprint %s from archivedFingerprintToMetrics index. This should never happen.", fp)
 	}
 	deleted, err = p.archivedFingerprintToTimeRange.Delete(codable.Fingerprint(fp))
 	if err != nil {
 		return err
 	}
 	if !deleted {
-		log.Errorf("Tried to delete non-archived fingerprint %s from archivedFingerprintToTimeRange index. This should never happen.", fp)
+		logger.Errorf("Tried to delete non-archived fingerprint %s from archivedFingerprintToTimeRange index. This should never happen.", fp)
 	}
 	p.unindexMetric(fp, metric)
 	return nil
@@ -1289,7 +1435,7 @@ func (p *persistence) unarchiveMetric(fp model.Fingerprint) (deletedAnything boo
 		return false, err
 	}
 	if !deleted {
-		log.Errorf("Tried to delete non-archived fingerprint %s from archivedFingerprintToTimeRange index. This should never happen.", fp)
+		logger.Errorf("Tried to delete non-archived fingerprint %s from archivedFingerprintToTimeRange index. This should never happen.", fp)
 	}
 	return true, nil
 }
@@ -1304,26 +1450,26 @@ func (p *persistence) close() error {
 	var lastError, dirtyFileRemoveError error
 	if err := p.archivedFingerprintToMetrics.Close(); err != nil {
 		lastError = err
-		log.Error("Error closing archivedFingerprintToMetric index DB: ", err)
+		logger.Error("Error closing archivedFingerprintToMetric index DB: ", err)
 	}
 	if err := p.archivedFingerprintToTimeRange.Close(); err != nil {
 		lastError = err
-		log.Error("Error closing archivedFingerprintToTimeRange index DB: ", err)
+		logger.Error("Error closing archivedFingerprintToTimeRange index DB: ", err)
 	}
 	if err := p.labelPairToFingerprints.Close(); err != nil {
 		lastError = err
-		log.Error("Error closing labelPairToFingerprints index DB: ", err)
+		logger.Error("Error closing labelPairToFingerprints index DB: ", err)
 	}
 	if err := p.labelNameToLabelValues.Close(); err != nil {
 		lastError = err
-		log.Error("Error closing labelNameToLabelValues index DB: ", err)
+		logger.Error("Error closing labelNameToLabelValues index DB: ", err)
 	}
 	if lastError == nil && !p.isDirty() {
 		dirtyFileRemoveError = os.Remove(p.dirtyFileName)
 	}
 	if err := p.fLock.Release(); err != nil {
 		lastError = err
-		log.Error("Error releasing file lock: ", err)
+		logger.Error("Error releasing file lock: ", err)
 	}
 	if dirtyFileRemoveError != nil {
 		// On Windows, removing the dirty file before unlocking is not
@@ -1365,11 +1511,11 @@ func (p *persistence) openChunkFileForWriting(fp model.Fingerprint) (*os.File, e
 func (p *persistence) closeChunkFile(f *os.File) {
 	if p.shouldSync() {
 		if err := f.Sync(); err != nil {
-			log.Error("Error syncing file:", err)
+			logger.Error("Error syncing file:", err)
 		}
 	}
 	if err := f.Close(); err != nil {
-		log.Error("Error closing chunk file:", err)
+		logger.Error("Error closing chunk file:", err)
 	}
 }
 
@@ -1407,11 +1553,11 @@ func (p *persistence) processIndexingQueue() {
 		}(time.Now())
 
 		if err := p.labelPairToFingerprints.IndexBatch(pairToFPs); err != nil {
-			log.Error("Error indexing label pair to fingerprints batch: ", err)
+			logger.Error("Error indexing label pair to fingerprints batch: ", err)
 			p.setDirty(err)
 		}
 		if err := p.labelNameToLabelValues.IndexBatch(nameToValues); err != nil {
-			log.Error("Error indexing label name to label values batch: ", err)
+			logger.Error("Error indexing label name to label values batch: ", err)
 			p.setDirty(err)
 		}
 		batchSize = 0
@@ -1461,7 +1607,7 @@ loop:
 					var err error
 					baseFPs, _, err = p.labelPairToFingerprints.LookupSet(lp)
 					if err != nil {
-						log.Errorf("Error looking up label pair %v: %s", lp, err)
+						logger.Errorf("Error looking up label pair %v: %s", lp, err)
 						continue
 					}
 					pairToFPs[lp] = baseFPs
@@ -1471,7 +1617,7 @@ loop:
 					var err error
 					baseValues, _, err = p.labelNameToLabelValues.LookupSet(ln)
 					if err != nil {
-						log.Errorf("Error looking up label name %v: %s", ln, err)
+						logger.Errorf("Error looking up label name %v: %s", ln, err)
 						continue
 					}
 					nameToValues[ln] = baseValues
@@ -1523,7 +1669,7 @@ loop:
 // (4.3.2) The unique metric string.
 // (4.3.3) The mapped fingerprint as big-endian uint64.
 func (p *persistence) checkpointFPMappings(fpm fpMappings) (err error) {
-	log.Info("Checkpointing fingerprint mappings...")
+	logger.Info("Checkpointing fingerprint mappings...")
 	begin := time.Now()
 	f, err := os.OpenFile(p.mappingsTempFileName(), os.O_WRONLY|os.O_TRUNC|os.O_CREATE, 0640)
 	if err != nil {
@@ -1546,7 +1692,7 @@ func (p *persistence) checkpointFPMappings(fpm fpMappings) (err error) {
 		}
 		err = os.Rename(p.mappingsTempFileName(), p.mappingsFileName())
 		duration := time.Since(begin)
-		log.Infof("Done checkpointing fingerprint mappings in %v.", duration)
+		logger.Infof("Done checkpointing fingerprint mappings in %v.", duration)
 	}()
 
 	w := bufio.NewWriterSize(f, fileBufSize)
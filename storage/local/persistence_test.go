@@ -17,6 +17,7 @@ import (
 	"bufio"
 	"context"
 	"errors"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -43,7 +44,7 @@ var (
 func newTestPersistence(t *testing.T, encoding chunk.Encoding) (*persistence, testutil.Closer) {
 	chunk.DefaultEncoding = encoding
 	dir := testutil.NewTemporaryDirectory("test_persistence", t)
-	p, err := newPersistence(dir.Path(), false, false, func() bool { return false }, 0.15)
+	p, err := newPersistence(dir.Path(), false, false, false, func() bool { return false }, 0.15)
 	if err != nil {
 		dir.Close()
 		t.Fatal(err)
@@ -55,6 +56,44 @@ func newTestPersistence(t *testing.T, encoding chunk.Encoding) (*persistence, te
 	})
 }
 
+func TestAllowCorruptedStartup(t *testing.T) {
+	dir := testutil.NewTemporaryDirectory("test_persistence", t)
+	defer dir.Close()
+
+	p, err := newPersistence(dir.Path(), false, false, false, func() bool { return false }, 0.15)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go p.run()
+	if err := p.close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Let the archived fingerprint-to-metric index refer to a missing manifest.
+	current := filepath.Join(dir.Path(), index.FingerprintToMetricDir, "CURRENT")
+	if err := ioutil.WriteFile(current, []byte("MANIFEST-999999\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err = newPersistence(dir.Path(), false, false, true, func() bool { return false }, 0.15)
+	if err != nil {
+		t.Fatalf("unexpected error opening corrupted storage: %s", err)
+	}
+	go p.run()
+	defer p.close()
+
+	if !p.isDirty() {
+		t.Error("expected storage to be dirty after quarantining an index")
+	}
+	quarantined, err := filepath.Glob(filepath.Join(dir.Path(), corruptedDirName, "*", index.FingerprintToMetricDir, "CURRENT"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(quarantined) != 1 {
+		t.Errorf("expected the corrupted index in the corrupted directory, got %v", quarantined)
+	}
+}
+
 func buildTestChunks(t *testing.T, encoding chunk.Encoding) map[model.Fingerprint][]chunk.Chunk {
 	fps := model.Fingerprints{
 		m1.FastFingerprint(),
@@ -1185,6 +1224,25 @@ func verifyIndexedState(i int, t *testing.T, b incrementalBatch, indexedFpsToMet
 		}
 	}
 
+	// Compare label names.
+	outLns, err := p.labelNames()
+	if err != nil {
+		t.Fatal(err)
+	}
+	outLnSet := map[model.LabelName]struct{}{}
+	for _, ln := range outLns {
+		outLnSet[ln] = struct{}{}
+	}
+	lnSet := map[model.LabelName]struct{}{}
+	for ln, lvs := range b.expectedLnToLvs {
+		if len(lvs) > 0 {
+			lnSet[ln] = struct{}{}
+		}
+	}
+	if !reflect.DeepEqual(lnSet, outLnSet) {
+		t.Errorf("%d. label names don't match. Got: %v; want %v", i, outLnSet, lnSet)
+	}
+
 	// Compare label pair -> fingerprints mappings.
 	for lp, fps := range b.expectedLpToFps {
 		outFPs := p.fingerprintsForLabelPair(lp)
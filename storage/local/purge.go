@@ -0,0 +1,136 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/common/model"
+)
+
+// PurgeWindow is a daily time window in UTC, given in the format HH:MM-HH:MM.
+// A window whose end is before its start spans midnight. The zero value is
+// the whole day.
+type PurgeWindow struct {
+	// Start and end of the window as offsets from midnight.
+	Start, End time.Duration
+}
+
+// String implements flag.Value.
+func (w PurgeWindow) String() string {
+	if w.Start == w.End {
+		return ""
+	}
+	return fmt.Sprintf("%s-%s", formatTimeOfDay(w.Start), formatTimeOfDay(w.End))
+}
+
+// Set implements flag.Value.
+func (w *PurgeWindow) Set(s string) error {
+	if s == "" {
+		*w = PurgeWindow{}
+		return nil
+	}
+	parts := strings.Split(s, "-")
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid purge window %q, expected HH:MM-HH:MM", s)
+	}
+	start, err := parseTimeOfDay(parts[0])
+	if err != nil {
+		return fmt.Errorf("invalid start of purge window %q: %s", s, err)
+	}
+	end, err := parseTimeOfDay(parts[1])
+	if err != nil {
+		return fmt.Errorf("invalid end of purge window %q: %s", s, err)
+	}
+	if start == end {
+		return fmt.Errorf("empty purge window %q", s)
+	}
+	*w = PurgeWindow{Start: start, End: end}
+	return nil
+}
+
+// Contains returns whether t is within the window.
+func (w PurgeWindow) Contains(t time.Time) bool {
+	if w.Start == w.End {
+		return true
+	}
+	t = t.UTC()
+	d := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	if w.Start < w.End {
+		return d >= w.Start && d < w.End
+	}
+	return d >= w.Start || d < w.End
+}
+
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func formatTimeOfDay(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d/time.Hour), int(d%time.Hour/time.Minute))
+}
+
+// PurgingStatus describes whether chunks beyond the retention period are
+// currently dropped from the series files.
+type PurgingStatus struct {
+	// Whether purging has been paused with PausePurging.
+	Paused bool `json:"paused"`
+	// The configured purge window, empty for the whole day.
+	Window string `json:"window"`
+	// Whether series files are purged right now.
+	Active bool `json:"active"`
+}
+
+// PausePurging stops dropping chunks beyond the retention period from the
+// series files until ResumePurging is called. New chunks are still persisted.
+func (s *MemorySeriesStorage) PausePurging() {
+	atomic.StoreInt32(&s.purgingPaused, 1)
+}
+
+// ResumePurging undoes PausePurging.
+func (s *MemorySeriesStorage) ResumePurging() {
+	atomic.StoreInt32(&s.purgingPaused, 0)
+}
+
+// PurgingStatus returns the current purging status.
+func (s *MemorySeriesStorage) PurgingStatus() PurgingStatus {
+	return PurgingStatus{
+		Paused: atomic.LoadInt32(&s.purgingPaused) == 1,
+		Window: s.purgeWindow.String(),
+		Active: s.purgingActive(time.Now()),
+	}
+}
+
+// purgingActive returns whether purging is neither paused nor outside of the
+// purge window at time t.
+func (s *MemorySeriesStorage) purgingActive(t time.Time) bool {
+	return atomic.LoadInt32(&s.purgingPaused) == 0 && s.purgeWindow.Contains(t)
+}
+
+// purgeBefore returns the time before which chunks are to be dropped by the
+// series maintenance. While purging is not active, it returns model.Earliest
+// so that series files are only appended to.
+func (s *MemorySeriesStorage) purgeBefore() model.Time {
+	if !s.purgingActive(time.Now()) {
+		return model.Earliest
+	}
+	return model.Now().Add(-s.dropAfter)
+}
@@ -0,0 +1,110 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+)
+
+func TestPurgeWindow(t *testing.T) {
+	at := func(hour, min int) time.Time {
+		return time.Date(2017, 6, 1, hour, min, 0, 0, time.UTC)
+	}
+	scenarios := []struct {
+		in      string
+		inside  []time.Time
+		outside []time.Time
+	}{
+		{
+			in:     "",
+			inside: []time.Time{at(0, 0), at(12, 30), at(23, 59)},
+		},
+		{
+			in:      "01:30-05:00",
+			inside:  []time.Time{at(1, 30), at(4, 59)},
+			outside: []time.Time{at(1, 29), at(5, 0), at(23, 0)},
+		},
+		{
+			in:      "22:00-06:00",
+			inside:  []time.Time{at(22, 0), at(23, 59), at(0, 0), at(5, 59)},
+			outside: []time.Time{at(6, 0), at(12, 0), at(21, 59)},
+		},
+	}
+	for _, s := range scenarios {
+		var w PurgeWindow
+		if err := w.Set(s.in); err != nil {
+			t.Fatalf("%q: %s", s.in, err)
+		}
+		if w.String() != s.in {
+			t.Errorf("%q: unexpected string %q", s.in, w.String())
+		}
+		for _, ts := range s.inside {
+			if !w.Contains(ts) {
+				t.Errorf("%q: expected %s to be inside", s.in, ts)
+			}
+		}
+		for _, ts := range s.outside {
+			if w.Contains(ts) {
+				t.Errorf("%q: expected %s to be outside", s.in, ts)
+			}
+		}
+	}
+
+	// The window is in UTC.
+	var w PurgeWindow
+	if err := w.Set("01:00-02:00"); err != nil {
+		t.Fatal(err)
+	}
+	if !w.Contains(time.Date(2017, 6, 1, 3, 30, 0, 0, time.FixedZone("", 2*3600))) {
+		t.Error("expected time in another zone to be inside")
+	}
+
+	for _, in := range []string{"01:00", "01:00-02:00-03:00", "1-2", "25:00-02:00", "01:00-01:00"} {
+		if err := w.Set(in); err == nil {
+			t.Errorf("%q: expected error", in)
+		}
+	}
+}
+
+func TestPausePurging(t *testing.T) {
+	s, closer := NewTestStorage(t, 2)
+	defer closer.Close()
+	s.dropAfter = time.Hour
+
+	if s.purgeBefore() == model.Earliest {
+		t.Fatal("expected purging to be active")
+	}
+	s.PausePurging()
+	if s.purgeBefore() != model.Earliest {
+		t.Error("expected no purging while paused")
+	}
+	if st := s.PurgingStatus(); !st.Paused || st.Active {
+		t.Errorf("unexpected status %+v", st)
+	}
+	s.ResumePurging()
+	if st := s.PurgingStatus(); st.Paused || !st.Active {
+		t.Errorf("unexpected status %+v", st)
+	}
+
+	// A window ending right now excludes the current time.
+	now := time.Now().UTC()
+	end := time.Duration(now.Hour())*time.Hour + time.Duration(now.Minute())*time.Minute
+	s.purgeWindow = PurgeWindow{Start: (end + 23*time.Hour) % (24 * time.Hour), End: end}
+	if s.purgeBefore() != model.Earliest {
+		t.Errorf("expected no purging outside of window %s", s.purgeWindow)
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// QuarantinedSeriesFile is a series file moved into the orphaned directory
+// because it could not be recovered.
+type QuarantinedSeriesFile struct {
+	// The path of the file relative to the storage directory.
+	Path string
+	// The metric and reason from the hint file written alongside.
+	Metric, Reason string
+}
+
+// RepairReport lists the data a repair moved out of the way.
+type RepairReport struct {
+	// The corrupted indexes and heads file quarantined, relative to the
+	// storage directory.
+	Corrupted []string
+	// The series files that could not be recovered.
+	Orphaned []QuarantinedSeriesFile
+}
+
+// RepairStorage runs the crash recovery of the local storage in basePath with
+// pedantic checks of each series file, quarantining corrupted indexes and a
+// corrupted heads file instead of failing. The indexes are rebuilt from the
+// series files. The storage must not be in use. An error is returned if the
+// storage cannot be repaired.
+func RepairStorage(basePath string) (*RepairReport, error) {
+	if fi, err := os.Stat(basePath); err != nil {
+		return nil, err
+	} else if !fi.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", basePath)
+	}
+
+	corruptedBefore, err := listCorrupted(basePath)
+	if err != nil {
+		return nil, err
+	}
+	orphanedBefore, err := listOrphaned(basePath)
+	if err != nil {
+		return nil, err
+	}
+
+	s := NewMemorySeriesStorage(&MemorySeriesStorageOptions{
+		TargetHeapSize:             2 * 1024 * 1024 * 1024,
+		PersistenceStoragePath:     basePath,
+		PersistenceRetentionPeriod: 24 * time.Hour * 365 * 100, // Never purge anything.
+		HeadChunkTimeout:           5 * time.Minute,
+		CheckpointInterval:         time.Hour,
+		CheckpointDirtySeriesLimit: 5000,
+		SyncStrategy:               Always,
+		MinShrinkRatio:             0.1,
+		Dirty:                      true,
+		PedanticChecks:             true,
+		AllowCorruptedStartup:      true,
+	})
+	if err := s.Start(); err != nil {
+		return nil, err
+	}
+	if err := s.Stop(); err != nil {
+		return nil, err
+	}
+
+	corrupted, err := listCorrupted(basePath)
+	if err != nil {
+		return nil, err
+	}
+	orphaned, err := listOrphaned(basePath)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &RepairReport{}
+	for c := range corrupted {
+		if _, ok := corruptedBefore[c]; !ok {
+			report.Corrupted = append(report.Corrupted, c)
+		}
+	}
+	for o := range orphaned {
+		if _, ok := orphanedBefore[o]; !ok {
+			report.Orphaned = append(report.Orphaned, quarantinedSeriesFile(basePath, o))
+		}
+	}
+	sort.Strings(report.Corrupted)
+	sort.Slice(report.Orphaned, func(i, j int) bool { return report.Orphaned[i].Path < report.Orphaned[j].Path })
+	return report, nil
+}
+
+// listCorrupted returns the items in the sub-directories of the corrupted
+// directory, relative to basePath.
+func listCorrupted(basePath string) (map[string]struct{}, error) {
+	matches, err := filepath.Glob(filepath.Join(basePath, corruptedDirName, "*", "*"))
+	if err != nil {
+		return nil, err
+	}
+	return relativePaths(basePath, matches)
+}
+
+// listOrphaned returns the series files in the orphaned directory, relative
+// to basePath.
+func listOrphaned(basePath string) (map[string]struct{}, error) {
+	matches, err := filepath.Glob(filepath.Join(basePath, orphanedDirName, "*", "*"+seriesFileSuffix))
+	if err != nil {
+		return nil, err
+	}
+	return relativePaths(basePath, matches)
+}
+
+func relativePaths(basePath string, paths []string) (map[string]struct{}, error) {
+	res := make(map[string]struct{}, len(paths))
+	for _, p := range paths {
+		rel, err := filepath.Rel(basePath, p)
+		if err != nil {
+			return nil, err
+		}
+		res[rel] = struct{}{}
+	}
+	return res, nil
+}
+
+// quarantinedSeriesFile reads the hint file of the orphaned series file at the
+// given path relative to basePath. The hint file is written on a best effort
+// basis, so its content may be missing.
+func quarantinedSeriesFile(basePath, path string) QuarantinedSeriesFile {
+	q := QuarantinedSeriesFile{Path: path}
+	hint := filepath.Join(basePath, strings.TrimSuffix(path, seriesFileSuffix)+hintFileSuffix)
+	b, err := ioutil.ReadFile(hint)
+	if err != nil {
+		return q
+	}
+	lines := strings.SplitN(string(b), "\n", 3)
+	q.Metric = lines[0]
+	if len(lines) > 1 {
+		q.Reason = lines[1]
+	}
+	return q
+}
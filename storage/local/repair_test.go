@@ -0,0 +1,77 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/prometheus/storage/local/index"
+	"github.com/prometheus/prometheus/util/testutil"
+)
+
+func TestRepairStorage(t *testing.T) {
+	dir := testutil.NewTemporaryDirectory("test_repair", t)
+	defer dir.Close()
+
+	p, err := newPersistence(dir.Path(), false, false, false, func() bool { return false }, 0.15)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go p.run()
+	if err := p.close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Let the archived fingerprint-to-metric index refer to a missing manifest.
+	current := filepath.Join(dir.Path(), index.FingerprintToMetricDir, "CURRENT")
+	if err := ioutil.WriteFile(current, []byte("MANIFEST-999999\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	// Add a series file too short to hold a single chunk.
+	if err := os.MkdirAll(filepath.Join(dir.Path(), "00"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir.Path(), "00", "00000000000001.db"), []byte("garbage"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := RepairStorage(dir.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Corrupted) != 1 || filepath.Base(report.Corrupted[0]) != index.FingerprintToMetricDir {
+		t.Errorf("expected the corrupted index to be reported, got %v", report.Corrupted)
+	}
+	if len(report.Orphaned) != 1 {
+		t.Fatalf("expected 1 orphaned series file, got %v", report.Orphaned)
+	}
+	if expected := filepath.Join(orphanedDirName, "00", "00000000000001.db"); report.Orphaned[0].Path != expected {
+		t.Errorf("expected orphaned series file %s, got %s", expected, report.Orphaned[0].Path)
+	}
+	if report.Orphaned[0].Reason == "" {
+		t.Error("expected the reason of the orphaned series file to be reported")
+	}
+
+	// A second repair finds nothing left to do.
+	report, err = RepairStorage(dir.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Corrupted) != 0 || len(report.Orphaned) != 0 {
+		t.Errorf("expected an empty report for a repaired storage, got %+v", report)
+	}
+}
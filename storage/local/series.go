@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
 
 	"github.com/prometheus/prometheus/storage/local/chunk"
 	"github.com/prometheus/prometheus/storage/metric"
@@ -203,7 +204,7 @@ func newMemorySeries(m model.Metric, chunkDescs []*chunk.Desc, modTime time.Time
 // The caller must have locked the fingerprint of the series.
 func (s *memorySeries) add(v model.SamplePair) (int, error) {
 	if len(s.chunkDescs) == 0 || s.headChunkClosed {
-		newHead := chunk.NewDesc(chunk.New(), v.Timestamp)
+		newHead := chunk.NewDesc(chunk.NewForMetric(s.metric), v.Timestamp)
 		s.chunkDescs = append(s.chunkDescs, newHead)
 		s.headChunkClosed = false
 	} else if s.headChunkUsedByIterator && s.head().RefCount() > 1 {
@@ -223,7 +224,7 @@ func (s *memorySeries) add(v model.SamplePair) (int, error) {
 		s.headChunkUsedByIterator = false
 	}
 
-	chunks, err := s.head().Add(v)
+	chunks, err := s.head().Add(s.metric, v)
 	if err != nil {
 		return 0, err
 	}
@@ -327,7 +328,7 @@ func (s *memorySeries) dropChunks(t model.Time) error {
 
 // preloadChunks is an internal helper method.
 func (s *memorySeries) preloadChunks(
-	indexes []int, fp model.Fingerprint, mss *MemorySeriesStorage,
+	ctx context.Context, indexes []int, fp model.Fingerprint, mss *MemorySeriesStorage,
 ) (SeriesIterator, error) {
 	loadIndexes := []int{}
 	pinnedChunkDescs := make([]*chunk.Desc, 0, len(indexes))
@@ -368,7 +369,7 @@ func (s *memorySeries) preloadChunks(
 	}
 
 	iter := &boundedIterator{
-		it:    s.newIterator(pinnedChunkDescs, curriedQuarantineSeries, mss.evictRequests),
+		it:    s.newIterator(ctx, pinnedChunkDescs, curriedQuarantineSeries, mss.evictRequests),
 		start: model.Now().Add(-mss.dropAfter),
 	}
 
@@ -376,10 +377,12 @@ func (s *memorySeries) preloadChunks(
 }
 
 // newIterator returns a new SeriesIterator for the provided chunkDescs (which
-// must be pinned).
+// must be pinned). The iterator stops returning range values once the given
+// context is done.
 //
 // The caller must have locked the fingerprint of the memorySeries.
 func (s *memorySeries) newIterator(
+	ctx context.Context,
 	pinnedChunkDescs []*chunk.Desc,
 	quarantine func(error),
 	evictRequests chan<- chunk.EvictRequest,
@@ -397,6 +400,7 @@ func (s *memorySeries) newIterator(
 		metric:           s.metric,
 		pinnedChunkDescs: pinnedChunkDescs,
 		evictRequests:    evictRequests,
+		ctx:              ctx,
 	}
 }
 
@@ -405,6 +409,7 @@ func (s *memorySeries) newIterator(
 // value in the given range, it will in fact preload zero chunks and just take
 // that value.
 func (s *memorySeries) preloadChunksForInstant(
+	ctx context.Context,
 	fp model.Fingerprint,
 	from model.Time, through model.Time,
 	mss *MemorySeriesStorage,
@@ -427,12 +432,13 @@ func (s *memorySeries) preloadChunksForInstant(
 	}
 	// If we are here, we are out of luck and have to delegate to the more
 	// expensive method.
-	return s.preloadChunksForRange(fp, from, through, mss)
+	return s.preloadChunksForRange(ctx, fp, from, through, mss)
 }
 
 // preloadChunksForRange loads chunks for the given range from the persistence.
 // The caller must have locked the fingerprint of the series.
 func (s *memorySeries) preloadChunksForRange(
+	ctx context.Context,
 	fp model.Fingerprint,
 	from model.Time, through model.Time,
 	mss *MemorySeriesStorage,
@@ -498,7 +504,7 @@ func (s *memorySeries) preloadChunksForRange(
 	for i := fromIdx; i <= throughIdx; i++ {
 		pinIndexes = append(pinIndexes, i)
 	}
-	return s.preloadChunks(pinIndexes, fp, mss)
+	return s.preloadChunks(ctx, pinIndexes, fp, mss)
 }
 
 // head returns a pointer to the head chunk descriptor. The caller must have
@@ -571,6 +577,8 @@ type memorySeriesIterator struct {
 	pinnedChunkDescs []*chunk.Desc
 	// Where to send evict requests when unpinning pinned chunks.
 	evictRequests chan<- chunk.EvictRequest
+	// The context of the query of the iterator.
+	ctx context.Context
 }
 
 // ValueAtOrBeforeTime implements SeriesIterator.
@@ -640,9 +648,13 @@ func (it *memorySeriesIterator) RangeValues(in metric.Interval) []model.SamplePa
 		if c.FirstTime().After(in.NewestInclusive) {
 			break
 		}
-		chValues, err := chunk.RangeValues(it.chunkIterator(i+j), in)
+		chValues, err := chunk.RangeValues(it.ctx, it.chunkIterator(i+j), in)
 		if err != nil {
-			it.quarantine(err)
+			// Abandoned queries are detected by their evaluation,
+			// the chunks are fine.
+			if err != it.ctx.Err() {
+				it.quarantine(err)
+			}
 			return nil
 		}
 		values = append(values, chValues...)
@@ -0,0 +1,186 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/common/model"
+)
+
+const (
+	// churnInterval is the resolution series churn is tracked with.
+	churnInterval = time.Minute
+	// churnIntervals is the number of intervals series churn is tracked for.
+	churnIntervals = 60
+)
+
+// SeriesStats holds statistics about the series currently in memory.
+type SeriesStats struct {
+	NumSeries int `json:"numSeries"`
+	// The top entries by number of series or label values.
+	SeriesCountByMetricName     []StatsEntry `json:"seriesCountByMetricName"`
+	LabelValueCountByLabelName  []StatsEntry `json:"labelValueCountByLabelName"`
+	SeriesCountByLabelValuePair []StatsEntry `json:"seriesCountByLabelValuePair"`
+	// The number of series created and removed from memory per interval,
+	// oldest first.
+	Churn []ChurnEntry `json:"churn"`
+}
+
+// StatsEntry is a single counted item in SeriesStats.
+type StatsEntry struct {
+	Name  string `json:"name"`
+	Value int    `json:"value"`
+}
+
+// ChurnEntry holds the number of series created and removed from memory in
+// the interval starting at Time.
+type ChurnEntry struct {
+	Time    time.Time `json:"time"`
+	Created int       `json:"created"`
+	Removed int       `json:"removed"`
+}
+
+// SeriesStats returns statistics about the series currently in memory. The
+// lists of SeriesStats are limited to the top limit entries.
+func (s *MemorySeriesStorage) SeriesStats(limit int) *SeriesStats {
+	c := newSeriesCounter()
+	// The metric of a series never changes, so it can be read without
+	// locking the fingerprint.
+	for fps := range s.fpToSeries.iter() {
+		c.add(fps.series.metric)
+	}
+
+	stats := c.stats(limit)
+	stats.Churn = s.churn.entries(time.Now())
+	return stats
+}
+
+// seriesCounter counts series by their metric names and label pairs.
+type seriesCounter struct {
+	numSeries    int
+	byMetricName map[string]int
+	byLabelPair  map[string]int
+	labelValues  map[model.LabelName]map[model.LabelValue]struct{}
+}
+
+func newSeriesCounter() *seriesCounter {
+	return &seriesCounter{
+		byMetricName: map[string]int{},
+		byLabelPair:  map[string]int{},
+		labelValues:  map[model.LabelName]map[model.LabelValue]struct{}{},
+	}
+}
+
+func (c *seriesCounter) add(m model.Metric) {
+	c.numSeries++
+	for ln, lv := range m {
+		if ln == model.MetricNameLabel {
+			c.byMetricName[string(lv)]++
+		}
+		c.byLabelPair[string(ln)+"="+string(lv)]++
+
+		vals, ok := c.labelValues[ln]
+		if !ok {
+			vals = map[model.LabelValue]struct{}{}
+			c.labelValues[ln] = vals
+		}
+		vals[lv] = struct{}{}
+	}
+}
+
+// stats returns the counted statistics, limiting the lists to the top limit
+// entries. Churn is left empty.
+func (c *seriesCounter) stats(limit int) *SeriesStats {
+	byLabelName := make(map[string]int, len(c.labelValues))
+	for ln, vals := range c.labelValues {
+		byLabelName[string(ln)] = len(vals)
+	}
+
+	return &SeriesStats{
+		NumSeries:                   c.numSeries,
+		SeriesCountByMetricName:     topEntries(c.byMetricName, limit),
+		LabelValueCountByLabelName:  topEntries(byLabelName, limit),
+		SeriesCountByLabelValuePair: topEntries(c.byLabelPair, limit),
+	}
+}
+
+// topEntries returns the limit entries of m with the highest values, highest
+// first.
+func topEntries(m map[string]int, limit int) []StatsEntry {
+	res := make([]StatsEntry, 0, len(m))
+	for name, v := range m {
+		res = append(res, StatsEntry{Name: name, Value: v})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Value != res[j].Value {
+			return res[i].Value > res[j].Value
+		}
+		return res[i].Name < res[j].Name
+	})
+	if len(res) > limit {
+		res = res[:limit]
+	}
+	return res
+}
+
+// churnTracker counts series created and removed from memory over the last
+// churnIntervals intervals.
+type churnTracker struct {
+	mtx       sync.Mutex
+	intervals [churnIntervals]ChurnEntry
+}
+
+// interval returns the entry for the interval t falls into, resetting it if
+// it still holds an older interval.
+func (c *churnTracker) interval(t time.Time) *ChurnEntry {
+	start := t.Truncate(churnInterval)
+	e := &c.intervals[start.Unix()/int64(churnInterval/time.Second)%churnIntervals]
+	if !e.Time.Equal(start) {
+		*e = ChurnEntry{Time: start}
+	}
+	return e
+}
+
+func (c *churnTracker) created(t time.Time) {
+	c.mtx.Lock()
+	c.interval(t).Created++
+	c.mtx.Unlock()
+}
+
+func (c *churnTracker) removed(t time.Time) {
+	c.mtx.Lock()
+	c.interval(t).Removed++
+	c.mtx.Unlock()
+}
+
+// entries returns the tracked intervals up to and including the one now falls
+// into, oldest first.
+func (c *churnTracker) entries(now time.Time) []ChurnEntry {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	res := make([]ChurnEntry, 0, churnIntervals)
+	last := now.Truncate(churnInterval)
+	for t := last.Add(-(churnIntervals - 1) * churnInterval); !t.After(last); t = t.Add(churnInterval) {
+		e := ChurnEntry{Time: t}
+		if ie := c.intervals[t.Unix()/int64(churnInterval/time.Second)%churnIntervals]; ie.Time.Equal(t) {
+			e = ie
+		}
+		res = append(res, e)
+	}
+	return res
+}
@@ -0,0 +1,93 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package local
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+)
+
+func TestSeriesStats(t *testing.T) {
+	storage, closer := NewTestStorage(t, 2)
+	defer closer.Close()
+
+	for i := 0; i < 6; i++ {
+		storage.Append(&model.Sample{
+			Metric: model.Metric{
+				model.MetricNameLabel: model.LabelValue(fmt.Sprintf("metric_%d", i%3)),
+				"instance":            model.LabelValue(fmt.Sprintf("host_%d", i%2)),
+				"job":                 "test",
+			},
+			Timestamp: model.Time(i),
+			Value:     model.SampleValue(i),
+		})
+	}
+	storage.WaitForIndexing()
+
+	stats := storage.SeriesStats(2)
+	if stats.NumSeries != 6 {
+		t.Errorf("expected 6 series, got %d", stats.NumSeries)
+	}
+
+	expectedByMetricName := []StatsEntry{{"metric_0", 2}, {"metric_1", 2}}
+	if !reflect.DeepEqual(stats.SeriesCountByMetricName, expectedByMetricName) {
+		t.Errorf("expected series count by metric name %v, got %v", expectedByMetricName, stats.SeriesCountByMetricName)
+	}
+	expectedByLabelName := []StatsEntry{{"__name__", 3}, {"instance", 2}}
+	if !reflect.DeepEqual(stats.LabelValueCountByLabelName, expectedByLabelName) {
+		t.Errorf("expected label value count by label name %v, got %v", expectedByLabelName, stats.LabelValueCountByLabelName)
+	}
+	expectedByLabelPair := []StatsEntry{{"job=test", 6}, {"instance=host_0", 3}}
+	if !reflect.DeepEqual(stats.SeriesCountByLabelValuePair, expectedByLabelPair) {
+		t.Errorf("expected series count by label pair %v, got %v", expectedByLabelPair, stats.SeriesCountByLabelValuePair)
+	}
+
+	if len(stats.Churn) != churnIntervals {
+		t.Fatalf("expected %d churn entries, got %d", churnIntervals, len(stats.Churn))
+	}
+	created := 0
+	for _, e := range stats.Churn {
+		created += e.Created
+	}
+	if created != 6 {
+		t.Errorf("expected 6 created series, got %d", created)
+	}
+}
+
+func TestChurnTracker(t *testing.T) {
+	var (
+		c     churnTracker
+		start = time.Unix(3600, 0)
+	)
+	c.created(start)
+	c.created(start.Add(30 * time.Second))
+	c.removed(start.Add(time.Minute))
+	// Reuses the slot of the first interval, which must be reset.
+	c.created(start.Add(churnIntervals * time.Minute))
+
+	entries := c.entries(start.Add(churnIntervals * time.Minute))
+	if len(entries) != churnIntervals {
+		t.Fatalf("expected %d entries, got %d", churnIntervals, len(entries))
+	}
+	if e := entries[0]; !e.Time.Equal(start.Add(time.Minute)) || e.Created != 0 || e.Removed != 1 {
+		t.Errorf("unexpected first entry %+v", e)
+	}
+	if e := entries[len(entries)-1]; !e.Time.Equal(start.Add(churnIntervals*time.Minute)) || e.Created != 1 || e.Removed != 0 {
+		t.Errorf("unexpected last entry %+v", e)
+	}
+}
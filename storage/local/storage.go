@@ -27,14 +27,17 @@ import (
 
 	opentracing "github.com/opentracing/opentracing-go"
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/common/log"
 	"github.com/prometheus/common/model"
 	"golang.org/x/net/context"
 
 	"github.com/prometheus/prometheus/storage/local/chunk"
 	"github.com/prometheus/prometheus/storage/metric"
+	"github.com/prometheus/prometheus/util/logging"
 )
 
+// logger logs the messages of the local storage.
+var logger = logging.New("storage")
+
 const (
 	evictRequestsCap      = 1024
 	quarantineRequestsCap = 1024
@@ -142,6 +145,7 @@ type syncStrategy func() bool
 type MemorySeriesStorage struct {
 	// archiveHighWatermark, chunksToPersist, persistUrgency have to be aligned for atomic operations.
 	archiveHighWatermark model.Time    // No archived series has samples after this time.
+	oldestSampleTime     model.Time    // No series had samples before this time at startup.
 	numChunksToPersist   int64         // The number of chunks waiting for persistence.
 	persistUrgency       int32         // Persistence urgency score * 1000, int32 allows atomic operations.
 	rushed               bool          // Whether the storage is in rushed mode.
@@ -159,8 +163,13 @@ type MemorySeriesStorage struct {
 	targetHeapSize             uint64
 	dropAfter                  time.Duration
 	headChunkTimeout           time.Duration
+	seriesIdleTimeout          time.Duration
+	maxSeriesPerQuery          int
+	maxChunksPerQuery          int
 	checkpointInterval         time.Duration
 	checkpointDirtySeriesLimit int
+	purgeWindow                PurgeWindow
+	purgingPaused              int32 // 1 while purging is paused, accessed atomically.
 
 	persistence *persistence
 	mapper      *fpMapper
@@ -172,6 +181,8 @@ type MemorySeriesStorage struct {
 	quarantineRequests                    chan quarantineRequest
 	quarantineStopping, quarantineStopped chan struct{}
 
+	churn churnTracker
+
 	persistErrors            prometheus.Counter
 	queuedChunksToPersist    prometheus.Counter
 	chunksToPersist          prometheus.GaugeFunc
@@ -186,6 +197,7 @@ type MemorySeriesStorage struct {
 	maintainSeriesDuration   *prometheus.SummaryVec
 	persistenceUrgencyScore  prometheus.GaugeFunc
 	rushedMode               prometheus.GaugeFunc
+	purgingActiveGauge       prometheus.GaugeFunc
 	targetHeapSizeBytes      prometheus.GaugeFunc
 }
 
@@ -197,13 +209,18 @@ type MemorySeriesStorageOptions struct {
 	PersistenceStoragePath     string        // Location of persistence files.
 	PersistenceRetentionPeriod time.Duration // Chunks at least that old are dropped.
 	HeadChunkTimeout           time.Duration // Head chunks idle for at least that long may be closed.
+	SeriesIdleTimeout          time.Duration // Series idle for at least that long are archived, 0 to only archive on memory pressure.
 	CheckpointInterval         time.Duration // How often to checkpoint the series map and head chunks.
 	CheckpointDirtySeriesLimit int           // How many dirty series will trigger an early checkpoint.
 	Dirty                      bool          // Force the storage to consider itself dirty on startup.
 	PedanticChecks             bool          // If dirty, perform crash-recovery checks on each series file.
+	AllowCorruptedStartup      bool          // Quarantine corrupted indexes and heads file on startup instead of failing.
 	SyncStrategy               SyncStrategy  // Which sync strategy to apply to series files.
 	MinShrinkRatio             float64       // Minimum ratio a series file has to shrink during truncation.
 	NumMutexes                 int           // Number of mutexes used for stochastic fingerprint locking.
+	MaxSeriesPerQuery          int           // Maximum number of series a query may touch, 0 for no limit.
+	MaxChunksPerQuery          int           // Maximum number of chunks a query may touch, 0 for no limit.
+	PurgeWindow                PurgeWindow   // When chunks beyond the retention period may be dropped, the zero value for always.
 }
 
 // NewMemorySeriesStorage returns a newly allocated Storage. Storage.Serve still
@@ -221,8 +238,12 @@ func NewMemorySeriesStorage(o *MemorySeriesStorageOptions) *MemorySeriesStorage
 		targetHeapSize:             o.TargetHeapSize,
 		dropAfter:                  o.PersistenceRetentionPeriod,
 		headChunkTimeout:           o.HeadChunkTimeout,
+		seriesIdleTimeout:          o.SeriesIdleTimeout,
+		maxSeriesPerQuery:          o.MaxSeriesPerQuery,
+		maxChunksPerQuery:          o.MaxChunksPerQuery,
 		checkpointInterval:         o.CheckpointInterval,
 		checkpointDirtySeriesLimit: o.CheckpointDirtySeriesLimit,
+		purgeWindow:                o.PurgeWindow,
 		archiveHighWatermark:       model.Now().Add(-o.HeadChunkTimeout),
 
 		evictList:     list.New(),
@@ -341,6 +362,20 @@ func NewMemorySeriesStorage(o *MemorySeriesStorageOptions) *MemorySeriesStorage
 			return 0
 		},
 	)
+	s.purgingActiveGauge = prometheus.NewGaugeFunc(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "purging_active",
+			Help:      "1 if chunks beyond the retention period are currently purged, 0 if purging is paused or outside of the purge window.",
+		},
+		func() float64 {
+			if s.purgingActive(time.Now()) {
+				return 1
+			}
+			return 0
+		},
+	)
 	s.persistenceUrgencyScore = prometheus.NewGaugeFunc(
 		prometheus.GaugeOpts{
 			Namespace: namespace,
@@ -373,6 +408,8 @@ func NewMemorySeriesStorage(o *MemorySeriesStorageOptions) *MemorySeriesStorage
 	s.maintainSeriesDuration.WithLabelValues(maintainArchived)
 	s.seriesOps.WithLabelValues(create)
 	s.seriesOps.WithLabelValues(archive)
+	s.seriesOps.WithLabelValues(idleArchive)
+	s.seriesOps.WithLabelValues(blockImport)
 	s.seriesOps.WithLabelValues(unarchive)
 	s.seriesOps.WithLabelValues(memoryPurge)
 	s.seriesOps.WithLabelValues(archivePurge)
@@ -406,7 +443,7 @@ func (s *MemorySeriesStorage) Start() (err error) {
 	var p *persistence
 	p, err = newPersistence(
 		s.options.PersistenceStoragePath,
-		s.options.Dirty, s.options.PedanticChecks,
+		s.options.Dirty, s.options.PedanticChecks, s.options.AllowCorruptedStartup,
 		syncStrategy,
 		s.options.MinShrinkRatio,
 	)
@@ -420,12 +457,12 @@ func (s *MemorySeriesStorage) Start() (err error) {
 	defer func() {
 		if err != nil {
 			if e := p.close(); e != nil {
-				log.Errorln("Error closing persistence:", e)
+				logger.Errorln("Error closing persistence:", e)
 			}
 		}
 	}()
 
-	log.Info("Loading series map and head chunks...")
+	logger.Info("Loading series map and head chunks...")
 	s.fpToSeries, s.numChunksToPersist, err = p.loadSeriesMapAndHeads()
 	for _, series := range s.fpToSeries.m {
 		if !series.headChunkClosed {
@@ -436,7 +473,10 @@ func (s *MemorySeriesStorage) Start() (err error) {
 	if err != nil {
 		return err
 	}
-	log.Infof("%d series loaded.", s.fpToSeries.length())
+	logger.Infof("%d series loaded.", s.fpToSeries.length())
+	if s.oldestSampleTime, err = s.loadOldestSampleTime(); err != nil {
+		return err
+	}
 	s.memorySeries.Set(float64(s.fpToSeries.length()))
 
 	s.mapper, err = newFPMapper(s.fpToSeries, p)
@@ -452,19 +492,49 @@ func (s *MemorySeriesStorage) Start() (err error) {
 	return nil
 }
 
+// loadOldestSampleTime returns the time of the oldest sample of the loaded and
+// archived series, or the current time if the storage holds no samples yet.
+func (s *MemorySeriesStorage) loadOldestSampleTime() (model.Time, error) {
+	oldest, err := s.persistence.oldestArchivedSampleTime()
+	if err != nil {
+		return 0, err
+	}
+	for _, series := range s.fpToSeries.m {
+		if t := series.firstTime(); t.Before(oldest) {
+			oldest = t
+		}
+	}
+	if now := model.Now(); oldest.After(now) {
+		// An empty storage holds complete data from its start on.
+		oldest = now
+	}
+	return oldest, nil
+}
+
+// OldestSampleTime returns the time from which on the storage is expected to
+// hold complete data. That is the time of the oldest sample held at startup,
+// or the startup time for a new storage, but no earlier than the retention
+// period allows.
+func (s *MemorySeriesStorage) OldestSampleTime() model.Time {
+	if retained := model.Now().Add(-s.dropAfter); retained.After(s.oldestSampleTime) {
+		return retained
+	}
+	return s.oldestSampleTime
+}
+
 // Stop implements Storage.
 func (s *MemorySeriesStorage) Stop() error {
-	log.Info("Stopping local storage...")
+	logger.Info("Stopping local storage...")
 
-	log.Info("Stopping maintenance loop...")
+	logger.Info("Stopping maintenance loop...")
 	close(s.loopStopping)
 	<-s.loopStopped
 
-	log.Info("Stopping series quarantining...")
+	logger.Info("Stopping series quarantining...")
 	close(s.quarantineStopping)
 	<-s.quarantineStopped
 
-	log.Info("Stopping chunk eviction...")
+	logger.Info("Stopping chunk eviction...")
 	close(s.evictStopping)
 	<-s.evictStopped
 
@@ -481,21 +551,36 @@ func (s *MemorySeriesStorage) Stop() error {
 	if err := s.persistence.close(); err != nil {
 		return err
 	}
-	log.Info("Local storage stopped.")
+	logger.Info("Local storage stopped.")
 	return nil
 }
 
 type memorySeriesStorageQuerier struct {
 	*MemorySeriesStorage
+	tracker *queryTracker
 }
 
 func (memorySeriesStorageQuerier) Close() error {
 	return nil
 }
 
-// Querier implements the storage interface.
+// QueryRange implements Querier.
+func (q memorySeriesStorageQuerier) QueryRange(ctx context.Context, from, through model.Time, matchers ...*metric.LabelMatcher) ([]SeriesIterator, error) {
+	return q.queryRange(ctx, q.tracker, from, through, matchers...)
+}
+
+// QueryInstant implements Querier.
+func (q memorySeriesStorageQuerier) QueryInstant(ctx context.Context, ts model.Time, stalenessDelta time.Duration, matchers ...*metric.LabelMatcher) ([]SeriesIterator, error) {
+	return q.queryInstant(ctx, q.tracker, ts, stalenessDelta, matchers...)
+}
+
+// Querier implements the storage interface. The series and chunks touched by
+// the queries of a querier count towards the limits per query.
 func (s *MemorySeriesStorage) Querier() (Querier, error) {
-	return memorySeriesStorageQuerier{s}, nil
+	return memorySeriesStorageQuerier{
+		MemorySeriesStorage: s,
+		tracker:             newQueryTracker(s.maxSeriesPerQuery, s.maxChunksPerQuery),
+	}, nil
 }
 
 // WaitForIndexing implements Storage.
@@ -573,8 +658,12 @@ func (bit *boundedIterator) Close() {
 	bit.it.Close()
 }
 
-// QueryRange implements Storage.
+// QueryRange implements Storage. The limits per query do not apply.
 func (s *MemorySeriesStorage) QueryRange(ctx context.Context, from, through model.Time, matchers ...*metric.LabelMatcher) ([]SeriesIterator, error) {
+	return s.queryRange(ctx, nil, from, through, matchers...)
+}
+
+func (s *MemorySeriesStorage) queryRange(ctx context.Context, tracker *queryTracker, from, through model.Time, matchers ...*metric.LabelMatcher) ([]SeriesIterator, error) {
 	span, _ := opentracing.StartSpanFromContext(ctx, "QueryRange")
 	span.SetTag(selectorsTag, metric.LabelMatchers(matchers).String())
 	span.SetTag(fromTag, int64(from))
@@ -585,21 +674,35 @@ func (s *MemorySeriesStorage) QueryRange(ctx context.Context, from, through mode
 		// In that case, nothing will match.
 		return nil, nil
 	}
-	fpSeriesPairs, err := s.seriesForLabelMatchers(from, through, matchers...)
+	fpSeriesPairs, err := s.seriesForLabelMatchers(from, through, tracker, matchers...)
 	if err != nil {
 		return nil, err
 	}
 	span.SetTag(numSeries, len(fpSeriesPairs))
 	iterators := make([]SeriesIterator, 0, len(fpSeriesPairs))
 	for _, pair := range fpSeriesPairs {
-		it := s.preloadChunksForRange(pair, from, through)
+		if err := ctx.Err(); err != nil {
+			// Preloading might load chunks from disk for each series,
+			// so stop doing it for abandoned queries.
+			closeIterators(iterators)
+			return nil, err
+		}
+		it := s.preloadChunksForRange(ctx, pair, from, through)
 		iterators = append(iterators, it)
+		if err := tracker.addChunks(pinnedChunks(it)); err != nil {
+			closeIterators(iterators)
+			return nil, err
+		}
 	}
 	return iterators, nil
 }
 
-// QueryInstant implements Storage.
+// QueryInstant implements Storage. The limits per query do not apply.
 func (s *MemorySeriesStorage) QueryInstant(ctx context.Context, ts model.Time, stalenessDelta time.Duration, matchers ...*metric.LabelMatcher) ([]SeriesIterator, error) {
+	return s.queryInstant(ctx, nil, ts, stalenessDelta, matchers...)
+}
+
+func (s *MemorySeriesStorage) queryInstant(ctx context.Context, tracker *queryTracker, ts model.Time, stalenessDelta time.Duration, matchers ...*metric.LabelMatcher) ([]SeriesIterator, error) {
 	span, _ := opentracing.StartSpanFromContext(ctx, "QueryInstant")
 	span.SetTag(selectorsTag, metric.LabelMatchers(matchers).String())
 	span.SetTag(tsTag, ts)
@@ -611,18 +714,33 @@ func (s *MemorySeriesStorage) QueryInstant(ctx context.Context, ts model.Time, s
 	from := ts.Add(-stalenessDelta)
 	through := ts
 
-	fpSeriesPairs, err := s.seriesForLabelMatchers(from, through, matchers...)
+	fpSeriesPairs, err := s.seriesForLabelMatchers(from, through, tracker, matchers...)
 	if err != nil {
 		return nil, err
 	}
 	iterators := make([]SeriesIterator, 0, len(fpSeriesPairs))
 	for _, pair := range fpSeriesPairs {
-		it := s.preloadChunksForInstant(pair, from, through)
+		if err := ctx.Err(); err != nil {
+			closeIterators(iterators)
+			return nil, err
+		}
+		it := s.preloadChunksForInstant(ctx, pair, from, through)
 		iterators = append(iterators, it)
+		if err := tracker.addChunks(pinnedChunks(it)); err != nil {
+			closeIterators(iterators)
+			return nil, err
+		}
 	}
 	return iterators, nil
 }
 
+// closeIterators closes the given iterators, unpinning their chunks.
+func closeIterators(its []SeriesIterator) {
+	for _, it := range its {
+		it.Close()
+	}
+}
+
 // fingerprintsForLabelPair returns the fingerprints with the given
 // LabelPair. If intersectWith is non-nil, the method will only return
 // fingerprints that are also contained in intersectsWith. If mergeWith is
@@ -650,26 +768,55 @@ func (s *MemorySeriesStorage) fingerprintsForLabelPair(
 
 // MetricsForLabelMatchers implements Storage.
 func (s *MemorySeriesStorage) MetricsForLabelMatchers(
-	_ context.Context,
+	ctx context.Context,
 	from, through model.Time,
 	matcherSets ...metric.LabelMatchers,
 ) ([]metric.Metric, error) {
-	fpToMetric := map[model.Fingerprint]metric.Metric{}
+	var metrics []metric.Metric
+	err := s.ForEachMetricForLabelMatchers(ctx, from, through, func(m metric.Metric) bool {
+		metrics = append(metrics, m)
+		return true
+	}, matcherSets...)
+	if err != nil {
+		return nil, err
+	}
+	if metrics == nil {
+		metrics = []metric.Metric{}
+	}
+	return metrics, nil
+}
+
+// ForEachMetricForLabelMatchers implements Storage.
+func (s *MemorySeriesStorage) ForEachMetricForLabelMatchers(
+	ctx context.Context,
+	from, through model.Time,
+	f func(metric.Metric) bool,
+	matcherSets ...metric.LabelMatchers,
+) error {
+	// Only the fingerprints of the metrics passed to f are kept to
+	// deduplicate the metrics matched by multiple sets of matchers.
+	seen := map[model.Fingerprint]struct{}{}
 	for _, matchers := range matcherSets {
-		metrics, err := s.metricsForLabelMatchers(from, through, matchers...)
-		if err != nil {
-			return nil, err
+		if err := ctx.Err(); err != nil {
+			return err
 		}
-		for fp, m := range metrics {
-			fpToMetric[fp] = m
+		done := false
+		err := s.forEachMetricForLabelMatchers(from, through, func(fp model.Fingerprint, m metric.Metric) bool {
+			if _, ok := seen[fp]; ok {
+				return true
+			}
+			seen[fp] = struct{}{}
+			if !f(m) {
+				done = true
+				return false
+			}
+			return true
+		}, matchers...)
+		if err != nil || done {
+			return err
 		}
 	}
-
-	metrics := make([]metric.Metric, 0, len(fpToMetric))
-	for _, m := range fpToMetric {
-		metrics = append(metrics, m)
-	}
-	return metrics, nil
+	return nil
 }
 
 // candidateFPsForLabelMatchers returns candidate FPs for given matchers and remaining matchers to be checked.
@@ -743,6 +890,7 @@ func (s *MemorySeriesStorage) candidateFPsForLabelMatchers(
 
 func (s *MemorySeriesStorage) seriesForLabelMatchers(
 	from, through model.Time,
+	tracker *queryTracker,
 	matchers ...*metric.LabelMatcher,
 ) ([]fingerprintSeriesPair, error) {
 	candidateFPs, matchersToCheck, err := s.candidateFPsForLabelMatchers(matchers...)
@@ -766,6 +914,9 @@ FPLoop:
 				continue FPLoop
 			}
 		}
+		if err := tracker.addSeries(1); err != nil {
+			return nil, err
+		}
 		result = append(result, fingerprintSeriesPair{fp, series})
 	}
 	return result, nil
@@ -801,17 +952,19 @@ FPLoop:
 	return candidateFPs, nil
 }
 
-func (s *MemorySeriesStorage) metricsForLabelMatchers(
+// forEachMetricForLabelMatchers calls f for each metric matching all the
+// matchers, until f returns false.
+func (s *MemorySeriesStorage) forEachMetricForLabelMatchers(
 	from, through model.Time,
+	f func(model.Fingerprint, metric.Metric) bool,
 	matchers ...*metric.LabelMatcher,
-) (map[model.Fingerprint]metric.Metric, error) {
+) error {
 
 	candidateFPs, matchersToCheck, err := s.candidateFPsForLabelMatchers(matchers...)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	result := map[model.Fingerprint]metric.Metric{}
 FPLoop:
 	for fp := range candidateFPs {
 		s.fpLocker.Lock(fp)
@@ -827,9 +980,11 @@ FPLoop:
 				continue FPLoop
 			}
 		}
-		result[fp] = metric.Metric{Metric: met}
+		if !f(fp, metric.Metric{Metric: met}) {
+			return nil
+		}
 	}
-	return result, nil
+	return nil
 }
 
 // metricForRange returns the metric for the given fingerprint if the
@@ -883,6 +1038,200 @@ func (s *MemorySeriesStorage) LabelValuesForLabelName(_ context.Context, labelNa
 	return s.persistence.labelValuesForLabelName(labelName)
 }
 
+// LabelNames implements Storage.
+func (s *MemorySeriesStorage) LabelNames(_ context.Context) (model.LabelNames, error) {
+	return s.persistence.labelNames()
+}
+
+// LabelValuesForLabelMatchers implements Querier.
+func (s *MemorySeriesStorage) LabelValuesForLabelMatchers(
+	ctx context.Context,
+	from, through model.Time,
+	labelName model.LabelName,
+	matcherSets ...metric.LabelMatchers,
+) (model.LabelValues, error) {
+	fps, err := s.indexedFPsForLabelMatcherSets(from, through, matcherSets...)
+	if err != nil || len(fps) == 0 {
+		return nil, err
+	}
+
+	set := map[model.LabelValue]struct{}{}
+	err = s.forEachMetricOfFPs(ctx, fps, func(m model.Metric) {
+		if lv, ok := m[labelName]; ok {
+			set[lv] = struct{}{}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	res := make(model.LabelValues, 0, len(set))
+	for lv := range set {
+		res = append(res, lv)
+	}
+	sort.Sort(res)
+	return res, nil
+}
+
+// LabelNamesForLabelMatchers implements Querier.
+func (s *MemorySeriesStorage) LabelNamesForLabelMatchers(
+	ctx context.Context,
+	from, through model.Time,
+	matcherSets ...metric.LabelMatchers,
+) (model.LabelNames, error) {
+	fps, err := s.indexedFPsForLabelMatcherSets(from, through, matcherSets...)
+	if err != nil || len(fps) == 0 {
+		return nil, err
+	}
+
+	set := map[model.LabelName]struct{}{}
+	err = s.forEachMetricOfFPs(ctx, fps, func(m model.Metric) {
+		for ln := range m {
+			set[ln] = struct{}{}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	res := make(model.LabelNames, 0, len(set))
+	for ln := range set {
+		res = append(res, ln)
+	}
+	sort.Sort(res)
+	return res, nil
+}
+
+// forEachMetricOfFPs calls f with the metric of each of the given
+// fingerprints. The metrics of series in memory are used directly, the ones
+// of archived series are looked up in the archive index. Fingerprints of
+// series purged in the meantime are skipped.
+func (s *MemorySeriesStorage) forEachMetricOfFPs(
+	ctx context.Context,
+	fps map[model.Fingerprint]struct{},
+	f func(model.Metric),
+) error {
+	for fp := range fps {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		s.fpLocker.Lock(fp)
+		var met model.Metric
+		if series, ok := s.fpToSeries.get(fp); ok {
+			met = series.metric
+		} else if m, err := s.persistence.archivedMetric(fp); err == nil {
+			// archivedMetric has already flagged the storage as
+			// dirty in case of an error.
+			met = m
+		}
+		s.fpLocker.Unlock(fp)
+
+		if met != nil {
+			f(met)
+		}
+	}
+	return nil
+}
+
+// indexedFPsForLabelMatcherSets returns the union of the fingerprints of the
+// series matching each set of matchers and having samples between 'from' and
+// 'through'. Unlike metricsForLabelMatchers, all matching is done on the
+// label pair index so that no metric has to be looked up.
+func (s *MemorySeriesStorage) indexedFPsForLabelMatcherSets(
+	from, through model.Time,
+	matcherSets ...metric.LabelMatchers,
+) (map[model.Fingerprint]struct{}, error) {
+	res := map[model.Fingerprint]struct{}{}
+	for _, matchers := range matcherSets {
+		fps, err := s.indexedFPsForLabelMatchers(matchers...)
+		if err != nil {
+			return nil, err
+		}
+		for fp := range fps {
+			res[fp] = struct{}{}
+		}
+	}
+
+	if from.After(model.Earliest) || through.Before(model.Latest) {
+		for fp := range res {
+			s.fpLocker.Lock(fp)
+			ok := s.hasSamplesInRange(fp, from, through)
+			s.fpLocker.Unlock(fp)
+			if !ok {
+				delete(res, fp)
+			}
+		}
+	}
+	return res, nil
+}
+
+// indexedFPsForLabelMatchers returns the fingerprints of the series matching
+// all the matchers by intersecting the fingerprints of the matching label
+// pairs, and removing the ones of the label pairs not matched by matchers
+// that match the empty string.
+func (s *MemorySeriesStorage) indexedFPsForLabelMatchers(
+	matchers ...*metric.LabelMatcher,
+) (map[model.Fingerprint]struct{}, error) {
+	sort.Sort(metric.LabelMatchers(matchers))
+
+	if len(matchers) == 0 || matchers[0].MatchesEmptyString() {
+		// No matchers at all or even the best matcher matches the empty string.
+		return nil, nil
+	}
+
+	var fps map[model.Fingerprint]struct{}
+	for _, m := range matchers {
+		if m.Type == metric.Equal && !m.MatchesEmptyString() {
+			fps = s.fingerprintsForLabelPair(model.LabelPair{Name: m.Name, Value: m.Value}, nil, fps)
+			if len(fps) == 0 {
+				return nil, nil
+			}
+			continue
+		}
+
+		lvs, err := s.LabelValuesForLabelName(context.TODO(), m.Name)
+		if err != nil {
+			return nil, err
+		}
+		if m.MatchesEmptyString() {
+			// Series without the label match, so only the ones with a
+			// non-matching value are removed.
+			for _, lv := range lvs {
+				if m.Match(lv) {
+					continue
+				}
+				for _, fp := range s.persistence.fingerprintsForLabelPair(model.LabelPair{Name: m.Name, Value: lv}) {
+					delete(fps, fp)
+				}
+			}
+		} else {
+			matched := map[model.Fingerprint]struct{}{}
+			for _, lv := range m.Filter(lvs) {
+				s.fingerprintsForLabelPair(model.LabelPair{Name: m.Name, Value: lv}, matched, fps)
+			}
+			fps = matched
+		}
+		if len(fps) == 0 {
+			return nil, nil
+		}
+	}
+	return fps, nil
+}
+
+// hasSamplesInRange returns whether the series of the given fingerprint has
+// samples between 'from' and 'through' without looking up its metric.
+//
+// The caller must have locked the fp.
+func (s *MemorySeriesStorage) hasSamplesInRange(fp model.Fingerprint, from, through model.Time) bool {
+	if series, ok := s.fpToSeries.get(fp); ok {
+		return !series.lastTime.Before(from) && !series.firstTime().After(through)
+	}
+	watermark := model.Time(atomic.LoadInt64((*int64)(&s.archiveHighWatermark)))
+	if watermark < from {
+		return false
+	}
+	has, first, last := s.persistence.hasArchivedMetric(fp)
+	return has && !first.After(through) && !last.Before(from)
+}
+
 // DropMetricsForLabelMatchers implements Storage.
 func (s *MemorySeriesStorage) DropMetricsForLabelMatchers(_ context.Context, matchers ...*metric.LabelMatcher) (int, error) {
 	fps, err := s.fpsForLabelMatchers(model.Earliest, model.Latest, matchers...)
@@ -1000,7 +1349,7 @@ func (s *MemorySeriesStorage) logThrottling() {
 		case <-s.throttled:
 			if !timer.Reset(time.Minute) {
 				score, _ := s.getPersistenceUrgencyScore()
-				log.
+				logger.
 					With("urgencyScore", score).
 					With("chunksToPersist", s.getNumChunksToPersist()).
 					With("memoryChunks", atomic.LoadInt64(&chunk.NumMemChunks)).
@@ -1008,7 +1357,7 @@ func (s *MemorySeriesStorage) logThrottling() {
 			}
 		case <-timer.C:
 			score, _ := s.getPersistenceUrgencyScore()
-			log.
+			logger.
 				With("urgencyScore", score).
 				With("chunksToPersist", s.getNumChunksToPersist()).
 				With("memoryChunks", atomic.LoadInt64(&chunk.NumMemChunks)).
@@ -1026,7 +1375,7 @@ func (s *MemorySeriesStorage) getOrCreateSeries(fp model.Fingerprint, m model.Me
 		var modTime time.Time
 		unarchived, err := s.persistence.unarchiveMetric(fp)
 		if err != nil {
-			log.Errorf("Error unarchiving fingerprint %v (metric %v): %v", fp, m, err)
+			logger.Errorf("Error unarchiving fingerprint %v (metric %v): %v", fp, m, err)
 			return nil, err
 		}
 		if unarchived {
@@ -1057,6 +1406,7 @@ func (s *MemorySeriesStorage) getOrCreateSeries(fp model.Fingerprint, m model.Me
 		}
 		s.fpToSeries.put(fp, series)
 		s.memorySeries.Inc()
+		s.churn.created(time.Now())
 		if !series.headChunkClosed {
 			s.headChunks.Inc()
 		}
@@ -1083,6 +1433,7 @@ func (s *MemorySeriesStorage) seriesForRange(
 }
 
 func (s *MemorySeriesStorage) preloadChunksForRange(
+	ctx context.Context,
 	pair fingerprintSeriesPair,
 	from model.Time, through model.Time,
 ) SeriesIterator {
@@ -1094,7 +1445,7 @@ func (s *MemorySeriesStorage) preloadChunksForRange(
 	s.fpLocker.Lock(fp)
 	defer s.fpLocker.Unlock(fp)
 
-	iter, err := series.preloadChunksForRange(fp, from, through, s)
+	iter, err := series.preloadChunksForRange(ctx, fp, from, through, s)
 	if err != nil {
 		s.quarantineSeries(fp, series.metric, err)
 		return nopIter
@@ -1103,6 +1454,7 @@ func (s *MemorySeriesStorage) preloadChunksForRange(
 }
 
 func (s *MemorySeriesStorage) preloadChunksForInstant(
+	ctx context.Context,
 	pair fingerprintSeriesPair,
 	from model.Time, through model.Time,
 ) SeriesIterator {
@@ -1114,7 +1466,7 @@ func (s *MemorySeriesStorage) preloadChunksForInstant(
 	s.fpLocker.Lock(fp)
 	defer s.fpLocker.Unlock(fp)
 
-	iter, err := series.preloadChunksForInstant(fp, from, through, s)
+	iter, err := series.preloadChunksForInstant(ctx, fp, from, through, s)
 	if err != nil {
 		s.quarantineSeries(fp, series.metric, err)
 		return nopIter
@@ -1150,7 +1502,7 @@ func (s *MemorySeriesStorage) handleEvictList() {
 				}
 			}()
 			ticker.Stop()
-			log.Info("Chunk eviction stopped.")
+			logger.Info("Chunk eviction stopped.")
 			close(s.evictStopped)
 			return
 		}
@@ -1392,7 +1744,7 @@ func (s *MemorySeriesStorage) cycleThroughMemoryFingerprints() chan model.Finger
 				if firstPass {
 					msg = "initial partial"
 				}
-				log.Infof(
+				logger.Infof(
 					"Completed %s maintenance sweep through %d in-memory fingerprints in %v.",
 					msg, count, time.Since(begin),
 				)
@@ -1413,11 +1765,19 @@ func (s *MemorySeriesStorage) cycleThroughArchivedFingerprints() chan model.Fing
 		defer close(archivedFingerprints)
 
 		for {
+			// Archived series are only maintained to be purged, so
+			// there is nothing to do while purging is not active.
+			if !s.purgingActive(time.Now()) {
+				if !s.waitForNextFP(0, 1) {
+					return
+				}
+				continue
+			}
 			archivedFPs, err := s.persistence.fingerprintsModifiedBefore(
 				model.Now().Add(-s.dropAfter),
 			)
 			if err != nil {
-				log.Error("Failed to lookup archived fingerprint ranges: ", err)
+				logger.Error("Failed to lookup archived fingerprint ranges: ", err)
 				s.waitForNextFP(0, 1)
 				continue
 			}
@@ -1436,7 +1796,7 @@ func (s *MemorySeriesStorage) cycleThroughArchivedFingerprints() chan model.Fing
 				s.waitForNextFP(len(archivedFPs), 1)
 			}
 			if len(archivedFPs) > 0 {
-				log.Infof(
+				logger.Infof(
 					"Completed maintenance sweep through %d archived fingerprints in %v.",
 					len(archivedFPs), time.Since(begin),
 				)
@@ -1455,7 +1815,7 @@ func (s *MemorySeriesStorage) loop() {
 	defer func() {
 		checkpointTimer.Stop()
 		checkpointMinTimer.Stop()
-		log.Info("Maintenance loop stopped.")
+		logger.Info("Maintenance loop stopped.")
 		close(s.loopStopped)
 	}()
 
@@ -1481,10 +1841,10 @@ func (s *MemorySeriesStorage) loop() {
 			checkpointCtx, s.fpToSeries, s.fpLocker,
 		)
 		if err == context.Canceled {
-			log.Info("Checkpoint canceled.")
+			logger.Info("Checkpoint canceled.")
 		} else if err != nil {
 			s.persistErrors.Inc()
-			log.Errorln("Error while checkpointing:", err)
+			logger.Errorln("Error while checkpointing:", err)
 		}
 		return time.Since(start)
 	}
@@ -1525,7 +1885,7 @@ loop:
 			checkpointCancel()
 			break loop
 		case fp := <-memoryFingerprints:
-			if s.maintainMemorySeries(fp, model.Now().Add(-s.dropAfter)) {
+			if s.maintainMemorySeries(fp, s.purgeBefore()) {
 				dirty := atomic.AddInt64(&dirtySeriesCount, 1)
 				s.dirtySeries.Set(float64(dirty))
 				// Check if we have enough "dirty" series so that we need an early checkpoint.
@@ -1545,7 +1905,7 @@ loop:
 				}
 			}
 		case fp := <-archivedFingerprints:
-			s.maintainArchivedSeries(fp, model.Now().Add(-s.dropAfter))
+			s.maintainArchivedSeries(fp, s.purgeBefore())
 		}
 	}
 	// Wait until both channels are closed.
@@ -1583,7 +1943,10 @@ loop:
 // - If no chunks need to be purged and no chunks need to be persisted, nothing
 // happens in this step.
 //
-// Next, the method checks if all chunks in the series are evicted. In that
+// Next, if the series has not received samples for the duration of
+// seriesIdleTimeout and all its chunks are persisted, it evicts all its chunks.
+//
+// Then the method checks if all chunks in the series are evicted. In that
 // case, it archives the series and returns true.
 //
 // Finally, it evicts chunk.Descs if there are too many.
@@ -1624,6 +1987,23 @@ func (s *MemorySeriesStorage) maintainMemorySeries(
 		return false
 	}
 
+	idle := s.seriesIdleTimeout > 0 &&
+		model.Now().Sub(series.lastTime) > s.seriesIdleTimeout &&
+		series.headChunkClosed &&
+		series.persistWatermark == len(series.chunkDescs)
+	if idle {
+		for _, cd := range series.chunkDescs {
+			if !cd.MaybeEvict() {
+				// The chunk is in use by a query.
+				idle = false
+				continue
+			}
+			// Take the chunk off the evict list so that the chunk.Desc
+			// does not stay referenced after archiving.
+			s.evictRequests <- chunk.EvictRequest{Desc: cd, Evict: false}
+		}
+	}
+
 	iOldestNotEvicted := -1
 	for i, cd := range series.chunkDescs {
 		if !cd.IsEvicted() {
@@ -1637,17 +2017,13 @@ func (s *MemorySeriesStorage) maintainMemorySeries(
 	if iOldestNotEvicted == -1 && model.Now().Sub(series.lastTime) > s.headChunkTimeout {
 		s.fpToSeries.del(fp)
 		s.memorySeries.Dec()
+		s.churn.removed(time.Now())
 		s.persistence.archiveMetric(fp, series.metric, series.firstTime(), series.lastTime)
 		s.seriesOps.WithLabelValues(archive).Inc()
-		oldWatermark := atomic.LoadInt64((*int64)(&s.archiveHighWatermark))
-		if oldWatermark < int64(series.lastTime) {
-			if !atomic.CompareAndSwapInt64(
-				(*int64)(&s.archiveHighWatermark),
-				oldWatermark, int64(series.lastTime),
-			) {
-				panic("s.archiveHighWatermark modified outside of maintainMemorySeries")
-			}
+		if idle {
+			s.seriesOps.WithLabelValues(idleArchive).Inc()
 		}
+		s.raiseArchiveHighWatermark(series.lastTime)
 		return
 	}
 	// If we are here, the series is not archived, so check for chunk.Desc
@@ -1657,6 +2033,24 @@ func (s *MemorySeriesStorage) maintainMemorySeries(
 	return series.dirty && !seriesWasDirty
 }
 
+// raiseArchiveHighWatermark sets archiveHighWatermark to t unless it is later
+// already. Series are archived by maintainMemorySeries and imported by
+// ImportBlock concurrently, so the watermark is raised in a CAS loop.
+func (s *MemorySeriesStorage) raiseArchiveHighWatermark(t model.Time) {
+	for {
+		oldWatermark := atomic.LoadInt64((*int64)(&s.archiveHighWatermark))
+		if oldWatermark >= int64(t) {
+			return
+		}
+		if atomic.CompareAndSwapInt64(
+			(*int64)(&s.archiveHighWatermark),
+			oldWatermark, int64(t),
+		) {
+			return
+		}
+	}
+}
+
 // writeMemorySeries (re-)writes a memory series file. While doing so, it drops
 // chunks older than beforeTime from both the series file (if it exists) as well
 // as from memory. The provided chunksToPersist are appended to the newly
@@ -1734,6 +2128,7 @@ func (s *MemorySeriesStorage) writeMemorySeries(
 		// All chunks dropped from both memory and persistence. Delete the series for good.
 		s.fpToSeries.del(fp)
 		s.memorySeries.Dec()
+		s.churn.removed(time.Now())
 		s.seriesOps.WithLabelValues(memoryPurge).Inc()
 		s.persistence.unindexMetric(fp, series.metric)
 		return true
@@ -1776,7 +2171,7 @@ func (s *MemorySeriesStorage) maintainArchivedSeries(fp model.Fingerprint, befor
 	if err != nil {
 		// TODO(beorn7): Should quarantine the series.
 		s.persistErrors.Inc()
-		log.Error("Error dropping persisted chunks: ", err)
+		logger.Error("Error dropping persisted chunks: ", err)
 	}
 	if allDropped {
 		if err := s.persistence.purgeArchivedMetric(fp); err != nil {
@@ -1788,7 +2183,7 @@ func (s *MemorySeriesStorage) maintainArchivedSeries(fp model.Fingerprint, befor
 	}
 	if err := s.persistence.updateArchivedTimeRange(fp, newFirstTime, lastTime); err != nil {
 		s.persistErrors.Inc()
-		log.Errorf("Error updating archived time range for fingerprint %v: %s", fp, err)
+		logger.Errorf("Error updating archived time range for fingerprint %v: %s", fp, err)
 	}
 }
 
@@ -1850,7 +2245,7 @@ func (s *MemorySeriesStorage) getPersistenceUrgencyScore() (float64, bool) {
 		}
 		// We are out of rushed mode!
 		s.rushed = false
-		log.
+		logger.
 			With("urgencyScore", score).
 			With("chunksToPersist", s.getNumChunksToPersist()).
 			With("memoryChunks", atomic.LoadInt64(&chunk.NumMemChunks)).
@@ -1860,7 +2255,7 @@ func (s *MemorySeriesStorage) getPersistenceUrgencyScore() (float64, bool) {
 	if score > persintenceUrgencyScoreForEnteringRushedMode {
 		// Enter rushed mode.
 		s.rushed = true
-		log.
+		logger.
 			With("urgencyScore", score).
 			With("chunksToPersist", s.getNumChunksToPersist()).
 			With("memoryChunks", atomic.LoadInt64(&chunk.NumMemChunks)).
@@ -1884,7 +2279,7 @@ func (s *MemorySeriesStorage) quarantineSeries(fp model.Fingerprint, metric mode
 	case s.quarantineRequests <- req:
 		// Request submitted.
 	default:
-		log.
+		logger.
 			With("fingerprint", fp).
 			With("metric", metric).
 			With("reason", err).
@@ -1898,13 +2293,13 @@ func (s *MemorySeriesStorage) handleQuarantine() {
 		select {
 		case req := <-s.quarantineRequests:
 			s.purgeSeries(req.fp, req.metric, req.reason)
-			log.
+			logger.
 				With("fingerprint", req.fp).
 				With("metric", req.metric).
 				With("reason", req.reason).
 				Warn("Series quarantined.")
 		case <-s.quarantineStopping:
-			log.Info("Series quarantining stopped.")
+			logger.Info("Series quarantining stopped.")
 			close(s.quarantineStopped)
 			return
 		}
@@ -1927,6 +2322,7 @@ func (s *MemorySeriesStorage) purgeSeries(fp model.Fingerprint, m model.Metric,
 	if series, ok = s.fpToSeries.get(fp); ok {
 		s.fpToSeries.del(fp)
 		s.memorySeries.Dec()
+		s.churn.removed(time.Now())
 		m = series.metric
 
 		// Adjust s.chunksToPersist and chunk.NumMemChunks down by
@@ -1955,7 +2351,7 @@ func (s *MemorySeriesStorage) purgeSeries(fp model.Fingerprint, m model.Metric,
 	if quarantineReason == nil {
 		// No reason stated, simply delete the file.
 		if _, err := s.persistence.deleteSeriesFile(fp); err != nil {
-			log.
+			logger.
 				With("fingerprint", fp).
 				With("metric", m).
 				With("error", err).
@@ -1967,7 +2363,7 @@ func (s *MemorySeriesStorage) purgeSeries(fp model.Fingerprint, m model.Metric,
 			s.seriesOps.WithLabelValues(completedQurantine).Inc()
 		} else {
 			s.seriesOps.WithLabelValues(failedQuarantine).Inc()
-			log.
+			logger.
 				With("fingerprint", fp).
 				With("metric", m).
 				With("reason", quarantineReason).
@@ -1998,6 +2394,7 @@ func (s *MemorySeriesStorage) Describe(ch chan<- *prometheus.Desc) {
 	s.maintainSeriesDuration.Describe(ch)
 	ch <- s.persistenceUrgencyScore.Desc()
 	ch <- s.rushedMode.Desc()
+	ch <- s.purgingActiveGauge.Desc()
 	ch <- s.targetHeapSizeBytes.Desc()
 }
 
@@ -2020,5 +2417,6 @@ func (s *MemorySeriesStorage) Collect(ch chan<- prometheus.Metric) {
 	s.maintainSeriesDuration.Collect(ch)
 	ch <- s.persistenceUrgencyScore
 	ch <- s.rushedMode
+	ch <- s.purgingActiveGauge
 	ch <- s.targetHeapSizeBytes
 }
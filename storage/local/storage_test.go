@@ -62,7 +62,7 @@ func TestMatches(t *testing.T) {
 	}
 	storage.WaitForIndexing()
 
-	// Archive every tenth metric.
+	// Archive every tenth metric and drop it from memory.
 	for i, fp := range fingerprints {
 		if i%10 != 0 {
 			continue
@@ -73,6 +73,7 @@ func TestMatches(t *testing.T) {
 		}
 		storage.fpLocker.Lock(fp)
 		storage.persistence.archiveMetric(fp, s.metric, s.firstTime(), s.lastTime)
+		storage.fpToSeries.del(fp)
 		storage.fpLocker.Unlock(fp)
 	}
 
@@ -197,6 +198,47 @@ func TestMatches(t *testing.T) {
 		},
 	}
 
+	// checkLabels checks that the label names and values of the series
+	// matching in the given range are the ones of the given metrics.
+	checkLabels := func(matchers metric.LabelMatchers, from, through model.Time, metrics []metric.Metric) {
+		names := map[model.LabelName]struct{}{}
+		values := map[model.LabelValue]struct{}{}
+		for _, m := range metrics {
+			for ln := range m.Metric {
+				names[ln] = struct{}{}
+			}
+			if lv, ok := m.Metric["label2"]; ok {
+				values[lv] = struct{}{}
+			}
+		}
+
+		lns, err := storage.LabelNamesForLabelMatchers(context.Background(), from, through, matchers)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(lns) != len(names) {
+			t.Errorf("expected %d label names for %q in [%v, %v], found %v", len(names), matchers, from, through, lns)
+		}
+		for _, ln := range lns {
+			if _, ok := names[ln]; !ok {
+				t.Errorf("unexpected label name %q for %q in [%v, %v]", ln, matchers, from, through)
+			}
+		}
+
+		lvs, err := storage.LabelValuesForLabelMatchers(context.Background(), from, through, "label2", matchers)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(lvs) != len(values) {
+			t.Errorf("expected %d label values for %q in [%v, %v], found %v", len(values), matchers, from, through, lvs)
+		}
+		for _, lv := range lvs {
+			if _, ok := values[lv]; !ok {
+				t.Errorf("unexpected label value %q for %q in [%v, %v]", lv, matchers, from, through)
+			}
+		}
+	}
+
 	for _, mt := range matcherTests {
 		metrics, err := storage.MetricsForLabelMatchers(
 			context.Background(),
@@ -206,6 +248,7 @@ func TestMatches(t *testing.T) {
 		if err != nil {
 			t.Fatal(err)
 		}
+		checkLabels(mt.matchers, model.Earliest, model.Latest, metrics)
 		if len(mt.expected) != len(metrics) {
 			t.Fatalf("expected %d matches for %q, found %d", len(mt.expected), mt.matchers, len(metrics))
 		}
@@ -273,6 +316,7 @@ func TestMatches(t *testing.T) {
 		if len(expected) != len(metrics) {
 			t.Errorf("expected %d range-limited matches for %q, found %d", len(expected), mt.matchers, len(metrics))
 		}
+		checkLabels(mt.matchers, from, through, metrics)
 		for _, m := range metrics {
 			fp1 := m.Metric.FastFingerprint()
 			found := false
@@ -593,6 +637,53 @@ func TestRetentionCutoff(t *testing.T) {
 	}
 }
 
+func TestQueryCanceled(t *testing.T) {
+	now := model.Now()
+	insertStart := now.Add(-2 * time.Hour)
+
+	s, closer := NewTestStorage(t, 2)
+	defer closer.Close()
+
+	for i := 0; i < 120; i++ {
+		s.Append(&model.Sample{
+			Metric:    model.Metric{"job": "test"},
+			Timestamp: insertStart.Add(time.Duration(i) * time.Minute),
+			Value:     1,
+		})
+	}
+	s.WaitForIndexing()
+
+	lm, err := metric.NewLabelMatcher(metric.Equal, "job", "test")
+	if err != nil {
+		t.Fatalf("error creating label matcher: %s", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := s.QueryRange(ctx, insertStart, now, lm); err != context.Canceled {
+		t.Errorf("expected context.Canceled from QueryRange, got %v", err)
+	}
+	if _, err := s.QueryInstant(ctx, now, time.Hour, lm); err != context.Canceled {
+		t.Errorf("expected context.Canceled from QueryInstant, got %v", err)
+	}
+
+	// Iterators stop returning range values once their query is canceled.
+	ctx, cancel = context.WithCancel(context.Background())
+	its, err := s.QueryRange(ctx, insertStart, now, lm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closeIterators(its)
+	in := metric.Interval{OldestInclusive: insertStart, NewestInclusive: now}
+	if vals := its[0].RangeValues(in); len(vals) != 120 {
+		t.Fatalf("expected 120 values, got %d", len(vals))
+	}
+	cancel()
+	if vals := its[0].RangeValues(in); len(vals) != 0 {
+		t.Errorf("expected no values after cancellation, got %d", len(vals))
+	}
+}
+
 func TestDropMetrics(t *testing.T) {
 	now := model.Now()
 	insertStart := now.Add(-2 * time.Hour)
@@ -672,12 +763,12 @@ func TestDropMetrics(t *testing.T) {
 		t.Errorf("unexpected number of fingerprints: %d", len(fps2))
 	}
 
-	it := s.preloadChunksForRange(makeFingerprintSeriesPair(s, fpList[0]), model.Earliest, model.Latest)
+	it := s.preloadChunksForRange(context.Background(), makeFingerprintSeriesPair(s, fpList[0]), model.Earliest, model.Latest)
 	if vals := it.RangeValues(metric.Interval{OldestInclusive: insertStart, NewestInclusive: now}); len(vals) != 0 {
 		t.Errorf("unexpected number of samples: %d", len(vals))
 	}
 
-	it = s.preloadChunksForRange(makeFingerprintSeriesPair(s, fpList[1]), model.Earliest, model.Latest)
+	it = s.preloadChunksForRange(context.Background(), makeFingerprintSeriesPair(s, fpList[1]), model.Earliest, model.Latest)
 	if vals := it.RangeValues(metric.Interval{OldestInclusive: insertStart, NewestInclusive: now}); len(vals) != N {
 		t.Errorf("unexpected number of samples: %d", len(vals))
 	}
@@ -705,12 +796,12 @@ func TestDropMetrics(t *testing.T) {
 		t.Errorf("unexpected number of fingerprints: %d", len(fps3))
 	}
 
-	it = s.preloadChunksForRange(makeFingerprintSeriesPair(s, fpList[0]), model.Earliest, model.Latest)
+	it = s.preloadChunksForRange(context.Background(), makeFingerprintSeriesPair(s, fpList[0]), model.Earliest, model.Latest)
 	if vals := it.RangeValues(metric.Interval{OldestInclusive: insertStart, NewestInclusive: now}); len(vals) != 0 {
 		t.Errorf("unexpected number of samples: %d", len(vals))
 	}
 
-	it = s.preloadChunksForRange(makeFingerprintSeriesPair(s, fpList[1]), model.Earliest, model.Latest)
+	it = s.preloadChunksForRange(context.Background(), makeFingerprintSeriesPair(s, fpList[1]), model.Earliest, model.Latest)
 	if vals := it.RangeValues(metric.Interval{OldestInclusive: insertStart, NewestInclusive: now}); len(vals) != 0 {
 		t.Errorf("unexpected number of samples: %d", len(vals))
 	}
@@ -788,7 +879,7 @@ func TestQuarantineMetric(t *testing.T) {
 	}
 
 	// This will access the corrupt file and lead to quarantining.
-	iter := s.preloadChunksForInstant(makeFingerprintSeriesPair(s, fpToBeArchived), now.Add(-2*time.Hour-1*time.Minute), now.Add(-2*time.Hour))
+	iter := s.preloadChunksForInstant(context.Background(), makeFingerprintSeriesPair(s, fpToBeArchived), now.Add(-2*time.Hour-1*time.Minute), now.Add(-2*time.Hour))
 	iter.Close()
 	time.Sleep(time.Second) // Give time to quarantine. TODO(beorn7): Find a better way to wait.
 	s.WaitForIndexing()
@@ -859,6 +950,57 @@ func TestLoop(t *testing.T) {
 	}
 }
 
+func TestOldestSampleTime(t *testing.T) {
+	directory := testutil.NewTemporaryDirectory("test_storage", t)
+	defer directory.Close()
+	o := &MemorySeriesStorageOptions{
+		TargetHeapSize:             1000000000,
+		PersistenceRetentionPeriod: 24 * time.Hour,
+		PersistenceStoragePath:     directory.Path(),
+		HeadChunkTimeout:           5 * time.Minute,
+		CheckpointInterval:         time.Hour,
+		SyncStrategy:               Adaptive,
+	}
+
+	before := model.Now()
+	s := NewMemorySeriesStorage(o)
+	if err := s.Start(); err != nil {
+		t.Fatalf("Error starting storage: %s", err)
+	}
+	// A new storage holds complete data from its start on.
+	if oldest := s.OldestSampleTime(); oldest.Before(before) || oldest.After(model.Now()) {
+		t.Errorf("Expected the start time of an empty storage, got %v", oldest)
+	}
+
+	insertStart := before.Add(-2 * time.Hour)
+	for i := 0; i < 120; i++ {
+		s.Append(&model.Sample{
+			Metric:    model.Metric{"job": "test"},
+			Timestamp: insertStart.Add(time.Duration(i) * time.Minute),
+			Value:     1,
+		})
+	}
+	s.WaitForIndexing()
+	if err := s.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	s = NewMemorySeriesStorage(o)
+	if err := s.Start(); err != nil {
+		t.Fatalf("Error restarting storage: %s", err)
+	}
+	defer s.Stop()
+	if oldest := s.OldestSampleTime(); oldest != insertStart {
+		t.Errorf("Expected the oldest sample time %v after a restart, got %v", insertStart, oldest)
+	}
+
+	// No data beyond the retention period is expected to be held.
+	s.dropAfter = time.Hour
+	if oldest := s.OldestSampleTime(); oldest.Before(before.Add(-time.Hour)) {
+		t.Errorf("Expected the oldest sample time to be within the retention period, got %v", oldest)
+	}
+}
+
 func testChunk(t *testing.T, encoding chunk.Encoding) {
 	samples := make(model.Samples, 500000)
 	for i := range samples {
@@ -916,6 +1058,58 @@ func TestChunkType2(t *testing.T) {
 	testChunk(t, 2)
 }
 
+func TestChunkEncodingRules(t *testing.T) {
+	var rules chunk.EncodingRules
+	if err := rules.Set("lossy_.*=float32"); err != nil {
+		t.Fatal(err)
+	}
+	chunk.MetricEncodings = rules
+	defer func() { chunk.MetricEncodings = nil }()
+
+	s, closer := NewTestStorage(t, chunk.DoubleDelta)
+	defer closer.Close()
+
+	for i := 0; i < 5000; i++ {
+		for _, name := range []model.LabelValue{"lossy_metric", "exact_metric"} {
+			s.Append(&model.Sample{
+				Metric:    model.Metric{model.MetricNameLabel: name},
+				Timestamp: model.Time(i),
+				Value:     model.SampleValue(float64(i) * 0.1),
+			})
+		}
+	}
+	s.WaitForIndexing()
+
+	for m := range s.fpToSeries.iter() {
+		s.fpLocker.Lock(m.fp)
+		expected := chunk.DoubleDelta
+		if m.series.metric[model.MetricNameLabel] == "lossy_metric" {
+			expected = chunk.Float32
+		}
+		var i int
+		for _, cd := range m.series.chunkDescs {
+			if cd.C.Encoding() != expected {
+				t.Errorf("%s: expected chunk encoding %d, got %d", m.series.metric, expected, cd.C.Encoding())
+			}
+			it := cd.C.NewIterator()
+			for it.Scan() {
+				v := model.SampleValue(float64(i) * 0.1)
+				if expected == chunk.Float32 {
+					v = model.SampleValue(float32(v))
+				}
+				if it.Value().Value != v {
+					t.Errorf("%s: %d. got %v; want %v", m.series.metric, i, it.Value().Value, v)
+				}
+				i++
+			}
+		}
+		if i != 5000 {
+			t.Errorf("%s: expected 5000 samples, got %d", m.series.metric, i)
+		}
+		s.fpLocker.Unlock(m.fp)
+	}
+}
+
 func testValueAtOrBeforeTime(t *testing.T, encoding chunk.Encoding) {
 	samples := make(model.Samples, 10000)
 	for i := range samples {
@@ -934,7 +1128,7 @@ func testValueAtOrBeforeTime(t *testing.T, encoding chunk.Encoding) {
 
 	fp := model.Metric{}.FastFingerprint()
 
-	it := s.preloadChunksForRange(makeFingerprintSeriesPair(s, fp), model.Earliest, model.Latest)
+	it := s.preloadChunksForRange(context.Background(), makeFingerprintSeriesPair(s, fp), model.Earliest, model.Latest)
 
 	// #1 Exactly on a sample.
 	for i, expected := range samples {
@@ -1034,7 +1228,7 @@ func benchmarkValueAtOrBeforeTime(b *testing.B, encoding chunk.Encoding) {
 
 	fp := model.Metric{}.FastFingerprint()
 
-	it := s.preloadChunksForRange(makeFingerprintSeriesPair(s, fp), model.Earliest, model.Latest)
+	it := s.preloadChunksForRange(context.Background(), makeFingerprintSeriesPair(s, fp), model.Earliest, model.Latest)
 
 	b.ResetTimer()
 
@@ -1116,7 +1310,7 @@ func testRangeValues(t *testing.T, encoding chunk.Encoding) {
 
 	fp := model.Metric{}.FastFingerprint()
 
-	it := s.preloadChunksForRange(makeFingerprintSeriesPair(s, fp), model.Earliest, model.Latest)
+	it := s.preloadChunksForRange(context.Background(), makeFingerprintSeriesPair(s, fp), model.Earliest, model.Latest)
 
 	// #1 Zero length interval at sample.
 	for i, expected := range samples {
@@ -1272,7 +1466,7 @@ func benchmarkRangeValues(b *testing.B, encoding chunk.Encoding) {
 
 	fp := model.Metric{}.FastFingerprint()
 
-	it := s.preloadChunksForRange(makeFingerprintSeriesPair(s, fp), model.Earliest, model.Latest)
+	it := s.preloadChunksForRange(context.Background(), makeFingerprintSeriesPair(s, fp), model.Earliest, model.Latest)
 
 	b.ResetTimer()
 
@@ -1322,7 +1516,7 @@ func testEvictAndPurgeSeries(t *testing.T, encoding chunk.Encoding) {
 
 	// Drop ~half of the chunks.
 	s.maintainMemorySeries(fp, 10000)
-	it := s.preloadChunksForRange(makeFingerprintSeriesPair(s, fp), model.Earliest, model.Latest)
+	it := s.preloadChunksForRange(context.Background(), makeFingerprintSeriesPair(s, fp), model.Earliest, model.Latest)
 	actual := it.RangeValues(metric.Interval{
 		OldestInclusive: 0,
 		NewestInclusive: 100000,
@@ -1340,7 +1534,7 @@ func testEvictAndPurgeSeries(t *testing.T, encoding chunk.Encoding) {
 
 	// Drop everything.
 	s.maintainMemorySeries(fp, 100000)
-	it = s.preloadChunksForRange(makeFingerprintSeriesPair(s, fp), model.Earliest, model.Latest)
+	it = s.preloadChunksForRange(context.Background(), makeFingerprintSeriesPair(s, fp), model.Earliest, model.Latest)
 	actual = it.RangeValues(metric.Interval{
 		OldestInclusive: 0,
 		NewestInclusive: 100000,
@@ -1511,7 +1705,7 @@ func testEvictAndLoadChunkDescs(t *testing.T, encoding chunk.Encoding) {
 	}
 
 	// Load everything back.
-	it := s.preloadChunksForRange(makeFingerprintSeriesPair(s, fp), 0, 100000)
+	it := s.preloadChunksForRange(context.Background(), makeFingerprintSeriesPair(s, fp), 0, 100000)
 
 	if oldLen != len(series.chunkDescs) {
 		t.Errorf("Expected number of chunkDescs to have reached old value again, old number %d, current number %d.", oldLen, len(series.chunkDescs))
@@ -1535,6 +1729,59 @@ func TestEvictAndLoadChunkDescsType1(t *testing.T) {
 	testEvictAndLoadChunkDescs(t, 1)
 }
 
+func TestArchiveIdleSeries(t *testing.T) {
+	s, closer := NewTestStorage(t, 1)
+	defer closer.Close()
+
+	s.seriesIdleTimeout = time.Hour
+
+	var (
+		idle   = model.Metric{model.MetricNameLabel: "test", "series": "idle"}
+		active = model.Metric{model.MetricNameLabel: "test", "series": "active"}
+		now    = model.Now()
+	)
+	for i := 0; i < 100; i++ {
+		s.Append(&model.Sample{
+			Metric:    idle,
+			Timestamp: now.Add(-2*time.Hour + time.Duration(i)*time.Second),
+			Value:     model.SampleValue(i),
+		})
+		s.Append(&model.Sample{
+			Metric:    active,
+			Timestamp: now.Add(-30*time.Minute + time.Duration(i)*time.Second),
+			Value:     model.SampleValue(i),
+		})
+	}
+	s.WaitForIndexing()
+
+	for _, m := range []model.Metric{idle, active} {
+		s.maintainMemorySeries(m.FastFingerprint(), 0)
+	}
+
+	if _, ok := s.fpToSeries.get(idle.FastFingerprint()); ok {
+		t.Error("idle series still in memory")
+	}
+	if archived, _, _ := s.persistence.hasArchivedMetric(idle.FastFingerprint()); !archived {
+		t.Error("idle series not archived")
+	}
+
+	series, ok := s.fpToSeries.get(active.FastFingerprint())
+	if !ok {
+		t.Fatal("active series not in memory")
+	}
+	for _, cd := range series.chunkDescs {
+		if cd.IsEvicted() {
+			t.Error("chunk of active series evicted")
+		}
+	}
+
+	// Samples are still queryable after archiving.
+	it := s.preloadChunksForRange(context.Background(), makeFingerprintSeriesPair(s, idle.FastFingerprint()), model.Earliest, model.Latest)
+	if n := len(it.RangeValues(metric.Interval{OldestInclusive: model.Earliest, NewestInclusive: model.Latest})); n != 100 {
+		t.Errorf("expected 100 samples of idle series, got %d", n)
+	}
+}
+
 func benchmarkAppend(b *testing.B, encoding chunk.Encoding) {
 	samples := make(model.Samples, b.N)
 	for i := range samples {
@@ -1839,7 +2086,7 @@ func verifyStorageRandom(t testing.TB, s *MemorySeriesStorage, samples model.Sam
 	for _, i := range rand.Perm(len(samples)) {
 		sample := samples[i]
 		fp := s.mapper.mapFP(sample.Metric.FastFingerprint(), sample.Metric)
-		it := s.preloadChunksForInstant(makeFingerprintSeriesPair(s, fp), sample.Timestamp, sample.Timestamp)
+		it := s.preloadChunksForInstant(context.Background(), makeFingerprintSeriesPair(s, fp), sample.Timestamp, sample.Timestamp)
 		found := it.ValueAtOrBeforeTime(sample.Timestamp)
 		startTime := it.(*boundedIterator).start
 		switch {
@@ -1882,7 +2129,7 @@ func verifyStorageSequential(t testing.TB, s *MemorySeriesStorage, samples model
 			if it != nil {
 				it.Close()
 			}
-			it = s.preloadChunksForRange(makeFingerprintSeriesPair(s, fp), sample.Timestamp, model.Latest)
+			it = s.preloadChunksForRange(context.Background(), makeFingerprintSeriesPair(s, fp), sample.Timestamp, model.Latest)
 			r = it.RangeValues(metric.Interval{
 				OldestInclusive: sample.Timestamp,
 				NewestInclusive: model.Latest,
@@ -2003,7 +2250,7 @@ func TestAppendOutOfOrder(t *testing.T) {
 
 	fp := s.mapper.mapFP(m.FastFingerprint(), m)
 
-	it := s.preloadChunksForRange(makeFingerprintSeriesPair(s, fp), 0, 2)
+	it := s.preloadChunksForRange(context.Background(), makeFingerprintSeriesPair(s, fp), 0, 2)
 	defer it.Close()
 
 	want := []model.SamplePair{
@@ -20,6 +20,7 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"sync/atomic"
 	"time"
 
 	"github.com/golang/protobuf/proto"
@@ -35,12 +36,27 @@ import (
 
 const maxErrMsgLen = 256
 
+// Content types and remote write protocol versions of the remote write
+// messages.
+const (
+	contentTypeV1 = "application/x-protobuf"
+	contentTypeV2 = "application/x-protobuf;proto=io.prometheus.write.v2.Request"
+
+	remoteWriteVersionV1 = "0.1.0"
+	remoteWriteVersionV2 = "2.0.0"
+)
+
 // Client allows reading and writing from/to a remote HTTP endpoint.
 type Client struct {
 	index   int // Used to differentiate metrics.
 	url     *config.URL
 	client  *http.Client
 	timeout time.Duration
+
+	protoMsg config.RemoteWriteProtoMsg
+	// Set to 1 once the endpoint rejected a version 2 write request, after
+	// which version 1 requests are sent. Accessed atomically.
+	downgraded uint32
 }
 
 // ClientConfig configures a Client.
@@ -48,6 +64,10 @@ type ClientConfig struct {
 	URL              *config.URL
 	Timeout          model.Duration
 	HTTPClientConfig config.HTTPClientConfig
+	SigV4Config      *config.SigV4Config
+	AzureADConfig    *config.AzureADConfig
+	GoogleIAMConfig  *config.GoogleIAMConfig
+	ProtobufMessage  config.RemoteWriteProtoMsg
 }
 
 // NewClient creates a new Client.
@@ -61,12 +81,36 @@ func NewClient(index int, conf *ClientConfig) (*Client, error) {
 	if err != nil {
 		return nil, err
 	}
+	if conf.SigV4Config != nil {
+		httpClient.Transport, err = httputil.NewSigV4RoundTripper(conf.SigV4Config, httpClient.Transport)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if conf.AzureADConfig != nil {
+		httpClient.Transport, err = httputil.NewAzureADRoundTripper(conf.AzureADConfig, httpClient.Transport)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if conf.GoogleIAMConfig != nil {
+		httpClient.Transport, err = httputil.NewGoogleIAMRoundTripper(conf.GoogleIAMConfig, httpClient.Transport)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	protoMsg := conf.ProtobufMessage
+	if protoMsg == "" {
+		protoMsg = config.RemoteWriteProtoMsgV1
+	}
 
 	return &Client{
-		index:   index,
-		url:     conf.URL,
-		client:  httpClient,
-		timeout: time.Duration(conf.Timeout),
+		index:    index,
+		url:      conf.URL,
+		client:   httpClient,
+		timeout:  time.Duration(conf.Timeout),
+		protoMsg: protoMsg,
 	}, nil
 }
 
@@ -75,8 +119,37 @@ type recoverableError struct {
 }
 
 // Store sends a batch of samples to the HTTP endpoint.
+//
+// If the client is configured to send version 2 requests and the endpoint
+// rejects them as an unsupported media type, the batch is resent as a version
+// 1 request, and so are all further batches.
 func (c *Client) Store(samples model.Samples) error {
-	req := ToWriteRequest(samples)
+	if c.protoMsg == config.RemoteWriteProtoMsgV2 && atomic.LoadUint32(&c.downgraded) == 0 {
+		err := c.store(samples, config.RemoteWriteProtoMsgV2)
+		if err != errUnsupportedMediaType {
+			return err
+		}
+		logger.Warnf("Remote storage %s does not support %s messages, falling back to %s", c.Name(), config.RemoteWriteProtoMsgV2, config.RemoteWriteProtoMsgV1)
+		atomic.StoreUint32(&c.downgraded, 1)
+	}
+	return c.store(samples, config.RemoteWriteProtoMsgV1)
+}
+
+var errUnsupportedMediaType = fmt.Errorf("server returned HTTP status %d %s", http.StatusUnsupportedMediaType, http.StatusText(http.StatusUnsupportedMediaType))
+
+func (c *Client) store(samples model.Samples, protoMsg config.RemoteWriteProtoMsg) error {
+	var (
+		req                    proto.Message
+		contentType, rwVersion string
+	)
+	switch protoMsg {
+	case config.RemoteWriteProtoMsgV2:
+		req = ToWriteRequestV2(samples)
+		contentType, rwVersion = contentTypeV2, remoteWriteVersionV2
+	default:
+		req = ToWriteRequest(samples)
+		contentType, rwVersion = contentTypeV1, remoteWriteVersionV1
+	}
 	data, err := proto.Marshal(req)
 	if err != nil {
 		return err
@@ -90,8 +163,8 @@ func (c *Client) Store(samples model.Samples) error {
 		return err
 	}
 	httpReq.Header.Add("Content-Encoding", "snappy")
-	httpReq.Header.Set("Content-Type", "application/x-protobuf")
-	httpReq.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	httpReq.Header.Set("Content-Type", contentType)
+	httpReq.Header.Set("X-Prometheus-Remote-Write-Version", rwVersion)
 
 	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
 	defer cancel()
@@ -104,6 +177,9 @@ func (c *Client) Store(samples model.Samples) error {
 	}
 	defer httpResp.Body.Close()
 
+	if httpResp.StatusCode == http.StatusUnsupportedMediaType && protoMsg == config.RemoteWriteProtoMsgV2 {
+		return errUnsupportedMediaType
+	}
 	if httpResp.StatusCode/100 != 2 {
 		scanner := bufio.NewScanner(io.LimitReader(httpResp.Body, maxErrMsgLen))
 		line := ""
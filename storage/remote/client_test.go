@@ -15,6 +15,7 @@ package remote
 
 import (
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -23,6 +24,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/snappy"
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/config"
 )
@@ -65,7 +68,7 @@ func TestStoreHTTPErrorHandling(t *testing.T) {
 		}
 
 		c, err := NewClient(0, &ClientConfig{
-			URL:     &config.URL{serverURL},
+			URL:     &config.URL{URL: serverURL},
 			Timeout: model.Duration(time.Second),
 		})
 		if err != nil {
@@ -80,3 +83,101 @@ func TestStoreHTTPErrorHandling(t *testing.T) {
 		server.Close()
 	}
 }
+
+func TestStoreRemoteWriteV2(t *testing.T) {
+	samples := model.Samples{
+		{
+			Metric:    model.Metric{model.MetricNameLabel: "up", "job": "a"},
+			Value:     1,
+			Timestamp: 1000,
+		},
+		{
+			Metric:    model.Metric{model.MetricNameLabel: "up", "job": "b"},
+			Value:     0,
+			Timestamp: 2000,
+		},
+	}
+
+	for _, acceptV2 := range []bool{true, false} {
+		var contentTypes []string
+		var received []*TimeSeries
+		server := httptest.NewServer(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				contentType := r.Header.Get("Content-Type")
+				contentTypes = append(contentTypes, contentType)
+				if contentType == contentTypeV2 && !acceptV2 {
+					w.WriteHeader(http.StatusUnsupportedMediaType)
+					return
+				}
+
+				compressed, err := ioutil.ReadAll(r.Body)
+				if err != nil {
+					t.Fatal(err)
+				}
+				buf, err := snappy.Decode(nil, compressed)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if contentType == contentTypeV2 {
+					var req WriteRequestV2
+					if err := proto.Unmarshal(buf, &req); err != nil {
+						t.Fatal(err)
+					}
+					if want := []string{"", "__name__", "up", "job", "a", "b"}; !reflect.DeepEqual(req.Symbols, want) {
+						t.Errorf("Unexpected symbols; want %v, got %v", want, req.Symbols)
+					}
+					received, err = FromWriteRequestV2(&req)
+					if err != nil {
+						t.Fatal(err)
+					}
+				} else {
+					var req WriteRequest
+					if err := proto.Unmarshal(buf, &req); err != nil {
+						t.Fatal(err)
+					}
+					received = req.Timeseries
+				}
+			}),
+		)
+
+		serverURL, err := url.Parse(server.URL)
+		if err != nil {
+			panic(err)
+		}
+
+		c, err := NewClient(0, &ClientConfig{
+			URL:             &config.URL{URL: serverURL},
+			Timeout:         model.Duration(time.Second),
+			ProtobufMessage: config.RemoteWriteProtoMsgV2,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i := 0; i < 2; i++ {
+			if err := c.Store(samples); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if len(received) != len(samples) {
+				t.Fatalf("Expected %d time series, got %d", len(samples), len(received))
+			}
+			for j, ts := range received {
+				if m := FromLabelPairs(ts.Labels); !m.Equal(samples[j].Metric) {
+					t.Errorf("Unexpected metric; want %v, got %v", samples[j].Metric, m)
+				}
+			}
+		}
+
+		want := []string{contentTypeV2, contentTypeV2}
+		if !acceptV2 {
+			// The first batch is resent as version 1, further batches are
+			// only sent as version 1.
+			want = []string{contentTypeV2, contentTypeV1, contentTypeV1}
+		}
+		if !reflect.DeepEqual(contentTypes, want) {
+			t.Errorf("Unexpected content types; want %v, got %v", want, contentTypes)
+		}
+
+		server.Close()
+	}
+}
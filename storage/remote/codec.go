@@ -17,6 +17,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"sort"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/golang/snappy"
@@ -82,6 +83,108 @@ func ToWriteRequest(samples []*model.Sample) *WriteRequest {
 	return req
 }
 
+// ToWriteRequestV2 converts an array of samples into a WriteRequestV2 proto,
+// interning all label names and values into the request's symbols. The
+// samples of the same series are grouped into one time series, in the order
+// they were given in.
+func ToWriteRequestV2(samples []*model.Sample) *WriteRequestV2 {
+	type seriesV2 struct {
+		metric model.Metric
+		ts     *TimeSeriesV2
+	}
+	var (
+		symbols = newSymbolTable()
+		series  = map[model.Fingerprint][]seriesV2{}
+		req     = &WriteRequestV2{}
+	)
+
+	for _, s := range samples {
+		fp := s.Metric.FastFingerprint()
+		var ts *TimeSeriesV2
+		for _, sv := range series[fp] {
+			if sv.metric.Equal(s.Metric) {
+				ts = sv.ts
+				break
+			}
+		}
+		if ts == nil {
+			names := make(model.LabelNames, 0, len(s.Metric))
+			for ln := range s.Metric {
+				names = append(names, ln)
+			}
+			sort.Sort(names)
+
+			refs := make([]uint32, 0, 2*len(names))
+			for _, ln := range names {
+				refs = append(refs, symbols.ref(string(ln)), symbols.ref(string(s.Metric[ln])))
+			}
+			ts = &TimeSeriesV2{LabelRefs: refs}
+			series[fp] = append(series[fp], seriesV2{metric: s.Metric, ts: ts})
+			req.Timeseries = append(req.Timeseries, ts)
+		}
+		ts.Samples = append(ts.Samples, &Sample{
+			Value:       float64(s.Value),
+			TimestampMs: int64(s.Timestamp),
+		})
+	}
+	req.Symbols = symbols.symbols
+
+	return req
+}
+
+// FromWriteRequestV2 unpacks the time series of a WriteRequestV2 proto,
+// resolving their label references against the request's symbols.
+func FromWriteRequestV2(req *WriteRequestV2) ([]*TimeSeries, error) {
+	result := make([]*TimeSeries, 0, len(req.Timeseries))
+	for _, ts := range req.Timeseries {
+		if len(ts.LabelRefs)%2 != 0 {
+			return nil, fmt.Errorf("odd number of label references: %d", len(ts.LabelRefs))
+		}
+		labels := make([]*LabelPair, 0, len(ts.LabelRefs)/2)
+		for i := 0; i < len(ts.LabelRefs); i += 2 {
+			nameRef, valueRef := ts.LabelRefs[i], ts.LabelRefs[i+1]
+			if int(nameRef) >= len(req.Symbols) || int(valueRef) >= len(req.Symbols) {
+				return nil, fmt.Errorf("label reference out of range of %d symbols", len(req.Symbols))
+			}
+			labels = append(labels, &LabelPair{
+				Name:  req.Symbols[nameRef],
+				Value: req.Symbols[valueRef],
+			})
+		}
+		result = append(result, &TimeSeries{
+			Labels:  labels,
+			Samples: ts.Samples,
+		})
+	}
+	return result, nil
+}
+
+// symbolTable interns strings for a WriteRequestV2.
+type symbolTable struct {
+	refs    map[string]uint32
+	symbols []string
+}
+
+func newSymbolTable() *symbolTable {
+	// The empty string is always the first symbol.
+	return &symbolTable{
+		refs:    map[string]uint32{"": 0},
+		symbols: []string{""},
+	}
+}
+
+// ref returns the reference of the given string, adding it to the table if
+// it is not present yet.
+func (t *symbolTable) ref(s string) uint32 {
+	if ref, ok := t.refs[s]; ok {
+		return ref
+	}
+	ref := uint32(len(t.symbols))
+	t.refs[s] = ref
+	t.symbols = append(t.symbols, s)
+	return ref
+}
+
 // ToQuery builds a Query proto.
 func ToQuery(from, to model.Time, matchers []*metric.LabelMatcher) (*Query, error) {
 	ms, err := toLabelMatchers(matchers)
@@ -0,0 +1,57 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remote
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/prometheus/common/model"
+)
+
+func TestToWriteRequestV2GroupsSeries(t *testing.T) {
+	a := model.Metric{model.MetricNameLabel: "up", "job": "a"}
+	b := model.Metric{model.MetricNameLabel: "up", "job": "b"}
+	samples := model.Samples{
+		{Metric: a, Value: 1, Timestamp: 1000},
+		{Metric: b, Value: 0, Timestamp: 1000},
+		{Metric: a.Clone(), Value: 2, Timestamp: 2000},
+	}
+
+	req := ToWriteRequestV2(samples)
+	if want := []string{"", "__name__", "up", "job", "a", "b"}; !reflect.DeepEqual(req.Symbols, want) {
+		t.Errorf("Unexpected symbols; want %v, got %v", want, req.Symbols)
+	}
+	series, err := FromWriteRequestV2(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(series) != 2 {
+		t.Fatalf("Expected 2 time series, got %d", len(series))
+	}
+	for i, want := range []struct {
+		metric  model.Metric
+		samples []*Sample
+	}{
+		{metric: a, samples: []*Sample{{Value: 1, TimestampMs: 1000}, {Value: 2, TimestampMs: 2000}}},
+		{metric: b, samples: []*Sample{{Value: 0, TimestampMs: 1000}}},
+	} {
+		if m := FromLabelPairs(series[i].Labels); !m.Equal(want.metric) {
+			t.Errorf("%d. Unexpected metric; want %v, got %v", i, want.metric, m)
+		}
+		if !reflect.DeepEqual(series[i].Samples, want.samples) {
+			t.Errorf("%d. Unexpected samples; want %v, got %v", i, want.samples, series[i].Samples)
+		}
+	}
+}
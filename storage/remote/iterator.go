@@ -15,6 +15,7 @@ package remote
 
 import (
 	"sort"
+	"time"
 
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/storage/local"
@@ -75,3 +76,33 @@ func IteratorsToMatrix(iters []local.SeriesIterator, interval metric.Interval) m
 	}
 	return result
 }
+
+// Downsample returns the matrix with at most one sample per series in each
+// interval of the given resolution, aligned to the Unix epoch. The last sample
+// of each interval is kept.
+func Downsample(m model.Matrix, resolution time.Duration) model.Matrix {
+	res := int64(resolution / time.Millisecond)
+	if res <= 0 {
+		return m
+	}
+	interval := func(t model.Time) int64 {
+		i := int64(t) / res
+		if t < 0 && int64(t)%res != 0 {
+			i--
+		}
+		return i
+	}
+
+	result := make(model.Matrix, 0, len(m))
+	for _, ss := range m {
+		values := make([]model.SamplePair, 0, len(ss.Values))
+		for i, sp := range ss.Values {
+			if i+1 < len(ss.Values) && interval(ss.Values[i+1].Timestamp) == interval(sp.Timestamp) {
+				continue
+			}
+			values = append(values, sp)
+		}
+		result = append(result, &model.SampleStream{Metric: ss.Metric, Values: values})
+	}
+	return result
+}
@@ -0,0 +1,67 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remote
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+)
+
+func TestDownsample(t *testing.T) {
+	m := model.Matrix{
+		&model.SampleStream{
+			Metric: model.Metric{"a": "1"},
+			Values: []model.SamplePair{
+				{Timestamp: -1500, Value: 1},
+				{Timestamp: -1000, Value: 2},
+				{Timestamp: -500, Value: 3},
+				{Timestamp: 0, Value: 4},
+				{Timestamp: 15000, Value: 5},
+				{Timestamp: 45000, Value: 6},
+				{Timestamp: 59999, Value: 7},
+				{Timestamp: 60000, Value: 8},
+			},
+		},
+		&model.SampleStream{
+			Metric: model.Metric{"a": "2"},
+		},
+	}
+	expected := model.Matrix{
+		&model.SampleStream{
+			Metric: model.Metric{"a": "1"},
+			Values: []model.SamplePair{
+				{Timestamp: -500, Value: 3},
+				{Timestamp: 59999, Value: 7},
+				{Timestamp: 60000, Value: 8},
+			},
+		},
+		&model.SampleStream{
+			Metric: model.Metric{"a": "2"},
+			Values: []model.SamplePair{},
+		},
+	}
+
+	if got := Downsample(m, time.Minute); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+	if got := Downsample(m, 0); !reflect.DeepEqual(got, m) {
+		t.Errorf("expected unchanged matrix %v, got %v", m, got)
+	}
+	if len(m[0].Values) != 8 {
+		t.Errorf("input matrix modified: %v", m)
+	}
+}
@@ -20,13 +20,19 @@ import (
 
 	"golang.org/x/time/rate"
 
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	otlog "github.com/opentracing/opentracing-go/log"
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/common/log"
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/config"
 	"github.com/prometheus/prometheus/relabel"
+	"github.com/prometheus/prometheus/util/logging"
 )
 
+// logger logs the messages of the remote storage.
+var logger = logging.New("remote")
+
 // String constants for instrumentation.
 const (
 	namespace = "prometheus"
@@ -74,6 +80,15 @@ var (
 		},
 		[]string{queue},
 	)
+	droppedOldSamplesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "dropped_old_samples_total",
+			Help:      "Total number of samples which were dropped for being older than the sample age limit.",
+		},
+		[]string{queue},
+	)
 	sentBatchDuration = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Namespace: namespace,
@@ -102,6 +117,15 @@ var (
 		},
 		[]string{queue},
 	)
+	queueLag = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "queue_lag_seconds",
+			Help:      "Age of the last sample in the most recent batch sent to the remote storage.",
+		},
+		[]string{queue},
+	)
 	numShards = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: namespace,
@@ -117,9 +141,11 @@ func init() {
 	prometheus.MustRegister(succeededSamplesTotal)
 	prometheus.MustRegister(failedSamplesTotal)
 	prometheus.MustRegister(droppedSamplesTotal)
+	prometheus.MustRegister(droppedOldSamplesTotal)
 	prometheus.MustRegister(sentBatchDuration)
 	prometheus.MustRegister(queueLength)
 	prometheus.MustRegister(queueCapacity)
+	prometheus.MustRegister(queueLag)
 	prometheus.MustRegister(numShards)
 }
 
@@ -151,6 +177,28 @@ type QueueManager struct {
 
 	samplesIn, samplesOut, samplesOutDuration *ewmaRate
 	integralAccumulator                       float64
+
+	// Sending statistics reported by Status.
+	statusMtx                           sync.Mutex
+	succeeded, failed, dropped, retried int64
+	lastSend, lastErrorTime             time.Time
+	lastError                           error
+}
+
+// QueueStatus describes the state of a QueueManager.
+type QueueStatus struct {
+	Name             string    `json:"name"`
+	URL              string    `json:"url"`
+	Shards           int       `json:"shards"`
+	Capacity         int       `json:"capacity"`
+	PendingSamples   int       `json:"pendingSamples"`
+	SucceededSamples int64     `json:"succeededSamples"`
+	FailedSamples    int64     `json:"failedSamples"`
+	DroppedSamples   int64     `json:"droppedSamples"`
+	Retries          int64     `json:"retries"`
+	LastSend         time.Time `json:"lastSend"`
+	LastError        string    `json:"lastError"`
+	LastErrorTime    time.Time `json:"lastErrorTime"`
 }
 
 // NewQueueManager builds a new QueueManager.
@@ -207,8 +255,11 @@ func (t *QueueManager) Append(s *model.Sample) error {
 		queueLength.WithLabelValues(t.queueName).Inc()
 	} else {
 		droppedSamplesTotal.WithLabelValues(t.queueName).Inc()
+		t.statusMtx.Lock()
+		t.dropped++
+		t.statusMtx.Unlock()
 		if t.logLimiter.Allow() {
-			log.Warn("Remote storage queue full, discarding sample. Multiple subsequent messages of this kind may be suppressed.")
+			logger.Warn("Remote storage queue full, discarding sample. Multiple subsequent messages of this kind may be suppressed.")
 		}
 	}
 	return nil
@@ -236,14 +287,46 @@ func (t *QueueManager) Start() {
 // Stop stops sending samples to the remote storage and waits for pending
 // sends to complete.
 func (t *QueueManager) Stop() {
-	log.Infof("Stopping remote storage...")
+	logger.Infof("Stopping remote storage...")
 	close(t.quit)
 	t.wg.Wait()
 
 	t.shardsMtx.Lock()
 	defer t.shardsMtx.Unlock()
 	t.shards.stop()
-	log.Info("Remote storage stopped.")
+	logger.Info("Remote storage stopped.")
+}
+
+// Status returns the current state of the queue. The URL of the status is
+// left empty as the queue does not know it.
+func (t *QueueManager) Status() QueueStatus {
+	t.shardsMtx.Lock()
+	numShards := t.shards.len()
+	pending := 0
+	for _, q := range t.shards.queues {
+		pending += len(q)
+	}
+	t.shardsMtx.Unlock()
+
+	t.statusMtx.Lock()
+	defer t.statusMtx.Unlock()
+
+	status := QueueStatus{
+		Name:             t.queueName,
+		Shards:           numShards,
+		Capacity:         t.cfg.Capacity,
+		PendingSamples:   pending,
+		SucceededSamples: t.succeeded,
+		FailedSamples:    t.failed,
+		DroppedSamples:   t.dropped,
+		Retries:          t.retried,
+		LastSend:         t.lastSend,
+		LastErrorTime:    t.lastErrorTime,
+	}
+	if t.lastError != nil {
+		status.LastError = t.lastError.Error()
+	}
+	return status
 }
 
 func (t *QueueManager) updateShardsLoop() {
@@ -287,7 +370,7 @@ func (t *QueueManager) calculateDesiredShards() {
 		timePerSample = samplesOutDuration / samplesOut
 		desiredShards = (timePerSample * (samplesIn + samplesPending + t.integralAccumulator)) / float64(time.Second)
 	)
-	log.Debugf("QueueManager.calculateDesiredShards samplesIn=%f, samplesOut=%f, samplesPending=%f, desiredShards=%f",
+	logger.Debugf("QueueManager.calculateDesiredShards samplesIn=%f, samplesOut=%f, samplesPending=%f, desiredShards=%f",
 		samplesIn, samplesOut, samplesPending, desiredShards)
 
 	// Changes in the number of shards must be greater than shardToleranceFraction.
@@ -295,7 +378,7 @@ func (t *QueueManager) calculateDesiredShards() {
 		lowerBound = float64(t.numShards) * (1. - shardToleranceFraction)
 		upperBound = float64(t.numShards) * (1. + shardToleranceFraction)
 	)
-	log.Debugf("QueueManager.updateShardsLoop %f <= %f <= %f", lowerBound, desiredShards, upperBound)
+	logger.Debugf("QueueManager.updateShardsLoop %f <= %f <= %f", lowerBound, desiredShards, upperBound)
 	if lowerBound <= desiredShards && desiredShards <= upperBound {
 		return
 	}
@@ -314,10 +397,10 @@ func (t *QueueManager) calculateDesiredShards() {
 	// to stay close to shardUpdateDuration.
 	select {
 	case t.reshardChan <- numShards:
-		log.Infof("Remote storage resharding from %d to %d shards.", t.numShards, numShards)
+		logger.Infof("Remote storage resharding from %d to %d shards.", t.numShards, numShards)
 		t.numShards = numShards
 	default:
-		log.Infof("Currently resharding, skipping.")
+		logger.Infof("Currently resharding, skipping.")
 	}
 }
 
@@ -417,14 +500,17 @@ func (s *shards) runShard(i int) {
 		case sample, ok := <-queue:
 			if !ok {
 				if len(pendingSamples) > 0 {
-					log.Debugf("Flushing %d samples to remote storage...", len(pendingSamples))
+					logger.Debugf("Flushing %d samples to remote storage...", len(pendingSamples))
 					s.sendSamples(pendingSamples)
-					log.Debugf("Done flushing.")
+					logger.Debugf("Done flushing.")
 				}
 				return
 			}
 
 			queueLength.WithLabelValues(s.qm.queueName).Dec()
+			if s.qm.tooOld(sample) {
+				continue
+			}
 			pendingSamples = append(pendingSamples, sample)
 
 			for len(pendingSamples) >= s.qm.cfg.MaxSamplesPerSend {
@@ -440,9 +526,31 @@ func (s *shards) runShard(i int) {
 	}
 }
 
+// tooOld returns whether the sample is older than the sample age limit, in
+// which case it is counted as dropped and must not be sent.
+func (t *QueueManager) tooOld(s *model.Sample) bool {
+	if t.cfg.SampleAgeLimit <= 0 || time.Since(s.Timestamp.Time()) <= t.cfg.SampleAgeLimit {
+		return false
+	}
+	droppedOldSamplesTotal.WithLabelValues(t.queueName).Inc()
+	t.statusMtx.Lock()
+	t.dropped++
+	t.statusMtx.Unlock()
+	if t.logLimiter.Allow() {
+		logger.Warn("Sample older than the remote storage sample age limit, discarding sample. Multiple subsequent messages of this kind may be suppressed.")
+	}
+	return true
+}
+
 func (s *shards) sendSamples(samples model.Samples) {
+	span := opentracing.StartSpan("remote_write_send")
+	span.SetTag("queue", s.qm.queueName)
+	span.SetTag("samples", len(samples))
+	defer span.Finish()
+
 	begin := time.Now()
-	s.sendSamplesWithBackoff(samples)
+	queueLag.WithLabelValues(s.qm.queueName).Set(begin.Sub(samples[len(samples)-1].Timestamp.Time()).Seconds())
+	s.sendSamplesWithBackoff(span, samples)
 
 	// These counters are used to calculate the dynamic sharding, and as such
 	// should be maintained irrespective of success or failure.
@@ -451,7 +559,8 @@ func (s *shards) sendSamples(samples model.Samples) {
 }
 
 // sendSamples to the remote storage with backoff for recoverable errors.
-func (s *shards) sendSamplesWithBackoff(samples model.Samples) {
+// Failed attempts are logged to span.
+func (s *shards) sendSamplesWithBackoff(span opentracing.Span, samples model.Samples) {
 	backoff := s.qm.cfg.MinBackoff
 	for retries := s.qm.cfg.MaxRetries; retries > 0; retries-- {
 		begin := time.Now()
@@ -460,11 +569,26 @@ func (s *shards) sendSamplesWithBackoff(samples model.Samples) {
 		sentBatchDuration.WithLabelValues(s.qm.queueName).Observe(time.Since(begin).Seconds())
 		if err == nil {
 			succeededSamplesTotal.WithLabelValues(s.qm.queueName).Add(float64(len(samples)))
+			s.qm.statusMtx.Lock()
+			s.qm.succeeded += int64(len(samples))
+			s.qm.lastSend = time.Now()
+			s.qm.statusMtx.Unlock()
 			return
 		}
 
-		log.Warnf("Error sending %d samples to remote storage: %s", len(samples), err)
-		if _, ok := err.(recoverableError); !ok {
+		logger.Warnf("Error sending %d samples to remote storage: %s", len(samples), err)
+		_, recoverable := err.(recoverableError)
+		span.LogFields(otlog.Error(err), otlog.Bool("recoverable", recoverable))
+
+		s.qm.statusMtx.Lock()
+		s.qm.lastError = err
+		s.qm.lastErrorTime = time.Now()
+		if recoverable && retries > 1 {
+			s.qm.retried++
+		}
+		s.qm.statusMtx.Unlock()
+
+		if !recoverable {
 			break
 		}
 		time.Sleep(backoff)
@@ -475,4 +599,8 @@ func (s *shards) sendSamplesWithBackoff(samples model.Samples) {
 	}
 
 	failedSamplesTotal.WithLabelValues(s.qm.queueName).Add(float64(len(samples)))
+	ext.Error.Set(span, true)
+	s.qm.statusMtx.Lock()
+	s.qm.failed += int64(len(samples))
+	s.qm.statusMtx.Unlock()
 }
@@ -252,3 +252,103 @@ func TestSpawnNotMoreThanMaxConcurrentSendsGoroutines(t *testing.T) {
 		t.Errorf("Saw %d concurrent sends, expected 1", numCalls)
 	}
 }
+
+// flakyStorageClient fails as many stores as configured by failures with a
+// recoverable error before succeeding.
+type flakyStorageClient struct {
+	mtx      sync.Mutex
+	failures int
+	stored   chan struct{}
+}
+
+func (c *flakyStorageClient) Store(ss model.Samples) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.failures > 0 {
+		c.failures--
+		return recoverableError{fmt.Errorf("server unavailable")}
+	}
+	close(c.stored)
+	return nil
+}
+
+func (c *flakyStorageClient) Name() string {
+	return "flakystorageclient"
+}
+
+func TestQueueStatus(t *testing.T) {
+	c := &flakyStorageClient{failures: 2, stored: make(chan struct{})}
+
+	cfg := config.DefaultQueueConfig
+	cfg.MaxShards = 1
+	cfg.MinBackoff = time.Millisecond
+	cfg.BatchSendDeadline = 10 * time.Millisecond
+	m := NewQueueManager(cfg, nil, nil, c)
+
+	m.Append(&model.Sample{Metric: model.Metric{model.MetricNameLabel: "test_metric"}})
+	if s := m.Status(); s.PendingSamples != 1 || s.Shards != 1 {
+		t.Fatalf("Expected 1 pending sample in 1 shard before starting, got %+v", s)
+	}
+
+	m.Start()
+	defer m.Stop()
+
+	select {
+	case <-c.stored:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Sample not stored")
+	}
+	// The status is updated after the store returned.
+	time.Sleep(50 * time.Millisecond)
+
+	s := m.Status()
+	if s.Name != "flakystorageclient" || s.Capacity != cfg.Capacity {
+		t.Errorf("Unexpected queue identity in status %+v", s)
+	}
+	if s.PendingSamples != 0 || s.SucceededSamples != 1 || s.FailedSamples != 0 {
+		t.Errorf("Expected 1 succeeded sample, got %+v", s)
+	}
+	if s.Retries != 2 {
+		t.Errorf("Expected 2 retries, got %d", s.Retries)
+	}
+	if s.LastError != "server unavailable" || s.LastErrorTime.IsZero() || s.LastSend.IsZero() {
+		t.Errorf("Unexpected last send or error in status %+v", s)
+	}
+}
+
+func TestSampleAgeLimit(t *testing.T) {
+	now := model.Now()
+	recent := model.Samples{
+		{Metric: model.Metric{model.MetricNameLabel: "test_metric"}, Timestamp: now.Add(-time.Minute), Value: 1},
+		{Metric: model.Metric{model.MetricNameLabel: "test_metric"}, Timestamp: now, Value: 2},
+	}
+
+	c := NewTestStorageClient()
+	c.expectSamples(recent)
+
+	cfg := config.DefaultQueueConfig
+	cfg.MaxShards = 1
+	cfg.BatchSendDeadline = 10 * time.Millisecond
+	cfg.SampleAgeLimit = time.Hour
+	m := NewQueueManager(cfg, nil, nil, c)
+
+	m.Append(&model.Sample{Metric: model.Metric{model.MetricNameLabel: "test_metric"}, Timestamp: now.Add(-2 * time.Hour), Value: 0})
+	for _, s := range recent {
+		m.Append(s)
+	}
+	m.Start()
+	defer m.Stop()
+
+	c.waitForExpectedSamples(t)
+
+	c.mtx.Lock()
+	received := len(c.receivedSamples[recent[0].Metric.String()])
+	c.mtx.Unlock()
+	if received != len(recent) {
+		t.Errorf("Expected %d samples to be sent, got %d", len(recent), received)
+	}
+	if s := m.Status(); s.DroppedSamples != 1 {
+		t.Errorf("Expected 1 dropped sample, got %d", s.DroppedSamples)
+	}
+}
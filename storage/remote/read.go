@@ -22,20 +22,41 @@ import (
 	"golang.org/x/net/context"
 
 	"github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/storage"
 	"github.com/prometheus/prometheus/storage/local"
 	"github.com/prometheus/prometheus/storage/metric"
 )
 
+// StartTimeCallback is a callback that returns the time from which on the
+// local storage is expected to hold complete data.
+type StartTimeCallback func() model.Time
+
 // Reader allows reading from multiple remote sources.
 type Reader struct {
 	mtx            sync.Mutex
-	clients        []*Client
+	clients        []*readClient
 	externalLabels model.LabelSet
+	localStartTime StartTimeCallback
+}
+
+type readClient struct {
+	*Client
+	readRecent       bool
+	requiredMatchers model.LabelSet
+	bestEffort       bool
+}
+
+// NewReader returns a new Reader. The localStartTime callback is used to decide
+// which time ranges are served by the local storage alone for remote read
+// endpoints that do not read recent data. It may be nil, in which case remote
+// read endpoints are always queried.
+func NewReader(localStartTime StartTimeCallback) *Reader {
+	return &Reader{localStartTime: localStartTime}
 }
 
 // ApplyConfig updates the state as the new config requires.
 func (r *Reader) ApplyConfig(conf *config.Config) error {
-	clients := []*Client{}
+	clients := []*readClient{}
 	for i, rrConf := range conf.RemoteReadConfigs {
 		c, err := NewClient(i, &ClientConfig{
 			URL:              rrConf.URL,
@@ -45,7 +66,12 @@ func (r *Reader) ApplyConfig(conf *config.Config) error {
 		if err != nil {
 			return err
 		}
-		clients = append(clients, c)
+		clients = append(clients, &readClient{
+			Client:           c,
+			readRecent:       rrConf.ReadRecent,
+			requiredMatchers: rrConf.RequiredMatchers,
+			bestEffort:       rrConf.BestEffort,
+		})
 	}
 
 	r.mtx.Lock()
@@ -66,8 +92,12 @@ func (r *Reader) Queriers() []local.Querier {
 	queriers := make([]local.Querier, 0, len(r.clients))
 	for _, c := range r.clients {
 		queriers = append(queriers, &querier{
-			client:         c,
-			externalLabels: r.externalLabels,
+			client:           c.Client,
+			externalLabels:   r.externalLabels,
+			readRecent:       c.readRecent || r.localStartTime == nil,
+			requiredMatchers: c.requiredMatchers,
+			localStartTime:   r.localStartTime,
+			bestEffort:       c.bestEffort,
 		})
 	}
 	return queriers
@@ -75,8 +105,12 @@ func (r *Reader) Queriers() []local.Querier {
 
 // querier is an adapter to make a Client usable as a promql.Querier.
 type querier struct {
-	client         *Client
-	externalLabels model.LabelSet
+	client           *Client
+	externalLabels   model.LabelSet
+	readRecent       bool
+	requiredMatchers model.LabelSet
+	localStartTime   StartTimeCallback
+	bestEffort       bool
 }
 
 func (q *querier) QueryRange(ctx context.Context, from, through model.Time, matchers ...*metric.LabelMatcher) ([]local.SeriesIterator, error) {
@@ -87,7 +121,24 @@ func (q *querier) QueryInstant(ctx context.Context, ts model.Time, stalenessDelt
 	return MatrixToIterators(q.read(ctx, ts.Add(-stalenessDelta), ts, matchers))
 }
 
+// read reads the matching series from the remote endpoint. If the querier is
+// best-effort, errors other than the cancellation of ctx are added to the
+// warnings of ctx instead of being returned, so that queries return the
+// results of the other storages.
 func (q *querier) read(ctx context.Context, from, through model.Time, matchers metric.LabelMatchers) (model.Matrix, error) {
+	res, err := q.readStrict(ctx, from, through, matchers)
+	if err != nil && q.bestEffort && ctx.Err() == nil {
+		storage.AddWarning(ctx, fmt.Errorf("remote read from %s failed, results may be incomplete: %s", q.client.Name(), err))
+		return nil, nil
+	}
+	return res, err
+}
+
+func (q *querier) readStrict(ctx context.Context, from, through model.Time, matchers metric.LabelMatchers) (model.Matrix, error) {
+	from, through, ok := q.filter(from, through, matchers)
+	if !ok {
+		return nil, nil
+	}
 	m, added := q.addExternalLabels(matchers)
 
 	res, err := q.client.Read(ctx, from, through, m)
@@ -103,6 +154,43 @@ func (q *querier) read(ctx context.Context, from, through model.Time, matchers m
 	return res, err
 }
 
+// filter decides whether the remote endpoint has to be queried for the given
+// time range and matchers at all. If so, it returns the possibly shortened time
+// range to query.
+func (q *querier) filter(from, through model.Time, matchers metric.LabelMatchers) (model.Time, model.Time, bool) {
+	if !q.hasRequiredMatchers(matchers) {
+		return from, through, false
+	}
+	if !q.readRecent {
+		localStartTime := q.localStartTime()
+		if !from.Before(localStartTime) {
+			return from, through, false
+		}
+		if through.After(localStartTime) {
+			through = localStartTime
+		}
+	}
+	return from, through, true
+}
+
+// hasRequiredMatchers returns true if for every required matcher of the
+// querier an equality matcher with the same label name and value is present.
+func (q *querier) hasRequiredMatchers(matchers metric.LabelMatchers) bool {
+	if len(q.requiredMatchers) == 0 {
+		return true
+	}
+	found := 0
+	for _, m := range matchers {
+		if m.Type != metric.Equal {
+			continue
+		}
+		if v, ok := q.requiredMatchers[m.Name]; ok && v == m.Value {
+			found++
+		}
+	}
+	return found == len(q.requiredMatchers)
+}
+
 // validateLabelsAndMetricName validates the label names/values and metric names returned from remote read.
 func validateLabelsAndMetricName(res model.Matrix) error {
 	for _, r := range res {
@@ -177,6 +265,11 @@ func (q *querier) MetricsForLabelMatchers(ctx context.Context, from, through mod
 	return nil, nil
 }
 
+func (q *querier) ForEachMetricForLabelMatchers(ctx context.Context, from, through model.Time, f func(metric.Metric) bool, matcherSets ...metric.LabelMatchers) error {
+	// TODO: Implement remote metadata querying.
+	return nil
+}
+
 func (q *querier) LastSampleForLabelMatchers(ctx context.Context, cutoff model.Time, matcherSets ...metric.LabelMatchers) (model.Vector, error) {
 	// TODO: Implement remote last sample querying.
 	return nil, nil
@@ -187,6 +280,23 @@ func (q *querier) LabelValuesForLabelName(ctx context.Context, ln model.LabelNam
 	return nil, nil
 }
 
+func (q *querier) LabelNames(ctx context.Context) (model.LabelNames, error) {
+	// TODO: Implement remote metadata querying.
+	return nil, nil
+}
+
+// errLabelMatchersNotSupported is returned by the label queries for label
+// matchers, as the remote read protocol only reads samples.
+var errLabelMatchersNotSupported = fmt.Errorf("querying label names and values for label matchers is not supported by remote read")
+
+func (q *querier) LabelValuesForLabelMatchers(ctx context.Context, from, through model.Time, ln model.LabelName, matcherSets ...metric.LabelMatchers) (model.LabelValues, error) {
+	return nil, errLabelMatchersNotSupported
+}
+
+func (q *querier) LabelNamesForLabelMatchers(ctx context.Context, from, through model.Time, matcherSets ...metric.LabelMatchers) (model.LabelNames, error) {
+	return nil, errLabelMatchersNotSupported
+}
+
 func (q *querier) Close() error {
 	return nil
 }
@@ -14,11 +14,19 @@
 package remote
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"reflect"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
+
+	"github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/storage"
 	"github.com/prometheus/prometheus/storage/metric"
 )
 
@@ -201,3 +209,153 @@ func TestAddExternalLabels(t *testing.T) {
 		}
 	}
 }
+
+func TestQuerierFilter(t *testing.T) {
+	localStartTime := model.Time(1000)
+	tests := []struct {
+		readRecent       bool
+		requiredMatchers model.LabelSet
+		from, through    model.Time
+		matchers         metric.LabelMatchers
+		ok               bool
+		expThrough       model.Time
+	}{
+		// Time range entirely covered by local storage.
+		{
+			from:    1500,
+			through: 2000,
+			ok:      false,
+		},
+		// Time range partially covered by local storage.
+		{
+			from:       500,
+			through:    2000,
+			ok:         true,
+			expThrough: 1000,
+		},
+		// Recent data requested explicitly.
+		{
+			readRecent: true,
+			from:       1500,
+			through:    2000,
+			ok:         true,
+			expThrough: 2000,
+		},
+		// Required matchers present.
+		{
+			readRecent:       true,
+			requiredMatchers: model.LabelSet{"job": "special"},
+			from:             0,
+			through:          2000,
+			matchers: metric.LabelMatchers{
+				mustNewLabelMatcher(metric.Equal, "job", "special"),
+				mustNewLabelMatcher(metric.Equal, "instance", "a"),
+			},
+			ok:         true,
+			expThrough: 2000,
+		},
+		// Required matcher present, but not as an equality matcher.
+		{
+			readRecent:       true,
+			requiredMatchers: model.LabelSet{"job": "special"},
+			from:             0,
+			through:          2000,
+			matchers: metric.LabelMatchers{
+				mustNewLabelMatcher(metric.RegexMatch, "job", "special"),
+			},
+			ok: false,
+		},
+		// Only some of the required matchers present.
+		{
+			readRecent:       true,
+			requiredMatchers: model.LabelSet{"job": "special", "env": "prod"},
+			from:             0,
+			through:          2000,
+			matchers: metric.LabelMatchers{
+				mustNewLabelMatcher(metric.Equal, "job", "special"),
+			},
+			ok: false,
+		},
+	}
+
+	for i, test := range tests {
+		q := querier{
+			readRecent:       test.readRecent,
+			requiredMatchers: test.requiredMatchers,
+			localStartTime:   func() model.Time { return localStartTime },
+		}
+
+		from, through, ok := q.filter(test.from, test.through, test.matchers)
+		if ok != test.ok {
+			t.Fatalf("%d. unexpected filter result; want %v, got %v", i, test.ok, ok)
+		}
+		if !ok {
+			continue
+		}
+		if from != test.from || through != test.expThrough {
+			t.Fatalf("%d. unexpected time range; want %v-%v, got %v-%v", i, test.from, test.expThrough, from, through)
+		}
+	}
+}
+
+func TestQuerierBestEffort(t *testing.T) {
+	server := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		}),
+	)
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := NewClient(0, &ClientConfig{
+		URL:     &config.URL{URL: serverURL},
+		Timeout: model.Duration(time.Second),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	matchers := metric.LabelMatchers{mustNewLabelMatcher(metric.Equal, model.MetricNameLabel, "up")}
+
+	ctx, warnings := storage.WithWarnings(context.Background())
+	q := &querier{client: c, readRecent: true}
+	if _, err := q.QueryRange(ctx, 0, 1000, matchers...); err == nil {
+		t.Error("expected strict querier to fail")
+	}
+	if ws := warnings.Strings(); len(ws) != 0 {
+		t.Errorf("unexpected warnings of strict querier: %v", ws)
+	}
+
+	q.bestEffort = true
+	its, err := q.QueryRange(ctx, 0, 1000, matchers...)
+	if err != nil {
+		t.Fatalf("unexpected error of best-effort querier: %s", err)
+	}
+	if len(its) != 0 {
+		t.Errorf("expected no series, got %d", len(its))
+	}
+	if ws := warnings.Strings(); len(ws) != 1 {
+		t.Errorf("expected one warning, got %v", ws)
+	}
+
+	// Canceled queries fail even with best-effort queriers.
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := q.QueryRange(canceled, 0, 1000, matchers...); err == nil {
+		t.Error("expected canceled query to fail")
+	}
+}
+
+func TestQuerierLabelMatchersNotSupported(t *testing.T) {
+	q := &querier{}
+	matchers := metric.LabelMatchers{mustNewLabelMatcher(metric.Equal, model.MetricNameLabel, "up")}
+
+	if _, err := q.LabelValuesForLabelMatchers(context.Background(), 0, 1000, "job", matchers); err != errLabelMatchersNotSupported {
+		t.Errorf("expected label values to be unsupported, got %v", err)
+	}
+	if _, err := q.LabelNamesForLabelMatchers(context.Background(), 0, 1000, matchers); err != errLabelMatchersNotSupported {
+		t.Errorf("expected label names to be unsupported, got %v", err)
+	}
+}
@@ -18,6 +18,8 @@ It has these top-level messages:
 	Query
 	LabelMatcher
 	QueryResult
+	WriteRequestV2
+	TimeSeriesV2
 */
 package remote
 
@@ -189,6 +191,9 @@ type Query struct {
 	StartTimestampMs int64           `protobuf:"varint,1,opt,name=start_timestamp_ms,json=startTimestampMs" json:"start_timestamp_ms,omitempty"`
 	EndTimestampMs   int64           `protobuf:"varint,2,opt,name=end_timestamp_ms,json=endTimestampMs" json:"end_timestamp_ms,omitempty"`
 	Matchers         []*LabelMatcher `protobuf:"bytes,3,rep,name=matchers" json:"matchers,omitempty"`
+	// If set, at most one sample per interval of that many milliseconds is
+	// returned per series.
+	MaxResolutionMs int64 `protobuf:"varint,4,opt,name=max_resolution_ms,json=maxResolutionMs" json:"max_resolution_ms,omitempty"`
 }
 
 func (m *Query) Reset()                    { *m = Query{} }
@@ -217,6 +222,13 @@ func (m *Query) GetMatchers() []*LabelMatcher {
 	return nil
 }
 
+func (m *Query) GetMaxResolutionMs() int64 {
+	if m != nil {
+		return m.MaxResolutionMs
+	}
+	return 0
+}
+
 type LabelMatcher struct {
 	Type  MatchType `protobuf:"varint,1,opt,name=type,enum=remote.MatchType" json:"type,omitempty"`
 	Name  string    `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
@@ -265,6 +277,59 @@ func (m *QueryResult) GetTimeseries() []*TimeSeries {
 	return nil
 }
 
+type WriteRequestV2 struct {
+	// Interned label names and values, referenced by index from the time
+	// series. The first symbol is always the empty string.
+	Symbols    []string        `protobuf:"bytes,1,rep,name=symbols" json:"symbols,omitempty"`
+	Timeseries []*TimeSeriesV2 `protobuf:"bytes,2,rep,name=timeseries" json:"timeseries,omitempty"`
+}
+
+func (m *WriteRequestV2) Reset()                    { *m = WriteRequestV2{} }
+func (m *WriteRequestV2) String() string            { return proto.CompactTextString(m) }
+func (*WriteRequestV2) ProtoMessage()               {}
+func (*WriteRequestV2) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *WriteRequestV2) GetSymbols() []string {
+	if m != nil {
+		return m.Symbols
+	}
+	return nil
+}
+
+func (m *WriteRequestV2) GetTimeseries() []*TimeSeriesV2 {
+	if m != nil {
+		return m.Timeseries
+	}
+	return nil
+}
+
+type TimeSeriesV2 struct {
+	// Pairs of label name and label value references into the request's
+	// symbols, sorted by label name.
+	LabelRefs []uint32 `protobuf:"varint,1,rep,packed,name=label_refs,json=labelRefs" json:"label_refs,omitempty"`
+	// Sorted by time, oldest sample first.
+	Samples []*Sample `protobuf:"bytes,2,rep,name=samples" json:"samples,omitempty"`
+}
+
+func (m *TimeSeriesV2) Reset()                    { *m = TimeSeriesV2{} }
+func (m *TimeSeriesV2) String() string            { return proto.CompactTextString(m) }
+func (*TimeSeriesV2) ProtoMessage()               {}
+func (*TimeSeriesV2) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *TimeSeriesV2) GetLabelRefs() []uint32 {
+	if m != nil {
+		return m.LabelRefs
+	}
+	return nil
+}
+
+func (m *TimeSeriesV2) GetSamples() []*Sample {
+	if m != nil {
+		return m.Samples
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Sample)(nil), "remote.Sample")
 	proto.RegisterType((*LabelPair)(nil), "remote.LabelPair")
@@ -275,38 +340,45 @@ func init() {
 	proto.RegisterType((*Query)(nil), "remote.Query")
 	proto.RegisterType((*LabelMatcher)(nil), "remote.LabelMatcher")
 	proto.RegisterType((*QueryResult)(nil), "remote.QueryResult")
+	proto.RegisterType((*WriteRequestV2)(nil), "remote.WriteRequestV2")
+	proto.RegisterType((*TimeSeriesV2)(nil), "remote.TimeSeriesV2")
 	proto.RegisterEnum("remote.MatchType", MatchType_name, MatchType_value)
 }
 
 func init() { proto.RegisterFile("remote.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 424 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x53, 0xc1, 0x6e, 0xd3, 0x40,
-	0x10, 0x65, 0xe3, 0x26, 0xc1, 0x63, 0x37, 0x84, 0xa1, 0x87, 0x1c, 0xc3, 0x4a, 0x08, 0x83, 0xa0,
-	0x42, 0x45, 0x70, 0xe3, 0x10, 0x50, 0x04, 0x42, 0x4d, 0x4b, 0xb7, 0x46, 0x70, 0xb3, 0xb6, 0x64,
-	0x24, 0x2c, 0x79, 0x13, 0x77, 0x77, 0x8d, 0x94, 0xcf, 0xe0, 0x8f, 0x51, 0x76, 0xb3, 0x8e, 0x23,
-	0xe5, 0xc4, 0x2d, 0x33, 0xef, 0xbd, 0x99, 0x97, 0x7d, 0x63, 0x48, 0x35, 0xa9, 0xb5, 0xa5, 0xf3,
-	0x5a, 0xaf, 0xed, 0x1a, 0x07, 0xbe, 0xe2, 0x33, 0x18, 0xdc, 0x4a, 0x55, 0x57, 0x84, 0x67, 0xd0,
-	0xff, 0x23, 0xab, 0x86, 0x26, 0x6c, 0xca, 0x32, 0x26, 0x7c, 0x81, 0x4f, 0x21, 0xb5, 0xa5, 0x22,
-	0x63, 0xa5, 0xaa, 0x0b, 0x65, 0x26, 0xbd, 0x29, 0xcb, 0x22, 0x91, 0xb4, 0xbd, 0x85, 0xe1, 0xef,
-	0x20, 0xbe, 0x94, 0x77, 0x54, 0x7d, 0x93, 0xa5, 0x46, 0x84, 0x93, 0x95, 0x54, 0x7e, 0x48, 0x2c,
-	0xdc, 0xef, 0xfd, 0xe4, 0x9e, 0x6b, 0xfa, 0x82, 0x4b, 0x80, 0xbc, 0x54, 0x74, 0x4b, 0xba, 0x24,
-	0x83, 0x2f, 0x60, 0x50, 0x6d, 0x87, 0x98, 0x09, 0x9b, 0x46, 0x59, 0x72, 0xf1, 0xf8, 0x7c, 0x67,
-	0xb7, 0x1d, 0x2d, 0x76, 0x04, 0xcc, 0x60, 0x68, 0x9c, 0xe5, 0xad, 0x9b, 0x2d, 0x77, 0x14, 0xb8,
-	0xfe, 0x9f, 0x88, 0x00, 0xf3, 0x8f, 0x90, 0xfe, 0xd0, 0xa5, 0x25, 0x41, 0xf7, 0x0d, 0x19, 0x8b,
-	0x17, 0x00, 0xce, 0xb8, 0x5b, 0xb9, 0x5b, 0x84, 0x41, 0xbc, 0x37, 0x23, 0x3a, 0x2c, 0xfe, 0x1e,
-	0x12, 0x41, 0x72, 0x19, 0x46, 0x3c, 0x87, 0xe1, 0x7d, 0xd3, 0xd5, 0x9f, 0x06, 0xfd, 0x4d, 0x43,
-	0x7a, 0x23, 0x02, 0xca, 0x3f, 0x40, 0xea, 0x75, 0xa6, 0x5e, 0xaf, 0x0c, 0xe1, 0x6b, 0x18, 0x6a,
-	0x32, 0x4d, 0x65, 0x83, 0xf0, 0xc9, 0xa1, 0xd0, 0x61, 0x22, 0x70, 0xf8, 0x5f, 0x06, 0x7d, 0x07,
-	0xe0, 0x2b, 0x40, 0x63, 0xa5, 0xb6, 0xc5, 0x41, 0x0e, 0xcc, 0xe5, 0x30, 0x76, 0x48, 0xbe, 0x0f,
-	0x03, 0x33, 0x18, 0xd3, 0x6a, 0x59, 0x1c, 0xc9, 0x6c, 0x44, 0xab, 0x65, 0x97, 0xf9, 0x06, 0x1e,
-	0x2a, 0x69, 0x7f, 0xfd, 0x26, 0x6d, 0x26, 0x91, 0x73, 0x74, 0x76, 0xf0, 0xe6, 0x0b, 0x0f, 0x8a,
-	0x96, 0xc5, 0x0b, 0x48, 0xbb, 0x08, 0x3e, 0x83, 0x13, 0xbb, 0xa9, 0x7d, 0xd6, 0xa3, 0x7d, 0x62,
-	0x0e, 0xce, 0x37, 0x35, 0x09, 0x07, 0xb7, 0x27, 0xd1, 0x3b, 0x76, 0x12, 0x51, 0xf7, 0x24, 0x66,
-	0x90, 0x74, 0x1e, 0xe3, 0x7f, 0xe2, 0x7a, 0xf9, 0x15, 0xe2, 0x76, 0x3f, 0xc6, 0xd0, 0x9f, 0xdf,
-	0x7c, 0x9f, 0x5d, 0x8e, 0x1f, 0xe0, 0x29, 0xc4, 0x57, 0xd7, 0x79, 0xe1, 0x4b, 0x86, 0x8f, 0x20,
-	0x11, 0xf3, 0xcf, 0xf3, 0x9f, 0xc5, 0x62, 0x96, 0x7f, 0xfa, 0x32, 0xee, 0x21, 0xc2, 0xc8, 0x37,
-	0xae, 0xae, 0x77, 0xbd, 0xe8, 0x6e, 0xe0, 0x3e, 0x95, 0xb7, 0xff, 0x02, 0x00, 0x00, 0xff, 0xff,
-	0x9b, 0x9e, 0x76, 0xb3, 0x3a, 0x03, 0x00, 0x00,
+	// 497 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0x03, 0x9d, 0x54, 0x4d, 0x6f, 0xd3, 0x40,
+	0x10, 0xc5, 0x76, 0x3e, 0xf0, 0xc4, 0x71, 0xd3, 0xa1, 0x87, 0x5c, 0x90, 0xc0, 0x12, 0x22, 0x54,
+	0xb4, 0x42, 0xe1, 0xe3, 0xc6, 0x21, 0xa0, 0x08, 0x84, 0x9a, 0x96, 0x6e, 0x4d, 0xcb, 0xcd, 0x38,
+	0x74, 0x2b, 0x2c, 0xd9, 0x71, 0xf0, 0x6e, 0xaa, 0xe6, 0xc7, 0xf1, 0xdf, 0x98, 0xec, 0x7a, 0xfd,
+	0x21, 0xf5, 0x80, 0xb8, 0xed, 0xbc, 0x37, 0xf3, 0x66, 0x3c, 0xf3, 0x64, 0xf0, 0x0a, 0x9e, 0xe5,
+	0x92, 0x1f, 0xaf, 0x8b, 0x5c, 0xe6, 0xd8, 0xd3, 0x51, 0x30, 0x83, 0xde, 0x45, 0x9c, 0xad, 0x53,
+	0x8e, 0x07, 0xd0, 0xbd, 0x8d, 0xd3, 0x0d, 0x1f, 0x5b, 0x4f, 0xac, 0x89, 0xc5, 0x74, 0x80, 0x4f,
+	0xc1, 0x93, 0x49, 0xc6, 0x85, 0xa4, 0xa4, 0x28, 0x13, 0x63, 0x9b, 0x48, 0x87, 0x0d, 0x2a, 0x6c,
+	0x21, 0x82, 0xb7, 0xe0, 0x9e, 0xc4, 0x4b, 0x9e, 0x7e, 0x8d, 0x93, 0x02, 0x11, 0x3a, 0xab, 0x38,
+	0xd3, 0x22, 0x2e, 0x53, 0xef, 0x5a, 0xd9, 0x56, 0xa0, 0x0e, 0x82, 0x18, 0x20, 0x24, 0x95, 0x0b,
+	0x5e, 0x24, 0x5c, 0xe0, 0x0b, 0xe8, 0xa5, 0x3b, 0x11, 0x41, 0x95, 0xce, 0x64, 0x30, 0xdd, 0x3f,
+	0x2e, 0xc7, 0xad, 0xa4, 0x59, 0x99, 0x80, 0x13, 0xe8, 0x0b, 0x35, 0xf2, 0x6e, 0x9a, 0x5d, 0xae,
+	0x6f, 0x72, 0xf5, 0x97, 0x30, 0x43, 0x07, 0x1f, 0xc0, 0xbb, 0x2a, 0x12, 0xc9, 0x19, 0xff, 0xbd,
+	0xa1, 0x71, 0x71, 0x0a, 0xa0, 0x06, 0x57, 0x2d, 0xcb, 0x46, 0x68, 0x8a, 0xeb, 0x61, 0x58, 0x23,
+	0x2b, 0x78, 0x07, 0x03, 0xc6, 0xe3, 0x6b, 0x23, 0xf1, 0x1c, 0xfa, 0xf4, 0x68, 0xd4, 0x0f, 0x4d,
+	0xfd, 0x39, 0xc1, 0x5b, 0x66, 0xd8, 0xe0, 0x3d, 0x78, 0xba, 0x4e, 0xac, 0xf3, 0x95, 0xe0, 0x78,
+	0x04, 0xfd, 0x82, 0x8b, 0x4d, 0x2a, 0x4d, 0xe1, 0xa3, 0x76, 0xa1, 0xe2, 0x98, 0xc9, 0x09, 0xfe,
+	0x58, 0xd0, 0x55, 0x04, 0xbe, 0x04, 0xa4, 0x4d, 0x17, 0x32, 0x6a, 0xdd, 0xc1, 0x52, 0x77, 0x18,
+	0x29, 0x26, 0xac, 0x8f, 0x41, 0xcb, 0x19, 0xf1, 0xd5, 0x75, 0x74, 0xcf, 0xcd, 0x7c, 0xc2, 0x9b,
+	0x99, 0xaf, 0xe0, 0x61, 0x16, 0xcb, 0x9f, 0xbf, 0x78, 0x21, 0xc6, 0x8e, 0x9a, 0xe8, 0xa0, 0xb5,
+	0xf3, 0x85, 0x26, 0x59, 0x95, 0x85, 0x87, 0xb0, 0x9f, 0xc5, 0x77, 0x11, 0x8d, 0x98, 0xa7, 0x1b,
+	0x99, 0xe4, 0xab, 0x9d, 0x78, 0x47, 0x89, 0xef, 0x11, 0xc1, 0x2a, 0x9c, 0x4c, 0x11, 0x81, 0xd7,
+	0x54, 0xc1, 0x67, 0xd0, 0x91, 0xdb, 0xb5, 0xf6, 0x85, 0x5f, 0x5f, 0x57, 0xd1, 0x21, 0x11, 0x4c,
+	0xd1, 0x95, 0x7d, 0xec, 0xfb, 0xec, 0xe3, 0x34, 0xed, 0x33, 0x83, 0x41, 0x63, 0x71, 0xff, 0x75,
+	0xda, 0x1f, 0xe0, 0x37, 0xed, 0x71, 0x39, 0xc5, 0x31, 0x59, 0x6b, 0x9b, 0x2d, 0xf3, 0xd2, 0x86,
+	0x2e, 0x33, 0x21, 0xbe, 0x69, 0xe9, 0xdb, 0xed, 0x7d, 0xd5, 0xfa, 0x97, 0xd3, 0x56, 0x87, 0x2b,
+	0xf0, 0x9a, 0x1c, 0x3e, 0x06, 0x50, 0x26, 0xa6, 0x1d, 0xde, 0xe8, 0x16, 0x43, 0xe6, 0x2a, 0x84,
+	0x11, 0xf0, 0xef, 0xce, 0x3e, 0xfc, 0x02, 0x6e, 0xb5, 0x3a, 0x74, 0xa1, 0x3b, 0x3f, 0xff, 0x36,
+	0x3b, 0x19, 0x3d, 0xc0, 0x21, 0xb8, 0xa7, 0x67, 0x61, 0xa4, 0x43, 0x0b, 0xf7, 0xc8, 0xbc, 0xf3,
+	0x4f, 0xf3, 0xef, 0xd1, 0x62, 0x16, 0x7e, 0xfc, 0x3c, 0xb2, 0x69, 0xbf, 0xbe, 0x06, 0x4e, 0xcf,
+	0x4a, 0xcc, 0x59, 0xf6, 0xd4, 0x1f, 0xe1, 0xf5, 0x5f, 0xcc, 0xac, 0x53, 0x24, 0x21, 0x04, 0x00,
+	0x00,
 }
@@ -25,6 +25,8 @@ import (
 type Writer struct {
 	mtx    sync.RWMutex
 	queues []*QueueManager
+	// The endpoint URLs of the queues, with credentials removed.
+	urls []string
 }
 
 // ApplyConfig updates the state as the new config requires.
@@ -33,6 +35,7 @@ func (w *Writer) ApplyConfig(conf *config.Config) error {
 	defer w.mtx.Unlock()
 
 	newQueues := []*QueueManager{}
+	newURLs := []string{}
 	// TODO: we should only stop & recreate queues which have changes,
 	// as this can be quite disruptive.
 	for i, rwConf := range conf.RemoteWriteConfigs {
@@ -40,6 +43,10 @@ func (w *Writer) ApplyConfig(conf *config.Config) error {
 			URL:              rwConf.URL,
 			Timeout:          rwConf.RemoteTimeout,
 			HTTPClientConfig: rwConf.HTTPClientConfig,
+			SigV4Config:      rwConf.SigV4Config,
+			AzureADConfig:    rwConf.AzureADConfig,
+			GoogleIAMConfig:  rwConf.GoogleIAMConfig,
+			ProtobufMessage:  rwConf.ProtobufMessage,
 		})
 		if err != nil {
 			return err
@@ -50,6 +57,7 @@ func (w *Writer) ApplyConfig(conf *config.Config) error {
 			rwConf.WriteRelabelConfigs,
 			c,
 		))
+		newURLs = append(newURLs, rwConf.URL.Redacted())
 	}
 
 	for _, q := range w.queues {
@@ -57,12 +65,27 @@ func (w *Writer) ApplyConfig(conf *config.Config) error {
 	}
 
 	w.queues = newQueues
+	w.urls = newURLs
 	for _, q := range w.queues {
 		q.Start()
 	}
 	return nil
 }
 
+// QueueStatus returns the state of all remote write queues.
+func (w *Writer) QueueStatus() []QueueStatus {
+	w.mtx.RLock()
+	defer w.mtx.RUnlock()
+
+	res := make([]QueueStatus, 0, len(w.queues))
+	for i, q := range w.queues {
+		status := q.Status()
+		status.URL = w.urls[i]
+		res = append(res, status)
+	}
+	return res
+}
+
 // Stop the background processing of the storage queues.
 func (w *Writer) Stop() {
 	for _, q := range w.queues {
@@ -0,0 +1,59 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"sync"
+
+	"golang.org/x/net/context"
+)
+
+type warningsKey struct{}
+
+// Warnings collects the non-fatal issues of queries, like the errors of
+// storages that did not fail a query but left its results incomplete. It is
+// safe for concurrent use.
+type Warnings struct {
+	mtx  sync.Mutex
+	errs []error
+}
+
+// WithWarnings returns a context collecting the warnings of the queries
+// run with it into the returned Warnings.
+func WithWarnings(ctx context.Context) (context.Context, *Warnings) {
+	w := &Warnings{}
+	return context.WithValue(ctx, warningsKey{}, w), w
+}
+
+// AddWarning adds err to the warnings collected by ctx, if any.
+func AddWarning(ctx context.Context, err error) {
+	if w, ok := ctx.Value(warningsKey{}).(*Warnings); ok {
+		w.mtx.Lock()
+		w.errs = append(w.errs, err)
+		w.mtx.Unlock()
+	}
+}
+
+// Strings returns the messages of the collected warnings in the order they
+// were added.
+func (w *Warnings) Strings() []string {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	var res []string
+	for _, err := range w.errs {
+		res = append(res, err.Error())
+	}
+	return res
+}
@@ -0,0 +1,414 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+	"github.com/prometheus/common/version"
+	"golang.org/x/net/context"
+	"golang.org/x/net/context/ctxhttp"
+
+	"github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/util/httputil"
+)
+
+const (
+	// The maximum number of finished spans waiting to be exported. Further
+	// spans are dropped.
+	maxQueueSize = 8192
+	// The maximum number of spans sent in one request.
+	maxBatchSize = 512
+	// The interval at which pending spans are sent.
+	batchTimeout = 5 * time.Second
+
+	tracesPath = "/v1/traces"
+	scopeName  = "github.com/prometheus/prometheus"
+)
+
+// OTLP span kinds and status codes.
+const (
+	spanKindInternal = 1
+	spanKindServer   = 2
+	spanKindClient   = 3
+	spanKindProducer = 4
+	spanKindConsumer = 5
+
+	statusCodeError = 2
+)
+
+var (
+	sentSpans = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "prometheus",
+		Subsystem: "tracing",
+		Name:      "sent_spans_total",
+		Help:      "Total number of spans sent to the tracing collector.",
+	})
+	failedSpans = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "prometheus",
+		Subsystem: "tracing",
+		Name:      "failed_spans_total",
+		Help:      "Total number of spans which could not be sent to the tracing collector.",
+	})
+	droppedSpans = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "prometheus",
+		Subsystem: "tracing",
+		Name:      "dropped_spans_total",
+		Help:      "Total number of spans dropped because the export queue was full.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(sentSpans)
+	prometheus.MustRegister(failedSpans)
+	prometheus.MustRegister(droppedSpans)
+}
+
+// exporter sends finished spans in batches to an OpenTelemetry collector,
+// using the JSON encoding of OTLP over HTTP.
+type exporter struct {
+	url     string
+	headers map[string]config.Secret
+	client  *http.Client
+	timeout time.Duration
+	logger  log.Logger
+
+	mtx     sync.Mutex
+	pending []*span
+	stopped bool
+
+	more chan struct{}
+	quit chan struct{}
+	done chan struct{}
+}
+
+func newExporter(cfg *config.TracingConfig, logger log.Logger) (*exporter, error) {
+	tlsConfig, err := httputil.NewTLSConfig(cfg.TLSConfig)
+	if err != nil {
+		return nil, err
+	}
+	scheme := "https"
+	if cfg.Insecure {
+		scheme = "http"
+	}
+
+	return &exporter{
+		url:     scheme + "://" + cfg.Endpoint + tracesPath,
+		headers: cfg.Headers,
+		client: httputil.NewClient(&http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsConfig,
+		}),
+		timeout: time.Duration(cfg.Timeout),
+		logger:  logger,
+		more:    make(chan struct{}, 1),
+		quit:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}, nil
+}
+
+// record queues a finished span for export.
+func (e *exporter) record(s *span) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	// Spans started before a reload may finish after their exporter was
+	// stopped. They would never be sent.
+	if e.stopped || len(e.pending) >= maxQueueSize {
+		droppedSpans.Inc()
+		return
+	}
+	e.pending = append(e.pending, s)
+	if len(e.pending) >= maxBatchSize {
+		select {
+		case e.more <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// run sends the pending spans until the exporter is stopped.
+func (e *exporter) run() {
+	defer close(e.done)
+
+	ticker := time.NewTicker(batchTimeout)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-e.quit:
+			e.flush()
+			return
+		case <-ticker.C:
+		case <-e.more:
+		}
+		e.flush()
+	}
+}
+
+// stop sends the remaining pending spans and stops the exporter.
+func (e *exporter) stop() {
+	e.mtx.Lock()
+	e.stopped = true
+	e.mtx.Unlock()
+
+	close(e.quit)
+	<-e.done
+}
+
+// flush sends all pending spans.
+func (e *exporter) flush() {
+	e.mtx.Lock()
+	spans := e.pending
+	e.pending = nil
+	e.mtx.Unlock()
+
+	for len(spans) > 0 {
+		n := len(spans)
+		if n > maxBatchSize {
+			n = maxBatchSize
+		}
+		if err := e.send(spans[:n]); err != nil {
+			failedSpans.Add(float64(n))
+			e.logger.With("url", e.url).Warnf("Error sending %d spans: %s", n, err)
+		} else {
+			sentSpans.Add(float64(n))
+		}
+		spans = spans[n:]
+	}
+}
+
+func (e *exporter) send(spans []*span) error {
+	b, err := json.Marshal(newExportRequest(spans))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", e.url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.headers {
+		req.Header.Set(k, string(v))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
+	defer cancel()
+
+	resp, err := ctxhttp.Do(ctx, e.client, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("server returned HTTP status %s", resp.Status)
+	}
+	return nil
+}
+
+// The following types are the JSON encoding of an OTLP export request.
+
+type exportRequest struct {
+	ResourceSpans []resourceSpans `json:"resourceSpans"`
+}
+
+type resourceSpans struct {
+	Resource   resource     `json:"resource"`
+	ScopeSpans []scopeSpans `json:"scopeSpans"`
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes"`
+}
+
+type scopeSpans struct {
+	Scope scope      `json:"scope"`
+	Spans []spanData `json:"spans"`
+}
+
+type scope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type spanData struct {
+	TraceID           string     `json:"traceId"`
+	SpanID            string     `json:"spanId"`
+	ParentSpanID      string     `json:"parentSpanId,omitempty"`
+	Name              string     `json:"name"`
+	Kind              int        `json:"kind"`
+	StartTimeUnixNano string     `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string     `json:"endTimeUnixNano"`
+	Attributes        []keyValue `json:"attributes,omitempty"`
+	Events            []event    `json:"events,omitempty"`
+	Status            status     `json:"status"`
+}
+
+type event struct {
+	TimeUnixNano string     `json:"timeUnixNano"`
+	Name         string     `json:"name"`
+	Attributes   []keyValue `json:"attributes,omitempty"`
+}
+
+type status struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+type anyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+func newExportRequest(spans []*span) *exportRequest {
+	data := make([]spanData, 0, len(spans))
+	for _, s := range spans {
+		data = append(data, s.data())
+	}
+	return &exportRequest{
+		ResourceSpans: []resourceSpans{{
+			Resource: resource{
+				Attributes: []keyValue{
+					newKeyValue("service.name", "prometheus"),
+					newKeyValue("service.version", version.Version),
+				},
+			},
+			ScopeSpans: []scopeSpans{{
+				Scope: scope{Name: scopeName, Version: version.Version},
+				Spans: data,
+			}},
+		}},
+	}
+}
+
+// data returns the OTLP representation of a finished span. Tags become
+// attributes, except for the span kind and error tags of the opentracing
+// conventions, which map to the span kind and status.
+func (s *span) data() spanData {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	d := spanData{
+		TraceID:           hex.EncodeToString(s.ctx.traceID[:]),
+		SpanID:            hex.EncodeToString(s.ctx.spanID[:]),
+		Name:              s.name,
+		Kind:              spanKindInternal,
+		StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+	}
+	if s.parentID != ([8]byte{}) {
+		d.ParentSpanID = hex.EncodeToString(s.parentID[:])
+	}
+
+	for k, v := range s.tags {
+		switch k {
+		case "span.kind":
+			d.Kind = spanKind(fmt.Sprint(v))
+		case "error":
+			if b, ok := v.(bool); ok && b {
+				d.Status.Code = statusCodeError
+			}
+		default:
+			d.Attributes = append(d.Attributes, newKeyValue(k, v))
+		}
+	}
+
+	for _, rec := range s.logs {
+		ev := event{
+			TimeUnixNano: strconv.FormatInt(rec.Timestamp.UnixNano(), 10),
+			Name:         "log",
+		}
+		for _, f := range rec.Fields {
+			switch f.Key() {
+			case "event":
+				ev.Name = fmt.Sprint(f.Value())
+			case "error":
+				d.Status.Code = statusCodeError
+				d.Status.Message = fmt.Sprint(f.Value())
+				ev.Attributes = append(ev.Attributes, newKeyValue(f.Key(), f.Value()))
+			default:
+				ev.Attributes = append(ev.Attributes, newKeyValue(f.Key(), f.Value()))
+			}
+		}
+		d.Events = append(d.Events, ev)
+	}
+	return d
+}
+
+func spanKind(kind string) int {
+	switch kind {
+	case "server":
+		return spanKindServer
+	case "client":
+		return spanKindClient
+	case "producer":
+		return spanKindProducer
+	case "consumer":
+		return spanKindConsumer
+	default:
+		return spanKindInternal
+	}
+}
+
+func newKeyValue(k string, v interface{}) keyValue {
+	kv := keyValue{Key: k}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Bool:
+		b := rv.Bool()
+		kv.Value.BoolValue = &b
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i := strconv.FormatInt(rv.Int(), 10)
+		kv.Value.IntValue = &i
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i := strconv.FormatUint(rv.Uint(), 10)
+		kv.Value.IntValue = &i
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			// Not representable in JSON.
+			s := strconv.FormatFloat(f, 'g', -1, 64)
+			kv.Value.StringValue = &s
+			break
+		}
+		kv.Value.DoubleValue = &f
+	default:
+		s := fmt.Sprint(v)
+		kv.Value.StringValue = &s
+	}
+	return kv
+}
@@ -0,0 +1,120 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracing exports the trace spans of Prometheus to an OpenTelemetry
+// collector.
+package tracing
+
+import (
+	"reflect"
+	"sync"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/prometheus/common/log"
+
+	"github.com/prometheus/prometheus/config"
+)
+
+// Manager is an opentracing.Tracer exporting spans as configured by the
+// tracing block of the configuration. As long as tracing is not configured,
+// it behaves like an opentracing.NoopTracer.
+//
+// The Manager is meant to be installed as the global tracer once, so that
+// tracing can be enabled and disabled by reloading the configuration.
+type Manager struct {
+	logger log.Logger
+
+	mtx      sync.RWMutex
+	config   *config.TracingConfig
+	tracer   opentracing.Tracer
+	exporter *exporter
+}
+
+// NewManager returns a Manager with tracing disabled.
+func NewManager(logger log.Logger) *Manager {
+	return &Manager{
+		logger: logger,
+		tracer: opentracing.NoopTracer{},
+	}
+}
+
+// ApplyConfig updates the tracer and exporter as the config requires.
+// Pending spans of a replaced exporter are still sent.
+func (m *Manager) ApplyConfig(conf *config.Config) error {
+	m.mtx.Lock()
+	if reflect.DeepEqual(m.config, conf.TracingConfig) {
+		m.mtx.Unlock()
+		return nil
+	}
+
+	var (
+		tracer   opentracing.Tracer = opentracing.NoopTracer{}
+		exporter *exporter
+	)
+	if tcfg := conf.TracingConfig; tcfg != nil {
+		var err error
+		if exporter, err = newExporter(tcfg, m.logger); err != nil {
+			m.mtx.Unlock()
+			return err
+		}
+		tracer = newTracer(exporter, tcfg.SamplingFraction)
+		go exporter.run()
+	}
+
+	old := m.exporter
+	m.config = conf.TracingConfig
+	m.tracer = tracer
+	m.exporter = exporter
+	m.mtx.Unlock()
+
+	// Sending the remaining spans must not block starting new ones.
+	if old != nil {
+		old.stop()
+	}
+	return nil
+}
+
+// Stop sends the pending spans and disables tracing.
+func (m *Manager) Stop() {
+	m.mtx.Lock()
+	old := m.exporter
+	m.config = nil
+	m.tracer = opentracing.NoopTracer{}
+	m.exporter = nil
+	m.mtx.Unlock()
+
+	if old != nil {
+		old.stop()
+	}
+}
+
+func (m *Manager) current() opentracing.Tracer {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	return m.tracer
+}
+
+// StartSpan implements opentracing.Tracer.
+func (m *Manager) StartSpan(operationName string, opts ...opentracing.StartSpanOption) opentracing.Span {
+	return m.current().StartSpan(operationName, opts...)
+}
+
+// Inject implements opentracing.Tracer.
+func (m *Manager) Inject(sm opentracing.SpanContext, format interface{}, carrier interface{}) error {
+	return m.current().Inject(sm, format, carrier)
+}
+
+// Extract implements opentracing.Tracer.
+func (m *Manager) Extract(format interface{}, carrier interface{}) (opentracing.SpanContext, error) {
+	return m.current().Extract(format, carrier)
+}
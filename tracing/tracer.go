@@ -0,0 +1,323 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/log"
+)
+
+// traceparentHeader is the W3C trace context header span contexts are
+// propagated with.
+const traceparentHeader = "traceparent"
+
+// spanContext identifies a span within a trace.
+type spanContext struct {
+	traceID [16]byte
+	spanID  [8]byte
+	sampled bool
+	baggage map[string]string
+}
+
+// ForeachBaggageItem implements opentracing.SpanContext.
+func (c *spanContext) ForeachBaggageItem(handler func(k, v string) bool) {
+	for k, v := range c.baggage {
+		if !handler(k, v) {
+			return
+		}
+	}
+}
+
+// IsSampled returns whether the spans of the trace are recorded. Callers can
+// skip describing spans that are not.
+func (c *spanContext) IsSampled() bool {
+	return c.sampled
+}
+
+// tracer is an opentracing.Tracer handing sampled spans to an exporter when
+// they are finished.
+type tracer struct {
+	exporter         *exporter
+	samplingFraction float64
+}
+
+func newTracer(e *exporter, samplingFraction float64) *tracer {
+	return &tracer{exporter: e, samplingFraction: samplingFraction}
+}
+
+// StartSpan implements opentracing.Tracer.
+func (t *tracer) StartSpan(operationName string, opts ...opentracing.StartSpanOption) opentracing.Span {
+	sso := opentracing.StartSpanOptions{}
+	for _, o := range opts {
+		o.Apply(&sso)
+	}
+
+	s := &span{
+		tracer: t,
+		name:   operationName,
+		start:  sso.StartTime,
+		tags:   make(map[string]interface{}, len(sso.Tags)),
+		ctx:    &spanContext{baggage: map[string]string{}},
+	}
+	if s.start.IsZero() {
+		s.start = time.Now()
+	}
+	for k, v := range sso.Tags {
+		s.tags[k] = v
+	}
+
+	var parent *spanContext
+	for _, ref := range sso.References {
+		if sc, ok := ref.ReferencedContext.(*spanContext); ok {
+			parent = sc
+			break
+		}
+	}
+	if parent != nil {
+		s.ctx.traceID = parent.traceID
+		s.ctx.sampled = parent.sampled
+		s.parentID = parent.spanID
+		for k, v := range parent.baggage {
+			s.ctx.baggage[k] = v
+		}
+	} else {
+		rand.Read(s.ctx.traceID[:])
+		s.ctx.sampled = t.sample(s.ctx.traceID)
+	}
+	rand.Read(s.ctx.spanID[:])
+
+	return s
+}
+
+// sample returns whether the trace with the given ID is recorded. The
+// decision only depends on the trace ID, so that it is the same across
+// processes sampling at the same fraction.
+func (t *tracer) sample(traceID [16]byte) bool {
+	if t.samplingFraction >= 1 {
+		return true
+	}
+	x := binary.BigEndian.Uint64(traceID[8:]) >> 1
+	return float64(x) < t.samplingFraction*(1<<63)
+}
+
+// Inject implements opentracing.Tracer. Span contexts are propagated as W3C
+// traceparent headers.
+func (t *tracer) Inject(sm opentracing.SpanContext, format interface{}, carrier interface{}) error {
+	sc, ok := sm.(*spanContext)
+	if !ok {
+		return opentracing.ErrInvalidSpanContext
+	}
+	if format != opentracing.HTTPHeaders && format != opentracing.TextMap {
+		return opentracing.ErrUnsupportedFormat
+	}
+	w, ok := carrier.(opentracing.TextMapWriter)
+	if !ok {
+		return opentracing.ErrInvalidCarrier
+	}
+
+	flags := 0
+	if sc.sampled {
+		flags = 1
+	}
+	w.Set(traceparentHeader, fmt.Sprintf("00-%x-%x-%02x", sc.traceID, sc.spanID, flags))
+	return nil
+}
+
+// Extract implements opentracing.Tracer.
+func (t *tracer) Extract(format interface{}, carrier interface{}) (opentracing.SpanContext, error) {
+	if format != opentracing.HTTPHeaders && format != opentracing.TextMap {
+		return nil, opentracing.ErrUnsupportedFormat
+	}
+	r, ok := carrier.(opentracing.TextMapReader)
+	if !ok {
+		return nil, opentracing.ErrInvalidCarrier
+	}
+
+	var traceparent string
+	err := r.ForeachKey(func(k, v string) error {
+		if strings.EqualFold(k, traceparentHeader) {
+			traceparent = v
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if traceparent == "" {
+		return nil, opentracing.ErrSpanContextNotFound
+	}
+	return parseTraceparent(traceparent)
+}
+
+// parseTraceparent parses a W3C traceparent header value.
+func parseTraceparent(s string) (*spanContext, error) {
+	parts := strings.Split(s, "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" || len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return nil, opentracing.ErrSpanContextCorrupted
+	}
+	sc := &spanContext{baggage: map[string]string{}}
+	if _, err := hex.Decode(sc.traceID[:], []byte(parts[1])); err != nil {
+		return nil, opentracing.ErrSpanContextCorrupted
+	}
+	if _, err := hex.Decode(sc.spanID[:], []byte(parts[2])); err != nil {
+		return nil, opentracing.ErrSpanContextCorrupted
+	}
+	var flags [1]byte
+	if _, err := hex.Decode(flags[:], []byte(parts[3])); err != nil {
+		return nil, opentracing.ErrSpanContextCorrupted
+	}
+	if sc.traceID == ([16]byte{}) || sc.spanID == ([8]byte{}) {
+		return nil, opentracing.ErrSpanContextCorrupted
+	}
+	sc.sampled = flags[0]&1 == 1
+	return sc, nil
+}
+
+// span is a span recorded by a tracer.
+type span struct {
+	tracer   *tracer
+	parentID [8]byte
+
+	mtx   sync.Mutex
+	ctx   *spanContext
+	name  string
+	start time.Time
+	end   time.Time
+	tags  map[string]interface{}
+	logs  []opentracing.LogRecord
+	ended bool
+}
+
+// Finish implements opentracing.Span.
+func (s *span) Finish() {
+	s.FinishWithOptions(opentracing.FinishOptions{})
+}
+
+// FinishWithOptions implements opentracing.Span.
+func (s *span) FinishWithOptions(opts opentracing.FinishOptions) {
+	s.mtx.Lock()
+	if s.ended {
+		s.mtx.Unlock()
+		return
+	}
+	s.ended = true
+	s.end = opts.FinishTime
+	if s.end.IsZero() {
+		s.end = time.Now()
+	}
+	s.logs = append(s.logs, opts.LogRecords...)
+	for _, ld := range opts.BulkLogData {
+		s.logs = append(s.logs, ld.ToLogRecord())
+	}
+	sampled := s.ctx.sampled
+	s.mtx.Unlock()
+
+	if sampled && s.tracer.exporter != nil {
+		s.tracer.exporter.record(s)
+	}
+}
+
+// Context implements opentracing.Span.
+func (s *span) Context() opentracing.SpanContext {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.ctx
+}
+
+// SetOperationName implements opentracing.Span.
+func (s *span) SetOperationName(operationName string) opentracing.Span {
+	s.mtx.Lock()
+	s.name = operationName
+	s.mtx.Unlock()
+	return s
+}
+
+// SetTag implements opentracing.Span.
+func (s *span) SetTag(key string, value interface{}) opentracing.Span {
+	s.mtx.Lock()
+	s.tags[key] = value
+	s.mtx.Unlock()
+	return s
+}
+
+// LogFields implements opentracing.Span.
+func (s *span) LogFields(fields ...log.Field) {
+	s.mtx.Lock()
+	s.logs = append(s.logs, opentracing.LogRecord{Timestamp: time.Now(), Fields: fields})
+	s.mtx.Unlock()
+}
+
+// LogKV implements opentracing.Span.
+func (s *span) LogKV(alternatingKeyValues ...interface{}) {
+	fields, err := log.InterleavedKVToFields(alternatingKeyValues...)
+	if err != nil {
+		fields = []log.Field{log.Error(err)}
+	}
+	s.LogFields(fields...)
+}
+
+// SetBaggageItem implements opentracing.Span. Baggage is only propagated
+// within the process.
+func (s *span) SetBaggageItem(restrictedKey, value string) opentracing.Span {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	// Span contexts are immutable as they are shared with child spans.
+	ctx := *s.ctx
+	ctx.baggage = make(map[string]string, len(s.ctx.baggage)+1)
+	for k, v := range s.ctx.baggage {
+		ctx.baggage[k] = v
+	}
+	ctx.baggage[restrictedKey] = value
+	s.ctx = &ctx
+	return s
+}
+
+// BaggageItem implements opentracing.Span.
+func (s *span) BaggageItem(restrictedKey string) string {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.ctx.baggage[restrictedKey]
+}
+
+// Tracer implements opentracing.Span.
+func (s *span) Tracer() opentracing.Tracer {
+	return s.tracer
+}
+
+// LogEvent implements opentracing.Span.
+func (s *span) LogEvent(event string) {
+	s.Log(opentracing.LogData{Event: event})
+}
+
+// LogEventWithPayload implements opentracing.Span.
+func (s *span) LogEventWithPayload(event string, payload interface{}) {
+	s.Log(opentracing.LogData{Event: event, Payload: payload})
+}
+
+// Log implements opentracing.Span.
+func (s *span) Log(ld opentracing.LogData) {
+	rec := ld.ToLogRecord()
+	s.mtx.Lock()
+	s.logs = append(s.logs, rec)
+	s.mtx.Unlock()
+}
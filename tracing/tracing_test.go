@@ -0,0 +1,231 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	otlog "github.com/opentracing/opentracing-go/log"
+	"github.com/prometheus/common/log"
+
+	"github.com/prometheus/prometheus/config"
+)
+
+// collector is a fake OTLP collector recording the received spans.
+type collector struct {
+	mtx     sync.Mutex
+	spans   []spanData
+	headers http.Header
+}
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != tracesPath {
+		http.NotFound(w, r)
+		return
+	}
+	var req exportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.headers = r.Header
+	for _, rs := range req.ResourceSpans {
+		for _, ss := range rs.ScopeSpans {
+			c.spans = append(c.spans, ss.Spans...)
+		}
+	}
+}
+
+func newTestManager(t *testing.T, c *collector, samplingFraction float64) (*Manager, func()) {
+	server := httptest.NewServer(c)
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewManager(log.Base())
+	err = m.ApplyConfig(&config.Config{
+		TracingConfig: &config.TracingConfig{
+			Endpoint:         u.Host,
+			Insecure:         true,
+			SamplingFraction: samplingFraction,
+			Headers:          map[string]config.Secret{"X-Scope-OrgID": "test"},
+			Timeout:          config.DefaultTracingConfig.Timeout,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m, server.Close
+}
+
+func attribute(d spanData, key string) *anyValue {
+	for _, kv := range d.Attributes {
+		if kv.Key == key {
+			return &kv.Value
+		}
+	}
+	return nil
+}
+
+func TestExport(t *testing.T) {
+	c := &collector{}
+	m, closeServer := newTestManager(t, c, 1)
+	defer closeServer()
+
+	parent := m.StartSpan("parent", ext.SpanKindRPCServer)
+	child := m.StartSpan("child", opentracing.ChildOf(parent.Context()))
+	child.SetTag("samples", 42)
+	child.SetTag("job", "node")
+	ext.Error.Set(child, true)
+	child.LogFields(otlog.Error(errors.New("scrape failed")))
+	child.Finish()
+	parent.Finish()
+
+	// Stopping sends the pending spans.
+	m.Stop()
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if got := c.headers.Get("X-Scope-OrgID"); got != "test" {
+		t.Errorf("want header %q, got %q", "test", got)
+	}
+	if len(c.spans) != 2 {
+		t.Fatalf("want 2 spans, got %d", len(c.spans))
+	}
+	ch, p := c.spans[0], c.spans[1]
+
+	if p.Name != "parent" || p.Kind != spanKindServer || p.ParentSpanID != "" {
+		t.Errorf("unexpected parent span %+v", p)
+	}
+	if ch.Name != "child" || ch.Kind != spanKindInternal {
+		t.Errorf("unexpected child span %+v", ch)
+	}
+	if ch.TraceID != p.TraceID || ch.ParentSpanID != p.SpanID {
+		t.Errorf("child span %s/%s is not a child of parent %s/%s", ch.TraceID, ch.ParentSpanID, p.TraceID, p.SpanID)
+	}
+	if ch.Status.Code != statusCodeError || ch.Status.Message != "scrape failed" {
+		t.Errorf("unexpected child span status %+v", ch.Status)
+	}
+	if v := attribute(ch, "samples"); v == nil || v.IntValue == nil || *v.IntValue != "42" {
+		t.Errorf("unexpected samples attribute %+v", v)
+	}
+	if v := attribute(ch, "job"); v == nil || v.StringValue == nil || *v.StringValue != "node" {
+		t.Errorf("unexpected job attribute %+v", v)
+	}
+	if attribute(ch, "error") != nil {
+		t.Errorf("error tag must not be exported as attribute")
+	}
+}
+
+func TestSampling(t *testing.T) {
+	c := &collector{}
+	m, closeServer := newTestManager(t, c, 0)
+	defer closeServer()
+
+	parent := m.StartSpan("parent")
+	if parent.Context().(*spanContext).IsSampled() {
+		t.Errorf("want unsampled span at sampling fraction 0")
+	}
+	m.StartSpan("child", opentracing.ChildOf(parent.Context())).Finish()
+	parent.Finish()
+	m.Stop()
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if len(c.spans) != 0 {
+		t.Fatalf("want no spans at sampling fraction 0, got %d", len(c.spans))
+	}
+}
+
+func TestFinishAfterReload(t *testing.T) {
+	c := &collector{}
+	m, closeServer := newTestManager(t, c, 1)
+	defer closeServer()
+
+	s := m.StartSpan("span")
+	old := m.exporter
+	m.Stop()
+	s.Finish()
+
+	old.mtx.Lock()
+	defer old.mtx.Unlock()
+	if len(old.pending) != 0 {
+		t.Fatalf("want no spans queued on a stopped exporter, got %d", len(old.pending))
+	}
+}
+
+func TestDisabled(t *testing.T) {
+	m := NewManager(log.Base())
+	if err := m.ApplyConfig(&config.Config{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := m.StartSpan("span").Tracer().(opentracing.NoopTracer); !ok {
+		t.Fatalf("want noop spans while tracing is not configured")
+	}
+}
+
+func TestInjectExtract(t *testing.T) {
+	tr := newTracer(nil, 1)
+
+	for _, sampled := range []bool{true, false} {
+		tr.samplingFraction = 0
+		if sampled {
+			tr.samplingFraction = 1
+		}
+		s := tr.StartSpan("span")
+
+		h := http.Header{}
+		if err := tr.Inject(s.Context(), opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(h)); err != nil {
+			t.Fatal(err)
+		}
+		sc, err := tr.Extract(opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(h))
+		if err != nil {
+			t.Fatalf("unexpected error extracting %q: %s", h.Get(traceparentHeader), err)
+		}
+
+		exp := s.Context().(*spanContext)
+		got := sc.(*spanContext)
+		if got.traceID != exp.traceID || got.spanID != exp.spanID || got.sampled != sampled {
+			t.Errorf("want span context %+v, got %+v", exp, got)
+		}
+	}
+
+	for _, tp := range []string{
+		"",
+		"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331",
+		"00-00000000000000000000000000000000-b7ad6b7169203331-01",
+		"00-0af7651916cd43dd8448eb211c80319c-xxad6b7169203331-01",
+	} {
+		h := http.Header{}
+		if tp != "" {
+			h.Set(traceparentHeader, tp)
+		}
+		if _, err := tr.Extract(opentracing.HTTPHeaders, opentracing.HTTPHeadersCarrier(h)); err == nil {
+			t.Errorf("expected error extracting %q", tp)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package awsutil provides helpers for the AWS clients.
+package awsutil
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/ec2rolecreds"
+	"github.com/aws/aws-sdk-go/aws/defaults"
+	"github.com/aws/aws-sdk-go/aws/ec2metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/go-ini/ini"
+)
+
+const (
+	imdsTokenHeader    = "X-aws-ec2-metadata-token"
+	imdsTokenTTLHeader = "X-aws-ec2-metadata-token-ttl-seconds"
+	imdsTokenTTL       = 6 * time.Hour
+	// How long to send requests without a token after fetching one failed.
+	imdsTokenRetryInterval = time.Minute
+)
+
+// NewEC2Metadata returns a client of the EC2 instance metadata service that
+// authenticates its requests with IMDSv2 session tokens. If no token can be
+// obtained, like on instances only supporting IMDSv1, requests are sent
+// without one.
+func NewEC2Metadata(p client.ConfigProvider, cfgs ...*aws.Config) *ec2metadata.EC2Metadata {
+	c := ec2metadata.New(p, cfgs...)
+	t := &imdsTokenSource{
+		endpoint: c.ClientInfo.Endpoint,
+		client:   c.Config.HTTPClient,
+		now:      time.Now,
+	}
+	c.Handlers.Build.PushBack(t.setToken)
+	return c
+}
+
+// NewCredentials returns the credentials of the default AWS credential chain,
+// with the instance profile credentials retrieved with IMDSv2 session tokens.
+// It returns nil if the shared config profile assumes a role, as only the
+// session resolves those credentials.
+func NewCredentials(p client.ConfigProvider, profile string) *credentials.Credentials {
+	if sharedConfigAssumesRole(profile) {
+		return nil
+	}
+	return credentials.NewChainCredentials([]credentials.Provider{
+		&credentials.EnvProvider{},
+		&credentials.SharedCredentialsProvider{Profile: profile},
+		remoteCredProvider(p),
+	})
+}
+
+// remoteCredProvider returns the provider of the SDK for the ECS task role if
+// the container credentials endpoint is set, and the provider of the EC2
+// instance role using IMDSv2 otherwise.
+func remoteCredProvider(p client.ConfigProvider) credentials.Provider {
+	if os.Getenv("AWS_CONTAINER_CREDENTIALS_RELATIVE_URI") != "" {
+		c := p.ClientConfig(ec2metadata.ServiceName)
+		return defaults.RemoteCredProvider(*c.Config, c.Handlers)
+	}
+	return &ec2rolecreds.EC2RoleProvider{
+		Client:       NewEC2Metadata(p),
+		ExpiryWindow: 5 * time.Minute,
+	}
+}
+
+// sharedConfigAssumesRole returns whether the given profile of the shared
+// config or credentials file has a role_arn. Like the SDK, the shared config
+// is only considered if AWS_SDK_LOAD_CONFIG is set.
+func sharedConfigAssumesRole(profile string) bool {
+	if ok, _ := strconv.ParseBool(os.Getenv("AWS_SDK_LOAD_CONFIG")); !ok {
+		return false
+	}
+	if profile == "" {
+		profile = envOr("AWS_PROFILE", envOr("AWS_DEFAULT_PROFILE", "default"))
+	}
+	home := envOr("HOME", os.Getenv("USERPROFILE"))
+	files := []string{
+		envOr("AWS_CONFIG_FILE", filepath.Join(home, ".aws", "config")),
+		envOr("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(home, ".aws", "credentials")),
+	}
+	for _, fn := range files {
+		f, err := ini.Load(fn)
+		if err != nil {
+			continue
+		}
+		// The config file prefixes profile sections with "profile ".
+		for _, name := range []string{profile, "profile " + profile} {
+			if sec, err := f.GetSection(name); err == nil && sec.Key("role_arn").String() != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func envOr(name, def string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return def
+}
+
+// imdsTokenSource caches the IMDSv2 session token.
+type imdsTokenSource struct {
+	endpoint string
+	client   *http.Client
+
+	mtx     sync.Mutex
+	token   string
+	expires time.Time
+
+	now func() time.Time
+}
+
+func (t *imdsTokenSource) setToken(r *request.Request) {
+	if token := t.get(); token != "" {
+		r.HTTPRequest.Header.Set(imdsTokenHeader, token)
+	}
+}
+
+// get returns the cached token, or a new one if it is about to expire. An
+// empty token is returned if none can be obtained, and for a while after.
+func (t *imdsTokenSource) get() string {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	if t.now().Before(t.expires) {
+		return t.token
+	}
+	token, err := t.fetch()
+	if err != nil {
+		// Do not delay every request by fetching the token on instances
+		// only supporting IMDSv1.
+		t.token = ""
+		t.expires = t.now().Add(imdsTokenRetryInterval)
+		return ""
+	}
+	t.token = token
+	// Renew the token well before it expires.
+	t.expires = t.now().Add(imdsTokenTTL - time.Minute)
+	return token
+}
+
+func (t *imdsTokenSource) fetch() (string, error) {
+	// The endpoint ends with the API version, e.g. http://169.254.169.254/latest.
+	req, err := http.NewRequest("PUT", t.endpoint+"/api/token", nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set(imdsTokenTTLHeader, strconv.Itoa(int(imdsTokenTTL.Seconds())))
+	client := t.client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %s fetching IMDSv2 token", resp.Status)
+	}
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -0,0 +1,141 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awsutil
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials/ec2rolecreds"
+	"github.com/aws/aws-sdk-go/aws/credentials/endpointcreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+)
+
+func TestEC2MetadataSessionToken(t *testing.T) {
+	for _, v2 := range []bool{true, false} {
+		tokens, fetches := 0, 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/latest/api/token":
+				fetches++
+				if !v2 {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				if r.Method != "PUT" || r.Header.Get(imdsTokenTTLHeader) == "" {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				tokens++
+				w.Write([]byte("token"))
+			case "/latest/meta-data/placement/availability-zone":
+				if v2 && r.Header.Get(imdsTokenHeader) != "token" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				w.Write([]byte("us-east-1a"))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer server.Close()
+
+		sess, err := session.NewSession()
+		if err != nil {
+			t.Fatal(err)
+		}
+		c := NewEC2Metadata(sess, &aws.Config{Endpoint: aws.String(server.URL + "/latest")})
+		for i := 0; i < 2; i++ {
+			region, err := c.Region()
+			if err != nil {
+				t.Fatalf("IMDSv2 %t: unexpected error: %s", v2, err)
+			}
+			if region != "us-east-1" {
+				t.Errorf("IMDSv2 %t: expected region us-east-1, got %s", v2, region)
+			}
+		}
+		if v2 && tokens != 1 {
+			t.Errorf("expected the token to be fetched once, got %d", tokens)
+		}
+		// Failed fetches are not retried for every request.
+		if fetches != 1 {
+			t.Errorf("IMDSv2 %t: expected one token request, got %d", v2, fetches)
+		}
+	}
+}
+
+func TestRemoteCredProvider(t *testing.T) {
+	sess, err := session.NewSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Unsetenv("AWS_CONTAINER_CREDENTIALS_RELATIVE_URI")
+
+	os.Unsetenv("AWS_CONTAINER_CREDENTIALS_RELATIVE_URI")
+	if _, ok := remoteCredProvider(sess).(*ec2rolecreds.EC2RoleProvider); !ok {
+		t.Errorf("expected the EC2 role provider outside of ECS")
+	}
+	os.Setenv("AWS_CONTAINER_CREDENTIALS_RELATIVE_URI", "/v2/credentials/task")
+	if _, ok := remoteCredProvider(sess).(*endpointcreds.Provider); !ok {
+		t.Errorf("expected the ECS task role provider with the container credentials endpoint set")
+	}
+}
+
+func TestSharedConfigAssumesRole(t *testing.T) {
+	dir, err := ioutil.TempDir("", "awsutil")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	configFile := filepath.Join(dir, "config")
+	err = ioutil.WriteFile(configFile, []byte(`
+[profile assume]
+role_arn = arn:aws:iam::123456789012:role/prometheus
+source_profile = default
+
+[profile static]
+region = us-east-1
+`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range map[string]string{
+		"AWS_CONFIG_FILE":             configFile,
+		"AWS_SHARED_CREDENTIALS_FILE": filepath.Join(dir, "credentials"),
+		"AWS_SDK_LOAD_CONFIG":         "1",
+	} {
+		os.Setenv(k, v)
+		defer os.Unsetenv(k)
+	}
+
+	if !sharedConfigAssumesRole("assume") {
+		t.Errorf("expected profile with role_arn to assume a role")
+	}
+	if sharedConfigAssumesRole("static") {
+		t.Errorf("expected profile without role_arn not to assume a role")
+	}
+	if NewCredentials(session.Must(session.NewSession()), "assume") != nil {
+		t.Errorf("expected no explicit credentials for a profile assuming a role")
+	}
+	os.Setenv("AWS_SDK_LOAD_CONFIG", "0")
+	if sharedConfigAssumesRole("assume") {
+		t.Errorf("expected the shared config to be ignored without AWS_SDK_LOAD_CONFIG")
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package hashutil provides an inline 64-bit FNV-1a hash for hot paths, as
+// hash/fnv allocates.
+package hashutil
+
+const (
+	offset64 = 14695981039346656037
+	prime64  = 1099511628211
+)
+
+// New returns the initial value of an FNV-1a hash.
+func New() uint64 {
+	return offset64
+}
+
+// Add adds the bytes of s to the hash h.
+func Add(h uint64, s string) uint64 {
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= prime64
+	}
+	return h
+}
+
+// AddByte adds b to the hash h.
+func AddByte(h uint64, b byte) uint64 {
+	h ^= uint64(b)
+	h *= prime64
+	return h
+}
@@ -0,0 +1,31 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hashutil
+
+import (
+	"hash/fnv"
+	"testing"
+)
+
+func TestFNV(t *testing.T) {
+	for _, s := range []string{"", "a", "job", "http_requests_total"} {
+		exp := fnv.New64a()
+		exp.Write([]byte(s))
+		exp.Write([]byte{0xff})
+
+		if got := AddByte(Add(New(), s), 0xff); got != exp.Sum64() {
+			t.Errorf("%q: expected hash %d, got %d", s, exp.Sum64(), got)
+		}
+	}
+}
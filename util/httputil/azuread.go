@@ -0,0 +1,150 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httputil
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/prometheus/prometheus/config"
+)
+
+// azureCloud holds the endpoints of an Azure cloud.
+type azureCloud struct {
+	// The Azure AD host tokens are requested from.
+	authorityHost string
+	// The Azure Monitor resource tokens are requested for.
+	audience string
+}
+
+var azureClouds = map[string]azureCloud{
+	config.AzurePublic:     {authorityHost: "https://login.microsoftonline.com/", audience: "https://monitor.azure.com"},
+	config.AzureChina:      {authorityHost: "https://login.chinacloudapi.cn/", audience: "https://monitor.azure.cn"},
+	config.AzureGovernment: {authorityHost: "https://login.microsoftonline.us/", audience: "https://monitor.azure.us"},
+}
+
+// azureIMDSEndpoint is the token endpoint of the Azure instance metadata
+// service, which hands out tokens for managed identities.
+var azureIMDSEndpoint = "http://169.254.169.254/metadata/identity/oauth2/token"
+
+// NewAzureADRoundTripper returns a http.RoundTripper that adds an Azure AD
+// access token for Azure Monitor to each request. Tokens are cached and only
+// refreshed once they have expired. Like the requests themselves, token
+// requests go through the passed round tripper.
+func NewAzureADRoundTripper(cfg *config.AzureADConfig, rt http.RoundTripper) (http.RoundTripper, error) {
+	cloudName := cfg.Cloud
+	if cloudName == "" {
+		cloudName = config.AzurePublic
+	}
+	cloud, ok := azureClouds[cloudName]
+	if !ok {
+		return nil, fmt.Errorf("unknown azuread cloud %q", cfg.Cloud)
+	}
+
+	var src oauth2.TokenSource
+	switch {
+	case cfg.ManagedIdentity != nil:
+		src = &azureManagedIdentityTokenSource{
+			clientID: cfg.ManagedIdentity.ClientID,
+			resource: cloud.audience,
+			client:   NewClient(rt),
+		}
+	case cfg.OAuth != nil:
+		src = &clientCredentialsTokenSource{
+			config: &config.OAuth2{
+				ClientID:         cfg.OAuth.ClientID,
+				ClientSecret:     cfg.OAuth.ClientSecret,
+				ClientSecretFile: cfg.OAuth.ClientSecretFile,
+				Scopes:           []string{cloud.audience + "//.default"},
+				TokenURL:         cloud.authorityHost + url.PathEscape(cfg.OAuth.TenantID) + "/oauth2/v2.0/token",
+			},
+			client: NewClient(rt),
+		}
+	default:
+		return nil, fmt.Errorf("neither managed_identity nor oauth configured in azuread")
+	}
+
+	return &oauth2.Transport{
+		Source: oauth2.ReuseTokenSource(nil, src),
+		Base:   rt,
+	}, nil
+}
+
+// azureManagedIdentityTokenSource fetches tokens of a managed identity from
+// the Azure instance metadata service.
+type azureManagedIdentityTokenSource struct {
+	clientID string
+	resource string
+	client   *http.Client
+}
+
+type azureIMDSTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	// Durations and times are returned as strings of seconds.
+	ExpiresIn string `json:"expires_in"`
+}
+
+// Token implements oauth2.TokenSource.
+func (s *azureManagedIdentityTokenSource) Token() (*oauth2.Token, error) {
+	v := url.Values{}
+	v.Set("api-version", "2018-02-01")
+	v.Set("resource", s.resource)
+	v.Set("client_id", s.clientID)
+
+	req, err := http.NewRequest("GET", azureIMDSEndpoint+"?"+v.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Metadata", "true")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch managed identity token: %s", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read managed identity token response: %s", err)
+	}
+	if resp.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("instance metadata service returned HTTP status %s: %s", resp.Status, body)
+	}
+
+	var tr azureIMDSTokenResponse
+	if err := json.Unmarshal(body, &tr); err != nil {
+		return nil, fmt.Errorf("unable to parse managed identity token response: %s", err)
+	}
+	if tr.AccessToken == "" {
+		return nil, fmt.Errorf("instance metadata service returned no access token")
+	}
+
+	token := &oauth2.Token{
+		AccessToken: tr.AccessToken,
+		TokenType:   tr.TokenType,
+	}
+	if expiresIn, err := strconv.ParseInt(tr.ExpiresIn, 10, 64); err == nil && expiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(expiresIn) * time.Second)
+	}
+	return token, nil
+}
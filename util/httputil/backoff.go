@@ -0,0 +1,52 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httputil
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Backoff is an exponential backoff between the retries of requests rejected
+// with 429 or failing with a 5xx status.
+type Backoff struct {
+	// The delay before the first retry, doubled for each further one.
+	Min time.Duration
+	// The maximum delay before a retry.
+	Max time.Duration
+	// The maximum number of retries of a request.
+	Retries int
+}
+
+// Delay returns the delay before the given retry, counted from zero, of a
+// request after its response, and whether it should be retried at all. The
+// Retry-After duration of a rejected request is used if set.
+func (b Backoff) Delay(resp *http.Response, retry int) (time.Duration, bool) {
+	if retry >= b.Retries {
+		return 0, false
+	}
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode/100 != 5 {
+		return 0, false
+	}
+
+	delay := b.Min << uint(retry)
+	if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		delay = time.Duration(secs) * time.Second
+	}
+	if delay > b.Max || delay < 0 {
+		delay = b.Max
+	}
+	return delay, true
+}
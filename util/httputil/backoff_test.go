@@ -0,0 +1,52 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httputil
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestBackoffDelay(t *testing.T) {
+	b := Backoff{Min: time.Second, Max: 10 * time.Second, Retries: 5}
+
+	for _, tc := range []struct {
+		status     int
+		retryAfter string
+		retry      int
+		delay      time.Duration
+		ok         bool
+	}{
+		{status: http.StatusOK},
+		{status: http.StatusNotFound},
+		{status: http.StatusInternalServerError, delay: time.Second, ok: true},
+		{status: http.StatusBadGateway, retry: 2, delay: 4 * time.Second, ok: true},
+		{status: http.StatusServiceUnavailable, retry: 4, delay: 10 * time.Second, ok: true},
+		{status: http.StatusServiceUnavailable, retry: 5},
+		{status: http.StatusTooManyRequests, retry: 1, delay: 2 * time.Second, ok: true},
+		{status: http.StatusTooManyRequests, retryAfter: "3", delay: 3 * time.Second, ok: true},
+		{status: http.StatusTooManyRequests, retryAfter: "60", delay: 10 * time.Second, ok: true},
+	} {
+		resp := &http.Response{StatusCode: tc.status, Header: http.Header{}}
+		if tc.retryAfter != "" {
+			resp.Header.Set("Retry-After", tc.retryAfter)
+		}
+		delay, ok := b.Delay(resp, tc.retry)
+		if ok != tc.ok || delay != tc.delay {
+			t.Errorf("Expected retry %d after status %d to be delayed by %s (%t), got %s (%t)",
+				tc.retry, tc.status, tc.delay, tc.ok, delay, ok)
+		}
+	}
+}
@@ -16,10 +16,16 @@ package httputil
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
 
 	"github.com/prometheus/prometheus/config"
 )
@@ -42,29 +48,45 @@ func NewClientFromConfig(cfg config.HTTPClientConfig) (*http.Client, error) {
 	}
 	// The only timeout we care about is the configured scrape timeout.
 	// It is applied on request. So we leave out any timings here.
+	var proxyConnectHeader http.Header
+	if len(cfg.ProxyConnectHeader) > 0 {
+		proxyConnectHeader = http.Header{}
+		for name, values := range cfg.ProxyConnectHeader {
+			for _, v := range values {
+				proxyConnectHeader.Add(name, string(v))
+			}
+		}
+	}
 	var rt http.RoundTripper = &http.Transport{
-		Proxy:             http.ProxyURL(cfg.ProxyURL.URL),
-		DisableKeepAlives: disableKeepAlives,
-		TLSClientConfig:   tlsConfig,
+		Proxy:              NewProxyFunc(cfg.ProxyURL.URL, cfg.NoProxy),
+		ProxyConnectHeader: proxyConnectHeader,
+		DisableKeepAlives:  disableKeepAlives,
+		TLSClientConfig:    tlsConfig,
 	}
 
 	// If a bearer token is provided, create a round tripper that will set the
-	// Authorization header correctly on each request.
-	bearerToken := string(cfg.BearerToken)
-	if len(bearerToken) == 0 && len(cfg.BearerTokenFile) > 0 {
-		b, err := ioutil.ReadFile(cfg.BearerTokenFile)
-		if err != nil {
+	// Authorization header correctly on each request. Bearer token files are
+	// read once here to catch errors early, and again on each request.
+	if len(cfg.BearerToken) > 0 {
+		rt = NewBearerAuthRoundTripper(string(cfg.BearerToken), rt)
+	} else if len(cfg.BearerTokenFile) > 0 {
+		if _, err := config.ReadSecretFile(cfg.BearerTokenFile); err != nil {
 			return nil, fmt.Errorf("unable to read bearer token file %s: %s", cfg.BearerTokenFile, err)
 		}
-		bearerToken = strings.TrimSpace(string(b))
+		rt = NewBearerAuthFileRoundTripper(cfg.BearerTokenFile, rt)
 	}
 
-	if len(bearerToken) > 0 {
-		rt = NewBearerAuthRoundTripper(bearerToken, rt)
+	if cfg.BasicAuth != nil {
+		if len(cfg.BasicAuth.PasswordFile) > 0 {
+			if _, err := config.ReadSecretFile(cfg.BasicAuth.PasswordFile); err != nil {
+				return nil, fmt.Errorf("unable to read password file %s: %s", cfg.BasicAuth.PasswordFile, err)
+			}
+		}
+		rt = NewBasicAuthRoundTripper(cfg.BasicAuth.Username, string(cfg.BasicAuth.Password), cfg.BasicAuth.PasswordFile, rt)
 	}
 
-	if cfg.BasicAuth != nil {
-		rt = NewBasicAuthRoundTripper(cfg.BasicAuth.Username, string(cfg.BasicAuth.Password), rt)
+	if cfg.OAuth2 != nil {
+		rt = NewOAuth2RoundTripper(cfg.OAuth2, rt)
 	}
 
 	// Return a new client with the configured round tripper.
@@ -91,27 +113,145 @@ func (rt *bearerAuthRoundTripper) RoundTrip(req *http.Request) (*http.Response,
 	return rt.rt.RoundTrip(req)
 }
 
+type bearerAuthFileRoundTripper struct {
+	bearerFile string
+	rt         http.RoundTripper
+}
+
+// NewBearerAuthFileRoundTripper adds the bearer token read from the provided
+// file to a request unless the authorization header has already been set. The
+// file is read on each request, so that the token can be rotated.
+func NewBearerAuthFileRoundTripper(bearerFile string, rt http.RoundTripper) http.RoundTripper {
+	return &bearerAuthFileRoundTripper{bearerFile, rt}
+}
+
+func (rt *bearerAuthFileRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if len(req.Header.Get("Authorization")) == 0 {
+		token, err := config.ReadSecretFile(rt.bearerFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read bearer token file %s: %s", rt.bearerFile, err)
+		}
+		req = cloneRequest(req)
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return rt.rt.RoundTrip(req)
+}
+
 type basicAuthRoundTripper struct {
-	username string
-	password string
-	rt       http.RoundTripper
+	username     string
+	password     string
+	passwordFile string
+	rt           http.RoundTripper
 }
 
 // NewBasicAuthRoundTripper will apply a BASIC auth authorization header to a request unless it has
-// already been set.
-func NewBasicAuthRoundTripper(username, password string, rt http.RoundTripper) http.RoundTripper {
-	return &basicAuthRoundTripper{username, password, rt}
+// already been set. If a password file is given, it is read on each request.
+func NewBasicAuthRoundTripper(username, password, passwordFile string, rt http.RoundTripper) http.RoundTripper {
+	return &basicAuthRoundTripper{username, password, passwordFile, rt}
 }
 
 func (rt *basicAuthRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
 	if len(req.Header.Get("Authorization")) != 0 {
 		return rt.rt.RoundTrip(req)
 	}
+	password := rt.password
+	if len(rt.passwordFile) > 0 {
+		var err error
+		if password, err = config.ReadSecretFile(rt.passwordFile); err != nil {
+			return nil, fmt.Errorf("unable to read password file %s: %s", rt.passwordFile, err)
+		}
+	}
 	req = cloneRequest(req)
-	req.SetBasicAuth(rt.username, rt.password)
+	req.SetBasicAuth(rt.username, password)
 	return rt.rt.RoundTrip(req)
 }
 
+// NewOAuth2RoundTripper returns a http.RoundTripper that fetches an access
+// token using the OAuth2 client credentials grant and adds it to each request.
+// Tokens are cached and only refreshed once they have expired. The token
+// endpoint is contacted through the passed round tripper, so TLS and proxy
+// settings apply to it as well.
+func NewOAuth2RoundTripper(cfg *config.OAuth2, rt http.RoundTripper) http.RoundTripper {
+	src := &clientCredentialsTokenSource{
+		config: cfg,
+		client: NewClient(rt),
+	}
+	return &oauth2.Transport{
+		Source: oauth2.ReuseTokenSource(nil, src),
+		Base:   rt,
+	}
+}
+
+// clientCredentialsTokenSource fetches tokens using the OAuth2 client
+// credentials grant (RFC 6749, section 4.4).
+type clientCredentialsTokenSource struct {
+	config *config.OAuth2
+	client *http.Client
+}
+
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+// Token implements oauth2.TokenSource.
+func (s *clientCredentialsTokenSource) Token() (*oauth2.Token, error) {
+	v := url.Values{}
+	v.Set("grant_type", "client_credentials")
+	if len(s.config.Scopes) > 0 {
+		v.Set("scope", strings.Join(s.config.Scopes, " "))
+	}
+	for k, p := range s.config.EndpointParams {
+		v.Set(k, p)
+	}
+
+	req, err := http.NewRequest("POST", s.config.TokenURL, strings.NewReader(v.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	secret, err := config.ReadSecret(s.config.ClientSecret, s.config.ClientSecretFile)
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(url.QueryEscape(s.config.ClientID), url.QueryEscape(secret))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch oauth2 token: %s", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read oauth2 token response: %s", err)
+	}
+	if resp.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("oauth2 token endpoint returned HTTP status %s: %s", resp.Status, body)
+	}
+
+	var tr tokenResponse
+	if err := json.Unmarshal(body, &tr); err != nil {
+		return nil, fmt.Errorf("unable to parse oauth2 token response: %s", err)
+	}
+	if tr.AccessToken == "" {
+		return nil, fmt.Errorf("oauth2 token endpoint returned no access token")
+	}
+
+	token := &oauth2.Token{
+		AccessToken:  tr.AccessToken,
+		TokenType:    tr.TokenType,
+		RefreshToken: tr.RefreshToken,
+	}
+	if tr.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(tr.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
+
 // cloneRequest returns a clone of the provided *http.Request.
 // The clone is a shallow copy of the struct and its Header map.
 func cloneRequest(r *http.Request) *http.Request {
@@ -128,7 +268,14 @@ func cloneRequest(r *http.Request) *http.Request {
 
 // NewTLSConfig creates a new tls.Config from the given config.TLSConfig.
 func NewTLSConfig(cfg config.TLSConfig) (*tls.Config, error) {
-	tlsConfig := &tls.Config{InsecureSkipVerify: cfg.InsecureSkipVerify}
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+		MinVersion:         uint16(cfg.MinVersion),
+		MaxVersion:         uint16(cfg.MaxVersion),
+	}
+	for _, c := range cfg.CipherSuites {
+		tlsConfig.CipherSuites = append(tlsConfig.CipherSuites, uint16(c))
+	}
 
 	// If a CA cert is provided then let's read it in so we can validate the
 	// scrape target's certificate properly.
@@ -14,12 +14,19 @@
 package httputil
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -293,19 +300,269 @@ func TestBasicAuthRoundTripper(t *testing.T) {
 
 	//Normal flow
 	basicAuthRoundTripper := NewBasicAuthRoundTripper(ExpectedUsername,
-		ExpectedPassword, fakeRoundTripper)
+		ExpectedPassword, "", fakeRoundTripper)
 	request, _ := http.NewRequest("GET", "/hitchhiker", nil)
 	request.Header.Set("User-Agent", "Douglas Adams mind")
 	basicAuthRoundTripper.RoundTrip(request)
 
 	//Should honor already Authorization header set
 	basicAuthRoundTripperShouldNotModifyExistingAuthorization := NewBasicAuthRoundTripper(newUsername,
-		newPassword, fakeRoundTripper)
+		newPassword, "", fakeRoundTripper)
 	request, _ = http.NewRequest("GET", "/hitchhiker", nil)
 	request.SetBasicAuth(ExpectedUsername, ExpectedPassword)
 	basicAuthRoundTripperShouldNotModifyExistingAuthorization.RoundTrip(request)
 }
 
+func TestSecretFileRoundTrippers(t *testing.T) {
+	dir, err := ioutil.TempDir("", "secret_file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	secretFile := filepath.Join(dir, "secret")
+	var expected string
+	rotate := func(secret string) {
+		expected = secret
+		if err := ioutil.WriteFile(secretFile, []byte(secret+"\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fakeRoundTripper := testutil.NewRoundTripCheckRequest(func(req *http.Request) {
+		if bearer := req.Header.Get("Authorization"); strings.HasPrefix(bearer, "Bearer ") {
+			if bearer != "Bearer "+expected {
+				t.Errorf("Expected bearer token %q, got %q", expected, bearer)
+			}
+			return
+		}
+		if _, password, _ := req.BasicAuth(); password != expected {
+			t.Errorf("Expected password %q, got %q", expected, password)
+		}
+	}, nil, nil)
+
+	for _, rt := range []http.RoundTripper{
+		NewBearerAuthFileRoundTripper(secretFile, fakeRoundTripper),
+		NewBasicAuthRoundTripper(ExpectedUsername, "", secretFile, fakeRoundTripper),
+	} {
+		// The file must be read on each request to pick up rotated secrets.
+		for _, secret := range []string{"first", "second"} {
+			rotate(secret)
+			request, _ := http.NewRequest("GET", "/hitchhiker", nil)
+			if _, err := rt.RoundTrip(request); err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+		}
+	}
+
+	if err := os.Remove(secretFile); err != nil {
+		t.Fatal(err)
+	}
+	request, _ := http.NewRequest("GET", "/hitchhiker", nil)
+	if _, err := NewBearerAuthFileRoundTripper(secretFile, fakeRoundTripper).RoundTrip(request); err == nil {
+		t.Errorf("Expected error for missing bearer token file")
+	}
+}
+
+func TestOAuth2RoundTripper(t *testing.T) {
+	tokenRequests := 0
+	authServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests++
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("Unable to parse token request: %s", err)
+		}
+		if gt := r.PostForm.Get("grant_type"); gt != "client_credentials" {
+			t.Errorf("Expected grant_type client_credentials, got %q", gt)
+		}
+		if scope := r.PostForm.Get("scope"); scope != "a b" {
+			t.Errorf("Expected scope %q, got %q", "a b", scope)
+		}
+		id, secret, ok := r.BasicAuth()
+		if !ok || id != ExpectedUsername || secret != ExpectedPassword {
+			t.Errorf("Unexpected client credentials %q/%q", id, secret)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":%q,"token_type":"Bearer","expires_in":3600}`, BearerToken)
+	}))
+	defer authServer.Close()
+
+	fakeRoundTripper := testutil.NewRoundTripCheckRequest(func(req *http.Request) {
+		bearer := req.Header.Get("Authorization")
+		if bearer != ExpectedBearer {
+			t.Errorf("The expected Bearer Authorization (%s) differs from the obtained Bearer Authorization (%s)",
+				ExpectedBearer, bearer)
+		}
+	}, &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(""))}, nil)
+
+	// Token requests go through the wrapped round tripper as well, so they
+	// have to be passed on to the real auth server.
+	oauth2RoundTripper := NewOAuth2RoundTripper(&config.OAuth2{
+		ClientID:     ExpectedUsername,
+		ClientSecret: ExpectedPassword,
+		Scopes:       []string{"a", "b"},
+		TokenURL:     authServer.URL,
+	}, &splitRoundTripper{token: http.DefaultTransport, rest: fakeRoundTripper, tokenURL: authServer.URL})
+
+	for i := 0; i < 2; i++ {
+		request, _ := http.NewRequest("GET", "/hitchhiker", nil)
+		if _, err := oauth2RoundTripper.RoundTrip(request); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+	if tokenRequests != 1 {
+		t.Errorf("Expected the token to be fetched once and reused, got %d token requests", tokenRequests)
+	}
+}
+
+// splitRoundTripper sends token requests to a real transport and everything
+// else to a fake one.
+type splitRoundTripper struct {
+	token, rest http.RoundTripper
+	tokenURL    string
+}
+
+func (rt *splitRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if strings.HasPrefix(req.URL.String(), rt.tokenURL) {
+		return rt.token.RoundTrip(req)
+	}
+	return rt.rest.RoundTrip(req)
+}
+
+func TestAzureADManagedIdentityRoundTripper(t *testing.T) {
+	tokenRequests := 0
+	imds := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests++
+		if r.Header.Get("Metadata") != "true" {
+			t.Errorf("Metadata header wasn't set")
+		}
+		q := r.URL.Query()
+		if q.Get("client_id") != ExpectedUsername || q.Get("resource") != "https://monitor.azure.us" {
+			t.Errorf("Unexpected managed identity token request %q", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":%q,"token_type":"Bearer","expires_in":"3599"}`, BearerToken)
+	}))
+	defer imds.Close()
+	defer func(endpoint string) { azureIMDSEndpoint = endpoint }(azureIMDSEndpoint)
+	azureIMDSEndpoint = imds.URL
+
+	fakeRoundTripper := testutil.NewRoundTripCheckRequest(func(req *http.Request) {
+		if bearer := req.Header.Get("Authorization"); bearer != ExpectedBearer {
+			t.Errorf("Expected Authorization %q, got %q", ExpectedBearer, bearer)
+		}
+	}, &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(""))}, nil)
+
+	rt, err := NewAzureADRoundTripper(&config.AzureADConfig{
+		Cloud:           config.AzureGovernment,
+		ManagedIdentity: &config.AzureManagedIdentityConfig{ClientID: ExpectedUsername},
+	}, &splitRoundTripper{token: http.DefaultTransport, rest: fakeRoundTripper, tokenURL: imds.URL})
+	if err != nil {
+		t.Fatalf("Can't create an Azure AD round tripper: %s", err)
+	}
+	for i := 0; i < 2; i++ {
+		request, _ := http.NewRequest("POST", "http://example.com/api/v1/remote_write", nil)
+		if _, err := rt.RoundTrip(request); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+	if tokenRequests != 1 {
+		t.Errorf("Expected the token to be fetched once and reused, got %d token requests", tokenRequests)
+	}
+}
+
+func TestGoogleIAMRoundTripper(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("Unable to parse token request: %s", err)
+		}
+		if gt := r.PostForm.Get("grant_type"); gt != "urn:ietf:params:oauth:grant-type:jwt-bearer" {
+			t.Errorf("Unexpected grant_type %q", gt)
+		}
+		if r.PostForm.Get("assertion") == "" {
+			t.Errorf("Token request without assertion")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":%q,"token_type":"Bearer","expires_in":3600}`, BearerToken)
+	}))
+	defer authServer.Close()
+
+	dir := testutil.NewTemporaryDirectory("google_iam", t)
+	defer dir.Close()
+	credentials, err := json.Marshal(map[string]string{
+		"type":         "service_account",
+		"client_email": "prometheus@example.iam.gserviceaccount.com",
+		"private_key":  string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})),
+		"token_uri":    authServer.URL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	credentialsFile := filepath.Join(dir.Path(), "credentials.json")
+	if err := ioutil.WriteFile(credentialsFile, credentials, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	fakeRoundTripper := testutil.NewRoundTripCheckRequest(func(req *http.Request) {
+		if bearer := req.Header.Get("Authorization"); bearer != ExpectedBearer {
+			t.Errorf("Expected Authorization %q, got %q", ExpectedBearer, bearer)
+		}
+	}, &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(""))}, nil)
+
+	rt, err := NewGoogleIAMRoundTripper(&config.GoogleIAMConfig{CredentialsFile: credentialsFile},
+		&splitRoundTripper{token: http.DefaultTransport, rest: fakeRoundTripper, tokenURL: authServer.URL})
+	if err != nil {
+		t.Fatalf("Can't create a Google IAM round tripper: %s", err)
+	}
+	request, _ := http.NewRequest("POST", "http://example.com/api/v1/remote_write", nil)
+	if _, err := rt.RoundTrip(request); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if _, err := NewGoogleIAMRoundTripper(&config.GoogleIAMConfig{CredentialsFile: "missing/credentials.json"}, fakeRoundTripper); err == nil {
+		t.Error("Expected error for missing credentials file")
+	}
+}
+
+func TestSigV4RoundTripper(t *testing.T) {
+	const body = "sample payload"
+
+	fakeRoundTripper := testutil.NewRoundTripCheckRequest(func(req *http.Request) {
+		auth := req.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=access/") {
+			t.Errorf("Unexpected Authorization header %q", auth)
+		}
+		if !strings.Contains(auth, "/us-east-1/aps/aws4_request") {
+			t.Errorf("Request not signed for the expected region and service: %q", auth)
+		}
+		if req.Header.Get("X-Amz-Date") == "" {
+			t.Errorf("X-Amz-Date header wasn't set")
+		}
+		b, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			t.Fatalf("Unable to read request body: %s", err)
+		}
+		if string(b) != body {
+			t.Errorf("Expected body %q, got %q", body, b)
+		}
+	}, nil, nil)
+
+	sigV4RoundTripper, err := NewSigV4RoundTripper(&config.SigV4Config{
+		Region:    "us-east-1",
+		AccessKey: "access",
+		SecretKey: "secret",
+	}, fakeRoundTripper)
+	if err != nil {
+		t.Fatalf("Can't create a SigV4 round tripper: %s", err)
+	}
+	request, _ := http.NewRequest("POST", "http://example.com/api/v1/remote_write", strings.NewReader(body))
+	sigV4RoundTripper.RoundTrip(request)
+}
+
 func TestTLSConfig(t *testing.T) {
 	configTLSConfig := config.TLSConfig{
 		CAFile:             TLSCAChainPath,
@@ -367,6 +624,46 @@ func TestTLSConfigEmpty(t *testing.T) {
 	}
 }
 
+func TestTLSConfigVersionsAndCipherSuites(t *testing.T) {
+	configTLSConfig := config.TLSConfig{
+		MinVersion:   tls.VersionTLS11,
+		MaxVersion:   tls.VersionTLS12,
+		CipherSuites: []config.TLSCipher{config.TLSCipher(tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256)},
+	}
+
+	tlsConfig, err := NewTLSConfig(configTLSConfig)
+	if err != nil {
+		t.Fatalf("Can't create a new TLS Config from a configuration (%s).", err)
+	}
+	if tlsConfig.MinVersion != tls.VersionTLS11 || tlsConfig.MaxVersion != tls.VersionTLS12 {
+		t.Errorf("Unexpected TLS versions %x to %x", tlsConfig.MinVersion, tlsConfig.MaxVersion)
+	}
+	if !reflect.DeepEqual(tlsConfig.CipherSuites, []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256}) {
+		t.Errorf("Unexpected cipher suites %v", tlsConfig.CipherSuites)
+	}
+}
+
+func TestProxyConnectHeader(t *testing.T) {
+	cfg := config.HTTPClientConfig{
+		ProxyURL: config.URL{URL: &url.URL{Scheme: "http", Host: "proxy.example.com:3128"}},
+		ProxyConnectHeader: map[string][]config.Secret{
+			"Proxy-Authorization": {"Basic c2VjcmV0"},
+		},
+	}
+	client, err := NewClientFromConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rt, ok := client.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("Unexpected round tripper %T", client.Transport)
+	}
+	expected := http.Header{"Proxy-Authorization": {"Basic c2VjcmV0"}}
+	if !reflect.DeepEqual(rt.ProxyConnectHeader, expected) {
+		t.Errorf("Expected proxy connect header %v, got %v", expected, rt.ProxyConnectHeader)
+	}
+}
+
 func TestTLSConfigInvalidCA(t *testing.T) {
 	var invalidTLSConfig = []struct {
 		configTLSConfig config.TLSConfig
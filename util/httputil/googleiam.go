@@ -0,0 +1,81 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httputil
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"golang.org/x/net/context"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+
+	"github.com/prometheus/prometheus/config"
+)
+
+// googleMonitoringWriteScope is the OAuth2 scope required to write to Google
+// Cloud Monitoring.
+const googleMonitoringWriteScope = "https://www.googleapis.com/auth/monitoring.write"
+
+// NewGoogleIAMRoundTripper returns a http.RoundTripper that adds an access
+// token of a Google service account to each request. The service account is
+// read from the credentials file of the config if set, and found through the
+// Application Default Credentials otherwise. Tokens are cached and only
+// refreshed once they have expired.
+func NewGoogleIAMRoundTripper(cfg *config.GoogleIAMConfig, rt http.RoundTripper) (http.RoundTripper, error) {
+	// Token requests go through the passed round tripper as well.
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, NewClient(rt))
+
+	var (
+		src oauth2.TokenSource
+		err error
+	)
+	if cfg.CredentialsFile != "" {
+		src, err = googleCredentialsFileTokenSource(ctx, cfg.CredentialsFile)
+	} else {
+		src, err = google.DefaultTokenSource(ctx, googleMonitoringWriteScope)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &oauth2.Transport{
+		Source: oauth2.ReuseTokenSource(nil, src),
+		Base:   rt,
+	}, nil
+}
+
+// googleCredentialsFileTokenSource returns a token source for the service
+// account of the JSON key file.
+func googleCredentialsFileTokenSource(ctx context.Context, filename string) (oauth2.TokenSource, error) {
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read google_iam credentials file %s: %s", filename, err)
+	}
+	jwtCfg, err := google.JWTConfigFromJSON(b, googleMonitoringWriteScope)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse google_iam credentials file %s: %s", filename, err)
+	}
+	// Service account keys name the endpoint their tokens are requested
+	// from, which JWTConfigFromJSON does not pick up.
+	var key struct {
+		TokenURI string `json:"token_uri"`
+	}
+	if err := json.Unmarshal(b, &key); err == nil && key.TokenURI != "" {
+		jwtCfg.TokenURL = key.TokenURI
+	}
+	return jwtCfg.TokenSource(ctx), nil
+}
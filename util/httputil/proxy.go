@@ -0,0 +1,96 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httputil
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// NewProxyFunc returns a proxy function for http.Transport sending requests
+// through the proxy, except for the hosts matching the comma-separated
+// no_proxy list. The list holds host names, which also match their
+// subdomains, IP addresses and CIDR ranges, each optionally with a port.
+// A single "*" disables the proxy for all hosts.
+func NewProxyFunc(proxyURL *url.URL, noProxy string) func(*http.Request) (*url.URL, error) {
+	if proxyURL == nil {
+		return nil
+	}
+	var rules []noProxyRule
+	for _, s := range strings.Split(noProxy, ",") {
+		if s = strings.ToLower(strings.TrimSpace(s)); s != "" {
+			rules = append(rules, parseNoProxyRule(s))
+		}
+	}
+
+	return func(req *http.Request) (*url.URL, error) {
+		host, port := req.URL.Hostname(), req.URL.Port()
+		if port == "" {
+			port = map[string]string{"http": "80", "https": "443"}[req.URL.Scheme]
+		}
+		host = strings.ToLower(host)
+		for _, r := range rules {
+			if r.matches(host, port) {
+				return nil, nil
+			}
+		}
+		return proxyURL, nil
+	}
+}
+
+type noProxyRule struct {
+	all    bool
+	domain string
+	ip     net.IP
+	ipNet  *net.IPNet
+	port   string
+}
+
+func parseNoProxyRule(s string) noProxyRule {
+	if s == "*" {
+		return noProxyRule{all: true}
+	}
+	if _, ipNet, err := net.ParseCIDR(s); err == nil {
+		return noProxyRule{ipNet: ipNet}
+	}
+	var r noProxyRule
+	if host, port, err := net.SplitHostPort(s); err == nil {
+		s, r.port = host, port
+	}
+	if ip := net.ParseIP(s); ip != nil {
+		r.ip = ip
+		return r
+	}
+	r.domain = strings.TrimPrefix(strings.TrimPrefix(s, "*"), ".")
+	return r
+}
+
+func (r noProxyRule) matches(host, port string) bool {
+	if r.all {
+		return true
+	}
+	if r.port != "" && r.port != port {
+		return false
+	}
+	ip := net.ParseIP(host)
+	switch {
+	case r.ipNet != nil:
+		return ip != nil && r.ipNet.Contains(ip)
+	case r.ip != nil:
+		return ip != nil && r.ip.Equal(ip)
+	}
+	return host == r.domain || strings.HasSuffix(host, "."+r.domain)
+}
@@ -0,0 +1,69 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httputil
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestProxyFunc(t *testing.T) {
+	proxyURL := &url.URL{Scheme: "http", Host: "proxy.example.com:3128"}
+	proxy := NewProxyFunc(proxyURL, "localhost, .svc.cluster.local,example.org:8080,10.0.0.0/8,192.168.1.1,[::1]:9090")
+
+	for _, c := range []struct {
+		url     string
+		proxied bool
+	}{
+		{"http://localhost:9090/metrics", false},
+		{"http://LOCALHOST/metrics", false},
+		{"http://node.example.com/metrics", true},
+		{"http://api.monitoring.svc.cluster.local/metrics", false},
+		{"http://svc.cluster.local/metrics", false},
+		{"http://cluster.local/metrics", true},
+		{"http://example.org:8080/metrics", false},
+		{"http://www.example.org:8080/metrics", false},
+		{"http://example.org/metrics", true},
+		{"http://10.1.2.3:9100/metrics", false},
+		{"http://11.1.2.3:9100/metrics", true},
+		{"https://192.168.1.1/metrics", false},
+		{"http://192.168.1.2/metrics", true},
+		{"http://[::1]:9090/metrics", false},
+		{"http://[::1]:9100/metrics", true},
+	} {
+		req, err := http.NewRequest("GET", c.url, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		u, err := proxy(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if proxied := u != nil; proxied != c.proxied {
+			t.Errorf("%s: expected proxied to be %t, got %t", c.url, c.proxied, proxied)
+		}
+	}
+}
+
+func TestProxyFuncAll(t *testing.T) {
+	proxy := NewProxyFunc(&url.URL{Scheme: "http", Host: "proxy.example.com:3128"}, "*")
+	req, _ := http.NewRequest("GET", "http://node.example.com/metrics", nil)
+	if u, _ := proxy(req); u != nil {
+		t.Errorf("Expected no proxy, got %s", u)
+	}
+	if NewProxyFunc(nil, "") != nil {
+		t.Errorf("Expected no proxy function without a proxy URL")
+	}
+}
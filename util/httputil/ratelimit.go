@@ -0,0 +1,81 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httputil
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimit tracks until when an API must not be requested, from the rate
+// limiting headers of its responses. An API is held off once the remaining
+// number of requests is zero, until the reset time given as Unix timestamp,
+// and after a request is rejected with 429, for the Retry-After duration or
+// until the reset time.
+type RateLimit struct {
+	remainingHeader, resetHeader string
+
+	mtx   sync.Mutex
+	until time.Time
+}
+
+// NewRateLimit returns a RateLimit for an API reporting the remaining number
+// of requests and the reset time in the given headers.
+func NewRateLimit(remainingHeader, resetHeader string) *RateLimit {
+	return &RateLimit{
+		remainingHeader: remainingHeader,
+		resetHeader:     resetHeader,
+	}
+}
+
+// Limited returns whether the API must not be requested at the given time,
+// and until when.
+func (l *RateLimit) Limited(now time.Time) (time.Time, bool) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	return l.until, now.Before(l.until)
+}
+
+// Update records the rate limiting headers of the response received at the
+// given time.
+func (l *RateLimit) Update(resp *http.Response, now time.Time) {
+	var until time.Time
+	reset, err := strconv.ParseInt(resp.Header.Get(l.resetHeader), 10, 64)
+	hasReset := err == nil
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+			until = now.Add(time.Duration(secs) * time.Second)
+		} else if hasReset {
+			until = time.Unix(reset, 0)
+		} else {
+			// Without an indication of the reset, the next request is up
+			// to the caller.
+			return
+		}
+	case resp.Header.Get(l.remainingHeader) == "0" && hasReset:
+		until = time.Unix(reset, 0)
+	default:
+		return
+	}
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	if until.After(l.until) {
+		l.until = until
+	}
+}
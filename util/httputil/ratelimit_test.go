@@ -0,0 +1,78 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httputil
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRateLimit(t *testing.T) {
+	now := time.Unix(10, 0)
+
+	for _, tc := range []struct {
+		status     int
+		remaining  string
+		reset      string
+		retryAfter string
+		until      time.Time
+	}{
+		{status: http.StatusOK},
+		{status: http.StatusOK, remaining: "5", reset: "30"},
+		{status: http.StatusOK, remaining: "0", reset: "30", until: time.Unix(30, 0)},
+		{status: http.StatusOK, remaining: "0", reset: "invalid"},
+		{status: http.StatusTooManyRequests, retryAfter: "20", reset: "60", until: time.Unix(30, 0)},
+		{status: http.StatusTooManyRequests, reset: "60", until: time.Unix(60, 0)},
+		{status: http.StatusTooManyRequests},
+	} {
+		resp := &http.Response{StatusCode: tc.status, Header: http.Header{}}
+		for h, v := range map[string]string{
+			"X-Remaining": tc.remaining,
+			"X-Reset":     tc.reset,
+			"Retry-After": tc.retryAfter,
+		} {
+			if v != "" {
+				resp.Header.Set(h, v)
+			}
+		}
+
+		l := NewRateLimit("X-Remaining", "X-Reset")
+		l.Update(resp, now)
+		until, limited := l.Limited(now)
+		if !until.Equal(tc.until) || limited != now.Before(tc.until) {
+			t.Errorf("Expected status %d with headers %v to limit until %s, got %s (%t)",
+				tc.status, resp.Header, tc.until, until, limited)
+		}
+	}
+}
+
+func TestRateLimitKeepsLatestReset(t *testing.T) {
+	l := NewRateLimit("X-Remaining", "X-Reset")
+	now := time.Unix(10, 0)
+
+	resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+	resp.Header.Set("X-Remaining", "0")
+	resp.Header.Set("X-Reset", "60")
+	l.Update(resp, now)
+
+	resp.Header.Set("X-Reset", "30")
+	l.Update(resp, now)
+	if until, _ := l.Limited(now); !until.Equal(time.Unix(60, 0)) {
+		t.Errorf("Expected the later reset to be kept, got %s", until)
+	}
+	if _, limited := l.Limited(time.Unix(60, 0)); limited {
+		t.Error("Expected no limit once the reset time is reached")
+	}
+}
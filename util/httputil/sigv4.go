@@ -0,0 +1,133 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httputil
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/aws/signer/v4"
+
+	"github.com/prometheus/prometheus/config"
+)
+
+// sigV4Service is the AWS service name requests are signed for. It is the
+// one used by Amazon Managed Service for Prometheus.
+const sigV4Service = "aps"
+
+type sigV4RoundTripper struct {
+	region string
+	signer *v4.Signer
+	rt     http.RoundTripper
+}
+
+// NewSigV4RoundTripper returns a new http.RoundTripper that signs requests
+// using AWS's Signature Version 4 signing process. Credentials are taken from
+// the config if set, and from the default AWS credential chain otherwise.
+func NewSigV4RoundTripper(cfg *config.SigV4Config, rt http.RoundTripper) (http.RoundTripper, error) {
+	awsCfg := aws.Config{}
+	if cfg.Region != "" {
+		awsCfg.Region = aws.String(cfg.Region)
+	}
+	if cfg.AccessKey != "" && cfg.SecretKeyFile != "" {
+		awsCfg.Credentials = NewSecretFileCredentials(cfg.AccessKey, cfg.SecretKeyFile)
+	} else if cfg.AccessKey != "" {
+		awsCfg.Credentials = credentials.NewStaticCredentials(cfg.AccessKey, string(cfg.SecretKey), "")
+	}
+
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:  awsCfg,
+		Profile: cfg.Profile,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not create aws session: %s", err)
+	}
+	if sess.Config.Region == nil || *sess.Config.Region == "" {
+		return nil, fmt.Errorf("region not configured in sigv4 or in default credentials chain")
+	}
+
+	creds := sess.Config.Credentials
+	if cfg.RoleARN != "" {
+		creds = stscreds.NewCredentials(sess, cfg.RoleARN)
+	}
+
+	return &sigV4RoundTripper{
+		region: *sess.Config.Region,
+		signer: v4.NewSigner(creds),
+		rt:     rt,
+	}, nil
+}
+
+func (rt *sigV4RoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	// The signature covers the request body, so it has to be read upfront and
+	// provided as an io.ReadSeeker.
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+	}
+
+	// Sign attaches the body to the signed request.
+	req = cloneRequest(req)
+	if _, err := rt.signer.Sign(req, bytes.NewReader(body), sigV4Service, rt.region, time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to sign request: %s", err)
+	}
+	return rt.rt.RoundTrip(req)
+}
+
+// NewSecretFileCredentials returns AWS credentials with a static access key
+// and a secret key read from a secret file each time credentials are needed,
+// so that the secret key can be rotated.
+func NewSecretFileCredentials(accessKey, secretKeyFile string) *credentials.Credentials {
+	return credentials.NewCredentials(&secretFileProvider{
+		accessKey:     accessKey,
+		secretKeyFile: secretKeyFile,
+	})
+}
+
+// secretFileProvider implements credentials.Provider.
+type secretFileProvider struct {
+	accessKey     string
+	secretKeyFile string
+}
+
+// Retrieve implements credentials.Provider.
+func (p *secretFileProvider) Retrieve() (credentials.Value, error) {
+	secretKey, err := config.ReadSecretFile(p.secretKeyFile)
+	if err != nil {
+		return credentials.Value{}, fmt.Errorf("unable to read secret key file %s: %s", p.secretKeyFile, err)
+	}
+	return credentials.Value{
+		AccessKeyID:     p.accessKey,
+		SecretAccessKey: secretKey,
+		ProviderName:    "SecretFileProvider",
+	}, nil
+}
+
+// IsExpired implements credentials.Provider. The credentials always expire, so
+// that the secret file is read again on each use.
+func (p *secretFileProvider) IsExpired() bool {
+	return true
+}
@@ -0,0 +1,74 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build windows
+
+package logging
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Sirupsen/logrus"
+	"golang.org/x/sys/windows/svc/eventlog"
+)
+
+func init() {
+	newEventlogFormatter = func(name string, debugAsInfo bool, fmter logrus.Formatter) (logrus.Formatter, error) {
+		if name == "" {
+			return nil, fmt.Errorf("missing name parameter")
+		}
+		l, err := eventlog.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		return &eventlogFormatter{wrap: fmter, log: l, debugAsInfo: debugAsInfo}, nil
+	}
+}
+
+// eventlogFormatter sends the messages of the components to the Windows
+// event log, like the eventlog formatter of the log package does for the
+// base logger.
+type eventlogFormatter struct {
+	wrap        logrus.Formatter
+	log         *eventlog.Log
+	debugAsInfo bool
+}
+
+// Format implements logrus.Formatter.
+func (s *eventlogFormatter) Format(e *logrus.Entry) ([]byte, error) {
+	data, err := s.wrap.Format(e)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "eventlog formatter: can't format entry: %v\n", err)
+		return data, err
+	}
+
+	switch e.Level {
+	case logrus.PanicLevel, logrus.FatalLevel, logrus.ErrorLevel:
+		err = s.log.Error(102, e.Message)
+	case logrus.WarnLevel:
+		err = s.log.Warning(101, e.Message)
+	case logrus.InfoLevel:
+		err = s.log.Info(100, e.Message)
+	case logrus.DebugLevel:
+		if s.debugAsInfo {
+			err = s.log.Info(100, e.Message)
+		}
+	default:
+		err = s.log.Info(100, e.Message)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "eventlog formatter: can't send log to eventlog: %v\n", err)
+	}
+	return data, err
+}
@@ -0,0 +1,445 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package logging provides loggers whose level can be set per component of
+// Prometheus.
+package logging
+
+import (
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/prometheus/common/log"
+)
+
+// Components are the parts of Prometheus whose log level can be set
+// individually.
+var Components = []string{
+	"discovery",
+	"notifier",
+	"query",
+	"remote",
+	"rules",
+	"runtime",
+	"scrape",
+	"storage",
+	"tracing",
+	"web",
+}
+
+// Levels are the log levels of the components of Prometheus.
+type Levels struct {
+	// Default is the level of the components without a level of their own.
+	Default    logrus.Level
+	Components map[string]logrus.Level
+}
+
+// ParseLevels parses a comma separated list of log levels. Each element is
+// either a level, which becomes the default level, or a component=level pair,
+// e.g. "info,scrape=debug,storage=warn".
+func ParseLevels(s string) (Levels, error) {
+	lvls := Levels{
+		Default:    logrus.InfoLevel,
+		Components: map[string]logrus.Level{},
+	}
+	for _, e := range strings.Split(s, ",") {
+		e = strings.TrimSpace(e)
+		if e == "" {
+			continue
+		}
+		if i := strings.Index(e, "="); i >= 0 {
+			comp, lvl := e[:i], e[i+1:]
+			if !isComponent(comp) {
+				return Levels{}, fmt.Errorf("unknown log component %q, valid components: [%s]", comp, strings.Join(Components, ", "))
+			}
+			l, err := parseLevel(lvl)
+			if err != nil {
+				return Levels{}, err
+			}
+			lvls.Components[comp] = l
+			continue
+		}
+		l, err := parseLevel(e)
+		if err != nil {
+			return Levels{}, err
+		}
+		lvls.Default = l
+	}
+	return lvls, nil
+}
+
+func parseLevel(s string) (logrus.Level, error) {
+	switch s {
+	case "debug", "info", "warn", "error", "fatal":
+		return logrus.ParseLevel(s)
+	}
+	return 0, fmt.Errorf("invalid log level %q, valid levels: [debug, info, warn, error, fatal]", s)
+}
+
+// levelString returns the name of the level as accepted by ParseLevels.
+func levelString(l logrus.Level) string {
+	if l == logrus.WarnLevel {
+		return "warn"
+	}
+	return l.String()
+}
+
+func isComponent(s string) bool {
+	for _, c := range Components {
+		if c == s {
+			return true
+		}
+	}
+	return false
+}
+
+// level returns the level of the given component.
+func (l Levels) level(component string) logrus.Level {
+	if lvl, ok := l.Components[component]; ok {
+		return lvl
+	}
+	return l.Default
+}
+
+// max returns the most verbose of all levels.
+func (l Levels) max() logrus.Level {
+	max := l.Default
+	for _, lvl := range l.Components {
+		if lvl > max {
+			max = lvl
+		}
+	}
+	return max
+}
+
+func (l Levels) String() string {
+	elems := make([]string, 0, len(l.Components)+1)
+	elems = append(elems, levelString(l.Default))
+	for c, lvl := range l.Components {
+		elems = append(elems, c+"="+levelString(lvl))
+	}
+	sort.Strings(elems[1:])
+	return strings.Join(elems, ",")
+}
+
+var (
+	mtx     sync.RWMutex
+	current = Levels{Default: logrus.InfoLevel}
+	// The levels of the components, shared by all loggers of a component
+	// and read whenever they log a message, so that they can be changed
+	// while the loggers are in use.
+	levels = map[string]*uint32{}
+
+	// out receives the messages of the components. The level of each
+	// message is checked by its logger, so out itself logs all levels.
+	out = &logrus.Logger{
+		Out:       os.Stderr,
+		Formatter: new(logrus.TextFormatter),
+		Hooks:     make(logrus.LevelHooks),
+		Level:     logrus.DebugLevel,
+	}
+)
+
+// setLevels sets the levels of all components.
+func setLevels(lvls Levels) {
+	mtx.Lock()
+	defer mtx.Unlock()
+	current = lvls
+	for c, lvl := range levels {
+		atomic.StoreUint32(lvl, uint32(current.level(c)))
+	}
+}
+
+// componentLevel returns the level shared by the loggers of the given
+// component.
+func componentLevel(component string) *uint32 {
+	mtx.Lock()
+	defer mtx.Unlock()
+	lvl, ok := levels[component]
+	if !ok {
+		lvl = new(uint32)
+		*lvl = uint32(current.level(component))
+		levels[component] = lvl
+	}
+	return lvl
+}
+
+// levelsFlag implements flag.Value for the component log levels. The default
+// level also applies to the messages logged through the shared base logger,
+// but only the first time it is set: unlike the levels of the components, the
+// level of the base logger cannot be changed safely while it is in use.
+type levelsFlag struct {
+	base     *flag.FlagSet
+	baseOnce *sync.Once
+}
+
+// String implements flag.Value.
+func (f levelsFlag) String() string {
+	mtx.RLock()
+	defer mtx.RUnlock()
+	return current.String()
+}
+
+// Set implements flag.Value.
+func (f levelsFlag) Set(s string) error {
+	lvls, err := ParseLevels(s)
+	if err != nil {
+		return err
+	}
+	f.baseOnce.Do(func() {
+		err = f.base.Set("log.level", lvls.Default.String())
+	})
+	if err != nil {
+		return err
+	}
+	setLevels(lvls)
+	return nil
+}
+
+// formatFlag implements flag.Value for the log.format flag of the base
+// logger, applying the format to the messages of the components as well.
+type formatFlag struct {
+	flag.Value
+}
+
+// Set implements flag.Value.
+func (f formatFlag) Set(s string) error {
+	if err := f.Value.Set(s); err != nil {
+		return err
+	}
+	// The format has been validated by the base logger.
+	u, err := url.Parse(s)
+	if err != nil {
+		return err
+	}
+	if u.Query().Get("json") == "true" {
+		out.Formatter = &logrus.JSONFormatter{}
+	}
+	switch u.Opaque {
+	case "syslog":
+		if newSyslogFormatter == nil {
+			return fmt.Errorf("system does not support syslog")
+		}
+		fmter, err := newSyslogFormatter(u.Query().Get("appname"), u.Query().Get("local"), out.Formatter)
+		if err != nil {
+			return err
+		}
+		out.Formatter = fmter
+	case "eventlog":
+		if newEventlogFormatter == nil {
+			return fmt.Errorf("system does not support eventlog")
+		}
+		debugAsInfo, _ := strconv.ParseBool(u.Query().Get("debugAsInfo"))
+		fmter, err := newEventlogFormatter(u.Query().Get("name"), debugAsInfo, out.Formatter)
+		if err != nil {
+			return err
+		}
+		out.Formatter = fmter
+	case "stdout":
+		out.Out = os.Stdout
+	case "stderr":
+		out.Out = os.Stderr
+	}
+	return nil
+}
+
+// newSyslogFormatter is nil if the target architecture does not support
+// syslog.
+var newSyslogFormatter func(appname, local string, fmter logrus.Formatter) (logrus.Formatter, error)
+
+// newEventlogFormatter is nil if the target OS does not support Eventlog.
+var newEventlogFormatter func(name string, debugAsInfo bool, fmter logrus.Formatter) (logrus.Formatter, error)
+
+// AddFlags adds the flags of the log package to the given flag set,
+// replacing its log.level flag with one accepting per-component levels.
+func AddFlags(fs *flag.FlagSet) {
+	base := flag.NewFlagSet("log", flag.ContinueOnError)
+	log.AddFlags(base)
+
+	fs.Var(
+		levelsFlag{base: base, baseOnce: new(sync.Once)}, "log.level",
+		fmt.Sprintf(
+			"Only log messages with the given severity or above. Valid levels: [debug, info, warn, error, fatal]. "+
+				"The level can be set per component with comma separated component=level pairs, e.g. \"info,scrape=debug\". Valid components: [%s]",
+			strings.Join(Components, ", "),
+		),
+	)
+	format := base.Lookup("log.format")
+	fs.Var(formatFlag{format.Value}, format.Name, format.Usage+`. JSON output contains the component and target of each message`)
+}
+
+// New returns a logger for the given component. Its messages carry the
+// component name and are filtered by the level set for the component.
+func New(component string) log.Logger {
+	return newLogger(logrus.NewEntry(out), component)
+}
+
+func newLogger(entry *logrus.Entry, component string) *logger {
+	return &logger{
+		entry:     entry.WithField("component", component),
+		component: component,
+		level:     componentLevel(component),
+	}
+}
+
+// logger drops the messages of a component below its level, which is read
+// for every message.
+type logger struct {
+	entry     *logrus.Entry
+	component string
+	level     *uint32
+}
+
+// enabled returns whether messages of the given level are logged.
+func (l *logger) enabled(lvl logrus.Level) bool {
+	return logrus.Level(atomic.LoadUint32(l.level)) >= lvl
+}
+
+// sourced adds a source field to the logger that contains the file name and
+// line where the logging happened, like the loggers of the log package.
+func (l *logger) sourced() *logrus.Entry {
+	_, file, line, ok := runtime.Caller(2)
+	if !ok {
+		file = "<???>"
+		line = 1
+	} else {
+		slash := strings.LastIndex(file, "/")
+		file = file[slash+1:]
+	}
+	return l.entry.WithField("source", fmt.Sprintf("%s:%d", file, line))
+}
+
+// With implements log.Logger. Setting the component field changes the
+// component whose level applies.
+func (l *logger) With(key string, value interface{}) log.Logger {
+	nl := &logger{
+		entry:     l.entry.WithField(key, value),
+		component: l.component,
+		level:     l.level,
+	}
+	if c, ok := value.(string); ok && key == "component" && c != l.component {
+		nl.component = c
+		nl.level = componentLevel(c)
+	}
+	return nl
+}
+
+// Debug implements log.Logger.
+func (l *logger) Debug(args ...interface{}) {
+	if l.enabled(logrus.DebugLevel) {
+		l.sourced().Debug(args...)
+	}
+}
+
+// Debugln implements log.Logger.
+func (l *logger) Debugln(args ...interface{}) {
+	if l.enabled(logrus.DebugLevel) {
+		l.sourced().Debugln(args...)
+	}
+}
+
+// Debugf implements log.Logger.
+func (l *logger) Debugf(format string, args ...interface{}) {
+	if l.enabled(logrus.DebugLevel) {
+		l.sourced().Debugf(format, args...)
+	}
+}
+
+// Info implements log.Logger.
+func (l *logger) Info(args ...interface{}) {
+	if l.enabled(logrus.InfoLevel) {
+		l.sourced().Info(args...)
+	}
+}
+
+// Infoln implements log.Logger.
+func (l *logger) Infoln(args ...interface{}) {
+	if l.enabled(logrus.InfoLevel) {
+		l.sourced().Infoln(args...)
+	}
+}
+
+// Infof implements log.Logger.
+func (l *logger) Infof(format string, args ...interface{}) {
+	if l.enabled(logrus.InfoLevel) {
+		l.sourced().Infof(format, args...)
+	}
+}
+
+// Warn implements log.Logger.
+func (l *logger) Warn(args ...interface{}) {
+	if l.enabled(logrus.WarnLevel) {
+		l.sourced().Warn(args...)
+	}
+}
+
+// Warnln implements log.Logger.
+func (l *logger) Warnln(args ...interface{}) {
+	if l.enabled(logrus.WarnLevel) {
+		l.sourced().Warnln(args...)
+	}
+}
+
+// Warnf implements log.Logger.
+func (l *logger) Warnf(format string, args ...interface{}) {
+	if l.enabled(logrus.WarnLevel) {
+		l.sourced().Warnf(format, args...)
+	}
+}
+
+// Error implements log.Logger.
+func (l *logger) Error(args ...interface{}) {
+	if l.enabled(logrus.ErrorLevel) {
+		l.sourced().Error(args...)
+	}
+}
+
+// Errorln implements log.Logger.
+func (l *logger) Errorln(args ...interface{}) {
+	if l.enabled(logrus.ErrorLevel) {
+		l.sourced().Errorln(args...)
+	}
+}
+
+// Errorf implements log.Logger.
+func (l *logger) Errorf(format string, args ...interface{}) {
+	if l.enabled(logrus.ErrorLevel) {
+		l.sourced().Errorf(format, args...)
+	}
+}
+
+// Fatal messages are never dropped, as they terminate the process.
+
+// Fatal implements log.Logger.
+func (l *logger) Fatal(args ...interface{}) {
+	l.sourced().Fatal(args...)
+}
+
+// Fatalln implements log.Logger.
+func (l *logger) Fatalln(args ...interface{}) {
+	l.sourced().Fatalln(args...)
+}
+
+// Fatalf implements log.Logger.
+func (l *logger) Fatalf(format string, args ...interface{}) {
+	l.sourced().Fatalf(format, args...)
+}
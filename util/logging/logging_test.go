@@ -0,0 +1,201 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"bytes"
+	"flag"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Sirupsen/logrus"
+)
+
+func TestParseLevels(t *testing.T) {
+	var scenarios = []struct {
+		input    string
+		expected Levels
+		err      string
+	}{
+		{
+			input:    "",
+			expected: Levels{Default: logrus.InfoLevel, Components: map[string]logrus.Level{}},
+		}, {
+			input:    "debug",
+			expected: Levels{Default: logrus.DebugLevel, Components: map[string]logrus.Level{}},
+		}, {
+			input: "warn, scrape=debug,storage=error",
+			expected: Levels{
+				Default: logrus.WarnLevel,
+				Components: map[string]logrus.Level{
+					"scrape":  logrus.DebugLevel,
+					"storage": logrus.ErrorLevel,
+				},
+			},
+		}, {
+			input: "rules=debug",
+			expected: Levels{
+				Default:    logrus.InfoLevel,
+				Components: map[string]logrus.Level{"rules": logrus.DebugLevel},
+			},
+		}, {
+			input: "verbose",
+			err:   `invalid log level "verbose"`,
+		}, {
+			input: "scrape=panic",
+			err:   `invalid log level "panic"`,
+		}, {
+			input: "tsdb=debug",
+			err:   `unknown log component "tsdb"`,
+		},
+	}
+
+	for _, s := range scenarios {
+		lvls, err := ParseLevels(s.input)
+		if s.err != "" {
+			if err == nil || !strings.Contains(err.Error(), s.err) {
+				t.Errorf("%q: expected error containing %q, got %v", s.input, s.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %s", s.input, err)
+			continue
+		}
+		if !reflect.DeepEqual(lvls, s.expected) {
+			t.Errorf("%q: expected %+v, got %+v", s.input, s.expected, lvls)
+		}
+	}
+}
+
+func TestLevelsFlag(t *testing.T) {
+	defer setLevels(current)
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	AddFlags(fs)
+	if fs.Lookup("log.format") == nil {
+		t.Fatalf("log.format flag not added")
+	}
+
+	// Loggers created before the flags are parsed are updated, and so are
+	// the loggers derived from them.
+	l := New("storage").(*logger)
+	d := l.With("target", "http://example.com/metrics").(*logger)
+	if l.enabled(logrus.DebugLevel) || d.enabled(logrus.DebugLevel) {
+		t.Errorf("expected debug messages to be dropped at the default level")
+	}
+
+	if err := fs.Set("log.level", "warn,storage=error,scrape=debug"); err != nil {
+		t.Fatal(err)
+	}
+	for _, l := range []*logger{l, d} {
+		if l.enabled(logrus.WarnLevel) {
+			t.Errorf("expected warnings to be dropped at level error")
+		}
+		if !l.enabled(logrus.ErrorLevel) {
+			t.Errorf("expected errors to be logged at level error")
+		}
+	}
+	if s := d.With("component", "scrape").(*logger); !s.enabled(logrus.DebugLevel) {
+		t.Errorf("expected debug messages of the scrape component to be logged")
+	}
+	if got, expected := fs.Lookup("log.level").Value.String(), "warn,scrape=debug,storage=error"; got != expected {
+		t.Errorf("expected flag value %q, got %q", expected, got)
+	}
+	if err := fs.Set("log.level", "scrape=trace"); err == nil {
+		t.Errorf("expected error for invalid level")
+	}
+}
+
+func TestLevelsFlagConcurrent(t *testing.T) {
+	defer setLevels(current)
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	AddFlags(fs)
+
+	var buf bytes.Buffer
+	base := logrus.New()
+	base.Out = &buf
+	base.Level = logrus.DebugLevel
+
+	l := newLogger(logrus.NewEntry(base), "scrape")
+	d := l.With("target", "http://example.com/metrics")
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			l.Debug("scrape debug")
+			d.Infof("scrape info %d", i)
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		lvl := "info,scrape=debug"
+		if i%2 == 0 {
+			lvl = "info,scrape=warn"
+		}
+		if err := fs.Set("log.level", lvl); err != nil {
+			t.Fatal(err)
+		}
+	}
+	<-done
+
+	if err := fs.Set("log.level", "info,scrape=warn"); err != nil {
+		t.Fatal(err)
+	}
+	buf.Reset()
+	d.Info("dropped info")
+	if buf.Len() != 0 {
+		t.Errorf("expected info messages of a derived logger to be dropped, got:\n%s", buf.String())
+	}
+}
+
+func TestLogger(t *testing.T) {
+	defer setLevels(current)
+
+	lvls, err := ParseLevels("warn,scrape=info")
+	if err != nil {
+		t.Fatal(err)
+	}
+	setLevels(lvls)
+
+	var buf bytes.Buffer
+	base := logrus.New()
+	base.Out = &buf
+	entry := logrus.NewEntry(base)
+
+	newLogger(entry, "storage").Info("storage info")
+	newLogger(entry, "storage").Warn("storage warning")
+	newLogger(entry, "scrape").With("target", "http://example.com/metrics").Info("scrape info")
+	newLogger(entry, "scrape").With("component", "discovery").Info("discovery info")
+
+	out := buf.String()
+	for _, s := range []string{
+		"storage warning",
+		"source=\"logging_test.go:",
+		"scrape info",
+		"component=scrape",
+		"target=\"http://example.com/metrics\"",
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("expected output to contain %q, got:\n%s", s, out)
+		}
+	}
+	for _, s := range []string{"storage info", "discovery info"} {
+		if strings.Contains(out, s) {
+			t.Errorf("expected output not to contain %q, got:\n%s", s, out)
+		}
+	}
+}
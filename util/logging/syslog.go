@@ -0,0 +1,102 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !windows,!nacl,!plan9
+
+package logging
+
+import (
+	"fmt"
+	"log/syslog"
+	"os"
+
+	"github.com/Sirupsen/logrus"
+)
+
+func init() {
+	newSyslogFormatter = func(appname, local string, fmter logrus.Formatter) (logrus.Formatter, error) {
+		priority, err := syslogFacility(local)
+		if err != nil {
+			return nil, err
+		}
+		w, err := syslog.New(priority, appname)
+		if err != nil {
+			return nil, err
+		}
+		var tag []byte
+		if _, ok := fmter.(*logrus.JSONFormatter); ok {
+			// Add the cee tag to JSON formatted messages.
+			tag = []byte("@cee:")
+		}
+		return &syslogFormatter{wrap: fmter, out: w, tag: tag}, nil
+	}
+}
+
+func syslogFacility(local string) (syslog.Priority, error) {
+	switch local {
+	case "0":
+		return syslog.LOG_LOCAL0, nil
+	case "1":
+		return syslog.LOG_LOCAL1, nil
+	case "2":
+		return syslog.LOG_LOCAL2, nil
+	case "3":
+		return syslog.LOG_LOCAL3, nil
+	case "4":
+		return syslog.LOG_LOCAL4, nil
+	case "5":
+		return syslog.LOG_LOCAL5, nil
+	case "6":
+		return syslog.LOG_LOCAL6, nil
+	case "7":
+		return syslog.LOG_LOCAL7, nil
+	}
+	return syslog.LOG_LOCAL0, fmt.Errorf("invalid local(%s) for syslog", local)
+}
+
+// syslogFormatter sends the messages of the components to syslog, like the
+// syslog formatter of the log package does for the base logger.
+type syslogFormatter struct {
+	wrap logrus.Formatter
+	out  *syslog.Writer
+	tag  []byte
+}
+
+// Format implements logrus.Formatter.
+func (s *syslogFormatter) Format(e *logrus.Entry) ([]byte, error) {
+	data, err := s.wrap.Format(e)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "syslog formatter: can't format entry: %v\n", err)
+		return data, err
+	}
+	line := string(append(s.tag, data...))
+
+	switch e.Level {
+	case logrus.PanicLevel, logrus.FatalLevel:
+		err = s.out.Crit(line)
+	case logrus.ErrorLevel:
+		err = s.out.Err(line)
+	case logrus.WarnLevel:
+		err = s.out.Warning(line)
+	case logrus.InfoLevel:
+		err = s.out.Info(line)
+	case logrus.DebugLevel:
+		err = s.out.Debug(line)
+	default:
+		err = s.out.Notice(line)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "syslog formatter: can't send log to syslog: %v\n", err)
+	}
+	return data, err
+}
@@ -69,6 +69,9 @@ func (l *Linter) Lint() ([]Problem, error) {
 
 	var problems []Problem
 
+	// The first metric using each help text, to detect copy-pasted help.
+	helps := map[string]string{}
+
 	var mf dto.MetricFamily
 	for {
 		if err := d.Decode(&mf); err != nil {
@@ -80,12 +83,13 @@ func (l *Linter) Lint() ([]Problem, error) {
 		}
 
 		problems = append(problems, lint(mf)...)
+		problems = append(problems, lintDuplicateHelp(mf, helps)...)
 	}
 
 	// Ensure deterministic output.
 	sort.SliceStable(problems, func(i, j int) bool {
-		if problems[i].Metric < problems[j].Metric {
-			return true
+		if problems[i].Metric != problems[j].Metric {
+			return problems[i].Metric < problems[j].Metric
 		}
 
 		return problems[i].Text < problems[j].Text
@@ -99,8 +103,11 @@ func lint(mf dto.MetricFamily) []Problem {
 	fns := []func(mf dto.MetricFamily) []Problem{
 		lintHelp,
 		lintMetricUnits,
+		lintUnitAbbreviations,
+		lintCamelCase,
 		lintCounter,
 		lintHistogramSummaryReserved,
+		lintLabelCardinality,
 	}
 
 	var problems []Problem
@@ -144,6 +151,99 @@ func lintMetricUnits(mf dto.MetricFamily) []Problem {
 	return problems
 }
 
+// lintUnitAbbreviations detects abbreviated units in metric names, e.g.
+// "request_duration_ms".
+func lintUnitAbbreviations(mf dto.MetricFamily) []Problem {
+	var problems problems
+
+	for _, s := range strings.Split(mf.GetName(), "_") {
+		if base, ok := unitAbbreviations[s]; ok {
+			problems.Add(mf, fmt.Sprintf("use base unit %q instead of %q", base, s))
+		}
+	}
+
+	return problems
+}
+
+// lintCamelCase detects metric and label names not written in snake_case.
+func lintCamelCase(mf dto.MetricFamily) []Problem {
+	var problems problems
+
+	if isCamelCase(mf.GetName()) {
+		problems.Add(mf, "metric names should be written in 'snake_case' not 'camelCase'")
+	}
+
+	seen := map[string]bool{}
+	for _, m := range mf.GetMetric() {
+		for _, l := range m.GetLabel() {
+			ln := l.GetName()
+			if !seen[ln] && isCamelCase(ln) {
+				problems.Add(mf, fmt.Sprintf("label names should be written in 'snake_case' not 'camelCase', found %q", ln))
+			}
+			seen[ln] = true
+		}
+	}
+
+	return problems
+}
+
+func isCamelCase(s string) bool {
+	return strings.ToLower(s) != s
+}
+
+// lintDuplicateHelp detects metrics sharing the help text of a previous
+// metric, which usually is the result of copying the instrumentation code of
+// another metric. The first metric using a help text is recorded in helps.
+func lintDuplicateHelp(mf dto.MetricFamily, helps map[string]string) []Problem {
+	help := mf.GetHelp()
+	if help == "" {
+		return nil
+	}
+
+	first, ok := helps[help]
+	if !ok {
+		helps[help] = mf.GetName()
+		return nil
+	}
+
+	var problems problems
+	problems.Add(mf, fmt.Sprintf("help text is the same as the one of %q", first))
+
+	return problems
+}
+
+// lintLabelCardinality detects labels with many distinct values within a
+// metric, which often hold unbounded values such as user IDs or request
+// paths.
+func lintLabelCardinality(mf dto.MetricFamily) []Problem {
+	values := map[string]map[string]struct{}{}
+	for _, m := range mf.GetMetric() {
+		for _, l := range m.GetLabel() {
+			ln := l.GetName()
+			// Buckets and quantiles are bounded by the instrumentation.
+			if ln == "le" || ln == "quantile" {
+				continue
+			}
+
+			vs, ok := values[ln]
+			if !ok {
+				vs = map[string]struct{}{}
+				values[ln] = vs
+			}
+			vs[l.GetValue()] = struct{}{}
+		}
+	}
+
+	var problems problems
+	for ln, vs := range values {
+		if len(vs) > maxLabelValues {
+			problems.Add(mf, fmt.Sprintf("label %q has %d distinct values, which may indicate unbounded cardinality", ln, len(vs)))
+		}
+	}
+
+	return problems
+}
+
 // lintCounter detects issues specific to counters, as well as patterns that should
 // only be used with counters.
 func lintCounter(mf dto.MetricFamily) []Problem {
@@ -229,6 +329,10 @@ func metricUnits(m string) (unit string, base string, ok bool) {
 	return "", "", false
 }
 
+// maxLabelValues is the number of distinct values of a single label of a
+// metric above which the label is reported as a cardinality issue.
+const maxLabelValues = 100
+
 // Units and their possible prefixes recognized by this library.  More can be
 // added over time as needed.
 var (
@@ -265,4 +369,25 @@ var (
 		"peta",
 		"pebi",
 	}
+
+	// Abbreviated units and their base units. Only unambiguous
+	// abbreviations are listed.
+	unitAbbreviations = map[string]string{
+		"ms":      "seconds",
+		"msec":    "seconds",
+		"millis":  "seconds",
+		"sec":     "seconds",
+		"secs":    "seconds",
+		"usec":    "seconds",
+		"nsec":    "seconds",
+		"minutes": "seconds",
+		"hours":   "seconds",
+		"days":    "seconds",
+		"kb":      "bytes",
+		"mb":      "bytes",
+		"gb":      "bytes",
+		"kib":     "bytes",
+		"mib":     "bytes",
+		"gib":     "bytes",
+	}
 )
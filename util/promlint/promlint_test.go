@@ -14,6 +14,7 @@
 package promlint_test
 
 import (
+	"fmt"
 	"reflect"
 	"strings"
 	"testing"
@@ -495,3 +496,214 @@ go_gc_duration_seconds_count 5962
 		})
 	}
 }
+
+func TestLintUnitAbbreviations(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       string
+		problems []promlint.Problem
+	}{
+		{
+			name: "milliseconds abbreviation",
+			in: `
+# HELP request_duration_ms Duration of requests.
+# TYPE request_duration_ms gauge
+request_duration_ms 10
+`,
+			problems: []promlint.Problem{{
+				Metric: "request_duration_ms",
+				Text:   `use base unit "seconds" instead of "ms"`,
+			}},
+		},
+		{
+			name: "kilobytes abbreviation",
+			in: `
+# HELP cache_size_kb Size of the cache.
+# TYPE cache_size_kb gauge
+cache_size_kb 10
+`,
+			problems: []promlint.Problem{{
+				Metric: "cache_size_kb",
+				Text:   `use base unit "bytes" instead of "kb"`,
+			}},
+		},
+		{
+			name: "OK",
+			in: `
+# HELP request_duration_seconds Duration of requests.
+# TYPE request_duration_seconds gauge
+request_duration_seconds 10
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := promlint.New(strings.NewReader(tt.in))
+
+			problems, err := l.Lint()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if want, got := tt.problems, problems; !reflect.DeepEqual(want, got) {
+				t.Fatalf("unexpected problems:\n- want: %v\n-  got: %v",
+					want, got)
+			}
+		})
+	}
+}
+
+func TestLintCamelCase(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       string
+		problems []promlint.Problem
+	}{
+		{
+			name: "camelCase metric name",
+			in: `
+# HELP cacheEntries Number of cache entries.
+# TYPE cacheEntries gauge
+cacheEntries 10
+`,
+			problems: []promlint.Problem{{
+				Metric: "cacheEntries",
+				Text:   "metric names should be written in 'snake_case' not 'camelCase'",
+			}},
+		},
+		{
+			name: "camelCase label name",
+			in: `
+# HELP cache_entries Number of cache entries.
+# TYPE cache_entries gauge
+cache_entries{cacheName="a"} 10
+cache_entries{cacheName="b"} 10
+`,
+			problems: []promlint.Problem{{
+				Metric: "cache_entries",
+				Text:   `label names should be written in 'snake_case' not 'camelCase', found "cacheName"`,
+			}},
+		},
+		{
+			name: "OK",
+			in: `
+# HELP cache_entries Number of cache entries.
+# TYPE cache_entries gauge
+cache_entries{cache_name="a"} 10
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := promlint.New(strings.NewReader(tt.in))
+
+			problems, err := l.Lint()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if want, got := tt.problems, problems; !reflect.DeepEqual(want, got) {
+				t.Fatalf("unexpected problems:\n- want: %v\n-  got: %v",
+					want, got)
+			}
+		})
+	}
+}
+
+func TestLintDuplicateHelp(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       string
+		problems []promlint.Problem
+	}{
+		{
+			name: "duplicate help",
+			in: `
+# HELP requests_total Number of requests.
+# TYPE requests_total counter
+requests_total 10
+# HELP errors_total Number of requests.
+# TYPE errors_total counter
+errors_total 1
+`,
+			problems: []promlint.Problem{{
+				Metric: "errors_total",
+				Text:   `help text is the same as the one of "requests_total"`,
+			}},
+		},
+		{
+			name: "OK",
+			in: `
+# HELP requests_total Number of requests.
+# TYPE requests_total counter
+requests_total 10
+# HELP errors_total Number of failed requests.
+# TYPE errors_total counter
+errors_total 1
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := promlint.New(strings.NewReader(tt.in))
+
+			problems, err := l.Lint()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if want, got := tt.problems, problems; !reflect.DeepEqual(want, got) {
+				t.Fatalf("unexpected problems:\n- want: %v\n-  got: %v",
+					want, got)
+			}
+		})
+	}
+}
+
+func TestLintLabelCardinality(t *testing.T) {
+	series := func(n int) string {
+		s := "# HELP requests_total Number of requests.\n# TYPE requests_total counter\n"
+		for i := 0; i < n; i++ {
+			s += fmt.Sprintf("requests_total{user=\"%d\",code=\"200\"} 1\n", i)
+		}
+		return s
+	}
+
+	tests := []struct {
+		name     string
+		in       string
+		problems []promlint.Problem
+	}{
+		{
+			name: "too many label values",
+			in:   series(101),
+			problems: []promlint.Problem{{
+				Metric: "requests_total",
+				Text:   `label "user" has 101 distinct values, which may indicate unbounded cardinality`,
+			}},
+		},
+		{
+			name: "OK",
+			in:   series(100),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := promlint.New(strings.NewReader(tt.in))
+
+			problems, err := l.Lint()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if want, got := tt.problems, problems; !reflect.DeepEqual(want, got) {
+				t.Fatalf("unexpected problems:\n- want: %v\n-  got: %v",
+					want, got)
+			}
+		})
+	}
+}
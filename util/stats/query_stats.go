@@ -13,6 +13,8 @@
 
 package stats
 
+import "github.com/prometheus/common/model"
+
 // QueryTiming identifies the code area or functionality in which time is spent
 // during a query.
 type QueryTiming int
@@ -46,3 +48,73 @@ func (s QueryTiming) String() string {
 		return "Unknown query timing"
 	}
 }
+
+// QuerySamples tracks the number of samples processed during a query.
+type QuerySamples struct {
+	// TotalSamples is the number of samples loaded from storage.
+	TotalSamples int64
+	// PeakSamples is the highest number of samples held in memory at the
+	// same time.
+	PeakSamples int
+}
+
+// QueryStats is the statistics of a query in a form suitable for JSON
+// encoding.
+type QueryStats struct {
+	Timings     QueryTimings      `json:"timings"`
+	Samples     QuerySamplesStats `json:"samples"`
+	Diagnostics []MatchDiagnostic `json:"diagnostics,omitempty"`
+}
+
+// QueryTimings holds the timings of a query, in seconds.
+type QueryTimings struct {
+	EvalTotalTime        float64 `json:"evalTotalTime"`
+	ResultSortTime       float64 `json:"resultSortTime"`
+	QueryPreparationTime float64 `json:"queryPreparationTime"`
+	InnerEvalTime        float64 `json:"innerEvalTime"`
+	ResultAppendTime     float64 `json:"resultAppendTime"`
+	ExecQueueTime        float64 `json:"execQueueTime"`
+}
+
+// QuerySamplesStats holds the sample counts of a query.
+type QuerySamplesStats struct {
+	TotalQueryableSamples int64 `json:"totalQueryableSamples"`
+	PeakSamples           int   `json:"peakSamples"`
+}
+
+// MatchDiagnostic describes the duplicate series a vector matching of a
+// binary operation failed on.
+type MatchDiagnostic struct {
+	// The side of the operation holding the series, "left" or "right".
+	Side string `json:"side"`
+	// The values of the matching labels of the series.
+	MatchGroup model.Metric `json:"matchGroup"`
+	// The duplicate series.
+	Series []model.Metric `json:"series"`
+	// The labels the series differ in, one of which could be matched on
+	// to tell them apart.
+	SuggestedLabels model.LabelNames `json:"suggestedLabels,omitempty"`
+}
+
+// NewQueryStats makes a QueryStats from the timers, sample counts and
+// diagnostics of a query.
+func NewQueryStats(tg *TimerGroup, qs *QuerySamples, diagnostics []MatchDiagnostic) *QueryStats {
+	seconds := func(qt QueryTiming) float64 {
+		return tg.GetTimer(qt).Duration().Seconds()
+	}
+	return &QueryStats{
+		Timings: QueryTimings{
+			EvalTotalTime:        seconds(TotalEvalTime),
+			ResultSortTime:       seconds(ResultSortTime),
+			QueryPreparationTime: seconds(QueryPreparationTime),
+			InnerEvalTime:        seconds(InnerEvalTime),
+			ResultAppendTime:     seconds(ResultAppendTime),
+			ExecQueueTime:        seconds(ExecQueueTime),
+		},
+		Samples: QuerySamplesStats{
+			TotalQueryableSamples: qs.TotalSamples,
+			PeakSamples:           qs.PeakSamples,
+		},
+		Diagnostics: diagnostics,
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/common/log"
+
+	"github.com/prometheus/prometheus/storage/local"
+)
+
+var (
+	errNoLogLevel = errors.New("log level not adjustable")
+	errNoPurging  = errors.New("storage does not purge series files")
+)
+
+// LogLevel is the level of the messages logged by the server.
+type LogLevel struct {
+	Level string `json:"level"`
+}
+
+// GCResult is the result of a forced garbage collection.
+type GCResult struct {
+	HeapAllocBefore uint64  `json:"heapAllocBefore"`
+	HeapAllocAfter  uint64  `json:"heapAllocAfter"`
+	HeapReleased    uint64  `json:"heapReleased"`
+	Duration        float64 `json:"duration"`
+}
+
+// currentLogLevel returns the level of the log level flag, whose value is
+// quoted.
+func (api *API) currentLogLevel() string {
+	s := api.logLevel.String()
+	if level, err := strconv.Unquote(s); err == nil {
+		return level
+	}
+	return s
+}
+
+func (api *API) serveLogLevel(r *http.Request) (interface{}, *apiError) {
+	if api.logLevel == nil {
+		return nil, &apiError{errorUnavailable, errNoLogLevel}
+	}
+	return LogLevel{Level: api.currentLogLevel()}, nil
+}
+
+// setLogLevel changes the log level to the one in the level parameter until
+// the next restart.
+func (api *API) setLogLevel(r *http.Request) (interface{}, *apiError) {
+	level := r.FormValue("level")
+	if level == "" {
+		return nil, &apiError{errorBadData, fmt.Errorf("no level parameter provided")}
+	}
+	if api.logLevel == nil {
+		return nil, &apiError{errorUnavailable, errNoLogLevel}
+	}
+	prev := api.currentLogLevel()
+	if err := api.logLevel.Set(level); err != nil {
+		return nil, &apiError{errorBadData, fmt.Errorf("invalid log level %q: %s", level, err)}
+	}
+	log.Warnf("Log level changed from %s to %s through the admin API", prev, api.currentLogLevel())
+	return LogLevel{Level: api.currentLogLevel()}, nil
+}
+
+// forceGC runs a garbage collection and returns as much memory to the
+// operating system as possible.
+func (api *API) forceGC(r *http.Request) (interface{}, *apiError) {
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	debug.FreeOSMemory()
+	took := time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	log.Infof("Forced garbage collection through the admin API in %s, heap reduced from %d to %d bytes", took, before.HeapAlloc, after.HeapAlloc)
+	return GCResult{
+		HeapAllocBefore: before.HeapAlloc,
+		HeapAllocAfter:  after.HeapAlloc,
+		HeapReleased:    after.HeapReleased,
+		Duration:        took.Seconds(),
+	}, nil
+}
+
+// purgeController is implemented by storages whose purging of samples beyond
+// the retention period can be paused.
+type purgeController interface {
+	PausePurging()
+	ResumePurging()
+	PurgingStatus() local.PurgingStatus
+}
+
+func (api *API) servePurging(r *http.Request) (interface{}, *apiError) {
+	pc, ok := api.Storage.(purgeController)
+	if !ok {
+		return nil, &apiError{errorUnavailable, errNoPurging}
+	}
+	return pc.PurgingStatus(), nil
+}
+
+// setPurging pauses or resumes purging according to the paused parameter
+// until the next restart.
+func (api *API) setPurging(r *http.Request) (interface{}, *apiError) {
+	s := r.FormValue("paused")
+	if s == "" {
+		return nil, &apiError{errorBadData, fmt.Errorf("no paused parameter provided")}
+	}
+	paused, err := strconv.ParseBool(s)
+	if err != nil {
+		return nil, &apiError{errorBadData, fmt.Errorf("invalid paused parameter %q: %s", s, err)}
+	}
+	pc, ok := api.Storage.(purgeController)
+	if !ok {
+		return nil, &apiError{errorUnavailable, errNoPurging}
+	}
+	if paused {
+		pc.PausePurging()
+		log.Warn("Purging of series files paused through the admin API")
+	} else {
+		pc.ResumePurging()
+		log.Info("Purging of series files resumed through the admin API")
+	}
+	return pc.PurgingStatus(), nil
+}
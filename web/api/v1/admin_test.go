@@ -0,0 +1,167 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/common/route"
+
+	"github.com/prometheus/prometheus/storage/local"
+)
+
+// testLevelFlag quotes its value like the log level flag.
+type testLevelFlag string
+
+func (f *testLevelFlag) String() string { return fmt.Sprintf("%q", string(*f)) }
+
+func (f *testLevelFlag) Set(s string) error {
+	switch s {
+	case "debug", "info":
+		*f = testLevelFlag(s)
+		return nil
+	}
+	return fmt.Errorf("not a valid level")
+}
+
+func TestLogLevel(t *testing.T) {
+	level := testLevelFlag("info")
+	api := &API{logLevel: &level}
+
+	if res, apiErr := api.serveLogLevel(nil); apiErr != nil || res != (LogLevel{Level: "info"}) {
+		t.Fatalf("Unexpected log level %v, error %v", res, apiErr)
+	}
+
+	set := func(v url.Values) (interface{}, *apiError) {
+		r, err := http.NewRequest("PUT", "http://example.org/api/v1/admin/loglevel", strings.NewReader(v.Encode()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return api.setLogLevel(r)
+	}
+	for _, v := range []url.Values{{}, {"level": []string{"verbose"}}} {
+		if _, apiErr := set(v); apiErr == nil || apiErr.typ != errorBadData {
+			t.Errorf("Expected bad data error for %v, got %v", v, apiErr)
+		}
+	}
+	if res, apiErr := set(url.Values{"level": []string{"debug"}}); apiErr != nil || res != (LogLevel{Level: "debug"}) {
+		t.Fatalf("Unexpected log level %v, error %v", res, apiErr)
+	}
+	if level != "debug" {
+		t.Errorf("Expected log level flag to be set to debug, got %s", level)
+	}
+
+	api = &API{}
+	if _, apiErr := api.serveLogLevel(nil); apiErr == nil || apiErr.typ != errorUnavailable {
+		t.Errorf("Expected unavailable error without log level flag, got %v", apiErr)
+	}
+}
+
+func TestForceGC(t *testing.T) {
+	res, apiErr := (&API{}).forceGC(nil)
+	if apiErr != nil {
+		t.Fatal(apiErr.err)
+	}
+	if res.(GCResult).HeapAllocAfter == 0 {
+		t.Errorf("Unexpected result %+v", res)
+	}
+}
+
+func TestRuntimeAdminDisabled(t *testing.T) {
+	r := route.New()
+	level := testLevelFlag("info")
+	api := &API{logLevel: &level}
+	api.Register(r)
+
+	s := httptest.NewServer(r)
+	defer s.Close()
+
+	for _, req := range []struct{ method, path string }{
+		{"GET", "/admin/loglevel"},
+		{"PUT", "/admin/loglevel?level=debug"},
+		{"POST", "/admin/gc"},
+	} {
+		hr, err := http.NewRequest(req.method, s.URL+req.path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := http.DefaultClient.Do(hr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusServiceUnavailable {
+			t.Errorf("Expected status %d for %s %s, got %d", http.StatusServiceUnavailable, req.method, req.path, resp.StatusCode)
+		}
+	}
+	if level != "info" {
+		t.Errorf("Expected log level to remain unchanged, got %s", level)
+	}
+}
+
+// purgingStorage is a storage whose purging can be paused.
+type purgingStorage struct {
+	local.Storage
+	paused bool
+}
+
+func (s *purgingStorage) PausePurging()  { s.paused = true }
+func (s *purgingStorage) ResumePurging() { s.paused = false }
+
+func (s *purgingStorage) PurgingStatus() local.PurgingStatus {
+	return local.PurgingStatus{Paused: s.paused, Active: !s.paused}
+}
+
+func TestPurging(t *testing.T) {
+	st := &purgingStorage{}
+	api := &API{Storage: st}
+
+	if res, apiErr := api.servePurging(nil); apiErr != nil || res != (local.PurgingStatus{Active: true}) {
+		t.Fatalf("Unexpected status %v, error %v", res, apiErr)
+	}
+
+	set := func(v url.Values) (interface{}, *apiError) {
+		r, err := http.NewRequest("PUT", "http://example.org/api/v1/admin/storage/purging", strings.NewReader(v.Encode()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return api.setPurging(r)
+	}
+	for _, v := range []url.Values{{}, {"paused": []string{"maybe"}}} {
+		if _, apiErr := set(v); apiErr == nil || apiErr.typ != errorBadData {
+			t.Errorf("Expected bad data error for %v, got %v", v, apiErr)
+		}
+	}
+	if res, apiErr := set(url.Values{"paused": []string{"true"}}); apiErr != nil || res != (local.PurgingStatus{Paused: true}) {
+		t.Fatalf("Unexpected status %v, error %v", res, apiErr)
+	}
+	if !st.paused {
+		t.Error("Expected purging to be paused")
+	}
+	if _, apiErr := set(url.Values{"paused": []string{"false"}}); apiErr != nil || st.paused {
+		t.Errorf("Expected purging to be resumed, error %v", apiErr)
+	}
+
+	api = &API{Storage: &local.NoopStorage{}}
+	if _, apiErr := api.servePurging(nil); apiErr == nil || apiErr.typ != errorUnavailable {
+		t.Errorf("Expected unavailable error without purging, got %v", apiErr)
+	}
+}
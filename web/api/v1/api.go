@@ -16,15 +16,22 @@ package v1
 import (
 	"encoding/json"
 	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"math"
+	"mime"
 	"net/http"
 	"net/url"
+	"regexp"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/common/route"
 	"golang.org/x/net/context"
@@ -32,10 +39,12 @@ import (
 	"github.com/prometheus/prometheus/config"
 	"github.com/prometheus/prometheus/promql"
 	"github.com/prometheus/prometheus/retrieval"
+	"github.com/prometheus/prometheus/rules"
 	"github.com/prometheus/prometheus/storage/local"
 	"github.com/prometheus/prometheus/storage/metric"
 	"github.com/prometheus/prometheus/storage/remote"
 	"github.com/prometheus/prometheus/util/httputil"
+	"github.com/prometheus/prometheus/util/stats"
 )
 
 type status string
@@ -48,12 +57,14 @@ const (
 type errorType string
 
 const (
-	errorNone     errorType = ""
-	errorTimeout            = "timeout"
-	errorCanceled           = "canceled"
-	errorExec               = "execution"
-	errorBadData            = "bad_data"
-	errorInternal           = "internal"
+	errorNone        errorType = ""
+	errorTimeout               = "timeout"
+	errorCanceled              = "canceled"
+	errorExec                  = "execution"
+	errorBadData               = "bad_data"
+	errorInternal              = "internal"
+	errorUnavailable           = "unavailable"
+	errorNotFound              = "not_found"
 )
 
 var corsHeaders = map[string]string{
@@ -63,6 +74,19 @@ var corsHeaders = map[string]string{
 	"Access-Control-Expose-Headers": "Date",
 }
 
+// corsAllowAll is the string representation of the anchored CORS origin
+// regexp that matches every origin.
+const corsAllowAll = "^(?:.*)$"
+
+// protobufContentType is the media type of protobuf encoded responses.
+const protobufContentType = "application/x-protobuf"
+
+var (
+	errForwardOnly    = errors.New("unavailable with Prometheus running in forward-only mode")
+	errAdminDisabled  = errors.New("admin APIs are disabled")
+	errNoBlockStorage = errors.New("storage does not support blocks")
+)
+
 type apiError struct {
 	typ errorType
 	err error
@@ -73,25 +97,44 @@ func (e *apiError) Error() string {
 }
 
 type targetRetriever interface {
-	Targets() []*retrieval.Target
+	TargetsActive() map[string][]*retrieval.Target
 }
 
 type alertmanagerRetriever interface {
 	Alertmanagers() []*url.URL
 }
 
+type remoteWriteRetriever interface {
+	QueueStatus() []remote.QueueStatus
+}
+
+type ruleGroupEvaluator interface {
+	EvalGroup(name string) ([]rules.RuleResult, error)
+}
+
 type response struct {
 	Status    status      `json:"status"`
 	Data      interface{} `json:"data,omitempty"`
 	ErrorType errorType   `json:"errorType,omitempty"`
 	Error     string      `json:"error,omitempty"`
+	Warnings  []string    `json:"warnings,omitempty"`
 }
 
-// Enables cross-site script calls.
-func setCORS(w http.ResponseWriter) {
+// Enables cross-site script calls from origins matching o. A nil o allows
+// all origins.
+func setCORS(w http.ResponseWriter, o *regexp.Regexp, r *http.Request) {
 	for h, v := range corsHeaders {
 		w.Header().Set(h, v)
 	}
+	if o == nil || o.String() == corsAllowAll {
+		return
+	}
+
+	w.Header().Del("Access-Control-Allow-Origin")
+	w.Header().Add("Vary", "Origin")
+	if origin := r.Header.Get("Origin"); origin != "" && o.MatchString(origin) {
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+	}
 }
 
 type apiFunc func(r *http.Request) (interface{}, *apiError)
@@ -104,31 +147,148 @@ type API struct {
 
 	targetRetriever       targetRetriever
 	alertmanagerRetriever alertmanagerRetriever
+	remoteWriteRetriever  remoteWriteRetriever
+	ruleGroupEvaluator    ruleGroupEvaluator
 
 	now    func() model.Time
 	config func() config.Config
+
+	// forwardOnly is set when Prometheus runs in forward-only mode, in which case
+	// there is no local storage to query.
+	forwardOnly bool
+	// enableAdmin enables the endpoints that modify the state of Prometheus.
+	enableAdmin bool
+	limiter     *queryLimiter
+	cache       *queryCache
+	corsOrigin  *regexp.Regexp
+
+	flagsMap    map[string]string
+	buildInfo   *PrometheusVersion
+	runtimeInfo func() (RuntimeInfo, error)
+	// reloadResults returns the results of the last configuration reload
+	// attempt and of the last successful one.
+	reloadResults func() (last, lastSuccess *ReloadResult)
+	settings      *settingsStore
+	links         *linkStore
+	// The log level flag adjusted by the admin API, if any.
+	logLevel flag.Value
+	// The registered endpoints.
+	endpoints []endpoint
+}
+
+// PrometheusVersion contains build information about Prometheus.
+type PrometheusVersion struct {
+	Version   string `json:"version"`
+	Revision  string `json:"revision"`
+	Branch    string `json:"branch"`
+	BuildUser string `json:"buildUser"`
+	BuildDate string `json:"buildDate"`
+	GoVersion string `json:"goVersion"`
+}
+
+// RuntimeInfo contains runtime information about Prometheus.
+type RuntimeInfo struct {
+	StartTime        time.Time `json:"startTime"`
+	CWD              string    `json:"CWD"`
+	GoroutineCount   int       `json:"goroutineCount"`
+	GOMAXPROCS       int       `json:"GOMAXPROCS"`
+	GOGC             string    `json:"GOGC"`
+	GOMEMLIMIT       string    `json:"GOMEMLIMIT"`
+	StoragePath      string    `json:"storagePath"`
+	StorageRetention string    `json:"storageRetention"`
+	// The size in bytes of the checkpoint of the series held in memory,
+	// which is replayed on startup.
+	CheckpointSize int64 `json:"checkpointSize"`
+}
+
+// ReloadResult describes the outcome of a configuration reload.
+type ReloadResult struct {
+	Time    time.Time `json:"time"`
+	Success bool      `json:"success"`
+	Error   string    `json:"error,omitempty"`
+	// The components affected by the reload. They are left empty if the
+	// configuration file could not be loaded.
+	ScrapePools       []ReloadedComponent `json:"scrapePools"`
+	RuleGroups        []ReloadedComponent `json:"ruleGroups"`
+	RemoteWriteQueues []ReloadedComponent `json:"remoteWriteQueues"`
+}
+
+// Actions taken on a component during a configuration reload.
+const (
+	ReloadCreated   = "created"
+	ReloadRestarted = "restarted"
+	ReloadStopped   = "stopped"
+)
+
+// ReloadedComponent is a component affected by a configuration reload, along
+// with what happened to it and why.
+type ReloadedComponent struct {
+	Name   string `json:"name"`
+	Action string `json:"action"`
+	Reason string `json:"reason"`
 }
 
 // NewAPI returns an initialized API type.
-func NewAPI(qe *promql.Engine, st local.Storage, tr targetRetriever, ar alertmanagerRetriever, configFunc func() config.Config) *API {
+func NewAPI(
+	qe *promql.Engine,
+	st local.Storage,
+	tr targetRetriever,
+	ar alertmanagerRetriever,
+	rwr remoteWriteRetriever,
+	rge ruleGroupEvaluator,
+	configFunc func() config.Config,
+	forwardOnly bool,
+	enableAdmin bool,
+	limits QueryLimits,
+	cacheOpts QueryCacheOptions,
+	flagsMap map[string]string,
+	buildInfo *PrometheusVersion,
+	runtimeInfo func() (RuntimeInfo, error),
+	reloadResults func() (last, lastSuccess *ReloadResult),
+	corsOrigin *regexp.Regexp,
+	settingsFile string,
+	linksFile string,
+	logLevel flag.Value,
+) *API {
 	return &API{
 		QueryEngine:           qe,
 		Storage:               st,
 		targetRetriever:       tr,
 		alertmanagerRetriever: ar,
-		now:    model.Now,
-		config: configFunc,
+		remoteWriteRetriever:  rwr,
+		ruleGroupEvaluator:    rge,
+		now:                   model.Now,
+		config:                configFunc,
+		forwardOnly:           forwardOnly,
+		enableAdmin:           enableAdmin,
+		limiter:               newQueryLimiter(limits),
+		cache:                 newQueryCache(cacheOpts),
+		flagsMap:              flagsMap,
+		buildInfo:             buildInfo,
+		runtimeInfo:           runtimeInfo,
+		reloadResults:         reloadResults,
+		corsOrigin:            corsOrigin,
+		settings:              newSettingsStore(settingsFile),
+		links:                 newLinkStore(linksFile),
+		logLevel:              logLevel,
 	}
 }
 
 // Register the API's endpoints in the given router.
 func (api *API) Register(r *route.Router) {
-	instr := func(name string, f apiFunc) http.HandlerFunc {
+	instr := func(name string, f apiFunc, protobuf bool) http.HandlerFunc {
 		hf := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			setCORS(w)
+			setCORS(w, api.corsOrigin, r)
+			if protobuf {
+				w.Header().Add("Vary", "Accept")
+			}
 			if data, err := f(r); err != nil {
 				respondError(w, err, data)
 			} else if data != nil {
+				if m, ok := protobufData(data); ok && protobuf && acceptsProtobuf(r) {
+					respondProtobuf(w, m)
+					return
+				}
 				respond(w, data)
 			} else {
 				w.WriteHeader(http.StatusNoContent)
@@ -138,27 +298,240 @@ func (api *API) Register(r *route.Router) {
 			Handler: hf,
 		})
 	}
+	// wrapForwardOnly disables query endpoints when running in forward-only mode.
+	wrapForwardOnly := func(f apiFunc) apiFunc {
+		if !api.forwardOnly {
+			return f
+		}
+		return func(r *http.Request) (interface{}, *apiError) {
+			return nil, &apiError{errorUnavailable, errForwardOnly}
+		}
+	}
+	// wrapAdmin disables admin endpoints unless they are enabled.
+	wrapAdmin := func(f apiFunc) apiFunc {
+		if api.enableAdmin {
+			return f
+		}
+		return func(r *http.Request) (interface{}, *apiError) {
+			return nil, &apiError{errorUnavailable, errAdminDisabled}
+		}
+	}
 
-	r.Options("/*path", instr("options", api.options))
-
-	r.Get("/query", instr("query", api.query))
-	r.Get("/query_range", instr("query_range", api.queryRange))
-
-	r.Get("/label/:name/values", instr("label_values", api.labelValues))
-
-	r.Get("/series", instr("series", api.series))
-	r.Del("/series", instr("drop_series", api.dropSeries))
+	r.Options("/*path", instr("options", api.options, false))
+
+	queryParam := endpointParam{name: "query", typ: paramString, required: true, description: "PromQL expression."}
+	timeoutParam := endpointParam{name: "timeout", typ: paramDuration, description: "Evaluation timeout, capped by the -query.timeout flag."}
+	statsParam := endpointParam{name: "stats", typ: paramString, description: "Include query statistics in the response if set."}
+
+	api.endpoints = []endpoint{
+		{
+			method: "GET", path: "/query", name: "query", summary: "Evaluates an instant query.",
+			params: []endpointParam{
+				queryParam,
+				{name: "time", typ: paramTime, description: "Evaluation timestamp, defaults to the current time."},
+				timeoutParam,
+				statsParam,
+			},
+			f: wrapForwardOnly(api.query), limited: true,
+		},
+		{
+			method: "GET", path: "/query_range", name: "query_range", summary: "Evaluates an expression query over a range of time.",
+			params: []endpointParam{
+				queryParam,
+				{name: "start", typ: paramTime, required: true, description: "Start timestamp."},
+				{name: "end", typ: paramTime, required: true, description: "End timestamp."},
+				{name: "step", typ: paramDuration, required: true, description: "Query resolution step width."},
+				timeoutParam,
+				statsParam,
+			},
+			f: wrapForwardOnly(api.queryRange), limited: true, protobuf: true,
+		},
+		{
+			method: "GET", path: "/labels", name: "label_names", summary: "Returns the label names of the selected series.",
+			params: []endpointParam{paramMatch, paramStart, paramEnd},
+			f:      wrapForwardOnly(api.labelNames), limited: true,
+		},
+		{
+			method: "GET", path: "/label/:name/values", name: "label_values", summary: "Returns the values of a label of the selected series.",
+			params: []endpointParam{paramMatch, paramStart, paramEnd},
+			f:      wrapForwardOnly(api.labelValues), limited: true,
+		},
+		{
+			method: "GET", path: "/series", name: "series", summary: "Returns the series matching the selectors.",
+			params: []endpointParam{
+				withRequired(paramMatch),
+				paramStart,
+				paramEnd,
+				{name: "limit", typ: paramInteger, description: "Maximum number of series returned."},
+			},
+			f: wrapForwardOnly(api.series), limited: true,
+		},
+		{
+			method: "DELETE", path: "/series", name: "drop_series", summary: "Deletes the series matching the selectors.",
+			params: []endpointParam{withRequired(paramMatch)},
+			f:      wrapForwardOnly(api.dropSeries),
+		},
+		{
+			method: "GET", path: "/targets", name: "targets", summary: "Returns the active targets.",
+			params: []endpointParam{
+				{name: "state", typ: paramString, description: "Health state of the returned targets: up, down, unknown or any."},
+				{name: "scrapePool", typ: paramString, description: "Name of the scrape pool of the returned targets."},
+			},
+			f: api.targets,
+		},
+		{
+			method: "GET", path: "/alertmanagers", name: "alertmanagers", summary: "Returns the discovered Alertmanagers.",
+			f: api.alertmanagers,
+		},
+		{
+			method: "GET", path: "/metadata", name: "metadata", summary: "Returns the metadata of the metrics exposed by the active targets.",
+			params: []endpointParam{
+				{name: "metric", typ: paramString, description: "Name of the metric to return the metadata of."},
+				{name: "limit", typ: paramInteger, description: "Maximum number of metrics returned."},
+			},
+			f: api.metricMetadata,
+		},
+		{
+			method: "POST", path: "/rules/:group/evaluate", name: "evaluate_rule_group", summary: "Evaluates a rule group once.",
+			f: wrapAdmin(wrapForwardOnly(api.evaluateRuleGroup)),
+		},
+		{
+			method: "GET", path: "/status/config", name: "config", summary: "Returns the loaded configuration file.",
+			f: api.serveConfig,
+		},
+		{
+			method: "GET", path: "/status/buildinfo", name: "buildinfo", summary: "Returns build information.",
+			f: api.serveBuildInfo,
+		},
+		{
+			method: "GET", path: "/status/runtimeinfo", name: "runtimeinfo", summary: "Returns runtime information.",
+			f: api.serveRuntimeInfo,
+		},
+		{
+			method: "GET", path: "/status/flags", name: "flags", summary: "Returns the values of the command-line flags.",
+			f: api.serveFlags,
+		},
+		{
+			method: "GET", path: "/status/remote-storage", name: "remote_storage", summary: "Returns the state of the remote storage queues.",
+			f: api.serveRemoteStorage,
+		},
+		{
+			method: "GET", path: "/status/storage", name: "storage_stats", summary: "Returns statistics of the series in memory.",
+			params: []endpointParam{
+				{name: "limit", typ: paramInteger, description: "Maximum number of entries returned per statistic."},
+			},
+			f: wrapForwardOnly(api.serveStorageStats),
+		},
+		{
+			method: "GET", path: "/settings", name: "settings", summary: "Returns the settings of the web UI.",
+			f: api.serveSettings,
+		},
+		{
+			method: "POST", path: "/settings", name: "update_settings", summary: "Replaces the settings of the web UI. Requires the admin APIs to be enabled.",
+			params: []endpointParam{
+				{name: "theme", typ: paramString, description: "Theme of the web UI: light or dark."},
+				{name: "queryTimeout", typ: paramDuration, description: "Timeout of the queries of the expression browser."},
+				{name: "lookback", typ: paramDuration, description: "Default range of graphs."},
+			},
+			f: wrapAdmin(api.updateSettings),
+		},
+		{
+			method: "POST", path: "/links", name: "create_link", summary: "Creates a short link to the graph page.",
+			params: []endpointParam{
+				{name: "params", typ: paramString, required: true, description: "Query string of the graph page."},
+			},
+			f: api.createLink,
+		},
+		{
+			method: "GET", path: "/links/:id", name: "link", summary: "Returns a short link to the graph page.",
+			f: api.serveLink,
+		},
+		{
+			method: "GET", path: "/admin/storage/export", name: "export_block", summary: "Exports the selected series as a block.",
+			params: []endpointParam{withRequired(paramMatch), paramStart, paramEnd},
+			h:      api.exportBlock, contentType: "application/x-tar",
+		},
+		{
+			method: "POST", path: "/admin/storage/import", name: "import_block", summary: "Imports a block from a directory of the server.",
+			params: []endpointParam{
+				{name: "path", typ: paramString, required: true, description: "Directory of the block."},
+			},
+			f: wrapAdmin(wrapForwardOnly(api.importBlock)),
+		},
+		{
+			method: "GET", path: "/admin/storage/purging", name: "purging", summary: "Returns whether samples beyond the retention period are purged from the series files.",
+			f: wrapAdmin(wrapForwardOnly(api.servePurging)),
+		},
+		{
+			method: "PUT", path: "/admin/storage/purging", name: "set_purging", summary: "Pauses or resumes purging until the next restart.",
+			params: []endpointParam{
+				{name: "paused", typ: paramString, required: true, description: "Whether purging is paused: true or false."},
+			},
+			f: wrapAdmin(wrapForwardOnly(api.setPurging)),
+		},
+		{
+			method: "GET", path: "/admin/loglevel", name: "log_level", summary: "Returns the log level.",
+			f: wrapAdmin(api.serveLogLevel),
+		},
+		{
+			method: "PUT", path: "/admin/loglevel", name: "set_log_level", summary: "Changes the log level until the next restart.",
+			params: []endpointParam{
+				{name: "level", typ: paramString, required: true, description: "Log level: debug, info, warn, error or fatal."},
+			},
+			f: wrapAdmin(api.setLogLevel),
+		},
+		{
+			method: "POST", path: "/admin/gc", name: "gc", summary: "Runs a garbage collection and returns freed memory to the operating system.",
+			f: wrapAdmin(api.forceGC),
+		},
+		{
+			method: "POST", path: "/read", name: "read", summary: "Serves remote read requests.",
+			h: api.remoteRead, contentType: protobufContentType,
+		},
+		{
+			method: "GET", path: "/openapi.json", name: "openapi", summary: "Returns the OpenAPI specification of the API.",
+			h: api.serveOpenAPI, contentType: "application/json",
+		},
+	}
 
-	r.Get("/targets", instr("targets", api.targets))
-	r.Get("/alertmanagers", instr("alertmanagers", api.alertmanagers))
+	limit := api.limiter.wrap
+	for _, e := range api.endpoints {
+		var h http.HandlerFunc
+		if e.f != nil {
+			h = instr(e.name, e.f, e.protobuf)
+		} else {
+			h = prometheus.InstrumentHandler(e.name, e.h)
+		}
+		if e.limited {
+			h = limit(h)
+		}
+		switch e.method {
+		case "GET":
+			r.Get(e.path, h)
+		case "POST":
+			r.Post(e.path, h)
+		case "PUT":
+			r.Put(e.path, h)
+		case "DELETE":
+			r.Del(e.path, h)
+		default:
+			panic("unsupported method " + e.method)
+		}
+	}
+}
 
-	r.Get("/status/config", instr("config", api.serveConfig))
-	r.Post("/read", prometheus.InstrumentHandler("read", http.HandlerFunc(api.remoteRead)))
+func withRequired(p endpointParam) endpointParam {
+	p.required = true
+	return p
 }
 
 type queryData struct {
-	ResultType model.ValueType `json:"resultType"`
-	Result     model.Value     `json:"result"`
+	ResultType model.ValueType   `json:"resultType,omitempty"`
+	Result     model.Value       `json:"result,omitempty"`
+	Stats      *stats.QueryStats `json:"stats,omitempty"`
+
+	// The warnings of the evaluation, returned next to the data.
+	warnings []string
 }
 
 func (api *API) options(r *http.Request) (interface{}, *apiError) {
@@ -196,19 +569,22 @@ func (api *API) query(r *http.Request) (interface{}, *apiError) {
 
 	res := qry.Exec(ctx)
 	if res.Err != nil {
+		data := failedQueryData(r, qry)
 		switch res.Err.(type) {
 		case promql.ErrQueryCanceled:
-			return nil, &apiError{errorCanceled, res.Err}
+			return data, &apiError{errorCanceled, res.Err}
 		case promql.ErrQueryTimeout:
-			return nil, &apiError{errorTimeout, res.Err}
+			return data, &apiError{errorTimeout, res.Err}
 		case promql.ErrStorage:
-			return nil, &apiError{errorInternal, res.Err}
+			return data, &apiError{errorInternal, res.Err}
 		}
-		return nil, &apiError{errorExec, res.Err}
+		return data, &apiError{errorExec, res.Err}
 	}
 	return &queryData{
 		ResultType: res.Value.Type(),
 		Result:     res.Value,
+		Stats:      queryStats(r, qry),
+		warnings:   res.Warnings,
 	}, nil
 }
 
@@ -255,6 +631,10 @@ func (api *API) queryRange(r *http.Request) (interface{}, *apiError) {
 		defer cancel()
 	}
 
+	if api.cache.cacheable(start, step) {
+		return api.cachedQueryRange(ctx, r, start, end, step)
+	}
+
 	qry, err := api.QueryEngine.NewRangeQuery(r.FormValue("query"), start, end, step)
 	if err != nil {
 		return nil, &apiError{errorBadData, err}
@@ -262,94 +642,295 @@ func (api *API) queryRange(r *http.Request) (interface{}, *apiError) {
 
 	res := qry.Exec(ctx)
 	if res.Err != nil {
-		switch res.Err.(type) {
-		case promql.ErrQueryCanceled:
-			return nil, &apiError{errorCanceled, res.Err}
-		case promql.ErrQueryTimeout:
-			return nil, &apiError{errorTimeout, res.Err}
-		}
-		return nil, &apiError{errorExec, res.Err}
+		return failedQueryData(r, qry), queryExecError(res.Err)
 	}
 	return &queryData{
 		ResultType: res.Value.Type(),
 		Result:     res.Value,
+		Stats:      queryStats(r, qry),
+		warnings:   res.Warnings,
 	}, nil
 }
 
+// cachedQueryRange evaluates the range query from the last cached evaluation
+// on. The statistics only cover the evaluations that were not cached.
+// Incomplete results are not cached.
+func (api *API) cachedQueryRange(ctx context.Context, r *http.Request, start, end model.Time, step time.Duration) (interface{}, *apiError) {
+	var (
+		k             = queryCacheKey{expr: r.FormValue("query"), start: start, step: step}
+		cached, found = api.cache.get(k)
+		from          = start
+	)
+	if found {
+		if !cached.until.Before(end) {
+			return &queryData{
+				ResultType: model.ValMatrix,
+				Result:     truncateMatrix(cached.matrix, end),
+				warnings:   cached.warnings,
+			}, nil
+		}
+		from = cached.until.Add(step)
+	}
+
+	qry, err := api.QueryEngine.NewRangeQuery(k.expr, from, end, step)
+	if err != nil {
+		return nil, &apiError{errorBadData, err}
+	}
+	res := qry.Exec(ctx)
+	if res.Err != nil {
+		return failedQueryData(r, qry), queryExecError(res.Err)
+	}
+	result := res.Value.(model.Matrix)
+	if found {
+		result = mergeMatrices(cached.matrix, result)
+	}
+	if !res.Incomplete {
+		api.cache.put(k, result, res.Warnings, end, api.now())
+	}
+
+	return &queryData{
+		ResultType: model.ValMatrix,
+		Result:     result,
+		Stats:      queryStats(r, qry),
+		warnings:   res.Warnings,
+	}, nil
+}
+
+func queryExecError(err error) *apiError {
+	switch err.(type) {
+	case promql.ErrQueryCanceled:
+		return &apiError{errorCanceled, err}
+	case promql.ErrQueryTimeout:
+		return &apiError{errorTimeout, err}
+	}
+	return &apiError{errorExec, err}
+}
+
+// queryStats returns the statistics of the query if they are requested by
+// the stats parameter.
+func queryStats(r *http.Request, qry promql.Query) *stats.QueryStats {
+	if r.FormValue("stats") == "" {
+		return nil
+	}
+	return stats.NewQueryStats(qry.Stats(), qry.SampleStats(), qry.Diagnostics())
+}
+
+// failedQueryData returns the statistics of a failed query along with its
+// error if they are requested and hold diagnostics of the failure.
+func failedQueryData(r *http.Request, qry promql.Query) interface{} {
+	if s := queryStats(r, qry); s != nil && len(s.Diagnostics) > 0 {
+		return &queryData{Stats: s}
+	}
+	return nil
+}
+
+func (api *API) labelNames(r *http.Request) (interface{}, *apiError) {
+	start, end, matcherSets, apiErr := parseSelection(r)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	q, err := api.Storage.Querier()
+	if err != nil {
+		return nil, &apiError{errorExec, err}
+	}
+	defer q.Close()
+
+	// Without any restriction, the label names can be read from the index
+	// directly.
+	if len(matcherSets) == 0 && start == model.Earliest && end == model.Latest {
+		names, err := q.LabelNames(r.Context())
+		if err != nil {
+			return nil, &apiError{errorExec, err}
+		}
+		sort.Sort(names)
+		return names, nil
+	}
+	if len(matcherSets) == 0 {
+		matcherSets = []metric.LabelMatchers{{anyValueMatcher(model.MetricNameLabel)}}
+	}
+
+	names, err := q.LabelNamesForLabelMatchers(r.Context(), start, end, matcherSets...)
+	if err != nil {
+		return nil, &apiError{errorExec, err}
+	}
+	if names == nil {
+		names = model.LabelNames{}
+	}
+	sort.Sort(names)
+
+	return names, nil
+}
+
 func (api *API) labelValues(r *http.Request) (interface{}, *apiError) {
 	name := route.Param(r.Context(), "name")
 
 	if !model.LabelNameRE.MatchString(name) {
 		return nil, &apiError{errorBadData, fmt.Errorf("invalid label name: %q", name)}
 	}
+	ln := model.LabelName(name)
+
+	start, end, matcherSets, apiErr := parseSelection(r)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
 	q, err := api.Storage.Querier()
 	if err != nil {
 		return nil, &apiError{errorExec, err}
 	}
 	defer q.Close()
 
-	vals, err := q.LabelValuesForLabelName(r.Context(), model.LabelName(name))
+	// Without any restriction, the label values can be read from the index
+	// directly.
+	if len(matcherSets) == 0 && start == model.Earliest && end == model.Latest {
+		vals, err := q.LabelValuesForLabelName(r.Context(), ln)
+		if err != nil {
+			return nil, &apiError{errorExec, err}
+		}
+		sort.Sort(vals)
+		return vals, nil
+	}
+	if len(matcherSets) == 0 {
+		matcherSets = []metric.LabelMatchers{{anyValueMatcher(ln)}}
+	}
+
+	vals, err := q.LabelValuesForLabelMatchers(r.Context(), start, end, ln, matcherSets...)
 	if err != nil {
 		return nil, &apiError{errorExec, err}
 	}
+	if vals == nil {
+		vals = model.LabelValues{}
+	}
 	sort.Sort(vals)
 
 	return vals, nil
 }
 
-func (api *API) series(r *http.Request) (interface{}, *apiError) {
-	r.ParseForm()
-	if len(r.Form["match[]"]) == 0 {
-		return nil, &apiError{errorBadData, fmt.Errorf("no match[] parameter provided")}
+// anyValueMatcher returns a matcher selecting all series that have the given
+// label name.
+func anyValueMatcher(ln model.LabelName) *metric.LabelMatcher {
+	m, err := metric.NewLabelMatcher(metric.RegexMatch, ln, ".+")
+	if err != nil {
+		panic(err)
 	}
+	return m
+}
 
-	var start model.Time
+// parseSelection parses the optional start, end and match[] parameters
+// restricting the series considered by a request. The start and end times
+// default to model.Earliest and model.Latest, respectively.
+func parseSelection(r *http.Request) (model.Time, model.Time, []metric.LabelMatchers, *apiError) {
+	r.ParseForm()
+
+	start, end := model.Earliest, model.Latest
 	if t := r.FormValue("start"); t != "" {
 		var err error
 		start, err = parseTime(t)
 		if err != nil {
-			return nil, &apiError{errorBadData, err}
+			return 0, 0, nil, &apiError{errorBadData, err}
 		}
-	} else {
-		start = model.Earliest
 	}
-
-	var end model.Time
 	if t := r.FormValue("end"); t != "" {
 		var err error
 		end, err = parseTime(t)
 		if err != nil {
-			return nil, &apiError{errorBadData, err}
+			return 0, 0, nil, &apiError{errorBadData, err}
 		}
-	} else {
-		end = model.Latest
 	}
 
 	var matcherSets []metric.LabelMatchers
 	for _, s := range r.Form["match[]"] {
 		matchers, err := promql.ParseMetricSelector(s)
 		if err != nil {
-			return nil, &apiError{errorBadData, err}
+			return 0, 0, nil, &apiError{errorBadData, err}
 		}
 		matcherSets = append(matcherSets, matchers)
 	}
+	return start, end, matcherSets, nil
+}
+
+func (api *API) series(r *http.Request) (interface{}, *apiError) {
+	r.ParseForm()
+	if len(r.Form["match[]"]) == 0 {
+		return nil, &apiError{errorBadData, fmt.Errorf("no match[] parameter provided")}
+	}
+
+	start, end, matcherSets, apiErr := parseSelection(r)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	limit := 0
+	if l := r.FormValue("limit"); l != "" {
+		var err error
+		limit, err = strconv.Atoi(l)
+		if err != nil || limit < 0 {
+			return nil, &apiError{errorBadData, fmt.Errorf("cannot parse %q to a valid limit", l)}
+		}
+	}
 
 	q, err := api.Storage.Querier()
 	if err != nil {
 		return nil, &apiError{errorExec, err}
 	}
-	defer q.Close()
+	return &seriesData{
+		ctx:         r.Context(),
+		querier:     q,
+		start:       start,
+		end:         end,
+		matcherSets: matcherSets,
+		limit:       limit,
+	}, nil
+}
+
+// seriesData is the data of a series response. Series lists can be huge, so
+// the matching series are encoded one by one as they are looked up instead of
+// being collected first.
+type seriesData struct {
+	ctx         context.Context
+	querier     local.Querier
+	start, end  model.Time
+	matcherSets []metric.LabelMatchers
+	// The maximum number of series to return, 0 for no limit.
+	limit int
+}
+
+// stream implements streamedData. It closes the querier.
+func (d *seriesData) stream(w io.Writer) error {
+	defer d.querier.Close()
 
-	res, err := q.MetricsForLabelMatchers(r.Context(), start, end, matcherSets...)
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+	var (
+		n      int
+		encErr error
+	)
+	err := d.querier.ForEachMetricForLabelMatchers(d.ctx, d.start, d.end, func(m metric.Metric) bool {
+		b, err := json.Marshal(m.Metric)
+		if err != nil {
+			encErr = err
+			return false
+		}
+		if n > 0 {
+			b = append([]byte{','}, b...)
+		}
+		if _, err := w.Write(b); err != nil {
+			encErr = err
+			return false
+		}
+		n++
+		return d.limit == 0 || n < d.limit
+	}, d.matcherSets...)
 	if err != nil {
-		return nil, &apiError{errorExec, err}
+		return err
 	}
-
-	metrics := make([]model.Metric, 0, len(res))
-	for _, met := range res {
-		metrics = append(metrics, met.Metric)
+	if encErr != nil {
+		return encErr
 	}
-	return metrics, nil
+	_, err = io.WriteString(w, "]")
+	return err
 }
 
 func (api *API) dropSeries(r *http.Request) (interface{}, *apiError) {
@@ -370,6 +951,8 @@ func (api *API) dropSeries(r *http.Request) (interface{}, *apiError) {
 		}
 		numDeleted += n
 	}
+	// Cached results may contain the dropped series.
+	api.cache.reset()
 
 	res := struct {
 		NumDeleted int `json:"numDeleted"`
@@ -386,11 +969,14 @@ type Target struct {
 	// Any labels that are added to this target and its metrics.
 	Labels model.LabelSet `json:"labels"`
 
-	ScrapeURL string `json:"scrapeUrl"`
+	ScrapePool string `json:"scrapePool"`
+	ScrapeURL  string `json:"scrapeUrl"`
 
 	LastError  string                 `json:"lastError"`
 	LastScrape time.Time              `json:"lastScrape"`
 	Health     retrieval.TargetHealth `json:"health"`
+	// The number of series of the last scrape not in the previous one.
+	SeriesAdded int `json:"seriesAdded"`
 }
 
 // TargetDiscovery has all the active targets.
@@ -398,27 +984,125 @@ type TargetDiscovery struct {
 	ActiveTargets []*Target `json:"activeTargets"`
 }
 
+// targets returns the active targets, optionally restricted to those of a
+// single scrape pool and those in the given health state.
 func (api *API) targets(r *http.Request) (interface{}, *apiError) {
-	targets := api.targetRetriever.Targets()
-	res := &TargetDiscovery{ActiveTargets: make([]*Target, len(targets))}
+	var health retrieval.TargetHealth
+	switch state := r.FormValue("state"); state {
+	case "", "any":
+	case string(retrieval.HealthGood), string(retrieval.HealthBad), string(retrieval.HealthUnknown):
+		health = retrieval.TargetHealth(state)
+	default:
+		return nil, &apiError{errorBadData, fmt.Errorf("invalid target state %q", state)}
+	}
+
+	pools := api.targetRetriever.TargetsActive()
+	if name := r.FormValue("scrapePool"); name != "" {
+		pools = map[string][]*retrieval.Target{name: pools[name]}
+	}
+
+	res := &TargetDiscovery{ActiveTargets: []*Target{}}
+	for pool, targets := range pools {
+		for _, t := range targets {
+			if health != "" && t.Health() != health {
+				continue
+			}
 
-	for i, t := range targets {
-		lastErrStr := ""
-		lastErr := t.LastError()
-		if lastErr != nil {
-			lastErrStr = lastErr.Error()
+			lastErrStr := ""
+			lastErr := t.LastError()
+			if lastErr != nil {
+				lastErrStr = lastErr.Error()
+			}
+
+			res.ActiveTargets = append(res.ActiveTargets, &Target{
+				DiscoveredLabels: t.DiscoveredLabels(),
+				Labels:           t.Labels(),
+				ScrapePool:       pool,
+				ScrapeURL:        t.URL().String(),
+				LastError:        lastErrStr,
+				LastScrape:       t.LastScrape(),
+				Health:           t.Health(),
+				SeriesAdded:      t.SeriesAdded(),
+			})
 		}
+	}
 
-		res.ActiveTargets[i] = &Target{
-			DiscoveredLabels: t.DiscoveredLabels(),
-			Labels:           t.Labels(),
-			ScrapeURL:        t.URL().String(),
-			LastError:        lastErrStr,
-			LastScrape:       t.LastScrape(),
-			Health:           t.Health(),
+	sort.Slice(res.ActiveTargets, func(i, j int) bool {
+		a, b := res.ActiveTargets[i], res.ActiveTargets[j]
+		if a.ScrapePool != b.ScrapePool {
+			return a.ScrapePool < b.ScrapePool
 		}
+		return a.ScrapeURL < b.ScrapeURL
+	})
+
+	return res, nil
+}
+
+// MetricMetadata is the metadata of a metric family as exposed by targets.
+type MetricMetadata struct {
+	Type string `json:"type"`
+	Help string `json:"help"`
+	Unit string `json:"unit"`
+}
+
+// metricMetadata returns the distinct metadata of the metric families exposed
+// by all active targets, by metric name. The metric and limit parameters
+// select a single metric and the maximum number of metrics, in the order of
+// their names.
+func (api *API) metricMetadata(r *http.Request) (interface{}, *apiError) {
+	limit := -1
+	if s := r.FormValue("limit"); s != "" {
+		var err error
+		if limit, err = strconv.Atoi(s); err != nil {
+			return nil, &apiError{errorBadData, fmt.Errorf("invalid limit %q: %s", s, err)}
+		}
+	}
+	metric := r.FormValue("metric")
+
+	distinct := map[string]map[MetricMetadata]struct{}{}
+	for _, targets := range api.targetRetriever.TargetsActive() {
+		for _, t := range targets {
+			for _, md := range t.Metadata() {
+				if metric != "" && md.Metric != metric {
+					continue
+				}
+				m, ok := distinct[md.Metric]
+				if !ok {
+					m = map[MetricMetadata]struct{}{}
+					distinct[md.Metric] = m
+				}
+				m[MetricMetadata{Type: md.Type, Help: md.Help, Unit: md.Unit}] = struct{}{}
+			}
+		}
+	}
+
+	names := make([]string, 0, len(distinct))
+	for name := range distinct {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if limit >= 0 && limit < len(names) {
+		names = names[:limit]
 	}
 
+	res := make(map[string][]MetricMetadata, len(names))
+	for _, name := range names {
+		mds := make([]MetricMetadata, 0, len(distinct[name]))
+		for md := range distinct[name] {
+			mds = append(mds, md)
+		}
+		sort.Slice(mds, func(i, j int) bool {
+			a, b := mds[i], mds[j]
+			if a.Type != b.Type {
+				return a.Type < b.Type
+			}
+			if a.Help != b.Help {
+				return a.Help < b.Help
+			}
+			return a.Unit < b.Unit
+		})
+		res[name] = mds
+	}
 	return res, nil
 }
 
@@ -443,18 +1127,195 @@ func (api *API) alertmanagers(r *http.Request) (interface{}, *apiError) {
 	return ams, nil
 }
 
+// RuleGroupEvaluation is the result of an on-demand evaluation of a rule group.
+type RuleGroupEvaluation struct {
+	Group string        `json:"group"`
+	Rules []*RuleResult `json:"rules"`
+}
+
+// RuleResult has the result or the error of the evaluation of one rule.
+type RuleResult struct {
+	Name   string       `json:"name"`
+	Result model.Vector `json:"result"`
+	Error  string       `json:"error,omitempty"`
+}
+
+func (api *API) evaluateRuleGroup(r *http.Request) (interface{}, *apiError) {
+	name := route.Param(r.Context(), "group")
+	if api.ruleGroupEvaluator == nil {
+		return nil, &apiError{errorUnavailable, errors.New("rule evaluation is not available")}
+	}
+
+	results, err := api.ruleGroupEvaluator.EvalGroup(name)
+	switch err {
+	case nil:
+	case rules.ErrGroupNotFound:
+		return nil, &apiError{errorNotFound, fmt.Errorf("rule group %q not found", name)}
+	case rules.ErrNotRunning:
+		return nil, &apiError{errorUnavailable, err}
+	default:
+		return nil, &apiError{errorInternal, err}
+	}
+
+	res := &RuleGroupEvaluation{Group: name, Rules: make([]*RuleResult, 0, len(results))}
+	for _, rr := range results {
+		rres := &RuleResult{Name: rr.Rule.Name(), Result: rr.Vector}
+		if rres.Result == nil {
+			rres.Result = model.Vector{}
+		}
+		if rr.Err != nil {
+			rres.Error = rr.Err.Error()
+		}
+		res.Rules = append(res.Rules, rres)
+	}
+	return res, nil
+}
+
 type prometheusConfig struct {
-	YAML string `json:"yaml"`
+	YAML              string        `json:"yaml"`
+	LastReload        *ReloadResult `json:"lastReload,omitempty"`
+	LastReloadSuccess *ReloadResult `json:"lastReloadSuccess,omitempty"`
 }
 
 func (api *API) serveConfig(r *http.Request) (interface{}, *apiError) {
 	cfg := &prometheusConfig{
 		YAML: api.config().String(),
 	}
+	if api.reloadResults != nil {
+		cfg.LastReload, cfg.LastReloadSuccess = api.reloadResults()
+	}
 	return cfg, nil
 }
 
+func (api *API) serveBuildInfo(r *http.Request) (interface{}, *apiError) {
+	return api.buildInfo, nil
+}
+
+func (api *API) serveRuntimeInfo(r *http.Request) (interface{}, *apiError) {
+	info, err := api.runtimeInfo()
+	if err != nil {
+		return nil, &apiError{errorInternal, err}
+	}
+	return info, nil
+}
+
+func (api *API) serveFlags(r *http.Request) (interface{}, *apiError) {
+	return api.flagsMap, nil
+}
+
+// RemoteStorageStatus has info about the remote write queues.
+type RemoteStorageStatus struct {
+	Queues []remote.QueueStatus `json:"queues"`
+}
+
+func (api *API) serveRemoteStorage(r *http.Request) (interface{}, *apiError) {
+	if api.remoteWriteRetriever == nil {
+		return nil, &apiError{errorUnavailable, fmt.Errorf("remote write is not available")}
+	}
+	return &RemoteStorageStatus{Queues: api.remoteWriteRetriever.QueueStatus()}, nil
+}
+
+// defaultStatsLimit is the default number of entries per list returned by the
+// storage stats endpoint.
+const defaultStatsLimit = 10
+
+// seriesStatser is implemented by storages that can report statistics about
+// the series they hold in memory.
+type seriesStatser interface {
+	SeriesStats(limit int) *local.SeriesStats
+}
+
+func (api *API) serveStorageStats(r *http.Request) (interface{}, *apiError) {
+	limit := defaultStatsLimit
+	if s := r.FormValue("limit"); s != "" {
+		var err error
+		if limit, err = strconv.Atoi(s); err != nil || limit <= 0 {
+			return nil, &apiError{errorBadData, fmt.Errorf("limit must be a positive number")}
+		}
+	}
+
+	st, ok := api.Storage.(seriesStatser)
+	if !ok {
+		return nil, &apiError{errorUnavailable, fmt.Errorf("storage does not provide series statistics")}
+	}
+	return st.SeriesStats(limit), nil
+}
+
+// blockStorage is implemented by storages that can export and import blocks
+// of series.
+type blockStorage interface {
+	ExportBlock(ctx context.Context, w io.Writer, from, through model.Time, matcherSets ...metric.LabelMatchers) error
+	ImportBlock(ctx context.Context, dir string) (int, error)
+}
+
+// BlockImport is the result of a block import.
+type BlockImport struct {
+	// The number of imported series.
+	Series int `json:"series"`
+}
+
+func (api *API) exportBlock(w http.ResponseWriter, r *http.Request) {
+	setCORS(w, api.corsOrigin, r)
+	if !api.enableAdmin {
+		respondError(w, &apiError{errorUnavailable, errAdminDisabled}, nil)
+		return
+	}
+	if api.forwardOnly {
+		respondError(w, &apiError{errorUnavailable, errForwardOnly}, nil)
+		return
+	}
+	r.ParseForm()
+	if len(r.Form["match[]"]) == 0 {
+		respondError(w, &apiError{errorBadData, fmt.Errorf("no match[] parameter provided")}, nil)
+		return
+	}
+	start, end, matcherSets, apiErr := parseSelection(r)
+	if apiErr != nil {
+		respondError(w, apiErr, nil)
+		return
+	}
+	bs, ok := api.Storage.(blockStorage)
+	if !ok {
+		respondError(w, &apiError{errorUnavailable, errNoBlockStorage}, nil)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-tar")
+	w.Header().Set("Content-Disposition", `attachment; filename="block.tar"`)
+	if err := bs.ExportBlock(r.Context(), w, start, end, matcherSets...); err != nil {
+		// The archive may be partially written already, so the client
+		// can only tell from its truncation.
+		log.Errorf("Error exporting block: %s", err)
+	}
+}
+
+func (api *API) importBlock(r *http.Request) (interface{}, *apiError) {
+	dir := r.FormValue("path")
+	if dir == "" {
+		return nil, &apiError{errorBadData, fmt.Errorf("no path parameter provided")}
+	}
+	bs, ok := api.Storage.(blockStorage)
+	if !ok {
+		return nil, &apiError{errorUnavailable, errNoBlockStorage}
+	}
+
+	n, err := bs.ImportBlock(r.Context(), dir)
+	if n > 0 {
+		// Cached results may lack the imported series.
+		api.cache.reset()
+	}
+	if err != nil {
+		return &BlockImport{Series: n}, &apiError{errorExec, err}
+	}
+	return &BlockImport{Series: n}, nil
+}
+
 func (api *API) remoteRead(w http.ResponseWriter, r *http.Request) {
+	if api.forwardOnly {
+		http.Error(w, errForwardOnly.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
 	req, err := remote.DecodeReadRequest(r)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
@@ -477,16 +1338,24 @@ func (api *API) remoteRead(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
+		if query.MaxResolutionMs < 0 {
+			http.Error(w, "negative max resolution", http.StatusBadRequest)
+			return
+		}
 		iters, err := querier.QueryRange(r.Context(), from, through, matchers...)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 
-		resp.Results[i] = remote.ToQueryResult(remote.IteratorsToMatrix(iters, metric.Interval{
+		matrix := remote.IteratorsToMatrix(iters, metric.Interval{
 			OldestInclusive: from,
 			NewestInclusive: through,
-		}))
+		})
+		if query.MaxResolutionMs > 0 {
+			matrix = remote.Downsample(matrix, time.Duration(query.MaxResolutionMs)*time.Millisecond)
+		}
+		resp.Results[i] = remote.ToQueryResult(matrix)
 	}
 
 	if err := remote.EncodeReadResponse(&resp, w); err != nil {
@@ -499,16 +1368,90 @@ func respond(w http.ResponseWriter, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 
-	b, err := json.Marshal(&response{
+	if sd, ok := data.(streamedData); ok {
+		respondStreamed(w, sd)
+		return
+	}
+
+	resp := &response{
 		Status: statusSuccess,
 		Data:   data,
-	})
+	}
+	if qd, ok := data.(*queryData); ok {
+		resp.Warnings = qd.warnings
+	}
+	b, err := json.Marshal(resp)
 	if err != nil {
 		return
 	}
 	w.Write(b)
 }
 
+// protobufData returns the protobuf encoding of the data of a response, if
+// the data can be encoded as protobuf. Only query results without statistics
+// and warnings are, as the message cannot hold them.
+func protobufData(data interface{}) (proto.Message, bool) {
+	qd, ok := data.(*queryData)
+	if !ok || qd.Stats != nil || len(qd.warnings) > 0 {
+		return nil, false
+	}
+	m, ok := qd.Result.(model.Matrix)
+	if !ok {
+		return nil, false
+	}
+	return remote.ToQueryResult(m), true
+}
+
+// acceptsProtobuf returns whether the client accepts protobuf responses, that
+// is whether it lists them in the Accept header with a non-zero quality.
+func acceptsProtobuf(r *http.Request) bool {
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(accept)
+		if err != nil || mediaType != protobufContentType {
+			continue
+		}
+		q, err := strconv.ParseFloat(params["q"], 64)
+		if err != nil {
+			q = 1
+		}
+		return q > 0
+	}
+	return false
+}
+
+func respondProtobuf(w http.ResponseWriter, m proto.Message) {
+	b, err := proto.Marshal(m)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", protobufContentType+"; proto=remote.QueryResult")
+	w.WriteHeader(http.StatusOK)
+	w.Write(b)
+}
+
+// streamedData is implemented by response data that encodes itself
+// incrementally, so that large responses are not buffered as a whole.
+type streamedData interface {
+	// stream writes the JSON encoding of the data to w.
+	stream(w io.Writer) error
+}
+
+// respondStreamed writes a success response with the streamed data. As the
+// status is sent first, errors while streaming can only be logged and leave
+// the response incomplete.
+func respondStreamed(w io.Writer, sd streamedData) {
+	if _, err := io.WriteString(w, `{"status":"`+string(statusSuccess)+`","data":`); err != nil {
+		return
+	}
+	if err := sd.stream(w); err != nil {
+		log.Errorf("Error streaming API response: %s", err)
+		return
+	}
+	io.WriteString(w, "}")
+}
+
 func respondError(w http.ResponseWriter, apiErr *apiError, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 
@@ -518,10 +1461,12 @@ func respondError(w http.ResponseWriter, apiErr *apiError, data interface{}) {
 		code = http.StatusBadRequest
 	case errorExec:
 		code = 422
-	case errorCanceled, errorTimeout:
+	case errorCanceled, errorTimeout, errorUnavailable:
 		code = http.StatusServiceUnavailable
 	case errorInternal:
 		code = http.StatusInternalServerError
+	case errorNotFound:
+		code = http.StatusNotFound
 	default:
 		code = http.StatusInternalServerError
 	}
@@ -14,17 +14,26 @@
 package v1
 
 import (
+	"archive/tar"
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
+	"path/filepath"
 	"reflect"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/snappy"
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/common/route"
 	"golang.org/x/net/context"
@@ -32,11 +41,15 @@ import (
 	"github.com/prometheus/prometheus/config"
 	"github.com/prometheus/prometheus/promql"
 	"github.com/prometheus/prometheus/retrieval"
+	"github.com/prometheus/prometheus/rules"
+	"github.com/prometheus/prometheus/storage/local"
+	"github.com/prometheus/prometheus/storage/metric"
+	"github.com/prometheus/prometheus/storage/remote"
 )
 
-type targetRetrieverFunc func() []*retrieval.Target
+type targetRetrieverFunc func() map[string][]*retrieval.Target
 
-func (f targetRetrieverFunc) Targets() []*retrieval.Target {
+func (f targetRetrieverFunc) TargetsActive() map[string][]*retrieval.Target {
 	return f()
 }
 
@@ -46,6 +59,12 @@ func (f alertmanagerRetrieverFunc) Alertmanagers() []*url.URL {
 	return f()
 }
 
+type remoteWriteRetrieverFunc func() []remote.QueueStatus
+
+func (f remoteWriteRetrieverFunc) QueueStatus() []remote.QueueStatus {
+	return f()
+}
+
 var samplePrometheusCfg = config.Config{
 	GlobalConfig:       config.GlobalConfig{},
 	AlertingConfig:     config.AlertingConfig{},
@@ -55,6 +74,66 @@ var samplePrometheusCfg = config.Config{
 	RemoteReadConfigs:  []*config.RemoteReadConfig{},
 }
 
+var sampleQueueStatus = []remote.QueueStatus{
+	{
+		Name:             "1:http://remote.example.com/write",
+		URL:              "http://remote.example.com/write",
+		Shards:           2,
+		Capacity:         100000,
+		PendingSamples:   10,
+		SucceededSamples: 1000,
+		Retries:          1,
+		LastError:        "server returned HTTP status 503 Service Unavailable",
+	},
+}
+
+var sampleFlagMap = map[string]string{
+	"flag1": "value1",
+	"flag2": "value2",
+}
+
+var sampleBuildInfo = &PrometheusVersion{
+	Version:   "1.7.2",
+	Revision:  "abcdef",
+	Branch:    "master",
+	BuildUser: "user@host",
+	BuildDate: "20170101-00:00:00",
+	GoVersion: "go1.8",
+}
+
+var sampleRuntimeInfo = RuntimeInfo{
+	StartTime:        time.Unix(0, 0).UTC(),
+	CWD:              "/prometheus",
+	GoroutineCount:   10,
+	GOMAXPROCS:       4,
+	GOGC:             "40",
+	GOMEMLIMIT:       "3865470566",
+	StoragePath:      "data",
+	StorageRetention: "360h0m0s",
+	CheckpointSize:   1024,
+}
+
+var (
+	sampleReload = &ReloadResult{
+		Time:    time.Unix(0, 0).UTC(),
+		Success: true,
+		ScrapePools: []ReloadedComponent{
+			{Name: "prometheus", Action: ReloadRestarted, Reason: "scrape config changed"},
+		},
+		RuleGroups: []ReloadedComponent{
+			{Name: "default", Action: ReloadRestarted, Reason: "rule files changed"},
+		},
+		RemoteWriteQueues: []ReloadedComponent{},
+	}
+	sampleFailedReload = &ReloadResult{
+		Time:              time.Unix(60, 0).UTC(),
+		Error:             "couldn't load configuration",
+		ScrapePools:       []ReloadedComponent{},
+		RuleGroups:        []ReloadedComponent{},
+		RemoteWriteQueues: []ReloadedComponent{},
+	}
+)
+
 func TestEndpoints(t *testing.T) {
 	suite, err := promql.NewTest(t, `
 		load 1m
@@ -73,17 +152,30 @@ func TestEndpoints(t *testing.T) {
 
 	now := model.Now()
 
-	tr := targetRetrieverFunc(func() []*retrieval.Target {
-		return []*retrieval.Target{
-			retrieval.NewTarget(
-				model.LabelSet{
-					model.SchemeLabel:      "http",
-					model.AddressLabel:     "example.com:8080",
-					model.MetricsPathLabel: "/metrics",
-				},
-				model.LabelSet{},
-				url.Values{},
-			),
+	tr := targetRetrieverFunc(func() map[string][]*retrieval.Target {
+		return map[string][]*retrieval.Target{
+			"test": {
+				retrieval.NewTarget(
+					model.LabelSet{
+						model.SchemeLabel:      "http",
+						model.AddressLabel:     "example.com:8080",
+						model.MetricsPathLabel: "/metrics",
+					},
+					model.LabelSet{},
+					url.Values{},
+				),
+			},
+			"blackbox": {
+				retrieval.NewTarget(
+					model.LabelSet{
+						model.SchemeLabel:      "http",
+						model.AddressLabel:     "localhost:9115",
+						model.MetricsPathLabel: "/probe",
+					},
+					model.LabelSet{},
+					url.Values{},
+				),
+			},
 		}
 	})
 
@@ -94,16 +186,28 @@ func TestEndpoints(t *testing.T) {
 			Path:   "/api/v1/alerts",
 		}}
 	})
+	rwr := remoteWriteRetrieverFunc(func() []remote.QueueStatus {
+		return sampleQueueStatus
+	})
 
 	api := &API{
 		Storage:               suite.Storage(),
 		QueryEngine:           suite.QueryEngine(),
 		targetRetriever:       tr,
 		alertmanagerRetriever: ar,
+		remoteWriteRetriever:  rwr,
 		now: func() model.Time { return now },
 		config: func() config.Config {
 			return samplePrometheusCfg
 		},
+		flagsMap:  sampleFlagMap,
+		buildInfo: sampleBuildInfo,
+		runtimeInfo: func() (RuntimeInfo, error) {
+			return sampleRuntimeInfo, nil
+		},
+		reloadResults: func() (last, lastSuccess *ReloadResult) {
+			return sampleFailedReload, sampleReload
+		},
 	}
 
 	start := model.Time(0)
@@ -299,6 +403,65 @@ func TestEndpoints(t *testing.T) {
 			},
 			errType: errorBadData,
 		},
+		{
+			endpoint: api.labelValues,
+			params: map[string]string{
+				"name": "foo",
+			},
+			query: url.Values{
+				"match[]": []string{`test_metric2`},
+			},
+			response: model.LabelValues{
+				"boo",
+			},
+		},
+		{
+			endpoint: api.labelValues,
+			params: map[string]string{
+				"name": "foo",
+			},
+			query: url.Values{
+				"start": []string{"100000"},
+			},
+			response: model.LabelValues{},
+		},
+		{
+			endpoint: api.labelValues,
+			params: map[string]string{
+				"name": "foo",
+			},
+			query: url.Values{
+				"match[]": []string{`test_metric1`},
+				"end":     []string{"invalid"},
+			},
+			errType: errorBadData,
+		},
+		{
+			endpoint: api.labelNames,
+			response: model.LabelNames{
+				"__name__",
+				"foo",
+			},
+		},
+		{
+			endpoint: api.labelNames,
+			query: url.Values{
+				"match[]": []string{`test_metric2`},
+				"start":   []string{"0"},
+				"end":     []string{"60"},
+			},
+			response: model.LabelNames{
+				"__name__",
+				"foo",
+			},
+		},
+		{
+			endpoint: api.labelNames,
+			query: url.Values{
+				"match[]": []string{`test_metric1{`},
+			},
+			errType: errorBadData,
+		},
 		{
 			endpoint: api.series,
 			query: url.Values{
@@ -311,6 +474,27 @@ func TestEndpoints(t *testing.T) {
 				},
 			},
 		},
+		{
+			endpoint: api.series,
+			query: url.Values{
+				"match[]": []string{`test_metric2`},
+				"limit":   []string{"1"},
+			},
+			response: []model.Metric{
+				{
+					"__name__": "test_metric2",
+					"foo":      "boo",
+				},
+			},
+		},
+		{
+			endpoint: api.series,
+			query: url.Values{
+				"match[]": []string{`test_metric2`},
+				"limit":   []string{"-1"},
+			},
+			errType: errorBadData,
+		},
 		{
 			endpoint: api.series,
 			query: url.Values{
@@ -475,12 +659,63 @@ func TestEndpoints(t *testing.T) {
 					{
 						DiscoveredLabels: model.LabelSet{},
 						Labels:           model.LabelSet{},
+						ScrapePool:       "blackbox",
+						ScrapeURL:        "http://localhost:9115/probe",
+						Health:           "unknown",
+					},
+					{
+						DiscoveredLabels: model.LabelSet{},
+						Labels:           model.LabelSet{},
+						ScrapePool:       "test",
+						ScrapeURL:        "http://example.com:8080/metrics",
+						Health:           "unknown",
+					},
+				},
+			},
+		},
+		{
+			endpoint: api.targets,
+			query: url.Values{
+				"scrapePool": []string{"test"},
+				"state":      []string{"unknown"},
+			},
+			response: &TargetDiscovery{
+				ActiveTargets: []*Target{
+					{
+						DiscoveredLabels: model.LabelSet{},
+						Labels:           model.LabelSet{},
+						ScrapePool:       "test",
 						ScrapeURL:        "http://example.com:8080/metrics",
 						Health:           "unknown",
 					},
 				},
 			},
 		},
+		{
+			endpoint: api.targets,
+			query: url.Values{
+				"state": []string{"down"},
+			},
+			response: &TargetDiscovery{
+				ActiveTargets: []*Target{},
+			},
+		},
+		{
+			endpoint: api.targets,
+			query: url.Values{
+				"scrapePool": []string{"nonexistent"},
+			},
+			response: &TargetDiscovery{
+				ActiveTargets: []*Target{},
+			},
+		},
+		{
+			endpoint: api.targets,
+			query: url.Values{
+				"state": []string{"broken"},
+			},
+			errType: errorBadData,
+		},
 		{
 			endpoint: api.alertmanagers,
 			response: &AlertmanagerDiscovery{
@@ -494,9 +729,29 @@ func TestEndpoints(t *testing.T) {
 		{
 			endpoint: api.serveConfig,
 			response: &prometheusConfig{
-				YAML: samplePrometheusCfg.String(),
+				YAML:              samplePrometheusCfg.String(),
+				LastReload:        sampleFailedReload,
+				LastReloadSuccess: sampleReload,
+			},
+		},
+		{
+			endpoint: api.serveRemoteStorage,
+			response: &RemoteStorageStatus{
+				Queues: sampleQueueStatus,
 			},
 		},
+		{
+			endpoint: api.serveBuildInfo,
+			response: sampleBuildInfo,
+		},
+		{
+			endpoint: api.serveRuntimeInfo,
+			response: sampleRuntimeInfo,
+		},
+		{
+			endpoint: api.serveFlags,
+			response: sampleFlagMap,
+		},
 	}
 
 	for _, test := range tests {
@@ -523,6 +778,9 @@ func TestEndpoints(t *testing.T) {
 		if apiErr == nil && test.errType != errorNone {
 			t.Fatalf("Expected error of type %q but got none", test.errType)
 		}
+		if sd, ok := resp.(streamedData); ok {
+			resp = streamedMetrics(t, sd)
+		}
 		if !reflect.DeepEqual(resp, test.response) {
 			t.Fatalf("Response does not match, expected:\n%+v\ngot:\n%+v", test.response, resp)
 		}
@@ -568,6 +826,117 @@ func TestRespondSuccess(t *testing.T) {
 	}
 }
 
+func TestRespondWarnings(t *testing.T) {
+	w := httptest.NewRecorder()
+	respond(w, &queryData{
+		ResultType: model.ValScalar,
+		Result:     &model.Scalar{Value: 1, Timestamp: 1000},
+		warnings:   []string{"remote read failed"},
+	})
+
+	var res struct {
+		Status   status   `json:"status"`
+		Warnings []string `json:"warnings"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+		t.Fatalf("Error unmarshaling JSON body: %s", err)
+	}
+	if res.Status != statusSuccess || !reflect.DeepEqual(res.Warnings, []string{"remote read failed"}) {
+		t.Fatalf("Unexpected response %s", w.Body.String())
+	}
+}
+
+// streamedMetrics returns the metrics of streamed series data.
+func streamedMetrics(t *testing.T, sd streamedData) []model.Metric {
+	var buf bytes.Buffer
+	if err := sd.stream(&buf); err != nil {
+		t.Fatalf("Error streaming series: %s", err)
+	}
+	var metrics []model.Metric
+	if err := json.Unmarshal(buf.Bytes(), &metrics); err != nil {
+		t.Fatalf("Error unmarshaling streamed series %q: %s", buf.Bytes(), err)
+	}
+	return metrics
+}
+
+// countingQuerier counts the metrics passed on by
+// ForEachMetricForLabelMatchers.
+type countingQuerier struct {
+	local.Querier
+	count int
+}
+
+func (q *countingQuerier) ForEachMetricForLabelMatchers(ctx context.Context, from, through model.Time, f func(metric.Metric) bool, matcherSets ...metric.LabelMatchers) error {
+	return q.Querier.ForEachMetricForLabelMatchers(ctx, from, through, func(m metric.Metric) bool {
+		q.count++
+		return f(m)
+	}, matcherSets...)
+}
+
+func TestRespondSeries(t *testing.T) {
+	suite, err := promql.NewTest(t, `
+		load 1m
+			test_metric1{foo="bar"} 1
+			test_metric1{foo="boo"} 1
+			test_metric1{foo="baz"} 1
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer suite.Close()
+	if err := suite.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	matchers, err := promql.ParseMetricSelector("test_metric1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, limit := range []int{0, 2} {
+		q, err := suite.Storage().Querier()
+		if err != nil {
+			t.Fatal(err)
+		}
+		cq := &countingQuerier{Querier: q}
+		data := &seriesData{
+			ctx:         context.Background(),
+			querier:     cq,
+			start:       model.Earliest,
+			end:         model.Latest,
+			matcherSets: []metric.LabelMatchers{matchers},
+			limit:       limit,
+		}
+
+		w := httptest.NewRecorder()
+		respond(w, data)
+		if w.Code != 200 {
+			t.Fatalf("Return code %d expected in success response but got %d", 200, w.Code)
+		}
+
+		var res struct {
+			Status status         `json:"status"`
+			Data   []model.Metric `json:"data"`
+		}
+		if err = json.Unmarshal(w.Body.Bytes(), &res); err != nil {
+			t.Fatalf("Error unmarshaling JSON body %q: %s", w.Body.Bytes(), err)
+		}
+		if res.Status != statusSuccess {
+			t.Fatalf("Expected status %q but got %q", statusSuccess, res.Status)
+		}
+		expected := 3
+		if limit > 0 {
+			expected = limit
+		}
+		if len(res.Data) != expected {
+			t.Errorf("Expected %d series with limit %d, got %v", expected, limit, res.Data)
+		}
+		// Series beyond the limit are not looked up.
+		if cq.count != expected {
+			t.Errorf("Expected %d series to be looked up with limit %d, got %d", expected, limit, cq.count)
+		}
+	}
+}
+
 func TestRespondError(t *testing.T) {
 	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		respondError(w, &apiError{errorTimeout, errors.New("message")}, "test")
@@ -749,3 +1118,606 @@ func TestOptionsMethod(t *testing.T) {
 		}
 	}
 }
+
+func TestCORSOrigin(t *testing.T) {
+	tests := []struct {
+		corsOrigin *regexp.Regexp
+		origin     string
+		expected   string
+	}{
+		{
+			corsOrigin: nil,
+			origin:     "https://example.com",
+			expected:   "*",
+		},
+		{
+			corsOrigin: regexp.MustCompile(corsAllowAll),
+			origin:     "https://example.com",
+			expected:   "*",
+		},
+		{
+			corsOrigin: regexp.MustCompile(`^(?:https://(foo|bar)\.com)$`),
+			origin:     "https://foo.com",
+			expected:   "https://foo.com",
+		},
+		{
+			corsOrigin: regexp.MustCompile(`^(?:https://(foo|bar)\.com)$`),
+			origin:     "https://foo.com.evil.com",
+			expected:   "",
+		},
+		{
+			corsOrigin: regexp.MustCompile(`^(?:https://(foo|bar)\.com)$`),
+			origin:     "",
+			expected:   "",
+		},
+	}
+
+	for i, test := range tests {
+		r := route.New()
+		api := &API{corsOrigin: test.corsOrigin}
+		api.Register(r)
+
+		req, err := http.NewRequest("OPTIONS", "/any_path", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if test.origin != "" {
+			req.Header.Set("Origin", test.origin)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if got := w.Header().Get("Access-Control-Allow-Origin"); got != test.expected {
+			t.Errorf("%d. expected Access-Control-Allow-Origin %q, got %q", i, test.expected, got)
+		}
+	}
+}
+
+func TestForwardOnlyMode(t *testing.T) {
+	r := route.New()
+	api := &API{forwardOnly: true}
+	api.Register(r)
+
+	s := httptest.NewServer(r)
+	defer s.Close()
+
+	for _, path := range []string{"/query?query=up", "/query_range", "/label/job/values", "/series?match[]=up"} {
+		resp, err := http.Get(s.URL + path)
+		if err != nil {
+			t.Fatalf("Error on test request: %s", err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusServiceUnavailable {
+			t.Errorf("Expected status %d for %s, got %d", http.StatusServiceUnavailable, path, resp.StatusCode)
+		}
+	}
+}
+
+type ruleGroupEvaluatorFunc func(string) ([]rules.RuleResult, error)
+
+func (f ruleGroupEvaluatorFunc) EvalGroup(name string) ([]rules.RuleResult, error) {
+	return f(name)
+}
+
+func TestEvaluateRuleGroup(t *testing.T) {
+	expr, err := promql.ParseExpr(`sum(up)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rule := rules.NewRecordingRule("job:up:sum", expr, nil)
+	sample := &model.Sample{Metric: model.Metric{"__name__": "job:up:sum"}, Value: 3, Timestamp: 1000}
+
+	evaluator := ruleGroupEvaluatorFunc(func(name string) ([]rules.RuleResult, error) {
+		if name != "default" {
+			return nil, rules.ErrGroupNotFound
+		}
+		return []rules.RuleResult{
+			{Rule: rule, Vector: model.Vector{sample}},
+			{Rule: rule, Err: errors.New("query timed out")},
+		}, nil
+	})
+
+	for _, enableAdmin := range []bool{false, true} {
+		r := route.New()
+		api := &API{ruleGroupEvaluator: evaluator, enableAdmin: enableAdmin}
+		api.Register(r)
+		s := httptest.NewServer(r)
+
+		cases := []struct {
+			group  string
+			status int
+			body   string
+		}{
+			{
+				group:  "default",
+				status: http.StatusOK,
+				body:   `{"status":"success","data":{"group":"default","rules":[{"name":"job:up:sum","result":[{"metric":{"__name__":"job:up:sum"},"value":[1,"3"]}]},{"name":"job:up:sum","result":[],"error":"query timed out"}]}}`,
+			},
+			{
+				group:  "other",
+				status: http.StatusNotFound,
+				body:   `{"status":"error","errorType":"not_found","error":"rule group \"other\" not found"}`,
+			},
+		}
+		for _, c := range cases {
+			resp, err := http.Post(s.URL+"/rules/"+c.group+"/evaluate", "", nil)
+			if err != nil {
+				t.Fatalf("Error on test request: %s", err)
+			}
+			body, err := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !enableAdmin {
+				if resp.StatusCode != http.StatusServiceUnavailable {
+					t.Errorf("Expected status %d with admin APIs disabled, got %d", http.StatusServiceUnavailable, resp.StatusCode)
+				}
+				continue
+			}
+			if resp.StatusCode != c.status {
+				t.Errorf("%s: expected status %d, got %d", c.group, c.status, resp.StatusCode)
+			}
+			if string(body) != c.body {
+				t.Errorf("%s: expected body\n%s\ngot\n%s", c.group, c.body, body)
+			}
+		}
+		s.Close()
+	}
+}
+
+func TestServeStorageStats(t *testing.T) {
+	suite, err := promql.NewTest(t, `
+		load 1m
+			test_metric1{foo="bar"} 0+100x100
+			test_metric1{foo="boo"} 1+0x100
+			test_metric2{foo="boo"} 1+0x100
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer suite.Close()
+
+	if err := suite.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	api := &API{Storage: suite.Storage()}
+
+	req, err := http.NewRequest("GET", "http://example.com?limit=1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, apiErr := api.serveStorageStats(req)
+	if apiErr != nil {
+		t.Fatalf("Unexpected error: %s", apiErr.err)
+	}
+	stats := resp.(*local.SeriesStats)
+	if stats.NumSeries != 3 {
+		t.Errorf("Expected 3 series, got %d", stats.NumSeries)
+	}
+	expected := []local.StatsEntry{{Name: "test_metric1", Value: 2}}
+	if !reflect.DeepEqual(stats.SeriesCountByMetricName, expected) {
+		t.Errorf("Expected %v, got %v", expected, stats.SeriesCountByMetricName)
+	}
+
+	req, err = http.NewRequest("GET", "http://example.com?limit=-1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, apiErr := api.serveStorageStats(req); apiErr == nil || apiErr.typ != errorBadData {
+		t.Errorf("Expected bad data error for negative limit, got %v", apiErr)
+	}
+
+	req, err = http.NewRequest("GET", "http://example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	api = &API{Storage: &local.NoopStorage{}}
+	if _, apiErr := api.serveStorageStats(req); apiErr == nil || apiErr.typ != errorUnavailable {
+		t.Errorf("Expected error for storage without stats")
+	}
+}
+
+func TestExportImportBlock(t *testing.T) {
+	load := func() *promql.Test {
+		suite, err := promql.NewTest(t, `
+			load 1m
+				test_metric1{foo="bar"} 0+100x100
+				test_metric1{foo="boo"} 1+0x100
+				test_metric2{foo="boo"} 1+0x100
+		`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := suite.Run(); err != nil {
+			t.Fatal(err)
+		}
+		return suite
+	}
+	src := load()
+	defer src.Close()
+
+	empty, err := promql.NewTest(t, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer empty.Close()
+
+	dir, err := ioutil.TempDir("", "test_block")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	newServer := func(st local.Storage, enableAdmin bool) *httptest.Server {
+		r := route.New()
+		api := &API{Storage: st, enableAdmin: enableAdmin}
+		api.Register(r)
+		return httptest.NewServer(r)
+	}
+
+	disabled := newServer(src.Storage(), false)
+	defer disabled.Close()
+	for _, req := range []func() (*http.Response, error){
+		func() (*http.Response, error) {
+			return http.Get(disabled.URL + "/admin/storage/export?match[]=test_metric1")
+		},
+		func() (*http.Response, error) {
+			return http.PostForm(disabled.URL+"/admin/storage/import", url.Values{"path": {dir}})
+		},
+	} {
+		resp, err := req()
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusServiceUnavailable {
+			t.Errorf("Expected status %d with admin APIs disabled, got %d", http.StatusServiceUnavailable, resp.StatusCode)
+		}
+	}
+
+	s := newServer(src.Storage(), true)
+	defer s.Close()
+	resp, err := http.Get(s.URL + "/admin/storage/export?match[]=test_metric1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+	tr := tar.NewReader(resp.Body)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := ioutil.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, hdr.Name), b, 0640); err != nil {
+			t.Fatal(err)
+		}
+	}
+	resp.Body.Close()
+
+	d := newServer(empty.Storage(), true)
+	defer d.Close()
+	for _, expected := range []string{
+		`{"status":"success","data":{"series":2}}`,
+		`{"status":"error","data":{"series":0},"errorType":"execution","error":"series test_metric1{foo=\"boo\"} exists already"}`,
+	} {
+		resp, err := http.PostForm(d.URL+"/admin/storage/import", url.Values{"path": {dir}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(body) != expected {
+			t.Errorf("Expected body\n%s\ngot\n%s", expected, body)
+		}
+	}
+
+	q, err := empty.Storage().Querier()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer q.Close()
+	metrics, err := q.MetricsForLabelMatchers(context.Background(), model.Earliest, model.Latest, metric.LabelMatchers{
+		{Type: metric.Equal, Name: model.MetricNameLabel, Value: "test_metric1"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(metrics) != 2 {
+		t.Errorf("Expected 2 imported series, got %d", len(metrics))
+	}
+}
+
+func TestQueryStats(t *testing.T) {
+	suite, err := promql.NewTest(t, `
+		load 1m
+			test_metric1{foo="bar"} 0+100x100
+			test_metric1{foo="boo"} 1+0x100
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer suite.Close()
+
+	if err := suite.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	api := &API{
+		Storage:     suite.Storage(),
+		QueryEngine: suite.QueryEngine(),
+		now:         func() model.Time { return model.TimeFromUnix(120) },
+	}
+
+	for _, c := range []struct {
+		endpoint apiFunc
+		query    url.Values
+		samples  int64
+	}{
+		{
+			endpoint: api.query,
+			query: url.Values{
+				"query": []string{"test_metric1"},
+				"stats": []string{"all"},
+			},
+			samples: 2,
+		},
+		{
+			endpoint: api.queryRange,
+			query: url.Values{
+				"query": []string{"test_metric1"},
+				"start": []string{"0"},
+				"end":   []string{"120"},
+				"step":  []string{"60"},
+				"stats": []string{"all"},
+			},
+			samples: 6,
+		},
+		{
+			endpoint: api.query,
+			query: url.Values{
+				"query": []string{"test_metric1"},
+			},
+		},
+	} {
+		req, err := http.NewRequest("ANY", fmt.Sprintf("http://example.com?%s", c.query.Encode()), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, apiErr := c.endpoint(req)
+		if apiErr != nil {
+			t.Fatalf("Unexpected error: %s", apiErr)
+		}
+
+		qs := resp.(*queryData).Stats
+		if c.query.Get("stats") == "" {
+			if qs != nil {
+				t.Errorf("Expected no stats, got %+v", qs)
+			}
+			continue
+		}
+		if qs == nil {
+			t.Fatalf("Expected stats for %s", c.query.Encode())
+		}
+		if qs.Samples.TotalQueryableSamples != c.samples {
+			t.Errorf("Expected %d samples, got %d", c.samples, qs.Samples.TotalQueryableSamples)
+		}
+		if qs.Timings.EvalTotalTime <= 0 {
+			t.Errorf("Expected positive total eval time, got %f", qs.Timings.EvalTotalTime)
+		}
+	}
+}
+
+func TestQueryMatchDiagnostics(t *testing.T) {
+	suite, err := promql.NewTest(t, `
+		load 1m
+			test_metric1{foo="bar"} 0+100x100
+			test_metric1{foo="boo"} 1+0x100
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer suite.Close()
+
+	if err := suite.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	api := &API{
+		Storage: suite.Storage(),
+		QueryEngine: promql.NewEngine(suite.Storage(), &promql.EngineOptions{
+			MaxConcurrentQueries: 20,
+			Timeout:              time.Minute,
+			MatchDiagnostics:     true,
+		}),
+		now: func() model.Time { return model.TimeFromUnix(120) },
+	}
+
+	for _, stats := range []string{"", "all"} {
+		query := url.Values{
+			"query": []string{"vector(1) + on() test_metric1"},
+			"stats": []string{stats},
+		}
+		req, err := http.NewRequest("ANY", fmt.Sprintf("http://example.com?%s", query.Encode()), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, apiErr := api.query(req)
+		if apiErr == nil {
+			t.Fatal("Expected an error")
+		}
+		if stats == "" {
+			if resp != nil {
+				t.Errorf("Expected no data without stats, got %+v", resp)
+			}
+			continue
+		}
+		d := resp.(*queryData).Stats.Diagnostics
+		if len(d) != 1 || d[0].Side != "right" || len(d[0].Series) != 2 {
+			t.Errorf("Expected a diagnostic of the two series on the right hand-side, got %+v", d)
+		}
+	}
+}
+
+func TestProtobufQueryRange(t *testing.T) {
+	suite, err := promql.NewTest(t, `
+		load 1m
+			test_metric1{foo="bar"} 0+100x100
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer suite.Close()
+
+	if err := suite.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	r := route.New()
+	api := &API{
+		Storage:     suite.Storage(),
+		QueryEngine: suite.QueryEngine(),
+		now:         func() model.Time { return model.TimeFromUnix(120) },
+	}
+	api.Register(r)
+
+	s := httptest.NewServer(r)
+	defer s.Close()
+
+	for _, c := range []struct {
+		path, accept string
+		protobuf     bool
+	}{
+		{path: "/query_range?query=test_metric1&start=0&end=120&step=60", accept: "application/x-protobuf, application/json;q=0.5", protobuf: true},
+		// Only range queries are encoded as protobuf, instant queries
+		// with matrix results included.
+		{path: "/query?query=test_metric1", accept: "application/x-protobuf, application/json;q=0.5"},
+		{path: "/query?query=test_metric1[2m]", accept: "application/x-protobuf, application/json;q=0.5"},
+		// A zero quality rejects protobuf.
+		{path: "/query_range?query=test_metric1&start=0&end=120&step=60", accept: "application/x-protobuf;q=0, application/json"},
+		// Statistics are not dropped.
+		{path: "/query_range?query=test_metric1&start=0&end=120&step=60&stats=all", accept: "application/x-protobuf"},
+	} {
+		req, err := http.NewRequest("GET", s.URL+c.path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Accept", c.accept)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Error on test request: %s", err)
+		}
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatalf("Error reading response body: %s", err)
+		}
+
+		if strings.HasPrefix(c.path, "/query_range") {
+			if h := resp.Header.Get("Vary"); h != "Accept" {
+				t.Errorf("%s: expected Vary header %q but got %q", c.path, "Accept", h)
+			}
+		}
+		if !c.protobuf {
+			if h := resp.Header.Get("Content-Type"); h != "application/json" {
+				t.Fatalf("%s with Accept %q: expected Content-Type %q but got %q", c.path, c.accept, "application/json", h)
+			}
+			continue
+		}
+
+		if h := resp.Header.Get("Content-Type"); h != "application/x-protobuf; proto=remote.QueryResult" {
+			t.Fatalf("Unexpected Content-Type %q", h)
+		}
+		var res remote.QueryResult
+		if err := proto.Unmarshal(body, &res); err != nil {
+			t.Fatalf("Error unmarshaling protobuf body: %s", err)
+		}
+		exp := model.Matrix{
+			{
+				Metric: model.Metric{"__name__": "test_metric1", "foo": "bar"},
+				Values: []model.SamplePair{{Timestamp: 0, Value: 0}, {Timestamp: 60000, Value: 100}, {Timestamp: 120000, Value: 200}},
+			},
+		}
+		if m := remote.FromQueryResult(&res); !reflect.DeepEqual(m, exp) {
+			t.Fatalf("Expected result \n%v\n but got \n%v\n", exp, m)
+		}
+	}
+}
+
+func TestRemoteReadMaxResolution(t *testing.T) {
+	suite, err := promql.NewTest(t, `
+		load 15s
+			test_metric1{foo="bar"} 0+1x39
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer suite.Close()
+
+	if err := suite.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	api := &API{Storage: suite.Storage()}
+
+	for _, c := range []struct {
+		maxResolution int64
+		status        int
+		samples       int
+	}{
+		{maxResolution: 0, status: http.StatusOK, samples: 40},
+		{maxResolution: 60000, status: http.StatusOK, samples: 10},
+		{maxResolution: -1, status: http.StatusBadRequest},
+	} {
+		data, err := proto.Marshal(&remote.ReadRequest{
+			Queries: []*remote.Query{{
+				StartTimestampMs: 0,
+				EndTimestampMs:   600000,
+				Matchers:         []*remote.LabelMatcher{{Type: remote.MatchType_EQUAL, Name: "__name__", Value: "test_metric1"}},
+				MaxResolutionMs:  c.maxResolution,
+			}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		req, err := http.NewRequest("POST", "http://example.com/api/v1/read", bytes.NewReader(snappy.Encode(nil, data)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		w := httptest.NewRecorder()
+		api.remoteRead(w, req)
+
+		if w.Code != c.status {
+			t.Fatalf("max resolution %d: expected status %d, got %d", c.maxResolution, c.status, w.Code)
+		}
+		if c.status != http.StatusOK {
+			continue
+		}
+		b, err := snappy.Decode(nil, w.Body.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		var resp remote.ReadResponse
+		if err := proto.Unmarshal(b, &resp); err != nil {
+			t.Fatal(err)
+		}
+		if n := len(resp.Results[0].Timeseries[0].Samples); n != c.samples {
+			t.Errorf("max resolution %d: expected %d samples, got %d", c.maxResolution, c.samples, n)
+		}
+	}
+}
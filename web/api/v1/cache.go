@@ -0,0 +1,197 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/common/model"
+)
+
+// QueryCacheOptions configures the cache of range query results. A zero TTL
+// disables the cache.
+type QueryCacheOptions struct {
+	// How long a result is cached.
+	TTL time.Duration
+	// Maximum number of cached results.
+	MaxEntries int
+}
+
+// queryCacheBoundary is how far in the past the evaluations of a range query
+// must be to be cached. More recent results may still change, as running
+// scrapes and rule evaluations append samples with earlier timestamps.
+const queryCacheBoundary = 5 * time.Minute
+
+type queryCacheKey struct {
+	expr  string
+	start model.Time
+	step  time.Duration
+}
+
+type queryCacheEntry struct {
+	matrix model.Matrix
+	// The warnings of the result. Incomplete results are not cached, so these
+	// only depend on the query.
+	warnings []string
+	// The timestamp of the last evaluation in the matrix.
+	until   model.Time
+	expires time.Time
+}
+
+// queryCache caches the results of range queries up to the cache boundary.
+// A dashboard reloading a query with the same start only has to evaluate the
+// steps after the cached ones. Caching requires the start to be aligned to
+// the step, so that the start of a relative range stays the same between
+// reloads within a step.
+type queryCache struct {
+	opts QueryCacheOptions
+
+	mtx     sync.Mutex
+	entries map[queryCacheKey]*queryCacheEntry
+
+	now func() time.Time
+}
+
+func newQueryCache(opts QueryCacheOptions) *queryCache {
+	if opts.TTL <= 0 {
+		return nil
+	}
+	return &queryCache{
+		opts:    opts,
+		entries: map[queryCacheKey]*queryCacheEntry{},
+		now:     time.Now,
+	}
+}
+
+// cacheable returns whether the results of the range query can be cached.
+func (c *queryCache) cacheable(start model.Time, step time.Duration) bool {
+	return c != nil && start.UnixNano()%int64(step) == 0
+}
+
+// get returns the cached result of the range query. Entries are never
+// modified once cached.
+func (c *queryCache) get(k queryCacheKey) (*queryCacheEntry, bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	e, ok := c.entries[k]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(e.expires) {
+		delete(c.entries, k)
+		return nil, false
+	}
+	return e, true
+}
+
+// put caches the evaluations of the result of the range query up to the cache
+// boundary, unless more of them are cached already.
+func (c *queryCache) put(k queryCacheKey, m model.Matrix, warnings []string, end, now model.Time) {
+	if boundary := now.Add(-queryCacheBoundary); boundary.Before(end) {
+		end = boundary
+	}
+	if end.Before(k.start) {
+		return
+	}
+	until := k.start.Add(end.Sub(k.start) / k.step * k.step)
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if e, ok := c.entries[k]; ok && !e.until.Before(until) {
+		return
+	}
+	if _, ok := c.entries[k]; !ok && c.opts.MaxEntries > 0 && len(c.entries) >= c.opts.MaxEntries {
+		c.evict()
+	}
+	c.entries[k] = &queryCacheEntry{
+		matrix:   truncateMatrix(m, until),
+		warnings: warnings,
+		until:    until,
+		expires:  c.now().Add(c.opts.TTL),
+	}
+}
+
+// evict removes the expired entries, or the entry that expires first if none
+// has expired.
+func (c *queryCache) evict() {
+	var (
+		now    = c.now()
+		first  queryCacheKey
+		expiry time.Time
+	)
+	for k, e := range c.entries {
+		if !now.Before(e.expires) {
+			delete(c.entries, k)
+			continue
+		}
+		if expiry.IsZero() || e.expires.Before(expiry) {
+			first, expiry = k, e.expires
+		}
+	}
+	if len(c.entries) >= c.opts.MaxEntries {
+		delete(c.entries, first)
+	}
+}
+
+// reset drops all cached results.
+func (c *queryCache) reset() {
+	if c == nil {
+		return
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.entries = map[queryCacheKey]*queryCacheEntry{}
+}
+
+// truncateMatrix returns the sample streams of the matrix with the values up
+// to the given timestamp. Streams without such values are dropped.
+func truncateMatrix(m model.Matrix, until model.Time) model.Matrix {
+	res := make(model.Matrix, 0, len(m))
+	for _, ss := range m {
+		n := sort.Search(len(ss.Values), func(i int) bool { return ss.Values[i].Timestamp.After(until) })
+		if n == 0 {
+			continue
+		}
+		res = append(res, &model.SampleStream{Metric: ss.Metric, Values: ss.Values[:n:n]})
+	}
+	return res
+}
+
+// mergeMatrices returns the sample streams of both matrices, with the values
+// of b following the values of a for the same metric.
+func mergeMatrices(a, b model.Matrix) model.Matrix {
+	streams := make(map[model.Fingerprint]*model.SampleStream, len(a)+len(b))
+	res := make(model.Matrix, 0, len(a)+len(b))
+	for _, m := range []model.Matrix{a, b} {
+		for _, ss := range m {
+			fp := ss.Metric.Fingerprint()
+			if prev, ok := streams[fp]; ok {
+				// Appending to the capped values of a copies them, so the
+				// cached streams are never modified.
+				prev.Values = append(prev.Values, ss.Values...)
+				continue
+			}
+			s := &model.SampleStream{Metric: ss.Metric, Values: ss.Values}
+			streams[fp] = s
+			res = append(res, s)
+		}
+	}
+	sort.Sort(res)
+	return res
+}
@@ -0,0 +1,173 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+
+	"github.com/prometheus/prometheus/promql"
+)
+
+func TestQueryRangeCache(t *testing.T) {
+	suite, err := promql.NewTest(t, `
+		load 1m
+			test_metric{foo="bar"} 0+10x100
+			test_metric{foo="boo"} 1+0x50
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer suite.Close()
+
+	if err := suite.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	now := model.Time(0).Add(100 * time.Minute)
+	api := &API{
+		Storage:     suite.Storage(),
+		QueryEngine: suite.QueryEngine(),
+		now:         func() model.Time { return now },
+		cache:       newQueryCache(QueryCacheOptions{TTL: time.Minute}),
+	}
+	uncached := &API{
+		Storage:     suite.Storage(),
+		QueryEngine: suite.QueryEngine(),
+		now:         func() model.Time { return now },
+	}
+	key := queryCacheKey{expr: "rate(test_metric[5m])", start: 0, step: time.Minute}
+
+	queryRange := func(api *API, end time.Duration) model.Matrix {
+		q := url.Values{
+			"query": []string{key.expr},
+			"start": []string{"0"},
+			"end":   []string{fmt.Sprint(end.Seconds())},
+			"step":  []string{"60"},
+		}
+		req, err := http.NewRequest("GET", "http://example.com?"+q.Encode(), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, apiErr := api.queryRange(req)
+		if apiErr != nil {
+			t.Fatalf("Unexpected error: %s", apiErr)
+		}
+		return res.(*queryData).Result.(model.Matrix)
+	}
+	cachedUntil := func() model.Time {
+		e, ok := api.cache.get(key)
+		if !ok {
+			t.Fatalf("Expected a cached result")
+		}
+		return e.until
+	}
+
+	for _, c := range []struct {
+		end   time.Duration
+		until time.Duration
+	}{
+		// The result up to the end is cached.
+		{end: 60 * time.Minute, until: 60 * time.Minute},
+		// The cached evaluations are reused and the range until the cache
+		// boundary is added.
+		{end: 100 * time.Minute, until: 95 * time.Minute},
+		// A shorter range is served from the cache.
+		{end: 30 * time.Minute, until: 95 * time.Minute},
+	} {
+		got, expected := queryRange(api, c.end), queryRange(uncached, c.end)
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("end %s: expected cached result\n%v\ngot\n%v", c.end, expected, got)
+		}
+		if until := cachedUntil(); until != model.Time(0).Add(c.until) {
+			t.Errorf("end %s: expected evaluations until %s to be cached, got %s", c.end, c.until, until.Time().Sub(time.Unix(0, 0)))
+		}
+	}
+
+	// Dropping series invalidates the cache.
+	req, err := http.NewRequest("POST", "http://example.com?match[]=test_metric{foo=\"boo\"}", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, apiErr := api.dropSeries(req); apiErr != nil {
+		t.Fatal(apiErr)
+	}
+	if _, ok := api.cache.get(key); ok {
+		t.Errorf("Expected the cache to be reset after dropping series")
+	}
+
+	// Results with the lookback warning are cached, and the warning is
+	// returned with the cached result.
+	q := url.Values{
+		"query": []string{"test_metric"},
+		"start": []string{"0"},
+		"end":   []string{"3600"},
+		"step":  []string{"600"},
+	}
+	lookbackKey := queryCacheKey{expr: "test_metric", start: 0, step: 10 * time.Minute}
+	var warnings []string
+	for i := 0; i < 2; i++ {
+		req, err := http.NewRequest("GET", "http://example.com?"+q.Encode(), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, apiErr := api.queryRange(req)
+		if apiErr != nil {
+			t.Fatalf("Unexpected error: %s", apiErr)
+		}
+		if _, ok := api.cache.get(lookbackKey); !ok {
+			t.Fatalf("Expected a cached result with the lookback warning")
+		}
+		ws := res.(*queryData).warnings
+		if len(ws) != 1 || (warnings != nil && !reflect.DeepEqual(ws, warnings)) {
+			t.Errorf("Expected the same lookback warning for each request, got %q", ws)
+		}
+		warnings = ws
+	}
+}
+
+func TestQueryCacheEviction(t *testing.T) {
+	c := newQueryCache(QueryCacheOptions{TTL: time.Minute, MaxEntries: 2})
+	now := time.Unix(0, 0)
+	c.now = func() time.Time { return now }
+
+	m := model.Matrix{&model.SampleStream{
+		Metric: model.Metric{"__name__": "up"},
+		Values: []model.SamplePair{{Timestamp: 0, Value: 1}, {Timestamp: 60000, Value: 1}},
+	}}
+	end := model.Time(60000)
+	for i, expr := range []string{"a", "b", "c"} {
+		now = time.Unix(int64(i), 0)
+		c.put(queryCacheKey{expr: expr, step: time.Minute}, m, nil, end, model.Latest)
+	}
+	// The boundary excludes all evaluations.
+	c.put(queryCacheKey{expr: "d", step: time.Minute}, m, nil, end, end)
+
+	for expr, cached := range map[string]bool{"a": false, "b": true, "c": true, "d": false} {
+		if _, ok := c.get(queryCacheKey{expr: expr, step: time.Minute}); ok != cached {
+			t.Errorf("%s: expected cached %t, got %t", expr, cached, ok)
+		}
+	}
+
+	now = now.Add(time.Minute)
+	if _, ok := c.get(queryCacheKey{expr: "c", step: time.Minute}); ok {
+		t.Errorf("Expected cached result to expire after the TTL")
+	}
+}
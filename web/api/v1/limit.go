@@ -0,0 +1,156 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"errors"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// QueryLimits restricts the load clients can put on the query endpoints.
+// Zero values disable the respective limit.
+type QueryLimits struct {
+	// Maximum number of requests served concurrently. Requests above the
+	// limit are rejected instead of being queued.
+	MaxConcurrent int
+	// Number of requests per second each client may send on average, and
+	// the number of requests it may send at once.
+	ClientRate  float64
+	ClientBurst int
+}
+
+// minClientLimiterTTL is the minimum time the rate limiter of an idle client
+// is kept.
+const minClientLimiterTTL = time.Minute
+
+var (
+	errTooManyConcurrentQueries = errors.New("too many concurrent queries, try again later")
+	errRateLimited              = errors.New("query rate limit exceeded, try again later")
+)
+
+type clientLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// queryLimiter enforces QueryLimits.
+type queryLimiter struct {
+	limits QueryLimits
+	active chan struct{}
+
+	mtx       sync.Mutex
+	clients   map[string]*clientLimiter
+	clientTTL time.Duration
+	lastSweep time.Time
+
+	now func() time.Time
+}
+
+func newQueryLimiter(limits QueryLimits) *queryLimiter {
+	l := &queryLimiter{
+		limits:  limits,
+		clients: map[string]*clientLimiter{},
+		now:     time.Now,
+	}
+	if limits.MaxConcurrent > 0 {
+		l.active = make(chan struct{}, limits.MaxConcurrent)
+	}
+	if limits.ClientRate > 0 {
+		// Keep limiters at least until their bucket is full again, so that
+		// forgetting about a client doesn't change its limit.
+		l.clientTTL = time.Duration(float64(limits.ClientBurst) / limits.ClientRate * float64(time.Second))
+		if l.clientTTL < minClientLimiterTTL {
+			l.clientTTL = minClientLimiterTTL
+		}
+	}
+	return l
+}
+
+// wrap rejects requests exceeding the limits with a 503 status and a
+// Retry-After header.
+func (l *queryLimiter) wrap(f http.HandlerFunc) http.HandlerFunc {
+	if l == nil {
+		return f
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		if delay := l.reserve(r); delay > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+			respondError(w, &apiError{errorUnavailable, errRateLimited}, nil)
+			return
+		}
+
+		if l.active != nil {
+			select {
+			case l.active <- struct{}{}:
+				defer func() { <-l.active }()
+			default:
+				w.Header().Set("Retry-After", "1")
+				respondError(w, &apiError{errorUnavailable, errTooManyConcurrentQueries}, nil)
+				return
+			}
+		}
+		f(w, r)
+	}
+}
+
+// reserve takes a token from the rate limiter of the client of the request.
+// If no token is available, nothing is taken and the time until one becomes
+// available is returned.
+func (l *queryLimiter) reserve(r *http.Request) time.Duration {
+	if l.limits.ClientRate <= 0 {
+		return 0
+	}
+	client, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		client = r.RemoteAddr
+	}
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	now := l.now()
+	if now.Sub(l.lastSweep) > l.clientTTL {
+		for c, cl := range l.clients {
+			if now.Sub(cl.lastSeen) > l.clientTTL {
+				delete(l.clients, c)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	cl, ok := l.clients[client]
+	if !ok {
+		burst := l.limits.ClientBurst
+		if burst < 1 {
+			burst = 1
+		}
+		cl = &clientLimiter{limiter: rate.NewLimiter(rate.Limit(l.limits.ClientRate), burst)}
+		l.clients[client] = cl
+	}
+	cl.lastSeen = now
+
+	res := cl.limiter.ReserveN(now, 1)
+	if delay := res.DelayFrom(now); delay > 0 {
+		res.CancelAt(now)
+		return delay
+	}
+	return 0
+}
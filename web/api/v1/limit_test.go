@@ -0,0 +1,92 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestQueryLimiterClientRate(t *testing.T) {
+	l := newQueryLimiter(QueryLimits{ClientRate: 1, ClientBurst: 2})
+	now := time.Unix(0, 0)
+	l.now = func() time.Time { return now }
+
+	h := l.wrap(func(w http.ResponseWriter, r *http.Request) {})
+
+	do := func(addr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/api/v1/query", nil)
+		req.RemoteAddr = addr
+		w := httptest.NewRecorder()
+		h(w, req)
+		return w
+	}
+
+	for i := 0; i < 2; i++ {
+		if w := do("10.0.0.1:1234"); w.Code != http.StatusOK {
+			t.Fatalf("request %d: unexpected status %d", i, w.Code)
+		}
+	}
+	w := do("10.0.0.1:4321")
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected status %d, got %d", http.StatusServiceUnavailable, w.Code)
+	}
+	if ra := w.Header().Get("Retry-After"); ra != "1" {
+		t.Fatalf("expected Retry-After 1, got %q", ra)
+	}
+
+	// Other clients are limited separately.
+	if w := do("10.0.0.2:1234"); w.Code != http.StatusOK {
+		t.Fatalf("unexpected status %d for other client", w.Code)
+	}
+
+	now = now.Add(time.Second)
+	if w := do("10.0.0.1:1234"); w.Code != http.StatusOK {
+		t.Fatalf("unexpected status %d after waiting", w.Code)
+	}
+}
+
+func TestQueryLimiterMaxConcurrent(t *testing.T) {
+	l := newQueryLimiter(QueryLimits{MaxConcurrent: 1})
+
+	started, release := make(chan struct{}), make(chan struct{})
+	h := l.wrap(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+	})
+
+	done := make(chan struct{})
+	go func() {
+		h(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/v1/query", nil))
+		close(done)
+	}()
+	<-started
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest("GET", "/api/v1/query", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected status %d, got %d", http.StatusServiceUnavailable, w.Code)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Fatalf("expected Retry-After header")
+	}
+
+	close(release)
+	<-done
+	if len(l.active) != 0 {
+		t.Fatalf("expected no active queries, got %d", len(l.active))
+	}
+}
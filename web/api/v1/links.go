@@ -0,0 +1,199 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+
+	"github.com/prometheus/common/log"
+	"github.com/prometheus/common/route"
+)
+
+const (
+	// The number of characters of link IDs. 10 base64 characters hold 60 bits
+	// of the hash of the parameters, enough to make collisions unlikely. IDs
+	// are extended in case of a collision nevertheless.
+	linkIDLength = 10
+	// maxLinkParamsLength limits the size of the parameters of a single link.
+	// Browsers and proxies do not reliably handle longer URLs anyway.
+	maxLinkParamsLength = 8 << 10
+	// maxLinks limits the number of links kept, so that the API cannot be
+	// used to fill up the disk.
+	maxLinks = 10000
+)
+
+// Link is a short link to the graph page.
+type Link struct {
+	ID string `json:"id"`
+	// The query string of the graph page, as in graph?<params>.
+	Params string `json:"params"`
+}
+
+// linkStore holds the short links of the graph page and persists them to a
+// file, if set. Links are never changed or removed, so the file is an
+// append-only log with one JSON encoded Link per line.
+type linkStore struct {
+	file string
+
+	mtx   sync.Mutex
+	links map[string]string
+}
+
+// newLinkStore returns a linkStore with the links persisted to the file. A
+// line written incompletely on a crash is truncated, so that the next link
+// appended starts on a line of its own.
+func newLinkStore(file string) *linkStore {
+	s := &linkStore{file: file, links: map[string]string{}}
+	if file == "" {
+		return s
+	}
+	b, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return s
+	}
+	if err != nil {
+		log.Errorf("Error reading short links: %s", err)
+		return s
+	}
+
+	if n := bytes.LastIndexByte(b, '\n') + 1; n < len(b) {
+		log.Warnf("Truncating incomplete short link at the end of file %s", file)
+		if err := os.Truncate(file, int64(n)); err != nil {
+			log.Errorf("Error truncating short links file %s: %s", file, err)
+		}
+		b = b[:n]
+	}
+	for _, line := range bytes.Split(b, []byte{'\n'}) {
+		if len(line) == 0 {
+			continue
+		}
+		var l Link
+		if err := json.Unmarshal(line, &l); err != nil {
+			log.Errorf("Error parsing short link in file %s: %s", file, err)
+			continue
+		}
+		s.links[l.ID] = l.Params
+	}
+	return s
+}
+
+// linkID returns the ID of the given length for the parameters.
+func linkID(params string, length int) string {
+	h := sha256.Sum256([]byte(params))
+	return base64.RawURLEncoding.EncodeToString(h[:])[:length]
+}
+
+func (s *linkStore) get(id string) (string, bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	params, ok := s.links[id]
+	return params, ok
+}
+
+// add stores the parameters and returns the ID of their link. Adding the same
+// parameters again returns the existing link. If the ID is taken by different
+// parameters, it is extended until it is unique.
+func (s *linkStore) add(params string) (string, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	for n := linkIDLength; n <= base64.RawURLEncoding.EncodedLen(sha256.Size); n++ {
+		id := linkID(params, n)
+		p, ok := s.links[id]
+		if !ok {
+			if err := s.insert(id, params); err != nil {
+				return "", err
+			}
+			return id, nil
+		}
+		if p == params {
+			return id, nil
+		}
+	}
+	return "", fmt.Errorf("no unique link ID for params")
+}
+
+func (s *linkStore) insert(id, params string) error {
+	if len(s.links) >= maxLinks {
+		return fmt.Errorf("maximum number of %d links reached", maxLinks)
+	}
+	if s.file != "" {
+		if err := s.persist(Link{ID: id, Params: params}); err != nil {
+			return err
+		}
+	}
+	s.links[id] = params
+	return nil
+}
+
+// persist appends the link to the file.
+func (s *linkStore) persist(l Link) error {
+	b, err := json.Marshal(l)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(s.file, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// LinkParams returns the query string of the graph page the link with the ID
+// points to.
+func (api *API) LinkParams(id string) (string, bool) {
+	return api.links.get(id)
+}
+
+// createLink returns a short link for the graph page query string given in the
+// params form value.
+func (api *API) createLink(r *http.Request) (interface{}, *apiError) {
+	params := r.FormValue("params")
+	if params == "" {
+		return nil, &apiError{errorBadData, fmt.Errorf("no params given")}
+	}
+	if len(params) > maxLinkParamsLength {
+		return nil, &apiError{errorBadData, fmt.Errorf("params exceed the maximum length of %d bytes", maxLinkParamsLength)}
+	}
+	if _, err := url.ParseQuery(params); err != nil {
+		return nil, &apiError{errorBadData, fmt.Errorf("invalid params: %s", err)}
+	}
+	id, err := api.links.add(params)
+	if err != nil {
+		return nil, &apiError{errorInternal, fmt.Errorf("error saving link: %s", err)}
+	}
+	return Link{ID: id, Params: params}, nil
+}
+
+func (api *API) serveLink(r *http.Request) (interface{}, *apiError) {
+	id := route.Param(r.Context(), "id")
+	params, ok := api.links.get(id)
+	if !ok {
+		return nil, &apiError{errorNotFound, fmt.Errorf("link %q not found", id)}
+	}
+	return Link{ID: id, Params: params}, nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/common/route"
+	"golang.org/x/net/context"
+
+	"github.com/prometheus/prometheus/util/testutil"
+)
+
+func TestLinks(t *testing.T) {
+	dir := testutil.NewTemporaryDirectory("ui_links", t)
+	defer dir.Close()
+	file := filepath.Join(dir.Path(), "ui_links.jsonl")
+
+	api := &API{links: newLinkStore(file)}
+
+	create := func(params string) (interface{}, *apiError) {
+		v := url.Values{"params": []string{params}}
+		r, err := http.NewRequest("POST", "http://example.org/api/v1/links", strings.NewReader(v.Encode()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return api.createLink(r)
+	}
+
+	for _, params := range []string{"", "g0.expr=%zz", strings.Repeat("a", maxLinkParamsLength+1)} {
+		if _, apiErr := create(params); apiErr == nil || apiErr.typ != errorBadData {
+			t.Errorf("expected bad data error for %q, got %v", params, apiErr)
+		}
+	}
+
+	params := "g0.expr=" + url.QueryEscape(`sum by(job) (rate(http_requests_total{code=~"5.."}[5m]))`) + "&g0.tab=0"
+	res, apiErr := create(params)
+	if apiErr != nil {
+		t.Fatal(apiErr.err)
+	}
+	link := res.(Link)
+	if len(link.ID) != linkIDLength || link.Params != params {
+		t.Fatalf("unexpected link %+v", link)
+	}
+	if res, _ := create(params); res.(Link).ID != link.ID {
+		t.Errorf("expected the same link for the same params, got %+v", res)
+	}
+
+	get := func(id string) (interface{}, *apiError) {
+		r, err := http.NewRequest("GET", "http://example.org/api/v1/links/"+id, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ctx := route.WithParam(context.Background(), "id", id)
+		return api.serveLink(r.WithContext(ctx))
+	}
+	if res, apiErr := get(link.ID); apiErr != nil || res.(Link) != link {
+		t.Errorf("unexpected link %v, error %v", res, apiErr)
+	}
+	if _, apiErr := get("unknown"); apiErr == nil || apiErr.typ != errorNotFound {
+		t.Errorf("expected not found error, got %v", apiErr)
+	}
+
+	// A colliding ID of other params is extended.
+	other := "g0.expr=up&g0.tab=1"
+	api.links.links[linkID(other, linkIDLength)] = "g0.expr=colliding"
+	res, apiErr = create(other)
+	if apiErr != nil {
+		t.Fatal(apiErr.err)
+	}
+	otherLink := res.(Link)
+	if otherLink.ID != linkID(other, linkIDLength+1) {
+		t.Errorf("expected extended ID for colliding params, got %+v", otherLink)
+	}
+
+	// The links are persisted.
+	links := newLinkStore(file)
+	for _, l := range []Link{link, otherLink} {
+		if p, ok := links.get(l.ID); !ok || p != l.Params {
+			t.Errorf("expected persisted params %q, got %q", l.Params, p)
+		}
+	}
+}
+
+func TestLinksIncompleteLine(t *testing.T) {
+	dir := testutil.NewTemporaryDirectory("ui_links", t)
+	defer dir.Close()
+	file := filepath.Join(dir.Path(), "ui_links.jsonl")
+
+	// The last line was written incompletely on a crash.
+	if err := ioutil.WriteFile(file, []byte(`{"id":"a","params":"g0.expr=up"}`+"\n"+`{"id":"b","par`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	s := newLinkStore(file)
+	if p, ok := s.get("a"); !ok || p != "g0.expr=up" {
+		t.Errorf("expected complete link to be loaded, got %q", p)
+	}
+	if _, ok := s.get("b"); ok {
+		t.Errorf("expected incomplete link to be dropped")
+	}
+
+	id, err := s.add("g0.expr=down")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The link added after the truncation is loaded again.
+	s = newLinkStore(file)
+	for l, params := range map[string]string{"a": "g0.expr=up", id: "g0.expr=down"} {
+		if p, ok := s.get(l); !ok || p != params {
+			t.Errorf("expected persisted params %q for link %q, got %q", params, l, p)
+		}
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/prometheus/common/version"
+)
+
+// endpoint defines an endpoint of the API. The endpoints are registered and
+// described in the OpenAPI specification from the same definitions, so that
+// the specification follows the routes.
+type endpoint struct {
+	method string
+	// The path in the syntax of the router, with :name for path parameters.
+	path    string
+	name    string
+	summary string
+	params  []endpointParam
+	// Either f responds in the JSON format of the API, or h writes a
+	// response of its own, whose content type is set in contentType.
+	f           apiFunc
+	h           http.HandlerFunc
+	contentType string
+	// Whether the endpoint is subject to the query limits.
+	limited bool
+	// Whether f responds with protobuf encoded results to clients
+	// accepting them.
+	protobuf bool
+}
+
+// Types of endpoint parameters, in addition to the OpenAPI data types.
+const (
+	paramString   = "string"
+	paramInteger  = "integer"
+	paramTime     = "time"
+	paramDuration = "duration"
+)
+
+// endpointParam is a query or form parameter of an endpoint. Path parameters
+// are derived from the path.
+type endpointParam struct {
+	name        string
+	typ         string
+	description string
+	required    bool
+	// Whether the parameter can be repeated, like match[].
+	repeated bool
+}
+
+var (
+	paramMatch = endpointParam{name: "match[]", typ: paramString, repeated: true, description: "Series selector selecting the series."}
+	paramStart = endpointParam{name: "start", typ: paramTime, description: "Start of the selected time range."}
+	paramEnd   = endpointParam{name: "end", typ: paramTime, description: "End of the selected time range."}
+)
+
+func (p endpointParam) schema() map[string]interface{} {
+	var s map[string]interface{}
+	switch p.typ {
+	case paramTime:
+		s = map[string]interface{}{
+			"type":        "string",
+			"description": "RFC 3339 timestamp or Unix timestamp in seconds.",
+		}
+	case paramDuration:
+		s = map[string]interface{}{
+			"type":        "string",
+			"description": "Duration like 5m or float number of seconds.",
+		}
+	default:
+		s = map[string]interface{}{"type": p.typ}
+	}
+	if p.repeated {
+		return map[string]interface{}{"type": "array", "items": s}
+	}
+	return s
+}
+
+// openAPIPath returns the path in the OpenAPI syntax and the names of its
+// path parameters.
+func openAPIPath(path string) (string, []string) {
+	var names []string
+	parts := strings.Split(path, "/")
+	for i, p := range parts {
+		if strings.HasPrefix(p, ":") {
+			names = append(names, p[1:])
+			parts[i] = "{" + p[1:] + "}"
+		}
+	}
+	return strings.Join(parts, "/"), names
+}
+
+// openAPISpec returns the OpenAPI 3.0 specification of the endpoints, served
+// below the given URL.
+func openAPISpec(endpoints []endpoint, serverURL string) map[string]interface{} {
+	envelope := map[string]interface{}{
+		"application/json": map[string]interface{}{
+			"schema": map[string]interface{}{"$ref": "#/components/schemas/Response"},
+		},
+	}
+	paths := map[string]interface{}{}
+	for _, e := range endpoints {
+		path, pathParams := openAPIPath(e.path)
+
+		var params []interface{}
+		for _, name := range pathParams {
+			params = append(params, map[string]interface{}{
+				"name":     name,
+				"in":       "path",
+				"required": true,
+				"schema":   map[string]interface{}{"type": "string"},
+			})
+		}
+		op := map[string]interface{}{
+			"operationId": e.name,
+			"summary":     e.summary,
+		}
+		if e.method == "POST" || e.method == "PUT" {
+			// The parameters of POST and PUT requests are passed as form
+			// values.
+			props := map[string]interface{}{}
+			var required []string
+			for _, p := range e.params {
+				s := p.schema()
+				s["description"] = p.description
+				props[p.name] = s
+				if p.required {
+					required = append(required, p.name)
+				}
+			}
+			if len(props) > 0 {
+				schema := map[string]interface{}{"type": "object", "properties": props}
+				if len(required) > 0 {
+					schema["required"] = required
+				}
+				op["requestBody"] = map[string]interface{}{
+					"content": map[string]interface{}{
+						"application/x-www-form-urlencoded": map[string]interface{}{"schema": schema},
+					},
+				}
+			}
+		} else {
+			for _, p := range e.params {
+				param := map[string]interface{}{
+					"name":        p.name,
+					"in":          "query",
+					"description": p.description,
+					"required":    p.required,
+					"schema":      p.schema(),
+				}
+				if p.repeated {
+					param["explode"] = true
+				}
+				params = append(params, param)
+			}
+		}
+		if len(params) > 0 {
+			op["parameters"] = params
+		}
+
+		if e.f != nil {
+			op["responses"] = map[string]interface{}{
+				"200":     map[string]interface{}{"description": "Success.", "content": envelope},
+				"204":     map[string]interface{}{"description": "Success without data."},
+				"default": map[string]interface{}{"description": "Error.", "content": envelope},
+			}
+		} else {
+			op["responses"] = map[string]interface{}{
+				"200": map[string]interface{}{
+					"description": "Success.",
+					"content":     map[string]interface{}{e.contentType: map[string]interface{}{}},
+				},
+				"default": map[string]interface{}{"description": "Error.", "content": envelope},
+			}
+		}
+
+		item, ok := paths[path].(map[string]interface{})
+		if !ok {
+			item = map[string]interface{}{}
+			paths[path] = item
+		}
+		item[strings.ToLower(e.method)] = op
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.0",
+		"info": map[string]interface{}{
+			"title":   "Prometheus HTTP API",
+			"version": version.Version,
+		},
+		"servers": []interface{}{map[string]interface{}{"url": serverURL}},
+		"paths":   paths,
+		"components": map[string]interface{}{
+			"schemas": map[string]interface{}{
+				"Response": map[string]interface{}{
+					"type":     "object",
+					"required": []string{"status"},
+					"properties": map[string]interface{}{
+						"status": map[string]interface{}{
+							"type": "string",
+							"enum": []string{string(statusSuccess), string(statusError)},
+						},
+						"data": map[string]interface{}{},
+						"errorType": map[string]interface{}{
+							"type": "string",
+							"enum": []string{
+								string(errorTimeout), string(errorCanceled), string(errorExec), string(errorBadData),
+								string(errorInternal), string(errorUnavailable), string(errorNotFound),
+							},
+						},
+						"error": map[string]interface{}{"type": "string"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// serveOpenAPI serves the OpenAPI specification of the registered endpoints.
+// It is served as is rather than in the response format of the API, so that
+// it can be passed to code generators.
+func (api *API) serveOpenAPI(w http.ResponseWriter, r *http.Request) {
+	setCORS(w, api.corsOrigin, r)
+	spec := openAPISpec(api.endpoints, strings.TrimSuffix(r.URL.Path, "/openapi.json"))
+	b, err := json.Marshal(spec)
+	if err != nil {
+		respondError(w, &apiError{errorInternal, err}, nil)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(b)
+}
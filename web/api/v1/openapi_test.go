@@ -0,0 +1,116 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/prometheus/common/route"
+)
+
+func TestOpenAPI(t *testing.T) {
+	r := route.New()
+	api := &API{}
+	api.Register(r.WithPrefix("/api/v1"))
+
+	s := httptest.NewServer(r)
+	defer s.Close()
+
+	resp, err := http.Get(s.URL + "/api/v1/openapi.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+
+	var spec struct {
+		OpenAPI string `json:"openapi"`
+		Servers []struct {
+			URL string `json:"url"`
+		} `json:"servers"`
+		Paths map[string]map[string]struct {
+			OperationID string `json:"operationId"`
+			Parameters  []struct {
+				Name     string `json:"name"`
+				In       string `json:"in"`
+				Required bool   `json:"required"`
+			} `json:"parameters"`
+			RequestBody *struct {
+				Content map[string]struct {
+					Schema struct {
+						Required []string `json:"required"`
+					} `json:"schema"`
+				} `json:"content"`
+			} `json:"requestBody"`
+		} `json:"paths"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&spec); err != nil {
+		t.Fatal(err)
+	}
+
+	if spec.OpenAPI != "3.0.0" {
+		t.Errorf("Unexpected OpenAPI version %q", spec.OpenAPI)
+	}
+	if len(spec.Servers) != 1 || spec.Servers[0].URL != "/api/v1" {
+		t.Errorf("Unexpected servers %v", spec.Servers)
+	}
+
+	// Every registered endpoint is described.
+	for _, e := range api.endpoints {
+		path, _ := openAPIPath(e.path)
+		var method string
+		switch e.method {
+		case "GET":
+			method = "get"
+		case "POST":
+			method = "post"
+		case "PUT":
+			method = "put"
+		case "DELETE":
+			method = "delete"
+		}
+		if op, ok := spec.Paths[path][method]; !ok || op.OperationID != e.name {
+			t.Errorf("Endpoint %s %s not described, got %v", e.method, path, spec.Paths[path])
+		}
+	}
+
+	values := spec.Paths["/label/{name}/values"]["get"]
+	if len(values.Parameters) == 0 || values.Parameters[0].Name != "name" || values.Parameters[0].In != "path" || !values.Parameters[0].Required {
+		t.Errorf("Unexpected parameters of label values %v", values.Parameters)
+	}
+
+	var params []string
+	for _, p := range spec.Paths["/query_range"]["get"].Parameters {
+		if p.In == "query" && p.Required {
+			params = append(params, p.Name)
+		}
+	}
+	if expected := []string{"query", "start", "end", "step"}; !reflect.DeepEqual(params, expected) {
+		t.Errorf("Expected required query_range parameters %v, got %v", expected, params)
+	}
+
+	link := spec.Paths["/links"]["post"]
+	if link.RequestBody == nil {
+		t.Fatal("Expected form parameters for creating links")
+	}
+	if required := link.RequestBody.Content["application/x-www-form-urlencoded"].Schema.Required; !reflect.DeepEqual(required, []string{"params"}) {
+		t.Errorf("Unexpected required form parameters %v", required)
+	}
+}
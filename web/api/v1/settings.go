@@ -0,0 +1,150 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/prometheus/common/log"
+	"github.com/prometheus/common/model"
+)
+
+// Themes of the web UI.
+const (
+	ThemeLight = "light"
+	ThemeDark  = "dark"
+)
+
+// UISettings are the preferences of the expression browser. The browser keeps
+// a copy in its local storage, so that they apply before they are loaded.
+type UISettings struct {
+	Theme string `json:"theme"`
+	// The timeout of queries and the range of graphs, as durations like 1m.
+	// Empty values leave the defaults.
+	QueryTimeout string `json:"queryTimeout"`
+	Lookback     string `json:"lookback"`
+}
+
+func (s UISettings) validate() error {
+	switch s.Theme {
+	case ThemeLight, ThemeDark:
+	default:
+		return fmt.Errorf("unknown theme %q, must be %q or %q", s.Theme, ThemeLight, ThemeDark)
+	}
+	if s.QueryTimeout != "" {
+		if d, err := model.ParseDuration(s.QueryTimeout); err != nil || d == 0 {
+			return fmt.Errorf("invalid query timeout %q", s.QueryTimeout)
+		}
+	}
+	if s.Lookback != "" {
+		if d, err := model.ParseDuration(s.Lookback); err != nil || d == 0 {
+			return fmt.Errorf("invalid lookback %q", s.Lookback)
+		}
+	}
+	return nil
+}
+
+// settingsStore holds the UI settings and persists them to a file, if set.
+type settingsStore struct {
+	file string
+
+	mtx      sync.Mutex
+	settings UISettings
+}
+
+// newSettingsStore returns a settingsStore with the settings persisted to the
+// file, or the defaults if it does not exist yet or is empty.
+func newSettingsStore(file string) *settingsStore {
+	s := &settingsStore{file: file, settings: UISettings{Theme: ThemeLight}}
+	if file == "" {
+		return s
+	}
+	b, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return s
+	}
+	if err != nil {
+		log.Errorf("Error reading UI settings: %s", err)
+		return s
+	}
+	var settings UISettings
+	if err := json.Unmarshal(b, &settings); err != nil {
+		log.Errorf("Error parsing UI settings file %s: %s", file, err)
+		return s
+	}
+	if err := settings.validate(); err != nil {
+		log.Errorf("Ignoring invalid UI settings in %s: %s", file, err)
+		return s
+	}
+	s.settings = settings
+	return s
+}
+
+func (s *settingsStore) get() UISettings {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.settings
+}
+
+func (s *settingsStore) set(settings UISettings) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.file != "" {
+		b, err := json.Marshal(settings)
+		if err != nil {
+			return err
+		}
+		// Write to a temporary file first so that a crash cannot leave
+		// truncated settings behind.
+		tmp := s.file + ".tmp"
+		if err := ioutil.WriteFile(tmp, b, 0644); err != nil {
+			return err
+		}
+		if err := os.Rename(tmp, s.file); err != nil {
+			return err
+		}
+	}
+	s.settings = settings
+	return nil
+}
+
+func (api *API) serveSettings(r *http.Request) (interface{}, *apiError) {
+	return api.settings.get(), nil
+}
+
+// updateSettings replaces the UI settings by the ones given as form values.
+// Missing values are reset to their defaults.
+func (api *API) updateSettings(r *http.Request) (interface{}, *apiError) {
+	settings := UISettings{
+		Theme:        r.FormValue("theme"),
+		QueryTimeout: r.FormValue("queryTimeout"),
+		Lookback:     r.FormValue("lookback"),
+	}
+	if settings.Theme == "" {
+		settings.Theme = ThemeLight
+	}
+	if err := settings.validate(); err != nil {
+		return nil, &apiError{errorBadData, err}
+	}
+	if err := api.settings.set(settings); err != nil {
+		return nil, &apiError{errorInternal, fmt.Errorf("error saving settings: %s", err)}
+	}
+	return settings, nil
+}
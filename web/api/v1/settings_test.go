@@ -0,0 +1,111 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/common/route"
+
+	"github.com/prometheus/prometheus/util/testutil"
+)
+
+func TestSettings(t *testing.T) {
+	dir := testutil.NewTemporaryDirectory("ui_settings", t)
+	defer dir.Close()
+	file := filepath.Join(dir.Path(), "ui_settings.json")
+
+	api := &API{settings: newSettingsStore(file)}
+
+	if s, _ := api.serveSettings(nil); s != (UISettings{Theme: ThemeLight}) {
+		t.Fatalf("unexpected default settings %+v", s)
+	}
+
+	post := func(v url.Values) (interface{}, *apiError) {
+		r, err := http.NewRequest("POST", "http://example.org/api/v1/settings", strings.NewReader(v.Encode()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return api.updateSettings(r)
+	}
+
+	for _, v := range []url.Values{
+		{"theme": []string{"blue"}},
+		{"theme": []string{"dark"}, "queryTimeout": []string{"10"}},
+		{"theme": []string{"dark"}, "lookback": []string{"0s"}},
+	} {
+		if _, apiErr := post(v); apiErr == nil || apiErr.typ != errorBadData {
+			t.Errorf("expected bad data error for %v, got %v", v, apiErr)
+		}
+	}
+
+	expected := UISettings{Theme: ThemeDark, QueryTimeout: "30s", Lookback: "6h"}
+	s, apiErr := post(url.Values{"theme": []string{"dark"}, "queryTimeout": []string{"30s"}, "lookback": []string{"6h"}})
+	if apiErr != nil {
+		t.Fatal(apiErr.err)
+	}
+	if s != expected {
+		t.Errorf("expected updated settings %+v, got %+v", expected, s)
+	}
+
+	// The settings are persisted.
+	if s := newSettingsStore(file).get(); s != expected {
+		t.Errorf("expected persisted settings %+v, got %+v", expected, s)
+	}
+}
+
+func TestUpdateSettingsRequiresAdmin(t *testing.T) {
+	dir := testutil.NewTemporaryDirectory("ui_settings", t)
+	defer dir.Close()
+
+	for _, enableAdmin := range []bool{false, true} {
+		r := route.New()
+		api := &API{
+			settings:    newSettingsStore(filepath.Join(dir.Path(), "ui_settings.json")),
+			enableAdmin: enableAdmin,
+		}
+		api.Register(r)
+		s := httptest.NewServer(r)
+
+		resp, err := http.PostForm(s.URL+"/settings", url.Values{"theme": []string{"dark"}})
+		if err != nil {
+			t.Fatalf("Error on test request: %s", err)
+		}
+		resp.Body.Close()
+		expected := http.StatusOK
+		if !enableAdmin {
+			expected = http.StatusServiceUnavailable
+		}
+		if resp.StatusCode != expected {
+			t.Errorf("Expected status %d with admin APIs enabled %t, got %d", expected, enableAdmin, resp.StatusCode)
+		}
+
+		// The settings can be read either way.
+		resp, err = http.Get(s.URL + "/settings")
+		if err != nil {
+			t.Fatalf("Error on test request: %s", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("Expected status %d reading the settings, got %d", http.StatusOK, resp.StatusCode)
+		}
+		s.Close()
+	}
+}
@@ -14,20 +14,29 @@
 package web
 
 import (
+	"bufio"
+	"fmt"
+	"io"
 	"net/http"
 	"sort"
+	"strconv"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/prometheus/client_golang/prometheus"
 	dto "github.com/prometheus/client_model/go"
 	"github.com/prometheus/common/expfmt"
-	"github.com/prometheus/common/log"
 	"github.com/prometheus/common/model"
+	"golang.org/x/net/context"
 
 	"github.com/prometheus/prometheus/promql"
 	"github.com/prometheus/prometheus/storage/metric"
 )
 
+// federationBufferSize is the size of the buffer the federation response
+// is encoded into before it is written out.
+const federationBufferSize = 32 * 1024
+
 var (
 	federationErrors = prometheus.NewCounter(prometheus.CounterOpts{
 		Name: "prometheus_web_federation_errors_total",
@@ -43,7 +52,9 @@ func (h *Handler) federation(w http.ResponseWriter, req *http.Request) {
 
 	var matcherSets []metric.LabelMatchers
 	for _, s := range req.Form["match[]"] {
-		matchers, err := promql.ParseMetricSelector(s)
+		// Selectors consisting of negative matchers only are fine here, as
+		// federating everything is a legitimate use case.
+		matchers, err := promql.ParseUnrestrictedMetricSelector(s)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
@@ -51,12 +62,26 @@ func (h *Handler) federation(w http.ResponseWriter, req *http.Request) {
 		matcherSets = append(matcherSets, matchers)
 	}
 
-	var (
-		minTimestamp = h.now().Add(-promql.StalenessDelta)
-		format       = expfmt.Negotiate(req.Header)
-		enc          = expfmt.NewEncoder(w, format)
-	)
-	w.Header().Set("Content-Type", string(format))
+	addExternalLabels := true
+	if s := req.Form.Get("external_labels"); s != "" {
+		var err error
+		if addExternalLabels, err = strconv.ParseBool(s); err != nil {
+			http.Error(w, fmt.Sprintf("invalid value %q for parameter external_labels", s), http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Federation shares the limit of concurrent queries, with a lower priority
+	// than rule evaluations and API queries.
+	done, err := h.queryEngine.Admit(promql.WithPriority(req.Context(), promql.PriorityFederation))
+	if err != nil {
+		federationErrors.Inc()
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	defer done()
+
+	minTimestamp := h.now().Add(-promql.StalenessDelta)
 
 	q, err := h.storage.Querier()
 	if err != nil {
@@ -74,9 +99,25 @@ func (h *Handler) federation(w http.ResponseWriter, req *http.Request) {
 	}
 	sort.Sort(byName(vector))
 
-	externalLabels := h.config.GlobalConfig.ExternalLabels.Clone()
-	if _, ok := externalLabels[model.InstanceLabel]; !ok {
-		externalLabels[model.InstanceLabel] = ""
+	var (
+		format = expfmt.Negotiate(req.Header)
+		bw     = bufio.NewWriterSize(w, federationBufferSize)
+		enc    = expfmt.NewEncoder(bw, format)
+	)
+	w.Header().Set("Content-Type", string(format))
+	defer func() {
+		if err := bw.Flush(); err != nil {
+			federationErrors.Inc()
+			logger.With("err", err).Error("federation failed")
+		}
+	}()
+
+	externalLabels := model.LabelSet{}
+	if addExternalLabels {
+		externalLabels = h.config.GlobalConfig.ExternalLabels.Clone()
+		if _, ok := externalLabels[model.InstanceLabel]; !ok {
+			externalLabels[model.InstanceLabel] = ""
+		}
 	}
 	externalLabelNames := make(model.LabelNames, 0, len(externalLabels))
 	for ln := range externalLabels {
@@ -121,7 +162,7 @@ func (h *Handler) federation(w http.ResponseWriter, req *http.Request) {
 				if protMetricFam != nil {
 					if err := enc.Encode(protMetricFam); err != nil {
 						federationErrors.Inc()
-						log.With("err", err).Error("federation failed")
+						logger.With("err", err).Error("federation failed")
 						return
 					}
 				}
@@ -141,7 +182,7 @@ func (h *Handler) federation(w http.ResponseWriter, req *http.Request) {
 			}
 		}
 		if !nameSeen {
-			log.With("metric", s.Metric).Warn("Ignoring nameless metric during federation.")
+			logger.With("metric", s.Metric).Warn("Ignoring nameless metric during federation.")
 			continue
 		}
 		// Attach global labels if they do not exist yet.
@@ -164,9 +205,108 @@ func (h *Handler) federation(w http.ResponseWriter, req *http.Request) {
 	if protMetricFam != nil {
 		if err := enc.Encode(protMetricFam); err != nil {
 			federationErrors.Inc()
-			log.With("err", err).Error("federation failed")
+			logger.With("err", err).Error("federation failed")
+		}
+	}
+}
+
+// chunkExporter is implemented by storages that can export the chunks of
+// series as a block.
+type chunkExporter interface {
+	ExportBlockWithLabels(ctx context.Context, w io.Writer, from, through model.Time, labels model.LabelSet, matcherSets ...metric.LabelMatchers) error
+}
+
+// federateChunks sends the chunks of the matched series between start and
+// end, so that a federating server can backfill the samples it missed, for
+// example after a network partition. The chunks are sent as a tar archive of
+// a block, which can be imported with the admin API.
+//
+// This is experimental.
+func (h *Handler) federateChunks(w http.ResponseWriter, req *http.Request) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	req.ParseForm()
+
+	if len(req.Form["match[]"]) == 0 {
+		http.Error(w, "no match[] parameter provided", http.StatusBadRequest)
+		return
+	}
+	var matcherSets []metric.LabelMatchers
+	for _, s := range req.Form["match[]"] {
+		matchers, err := promql.ParseUnrestrictedMetricSelector(s)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		matcherSets = append(matcherSets, matchers)
+	}
+
+	start, err := parseFederationTime(req.Form.Get("start"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid value %q for parameter start", req.Form.Get("start")), http.StatusBadRequest)
+		return
+	}
+	end := h.now()
+	if s := req.Form.Get("end"); s != "" {
+		if end, err = parseFederationTime(s); err != nil {
+			http.Error(w, fmt.Sprintf("invalid value %q for parameter end", s), http.StatusBadRequest)
+			return
 		}
 	}
+	if end.Before(start) {
+		http.Error(w, "end timestamp must not be before start time", http.StatusBadRequest)
+		return
+	}
+
+	addExternalLabels := true
+	if s := req.Form.Get("external_labels"); s != "" {
+		if addExternalLabels, err = strconv.ParseBool(s); err != nil {
+			http.Error(w, fmt.Sprintf("invalid value %q for parameter external_labels", s), http.StatusBadRequest)
+			return
+		}
+	}
+
+	exporter, ok := h.storage.(chunkExporter)
+	if !ok {
+		http.Error(w, "the storage does not support chunk federation", http.StatusNotImplemented)
+		return
+	}
+
+	done, err := h.queryEngine.Admit(promql.WithPriority(req.Context(), promql.PriorityFederation))
+	if err != nil {
+		federationErrors.Inc()
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	defer done()
+
+	var externalLabels model.LabelSet
+	if addExternalLabels {
+		externalLabels = h.config.GlobalConfig.ExternalLabels
+	}
+
+	w.Header().Set("Content-Type", "application/x-tar")
+	bw := bufio.NewWriterSize(w, federationBufferSize)
+	err = exporter.ExportBlockWithLabels(req.Context(), bw, start, end, externalLabels, matcherSets...)
+	if err == nil {
+		err = bw.Flush()
+	}
+	if err != nil {
+		// The archive may be partially written already, so the client
+		// can only tell from its truncation.
+		federationErrors.Inc()
+		logger.With("err", err).Error("chunk federation failed")
+	}
+}
+
+// parseFederationTime parses a Unix timestamp in seconds.
+func parseFederationTime(s string) (model.Time, error) {
+	t, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+	return model.TimeFromUnixNano(int64(t * float64(time.Second))), nil
 }
 
 // byName makes a model.Vector sortable by metric name.
@@ -14,8 +14,12 @@
 package web
 
 import (
+	"archive/tar"
 	"bufio"
 	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"sort"
@@ -154,6 +158,52 @@ test_metric1{foo="boo",instance="i"} 1 6000000
 test_metric2{foo="boo",instance="i"} 1 6000000
 # TYPE test_metric_without_labels untyped
 test_metric_without_labels{instance="baz"} 1001 6000000
+`,
+	},
+	"negative matchers only match all named series": {
+		params: "match[]={foo!='bar'}",
+		code:   200,
+		body: `# TYPE test_metric1 untyped
+test_metric1{foo="boo",instance="i"} 1 6000000
+# TYPE test_metric2 untyped
+test_metric2{foo="boo",instance="i"} 1 6000000
+# TYPE test_metric_without_labels untyped
+test_metric_without_labels{instance=""} 1001 6000000
+`,
+	},
+	"negative metric name matcher": {
+		params: "match[]={__name__!~'test_metric[12]'}",
+		code:   200,
+		body: `# TYPE test_metric_without_labels untyped
+test_metric_without_labels{instance=""} 1001 6000000
+`,
+	},
+	"negative matchers combined with positive ones": {
+		params: "match[]=test_metric1{foo!='bar'}&match[]={foo!~'b.*'}",
+		code:   200,
+		body: `# TYPE test_metric1 untyped
+test_metric1{foo="boo",instance="i"} 1 6000000
+# TYPE test_metric_without_labels untyped
+test_metric_without_labels{instance=""} 1001 6000000
+`,
+	},
+	"external labels are not added if disabled": {
+		params:         "match[]={__name__=~'.%2b'}&external_labels=false",
+		externalLabels: model.LabelSet{"zone": "ie", "foo": "baz"},
+		code:           200,
+		body: `# TYPE test_metric1 untyped
+test_metric1{foo="bar",instance="i"} 10000 6000000
+test_metric1{foo="boo",instance="i"} 1 6000000
+# TYPE test_metric2 untyped
+test_metric2{foo="boo",instance="i"} 1 6000000
+# TYPE test_metric_without_labels untyped
+test_metric_without_labels 1001 6000000
+`,
+	},
+	"invalid external_labels parameter": {
+		params: "match[]=test_metric1&external_labels=maybe",
+		code:   400,
+		body: `invalid value "maybe" for parameter external_labels
 `,
 	},
 }
@@ -213,6 +263,87 @@ func TestFederation(t *testing.T) {
 	}
 }
 
+func TestFederateChunks(t *testing.T) {
+	suite, err := promql.NewTest(t, `
+		load 1m
+			test_metric1{foo="bar",instance="i"}    0+100x100
+			test_metric1{foo="boo",instance="i"}    1+0x100
+			test_metric2{foo="boo",instance="i"}    1+0x100
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer suite.Close()
+
+	if err := suite.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	h := &Handler{
+		storage:     suite.Storage(),
+		queryEngine: suite.QueryEngine(),
+		now:         func() model.Time { return 101 * 60 * 1000 },
+		config: &config.Config{
+			GlobalConfig: config.GlobalConfig{
+				ExternalLabels: model.LabelSet{"zone": "ie", "foo": "baz"},
+			},
+		},
+	}
+
+	for _, params := range []string{"", "match[]=test_metric1", "match[]=test_metric1&start=60&end=0", "match[]=test_metric1&start=0&external_labels=maybe"} {
+		res := httptest.NewRecorder()
+		h.federateChunks(res, httptest.NewRequest("GET", "http://example.org/federate/chunks?"+params, nil))
+		if res.Code != http.StatusBadRequest {
+			t.Errorf("%q: got code %d, want %d", params, res.Code, http.StatusBadRequest)
+		}
+	}
+
+	res := httptest.NewRecorder()
+	h.federateChunks(res, httptest.NewRequest("GET", "http://example.org/federate/chunks?match[]=test_metric1&start=0&end=3000", nil))
+	if res.Code != http.StatusOK {
+		t.Fatalf("got code %d, want %d: %s", res.Code, http.StatusOK, res.Body)
+	}
+
+	var (
+		files int
+		meta  struct {
+			Series []struct {
+				Metric model.Metric `json:"metric"`
+			} `json:"series"`
+		}
+	)
+	tr := tar.NewReader(res.Body)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := ioutil.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hdr.Name != "meta.json" {
+			files++
+			continue
+		}
+		if err := json.Unmarshal(b, &meta); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if files != 2 || len(meta.Series) != 2 {
+		t.Fatalf("got %d series files and %d series, want 2", files, len(meta.Series))
+	}
+	for _, s := range meta.Series {
+		// External labels do not override the labels of the series.
+		if s.Metric["zone"] != "ie" || s.Metric["foo"] == "baz" {
+			t.Errorf("unexpected labels of federated series %v", s.Metric)
+		}
+	}
+}
+
 // normalizeBody sorts the lines within a metric to make it easy to verify the body.
 // (Federation is not taking care of sorting within a metric family.)
 func normalizeBody(body *bytes.Buffer) string {
@@ -0,0 +1,61 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/common/log"
+)
+
+// requestLogHandler logs every request it serves along with the status code
+// and the time it took to handle it.
+type requestLogHandler struct {
+	logger  log.Logger
+	handler http.Handler
+}
+
+func (h requestLogHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	sw := &statusResponseWriter{ResponseWriter: w, status: http.StatusOK}
+	h.handler.ServeHTTP(sw, r)
+
+	h.logger.
+		With("method", r.Method).
+		With("path", r.URL.Path).
+		With("status", sw.status).
+		With("duration", time.Since(start)).
+		With("remote_addr", r.RemoteAddr).
+		Info("HTTP request")
+}
+
+// statusResponseWriter records the status code written to the wrapped
+// http.ResponseWriter.
+type statusResponseWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusResponseWriter) WriteHeader(code int) {
+	w.status = code
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Flush implements http.Flusher if the wrapped http.ResponseWriter does.
+func (w *statusResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
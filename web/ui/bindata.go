@@ -5,13 +5,16 @@
 // web/ui/templates/config.html
 // web/ui/templates/flags.html
 // web/ui/templates/graph.html
+// web/ui/templates/remote.html
 // web/ui/templates/rules.html
 // web/ui/templates/status.html
+// web/ui/templates/storage.html
 // web/ui/templates/targets.html
 // web/ui/static/css/alerts.css
 // web/ui/static/css/graph.css
 // web/ui/static/css/prom_console.css
 // web/ui/static/css/prometheus.css
+// web/ui/static/css/storage.css
 // web/ui/static/css/targets.css
 // web/ui/static/img/ajax-loader.gif
 // web/ui/static/img/favicon.ico
@@ -19,6 +22,9 @@
 // web/ui/static/js/graph.js
 // web/ui/static/js/graph_template.handlebar
 // web/ui/static/js/prom_console.js
+// web/ui/static/js/promql_editor.js
+// web/ui/static/js/settings.js
+// web/ui/static/js/storage.js
 // web/ui/static/js/targets.js
 // web/ui/static/vendor/bootstrap-3.3.1/css/bootstrap-theme.min.css
 // web/ui/static/vendor/bootstrap-3.3.1/css/bootstrap.min.css
@@ -109,7 +115,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _webUiTemplates_baseHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x56\xdd\x6e\xdc\x36\x13\xbd\xcf\x53\xcc\xc7\x04\x5f\xec\x0b\xad\x50\xe4\xa6\x88\x25\x15\x89\xe3\x34\x06\x82\x66\x61\x6f\x83\x16\x45\x61\xcc\x4a\x23\x89\x0e\x45\x2a\xe4\x48\xf5\x62\xb1\xef\x5e\x70\xf5\x53\xad\xec\xb5\xfb\x87\x5e\x89\xa2\x0e\xcf\x0c\xcf\x9c\xa1\x18\xfd\xef\xdd\xa7\xf3\xd5\xcf\xcb\x0b\x28\xb9\x52\xc9\xb3\xc8\x3f\x40\xa1\x2e\x62\x41\x5a\x24\xcf\x00\xa2\x92\x30\xf3\x03\x80\xa8\x22\x46\x28\x99\xeb\x80\xbe\x36\xb2\x8d\xc5\xb9\xd1\x4c\x9a\x83\xd5\xa6\x26\x01\x69\xf7\x16\x0b\xa6\x3b\x0e\x3d\xd5\x19\xa4\x25\x5a\x47\x1c\x37\x9c\x07\xdf\x8a\x9e\x87\x25\x2b\x4a\x96\xd6\x54\xc4\x25\x35\x0e\x56\xb2\x22\xb8\x26\x2b\xc9\xc1\xb9\x51\x8a\x52\x96\x46\x03\xea\x0c\x96\xd6\xa4\xe4\x9c\xd4\x85\x07\xb4\x64\xa3\xb0\x5b\xde\x51\x29\xa9\xbf\x80\x25\x15\x0b\x57\x1a\xcb\x69\xc3\x20\x53\xa3\x05\x94\x96\xf2\x58\x6c\xb7\x50\x23\x97\x4b\x4b\xb9\xbc\x83\xdd\x2e\x74\x8c\x2c\xd3\x50\x56\x45\x98\x63\xeb\xa1\x0b\x99\x9a\xef\xda\x78\xbb\x85\x75\x23\x55\xf6\x99\xac\xf3\xb1\x77\xbb\x21\x5b\x97\x5a\x59\x33\x38\x9b\x1e\xe7\x6b\x49\x67\xc6\x86\xb7\x2e\xbc\xfd\xda\x90\xdd\x2c\x2a\xa9\x17\xb7\xee\x08\x6f\x14\x76\x9c\x7f\x3d\xc0\xda\x18\x76\x6c\xb1\x0e\x5e\x2d\x5e\x2d\xbe\xf1\x01\xc7\xa9\x3f\x1b\x73\x22\x1c\x6f\x6a\xea\xcb\x95\x3a\x27\x7a\x21\x79\xa3\xc8\x95\x44\xfc\x94\x8a\x47\x92\x4a\xdd\x3c\xab\xd4\x1d\x4b\xeb\xdf\x4b\xc6\x47\xad\x47\x4b\x3d\x16\x72\xaa\x7a\x97\x00\x40\x8b\x16\x96\x6f\x56\x1f\x6e\x96\x57\x17\xef\x2f\x7f\x82\x18\xee\x05\x12\x67\x13\xec\xdb\x1f\x2f\x3f\xbe\xbb\xf9\x7c\x71\x75\x7d\xf9\xe9\x87\x1e\x3d\x8f\x34\xe0\x5f\x9c\xe4\x8d\xee\x1c\x7d\x72\x0a\xdb\x7e\xd6\xcf\xbf\xfc\x25\x43\xc6\x80\x4d\x51\x28\xbf\x77\x63\x14\xcb\x5a\xfc\xfa\xf2\x74\xd1\x8f\x4f\x4e\x7b\xf8\xae\x1b\xcc\xca\xb8\xdd\x32\x55\xb5\x42\x26\x10\xbe\x51\x05\x2c\x76\x3b\xdf\xb5\x61\xd7\xb6\x7e\xb8\x36\xd9\xa6\xd7\x59\x63\x0b\xa9\x42\xe7\x62\xa1\xb1\x5d\xa3\x85\xee\x11\x48\xdd\x92\x75\x34\xbc\xe6\xf2\x8e\xb2\x80\x4d\x2d\x06\x7d\xa2\x4c\x8e\x4b\x7d\x9f\xa3\xd4\x64\x83\x5c\x35\x32\x1b\x31\x87\xa8\x9e\xca\xe7\x41\x76\x82\xf1\x19\x35\xcc\x46\xf7\x05\xef\x5e\xc4\x6c\x59\x27\x09\xa4\x46\x29\xac\x1d\x65\x02\x0e\x94\x1a\xe6\x87\x69\xb4\x05\x71\x2c\x9e\x77\xab\x05\xa0\x95\x18\xd0\x5d\x8d\x3a\xa3\x2c\x16\x39\x2a\x8f\xdd\xcf\xfa\xec\xad\x51\x63\xa8\x83\xd4\xbc\x2f\x6a\xd4\x43\x32\xce\x06\x46\xab\x8d\x48\x56\x5d\x3a\x1a\x5b\x59\xa0\xaf\x64\x14\x7a\xdc\x23\x4b\xfd\xd1\x12\xec\xe9\xff\x2b\x68\x14\x76\x52\x1e\xcc\xe1\x4c\xd7\xb5\x45\x9d\x1d\x6d\x25\x31\x39\x94\xa3\x10\x27\x85\x0d\x33\xd9\xce\xea\x2c\xb3\x51\xc2\x59\x90\xa1\x3a\x63\xf9\x0e\xcb\xdf\xa8\x09\x7e\xb0\xdc\x64\xa8\x28\xe7\x59\x55\xb6\xdb\x17\xa9\xd1\xce\x28\x72\xf0\x3a\x86\x61\xbc\x44\x2e\xf7\x7e\x9f\x22\x65\x0e\x23\x78\xf6\x31\x52\x32\x89\x70\xdc\xfd\x04\x26\x92\xf3\x7e\xec\xf7\x1d\x85\x4a\xce\x13\x00\xd2\x19\x3c\xce\x37\x53\x13\x15\x59\x76\x22\x79\xb3\x7f\x3e\xcc\xfb\x38\x43\x61\xb1\x2e\x45\xf2\xbd\x7f\x1c\x5d\x3f\x88\x99\x59\x53\x67\xe6\x37\x3d\x93\x6e\x6f\x82\x8e\xff\xb9\x98\x63\xfb\x86\x9a\x75\xd7\xc8\x04\xd6\xa8\x49\x8b\xee\xfb\xa7\x44\x57\x9b\xba\xa9\x63\xc1\xb6\xa1\x23\xad\x96\x5c\x33\x72\xe3\x0e\xcd\x9b\xa2\x25\x1e\x9d\x7b\xe0\xaf\x7b\xce\x18\x13\xac\x48\x37\xf7\x76\xf4\x94\x6e\x6e\x1f\x5d\x24\x57\x8d\x66\x7f\xb5\xf8\x3f\x56\xf5\x19\xbc\xf5\xe7\x33\x5c\xea\xdc\xd8\xaa\x6f\xe2\x87\x24\x7d\x9a\x3e\x57\x58\x38\xef\x98\xaa\x42\x9d\x05\x1f\xa5\x26\x78\xef\xe7\xfe\x2e\x61\x6a\x74\x2e\x8b\xbd\x07\x73\x59\x34\xf6\x1f\x65\x67\x1b\x45\xfb\xbd\x1f\x35\xf3\xd3\x1c\xdd\x81\xea\x44\xb2\xea\x06\xc7\x78\xa2\xb0\x51\x33\x43\x3e\x68\xf1\x63\x8e\xf4\x97\x49\xf7\x3a\x9c\xfe\xb8\xa5\x09\x33\x93\x3a\x01\xc3\xa1\x7e\xb3\x56\xa8\xbf\x88\xe4\x03\xa9\xfa\x9e\x69\xe6\xe1\x0e\x13\x3a\x38\xb6\x26\x2f\x51\xa8\xb1\x7d\xe0\x17\xda\xdf\x60\xff\xf8\x8b\x76\xff\xce\x28\xec\xae\xc7\xbf\x07\x00\x00\xff\xff\x54\x18\xdc\x13\x2f\x0b\x00\x00")
+var _webUiTemplates_baseHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc5\x58\x51\x6f\xdb\x36\x10\x7e\xef\xaf\xe0\xd8\xa2\x6d\x80\xc9\x5a\xd0\x3e\x14\x89\xac\xa1\x4d\xdb\x35\x40\xb1\x66\x89\x57\x6c\x18\x86\x80\x96\x68\x89\x0d\x45\xaa\x24\xe5\xc5\x33\xfc\xdf\x77\x24\x25\x45\x92\xe5\x38\x4d\x8b\xf5\xc1\x12\x49\x1f\xef\x8e\xdf\x1d\x3f\x1e\x15\xfd\xf0\xfa\xc3\xc9\xec\xcf\xb3\x37\x28\x37\x05\x8f\x1f\x44\xf6\x85\x38\x11\xd9\x14\x53\x81\xe3\x07\x08\x45\x39\x25\xa9\x6d\x40\xb3\xa0\x86\x80\xa4\x29\x03\xfa\xb9\x62\xcb\x29\x3e\x91\xc2\x50\x61\x82\xd9\xaa\xa4\x18\x25\xbe\x37\xc5\x86\x5e\x9b\xd0\xaa\x3a\x46\x49\x4e\x94\xa6\x66\x5a\x99\x45\xf0\x02\xd7\x7a\x0c\x33\x9c\xc6\x67\x4a\x82\xc2\x9c\x56\x1a\xcd\x58\x41\xd1\x05\x55\x8c\x6a\x74\x22\x39\xa7\x89\x61\x52\x20\x22\x52\x04\x52\x09\xd5\x9a\x89\xcc\x0a\x2c\xa9\x8a\x42\x3f\xdd\xab\xe2\x4c\x5c\x21\x45\xf9\x14\xeb\x5c\x2a\x93\x54\x06\x31\xf0\x03\xa3\x5c\xd1\xc5\x14\xaf\xd7\xa8\x24\x26\x3f\x83\x0e\xbb\x46\x9b\x4d\xa8\x0d\x31\x2c\x09\x59\x91\x85\x0b\xb2\xb4\xa2\x13\x78\xfc\xbc\x9c\x82\xe4\xbc\x62\x3c\xfd\x48\x95\xb6\xb6\x37\x9b\xc6\x5b\x9d\x28\x56\x1a\xa4\x55\xb2\x5b\xdf\x92\x8a\x54\xaa\xf0\x93\x0e\x3f\x7d\xae\xa8\x5a\x4d\x0a\x26\x26\x9f\xf4\x0e\xbd\x51\xe8\x75\x7e\xb9\x81\xb9\x94\x46\x1b\x45\xca\xe0\xd9\xe4\xd9\xe4\xd0\x1a\x6c\x87\xee\x6a\xb3\x03\x9c\x81\xb8\xd5\xe1\x4a\xb4\xc6\x35\x90\x66\xc5\xa9\xce\x29\x35\xfb\x50\xdc\xe1\x14\xa8\x1a\x78\x05\x23\xb7\x42\xfc\x2d\x9c\xb1\x56\xcb\x36\xa5\x6e\x33\xd9\x45\xdd\x3b\x80\xd0\x92\x28\x74\xf6\x72\xf6\xee\xf2\xec\xfc\xcd\xdb\xd3\x3f\xd0\x14\x6d\x19\xc2\xc7\x1d\xd9\x57\xbf\x9f\xbe\x7f\x7d\xf9\xf1\xcd\xf9\xc5\xe9\x87\x5f\x6b\xe9\xa1\xa5\x46\xfe\xd1\xd3\x45\x25\x7c\x46\x3f\x3d\x40\xeb\x7a\xd4\x8e\x3f\xf9\x2b\x25\x86\x04\x46\x66\x19\xb7\x6b\x97\x92\x1b\x56\xe2\xbf\x9f\x1c\x4c\xea\xf6\xd3\x83\x5a\x7c\xe3\x1b\xf7\x48\x1d\xc8\x11\xd8\x81\x06\x76\x90\xbe\x73\x7a\xac\xd7\x86\x16\x25\x27\x86\x22\x6c\x09\x00\xa3\xc9\x66\x63\xd9\x20\xf4\x74\x60\x9b\x73\x99\xae\x6a\x37\x04\x59\xa2\x84\x13\xad\xa7\x18\x9a\x73\xc0\xc7\xbf\x02\x26\x60\xc7\x6a\xda\x74\xc1\x31\x9a\xc2\x72\x4b\xdc\xe0\x1e\xa5\xac\x9d\x6a\xf9\x83\x30\x41\x41\x8e\x57\x2c\x6d\x65\xfa\x52\xb5\x2a\xeb\x07\x55\x1d\x19\xeb\x51\x65\x0c\xac\xc7\x27\x92\xef\xe0\xc1\x34\x0f\x35\x50\x15\xe7\xa4\xd4\x14\x16\xd6\x8b\x40\x33\xde\x0c\x13\x95\x01\x79\xe1\x87\x7e\x36\x46\x44\x31\x12\xd0\xeb\x12\x98\x89\xa6\x53\xbc\x20\xdc\xca\xba\x51\xeb\xbd\x92\xbc\x35\xd5\x73\xcd\x86\x0a\x26\x35\xce\x68\x15\x48\xc1\x57\x38\x9e\x79\x77\x60\x06\xcb\x88\xcd\x10\x88\x03\xc8\xdd\x32\xd5\x52\x56\xe0\xd4\xff\x5f\xa2\x51\xe8\xa1\xec\x8d\x91\x01\xae\x73\x05\x90\xec\xdc\xa2\xb8\x43\xf6\x51\x48\x3a\x81\x0d\x21\xb2\x83\x38\xb3\xb4\x85\x70\x60\xa4\x89\x4e\x1b\xbe\x7e\xf8\x2b\xde\x91\x6f\x52\xae\xd3\xe4\x74\x61\x06\x51\x59\xaf\x1f\xc1\xca\xb5\x04\x8e\x41\x47\x53\xd4\xb4\xcf\xc0\x7b\x97\xef\x5d\x49\xb6\x40\xad\xf0\xe0\x4f\x20\xb0\x18\x20\x69\x56\xdf\x11\xc3\xf1\x49\xdd\xb6\xeb\x8e\x42\x10\x1c\xa8\x45\x40\xa2\xe8\x76\x7d\x03\x34\x09\xa7\xca\x68\x1c\xbf\x74\xef\x71\xbd\xb7\x6b\xc8\x80\x98\x73\x1c\xff\x62\x5f\x3b\xe7\x37\x60\xa6\x4a\x96\xa9\xfc\x47\x0c\xa0\x73\x49\xe0\xf5\x3f\xc4\x43\xd9\x7a\x43\x0d\x76\x57\xab\x09\xc1\x46\xe9\x6c\x51\xb7\x7f\x72\xa2\x4b\x59\x56\x25\xd0\xa0\xaa\xe8\x8e\xad\x16\x5f\x00\xa7\x41\xc1\xd0\x4b\xde\x84\x28\x38\x1e\x9a\xcc\xed\xe5\xd7\x56\x66\xb4\x0e\x16\x54\x54\x5b\x2b\xda\x87\x9b\x76\xd6\x71\x7c\x5e\x09\x63\x4b\x96\xc7\xa4\x28\x8f\xd1\x2b\x4b\xa6\xe8\x54\x2c\xa4\x2a\xea\x4d\x3c\x06\xe9\x7e\xf5\x0b\x4e\x32\x6d\x33\xa6\x28\x60\xd5\xc1\x7b\xe0\x42\xf4\xd6\x8e\xdd\x57\x21\xe4\xe1\x82\x65\x2e\x07\xe1\x5d\xa9\xaf\xf2\x4e\x55\x90\xc5\x76\xed\x3b\x93\x79\xbf\x0e\x4f\xa8\xa0\x65\xe6\x1b\xf7\xd5\xa3\x8d\x54\x24\x73\xf9\xe0\x1a\xe8\x84\xa8\x94\x09\xc2\x99\x59\xdd\x7b\x7d\xb4\x90\x86\x06\xad\xea\x73\xd7\x47\xb5\x85\x5d\x5a\xa3\xb0\xe2\x83\xad\x33\xba\x19\x77\xed\x1d\x5b\x4e\xeb\xa3\xb0\x5b\xba\x30\x19\xa6\x32\x81\x0a\xa8\x39\x7e\x2e\xe7\x50\x92\x5f\xe1\xf8\x1d\xe5\xe5\x56\x7a\x0f\xcd\x0d\x1d\xda\x4b\x8b\x8a\x65\xf9\x90\x17\x7b\x48\x3d\x1c\x6c\xe3\x42\xa6\x84\x0f\x4f\xc8\xa6\xc4\xb8\xf4\xff\xc6\x17\x75\x7f\x0c\xb7\xbe\x8b\xbd\x33\xa0\xd3\x89\x42\x70\xb0\xa9\xd6\x3a\x05\x80\x33\x80\x16\x70\xfc\x63\x77\x56\x0c\x2c\x03\x6a\x73\x06\x9c\x71\x3d\xc5\xc1\x61\xc3\x34\x29\x23\x5c\x66\x35\xa9\x70\x32\xa7\x70\xc7\x48\xe7\xab\xce\x64\x77\xa9\x18\x2d\x4b\x9c\xda\xa0\xd1\x50\xeb\x93\x49\x05\x0c\x62\x76\xd4\x28\x7e\x4a\x7d\x1f\xea\x1f\x52\x96\x24\x1a\x31\xdb\x0e\xe0\xda\xc2\xfe\xb5\x95\x0f\x1f\xc6\x60\x4b\xe1\x48\xcd\xb3\xb7\xee\x49\xb8\x6c\xcb\x99\x94\xe9\x82\xb5\xea\xba\x68\xc0\x55\xce\xc9\xc5\x9e\x58\x3d\x21\xb3\x34\xa5\xa2\xa6\xe3\xf8\xb1\x25\x3c\x7d\xdc\x92\xec\x76\x4d\xe0\x5c\xc9\x9f\xf7\x5d\xf6\xb0\xf6\xe3\x54\x43\x7d\x93\x21\xf9\xf3\x61\x4a\xf7\xaa\x82\x71\x30\x6c\xf9\xb9\x0d\x45\x47\xcc\x1d\x93\xc8\x3d\x83\x14\xae\xb4\x80\x1c\x72\xd7\x09\x9b\x0e\x1a\x8a\xdb\xd5\x11\x12\x52\xd8\x45\x6f\xdb\xeb\xab\x72\x81\xca\x94\xac\xca\xd1\x23\xc3\x22\x88\x40\xa6\xbb\xc6\x9c\x16\xf4\x26\x08\x92\x07\xba\x08\x9e\xa3\xba\x48\xf4\xa0\x03\x0b\x5a\x29\xd8\x1d\xb6\x37\xa2\xb8\x57\x1b\x3b\x0d\x2f\x46\xec\xdb\x52\x8e\xda\x2b\x73\xcf\xdb\xda\xd2\x10\x7a\xef\x96\x20\x85\xbd\x6e\xb8\xce\x98\x42\x50\x29\x4b\x77\x61\x59\x12\x5e\x81\x28\xf7\x1c\xf1\xde\xbe\xa2\xd0\xff\x77\xa7\x89\x29\x51\x40\x5c\xaf\xe1\x79\xdb\x34\xc8\x2a\xb7\x82\x11\x0c\x46\x43\xf3\xed\xe3\xe5\x6e\xed\x97\x36\xc7\x65\x65\xf6\xc6\xed\x37\x2b\x8d\x6a\xe9\x6f\x10\x3f\x26\xca\xca\x74\x2e\xc0\xf8\x0e\xa1\x1c\x78\xec\x43\xea\x06\x67\xcd\x18\xe4\x78\x42\x73\xc9\x81\x34\xec\x44\xfb\xf5\x04\xa5\x74\x41\x2a\x6e\x7e\x44\x74\x92\x4d\xd0\xb3\x9f\x34\xfe\x7e\xa0\x73\x29\xaf\xe6\x24\xb9\xda\x8b\xb7\x2b\x54\x91\xb2\x9b\xf8\x3b\xa1\x7d\xe3\xaa\x07\xfa\xa6\xdf\x03\xf9\x30\xff\x0a\x38\xef\x44\x7b\x0b\x09\x45\xc9\x17\x9e\x01\x73\x23\x10\xfc\x82\x3a\xf6\xe3\xa7\x41\x7c\x42\x44\x42\xf9\x4e\x66\xef\x19\xd0\xd5\xbc\x60\x66\xcb\x40\xa9\x58\x41\x14\xd0\xf2\x05\x59\xd2\x71\x4d\x5b\x8b\x8c\x42\x0b\xfb\x5d\xca\x01\xd7\xdc\xfa\x3c\xd1\x9c\xb2\xed\x17\x0a\xff\x5d\x22\x0a\xfd\x27\xcd\xff\x00\x2a\x4d\x0a\x5f\xe3\x14\x00\x00")
 
 func webUiTemplates_baseHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -124,7 +130,7 @@ func webUiTemplates_baseHtml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "web/ui/templates/_base.html", size: 2863, mode: os.FileMode(436), modTime: time.Unix(1506011184, 0)}
+	info := bindataFileInfo{name: "web/ui/templates/_base.html", size: 5347, mode: os.FileMode(436), modTime: time.Unix(1791993733, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -189,7 +195,7 @@ func webUiTemplatesFlagsHtml() (*asset, error) {
 	return a, nil
 }
 
-var _webUiTemplatesGraphHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x95\xc1\x92\x9b\x30\x0c\x86\xef\x79\x0a\x8f\xef\x84\x43\xae\x21\x9d\x9e\x7a\xed\xa9\xd7\x1d\x61\x2b\xb5\x12\x63\x53\x4b\xb0\x21\x0c\xef\xde\x21\x59\x48\x3a\xd3\xdd\xee\x4e\xc3\x5e\x40\xd8\xb2\xfe\x4f\xb6\xb0\xfa\xde\xe2\x9e\x02\x2a\xed\x10\xac\x1e\x86\x95\x52\x4a\x6d\x3d\x85\xa3\x92\xae\xc6\x42\x0b\x9e\x24\x37\xcc\x5a\x25\xf4\x85\x66\xe9\x3c\xb2\x43\x14\xad\x5c\xc2\x7d\xa1\xfb\x5e\xd5\x20\xee\x7b\xc2\x3d\x9d\xd4\x30\xe4\x2c\x20\x64\xc6\x35\xf9\xcf\x04\xb5\x5b\x1b\xe6\x2f\x6d\xd1\xf7\xaa\x6c\xc8\xdb\x1f\x98\x98\x62\x50\xc3\xa0\x77\xab\xc7\xc9\xb5\x18\x6c\x4c\x79\x22\x73\x64\x07\xcf\xb3\xb1\xae\x28\xbc\x45\xf0\x68\x00\x8c\x01\xd8\x42\xc8\xca\x18\x85\x25\x41\x9d\x59\x10\x14\xaa\xb0\x26\x73\xc4\x94\xbf\x36\xf1\x2f\xd2\x2b\x2a\x9b\x44\xb5\x28\x4e\xe6\xfd\x7b\xf1\xf2\x6d\x37\xeb\x76\xb3\x3e\xbc\x26\xb0\xcd\xaf\xb1\x77\x8f\x10\xf2\xd0\xc5\x46\x2e\x29\x2d\x29\xf8\xc7\x29\x2f\x20\x54\xc5\x0a\x83\xbc\xbc\x3e\x45\x24\x1b\x0b\xe2\x1c\x03\x66\xcf\x24\x6e\x2c\x11\x58\x4a\xf7\x3f\x4b\x75\x01\xa2\x59\x6f\x93\x8d\x7f\x23\x8c\x97\xd2\x5f\x07\x97\x02\xd8\x37\xe7\x73\x77\x7d\xbe\x27\xfc\xc7\x8f\xba\x61\x01\xe3\x70\x36\x96\x4a\xe4\xc0\xf9\xe1\x57\x83\xa9\x5b\x33\x7a\x34\x42\x71\x61\x19\x17\xe5\x88\x1d\x3f\x76\xd7\x0e\x53\x0b\xf9\x68\x54\xb2\x85\xbe\xac\x7c\x12\xac\x6a\x0f\x82\xfa\xfe\x7e\x3f\x65\x0e\x82\xf5\x58\x42\xe2\x6c\xf6\xb8\x0b\xd6\xf7\x18\xec\x30\xac\x56\xb7\x06\x69\x62\x10\x0c\x32\xf7\x48\x4b\xed\x9d\xcc\x38\x0b\x14\x30\x69\x65\x3c\x30\x17\x7a\x1e\xc9\xf6\xbe\x21\x3b\xb5\x9a\xdc\x52\xbb\xbb\x45\x78\xd3\xf9\xea\xb3\xdb\x52\xa8\x1b\x99\x5c\x4b\x09\xaa\x94\x90\xd5\x89\x2a\x48\xdd\x94\x17\x37\x65\x45\xa2\x55\x0b\xbe\xc1\x42\x7f\xb5\x56\x7d\x1b\xc9\xf4\x05\x12\xac\x7d\xba\x80\x8e\x49\xde\x08\xae\xe6\x94\xec\xef\x00\x00\x00\xff\xff\x1e\x45\xed\x26\x0d\x08\x00\x00")
+var _webUiTemplatesGraphHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbd\x55\x4d\x8f\x9b\x30\x10\xbd\xef\xaf\xb0\x7c\x07\x0e\x7b\x0d\xa9\xaa\x1e\x7a\xad\x54\xa9\xd7\xc8\xe0\x21\x38\x31\x36\x3b\x1e\xb2\x21\x28\xff\xbd\x03\x2c\x2c\x49\xb5\xdb\x5d\x35\x69\x24\x92\x19\x3c\xf3\xde\x7c\xc5\xd3\x75\x1a\x0a\xe3\x40\xc8\x12\x94\x96\xe7\xf3\x83\xe0\xcf\xca\x1a\xb7\x17\xd4\xd6\x90\x4a\x82\x23\x25\x79\x08\x52\x20\xd8\x54\x06\x6a\x2d\x84\x12\x80\xa4\x28\x11\x8a\x54\x76\x9d\xa8\x15\x95\x3f\x58\x31\x47\x71\x3e\x27\x81\x14\x99\xbc\xf7\x49\xb6\xa8\xea\x32\x66\xe9\xcb\x21\x65\xbb\xac\x31\x56\xff\x02\x0c\xc6\x3b\xb6\x94\xeb\x87\xdb\xd1\x1d\xc0\x69\x8f\x09\x9a\x7c\x1f\x4a\xf5\x3c\x0b\x71\x65\xdc\x7b\x11\xdc\x3a\x00\xf0\x4e\x05\xad\x5c\x94\x79\x4f\x81\xb8\x00\x91\x56\x04\x64\x2a\xa8\x39\x24\xc0\xe4\xad\x83\xbf\x45\x3a\x86\x1a\x72\x34\x35\x89\x80\xf9\xc7\x6b\xf1\xa2\xeb\xc7\xf8\xf0\x18\xef\xde\x22\x58\x25\x23\xf6\xfa\x16\x44\x56\xb5\xbe\xa1\x21\xa5\x7b\x12\x5e\x74\xf9\x0e\x44\x95\xaf\xc0\xd1\xcb\xcf\x7f\x21\x89\xfa\x81\x38\x79\x07\xd1\xb3\xa1\xb2\x1f\x11\x75\x2f\xde\x7f\x1c\xd5\x3b\x44\x54\x34\xa7\x53\x3b\x7e\x7f\x04\xfe\xf3\x95\x6e\x58\xcf\x4b\x98\x85\x7b\x25\xb2\x0b\xc9\xee\xa9\x01\x6c\xe3\x00\x16\x72\x62\xc4\xfb\xd2\x94\x9e\xf6\xd0\x86\xdb\x56\x8d\xe1\x6b\xf4\xd5\x93\xdd\x80\x36\xe4\xf1\xa6\x29\xec\xa6\xf5\xf0\xd9\x90\x8d\x4e\xe5\xe0\xb9\x21\xa8\x6a\xcb\x83\x29\x97\x77\xf7\x31\x2a\x95\xd3\x16\x32\x85\x21\x9a\x2d\x16\x60\x5d\xc7\xc5\xe3\x4d\xc7\xc2\xb4\xfc\x72\xef\x88\xff\x7b\xf3\xfe\xd3\xe6\xb0\xa0\xe9\x4f\x15\xdb\xa1\x14\xb9\x55\x21\xa4\x72\x7e\x13\x15\xb6\x31\x7a\x5a\x23\x09\xfb\xad\x5f\x11\xde\x35\xbe\xb4\x29\x3c\x56\x91\x71\xbc\x87\x60\x3e\x67\x0b\xe3\xea\x86\x26\x9b\x8c\x9c\xe0\x27\xaa\xd1\x54\x0a\xdb\x29\xe9\xd0\x64\x95\xe1\xed\x74\x50\xb6\x61\xf5\xab\xd6\xe2\x7b\x1f\xb6\x1c\x32\x50\x5a\x6f\x86\x2c\x96\xb0\x59\x43\xc4\x35\x1e\xfd\x47\x45\x5e\xb3\x70\x69\x54\x63\x69\x44\xe1\x2b\x16\x61\xd3\x6f\x49\x66\x35\x64\xd9\xed\x1b\x02\xd7\x55\x28\x11\x4a\x8f\x24\xc6\x0d\xea\x05\x95\x20\x06\xbe\x20\x7c\xc1\x9a\x09\xdc\xfd\x2d\xc4\x72\xfd\xb3\xc7\x58\x25\x23\xdd\x1f\x39\xbe\x36\x50\x5e\xd4\xa4\x2f\x1e\x7a\x7b\x1d\xc6\xa6\x41\xdb\x6f\x68\xa5\xbd\xb3\xed\x02\x2d\xd4\xca\x4d\x00\x3d\x1a\x5f\x5c\x6e\xdb\x77\xee\xca\x1f\x10\x3d\x0e\x53\xc1\x0e\x73\x47\x16\x0d\x1c\xc5\x69\x56\x7e\x03\xaa\x69\xfd\xb5\x28\x09\x00\x00")
 
 func webUiTemplatesGraphHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -204,7 +210,27 @@ func webUiTemplatesGraphHtml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "web/ui/templates/graph.html", size: 2061, mode: os.FileMode(436), modTime: time.Unix(1505729492, 0)}
+	info := bindataFileInfo{name: "web/ui/templates/graph.html", size: 2344, mode: os.FileMode(436), modTime: time.Unix(1791993947, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _webUiTemplatesRemoteHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x85\x54\xc1\x8a\xdb\x30\x10\xbd\xe7\x2b\xa6\x3e\xb5\x07\x27\xb0\x67\xaf\x4f\xdd\x85\xc2\x12\x4a\xd2\x5e\x7a\x29\x8a\x35\x49\x04\x8a\x64\x46\xca\xee\x16\xe1\x7f\xef\x48\x96\x1d\x27\x38\xe4\x22\x78\x7a\x4f\xa3\xd1\xbc\xd1\x84\x20\x71\xaf\x0c\x42\x71\x44\x21\x8b\xae\xab\xbe\x94\x25\x18\xf5\x09\x65\x59\x87\x80\x46\x76\xdd\x62\x11\x46\x55\x63\x8d\x47\xe3\x59\xb8\x00\xa8\xa4\x7a\x87\x46\x0b\xe7\x9e\x13\x21\x58\x42\xe5\x5e\x9f\x95\x2c\x6a\xe6\x59\x71\x7c\x02\x25\x9f\x0b\xc2\x93\xf5\xe8\xbc\x25\x71\xc0\xa2\xde\x24\x08\xdb\x1e\x57\xab\xe3\x53\x2f\x0f\x41\xed\xc1\x58\x0f\xcb\x14\x9f\xcf\xb7\xf5\xda\x42\x7f\x1a\x3e\x48\xf1\xca\x29\xb5\x56\x19\xef\x40\x10\x02\x5f\xbb\x57\x87\x33\xa1\x5c\x56\xab\x76\x88\x82\xda\xe1\x10\xc1\x8b\x9d\xc6\x21\xcb\x1e\xa4\xb5\xe4\xa3\x12\x8d\x43\x99\xf1\xce\x92\x44\x1a\xa1\xf3\xa4\xda\x11\x1d\xed\x3b\x52\x7e\x54\x0c\x1a\xab\x35\xa0\x88\xe9\x02\x12\x5d\xbf\xe4\x34\xab\x15\x83\x1b\x6e\x7b\x14\x24\xdd\x1c\xf3\x93\x5f\xa7\xcc\x01\xb6\xe2\xd4\x6a\x9c\x95\x6c\xcf\x4d\x83\x28\x51\xce\x91\xaf\x42\xe9\x79\xe6\x3b\xd9\xb6\x9d\xa7\x36\xc8\x4f\x9d\xbf\xec\x4d\x38\x0f\xe9\x46\xe7\xf6\x67\x0d\x5b\xce\xef\xae\xee\x85\xc8\xd2\x35\xcb\x68\xac\x4c\x64\x26\x55\xab\xfc\xce\xca\x7f\x17\x69\x08\x24\xcc\x01\x47\xeb\x67\xeb\x2a\xb9\x27\x97\xbf\x37\x6f\xdc\xa7\x3b\xaa\x2b\xd7\x0a\x33\x58\xab\xc5\x0e\x35\xa4\xb5\xe4\x6e\x15\x67\xed\x8b\xa8\x5e\x8b\x13\xf7\x42\xb5\x8a\xda\x9a\x73\x90\x33\x11\x7b\x43\xa2\x6a\x96\xce\xae\x64\x53\xee\xca\x46\x67\x1e\x09\x7b\x97\x1e\xa9\xb2\x63\x8f\x64\xd9\xbd\x7b\x3c\x7f\xa8\x65\x74\x27\x3a\xb7\xfc\xe1\xfe\x20\xd9\xae\x5b\x23\x77\xf3\xf0\x4d\x42\x70\xca\x34\x78\x91\x75\x1d\x88\x83\xcd\x9f\x7f\x2e\xea\x04\xe6\x3f\x9b\xce\x26\xff\x27\xee\x25\xf9\xd4\x22\xa1\x91\x3c\xa4\xb5\x94\xd1\x6c\x02\xe7\x85\xc7\xbf\x8a\xeb\xdb\x08\x9e\x06\xc9\xb1\x49\xac\x6c\xdb\x55\xc8\xaf\x57\x09\x27\xe1\x2f\x15\x3d\x8e\x59\x7f\xbb\xc9\xad\x1f\x60\x93\x7c\xae\x9e\x33\x6d\xcf\x5b\x35\x73\x97\x06\x65\x10\xc7\xc0\x38\x5f\xb2\xae\x5a\xf1\x04\xac\x17\xc3\xc6\x7f\xc3\xa8\x18\x5c\x4d\x05\x00\x00")
+
+func webUiTemplatesRemoteHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_webUiTemplatesRemoteHtml,
+		"web/ui/templates/remote.html",
+	)
+}
+
+func webUiTemplatesRemoteHtml() (*asset, error) {
+	bytes, err := webUiTemplatesRemoteHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "web/ui/templates/remote.html", size: 1357, mode: os.FileMode(436), modTime: time.Unix(1791984229, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -249,7 +275,27 @@ func webUiTemplatesStatusHtml() (*asset, error) {
 	return a, nil
 }
 
-var _webUiTemplatesTargetsHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x56\x5f\x8f\xe3\x34\x10\x7f\xef\xa7\x18\x85\x0a\x81\x74\x69\xa4\x93\x78\x59\x9c\x20\x01\x27\x1d\xd2\x0a\x2d\xb7\x77\x3c\xf0\x72\x72\xe2\x69\xe3\x5d\xaf\x1d\xec\x49\x75\x95\xcf\xdf\x1d\xd9\x49\xfa\x67\xb7\xe9\xc2\xc2\x89\x97\xb4\xf6\xcc\xfc\x3c\xbf\xf1\xfc\xb1\xf7\x02\xd7\x52\x23\x64\x2d\x72\x91\x85\xb0\x60\x4a\xea\x7b\xa0\x5d\x87\x65\x46\xf8\x89\x8a\xc6\xb9\x0c\x2c\xaa\x32\x73\xb4\x53\xe8\x5a\x44\xca\xa0\xb5\xb8\x2e\x33\xef\xa1\xe3\xd4\xde\x58\x5c\xcb\x4f\x10\x42\xe1\x88\x93\x6c\xa2\x4d\x41\xdc\x6e\x90\xdc\xaa\x71\xee\x87\x6d\xe9\x3d\xd4\xbd\x54\xe2\x77\xb4\x4e\x1a\x0d\x21\x64\xd5\x82\xb9\xc6\xca\x8e\xc0\xd9\x66\x1e\xeb\xee\x00\x75\x37\x87\xc4\x8a\x01\xa9\x5a\x78\x8f\x5a\x84\xb0\x58\x1c\x98\x35\x46\x13\x6a\x8a\xe4\x00\x98\x90\x5b\x68\x14\x77\xae\x4c\x02\x2e\x35\xda\x7c\xad\x7a\x29\xb2\x6a\x01\x00\xc0\xda\xd7\x20\x45\x99\x8d\x87\x66\xd5\xfb\xe1\x0f\x2b\xda\xd7\x83\x06\x00\x23\x5e\x2b\x9c\x70\x86\x45\xfa\xe6\x8d\xd1\x02\xb5\x43\x31\xae\x6b\x63\x05\xda\xfd\xb2\x35\x5b\xb4\xd9\x04\x03\xe0\xbd\xe5\x7a\x83\xb0\xbc\x33\xf5\x2b\x58\x76\xc6\x28\xb8\x2a\x61\x35\x9c\x79\x63\x8c\x72\x90\xfc\x3e\x18\x2c\x5b\xe4\x8a\xda\x5d\xd4\xd3\xfd\xc3\xdb\x71\x95\x6c\x1f\xab\x92\x21\x9e\x00\x15\xea\x33\x1a\x91\x88\x9d\x58\xdc\x99\xfa\x63\x4c\x02\xb4\xde\xcb\x35\x28\x82\xfd\x49\x03\x4e\x08\x20\xa2\xb3\x76\x8c\xf1\x11\x8d\x09\x4c\x40\x63\x94\xeb\xb8\x2e\xb3\xef\x9e\x88\x01\x98\x9c\x0e\x93\x8d\xd1\x79\xd3\xe2\xd6\x1a\x9d\xf7\x5d\xbc\x41\x59\x31\x9e\x02\x7f\x67\xea\xdc\xfb\x18\x91\x10\xa6\x44\xfb\xea\x64\xb3\x9a\xfe\xc1\x37\x87\x78\x84\x50\x4c\x8c\x43\x80\xbe\xfb\x96\x15\xfc\x89\x87\x05\x89\xd3\x3d\x56\x90\xad\x2e\x85\x44\x20\x71\xa9\xdc\x39\xb2\x67\x08\xbe\x34\x2f\x1c\x59\xd9\xcd\x66\xc9\xf1\x01\xf1\x8a\xce\x49\x92\xe3\xe7\x05\xc9\xac\x7a\xa3\x45\x67\xa4\x26\x56\x50\x7b\x49\xef\x96\x38\xe1\x73\x4a\xd7\xbc\x46\xe5\x9e\xd7\x72\x04\xb7\x8d\xe5\xdd\xb3\x80\x6f\xac\x35\x76\x5e\xe9\xe9\x35\xed\xf7\xe7\x02\xc2\xa8\x36\x62\x77\x4e\xb2\x2f\xba\x33\x05\x71\xb0\xbe\x10\xcc\x99\x0b\x48\x42\xbe\x6f\x8d\xab\x0f\xef\xae\xe1\x33\x6c\x94\xa9\xb9\xfa\xf0\xee\x7a\x48\xdc\xb8\xbb\xba\x6d\x5a\x7c\xc0\x10\xae\x8a\x62\xdc\x79\x6b\x1c\x85\x30\x2e\x6e\x38\xb5\x21\xc4\xfc\x65\xf5\xac\x1b\x47\x3c\x54\xbc\x8d\x57\xb0\xdc\x72\xd5\xa3\x4b\x0d\x24\xc2\xfc\xd6\xa3\xdd\xc1\x0c\xc1\x47\x10\x72\x32\x8f\xd6\x23\xd0\x45\x4b\x00\x16\x0b\x7d\xca\xf5\xe4\x02\xa4\x6f\xde\x59\xf9\xc0\xed\x2e\x95\x69\xda\x09\x21\xc6\x63\x40\x0d\x21\x63\x45\xb4\x9c\xe7\x15\xdd\x1a\xba\xf8\xcb\xe4\x4f\xab\xfc\x48\x76\xf1\xf2\x8e\x19\x71\x85\x96\x20\x7d\x73\xef\x61\x35\x34\x5a\xf8\x0c\x43\xbf\x79\x6f\x7e\x8a\x7a\x10\x02\xc4\x41\x85\x1f\xa5\x16\xb2\xe1\x64\x2c\xc4\xb1\x99\xf7\x5d\x87\xb6\xe1\x0e\xcf\xd6\xf1\x81\xc8\x88\x7b\x81\xec\xe5\x70\xfd\x37\x64\x9b\xde\x3a\x63\xf3\xd4\x20\xd0\x66\x20\x38\xf1\x9c\xcc\x66\xa3\xe2\x33\xc0\x18\x45\xb2\xcb\x80\x24\xc5\xf5\x28\x6e\xe9\x41\x95\x64\x7b\x1c\x96\xc6\xca\x8d\xd4\x5c\xe5\xa3\x16\xab\xab\x1f\x71\x6d\x2c\xc6\xc7\x43\xcc\x02\xa9\x37\x57\xac\xa8\xab\x7d\xce\xdd\xc7\x9c\x4b\xd9\xfa\xb3\x74\x4d\xec\x79\x28\x86\xc6\x12\x42\x4c\x7c\xef\x97\xb8\x1d\xf2\x31\x86\x9d\x1e\x54\xac\x90\xe5\x7d\x08\xe5\xd7\x7f\xf6\x86\xbe\x4f\x0a\x21\x4c\x8b\xf3\x53\xe9\x34\xdc\x43\x42\xa6\x1a\x49\x3d\x77\x38\x0f\x56\xe3\x6f\x6c\xf9\xd9\xdf\xab\x98\x93\xa2\x4b\x5e\xaa\xd1\xf7\xff\xb1\x6a\x94\xc3\x97\x9e\x2f\x70\xcd\x7b\x45\x59\xa5\x8d\xc6\x7f\x5f\xa2\x5f\x28\x6b\xd3\xdb\x64\x15\x07\xcb\x30\x57\x56\xbf\xb8\x3f\xd0\x9a\x10\x7e\xc5\x6d\x7a\x99\xa4\x08\x78\xef\xa4\x6e\xf0\x58\x31\x04\xe0\x1b\xf3\x85\x1a\xc7\xc1\xab\x34\xc7\x2e\x85\x65\xae\xc5\x0c\x4f\xab\xc7\xbd\x24\x4d\x8c\x23\xdc\xe7\xee\xe5\xa5\xfc\xe6\xe6\xeb\x3c\x1e\x2b\x66\xe6\x2b\x2b\xd2\x23\xe6\x9f\x3e\xbd\x4e\x4f\x3a\x02\x61\x85\x90\xdb\xc3\xc3\xfe\xaf\x00\x00\x00\xff\xff\x3b\x72\x1e\x96\xb2\x0c\x00\x00")
+var _webUiTemplatesStorageHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc5\x55\x4d\x6f\xdb\x30\x0c\xbd\xef\x57\x10\xbe\xc7\x5e\x1b\xec\x32\x38\xde\xa1\xd7\x6d\x28\xd0\xa1\x57\x43\xb6\x98\x4a\xad\x2c\x19\x92\x9c\xd6\x08\xf2\xdf\x47\xc9\x1f\x71\x5a\x34\x28\xb0\x14\x0b\x10\x5b\x24\xa5\xc7\xc7\x47\xda\xde\xef\x39\x6e\xa5\x46\x48\x04\x32\x9e\x1c\x0e\x5f\x72\x25\xf5\x13\xf8\xbe\xc5\x4d\xe2\xf1\xc5\x67\xb5\x73\x09\x58\x54\x9b\xc4\xf9\x5e\xa1\x13\x88\x3e\x01\x61\x71\xbb\x49\xf6\x7b\x68\x99\x17\xb7\x64\xc8\x17\x38\x1c\x32\xe7\x99\x97\x75\xb6\x43\xcd\x8d\xcd\xac\xac\x9f\x9c\x60\xcf\xf3\x22\x6d\xa4\x4e\x09\xf0\xc7\x6e\x43\x47\xab\x4e\x2a\x7e\x8f\xd6\x49\xa3\xe9\x70\x52\x5c\x26\x39\x9d\xa1\xa5\xb1\xec\x01\xcf\xe6\x72\xb5\x95\xad\x07\x67\xeb\x8f\x17\x32\xda\x7c\x9d\xee\xd6\xe9\xe3\x7b\xd8\x79\x36\x60\xff\x73\x12\xc5\x7a\xd3\xf9\xa8\xda\x67\x25\x3b\x69\xcd\xa5\x92\x3c\x1e\x3b\xf0\x01\xc8\xfd\x9e\x38\xd1\xe8\xd1\x62\x9a\xc6\xda\x68\x8f\xda\x87\x81\x04\xc8\xb9\xdc\x41\xad\x98\x73\x9b\x18\x60\xb4\xc5\xae\xb6\xaa\x93\x9c\xfa\x08\xf4\xcb\xc5\x35\x48\x1e\x86\x24\x26\x4d\x8a\xbb\x61\x01\x37\xcc\x72\xa9\x99\x92\xbe\xcf\x33\x71\x3d\xee\x5e\xe0\x31\x85\xd6\x43\xbc\xae\x38\xd3\x0f\x68\x93\x25\x52\x89\xd6\x1a\x72\xc5\xe9\xdb\x24\x5c\xba\x96\x7a\xf2\x1d\xb4\xd1\x18\x4a\x20\xa4\x11\xb3\x1d\xee\x00\x77\x41\x02\x47\x2a\x38\x60\x15\x75\x0f\xbc\x40\xc8\x5d\xcb\x74\x04\xd6\x5d\x53\x3a\xb4\x12\x5d\x52\xa4\x69\x4a\x2a\x50\xa4\x80\xc1\x05\x75\x67\x2d\xd5\xad\x7a\x10\xa8\x38\x48\x0d\x0d\x36\xc6\xf6\xe9\x04\x2e\xcc\x73\x04\xf4\xa6\x1d\x5d\xb9\x43\x85\xb5\x3f\x61\xad\x64\x23\x7d\x32\x31\xa2\x3d\xa6\xf5\xa4\x7b\x71\xf5\x35\xcf\xc6\xe5\x9b\xd8\xf5\x99\xd8\xb7\x33\xb1\xab\xaf\x6f\x82\x54\x54\xe4\x34\xd9\x54\x51\xa8\x6e\x28\x22\xcf\x48\xaa\xb1\x6b\xeb\xc8\xba\x16\x9d\xd5\xd4\xb3\x41\x82\x9b\x60\x51\xb3\xd6\x93\xb0\x53\xab\xc6\xe2\x56\x0a\x1f\x68\x5e\xe6\xfd\xb5\x45\xe6\x91\x03\xd3\x9c\xde\x14\x8d\xd9\xd1\x7a\x6b\x4d\x33\x2a\x07\x2d\x5a\xa0\xd9\xee\x3c\x02\xc5\x6c\x54\x8f\x00\x3d\x08\xd3\xd9\x34\xb2\x79\x3d\x14\x91\xd0\xaa\x16\xcc\x1e\x45\x8c\xf1\x99\x6d\xd9\x97\xec\x45\xd2\xbb\xe9\xe4\x44\xbf\x8a\xce\xe5\x5c\xbc\x39\x38\xa2\x9e\xdb\x32\x15\xb8\x1c\xaf\x61\x79\x22\x5b\x83\xa4\x6a\x5d\x6a\xd6\x84\x59\xfa\x63\x5a\xf8\x15\x3d\xf0\x3b\x78\xa0\xea\x61\x52\xd4\x74\xda\x2f\x14\xf5\xac\x52\x38\x31\x1f\x8c\x78\x5d\xd1\xc3\xc5\x51\x3b\x12\x70\xb0\x2b\x63\x39\xda\xd9\x74\x84\xde\xce\x96\x08\x6a\x8e\x0f\x4b\xcc\x53\x56\x7d\xb9\xe0\x74\x54\xce\x87\x6f\x4b\x91\x7b\x4b\x7f\x51\x2c\x48\xe6\x19\xd9\xc1\x37\x10\x1d\xcc\x2c\xec\xcb\x86\x33\x33\x42\x65\x78\x1f\xbc\xf1\x3e\x2a\x12\x69\xbc\xd2\x44\xb1\x0a\xd5\x52\x92\x9f\xc1\x71\x54\xe4\x9e\xa9\x0e\x3f\x5f\x90\x5d\x48\x13\x05\x39\x12\x7a\x4f\x8f\x23\xc3\x59\x8e\xc8\xf2\x72\x72\xb4\x4c\xda\x13\x39\x6e\x83\xe3\xff\x0c\xc8\x91\xd1\x79\x3d\x02\xc5\x8b\x8c\xc7\xfc\xec\x4c\x5f\x99\xbf\x76\x42\x05\x1e\xf3\x08\x00\x00")
+
+func webUiTemplatesStorageHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_webUiTemplatesStorageHtml,
+		"web/ui/templates/storage.html",
+	)
+}
+
+func webUiTemplatesStorageHtml() (*asset, error) {
+	bytes, err := webUiTemplatesStorageHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "web/ui/templates/storage.html", size: 2291, mode: os.FileMode(436), modTime: time.Unix(1791983405, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _webUiTemplatesTargetsHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xad\x56\xdf\x8f\xd3\x30\x0c\x7e\xdf\x5f\x61\x85\x13\x02\x89\xad\x12\x8f\xd0\x15\x9d\xc4\x21\x1e\x10\x3a\x89\x1f\xaf\x28\x6b\xbc\x35\x90\x4b\xa2\xc4\xdd\xdd\x54\xdd\xff\x8e\xd3\xa6\xdb\x3a\x36\x1d\x42\xdc\x43\x2f\x89\xed\xcf\x8e\x3f\xdb\x59\xd7\x29\x5c\x6b\x8b\x20\x1a\x94\x4a\x3c\x3e\xce\x4a\xa3\xed\x2f\xa0\x9d\xc7\xa5\x20\x7c\xa0\xa2\x8e\x51\x40\x40\xb3\x14\x91\x76\x06\x63\x83\x48\x02\x9a\x80\xeb\xa5\xe8\x3a\xf0\x92\x9a\x5b\xde\xe8\x07\x78\x7c\x2c\x22\x49\xd2\x75\xb2\x29\x48\x86\x0d\x52\x5c\xf0\xfa\xdd\x76\xc9\x9a\xab\x56\x1b\xf5\x1d\x43\xd4\xce\xb2\xae\xa8\x66\x65\xac\x83\xf6\x04\x31\xd4\x97\xb1\x7e\x1e\xa0\x7e\x5e\x42\x2a\x8b\x01\xa9\x9a\x75\x1d\x5a\xc5\xd7\xe0\xc5\x78\xb3\xda\x59\x42\x4b\xe9\x72\x00\xa5\xd2\x5b\xa8\x8d\x8c\x71\xd9\x0b\x24\xab\x84\xf9\xda\xb4\x5a\x71\x40\xc0\x7f\x65\xf3\x1a\xb4\xe2\xcb\x0f\x4e\x45\xf5\x75\x58\x94\x45\xf3\x3a\x6b\xac\x5d\xb8\x1b\x41\xd2\x7a\xae\xad\x49\xae\xb2\xc9\x7c\xad\x0d\x61\x10\xc7\x30\x3f\xf2\xd9\x80\x30\x8d\xa3\x87\xd8\x04\xd7\xfa\xbd\x98\x15\xb4\xf5\x2d\x65\x22\x22\xca\x50\x37\x62\x62\x90\xa2\x0f\xce\x4c\xbd\x8c\x8a\xde\xc8\x1a\x1b\x67\x14\x86\xa5\xf8\xd0\xbb\x86\xd5\x0e\x38\x37\xde\x69\x4b\xe0\x02\x18\xb9\x42\x13\x0f\x01\x15\x1c\xd1\xdf\x47\x17\xd1\x60\x4d\x4f\x07\xe4\x1d\x9f\x1c\xec\xd8\xd2\x79\x4a\xb4\x6d\xa5\x69\xf9\x66\xa2\xba\x36\x06\x98\x3d\xe9\x11\x92\x32\xe7\x79\xd0\x38\x36\xea\xba\x20\xed\x06\xe1\x2a\x69\xbc\x82\xab\x8c\x0e\x6f\x96\xb0\x18\xe8\xb9\x4d\xa6\x3d\xc3\x17\x1c\x75\x5d\x6f\x9c\xaa\x65\xbf\x84\x17\x5d\x67\xdb\xbb\x8f\x28\x0d\x35\xbb\x3d\x2c\xd7\x5e\xd7\x19\xb4\x47\x07\xd0\xfa\x97\xe7\x03\x1b\xca\x6d\xef\xb4\x18\x12\xf3\x64\x56\x57\x64\x73\x52\xa7\xfc\x71\xcd\xe3\x71\x9e\x57\x2d\x11\x5f\x62\x28\x83\x61\x23\x8e\x30\x20\xe1\x70\xa1\xcb\xd6\x10\xc8\x9a\xf4\x16\x05\x28\x49\x72\xde\x23\xe5\xfc\x96\xc5\x60\xf9\x6f\xb8\x53\xc0\x54\x06\xdf\xfc\xff\x44\x54\xee\xde\x8a\xea\x3d\x7f\xff\x6b\x9c\xf6\x97\xed\x81\xbf\x0d\x8b\x53\xec\x23\x66\xca\x22\xd5\x6f\x35\x9b\x9d\x92\x24\x0d\x06\x4e\x6b\xfa\xce\x55\x2a\xc0\x93\x9e\xc6\x10\x1c\x1f\xf5\x73\x91\xef\xa1\x23\xb7\xdd\xee\x0d\x58\x67\x31\xcd\xa4\x83\x03\x3f\x22\x8e\x13\x22\xb6\x77\x77\x32\xec\x4e\xc8\xcf\x87\xd5\x27\x27\x95\xb6\x9b\x71\x9e\x2c\x16\x8b\xb2\xf0\x63\x7c\x24\x57\x06\x0f\x78\x69\xd3\x7f\x53\xff\x29\xb4\x11\x55\xde\xaf\x5c\xe0\xf6\xdf\x6f\x23\xf1\x84\xdc\xef\x1a\xb7\x3d\xbd\x4d\x2f\x38\xcc\x03\x4a\x2f\xc2\x11\x13\x14\x26\x6d\x4c\x4d\x75\x93\xa7\x49\x59\xf0\xe6\x44\xf6\x65\xe8\xe8\xd4\x96\x67\xc5\x89\xa4\x73\x82\x4f\xfd\x54\x3a\x2f\x89\x04\x03\xec\x19\x31\x90\xa6\x44\xc2\x17\x0c\x1a\x23\xb8\x35\x70\xfc\x3c\xe3\xd8\x26\x0f\x17\x6a\x24\xc1\x3d\x27\x84\xf9\x21\xd0\xb6\x57\xf0\x01\xb7\xda\xb5\x6c\x90\x28\xcb\xc6\xd7\x4a\xa1\x3a\x17\xc2\x4d\xe2\x7b\x2a\xe0\x5d\x38\x94\xd4\x24\x67\x25\xad\x9c\xda\x71\x1d\x0c\xff\x73\xa9\xf5\x59\x1e\xb9\xb4\xf2\x30\x1c\x5a\x33\x92\xea\xe5\xe6\xe8\xa5\x60\x91\xd1\x7b\x51\x8e\x97\xcb\x4b\xe6\x37\xf8\xd9\xc9\xc0\x65\x0d\x51\x3d\x37\x32\x84\xb7\x70\x9b\xd5\xcb\x42\x72\x1c\x46\x4f\x30\xab\x32\x7a\x69\xa7\xc6\xec\xb9\x7f\x4d\x59\xf0\xa7\xc1\x18\x84\xe5\x9f\x05\x97\x03\x18\xa4\x9f\xf9\x0b\xcf\x43\x8a\xe2\xd4\x79\x59\xb4\x66\xcc\x46\x4e\x40\xee\x95\x71\x9a\xfe\x06\x00\xf1\xa3\x7c\x96\x08\x00\x00")
 
 func webUiTemplatesTargetsHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -264,7 +310,7 @@ func webUiTemplatesTargetsHtml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "web/ui/templates/targets.html", size: 3250, mode: os.FileMode(436), modTime: time.Unix(1506011184, 0)}
+	info := bindataFileInfo{name: "web/ui/templates/targets.html", size: 2198, mode: os.FileMode(436), modTime: time.Unix(1791995822, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -289,7 +335,7 @@ func webUiStaticCssAlertsCss() (*asset, error) {
 	return a, nil
 }
 
-var _webUiStaticCssGraphCss = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x56\xff\x8e\xa3\x36\x10\xfe\x3f\x4f\x31\xdd\x55\xa5\xbb\x2a\x20\x48\x37\xb7\x7b\x44\x77\x52\xff\xeb\x3b\x54\x27\x34\xc0\x40\xac\x35\x36\xb2\x27\x3f\xb6\xd5\xbd\x7b\x65\x1b\x08\x6c\x48\xda\x93\x6e\x37\x89\x64\x66\x3c\xdf\x78\xbe\x99\xcf\x14\xba\x7a\x83\x7f\x56\x00\x2d\x9a\x46\xa8\x0c\x92\xdd\xea\xfb\x6a\x15\xd3\x11\x65\x6e\x19\xd9\x7a\x6b\xad\x15\x47\x56\xfc\x4d\x19\xa4\x69\x77\x0e\x3e\x8d\xc1\x6e\x9f\x9f\x0c\x76\x1d\x99\x49\x90\x88\x75\x97\x41\xba\x99\xf9\x79\x7b\xa7\xad\x60\xa1\x55\x06\x86\x24\xb2\x38\xd2\x6e\x05\x20\xa9\xe6\x0c\x9e\x12\xe7\x0f\xd0\x0a\x15\xed\x49\x34\x7b\xff\x2c\xe9\x83\x3c\x62\x55\xe5\x97\x40\x33\xa0\xc1\x27\x56\x78\x8c\x18\x0b\xfb\x7f\x5c\xbe\x82\x14\xf0\x15\x30\xe4\x85\x55\x25\x54\x93\xc1\xb6\x3b\x4f\x9c\x19\x8b\xa8\x43\x45\xde\xa7\xd0\xa6\x22\x13\x85\x64\xd3\xee\x0c\x56\x4b\x51\xc1\x63\x55\x55\xbb\x8b\xd9\x84\xc4\x6f\xda\x0b\xcd\xac\xdb\x25\x87\x69\x0e\xd3\xba\xd9\x63\x33\xc5\x0f\xe7\xb9\xec\x46\xc4\xbb\xf0\x73\xfb\x02\xbc\x77\x70\x70\x92\x1a\x52\x95\xc7\xaa\x84\xed\x24\xbe\x65\x20\x94\x14\x8a\xa2\x42\xea\xf2\xd5\x85\x39\x92\x61\x51\xa2\x8c\x50\x8a\x46\x65\xc0\xba\xdb\x4d\x9b\xc7\xff\x7f\x4a\xe6\x1d\x82\x86\xf0\x0e\xfd\xbe\xb7\x6a\x6c\x85\x7c\xcb\xe0\x0f\x23\x50\xae\xe1\x4f\x92\x47\x72\x48\x6b\xb0\xa8\x6c\x64\xc9\x88\x7a\x8a\xe4\x88\x4a\xfc\xef\x66\x44\x7b\xcb\xf1\x2c\x02\xf9\xfa\x48\xa6\x96\xfa\x94\xc1\x51\x58\x51\x48\x0f\x74\x81\xc7\xc2\x6a\x79\x60\xff\x74\x28\x68\xa8\x52\x28\x4f\xe2\x16\x27\x51\xf1\x7e\xe8\xcb\x49\xfc\x81\x90\x05\x8c\x0b\x6b\x71\x45\x8c\x42\x42\x2c\x98\xda\x18\x4b\x77\x58\xbf\xcb\xd7\x73\xe8\xef\x34\x7e\xa2\x76\x46\x7e\x12\x6f\xdd\x13\xcf\x07\x16\x24\x6f\x8c\xdf\xfb\x38\xf3\x99\x9c\xa3\x0f\xab\xdc\x9e\x90\xcb\x30\x3f\xb5\xd4\xc8\x19\xf8\x76\xd9\xdd\x23\xbc\x2f\x42\xda\x0f\xe7\x08\x38\x0c\x6b\x4f\xc7\xc6\x11\xe1\x29\x79\xf1\x86\xef\xab\x95\x50\xdd\x81\xff\x62\xc1\x92\xbe\xfc\xf6\x2d\xdb\xbb\x72\x65\x58\x73\x2f\x15\xa5\x56\x4c\x8a\x33\x40\x66\xf3\xc1\xbb\x7d\x0c\x47\x28\xb5\xaa\x45\x03\x7e\xff\x1a\x86\xa5\x25\x49\x25\xfb\xad\x63\x12\x9b\x69\x12\xd1\x84\xbc\x49\x18\x5f\xc5\xa5\xa6\x1e\xbd\xac\x96\x94\x33\x16\x92\x66\x42\xe8\x3b\x2c\x00\x4c\xca\x9f\xc4\x2f\x3d\x3f\x7d\x42\x0a\x5b\xfa\xf2\x20\x94\x25\xc3\x79\x4b\x6c\x44\xf9\x30\xd5\x9f\x31\xab\x81\xa1\x0a\x99\x3a\x51\xbe\xf6\x75\x98\x52\x9b\x74\xec\x7d\x42\xe9\x42\x64\x52\x55\xee\xd7\x0f\xdf\xd6\x30\x35\x18\x54\x0d\x0d\xa6\x29\x62\x50\xa8\x28\x9d\x15\xa7\x17\x86\x68\x6c\x14\x32\x46\x9b\x2b\xf1\x7b\xc7\x69\x70\x2d\x58\xad\x21\xae\xb5\x69\x23\xc7\x9a\xd1\x72\x0d\x37\x84\x74\x90\x21\xac\xc4\xc1\x8e\x54\x74\x46\xb7\xc4\x7b\x3a\xd8\x90\x6f\xde\x18\x7d\xe8\xee\x2b\xcd\x90\x85\xeb\xb4\x21\xb3\xcb\xc4\xe1\x81\xf5\xbd\xd8\xf1\xa4\x3a\xd7\xb5\xd9\x7e\x1e\x8e\x76\x23\x33\x77\xe4\xf7\xec\x5c\x98\xbf\xb9\xeb\x02\x37\x8e\xcd\xbb\xb9\xd9\x7c\x0e\xeb\xb1\xe6\x9f\xdc\x85\xb3\xb9\x6a\xb3\xf4\x69\x69\xca\xe3\xa7\xcd\xcb\xf6\x39\x7d\xfa\x7d\xe7\x07\x48\x6a\x93\xc1\xe3\x76\xbb\xf5\xd2\x85\xe5\xab\x4b\x43\x55\xd1\x60\xa9\xeb\xfa\x9d\x45\xb4\xd8\x50\x06\x4a\x2b\xba\x5c\x0a\xb3\xdb\xa0\x2c\x4b\x67\x89\x4e\x54\xbc\x0a\x8e\x0a\x7d\x8e\xec\x1e\x2b\x57\x73\xd7\xe3\x0c\x89\xf7\x76\x5f\xd3\x14\xf8\x21\x59\x43\xf8\xc4\xc9\xf3\xf6\x63\x08\xfa\xc3\x5b\x06\x34\x36\xa8\x06\x89\xee\x3b\xc9\x9f\x05\x08\x2d\x45\x42\x45\xfa\xc0\x10\xa7\x5b\xbb\x5e\x48\xf0\xca\xc9\x47\xd6\x3f\x12\xf4\x3f\x82\xfd\xac\x48\xf7\x5a\xc8\xa9\x43\x7e\xd5\x47\x9b\xf1\x3d\x28\xa6\x73\x67\xc8\x5a\xa1\xd5\xb5\x5b\x9a\x24\xbf\xc2\x2f\xa2\xed\xb4\x61\x54\xbc\xa0\x8d\xe9\x52\x9c\x89\xb4\x0e\x78\x7e\xea\x16\x23\x85\x09\x7a\x9e\xdf\xf0\x4e\x16\x50\x28\x32\x10\x1b\x51\xbe\xda\x3d\x9e\xf2\xc9\xeb\xc4\x42\x6f\x6e\xfc\xdf\xee\x86\x64\xfc\x1b\x00\x00\xff\xff\xda\xef\xe5\xef\x96\x0a\x00\x00")
+var _webUiStaticCssGraphCss = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xad\x58\xdb\x8e\xdb\x36\x10\x7d\xdf\xaf\x60\x77\x51\x20\x29\x2c\x41\xbe\xad\xd7\x36\x52\xa0\x08\x0a\xf4\x25\xfd\x81\x22\x10\x68\x69\x2c\x13\xa6\x48\x95\xa2\x6f\x09\xfa\xef\x1d\x52\xa2\x44\xc9\x92\x9b\x14\xd9\x8b\x76\x45\x0e\x67\x86\x73\xce\x0c\x87\xde\xc9\xf4\x46\xbe\x3e\x11\x92\x53\x95\x31\xb1\x21\xd1\xf6\xe9\x9f\xa7\xa7\x10\xce\x94\xc7\xa5\xa6\xba\xb4\xb3\x7b\x29\x74\x50\xb2\x2f\xb0\x21\xd3\x69\x71\xad\x64\x32\x45\x8b\x43\x7c\xc1\x67\x01\xca\x53\x12\x68\x59\xa0\xdc\xac\x23\x67\xe7\x0b\x59\x32\xcd\x24\x9a\x51\xc0\xa9\x66\x67\xd8\xe2\x28\x87\xbd\xde\x90\x45\x64\xe4\x51\x07\x2a\x38\x00\xcb\x0e\x76\x2c\xaa\x95\xbc\xd0\x34\x8d\x5b\x45\x1d\x43\x4e\x26\x14\xf4\x1c\x68\xba\x2b\xbf\x45\xe4\x57\xc2\x19\x3e\x68\xe5\x17\x6a\x67\x22\xdb\x90\x65\x71\xf5\x84\x51\x30\x28\xa8\x00\x2b\xb3\x93\x2a\x05\x15\x54\xce\x62\x0c\x48\x29\x39\x4b\xc9\x4b\x9a\xa6\xdb\x76\x5a\x55\x8e\x8f\xce\xef\xa4\xd6\x32\x1f\x12\xf0\x7d\xf0\xe3\x56\x9e\x33\xdf\x7e\xb5\x9f\x76\x35\xa5\xf4\xa1\xf9\xee\xfc\x80\x79\x2b\x60\xcc\x71\xc8\x40\xa4\xd6\x56\xca\xca\x82\xd3\xdb\x86\x30\xc1\x99\x80\x60\xc7\x65\x72\x34\x6a\xce\xa0\x34\x4b\x28\x0f\x28\x67\x19\xc2\x88\xde\x6c\x7d\xf2\xd8\xef\xd7\xa8\xcb\x10\xaa\x80\x3e\x80\xdf\x72\x6b\x4f\x73\xc6\xd1\xe0\x6f\x8a\x51\x3e\x21\x7f\x00\x3f\x83\xb1\x34\x21\x25\x15\x65\x50\x82\x62\x7b\xdf\x92\x01\x2a\xb2\xcf\x59\x63\xed\x16\xd3\x2b\xab\xc0\x97\xe8\xe8\x9e\xcb\xcb\x86\x9c\x59\xc9\x76\xdc\x1a\x6a\xcd\x23\x01\x24\x3f\x69\x3b\xea\x02\x5a\x45\xa9\x0a\x4f\x64\x5e\x2e\x2c\xd5\x07\xc7\x4b\x4f\xbf\x03\x64\xc0\x46\x8b\x5a\x98\x82\xa6\x8c\x93\x90\x69\xc8\x43\x9a\x98\xcd\xda\x55\x36\x9e\x8e\xdf\xd3\x70\x01\x79\x07\xfc\x28\x5c\x9a\x11\x8b\x07\xdd\x01\x1f\x49\xbf\xbe\x9e\x6e\x4e\x76\xad\xbb\xb7\xb8\xbc\x50\x9d\x54\xf9\x83\x7e\x53\x5c\x67\xe9\xb2\x7d\x04\x78\x1d\x84\x69\x9d\x9c\x8d\x41\x97\xac\x35\x1c\x33\x03\x84\x85\xe4\xcd\x4e\xa0\x2b\x4c\x14\x27\xfd\x97\x66\x9a\xc3\x87\x5f\x3e\x6f\x0e\x26\x5c\x1b\xba\xd7\x75\xa9\x48\x70\x4b\x20\x50\x15\xd5\x5a\xbd\xb3\x62\xef\xab\x2d\xe0\xcc\x9e\x65\xc4\xae\x9f\x10\xf7\x5a\x02\x87\x44\xdb\xa5\x8d\x13\x33\xdf\x89\xc0\x03\xcf\x53\x63\xa3\x38\x44\xea\x46\x0a\xa9\x00\x31\xa6\x3a\x87\x4e\x21\xb4\x0c\xab\x0c\x78\xe1\x8f\xc2\xb7\x1a\x9f\xda\x21\x41\x73\xf8\xf0\xcc\x04\x32\x54\xc7\x39\x68\xc5\x92\x67\xbf\xfe\x34\x5e\x39\x84\x52\xaa\xa1\x60\xc9\xb1\x8e\x83\x0f\x6d\x54\x68\x2b\x53\x85\xae\xd2\x8c\x29\x19\xdb\xf7\xe7\xcf\x13\xe2\x4f\x28\x2a\x32\x70\x53\xbe\xc5\xaa\x42\x05\xd3\x4e\x70\xea\xc2\x10\x34\x44\x01\xa5\xa4\xba\x2b\x7e\x3d\x4c\x2b\xd1\x9d\x16\x88\xc3\x5e\xaa\x3c\x30\xa8\x29\x89\x09\x3a\x52\x48\x5d\x19\xa2\x29\x3b\x95\x0d\x14\x85\x92\x18\x99\x03\x9c\xca\xca\x5f\x2c\xe4\xf2\x54\x3c\xae\x34\xce\x0b\xc3\x34\xe7\x59\x9b\x71\xf4\xa4\xe5\x23\xdd\xa1\x17\x9d\xfb\xd8\x2c\xd7\x6e\x6b\x23\x9e\x99\x2d\xf7\xd1\x69\x91\x1f\x5d\xd5\x9a\x6b\xd2\xa6\x97\x37\xb3\x75\xf5\xde\xc4\xfc\xd5\x1c\x38\xb3\x3b\x9a\x4d\x17\x43\x59\x1e\x2e\x66\x6f\xcb\xd5\x74\x31\xdf\xda\x04\xe2\x52\x6d\xc8\xcb\x72\xb9\xb4\xa5\x8b\x26\x47\xe3\x86\x48\x03\x37\xb3\xdf\xef\x7b\x33\x2c\xa7\x19\x6a\x17\x52\x40\x7b\x28\x74\x4e\x83\x24\x49\xcc\x4c\x70\x81\xdd\x91\x69\x64\xef\x35\x28\x0f\x34\x35\x31\x37\x1c\xd7\x98\xe1\x46\xda\xfc\xaa\x6c\x47\xdf\x45\x13\x52\xfd\x84\xd1\x6a\xf9\xbe\x52\xfa\xdd\x4b\x9c\x35\x8d\xa8\xb9\x12\x5d\x33\xc9\xee\x85\x00\x2d\x21\x40\xf8\x24\x86\x37\x9c\x2e\xcb\xc9\x80\x83\x77\x42\x56\xb3\xfc\x1e\xa5\xff\xa1\xec\x47\x69\x7a\x44\x21\x53\x1d\xe2\x3b\x1e\xcd\x9a\x3e\x28\x84\x6b\xa1\xa0\x2c\xd1\x89\x78\x88\x6e\x3f\x93\x9f\x58\x5e\x48\xa5\xa9\xd0\x03\xb5\x71\x3a\xa4\xc7\x2b\xad\xce\x9e\xcd\xba\x41\x4d\x55\x06\xad\x9c\x9a\x03\x2b\xb5\x54\xb7\x6f\xd6\x71\x0d\xfc\xe9\x51\xbd\x2f\x18\x3c\x05\x31\xd2\xff\x38\xde\xcc\x79\x42\xf1\x49\xf5\x8a\xbc\xe3\xf8\xfd\xca\xc6\xc3\xf9\xb2\xd7\xa8\x98\xea\x46\x31\xe3\x14\x09\xb1\x8c\x1f\x51\xfd\x25\xf6\xba\xa2\x81\x14\x9b\xd9\xaf\xed\xc3\xca\xf7\x37\x8f\x21\x65\xda\x55\xdb\xc1\x1e\x68\x10\xa6\xf1\xa4\xbe\x57\x3d\xcc\x8b\x61\x63\x5f\x90\x8e\x29\x5c\xd1\xcc\xf0\xe9\x39\x64\xd7\x72\xbf\xc0\x70\x57\x40\x8e\x8c\xe2\x7f\xba\xf1\x74\x3e\x9f\xdf\xb5\x77\x9f\x40\x70\x39\x21\x9f\xa4\xa0\x09\xfe\xfd\x68\x4f\x5f\x8a\x09\xf3\xfc\x51\x9e\x14\xc3\xc8\xff\x09\x97\xe7\x09\xc9\xa5\x90\xa8\x38\x81\xce\x5e\x0f\x58\x07\xb9\xa9\x85\xbd\xcd\xdd\xb5\x73\xd1\x5d\x33\x57\xd1\xcb\xfe\x5b\x1f\x85\xd1\xb6\x7b\xeb\x19\x29\xca\xf7\x35\xb2\xb7\xe9\x1e\xf0\x8b\x3e\x72\x2d\x15\xdb\xe3\xeb\xc0\xd2\x14\x84\xa5\xe2\x01\x5b\xb4\xc0\x6e\x75\x43\x10\xc1\xc0\x5c\xa7\xec\x04\xaa\xb5\x2f\x58\x6b\xb0\x7f\x3e\x06\x66\xe0\x47\xc4\xf3\xff\x9e\x33\x16\x4e\x0f\x8c\xaa\xd7\x21\x5f\x3b\x02\xd8\xf8\xb9\xf9\xba\xf1\x6a\xa6\xe9\x7a\xb1\x58\xcc\x7c\x89\x12\x15\x88\xcc\xd7\x90\xac\x5e\xe7\xa9\x2f\x22\x4e\xf9\x0e\x14\x1e\x15\xf5\x7b\x7a\x52\xd4\x60\xee\x2d\x7a\xa3\xaf\xe9\x7c\xe7\x2f\xda\x9f\x44\x62\x84\xda\x65\x34\xcb\x14\x64\xd4\xe6\x60\x6b\x6d\xba\x8a\xde\xf6\xdb\x2a\x1c\x97\x7a\xef\x3b\xc9\x3b\x0e\x1c\xe1\x66\x02\xef\x2d\x5b\xd1\x39\xac\x3b\x32\x89\xcc\x73\x24\x83\x27\xb3\x5e\xaf\x6b\xbd\xa5\xbe\x71\x8c\x33\xd3\x78\x61\x4a\xfc\x45\x12\xef\xcc\x3d\x87\xcc\x49\x4e\xbc\x08\x23\x28\x3a\xb6\x7d\x5a\xbb\x93\xb6\x6d\xd3\x70\xd5\x41\x0a\x89\xac\x22\xb2\x21\xc8\x35\x50\x06\x48\x72\xa1\xe7\x1b\xde\x2b\xd7\xcb\xf9\xa2\x5b\x2c\xd0\xd3\x82\x83\x11\x77\x77\xe4\x6b\x83\xfa\xdc\xf5\x2a\x8e\xa6\xc1\xcd\xf5\x59\xee\x92\x84\xc7\x4b\xa7\x7e\xcc\x46\x94\xc7\xfa\x56\xc0\x7d\xdb\x35\xad\xdb\xb8\xfe\xe5\xc3\x8f\xda\xd8\x67\x0d\x4d\x05\x10\x75\x3f\xe7\x7d\x4a\x30\x7d\xeb\x36\xae\xc1\xab\xbd\x89\x2c\xcc\xf3\xc7\x67\x4c\x5d\x17\x1a\x3a\xac\x56\xf5\x49\x08\x54\xe7\x14\x8f\x70\x7b\x49\x2c\xa8\x3e\x4c\xfa\x83\x06\x9c\x49\x73\x8f\xec\x08\x76\xc6\x2c\x88\xb6\xfd\x64\x9c\xb7\xf5\x03\xf3\x45\x1e\x61\xd3\xdc\xe9\xf1\x7c\x2a\x20\xc0\x3a\x84\xb8\xdb\xba\x95\x28\x3c\xfd\x7e\x4f\x33\x28\x87\x5c\x32\x84\xe9\x5b\x32\x63\x63\x1f\xf0\xfc\x0b\x2d\x84\x6e\xe2\x1b\x12\x00\x00")
 
 func webUiStaticCssGraphCssBytes() ([]byte, error) {
 	return bindataRead(
@@ -304,7 +350,7 @@ func webUiStaticCssGraphCss() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "web/ui/static/css/graph.css", size: 2710, mode: os.FileMode(436), modTime: time.Unix(1493992669, 0)}
+	info := bindataFileInfo{name: "web/ui/static/css/graph.css", size: 4635, mode: os.FileMode(436), modTime: time.Unix(1791993947, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -329,7 +375,7 @@ func webUiStaticCssProm_consoleCss() (*asset, error) {
 	return a, nil
 }
 
-var _webUiStaticCssPrometheusCss = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x4c\x8f\x4d\x6a\xc3\x40\x0c\x46\xf7\x3e\xc5\xb7\x0e\x4c\x13\x4a\xbb\x99\x9e\xa1\x67\x08\xb2\x47\x8e\x05\x63\x69\xb0\xe5\x9f\x50\x7a\xf7\x10\x4f\x16\xd9\x68\xf1\x78\xdf\x03\x9d\x4f\xf8\xb5\x95\x91\x6c\x53\x74\xa6\xce\xea\x68\xb9\xa3\x65\x66\x6c\x8c\x81\x56\x06\xa1\x97\x9d\x13\x94\xd6\x96\x26\xf8\x40\x0e\x99\xf1\x7d\x29\x3b\x9c\x72\xc6\xe9\xdc\xb4\x96\xee\xf8\x6b\x80\x42\x29\x89\xde\x82\x5b\x89\x87\xf2\xf3\x06\x5b\x73\xb7\x31\xe2\xf3\xe0\xff\x4d\xf3\x31\x3b\x39\x5f\x45\x93\x74\xe4\x36\xbd\x27\x22\x2e\xf8\x2a\x7b\xbd\xd5\xce\xe2\x3c\x51\xbe\xda\xe2\x65\x71\x78\x3a\xfc\xde\xd4\x43\x4f\xa3\xe4\x7b\xc4\x68\x6a\x73\xa1\x8e\xeb\xa2\x5b\xa6\xd9\xa6\x50\x4c\xd4\xb9\xe6\x2b\x8a\x78\xb1\xea\xb9\x59\x76\x29\x41\x54\x5f\xda\x48\x7b\xd8\x24\xf9\x10\xa1\xa6\xfc\x7c\xc3\x79\xf7\x40\x59\x6e\x1a\x91\xb9\xf7\xe7\xf4\x11\x00\x00\xff\xff\xa0\xeb\x4b\x6c\x42\x01\x00\x00")
+var _webUiStaticCssPrometheusCss = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa5\x54\xdb\x6e\xdb\x30\x0c\x7d\xcf\x57\x10\xd8\xcb\x56\x44\x6e\xd7\x64\xc3\xe0\x01\x7b\xda\xeb\xbe\x21\xa0\x2d\x3a\xd6\x2a\x8b\x82\x4c\x37\x09\x86\xfd\xfb\x24\x39\x0d\xda\x4d\xee\x05\x83\x60\x19\x20\x8f\xc8\xc3\x23\x52\xd7\x57\xf0\x83\xef\x09\x34\x1f\x1c\xb4\xec\x84\x9c\x40\x43\x2d\x4e\x23\xc1\x81\xa0\xc7\xe8\x44\xe8\xcc\x91\x34\x38\xbc\x6f\x30\x80\xf4\x28\x60\x46\xf8\x74\xe3\x8f\x20\x68\x2d\x5c\x5d\xaf\x1a\xd6\x27\xf8\xb5\x02\xf0\xa8\xb5\x71\x7b\x25\xec\xeb\x0c\xf9\xfa\xc8\xd8\xb0\x08\x0f\x35\xdc\x66\xfb\xef\xd5\xaa\x1a\x05\x85\x76\xc6\x69\xd3\xa2\x70\x78\x1c\xa2\x86\x1b\xd8\xc6\x14\x79\x9f\xd1\xd6\x08\x05\xb4\x3b\x9e\xc4\x4f\x02\xa2\x33\xbe\x8b\xbc\x55\x87\x83\xb1\xa7\x1a\x06\x76\x3c\x7a\x6c\x69\x3e\xd1\x4e\x61\xe4\xa0\x3c\x9b\x58\xdb\x1c\x7e\x36\xd5\x70\xb6\xcd\x38\x61\xb6\x62\xbc\x32\xce\x9d\x61\x03\x1e\xd5\xc1\x68\xe9\x6b\x70\xec\x28\x95\x21\x74\x14\x85\xd6\xec\x5d\x0d\x96\x3a\xc9\x47\xaf\xaf\xe0\x3b\x86\xbb\x28\x0b\x0d\xb4\x86\x91\x28\x7e\x22\xb1\x80\xb1\xfa\x39\x56\x49\x9c\x5e\x06\x5b\x65\xbf\xd2\x09\x7a\x11\xab\xc1\xf6\x6e\x1f\x78\x72\x5a\xb5\x6c\x13\xa9\x77\x1f\x29\xad\x94\xed\xc1\xa2\xbf\xa4\x95\x73\xfd\x1d\x08\xe7\x82\xce\xc0\xcf\x5d\xb3\x21\x2c\x02\xab\x8e\xc3\xa0\xd2\x05\x07\xb6\xeb\x7f\xdd\xc6\x45\x3d\x0b\xf6\x81\x35\x5a\x75\x6e\x8c\x82\x5f\x07\xf6\xa9\x77\xd4\x40\x6e\x2a\xf8\x3d\x3a\x2a\xe5\x3b\x90\x2d\x98\x7d\xa0\x25\x59\x6e\x31\xad\x24\x4b\xc3\x41\x53\xb8\x38\xb6\xdb\xed\x2b\xc5\x7a\x4a\x16\xbe\x81\x35\x71\xc3\x02\xbb\xd6\xf2\x48\x4f\xa4\x7d\x7b\xd8\xba\x8f\x73\x15\x0a\xc1\x05\x1b\x4b\x2a\x7b\x23\x4e\x72\x2f\xc4\x7f\x78\xe1\xc0\x28\xc1\xf8\x38\x84\x4f\x8e\x38\xe9\x15\x77\x4a\x4e\x9e\xde\xb3\xd6\x1f\x96\xd4\xdb\x6c\x36\x65\xee\x39\x76\x8a\xd5\x13\xe6\xd8\x99\x53\xbf\x7e\x06\x79\xc9\x9e\x36\xbd\xd8\x33\x29\x60\xb1\x9c\xd9\xdd\x31\x3f\xcc\x63\xe9\x42\x4b\x5c\xe3\xf3\xa3\x22\x8b\x31\x4b\x5c\x61\x2b\xe6\x9e\x16\x2e\x70\x09\xba\xa8\xf1\xe2\x81\x8e\xdb\x69\x7c\x79\x56\xff\xad\x61\xde\x24\xa0\x8b\xaf\x51\x88\xd3\xf3\xda\x2e\xbd\x50\x79\x8b\x36\x8d\x38\xa5\xa9\xc3\xc9\xca\xb3\x3d\xf0\x3f\xe3\x13\x4c\x7b\x37\xf6\x78\xd8\xed\x03\xfa\x1e\xaa\xd3\x4e\x92\x25\xbf\x8a\xeb\x97\xe1\xc7\x0c\x8f\x8d\x64\xc4\xce\xc3\xd5\x19\x6b\x1f\xe5\x2c\xb1\xf8\x03\x2e\x41\xe4\x41\xa0\x06\x00\x00")
 
 func webUiStaticCssPrometheusCssBytes() ([]byte, error) {
 	return bindataRead(
@@ -344,12 +390,32 @@ func webUiStaticCssPrometheusCss() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "web/ui/static/css/prometheus.css", size: 322, mode: os.FileMode(436), modTime: time.Unix(1506011184, 0)}
+	info := bindataFileInfo{name: "web/ui/static/css/prometheus.css", size: 1696, mode: os.FileMode(436), modTime: time.Unix(1791993733, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _webUiStaticCssTargetsCss = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x54\x8e\x51\xaa\xc2\x30\x10\x45\xff\xb3\x8a\xfb\x16\xd0\x47\xf5\x33\x05\xb7\x22\xa9\x33\xb6\x23\x6d\x26\x4c\x46\x14\x4b\xf7\x2e\x14\x91\xfa\x7b\x38\xf7\x72\xdc\xfe\x6f\xda\x9f\x47\x4e\xc4\x86\x25\x00\xc0\x55\xb3\x37\x55\x5e\x1c\x71\x6c\xcb\xb3\xdb\x60\x49\x44\x92\x87\xc6\xb5\x44\x1c\xbe\x78\x73\x1f\x2c\xc3\xe8\x11\xbd\x4e\xf4\x6b\xf7\xea\xae\xf3\x7e\x70\xb9\x5b\x55\x8b\x28\x2a\xd9\xd9\xba\xb0\x86\xf0\x89\x20\xf6\x24\x53\xc5\x09\x4e\xcb\xfe\x26\xa2\xc5\x9f\xcc\x45\xcd\x53\xf6\x2e\xac\xef\x00\x00\x00\xff\xff\x62\x16\x77\x5a\xb6\x00\x00\x00")
+var _webUiStaticCssStorageCss = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x65\x8d\xc1\x0a\x83\x30\x10\x44\xef\xf9\x8a\x85\x9e\x23\x52\x0a\x42\xfc\x9a\xd5\xa4\x71\x21\x66\x25\x59\x5b\x4b\xf1\xdf\x2b\x4d\xa4\x87\xce\x69\x67\x76\x78\xd3\x64\xe1\x84\xde\xe9\xe0\xbc\x8b\x16\xde\x0a\x0e\x8d\x1c\x38\x19\xb8\x74\x5d\xd7\xab\x5d\xa9\x66\x9c\xd6\x14\xf5\x38\x61\x92\x5a\x59\x38\x93\x10\x47\x03\xc9\x05\x14\x7a\xb8\xbe\xe4\x68\x2d\x45\x7f\xf0\xee\x62\xe0\xd6\x2e\x5b\xc9\x67\x4c\x9e\xa2\x1e\x58\x84\x67\x03\xd7\xef\xe3\x87\x7e\x69\xdc\x28\xff\xb1\x71\xc8\x1c\x56\xa9\x6c\xe1\xc5\x40\x5b\xee\x13\x54\x6d\x99\xab\xe6\x49\x56\xa6\x73\x7c\x57\x1f\x8b\xef\xc6\xef\xe4\x00\x00\x00")
+
+func webUiStaticCssStorageCssBytes() ([]byte, error) {
+	return bindataRead(
+		_webUiStaticCssStorageCss,
+		"web/ui/static/css/storage.css",
+	)
+}
+
+func webUiStaticCssStorageCss() (*asset, error) {
+	bytes, err := webUiStaticCssStorageCssBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "web/ui/static/css/storage.css", size: 228, mode: os.FileMode(436), modTime: time.Unix(1791983405, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _webUiStaticCssTargetsCss = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x65\x8e\x41\x0a\x83\x30\x10\x45\xf7\x39\xc5\x80\xeb\x04\x0b\x05\x21\x1e\xa6\x24\x1a\xcd\xd0\x89\x91\x64\xa4\x95\xd2\xbb\x37\xd0\x2c\xb4\x9d\xe5\xe7\xbd\x3f\x5f\xb1\x49\xb3\xe3\x2c\x27\x24\x76\x09\x5e\x02\xca\x85\x12\xe2\x22\x6d\x64\x8e\x41\xc3\xa5\x5d\x9f\xbd\x78\x0b\xa1\x7e\x68\x35\xc5\x14\xe4\x9c\xe2\xb6\x9e\xcd\x84\xb3\xe7\x83\xd8\x54\xf1\x96\x9d\x49\x83\xaf\xf0\x03\x47\xf6\x1a\xae\xed\x5f\x7f\xde\x42\x69\xda\x2b\x37\x44\x8a\x49\x43\xd3\x75\xdd\xb9\x8d\x8d\x25\x07\x8a\x8c\x75\x54\xd9\x11\xf3\x4a\x66\xd7\x80\x0b\xe1\xe2\xa4\xa5\x38\xdc\xfb\xc3\xb6\xb2\xea\xfb\xed\x03\x4d\x33\x43\xdc\xfb\x00\x00\x00")
 
 func webUiStaticCssTargetsCssBytes() ([]byte, error) {
 	return bindataRead(
@@ -364,7 +430,7 @@ func webUiStaticCssTargetsCss() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "web/ui/static/css/targets.css", size: 182, mode: os.FileMode(436), modTime: time.Unix(1506011184, 0)}
+	info := bindataFileInfo{name: "web/ui/static/css/targets.css", size: 251, mode: os.FileMode(436), modTime: time.Unix(1791983192, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -429,7 +495,7 @@ func webUiStaticJsAlertsJs() (*asset, error) {
 	return a, nil
 }
 
-var _webUiStaticJsGraphJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xe4\x7d\x6b\x77\xdb\xb6\xb2\xe8\x77\xff\x8a\x09\x9b\x15\x51\xb5\x44\xd9\xe9\x6e\xef\xae\x6c\xb9\x37\xcd\x63\x27\xe7\xe4\xb5\x1d\xb7\xbb\x3d\x8e\x8f\x17\x44\x42\x22\x63\x8a\xe4\x06\x20\xdb\x6a\xa2\x9f\x75\xff\xc0\xfd\x65\x77\x61\xf0\x20\x40\x52\x8f\xb6\xe7\xee\x75\xef\x3a\xf9\x20\x47\x78\x0c\x06\x33\x83\xc1\x60\x30\x03\xdd\x12\x06\xef\x59\xb9\xa0\x22\xa5\x4b\x0e\x13\xf7\xcb\x97\x2f\xf0\x79\x7d\x72\x20\x9b\xcc\x19\xa9\xd2\x0b\xba\xa8\x72\x22\xe8\xc9\x01\x96\x7d\x78\xfe\xf4\xdd\xdb\x67\x30\x81\xe3\xa3\xa3\xa3\x93\x83\x83\xba\x67\xf4\x37\xd9\x1c\x26\x30\x5b\x16\xb1\xc8\xca\x22\xa4\x39\x5d\xd0\x42\x0c\xa0\xac\xe4\x77\x3e\x80\x94\x14\x49\x4e\x9f\xa6\xa4\x98\x53\xf3\xed\x9c\x2e\xca\x5b\xda\x87\xcf\x07\x00\x22\xcd\x78\x44\x73\x98\x80\xee\x7b\x62\x0a\x11\x97\x97\x17\x6f\x5e\xc3\x04\x8a\x65\x9e\xdb\x0a\x0d\x1b\x26\x66\x14\x5b\xe3\x0e\x06\x13\x6f\xec\x46\x1b\x85\x82\x8b\xba\x42\x07\x3c\x14\x43\xd9\xa3\x2f\xbb\xae\x6d\x7f\x96\xc5\x37\x3c\x25\x77\x66\xee\x1e\x6a\x09\x11\x04\x26\x70\x79\x75\x72\x60\x8a\xb2\x22\x13\x19\xc9\xb3\xdf\x68\xd8\x3f\x39\x58\x77\x10\x30\x12\xd9\x82\xbe\x20\xb1\x28\x99\x9c\x94\x44\x23\x58\x05\x63\xf8\xee\x08\xbe\x56\x1f\x8f\xff\x02\x5f\xc3\x37\xdf\x7d\x3b\x90\x55\x77\xed\xaa\xff\x81\x15\x49\xa3\x02\x0b\xd3\xba\x10\xbf\x2f\xf0\x3b\xfe\x97\x07\x63\x38\xee\xc6\x88\x0b\x5a\xfd\x4c\xf2\x25\x95\x08\x5d\xca\xc6\xc7\x3c\x18\x40\x70\x7c\xa4\xfe\x2c\xe4\xe7\xb7\xf8\x79\xac\xfe\x7c\x73\xa4\xbe\xa5\xf2\xf3\x31\x7e\x7e\x87\x9f\xc7\xea\xcb\x71\x82\x15\x49\x80\x43\x1f\xdf\xe1\x37\xfc\xfc\x0b\x7e\xfe\x15\x3f\x8f\x57\x58\xbe\x0a\x0e\xae\xba\xd0\x2a\x96\x0b\xfc\x8f\xc4\xaa\x4b\x14\xa3\x8a\x95\xa2\x14\xab\x8a\x3a\x64\x6f\x33\x59\x4a\x35\xa7\xf9\x0c\x26\xc8\x22\xc9\x3d\xf9\x35\xca\x12\x6f\x61\x34\x07\x3d\x3c\x44\xae\x8e\x46\xf0\x81\x0a\x48\xe8\x8c\x2c\x73\x61\x64\x30\x32\x40\xcc\x77\x04\xa6\xc1\x9e\x34\x2b\x99\x14\xc9\xeb\xac\xa8\x96\xc2\xb4\xea\xaa\xfa\xf2\x05\x29\x2a\xbb\x67\x33\x08\xbd\x76\x82\x4c\x61\x32\x99\xc0\xb2\x48\xe8\x2c\x2b\x68\x62\x04\xb8\xdd\x0a\x8e\x51\x84\x35\xf2\xcf\x18\xb9\x53\x0b\x1d\xe2\xb2\x10\xac\xcc\x39\x90\x22\xc1\x2f\x24\x2b\x28\x83\x19\x2b\x17\xf0\x12\xd7\xc1\x94\x30\x0e\x42\x2b\x84\xe8\x40\x13\xaf\x5e\x81\x6a\xc8\x5e\x45\x44\xfa\x9e\xd1\x59\x76\xdf\x1b\xc3\xfb\x27\x17\x2f\xaf\xdf\x9f\x3f\x7f\xf1\xea\x97\x81\xaa\x9e\x2e\xb3\x3c\xf9\x99\x32\x9e\x95\x45\x6f\x0c\x3f\xfe\xf4\xea\xf5\xb3\xeb\x9f\x9f\x9f\x7f\x78\xf5\xee\xad\x59\x5c\x9f\xfe\xbe\xa4\x6c\x15\xd1\x7b\x41\x8b\x24\xb4\xfa\xc3\x9d\x4d\xdf\xd2\xd1\xd5\x0d\x0f\xc3\x37\x4b\x2e\x48\x9c\xd2\x88\xd1\x22\xa1\x2c\xf4\xb4\x98\xd5\x45\xfd\xba\x3b\xcd\x23\x52\x55\x72\x1c\x1f\x5a\xdf\x30\xf8\x6f\x54\x00\xa3\x33\xca\x68\x11\x53\x0e\xa2\x04\x92\xe7\x20\x52\x0a\x59\x21\x28\xa3\x5c\x64\xc5\xdc\x68\x2c\x0e\x59\x81\x75\x35\x51\x15\x1d\x49\x91\x28\x70\xd3\xac\x48\x80\xde\xd2\x42\x68\xf5\xc2\x50\x5e\xac\xc6\xfd\x07\x93\xe8\x30\x23\x0a\x34\x8f\x66\x59\x91\x84\xc1\x57\x58\x7b\x7d\xa7\xaa\x03\x38\x34\x02\x55\x4f\xe5\x9f\x92\x6a\x2f\x4a\xb6\x80\x89\x07\x4b\x43\x50\xf5\xd7\xb3\x92\x2d\x02\x35\x3b\x35\xc2\x7d\xc5\xba\x3b\x08\x7a\x2f\x08\xa3\xe4\xb2\x20\x0b\x3a\x91\xed\xae\x02\x87\x70\xf7\x15\x8b\x6e\xe8\xaa\x62\x94\xf3\xb0\x56\xfb\x46\xf6\x46\x23\x78\x2e\x09\x04\x77\x84\x03\x36\xa2\x09\xdc\x65\x22\x2d\x97\x02\x49\xc4\xd3\x6c\x26\xe0\x86\xae\x22\x6c\x2f\xa5\x9a\x46\x77\x69\x16\xa7\x30\x99\xc0\xf1\x37\xf0\xe8\x11\x3c\xa0\x11\x36\xfb\x77\xba\x32\x70\x9b\x93\x8d\xf8\x72\xba\xc8\x44\x88\x98\xc9\x7f\x34\xaa\x18\x12\xf8\x99\x5a\x96\xa6\x06\x85\x1e\xf1\x7a\xb2\x14\xe5\x90\x51\x2e\x35\x82\xc4\x44\x4e\x14\xe4\x4c\xa1\x2c\x00\x97\x9b\x42\x09\xe5\x7b\x36\xe3\x54\x68\xf5\x10\xa9\x6f\x2f\x69\x36\x4f\x05\x0c\x55\x59\x9c\x67\xb4\xd0\x65\x27\xb6\x9f\x02\x7f\xa1\x49\xe8\x6f\x8c\xf5\x54\x00\x1e\xca\xef\x51\xcc\x79\xd8\x4b\x11\x44\x6f\x00\x3d\xb2\x14\x65\xaf\x59\x4a\xf3\x88\xc7\xac\xcc\x73\x3d\xfc\xa1\xc6\xcd\x4c\x4f\xfd\x79\xa8\x36\xaa\xa8\x2c\xc2\xde\x0d\x5d\x2d\x2b\x35\xa1\xde\xc0\xd3\x7c\x0d\xf4\xf4\xe6\x06\x6b\xb5\xc1\x35\x98\x1c\xe3\xae\xa9\xd6\x87\xbb\x8f\x3a\x42\x84\x9a\xea\x95\xab\xc3\x6a\xfe\x28\x61\x42\x2c\x94\x24\x39\x6a\xcd\x15\x28\xb9\x70\x6f\x68\xf2\xa3\x28\x36\xc1\x30\x4d\xae\xa7\xa2\x68\x77\xdc\x63\x64\xdd\xd2\x1d\x35\x2b\x38\x65\xe2\x0d\x15\x2c\x8b\x37\x41\xe0\x34\xa7\xb1\x06\xa1\xda\x5f\x2f\xb0\x83\x0b\x88\xd1\x19\xa3\x3c\x7d\x25\x65\xfe\x96\xe4\xfb\xc0\xd2\x5d\xae\xdc\xe5\x18\x97\x05\x2f\x73\x7a\x81\xca\xba\x6b\x15\xeb\x06\x41\x43\x03\xca\x0e\xb0\xa1\x8b\x52\x1d\x56\x19\xb9\xc3\x09\x32\xe5\xdd\xbd\xc8\xa5\xb4\x60\x86\xa2\x9c\xcf\x73\x3a\xe9\x09\x32\xed\xb9\xd3\x95\x1d\x23\xfa\xcf\xd6\x46\xd4\x97\x1f\x61\xc0\xd3\xf2\xae\xd9\xba\x2c\x54\x79\x11\x4d\xb1\x69\xe0\xc8\xa4\x55\x1b\x72\xed\x08\xc2\xe6\xb8\xe6\x1e\x86\x34\x52\x5f\xb4\x90\x77\x6c\x68\xaa\x3e\xaa\x08\xa3\x85\x08\xfb\x51\x56\x24\xf4\x3e\x74\xdb\xbb\x32\x6b\x2a\xa4\xb6\x79\x18\x06\x5f\x49\x45\xaa\x21\x10\x21\x58\x18\x10\x96\x91\xa1\xd9\x0c\x83\x7e\x3f\x4a\x09\x7f\x9a\x13\xce\xc3\x80\xd1\xbc\x24\x49\xd0\x6f\x68\x22\xa5\x7f\x70\xcb\xaa\x55\x8d\x5a\x45\x4a\xe5\x9f\x53\xb1\x64\x05\x48\x2b\x92\xc3\xac\x8c\x97\x1c\xa6\x24\xbe\x91\x5b\x09\x2a\xdf\xac\xe0\x82\x92\x04\xca\x19\x28\x58\x72\x47\x89\xba\x04\x34\x9a\x22\x6b\x6e\xe8\x2a\x29\xef\x0a\x69\x1f\x31\x84\xdd\x49\xc9\x7a\x01\xe3\x98\x1e\x49\xb0\xf8\x96\xe4\xa1\xff\xad\xaf\xdb\x28\xa8\x1b\x34\xe9\xba\x5f\xef\x1d\x8c\x95\x1b\x36\x0f\x55\x17\xf4\xa3\x34\x4b\x34\xd5\x6b\x61\x7d\xa2\x54\xe2\x66\x59\x95\x4a\xa9\x29\xe1\x66\x45\x59\x08\x5e\x17\xa7\xf5\xea\xc9\x7d\xc6\x37\xb6\x5e\x5d\x93\xfb\x8c\x3b\xcd\x73\x3a\xa7\x45\xb2\x01\x1d\x55\xe9\x2a\x9b\x2a\x2b\x0a\xba\x69\xd2\xba\xd6\xdd\x26\x6f\x49\xfe\x41\x10\xb1\x61\x95\x61\xfd\x35\x97\x0d\xbc\x4d\xb9\x48\x9e\x11\x41\xbb\xfb\x38\x0a\x8d\x16\x49\x5b\x91\xea\xce\xf2\x04\x42\xe5\x79\xa2\xca\xe2\x1b\xca\x42\x25\x15\x79\x19\x93\x9c\x8e\xa1\x47\x8b\x9e\x32\xc9\xa4\x41\x40\xc4\x18\x7a\xbf\xfe\xfa\xeb\xaf\xc3\x37\x6f\x86\xcf\x9e\xc1\xcb\x97\xe3\xc5\x42\xd7\x8b\xb2\xcc\xa7\x84\xbd\xcf\x49\x8c\x36\xce\x18\x7a\xd3\x52\x88\xd2\xd4\xf3\x2c\xa1\x3f\xae\x3e\x64\x09\x1d\x83\x60\x4b\xaa\x4b\xd3\xf2\xee\xa2\x4c\xc8\xea\xc7\xa5\x10\x65\xd1\xac\x7a\x9a\x53\xc2\xda\x85\x25\xf7\x80\x48\xec\xff\xa3\x2c\x24\xba\x3f\x5d\x3c\xc5\xf1\xd4\xe6\xd4\x32\x81\x2d\x21\x7c\xe9\xaf\x29\x41\xc2\x9e\xfc\xef\x45\xb6\xa0\xef\x91\x1e\xbd\x3e\x12\x68\x13\x18\x65\x26\x37\xe0\x48\x0d\x96\x54\x7a\x43\x0c\x1a\x5b\x6a\x87\x32\x70\xb7\xd2\xc6\xfe\x60\x76\xd5\x36\x88\x65\x25\xf1\x3a\x57\xcd\x0d\x10\xab\x0d\xf8\x07\xbb\xdb\xb5\xce\xab\x7a\xd9\xba\x9b\xa2\x5a\xd6\x78\x3a\xe8\x1d\xf7\xf4\xf1\xd5\x9c\x7b\xc4\x2a\xa7\x08\x4e\xed\xb9\x2d\x78\xb2\x51\x16\x97\x76\x3f\xae\x77\x68\x25\x89\xbd\x68\x9e\xaf\xaa\x54\x36\xe9\x39\x7a\xd5\x47\x34\x6c\xe9\xcb\x1a\x0a\x49\x12\xad\x5b\xa7\xa2\x18\x56\x2c\x5b\x10\xb6\x0a\xac\x25\x27\x01\x3b\x6d\xec\x60\xc3\x38\xa5\xf1\x4d\xa3\x1d\xc3\x63\x7a\xab\xe9\xb2\xc0\xc6\x34\x31\xcd\xd7\x40\x73\x4e\x37\xa2\xe4\x81\xf9\x7d\x58\xb5\x86\xda\x8e\x99\x37\x89\xb5\x39\xfb\x78\x4c\x09\x1d\xce\x3b\x38\xc6\x79\x16\xdf\x84\x2d\x76\x75\xd1\x5e\x1a\xd1\xb5\x1e\xfc\xb7\x0f\xef\xde\xd6\xdc\x18\x8d\xe0\xd5\xcc\x39\xad\x48\x43\x5d\x8f\x32\xc0\xe2\x92\x65\xf3\xac\x20\x39\x70\xca\x32\xca\x01\x5d\x1a\xf3\x52\xc0\x62\x29\x88\xa0\x49\x0d\x27\xe4\x52\xab\x24\x7d\x3c\x3d\xde\x51\x28\x28\x4d\xe4\xfe\xc6\xa8\x34\x57\x04\x5b\xc6\x02\x32\xa1\x4e\x93\x1e\x64\x89\x11\xc2\x8d\x5c\x7e\x68\xdf\x89\x32\x1d\x18\x29\xb8\xd4\x51\xcf\xe4\x22\x6e\xcc\xa5\x26\x1e\xb4\xc5\xbe\x45\x8b\x1f\xa0\x77\xd4\x83\xb1\x5c\x09\x66\x33\x6c\x52\xdb\x02\x52\xab\x10\x4f\xfb\xa1\xb5\x8a\x5b\x27\x2d\x73\xf8\x68\xf1\xa2\x61\xcb\x39\xf2\x62\xac\x08\x67\x2c\x63\xc0\x6d\x6f\xd5\x61\x67\xe8\x05\x3f\x23\x39\xa7\x0d\xcb\x5d\xef\x44\x76\xfb\x6d\xa3\xae\x36\x93\x29\xaa\x67\x63\xdb\xc6\xd7\x68\x9c\x5f\x05\xfd\x0e\x21\x33\xf6\x48\xcc\x28\xe1\xf4\x5c\x9b\x53\xee\xa0\xdb\x80\x27\x74\x0f\xe0\x09\xed\x00\xbe\x2f\xea\xb4\x48\xf6\x41\xfc\x79\x91\xfc\x4e\xb4\x77\x00\x36\x48\x3b\x80\x3b\x8d\xb7\x0e\x8d\xdf\xb0\xc8\xd4\xe1\x40\xd6\x05\x8c\x56\x72\xc3\x0d\x06\xf0\x59\x1e\x4f\xc7\x1d\xf0\x50\xb5\x0f\x60\x51\xca\x9d\x37\x98\xd2\x59\xc9\x68\xb0\x6e\x99\x79\xc6\xfa\x93\xeb\x94\x51\xfc\x96\x15\xf3\x5a\xa2\xd5\x69\x55\xaa\x28\xb5\x0d\x74\x58\x1c\xe6\xb8\x22\x1b\x69\x4b\xc3\xf6\xd8\xa4\x8d\xf4\xa6\x87\xbe\xd3\x2d\xe2\x6a\x28\x55\x95\xd5\x32\x27\x82\xbe\xc2\x19\x92\x69\x4e\xd5\x2c\xb9\x16\x5e\xab\xdc\x1c\x63\xd5\x1d\xa9\xb5\x3a\xd6\xdd\xee\xcc\xda\x2d\xb8\x71\xc4\xbd\xbc\x84\x0f\x23\xf2\x89\xdc\x87\x46\x97\xca\x41\xca\x64\x0c\xc1\xdf\x9e\x5f\x04\x03\x5d\xb8\x64\xb9\xe7\x02\x83\x43\x08\x46\xa4\xca\x46\xb7\xc7\xa3\x9c\x4c\x69\x3e\xba\xbe\x96\x94\xbd\xbe\x1e\xdd\xa2\x87\xd5\xf6\x94\x0a\xf0\x62\x55\x49\xbe\x7e\xe2\x65\x61\xcb\xf9\x32\x8e\x29\xe7\xe3\x1a\x41\x59\x3d\x40\x0f\x86\xb4\x32\x97\xdc\xf5\x2d\x48\x9a\xc9\x7a\xa9\x15\xc5\x92\xc3\x83\xc9\x04\x02\x0d\x22\x70\x1b\x1a\x1a\xa6\xe5\xdd\x73\x69\xb6\x87\x01\xfe\x01\xa9\x83\xb2\x62\x0e\xe4\x96\x64\xb9\xa4\x10\xa8\x73\x2f\x7f\x50\x6f\x71\x35\x63\xeb\x92\xb5\xfd\x9f\xa4\xdc\xc2\x92\x15\x91\x91\x73\xab\x9b\xce\x4a\x06\x21\x1a\x1a\xe8\xc8\x85\x0c\x4e\x4d\x87\x28\xa7\xc5\x5c\xa4\x27\x90\x1d\x1e\x76\x60\xeb\xae\x85\xcb\xa3\x2b\x6b\xc3\x91\x24\x09\x0b\x7a\x07\xef\xf0\x7b\xa8\x81\x5d\x66\x57\x03\xa8\xff\xdf\xef\xbb\xd8\x1e\x78\x80\x67\xcb\xdf\x7e\x5b\x9d\x53\xbe\xcc\x85\x75\x6b\xaa\x7f\xa8\x28\xc6\xe8\xe7\x1f\x78\xd3\x97\x6d\xdb\xe5\x0b\x52\x8d\xe1\xf3\x7a\xe3\x40\x28\xca\x52\x16\x49\x4a\x49\x12\x7a\x33\x2c\x97\x2c\xa6\x63\x83\xb1\x0b\x35\x13\x74\xc1\xc7\x10\x90\x3c\x0f\xfc\xd1\x44\x9c\x52\xe6\xc8\x86\x6c\xe9\x13\xce\x6c\xfa\x77\x14\x52\x72\x4b\x35\xe6\xc8\x84\x78\xc9\xe4\x09\x5a\xcd\x71\x00\xfc\x26\xab\xbc\x8e\x76\x01\x3a\xe4\x51\x9a\x13\xe5\x0a\x5d\x61\xf8\xb5\x39\x62\x9b\xaa\xba\x9b\xdb\xe9\x64\x57\x97\x05\xa9\x24\x33\xd6\x3b\x1b\x32\xc3\x38\x2c\x8c\x66\x59\x2e\x28\x0b\xeb\x91\x22\xad\x59\xc3\x11\x8c\xe6\x03\xe8\xf5\xfa\x56\x2e\x06\x2d\xcc\x01\x2a\x26\x4f\x1f\xa7\x5c\xb0\xb2\x98\x9f\xf5\x06\xed\x06\x25\x97\x47\xa2\xd3\x91\x69\xd2\x68\xb1\xee\xef\x89\x72\x34\x2b\xd9\x73\x12\xa7\xb5\x2a\x65\x6d\x52\x76\x53\xe6\x92\x45\xc6\xa2\xba\x82\x09\xb0\xe6\x88\x4d\x1c\x1c\x41\x84\x5a\x2f\x4b\x71\x81\xac\xe8\x1c\xc1\xed\xbf\x1e\x1c\x78\x92\xca\x44\x4b\xea\x78\x13\x73\x2c\x8c\x64\xdb\x7a\x7a\x64\x30\x6d\x4f\xd0\xa8\x82\xce\x69\x4e\xaf\x22\x1e\x97\x8c\xc2\xb0\xbb\x9e\xe8\xfa\xe6\xfc\xcd\x04\xf1\x1c\x74\x04\x3f\x00\x89\xd4\x39\xf8\x69\xb9\xa8\x08\xa3\xe1\xb4\x0f\x63\xc8\x1a\x44\x6a\x10\xcd\xa1\x12\xdf\x4c\x8e\x34\x9b\xa7\x79\x36\x4f\x3d\x9a\x40\xe7\x52\xd4\x00\x1f\x86\xbd\xd3\x24\xbb\x3d\xeb\x19\x9f\x7e\x73\x56\xb2\xef\x55\xc4\x05\x93\xaa\xf8\x50\x8a\x1a\x36\xef\xfb\x38\x74\xa1\x3d\x1a\xc1\x45\x9a\x71\x34\xc7\xf1\xea\x22\xc5\xbb\x0e\x20\x33\x41\x19\x10\x21\x48\x9c\x4a\xa0\xe8\x04\x37\x7a\x08\xaa\x7c\x39\xcf\x8a\x01\x10\x0e\x99\x70\x61\x95\x22\xa5\xec\x2e\xe3\x14\xa6\x8c\x92\x1b\xde\xe8\x67\x66\x4b\xf2\x4c\xac\xa2\x0e\x55\xe7\xf9\xa1\x1c\xa4\xd1\x55\x34\x6e\x9f\x3f\xe1\x4f\x6d\x4c\x6b\xe3\x2e\xd8\x61\x07\xcc\xa9\x78\x67\x2f\xb1\x76\x6f\xfc\x8d\x4b\xaf\xfa\x38\xad\x0a\xd1\x09\x6e\xae\x4a\x01\x02\xc7\xd9\xad\xb5\x75\x60\x9d\x0c\xa6\x80\x0b\x5a\x35\x4b\xf0\xcc\x12\x1c\x00\x5c\x6d\x36\x80\x55\x97\x7e\x44\x3d\xad\x81\x0e\xd0\x81\xb9\x91\x72\xcf\xf2\xd2\xd6\xa8\x6f\xd7\x23\xf9\xd5\xf1\x86\x46\x59\xf1\x84\x31\xb2\x0a\x65\xf9\xc0\x9b\x4e\x1f\xce\x26\x70\x54\xb3\x05\xef\x6a\x34\x14\xb4\x5c\xf4\x56\x0d\x67\x6e\x2b\x30\x74\x42\xf3\xf1\xca\x19\x19\xfb\x58\x3e\x79\x2e\x53\xdb\xc9\x5c\x4c\x35\x8c\x3e\xb7\x85\x72\x00\x37\x7d\xc2\xca\x3a\xc5\xa5\x65\x83\x02\x76\x99\x82\x84\x71\xfa\x6c\xc9\x08\x2e\x56\x47\x0a\x90\x7b\x17\xf4\x5e\xd4\xe2\x80\x45\xe7\xcf\x61\x02\xd2\xc8\x38\xa7\xf3\xe7\xf7\x55\x18\xfc\x67\x78\x79\x34\xfc\xfe\xea\xb0\x1f\x5e\xae\xee\x92\x74\xc1\xaf\x0e\xfb\x0f\x95\x2c\xa2\x09\x84\x7b\xb3\x14\x0b\x0b\x31\xc2\xb2\x50\x83\xb3\x5e\xad\x07\xba\xa9\xba\xa4\x41\xb3\x0a\x69\x23\xeb\x74\x95\x21\xf6\x83\x09\x7c\xd3\x70\xfd\x7c\x77\x64\xfc\x56\x72\x54\x24\x33\x4c\x00\xa7\xf7\xaa\x10\x06\xc0\xe5\xf1\x95\xc5\x6c\x59\x64\x72\xb3\x34\x35\x8f\xaf\x1c\xf2\xa9\xfe\x5f\xb7\xef\xc1\x9d\x28\x85\x4b\x09\xe0\x6a\x27\x85\xbd\x53\xe3\xde\xeb\x0c\x89\xf3\x81\xc6\x65\x91\x58\x87\xae\xc7\xab\xb0\x71\xfb\xe4\x78\xb1\xbb\x0c\xcb\x2d\xc1\x0d\x5d\xc6\xa6\xa4\xb9\x87\xc2\x69\x17\x0a\x5b\x80\xa2\xa1\xe9\xbb\x9a\x1a\xb8\xee\xe8\x7c\xe2\x2c\xb8\x0d\xa7\x1f\xd8\xe2\x1f\xa8\x2d\x71\xd7\x42\x5f\xef\x73\x3a\xf2\x4e\xe2\xff\x7a\x86\xed\xe6\x14\x0c\xe1\x58\x72\xf5\x4c\x71\x77\x38\xdc\xc8\xb5\xb3\xff\x3e\x5c\x9b\x53\xf1\xdc\x5e\x1d\xec\x66\x19\x2a\x1c\xef\xc2\xe1\xcb\x17\xf0\x0a\x7c\xac\x99\xb9\xc9\x5a\xe0\x5d\x9b\xd1\x35\xae\xdf\x79\x1f\x97\xfb\x7e\x7b\x32\xfb\xf0\xfb\x26\x23\x8b\x12\xd5\x58\x79\xd5\x6c\x77\xe7\xfa\x89\xd7\x85\xb2\x6d\xdf\xd1\x76\x09\xc6\xb9\xed\x40\x8c\x77\xe2\x84\xa0\xb6\xc6\x13\xed\x43\x16\x8d\xd0\x9e\x9a\xf4\x79\xd1\x71\x07\xb0\x81\x2c\x05\xbd\xd3\x28\x6b\xd6\x19\x02\xb9\x44\xd6\xcb\x50\xb7\xc5\x63\xf4\xde\xeb\x17\x46\xf0\x78\x00\x3d\xae\x56\x5c\xaf\x93\xde\x1a\xb0\x53\xe7\x8b\xfe\x9e\x0a\xe9\xff\xf6\xbc\xf9\x72\x2a\x18\x89\xc5\xff\x53\x93\x77\x5a\xef\x1f\xc3\x16\xe7\x94\x30\x65\x36\xf7\x1b\xab\xbd\xa5\x8f\x6a\x4d\xb3\x3e\x68\xba\x90\xa5\xf5\x1d\x76\xdc\x68\x46\x74\x51\x89\x55\xd8\x77\x2e\x94\x08\x13\x52\xae\xb5\x71\xa4\xa8\x2b\xe9\x2d\x0b\xc3\xfe\x7f\xc5\x2e\xa1\x63\x6b\xca\x7c\xa9\x6d\x35\x6b\xdc\x6c\x36\x91\x4d\xf0\x87\xb1\xb2\xaf\x82\xbe\x99\xfd\x97\x2f\xf0\x86\x88\x34\x5a\x90\xfb\x10\xff\x33\xcb\xcb\x92\xf9\xfb\xc7\x08\x1e\x7f\x7b\xd4\x1f\xc0\xb1\x45\xa0\xbe\x9e\x6d\x69\x1a\x18\x99\xe0\x58\x47\xff\x23\x56\xbf\xa4\xcc\xf3\x58\x9a\xc2\x88\x4c\xe5\xb1\xb8\xef\x5a\x6e\x4b\x96\x9b\xb1\xb4\xbf\xce\x7c\xad\x08\x23\x8b\x3a\xdc\x2e\x40\x28\xc1\xb8\x69\x26\x9b\xeb\xa4\x8d\xb1\x82\xd6\x4e\x57\x00\x23\xe4\x9d\x34\xd1\xf5\xd4\x86\x1e\x97\x4e\xdc\xa6\xea\xb6\x5c\x37\x3c\xf1\x81\xd0\x4a\xda\xb8\x96\x3f\xaa\x76\xc9\x72\xb9\xa5\x77\x3b\x42\x55\x54\x1a\x0e\x16\x68\xd7\xb5\x9a\xb1\x2b\xe8\x1d\x5e\x4e\x37\xb6\x03\x97\xcb\x39\xe5\x55\x59\x70\xda\x6e\x7c\xa2\x68\xe1\xdd\xfc\x69\x8c\x85\x92\xd6\x5a\x72\x0d\xfb\xf6\xc3\xfb\x0f\x63\xfc\x54\x5d\x0d\xed\xc6\xd9\xfa\xc7\x0d\xdf\xd5\x7f\x1a\x87\xc2\x5f\x52\x79\x54\xda\xe0\x93\x6e\x2c\x0c\x15\xe7\xa2\x2a\x83\xbe\xe7\xab\x5e\xb2\x7c\x97\x07\x5a\x96\x8f\x35\x12\xff\x6a\xaf\x34\xf6\x42\x67\xc1\x9e\xde\x67\x0d\x35\xb4\x7e\x67\x9f\xc4\xbb\xfc\x10\xf7\x29\x1b\x48\x61\xae\x9a\xe8\xcb\x32\x79\xfc\x0a\x70\xe9\x36\x90\x46\x05\xc1\x3c\x1f\x9c\xec\x73\x9f\xb2\x88\x69\x76\xe3\xad\xe7\x83\xae\x88\x5d\xf3\x8f\x32\xc9\xd0\x66\x1f\x35\x79\xcf\xf9\xe4\xdf\x66\x37\x3b\x2b\x12\xcb\xe3\xa6\xd7\x69\xa7\xe3\x9f\xde\xd3\x78\x89\x81\xad\xda\xe5\x1d\xc0\xa1\x04\xdb\x6f\x53\xd9\x52\x2f\x2e\x17\x55\x4e\x05\xdd\x9b\x80\x93\x0d\x04\xdc\x7e\x9b\x90\xd4\xc7\xf4\xae\x3d\x06\x86\xf5\x62\x3e\xf1\x3a\x8a\x52\x90\x5c\x16\x7f\x50\xb7\xd9\x18\x37\xbe\x8d\x43\xea\x1a\x7a\x0b\x9b\x36\x76\xd2\x1e\x5d\xb9\x7e\x50\xd9\x06\x3c\x26\x39\x61\x41\x93\xcb\x6d\x94\x8e\x77\x32\xb7\xdd\x67\x1b\x0a\xe6\x58\xdb\xc9\xfd\x75\xc3\x47\x67\x37\xf6\x54\x2c\xf2\x30\x78\x5d\x92\x04\x43\x70\x14\xfb\x2d\xe1\x0f\x21\x58\x70\x38\x9d\x32\x18\x9d\xc1\xb9\xd5\xf5\xaa\x95\xb3\x37\x1f\x42\x60\x9a\xc9\x9a\xe0\x42\x62\x8e\x00\x75\x40\x81\xea\xd1\x98\x90\x23\x62\x9d\x17\xd9\x35\xea\x7b\xf8\xf6\xac\x60\xbb\xaa\x79\xc1\xe7\x3b\x8c\x75\xd9\x23\x92\x9a\x02\xdb\x36\xca\x8d\x39\xb4\x63\xe8\xda\xfa\xfa\xa3\x63\xf7\x7a\xcd\xa1\x0d\x0d\x76\x0c\xed\x45\x10\xed\x61\x2f\xba\x76\x82\x64\x4f\xb9\x14\xaf\x9e\x19\x59\xbd\xcb\x8a\xa4\xbc\x53\xd3\xb9\x50\x95\xcd\x96\xd6\x6c\xcc\x1a\xc1\xaf\x5d\x46\x5d\x23\x0c\xaa\xb6\xec\xd0\x3c\x35\x10\x7c\xf7\x97\x0d\x23\x35\x43\xc2\xc4\xe0\xc5\xd5\xc2\x97\x58\x75\x5f\x41\x77\x1c\xb0\x3b\xc3\xac\xe4\x1c\x06\xf5\x0c\xbe\xd6\xc9\x4e\xbb\xa9\xad\x32\x0d\x5e\x93\x29\xcd\x3d\x0b\x00\x6f\x78\x79\x4d\x72\xfc\xfe\x01\xbd\xf8\x5c\x27\x06\x39\x4e\x0f\xac\x85\xac\x00\xb7\x9b\x22\x8a\xaa\x92\xdb\x8d\xb9\x2e\x76\x14\x89\x0b\x35\xaa\x96\x3c\x0d\x03\x73\x59\x25\x17\x97\xea\x7b\x08\x81\xbd\x9f\xd2\xba\x9c\xc7\xa4\xa2\x2f\x2f\xde\xbc\xd6\x78\x5e\xe2\x1f\x7b\x2f\xba\xf6\x8f\xf6\xb9\x99\x5d\x70\x9a\x64\xb7\x10\xe7\x84\xf3\xc9\xc7\x40\x15\x7f\x0c\xea\xa1\x0c\x26\x9f\xca\xac\x08\x83\xd3\x29\x3b\x0b\xfa\x6a\xf8\x24\xbb\x3d\x0b\x76\x12\x53\xb9\xf1\x2f\xca\x0b\xfe\x56\x39\xab\x37\x92\x53\x98\x16\xba\x26\x32\xc4\x91\x36\x7d\xaf\x87\xa3\x7e\x0e\x4e\xb6\x11\x7f\x27\xf5\x77\x93\xbf\x83\xfe\x96\xe4\x93\x8f\x81\xa5\x8b\xa1\xaf\x2c\xff\x18\xd8\x4b\x0a\xd4\xc0\xf2\x43\xcf\xe6\x70\xd2\x45\xc6\x81\xa2\xe1\x3a\x70\xbc\x15\xaa\xc3\x7e\x9e\xed\x9f\xb5\x1f\xd8\xd2\x12\x1d\xbb\x35\x29\xd5\x8a\xc5\xa6\x2f\xf2\x92\x08\x5d\x6f\x16\x65\xc6\xdf\x92\xb7\xb2\xac\xef\xe4\x76\x04\x87\xaf\x8a\x59\x30\x80\x60\xa8\xff\xe2\x77\xb8\xcb\xf2\x1c\xa6\x54\x01\x4b\xe4\x72\x2a\xe1\x2d\x79\x0b\xd3\x95\x0b\xbf\x1f\xc1\x45\x4a\x0d\xa8\x98\x14\x3d\x21\x3b\x61\xe4\x09\x4d\x06\xc0\x4b\x8c\x07\x05\x91\xd2\x05\x10\x0e\x73\x52\x71\x08\x8b\x65\x9e\xf7\x23\xd7\x11\x65\x12\xee\xd6\x9e\xcf\x7a\x27\x51\xbc\x90\xb2\xa6\xd1\xbe\xd5\xa1\x50\x91\x9c\x0a\x61\xce\xb7\xe7\x3a\xff\x2f\x7a\x5a\xe6\x25\x8b\xde\xab\xca\xfa\xb0\x8d\x66\xa7\x63\x0a\x48\x19\x5a\x10\xc1\xb2\xfb\xc0\x57\x51\xb5\xf9\xa5\xc3\x0e\x32\x0e\x45\x29\xa0\x9c\x81\x6a\x8f\xb7\x6c\x0f\xe0\x7d\x4e\x09\xa7\x40\x31\xaf\x86\x40\x5c\x32\x46\x63\x81\x51\xe4\x94\xf3\xac\x2c\xa2\xc0\x0f\xb5\x51\x72\xbe\xae\xbd\x63\xc4\x44\x61\x30\x7b\xbf\x58\xeb\x4d\xc1\x9b\xb7\x45\x27\xf6\x9b\x92\xe2\xfa\xba\x48\x70\xbd\x56\xd1\xc0\x41\xd6\xd8\x45\xa1\xef\x99\x8c\xd5\x73\xe2\xaa\x2a\xee\xdc\xe2\x37\xec\x1b\x73\x3d\x55\xab\x26\xa4\x8e\xaf\x12\xea\x81\xeb\x10\x0e\x0b\xd8\xd6\xb9\x71\x81\x9a\x14\xee\x28\x63\xfc\x1c\x78\xdd\xc7\xfa\xaf\x7f\xd0\x11\x5c\x5d\x56\x71\x9f\x52\xce\x02\x52\xff\x1a\x83\xc8\x7f\xf7\x63\x75\x81\x72\x79\x74\xe5\x46\x0d\xac\xc6\xce\xde\x88\x2b\x53\x41\xbb\x3c\xbe\xaa\x6f\x74\x6d\x98\xc3\xba\x5f\x9b\xd7\xb9\x3c\x9c\x68\x09\x8c\xf0\x6b\xa8\x7a\xac\xeb\xd8\x3f\x34\xfd\x5a\x81\x04\xdc\x59\xb8\x2a\xdc\x09\x39\xc6\x51\x01\x92\x3c\x87\x45\xc6\xb9\xb4\xf6\xe5\x01\x9e\xd7\xc9\x4f\x05\xbd\xb3\x56\xa6\x56\x99\x6a\x19\x94\x8e\xf9\x6c\x95\xa8\x70\xb6\x7d\xeb\x52\x38\x01\x01\xa7\x7e\x39\x2d\x12\x59\x7a\xd8\x6c\x4d\x2b\x2f\x36\xf5\x49\x9e\x97\x77\x08\x7d\x26\x95\x86\x44\xaf\x2a\xb3\x42\x40\x56\x90\x38\x5e\x32\x12\xdb\x4b\x66\xb4\x5e\x94\xd9\x6b\x2f\x22\x25\x8e\x8f\x1e\x81\x2a\xbe\xac\x4a\x7e\x15\xdd\xc3\xa9\x1c\xb7\x35\xac\x3a\xf4\xbb\xec\xb4\x13\x57\x2a\xdd\x01\xe2\x98\xa7\x55\x89\x79\xa0\x9a\x51\x4d\x5b\xbd\x01\xe2\xf3\xfd\x18\xc4\x00\x74\xf4\xd0\xba\xdf\xbe\xfd\x04\xb0\x49\xc3\xb6\x6f\xcd\xd8\xda\x49\x4d\xf6\xb4\xff\x5a\x19\xd9\x5b\xaf\x01\x6c\xdc\xad\xa1\xa0\x71\x12\xf9\x66\x18\xa6\xb3\x60\xbe\x34\x29\x56\x20\x18\x89\x29\x97\x6a\x8a\x14\x40\xef\x33\x95\x0b\x89\x6a\x3c\xf2\xd3\x2b\x6a\x5f\xa1\x33\x5c\x9d\x9b\x11\xa7\x59\x9e\x30\x5a\x84\xfd\x8e\x9b\xe4\xba\x6d\x23\x9e\x10\x2b\x30\xdb\xc3\xab\x58\x37\xd3\x46\x74\x84\x85\x36\x5b\x02\x95\x2f\x72\x66\xc2\x28\x4e\x9a\x79\x23\x8d\xe6\x3a\x61\xa4\xdd\xbe\x46\xbf\x95\x41\xba\xab\x11\x0e\x55\x3b\x4e\x69\x91\x68\xb7\xe9\x46\x7f\xa2\xa4\xfc\xd3\xb2\xb8\x95\x6b\x57\x94\xf0\xd3\xdb\x57\xbf\xe0\x51\x8a\x0b\xb2\xa8\x4c\x06\xa9\x73\x36\xde\xdf\x7b\xfd\xe5\x0b\x7c\xf3\x9d\x1e\xe1\x38\x35\xc9\xcc\x51\x87\x4f\xd7\xa0\x39\xb4\x03\xd9\x69\xee\xd6\x3b\xef\x49\x82\x21\x1b\x3a\x96\xfc\x2e\x13\x29\x64\xc5\x6d\xc6\xb3\x69\x4e\x21\x90\xab\x22\x50\x0a\x93\x03\x51\x19\xa2\x71\x59\xcc\xb2\xf9\x92\xd1\x04\xee\x87\x92\x09\x30\x2d\x97\x45\x42\x10\x00\x2d\xf8\x92\x51\x6e\xc0\x8b\x94\x08\x25\x79\x1c\x08\xa3\x90\x64\xbc\xca\xc9\x4a\xe7\x9c\x02\x81\x59\x76\x5f\xc3\x41\x2a\x78\x89\x57\x05\xa9\x2a\x0c\x85\x29\x71\x68\x1b\x58\x62\xe1\xcb\x89\x9b\x6e\xd8\xa4\x8e\x5a\xaf\xd5\xcf\xe5\x91\xd4\x32\x67\x35\xd5\x9c\x7b\x44\x45\xa3\x65\x81\x09\xad\xa8\x0f\x6c\xab\x96\x5e\x58\x37\xe1\xfa\xda\x6d\x08\xc7\x4a\x9b\x69\x8e\xb4\x46\xb1\x2a\x47\x37\xe8\x1c\xa0\xce\x50\x7b\x5b\xde\x41\xcc\x28\x11\x2a\x1f\x56\xda\x36\xfe\x22\x6e\xbd\x74\xe0\x5a\x3f\x2a\x48\x5e\x61\xa0\x23\x3c\xc6\x8e\xf0\xdb\xfd\x4f\x65\xb2\x8e\x6b\x87\xbb\xb3\xb0\xf1\x8c\xaf\x12\x5b\xc3\xfe\x00\xd5\xf1\x40\x1f\x3f\x13\x91\x6e\xe9\xf3\x0f\x59\x8f\x6e\x9f\xbf\x1e\x0d\xe0\xb1\xed\xa7\x4e\x65\x94\x8d\x3b\x72\x22\x7e\xd0\x01\x36\x01\x8c\x21\xc8\xb3\x82\x1a\x37\x28\x9e\xfe\xaa\x32\x27\xda\x9f\x21\xeb\x08\xd3\xbe\x4f\xe3\xb3\xb0\xf2\xae\x8a\x17\x99\x6c\x49\x96\xa2\x0c\x06\x1e\x51\x5f\x64\x45\x82\xf9\x10\x9c\x6a\xc9\xec\x71\x58\x90\xfb\xd1\x22\x2b\x0e\x36\x64\x6b\x48\xa5\x2b\xd8\xd2\x4d\xa2\xfe\x47\x4a\x0b\x93\x96\x21\xed\x42\x95\x90\x99\xd8\xbd\x78\x41\xee\xeb\xbd\x78\xcb\x5a\x14\xb5\x87\xc5\x4a\x8b\xec\x1f\x2f\x19\x53\xe5\x6f\x5c\x48\x2a\xf9\x4a\xef\x60\xdd\x10\x65\xe9\x7b\xb9\x23\x37\xbd\x7b\xb6\x22\x5a\xc1\x59\x63\x80\x47\x8f\xc0\xad\x7e\xd0\xb4\x1d\xd1\xd4\x69\xa0\xe4\x74\xe8\xf0\x3f\xda\xad\x54\x52\xe2\x70\xe2\xf7\xd6\xd2\xee\x6e\x18\x9e\x2c\x47\x8a\x7c\x0b\x72\xff\xf5\x71\x74\xf4\xed\xe6\x66\x59\x61\x68\xe3\xed\xf4\xc8\x01\xac\x7b\x55\xcc\xb2\x22\x13\xab\x93\x06\x67\x86\x7e\xc5\xef\xe4\xd0\x7f\x0d\x13\x4e\x11\xc7\x7d\x48\xaf\xe6\xb2\x95\xe0\x5d\x3c\x5e\xec\xc9\xd9\xc5\xfe\xfc\x5c\x3b\x19\x65\x88\xd5\x04\xd9\xd4\x0c\xcc\xe8\x66\x26\x1c\xd6\x9e\xd4\x8d\xdc\x94\x9f\x43\xd3\xae\x2b\x2d\x6c\x33\xf0\xf0\x28\x3a\xfe\x5a\x5d\x18\x92\x29\x0f\x65\xe1\x50\xc2\xeb\xd7\x87\x92\x1d\xc3\xee\x84\xb0\x36\x4e\x35\x29\x4a\xf7\xda\x34\x69\xeb\xdd\x08\xcd\x1f\xf4\x7d\x7f\x56\x5a\x66\xdc\xa5\xb2\x9d\x5c\x8f\xd5\x0e\x58\xbf\x6a\x55\xbe\x11\x98\xd2\x7b\x25\xcb\x68\x21\xac\xa6\xa4\x33\x13\xbc\x28\xb2\xf8\xe6\x85\xce\x29\xc5\xb0\x6b\x95\x60\xfa\xef\x6f\x7e\xbc\x18\x74\xec\x11\x88\x8e\xde\x23\xdc\x84\x10\x9f\x74\xfa\x39\x8f\x7a\x16\x69\x79\x4b\xd9\x33\x2a\x48\x96\x77\xcf\xe5\x65\xdd\x60\xbf\x09\x29\x34\xfd\x58\x66\xa5\xf3\x07\x70\x3f\x80\x95\xaf\x36\x75\xa4\x49\xef\x94\x57\xa4\x30\xa6\xa2\x2c\x0c\x30\x90\xd7\x5e\x4d\xdc\xc3\xd7\x68\xc0\xf5\x23\x51\xfe\x74\xf1\x54\x39\x76\xc2\xbe\x8a\xe3\x95\x7d\xcf\x7a\x27\x0e\x58\x7e\x47\x44\x9c\xb6\x01\xe3\x3c\xae\x55\x6d\xa0\xd2\xd6\x26\xc1\x94\xc4\x37\x73\x26\x4d\xa2\xa1\x3e\x1d\xaa\x18\x62\x54\x17\x58\x22\x87\x91\x96\x6b\x7b\xa0\xb8\x2c\x04\x2d\xf0\xc8\xa6\x86\x3c\x04\x3d\xdb\xa8\xcb\x9f\x86\x86\x99\x72\xaa\x8d\xc1\x75\x30\xae\xf4\x4c\x74\xf0\xbb\x19\xc2\x89\xa9\xc1\x06\x53\x86\x64\x31\xa3\x3a\x45\xda\x2b\x5c\xfb\x50\x7d\x34\xda\xf6\x0a\x7a\x23\x4c\xce\x76\x07\xe3\x5f\x63\x5d\xa7\x3d\xa2\xba\x59\x83\x64\xab\x40\x38\xa3\x39\x31\xdd\xdd\x43\xfe\x48\x53\x72\x9b\x95\x2c\xd2\xaa\xfa\xa5\xe9\x10\xc2\x5e\xa2\xa7\xf0\x1a\xeb\xbf\xfe\xe0\x3c\xa5\xf9\xad\xb4\x4c\xf7\x1a\xf9\x02\xad\x83\xfd\x04\x7e\xd3\xa8\xee\x35\xb5\x7d\x34\x61\xa7\x13\x9c\x67\xbf\xfd\x91\x23\xa7\xaf\xa6\x1e\x34\x7c\x49\x1d\x9a\xc0\x1e\x0a\xec\x3d\xf7\x1f\x35\x11\xb7\x58\x05\xb5\xba\xd9\x23\xe8\xae\x23\x06\x61\x47\x24\x40\x37\x4d\xe4\xd9\x5a\x63\xa1\x33\x6c\x39\x54\x04\xdf\xcd\x71\x13\x70\x67\x25\xb3\xf6\xa0\x3a\xf0\xa0\xc3\xd4\xc9\xba\xe5\xe4\x96\x1e\xe8\x53\x91\x93\x6b\xfb\xe4\xdf\x9e\xfc\x02\xe6\xa2\x50\x9e\x62\x4a\x96\x50\xa6\xd2\x74\x87\xd6\x27\x0a\x99\x50\x6e\x5b\x67\x4c\x05\xec\x4e\x5a\xa2\x12\xe2\x92\x53\x26\x0f\x58\xf2\x7c\xa4\x92\x00\x10\x1f\xf7\xd5\x0a\x9b\xa2\xab\xfd\x8d\xde\x41\xb1\x3b\xb5\x17\x9d\xaf\x3b\xdd\x11\x9d\x5e\xd3\xb7\x25\xa2\x89\xee\x21\x0e\x33\xa9\x11\x1b\x9e\xd0\xb6\x5f\xe0\x82\x4c\xfd\xcc\x6c\x37\xe5\xd6\xb9\x21\xb2\x29\xc0\x7b\x49\x41\x23\xae\xa3\x11\x24\x48\xf6\x92\x03\x15\xbc\x55\xe7\x0e\x6f\xc7\xd2\xa5\xb4\xf2\x87\x9b\x0b\x92\x1f\xcb\x64\x65\x48\xed\x80\xf3\xdf\x91\xb9\xc6\xcc\x47\x10\xd3\x32\xd1\x39\xee\xd8\xcf\x8b\xed\xe2\x77\x99\x88\xd3\xb0\x71\xb3\xad\xf0\x8f\x09\xa7\x10\xdc\xd2\x58\x94\x2c\x18\x1f\xb8\xe6\xa1\x7f\x05\xed\x73\xd0\x0c\xa3\x9d\x22\xc1\xa9\x60\x67\xa7\x22\x81\xb8\xcc\xe5\x5e\x35\xe9\x3d\xee\x9d\x9d\x66\x67\x85\x62\xec\xe9\x28\x3b\x3b\x1d\x89\x44\x7e\xb0\xb3\x3a\xc5\xa3\x19\x1f\xdb\x1d\xf5\xdd\x71\x1d\xee\xa7\x14\x22\x0f\xb4\x5d\xaa\x1b\x5e\x66\x57\xee\x6e\x69\x2f\x9b\xba\x3c\xd2\xd6\x21\x7d\xb2\x6d\x6a\x67\x8d\x6b\x37\x05\x52\x5f\x8e\xc9\xa9\xe9\x26\xda\xe1\x7c\x79\x7c\x55\x57\xb9\xb3\x56\xf3\xc4\x04\x9c\x13\x4b\x7f\x7d\xab\xf0\xff\x31\xfd\x6f\xff\x38\xfd\x6f\x9b\xf4\xb7\xb9\x0f\x17\xf4\x5e\x5a\x38\x81\xbd\x82\xb0\xe8\x7d\x52\xe8\x7d\x82\x53\xb8\x35\x1e\x7e\x83\xdb\x27\x3f\xdd\xb4\x86\x74\x38\xb1\x8d\x2f\x3f\x5d\x69\x0e\xc1\xff\x94\x5c\x73\xcb\x8f\x14\xe7\xa6\x6c\x74\x16\xf8\x6e\xde\x3f\x29\x1a\x0e\x26\x7b\x4b\x86\xbe\x83\x51\x92\xd1\x3d\xba\x6a\xe2\x8d\xe4\x72\x62\x93\x20\x36\x07\x42\xcb\x76\xfb\x40\xd8\xc4\x1b\xc8\x99\xb5\x3f\x66\x7f\xc7\xa0\xda\x4d\x39\xee\xdc\x0f\x7e\x2a\xf8\xb2\xaa\x4a\x26\x68\xa2\x93\x58\xf0\xfe\xac\x05\x64\xe7\xd6\xce\x36\xbc\x0d\xda\x95\x10\xde\x7c\x40\xd0\xf3\x49\x3b\x36\xd5\x79\x77\xf1\xde\xa6\x56\x7d\x9c\x72\xf1\x5a\xd5\x88\x91\x29\xbf\x96\x8a\xdf\x1e\x2e\x57\x76\x5b\x55\x55\x67\x13\x38\xa6\x8f\xff\xd2\x88\xea\x0f\x57\x30\x52\xe5\x91\x28\x9d\x73\x4a\xf0\x6b\xe0\xb8\x3d\x9a\x50\x8e\x37\x40\x39\x6e\x42\xf9\x8f\x2d\x50\x8e\xff\xda\x0d\xe5\xf8\xaf\x4d\x28\xcf\xb7\x41\xf9\x76\x03\x94\x6f\x9b\x50\xde\x6f\x83\xf2\x78\x03\x94\xc7\x4d\x28\x17\x5b\xa0\x7c\xdf\x0d\xe4\xfb\x26\x8c\xbf\x6d\x81\xf1\x5d\x37\x8c\xef\x9a\x30\xde\x6c\x81\xd1\x4c\x10\xd3\x30\xbe\x69\xc2\xb8\xd9\x0c\xa3\x01\x61\xd5\xd5\xce\xdb\x5b\xb6\x35\x3c\x95\x48\x0d\x37\xc9\xde\xb0\x2d\x7c\xab\x6e\xc4\x34\x9c\x0d\xd2\x37\x6c\x8b\xdf\x6f\xdb\xe0\x6c\x92\xbf\x61\x5b\x00\xc9\x56\x38\x1b\x24\x70\xd8\x16\xc1\xd9\x56\x38\x1b\x64\x70\xd8\x16\xc2\x6a\x1b\x9c\xef\xeb\x7d\xac\x01\xa8\x25\x88\xc5\x36\x38\x1b\x24\x71\xd8\x12\xc5\xff\xfd\xbf\x36\x81\x39\xa6\xc3\x0d\xb2\x38\x6c\x09\xe3\x62\x33\x2e\x5d\x32\x76\xb0\x3e\x38\xb0\x99\xd6\x6e\xf4\x00\x82\xac\xf5\x22\x2d\x44\x26\x56\x6f\xd4\x43\x02\x08\x25\x78\x14\x8c\x21\x78\x44\x16\xd5\x89\xc9\xbc\x3d\xc5\x92\x5c\xd8\x82\x33\x2c\x98\xdb\x82\x5e\xd0\x1b\x43\xef\xd1\x3f\x97\xa5\x38\xd1\xcf\x01\x04\xbd\x40\x16\x7d\xf5\xcd\xf7\xb6\x64\xa4\x4a\xee\x1f\xbf\x38\xe9\xd9\x47\xb7\x34\xd2\x7a\xaa\x1a\xbd\xfa\x3d\x82\xcb\x47\xa7\x67\x41\xef\xe3\xe8\x6a\x34\x1f\x38\xa9\xe3\xbc\x31\x67\x3b\x8d\x4b\x7e\x65\xee\x87\xd7\xde\x8e\xf1\x9e\x74\xa5\xec\xd5\x2f\x69\x9b\xeb\xfc\xc6\x46\x23\xbb\x35\x9e\x4d\xee\xde\xf9\x10\x48\x9d\x33\x8d\x80\xf1\xaa\xf1\xa7\xf3\xd7\xf5\x15\xaf\xdb\xaa\xd3\x06\xf5\x1a\xa8\x1b\xab\x75\x1d\x4b\xe8\xd5\x1a\xb7\x37\x0e\x45\x92\x44\x79\x31\x40\xbf\xc9\x8d\xbb\x6f\xf0\x15\x49\x92\x6b\xfd\x16\xa0\x7e\x94\xc6\x6b\xae\x1e\x4f\x94\x45\x03\xf8\xbc\xee\xb7\x37\xda\xc6\xfc\xcd\x8c\xda\x34\x90\xb3\xd3\xe1\x87\x79\x19\xa3\x0b\x34\xe2\x94\x30\xf5\x72\x6d\x10\x34\x18\x66\x82\x70\x34\xf5\x30\xa2\xfa\xbd\x09\xe7\xef\x86\x13\xf1\xe5\x54\xc9\x47\x78\xdc\x8f\x78\x95\x67\x22\xec\x3d\xea\xd9\x04\x94\x1a\xc6\x4b\x9a\x57\xd6\x2d\xd5\x9c\xcc\xdf\x1b\xcd\x42\x37\x94\xa0\x09\x43\x4d\xb8\xee\xc2\x43\x07\xd3\x9d\xd4\x32\x54\x76\xa9\x65\x5e\x5b\xf6\x05\xa7\x8d\xab\x3a\x62\x23\xc9\x1e\xda\x97\x8e\x9d\xe7\x4a\xb5\xc3\x59\xbf\x03\xad\x0c\x4c\xc9\x59\x75\x40\xff\xe9\xfc\x75\xcd\xda\xbe\x53\xad\xec\xaf\x06\xef\xfb\x07\x00\xfd\xfa\x49\x76\xb5\x1e\x94\xf4\xd5\x37\xf7\x0f\x35\x7b\xfb\xda\xaf\xd5\x0e\x2d\x35\xe1\x08\xd6\xeb\x55\x3f\x12\x26\xe9\x34\x1a\xc1\xdb\x77\x17\xcf\xc7\x8d\xe7\x17\xa6\x14\x6e\x68\x25\xf0\x91\x8d\x55\x11\xab\xab\xe9\xd1\x52\x64\xf9\x88\x0b\x66\xfe\xc6\x65\x71\x1b\xcd\xcb\x31\xc2\x7d\x9d\x15\x37\x2f\x4a\xf6\xdc\x86\x78\x6d\xe1\x81\xa5\x47\xf7\xb2\x45\x76\x2a\xe5\x63\x56\xad\x9e\xbe\x17\xdb\x34\x57\x6b\x0b\x9f\x11\x70\xe3\xc1\x1a\xab\x5e\x51\xa0\x7e\x3c\xc1\x04\x65\xfc\x69\xf1\x74\x40\xbc\x9b\x7e\xa2\xb1\x54\x42\x2d\x59\x9d\xd3\x82\x32\x22\x94\xb8\xaa\x66\x9e\xc2\x31\xf8\x7b\xd1\x70\x0f\x55\xd0\x4f\xe8\xc0\x36\x71\xbf\xea\xd1\x64\x15\x6e\xf9\x48\xbf\xc4\x99\x66\x5c\x94\x6c\x85\xc2\xf1\x41\x10\x41\xc3\xcf\xeb\x01\x04\xc1\x00\x54\x08\xc9\x0f\xf2\x00\xe3\x10\x75\xe7\x1a\x71\x04\xd2\xe5\x90\x92\xbb\x0e\x1d\xed\xb2\x48\xbf\x63\x53\x77\xea\xc3\x67\x3d\xad\x39\xba\x4d\xb1\x5d\x47\x48\x7c\x27\xa5\x1b\x02\xb2\x4f\x97\xa6\x66\xfc\xbb\xa7\xc6\x2c\x34\x57\x67\x58\xc9\x43\x47\x23\x4d\xfc\x2e\xea\x6e\x08\xa7\xf5\xaa\xb8\x25\x79\x96\x74\xa8\x1d\xf5\x64\x8c\xab\xb6\x54\x37\x2a\x62\xc3\xea\x17\xac\x5c\xbc\x53\x03\x68\x00\xed\xe1\x06\x70\xb4\x27\x65\xa2\x7a\x74\x75\x89\x05\x13\x18\xfd\xe7\xfc\x63\x72\xf8\x31\x8a\x0e\x27\xd1\xe1\xc3\xd1\xef\x23\x56\xc7\x0c\x5d\x7a\xa1\x44\x5e\x2c\xab\xdc\xdc\xfa\xea\x69\x3a\xe5\x2d\xde\xd7\x75\x8d\x9d\xe6\x77\x4f\x2e\x12\x94\x0b\x17\xde\x49\x77\x5e\xc5\xce\x49\x6e\xe3\xc7\x06\xf1\x18\x28\x91\x7d\x55\xeb\x19\xb9\xaf\x3a\x0d\x6a\xa3\xa1\x75\xb6\x68\x6c\xa9\x15\xfe\xde\xc0\xbb\x99\xd4\xb6\x08\xcf\x7b\x5b\x0a\xa1\xa9\x9f\x24\x08\x9d\x21\xcd\x5e\x5a\x2c\x17\x53\xca\xde\xcd\xd4\xa0\x2f\x4a\x26\xa1\x98\x45\xea\xa2\xb3\x37\x1b\xea\x0a\x15\x03\xc9\xff\x91\x89\x34\x6c\x21\xa9\x89\x6d\x53\x74\x34\x05\xb6\xe1\xb3\x9b\x12\xbb\x26\x21\x6d\x89\x98\x86\x47\x83\x2d\xf3\x56\xea\xaf\x13\x54\xbb\xd0\xdf\x3c\xf6\xa2\x89\xb5\x6d\x5a\x24\xd1\xb4\x70\x1f\xdc\xf4\xdf\xdb\xa9\x6d\x4d\x67\x75\xbf\x9b\xbd\x2b\xf4\x2e\xdc\xc6\xcf\xf2\x59\x01\x79\x12\xc7\xcb\xc5\x32\x27\x02\xf3\x72\xf6\x50\x26\x1b\x24\x16\x0e\x75\x3e\x70\x0b\xac\x0d\xf1\xaa\x7f\xaa\xa2\xf9\x22\x8d\xd3\xfa\x77\x2f\xb5\xcd\x93\xdf\xad\x86\xbd\x67\x8b\xc0\x17\xee\x56\x34\x8a\xcb\xc4\xba\xf7\x5b\xb2\xa0\x4f\x8a\xc4\xa4\x14\x08\xc5\x51\x65\xa0\x4e\x7a\xce\x06\x5e\x37\xb7\xbf\xce\xe3\xf6\xbd\x3c\x52\x0f\x1b\xb9\x8d\x0d\xd0\x84\xc6\x65\x42\x7f\x3a\x7f\xf5\xb4\x5c\x54\x65\x41\x0b\x43\x4b\x0f\xc0\xf1\x55\x7d\x74\xfa\x78\x28\xcf\x4c\x01\x04\xfd\xbe\x86\x2a\x57\x92\x8b\xc2\x04\x02\x41\xa6\x4e\xe6\x86\x3f\xa4\xcd\x22\x77\x8a\xd5\x93\x99\x82\x4c\x21\xe3\x18\x1a\x36\xa7\x4c\x3b\x5a\x5d\x83\xf4\xb2\x1e\xe6\xca\x4e\xf5\x67\xf3\xc2\xd1\xba\x83\xfd\xed\x07\x89\x76\x31\xbd\xa9\xc7\x5c\x56\x3b\x86\x9a\x1e\x25\x98\x4b\xcb\x24\xd3\x62\x1a\x44\xed\xb4\x9b\x5d\xe3\x75\x98\x57\x2d\x8b\xa5\x61\x69\x59\x29\xab\x0c\x86\xdd\x1a\x38\xf3\x94\xaf\x6f\xe6\x29\xb1\x54\x5f\xa3\x1b\xba\xe2\xde\x48\xfd\xb6\x90\xde\xd4\xbf\x0b\xe2\x40\xba\xd4\x28\x1c\xc2\x0d\x5d\x5d\x19\x5b\x55\x43\xb9\x94\x65\xad\xb8\x6a\xa7\xb7\x22\x96\x3d\x7f\xcb\x63\xb0\x36\xa2\x55\x7e\xf5\x07\x2a\x96\x95\xbe\x7c\x8e\x49\x9c\xd2\xb1\x7a\xd1\xb4\x66\xb6\x97\x87\xdd\xf9\x08\x28\x17\x44\x64\xf1\xe8\x13\x1f\xa9\xc3\x8e\xfd\x59\x9d\xd4\xfc\xd4\xce\x0f\xb7\x13\xc9\x44\xef\xf7\x71\x74\x1c\x62\x2b\xdb\x3a\x21\x82\x48\x0c\xb5\x64\x7b\xbf\x79\xa3\xaf\x55\xcc\x3d\x84\xfd\x7d\x1c\x14\x78\xd5\xd3\xd4\xa9\x5c\x9f\x67\xb4\x62\x34\x26\x82\xaa\xf3\x1c\x1e\xe9\xfd\x4c\x87\x24\x63\x34\x16\x17\xe5\x9b\x6c\x2e\x65\x24\xb1\xa7\x7e\xe8\x8a\x83\xc7\x9f\x1b\x53\x0e\x89\x8e\x33\x40\xe8\xc4\xd3\xa3\x50\x2a\x72\xb7\xa3\xe3\xb5\x97\x03\x8f\x56\x17\x29\xe5\x14\xc4\x5d\xa9\x53\xdc\x79\x37\xde\x18\x7c\xd9\x89\x6e\x5f\x42\x21\x8c\x02\x49\x12\x9a\x40\x59\xe4\x2b\xbc\x1a\x9a\x92\xf8\xe6\x8e\xb0\x04\x73\x99\x89\xc8\xa6\x59\x9e\x89\x95\x3c\xb9\x95\x79\xa2\x64\x44\x87\x09\x45\x8e\x80\x74\x92\x6c\xa3\xa3\x20\x25\x3c\xdd\x62\xd9\xd4\x8f\xe3\x9a\xcd\x4f\x69\xc3\xe4\x05\x23\xf3\x85\x8a\xd8\xe9\xd0\x8f\x5d\xa3\xa8\xdb\x5c\xb6\xb2\xcc\xc0\xe4\x60\xcd\x78\x1f\xa8\xde\x93\xc3\xe3\xbe\x52\x7a\x09\x2b\x2b\xbc\xd8\x97\x70\xe0\x2b\xf4\xc6\xc5\x18\x26\x14\xd2\x96\x4f\xd1\x41\xb9\xb6\xd2\x99\x54\x7f\xae\x63\x6e\x83\xdc\x58\xb5\xf1\xe7\xa6\xd9\x71\x40\xfd\x33\xb3\xed\x56\x4d\x4d\xaf\x94\x67\xf9\x94\xbe\x3a\xac\xf7\x4d\xab\x0f\x3b\xd4\xb2\x6c\xe3\xaa\xbb\x72\x1f\x4d\xb7\x5d\xd7\x95\x0d\x35\x07\xde\xaf\xfa\xd8\x89\xe1\x73\x11\xdd\xc7\xe1\x06\x91\x3b\x9e\xc0\x68\x1c\x7f\x91\xd1\x0f\x43\xb9\x74\xfb\x27\x07\xff\x27\x00\x00\xff\xff\x31\x79\xab\xcb\x60\x70\x00\x00")
+var _webUiStaticJsGraphJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xe5\x7d\xeb\x7a\xdb\x38\x92\xe8\xff\x3c\x05\xc2\xce\x46\x54\x5b\xa6\xed\x64\x92\xed\xb6\x63\xcf\xe6\x3a\xc9\x6e\x6e\x9d\xb8\xbb\x67\xd6\xf1\xfa\xa3\x24\xd8\x62\x42\x89\x1a\x92\xb2\xad\xcd\xf8\xb1\xce\x0b\x9c\x27\xdb\xba\xe0\x4a\x82\x92\xd2\x3d\xbb\x67\xce\xb7\xf9\xbe\xc8\x12\x08\x14\x0a\x85\x42\xa1\x6e\x00\x2f\xd3\x52\xbc\x2f\x8b\xa9\xac\x27\x72\x51\x89\x43\xf7\xc7\xdf\xfe\x26\xbe\xde\x1c\xdc\xba\x84\x2a\x17\x65\x3a\x9f\x1c\xcb\xe9\x3c\x4f\x6b\x79\x70\x8b\xca\x3e\x3e\x7f\xfa\xee\xed\x33\x68\xb2\xb7\xbb\xbb\x0b\x65\xb6\x65\xf2\x27\xac\x0e\x4f\xce\x17\xb3\x51\x9d\x15\xb3\x58\xe6\x72\x2a\x67\xf5\x40\x14\x73\xfc\x5d\x0d\xc4\x24\x9d\x8d\x73\xf9\x14\xfe\x5c\x48\xfd\xeb\x83\x9c\x16\x97\xb2\x2f\xbe\xde\x12\xa2\x9e\x64\x55\x22\x73\x00\xa2\xda\x1e\xe8\x42\xc2\xe5\xe5\xf1\x9b\xd7\xf0\x6c\xb6\xc8\x73\xf3\x40\xc1\x86\x62\xf5\xcd\x3c\x71\x3b\x83\xc7\xee\xcf\x46\x1d\x46\xc1\x45\x9d\xd1\x11\x1e\x8a\x31\xb6\xe8\x63\xd3\x1b\xd3\xbe\xcc\x46\x5f\xaa\x49\x7a\xa5\xc7\xee\xa1\x36\x4e\xeb\x14\xca\x4e\x4e\x81\x4e\xaa\x28\x9b\x65\x75\x96\xe6\xd9\x7f\xca\x18\x20\xdd\x04\x08\x98\xd4\xd9\x54\xbe\x48\x47\x75\x51\xe2\xa0\x10\x8d\x68\x19\xed\x8b\x87\xbb\xe2\x7b\xfe\xb8\xf7\x07\xf8\xb8\xff\xf0\xc1\x00\x1f\x5d\xb5\x1f\xfd\x33\x3d\x18\x37\x1e\x50\xe1\xc4\x16\xd2\xef\x29\xfd\xa6\xaf\x15\x7c\xdd\x0b\x63\x54\xd5\x72\xfe\x4b\x9a\x2f\x24\x22\x74\x82\x95\xf7\xaa\x68\x00\x9f\xbb\xfc\x67\x8a\x9f\x0f\xe8\x73\x8f\xff\xdc\xdf\xe5\x5f\x13\xfc\xbc\x47\x9f\x0f\xe9\x73\x8f\x7f\xec\x8d\xe9\x01\x7c\x12\xb4\x2b\xfa\x45\x9f\x7f\xa0\xcf\x1f\xe8\x73\x6f\x49\xe5\xcb\xe8\xd6\x69\x08\xad\xd9\x62\x4a\x5f\x10\x2b\x64\xc5\x9d\x1d\xf1\xd3\x42\x96\xcb\x97\x59\x05\xc4\x5b\x8a\x2f\x52\xce\x2b\x20\xbb\x14\x79\x5a\xd5\x42\x5e\xcb\xd1\xa2\x96\x63\xf8\x32\x2f\x65\x55\x11\xd7\x64\x33\xae\x50\x8c\xd2\x5c\x60\xb3\x14\x78\xa5\x38\x47\x58\x58\x3e\x2c\x8b\xab\x4a\x96\x03\x31\x2d\x00\x42\x29\x47\xc0\x92\xe2\x3c\x2b\xab\x3a\x71\xf1\xf1\xba\xe5\x39\x53\xb0\xfe\x4d\x2e\xf7\x45\x34\xb7\x55\x27\x5c\x8b\x06\x3e\x4d\xaf\x9f\xcf\xea\x32\x93\xd5\xbe\x78\x00\xb3\x00\x45\x17\xb2\xde\x6f\x73\x61\x0d\x60\xf9\x9b\x10\xb8\x08\x27\xa6\xab\x7f\xfd\xf8\xee\x6d\x32\x4f\xcb\x4a\xc6\x34\x86\x8f\xdc\x6d\x02\x70\x5e\xd5\x72\x1a\x77\x20\x99\x58\xf4\xfa\xc4\xd1\xf8\xaf\x94\xf5\xa2\x9c\x89\x3b\x49\x56\x3d\x2e\xcb\x74\x19\xab\x6e\xfa\xe2\x8f\xa6\xc7\x7d\xe2\x66\xac\x7d\x23\x46\x69\x3d\x9a\x88\x58\xf6\x0d\x6e\x0a\x82\xa9\x82\x4b\x85\x86\x95\x8e\xc7\xce\xb0\x70\x02\x74\x23\x7f\x38\x5d\xe8\xc2\x70\xe2\x7e\x72\x9e\xe5\xb5\x2c\x63\x0b\x07\x80\xe8\x3e\xa5\xb8\x7d\x78\x48\x53\x7b\x20\x6e\xd4\x90\x14\xdc\x64\x31\xab\x26\xd9\x79\xcd\xfd\x1e\xb4\x28\xea\x11\xae\xda\x98\x70\x03\x26\x7e\x05\x13\x38\xbb\xc8\xce\x0d\xb9\x92\x2a\xcf\x46\x32\xde\x1d\x74\x8e\xc6\xce\x7b\x5f\x53\x3f\x44\x4d\xe0\xc1\xe3\x16\x6f\x4e\xd3\xa5\x18\x4a\xb1\x98\xa5\x97\x69\x96\xa7\xc3\x1c\xe4\x28\x4c\x77\x82\x9c\x3c\x2f\xb3\x4b\x90\xd4\xcc\xb5\x80\x54\x62\x67\x21\xbc\xb0\x81\x2f\xeb\xa2\x5e\xce\xa5\x23\x97\xda\x52\x10\xa7\xa8\x92\xf9\x39\x3c\x41\x19\x86\x08\xe3\xcf\x24\x1b\xfb\x33\xd6\x58\x95\x5b\x5b\x24\xf6\x60\x14\x1f\x65\x2d\xc6\xf2\x3c\x5d\xe4\xb5\x16\xd2\x89\x06\xa2\x7f\x13\x30\x05\xf6\xa0\xf9\xb0\x44\x99\x7d\x96\xcd\xe6\x8b\x5a\xd7\x0a\x3d\x82\xad\xcb\x41\x07\x7a\xad\x81\x08\x95\x62\x9e\xbc\x28\xbe\x0c\xd3\xd1\x17\xac\x85\x82\x09\x3b\xc9\xce\x45\xec\x41\xab\xd3\xa1\x38\x04\x3e\x5a\xcc\x00\xdf\x6c\x26\xc7\x7a\x36\xda\xb5\xc4\x1e\xed\x04\x6a\x88\xcf\xca\xf4\x8a\xf7\x4b\x31\x2a\x60\x6e\x8b\xbc\x12\xb0\x75\xd0\x8f\x14\x00\x95\xe2\x1c\x30\x13\x2f\x69\x3b\x19\xc2\x7a\x15\xb5\xda\x57\x93\x5b\x8a\xc4\x76\x23\xe3\x2e\x7b\xf3\xb4\x9e\xbc\x2f\x01\x8f\xeb\xde\xbe\x78\xff\xf8\xf8\xe5\xd9\xfb\x0f\xcf\x5f\xbc\xfa\xf3\x80\x1f\x0f\x17\x59\x3e\xfe\x45\x96\x28\xc8\xa0\xc2\x93\x9f\x5f\xbd\x7e\x76\xf6\xcb\xf3\x0f\x1f\x5f\xbd\x7b\xab\xf7\xa8\xcf\xc4\x77\x89\xbc\xae\xe5\x6c\x1c\x9b\x6d\xd8\x1d\x4d\xdf\x50\xdb\xdd\x62\xef\xc4\x6f\x16\x55\x9d\x8e\x26\x32\x29\xa1\x29\xac\x3a\x4f\x19\x30\x5b\x7a\xdf\x36\x97\x79\x92\xce\xe7\xd8\x8f\x0f\xad\xaf\xd9\xe0\x4f\x12\x45\xe8\xb9\x04\x80\x23\xd8\x4a\xea\x42\xa4\x79\x4e\x62\x36\x9b\xc1\xba\x96\x15\x4e\x97\xde\xf8\x8d\x68\xb6\x44\x65\x3a\x02\x05\x19\xdc\x30\x03\xfa\xca\x4b\x94\xc8\xbc\x4b\x97\xc4\x55\x46\x71\xf9\xb5\x44\x74\x4a\xcd\x30\x80\x1e\xcc\xe8\x38\x8e\xbe\xa3\xa7\x67\x57\xfc\x38\x12\x5b\x9a\xed\xec\x50\xfe\x8a\x54\x7b\x51\x94\x53\x68\xec\xc2\x52\x10\xf8\xf9\xd9\x39\x54\x88\x78\x74\xdc\x03\x88\x97\x70\x83\x1a\x26\x20\x2d\x65\x7a\x32\x4b\xa7\xf2\x10\xeb\x9d\x46\x0e\xe1\xe0\x77\xf2\x45\x2e\x69\x57\xf2\xa5\xdb\x2d\x25\x07\x9e\x23\x81\xc4\x55\x5a\x09\xaa\x04\x9b\xd8\x55\x56\x4f\x0a\xe0\x79\x24\x11\x49\x37\xd8\xee\x96\xbc\xe0\x91\xab\x65\x72\x35\xc9\x40\x9e\x00\x2f\xef\xdd\x17\x77\xef\x8a\xdb\x20\xda\xb0\x1a\x4a\x7c\x23\x61\xfc\xc1\x26\xd5\x62\x38\xcd\xea\xd8\x6c\x08\x12\x04\x04\x11\xf8\x19\x2f\x5e\xfd\x84\x98\x9e\xf0\x7a\xbc\xa8\x8b\x6d\xc0\x08\xe5\x06\x62\x82\x03\x15\x38\x52\x51\xcc\x04\x2d\xca\xc4\x48\xf9\xe2\xfc\x1c\x84\xab\x12\x22\x09\xff\x7a\x29\xb3\x8b\x49\x2d\xb6\xb9\x6c\x94\x67\xd0\x19\x97\x1d\x98\x76\x0c\xfe\x58\x91\xd0\xd7\x2f\xed\x50\x04\xb0\x2c\xfc\x4e\x46\x40\xc2\xde\x84\x40\xf4\x06\xa2\x97\x02\x82\xbd\x66\x29\xb0\x42\x35\x82\x25\x9a\xab\xee\xb7\x14\x6e\x7a\x78\xfc\xe7\x0e\xeb\x7b\x09\x74\xd4\x03\xda\x2e\xe6\x3c\x20\x68\xef\xca\xc7\x06\x7a\x4a\x47\x54\x5b\xd0\x4d\x63\x92\x47\xa4\x7c\xf2\xfa\x70\xd5\x51\xa7\xda\x38\x03\x41\x8f\x8a\xa4\xbc\x72\x85\x19\x7e\xfd\xe9\xf5\x73\x7a\x1a\x1b\x80\x0e\xf3\x91\x1c\x7c\xe5\x4a\x48\x3b\xaf\xcc\x84\x84\x3d\x73\xa0\x23\x34\x5d\x46\xc4\x05\xff\x45\x8e\x9f\xd4\xb3\x2e\x18\xba\xca\xd9\xb0\x9e\xb5\x1b\x6e\xd0\xb3\xaa\xe9\xf6\x3a\x91\x69\x3d\x4d\xe7\xab\x7a\x55\x55\x1a\xbd\xaa\xd2\x0d\x7a\x55\x35\xdd\x5e\xb3\x19\xe8\x75\xf5\x1b\x09\xdb\xf0\xa8\x0b\x02\x14\xca\x91\x02\xc1\xf5\xcf\xa6\xd4\xc0\x43\x9f\x37\xf5\x8f\x54\x77\x13\x48\xaa\x81\x0b\x03\xa4\x22\xb0\xd1\xe4\x15\xae\xf2\xcb\x34\xdf\x04\x8a\x6a\x72\xea\x0a\x20\x10\x92\x55\x91\xcb\x63\xda\x9e\x42\x72\x4b\x55\x88\x1a\x32\x1f\x1b\x88\x8e\x26\x2c\x2c\x8d\xf8\x75\xbb\x83\x6d\xb0\x0a\xb7\x4a\x4f\xd0\xf4\xd9\xae\x8b\x8b\x8b\x5c\x1e\xf6\xa0\x62\xcf\x1d\x2e\x36\x4c\xe4\x5f\x5b\x5b\x6f\x1f\x3f\x60\x98\x93\xe2\xaa\x59\x1b\x16\x1b\x95\xcf\x92\x21\x55\x8d\x9c\x55\x28\x5d\x5d\x12\x56\xe1\x05\x49\x19\x10\x07\x09\xff\x50\xcb\x3a\xb0\x85\xf3\x73\xd4\x9f\x41\xee\x80\x8e\x00\xc8\xcb\xeb\xd8\xad\xef\xae\x52\xfd\x00\xe5\xeb\x1d\xd8\x47\x70\xeb\x50\x10\xd2\xba\x2e\x61\xd8\x65\x96\x6e\xeb\xed\x3f\xea\xf7\xa1\x75\xf5\x14\x6c\x8f\x2a\x8e\x4a\x99\x17\xe9\x18\xca\x7c\xd9\xcb\x12\x97\x36\x69\x2b\x5c\x59\x6e\xf0\x26\xf7\x81\xb5\x5b\x34\x3f\x2b\x71\x5e\x8c\xc0\x40\x27\x25\x06\x36\x4f\xda\x6e\x80\x2d\x6b\x99\x8e\x41\x80\x09\x86\x55\x2b\xbd\xaf\xc5\xe4\xc9\x90\xa6\x06\x24\xd9\x18\xc8\x88\x86\x15\x6b\xce\x41\x4a\x5a\x91\x45\x7d\x7a\x24\xa1\x62\xe0\xd2\xd8\xff\xa5\x35\x59\xad\x8f\x07\xf7\x8e\x9b\xbe\xdd\x2d\xcb\xb2\xe8\xd8\x2e\xf9\x59\x04\xf4\xcb\xc6\x8a\xea\x96\x59\x1f\xf3\x26\xd0\xcd\xab\x28\x86\x9b\x1c\xae\x57\x94\x81\xe0\x35\x71\x6a\x2f\x1f\x5f\x67\x55\x67\xed\xe5\x59\x0a\x8f\x9d\xea\xb9\xbc\x00\x85\xa7\x03\x1d\x7e\xe8\x8a\xc9\x79\x36\x9b\xc9\xae\x41\xab\xa7\xae\x62\x70\x89\x76\x49\x5a\x57\x5d\x64\x82\xe7\x67\x15\x56\xf0\xd4\x90\xd9\xf8\x19\x5a\x01\xc1\x36\x8e\x50\x84\x7a\xed\x2d\x40\x35\x46\xd7\x85\x44\x47\xc4\x3c\x03\x71\x5d\xc6\xcc\x15\x64\x89\xc8\x7d\xd1\x93\xb3\x1e\x2b\xa1\xa8\x02\xa5\x60\xb1\xf6\xfe\x02\xff\xb6\xdf\xbc\xd9\x7e\xf6\x4c\xbc\x7c\xb9\x3f\x9d\xaa\xe7\x75\x51\xe4\xa0\xed\xbe\xcf\xd3\x11\x69\x75\x50\x73\x58\xd4\x75\xa1\x9f\x57\x30\xc1\x4f\x96\x1f\xe1\x73\x1f\x4c\xb2\x85\x54\xa5\xb0\xd0\x8f\x8b\x71\xba\x7c\xb2\x80\xba\xb3\xe6\xa3\xa7\xb9\x4c\xcb\x76\x61\x51\x79\x40\x10\xfb\x7f\x2f\x66\x88\xee\xcf\xc7\x4f\xa9\x3f\xde\x8e\x5b\x4a\xbf\x21\x84\xcf\xfd\x96\x12\x69\xdc\xc3\xaf\xc7\x00\xf1\x3d\xd1\x03\x34\x0a\x24\x50\x17\x18\x36\x0c\x1a\x70\x50\x82\x8d\xe7\x4a\x05\x88\x1a\x4a\x44\x40\x18\xb8\xca\x43\x63\x7f\xd0\x7a\x44\x1b\xc4\x62\x8e\x78\x7d\xe0\xea\x1a\x88\x91\x06\xd5\x47\xb3\x4f\xb7\x5c\x0c\x6a\xd9\xba\xdb\x39\x2f\x6b\xb2\x87\x7a\x7b\x3d\xe5\xf7\xd2\xf6\x60\xbd\xcc\x25\x81\xe3\x7d\xbb\x05\x0f\x2b\x65\x20\x0b\xf5\x5a\xb2\xba\x05\x73\x62\x2f\xb9\xc8\x97\xf3\x09\x56\xe9\x39\x72\xd5\x47\x34\x6e\xc9\x4b\x0b\x25\x1d\x8f\x95\x6c\x05\xad\x60\x1b\x4c\xdf\x69\x5a\x2e\x23\xa3\xbb\x22\x60\xa7\x8e\xe9\x6c\x1b\x4c\x9a\xd1\x97\x46\xbd\x92\xfc\x7b\xad\xaa\x30\x26\xac\x2c\xc7\x91\xb1\xd2\x65\x5e\xc9\x4e\x94\x3c\x30\xdf\x86\x55\xab\xab\xd5\x98\x79\x83\xb8\xd1\xd6\x9e\x37\x29\xb1\x33\xf3\x0e\x8e\xa0\x63\x8f\xbe\xc4\xad\xe9\x0a\xd1\x1e\xcd\x06\x2b\x07\xd1\xd9\xe1\xf9\x26\x5e\x9d\x3b\xf6\x19\x9a\x26\xaa\x97\x01\x15\x17\x65\x76\x91\xcd\xd0\x71\x21\xd1\xd1\x21\xc8\x17\x7a\x51\xd4\x62\xba\x00\x81\x25\xc7\x16\x4e\x5c\xa1\x54\x01\x4b\x1b\xed\xe5\x2b\x09\x9a\x2f\x70\x28\xec\x6f\xa5\x44\x75\x05\x16\x34\xa8\x55\x59\xcd\xf6\xb3\x07\x19\x31\x22\xb8\x89\x3b\x1f\xca\xe9\xca\xaa\x03\x28\xba\x15\xca\xa8\x67\xb8\x88\x1b\x63\xb1\xc4\x13\x6d\xb6\x6f\xd1\xe2\x8f\xa2\xb7\xdb\x13\xfb\xb8\x12\xf4\x66\xd8\xa4\xb6\x01\xc4\xab\x90\xbc\x20\xb1\xb1\x03\x6e\x19\x87\x8e\xd6\x5b\x59\x70\x08\xd8\x6f\xc0\xaa\xae\x97\x62\x31\xab\x33\xb6\x87\x17\x40\x34\xc1\xda\x13\xd8\xc1\xf5\x00\x6d\x61\xb6\xe7\x46\x69\x25\x19\x12\xa9\x91\x30\x9a\xa9\x18\x2e\x00\x47\xd8\x24\x60\xe3\x13\x6c\xac\x03\x01\x61\x3e\x52\xd3\xd3\x70\xa9\xfd\x2f\x56\x35\xa8\x5e\x1a\xf5\xb9\x9b\x1b\x14\x00\x57\x12\xf4\x5a\xee\x3e\x0d\x4f\x23\x14\x7b\xa4\x75\x2b\x05\xc0\xb9\x82\x45\xcd\x02\x90\xf5\xa5\x6b\x13\xac\x95\x2d\xd6\x82\xd8\x44\xb6\x28\xd8\x2d\xd9\xe2\x40\xf9\x47\x91\x2d\x0e\x4a\xff\xcf\x65\x4b\x60\x6e\x5c\x11\xe3\xa0\xda\x21\x62\x5a\x1c\xd0\x9c\x8f\xd0\x1a\xeb\xe8\xd5\x9b\xd2\x80\x78\xfa\x2d\x92\xa0\x6b\xf1\x7a\x2a\x79\xd8\x63\x12\x1e\xab\x35\xc7\x9c\x69\xd1\x86\x80\x33\x3e\x6d\x83\xad\xae\x15\x30\x15\xd4\xd2\x3a\x4f\x81\x71\x1a\xee\x06\xa5\x4c\x1a\x0d\xba\x8d\x3a\xeb\x83\x43\xd2\xb0\xb4\x89\x3b\x3a\x23\xcf\x00\x28\x84\x81\x49\xd4\x26\xc5\x08\x14\xe2\x4a\x7e\x50\x16\x91\xdb\xe9\x2a\xe0\x63\xb9\x01\x70\xa8\xd4\x06\xbe\x29\xea\x20\xf9\x36\x41\xfc\x39\xb4\xfd\x36\xb4\xd7\x00\xd6\x48\x3b\x80\x1d\x59\xaf\x42\x1b\x20\xe4\x71\x46\xd1\x71\x37\x91\x33\x30\x8c\x4a\xdc\xd3\xa0\xb4\x98\xc3\x4f\xd8\x33\xab\x02\x24\x7f\x4a\x3b\x1d\x60\x9a\x2f\xc6\x20\xf9\x11\x31\xd8\x3b\x19\x1a\x58\x78\x05\xec\x0d\xca\xb9\xc9\xde\x65\x32\x8d\x83\x8e\x08\x52\x37\xa7\x05\x6c\x24\x68\xed\xb1\xe5\xd8\x50\x3a\x8d\x28\x1d\x2d\x4a\xb4\x7f\x8d\x34\x75\xe1\x9c\xec\xaa\x58\x8e\xaa\x64\xb4\xdd\x5c\xce\x2e\xea\x89\x76\x83\x8b\x75\x91\x9b\xa2\x7c\x9e\x8e\x26\x71\x30\x04\xd4\x06\x0e\x0b\x21\x46\x07\xd8\xbb\xb9\xa9\x3c\x20\x7b\xd7\x84\x4b\x9a\xee\x35\x7f\xf0\x01\xfd\xd8\x88\x8c\xdb\x81\xfa\x6c\xbf\x36\xf6\xb5\x96\x7e\xe0\x1b\xbe\x21\x08\xae\xe3\xa0\xf5\x38\xf2\x96\x33\x3b\xfa\xb4\x73\xbd\x65\x5f\xb7\xcd\xee\xb6\x27\xc2\xd3\xed\x5d\x4b\xbf\x6b\xf8\x16\x3c\x7b\x92\xf0\x19\x88\x9a\x39\x5a\x67\xc0\x1c\x5f\xd1\x7b\xbb\x1f\x80\x47\x83\xc3\x18\x28\x9a\x69\xd1\x50\xc2\x6c\xca\xe8\xe6\x37\x8c\x06\xf9\x18\x6c\x19\xfc\x95\xcd\x2e\xec\x10\xd8\xcd\x8b\xbb\x11\xef\xf8\x01\xc3\x55\x7b\xbd\xb0\x92\x32\x58\x4d\x8b\x95\x3b\x0e\xd7\x5a\x25\x32\x35\x0d\xe7\xc5\x7c\x81\xc1\x8d\x57\x34\x76\x0c\xad\xf1\xf8\x2b\x25\x40\xcd\x86\xe3\xf8\x3c\xdc\x9e\x5a\x12\x7a\x6d\xd4\xad\xb3\xc7\x8d\x82\x70\x77\x92\xf4\x73\x7a\x1d\x6b\xae\xc5\x4e\x8a\x31\x4c\xd0\x9f\x9e\x1f\x47\x03\x55\xb8\x28\x73\x2f\x76\x24\xb6\x44\xb4\x93\xce\xb3\x9d\xcb\xbd\x9d\x3c\x1d\xca\x7c\xe7\xec\x0c\x29\x7b\x76\xb6\x73\x49\x11\x7e\xd3\x12\x77\xcf\x63\x40\x12\x00\x7e\xae\x8a\x99\x29\xaf\x16\xa3\x91\xac\x2a\x27\x9c\x8b\x8f\x07\xe4\xfa\x47\x67\xc5\xa2\x72\x9d\xf2\x48\x33\x7c\x8e\xca\x35\x3c\xa2\x20\x6d\xa4\x40\x44\x6e\x45\x4d\x43\x30\xe5\x9f\xa3\xf7\x27\x8e\xe8\x0f\x49\x4d\x0c\x0b\x99\x70\xa7\x60\x17\x6c\x75\xdb\x6a\x33\xcd\x35\x6b\xd7\xad\x16\x71\x53\x43\x56\x42\x06\xc7\x66\xab\x02\x3b\x8b\x98\x74\x4a\x4a\x24\x80\x3f\x8f\x74\x03\x25\xe6\xa0\x6c\x6b\x2b\x80\xad\xbb\x4a\x40\x52\x76\xc9\x2f\x05\xec\x24\x3b\x1d\x08\xfb\xbd\xdf\x77\xb1\xbd\xe5\x01\x56\xeb\xa9\x92\x0a\xf8\x5b\x98\xa2\x4a\xc3\x71\xda\x75\x08\x0c\x0a\xbb\xab\x18\x0e\x52\x31\x90\x52\xf0\xfb\x28\x7e\xa3\xdd\x29\x6b\x18\x1c\xa4\xff\x3b\x13\xd6\x5c\xcf\xd1\x8d\x30\xa8\x75\x37\x70\x21\x85\x37\x74\x0e\x8a\x10\x91\x13\xc6\x50\xec\x19\x19\x27\x8c\x2e\xc0\xdc\x95\x66\x09\xd9\x74\xfa\xa7\xd2\x48\x23\xf8\x75\xda\xad\x6c\x30\x84\x7e\x22\xbd\x5d\x8c\xfc\xc5\x03\x1d\xb2\x74\xf7\x54\x5c\x53\x36\x8b\x29\xc1\x9f\x8e\xf3\x18\x18\x87\x33\x2c\xb0\x7c\xe0\x8d\xae\x2f\x8e\x80\x09\xed\x2c\x51\x30\x4f\x41\xa1\x15\xaa\x77\xde\x23\xb7\x96\xd0\x64\x23\x31\x79\xea\xf4\x4c\x6d\xcc\xb4\x79\xea\xac\x69\xa4\x23\x97\x0d\xe1\xe6\xd6\x60\x7f\x79\xd3\x85\xce\x52\x98\x24\xaa\x49\xbe\x5a\x27\xf2\x30\x57\xe5\xd9\xa2\x4c\xc9\xf6\x75\x98\x82\x26\x13\xc3\x68\x96\x3b\xa8\xe8\xc3\x73\x15\x0d\xfb\x20\x2f\x9e\x5f\xcf\xe3\xe8\x3f\xe2\x93\xdd\xed\x1f\x4f\xb7\xfa\xf1\xc9\xf2\x6a\x3c\x99\x56\xf0\xf5\x0e\xb3\x26\x2d\x75\xcc\xa3\xa0\x4c\x25\x03\x31\xa1\xb2\x58\x81\x33\x4e\xc0\xdb\xaa\xaa\xcd\x22\x39\x20\xda\xe0\x33\xf5\x48\x13\xfb\xf6\xa1\xb8\xdf\xf0\x94\x3d\xdc\xd5\x6e\x3e\xec\x95\xc8\x0c\x7d\xd2\xf0\x5e\xcd\x6a\x0d\xe0\x64\xef\xd4\x60\xb6\x98\x65\xa8\x64\xe9\x27\xf7\x4e\x1d\xf2\x71\xfb\xef\xc5\xaa\x6c\xb0\x13\x04\x70\x7a\xb0\x3e\x95\xc3\x51\xa2\x37\x5e\x76\x44\x9c\x8f\xe8\x72\x19\x1b\xff\xb7\x37\x57\x71\x23\xcc\xe8\xa8\x3c\x21\x01\xba\x22\x89\x2c\x24\x54\x91\xe6\x1e\x0a\x8f\x42\x28\xac\x00\x4a\x02\xd5\xb7\xff\x1a\xb8\xae\x69\x7c\xe0\x2c\xb8\x8e\x5d\x7e\x95\x2d\x66\x77\x9c\xa6\xf6\xb8\x56\x0b\xf0\xac\x9e\xff\xf9\x09\x5b\x3f\x53\x62\x1b\xb4\x7c\xa8\x7b\xc4\xb3\xbb\xbd\xdd\x39\x6b\x47\xff\x7b\x66\x0d\xb6\xb6\xe7\x26\xd2\xb2\x7e\xca\xac\xe9\xa1\xe3\x33\x7f\xfb\x9b\xf0\x0a\x7c\xac\x4b\x1d\xf8\x9b\x52\x68\x52\xcb\x1a\xd7\x9b\xb6\x49\x84\x62\xb3\x2d\xba\xfc\xf8\x6d\x83\xc1\xa2\x31\x57\x66\x0f\x86\x69\xee\x44\xeb\x2a\x5b\x88\x75\xfb\x8e\xb4\x1b\x53\x3e\xf1\x1a\xc4\xaa\x20\x4e\x04\x6a\x65\x5a\xda\x26\x64\x51\x08\x6d\x28\x49\x9f\xcf\xc6\x1b\x93\x05\x76\x2a\x85\xb2\x9a\x3a\x4d\x20\x97\xc8\x6a\x19\xaa\xba\xa4\x2e\x6e\xbc\x7e\xc5\x8e\xb8\x37\x10\xbd\x8a\x57\x5c\x2f\x48\x6f\x05\xd8\x79\xe6\xb3\xfe\x86\x02\xe9\xbf\x7b\xdc\x80\x55\x5d\xc2\xde\xf6\x0f\x35\x78\xa7\xf6\xe6\xa9\x90\x23\x0c\x49\xb2\x16\xdd\x6f\xac\xf6\x96\x3c\xb2\x92\x86\xb4\xfe\x2e\xc7\x89\x61\x8a\x46\x84\xdd\x73\xef\xa1\xf2\x1e\x07\x02\xc6\x09\x45\x14\xe2\xbe\x13\xaf\x4b\xcb\x1a\xd7\x81\x52\xa6\x78\x36\x70\x7e\xb0\x30\xee\xff\x3d\x76\x15\x95\xac\x55\xe4\x0b\xa5\xdb\x19\x65\x68\x7d\x56\x90\x56\xd2\xd1\xd1\xa6\xa8\x05\xf2\xf1\x4d\x5a\x4f\x30\x5b\x36\xa6\x2f\xe7\x79\x01\xf4\xf5\x30\x04\x76\x78\xb0\xdb\x1f\x88\x3d\x83\x80\x8d\x7e\xb7\x24\x13\xd4\x56\x87\x16\x9c\xfd\x82\xb0\xfa\xf3\xa4\xf4\x2c\x79\x5d\x98\xa4\xc3\xa2\x74\xa4\x2f\x69\x71\x65\xae\xfb\x52\x76\xac\xfe\x09\xc3\x4d\xa7\x36\x7f\x33\x22\x28\xd1\x7e\x53\xad\xd6\x1e\x75\xca\x56\x01\xe2\x17\x94\xb2\xd5\x9d\xb8\x4a\x60\x8e\xb9\xa2\xe6\x2d\xd5\x4e\x23\xcd\x3d\x27\x16\x5a\x6d\xab\xdf\xac\xca\x73\xdd\x6d\x40\x20\x36\x41\xeb\x41\x51\x71\xdb\x63\x88\x03\xb7\x2a\xe7\x3d\xa8\x8a\x07\x3e\x10\x89\x51\x25\xcb\x0a\xfc\x14\x08\x87\xe3\x0c\xfb\x22\x38\xa3\x92\x3a\x8b\x94\xf7\x88\x89\xeb\xae\xc1\x80\xa3\xc1\xf5\x8d\xd1\x4a\xfe\x20\xab\x39\x8c\x50\xb6\x2b\x1f\x30\xd9\xbd\x38\x8b\x43\x38\xe4\x18\xb3\x48\x34\xa7\x6c\x86\xf7\x6f\xc6\xf8\x29\x47\x08\xd6\xe3\x6c\x5c\x54\x9a\xc5\xf8\x4b\xc3\x5e\x05\x8e\xc5\xc4\xa7\xb0\x5b\xa8\xb1\x06\x39\x63\x89\x1f\x46\x7d\xcf\x5d\x04\x1f\xeb\x9c\x40\x58\xbe\xaf\x90\xf8\x9f\x76\x0c\x51\x2b\x72\x6b\x6c\xe8\x00\x52\x50\x63\xe3\xfa\xf1\x49\xbc\xce\x63\x72\x3d\x29\x07\xc8\xcc\xf3\x26\xfa\x58\x86\x96\x61\x44\x52\xa2\x81\x34\xc9\xa2\xb2\x74\x31\xc4\x36\x00\x2c\x29\xd5\x74\x53\xfc\xfa\x76\x28\xdb\x5c\xff\x03\x00\x30\xa1\xcd\x36\x3c\x78\x17\x72\x23\x76\xd8\x6c\xcc\x24\x46\x4b\xd8\x6b\xb4\xd6\xf7\xc6\x27\x66\xd0\x17\x44\x7c\x03\x4c\x00\xac\x0f\x60\xfb\x6d\x2a\x1b\xea\x8d\x8a\xe9\x3c\x97\xb5\xdc\x98\x80\x87\x1d\x04\x5c\xed\xd0\x1b\x5b\x0f\x42\x68\x3b\x03\xc1\x65\x16\xf3\x81\xd7\x10\x76\xf9\x34\xc7\xe2\x8f\x9c\x97\x40\x47\x87\x56\xcd\x10\x87\x11\x57\x4c\x53\x67\x23\x2c\x59\xe4\x35\xae\x1f\x12\xb6\x11\x26\x3a\xa4\x65\xd4\x9c\xe5\x36\x4a\x7b\x6b\x27\xb7\xdd\x66\x15\x0a\xda\xe2\x0e\xce\xfe\x4d\xc3\x9b\x68\x74\x88\x49\x3d\xcd\xe3\xe8\x75\x91\x8e\x69\x3f\xe1\xe9\x37\x84\x07\x21\x08\x92\xe8\xd1\xb0\x14\x3b\x47\xe2\x83\x91\xf5\x5c\xcb\x51\x03\xa0\x9e\xae\x86\x4f\xa2\x63\xc4\x9c\x00\xaa\xd4\x10\x6e\xd1\x18\x90\xc3\x62\x78\x80\x84\x0f\xbf\x54\xe2\x3c\xcd\x72\xe4\xc7\x6c\x26\x86\xb2\xaa\xb7\xe5\x39\x98\xb2\x35\x45\x26\x04\xa8\x60\x97\x9c\x85\xce\x83\xc6\x38\x9a\x62\xc6\xc4\x00\x6b\xcf\xd4\x55\x5a\xce\x70\xb7\x45\x7d\xe3\xe4\x34\x10\xaa\xba\x0a\x48\x23\x4b\x24\x75\xe4\xe1\x4e\x1c\xa9\x31\x82\x36\x82\x3f\xaa\x79\x3a\x13\x23\x8c\xe6\x1e\x7e\xa2\xfc\xff\x6d\xd5\xd1\xa7\xe8\xe8\xd1\x0e\x3e\x85\xaa\x09\x3e\x88\xa3\x5f\xf9\x09\x13\xe2\xca\xf3\x0b\x37\x7d\xbd\xcd\xb8\xae\x9d\xc2\x0d\xbc\xb1\x66\x81\xbb\x5b\xd4\xb4\xba\x58\x63\x4f\x61\x0b\xc6\x14\xeb\x36\xca\xb5\x06\xba\xa6\x6b\xab\x20\xff\xd6\xbe\x7b\xbd\x66\xd7\x9a\x06\x6b\xba\xf6\x72\xe2\x36\x50\xe9\x5d\x7d\x49\xe9\x51\xaf\x9e\x69\x1e\xb8\x02\xd5\xb5\xb8\xe2\xe1\x28\x9d\xac\x59\xd3\x68\xf6\x59\x23\x9d\x3b\xa4\x47\x37\x12\xfb\xac\x32\x4d\x16\x84\x86\xe0\x7b\x28\x4d\x62\xb4\xee\x12\x3a\x50\x78\x55\x2c\x00\x11\xab\x70\x34\x2c\xe0\x03\x09\x26\x0e\xd2\xc9\x3d\x3b\x82\xef\xd5\xb9\xdf\xf5\xd4\xe6\x10\xe0\x6b\x0c\x2d\x79\x9a\x10\x05\x9b\x2a\x4b\x72\xfa\xfd\x91\xce\xce\x55\xea\x8c\xac\xe3\x97\xa2\xa7\xb8\xca\xdd\x66\x4c\x14\x7e\x84\xdb\xae\x8e\x5c\x39\x02\xd5\x85\x9a\xcc\x17\x30\x14\x58\x89\x75\x59\xcc\x2e\x48\xf8\x70\x5b\x10\x42\xb0\x02\xb9\x54\xed\x69\x20\x99\xe7\x12\x0f\x2b\x29\x3c\x4f\xe8\x8f\x09\xd1\xdc\xf8\xde\x97\x5c\x8f\x2e\x7a\x34\xce\x2e\xcd\x2a\xe7\x62\x58\xdf\xa6\x2b\x8d\xc9\xe7\x22\x9b\x91\x80\x80\x35\xcf\xdd\x43\xbb\xa3\x68\x2d\x31\x39\xf0\x72\x5c\x1c\x57\x6f\x39\x9e\xd0\x49\xce\x5a\xd7\x50\x4f\x12\x4d\x1c\x14\x6b\x98\xb3\x05\xbd\x7e\x8d\x0e\x56\x11\x7f\x2d\xf5\xd7\x93\x3f\x40\x7f\x43\x72\x20\x90\xa1\x8b\xa6\x2f\x96\x7f\x32\x71\x71\xde\x89\xf0\x43\x8d\x66\xeb\x30\x44\xc6\x01\xd3\xf0\x26\x72\x1c\x4a\xdc\x60\xb3\xe0\xc3\x2f\xca\x55\x6f\x68\x49\xbe\x77\x4b\x4a\x5e\xb1\x54\xf5\x45\x5e\xa4\xb5\x7a\xae\x17\x65\x06\x5d\xbd\xc5\xb2\xbe\x73\x3e\x2b\xda\x7a\x35\x3b\xc7\x9c\xfa\x6d\xf5\x97\x7e\xc3\xaa\xcc\x73\x3c\xad\x49\xc0\xc6\xb8\x9c\x0a\x01\xad\x31\x1f\xcf\x81\xdf\x4f\x30\x55\x44\x83\x1a\xa5\xb3\x5e\x8d\x8d\x28\x08\xae\x52\x43\x50\xca\xe2\xd6\x36\xc5\xbc\xbe\x8b\x74\x5e\x89\x18\x4f\x9a\xf7\x13\xd7\x57\xa8\xcf\x9e\xdf\x78\x61\x85\xb5\x44\xf1\x52\xa3\x9a\xc6\xcb\x4a\x9f\xcf\x3c\x85\xcd\xb5\xd6\x2e\x85\x0f\xea\x28\x7c\xf2\xb4\xc8\x41\x3a\xbf\xe7\x87\xd6\x1f\x42\xea\xb7\xa3\x12\x21\x0f\x4d\x53\x98\xda\xeb\xc8\x17\x51\x56\x0d\xfd\xa0\x36\xf2\x4a\xcc\x8a\x1a\x33\x60\xb8\xbe\x40\xbc\x6f\x8b\xf7\x39\x3a\xa9\xc0\x04\xc5\xb3\x71\x29\x68\x9e\x65\x89\x47\x70\xec\xf1\xee\x24\xf2\xa3\xfe\xcc\xe7\x37\xd6\x81\x99\xea\x80\xb0\x52\x93\x30\x0d\xcd\x0c\xbf\xae\x9a\x01\x3d\x7b\x26\x8d\xb9\xd8\x46\xf4\x40\x11\x98\xaa\xa3\x44\x87\x7c\x09\x80\x5d\x14\x2a\x14\xa8\xb5\xbf\x03\x57\x54\xe9\x30\x67\x40\xcf\xd3\x11\x44\x2b\x9a\x88\x3a\xbe\x48\xb0\x1d\x5b\xad\xc1\x00\x36\xcf\x02\xe9\x98\x6e\x2f\xfb\xf4\x39\xf0\x9a\xef\xab\xbf\xbe\xc1\x07\x10\x39\x4b\xc0\xa7\x94\xb3\x80\xbc\xc4\x50\x57\x6d\xba\xde\xe7\x18\xd7\xc9\xee\xe9\xc0\x29\x5e\xee\x3b\x7b\x23\xad\x4c\x86\x86\x71\x33\xab\x05\x19\x3d\xa7\x6f\xcd\x8c\x1c\x8d\x34\xc5\x81\x09\xfd\x8c\xfb\xf6\xf8\x1e\x2b\x4e\xa4\x02\xb7\x14\xba\xca\x59\xb8\x9c\x79\x41\x33\x56\x91\x00\xc4\x23\xa9\xd3\xac\xc2\xfc\x14\x81\x8e\x8c\xca\x1e\x60\x04\x26\x37\xda\xb6\x3e\xe2\x4e\xcb\xa0\x70\xcc\x08\x23\x44\x6b\x67\xdb\x37\xae\x95\x03\x28\x7e\xe4\x97\xc3\x7e\x89\xa5\x5b\xcd\xda\x72\xee\x65\x5b\x3f\xce\x73\x10\x01\x08\xfd\x1c\x85\x06\xa2\x37\x07\x71\x88\x5a\x6e\x3a\x1a\x81\x52\x31\x5a\x26\x6e\x2c\x84\xd5\x7f\x13\x2b\x46\x1c\x31\x9d\x9b\x8a\x4f\xe0\xd7\x69\x72\x2d\x1e\x61\xbf\xad\x6e\xd9\xf9\xe1\x4e\xa7\x19\x38\x8b\x74\x07\x88\xa3\x9e\xc2\x4f\x3c\xf1\xdd\x61\xb3\x34\x40\x7c\x05\x76\xa8\x07\x38\xfb\x48\xf9\x9b\x7e\x3b\x40\x2d\x84\xb9\x3f\xc3\xb4\xb5\x13\x6b\xe3\x08\x29\xcb\x37\xa0\x90\x93\x81\xac\x9e\x57\x98\x6c\x47\x39\x73\x26\x51\x1d\xa4\xb0\x4a\x48\xc7\x6c\x69\xba\xe2\x41\x65\x4f\xf3\xa5\x0f\x26\xab\xba\x1a\xa8\x8c\x6b\xac\xc7\xe9\x52\xd0\x16\x04\x37\xb4\xc9\x4a\x71\xc6\xcd\x44\xb5\x38\x3f\x07\x81\x84\xc9\x78\x13\x34\x3f\x40\xe9\xcf\x25\xaf\x1d\x04\x87\x27\xa0\x44\x7a\x5e\x33\x0a\x99\xca\x0b\x00\x79\x36\x2e\x0b\x30\x1c\x08\xa0\xe6\xcb\x4a\xe4\xd9\x17\x30\x5e\xd8\x94\x5d\x19\x97\x70\x46\xfa\x6d\xb1\x27\xa2\x28\x68\x04\xe6\x87\xc9\xe3\x43\x67\x20\x85\xa4\x76\xd4\xd0\x3e\x0d\x77\x40\xf1\xae\xea\x96\x13\xba\x11\xa1\xb2\xa9\x54\x8d\xf0\x14\x81\xc7\x4c\xc7\xa5\xbf\xf4\x4c\xa5\x44\xa9\x2a\xb9\xf4\xcd\xeb\x83\x4d\x6c\x19\x27\x4d\x77\x63\x1a\x84\x47\xdd\x52\xae\xe9\xd8\x1d\x5d\x08\x93\xce\x60\xbe\x61\x69\x49\x64\x0f\x9c\x5b\x79\x9d\xf1\x29\x75\x62\xa6\xc4\x3f\x06\x66\x9d\xee\xcd\xe4\x64\x3a\x43\x36\x9a\x64\xf9\x18\xd4\x63\xbc\x8b\xa0\x95\xc2\x61\xeb\x36\x12\xd6\xec\xa9\x34\xef\xc1\xcd\xad\x55\xc9\xd1\xab\xb2\xdd\x1d\x75\xdf\x3c\x3b\x68\x05\x26\x1a\x87\xe7\xee\xc4\x3d\x47\xd5\x8d\xf8\xd4\xdc\x11\xab\xb1\xbd\xf6\xe9\xb9\x46\x75\x75\x6c\xae\x5d\xdf\x12\xa7\x75\x73\xc0\xba\x4a\xd4\x95\x8d\x6f\x40\xb9\x8a\x6e\x74\xba\xfd\x71\x5e\x9f\x16\xb3\x4b\x94\xf7\xa0\x87\xfd\xfc\xf6\xd5\x9f\xc9\x0d\x01\x82\x79\x3a\xd7\x37\x07\x38\x7e\xa5\xcd\x83\x52\xb0\x6c\xee\x3f\x54\x3d\xec\x4d\xdc\xa3\x16\xcd\xd0\x8b\x46\x73\xdb\x74\x64\x86\xb9\x7e\xaf\x7a\x9f\x8e\x29\x31\x4b\x09\x30\xbc\x01\x00\xa4\xff\x65\x56\x65\x98\xa4\x15\xa1\x24\x8d\x78\x93\x05\xe9\xc6\x37\x03\x8c\x8a\xd9\x79\x76\xb1\xc0\x03\x21\xd7\xdb\x38\x09\x62\x58\xc0\x3a\x4b\x09\x80\x9c\x55\xf0\xa4\xd2\xe0\x29\xfd\x58\x27\x17\x83\xd0\x1b\x67\xd5\x3c\x4f\x97\xea\xae\x01\x50\xb0\x40\xd0\x59\x38\x44\x05\xef\xf8\xe9\x0c\xa6\x07\xd1\xab\x0b\xea\xda\xa4\x8f\x19\xf8\x38\x70\xdd\x8c\xaa\xd8\xb3\x3b\x76\xcb\xc2\x34\xba\x6b\xcc\x23\xd0\x54\x73\xd2\x03\x98\x46\xfa\x36\x17\xdc\x43\x4c\xad\xd6\x5e\x72\xd3\x84\xeb\xef\x88\xdb\x62\x8f\x77\x40\x35\x23\xad\x5e\xcc\x36\xa5\x2a\x04\x3b\xb0\xb9\xde\x6f\x61\x73\xc6\xa0\x69\xcd\x9b\x0a\xea\xc3\xbe\x88\x68\x5d\x14\xe5\x6a\xcc\x7c\xda\x80\x31\x50\x89\x5b\xfb\x0e\xf3\x1b\x9d\x89\x6f\x30\xd8\xb7\x71\x31\x47\x6c\x90\x5f\x88\x2f\x34\xc0\x64\x5d\xdc\xc2\x07\xca\x65\x31\xae\x27\x2b\xda\xfc\x8a\xcf\xc9\x65\xfa\xc3\xee\x40\xdc\x33\xed\xd4\x51\x22\xd0\xb2\x42\xa7\xa1\x38\x8d\x2e\x12\x60\x40\xe7\x20\xb4\x75\x08\x81\x3c\x06\xf3\x22\x4f\x95\x2f\x10\x9f\x81\xd2\xab\xce\x69\x2a\x7f\x9f\xe1\x77\x2e\x9e\x66\x58\x13\x6f\x6a\x88\x06\x1e\x51\x5f\xe0\x0d\x1f\xb8\xb5\xe2\xbd\x11\x84\x71\xaf\xc2\x4b\x93\x76\xa0\xc5\xad\x8e\x33\x6b\x28\xd2\xf1\x10\xa8\xb3\x6e\x7e\x9d\xc0\x26\xac\xaf\x28\xc8\x2a\x75\xb0\x6a\x6c\xf4\x37\x80\x68\xf5\xb7\x15\x6b\xb1\xb6\xde\x49\xef\x36\x26\xcc\x5e\xe7\xf2\x37\x2e\x24\x3e\x82\xaa\xb4\x9e\x30\x44\x2c\x7d\x8f\x5a\x5c\xd3\x33\x6e\x1e\x24\x4b\x58\x0b\x7e\x07\xa0\xc6\xb9\x8f\x6f\x37\xed\x0d\x9d\x50\xef\xa2\xe4\x34\x08\xf8\xee\x8d\xfa\x85\x94\x00\x45\xd4\x6b\xed\x26\xdc\x87\x78\x39\x61\xf2\xc1\xe7\xf7\x7b\xc9\xee\x83\xee\x6a\xd9\x4c\xd3\xc6\xd3\x0e\x69\x06\xe8\x19\x98\xcc\x78\x0d\xd1\xf2\xa0\x31\x33\xdb\xfe\x83\x6f\x9c\xa1\xbf\xcf\x24\x3c\x22\x1c\x37\x21\x3d\x8f\x65\x25\xc1\x43\x73\x3c\xdd\x70\x66\xa7\x9b\xcf\xe7\x8d\x73\x50\x8a\xb0\x3a\xa4\x69\x6a\xe6\x5b\x85\x27\x13\x0c\x83\xbd\x83\x15\xf5\x68\x94\xf8\xb9\xad\xeb\x85\x0e\xb0\x75\x03\x8f\x77\x93\xbd\xef\x39\xae\x9f\x0e\xab\x18\x0b\xb7\x11\x5e\xbf\xdf\xdf\xb0\xdb\xb5\x10\x6e\xb4\x23\x16\x59\xe9\x5a\xa9\x26\x6d\xb9\x9b\x90\x72\x45\x71\xa3\xaf\x2c\x65\xf6\x43\x22\xdb\x39\xaa\xb0\x5c\x03\xeb\x2f\x4a\x94\x77\x02\x63\xb9\x57\x94\x78\xdd\x8d\x91\x94\xf2\x5c\xa7\x28\xd7\x50\xf7\x85\x3a\x59\x4f\x17\xe3\xf0\x31\xfb\x7f\x7b\xf3\xe4\x78\x10\xd8\x23\x08\x1d\xb5\x47\xb8\xe7\x19\x7c\xd2\xd9\xb3\x19\x6a\x14\x13\x50\x26\xcb\x67\xb2\x86\x6d\x3a\x3c\x96\x97\xb6\xc2\x66\x03\x62\x34\x6b\xe9\x46\x4f\x59\xe6\x0f\xc4\x35\x6c\xa0\xbe\xd8\x54\x09\x64\x3d\x37\x54\x12\x61\x61\x74\xd4\x03\x06\x31\x61\xbd\x6b\xf1\x3d\x29\x70\xfd\xa4\x2e\x7e\x3e\x7e\xca\xce\xc0\x18\x7d\x80\x3d\x15\x48\xe9\x1d\x38\x60\xab\x2b\xba\x48\xad\x05\x98\xc6\x71\xc6\x4f\x23\x3e\xbc\x7b\x18\xe1\x5d\x1a\x17\x25\xaa\x44\xdb\xca\xa3\xd0\x23\x8b\x98\xc4\x05\x95\x60\x37\x26\x62\xe3\x75\x84\x17\x7d\xa8\x63\x53\xdc\xe5\x96\x50\xa3\x4d\x42\x3e\x58\x52\xcc\xd8\x11\xbb\x2f\x5c\xa7\xf4\x52\x8d\x84\x4b\x7a\x8d\xdb\xf8\x88\x4a\x58\x61\x58\x12\x59\x74\xaf\x4e\x91\x8a\x24\x58\xbf\xbb\x8f\x46\x5b\x5f\x21\x0f\x96\xbe\xb9\x22\x30\xf1\xaf\xe9\x59\x50\x1f\xe1\x66\x46\x21\x59\xc9\x10\x4e\x6f\x13\x50\x4b\x72\x54\x4d\x64\x19\xee\xf2\x89\x04\xf3\x39\x2b\xca\x44\x89\xea\x97\xba\x41\x2c\x36\x62\x3d\xc6\x6b\x5f\xfd\xf5\x3b\xaf\x26\x32\xbf\x44\xcd\x74\xa3\x9e\x8f\x49\x3b\x88\x7f\x57\xaf\xc1\x03\x5b\xca\x4d\xe1\x99\x5e\x4a\xc9\xaa\x1a\x9e\x08\xcf\x5f\x61\x5d\x12\xde\xa1\xee\x01\x02\x23\xcd\xbc\xd1\xae\x42\x17\x03\x18\x04\xd5\x62\x3a\x85\xe6\x8b\xb9\x48\x47\x65\x51\x55\x0e\xa0\x44\x3c\x5d\x4c\xf1\xe4\x51\x76\xa9\xbb\x45\x68\x23\x58\x08\xea\x1c\x39\xf2\x9e\xf6\x58\xb3\x21\x41\x8f\x54\x17\xa0\x97\x65\x97\xd9\x78\x91\xe6\xba\xf3\x64\xbd\xad\xde\x79\xde\xbc\x2b\xf8\xd7\x75\xb7\xea\x5a\xcb\x54\x93\xe8\x1f\xc6\x42\xb5\x0e\x18\xef\x98\x7c\xc0\xf8\x37\xe1\x64\x0f\xbd\x34\x47\xa3\x95\x3e\x75\x18\x19\x84\xa4\x0a\x1f\x2b\x79\x11\x29\xfa\x56\x18\x41\x10\xc5\x2c\xa7\x7b\x22\xc7\x65\x4a\xc7\x3f\xcb\xf6\x7d\x01\x03\x91\x25\x32\xf1\xac\x49\xc7\x7b\xe5\xf9\xbc\x52\xf1\xbd\x71\x78\x51\x31\x2c\x97\xa8\x1f\x70\x1b\xa8\xd9\x54\x3d\x3c\x59\xbe\x96\xc6\xc1\xbd\x89\x91\xcb\x82\x09\x25\xaa\xf5\x0d\x59\x1d\xe6\xb6\x03\xf6\x24\x97\xa7\x76\x3b\x69\x3c\xc0\x3e\xf1\x9e\x1a\xbc\x1b\x73\x81\x67\x08\xf7\xdd\x70\x4b\x2e\xc1\x3c\x62\x7f\xb6\x52\x09\x3d\x67\xf6\xdc\x71\x51\xa1\xfd\x37\x4f\xae\xc9\xf6\x9b\x27\xcb\x1b\xf4\x4c\xdd\x1c\x74\x9e\x12\x6d\xa0\xd1\x31\xda\x39\x10\xde\xdf\x09\x97\x34\x62\xb2\x52\xb3\x53\xab\xd1\xe1\x98\x97\xe4\x1a\x6b\xaa\x81\x80\x0b\x86\xfa\xf0\x0f\x6c\x2d\xbb\xb8\xa9\x2c\x1b\x2e\x54\xe7\x80\xac\x33\x23\xd0\xea\xdd\xf0\x33\x9e\x48\xfd\x22\x97\x55\xec\x20\xdc\xf7\xa9\x90\xbb\x77\xab\x36\xc6\xa5\x0f\x4f\x83\xc4\xb0\x64\x55\x77\x30\x56\x2a\xf4\x85\x76\xaf\x16\x0d\x15\xe6\x50\x1a\xc8\xe9\x40\x0c\x1d\xd8\x60\xcf\xd3\x2d\x8d\xdb\x62\xc8\xdf\x0e\x5c\xd1\xed\x98\x66\xb4\xe5\xca\x3c\xb7\xde\x77\x0d\xbf\x45\xe1\xa1\x43\xe1\x61\xf7\x2c\x7c\xf6\x4f\x59\x11\x51\x03\xc4\xf6\x13\x91\x6e\x9c\x79\x03\x0e\x05\xb3\xff\x10\x4f\x46\x88\x5d\xb0\x89\x15\x42\x27\x19\xbb\x17\x68\x42\x3f\xc3\x84\x82\x91\xbc\xeb\x6b\x0e\x0b\xd2\x1b\x8c\x49\x8e\x5d\x83\x42\xcb\xf0\x68\x46\x07\xf0\xdf\xb5\xca\x9c\xca\xf0\x7f\xc0\x20\x4c\x0d\xa2\x8b\x72\x59\x30\x12\xfb\x22\x1b\x08\xcd\xbd\x54\x79\x9f\xff\xdc\xb4\x4e\x50\x1b\x52\x97\x17\xa4\x5d\x7f\xad\x8b\xf9\xbe\x78\x30\x10\x25\x3b\x1b\xee\xed\xc2\x8c\xd1\xa5\x4d\xf0\x1d\x8a\x51\x51\xc5\x52\x93\xc4\x4a\x0e\x06\x17\xc3\xf5\x1e\x06\xdd\x94\x1d\x1a\xab\xda\xfa\x1e\x0d\xdd\xce\x82\xa5\xdc\x87\x31\x79\x74\x78\x04\x09\xe2\x67\x7f\x95\xfa\x32\x49\xd3\xec\xa5\xee\x73\xa2\x2f\x9f\x54\x55\x61\xdc\xf6\x07\x8f\x38\x98\x3f\xdd\x8e\xea\x84\x9d\x90\x4e\x80\x47\x1b\x21\x68\xba\xdd\xa7\xc4\x8d\x84\x6e\xbd\x49\x16\xf5\x48\x85\xad\x92\x71\x31\x4d\xb3\x59\x7c\x62\x14\x5f\xdb\xa7\x52\x80\x07\x56\x29\xd6\x5d\xa9\x27\x2a\x58\xc6\xbe\xc9\xf8\x64\x77\xe0\x50\xc8\x1e\x40\x5b\x72\xf7\xdc\x73\x51\x8e\xf1\x16\x9d\x46\xef\x7a\x4d\x79\xc2\x40\xad\x27\xbd\x64\x33\x5c\xa2\x6e\x87\x4f\x60\xe9\x57\xf1\x89\x43\x5d\x60\x5f\xdb\x2d\xeb\xd1\x4e\xd7\xec\x14\x6a\x8e\x1b\x90\x46\x4e\x07\xf6\xdf\x6b\x0c\x27\xfa\xee\xfc\x9f\xcf\x87\xe7\x14\x58\xff\x6e\xf7\x87\xfb\xbb\x0f\x87\x91\x03\x1e\x98\xff\xd7\x26\x0b\x3a\x0c\xf2\x7d\x28\xc2\x65\x2a\x5a\x8f\xac\xe3\x4b\xdc\x53\x29\xe5\x7a\xf2\x2f\x2f\x14\xfa\xb4\xf9\xc5\x9e\x67\xae\xaf\xf7\xec\x08\xaa\x45\x0a\x71\xce\xae\x25\xc6\x04\xa4\xe9\xaf\xf7\x84\x59\x0f\x1e\xf1\x17\xfd\x4c\x01\x6a\x80\x31\x01\x7a\x1c\x3f\xfd\xc0\x33\xe3\x31\xda\x0e\x2e\xcb\x83\x61\x31\x70\xca\x90\x9b\xa1\xa8\xaf\xaf\x86\xbb\xbc\x50\xe8\x3f\xce\x73\x3c\xf5\x3f\xaa\x75\x2f\x74\x50\x86\x44\x88\x2a\xa0\x88\x7a\x6c\x07\xe6\x56\x66\x94\xae\xdd\xbb\x24\x46\x0e\x6f\x5c\xc7\x86\x45\x47\x89\xb1\xdc\x70\xe9\xdb\x69\xda\x11\xf7\x90\x83\x5c\x78\xcb\x2e\x78\x4b\x00\xc3\x3c\xd9\x6f\xb6\xd1\xd4\x35\x80\xc3\x14\x5e\x5a\x1e\x8d\x35\xd7\x92\xf5\x17\x47\xe7\x59\x9e\x77\x75\x3c\x4a\x58\x4a\xb3\x64\xe7\xf8\xb2\x2a\xeb\xa3\xd3\x93\xe6\x81\x2f\x8e\x8c\x1c\xcc\x14\xc5\xea\xac\xc6\x6b\x36\xb9\x90\xb4\x34\xaf\x13\xdf\xc4\x0b\x51\xac\x65\xeb\x46\x9f\x66\x68\xb0\xc2\x37\xbd\xcb\x68\xc2\x9c\x62\x00\x8d\xac\x4a\x7c\xaa\x70\xb4\x42\x5e\xcd\x7d\x07\x6f\x91\x82\x89\x7c\x75\x2d\xf8\x6a\xc3\x0d\x18\x6f\x97\xd8\xcc\x95\xa5\xcc\x67\xdc\x14\x56\x78\x1e\xe3\x5a\xc1\x4e\x01\x24\xf0\x11\xad\xfa\xf8\xba\x9f\xb0\xc7\x23\x8e\x58\xb8\x62\xfe\x23\xaa\xf8\xf1\x0f\x7d\xef\xce\x14\xf7\x3e\x2b\x52\x03\x75\x08\x82\x43\xa8\xac\x2f\x50\x1c\x03\x6f\x19\xa6\x58\x03\x2a\xf5\xb4\x08\xd8\x40\x61\x58\xc6\x01\x6f\x7c\x36\x1f\x43\x0b\x59\xbb\x4f\x36\x58\xc8\x0f\x77\xff\xee\xab\xf8\xc1\x8f\x5d\x8b\x56\xed\x29\x18\x40\x7d\x6b\x85\xdb\x48\x66\xb9\x91\xd2\x2a\x98\xb1\x13\x3c\x28\xe3\x4e\xd0\x8e\xd8\x7b\x40\x32\x8d\xe0\x6a\x5a\xac\x65\x8a\xa5\xc7\x14\x9d\x33\xbb\xb4\x33\x4b\x5e\x2c\x9d\xb9\x41\xd3\xcb\x67\x35\xe3\xa5\x96\xf3\xed\x6b\xfc\xbd\xbd\x45\xfc\x93\x33\xe4\x43\x3a\x70\xaa\x37\x1b\x05\x90\x3d\x63\xe1\xe6\x46\x03\xc3\x45\x41\x2d\x57\xdb\xe3\x2b\x13\x19\xf1\x5e\xe7\xdf\x10\x60\x0e\xc4\x5c\xd7\x5e\x55\xd5\x71\xe9\x54\x33\x26\x6b\xe0\xf8\xa6\x71\xd3\x44\x08\x78\xff\x4c\x20\xd0\x9c\x0b\xf9\xad\x61\xa1\x15\x91\x00\xf7\xfa\x97\xb5\xe7\x67\x03\x67\x76\xd6\x9c\x9c\x09\xd3\x1d\xa3\xf5\x0a\x0b\x75\xb7\x60\x05\x66\x09\xdd\x91\xee\x5e\x3d\x88\x26\xb0\x8e\x01\x71\x90\x93\x12\xeb\x9c\xfb\x06\xab\xf4\x52\x09\x0e\xef\x96\xc1\xc7\xff\xfa\xf8\xcf\x42\x67\x8c\x63\xe4\x12\x14\x27\xba\x9d\x0f\x0a\xb7\xcd\xa2\xe6\x7b\x9b\x10\x8a\xed\x93\x81\xe1\x7d\x4f\xf6\x52\xbf\x71\x56\x61\x4c\x94\x1d\x3e\x84\x8f\x7b\x5f\xaf\x61\x0f\x95\x97\xe6\xd9\xcd\xe1\xab\xcc\x28\x49\x6f\x6d\x82\x43\x30\xbb\xee\x6d\x41\x68\x52\x1a\x11\x26\x3f\x81\x40\x6d\x64\xcc\xb5\x73\x01\xf0\x9a\x32\xef\xde\x38\xf7\xa6\xb2\x10\x2b\x6f\xc4\x05\x8d\x73\x50\x8d\xf3\xbe\xe9\x46\x7c\xd0\xbc\x72\x6d\x35\x96\x2e\xa5\xd9\xaf\xa4\x13\x69\x9f\x14\xe3\xa5\x26\xb5\x03\xce\xbf\x41\xfb\x8c\x2e\xeb\x11\xf5\x10\x2a\x33\x54\x6a\xe7\x1d\xbb\xac\x60\xcf\x02\x8b\xb3\x71\x12\x84\xf1\xc7\x6b\x1c\x45\x74\x49\x9e\x94\x68\xff\x96\x1b\x12\xf2\x8f\x6c\xf8\x33\xa8\xbb\xd1\x72\xfb\x51\x5d\x1e\x3d\xaa\xf1\x2d\x13\x39\xfa\xa7\x0f\x7b\xf7\x7a\x47\x8f\xb2\xa3\x19\x4f\xec\xa3\x9d\xec\xe8\xd1\x4e\x3d\xc6\x0f\xcc\x3c\xee\x76\x5c\x84\x2e\x70\x08\x1c\x1f\xf1\x6f\xc1\xa1\x39\x50\xb1\x28\x55\x11\xe4\xae\x6b\xe7\x9a\xa4\xe4\x50\xe6\xa2\x49\x5c\x3c\x58\x35\xb4\xa3\x46\x7a\x36\x83\x54\x49\xd4\x38\x34\x55\x45\x25\x26\x9e\xec\x9d\xda\x47\xee\xa8\x95\x83\xa6\x94\xe9\x97\x03\x43\x7f\x95\x7d\xfa\xff\x31\xfd\x2f\x7f\x3b\xfd\x2f\x9b\xf4\x37\xd7\x98\xe0\x41\x31\x4c\x58\x35\xa9\xaa\x06\xbd\xcf\x8c\xde\x67\x40\xef\x52\x67\x82\x6a\xdc\x3e\xfb\x37\x24\x59\x48\x5b\x87\xa6\xf2\xc9\xe7\x53\x35\x43\xe2\x5f\x70\xd6\xdc\xf2\x5d\x9e\xb9\x61\xb9\x73\x14\x35\x9d\x2d\xbf\x8b\x35\x1c\x4c\x36\xe6\x0c\x95\xab\xcb\x9c\x11\xee\x9d\xab\x78\x3d\xb9\x33\xd1\xc5\x88\xcd\x8e\x48\xc3\x5f\xdd\x11\x55\xf1\x3a\x72\x46\xed\xf7\xd9\x5f\xd3\xa9\x4a\x4d\xda\x0f\xee\x07\x3f\xcf\x2a\xd0\xad\x8b\x12\x9d\xbe\x7c\x1f\x0d\xe5\x59\xb7\x80\xdc\xac\x57\x9d\xc2\xaf\x53\x0b\xdd\x61\xd6\x7c\x59\x8c\x97\xe5\xe6\xe8\x6d\x1f\xc2\xc5\x1b\xab\x73\x36\x84\xea\xe2\xb5\xb4\x88\xa5\xc3\xea\x6c\xa9\xb5\x6c\x0c\x28\x2f\xcd\xb6\xca\x8f\x8e\x0e\xc5\x9e\xbc\xf7\x87\x46\xfa\x63\xbc\x44\xe5\x1a\xcb\x41\x79\x77\xed\xb5\xbf\x44\x4e\xaa\x43\x13\xca\x5e\x07\x94\xbd\x26\x94\x7f\x5f\x01\x65\xef\x87\x30\x14\x28\x6f\x40\x79\xbe\x0a\xca\x83\x0e\x28\x0f\x9a\x50\xde\xaf\x82\x72\xaf\x03\xca\xbd\x26\x94\xe3\x15\x50\x7e\x0c\x03\xf9\xb1\x09\xe3\x4f\x2b\x60\x3c\x0c\xc3\x78\xd8\x84\xf1\x66\x05\x8c\xfb\x61\x18\xf7\x9b\x30\xbe\x74\xc3\x68\x40\x58\x86\xea\x79\x7b\xcb\xaa\x8a\x8f\x10\xa9\xed\x2e\xde\xdb\x6e\x33\xdf\x32\x8c\x98\x82\xb3\xd7\x05\xa7\xc5\x7e\xff\xb9\x0a\x4e\x17\xff\x6d\xb7\x19\x30\x5d\x09\xe7\x41\x17\x9c\x16\x0b\x9e\xaf\x84\x73\xaf\x0b\x4e\x8b\x09\xe7\xab\xe0\xfc\xd8\xf2\xd0\x68\x40\x2d\x46\x9c\xad\x82\xd3\xc1\x89\xdb\x2d\x56\xfc\xbf\xff\xa7\x0b\x0c\xd4\xee\xe0\xc5\xed\x16\x33\x4e\xbb\x71\x09\xf1\xd8\xad\x9b\x5b\xb7\xb4\x1c\xf4\x4e\x99\x10\x48\x2b\x17\xc1\xac\xcf\xea\xe5\x1b\x0a\xdc\xaa\x8b\x2d\xee\xa2\xaf\xe9\x6e\x3a\x9d\x1f\xe8\x4b\xf4\x1e\x51\x49\x5e\x9b\x82\x23\x2a\xb8\x30\x05\xbd\xa8\xb7\x2f\x7a\x77\xff\xba\x28\xea\x03\xf5\xee\x87\xa8\x17\x61\xd1\x77\xf7\x7f\x34\x25\x3b\x5c\x72\x7d\xef\xc5\x41\xcf\xdc\x0a\xae\x90\x56\x43\x55\xe8\x25\xea\xda\xd2\x78\xe7\xe4\xee\xa3\xa3\xa8\xf7\x69\xe7\x74\xe7\xc2\xba\xf2\x84\x8d\x29\xea\xf7\x91\xe8\x61\x9c\x54\xa7\x3a\xf2\x71\xe3\xed\x18\xef\xd3\xd0\xed\x5b\xf6\xe5\xa3\x3a\xf0\xd4\xd8\x68\xb0\x59\xe3\x45\x7a\xe1\x9d\x8f\x80\xd8\xdb\x10\x09\x30\xc5\xcf\x7e\xfe\xf0\xda\xa6\x6d\xbb\xb5\x82\x3a\xa8\x57\x81\x43\x3e\x37\xf6\xcc\xa9\xf7\x54\x07\xbd\xa8\xab\x74\x3c\xe6\xcc\x05\xa1\x5e\x63\x4a\xbb\x6f\xf4\x1d\x94\x9f\xa9\xb7\xa0\xa8\x7b\x54\xbd\xea\xfc\xda\x18\x2c\x1a\x88\xaf\x37\xec\x3f\xc2\x66\x60\x6f\x97\xf2\x2c\xcf\x66\x5f\xfc\x76\x54\xfe\x1a\x8a\x6d\xc3\x7e\x7f\x1d\xd1\x34\x19\xda\x84\x43\x92\xa8\xb3\xad\xf8\xf6\x11\x7c\x92\x54\x32\x2d\xf9\xd5\x66\x51\xd4\x98\x65\x7d\xc2\x4b\x91\x9c\xae\x2d\x78\xaf\xef\xcc\x08\xc3\xc1\x23\xb1\xcc\x54\x31\x88\xbf\x6a\x9e\x67\x75\xdc\xbb\xdb\x33\x0e\x38\x0b\xe3\xa5\xcc\xe7\x32\xf4\x6e\x2e\x1c\xcc\x4f\x8d\x6a\xb1\x7b\x4e\xa5\x09\x83\x07\x6c\x9b\x54\xb1\x83\xe9\x5a\x6a\xe9\xa9\x71\xa9\xa5\x5f\xc7\xe7\x73\x5b\x1b\x57\xb6\xcb\xd5\xfb\xcd\xf4\xab\xf0\x9c\xb7\x3b\xa9\xcc\x34\xf5\xa2\x40\xd6\x4a\x71\x5a\xd9\xaa\x87\x29\x72\xa6\xd5\x79\xcc\x4a\x5b\x83\x61\xd0\x6b\xd7\xb7\xaf\xbe\xe5\x45\xc4\x2c\x6b\x13\x28\xee\xa8\xe9\xed\x2b\x87\x5b\xfb\xdc\xb2\x3e\x15\x61\xdc\x71\xf6\xf6\x63\x95\x52\xf3\xf6\xdd\xf1\xf3\x7d\x71\x3c\xc1\x73\x82\x52\x8e\xe9\xd5\x82\x43\x29\xbe\xc8\x39\x3a\x65\x44\xb5\x9c\x8d\xd8\x81\xbc\xb3\xa8\xb3\x1c\x33\xad\xf4\x5f\x18\xf9\x65\x72\x51\xec\x13\x5c\xe4\xda\x17\xb0\x64\xcc\xf9\xc1\x15\x73\x40\xf3\xc5\x22\xa9\xcd\xb4\xfa\x50\xaa\x33\x6a\x2f\x9e\x76\xc1\xeb\x90\x6e\x0f\x75\x53\x1f\x1a\x12\x82\x07\x6e\xaf\x50\xd5\x9e\xc1\xdf\xcd\x95\x0e\x08\xce\x06\x80\xd6\x2d\x16\xbd\x90\xc0\x10\x30\xe7\x3f\xd9\x6a\x9e\x70\xd2\xf8\x7b\x27\x2c\xef\x70\x98\x2d\x76\x60\xeb\xb3\xe4\xfc\x32\x3d\x3e\xc2\x7b\x37\x5a\xcb\xe4\x86\xe1\xda\xe4\xd5\xef\x59\x45\x4e\x42\xa7\x90\x04\x09\x38\x00\x59\x00\xff\x09\xc1\x3f\xd2\x7d\x12\x48\x7c\x67\x96\xe2\x90\xe8\x3a\x5b\x94\xb9\xba\x70\x2a\x72\x5e\x37\xa5\xd8\xca\x88\x32\x95\xd9\x8f\xc9\x57\x60\x2e\x95\xb5\xc0\xb6\xfa\x9c\x83\xbe\x3d\x5d\x3b\x15\x53\xbc\xe9\x1d\xb8\x02\x50\xd7\x87\xca\xf8\x1a\x8a\x6b\xef\xc5\xc3\xf8\x42\x4e\x04\x81\x2f\x2b\x9b\x82\x0d\x93\xab\xab\xe1\xd1\x77\x09\x06\x58\xb2\x82\x34\x16\xaf\xe0\x3e\x03\x63\xd2\xef\x01\x0c\x8d\xd6\x04\x16\xd0\xe8\x7b\x9e\xb7\x6b\xa9\x97\x6f\x61\x3d\x55\x47\x5d\x53\xc1\x65\xde\xf5\x42\xe6\xce\xe9\xf7\xef\x3e\xea\x4b\xa7\x57\x5e\x39\x0d\x1d\xe8\x2b\xa6\xc3\x77\x0b\x71\x62\xce\x57\x8e\xd6\xee\x07\xa6\x51\xdd\x34\x13\xbe\x6d\xc8\xf7\x8e\xf0\xcd\x4d\x4d\xb1\x4f\x84\x1c\x15\x74\x28\x7d\x67\x87\xae\xdc\x68\xd4\x98\xe0\xcb\x9e\xb7\x5a\x63\xb8\xa0\xca\xe6\x16\x21\xf5\xa2\x5a\x35\x64\x7b\x20\x08\x7e\xf4\xd5\xb5\x18\x3a\xce\xa4\x5d\x0d\x2a\x7b\xb7\x7d\xc1\x90\x8f\xf7\xb4\x42\xa1\xa2\x2e\xe1\x21\xde\x43\x31\x43\x7b\xad\x9b\x1b\xd4\xba\x4b\xe8\xee\xdd\x8e\xbb\x82\x5c\xc7\x0c\x02\xdf\x3a\xd4\xe1\xc2\xd5\x77\x0b\xdd\xb8\x57\x22\x69\x3e\xd0\x97\x80\x78\x03\x77\xef\x23\xe9\xb8\x8d\xa4\xc1\xc5\xce\x96\xe1\xf2\x31\xef\x0c\x01\xd5\xcb\x95\xa6\xcd\x08\x12\xaa\xab\x4a\x02\x5d\x50\x34\x84\xea\x75\xe1\xf0\x53\x5b\x76\xba\xcb\x68\x93\x26\x4d\xdd\xe5\x27\x4f\xd1\x30\xd0\xdc\x5d\xdd\xac\x4f\x8a\x1f\xc8\xb1\xdf\x84\xd3\xbc\x69\x58\xaf\x66\xc0\x45\xd9\x38\xa0\x18\x70\x26\x95\xab\x58\x70\x33\x59\x8f\xb4\x54\x7e\x01\x88\xbf\xe3\x0e\x14\x80\x76\x77\x9c\x5b\xb4\xd1\x30\x6d\xef\x1c\x75\x03\x4c\x77\xfe\xe3\xe2\xd3\x78\xeb\x53\x92\x6c\x1d\x26\x5b\x77\x76\xbe\x8d\x58\x81\x11\xba\xf4\xa2\x55\x7f\xbc\x00\x61\x59\x79\x3b\xa9\x53\xde\x9a\x7b\xfb\xac\xa1\x0b\x7e\xf3\xe0\xf8\xb8\xab\x03\xef\x60\x05\x23\xaf\x1a\xe4\xaa\xf9\xe8\x60\x8f\x01\xb3\xec\x2b\xab\x12\xe0\x0a\x77\x2a\x58\x5b\xa0\xe5\x32\x68\x28\xbd\x73\x7a\x65\xf4\xbb\x73\xd4\x87\x08\x9e\x66\x2f\x0b\x8d\xdf\x2a\x1d\x3b\x5d\x9a\x6b\x3c\x17\xd3\xa1\x2c\xdf\x9d\x73\xa7\x40\x17\x84\xa2\x17\xa9\x8b\xce\xc6\xd3\x60\x1f\x70\xb2\x54\xf5\x2b\x68\x62\x71\x0b\x49\x45\x6c\x73\x53\x95\xa2\xc0\x2a\x7c\xd6\x53\x62\xdd\x20\x50\xdb\xe7\x57\xb5\x77\xf7\xc3\x1a\x6c\x10\x54\xbb\xb0\x91\x4a\xba\x09\x4d\x8c\xf5\xd1\x22\x89\xa2\x45\x33\x51\xaf\x08\x98\x90\xce\xea\x7e\x77\xfe\x6e\xa6\xf4\xe4\x79\x68\x30\x2e\x90\xc7\xa3\x11\xe7\x81\x53\x7e\xd6\x06\xc2\xa4\x83\x63\xf1\xb0\x90\x73\x5d\xbc\x03\xd6\x9c\xd6\xb4\x6f\x1b\x6f\xde\x19\xef\xd4\xfe\xe6\xa5\xd6\x3d\xf8\xf5\x62\xd8\x7b\xcf\x80\xf0\x99\xbb\x75\xb0\xcc\x9d\x44\xdb\x1a\x03\x0e\x8f\x67\x63\x7d\xa3\x4c\xcd\x33\xca\x26\xe4\x61\xcf\xd1\xb5\x6d\x75\xa8\xd6\x6e\x4b\x6f\xd9\x69\x54\xd6\x40\xc7\x72\x54\x8c\x41\x11\x7e\xf5\x14\xb4\xc8\x62\x86\x59\x16\x01\x00\x7b\xa7\xd6\x23\xf2\x69\x0b\x5d\x21\x91\x88\x54\xf6\x03\xaf\x24\x17\x05\xd8\xfe\xf1\x1d\xba\x56\x2d\xf0\xbb\x34\xf7\xb6\x3a\xc5\xfc\xf2\x16\xbc\x37\x34\xab\xe8\x94\xe7\x85\x2c\xdd\x97\x81\xeb\xf7\x0f\xd8\x6e\x4e\xcd\x50\x7f\xd1\xef\x20\xb8\x09\x4c\x7f\xf5\xcd\x93\xde\x94\x63\xee\x54\x3b\x36\x95\xea\x25\xba\xe0\x6c\x25\x66\xd3\x28\x89\xbe\xb9\xbf\x80\x25\xd4\xd2\x58\x1a\x46\x91\xe1\xb2\xb9\xc6\x30\x2c\x81\x33\x4f\xf8\xfa\x16\x19\xb3\xa5\x9b\xae\xed\xf6\x14\xb8\xa8\xee\x8b\x7d\xb5\xbb\x03\xe9\x44\xa1\xb0\x85\x2f\x88\x3f\xd5\x66\xa5\x82\x72\x82\x65\xad\x6b\x35\x9c\xd6\x4c\x2c\xe3\x56\x43\xef\x96\x32\x35\xd8\x0e\xf8\x08\x2d\xe6\xca\x18\x18\x01\x36\x78\xef\x23\x5e\x08\x61\x27\xdb\xb3\x17\x82\xb6\x01\x5e\x4f\x99\x8d\x76\x3e\x57\x3b\xec\x8e\xa8\x25\x98\x4b\x78\x3f\x34\x87\x78\x86\x69\xf9\xc7\x4b\xca\x7c\x7b\xf2\xf3\xab\xd7\xcf\xce\x7e\x79\xfe\xe1\xe3\xab\x77\x6f\xbb\xcc\x00\x54\xcd\x11\x43\xc5\xd9\x9c\xb5\xa0\x20\xaa\x68\xa9\xd6\x5e\xdf\x2c\x30\x03\x63\xa2\xc4\x08\xb7\x74\x15\xec\xac\x7a\x26\x81\x7a\x23\x7c\x5f\x25\x09\x15\xf2\xd4\xf9\xb9\xe1\xe3\x0c\xb3\x23\x8f\x8b\x37\xd9\x05\xf2\xc8\xd8\x38\xf3\x82\xd7\xa0\xe0\x2c\x2b\x3f\x63\xc0\x5c\x8f\x9d\xeb\x54\x88\x29\x99\xdc\xe1\xb7\x77\xc0\xba\x23\x2b\xf5\x78\x22\xa1\x8b\xfa\xaa\x50\xc6\x58\x15\xc6\x9b\xce\x51\x07\xd1\xed\x23\x14\xcc\xb5\x4b\xc7\xf8\x7e\x32\x3a\x39\x82\x11\x5f\x3c\x1b\x77\x95\x96\x63\x32\x5f\x61\x86\x86\x19\xc8\xb5\x25\xda\xac\x45\x3e\x66\x1e\x51\x27\xfe\x12\x87\x41\x82\x24\xeb\x74\xe5\x4d\xd2\x6a\xb2\x42\xb3\x71\xee\x16\xd1\xf7\x33\x90\x34\x1c\xbf\x28\xd3\x8b\x29\x1f\xbe\x0b\xc8\xc7\x50\x2f\x9c\xa4\x01\x28\xeb\xc9\x20\x6b\x47\x4d\xbc\x0f\x54\xed\xc9\x31\x66\xcb\xa1\xd0\xc3\xbb\x5a\xc8\xd4\x47\x38\xe2\x3b\x72\xb2\x8f\xe8\xc4\x5f\x2c\x5b\xa1\x82\xd0\x75\x28\x78\x1e\xfd\xc0\xf3\xb7\x77\xf0\x8d\x11\x1b\xbf\x6f\x98\x01\x5f\xd2\xef\x19\x6d\x58\x34\x35\x9d\xcd\x9e\xe6\x53\xf8\xe2\xd0\xee\x9b\x46\x1e\x06\xc4\x32\xd6\x71\xc5\x5d\xb1\x89\xa4\x5b\x2d\xeb\x8a\x86\x98\x6b\x1f\x8c\xf9\xab\xba\xe8\xbd\xcb\x73\xd5\x20\x72\xe0\x26\x68\xeb\x73\xa2\xb6\x34\xd1\x77\x62\x5c\xba\x00\xe0\xbf\x00\x42\x7a\x69\x8f\x6a\x8d\x00\x00")
 
 func webUiStaticJsGraphJsBytes() ([]byte, error) {
 	return bindataRead(
@@ -444,12 +510,12 @@ func webUiStaticJsGraphJs() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "web/ui/static/js/graph.js", size: 28768, mode: os.FileMode(436), modTime: time.Unix(1506011212, 0)}
+	info := bindataFileInfo{name: "web/ui/static/js/graph.js", size: 36202, mode: os.FileMode(436), modTime: time.Unix(1791995491, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _webUiStaticJsGraph_templateHandlebar = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd4\x58\x5f\x8f\xdb\x36\x12\x7f\xbf\x4f\x31\xc7\x7b\x49\x70\xd0\xea\x92\x03\xf2\x50\x48\x2e\xda\x74\x11\xa0\x40\x90\x22\xd9\xe4\xd5\xa0\xc5\x91\xc5\x86\x22\x15\x72\x24\xdb\x35\xf6\xbb\x17\xa4\xfe\xac\xed\x95\x65\x39\xd9\x06\xc8\x3e\x78\x6d\x72\xfe\x71\xe6\xc7\x1f\x39\x84\xee\x2f\x11\xb2\x01\x29\x52\xb6\xb6\xbc\x2a\x96\x1b\xcb\xab\x0a\xed\x7e\x2f\xc5\xfd\x3d\x83\x4c\x71\xe7\x4e\xe6\xd8\xe2\x5f\x30\xfc\x25\xb9\xb1\x65\x2f\xf6\xa5\x46\xbb\x5b\x86\x11\xff\x11\x49\xad\xa4\xc6\x23\xf9\xce\x61\xa7\x60\xcd\xe6\x64\xf6\x78\x3e\x33\x2a\x52\xeb\xe8\xc5\xff\x1e\x49\x01\x24\x84\x5b\xe2\x16\x39\x58\xb3\x71\x29\x7b\xc1\xa0\x52\x3c\xc3\xc2\x28\x81\x36\x65\xb7\xdb\xca\xa2\x73\xd2\x68\x78\x16\xbe\xc1\x87\x42\xe6\xf4\xdf\x5b\x4d\x68\x7d\x7c\xa0\x71\xe3\xe3\x73\xcf\x19\x68\x5e\x62\xca\x70\x5b\x59\x16\x92\xe1\xbf\x9d\xe4\x20\xac\x28\x33\x9a\xac\x51\x80\x83\xf1\xa5\xd4\x55\x4d\x0c\x04\x27\x1e\x55\xd6\x34\x52\x60\xca\x68\x57\x21\x2f\x90\x0b\x06\xbc\x26\x93\x99\xb2\x52\x48\x98\x32\x93\xe7\x6c\xb1\xdf\x7b\xfd\xfb\xfb\x24\xee\xd7\xf0\x28\x09\xb1\x90\xcd\x8c\xcc\xbc\x1c\x4b\xcc\x81\x18\x36\x5c\x2d\x1d\x71\x72\x50\xd5\x4a\x45\x56\xae\x0b\x62\x8b\x51\xf3\x00\x89\x2c\xd7\xe0\x6c\x96\xb2\xfd\x1e\x2a\x4e\xc5\x1f\x16\x73\xb9\x85\xfb\xfb\xd8\xdb\x90\x59\x2c\xcb\x75\xcc\xff\xe4\xdb\x48\x19\x2e\xd0\xde\xac\x65\xfe\x73\x93\xee\xf7\xb0\xaa\xa5\x12\x9f\xd0\x86\x7c\x1f\x64\xcd\x55\x52\x6b\xb4\x0c\xb8\xa2\x94\x79\xd5\x65\x3f\x34\x63\xcd\x63\x43\x4f\x05\x9f\x50\xb7\x5e\x72\x45\x1a\x56\xa4\xa3\xca\xca\x92\xdb\x1d\xe0\x16\xb3\x9a\x70\xb9\x22\xcd\xc0\x17\x33\x65\xae\x5e\x95\x92\x18\x34\x5c\xd5\xe8\xe1\x15\x24\x7a\xe8\x74\xb3\x23\x7e\x1c\x2a\xcc\xe8\x12\x8a\x5a\xa9\xde\x9a\xd4\x0e\x2d\x2d\x4b\x24\x2b\xb3\x11\xa3\x00\x89\xa9\xc8\xa7\xba\x8b\x86\x2d\x22\x68\x95\xa0\x55\x02\x4e\x90\xd5\xd6\x19\x0b\x51\x12\xb7\xc2\x23\xc1\xc5\xad\xdf\xef\x57\x8a\x8b\x80\xb5\xd6\x58\xe0\xca\xaf\x24\x7c\x46\x82\xeb\xb5\x47\xcb\xf8\x96\x38\x1b\xe8\xf1\xd8\xbf\xa3\xe8\x44\xf3\xee\xdd\x6f\xef\x7e\x82\xd7\x46\x37\xde\x15\x15\xd2\x01\x19\xf8\xd5\x18\x72\x64\x79\x05\x9a\x37\x2b\x6e\x6f\x00\xee\xfc\x94\xc5\x2f\xb5\xb4\xe8\xe0\x77\xde\x70\x97\x59\x59\xd1\x48\x51\x00\x2c\xe6\x16\x5d\x71\x73\x32\x19\x45\xff\x60\xe6\xac\x51\x9e\x71\xf8\xaa\xe2\x1a\xd5\x38\x5a\x6a\xd5\x9b\xd3\xbc\xf1\x6b\x8b\x88\xaf\x1c\x7b\xd0\x55\xd2\x8d\xa1\x37\x28\x2b\xd9\xc9\x79\xb4\xa2\xf6\x4c\x60\x34\x5b\x24\x1c\x0a\x8b\x79\xca\xfe\x13\x8e\x87\x9e\x2e\xb9\x95\xbc\x47\x78\x7f\x74\xf4\x73\x83\xbb\x8e\x2f\xc9\xac\xd7\xfd\xc8\xe2\x8d\x97\x4c\x62\xbe\x48\x62\x25\xaf\x0a\xa5\x5f\x1b\xcf\x48\x36\x78\x18\x59\x66\xb4\x33\x0a\xcf\xc4\x76\x32\x3b\x19\xdd\xeb\x56\x76\x2a\xbe\x24\xae\xd5\xe8\xf8\x41\x35\x89\xaf\x42\x00\xa8\xcf\xa6\x7b\xa4\xa6\x87\xda\x7e\x04\xda\x03\xd9\x1b\xe2\x52\xa3\x05\x8b\x9e\x90\xd9\xc3\x41\xde\xad\x69\xdc\xc5\x09\xc0\x14\x72\x9b\xcb\xed\x59\xe1\x76\xff\xc0\xed\x96\x2c\xcf\x08\x85\xdf\x28\xb9\xb1\x99\x0f\xc3\xd4\x15\x0a\x08\x5c\xea\x6e\x1e\xe1\xfc\x9c\xcb\xca\x9a\x12\xa9\xc0\xda\xb5\xc7\xe7\x32\x18\x02\xeb\xb7\x7a\x3b\xd2\x1e\x57\x0a\xf3\x73\x69\xea\x8c\xae\x6a\x22\xa3\x27\x24\xe0\x94\xe2\x05\xe6\xbc\x56\x87\x0e\x26\xb5\x5b\xf2\x6f\xdd\x4c\x4b\xb6\xd4\x2d\x30\x5b\x86\x75\x5c\x30\x2b\xc9\x57\xf8\x43\x61\xa5\xfe\x0c\x54\x20\x90\x2c\xb1\xcd\xc0\xcd\xe4\x92\xfd\xd1\x35\xdc\xcb\xd4\xae\x2a\x64\x66\x34\x0c\xdf\xa2\x52\xea\xda\x79\xba\x3c\xb3\x87\x3a\x1b\x71\xbb\xa4\x49\x99\x50\x89\x39\xb9\x1d\x72\xd9\x22\x61\x7a\xe9\x1e\xa3\x07\x95\xee\x90\x3a\x27\x5b\x77\x43\x8a\xc0\xe4\xed\x1e\x98\x53\x3c\x7f\xc9\x9a\x53\xba\x83\xa0\xa6\xc5\x9d\xfc\x0b\x53\xf6\xff\x69\xa1\xee\x64\xde\xef\x0f\xcc\x4e\xec\x48\x98\x89\xe6\x6f\xc5\xf3\x35\x88\x86\xe1\x3a\x32\x0b\xd3\x43\x9d\xde\x58\xb3\x79\x52\x4c\x57\xea\x49\x20\x3d\x76\x35\x38\x9a\xff\x3e\x34\x77\x48\x6d\x3f\x20\x1a\x3c\xc3\xa1\x16\x33\xb1\xf0\x1e\x37\x52\x8b\x80\x06\xf4\xff\x65\xf9\x8d\x58\x58\xf1\xec\xf3\x86\x5b\x71\x05\x1e\xbe\x8d\xe3\x46\x58\x4e\x70\xea\xcf\xa9\x19\x74\xd1\x52\x1e\x6a\x31\x87\xea\x86\xc4\xdd\x76\xd9\x1a\xa8\x0e\x9e\x7d\xbc\x7b\xfd\xfc\x92\xf6\x51\x0f\xfc\x51\x93\x54\x97\x34\xc2\x5d\xc7\xf7\x25\x9c\x52\xb6\xdb\xed\x76\xd1\xdb\xb7\x91\xb8\x5c\xdf\x99\xdc\xda\xc3\x06\xb5\x98\xc3\xad\x3d\xbb\xbe\x78\x75\x49\x6e\x20\x58\xd4\xed\x55\xe7\x07\xdc\x4b\x9e\x59\xe7\xef\xa5\x5f\x44\xc3\x75\x86\x4f\xb8\x99\x72\x63\xaf\xdc\x4b\xe7\x25\x2e\x70\xeb\x75\xbc\x38\xb5\xa0\xc3\xc6\xbd\x7b\x7d\x19\xc8\xc6\x19\x55\x87\xc6\x58\x6a\x70\x98\x19\x2d\xdc\xc9\xbb\xd0\x7b\x74\x37\xf0\xcc\x3d\x67\x87\x08\xee\xbb\x78\xc2\xaa\x7f\xd0\xf1\x7b\xf6\xe1\x77\xdf\x23\x0c\xa0\x7b\x98\xf2\xc3\x2d\x66\x5f\x4d\x5d\xa1\xbf\x5b\x7e\xda\x4a\x1d\x83\xf1\x1c\xb4\x1d\xf1\xec\x33\x8a\xf0\xce\x71\x35\x8e\x5a\xd4\xf4\x36\x9e\xe4\xb2\xd9\x45\x5d\x48\x21\x50\x3f\x54\x25\x38\x38\x4a\x7e\x18\x99\xbc\x4e\x9d\x79\xe3\x3a\x9a\x9c\xd1\x1d\xb5\x9d\x16\xb7\xc8\xcf\x3e\x9b\x3d\x56\x52\xb8\xf6\xbb\x7a\x4a\x61\x6a\x6a\x4e\x07\xd8\xf6\xba\xd0\xb5\xb0\x47\x0d\xe0\x71\x5b\x7b\x36\x5e\xdf\xf5\xe3\x81\x5d\x85\x10\x3e\x7d\x7f\x2a\x50\x3b\x7f\x03\x0a\xbf\x0b\xd3\xa0\xed\x3d\x2d\xc3\xd8\x54\xde\xa9\x40\x2e\x26\x4b\x4d\xc5\xe2\x56\x61\x89\x9a\x92\x98\x8a\x4b\xa2\x9f\x7c\xd5\xa7\x05\xfd\xec\xa4\xd3\x84\x56\x46\xec\xa6\x3d\xd9\x45\x42\x02\x32\xa3\x5c\xc5\x75\xca\x5e\xb2\x45\x22\x17\xda\x84\xf3\xd1\x03\x3d\x89\x49\xf8\x0f\x3b\x19\xc7\x94\x9f\x24\x0e\xc9\xbb\x12\x10\xe7\x1e\x6b\xaf\x7b\x0f\x3b\x1a\xfa\x9a\xe7\x27\xf0\x44\xd9\x3f\x20\x8f\xaf\x80\xf7\x8d\x14\x96\xa6\x41\xd6\x3f\xc2\xb0\xc5\xfb\x30\x00\xc3\x0b\xcf\x57\x44\x9d\xc4\xfe\x8a\xf2\x30\xd2\x09\xfc\x1d\x00\x00\xff\xff\x8f\x46\x2a\x3c\xc7\x18\x00\x00")
+var _webUiStaticJsGraph_templateHandlebar = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd5\x59\xdd\x6f\xdb\x36\x10\x7f\xdf\x5f\xc1\x69\x2f\x29\x06\x59\x4b\x07\xf4\x61\xb0\x3d\x6c\x59\x50\x60\x40\xd1\xa1\x4d\xfb\x6a\xd0\xd2\xd9\xe2\x42\x91\x2a\x49\x39\xf1\x0c\xff\xef\x3d\x92\xa2\x2c\x3b\xb2\x2c\xe7\x03\x6b\x8d\x44\x91\x8f\x77\xc7\xfb\xf8\xf1\x48\x5e\x08\xf1\x9f\x71\xc6\x56\x84\x65\x93\x68\xa9\x68\x99\xcf\xee\xf0\x59\x82\xda\x6c\x58\xb6\xdd\x46\x24\xe5\x54\xeb\x83\xb1\x68\xfa\x03\x69\x3e\xe3\x85\x54\x45\x60\xfb\x52\x81\x5a\xcf\x1c\xc5\x3e\x62\x26\x38\x13\xb0\xc7\x5f\x4f\x58\x0b\x28\x79\x77\x30\xba\x3f\x9e\x4a\x1e\xf3\x65\x7c\xf9\xcb\x03\x2e\xe4\x33\x70\x6f\xa8\x02\x4a\x50\x0b\xf2\x5e\x46\xa4\xe4\x34\x85\x5c\xf2\x0c\xd4\x24\xba\xbe\x2f\x15\x68\xcd\xa4\x20\x17\xee\x8d\x7c\xcc\xd9\xc2\xfc\x7c\x2d\x0c\x28\x6b\x1f\x11\x70\x67\xed\xd3\xaf\x22\x22\x68\x01\x93\x08\x50\x24\x72\xc1\xb0\x6f\x07\x31\x70\x1e\xa5\x52\x18\x25\x39\x81\x46\xf9\x8c\x89\xb2\x32\x11\xa1\x95\x91\xa9\x2c\x4a\x0e\x06\x35\xc9\xc5\x22\x22\xba\x04\xce\xd3\x1c\xd2\x5b\x94\xa6\x5c\x63\x24\x36\x1b\x2b\xb9\xdd\x8e\x93\x60\xfd\x03\xf7\x13\xf4\x7f\x40\x4c\x5e\x77\x85\xa4\xc5\x06\x2b\xca\x67\xda\x50\xa3\x49\x59\x71\x1e\x2b\xb6\xcc\x4d\x34\xed\x54\x8f\x92\xac\x58\x12\xad\xd2\x49\xb4\xd9\x90\x92\x9a\xfc\x1f\x05\x0b\x76\x4f\xb6\xdb\xc4\xea\x60\x69\x82\x0c\x09\xfd\x97\xde\xc7\x5c\x52\x8c\xef\x68\xc9\x16\xbf\xaf\x26\xc8\x3d\xaf\x18\xcf\x3e\x83\x72\x91\x6e\xc5\x4b\x97\x4c\x08\x44\x0b\xa1\xdc\x4c\x22\x2b\x3a\x0b\xa4\x01\x3e\x77\x91\x9e\x0b\x38\x2e\x63\x81\x73\x6e\x04\xc1\xdf\xb8\x54\xac\xa0\x6a\x8d\x99\x85\xb4\x32\x30\x43\x5a\x44\xcc\xba\xc4\x64\xea\x6a\x5e\x30\x4c\x31\x46\xb4\x02\x0b\x2c\xc7\x11\x40\x53\x8f\x76\xcc\xa3\x81\x43\x6a\x4e\xe1\xc7\x73\x05\x6d\x4c\x68\x50\x66\x56\x80\x51\x2c\xed\x50\x8a\x6a\x65\x69\x6c\xa8\x6b\x6b\xa2\x69\x4c\xbc\x10\xf1\x42\x84\xe2\x94\x95\xd2\x08\xf0\x78\x9c\x78\xe6\x0e\xe3\x12\x3f\xef\x79\x66\xe7\x4c\x1b\x89\x4b\x7c\xdf\xe6\x9a\x3a\xd4\x5a\x57\x25\x82\xaa\xc7\xd8\xf8\x72\x70\x39\xb9\xa8\x94\xc2\xb0\x52\x6e\xa3\xed\x9e\x71\x46\xc5\xd2\x22\xba\x7b\xd9\x1e\x35\x74\x9f\xf6\x63\x1c\x1f\x48\xde\xbc\xff\xeb\xfd\x6f\xe4\x4a\x8a\x95\x9d\xca\x60\xb0\x88\x91\xe4\x4f\x29\x8d\x36\x58\x85\x31\xf0\xab\x39\x55\x23\x64\xb4\x43\x0a\xbe\x54\x0c\xf1\x44\xfe\xa6\x2b\xaa\x53\xc5\x4a\xd3\x91\x0a\x82\x7c\x0b\xe4\xca\x47\x07\x83\x71\xfc\x82\x91\x43\xd8\x60\xde\x0d\x9d\x97\x54\x00\xef\xc6\x48\xc5\x83\x3a\xf4\xcb\xfa\x16\x23\xbf\x8e\x76\xb2\x1c\xc1\xd2\x29\x8a\xc2\x9c\xd5\x7c\x76\x45\x81\xb0\xd5\x4a\x0a\x4c\x08\x25\x39\xfa\x3b\x89\x7e\x72\x9b\x57\x28\xe6\x54\x31\x1a\xe0\x1c\x36\xb6\x30\xd6\x4c\x17\x91\x8c\x1a\x1a\x1b\xb9\x5c\x06\xca\xf4\xad\xe5\x1c\x27\x14\x33\xcd\xd9\x59\xa6\x04\xdf\x68\x6a\xd8\x0a\xda\x96\xa1\x1d\x1a\xf9\x8f\xd8\x76\x30\xda\x6b\xdd\x95\xe7\xed\xb3\x6f\x9c\x54\xbc\x93\xde\xca\x26\xea\x72\x06\xa0\xed\xc7\xc2\xdd\x91\xd3\xb6\xb4\xa5\x10\x7f\x5c\xb0\x8a\x28\xee\xaf\x0a\x71\x67\x37\x8d\x68\x77\xcc\xa8\x7d\xea\x9e\xe2\x00\x60\x1c\xa8\xc2\xad\xe8\x28\xb3\x5f\x3f\xe4\xfa\x1e\x17\x46\x6a\x20\xb3\x0b\x05\x8b\x56\x6a\xcd\x90\x55\x89\x04\x57\xef\xf5\xe8\x01\xce\x8f\x4d\x59\x2a\x89\xa5\x34\x87\x4a\xfb\xcd\x7d\xe6\x14\x11\x65\x97\xba\xa7\xf8\x2d\x95\xc3\xc2\xf4\x98\x85\x4a\xe7\x95\x31\x52\xf4\x70\x90\xc3\x6d\x28\x83\x05\xad\x78\x7b\x82\x5e\x69\xbf\x41\xf9\x69\xfa\x39\x7d\xa9\xce\x20\x9d\x39\x3f\x4e\xa8\x65\xc6\x66\xf8\x63\xae\x98\xb8\xc5\xf2\x03\x48\x29\xc0\x47\x60\xd4\xeb\xb2\xdd\x5e\x9b\x53\x23\x5f\x97\x39\x43\x18\x90\xe6\x2d\x2e\x98\xa8\xb4\x2d\x97\xac\x37\x70\x89\x77\xa9\x97\xc7\x65\x62\x48\x6c\x9b\x58\x7a\x24\xf4\xbb\x6e\x31\xda\xca\x74\x8d\xd4\x21\xd1\xba\x69\x42\x44\xe4\xc2\xaf\x81\x21\xc9\xb3\x07\xc1\x21\xa9\x6b\x19\xd5\xcf\xae\xd9\x7f\xc8\xfe\x6b\x3f\x53\xbd\x1f\x6f\x36\x2d\xb5\x3d\x2b\x72\x28\x9a\x9f\x8a\xe7\x73\x10\x4d\x9a\x23\xd3\x20\x4c\x37\x79\x7a\x8b\x7b\xda\xb3\x62\xba\xe4\xcf\x02\xe9\xae\xa3\xc1\xff\x50\xe6\xda\xa5\xed\x3b\x44\x83\xad\x70\x20\xb2\x81\x58\xf8\x00\x77\x4c\x64\x0e\x0d\x60\xff\x22\x22\x9e\x86\x85\x39\x4d\x6f\xef\xa8\xca\xce\xc0\xc3\xd3\x6a\x5c\x47\x95\xc3\xe3\x41\xd8\xa7\x06\x94\x0b\x5f\xf2\xd0\xfb\x21\xa5\xae\x09\xdc\x75\x1d\xad\xa6\xd4\x91\x8b\x4f\x37\x57\xaf\x4e\x49\xef\xdd\xd0\x3f\x09\xc3\xf8\x29\x09\x77\xd6\xb1\x97\x10\x8a\xb7\xc8\x35\x7e\xe2\x77\xef\xe2\x2c\x1b\x06\x9c\xd3\xb5\x35\xc0\x06\xfd\x9f\x0d\x0a\x96\xaf\xae\x97\x6f\x4e\xf1\x35\x05\x16\x35\xbb\xc2\xfa\x9d\x56\xd6\xe1\x6b\xe9\x8f\x6c\x45\x05\xd6\xa3\xe7\x5b\x4c\x98\xf6\x33\xd7\xd2\xa3\x6b\xeb\x79\x75\xf1\xd4\x8a\x0d\xaa\xea\xde\x50\x53\x6c\xf0\x8c\x5e\xb9\xeb\x30\x13\x44\x03\xba\x98\xe9\x83\xae\x15\xf2\x8c\xc8\x85\x6d\x49\xb5\x10\x1c\x3a\x0d\x06\xca\xd0\x6e\xb2\x6b\x76\xf7\x3d\xdc\x11\x1a\xd0\xed\x86\x2c\xd9\x63\xf6\x4d\xf4\x2d\xc4\xc7\x67\x6a\x1f\x8c\xc7\xa0\xad\x0d\x56\x53\xc8\x5c\x2f\xe6\x6c\x1c\x79\xd4\x04\x1d\xcf\x72\xd8\xac\xad\xce\x59\x96\x81\xd8\x65\xc5\x4d\xb0\x17\x7c\x47\xe9\x3d\x4e\x7d\x9b\x01\xcf\x81\x9a\x82\x96\x75\xf3\x2b\xa0\x56\x20\x32\x7d\xbf\x06\x2b\x7d\x41\xe6\x15\x7a\x67\x34\xa1\xf8\x13\x24\x46\x8f\xce\x4f\xad\xe0\xe5\xf2\x53\x4f\xd0\xca\x4f\x4d\x19\x92\x9f\xc7\x24\xaf\x9d\x3a\x7f\x13\xb6\x7d\xde\xa3\xad\xd7\x87\x42\x1c\x96\xb6\xea\xf6\x09\xf4\x0d\x0d\xb9\xa1\xfb\x5e\x04\xa9\x5b\x0c\x7b\x17\xf4\xfd\xb6\xc3\x51\x7b\x6d\x57\x06\x5a\x7a\xf1\x8b\x7b\xda\xfe\x01\x46\x5f\xdb\x13\xaa\xfb\x9e\xcb\x15\xc2\xa7\xd6\x3a\x73\xb4\xbe\xb8\x23\xce\x69\xd6\x9b\x6a\x93\x4f\xaf\x39\x14\x20\xcc\x38\xc1\xf7\x13\xac\x9f\x6d\xd6\xfb\x19\xed\x68\xef\xa4\x63\x33\x97\xd9\xba\x7f\x26\x35\x1d\x9b\x0c\xdd\xe4\x1a\x03\x3c\x89\x5e\x63\xfa\xd8\x54\x48\x77\x7e\xb1\x40\xc7\x49\x32\xfb\x50\xbd\x76\xf4\xcd\x83\xc3\x36\x78\x67\x02\xe2\x58\xc3\xff\xbc\x7e\xe5\x93\xdb\x83\xc4\x6e\x64\xe1\x9f\x10\xdd\x1e\xd0\x70\xd1\x85\x02\x21\x13\x85\x26\x59\x34\xfd\xe0\x08\xa4\xe9\xc0\x3d\xc2\xea\x71\x62\x8f\x90\x3b\x4a\xcd\xf0\x15\x34\x13\x6c\xd7\x05\x1b\x00\x00")
 
 func webUiStaticJsGraph_templateHandlebarBytes() ([]byte, error) {
 	return bindataRead(
@@ -464,7 +530,7 @@ func webUiStaticJsGraph_templateHandlebar() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "web/ui/static/js/graph_template.handlebar", size: 6343, mode: os.FileMode(436), modTime: time.Unix(1505729492, 0)}
+	info := bindataFileInfo{name: "web/ui/static/js/graph_template.handlebar", size: 6917, mode: os.FileMode(436), modTime: time.Unix(1791993947, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -489,7 +555,67 @@ func webUiStaticJsProm_consoleJs() (*asset, error) {
 	return a, nil
 }
 
-var _webUiStaticJsTargetsJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x93\x4d\x8e\x9c\x30\x10\x85\xf7\x9c\xa2\x62\xf5\xc2\x96\x18\x2b\xfb\x16\xab\xac\x66\x9d\x03\x44\xc6\x14\x60\xe2\xb1\x5b\x76\xd1\x19\x29\xe2\xee\x91\x01\x37\x3f\x33\x69\x4d\x2d\x71\xbd\x72\xbd\xef\x99\x76\x74\x9a\x8c\x77\x40\xbe\xeb\x2c\x72\x5f\x0f\x25\x44\x52\x84\xe2\x6f\x01\x00\x70\x57\x01\x8c\xf6\x0e\x2a\xb8\xa4\x53\x21\x5b\xe3\x1a\xce\x0c\x13\xd7\xb9\xc1\xb4\xc0\x53\x83\xb4\xe8\x3a\xea\xa1\xaa\x2a\xf8\x0e\x02\x16\x79\xaa\x80\x34\x06\xb7\x74\x4f\xc5\x43\x34\xdf\x32\xb7\x53\x18\x71\x2f\x98\xc7\x05\x7c\xf3\x77\xfc\x61\x55\x8c\x9c\xa5\x2f\x2f\xba\xc7\x7b\xf0\xee\xa5\xf1\x7f\x1c\x13\x52\x35\xcd\x67\xa7\xe3\x2d\x6f\x36\x01\xda\x88\x5f\x9f\x9b\x94\xff\x9b\xba\xdc\x79\xf0\xb0\xe2\x70\xf8\x4e\x5c\xc8\x95\xdf\x82\xee\x5a\x4c\x45\xf1\x40\x6b\x9c\x21\x9e\xfd\x5d\x38\x93\x83\xaf\x7f\xf5\xa8\x1a\x0c\x4c\x48\x6d\x8d\xfe\xcd\x73\x33\xdf\x73\x48\xec\x07\x5f\xcf\xe8\xa9\x37\x31\xb3\x57\x69\x4f\xa2\xc0\x99\x69\x98\x28\x1f\xfd\xa9\xf0\xfd\xa6\x5c\x83\xe1\x35\x67\xb6\x17\x1a\xf9\xa9\xa9\x0d\x50\x0b\x7c\x3f\x20\x67\xfa\x2d\x65\xba\xdf\x2c\x95\xf5\x5a\xd9\x9f\xe4\x83\xea\x50\x46\xa4\x57\xc2\x37\x3e\xf8\xba\x84\x56\xd9\x88\x2b\xac\x5c\x2b\x9f\xb4\x4d\xb9\x04\xbe\x9d\x7f\x08\xea\xf9\xf8\x93\xfa\xc3\xf4\xf3\xf5\xd3\x92\x5a\x36\x7a\x4c\x00\x12\x4c\x54\xba\xdf\x22\x30\x25\xa4\x64\x4f\x41\x44\xb4\xa8\xc9\x87\xed\x47\xd8\x22\xb8\x1e\x08\x1e\x36\xef\xd6\xcd\xb3\x5c\xcc\x2f\x9e\x25\x0f\xec\x48\x74\xf5\x90\x13\xbb\xa9\x80\x8e\xe2\xe9\xb9\x3c\x73\x37\x15\xc5\x85\xa7\xc7\x26\xae\xff\x02\x00\x00\xff\xff\xc9\x97\xfe\x65\xd7\x03\x00\x00")
+var _webUiStaticJsPromql_editorJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xad\x3c\x6b\x77\xdb\xb8\xb1\xdf\xf3\x2b\x10\x36\x6d\xa8\x58\xa6\xec\xa4\x7b\xdb\xca\x51\x7c\xb6\xdb\x6c\x9b\x76\x37\xed\xdd\x64\xef\xe9\xb9\xb2\xa2\x85\x48\x48\xe2\x9a\x22\x15\x92\xf2\x63\x63\xf7\xb7\xdf\x79\x00\x20\xf8\x92\x95\xec\xfd\x10\x4b\x24\x06\x33\x83\xc1\xbc\x01\x65\x34\x12\xff\xca\xb3\xcd\x7f\x7f\x27\x54\x14\x97\x59\x3e\x16\xc5\x6d\x5a\xca\x1b\xb1\x8e\x57\xeb\x04\xfe\x95\x71\xba\x1a\x8a\x30\x4b\x4b\x75\x53\x1e\xcb\x6b\x99\x2b\x21\x77\x65\x16\x66\x9b\x6d\xa2\xca\x38\x4b\x85\x4c\x23\xb1\x90\x45\x1c\x3e\x1a\x8d\x44\x12\xa7\x38\x45\xc0\xfb\x32\xdb\x8a\x6c\x29\xca\xb5\x12\xea\x66\x9b\xab\xa2\x40\x68\xc4\x03\x48\x64\xf0\xe8\xd1\x95\xcc\x89\xba\x02\x90\x5d\x21\x26\xee\xc3\xdd\x9d\xf8\x74\x7f\xf6\xe8\x51\xf5\x2a\xd0\x8c\x4e\xc4\xa7\x47\x42\x00\xa9\x6f\x77\x69\x48\x0c\x14\xf1\x2a\x95\xe5\x0e\x28\x0c\xc5\xa5\x52\x5b\x11\xa7\xb8\x8c\x50\x5c\xc7\xe5\x5a\x6c\x61\xda\xc7\x64\xb4\xd4\xd0\x45\xb0\xca\x02\x40\x60\x9f\xc7\x84\x4f\x08\x4f\x2e\x0a\x6f\x2c\xa6\x5e\x9c\x16\xa5\x4c\xcb\xe3\x2b\x15\x82\x44\xbc\xd9\xd0\x0e\xab\xb4\xdc\x0b\x71\xb5\x9a\x67\x57\x2a\x9f\x97\xf1\x46\x11\x60\x2e\xd3\x95\x6a\x82\x85\x2a\x4e\xf6\xa1\x09\xd7\x38\xab\xd8\x83\x20\x91\x9b\xed\x7c\x23\x6f\xba\xb0\x0c\x85\x57\x84\x32\x91\x2d\xf0\x38\x3d\x10\x3c\xdb\xa5\xe5\x41\xeb\x20\x40\x3d\x7b\xcf\x7a\x22\x79\x3b\xcf\x96\xf3\x0d\xe8\xd0\x9a\xe0\xa6\x75\xc0\x59\x13\xf2\x5a\xa9\xcb\x07\x01\x8b\x79\x9c\x1e\x84\x53\x25\xa5\xec\x5f\x44\xa4\xf2\xf8\x6a\xcf\x70\x9e\x6d\xe7\xa0\xeb\x40\x68\x9e\xc8\x85\x4a\xf6\xaa\x08\xa8\xf9\xbe\xe1\x65\x92\x65\x7b\x25\xb5\x8e\x8b\x32\x5b\xe5\x72\x33\x5f\xe6\x92\xb4\x93\xa0\xb5\x88\xab\xad\x82\x6f\x0f\x62\xf8\xb8\x83\xe1\x38\x51\x0d\x0c\x7d\xf3\xb2\xa4\x9c\x5f\x83\xed\xaa\xbc\x43\xf1\x6a\x94\x1b\xea\xb2\xce\x76\xf9\xfe\x1d\x88\x1f\xd8\x82\x38\x0d\xc1\x1f\x14\x7b\x34\x2d\xce\x65\xb9\x67\x98\x36\x66\xfe\x73\xd6\xaf\xe1\x65\x0e\x3e\xa9\xef\x5b\x10\x04\x0d\x54\x49\x76\xad\xf2\xd0\xb0\xb4\x0f\x5f\x7b\x6e\xae\xb6\x89\x0c\xd5\x17\x71\xa2\xbf\xd5\x11\xc2\xbb\xcd\x17\xf0\xb1\xdb\x6e\xbf\x64\x0d\xe9\x3e\xfd\x4c\xb2\xd5\xe9\xc9\x03\x00\xcf\xf7\x8d\x6f\x64\x74\x88\x67\x01\xd7\x76\x10\x18\x78\x80\xc3\xc0\x76\x5a\x7d\x7a\x75\xf4\x00\x47\x02\x11\x2c\x8a\xc3\x72\x0e\x11\x4e\x69\x87\xd7\x63\x24\x66\x86\x31\xc1\x06\x93\x95\x29\x75\xb2\xbb\x5f\xd7\x21\xc8\xa9\x72\x4f\x6c\xc8\xc1\x29\x47\x3d\x5b\x3e\x65\xca\xd5\x92\x1e\xf6\xdd\x45\x96\x97\x0f\x8d\xcf\x23\x55\x84\x7b\x81\x3e\x3e\x80\xa4\x8c\x22\x75\x75\xc8\x56\x02\x24\x24\x0d\x07\x41\xee\x36\x87\x80\x99\x51\xf3\xac\x87\x9d\x6d\x32\x23\xb7\x66\xd3\xdb\x0a\x02\x00\xf7\xc3\x47\xf0\x57\xae\x56\xb9\x5a\x49\x78\xeb\x64\x15\x57\x2b\x26\x20\xbc\x45\x56\x96\xd9\xe6\xb2\x8e\x5d\xc7\x51\x03\xc3\x41\xf5\x4a\x26\x3b\x9d\x01\x34\x7c\x82\x8e\xfa\x08\xaa\x23\x3a\x7e\xed\x72\xf6\xb3\xa1\x91\xac\x81\x62\xe9\xb9\xcb\x05\x29\x99\x41\xc8\xd6\xea\x9c\xd9\x55\x5d\xaa\xdb\xeb\x2c\x8f\x0a\x1c\x84\x5c\x0f\x35\x89\xf5\x69\x97\x26\x90\xd4\xe1\xb7\x48\x2d\xe5\x2e\x29\xf1\xeb\xe2\x16\xff\x62\xe6\x95\xed\xe8\x05\x04\x31\x8c\x3b\xab\x34\x33\x0e\x67\x05\x4a\xba\x9d\x27\x6a\x59\x56\x4f\x39\xe6\x9a\x04\xbe\x5c\x82\x86\x13\xa6\x2c\x4b\x70\xdd\x9c\xed\xfd\x43\x73\x21\x96\x59\x82\xfe\x19\x72\xce\x5b\x21\x21\xdf\x2c\x4a\xcc\x32\xc9\xe9\x89\x54\x6e\x54\x81\xe9\xdf\x16\x52\x4c\xb0\x68\x30\x96\x02\x93\x3d\xa2\x01\xd4\xff\xe1\x2c\xe5\x57\x31\xca\x6c\x45\x3b\x30\x56\xce\x21\xa7\xde\xe9\x06\x81\xbe\xa2\xbf\xa7\x27\xf4\xf1\x82\x3f\x4e\xd7\xf8\xf7\xbf\xe8\xef\xe9\x73\xfe\x20\x31\x9e\x5e\x83\x98\x31\xcb\x85\xf5\x95\xd9\xa5\x4a\xe3\x5f\x94\x28\xb6\x49\x5c\x16\xb0\x34\x93\x98\x57\xb9\x33\xc4\xe7\x8c\x01\x0b\xce\x6d\x61\x85\x71\x2e\xca\xdb\xad\xc2\x2c\x1c\xd1\x6c\xb3\x22\x46\x96\x02\xf1\xa6\x14\xa9\x02\x0b\x10\x4b\x19\x27\xc5\x99\xd8\xa5\x97\x69\x76\x9d\x0a\x48\x2f\x31\xb9\x80\x40\x2f\x16\x0a\x52\x1b\x05\x79\x4b\x9e\xc3\x8e\x6a\xcc\x41\x3b\xe7\x0e\x2c\x73\x13\x9b\x37\xfb\x71\xba\xdd\x95\x03\x52\x73\x4c\xe4\xf3\x1d\x28\x03\x8c\x4f\x49\xb3\xa6\xde\xf5\x3a\x2e\x55\xb1\xc5\x50\x38\x14\xa3\x0f\x17\xc5\xd1\x48\x6b\xdd\xd4\xc3\x84\x0a\x73\x69\x1c\xf8\x4d\xf0\xcc\x0c\x00\xfb\xef\xa1\x58\x48\x24\x6c\x29\x09\x5b\x84\x72\x4b\x79\x3d\x15\x11\x61\x02\x6b\x83\xd2\xe2\xe3\x2e\x2b\xd5\x50\xc4\x4b\x58\xf3\x6d\xa0\x51\xda\x70\x36\xfa\xe0\xf9\xe7\xe3\x8b\x8b\xe0\x6e\xfa\xc1\xbb\xb8\xb8\x48\x67\x83\x67\xbe\x77\x3e\xa8\xa8\x3b\xa0\x4f\x2d\xe8\x53\x03\xfa\xb4\x07\xf4\xa7\xe9\x87\x9f\x66\xcf\xfc\x9f\xdc\x61\xb3\xff\x04\x30\x3d\x39\xfe\xd3\xec\x68\x5a\x6c\xd6\xd1\xf5\xed\xec\x62\x51\x81\xa5\xbb\xcd\x42\xe5\x04\x04\xf4\x4e\xa6\x37\xff\x9e\x21\xb0\x3c\x5e\x7e\x7d\xfc\xed\xec\xe8\x8e\x66\x3e\xbb\x08\xce\x19\x05\xc0\x4c\xd5\xeb\xd9\xf4\xf8\x68\xa6\xdf\x0c\x5c\xa2\x90\x4e\x81\xad\x2f\x63\x8d\x71\x2a\x8f\x7f\xf9\xfa\xf8\x7f\xe7\xe3\x99\xfe\x06\x33\xe0\xe1\x59\x35\x21\x83\x44\x40\x72\x08\x20\x06\x26\x93\xbb\xc7\x93\xbb\x57\x93\xbb\x97\x93\xbb\xc9\x7f\xee\x1e\xff\xe7\x0e\x48\x3d\xbb\x18\xfd\xf6\xc3\xe4\xe5\xab\x99\x43\x69\x8b\x5b\xbd\x73\x56\xe8\x0f\x3e\xdd\x5f\x4c\x2f\x66\xc3\xd9\x08\xdd\xc3\xec\x4c\xef\xbd\xad\xcc\x5a\x9a\x63\x20\xb4\xd2\x82\x7a\xd8\x49\xa0\xa9\xf0\x7c\x72\xc6\xe6\xfd\xae\x94\xe1\x25\x1a\x32\x70\x9b\x8a\x05\x68\xe8\x25\xc4\x39\xab\xe5\x58\x81\xc2\x66\xec\xc2\x12\x9f\x6e\x41\x73\x93\x0c\x54\xa1\xa4\x52\x0e\xb1\x15\x34\x9f\xf1\xc3\x2b\xd0\xbe\x44\x09\x1f\x69\xbc\x14\xa4\xa9\x41\xa2\xd2\x55\xb9\x1e\x68\xbf\x4c\x2a\xab\x40\xd1\x26\x7a\xb8\x48\xe2\x50\xe1\x84\xc1\x99\x05\x20\xc3\x9a\x18\xfb\x70\xde\x43\x0d\x0b\xef\x71\xfe\xf4\x64\xc6\xef\x97\x59\x2e\x7c\x1c\x8c\x69\x55\xf0\xf1\x92\x6d\x42\x13\x86\x37\x47\x47\x86\x3a\xa3\xd9\x20\x0e\x04\x99\xc6\xb3\xe9\xe9\x2c\x50\x37\x2a\xf4\x11\xa9\x66\x41\xa0\x8e\xfb\x9b\x6a\x92\x30\x1c\xd9\x59\x86\x3a\x8d\x31\x57\x9b\xda\xcb\x05\xa4\xd6\x97\xe6\xf1\xfe\x51\xf5\x57\xef\x0a\x96\xd3\x88\x74\x4c\xa8\x87\x84\x64\x4c\x7f\x87\x28\xd3\x1c\x1e\x40\x26\x43\xa1\xd2\x88\xbe\x89\x23\x1a\xd4\x8b\xba\x67\xcc\xc8\x26\x73\x36\x99\xd8\xcc\xb2\x62\x1b\xc8\x04\x3b\x2c\x2d\x20\x62\x41\x72\x13\x11\x93\xa7\x33\x86\xd6\x62\x75\x99\xda\x02\x00\xed\xa7\xa6\x22\x5e\x89\x13\x71\xce\xaf\xa6\xb5\x81\x63\x01\x68\xc6\x22\xdd\x25\x09\x6d\x7b\x93\x15\xc7\x56\xea\xa2\x07\x97\x7a\xa5\xf5\x15\xdc\x1b\x3e\xbd\x03\xc7\x0f\x90\x21\x04\x52\x9f\xb5\xb5\xb6\x0b\xc8\xd5\xef\x7e\x47\x9f\xc1\x65\x9c\x46\x7a\xa9\x2a\xe1\x84\x01\xfb\x15\xf5\x21\x13\x72\xbc\x41\x6d\xff\x40\x14\x46\xab\x28\x64\x79\x76\x6f\x04\x14\x97\x8a\x68\x69\xae\x4c\xdc\x0d\x00\xa7\xba\xf9\xe7\xd2\x27\xc1\x97\xd9\x77\x58\x9d\x7c\x03\x99\xbd\x0f\xa8\x1f\x03\xad\xe3\xd3\x3e\x12\x1a\x45\x17\x11\x70\x05\x6f\xd2\xe5\xdd\x5b\xf9\x76\xf0\x64\x14\x07\xe0\xab\x4b\x22\xd0\xcb\xae\xf6\x62\x9d\xa8\x2e\x8a\x67\x17\xfe\x88\x91\xb8\xe6\x84\xf3\x41\x73\x00\x27\xc8\x8e\xf4\x13\x62\xb3\x5e\x9e\x6d\xc0\xf4\x11\x34\x00\x5d\x24\x1b\xb8\x9c\xc4\xab\x0f\x5b\x05\xf2\x2b\x96\xf0\xd8\x27\xc5\x81\x6f\xf8\x19\x54\x8a\x66\x04\x5d\x93\x1e\xb8\xb5\x6f\x64\x92\x80\xc2\x27\xf1\x25\x44\x68\x1b\x3b\x87\x62\xb1\x83\xe8\x9c\x41\xe6\x92\x2a\x71\xad\x04\x46\xe6\xe0\x30\x21\x18\x73\xe2\x60\x3e\x11\xe0\x14\x55\x63\x41\xdd\x02\x00\xc7\x9c\xc7\xa1\xd7\xf0\x05\x8e\x48\xed\x62\x5c\xbf\xdf\x36\x9b\xf7\xe4\x34\x0e\xb3\x9c\x02\xfc\x77\xb8\xe6\xed\xaa\x30\x61\x55\x2a\xbc\x4f\xde\xd8\xf2\xc9\x46\xbd\xdd\x15\x6b\xff\x13\x9a\xd0\xd8\x31\xad\x21\x07\x8f\x31\x7e\x0c\x05\xaf\x62\x6c\x39\xd1\x5b\xf1\xde\x2e\x74\x52\x2d\x15\x7c\x86\x1d\x43\x7d\x61\x47\x71\x3f\xe8\xf1\x8e\xcc\xd6\x74\x3f\x5b\x54\x45\xd4\x78\x7a\x00\x9f\xef\xe0\x43\x11\x1a\xbf\x00\x32\xdc\xbb\x06\xa3\x51\x38\xaa\x85\xdd\xcc\x62\xad\x63\x70\x57\xd9\xed\x20\xce\xf6\xac\xc9\x72\x74\xee\x78\x2d\x10\x96\x47\x49\x74\x43\xfe\xa8\x8f\x35\xe9\xfb\x6d\xd6\xad\xce\xa2\x57\x6c\x0f\x3b\x96\x38\xf8\xa2\x2d\xba\xb7\x22\xe5\xe7\x59\xe3\x79\xd0\x10\x39\xa5\x14\x10\xe7\x70\x22\xea\xdd\x90\xa6\xe0\x56\x0f\x09\x18\x37\xe9\x7e\x8a\xf4\x9d\xc0\xe9\x38\x7d\x74\xec\x24\x03\xe6\x11\x17\x81\x28\x5d\x53\xb7\x72\xcd\xb6\xbe\xc3\x7c\xcb\x20\x8d\xf5\x2e\xa0\xc6\x4a\x43\x0a\x85\xae\x01\x1b\xb3\xdc\x1b\xb9\x11\x83\x6e\xc8\x7f\x59\xa0\xd4\x48\x30\xd7\x27\x3d\x80\xef\x9a\x67\xce\xc9\xb4\xcb\xc3\x57\x48\x31\x57\x90\x83\xa7\x90\x26\xd0\x94\xb1\x9e\x3a\x24\x19\x8c\x99\x18\xe4\x01\x9d\xcd\xfa\xa6\x7f\x70\xeb\x07\x83\x66\xa1\x20\x75\x52\x2c\xcc\x5a\x12\xe5\xf3\x08\xc9\x7b\x07\x9a\xbe\x8c\x53\x10\xd8\xb9\x61\x5d\xaf\x6c\x6c\x11\xc0\x1a\x31\xed\x7a\xc5\xe9\xd7\xf1\xb1\xd9\x1f\xde\x49\x9c\x03\x19\x13\xeb\x21\x1a\x85\x5b\xa1\xf0\x2e\xb7\x41\x4c\xa1\x52\x6d\xb5\x16\x86\x05\xae\xb2\x17\x47\x54\x2c\x66\x5d\xd8\xe1\x89\x88\x1e\xe0\x3a\x66\x19\xe7\x90\x77\x6e\xf3\x6c\x91\xa8\x0d\x2c\x19\xd6\x86\xa1\xac\x7e\x4e\x02\xe2\xcd\x09\x4f\x57\x39\x46\x28\xf7\x94\x62\xaa\xe8\xca\xc5\x6d\x15\xa7\xe1\xcf\x1e\xf5\x64\xad\x90\xb3\xd6\x64\x5c\x4b\x5d\xab\xbc\xb1\x82\xb3\x72\xd0\xa2\x76\x8c\x9d\x53\xe7\x96\xfc\x3e\xd5\x7d\x7a\x51\xc8\x15\xa4\xa0\xde\x2e\x05\x09\x80\xdb\x87\x7d\xb6\x65\xaa\xf0\x20\xe7\xfc\xfb\xbb\x7f\xbe\x0d\x38\xb1\x8c\x97\xb7\x4c\x03\x63\xca\xbd\x9b\x3d\x1a\xea\x6e\xae\x79\x38\x65\x27\x3f\xd5\x19\x6c\x0f\x72\x63\xbb\x5f\xb0\xa8\x2f\x58\x0a\xc5\xf9\xc3\x49\x71\x5a\x60\x34\xe3\xf3\x09\x1a\xc7\xc2\x06\x61\x1e\x9d\xbc\x96\x03\xa0\x19\xaf\x4c\xc5\xd6\xc2\xed\xa1\xb6\xa1\xd1\x7b\x17\x3f\x2d\xe7\xd0\x55\x12\x0b\x62\xb3\x03\x2b\x5a\x60\x5a\x65\x48\x73\x9a\xf5\xd5\xc6\xdd\x38\xfc\x87\x6b\x43\x65\x45\x87\xe5\x78\x4a\x57\xa3\x75\x88\x30\x50\xd3\x26\x38\xfa\x4f\x66\x92\x51\x37\x18\x44\xd0\xfa\x3e\x60\xb3\xa2\x7b\xc3\x09\x6d\xb5\x01\x9d\x6e\xa3\x6d\xbb\xf6\x8c\xd3\xb5\x7b\x6c\x77\x55\x66\xbf\xbf\x0a\x47\x21\x20\xfc\xbe\x0c\xdc\xf0\x81\x60\x47\x18\x15\xe1\x6f\x13\x76\x8a\xa3\xb3\x00\x4f\x5b\x7c\x8a\x9f\x08\x39\xf0\xce\x1c\x59\x37\xc8\x74\x24\xe7\xc8\xae\xcc\x57\x45\x95\x48\x3a\x40\x9a\x02\xa8\x46\x28\x4b\xbf\xdd\x35\x1e\x9c\x75\x31\x2b\xa6\x8b\xdb\x3b\xdd\xcd\x13\x3e\xd5\x56\x43\x11\x04\xc1\x60\x26\x68\x1d\x48\xae\x97\xeb\x87\x37\xe0\x35\x1d\x8f\xd7\xe2\x97\x3e\xc8\xe6\x35\x95\xeb\x18\xf3\x31\xf0\xab\x18\x40\xf5\xd0\x99\x19\xe0\x84\xf4\x2d\x75\x27\x4d\x0f\x84\x06\x42\x19\xae\x71\x4b\x3f\xdd\x57\xaf\xec\xc9\x7a\x03\x96\x33\x62\xca\x19\x4e\xce\x2a\x92\x71\x19\xcb\x04\xbd\xfa\x60\x2f\xeb\x10\x8a\xb3\x32\x43\xa3\x74\xe6\xb8\xeb\xa9\x14\x09\x08\x2d\x71\x15\x80\x9f\xaa\x6a\x7c\x0e\xae\x73\x89\x27\x4a\xf0\xfe\x89\xef\xbd\x8c\xe2\x2b\x11\x26\xb2\x28\x26\x4f\xf9\x7c\x7d\xce\xf7\x07\x9e\xbe\x7a\x39\x82\xb1\x57\x1e\x6d\x12\x4d\xe4\x62\x8a\x83\xb4\xef\xa2\xaa\x40\xec\x65\x03\x8d\x1d\x82\x60\x03\xbb\x85\x78\x0a\x1b\x19\xcb\xe3\x75\x1c\x41\x69\x3f\x79\x8a\xc9\x13\xd2\x84\x19\x2e\x4d\x08\xda\x3b\x8d\x6b\x97\x18\x54\x78\x96\x1b\x81\x77\x3c\xa6\x51\x8d\xd8\x91\x36\xe2\xd9\x25\x80\x06\xf8\x89\x94\xef\xb2\x97\x96\xbd\xeb\xc6\xc1\x8e\x55\xeb\x35\x06\xf8\x37\x8d\xfc\xfa\x32\x87\xae\x64\x22\x05\x19\xd4\xda\x1f\x0c\x2b\xd6\x3b\xd0\x2c\x21\x3c\xf9\x96\x9b\x01\xed\x0b\xee\x15\xa6\x02\xef\xe3\x8d\x02\xad\x6f\x48\x1c\xb6\xd4\x63\x85\x84\x52\x02\x7b\xa9\x6b\x5d\xba\xd8\x1d\x57\xc6\x1c\x21\x4b\x79\x2b\xaf\xe2\x15\x7b\x51\x00\x2f\x04\xde\xf3\x80\x09\x11\x56\xaf\xfc\x0e\x45\x17\xd8\x58\xa1\xea\xa5\xca\x6e\x4b\xae\x7d\x7a\xfa\x62\x28\x9e\xff\x61\x28\x5e\xfc\x71\x28\x7e\x7f\x32\xb3\x15\x8a\x0a\x20\x08\x84\xeb\x76\xbf\x82\x2d\xcf\x8d\x44\xb4\x04\x28\x3c\x22\x58\xb0\x36\xf5\x30\x51\x32\xd7\xab\xf4\x9d\x15\xeb\x61\xe7\x0d\x26\xc4\xca\x3c\xf8\xae\x6e\x33\x5e\x04\x05\xac\xe2\x1e\x58\x3c\x39\x19\x9c\x35\xd7\xc3\x59\x1f\x4b\xaa\x62\x92\xa6\x6a\x4d\x51\x86\x29\x8a\x2e\x83\x0e\xa1\x17\x61\x9e\x25\x89\x2b\x68\x83\xa9\xae\x05\x01\x03\xbe\x87\x92\xc1\xc1\x50\xbd\x1c\x10\xf2\x4e\x12\x61\x12\x87\x97\xbd\x14\x8a\x75\x76\xfd\xce\xc4\x0b\xbf\x1f\xcb\x22\xd9\xe5\x5d\x48\x40\x1d\xfe\x1a\x5f\xa1\x09\x02\x95\x82\xae\xf2\x80\x93\x22\xab\x91\xa4\x46\xa1\x82\x90\x0c\x5b\xb7\x8a\x0b\x50\xcb\x40\x53\xde\x23\x76\x34\xa8\xef\x61\xbe\x16\xfd\x73\x2d\xfa\xfb\x41\xe5\x5f\x2a\xb6\xb4\xae\x75\x6a\x2a\x6e\xd5\x63\x6b\x27\x41\x5c\x40\x79\x7d\x15\x17\x31\xa4\xd2\x6e\xef\x85\x77\x94\x54\x8e\x54\xf4\xc5\x73\xd4\x4e\x15\x84\x65\x9e\x40\xf5\xec\x56\x6e\xf5\xbd\x45\x7f\xe2\xd4\x6f\x8a\x0a\x18\xa8\x00\xfe\xc2\x07\x5c\x55\x6d\x77\xdf\xaf\xbf\xba\xe7\x61\x55\x9e\x69\x51\x69\xfa\xe2\x8f\x63\x94\xee\x8f\xdb\xc0\xd5\x2c\x76\xec\xbe\xf3\x1d\x8c\x0e\x32\x0e\x4b\xcc\x29\x05\x09\xcd\xef\x4f\x08\xcd\x5f\xac\x45\xee\x43\x74\xb4\x07\xd1\x9f\xc6\x7c\xea\x22\x17\x41\xf5\xf2\xf4\x05\x61\xff\x81\x56\x56\xc3\x2f\xc3\x50\x6d\x35\x7e\xc7\x71\x4e\x6b\x04\x67\xbd\xd4\x9e\xff\x81\x10\xbf\x2e\x42\x09\x51\xc8\x45\xec\xe8\x47\x7b\xae\x3e\x5c\x1c\xf7\x4a\xbc\x6f\x97\x14\x64\x5d\xd9\xf6\x0d\x94\x70\x51\x0c\x79\x3d\x44\xc3\xad\x64\x1f\xe7\xb7\xd4\x8f\xd4\x09\xb5\x6f\x93\xed\x20\x71\x63\xfd\xf3\x92\xb8\x53\x0b\xfb\x08\xee\x15\xd2\x13\x1f\x03\xea\x80\x5d\xa2\x3f\x98\xb5\x58\xa8\x7c\xde\x61\x61\x1c\x2c\xee\xfb\x5a\x5e\x51\xcb\x0b\x0b\x27\x2f\xa9\xa7\x1f\x34\x6a\xcb\x53\xa6\x2a\x76\xdb\x08\x44\xc4\x05\xaa\x7b\xf7\x4f\xe0\x21\x7a\x22\x6f\xc5\x12\x98\xa0\x51\x93\xdc\xf0\xa5\xc0\xb4\x0c\x0e\x60\x55\x13\x79\x38\xdb\x68\x9c\x16\xed\xa9\x61\x1d\xa7\x71\x25\x13\x70\x96\xba\x16\x35\x38\xd6\xe5\x26\xc1\xa0\x1d\x6c\xe4\xd6\xf6\x1a\xdc\xe6\x43\xad\xa0\xe5\x5e\x0a\x46\x78\xa8\x53\x52\xcc\x01\xf0\x55\x55\x2b\x05\x88\xce\x1f\xf4\x55\xb9\x4e\x85\xd3\x6e\x15\xc0\xfc\xe6\x19\x47\x98\xe0\xea\x3c\x9d\x42\x60\x7a\x6a\xf0\x55\x14\x6c\xbc\x7a\x8d\x25\x34\xfa\xaf\xfa\x1b\xdd\x92\xa2\x43\x1a\xe2\x02\x51\xd0\x93\xd3\x69\x05\x3a\x47\x40\xc8\x64\x3b\x38\x79\xee\x9e\x66\xdd\xbb\xa9\x34\x2f\xde\xe4\x37\xc8\x15\xcd\x17\xde\xd3\x57\x9e\x3e\xf7\xc1\xa7\x97\x23\x96\x11\xeb\xaf\xce\xa9\x39\xf5\x01\x7d\xfa\x5a\x94\xb9\x8c\x13\x54\x9d\x54\x5d\xe3\x65\x19\xf8\x54\x51\x61\xd4\x05\x23\x07\x54\x6d\xac\x11\xe0\x9e\xe8\x84\x6f\xad\x28\x20\xb6\xf2\x41\x16\x3b\x6d\x25\x10\xbe\x48\xbd\x8e\x9c\xf1\x90\x30\x7a\x98\x41\x35\xdb\x2a\x8e\x05\x55\xfb\x30\x71\x52\x7d\xd6\x3c\x7d\x8e\x25\xce\xa9\x7e\x10\x63\xd1\xdd\xb0\xf1\x9b\xf3\x06\x36\x89\x77\xb3\x1d\x4e\xf5\x9b\x11\xfc\x40\x8f\xe0\x4e\x3b\xc8\xda\xda\x8a\xd6\xc8\x55\xc0\xbe\x65\x14\x7d\x83\x2a\xe1\x7b\x74\x07\x38\xc2\xe4\x28\x37\x16\xd2\xd0\x49\x5d\x02\xd7\x4a\x34\x53\x64\x55\x18\x73\xb5\x01\xb7\xd2\x8f\x94\xb4\x49\x33\x1c\xee\xf2\x82\xc4\x5e\x6d\xee\x14\x12\x4b\x8e\x36\xb0\xb6\x77\xa8\xf1\xbf\xc6\x71\xe8\x73\x9e\x93\xa1\x26\xd5\xf2\x24\x61\x79\xc3\x4d\x51\xa7\xeb\xc8\x8d\x56\xdd\xfd\xaa\x0f\x51\xab\xc0\xb4\x51\xaa\x96\x2b\x25\xeb\x92\x0e\x9c\x3f\x0b\x55\x03\x05\x95\xbb\x13\xfb\x0a\xf7\x0f\xf9\x03\xef\x00\x1f\x4e\x9b\x86\x7b\xf7\xe6\x3d\x2a\x82\xd9\x58\x8d\xc1\xbc\xa6\xdd\xa9\x4e\x81\x88\x45\x6c\xeb\xe3\x97\xbe\x9e\x7e\x63\xcc\x6d\xe8\x37\xa8\x30\xa4\xf6\x80\xf7\x46\x07\xe3\x95\x0e\x43\xe6\x70\xa3\xa7\xd7\xc1\x0d\x0e\xab\xa7\xf1\xaa\xad\x9c\xac\x84\x30\xc2\x04\x74\x50\x33\xe2\x87\xda\x8a\xba\x7a\x10\xd8\xae\xd7\xb2\x14\x31\xde\x79\x41\xd7\x84\xcc\x47\x42\x96\x7c\xb7\x80\xf6\xfd\x90\x38\x56\xb5\xdd\x3b\x4c\x0b\xb4\xa9\xc3\x3b\x9c\x35\xf5\xb8\x02\xe8\xd7\xe3\x07\x3b\xb8\x80\xba\xad\xb7\x2d\x2b\xa8\x3a\xb4\xff\x8f\x1a\xc8\xe6\xf0\x09\x32\xa0\x65\x7c\x33\x06\xc7\x67\x2f\x0a\x30\x1f\xfa\xae\x00\x3f\xdc\x93\x1d\xef\x53\x2c\x73\x56\xd7\x56\x2b\x3e\x1f\xef\x18\x70\x75\x51\x07\xba\x7e\x8d\xec\x98\x6f\x8f\xd6\xda\x43\x55\x17\xb3\x3d\xa6\x0f\xc0\xad\x8a\xa3\x05\xb1\x14\x9a\x8a\xce\x63\x3a\x32\xf3\x50\x61\xb6\x57\x70\x6e\x4d\x97\x84\x49\xfb\xe8\x16\x8a\xfe\x9d\x44\x75\x5f\x41\x50\xc5\xef\xa8\xa7\x90\xa0\xc3\x0a\xcf\x03\x6c\x03\x8d\x20\x26\xd5\x1d\x20\x73\xf3\x87\x2f\x92\x54\x2a\xe2\xea\x07\xb3\xa6\xd0\x4d\x18\xc4\x47\x8c\x09\xb5\x51\xf7\xfb\x5d\x5b\x0d\xb3\xad\x31\x64\x50\x12\x5a\x4b\xb7\x73\x73\xae\x54\xec\x3d\x03\xb2\xc9\x98\x23\xbf\xe6\xa1\x0e\xa8\x9d\x18\xd7\xdf\x0d\x5a\x9a\xd4\xd8\x1e\xdd\xb4\xa7\x44\x89\x98\x36\x5f\xba\xee\x6b\x38\xa5\xee\x77\x74\x73\x90\x6e\x5c\x8a\x38\x2d\x60\x0b\xa0\xca\x35\x80\x81\xd3\x21\xfe\xac\xc5\xb5\xd6\xe3\x5c\x2b\xe2\xbb\x8a\x78\x88\x08\x0b\xff\x6c\x94\xa6\xa5\x92\x6d\x41\x13\xdd\x23\x3d\xaa\x8d\x19\x37\x29\x2f\x7c\x71\x9a\x1a\x6c\x4d\xad\xec\xb4\x9a\x7e\x6f\xb5\x83\xe5\xa5\xa7\xfc\x0f\x0a\xc6\xab\x54\xc7\x30\xaf\xf1\xd7\x34\x9e\x6d\x19\x63\x35\xc9\x9d\x1f\xab\x61\xba\xb8\xe7\xda\x8a\xbd\xa9\xd4\x6d\x4a\x5a\x7d\x4f\x07\xfb\x6c\x0a\xeb\xdb\x6e\xc5\xae\xb5\x89\x61\xd0\x6d\x51\xef\xd7\xa1\x66\x3f\x5c\xcb\xc8\x4c\xee\x55\xaf\xbc\xd6\x37\x72\x45\x69\xfd\xca\x3e\xa6\x2c\x5e\xbf\x57\x6f\x71\x67\x9b\x83\xed\x9b\x46\x88\xac\xe7\xa2\x8a\xbd\x0c\xf8\xf9\x9a\x80\xf5\xa3\xd7\xbb\xd9\x5d\xcc\x9e\xd7\x14\xa1\xa6\xac\x6d\x09\xb8\xf4\xaa\x73\x50\x22\xe8\x02\x1f\x96\x09\x2f\x15\xf6\x60\x9c\x30\xbd\xcb\x93\xa1\x80\x12\x57\x82\xdf\x91\x49\xb2\x90\xe1\xe5\xde\x12\x14\x62\x04\xbc\x81\x59\x58\x78\x9c\x63\xf9\xf3\x24\x80\x9c\x4a\x6e\x7c\x44\xc2\x3f\x4f\x1b\x0a\xdb\x2e\x42\x89\xe3\x14\xfc\xe5\x19\x15\xfe\xd8\xd7\xaf\x5a\x3f\x4c\xd0\xaf\x86\xa6\x00\x3c\xeb\xcc\x92\x9f\x04\xf2\x67\x79\xe3\xf3\x4c\x5c\x65\x86\xd7\x4e\xfe\xfa\xfa\xbd\xc7\xd7\x32\x81\x25\xa8\x30\xbe\x7e\xff\xb7\xf9\xbf\x7e\x78\xfd\xed\x9b\x7f\x03\x67\xb8\x36\xee\x94\x00\x6b\x63\x5e\x25\x1f\xf2\xe7\x32\xa2\xdb\xc0\x12\xe6\x20\xaf\x15\xd8\x7b\xba\xf3\xe7\xfd\x5c\xe0\xdd\x4e\xce\xa9\x76\x61\x08\x32\x1f\x57\x32\xc3\xc1\x7a\x4f\x0d\xdf\xa0\xd9\x95\xf8\x73\x3d\xda\x6b\x9e\xe4\xb5\x3b\x6a\x76\x95\x20\x46\x9a\x86\x54\xab\xce\x9a\x95\x89\x1d\x1b\xb4\xef\x3a\xdc\x0f\xce\xaa\x8c\x8e\x3b\x74\x02\x6b\x1c\x7d\x1f\xd8\x39\x28\xc1\x73\x6c\x27\x56\x56\xb7\xa0\x7f\xa4\x3b\xea\x38\x1e\xaa\x68\x28\xe2\x12\x91\x65\x69\x72\x2b\xa2\x0c\x92\xac\x22\x83\x07\x08\xc4\x05\x29\x14\x66\x86\x6b\x89\x39\xa2\x4a\x39\x45\x3c\x2c\x29\xd4\xac\x39\xea\x46\xf4\xf6\x2a\x18\xa7\x51\xba\x4b\x44\xf1\xd4\xb7\x9a\xf4\x18\x07\x41\xc5\xfc\xc7\x84\xc7\x64\xf1\xc6\x43\x72\xb9\xe9\xa6\xfc\x95\x87\x67\x77\x3d\x68\x24\xca\xf5\xee\x5a\x5f\x93\xb8\xa9\x8a\x74\x47\x55\xb2\x21\xf1\x01\x95\x2e\x37\xb4\x41\x1b\x22\x04\x33\xf5\xf8\x63\xe6\xcd\x74\x75\x51\xb9\x7f\x9b\x4c\x00\xcd\xda\xbd\x85\x52\x6d\x0a\x27\x14\xff\x59\xc6\x89\xc0\x2e\x7e\xac\xd3\x21\x3a\xbe\xc0\x5a\x91\x0e\x21\x62\xd3\x88\xc6\x1f\x3b\x6c\x54\x15\x9b\x53\x42\xdb\x96\xa5\x96\x26\x0e\x83\x34\xf1\x8e\x1d\x87\x0c\x14\x57\x15\x4d\xf4\x90\x15\xa4\x91\xea\x83\x87\x14\xb8\x1c\x92\x2b\xcc\x40\xd5\xc2\xc5\xd0\x72\xb9\xa7\xa7\xfb\xc0\x75\x74\x7c\x43\xc9\xd9\xaa\x71\x85\x8f\x9c\x96\xef\x8d\xe4\x36\x1e\x5d\x9d\x8e\x08\x68\x84\xae\x47\xa5\x61\x16\xa9\x1f\x7f\x78\xf3\x0d\xa8\x5a\x96\x42\x8e\xe8\xdb\x50\x4c\x27\x99\x23\xfd\x83\x91\x21\xef\x85\xd3\xe8\xe2\x01\xe7\x28\x03\x78\xf6\xb9\x29\xc6\x43\x2e\x2c\xf6\xeb\xed\xc9\x36\x5d\x04\xbe\x1a\x0a\xbe\x1d\xec\x11\xb4\x77\x7f\x06\x46\x69\x0e\x40\xf4\xa7\x6d\xd5\x3a\x6b\xa3\x58\xf1\xc0\xd2\xba\xd8\x75\x3a\x97\x0d\x6e\x69\xc4\x85\x74\x98\x4d\xd9\x26\xe6\x73\x04\x9a\xcf\xad\x6d\xe8\xb8\x63\x1b\x33\x7a\x51\xa9\x5d\x14\x27\x45\x07\x2c\xca\xc6\x6f\xbd\xa6\x8a\xaf\x76\xfe\x66\x7f\x15\xe2\x70\x1b\xb5\x45\x1b\x59\x2e\x2c\x72\x97\x11\xcb\x40\xad\xfd\xfd\xf0\x39\x3f\xc3\x90\x32\xda\xf6\xa7\xee\x70\xdb\x26\xb0\xc3\xd8\xa6\xcd\xd8\xc6\x32\xa6\x6b\x33\x62\x8b\x71\x3f\x09\x14\x1e\x56\x36\x2f\x05\x38\x08\x97\x88\x90\xc8\xeb\x4b\x86\x8c\x74\x69\x91\xda\xea\xed\x7e\xd0\x87\xd7\xb9\x0c\xe0\x60\x96\xdd\x98\xa5\xc5\xec\x54\x7d\xfd\xb8\xcd\xad\x6a\x07\x71\x3c\x14\x97\xdd\xb8\x2f\x2d\x6e\x53\x33\xba\x88\x49\x0b\x1c\xb3\xff\x8c\x2e\xdd\xf7\x7c\x40\x6d\x59\x70\x3c\xc8\xbe\xa0\x41\xe6\x42\xbd\x01\x82\x75\x5d\xb2\x0e\x0e\x14\x09\xbc\xc6\xaf\x1c\x40\x7d\xf8\x7c\xe0\x97\x5f\x6e\x83\x65\x9c\xe0\x59\x72\x35\x47\x13\x1e\x5a\xab\x83\xd7\x63\x6c\x06\x97\x79\x96\xae\x5e\xe9\xf4\x80\x2e\x07\x96\x63\x6a\xff\x36\x06\x40\x54\x78\x55\x6b\x5c\x77\xec\x8e\x62\xe1\x23\x97\x0a\x26\xb8\xdb\x78\x43\xbc\x05\xf8\x33\xc2\xea\xd8\x10\x76\x74\x51\xf9\x80\x11\xfe\x17\x05\x6a\xa9\xa8\xc2\xc4\x25\x6a\x21\x0c\x31\x18\xa4\x10\xab\x4b\x2c\x83\x69\x6d\x98\x6e\xe6\xfc\x8b\x2f\x7b\x63\x59\xc2\xc2\x65\x90\xe5\xf1\x2a\x86\x34\x88\xeb\x0a\x53\x42\x55\x32\xe0\x26\x2e\xb6\x41\xb0\xf0\x3c\x39\x73\x31\x2c\x00\xc3\xe2\x4b\x31\x50\x2a\x2e\x69\x5b\xb6\x0b\x37\x4b\xd2\xa2\x01\xec\xc7\xc0\x64\xf3\x58\x11\x09\x47\x44\x97\x96\x04\x30\x92\xbf\x9d\xd5\x93\xf6\xc8\x52\x6d\xae\x31\xc9\x20\xc5\x52\x18\x30\x24\xc4\xf7\x06\xff\x58\x38\x46\x75\x87\x57\xa9\x16\xfb\x0c\xbd\x35\x8e\x99\xe4\xad\x92\x01\x2c\xeb\xa6\xc4\xeb\x0d\x9f\xb0\x45\x3f\x16\xb9\xbe\xe6\x04\x99\x71\x6e\x09\xd6\x1c\xeb\x3d\x1f\x12\xbc\xcd\x38\xd9\x2a\x21\xfd\x5a\xe2\xd6\xea\x80\x4f\x89\x19\xa7\x1c\xb1\x6e\xd9\xd5\xf3\x31\x21\x13\xf0\x8b\x11\xfd\xec\x8b\x7e\x1c\xc3\x5c\x9b\x3a\x9b\x85\x81\x99\x53\xc7\xc0\x29\x86\x05\xfd\x1e\x9b\x1d\xf6\x32\xaf\xb3\x89\x7b\xf3\xa6\x8e\x76\x76\xfd\xb2\x0e\x4b\xce\x90\xb6\xfd\xb9\xaf\x4e\x06\x3d\x1e\xb7\x12\x20\xf0\x30\x46\x46\x40\x74\x1b\xeb\x62\xaa\x93\x49\xb5\xd9\x96\xb7\xcc\x87\x76\x67\x4d\xf2\x75\x7f\x16\xd6\x6e\x5a\xe9\xa3\x2d\x29\xd6\xb0\xca\xc9\xd3\xdf\xe0\x7d\x15\xa9\x6f\xab\x68\x0f\x42\x27\x2d\x35\xa3\xc3\x9f\xe2\xb1\x55\xf1\x82\x72\xca\x98\xd3\xa7\xa5\x50\x74\x80\x2b\xf0\x57\x8a\xdc\xe8\xd4\x93\x24\x1f\xd7\xb0\x14\x18\x63\x40\xbf\x66\xf4\x47\xfe\xcb\x8b\xd1\xb9\xf6\x1b\x83\x91\x2b\x8e\x6d\xed\xc0\xca\xaa\xd6\xe8\x83\x33\xe3\x89\xfe\x5d\x06\xc3\x9e\xe3\xaf\x3b\xb1\xfb\xd4\x3a\xae\xa3\xf1\xda\x51\x5d\xed\x84\xca\x68\x62\xfd\x52\xb6\xe4\xb9\x21\x1b\x86\x9b\xe4\x49\x73\x7b\xc7\x10\x6a\x5c\xfd\xa9\xa4\x3f\x47\xe5\x44\xa9\xd6\xd8\x09\xa9\xee\x1e\xb8\x59\x37\x6d\xa6\x83\x35\x89\x61\x12\xfc\x81\x29\xfa\xad\x6c\x5d\xfb\xd0\xa7\xfa\x27\x0d\x95\xa0\xe8\xf3\x19\x87\xc5\x88\xa4\x96\x7c\x57\x91\x26\x35\x6d\x69\x47\x9f\x9c\x5e\x60\xf3\xf6\x99\x1f\x43\xce\x09\xf9\xd7\x6f\x45\xea\xdc\x70\x03\x96\xc2\x75\x9c\x44\xb9\x4a\xfd\x41\xfd\x88\x07\xff\x2f\x8b\x2b\xa8\x4a\x02\xf5\xd1\xaf\x21\x1b\x38\x87\x4b\x06\xe8\xd0\x15\x19\xeb\xec\x39\xb1\x23\x86\xaa\x8b\x5c\x3d\x57\xeb\x0e\x23\xc5\x47\xfa\xb5\x78\xcd\x94\xb8\x58\xab\xb7\x8b\xdc\x82\x87\x8b\xbc\x30\xd0\x6d\x8e\x87\x4f\x09\xf4\xe9\x42\x68\x1b\x6b\x6c\x9c\x1d\xbd\x6f\x43\x94\x4f\x65\x27\x78\x5b\xb3\x71\x94\xe3\x4e\xe3\xec\xbd\x36\x87\xaf\x29\x06\xfa\xff\xab\xf0\x47\x17\x17\xa3\xd5\x50\xe0\x4f\x66\x2f\xbc\x81\x7d\x9d\xaa\x6b\xf1\x83\x5a\xbd\xbe\xd9\xfa\xb6\x85\x87\xbf\x85\xf6\x06\x04\x4b\x87\xc4\xe6\xbd\xe3\x4f\x80\xde\x54\xf7\xe3\x66\xb6\xa8\x62\xa0\xea\x67\x81\x9a\x73\x3b\x54\xbf\xb5\xdb\x10\x51\xfd\xc4\xc3\x14\x6f\x83\xea\x5c\xda\xe9\x77\x33\xe1\x41\xfb\xec\xa5\x2a\xfa\x6a\x3f\x63\xec\x12\x74\x5d\x62\xe0\x0f\xbf\x07\x7d\x06\xcf\x53\x94\xed\x1f\x23\xeb\xcb\x33\x0f\x50\x81\xa7\xba\x5c\x26\xae\x5c\x4c\xe2\x60\xc3\x64\xf3\x9c\xa8\x7c\x67\x8e\x8a\x7e\xc0\x36\xa3\x6f\x4e\x5b\x74\x77\xdf\x2a\x79\x3d\x6a\x39\x52\x84\xd0\xbc\x5a\x41\xe6\x67\xef\xb7\xf5\x9f\x3f\xeb\x6b\x72\x68\x1e\xff\x07\x2d\x68\x1a\xa0\x22\x4a\x00\x00")
+
+func webUiStaticJsPromql_editorJsBytes() ([]byte, error) {
+	return bindataRead(
+		_webUiStaticJsPromql_editorJs,
+		"web/ui/static/js/promql_editor.js",
+	)
+}
+
+func webUiStaticJsPromql_editorJs() (*asset, error) {
+	bytes, err := webUiStaticJsPromql_editorJsBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "web/ui/static/js/promql_editor.js", size: 18978, mode: os.FileMode(436), modTime: time.Unix(1791994796, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _webUiStaticJsSettingsJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xad\x56\xdd\x6f\xe3\x44\x10\x7f\xcf\x5f\x31\x32\xd5\xc9\xd6\x85\x8d\x78\x6d\x95\x87\x13\x04\x28\x08\xae\xba\x06\x09\x09\xa1\xd3\xc6\x9e\x24\xbe\xae\xbd\x66\x77\x9d\xd4\xea\xe5\x7f\x67\x66\xed\xb5\x9d\xc6\xd7\x2b\x12\x79\x70\x92\xf9\x9e\xdf\x7c\x79\xb1\x80\x7b\x74\x2e\x2f\x77\x16\xf4\x16\xdc\x1e\xe1\x88\x1b\xf8\xe3\x56\xc0\x7a\x8f\x0d\x48\x83\xf0\x80\x95\x83\xbc\xf4\x4c\xa5\x53\xa9\xc0\x3a\x6d\xe4\x0e\x83\xc6\xc6\xe8\xa3\x45\x33\x9f\x2d\x16\x60\x35\x91\xa4\x63\x3a\x69\x57\x95\x6a\xc0\xe4\xbb\xbd\x03\x79\x94\xcd\x1c\x64\x99\x81\x6d\xca\x14\x33\x38\xe6\x6e\xef\xd5\x49\xf5\x40\xda\x67\xaa\x6c\x6a\xab\x95\xd2\x47\x2f\x52\x93\x0c\xc8\xd4\x68\x6b\x83\x37\x2b\x66\x07\x69\xe0\xce\xe8\x02\x49\xa4\xb6\xb0\x1c\xff\xf9\xfc\x19\x9e\x4e\x37\xb3\xd9\x40\x12\x7d\xa2\x4b\x78\x9a\x41\x48\xe2\x57\x6c\xae\x21\xaa\x06\x31\xdb\x89\x45\x73\x12\xca\x70\x2b\x6b\xe5\xec\x35\x3c\x11\xb7\x40\x12\x55\x9c\x4e\x34\x87\x7f\x6a\x34\xcd\x3a\x2f\x50\xd7\x8e\xc8\x44\x51\x5a\x3f\x6c\x64\xfa\xc0\xff\x4e\xf3\x19\xa9\xef\x90\x58\xdb\xba\x4c\x5d\xae\xcb\x38\xf1\x7e\x01\x38\x6e\x3b\x04\x73\x25\xf0\xd1\x61\x99\xc5\x4f\xa7\x39\x4c\xc4\x2b\x42\x10\xc9\x8d\x57\x77\xa6\xe9\x0c\xc1\xa0\x1b\xec\xcd\xe1\x97\xfb\xf7\xbf\x8b\x4a\x1a\x8b\xb1\xaf\xd6\x7d\x9b\xa7\xa0\x58\x6e\x1d\x16\xf1\x94\x87\x01\x8b\x24\xe9\xbc\x9c\x20\x95\x2e\xdd\x43\x8c\x49\xef\x8d\x8a\xf2\x5b\x6e\x2d\xa9\x80\x36\x50\x48\xb5\xd5\xa6\xa0\x52\xf6\xc9\x28\x94\x07\xf4\x15\x0b\x31\x8b\xd6\x98\x7f\x1a\x74\xb5\x29\x7b\x69\xf6\xd3\xc2\xc4\xee\x71\x04\x54\x90\x08\x9e\xc7\x19\x9f\xe5\x64\x5f\x95\x53\x87\x89\x75\x86\x18\xf9\xb6\x19\xec\xbf\x9c\xeb\xfa\xa2\xe1\x0b\xd9\xc0\x86\xda\xb1\x94\x07\x99\x2b\xb9\x51\x38\x07\x42\x56\xf0\x78\x54\x26\x3f\x48\xd7\x4d\x03\x59\x1f\x67\x3e\x15\x9f\x1f\x8e\x21\x94\x01\x0d\xcf\x78\x01\x8d\xab\x38\xd3\x69\x5d\x60\xe9\x44\xf8\xb1\x52\xc8\x5f\x89\x70\x7a\xb7\x53\xf8\xbd\x92\xd6\xc6\x91\xef\xd8\x6f\x33\x69\x1e\xa8\x39\x83\x15\xe1\xa9\xb0\x5c\x2e\x21\xf2\xac\x91\x67\x9e\x5f\x1a\x4e\x2a\x54\xa5\x64\x8a\x76\x3c\xf2\xa1\xc4\x9b\xc6\x53\x75\x89\xfd\xc6\x68\x07\x98\xf3\x65\xed\x89\x86\xbf\x12\xf2\x93\x7c\x8c\x03\xb4\x0c\x85\xce\x68\x4a\x7e\x5a\xad\xfd\x94\xf1\xa7\x36\xea\x1a\xee\xde\xad\x7f\xfe\x78\xf7\x61\xf5\xe3\xed\x9f\xf0\x16\xa2\x85\xac\xf2\xc5\xe1\xbb\xc5\xd9\x48\xf2\x27\x93\x4e\xae\x9b\x8a\xc7\xf1\x93\xd5\x65\x4f\xb7\x75\x4a\x61\xdb\x51\x08\xcc\x1e\x8a\x0a\x90\x6f\xc1\xd3\xa8\x1b\xa4\xe3\xa5\xc1\x38\x74\x6a\xd1\x58\x70\xba\x68\xbe\x53\x5b\x03\x1c\x43\xd7\x3f\x43\xa1\xc3\xf7\x69\x84\xaa\xa5\x99\x98\x28\xe7\x3c\x44\x4b\x2d\x64\x8c\x36\x5f\x03\xeb\xee\xfd\xfd\xff\x8c\x16\xd3\xaf\xfb\xca\xbe\x1e\xc3\xff\x06\x4c\x67\x2f\xee\x49\xa7\xe0\xc9\xa7\x3d\xf2\xf3\xb8\x37\x63\x37\xbc\x26\x0b\xbb\xa3\x0d\x19\xad\x58\x92\x81\xe4\xd5\xd3\xe7\x77\x73\x56\x55\xd2\x16\x06\x6d\xa5\x4b\x8b\x3c\xef\xf0\xe6\x0d\x3c\xa7\x89\x33\xa4\x3b\x80\xc9\xc5\x5b\xf2\x41\xe8\x10\x8a\xd3\x1a\x97\x65\xee\xa2\x8f\x49\x7b\x48\x6c\x5c\xfa\x19\x5f\x1f\x1a\xa8\x77\xfe\x06\x4e\x0d\x12\xd2\xf6\x6c\x97\x65\xc5\xbb\x25\xb7\x34\x77\x65\x86\x06\x33\x31\xfb\xf2\xc2\x98\xe2\xd0\x6a\x8f\x79\x97\xcd\xae\xe2\x67\xb3\xe7\x31\xd4\x19\xf9\xa5\x3b\x13\x47\xdf\x04\xef\x1f\x3d\xb1\x9d\x7d\x96\xe1\x45\x4e\x22\x9e\x2a\xb6\x39\x1d\x94\x88\x49\x83\x80\xcf\x76\xa5\x9e\xc9\x08\xa9\xd0\x38\x5a\x31\xe5\x0e\x0d\x0b\x93\x74\xcb\xa7\x08\x22\xbb\xd7\x47\xb1\xb1\xa2\xf5\x35\xff\xea\x21\xfc\x62\x6a\x2d\xc0\x1c\x50\xe7\xd7\xa2\xc2\xd4\xfd\x55\xca\x02\x97\x7e\x9f\xfd\x1d\x25\xe2\x20\x55\x7c\xbe\xe5\x2e\x15\xf3\xb2\xaa\x3b\xbd\xf1\xfd\xbe\x50\x1f\x33\x5f\xb4\x12\x6e\xfe\x85\x85\xc0\xe8\xb4\x3b\xfc\xc4\x3e\xcf\xb0\x4d\xe8\xd4\xc2\xe5\xcd\xda\x7a\x53\xe4\x6e\x28\x5e\x7f\x87\x50\x54\x06\x0f\xb4\xda\x7f\x68\xef\x69\xc0\x62\x72\x04\x69\xcf\xf4\x6b\xa3\x7b\x5b\x79\x0d\x66\x49\x98\xc7\xf3\x37\x9a\x2b\x41\xf7\xb2\x88\x5f\x0f\x5e\xd2\x1b\x1a\x5e\x84\x5e\x34\xf2\x1c\xbb\x24\x69\x07\x68\xa2\x53\x42\x5b\xf9\x67\x1c\x31\x8a\x51\x38\xde\x23\x71\x9e\xc6\x5e\x23\x40\xee\xe8\x15\xc9\x73\x04\x37\x64\x40\xf0\x34\x2e\xc2\x24\x9c\x74\xcb\x58\x98\x45\xfe\x05\xf4\x91\xba\xe1\x27\x0b\x00\x00")
+
+func webUiStaticJsSettingsJsBytes() ([]byte, error) {
+	return bindataRead(
+		_webUiStaticJsSettingsJs,
+		"web/ui/static/js/settings.js",
+	)
+}
+
+func webUiStaticJsSettingsJs() (*asset, error) {
+	bytes, err := webUiStaticJsSettingsJsBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "web/ui/static/js/settings.js", size: 2855, mode: os.FileMode(436), modTime: time.Unix(1791993733, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _webUiStaticJsStorageJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9d\x56\x6d\x6f\xdb\x36\x10\xfe\xee\x5f\x41\xa8\x46\x21\x61\x86\xe2\x74\x0b\xb0\x39\x4d\x81\x36\x4d\xd6\x6e\x69\x16\x24\xc6\xb0\x22\x08\x0c\x5a\x3a\x4b\x6c\x25\x52\xa0\x28\xc7\x86\xe1\xff\xbe\x23\xa9\x17\xca\x76\x92\xa2\xfa\x60\xcb\xc7\xbb\xe7\xee\xb9\x37\x7a\x49\x25\x89\xd2\x4a\xf2\x3f\x25\x2d\xd2\xd3\xc1\x60\x51\xf1\x48\x31\xc1\xc9\x82\x65\xd9\x94\xce\x33\xf0\x4b\xc8\x20\x52\x42\x8e\x08\x70\x25\x19\x94\x01\xd9\x0c\x08\x3e\x4b\x34\x56\x73\x11\xaf\xc9\x19\x19\xb6\x6a\xe4\x17\xe2\x59\xb1\x17\x84\x90\x17\x6a\xed\x07\xa7\x46\x9f\x2d\x88\x5f\x43\x84\x19\xf0\x44\xa5\xe4\xec\xec\x8c\x8c\x1b\x3c\xfd\x18\xc3\x90\x16\x05\xf0\xd8\x1f\xfa\xde\x5b\x25\xdf\x21\x8e\x2b\x88\x8d\x40\x29\xe9\x7b\x91\xc8\xca\x82\x72\x6f\x44\xde\x04\xa1\x82\x95\xf2\xbd\x6b\x41\x4a\x30\x2e\xbc\x20\xa8\x1d\xeb\x47\x82\x42\x9a\xf6\xf7\xd6\x7c\x0e\x43\xa0\x51\xda\x44\x34\x22\x0d\x75\x9f\x21\xd3\x1f\x8f\xa9\x55\x33\x98\x6d\x80\x26\x1a\x08\x39\xcd\x21\x18\x3d\xaf\xb3\xa4\x59\x05\x41\xab\xd3\x44\xbd\xc5\xef\xad\x53\x11\x89\xde\x40\x9e\xeb\x6a\xf9\xa6\x66\xbd\x3a\x88\x1b\xc1\xb8\x2a\xb1\x14\x2d\x8f\xef\xb0\x76\x69\xd8\x0c\x20\xed\x9c\x16\x16\xc0\xe1\xdc\x23\xec\x68\x6f\x56\x13\xc2\xe1\x91\x7c\xa4\x0a\x30\x54\xc5\x90\x4e\x98\x80\x9a\xe2\x8b\x1f\x90\x23\x72\x3c\x1e\x8f\x47\x64\x3d\x21\x70\x8f\xfe\x1e\xb6\x5d\xc6\xb7\x0d\x8f\xd3\x36\x4a\x5b\x19\x8c\xf1\xbe\xd5\xda\xe8\x0c\x4d\x88\x77\x2e\x01\x5d\xc4\x58\x4a\x2c\xaa\x90\x28\x79\x75\x12\xcd\x7f\x3f\x89\x50\x12\x53\x45\x27\x2d\x45\x2c\x7b\xad\x1b\x6c\x47\x7b\x38\xb7\x90\x8b\x65\x1f\x27\xfe\xe3\xe4\xd7\xdf\x16\x07\x70\x64\xad\x1b\xd8\x86\x78\xc0\xfe\x6f\xfa\xb4\x1b\x0a\x37\x31\x9d\x34\xb4\x54\xee\xc7\x0f\xa1\x46\x45\x4a\x7d\xc1\xe9\x33\x36\xc7\xbb\x36\xc7\xcf\xd8\x54\x45\xac\x53\xff\x64\x27\x0f\xfa\xfa\x08\xaa\xcb\x75\xcb\xa2\xef\x65\x4a\x1f\x43\x23\xf4\x3b\x06\x38\xa4\x39\x76\xfc\x84\xc4\x22\xaa\xf4\x9b\x2e\xe6\x85\x15\x7e\x58\x7f\x8e\x31\xb9\x1a\x6b\x16\xa5\x54\x2a\xcf\xe9\xdc\x14\x58\x92\xa2\xdd\x1b\x2c\xb7\x13\x8a\x6e\x49\xd0\x59\xce\x18\x07\xaf\x3b\xc1\x04\x83\x2c\x44\x46\x75\x77\xd5\xc7\x54\x3a\x0a\x96\xfa\xa4\xfe\x1e\xb8\x0d\xb3\x4f\x20\x7c\xbf\x62\x65\x68\x7a\x6e\x93\x68\xc1\xc4\xa1\xfc\x92\xd9\xd7\x9f\xa0\xbf\x9e\x51\x34\x75\xf9\xef\xb9\xed\x8e\x04\x32\xe0\xaa\x65\x0a\x0b\xe5\xf0\x54\x18\xd1\xa5\x90\x39\x45\xa7\x6d\x74\x97\x6c\x85\x35\xc4\x1d\x75\x5d\xe5\x73\x90\xe1\xc2\x28\xfc\xfd\xe5\xc3\xf4\xa5\x44\x5c\x41\xa2\x97\xce\x7e\x16\x46\x3f\xcc\x2d\x33\x10\xd8\xf4\x4f\x7b\xf9\x84\x63\x21\x3f\x82\xa2\x2c\x7b\x26\xe1\x4e\x97\xda\x46\xf0\x77\x36\x56\x26\x68\x7c\x87\x89\x29\xfd\x66\x88\x86\x21\xfd\x46\x57\x4e\x41\x2a\x99\x4d\xc8\xcd\xfb\xe9\xa7\xd9\xcd\xed\xc5\xe5\xe7\xff\xf4\xed\x71\x44\x0b\x76\xb4\x3c\x3e\x2a\xd1\xb4\x2a\xf1\x4b\x48\x9a\xb8\xcd\x65\xe7\x78\x93\xb1\x9c\x21\x5d\xdc\xa7\xaf\x6a\x9d\x99\x11\xe1\x66\xc5\x95\xea\xbb\xdb\x41\x5b\x4c\xd7\x85\x5e\x10\xdf\x4a\xc1\xdd\x3e\xac\xa2\x08\x4a\x6c\xc4\x76\x15\x6a\x85\xdd\x6d\x68\x96\x97\x66\x82\xf3\xa5\xcf\x77\xe6\xb5\x5e\xeb\x6d\x18\x20\xa5\x90\x18\x46\xca\xe2\xde\xe8\x36\x7a\xbc\xca\x67\xb6\xf1\x9b\x5b\xc0\x80\x87\x28\xbf\x33\xe2\x1d\x9b\xee\x32\x46\x1f\x46\x61\x36\x5f\xcf\x72\xc0\x9b\x2b\x9a\xe9\xc5\x87\xcb\xcd\x22\xd8\xd3\x73\x51\xe9\x9a\x7f\x31\x0a\xd7\xfa\x0a\x7a\x1a\xcf\xdc\x3e\x06\x2f\xa3\x73\xc8\xfa\x70\x46\xf4\xaf\xd6\xa8\x21\xaf\xb4\xe0\x05\xc4\x2e\x42\x8b\x58\x50\x26\x0f\x07\x78\xd5\xc2\xdf\xa0\xce\x0e\xa4\x7b\xdf\x59\x5b\x7b\xeb\x39\x37\x4c\x57\x46\x93\x71\xa7\x88\xab\x54\x1e\xaa\x61\x5e\x26\x58\x41\x3c\x0c\x6d\x73\x4d\x31\xf7\x7d\xaf\x7a\xf9\xeb\x73\x9c\xce\x42\xf0\x12\xfe\xba\xfb\xe7\x9a\xbc\x7e\x4d\x76\x65\xa1\xf1\xb8\xeb\x43\x3f\x9d\x8f\x7d\xfd\xbe\xaf\xed\x8b\x0d\x64\xff\xd3\x5c\xe8\x5f\x66\x9a\x18\x4f\x48\xad\x63\xf2\xc9\x4a\xdc\x2f\xd8\xbc\x1e\xce\x0d\xfa\x0d\xc2\x32\x15\x8f\x6e\xc7\x6d\x0f\xfe\x95\x60\x9c\xa9\x6e\x26\x0f\xcc\x0f\xae\x7f\x9e\x80\xdf\x0e\x70\x8d\xe8\x0c\xb4\x01\x1c\xfa\x1a\x09\xdf\xff\x07\x07\xd2\xfe\x97\x48\x0a\x00\x00")
+
+func webUiStaticJsStorageJsBytes() ([]byte, error) {
+	return bindataRead(
+		_webUiStaticJsStorageJs,
+		"web/ui/static/js/storage.js",
+	)
+}
+
+func webUiStaticJsStorageJs() (*asset, error) {
+	bytes, err := webUiStaticJsStorageJsBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "web/ui/static/js/storage.js", size: 2632, mode: os.FileMode(436), modTime: time.Unix(1791983405, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _webUiStaticJsTargetsJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbd\x19\xfd\x6f\xdb\xb8\xf5\xf7\xfc\x15\xac\xd6\x35\xd2\x62\xcb\xce\x1d\x70\x03\xec\xa4\x45\xd7\x4b\x6f\xd9\x7a\x6d\xd6\xe4\x80\x6d\xb9\x2c\xa0\x25\xda\x56\x22\x8b\x1a\x45\x39\x09\xee\xf2\xbf\xef\x3d\x7e\x48\xa4\x24\xa7\xb9\x1e\x30\x15\x68\x24\xf2\xf1\x7d\x7f\xd2\x5b\x2a\xc8\xc5\xdb\xcf\x3f\x9c\x5c\x9c\x5f\x9f\xbd\xfd\xe1\xe4\xfa\xfc\xf4\xdf\x27\xe4\x98\x1c\x4e\xa7\xf3\xbd\xbd\x2d\xec\x4a\x2a\x56\x4c\x56\xe7\x92\x4a\x06\x1b\xbf\xec\x11\x78\xcc\xe2\x8c\x5c\x5e\x8d\xe0\x73\x32\x21\x17\x7a\x85\x08\x26\x6b\x51\xb0\x94\x2c\x1e\x88\x5c\x33\xf2\xf6\xec\x94\x2c\xb9\x50\xef\x49\x2d\x04\x2b\x24\x29\x39\xcf\x09\x2d\x52\x52\x21\xd2\x58\x61\x5c\x66\xb9\x64\x82\xa5\x06\xa5\x83\x71\x43\x65\xb2\xce\x8a\x95\x42\x51\x31\x2a\x92\x35\x1c\x14\xb0\xa2\x4f\x96\x74\xc5\x66\x64\xba\xf7\x08\x0c\x2f\xeb\x22\x91\x19\x2f\xc8\x9a\xd1\x5c\xae\x2f\xf8\xbb\x9c\x56\x55\xa8\xbf\x22\xc3\x7c\x75\x97\x01\x46\xd2\x59\x4d\x68\xc5\x48\x50\x97\xc1\x4c\x7d\xe2\xa3\x65\x21\x41\x55\x27\x09\xab\xaa\x60\xee\x02\x16\xb7\x05\xbf\x2b\x06\xa0\xef\xa8\x28\x80\x39\x03\x9d\xb2\x25\xad\x73\xd9\x07\x4b\x69\xb1\x62\xc2\x40\x3d\xee\x3d\x3a\xcc\x83\xbe\x40\xe8\xf3\xac\x48\x58\x08\xfc\xcb\xf3\x44\xd0\x92\x59\x46\x95\x51\xc0\x12\x05\xbb\x23\xdf\x83\xfe\x5c\x10\x8d\x2d\x5b\x92\x30\xab\x3e\xd2\x8f\xa1\x8c\x41\x85\x17\xd9\x86\x85\x51\x44\x7e\xfd\x95\xa8\xef\xf7\x75\x9e\xff\x0b\xf4\x18\x46\xe4\x08\x2c\x6d\xf1\xba\xcc\x7d\x64\x5b\x87\x37\x4b\xb5\x62\x49\x05\x84\x7f\xa4\x72\x1d\x6f\xe8\x7d\x38\x1d\x91\x10\x39\x88\x41\x13\x80\x6c\x4c\x3c\x72\x13\x74\xa2\xa9\xc3\x92\x3a\x7e\x44\xbe\x9b\x0e\x50\xc4\xbd\x58\xf2\xf7\xd9\x3d\x4b\xc3\x6f\x23\x72\x00\x5a\x27\x74\xc5\x3d\x1e\x1c\x24\xdf\x7e\x37\x1d\x42\xa3\x58\x5b\xe6\x9c\x0b\x0d\x38\x51\xd4\x00\xd9\x86\x04\xf0\xa7\xbb\xfd\x47\xbb\xdd\xa7\xb5\x13\xa1\xa6\x0c\x67\xd6\x5d\x94\x16\xa7\x86\x70\x48\x6b\xdc\x60\x61\xf0\xea\x55\xce\x17\x34\xff\xe9\xf3\x07\xa0\x50\xe6\x14\xfc\x8a\xe4\x3c\xa1\x39\x59\xf3\x4a\x16\x74\x03\xdf\x59\xa1\x5d\x5d\x99\x94\x20\x28\x38\xec\x5a\xad\x21\x90\x7a\xf9\xe9\x14\x91\x65\xc0\xb7\x72\x4d\x88\x37\x5e\x8c\x48\xc5\x61\x93\x4a\x92\x67\xc5\x6d\x45\xee\xb8\xb8\x25\x4b\xc1\x37\x84\xc3\x11\x01\x81\x84\x71\xc4\xaa\xb8\xf5\xb4\x86\x9b\x50\x53\x83\x37\xd7\xcd\x28\x58\x3b\xe5\x49\xbd\x81\xa8\x8d\x13\xc1\xc0\xd6\x27\x39\xc3\xaf\x30\xa0\x81\x31\x2d\x8d\xd7\x82\x2d\x01\xb2\x41\xd1\x9a\x1c\xf6\x8c\x58\xe4\xf8\xf8\x98\x04\x4a\x54\x5c\x0a\xd0\x1b\xbb\xbb\x87\xdf\xfc\x39\x9e\xc2\xbf\xc3\xc1\xdd\xcb\xd9\xec\xf0\x2a\x70\x8d\xee\x42\x80\x8e\x8a\x94\xdf\xc5\x48\x01\x45\x6b\xb6\x06\x8c\xaa\x39\x9e\x7b\x31\x97\xd3\x05\xcb\xcf\x4b\x5a\x84\x78\x68\x04\xe2\xe7\x75\x13\x72\xe6\xdc\xcb\x30\x38\xaa\x00\xe4\x75\x10\xc5\x34\x4d\x75\x6e\x09\xd4\x49\x7d\x7e\x5c\x8a\x6c\x43\xc5\x03\xec\x4b\x76\x2f\x15\x2a\x70\x81\xfd\xe3\x60\x1f\xfe\x28\x94\xf8\x19\xec\x47\x3e\xf1\x8a\x0b\xc9\xd2\xbf\xb3\x87\x2a\xe4\x8b\x9b\x0e\xd5\x4f\x8b\x1b\x96\xc8\xf8\xd6\xee\xc6\x08\x1d\x76\x30\xa8\x24\xc9\xaa\x73\x95\x1e\x43\x9d\x9e\x47\x26\x5b\x5a\x7c\x3a\x7c\x54\xfe\x54\x0a\x0d\x06\x02\x48\x8a\x9a\x75\x43\x4e\x63\x8b\x8d\x79\x45\x0e\x71\xfa\x81\xdf\x31\xf1\x0e\x12\x61\x18\xc5\xa0\x77\x76\xff\x69\x19\x5a\x62\x2f\x00\xf7\xf8\xf0\x19\xb8\xb1\x2c\x84\xe8\x66\x4a\x4d\xe8\xf5\x9a\x90\x52\x65\xe5\x22\x40\xa0\x92\x66\x02\xb3\x9e\xa7\x52\xef\xc4\x25\xee\x5d\x69\x05\xcf\x9b\xb3\x28\x01\x9e\xfd\xcd\x5c\x0f\x72\xde\x72\xef\x79\xd4\x92\xe6\x15\xf3\x2d\x02\x95\x2e\x65\x42\x17\x31\xa3\x42\x2f\x83\xa3\x30\xe8\x50\x52\xbc\xc6\x48\xfa\xca\x98\xeb\xda\x66\xde\xe0\x01\xf2\x25\xcf\x0a\x69\xc9\xa4\xca\x6b\xcb\x12\xd6\xc3\x46\x14\xdc\xa1\x6a\x43\x4a\x11\x06\x88\x34\x18\x39\x59\xa1\x8b\x3e\x32\x8e\x4d\xe3\x52\x70\xc9\x13\xa8\xe3\x90\xe1\x26\x13\xcc\x84\x3a\x1a\xd5\x4b\x09\x49\x11\x8d\x11\x8d\x1a\x4a\xc1\xd1\x02\x04\x55\x9f\x51\xcb\x64\x49\x05\xdd\x60\x4d\xa1\xb1\x36\x44\x6c\xb2\x62\x38\xf9\xcf\xcf\x6f\x26\x23\x74\xd2\x36\x9b\x18\xe8\x17\x3d\xe7\x7d\x19\x33\xc8\x6d\x66\x3f\xae\xca\x3c\x03\x65\xbd\x0a\xa2\x11\xb1\xe6\x08\xb3\x11\x29\xbb\xd6\x45\x0e\x6e\xb7\x40\xbd\xb4\x67\x8e\x2d\x39\xfb\x58\x2d\x5a\xcd\xb5\x69\x22\x65\x09\x4f\x21\xdb\x9d\xbe\xe3\x9b\x92\x17\x68\x9f\xdb\xed\xe5\xf4\x0a\x88\x0e\x6e\xc5\x55\x9e\x81\x5c\x87\x51\x7c\x03\x08\x15\x29\x78\x1c\xaf\x8a\xdc\xd8\x90\xc2\x52\xb4\x1c\x58\x1f\x69\x77\x5a\xb3\x2a\x9b\x78\xb6\x3a\x83\x16\x2b\x7a\xea\xc8\x90\x27\xf4\x73\x1b\xcd\x99\x90\x44\xfd\x3f\x46\x1b\xfb\x6d\x95\xa1\x68\xfb\x28\xf0\x04\xdd\xd1\x5d\x43\x70\x65\x90\x86\xb1\xed\x03\xce\xc6\x35\xd0\x12\xd8\x36\x75\x58\x35\x07\xb5\x53\xb8\x31\x90\x66\x55\xc2\xb7\xd8\x0e\xa2\xf7\x42\xaf\x51\x86\x4e\x8a\x34\xa7\x5b\xa0\x0f\x3a\x61\x38\xd6\x56\xce\xd7\xcf\x40\x83\xc9\xa3\x8b\xa7\x9f\x46\x1e\x1d\x8f\xd5\xa9\xc6\x04\x55\x5f\x61\xd0\xe2\x02\xa7\x63\x65\x31\xe8\xa0\x6c\x60\xa5\x54\xd2\xb1\xe4\xab\x55\xce\x20\xbe\x02\x09\xd6\x91\x59\xd9\x6c\xcb\x4c\xea\x8d\xbf\x30\x48\x8a\x0c\xd8\x55\x64\xa0\x8b\x9c\xa9\x26\xa3\x65\xd1\x38\x0f\x80\x46\x0e\x53\xba\x6f\x40\x55\xad\x20\x7e\x06\x74\x95\xef\xd2\x90\xa7\x19\x15\x58\x37\x7c\x11\xcc\x1b\x91\x31\xec\x14\xf6\x38\x67\xc5\x4a\xea\xca\xe1\x75\x5e\x1a\xb5\xeb\x60\x4f\x97\x48\xd3\x10\x5b\x57\x08\x0a\x08\x90\x46\x18\xed\xfc\x26\x9c\x15\x5d\x3f\x84\xbb\x76\xf5\x89\x77\x6b\xf8\x40\x75\x00\x15\x04\xc4\x06\xb9\x15\xf2\x89\xf8\x30\x8a\xfb\x62\xf0\xb9\x2d\x7b\x43\xb6\x69\xcb\xa3\x27\x08\x79\xb1\xcb\x44\xc6\xaa\xb7\x69\xca\x52\x2f\x1e\x10\xd5\x89\x10\x5c\xb8\xc9\x7c\xde\x2d\xd0\x0d\x94\xaf\x21\xb3\xf8\xb4\x85\xdc\x40\xd7\x93\x49\x37\x94\x3b\xac\xb6\xc4\x76\xe4\xad\x16\xc0\x08\xd2\xd4\xd2\xdd\x75\xb2\x0a\xdd\x12\x69\x7b\x15\x14\xf9\x0f\x66\xe2\xbc\xd6\x8b\xc0\x0c\x74\x53\x50\xc6\xbd\xa2\x3e\x77\x67\x53\x35\xb0\xc6\x76\xac\x6c\x83\xc3\xdb\x36\x1f\x8e\x93\x49\x27\x28\x3a\x4d\x55\xd3\x4f\xe9\xf0\x70\x8a\xd8\x8a\x79\x73\xd1\xe1\x48\xbf\x27\x2c\xcb\xc3\x41\x76\x6c\x34\x4d\xfa\xc3\x77\x34\x24\x06\x92\x68\x28\x40\x02\xe8\x6d\x8e\x0c\x17\x63\x98\xe7\x1c\xd6\xc0\x86\x42\x36\x6d\x82\x8b\xec\x4f\x7d\xca\x73\x4f\xa2\xee\xa9\x86\x75\x5d\xc7\x14\xe6\x91\x21\x70\x30\x20\x86\xc3\x85\x5c\xf0\xf4\xa1\x63\x47\x49\x17\x39\xd3\x3b\x60\x4c\xb6\x29\xe5\x83\x35\x60\x53\xcd\x51\x2c\x37\xfa\xfd\x2e\x4a\x29\x09\xcf\x5b\x8f\x1b\xea\xb8\xba\x91\xae\xe0\x97\xe0\xd4\xe1\xfe\xa5\x93\x94\x8f\x9b\x94\x7c\xb5\x8f\x4e\xa5\xde\x43\x57\x86\xba\xd0\xd5\xea\x61\x87\x27\x59\xf5\xec\x70\x25\x5b\xec\x74\x8e\xad\x4b\x95\x62\x8d\x17\x18\xa9\x5d\x27\xaf\x37\xee\x18\xd1\x36\x51\xe7\x6b\x7e\x87\x57\x21\x41\x53\xbe\x76\x38\x16\xd6\x62\xbe\xec\xc3\x99\x0f\x17\xcc\x2c\x91\x10\x81\x5b\x31\x71\xab\xe0\x92\xd4\x65\x14\x7b\x7d\x9b\xcb\x28\xda\xa8\xc9\xe4\x67\xe8\x35\x88\xa4\xef\x9e\xb0\x78\x18\xb9\x4c\x29\x6f\x1d\x42\x28\xd8\x16\x10\x42\x27\x87\x5d\x13\x9a\x02\xcd\x63\x92\x14\x94\x41\xf4\x9a\x34\x18\x0d\x45\x87\x2a\x4c\x7d\x8c\x05\xf0\xf6\x75\x18\x5f\x1f\x7b\x41\xa5\xe7\xf7\xba\x04\xb7\x61\x1f\xcc\x94\x09\xfe\x0f\xf5\xba\x52\x23\xb9\x36\x01\xe4\x08\x29\xc1\x44\xcd\x18\x0f\x6d\x74\x3b\x98\x3b\xb7\x60\x88\x6c\x9b\xb1\x3b\x92\xd0\x82\x2c\x60\xdc\x5f\x53\xcc\x53\xea\x5a\xac\x16\xdb\x6c\xcb\xf0\x32\x2d\xe7\x34\x75\x07\x76\x9f\xbc\x97\x2e\x9b\x5e\xba\x0d\x8f\xb6\x17\x9c\xf9\x5a\x86\x15\x9b\x42\xdb\x16\x5d\x25\x7c\x1f\x50\x2d\x91\x98\x02\xf1\x2d\x1a\x1a\x43\x26\x0c\xd4\x6a\xe0\x9e\x54\x79\x71\xf6\x44\xa2\xd6\x51\xd8\x89\x6f\xe4\xd7\x89\x97\x5b\x98\xba\xdd\xe0\xc6\xd2\xf6\x62\xdb\x6d\xda\x53\x96\x33\xe0\x49\x9f\xbe\xbc\xbd\xea\xcd\x65\x4e\x5f\xf4\xdf\x9a\x09\x1d\xb0\x0a\xdc\x90\x34\x00\xe6\xc2\x60\x9d\xa1\x0d\x1f\xec\xe0\xa1\xec\x1f\x16\x75\x9e\xe3\xf0\x31\xea\x5d\x2b\xd8\xd1\x06\xdd\x5c\xa3\x7f\x43\x82\x37\xe8\xd2\xfa\x6b\x86\xa3\x49\x67\x38\x47\x23\x0e\x15\xb8\xff\xbb\xc5\x7c\x2b\xd0\x1b\xa8\x55\x2d\xe9\x5a\x00\xcd\xb3\xb7\x17\x7f\xbd\x3e\xfb\x7c\xf2\xfe\xf4\x9f\x6a\xb2\xa3\x65\x36\xd9\x1e\x4e\x0c\xf6\xa0\x25\x8d\x78\x67\x46\x02\x7f\xf5\xe2\xa1\x04\x9e\x82\x9b\x8a\x17\x0e\xbc\xb9\x43\x9d\xb5\xe6\x46\x80\xae\x71\x5d\x49\x18\x36\x0f\x20\xc1\x3a\x4b\x9b\xda\xde\xe4\xfd\x81\x9c\x06\x7a\x44\x94\x4a\x62\x23\xbf\x51\xfa\x13\x67\x4d\xa5\x9b\xce\x3b\x63\xbf\xd7\x93\x38\x2e\xd6\x0a\xa4\xd8\x73\xc4\xb9\x5f\x8b\xa1\xf9\x72\x53\xad\x00\x3f\x6c\xc6\x68\x83\xba\xba\x80\x74\xe4\x13\x43\x37\xc7\x7d\xc8\x23\x30\x2c\x56\xec\x6f\xe7\x9f\x3e\x92\x57\xaf\x48\x77\x2d\x66\xdd\xde\xce\x3e\x2d\x8d\x3e\xbc\x4f\xeb\xf1\x8b\xda\xd6\xa9\x5c\x37\x9b\xe8\xb7\xea\xe2\xdd\xde\xf6\xa3\x9b\x03\xb1\x28\xae\xd6\x78\xe3\x3b\x14\x7a\xae\xdf\x83\x77\x54\xec\x1f\x18\x16\x3b\xdc\xde\x4f\x08\xdd\x50\xdb\x79\x23\xf0\xbc\x09\x5f\xdd\x18\x0c\xdc\x74\xb5\xbd\x68\x97\xff\x67\xdc\x09\x98\xb4\xb3\x73\xf8\xbf\xc2\xdb\x9b\x67\x8e\xff\xad\x64\x3f\x1f\x4c\x56\x7a\x30\xe9\xf4\x2b\xa6\x7b\xd0\x54\x7d\xe5\x66\x05\x30\x37\xa8\x56\x57\xed\xdd\xcb\x13\xf7\x7a\xc0\xbd\x40\x19\x4e\x35\xfd\x33\xdd\xbb\x41\x0b\x81\xc1\xb4\x0b\xa1\x4e\x49\x8b\x5a\x4a\xc8\x08\x28\xf5\x06\x66\x59\x3b\x77\x98\x3c\x35\x77\x4e\xee\x0f\x9e\xd4\xed\x9a\x5a\xd1\xe3\xbb\x4b\x5a\x4d\xeb\xd8\xba\x39\x13\x8d\x87\xb9\xcf\xb1\x77\x35\xda\x63\xd9\x2d\x5e\xfe\x09\x8b\xaf\xd7\x68\xe8\xfa\x8f\xde\x59\x2f\x36\x60\x9b\xc6\x31\x3d\xc5\x40\xca\x81\x16\x07\x7c\xe2\x7b\x3d\x07\x87\x1d\x8b\x0f\x58\x22\x59\xe3\x40\xd6\xe2\x73\xd1\x75\xfb\x81\x56\x8f\x5e\xd1\xd9\x4d\xa3\x63\x9c\x04\xbc\xf4\x76\x98\xd4\xef\x31\xa9\x84\x2a\xbb\xdb\x38\x5f\x25\x48\x67\x4c\xc4\x5f\x9d\x78\x2d\x07\x04\xb4\xa6\x04\xac\x41\x56\x94\xb5\x0c\x9c\xa4\xe1\x0a\x98\xe4\x00\x6a\xf0\x84\x1e\x56\x87\x1b\x6f\x1d\x7f\xf4\xd0\x3f\x78\xe1\x91\x41\xa4\xcf\xac\x38\xbb\xc5\xff\x42\x3d\x22\xdf\x34\xbf\xb1\x35\x5a\x19\x68\xa8\x3b\x76\x7d\x96\x4b\xfa\xd7\x0b\x5e\x67\xec\x5f\x01\x0d\xca\x38\x1e\x3f\x57\x86\x9d\xbe\x69\x5a\xf7\xdf\xc3\xfb\xae\x51\x64\x60\xfc\x25\x47\x4f\x8e\x54\x5f\x14\xf8\xe0\xe0\x37\x0b\xbc\x37\xe0\xdf\x90\x59\x5e\x86\x98\xdc\xe1\xfd\x7f\xf3\x2e\x55\x0b\x9e\x1f\x00\x00")
 
 func webUiStaticJsTargetsJsBytes() ([]byte, error) {
 	return bindataRead(
@@ -504,7 +630,7 @@ func webUiStaticJsTargetsJs() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "web/ui/static/js/targets.js", size: 983, mode: os.FileMode(436), modTime: time.Unix(1506011184, 0)}
+	info := bindataFileInfo{name: "web/ui/static/js/targets.js", size: 8094, mode: os.FileMode(436), modTime: time.Unix(1791995822, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -1001,18 +1127,11 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
-	"web/ui/templates/_base.html":                                                             webUiTemplates_baseHtml,
-	"web/ui/templates/alerts.html":                                                            webUiTemplatesAlertsHtml,
-	"web/ui/templates/config.html":                                                            webUiTemplatesConfigHtml,
-	"web/ui/templates/flags.html":                                                             webUiTemplatesFlagsHtml,
-	"web/ui/templates/graph.html":                                                             webUiTemplatesGraphHtml,
-	"web/ui/templates/rules.html":                                                             webUiTemplatesRulesHtml,
-	"web/ui/templates/status.html":                                                            webUiTemplatesStatusHtml,
-	"web/ui/templates/targets.html":                                                           webUiTemplatesTargetsHtml,
 	"web/ui/static/css/alerts.css":                                                            webUiStaticCssAlertsCss,
 	"web/ui/static/css/graph.css":                                                             webUiStaticCssGraphCss,
 	"web/ui/static/css/prom_console.css":                                                      webUiStaticCssProm_consoleCss,
 	"web/ui/static/css/prometheus.css":                                                        webUiStaticCssPrometheusCss,
+	"web/ui/static/css/storage.css":                                                           webUiStaticCssStorageCss,
 	"web/ui/static/css/targets.css":                                                           webUiStaticCssTargetsCss,
 	"web/ui/static/img/ajax-loader.gif":                                                       webUiStaticImgAjaxLoaderGif,
 	"web/ui/static/img/favicon.ico":                                                           webUiStaticImgFaviconIco,
@@ -1020,6 +1139,9 @@ var _bindata = map[string]func() (*asset, error){
 	"web/ui/static/js/graph.js":                                                               webUiStaticJsGraphJs,
 	"web/ui/static/js/graph_template.handlebar":                                               webUiStaticJsGraph_templateHandlebar,
 	"web/ui/static/js/prom_console.js":                                                        webUiStaticJsProm_consoleJs,
+	"web/ui/static/js/promql_editor.js":                                                       webUiStaticJsPromql_editorJs,
+	"web/ui/static/js/settings.js":                                                            webUiStaticJsSettingsJs,
+	"web/ui/static/js/storage.js":                                                             webUiStaticJsStorageJs,
 	"web/ui/static/js/targets.js":                                                             webUiStaticJsTargetsJs,
 	"web/ui/static/vendor/bootstrap-3.3.1/css/bootstrap-theme.min.css":                        webUiStaticVendorBootstrap331CssBootstrapThemeMinCss,
 	"web/ui/static/vendor/bootstrap-3.3.1/css/bootstrap.min.css":                              webUiStaticVendorBootstrap331CssBootstrapMinCss,
@@ -1043,17 +1165,29 @@ var _bindata = map[string]func() (*asset, error){
 	"web/ui/static/vendor/rickshaw/rickshaw.min.js":                                           webUiStaticVendorRickshawRickshawMinJs,
 	"web/ui/static/vendor/rickshaw/vendor/d3.layout.min.js":                                   webUiStaticVendorRickshawVendorD3LayoutMinJs,
 	"web/ui/static/vendor/rickshaw/vendor/d3.v3.js":                                           webUiStaticVendorRickshawVendorD3V3Js,
+	"web/ui/templates/_base.html":                                                             webUiTemplates_baseHtml,
+	"web/ui/templates/alerts.html":                                                            webUiTemplatesAlertsHtml,
+	"web/ui/templates/config.html":                                                            webUiTemplatesConfigHtml,
+	"web/ui/templates/flags.html":                                                             webUiTemplatesFlagsHtml,
+	"web/ui/templates/graph.html":                                                             webUiTemplatesGraphHtml,
+	"web/ui/templates/remote.html":                                                            webUiTemplatesRemoteHtml,
+	"web/ui/templates/rules.html":                                                             webUiTemplatesRulesHtml,
+	"web/ui/templates/status.html":                                                            webUiTemplatesStatusHtml,
+	"web/ui/templates/storage.html":                                                           webUiTemplatesStorageHtml,
+	"web/ui/templates/targets.html":                                                           webUiTemplatesTargetsHtml,
 }
 
 // AssetDir returns the file names below a certain
 // directory embedded in the file by go-bindata.
 // For example if you run go-bindata on data/... and data contains the
 // following hierarchy:
-//     data/
-//       foo.txt
-//       img/
-//         a.png
-//         b.png
+//
+//	data/
+//	  foo.txt
+//	  img/
+//	    a.png
+//	    b.png
+//
 // then AssetDir("data") would return []string{"foo.txt", "img"}
 // AssetDir("data/img") would return []string{"a.png", "b.png"}
 // AssetDir("foo.txt") and AssetDir("notexist") would return an error
@@ -1094,6 +1228,7 @@ var _bintree = &bintree{nil, map[string]*bintree{
 					"graph.css":        &bintree{webUiStaticCssGraphCss, map[string]*bintree{}},
 					"prom_console.css": &bintree{webUiStaticCssProm_consoleCss, map[string]*bintree{}},
 					"prometheus.css":   &bintree{webUiStaticCssPrometheusCss, map[string]*bintree{}},
+					"storage.css":      &bintree{webUiStaticCssStorageCss, map[string]*bintree{}},
 					"targets.css":      &bintree{webUiStaticCssTargetsCss, map[string]*bintree{}},
 				}},
 				"img": &bintree{nil, map[string]*bintree{
@@ -1105,6 +1240,9 @@ var _bintree = &bintree{nil, map[string]*bintree{
 					"graph.js":                 &bintree{webUiStaticJsGraphJs, map[string]*bintree{}},
 					"graph_template.handlebar": &bintree{webUiStaticJsGraph_templateHandlebar, map[string]*bintree{}},
 					"prom_console.js":          &bintree{webUiStaticJsProm_consoleJs, map[string]*bintree{}},
+					"promql_editor.js":         &bintree{webUiStaticJsPromql_editorJs, map[string]*bintree{}},
+					"settings.js":              &bintree{webUiStaticJsSettingsJs, map[string]*bintree{}},
+					"storage.js":               &bintree{webUiStaticJsStorageJs, map[string]*bintree{}},
 					"targets.js":               &bintree{webUiStaticJsTargetsJs, map[string]*bintree{}},
 				}},
 				"vendor": &bintree{nil, map[string]*bintree{
@@ -1162,8 +1300,10 @@ var _bintree = &bintree{nil, map[string]*bintree{
 				"config.html":  &bintree{webUiTemplatesConfigHtml, map[string]*bintree{}},
 				"flags.html":   &bintree{webUiTemplatesFlagsHtml, map[string]*bintree{}},
 				"graph.html":   &bintree{webUiTemplatesGraphHtml, map[string]*bintree{}},
+				"remote.html":  &bintree{webUiTemplatesRemoteHtml, map[string]*bintree{}},
 				"rules.html":   &bintree{webUiTemplatesRulesHtml, map[string]*bintree{}},
 				"status.html":  &bintree{webUiTemplatesStatusHtml, map[string]*bintree{}},
+				"storage.html": &bintree{webUiTemplatesStorageHtml, map[string]*bintree{}},
 				"targets.html": &bintree{webUiTemplatesTargetsHtml, map[string]*bintree{}},
 			}},
 		}},
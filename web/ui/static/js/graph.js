@@ -33,13 +33,39 @@ Prometheus.Graph.stepValues = [
 
 Prometheus.Graph.numGraphs = 0;
 
+// QueryHistory keeps the last executed expressions in the local storage of
+// the browser, most recent first.
+Prometheus.QueryHistory = {
+  storageKey: "prometheus.history",
+  maxEntries: 50,
+
+  get: function() {
+    try {
+      var history = JSON.parse(localStorage.getItem(Prometheus.QueryHistory.storageKey));
+      return $.isArray(history) ? history : [];
+    } catch (e) {
+      return [];
+    }
+  },
+
+  add: function(expr) {
+    var history = Prometheus.QueryHistory.get().filter(function(e) { return e !== expr; });
+    history.unshift(expr);
+    try {
+      localStorage.setItem(Prometheus.QueryHistory.storageKey, JSON.stringify(history.slice(0, Prometheus.QueryHistory.maxEntries)));
+    } catch (e) {
+      // The local storage may be unavailable, e.g. in private browsing.
+    }
+  }
+};
+
 Prometheus.Graph.prototype.initialize = function() {
   var self = this;
   self.id = Prometheus.Graph.numGraphs++;
 
   // Set default options.
   self.options.id = self.id;
-  self.options.range_input = self.options.range_input || "1h";
+  self.options.range_input = self.options.range_input || Prometheus.Settings.get().lookback || "1h";
   if (self.options.tab === undefined) {
     self.options.tab = 1;
   }
@@ -75,11 +101,15 @@ Prometheus.Graph.prototype.initialize = function() {
     $(this).on('keyup input', function() { resizeTextarea(this); });
   });
   self.expr.change(self.handleChange);
+  self.editor = new Prometheus.PromQLEditor(self.expr);
 
   self.rangeInput = self.queryForm.find("input[name=range_input]");
   self.stackedBtn = self.queryForm.find(".stacked_btn");
   self.stacked = self.queryForm.find("input[name=stacked]");
+  self.heatmapBtn = self.queryForm.find(".heatmap_btn");
+  self.heatmap = self.queryForm.find("input[name=heatmap]");
   self.insertMetric = self.queryForm.find("select[name=insert_metric]");
+  self.historySelect = self.queryForm.find("select[name=history]");
   self.refreshInterval = self.queryForm.find("select[name=refresh]");
 
   self.consoleTab = graphWrapper.find(".console");
@@ -157,6 +187,38 @@ Prometheus.Graph.prototype.initialize = function() {
     self.updateGraph();
   });
 
+  // The heatmap option is empty until the user toggles it, in which case
+  // histogram buckets are rendered as a heatmap by default.
+  self.isHeatmap = function() {
+    if (self.heatmap.val() === '') {
+      return self.isHistogram();
+    }
+    return self.heatmap.val() === '1';
+  };
+
+  self.styleHeatmapBtn = function() {
+    var icon = self.heatmapBtn.find('.glyphicon');
+    if (self.isHeatmap()) {
+      self.heatmapBtn.addClass("btn-primary");
+      icon.addClass("glyphicon-check");
+      icon.removeClass("glyphicon-unchecked");
+    } else {
+      self.heatmapBtn.removeClass("btn-primary");
+      icon.addClass("glyphicon-unchecked");
+      icon.removeClass("glyphicon-check");
+    }
+  };
+  self.styleHeatmapBtn();
+
+  self.heatmapBtn.click(function() {
+    self.heatmap.val(self.isHeatmap() ? '0' : '1');
+    self.styleHeatmapBtn();
+    if (self.graphJSON) {
+      self.data = self.transformData(self.graphJSON);
+      self.updateGraph();
+    }
+  });
+
   self.queryForm.submit(function() {
     self.consoleTab.addClass("reload");
     self.graphTab.addClass("reload");
@@ -171,8 +233,29 @@ Prometheus.Graph.prototype.initialize = function() {
   self.queryForm.find("button[name=inc_end]").click(function() { self.increaseEnd(); });
   self.queryForm.find("button[name=dec_end]").click(function() { self.decreaseEnd(); });
 
+  // The history is loaded whenever it is opened, so that it includes queries
+  // of other graphs and tabs.
+  self.historySelect.on("mousedown focus", function() {
+    var current = self.historySelect[0];
+    current.options.length = 1;
+    Prometheus.QueryHistory.get().forEach(function(expr) {
+      current.options.add(new Option(expr, expr));
+    });
+  });
+  self.historySelect.change(function() {
+    if (!self.historySelect.val()) {
+      return;
+    }
+    self.expr.val(self.historySelect.val());
+    self.historySelect.val("");
+    self.editor.render();
+    self.expr.focus();
+    self.handleChange();
+  });
+
   self.insertMetric.change(function() {
     self.expr.selection("replace", {text: self.insertMetric.val(), mode: "before"});
+    self.editor.render();
     self.expr.focus(); // refocusing
   });
 
@@ -205,46 +288,7 @@ Prometheus.Graph.prototype.populateInsertableMetrics = function() {
           self.insertMetric[0].options.add(new Option(metrics[i], metrics[i]));
         }
 
-        self.fuzzyResult = {
-          query: null,
-          result: null,
-          map: {}
-        }
-
-        self.expr.typeahead({
-          source: metrics,
-          items: "all",
-          matcher: function(item) {
-            // If we have result for current query, skip
-            if (self.fuzzyResult.query !== this.query) {
-              self.fuzzyResult.query = this.query;
-              self.fuzzyResult.map = {};
-              self.fuzzyResult.result = fuzzy.filter(this.query.replace(/ /g, ''), metrics, {
-                pre: '<strong>',
-                post: '</strong>'
-              });
-              self.fuzzyResult.result.forEach(function(r) {
-                self.fuzzyResult.map[r.original] = r;
-              });
-            }
-
-            return item in self.fuzzyResult.map;
-          },
-
-          sorter: function(items) {
-            items.sort(function(a,b) {
-              var i = self.fuzzyResult.map[b].score - self.fuzzyResult.map[a].score;
-              return i === 0 ? a.localeCompare(b) : i;
-            });
-            return items;
-          },
-
-          highlighter: function (item) {
-            return $('<div>' + self.fuzzyResult.map[item].string + '</div>')
-          },
-        });
-        // This needs to happen after attaching the typeahead plugin, as it
-        // otherwise breaks the typeahead functionality.
+        self.editor.setMetricNames(metrics);
         self.expr.focus();
       },
       error: function() {
@@ -261,7 +305,8 @@ Prometheus.Graph.prototype.getOptions = function() {
     "range_input",
     "end_input",
     "step_input",
-    "stacked"
+    "stacked",
+    "heatmap"
   ];
 
   self.queryForm.find("input").each(function(index, element) {
@@ -360,6 +405,7 @@ Prometheus.Graph.prototype.submitQuery = function() {
     return;
   }
 
+  Prometheus.QueryHistory.add(self.expr.val());
   self.spinner.show();
   self.evalStats.empty();
 
@@ -376,6 +422,10 @@ Prometheus.Graph.prototype.submitQuery = function() {
   var params = {
     "query": self.expr.val()
   };
+  var timeout = Prometheus.Settings.get().queryTimeout;
+  if (timeout) {
+    params.timeout = timeout;
+  }
   if (self.options.tab === 0) {
     params.start = endDate - rangeSeconds;
     params.end = endDate;
@@ -426,6 +476,10 @@ Prometheus.Graph.prototype.submitQuery = function() {
           }
         }
         self.evalStats.html("Load time: " + duration + "ms <br /> Resolution: " + resolution + "s <br />" + "Total time series: " + totalTimeSeries);
+        // Storages failing in best-effort mode leave the result incomplete.
+        (xhr.responseJSON.warnings || []).forEach(function(w) {
+          self.evalStats.append($("<br />"), $("<span class=\"text-warning\"></span>").text("Warning: " + w));
+        });
         self.spinner.hide();
       }
   });
@@ -538,6 +592,17 @@ Prometheus.Graph.prototype.transformData = function(json) {
   return data;
 };
 
+// isHistogram returns whether the graphed series are the buckets of
+// histograms, which are selected by their _bucket suffix and have an le label
+// even after their name is dropped by functions like rate().
+Prometheus.Graph.prototype.isHistogram = function() {
+  var self = this;
+  if (!self.data || self.data.length === 0 || !/_bucket\b/.test(self.expr.val())) {
+    return false;
+  }
+  return self.data.every(function(s) { return s.labels.le !== undefined; });
+};
+
 Prometheus.Graph.prototype.updateGraph = function() {
   var self = this;
   if (self.data.length === 0) { return; }
@@ -548,6 +613,11 @@ Prometheus.Graph.prototype.updateGraph = function() {
     self.graph.remove();
     self.yAxis.remove();
   }
+  self.styleHeatmapBtn();
+  if (self.isHeatmap()) {
+    self.updateHeatmap();
+    return;
+  }
   self.graph = $('<div class="graph"></div>');
   self.yAxis = $('<div class="y_axis"></div>');
   self.graphArea.append(self.graph);
@@ -655,8 +725,123 @@ Prometheus.Graph.prototype.updateGraph = function() {
   self.handleChange();
 };
 
+// updateHeatmap renders the buckets of the graphed histograms as a heatmap,
+// with buckets of the same le summed up across histograms. Cumulative bucket
+// counts are turned into the counts of the individual buckets.
+Prometheus.Graph.prototype.updateHeatmap = function() {
+  var self = this;
+  self.rickshawGraph = null;
+  self.graph = $('<div class="graph heatmap"></div>');
+  self.yAxis = $('<div class="y_axis"></div>');
+  self.graphArea.append(self.graph);
+  self.graphArea.append(self.yAxis);
+
+  if (!self.isHistogram()) {
+    self.graph.append($('<div class="alert alert-warning">').text(
+      "Heatmaps can only be drawn for histogram buckets, i.e. series with an le label selected by a *_bucket selector."));
+    return;
+  }
+
+  var bucketsByLe = {};
+  self.data.forEach(function(s) {
+    var le = s.labels.le;
+    if (!bucketsByLe[le]) {
+      bucketsByLe[le] = {le: le, upper: parseFloat(le), data: s.data.map(function(p) { return {x: p.x, y: p.y}; })};
+      return;
+    }
+    bucketsByLe[le].data.forEach(function(p, i) {
+      var y = s.data[i].y;
+      if (y !== null) {
+        p.y = (p.y || 0) + y;
+      }
+    });
+  });
+  var buckets = Object.keys(bucketsByLe).map(function(le) { return bucketsByLe[le]; });
+  // parseFloat handles "+Inf".
+  buckets.sort(function(a, b) { return a.upper - b.upper; });
+
+  var max = 0;
+  var cells = [];
+  buckets.forEach(function(b, i) {
+    b.data.forEach(function(p, j) {
+      if (p.y === null) {
+        return;
+      }
+      var below = i > 0 ? buckets[i - 1].data[j].y : 0;
+      var count = Math.max(p.y - (below || 0), 0);
+      max = Math.max(max, count);
+      cells.push({bucket: i, x: p.x, count: count});
+    });
+  });
+
+  var margin = {top: 5, right: 20, bottom: 25, left: 20};
+  var width = Math.max(self.graph.innerWidth() - 80, 200);
+  var height = Math.max(self.graph.innerHeight(), 100);
+  var innerWidth = width - margin.left - margin.right;
+  var innerHeight = height - margin.top - margin.bottom;
+
+  var startTime = self.params.start;
+  var endTime = self.params.end;
+  var x = d3.time.scale.utc()
+    .domain([new Date(startTime * 1000), new Date(endTime * 1000)])
+    .range([0, innerWidth]);
+  var y = d3.scale.ordinal()
+    .domain(buckets.map(function(b, i) { return i; }))
+    .rangeBands([innerHeight, 0]);
+  var color = d3.scale.linear()
+    .domain([0, max || 1])
+    .range(["#f7fbff", "#08306b"]);
+  var cellWidth = Math.max(innerWidth * self.params.step / Math.max(endTime - startTime, 1), 1);
+
+  var svg = d3.select(self.graph[0]).append("svg")
+    .attr("width", width)
+    .attr("height", height)
+    .append("g")
+    .attr("transform", "translate(" + margin.left + "," + margin.top + ")");
+
+  svg.selectAll("rect")
+    .data(cells)
+    .enter().append("rect")
+    .attr("x", function(c) { return x(new Date(c.x * 1000)) - cellWidth / 2; })
+    .attr("y", function(c) { return y(c.bucket); })
+    .attr("width", cellWidth)
+    .attr("height", y.rangeBand())
+    .style("fill", function(c) { return c.count > 0 ? color(c.count) : "transparent"; })
+    .append("title")
+    .text(function(c) {
+      return new Date(c.x * 1000).toUTCString() + "\nle=" + buckets[c.bucket].le + ": " + c.count;
+    });
+
+  svg.append("g")
+    .attr("class", "x axis")
+    .attr("transform", "translate(0," + innerHeight + ")")
+    .call(d3.svg.axis().scale(x).orient("bottom").ticks(8));
+
+  // The buckets are labeled with their upper bound on the axis left of the
+  // graph.
+  var yAxisSvg = d3.select(self.yAxis[0]).append("svg")
+    .attr("width", 60)
+    .attr("height", height)
+    .append("g")
+    .attr("transform", "translate(59," + margin.top + ")");
+  var everyNth = Math.ceil(buckets.length / Math.max(Math.floor(innerHeight / 15), 1));
+  yAxisSvg.append("g")
+    .attr("class", "y axis")
+    .call(d3.svg.axis().scale(y).orient("left")
+      .tickValues(y.domain().filter(function(i) { return i % everyNth === 0; }))
+      .tickFormat(function(i) { return buckets[i].le; }));
+
+  self.handleChange();
+};
+
 Prometheus.Graph.prototype.resizeGraph = function() {
   var self = this;
+  if (self.isHeatmap()) {
+    if (self.graphJSON) {
+      self.updateGraph();
+    }
+    return;
+  }
   if (self.rickshawGraph !== null) {
     self.rickshawGraph.configure({
       width: Math.max(self.graph.innerWidth() - 80, 200),
@@ -805,6 +990,7 @@ Prometheus.Page.prototype.init = function() {
   graphOptions.forEach(this.addGraph, this);
 
   $("#add_graph").click(this.addGraph.bind(this, {}));
+  $("#share_link").click(this.shareLink.bind(this));
 };
 
 Prometheus.Page.prototype.parseURL = function() {
@@ -832,15 +1018,44 @@ Prometheus.Page.prototype.addGraph = function(options) {
 };
 
 // NOTE: This needs to be kept in sync with /util/strutil/strconv.go:GraphLinkForExpression
-Prometheus.Page.prototype.updateURL = function() {
-  var queryString = this.graphs.map(function(graph, index) {
+Prometheus.Page.prototype.queryString = function() {
+  return this.graphs.map(function(graph, index) {
     var graphOptions = graph.getOptions();
     var queryParamHelper = new Prometheus.Page.QueryParamHelper();
     var queryObject = queryParamHelper.generateQueryObject(graphOptions, index);
     return $.param(queryObject);
   }, this).join("&");
+};
+
+Prometheus.Page.prototype.updateURL = function() {
+  history.pushState({}, "", "graph?" + this.queryString());
+  $("#share_link_url").val("").hide();
+};
 
-  history.pushState({}, "", "graph?" + queryString);
+// shareLink creates a short link to the current graphs, as long URLs of
+// complex expressions tend to get mangled when pasted.
+Prometheus.Page.prototype.shareLink = function() {
+  var urlInput = $("#share_link_url");
+  var errorEl = $("#share_link_error");
+  errorEl.text("");
+  $.ajax({
+    method: "POST",
+    url: PATH_PREFIX + "/api/v1/links",
+    dataType: "json",
+    data: {params: this.queryString()},
+    success: function(json) {
+      var url = window.location.protocol + "//" + window.location.host + PATH_PREFIX + "/g/" + json.data.id;
+      urlInput.val(url).show().select();
+    },
+    error: function(xhr) {
+      var msg = "Error creating link";
+      if (xhr.responseJSON && xhr.responseJSON.error) {
+        msg += ": " + xhr.responseJSON.error;
+      }
+      errorEl.text(msg);
+      urlInput.hide();
+    }
+  });
 };
 
 Prometheus.Page.prototype.removeGraph = function(graph) {
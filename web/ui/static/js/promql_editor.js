@@ -0,0 +1,560 @@
+// PromQL editor: syntax highlighting, context-aware autocompletion and basic
+// linting on top of the expression textarea.
+
+var Prometheus = Prometheus || {};
+
+Prometheus.PromQL = {
+  // Function signatures, keep in sync with promql/functions.go.
+  functions: {
+    "abs": ["instant-vector"],
+    "absent": ["instant-vector"],
+    "avg_over_time": ["range-vector"],
+    "ceil": ["instant-vector"],
+    "changes": ["range-vector"],
+    "clamp_max": ["instant-vector", "scalar"],
+    "clamp_min": ["instant-vector", "scalar"],
+    "count_over_time": ["range-vector"],
+    "count_scalar": ["instant-vector"],
+    "day_of_month": ["[instant-vector]"],
+    "day_of_week": ["[instant-vector]"],
+    "days_in_month": ["[instant-vector]"],
+    "delta": ["range-vector"],
+    "deriv": ["range-vector"],
+    "drop_common_labels": ["instant-vector"],
+    "exp": ["instant-vector"],
+    "floor": ["instant-vector"],
+    "histogram_fraction": ["scalar", "scalar", "instant-vector"],
+    "histogram_quantile": ["scalar", "instant-vector"],
+    "holt_winters": ["range-vector", "scalar", "scalar"],
+    "hour": ["[instant-vector]"],
+    "idelta": ["range-vector"],
+    "increase": ["range-vector"],
+    "irate": ["range-vector"],
+    "label_join": ["instant-vector", "string", "string", "string", "..."],
+    "label_lowercase": ["instant-vector", "string", "..."],
+    "label_replace": ["instant-vector", "string", "string", "string", "string"],
+    "label_trim": ["instant-vector", "string", "..."],
+    "label_uppercase": ["instant-vector", "string", "..."],
+    "ln": ["instant-vector"],
+    "log10": ["instant-vector"],
+    "log2": ["instant-vector"],
+    "mad_over_time": ["range-vector"],
+    "max_over_time": ["range-vector"],
+    "min_over_time": ["range-vector"],
+    "minute": ["[instant-vector]"],
+    "month": ["[instant-vector]"],
+    "predict_linear": ["range-vector", "scalar"],
+    "quantile_over_time": ["scalar", "range-vector"],
+    "rate": ["range-vector"],
+    "resets": ["range-vector"],
+    "round": ["instant-vector", "[scalar]"],
+    "scalar": ["instant-vector"],
+    "sort": ["instant-vector"],
+    "sort_desc": ["instant-vector"],
+    "sqrt": ["instant-vector"],
+    "stddev_over_time": ["range-vector"],
+    "stdvar_over_time": ["range-vector"],
+    "sum_over_time": ["range-vector"],
+    "time": [],
+    "vector": ["scalar"],
+    "year": ["[instant-vector]"]
+  },
+
+  aggregators: {
+    "avg": [], "bottomk": ["scalar"], "count": [], "count_values": ["string"],
+    "max": [], "min": [], "quantile": ["scalar"], "stddev": [], "stdvar": [],
+    "sum": [], "topk": ["scalar"]
+  },
+
+  keywords: ["and", "or", "unless", "default", "by", "without", "on", "ignoring", "group_left", "group_right", "offset", "bool"],
+
+  // Keywords followed by a list of label names in parentheses.
+  groupingKeywords: ["by", "without", "on", "ignoring", "group_left", "group_right"],
+
+  durations: ["1m", "5m", "10m", "30m", "1h", "6h", "12h", "1d", "1w"]
+};
+
+// tokenize splits a PromQL expression into tokens with their type and
+// position. It never fails; unknown characters become "error" tokens.
+Prometheus.PromQL.tokenize = function(input) {
+  var rules = [
+    ["whitespace", /^\s+/],
+    ["comment", /^#.*/],
+    // The last group captures the closing quote, if any.
+    ["string", /^"(?:\\.|[^"\\\n])*("?)/],
+    ["string", /^'(?:\\.|[^'\\\n])*('?)/],
+    ["string", /^`[^`]*(`?)/],
+    ["duration", /^[0-9]+[smhdwy]\b/],
+    ["number", /^(?:0[xX][0-9a-fA-F]+|[0-9]*\.?[0-9]+(?:[eE][-+]?[0-9]+)?)/],
+    ["identifier", /^[a-zA-Z_:][a-zA-Z0-9_:]*/],
+    ["operator", /^(?:==|!=|>=|<=|=~|!~|[-+*\/%^=<>])/],
+    ["punctuation", /^[(){}\[\],]/]
+  ];
+  var PromQL = Prometheus.PromQL;
+  var tokens = [];
+  var pos = 0;
+  // Stack of open brackets with the construct they belong to.
+  var stack = [];
+
+  while (pos < input.length) {
+    var rest = input.slice(pos);
+    var type = "error";
+    var text = rest[0];
+    for (var i = 0; i < rules.length; i++) {
+      var m = rules[i][1].exec(rest);
+      if (m) {
+        type = rules[i][0];
+        text = m[0];
+        break;
+      }
+    }
+    var tok = {type: type, text: text, start: pos, end: pos + text.length};
+    if (type === "string") {
+      tok.unterminated = m[1] === "";
+    }
+    var top = stack.length > 0 ? stack[stack.length - 1] : null;
+
+    if (type === "identifier") {
+      var prev = PromQL.prevSignificant(tokens);
+      if (top && (top.kind === "selector" || top.kind === "grouping")) {
+        tok.type = "label";
+      } else if (PromQL.keywords.indexOf(text.toLowerCase()) !== -1) {
+        tok.type = "keyword";
+      } else if (/^(Inf|NaN)$/i.test(text)) {
+        tok.type = "number";
+      } else if (/^\s*\(/.test(input.slice(tok.end)) && text in PromQL.functions) {
+        tok.type = "function";
+      } else if (text in PromQL.aggregators) {
+        tok.type = "aggregator";
+      } else if (/^\s*\(/.test(input.slice(tok.end)) && !(prev && prev.type === "keyword")) {
+        // Called like a function, but not one we know.
+        tok.type = "function";
+        tok.unknown = true;
+      } else {
+        tok.type = "metric";
+      }
+    } else if (type === "punctuation") {
+      var prevTok = PromQL.prevSignificant(tokens);
+      switch (text) {
+      case "{":
+        stack.push({kind: "selector", token: tok, metric: prevTok && prevTok.type === "metric" ? prevTok.text : null});
+        break;
+      case "[":
+        stack.push({kind: "range", token: tok});
+        break;
+      case "(":
+        var grouping = prevTok && prevTok.type === "keyword" && PromQL.groupingKeywords.indexOf(prevTok.text.toLowerCase()) !== -1;
+        stack.push({kind: grouping ? "grouping" : "paren", token: tok, func: prevTok && (prevTok.type === "function" || prevTok.type === "aggregator") ? prevTok.text : null});
+        break;
+      case "}":
+      case "]":
+      case ")":
+        var open = {"}": "{", "]": "[", ")": "("}[text];
+        if (top && top.token.text === open) {
+          stack.pop();
+        } else {
+          tok.unbalanced = true;
+        }
+        break;
+      }
+    }
+    tok.context = stack.length > 0 ? stack[stack.length - 1] : null;
+    tokens.push(tok);
+    pos = tok.end;
+  }
+  return {tokens: tokens, open: stack};
+};
+
+Prometheus.PromQL.prevSignificant = function(tokens, before) {
+  for (var i = (before === undefined ? tokens.length : before) - 1; i >= 0; i--) {
+    if (tokens[i].type !== "whitespace" && tokens[i].type !== "comment") {
+      return tokens[i];
+    }
+  }
+  return null;
+};
+
+// lint returns the first problem found in the expression, or null.
+Prometheus.PromQL.lint = function(input) {
+  var res = Prometheus.PromQL.tokenize(input);
+  for (var i = 0; i < res.tokens.length; i++) {
+    var tok = res.tokens[i];
+    if (tok.type === "error") {
+      return {token: tok, message: "unexpected character " + JSON.stringify(tok.text)};
+    }
+    if (tok.unterminated) {
+      return {token: tok, message: "unterminated string"};
+    }
+    if (tok.unbalanced) {
+      return {token: tok, message: "unexpected " + JSON.stringify(tok.text)};
+    }
+    if (tok.unknown) {
+      return {token: tok, message: "unknown function " + JSON.stringify(tok.text)};
+    }
+    if (tok.context && tok.context.kind === "range" && tok.type !== "duration" && tok.type !== "whitespace" && tok !== tok.context.token) {
+      return {token: tok, message: "range must be a duration like 5m"};
+    }
+  }
+  if (res.open.length > 0) {
+    var open = res.open[res.open.length - 1].token;
+    return {token: open, message: "unclosed " + JSON.stringify(open.text)};
+  }
+  return null;
+};
+
+Prometheus.PromQL.signature = function(name) {
+  var PromQL = Prometheus.PromQL;
+  if (name in PromQL.functions) {
+    return name + "(" + PromQL.functions[name].join(", ") + ")";
+  }
+  if (name in PromQL.aggregators) {
+    var args = PromQL.aggregators[name].concat(["instant-vector"]);
+    return name + " [by|without (label, ...)] (" + args.join(", ") + ")";
+  }
+  return null;
+};
+
+Prometheus.PromQLEditor = function(textarea) {
+  this.input = textarea;
+  this.metricNames = [];
+  this.cache = {};
+  this.completions = [];
+  this.selected = 0;
+  this.initialize();
+};
+
+Prometheus.PromQLEditor.prototype.initialize = function() {
+  var self = this;
+
+  self.wrapper = $("<div class='promql_editor'></div>");
+  self.input.before(self.wrapper);
+  self.highlight = $("<pre class='promql_highlight' aria-hidden='true'></pre>");
+  self.menu = $("<ul class='dropdown-menu promql_completions'></ul>").hide();
+  self.hint = $("<div class='promql_hint'></div>");
+  self.wrapper.append(self.highlight, self.input.detach(), self.menu);
+  self.wrapper.after(self.hint);
+
+  var lintTimeout;
+  self.input.on("input keyup change", function(e) {
+    // Navigation keys are handled on keydown.
+    if (e.type === "keyup" && [13, 27, 38, 40].indexOf(e.which) !== -1) {
+      return;
+    }
+    self.render();
+    clearTimeout(lintTimeout);
+    lintTimeout = setTimeout(function() { self.lint(); }, 300);
+    if (e.type !== "change") {
+      self.complete();
+    }
+  });
+  self.input.on("scroll", function() {
+    self.highlight.scrollTop(self.input.scrollTop());
+  });
+  self.input.on("click", function() {
+    self.showSignature();
+  });
+  self.input.on("blur", function() {
+    // Give clicks on the menu a chance to register.
+    setTimeout(function() { self.hideMenu(); }, 200);
+  });
+
+  self.input.on("keydown", function(e) {
+    if (!self.menu.is(":visible")) {
+      if (e.which === 32 && e.ctrlKey) {
+        self.complete(true);
+        e.preventDefault();
+      }
+      return;
+    }
+    switch (e.which) {
+    case 38: // Up.
+      self.select(self.selected - 1);
+      break;
+    case 40: // Down.
+      self.select(self.selected + 1);
+      break;
+    case 9:  // Tab.
+    case 13: // Return.
+      self.accept(self.completions[self.selected]);
+      break;
+    case 27: // Escape.
+      self.hideMenu();
+      break;
+    default:
+      return;
+    }
+    e.preventDefault();
+    e.stopImmediatePropagation();
+  });
+
+  self.menu.on("mousedown", "li", function(e) {
+    e.preventDefault();
+    self.accept(self.completions[$(this).index()]);
+  });
+
+  self.render();
+};
+
+Prometheus.PromQLEditor.prototype.setMetricNames = function(names) {
+  this.metricNames = names;
+};
+
+// render updates the highlighting overlay from the textarea content.
+Prometheus.PromQLEditor.prototype.render = function() {
+  var self = this;
+  var tokens = Prometheus.PromQL.tokenize(self.input.val()).tokens;
+  var html = $.map(tokens, function(tok) {
+    var text = $("<span>").text(tok.text).html();
+    if (tok.type === "whitespace") {
+      return text;
+    }
+    var cls = "promql_" + tok.type;
+    if (self.lintError && self.lintError.token.start === tok.start) {
+      cls += " promql_lint_error";
+    }
+    return "<span class='" + cls + "'>" + text + "</span>";
+  }).join("");
+  // A trailing newline needs content to be rendered with height.
+  self.highlight.html(html + "\n");
+  self.highlight.scrollTop(self.input.scrollTop());
+};
+
+Prometheus.PromQLEditor.prototype.lint = function() {
+  this.lintError = this.input.val() === "" ? null : Prometheus.PromQL.lint(this.input.val());
+  this.render();
+  this.showSignature();
+};
+
+Prometheus.PromQLEditor.prototype.showSignature = function() {
+  var self = this;
+  if (self.lintError) {
+    self.hint.addClass("text-danger").text(self.lintError.message);
+    return;
+  }
+  self.hint.removeClass("text-danger").text("");
+
+  var cursor = self.input[0].selectionStart;
+  var tokens = Prometheus.PromQL.tokenize(self.input.val().slice(0, cursor)).tokens;
+  var ctx = tokens.length > 0 ? tokens[tokens.length - 1].context : null;
+  var last = tokens.length > 0 ? tokens[tokens.length - 1] : null;
+  var name = null;
+  if (ctx && ctx.kind === "paren" && ctx.func) {
+    name = ctx.func;
+  } else if (last && (last.type === "function" || last.type === "aggregator")) {
+    name = last.text;
+  }
+  var sig = name && Prometheus.PromQL.signature(name);
+  if (sig) {
+    self.hint.text(sig);
+  }
+};
+
+// context determines what is being typed at the cursor.
+Prometheus.PromQLEditor.prototype.context = function() {
+  var val = this.input.val();
+  var cursor = this.input[0].selectionStart;
+  var res = Prometheus.PromQL.tokenize(val.slice(0, cursor));
+  var tokens = res.tokens;
+  var last = tokens.length > 0 ? tokens[tokens.length - 1] : null;
+  var ctx = {prefix: "", start: cursor, end: cursor};
+
+  if (last && (last.type === "metric" || last.type === "label" || last.type === "function" ||
+      last.type === "aggregator" || last.type === "keyword" || last.type === "duration" || last.type === "number")) {
+    ctx.prefix = last.text;
+    ctx.start = last.start;
+    // Replace the rest of the identifier after the cursor as well.
+    var after = /^[a-zA-Z0-9_:]*/.exec(val.slice(cursor));
+    ctx.end = cursor + after[0].length;
+  }
+  var scope = last ? last.context : null;
+  var prev = Prometheus.PromQL.prevSignificant(tokens, ctx.prefix ? tokens.length - 1 : tokens.length);
+
+  if (last && last.type === "string" && scope && scope.kind === "selector") {
+    // Label value inside a selector.
+    var op = Prometheus.PromQL.prevSignificant(tokens, tokens.length - 1);
+    var label = op ? Prometheus.PromQL.prevSignificant(tokens, tokens.indexOf(op)) : null;
+    if (!label || label.type !== "label") {
+      return null;
+    }
+    ctx.kind = "labelValue";
+    ctx.label = label.text;
+    ctx.metric = scope.metric;
+    ctx.quote = last.text[0];
+    ctx.prefix = last.text.slice(1);
+    ctx.start = last.start + 1;
+    ctx.end = cursor;
+    return ctx;
+  }
+  if (last && last.type === "string") {
+    return null;
+  }
+  if (scope && scope.kind === "range") {
+    ctx.kind = "duration";
+    return ctx;
+  }
+  if (scope && (scope.kind === "selector" || scope.kind === "grouping")) {
+    if (prev && prev.type === "operator") {
+      return null;
+    }
+    ctx.kind = "labelName";
+    ctx.metric = scope.kind === "selector" ? scope.metric : null;
+    return ctx;
+  }
+  ctx.kind = "expression";
+  return ctx;
+};
+
+Prometheus.PromQLEditor.prototype.fetch = function(url, data, callback) {
+  var self = this;
+  var key = url + "?" + $.param(data || {}, true);
+  if (key in self.cache) {
+    callback(self.cache[key]);
+    return;
+  }
+  $.ajax({
+    method: "GET",
+    url: PATH_PREFIX + url,
+    data: data,
+    traditional: true,
+    dataType: "json",
+    success: function(json) {
+      if (json.status === "success") {
+        self.cache[key] = json.data;
+        callback(json.data);
+      }
+    }
+  });
+};
+
+// complete shows the completions for the cursor position. Unless forced, it
+// only does so once something has been typed.
+Prometheus.PromQLEditor.prototype.complete = function(force) {
+  var self = this;
+  var ctx = self.context();
+  if (!ctx || (!force && ctx.prefix === "" && ctx.kind !== "labelValue")) {
+    self.hideMenu();
+    self.showSignature();
+    return;
+  }
+  var match = {};
+  if (ctx.metric) {
+    match["match[]"] = ctx.metric;
+  }
+  var show = function(items) {
+    // Bail out if the input moved on in the meantime.
+    var now = self.context();
+    if (!now || now.start !== ctx.start || now.kind !== ctx.kind) {
+      return;
+    }
+    self.showMenu(ctx, items);
+  };
+
+  switch (ctx.kind) {
+  case "labelValue":
+    self.fetch("/api/v1/label/" + encodeURIComponent(ctx.label) + "/values", match, function(values) {
+      show($.map(values, function(v) { return {text: v, type: "value"}; }));
+    });
+    break;
+  case "labelName":
+    self.fetch("/api/v1/labels", match, function(names) {
+      show($.map(names, function(n) { return n === "__name__" && ctx.metric ? null : {text: n, type: "label"}; }));
+    });
+    break;
+  case "duration":
+    show($.map(Prometheus.PromQL.durations, function(d) { return {text: d, type: "duration"}; }));
+    break;
+  default:
+    var PromQL = Prometheus.PromQL;
+    var items = $.map(self.metricNames, function(m) { return {text: m, type: "metric"}; });
+    $.each(PromQL.functions, function(f) { items.push({text: f, type: "function"}); });
+    $.each(PromQL.aggregators, function(a) { items.push({text: a, type: "aggregator"}); });
+    $.each(PromQL.keywords, function(i, k) { items.push({text: k, type: "keyword"}); });
+    show(items);
+  }
+};
+
+Prometheus.PromQLEditor.prototype.showMenu = function(ctx, items) {
+  var self = this;
+  var matches = items;
+  if (ctx.prefix !== "") {
+    var results = fuzzy.filter(ctx.prefix, items, {
+      pre: "<strong>",
+      post: "</strong>",
+      extract: function(item) { return item.text; }
+    });
+    results.sort(function(a, b) {
+      // Prefer prefix matches, then better fuzzy scores.
+      var pa = a.original.text.indexOf(ctx.prefix) === 0 ? 1 : 0;
+      var pb = b.original.text.indexOf(ctx.prefix) === 0 ? 1 : 0;
+      if (pa !== pb) {
+        return pb - pa;
+      }
+      var d = b.score - a.score;
+      return d === 0 ? a.original.text.localeCompare(b.original.text) : d;
+    });
+    matches = $.map(results, function(r) {
+      return $.extend({html: r.string}, r.original);
+    });
+  }
+  // Nothing to offer if the only match is what has been typed already.
+  if (matches.length === 0 || (matches.length === 1 && matches[0].text === ctx.prefix)) {
+    self.hideMenu();
+    return;
+  }
+  self.completions = $.map(matches.slice(0, 50), function(m) { return $.extend({ctx: ctx}, m); });
+  self.menu.empty();
+  $.each(self.completions, function(i, c) {
+    var a = $("<a href='#'></a>");
+    if (c.html) {
+      // The fuzzy matcher doesn't escape its input.
+      a.html($.map(c.html.split(/(<\/?strong>)/), function(part) {
+        return /^<\/?strong>$/.test(part) ? part : $("<span>").text(part).html();
+      }).join(""));
+    } else {
+      a.text(c.text);
+    }
+    a.append($("<span class='promql_completion_type'></span>").text(c.type));
+    self.menu.append($("<li></li>").append(a));
+  });
+  self.select(0);
+  self.menu.show();
+};
+
+Prometheus.PromQLEditor.prototype.select = function(i) {
+  var n = this.completions.length;
+  this.selected = (i + n) % n;
+  this.menu.children().removeClass("active").eq(this.selected).addClass("active");
+};
+
+Prometheus.PromQLEditor.prototype.hideMenu = function() {
+  this.menu.hide();
+  this.completions = [];
+};
+
+Prometheus.PromQLEditor.prototype.accept = function(c) {
+  if (!c) {
+    return;
+  }
+  var ctx = c.ctx;
+  var val = this.input.val();
+  var text = c.text;
+  if (c.type === "function") {
+    text += "(";
+  } else if (c.type === "value") {
+    text = text.replace(/\\/g, "\\\\").replace(new RegExp(ctx.quote, "g"), "\\" + ctx.quote);
+    if (val[ctx.end] !== ctx.quote) {
+      text += ctx.quote;
+    }
+  }
+  this.input.val(val.slice(0, ctx.start) + text + val.slice(ctx.end));
+  var cursor = ctx.start + text.length;
+  if (c.type === "value") {
+    // Move past the closing quote.
+    cursor = ctx.start + text.length + (val[ctx.end] === ctx.quote ? 1 : 0);
+  }
+  this.input[0].setSelectionRange(cursor, cursor);
+  this.hideMenu();
+  this.input.trigger("change");
+  this.render();
+  this.lint();
+};
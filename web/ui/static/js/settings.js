@@ -0,0 +1,98 @@
+// Settings of the web UI. They are kept in the local storage of the browser,
+// so that they apply right away, and synced with the server, so that they
+// follow the user across browsers.
+var Prometheus = Prometheus || {};
+
+Prometheus.Settings = {
+  storageKey: "prometheus.settings",
+  defaults: {theme: "light", queryTimeout: "", lookback: ""},
+
+  get: function() {
+    var settings = $.extend({}, Prometheus.Settings.defaults);
+    try {
+      $.extend(settings, JSON.parse(localStorage.getItem(Prometheus.Settings.storageKey)));
+    } catch (e) {
+      // Missing or malformed settings leave the defaults.
+    }
+    return settings;
+  },
+
+  store: function(settings) {
+    try {
+      localStorage.setItem(Prometheus.Settings.storageKey, JSON.stringify(settings));
+    } catch (e) {
+      // The local storage may be unavailable, e.g. in private browsing.
+    }
+    Prometheus.Settings.apply(settings);
+  },
+
+  apply: function(settings) {
+    $(document.documentElement).toggleClass("theme-dark", settings.theme === "dark");
+  },
+
+  // sync replaces the local settings by the ones of the server.
+  sync: function() {
+    $.ajax({
+      method: "GET",
+      url: PATH_PREFIX + "/api/v1/settings",
+      dataType: "json",
+      success: function(json) {
+        if (json.status === "success") {
+          Prometheus.Settings.store(json.data);
+        }
+      }
+    });
+  },
+
+  save: function(settings, success, error) {
+    $.ajax({
+      method: "POST",
+      url: PATH_PREFIX + "/api/v1/settings",
+      dataType: "json",
+      data: settings,
+      success: function(json) {
+        Prometheus.Settings.store(json.data);
+        success();
+      },
+      error: function(xhr) {
+        var msg = "Error saving settings";
+        if (xhr.responseJSON && xhr.responseJSON.error) {
+          msg += ": " + xhr.responseJSON.error;
+        }
+        error(msg);
+      }
+    });
+  }
+};
+
+// Apply the local settings before the page is rendered.
+Prometheus.Settings.apply(Prometheus.Settings.get());
+
+$(function() {
+  var modal = $("#settings_modal");
+  var form = modal.find("form");
+  var errorEl = modal.find(".alert-danger");
+
+  modal.on("show.bs.modal", function() {
+    var settings = Prometheus.Settings.get();
+    form.find("select[name=theme]").val(settings.theme);
+    form.find("input[name=queryTimeout]").val(settings.queryTimeout);
+    form.find("input[name=lookback]").val(settings.lookback);
+    errorEl.hide();
+  });
+
+  form.submit(function(e) {
+    e.preventDefault();
+    Prometheus.Settings.save({
+      theme: form.find("select[name=theme]").val(),
+      queryTimeout: $.trim(form.find("input[name=queryTimeout]").val()),
+      lookback: $.trim(form.find("input[name=lookback]").val())
+    }, function() {
+      modal.modal("hide");
+    }, function(msg) {
+      errorEl.text(msg).show();
+    });
+  });
+
+  Prometheus.Settings.sync();
+});
@@ -0,0 +1,83 @@
+var churnGraph;
+
+function fillTable(selector, entries) {
+    var tbody = $(selector + " tbody").empty();
+    if (entries.length === 0) {
+        tbody.append($("<tr>").append($("<td>").attr("colspan", 2).text("No series.")));
+        return;
+    }
+    $.each(entries, function(i, e) {
+        tbody.append($("<tr>").append(
+            $("<td>").text(e.name),
+            $("<td>").text(e.value)
+        ));
+    });
+}
+
+function renderChurn(churn) {
+    var toPoints = function(key) {
+        return $.map(churn, function(e) {
+            return {x: new Date(e.time).getTime() / 1000, y: e[key]};
+        });
+    };
+    var series = [
+        {name: "Created", color: "#5cb85c", data: toPoints("created")},
+        {name: "Removed", color: "#d9534f", data: toPoints("removed")}
+    ];
+
+    if (churnGraph) {
+        churnGraph.series[0].data = series[0].data;
+        churnGraph.series[1].data = series[1].data;
+        churnGraph.update();
+        return;
+    }
+
+    churnGraph = new Rickshaw.Graph({
+        element: document.getElementById("churn_chart"),
+        height: 200,
+        renderer: "line",
+        interpolation: "linear",
+        series: series
+    });
+    new Rickshaw.Graph.Axis.Time({graph: churnGraph});
+    new Rickshaw.Graph.Axis.Y({
+        element: document.getElementById("churn_y_axis"),
+        graph: churnGraph,
+        orientation: "left",
+        tickFormat: Rickshaw.Fixtures.Number.formatKMBT
+    });
+    new Rickshaw.Graph.Legend({graph: churnGraph, element: document.getElementById("churn_legend")});
+    new Rickshaw.Graph.HoverDetail({graph: churnGraph});
+    churnGraph.render();
+}
+
+function loadStats() {
+    $.ajax({
+        url: PATH_PREFIX + "/api/v1/status/storage",
+        data: {limit: $("#storage_limit").val()},
+        dataType: "json",
+        success: function(json) {
+            var stats = json.data;
+            $("#storage_error").hide();
+            $("#num_series").text(stats.numSeries);
+            fillTable("#series_by_metric_name", stats.seriesCountByMetricName);
+            fillTable("#values_by_label_name", stats.labelValueCountByLabelName);
+            fillTable("#series_by_label_pair", stats.seriesCountByLabelValuePair);
+            renderChurn(stats.churn);
+        },
+        error: function(xhr) {
+            var msg = xhr.statusText;
+            if (xhr.responseJSON && xhr.responseJSON.error) {
+                msg = xhr.responseJSON.error;
+            }
+            $("#storage_error").text("Error loading storage statistics: " + msg).show();
+        }
+    });
+}
+
+function init() {
+    $("#storage_limit").change(loadStats);
+    loadStats();
+}
+
+$(init);
@@ -1,38 +1,253 @@
-function toggle(obj, state){
-    var icon = $(obj).find("i");
-    if (icon.length === 0 ) {
-        return;
+var TARGETS_PAGE_SIZE = 100;
+
+var targetsState = {
+    targets: [],   // Targets returned by the API for the current pool and state.
+    filtered: [],  // Targets matching the search string.
+    page: 0
+};
+
+function healthToClass(health) {
+    switch (health) {
+    case "up":
+        return "success";
+    case "unknown":
+        return "warning";
+    default:
+        return "danger";
     }
+}
 
-    if (state === true) {
-        icon.removeClass("icon-chevron-down").addClass("icon-chevron-up");
-    } else {
-        icon.removeClass("icon-chevron-up").addClass("icon-chevron-down");
+function formatSince(lastScrape) {
+    var t = new Date(lastScrape);
+    if (isNaN(t.getTime()) || t.getFullYear() <= 1) {
+        return "Never";
+    }
+    var secs = Math.max(0, (Date.now() - t.getTime()) / 1000);
+    if (secs < 60) {
+        return secs.toFixed(3) + "s ago";
     }
+    if (secs < 3600) {
+        return Math.floor(secs / 60) + "m " + Math.floor(secs % 60) + "s ago";
+    }
+    return Math.floor(secs / 3600) + "h " + Math.floor((secs % 3600) / 60) + "m ago";
+}
 
-    $(obj).next().toggle(state);
+// globalURL replaces local hostnames in the scrape URL with the host the UI
+// is accessed on, so that links work from other machines.
+function globalURL(scrapeURL) {
+    var a = document.createElement("a");
+    a.href = scrapeURL;
+    if (a.hostname === "localhost" || a.hostname === "127.0.0.1" || a.hostname === "[::1]") {
+        a.hostname = window.location.hostname;
+    }
+    return a.href;
 }
 
-function init() {
-    $(".job_header").click(function() {
-        var job = $(this).find("a").attr("id"),
-            expanderIcon = $(this).find("i.icon-chevron-down");
-
-        if (expanderIcon.length !== 0) {
-            localStorage.setItem(job, false);
-            toggle(this, true);
-        } else {
-            localStorage.setItem(job, true);
-            toggle(this, false);
+function labelSpan(name, value) {
+    return $("<span>").addClass("label label-primary").text(name + '="' + value + '"');
+}
+
+function sortedKeys(obj) {
+    return Object.keys(obj).sort();
+}
+
+function matchesSearch(target, search) {
+    if (search === "") {
+        return true;
+    }
+    if (target.scrapeUrl.toLowerCase().indexOf(search) !== -1) {
+        return true;
+    }
+    for (var name in target.labels) {
+        var pair = name + '="' + target.labels[name] + '"';
+        if (pair.toLowerCase().indexOf(search) !== -1) {
+            return true;
+        }
+    }
+    return false;
+}
+
+function renderTarget(target) {
+    var tr = $("<tr>");
+
+    var a = document.createElement("a");
+    a.href = target.scrapeUrl;
+    var endpoint = $("<td>").append(
+        $("<a>").attr("href", globalURL(target.scrapeUrl)).text(a.protocol + "//" + a.host + a.pathname),
+        "<br>"
+    );
+    var params = a.search.replace(/^\?/, "");
+    if (params !== "") {
+        $.each(params.split("&"), function(i, p) {
+            var kv = p.split("=");
+            endpoint.append(labelSpan(decodeURIComponent(kv[0]), decodeURIComponent(kv.slice(1).join("="))));
+        });
+    }
+    tr.append(endpoint);
+
+    tr.append($("<td>").text(target.scrapePool));
+
+    tr.append($("<td>").append(
+        $("<span>").addClass("alert alert-" + healthToClass(target.health) + " state_indicator text-uppercase").text(target.health)
+    ));
+
+    var discovered = $.map(sortedKeys(target.discoveredLabels), function(name) {
+        return name + '="' + target.discoveredLabels[name] + '"';
+    });
+    var labels = $("<span>").addClass("cursor-pointer").attr("data-toggle", "tooltip").attr("title", "Before relabeling: " + discovered.join(", "));
+    var names = $.grep(sortedKeys(target.labels), function(name) { return name !== "job"; });
+    if (names.length === 0) {
+        labels.append($("<span>").addClass("label label-default").text("none"));
+    }
+    $.each(names, function(i, name) {
+        labels.append(labelSpan(name, target.labels[name]), " ");
+    });
+    tr.append($("<td>").append(labels));
+
+    tr.append($("<td>").text(formatSince(target.lastScrape)));
+    tr.append($("<td>").text(target.seriesAdded));
+
+    var lastError = $("<td>");
+    if (target.lastError) {
+        lastError.append($("<span>").addClass("alert alert-danger state_indicator").text(target.lastError));
+    }
+    tr.append(lastError);
+
+    return tr;
+}
+
+function renderTargets() {
+    var search = $("#targets_search").val().toLowerCase();
+    targetsState.filtered = $.grep(targetsState.targets, function(t) { return matchesSearch(t, search); });
+
+    var pages = Math.max(1, Math.ceil(targetsState.filtered.length / TARGETS_PAGE_SIZE));
+    targetsState.page = Math.min(targetsState.page, pages - 1);
+
+    var start = targetsState.page * TARGETS_PAGE_SIZE;
+    var page = targetsState.filtered.slice(start, start + TARGETS_PAGE_SIZE);
+
+    var tbody = $("#targets_table tbody").empty();
+    $.each(page, function(i, target) {
+        tbody.append(renderTarget(target));
+    });
+    tbody.find('[data-toggle="tooltip"]').tooltip();
+
+    var unhealthy = $.grep(targetsState.filtered, function(t) { return t.health !== "up"; }).length;
+    $("#targets_summary").text(
+        "Showing " + targetsState.filtered.length + " of " + targetsState.targets.length + " targets (" + unhealthy + " not up)."
+    );
+    $("#targets_page").text("Page " + (targetsState.page + 1) + " of " + pages);
+    $("#targets_prev").parent().toggleClass("disabled", targetsState.page === 0);
+    $("#targets_next").parent().toggleClass("disabled", targetsState.page >= pages - 1);
+}
+
+// updateLocation stores the filter settings in the URL, so that the current
+// view can be shared and survives reloads.
+function updateLocation() {
+    var params = {
+        scrapePool: $("#targets_pool").val(),
+        state: $("#targets_state .active").data("state"),
+        search: $("#targets_search").val()
+    };
+    $.each(params, function(k, v) {
+        if (!v) {
+            delete params[k];
         }
     });
+    var query = $.param(params);
+    window.history.replaceState(null, "", window.location.pathname + (query ? "?" + query : ""));
+}
+
+function loadTargets() {
+    var params = {
+        scrapePool: $("#targets_pool").val(),
+        state: $("#targets_state .active").data("state")
+    };
+    $.ajax({
+        url: PATH_PREFIX + "/api/v1/targets",
+        data: params,
+        dataType: "json",
+        success: function(json) {
+            $("#targets_error").hide();
+            targetsState.targets = json.data.activeTargets;
+            targetsState.page = 0;
+            renderTargets();
+        },
+        error: function(xhr) {
+            var msg = xhr.statusText;
+            if (xhr.responseJSON && xhr.responseJSON.error) {
+                msg = xhr.responseJSON.error;
+            }
+            $("#targets_error").text("Error loading targets: " + msg).show();
+        }
+    });
+}
 
-    $(".job_header a").each(function(i, obj) {
-        var selector = $(obj).attr("id");
-        if (localStorage.getItem(selector) === "true") {
-          toggle($(this).parents(".job_header"), false);
+function parseQuery() {
+    var params = {};
+    $.each(window.location.search.replace(/^\?/, "").split("&"), function(i, p) {
+        if (p === "") {
+            return;
         }
+        var kv = p.split("=");
+        params[decodeURIComponent(kv[0])] = decodeURIComponent(kv.slice(1).join("=").replace(/\+/g, " "));
     });
+    return params;
+}
+
+function init() {
+    var params = parseQuery();
+    if (params.scrapePool) {
+        $("#targets_pool").val(params.scrapePool);
+    }
+    if (params.state) {
+        $("#targets_state button").removeClass("active");
+        $('#targets_state button[data-state="' + params.state + '"]').addClass("active");
+    }
+    if (params.search) {
+        $("#targets_search").val(params.search);
+    }
+
+    $("#targets_filter").submit(function(e) {
+        e.preventDefault();
+    });
+    $("#targets_pool").change(function() {
+        updateLocation();
+        loadTargets();
+    });
+    $("#targets_state button").click(function() {
+        $("#targets_state button").removeClass("active");
+        $(this).addClass("active");
+        updateLocation();
+        loadTargets();
+    });
+
+    var searchTimeout;
+    $("#targets_search").on("input", function() {
+        clearTimeout(searchTimeout);
+        searchTimeout = setTimeout(function() {
+            targetsState.page = 0;
+            updateLocation();
+            renderTargets();
+        }, 200);
+    });
+
+    $("#targets_prev").click(function(e) {
+        e.preventDefault();
+        if (targetsState.page > 0) {
+            targetsState.page--;
+            renderTargets();
+        }
+    });
+    $("#targets_next").click(function(e) {
+        e.preventDefault();
+        if ((targetsState.page + 1) * TARGETS_PAGE_SIZE < targetsState.filtered.length) {
+            targetsState.page++;
+            renderTargets();
+        }
+    });
+
+    loadTargets();
 }
 
-$(init);
\ No newline at end of file
+$(init);
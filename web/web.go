@@ -15,7 +15,9 @@ package web
 
 import (
 	"bytes"
+	"crypto/tls"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -26,6 +28,8 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"regexp"
+	"runtime"
 	"sort"
 	"strings"
 	"sync"
@@ -50,12 +54,17 @@ import (
 	"github.com/prometheus/prometheus/retrieval"
 	"github.com/prometheus/prometheus/rules"
 	"github.com/prometheus/prometheus/storage/local"
+	"github.com/prometheus/prometheus/storage/remote"
 	"github.com/prometheus/prometheus/template"
 	"github.com/prometheus/prometheus/util/httputil"
+	"github.com/prometheus/prometheus/util/logging"
 	api_v1 "github.com/prometheus/prometheus/web/api/v1"
 	"github.com/prometheus/prometheus/web/ui"
 )
 
+// logger logs the messages of the web handlers.
+var logger = logging.New("web")
+
 var localhostRepresentations = []string{"127.0.0.1", "localhost"}
 
 // Handler serves various HTTP endpoints of the Prometheus server
@@ -80,6 +89,9 @@ type Handler struct {
 	cwd         string
 	flagsMap    map[string]string
 
+	lastReload        *ReloadResult
+	lastReloadSuccess *ReloadResult
+
 	mtx sync.RWMutex
 	now func() model.Time
 
@@ -96,16 +108,41 @@ func (h *Handler) ApplyConfig(conf *config.Config) error {
 	return nil
 }
 
-// PrometheusVersion contains build information about Prometheus.
-type PrometheusVersion struct {
-	Version   string `json:"version"`
-	Revision  string `json:"revision"`
-	Branch    string `json:"branch"`
-	BuildUser string `json:"buildUser"`
-	BuildDate string `json:"buildDate"`
-	GoVersion string `json:"goVersion"`
+// RecordReload records the result of a configuration reload attempt, which
+// is returned by reload requests and reported by the API.
+func (h *Handler) RecordReload(res *ReloadResult) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	h.lastReload = res
+	if res.Success {
+		h.lastReloadSuccess = res
+	}
 }
 
+func (h *Handler) reloadResults() (last, lastSuccess *ReloadResult) {
+	h.mtx.RLock()
+	defer h.mtx.RUnlock()
+
+	return h.lastReload, h.lastReloadSuccess
+}
+
+// PrometheusVersion contains build information about Prometheus.
+type PrometheusVersion = api_v1.PrometheusVersion
+
+// ReloadResult describes the outcome of a configuration reload.
+type ReloadResult = api_v1.ReloadResult
+
+// ReloadedComponent is a component affected by a configuration reload.
+type ReloadedComponent = api_v1.ReloadedComponent
+
+// Actions taken on a component during a configuration reload.
+const (
+	ReloadCreated   = api_v1.ReloadCreated
+	ReloadRestarted = api_v1.ReloadRestarted
+	ReloadStopped   = api_v1.ReloadStopped
+)
+
 // Options for the web Handler.
 type Options struct {
 	Context       context.Context
@@ -127,7 +164,22 @@ type Options struct {
 	UserAssetsPath       string
 	ConsoleTemplatesPath string
 	ConsoleLibrariesPath string
-	EnableQuit           bool
+	EnableLifecycle      bool
+	EnableAdminAPI       bool
+	ForwardOnly          bool
+	WebConfigFile        string
+	QueryLimits          api_v1.QueryLimits
+	QueryCache           api_v1.QueryCacheOptions
+	StoragePath          string
+	StorageRetention     time.Duration
+	// The effective GOGC setting, reported as runtime information.
+	GOGC string
+	// Origins allowed to make cross-site requests to the API.
+	CORSOrigin   *regexp.Regexp
+	LogRequests  bool
+	RemoteWriter *remote.Writer
+	// The log level flag, adjustable through the admin API.
+	LogLevel flag.Value
 }
 
 // New initializes a new web Handler.
@@ -162,16 +214,51 @@ func New(o *Options) *Handler {
 		ready: 0,
 	}
 
+	// Avoid passing a typed nil, which the API could not tell from a
+	// configured writer.
+	var rwr interface {
+		QueueStatus() []remote.QueueStatus
+	}
+	if o.RemoteWriter != nil {
+		rwr = o.RemoteWriter
+	}
+	var rge interface {
+		EvalGroup(string) ([]rules.RuleResult, error)
+	}
+	if o.RuleManager != nil {
+		rge = o.RuleManager
+	}
+	// The UI settings and short links are kept next to the local storage, if
+	// there is one.
+	settingsFile, linksFile := "", ""
+	if o.StoragePath != "" {
+		settingsFile = filepath.Join(o.StoragePath, "ui_settings.json")
+		linksFile = filepath.Join(o.StoragePath, "ui_links.jsonl")
+	}
 	h.apiV1 = api_v1.NewAPI(
 		o.QueryEngine,
 		o.Storage,
 		o.TargetManager,
 		o.Notifier,
+		rwr,
+		rge,
 		func() config.Config {
 			h.mtx.RLock()
 			defer h.mtx.RUnlock()
 			return *h.config
 		},
+		o.ForwardOnly,
+		o.EnableAdminAPI,
+		o.QueryLimits,
+		o.QueryCache,
+		o.Flags,
+		o.Version,
+		h.runtimeInfo,
+		h.reloadResults,
+		o.CORSOrigin,
+		settingsFile,
+		linksFile,
+		o.LogLevel,
 	)
 
 	if o.RoutePrefix != "/" {
@@ -185,31 +272,42 @@ func New(o *Options) *Handler {
 	instrh := prometheus.InstrumentHandler
 	instrf := prometheus.InstrumentHandlerFunc
 	readyf := h.testReady
+	forwardOnlyf := h.testNotForwardOnly
 
 	router.Get("/", func(w http.ResponseWriter, r *http.Request) {
+		if o.ForwardOnly {
+			http.Redirect(w, r, path.Join(o.ExternalURL.Path, "/targets"), http.StatusFound)
+			return
+		}
 		http.Redirect(w, r, path.Join(o.ExternalURL.Path, "/graph"), http.StatusFound)
 	})
 
-	router.Get("/alerts", readyf(instrf("alerts", h.alerts)))
-	router.Get("/graph", readyf(instrf("graph", h.graph)))
+	router.Get("/alerts", readyf(forwardOnlyf(instrf("alerts", h.alerts))))
+	router.Get("/graph", readyf(forwardOnlyf(instrf("graph", h.graph))))
+	router.Get("/g/:id", readyf(forwardOnlyf(instrf("short_link", h.shortLink))))
 	router.Get("/status", readyf(instrf("status", h.status)))
 	router.Get("/flags", readyf(instrf("flags", h.flags)))
 	router.Get("/config", readyf(instrf("config", h.serveConfig)))
-	router.Get("/rules", readyf(instrf("rules", h.rules)))
+	router.Get("/rules", readyf(forwardOnlyf(instrf("rules", h.rules))))
 	router.Get("/targets", readyf(instrf("targets", h.targets)))
+	router.Get("/storage", readyf(forwardOnlyf(instrf("storage", h.storageStats))))
+	router.Get("/remote-storage", readyf(instrf("remote_storage", h.remoteStorage)))
 	router.Get("/version", readyf(instrf("version", h.version)))
 
 	router.Get("/heap", readyf(instrf("heap", dumpHeap)))
 
 	router.Get(o.MetricsPath, readyf(prometheus.Handler().ServeHTTP))
 
-	router.Get("/federate", readyf(instrh("federate", httputil.CompressionHandler{
+	router.Get("/federate", readyf(forwardOnlyf(instrh("federate", httputil.CompressionHandler{
 		Handler: http.HandlerFunc(h.federation),
-	})))
+	}))))
+	router.Get("/federate/chunks", readyf(forwardOnlyf(instrh("federate_chunks", httputil.CompressionHandler{
+		Handler: http.HandlerFunc(h.federateChunks),
+	}))))
 
 	h.apiV1.Register(router.WithPrefix("/api/v1"))
 
-	router.Get("/consoles/*filepath", readyf(instrf("consoles", h.consoles)))
+	router.Get("/consoles/*filepath", readyf(forwardOnlyf(instrf("consoles", h.consoles))))
 
 	router.Get("/static/*filepath", readyf(instrf("static", serveStaticAsset)))
 
@@ -217,11 +315,8 @@ func New(o *Options) *Handler {
 		router.Get("/user/*filepath", readyf(instrf("user", route.FileServe(o.UserAssetsPath))))
 	}
 
-	if o.EnableQuit {
-		router.Post("/-/quit", readyf(h.quit))
-	}
-
-	router.Post("/-/reload", readyf(h.reload))
+	router.Post("/-/quit", readyf(h.testLifecycle(h.quit)))
+	router.Post("/-/reload", readyf(h.testLifecycle(h.reload)))
 	router.Get("/-/reload", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusMethodNotAllowed)
 		fmt.Fprintf(w, "This endpoint requires a POST request.\n")
@@ -278,14 +373,14 @@ func serveStaticAsset(w http.ResponseWriter, req *http.Request) {
 
 	info, err := ui.AssetInfo(fp)
 	if err != nil {
-		log.With("file", fp).Warn("Could not get file info: ", err)
+		logger.With("file", fp).Warn("Could not get file info: ", err)
 		w.WriteHeader(http.StatusNotFound)
 		return
 	}
 	file, err := ui.Asset(fp)
 	if err != nil {
 		if err != io.EOF {
-			log.With("file", fp).Warn("Could not get file: ", err)
+			logger.With("file", fp).Warn("Could not get file: ", err)
 		}
 		w.WriteHeader(http.StatusNotFound)
 		return
@@ -294,7 +389,8 @@ func serveStaticAsset(w http.ResponseWriter, req *http.Request) {
 	http.ServeContent(w, req, info.Name(), info.ModTime(), bytes.NewReader(file))
 }
 
-// Ready sets Handler to be ready.
+// Ready sets Handler to be ready. Until then, all endpoints but /-/healthy
+// respond with 503.
 func (h *Handler) Ready() {
 	atomic.StoreUint32(&h.ready, 1)
 }
@@ -308,6 +404,18 @@ func (h *Handler) isReady() bool {
 	return true
 }
 
+// Checks if the lifecycle endpoints are enabled, calls f if they are, returns
+// 403 if they are not.
+func (h *Handler) testLifecycle(f http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !h.options.EnableLifecycle {
+			http.Error(w, "Lifecycle API is not enabled.", http.StatusForbidden)
+			return
+		}
+		f(w, r)
+	}
+}
+
 // Checks if server is ready, calls f if it is, returns 503 if it is not.
 func (h *Handler) testReady(f http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -320,6 +428,19 @@ func (h *Handler) testReady(f http.HandlerFunc) http.HandlerFunc {
 	}
 }
 
+// Checks if server runs in forward-only mode, returns 503 if it does and calls f
+// otherwise.
+func (h *Handler) testNotForwardOnly(f http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if h.options.ForwardOnly {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprintf(w, "Unavailable with Prometheus running in forward-only mode")
+			return
+		}
+		f(w, r)
+	}
+}
+
 // ListenError returns the receive-only channel that signals errors while starting the web server.
 func (h *Handler) ListenError() <-chan error {
 	return h.listenErrCh
@@ -337,13 +458,37 @@ func (h *Handler) Reload() <-chan chan error {
 
 // Run serves the HTTP endpoints.
 func (h *Handler) Run() {
-	log.Infof("Listening on %s", h.options.ListenAddress)
+	logger.Infof("Listening on %s", h.options.ListenAddress)
 	operationName := nethttp.OperationNameFunc(func(r *http.Request) string {
 		return fmt.Sprintf("%s %s", r.Method, r.URL.Path)
 	})
+	var handler http.Handler = h.router
+	var tlsConfig *tls.Config
+	if h.options.WebConfigFile != "" {
+		webConfig, err := config.LoadWebConfigFile(h.options.WebConfigFile)
+		if err != nil {
+			h.listenErrCh <- fmt.Errorf("couldn't load web configuration (-web.config.file=%s): %v", h.options.WebConfigFile, err)
+			return
+		}
+		if len(webConfig.BasicAuthUsers) > 0 {
+			handler = newBasicAuthHandler(webConfig.BasicAuthUsers, handler)
+		}
+		if webConfig.TLSServerConfig != nil {
+			tlsConfig, err = newTLSServerConfig(webConfig.TLSServerConfig)
+			if err != nil {
+				h.listenErrCh <- err
+				return
+			}
+		}
+	}
+
+	if h.options.LogRequests {
+		handler = requestLogHandler{logger: logger, handler: handler}
+	}
+
 	server := &http.Server{
 		Addr:        h.options.ListenAddress,
-		Handler:     nethttp.Middleware(opentracing.GlobalTracer(), h.router, operationName),
+		Handler:     nethttp.Middleware(opentracing.GlobalTracer(), handler, operationName),
 		ErrorLog:    log.NewErrorLogger(),
 		ReadTimeout: h.options.ReadTimeout,
 	}
@@ -352,6 +497,9 @@ func (h *Handler) Run() {
 		h.listenErrCh <- err
 	} else {
 		limitedListener := netutil.LimitListener(listener, h.options.MaxConnections)
+		if tlsConfig != nil {
+			limitedListener = tls.NewListener(limitedListener, tlsConfig)
+		}
 		h.listenErrCh <- server.Serve(limitedListener)
 	}
 }
@@ -426,6 +574,17 @@ func (h *Handler) graph(w http.ResponseWriter, r *http.Request) {
 	h.executeTemplate(w, "graph.html", nil)
 }
 
+// shortLink redirects to the graph page a short link created through the API
+// points to.
+func (h *Handler) shortLink(w http.ResponseWriter, r *http.Request) {
+	params, ok := h.apiV1.LinkParams(route.Param(r.Context(), "id"))
+	if !ok {
+		http.Error(w, "link not found", http.StatusNotFound)
+		return
+	}
+	http.Redirect(w, r, path.Join(h.options.ExternalURL.Path, "/graph")+"?"+params, http.StatusFound)
+}
+
 func (h *Handler) status(w http.ResponseWriter, r *http.Request) {
 	h.executeTemplate(w, "status.html", struct {
 		Birth         time.Time
@@ -440,6 +599,28 @@ func (h *Handler) status(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func (h *Handler) runtimeInfo() (api_v1.RuntimeInfo, error) {
+	info := api_v1.RuntimeInfo{
+		StartTime:        h.birth,
+		CWD:              h.cwd,
+		GoroutineCount:   runtime.NumGoroutine(),
+		GOMAXPROCS:       runtime.GOMAXPROCS(0),
+		GOGC:             h.options.GOGC,
+		GOMEMLIMIT:       memoryLimit(),
+		StoragePath:      h.options.StoragePath,
+		StorageRetention: h.options.StorageRetention.String(),
+	}
+	if h.options.StoragePath == "" {
+		return info, nil
+	}
+	size, err := local.CheckpointSize(h.options.StoragePath)
+	if err != nil {
+		return info, fmt.Errorf("error reading checkpoint size: %s", err)
+	}
+	info.CheckpointSize = size
+	return info, nil
+}
+
 func (h *Handler) flags(w http.ResponseWriter, r *http.Request) {
 	h.executeTemplate(w, "flags.html", h.flagsMap)
 }
@@ -456,26 +637,28 @@ func (h *Handler) rules(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handler) targets(w http.ResponseWriter, r *http.Request) {
-	// Bucket targets by job label
-	tps := map[string][]*retrieval.Target{}
-	for _, t := range h.targetManager.Targets() {
-		job := string(t.Labels()[model.JobLabel])
-		tps[job] = append(tps[job], t)
-	}
-
-	for _, targets := range tps {
-		sort.Slice(targets, func(i, j int) bool {
-			return targets[i].Labels()[model.InstanceLabel] < targets[j].Labels()[model.InstanceLabel]
-		})
-	}
-
+	// Only the per scrape pool summary is rendered here. The targets of
+	// the selected pool and state are loaded from the API at once, and
+	// paginated in the browser.
 	h.executeTemplate(w, "targets.html", struct {
 		TargetPools map[string][]*retrieval.Target
 	}{
-		TargetPools: tps,
+		TargetPools: h.targetManager.TargetsActive(),
 	})
 }
 
+func (h *Handler) storageStats(w http.ResponseWriter, r *http.Request) {
+	h.executeTemplate(w, "storage.html", nil)
+}
+
+func (h *Handler) remoteStorage(w http.ResponseWriter, r *http.Request) {
+	var queues []remote.QueueStatus
+	if h.options.RemoteWriter != nil {
+		queues = h.options.RemoteWriter.QueueStatus()
+	}
+	h.executeTemplate(w, "remote.html", queues)
+}
+
 func (h *Handler) version(w http.ResponseWriter, r *http.Request) {
 	dec := json.NewEncoder(w)
 	if err := dec.Encode(h.versionInfo); err != nil {
@@ -488,11 +671,28 @@ func (h *Handler) quit(w http.ResponseWriter, r *http.Request) {
 	close(h.quitCh)
 }
 
+// reload triggers a configuration reload and responds with its result.
 func (h *Handler) reload(w http.ResponseWriter, r *http.Request) {
 	rc := make(chan error)
 	h.reloadCh <- rc
-	if err := <-rc; err != nil {
-		http.Error(w, fmt.Sprintf("failed to reload config: %s", err), http.StatusInternalServerError)
+	err := <-rc
+
+	// The reload result has been recorded before the reload channel was
+	// answered.
+	res, _ := h.reloadResults()
+	if res == nil {
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to reload config: %s", err), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		logger.Errorf("error writing reload result: %s", err)
 	}
 }
 
@@ -628,7 +828,7 @@ func dumpHeap(w http.ResponseWriter, r *http.Request) {
 	target := fmt.Sprintf("/tmp/%d.heap", time.Now().Unix())
 	f, err := os.Create(target)
 	if err != nil {
-		log.Error("Could not dump heap: ", err)
+		logger.Error("Could not dump heap: ", err)
 	}
 	fmt.Fprintf(w, "Writing to %s...", target)
 	defer f.Close()
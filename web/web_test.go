@@ -14,11 +14,18 @@
 package web
 
 import (
+	"bytes"
+	"crypto/tls"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/prometheus/common/log"
+
+	"github.com/prometheus/prometheus/config"
 )
 
 func TestGlobalURL(t *testing.T) {
@@ -181,3 +188,154 @@ func TestDebugHandler(t *testing.T) {
 		}
 	}
 }
+
+func TestLifecycleHandler(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		opts := &Options{
+			RoutePrefix:     "/",
+			MetricsPath:     "/metrics",
+			EnableLifecycle: enabled,
+		}
+		handler := New(opts)
+		handler.Ready()
+
+		go func() {
+			rc := <-handler.Reload()
+			rc <- nil
+		}()
+
+		expected := http.StatusForbidden
+		if enabled {
+			expected = http.StatusOK
+		}
+		for _, path := range []string{"/-/reload", "/-/quit"} {
+			w := httptest.NewRecorder()
+			req, err := http.NewRequest("POST", path, nil)
+			if err != nil {
+				t.Fatalf("Unexpected error %s", err)
+			}
+
+			handler.router.ServeHTTP(w, req)
+			if w.Code != expected {
+				t.Fatalf("Path %s with lifecycle enabled=%t, expected status %d got %d: %s", path, enabled, expected, w.Code, w.Body.String())
+			}
+		}
+
+		select {
+		case <-handler.Quit():
+			if !enabled {
+				t.Fatalf("Quit channel closed with lifecycle API disabled")
+			}
+		default:
+			if enabled {
+				t.Fatalf("Quit channel not closed with lifecycle API enabled")
+			}
+		}
+	}
+}
+
+func TestBasicAuthHandler(t *testing.T) {
+	handler := newBasicAuthHandler(
+		map[string]config.Secret{
+			// bcrypt hash of "secret".
+			"alice": "$2a$10$xpneLGMXkm3eJF/BoxrN1uuUKfTMfAO.8f2/TIXX3FYOB.uYezXr6",
+		},
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}),
+	)
+	s := httptest.NewServer(handler)
+	defer s.Close()
+
+	tests := []struct {
+		user, password string
+		auth           bool
+		code           int
+	}{
+		{code: http.StatusUnauthorized},
+		{user: "alice", password: "secret", auth: true, code: http.StatusOK},
+		// The cached check of the password is used.
+		{user: "alice", password: "secret", auth: true, code: http.StatusOK},
+		{user: "alice", password: "wrong", auth: true, code: http.StatusUnauthorized},
+		{user: "bob", password: "secret", auth: true, code: http.StatusUnauthorized},
+	}
+
+	for i, test := range tests {
+		req, err := http.NewRequest("GET", s.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if test.auth {
+			req.SetBasicAuth(test.user, test.password)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Unexpected HTTP error %s", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != test.code {
+			t.Errorf("%d. expected status %d, got %d", i, test.code, resp.StatusCode)
+		}
+		if resp.StatusCode == http.StatusUnauthorized && resp.Header.Get("WWW-Authenticate") == "" {
+			t.Errorf("%d. expected WWW-Authenticate header", i)
+		}
+	}
+	if n := len(handler.cache); n != 1 {
+		t.Errorf("expected the successful password check to be cached once, got %d entries", n)
+	}
+}
+
+func TestNewTLSServerConfig(t *testing.T) {
+	tlsConfig, err := newTLSServerConfig(&config.TLSServerConfig{
+		CertFile:       "../util/httputil/testdata/server.crt",
+		KeyFile:        "../util/httputil/testdata/server.key",
+		ClientAuthType: config.RequireAndVerifyClientCert,
+		ClientCAFile:   "../util/httputil/testdata/tls-ca-chain.pem",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tlsConfig.Certificates) != 1 {
+		t.Errorf("Expected 1 server certificate, got %d", len(tlsConfig.Certificates))
+	}
+	if tlsConfig.ClientAuth != tls.RequireAndVerifyClientCert {
+		t.Errorf("Expected client auth type %v, got %v", tls.RequireAndVerifyClientCert, tlsConfig.ClientAuth)
+	}
+	if tlsConfig.ClientCAs == nil {
+		t.Errorf("Expected client CAs to be set")
+	}
+
+	if _, err := newTLSServerConfig(&config.TLSServerConfig{
+		CertFile: "../util/httputil/testdata/server.crt",
+		KeyFile:  "../util/httputil/testdata/missing.key",
+	}); err == nil {
+		t.Errorf("Expected error for missing key file")
+	}
+}
+
+func TestRequestLogHandler(t *testing.T) {
+	var buf bytes.Buffer
+	handler := requestLogHandler{
+		logger: log.NewLogger(&buf),
+		handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		}),
+	}
+
+	req, err := http.NewRequest("POST", "/api/v1/query", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.RemoteAddr = "192.0.2.1:1234"
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusTeapot {
+		t.Fatalf("Expected status %d, got %d", http.StatusTeapot, w.Code)
+	}
+	for _, s := range []string{"method=POST", `path="/api/v1/query"`, "status=418", `remote_addr="192.0.2.1:1234"`, "duration="} {
+		if !strings.Contains(buf.String(), s) {
+			t.Errorf("Expected %q in request log, got %q", s, buf.String())
+		}
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2017 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/prometheus/prometheus/config"
+)
+
+var clientAuthTypes = map[string]tls.ClientAuthType{
+	config.NoClientCert:               tls.NoClientCert,
+	config.RequestClientCert:          tls.RequestClientCert,
+	config.RequireAnyClientCert:       tls.RequireAnyClientCert,
+	config.VerifyClientCertIfGiven:    tls.VerifyClientCertIfGiven,
+	config.RequireAndVerifyClientCert: tls.RequireAndVerifyClientCert,
+}
+
+// newTLSServerConfig creates the TLS configuration of the HTTP server.
+func newTLSServerConfig(cfg *config.TLSServerConfig) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to use specified server cert (%s) & key (%s): %s", cfg.CertFile, cfg.KeyFile, err)
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   clientAuthTypes[cfg.ClientAuthType],
+	}
+
+	if len(cfg.ClientCAFile) > 0 {
+		caCert, err := ioutil.ReadFile(cfg.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to use specified client CA cert %s: %s", cfg.ClientCAFile, err)
+		}
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("no certificates found in client CA cert %s", cfg.ClientCAFile)
+		}
+		tlsConfig.ClientCAs = clientCAs
+	}
+
+	return tlsConfig, nil
+}
+
+// dummyPasswordHash is compared with the passwords of unknown users.
+const dummyPasswordHash = "$2a$10$KbteI30bbuuvJTl7oKUxau.5nTq09Xt.I6ZN28VYdTEmcX/eYnWLy"
+
+// maxAuthCacheEntries limits the number of cached successful password checks.
+const maxAuthCacheEntries = 100
+
+// basicAuthHandler requires all requests to authenticate as one of the given
+// users. The users map user names to bcrypt hashes of their passwords.
+//
+// Successful password checks are cached, as bcrypt is deliberately slow.
+// The cache is keyed by a hash of the user, the password hash and the
+// password, so that entries never match once any of them changes. Checks that
+// are not cached run one at a time, so that failing requests cannot use up
+// more than one CPU.
+type basicAuthHandler struct {
+	users   map[string]config.Secret
+	handler http.Handler
+
+	bcryptMtx sync.Mutex
+
+	cacheMtx sync.Mutex
+	cache    map[[sha256.Size]byte]struct{}
+}
+
+func newBasicAuthHandler(users map[string]config.Secret, handler http.Handler) *basicAuthHandler {
+	return &basicAuthHandler{
+		users:   users,
+		handler: handler,
+		cache:   map[[sha256.Size]byte]struct{}{},
+	}
+}
+
+func (h *basicAuthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, pass, ok := r.BasicAuth()
+	if ok && h.authenticate(user, pass) {
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+
+	w.Header().Set("WWW-Authenticate", `Basic realm="Prometheus"`)
+	http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+}
+
+// authenticate returns whether the password is the one of the user.
+func (h *basicAuthHandler) authenticate(user, pass string) bool {
+	hash, exists := h.users[user]
+	if !exists {
+		// Compare with a hash anyway, so that unknown users cannot be
+		// told apart by the response time.
+		hash = dummyPasswordHash
+	}
+	key := sha256.Sum256([]byte(user + "\x00" + string(hash) + "\x00" + pass))
+
+	h.cacheMtx.Lock()
+	_, cached := h.cache[key]
+	h.cacheMtx.Unlock()
+	if cached {
+		return true
+	}
+
+	h.bcryptMtx.Lock()
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(pass))
+	h.bcryptMtx.Unlock()
+	if err != nil || !exists {
+		return false
+	}
+
+	h.cacheMtx.Lock()
+	defer h.cacheMtx.Unlock()
+	if len(h.cache) >= maxAuthCacheEntries {
+		// Evict an arbitrary entry.
+		for k := range h.cache {
+			delete(h.cache, k)
+			break
+		}
+	}
+	h.cache[key] = struct{}{}
+	return true
+}